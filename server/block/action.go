@@ -1,6 +1,9 @@
 package block
 
-import "time"
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"time"
+)
 
 // OpenAction is a world.BlockAction to open a block at a position. It is sent for blocks such as chests.
 type OpenAction struct{ action }
@@ -34,6 +37,17 @@ type DecoratedPotWobbleAction struct {
 	Success bool
 }
 
+// BellRingAction is a world.BlockAction to make a bell swing and ring when it is used.
+type BellRingAction struct {
+	action
+	// Direction is the direction in which the bell swings.
+	Direction cube.Direction
+}
+
+// EndGatewayBeamAction is a world.BlockAction to make an end gateway show its beam, which it does after teleporting
+// an entity.
+type EndGatewayBeamAction struct{ action }
+
 // action implements the Action interface. Structures in this package may embed it to gets its functionality
 // out of the box.
 type action struct{}
@@ -0,0 +1,91 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// ActivatorRail is a rail that activates minecarts riding over it while it is
+// powered by redstone. Activated minecarts eject their passenger, and hopper
+// minecarts stop collecting items.
+type ActivatorRail struct {
+	empty
+	transparent
+
+	// Shape is the shape of the rail. Activator rails cannot be curved.
+	Shape RailShape
+	// Powered is true if the rail receives a redstone signal, either directly
+	// or through a line of up to 8 activator rails.
+	Powered bool
+}
+
+// UseOnBlock ...
+func (r ActivatorRail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	r.Powered = false
+	return placeRail(r, pos, face, tx, user, ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r ActivatorRail) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !checkRailSupport(r, pos, tx) {
+		return
+	}
+	if powered := railSignal(pos, r, tx); powered != r.Powered {
+		r.Powered = powered
+		tx.SetBlock(pos, r, nil)
+	}
+}
+
+// SideClosed ...
+func (ActivatorRail) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// HasLiquidDrops ...
+func (ActivatorRail) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (r ActivatorRail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(ActivatorRail{}))
+}
+
+// EncodeItem ...
+func (ActivatorRail) EncodeItem() (name string, meta int16) {
+	return "minecraft:activator_rail", 0
+}
+
+// EncodeBlock ...
+func (r ActivatorRail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:activator_rail", map[string]any{"rail_direction": int32(r.Shape.Uint8()), "rail_data_bit": boolByte(r.Powered)}
+}
+
+// railShape ...
+func (r ActivatorRail) railShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r ActivatorRail) withRailShape(s RailShape) railBlock {
+	r.Shape = s
+	return r
+}
+
+// curvable ...
+func (ActivatorRail) curvable() bool {
+	return false
+}
+
+// allActivatorRails ...
+func allActivatorRails() (b []world.Block) {
+	for _, s := range RailShapes() {
+		if s.Curved() {
+			continue
+		}
+		b = append(b, ActivatorRail{Shape: s}, ActivatorRail{Shape: s, Powered: true})
+	}
+	return
+}
@@ -34,6 +34,27 @@ func (Banner) MaxCount() int {
 	return 16
 }
 
+// Helmet checks if the Banner may be worn on the head. Only illager banners
+// may be worn, as is done by the captains of illager patrols.
+func (b Banner) Helmet() bool {
+	return b.Illager
+}
+
+// DefencePoints ...
+func (Banner) DefencePoints() float64 {
+	return 0
+}
+
+// Toughness ...
+func (Banner) Toughness() float64 {
+	return 0
+}
+
+// KnockBackResistance ...
+func (Banner) KnockBackResistance() float64 {
+	return 0
+}
+
 // BreakInfo ...
 func (b Banner) BreakInfo() BreakInfo {
 	return newBreakInfo(1, alwaysHarvestable, axeEffective, oneOf(b))
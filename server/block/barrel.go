@@ -188,3 +188,8 @@ func allBarrels() (b []world.Block) {
 	}
 	return
 }
+
+// VillagePoint ...
+func (Barrel) VillagePoint() bool {
+	return true
+}
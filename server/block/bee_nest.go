@@ -0,0 +1,95 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// BeeNest is a naturally generated block that bees live in. It behaves the
+// same as a Beehive, but only drops when broken using silk touch.
+type BeeNest struct {
+	solid
+	bass
+
+	// Facing is the direction that the entrance of the bee nest faces.
+	Facing cube.Direction
+	// HoneyLevel is the amount of honey in the bee nest, ranging from 0 to 5.
+	// Honey may be harvested using glass bottles or shears once the
+	// HoneyLevel is 5.
+	HoneyLevel int
+	// Occupants are the bees currently inside the bee nest. A bee nest holds
+	// at most 3 bees.
+	Occupants []BeehiveOccupant
+}
+
+// FlammabilityInfo ...
+func (BeeNest) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(30, 20, true)
+}
+
+// BreakInfo ...
+func (n BeeNest) BreakInfo() BreakInfo {
+	return newBreakInfo(0.3, alwaysHarvestable, axeEffective, silkTouchOnlyDrop(BeeNest{Occupants: n.Occupants})).withBreakHandler(func(pos cube.Pos, tx *world.Tx, u item.User) {
+		breakHive(n, pos, tx, u)
+	})
+}
+
+// Activate ...
+func (n BeeNest) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, ctx *item.UseContext) bool {
+	return harvestHive(n, pos, tx, u, ctx)
+}
+
+// UseOnBlock ...
+func (n BeeNest) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(tx, pos, face, n)
+	if !used {
+		return
+	}
+	n.Facing = user.Rotation().Direction().Opposite()
+	place(tx, pos, n, user, ctx)
+	return placed(ctx)
+}
+
+// AddOccupant adds a bee to the bee nest at the position passed. If nectar is
+// true, the honey level of the bee nest is increased. False is returned if
+// the bee nest was already full.
+func (n BeeNest) AddOccupant(pos cube.Pos, tx *world.Tx, o BeehiveOccupant, nectar bool) bool {
+	return addHiveOccupant(n, pos, tx, o, nectar)
+}
+
+// Tick ...
+func (n BeeNest) Tick(currentTick int64, pos cube.Pos, tx *world.Tx) {
+	tickHive(n, currentTick, pos, tx)
+}
+
+// EncodeItem ...
+func (BeeNest) EncodeItem() (name string, meta int16) {
+	return "minecraft:bee_nest", 0
+}
+
+// EncodeBlock ...
+func (n BeeNest) EncodeBlock() (string, map[string]any) {
+	return "minecraft:bee_nest", map[string]any{"direction": int32(horizontalDirection(n.Facing)), "honey_level": int32(n.HoneyLevel)}
+}
+
+// EncodeNBT ...
+func (n BeeNest) EncodeNBT() map[string]any {
+	return encodeHiveNBT(n.Occupants)
+}
+
+// DecodeNBT ...
+func (n BeeNest) DecodeNBT(data map[string]any) any {
+	n.Occupants = decodeHiveNBT(data)
+	return n
+}
+
+func (n BeeNest) state() (cube.Direction, int, []BeehiveOccupant) {
+	return n.Facing, n.HoneyLevel, n.Occupants
+}
+
+func (n BeeNest) withState(honey int, occupants []BeehiveOccupant) hive {
+	n.HoneyLevel, n.Occupants = honey, occupants
+	return n
+}
@@ -0,0 +1,315 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// Beehive is a block crafted from planks and honeycomb that bees live in. Bees
+// that return to the beehive with nectar fill it with honey, which may be
+// harvested once the beehive is full.
+type Beehive struct {
+	solid
+	bass
+
+	// Facing is the direction that the entrance of the beehive faces.
+	Facing cube.Direction
+	// HoneyLevel is the amount of honey in the beehive, ranging from 0 to 5.
+	// Honey may be harvested using glass bottles or shears once the
+	// HoneyLevel is 5.
+	HoneyLevel int
+	// Occupants are the bees currently inside the beehive. A beehive holds at
+	// most 3 bees.
+	Occupants []BeehiveOccupant
+}
+
+// BeehiveOccupant is a bee that is currently inside a Beehive or BeeNest.
+type BeehiveOccupant struct {
+	// Data holds the NBT data of the bee. It is used to restore the bee once
+	// it leaves the hive. If nil, a new bee is created when it leaves.
+	Data map[string]any
+	// TicksLeft is the amount of ticks left until the bee may leave the hive.
+	TicksLeft int
+}
+
+const (
+	// maxHiveOccupants is the maximum amount of bees that may live in a
+	// Beehive or BeeNest at the same time.
+	maxHiveOccupants = 3
+	// maxHoneyLevel is the honey level at which honey may be harvested from a
+	// Beehive or BeeNest.
+	maxHoneyLevel = 5
+)
+
+// hive is implemented by Beehive and BeeNest, so that their shared behaviour
+// only needs to be implemented once.
+type hive interface {
+	world.Block
+	// state returns the facing direction, honey level and occupants of the
+	// hive.
+	state() (cube.Direction, int, []BeehiveOccupant)
+	// withState returns the hive with the honey level and occupants passed.
+	withState(honey int, occupants []BeehiveOccupant) hive
+}
+
+// FlammabilityInfo ...
+func (Beehive) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(5, 20, true)
+}
+
+// FuelInfo ...
+func (Beehive) FuelInfo() item.FuelInfo {
+	return newFuelInfo(time.Second * 15)
+}
+
+// BreakInfo ...
+func (h Beehive) BreakInfo() BreakInfo {
+	return newBreakInfo(0.6, alwaysHarvestable, axeEffective, func(_ item.Tool, enchantments []item.Enchantment) []item.Stack {
+		if hasSilkTouch(enchantments) {
+			return []item.Stack{item.NewStack(Beehive{Occupants: h.Occupants}, 1)}
+		}
+		return []item.Stack{item.NewStack(Beehive{}, 1)}
+	}).withBreakHandler(func(pos cube.Pos, tx *world.Tx, u item.User) {
+		breakHive(h, pos, tx, u)
+	})
+}
+
+// Activate ...
+func (h Beehive) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, ctx *item.UseContext) bool {
+	return harvestHive(h, pos, tx, u, ctx)
+}
+
+// UseOnBlock ...
+func (h Beehive) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(tx, pos, face, h)
+	if !used {
+		return
+	}
+	h.Facing = user.Rotation().Direction().Opposite()
+	place(tx, pos, h, user, ctx)
+	return placed(ctx)
+}
+
+// AddOccupant adds a bee to the beehive at the position passed. If nectar is
+// true, the honey level of the beehive is increased. False is returned if the
+// beehive was already full.
+func (h Beehive) AddOccupant(pos cube.Pos, tx *world.Tx, o BeehiveOccupant, nectar bool) bool {
+	return addHiveOccupant(h, pos, tx, o, nectar)
+}
+
+// Tick ...
+func (h Beehive) Tick(currentTick int64, pos cube.Pos, tx *world.Tx) {
+	tickHive(h, currentTick, pos, tx)
+}
+
+// EncodeItem ...
+func (Beehive) EncodeItem() (name string, meta int16) {
+	return "minecraft:beehive", 0
+}
+
+// EncodeBlock ...
+func (h Beehive) EncodeBlock() (string, map[string]any) {
+	return "minecraft:beehive", map[string]any{"direction": int32(horizontalDirection(h.Facing)), "honey_level": int32(h.HoneyLevel)}
+}
+
+// EncodeNBT ...
+func (h Beehive) EncodeNBT() map[string]any {
+	return encodeHiveNBT(h.Occupants)
+}
+
+// DecodeNBT ...
+func (h Beehive) DecodeNBT(data map[string]any) any {
+	h.Occupants = decodeHiveNBT(data)
+	return h
+}
+
+func (h Beehive) state() (cube.Direction, int, []BeehiveOccupant) {
+	return h.Facing, h.HoneyLevel, h.Occupants
+}
+
+func (h Beehive) withState(honey int, occupants []BeehiveOccupant) hive {
+	h.HoneyLevel, h.Occupants = honey, occupants
+	return h
+}
+
+// harvestHive harvests the honey in a full hive if the user is holding a glass
+// bottle or shears. If no lit campfire is found below the hive, the bees
+// inside of it leave the hive to attack the user.
+func harvestHive(h hive, pos cube.Pos, tx *world.Tx, u item.User, ctx *item.UseContext) bool {
+	if _, honey, _ := h.state(); honey < maxHoneyLevel {
+		return false
+	}
+	held, _ := u.HeldItems()
+	switch held.Item().(type) {
+	case item.GlassBottle:
+		ctx.SubtractFromCount(1)
+		ctx.NewItem = item.NewStack(item.HoneyBottle{}, 1)
+		tx.PlaySound(pos.Vec3Centre(), sound.BottleFill{})
+	case item.Shears:
+		ctx.DamageItem(1)
+		dropItem(tx, item.NewStack(item.Honeycomb{}, 3), pos.Side(cube.FaceUp).Vec3Middle())
+		tx.PlaySound(pos.Vec3Centre(), sound.BeehiveShear{})
+	default:
+		return false
+	}
+	_, _, occupants := h.state()
+	tx.SetBlock(pos, h.withState(0, occupants), nil)
+	if !smokedHive(pos, tx) {
+		releaseHiveOccupants(h.withState(0, occupants), pos, tx, u)
+	}
+	return true
+}
+
+// breakHive releases the bees in a hive that was broken by the user passed,
+// unless the user broke it using silk touch, in which case the bees are kept
+// inside the hive item dropped. The released bees attack the user unless a lit
+// campfire is found below the hive.
+func breakHive(h hive, pos cube.Pos, tx *world.Tx, u item.User) {
+	if held, _ := u.HeldItems(); hasSilkTouch(held.Enchantments()) {
+		return
+	}
+	var target world.Entity = u
+	if smokedHive(pos, tx) {
+		target = nil
+	}
+	releaseHiveOccupants(h, pos, tx, target)
+}
+
+// smokedHive checks if a lit campfire is present up to 5 blocks below the hive
+// at the position passed. The smoke of a campfire pacifies bees, so that they
+// do not attack players harvesting their hive.
+func smokedHive(pos cube.Pos, tx *world.Tx) bool {
+	for i := 1; i <= 5; i++ {
+		if c, ok := tx.Block(pos.Sub(cube.Pos{0, i})).(Campfire); ok && !c.Extinguished {
+			return true
+		}
+	}
+	return false
+}
+
+// addHiveOccupant adds a bee to a hive if the hive is not yet full.
+func addHiveOccupant(h hive, pos cube.Pos, tx *world.Tx, o BeehiveOccupant, nectar bool) bool {
+	_, honey, occupants := h.state()
+	if len(occupants) >= maxHiveOccupants {
+		return false
+	}
+	if nectar && honey < maxHoneyLevel {
+		honey++
+	}
+	tx.SetBlock(pos, h.withState(honey, append(occupants[:len(occupants):len(occupants)], o)), nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.BeehiveEnter{})
+	return true
+}
+
+// tickHive counts down the ticks that the bees in a hive have left to stay in
+// it and releases them once they may leave. Bees only leave their hive during
+// the day and while it is not raining.
+func tickHive(h hive, currentTick int64, pos cube.Pos, tx *world.Tx) {
+	_, honey, occupants := h.state()
+	if len(occupants) == 0 || currentTick%20 != 0 {
+		return
+	}
+	occupants = append([]BeehiveOccupant(nil), occupants...)
+	stay := occupants[:0]
+	t := tx.World().Time() % 24000
+	night := t > 12000 && t < 23500
+	for _, o := range occupants {
+		if o.TicksLeft -= 20; o.TicksLeft > 0 || night || tx.RainingAt(pos) || !releaseHiveOccupant(h, pos, tx, o, nil) {
+			stay = append(stay, o)
+		}
+	}
+	tx.SetBlock(pos, h.withState(honey, stay), nil)
+}
+
+// releaseHiveOccupants makes all bees in a hive leave it immediately. If
+// target is not nil, the bees attack the target once they have left the hive.
+func releaseHiveOccupants(h hive, pos cube.Pos, tx *world.Tx, target world.Entity) {
+	_, honey, occupants := h.state()
+	if len(occupants) == 0 {
+		return
+	}
+	var stay []BeehiveOccupant
+	for _, o := range occupants {
+		if !releaseHiveOccupant(h, pos, tx, o, target) {
+			stay = append(stay, o)
+		}
+	}
+	if _, ok := tx.Block(pos).(hive); ok {
+		tx.SetBlock(pos, h.withState(honey, stay), nil)
+	}
+}
+
+// releaseHiveOccupant spawns the bee passed in front of the hive at the
+// position passed. False is returned if the entrance of the hive is blocked.
+// If the hive is no longer present, for example because it was broken, the bee
+// is spawned at the position of the hive.
+func releaseHiveOccupant(h hive, pos cube.Pos, tx *world.Tx, o BeehiveOccupant, target world.Entity) bool {
+	spawnPos := pos.Vec3Centre()
+	if _, ok := tx.Block(pos).(hive); ok {
+		facing, _, _ := h.state()
+		front := pos.Side(facing.Face())
+		if len(tx.Block(front).Model().BBox(front, tx)) > 0 {
+			return false
+		}
+		spawnPos = front.Vec3Middle()
+	}
+	opts := world.EntitySpawnOpts{Position: spawnPos, Rotation: cube.Rotation{rand.Float64() * 360}}
+	tx.AddEntity(tx.World().EntityRegistry().Config().Bee(opts, o.Data, pos, target))
+	tx.PlaySound(spawnPos, sound.BeehiveExit{})
+	return true
+}
+
+// encodeHiveNBT encodes the occupants of a hive into a map.
+func encodeHiveNBT(occupants []BeehiveOccupant) map[string]any {
+	list := make([]any, 0, len(occupants))
+	for _, o := range occupants {
+		data := o.Data
+		if data == nil {
+			data = map[string]any{}
+		}
+		list = append(list, map[string]any{
+			"ActorIdentifier": "minecraft:bee<>",
+			"SaveData":        data,
+			"TicksLeftToStay": int32(o.TicksLeft),
+		})
+	}
+	return map[string]any{"id": "Beehive", "Occupants": list, "ShouldSpawnBees": uint8(0)}
+}
+
+// decodeHiveNBT decodes the occupants of a hive from the map passed.
+func decodeHiveNBT(data map[string]any) []BeehiveOccupant {
+	list, _ := data["Occupants"].([]any)
+	occupants := make([]BeehiveOccupant, 0, len(list))
+	for _, v := range list {
+		m, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		o := BeehiveOccupant{TicksLeft: int(nbtconv.Int32(m, "TicksLeftToStay"))}
+		if d, ok := m["SaveData"].(map[string]any); ok && len(d) > 0 {
+			o.Data = d
+		}
+		occupants = append(occupants, o)
+	}
+	if len(occupants) == 0 {
+		return nil
+	}
+	return occupants
+}
+
+// allBeehives ...
+func allBeehives() (b []world.Block) {
+	for _, d := range cube.Directions() {
+		for honey := 0; honey <= maxHoneyLevel; honey++ {
+			b = append(b, Beehive{Facing: d, HoneyLevel: honey})
+			b = append(b, BeeNest{Facing: d, HoneyLevel: honey})
+		}
+	}
+	return
+}
@@ -0,0 +1,140 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Bell is a block that rings when it is used. Bells are found at the meeting
+// point of villages and are one of the blocks that make up a village.
+type Bell struct {
+	transparent
+
+	// Attach is the way the Bell is attached to the blocks around it.
+	Attach BellAttachment
+	// Facing is the direction the Bell is facing. For bells attached to a
+	// wall, it is the direction away from the wall.
+	Facing cube.Direction
+}
+
+// BreakInfo ...
+func (b Bell) BreakInfo() BreakInfo {
+	return newBreakInfo(5, pickaxeHarvestable, pickaxeEffective, oneOf(b)).withBlastResistance(25)
+}
+
+// Activate ...
+func (b Bell) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
+	b.Ring(pos, u.Rotation().Direction(), tx)
+	return true
+}
+
+// Ring makes the Bell at the position passed ring, swinging it in the
+// direction passed.
+func (b Bell) Ring(pos cube.Pos, dir cube.Direction, tx *world.Tx) {
+	tx.PlaySound(pos.Vec3Centre(), sound.BellRing{})
+	for _, v := range tx.Viewers(pos.Vec3Centre()) {
+		v.ViewBlockAction(pos, BellRingAction{Direction: dir})
+	}
+}
+
+// UseOnBlock ...
+func (b Bell) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) (used bool) {
+	pos, face, used = firstReplaceable(tx, pos, face, b)
+	if !used {
+		return false
+	}
+	b.Facing = user.Rotation().Direction().Opposite()
+	switch face {
+	case cube.FaceUp:
+		b.Attach = StandingBellAttachment()
+	case cube.FaceDown:
+		b.Attach = HangingBellAttachment()
+	default:
+		b.Attach, b.Facing = WallBellAttachment(), face.Direction()
+		if b.supported(pos, face, tx) {
+			b.Attach = DoubleWallBellAttachment()
+		}
+	}
+	place(tx, pos, b, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (b Bell) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	switch b.Attach {
+	case StandingBellAttachment():
+		if b.supported(pos, cube.FaceDown, tx) {
+			return
+		}
+	case HangingBellAttachment():
+		if b.supported(pos, cube.FaceUp, tx) {
+			return
+		}
+	case WallBellAttachment():
+		if b.supported(pos, b.Facing.Face().Opposite(), tx) {
+			return
+		}
+	case DoubleWallBellAttachment():
+		// A bell attached to two walls only breaks once both of its walls
+		// are gone.
+		if b.supported(pos, b.Facing.Face(), tx) || b.supported(pos, b.Facing.Face().Opposite(), tx) {
+			return
+		}
+	}
+	breakBlock(b, pos, tx)
+}
+
+// supported checks if the block on the face passed of the Bell is able to
+// support it.
+func (b Bell) supported(pos cube.Pos, face cube.Face, tx *world.Tx) bool {
+	side := pos.Side(face)
+	return tx.Block(side).Model().FaceSolid(side, face.Opposite(), tx)
+}
+
+// VillagePoint ...
+func (Bell) VillagePoint() bool {
+	return true
+}
+
+// Model ...
+func (Bell) Model() world.BlockModel {
+	return model.Bell{}
+}
+
+// EncodeNBT ...
+func (Bell) EncodeNBT() map[string]any {
+	return map[string]any{"id": "Bell"}
+}
+
+// DecodeNBT ...
+func (b Bell) DecodeNBT(map[string]any) any {
+	return b
+}
+
+// EncodeItem ...
+func (Bell) EncodeItem() (name string, meta int16) {
+	return "minecraft:bell", 0
+}
+
+// EncodeBlock ...
+func (b Bell) EncodeBlock() (string, map[string]any) {
+	return "minecraft:bell", map[string]any{
+		"attachment": b.Attach.String(),
+		"direction":  int32(horizontalDirection(b.Facing)),
+		"toggle_bit": false,
+	}
+}
+
+// allBells ...
+func allBells() (bells []world.Block) {
+	for _, a := range BellAttachments() {
+		for _, d := range cube.Directions() {
+			bells = append(bells, Bell{Attach: a, Facing: d})
+		}
+	}
+	return
+}
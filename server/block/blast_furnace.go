@@ -132,3 +132,8 @@ func allBlastFurnaces() (furnaces []world.Block) {
 	}
 	return
 }
+
+// VillagePoint ...
+func (BlastFurnace) VillagePoint() bool {
+	return true
+}
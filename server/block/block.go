@@ -73,10 +73,19 @@ type EntityInsider interface {
 	EntityInside(pos cube.Pos, tx *world.Tx, e world.Entity)
 }
 
+// EntityStepper represents a block that reacts to an entity standing on it.
+type EntityStepper interface {
+	// EntityStepOn is called every tick while an entity is standing on the
+	// block.
+	EntityStepOn(pos cube.Pos, tx *world.Tx, e world.Entity)
+}
+
 // ProjectileHitter represents a block that handles being hit by a projectile.
 type ProjectileHitter interface {
-	// ProjectileHit is called when a projectile hits the block.
-	ProjectileHit(pos cube.Pos, tx *world.Tx, e world.Entity, face cube.Face)
+	// ProjectileHit is called when a projectile hits the block. The face is
+	// the face of the block that was hit, and hitPos the exact position at
+	// which the projectile hit it.
+	ProjectileHit(pos cube.Pos, tx *world.Tx, e world.Entity, face cube.Face, hitPos mgl64.Vec3)
 }
 
 // Frictional represents a block that may have a custom friction value. Friction is used for entity drag when the
@@ -146,3 +146,8 @@ func allBrewingStands() (stands []world.Block) {
 	}
 	return
 }
+
+// VillagePoint ...
+func (BrewingStand) VillagePoint() bool {
+	return true
+}
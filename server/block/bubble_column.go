@@ -0,0 +1,123 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// BubbleColumn is a column of bubbles in water, formed above soul sand and
+// magma blocks. Bubble columns above soul sand push entities up, while those
+// above magma blocks drag entities down.
+type BubbleColumn struct {
+	empty
+	transparent
+
+	// DragDown specifies if the bubble column drags entities down. This is
+	// the case for bubble columns formed above magma blocks.
+	DragDown bool
+}
+
+// EntityVelocity returns the velocity of an entity with velocity vel inside
+// the bubble column after the bubble column pushed it. surface specifies if
+// the entity is in the top block of the bubble column, where entities are
+// pushed with a greater force.
+func (b BubbleColumn) EntityVelocity(vel mgl64.Vec3, surface bool) mgl64.Vec3 {
+	switch {
+	case b.DragDown && surface:
+		vel[1] = math.Max(-0.9, vel[1]-0.03)
+	case b.DragDown:
+		vel[1] = math.Max(-0.3, vel[1]-0.03)
+	case surface:
+		vel[1] = math.Min(1.8, vel[1]+0.1)
+	default:
+		vel[1] = math.Min(0.7, vel[1]+0.06)
+	}
+	return vel
+}
+
+// NeighbourUpdateTick removes the bubble column if the block below it no
+// longer forms a bubble column or if it no longer contains a water source,
+// and extends the column upwards otherwise.
+func (b BubbleColumn) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	dragDown, ok := bubbleColumnSource(pos.Side(cube.FaceDown), tx)
+	if l, water := tx.Liquid(pos); !ok || !water || !waterSource(l) {
+		tx.SetBlock(pos, nil, nil)
+		return
+	}
+	if dragDown != b.DragDown {
+		tx.SetBlock(pos, BubbleColumn{DragDown: dragDown}, nil)
+		return
+	}
+	formBubbleColumn(pos.Side(cube.FaceUp), tx)
+}
+
+// CanDisplace ...
+func (BubbleColumn) CanDisplace(b world.Liquid) bool {
+	_, ok := b.(Water)
+	return ok
+}
+
+// SideClosed ...
+func (BubbleColumn) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// ReplaceableBy ...
+func (BubbleColumn) ReplaceableBy(world.Block) bool {
+	return true
+}
+
+// HasLiquidDrops ...
+func (BubbleColumn) HasLiquidDrops() bool {
+	return false
+}
+
+// EncodeBlock ...
+func (b BubbleColumn) EncodeBlock() (string, map[string]any) {
+	return "minecraft:bubble_column", map[string]any{"drag_down": b.DragDown}
+}
+
+// allBubbleColumns returns all possible bubble column states.
+func allBubbleColumns() []world.Block {
+	return []world.Block{BubbleColumn{}, BubbleColumn{DragDown: true}}
+}
+
+// formBubbleColumn turns the water source block at the position passed into
+// a bubble column if the block below it forms one. True is returned if a
+// bubble column was formed.
+func formBubbleColumn(pos cube.Pos, tx *world.Tx) bool {
+	w, ok := tx.Block(pos).(Water)
+	if !ok || !waterSource(w) {
+		return false
+	}
+	dragDown, ok := bubbleColumnSource(pos.Side(cube.FaceDown), tx)
+	if !ok {
+		return false
+	}
+	tx.SetBlock(pos, BubbleColumn{DragDown: dragDown}, nil)
+	return true
+}
+
+// bubbleColumnSource checks if the block at the position passed forms a
+// bubble column above it, and if so, whether that bubble column drags
+// entities down.
+func bubbleColumnSource(pos cube.Pos, tx *world.Tx) (dragDown bool, ok bool) {
+	switch b := tx.Block(pos).(type) {
+	case SoulSand:
+		return false, true
+	case Magma:
+		return true, true
+	case BubbleColumn:
+		return b.DragDown, true
+	}
+	return false, false
+}
+
+// waterSource checks if the liquid passed is a water source block that is
+// not falling.
+func waterSource(l world.Liquid) bool {
+	w, ok := l.(Water)
+	return ok && w.Depth == 8 && !w.Falling
+}
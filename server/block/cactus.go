@@ -75,6 +75,11 @@ func (c Cactus) EntityInside(_ cube.Pos, _ *world.Tx, e world.Entity) {
 	}
 }
 
+// Pottable ...
+func (Cactus) Pottable() bool {
+	return true
+}
+
 // BreakInfo ...
 func (c Cactus) BreakInfo() BreakInfo {
 	return newBreakInfo(0.4, alwaysHarvestable, nothingEffective, oneOf(c))
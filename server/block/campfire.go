@@ -30,6 +30,9 @@ type Campfire struct {
 	Type FireType
 }
 
+// campfireCookTime is the time that it takes for a campfire to cook an item.
+const campfireCookTime = time.Second * 30
+
 // CampfireItem holds data about the items in the campfire.
 type CampfireItem struct {
 	// Item is a specific item being cooked on top of the campfire.
@@ -108,7 +111,7 @@ func (c Campfire) extinguish(pos cube.Pos, tx *world.Tx) {
 	c.Extinguished = true
 
 	for i := range c.Items {
-		c.Items[i].Time = time.Second * 30
+		c.Items[i].Time = campfireCookTime
 	}
 
 	tx.SetBlock(pos, c, nil)
@@ -140,7 +143,7 @@ func (c Campfire) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User,
 		if it.Item.Empty() {
 			c.Items[i] = CampfireItem{
 				Item: held.Grow(-held.Count() + 1),
-				Time: time.Second * 30,
+				Time: campfireCookTime,
 			}
 
 			ctx.SubtractFromCount(1)
@@ -244,7 +247,7 @@ func (c Campfire) EncodeNBT() map[string]any {
 		id := strconv.Itoa(i + 1)
 		if !v.Item.Empty() {
 			m["Item"+id] = nbtconv.WriteItem(v.Item, true)
-			m["ItemTime"+id] = uint8(v.Time.Milliseconds() / 50)
+			m["ItemTime"+id] = int32(v.Time.Milliseconds() / 50)
 		}
 	}
 	return m
@@ -256,7 +259,7 @@ func (c Campfire) DecodeNBT(data map[string]any) any {
 		id := strconv.Itoa(i + 1)
 		c.Items[i] = CampfireItem{
 			Item: nbtconv.MapItem(data, "Item"+id),
-			Time: time.Duration(nbtconv.Int16(data, "ItemTime"+id)) * time.Millisecond * 50,
+			Time: nbtconv.AnyTickDuration(data, "ItemTime"+id),
 		}
 	}
 	return c
@@ -0,0 +1,102 @@
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestCampfireDecodesItemTime(t *testing.T) {
+	for _, v := range []any{uint8(30), int32(30)} {
+		c := Campfire{}.DecodeNBT(map[string]any{"ItemTime1": v}).(Campfire)
+		if c.Items[0].Time != time.Second*30/20 {
+			t.Fatalf("expected ItemTime of type %T to decode to 30 ticks, got %v", v, c.Items[0].Time)
+		}
+	}
+}
+
+// droppedItem is a minimal world.Entity of an item dropped by a block.
+type droppedItem struct {
+	handle *world.EntityHandle
+	data   *world.EntityData
+}
+
+func (d droppedItem) Close() error            { return nil }
+func (d droppedItem) H() *world.EntityHandle  { return d.handle }
+func (d droppedItem) Position() mgl64.Vec3    { return d.data.Pos }
+func (d droppedItem) Rotation() cube.Rotation { return d.data.Rot }
+func (d droppedItem) Item() item.Stack        { return d.data.Data.(item.Stack) }
+
+// droppedItemType is the world.EntityType of droppedItem.
+type droppedItemType struct{}
+
+func (droppedItemType) Open(_ *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return droppedItem{handle: handle, data: data}
+}
+func (droppedItemType) EncodeEntity() string                        { return "minecraft:item" }
+func (droppedItemType) BBox(world.Entity) cube.BBox                 { return cube.BBox{} }
+func (droppedItemType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (droppedItemType) EncodeNBT(*world.EntityData) map[string]any  { return nil }
+
+// droppedItemConfig is the world.EntityConfig of droppedItem.
+type droppedItemConfig struct{ it item.Stack }
+
+func (conf droppedItemConfig) Apply(data *world.EntityData) { data.Data = conf.it }
+
+// withDroppedItems runs f in a world in which items dropped by blocks are
+// added as droppedItem entities.
+func withDroppedItems(f func(tx *world.Tx)) {
+	reg := world.EntityRegistryConfig{
+		Item: func(opts world.EntitySpawnOpts, it any) *world.EntityHandle {
+			return opts.New(droppedItemType{}, droppedItemConfig{it: it.(item.Stack)})
+		},
+	}.New([]world.EntityType{droppedItemType{}})
+	w := world.Config{Entities: reg}.New()
+	defer w.Close()
+	<-w.Exec(f)
+}
+
+// droppedItems returns the items dropped in the World.
+func droppedItems(tx *world.Tx) []item.Stack {
+	var items []item.Stack
+	for e := range tx.Entities() {
+		if d, ok := e.(droppedItem); ok {
+			items = append(items, d.Item())
+		}
+	}
+	return items
+}
+
+func TestCampfireCooksItem(t *testing.T) {
+	withDroppedItems(func(tx *world.Tx) {
+		pos := cube.Pos{0, 64, 0}
+		c := Campfire{}
+		c.Items[0] = CampfireItem{Item: item.NewStack(item.Beef{}, 1), Time: campfireCookTime}
+		tx.SetBlock(pos, c, nil)
+
+		ticks := int(campfireCookTime / (time.Second / 20))
+		for i := range ticks {
+			tx.Block(pos).(Campfire).Tick(int64(i), pos, tx)
+		}
+		if items := droppedItems(tx); len(items) != 0 {
+			t.Errorf("expected no items to pop out before the cook time passed, got %v", items)
+			return
+		}
+		if it := tx.Block(pos).(Campfire).Items[0]; it.Item.Empty() || it.Time != 0 {
+			t.Errorf("expected beef to finish cooking, got %v with %v left", it.Item, it.Time)
+			return
+		}
+
+		tx.Block(pos).(Campfire).Tick(int64(ticks), pos, tx)
+		if items := droppedItems(tx); len(items) != 1 || !items[0].Equal(item.NewStack(item.Beef{Cooked: true}, 1)) {
+			t.Errorf("expected cooked beef to pop out of the campfire, got %v", items)
+		}
+		if it := tx.Block(pos).(Campfire).Items[0]; !it.Item.Empty() {
+			t.Errorf("expected cooked item to be removed from the campfire, got %v", it.Item)
+		}
+	})
+}
@@ -30,9 +30,15 @@ func (c Carrot) ConsumeDuration() time.Duration {
 	return item.DefaultConsumeDuration
 }
 
+// FoodInfo ...
+func (Carrot) FoodInfo() item.FoodInfo {
+	return item.FoodInfo{Food: 3, Saturation: 3.6}
+}
+
 // Consume ...
 func (c Carrot) Consume(_ *world.Tx, co item.Consumer) item.Stack {
-	co.Saturate(3, 3.6)
+	info := c.FoodInfo()
+	co.Saturate(info.Food, info.Saturation)
 	return item.Stack{}
 }
 
@@ -0,0 +1,60 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+// CartographyTable is a block used to copy, zoom out and lock maps. It is also used as a cartographer's job site
+// block that is found in villages.
+type CartographyTable struct {
+	solid
+	bass
+}
+
+// FuelInfo ...
+func (CartographyTable) FuelInfo() item.FuelInfo {
+	return newFuelInfo(time.Second * 15)
+}
+
+// BreakInfo ...
+func (c CartographyTable) BreakInfo() BreakInfo {
+	return newBreakInfo(2.5, alwaysHarvestable, axeEffective, oneOf(c))
+}
+
+// Activate ...
+func (CartographyTable) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos, tx)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (c CartographyTable) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(tx, pos, face, c)
+	if !used {
+		return
+	}
+	place(tx, pos, c, user, ctx)
+	return placed(ctx)
+}
+
+// EncodeItem ...
+func (CartographyTable) EncodeItem() (name string, meta int16) {
+	return "minecraft:cartography_table", 0
+}
+
+// EncodeBlock ...
+func (CartographyTable) EncodeBlock() (string, map[string]any) {
+	return "minecraft:cartography_table", nil
+}
+
+// VillagePoint ...
+func (CartographyTable) VillagePoint() bool {
+	return true
+}
@@ -0,0 +1,60 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// ChorusFlower is the block found at the end of the branches of chorus
+// plants, from which the chorus plant grows.
+type ChorusFlower struct {
+	solid
+	transparent
+
+	// Age is the age of the chorus flower, which can be 0-5. A chorus flower
+	// with an age of 5 is dead and won't grow any further.
+	Age int
+}
+
+// UseOnBlock ...
+func (c ChorusFlower) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, c)
+	if !used || !chorusPlantSupported(pos, tx) {
+		return false
+	}
+
+	place(tx, pos, c, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (c ChorusFlower) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !chorusPlantSupported(pos, tx) {
+		breakBlock(c, pos, tx)
+	}
+}
+
+// BreakInfo ...
+func (c ChorusFlower) BreakInfo() BreakInfo {
+	return newBreakInfo(0.4, alwaysHarvestable, axeEffective, oneOf(ChorusFlower{}))
+}
+
+// EncodeItem ...
+func (ChorusFlower) EncodeItem() (name string, meta int16) {
+	return "minecraft:chorus_flower", 0
+}
+
+// EncodeBlock ...
+func (c ChorusFlower) EncodeBlock() (string, map[string]any) {
+	return "minecraft:chorus_flower", map[string]any{"age": int32(c.Age)}
+}
+
+// allChorusFlowers returns all possible states of a chorus flower.
+func allChorusFlowers() (b []world.Block) {
+	for i := 0; i < 6; i++ {
+		b = append(b, ChorusFlower{Age: i})
+	}
+	return
+}
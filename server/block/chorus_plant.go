@@ -0,0 +1,87 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+)
+
+// ChorusPlant is a plant that grows on end stone on the outer islands of the
+// End. It drops chorus fruit when broken.
+type ChorusPlant struct {
+	transparent
+}
+
+// Model ...
+func (ChorusPlant) Model() world.BlockModel {
+	return model.ChorusPlant{}
+}
+
+// UseOnBlock ...
+func (c ChorusPlant) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, c)
+	if !used || !chorusPlantSupported(pos, tx) {
+		return false
+	}
+
+	place(tx, pos, c, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (c ChorusPlant) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !chorusPlantSupported(pos, tx) {
+		breakBlock(c, pos, tx)
+	}
+}
+
+// SideClosed ...
+func (ChorusPlant) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// BreakInfo ...
+func (c ChorusPlant) BreakInfo() BreakInfo {
+	return newBreakInfo(0.4, alwaysHarvestable, axeEffective, func(item.Tool, []item.Enchantment) []item.Stack {
+		if rand.IntN(2) == 0 {
+			return []item.Stack{item.NewStack(item.ChorusFruit{}, 1)}
+		}
+		return nil
+	})
+}
+
+// EncodeItem ...
+func (ChorusPlant) EncodeItem() (name string, meta int16) {
+	return "minecraft:chorus_plant", 0
+}
+
+// EncodeBlock ...
+func (ChorusPlant) EncodeBlock() (string, map[string]any) {
+	return "minecraft:chorus_plant", nil
+}
+
+// chorusPlantSupported checks if a chorus plant or flower at the position
+// passed is supported: Either by end stone or a chorus plant below it, or by
+// a chorus plant next to it that is supported from below.
+func chorusPlantSupported(pos cube.Pos, tx *world.Tx) bool {
+	supports := func(b world.Block) bool {
+		switch b.(type) {
+		case ChorusPlant, EndStone:
+			return true
+		}
+		return false
+	}
+	if supports(tx.Block(pos.Side(cube.FaceDown))) {
+		return true
+	}
+	for _, f := range cube.HorizontalFaces() {
+		side := pos.Side(f)
+		if _, ok := tx.Block(side).(ChorusPlant); ok && supports(tx.Block(side.Side(cube.FaceDown))) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Cobweb is a block that slows down entities moving through it. Cobwebs are
+// found in mineshafts and strongholds and are broken quickly using swords and
+// shears.
+type Cobweb struct {
+	transparent
+	empty
+}
+
+// EntityVelocity returns the velocity of an entity with velocity vel inside
+// the cobweb after the cobweb slowed it down. Entities are slowed down
+// considerably more vertically than horizontally.
+func (Cobweb) EntityVelocity(vel mgl64.Vec3) mgl64.Vec3 {
+	return mgl64.Vec3{vel[0] * 0.25, vel[1] * 0.05, vel[2] * 0.25}
+}
+
+// EntityInside resets the fall distance of entities inside the cobweb, as
+// entities caught in a cobweb do not take fall damage.
+func (Cobweb) EntityInside(_ cube.Pos, _ *world.Tx, e world.Entity) {
+	if fallEntity, ok := e.(fallDistanceEntity); ok {
+		fallEntity.ResetFallDistance()
+	}
+}
+
+// LightDiffusionLevel ...
+func (Cobweb) LightDiffusionLevel() uint8 {
+	return 1
+}
+
+// SideClosed ...
+func (Cobweb) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// BreakInfo ...
+func (c Cobweb) BreakInfo() BreakInfo {
+	return newBreakInfo(4, cobwebEffective, cobwebEffective, func(t item.Tool, enchantments []item.Enchantment) []item.Stack {
+		if t.ToolType() == item.TypeShears || hasSilkTouch(enchantments) {
+			return []item.Stack{item.NewStack(c, 1)}
+		}
+		return []item.Stack{item.NewStack(item.String{}, 1)}
+	})
+}
+
+// cobwebEffective is a function for cobwebs, which are effectively mined and
+// only drop items when mined with a sword or shears.
+var cobwebEffective = func(t item.Tool) bool {
+	return t.ToolType() == item.TypeSword || t.ToolType() == item.TypeShears
+}
+
+// EncodeItem ...
+func (Cobweb) EncodeItem() (name string, meta int16) {
+	return "minecraft:web", 0
+}
+
+// EncodeBlock ...
+func (Cobweb) EncodeBlock() (string, map[string]any) {
+	return "minecraft:web", nil
+}
@@ -155,3 +155,8 @@ func allComposters() (all []world.Block) {
 	}
 	return
 }
+
+// VillagePoint ...
+func (Composter) VillagePoint() bool {
+	return true
+}
@@ -0,0 +1,118 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"time"
+)
+
+// DaylightDetector is a block that emits a redstone signal depending on the
+// strength of the sunlight shining on it. The signal is strongest at noon and
+// is weakened by rain and thunderstorms. An inverted daylight detector emits
+// the opposite signal, so that it is strongest at night.
+type DaylightDetector struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Inverted specifies if the daylight detector is inverted. Interacting
+	// with a daylight detector toggles this.
+	Inverted bool
+	// Power is the strength of the redstone signal emitted by the daylight
+	// detector, ranging from 0 to 15.
+	Power int
+}
+
+// Model ...
+func (DaylightDetector) Model() world.BlockModel {
+	return model.DaylightDetector{}
+}
+
+// Activate toggles the daylight detector between its normal and inverted mode.
+func (d DaylightDetector) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
+	d.Inverted = !d.Inverted
+	d.Power = daylightPower(pos, tx, d.Inverted)
+	tx.SetBlock(pos, d, nil)
+	return true
+}
+
+// Tick updates the redstone signal of the daylight detector once every second.
+func (d DaylightDetector) Tick(currentTick int64, pos cube.Pos, tx *world.Tx) {
+	if currentTick%20 != 0 {
+		return
+	}
+	if power := daylightPower(pos, tx, d.Inverted); power != d.Power {
+		d.Power = power
+		tx.SetBlock(pos, d, nil)
+	}
+}
+
+// daylightPower returns the strength of the redstone signal emitted by a
+// daylight detector at a position. The signal is based on the sky light at the
+// position, darkened by the time of day and the weather, and follows the
+// angle of the sun for non-inverted detectors.
+func daylightPower(pos cube.Pos, tx *world.Tx, inverted bool) int {
+	w := tx.World()
+	light := int(tx.SkyLight(pos)) - w.SkyDarkening()
+	if inverted {
+		return int(mgl64.Clamp(float64(15-light), 0, 15))
+	}
+	if light <= 0 {
+		return 0
+	}
+	// The angle of the sun is moved a little towards noon, so that the signal
+	// stays at its maximum for a while around noon.
+	angle, target := w.SunAngle(), 0.0
+	if angle >= math.Pi {
+		target = math.Pi * 2
+	}
+	angle += (target - angle) * 0.2
+	return int(mgl64.Clamp(math.Round(float64(light)*math.Cos(angle)), 0, 15))
+}
+
+// BreakInfo ...
+func (d DaylightDetector) BreakInfo() BreakInfo {
+	return newBreakInfo(0.2, alwaysHarvestable, axeEffective, oneOf(DaylightDetector{}))
+}
+
+// FuelInfo ...
+func (DaylightDetector) FuelInfo() item.FuelInfo {
+	return newFuelInfo(time.Second * 15)
+}
+
+// DecodeNBT ...
+func (d DaylightDetector) DecodeNBT(map[string]any) any {
+	return d
+}
+
+// EncodeNBT ...
+func (DaylightDetector) EncodeNBT() map[string]any {
+	return map[string]any{"id": "DaylightDetector"}
+}
+
+// EncodeItem ...
+func (DaylightDetector) EncodeItem() (name string, meta int16) {
+	return "minecraft:daylight_detector", 0
+}
+
+// EncodeBlock ...
+func (d DaylightDetector) EncodeBlock() (string, map[string]any) {
+	name := "minecraft:daylight_detector"
+	if d.Inverted {
+		name = "minecraft:daylight_detector_inverted"
+	}
+	return name, map[string]any{"redstone_signal": int32(d.Power)}
+}
+
+// allDaylightDetectors returns all possible states of a daylight detector.
+func allDaylightDetectors() (detectors []world.Block) {
+	for _, inverted := range []bool{false, true} {
+		for power := 0; power <= 15; power++ {
+			detectors = append(detectors, DaylightDetector{Inverted: inverted, Power: power})
+		}
+	}
+	return
+}
@@ -0,0 +1,52 @@
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestDaylightDetectorPower(t *testing.T) {
+	tests := []struct {
+		name          string
+		time          int
+		thundering    bool
+		power, invert int
+	}{
+		{name: "noon", time: 6000, power: 15, invert: 0},
+		{name: "midnight", time: 18000, power: 0, invert: 11},
+		{name: "thunderstorm at noon", time: 6000, thundering: true, power: 10, invert: 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := world.Config{}.New()
+			defer w.Close()
+			w.StopTime()
+			w.StopWeatherCycle()
+			w.SetTime(test.time)
+			if test.thundering {
+				w.StartThundering(time.Hour)
+			} else {
+				w.StopRaining()
+			}
+
+			<-w.Exec(func(tx *world.Tx) {
+				pos := cube.Pos{0, 64, 0}
+				for _, inverted := range []bool{false, true} {
+					tx.SetBlock(pos, DaylightDetector{Inverted: inverted}, nil)
+					tx.Block(pos).(DaylightDetector).Tick(0, pos, tx)
+
+					want := test.power
+					if inverted {
+						want = test.invert
+					}
+					if d := tx.Block(pos).(DaylightDetector); d.Power != want {
+						t.Errorf("expected daylight detector (inverted: %v) to have power %v, got %v", inverted, want, d.Power)
+					}
+				}
+			})
+		})
+	}
+}
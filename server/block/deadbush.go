@@ -49,6 +49,11 @@ func (d DeadBush) FlammabilityInfo() FlammabilityInfo {
 	return newFlammabilityInfo(60, 100, true)
 }
 
+// Pottable ...
+func (DeadBush) Pottable() bool {
+	return true
+}
+
 // BreakInfo ...
 func (d DeadBush) BreakInfo() BreakInfo {
 	return newBreakInfo(0, alwaysHarvestable, nothingEffective, func(t item.Tool, enchantments []item.Enchantment) []item.Stack {
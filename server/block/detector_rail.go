@@ -0,0 +1,123 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// DetectorRail is a rail that emits a redstone signal while a minecart rides
+// over it.
+type DetectorRail struct {
+	empty
+	transparent
+
+	// Shape is the shape of the rail. Detector rails cannot be curved.
+	Shape RailShape
+	// Powered is true while a minecart is on the rail, in which case it emits
+	// a redstone signal with a strength of 15.
+	Powered bool
+}
+
+// RiddenOver makes the detector rail emit a redstone signal. The signal stops
+// once no RailRider has been on the rail for a second.
+func (r DetectorRail) RiddenOver(pos cube.Pos, tx *world.Tx, _ RailRider) {
+	if r.Powered {
+		return
+	}
+	r.Powered = true
+	tx.SetBlock(pos, r, nil)
+	tx.ScheduleBlockUpdate(pos, r, time.Second)
+}
+
+// ScheduledTick stops the redstone signal of the detector rail if no RailRider
+// is on it anymore.
+func (r DetectorRail) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if !r.Powered {
+		return
+	}
+	for e := range tx.EntitiesWithin(cube.Box(0, 0, 0, 1, 1, 1).Translate(pos.Vec3())) {
+		if rider, ok := e.(RailRider); ok {
+			if railPos, riding := rider.RidingRail(); riding && railPos == pos {
+				tx.ScheduleBlockUpdate(pos, r, time.Second)
+				return
+			}
+		}
+	}
+	r.Powered = false
+	tx.SetBlock(pos, r, nil)
+}
+
+// UseOnBlock ...
+func (r DetectorRail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	r.Powered = false
+	return placeRail(r, pos, face, tx, user, ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r DetectorRail) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	checkRailSupport(r, pos, tx)
+}
+
+// redstonePower ...
+func (r DetectorRail) redstonePower(cube.Face) int {
+	if r.Powered {
+		return 15
+	}
+	return 0
+}
+
+// SideClosed ...
+func (DetectorRail) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// HasLiquidDrops ...
+func (DetectorRail) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (r DetectorRail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(DetectorRail{}))
+}
+
+// EncodeItem ...
+func (DetectorRail) EncodeItem() (name string, meta int16) {
+	return "minecraft:detector_rail", 0
+}
+
+// EncodeBlock ...
+func (r DetectorRail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:detector_rail", map[string]any{"rail_direction": int32(r.Shape.Uint8()), "rail_data_bit": boolByte(r.Powered)}
+}
+
+// railShape ...
+func (r DetectorRail) railShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r DetectorRail) withRailShape(s RailShape) railBlock {
+	r.Shape = s
+	return r
+}
+
+// curvable ...
+func (DetectorRail) curvable() bool {
+	return false
+}
+
+// allDetectorRails ...
+func allDetectorRails() (b []world.Block) {
+	for _, s := range RailShapes() {
+		if s.Curved() {
+			continue
+		}
+		b = append(b, DetectorRail{Shape: s}, DetectorRail{Shape: s, Powered: true})
+	}
+	return
+}
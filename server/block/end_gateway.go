@@ -0,0 +1,265 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+)
+
+// EndGateway is a block found in the End that teleports entities entering it.
+// End gateways are opened around the main island of the End when an ender
+// dragon is defeated and lead to the outer islands, where a return gateway
+// leads back. End gateways cannot be broken and do not have an item form.
+type EndGateway struct {
+	empty
+	transparent
+
+	// Exit is the position of the exit of the end gateway. Unless
+	// ExactTeleport is true, entities are teleported to the top of the
+	// blocks close to the exit. If Exit is nil, an exit on the outer islands
+	// of the End is found and a return gateway created there the first time
+	// an entity enters the end gateway.
+	Exit *cube.Pos
+	// ExactTeleport specifies if entities are teleported to the Exit exactly,
+	// rather than to the top of the blocks close to it.
+	ExactTeleport bool
+	// Age is the amount of ticks that passed since the end gateway was
+	// placed. End gateways that are less than 200 ticks old show a beam.
+	Age int
+	// Cooldown is the amount of ticks until the end gateway can teleport
+	// entities again. The end gateway shows a beam while on cooldown.
+	Cooldown int
+}
+
+const (
+	// endGatewaySpawnTicks is the amount of ticks that a new end gateway
+	// shows a beam for.
+	endGatewaySpawnTicks = 200
+	// endGatewayCooldown is the amount of ticks that an end gateway cannot
+	// teleport entities for after teleporting one.
+	endGatewayCooldown = 40
+)
+
+// Place places the end gateway at the position passed, along with the
+// bedrock frame around it.
+func (g EndGateway) Place(pos cube.Pos, tx *world.Tx) {
+	for x := -1; x <= 1; x++ {
+		for y := -2; y <= 2; y++ {
+			for z := -1; z <= 1; z++ {
+				var b world.Block
+				switch edge := y == 2 || y == -2; {
+				case x == 0 && y == 0 && z == 0:
+					b = g
+				case y == 0:
+					// The blocks around the end gateway itself are cleared.
+				case x == 0 && z == 0 && edge:
+					b = Bedrock{}
+				case (x == 0 || z == 0) && !edge:
+					b = Bedrock{}
+				}
+				tx.SetBlock(pos.Add(cube.Pos{x, y, z}), b, nil)
+			}
+		}
+	}
+}
+
+// Tick ...
+func (g EndGateway) Tick(_ int64, pos cube.Pos, tx *world.Tx) {
+	if g.Age >= endGatewaySpawnTicks && g.Cooldown <= 0 {
+		return
+	}
+	g.Age++
+	g.Cooldown = max(g.Cooldown-1, 0)
+	tx.SetBlock(pos, g, nil)
+}
+
+// EntityInside teleports the entity to the exit of the end gateway, unless
+// the end gateway is on cooldown. The chunk that the entity is teleported to
+// is loaded or generated before the entity is teleported.
+func (g EndGateway) EntityInside(pos cube.Pos, tx *world.Tx, e world.Entity) {
+	t, ok := e.(interface{ Teleport(pos mgl64.Vec3) })
+	if !ok || g.Cooldown > 0 {
+		return
+	}
+	if g.Exit == nil {
+		if tx.World().Dimension() != world.End {
+			// Exits on the outer islands only exist in the End.
+			return
+		}
+		exit := g.createReturnGateway(pos, tx)
+		g.Exit = &exit
+	}
+	dest := g.Exit.Vec3Middle()
+	if !g.ExactTeleport {
+		top, _ := tallestBlock(g.Exit.Add(cube.Pos{0, 2, 0}), 5, false, tx)
+		dest = top.Side(cube.FaceUp).Vec3Middle()
+	} else {
+		// Reading the block loads the chunk of the exit, so that the entity
+		// is not teleported into a chunk that does not exist yet.
+		tx.Block(*g.Exit)
+	}
+	g.Cooldown = endGatewayCooldown
+	tx.SetBlock(pos, g, nil)
+	for _, v := range tx.Viewers(pos.Vec3Centre()) {
+		v.ViewBlockAction(pos, EndGatewayBeamAction{})
+	}
+	t.Teleport(dest)
+}
+
+// createReturnGateway finds the exit of an end gateway at the position
+// passed on the outer islands of the End and places a return gateway that
+// leads back to pos there. The position of the return gateway is returned.
+func (g EndGateway) createReturnGateway(pos cube.Pos, tx *world.Tx) cube.Pos {
+	dir := mgl64.Vec3{float64(pos[0]), 0, float64(pos[2])}
+	if dir.Len() == 0 {
+		dir = mgl64.Vec3{1, 0, 0}
+	}
+	dir = dir.Normalize()
+
+	// Starting 1024 blocks away from the centre of the End, we first move
+	// inwards until we reach the void between the main island and the outer
+	// islands, and then outwards again until we reach the first outer island.
+	target := dir.Mul(1024)
+	for i := 0; i < 16 && !endChunkEmpty(target, tx); i++ {
+		target = target.Sub(dir.Mul(16))
+	}
+	for i := 0; i < 16 && endChunkEmpty(target, tx); i++ {
+		target = target.Add(dir.Mul(16))
+	}
+	island, ok := endIslandSpawn(target, tx)
+	if !ok {
+		island = cube.Pos{int(math.Floor(target[0])), 75, int(math.Floor(target[2]))}
+		placeEndIsland(island, tx)
+	}
+	top, _ := tallestBlock(island, 16, true, tx)
+	exit := top.Add(cube.Pos{0, 10, 0})
+	EndGateway{Exit: &pos}.Place(exit, tx)
+	return exit
+}
+
+// endChunkEmpty checks if the chunk at the position passed has no blocks in
+// it at all.
+func endChunkEmpty(pos mgl64.Vec3, tx *world.Tx) bool {
+	baseX, baseZ := int(math.Floor(pos[0]))&^15, int(math.Floor(pos[2]))&^15
+	for x := baseX; x < baseX+16; x++ {
+		for z := baseZ; z < baseZ+16; z++ {
+			if y, _ := tx.HighestBlock(x, z); y > tx.Range().Min() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// endIslandSpawn finds the end stone block in the chunk at the position
+// passed that is closest to the centre of the End and has space for an
+// entity to stand on it. False is returned if no such block exists.
+func endIslandSpawn(pos mgl64.Vec3, tx *world.Tx) (cube.Pos, bool) {
+	var (
+		spawn cube.Pos
+		found bool
+	)
+	baseX, baseZ := int(math.Floor(pos[0]))&^15, int(math.Floor(pos[2]))&^15
+	for x := baseX; x < baseX+16; x++ {
+		for z := baseZ; z < baseZ+16; z++ {
+			y, b := tx.HighestBlock(x, z)
+			if _, ok := b.(EndStone); !ok {
+				continue
+			}
+			candidate := cube.Pos{x, y, z}
+			if !found || candidate.Vec3().LenSqr() < spawn.Vec3().LenSqr() {
+				spawn, found = candidate, true
+			}
+		}
+	}
+	return spawn, found
+}
+
+// tallestBlock finds the highest full block within the radius passed around
+// the x and z of pos. Bedrock is only considered if bedrock is true. If no
+// such block is found, pos and false are returned.
+func tallestBlock(pos cube.Pos, radius int, bedrock bool, tx *world.Tx) (cube.Pos, bool) {
+	var (
+		tallest cube.Pos
+		found   bool
+	)
+	for x := pos[0] - radius; x <= pos[0]+radius; x++ {
+		for z := pos[2] - radius; z <= pos[2]+radius; z++ {
+			for y := tx.HighestLightBlocker(x, z); y > tx.Range().Min() && (!found || y > tallest[1]); y-- {
+				b := tx.Block(cube.Pos{x, y, z})
+				if _, ok := b.(Bedrock); ok && !bedrock {
+					continue
+				}
+				if _, ok := b.Model().(model.Solid); ok {
+					tallest, found = cube.Pos{x, y, z}, true
+					break
+				}
+			}
+		}
+	}
+	if !found {
+		return pos, false
+	}
+	return tallest, true
+}
+
+// placeEndIsland places a small island of end stone with its top at the
+// position passed.
+func placeEndIsland(pos cube.Pos, tx *world.Tx) {
+	r := 4 + float64(rand.IntN(3))
+	for y := 0; r > 0.5; y-- {
+		for x := int(math.Floor(-r)); x <= int(math.Ceil(r)); x++ {
+			for z := int(math.Floor(-r)); z <= int(math.Ceil(r)); z++ {
+				if float64(x*x+z*z) <= (r+1)*(r+1) {
+					tx.SetBlock(pos.Add(cube.Pos{x, y, z}), EndStone{}, nil)
+				}
+			}
+		}
+		r -= float64(rand.IntN(2)) + 0.5
+	}
+}
+
+// LightEmissionLevel ...
+func (EndGateway) LightEmissionLevel() uint8 {
+	return 15
+}
+
+// SideClosed ...
+func (EndGateway) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// DecodeNBT ...
+func (g EndGateway) DecodeNBT(data map[string]any) any {
+	g.Age = int(nbtconv.Int32(data, "Age"))
+	g.ExactTeleport = nbtconv.Bool(data, "ExactTeleport")
+	g.Cooldown = int(nbtconv.Int32(data, "TeleportCooldown"))
+	if _, ok := data["ExitPortal"]; ok {
+		exit := nbtconv.Pos(data, "ExitPortal")
+		g.Exit = &exit
+	}
+	return g
+}
+
+// EncodeNBT ...
+func (g EndGateway) EncodeNBT() map[string]any {
+	m := map[string]any{
+		"id":               "EndGateway",
+		"Age":              int32(g.Age),
+		"ExactTeleport":    boolByte(g.ExactTeleport),
+		"TeleportCooldown": int32(g.Cooldown),
+	}
+	if g.Exit != nil {
+		m["ExitPortal"] = nbtconv.PosToInt32Slice(*g.Exit)
+	}
+	return m
+}
+
+// EncodeBlock ...
+func (EndGateway) EncodeBlock() (string, map[string]any) {
+	return "minecraft:end_gateway", nil
+}
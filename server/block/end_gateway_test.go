@@ -0,0 +1,78 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// teleporter is a world.Entity that records the positions it is teleported
+// to.
+type teleporter struct {
+	world.Entity
+	dest *[]mgl64.Vec3
+}
+
+func (t teleporter) Teleport(pos mgl64.Vec3) { *t.dest = append(*t.dest, pos) }
+
+func TestEndGatewayTeleportsToExit(t *testing.T) {
+	w := world.Config{Dim: world.End}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		var dest []mgl64.Vec3
+		e := teleporter{dest: &dest}
+
+		pos, exit := cube.Pos{0, 70, 0}, cube.Pos{100, 70, 100}
+		EndGateway{Exit: &exit, ExactTeleport: true}.Place(pos, tx)
+		tx.Block(pos).(EndGateway).EntityInside(pos, tx, e)
+		if len(dest) != 1 || dest[0] != exit.Vec3Middle() {
+			t.Errorf("expected entity to be teleported to the exact exit %v, got %v", exit.Vec3Middle(), dest)
+			return
+		}
+		tx.Block(pos).(EndGateway).EntityInside(pos, tx, e)
+		if len(dest) != 1 {
+			t.Errorf("expected end gateway on cooldown not to teleport the entity again, got %v", dest)
+			return
+		}
+
+		// Without ExactTeleport, the entity is teleported on top of the
+		// blocks near the exit, ignoring the bedrock frame of the return
+		// gateway there.
+		pos = cube.Pos{0, 70, 20}
+		EndGateway{Exit: &exit}.Place(exit, tx)
+		tx.SetBlock(exit.Add(cube.Pos{2, -10, 0}), EndStone{}, nil)
+		EndGateway{Exit: &exit}.Place(pos, tx)
+		tx.Block(pos).(EndGateway).EntityInside(pos, tx, e)
+		if want := exit.Add(cube.Pos{2, -9, 0}).Vec3Middle(); len(dest) != 2 || dest[1] != want {
+			t.Errorf("expected entity to be teleported on top of the blocks near the exit %v, got %v", want, dest)
+		}
+	})
+}
+
+func TestEndGatewayCreatesReturnGateway(t *testing.T) {
+	w := world.Config{Dim: world.End}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		var dest []mgl64.Vec3
+		pos := cube.Pos{96, 75, 0}
+		EndGateway{}.Place(pos, tx)
+		tx.Block(pos).(EndGateway).EntityInside(pos, tx, teleporter{dest: &dest})
+
+		exit := tx.Block(pos).(EndGateway).Exit
+		if exit == nil || len(dest) != 1 {
+			t.Errorf("expected entity to be teleported to a new exit, exit: %v, teleported to: %v", exit, dest)
+			return
+		}
+		if exit[0] < 512 {
+			t.Errorf("expected exit to be on the outer islands, got %v", *exit)
+		}
+		ret, ok := tx.Block(*exit).(EndGateway)
+		if !ok || ret.Exit == nil || *ret.Exit != pos {
+			t.Errorf("expected return gateway at %v leading back to %v, got %#v", *exit, pos, tx.Block(*exit))
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// EndPortal is the block that fills the exit portal in the End, which is
+// activated once the ender dragon is defeated. It cannot be broken and does
+// not have an item form.
+type EndPortal struct {
+	empty
+	transparent
+}
+
+// LightEmissionLevel ...
+func (EndPortal) LightEmissionLevel() uint8 {
+	return 15
+}
+
+// SideClosed ...
+func (EndPortal) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// EncodeBlock ...
+func (EndPortal) EncodeBlock() (string, map[string]any) {
+	return "minecraft:end_portal", nil
+}
@@ -30,6 +30,18 @@ type ExplosionConfig struct {
 	// the item drop chance is 1/Size. If negative, no items will be dropped by
 	// the explosion. If set to 1 or higher, all items are dropped.
 	ItemDropChance float64
+	// KeepBlocks prevents the explosion from destroying any blocks. Entities
+	// are still affected by the explosion.
+	KeepBlocks bool
+	// MaxBlastResistance limits the blast resistance of breakable blocks hit
+	// by the explosion, so that it is able to destroy blocks that are usually
+	// resistant to explosions. Unbreakable blocks always stop the explosion.
+	// If 0, the blast resistance of blocks is not limited.
+	MaxBlastResistance float64
+	// Source is the entity that caused the explosion, such as a creeper or
+	// the owner of a fireball. Entities hurt by the explosion are hurt with
+	// Source as the cause of the damage. Source may be nil.
+	Source world.Entity
 
 	// Sound is the sound to play when the explosion is created. If set to nil, this will default to the sound of a
 	// regular explosion.
@@ -110,7 +122,12 @@ func (c ExplosionConfig) Explode(tx *world.Tx, explosionPos mgl64.Vec3) {
 	}
 
 	affectedBlocks := make([]cube.Pos, 0, 32)
-	for _, ray := range rays {
+	blockRays := rays
+	if c.KeepBlocks {
+		// Without any rays, no blocks are affected by the explosion.
+		blockRays = nil
+	}
+	for _, ray := range blockRays {
 		pos := explosionPos
 		for blastForce := c.Size * (0.7 + r.Float64()*0.6); blastForce > 0.0; blastForce -= 0.225 {
 			current := cube.PosFromVec3(pos)
@@ -121,6 +138,9 @@ func (c ExplosionConfig) Explode(tx *world.Tx, explosionPos mgl64.Vec3) {
 				resistance = l.BlastResistance()
 			} else if i, ok := currentBlock.(Breakable); ok {
 				resistance = i.BreakInfo().BlastResistance
+				if c.MaxBlastResistance > 0 {
+					resistance = math.Min(resistance, c.MaxBlastResistance)
+				}
 			} else if _, ok = currentBlock.(Air); !ok {
 				// Completely stop the ray if the current block is not air and unbreakable.
 				break
@@ -20,6 +20,11 @@ func (g Fern) FlammabilityInfo() FlammabilityInfo {
 	return newFlammabilityInfo(60, 100, false)
 }
 
+// Pottable ...
+func (Fern) Pottable() bool {
+	return true
+}
+
 // BreakInfo ...
 func (g Fern) BreakInfo() BreakInfo {
 	return newBreakInfo(0, alwaysHarvestable, nothingEffective, func(t item.Tool, enchantments []item.Enchantment) []item.Stack {
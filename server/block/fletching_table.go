@@ -30,3 +30,8 @@ func (FletchingTable) EncodeItem() (string, int16) {
 func (FletchingTable) EncodeBlock() (string, map[string]any) {
 	return "minecraft:fletching_table", nil
 }
+
+// VillagePoint ...
+func (FletchingTable) VillagePoint() bool {
+	return true
+}
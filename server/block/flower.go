@@ -87,6 +87,11 @@ func (f Flower) FlammabilityInfo() FlammabilityInfo {
 	return newFlammabilityInfo(60, 100, false)
 }
 
+// Pottable ...
+func (Flower) Pottable() bool {
+	return true
+}
+
 // BreakInfo ...
 func (f Flower) BreakInfo() BreakInfo {
 	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(f))
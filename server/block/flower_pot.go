@@ -0,0 +1,119 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// PottablePlant represents a plant that can be placed in a FlowerPot.
+type PottablePlant interface {
+	world.Block
+	world.Item
+	// Pottable returns true if the plant may be placed in a flower pot.
+	Pottable() bool
+}
+
+// FlowerPot is a decorative block that can hold a single plant, such as a
+// flower, a fern or a cactus.
+type FlowerPot struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Contents is the plant held by the flower pot. If nil, the flower pot is
+	// empty.
+	Contents PottablePlant
+}
+
+// SideClosed ...
+func (FlowerPot) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// Activate takes the plant out of the flower pot if it holds one, or puts the
+// plant held by the user into the pot if it is empty.
+func (f FlowerPot) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, ctx *item.UseContext) bool {
+	if f.Contents != nil {
+		ctx.NewItem = item.NewStack(f.Contents, 1)
+		f.Contents = nil
+		tx.SetBlock(pos, f, nil)
+		return true
+	}
+	held, _ := u.HeldItems()
+	plant, ok := held.Item().(PottablePlant)
+	if !ok || !plant.Pottable() {
+		return false
+	}
+	f.Contents = plant
+	tx.SetBlock(pos, f, nil)
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// UseOnBlock ...
+func (f FlowerPot) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, f)
+	if !used {
+		return false
+	}
+
+	place(tx, pos, f, user, ctx)
+	return placed(ctx)
+}
+
+// HasLiquidDrops ...
+func (FlowerPot) HasLiquidDrops() bool {
+	return true
+}
+
+// Model ...
+func (FlowerPot) Model() world.BlockModel {
+	return model.FlowerPot{}
+}
+
+// BreakInfo ...
+func (f FlowerPot) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, func(item.Tool, []item.Enchantment) []item.Stack {
+		drops := []item.Stack{item.NewStack(FlowerPot{}, 1)}
+		if f.Contents != nil {
+			drops = append(drops, item.NewStack(f.Contents, 1))
+		}
+		return drops
+	})
+}
+
+// Pick ...
+func (FlowerPot) Pick() item.Stack {
+	return item.NewStack(FlowerPot{}, 1)
+}
+
+// EncodeItem ...
+func (FlowerPot) EncodeItem() (name string, meta int16) {
+	return "minecraft:flower_pot", 0
+}
+
+// EncodeBlock ...
+func (FlowerPot) EncodeBlock() (string, map[string]any) {
+	return "minecraft:flower_pot", map[string]any{"update_bit": false}
+}
+
+// EncodeNBT ...
+func (f FlowerPot) EncodeNBT() map[string]any {
+	m := map[string]any{"id": "FlowerPot"}
+	if f.Contents != nil {
+		m["PlantBlock"] = nbtconv.WriteBlock(f.Contents)
+	}
+	return m
+}
+
+// DecodeNBT ...
+func (f FlowerPot) DecodeNBT(data map[string]any) any {
+	f.Contents = nil
+	if plant, ok := nbtconv.Block(data, "PlantBlock").(PottablePlant); ok && plant.Pottable() {
+		f.Contents = plant
+	}
+	return f
+}
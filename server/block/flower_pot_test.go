@@ -0,0 +1,50 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestFlowerPotPottingAndUnpotting(t *testing.T) {
+	w := world.Config{}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		pos := cube.Pos{0, 64, 0}
+		tx.SetBlock(pos, FlowerPot{}, nil)
+		activate := func(held world.Item) (*item.UseContext, bool) {
+			ctx := &item.UseContext{}
+			u := testUser{pos: pos.Vec3Centre(), held: item.NewStack(held, 1)}
+			return ctx, tx.Block(pos).(FlowerPot).Activate(pos, cube.FaceUp, tx, u, ctx)
+		}
+
+		if _, ok := activate(Stone{}); ok {
+			t.Errorf("expected stone not to be put into the flower pot")
+		}
+		poppy := Flower{Type: Poppy()}
+		if ctx, ok := activate(poppy); !ok || ctx.CountSub != 1 {
+			t.Errorf("expected poppy held to be put into the flower pot")
+			return
+		}
+		pot := tx.Block(pos).(FlowerPot)
+		if pot.Contents != poppy {
+			t.Errorf("expected flower pot to hold a poppy, got %v", pot.Contents)
+			return
+		}
+		if decoded := pot.DecodeNBT(pot.EncodeNBT()).(FlowerPot); decoded.Contents != poppy {
+			t.Errorf("expected poppy to be kept when encoding the flower pot, got %v", decoded.Contents)
+		}
+		// Using a full flower pot takes the plant out of it, even if the user
+		// holds another plant.
+		ctx, ok := activate(Fern{})
+		if !ok || ctx.CountSub != 0 || ctx.NewItem.Item() != poppy {
+			t.Errorf("expected poppy to be taken out of the flower pot, got %v", ctx.NewItem)
+		}
+		if pot := tx.Block(pos).(FlowerPot); pot.Contents != nil {
+			t.Errorf("expected flower pot to be empty, got %v", pot.Contents)
+		}
+	})
+}
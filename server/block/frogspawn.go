@@ -0,0 +1,83 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+)
+
+// Frogspawn is a block laid by frogs on top of water after breeding. After
+// some time, frogspawn hatches into two to five tadpoles.
+type Frogspawn struct {
+	empty
+	transparent
+}
+
+// HasLiquidDrops ...
+func (Frogspawn) HasLiquidDrops() bool {
+	return false
+}
+
+// NeighbourUpdateTick ...
+func (f Frogspawn) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !frogspawnSupported(pos, tx) {
+		breakBlock(f, pos, tx)
+	}
+}
+
+// RandomTick hatches the frogspawn into tadpoles. Frogspawn is not hatched on
+// every random tick, so that it takes several minutes on average to hatch.
+func (f Frogspawn) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	if r.IntN(5) != 0 {
+		return
+	}
+	tx.SetBlock(pos, nil, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.FrogspawnHatch{})
+
+	create := tx.World().EntityRegistry().Config().Tadpole
+	if create == nil {
+		return
+	}
+	for range 2 + r.IntN(4) {
+		opts := world.EntitySpawnOpts{
+			Position: pos.Vec3().Add(mgl64.Vec3{0.2 + r.Float64()*0.6, -0.2, 0.2 + r.Float64()*0.6}),
+			Rotation: cube.Rotation{r.Float64() * 360},
+		}
+		tx.AddEntity(create(opts))
+	}
+}
+
+// UseOnBlock ...
+func (f Frogspawn) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, f)
+	if !used || !frogspawnSupported(pos, tx) {
+		return false
+	}
+	place(tx, pos, f, user, ctx)
+	return placed(ctx)
+}
+
+// BreakInfo ...
+func (f Frogspawn) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, simpleDrops())
+}
+
+// EncodeItem ...
+func (Frogspawn) EncodeItem() (name string, meta int16) {
+	return "minecraft:frog_spawn", 0
+}
+
+// EncodeBlock ...
+func (Frogspawn) EncodeBlock() (string, map[string]any) {
+	return "minecraft:frog_spawn", nil
+}
+
+// frogspawnSupported checks if frogspawn may exist at the position passed,
+// which is the case if there is still water below it.
+func frogspawnSupported(pos cube.Pos, tx *world.Tx) bool {
+	liq, ok := tx.Liquid(pos.Side(cube.FaceDown))
+	return ok && liq.LiquidType() == "water" && liq.LiquidDepth() == 8
+}
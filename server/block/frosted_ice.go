@@ -0,0 +1,121 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"math/rand/v2"
+	"time"
+)
+
+// FrostedIce is a variant of ice created by walking over water with boots
+// enchanted with Frost Walker. It melts back into water over time, faster when
+// it is in bright light or has few other frosted ice blocks around it.
+type FrostedIce struct {
+	solid
+	transparent
+
+	// Age is the age of the frosted ice, from 0-3. Frosted ice ages as it
+	// melts and turns into water once it melts at an age of 3.
+	Age int
+}
+
+// Instrument ...
+func (FrostedIce) Instrument() sound.Instrument {
+	return sound.Chimes()
+}
+
+// Friction ...
+func (FrostedIce) Friction() float64 {
+	return 0.98
+}
+
+// BreakInfo ...
+func (f FrostedIce) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, alwaysHarvestable, pickaxeEffective, simpleDrops()).withBreakHandler(func(pos cube.Pos, tx *world.Tx, u item.User) {
+		tx.SetBlock(pos, Water{Still: true, Depth: 8}, nil)
+	})
+}
+
+// ScheduleMelt schedules the frosted ice at the position passed to start
+// melting after a random delay.
+func (f FrostedIce) ScheduleMelt(pos cube.Pos, tx *world.Tx) {
+	tx.ScheduleBlockUpdate(pos, f, time.Duration(60+rand.IntN(61))*time.Second/20)
+}
+
+// ScheduledTick ...
+func (f FrostedIce) ScheduledTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	f.tick(pos, tx, r)
+}
+
+// RandomTick ...
+func (f FrostedIce) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	// Frosted ice also melts on random ticks, so that it does not persist if
+	// its scheduled tick was lost, for example because its chunk was unloaded.
+	f.tick(pos, tx, r)
+}
+
+// tick makes the frosted ice melt a little if the light around it is bright
+// enough, melting neighbouring frosted ice too if it turned into water. If it
+// did not melt, another tick is scheduled.
+func (f FrostedIce) tick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	if (r.IntN(3) == 0 || f.neighbours(pos, tx) < 4) && frostedIceLight(pos, tx) > 10-f.Age && f.melt(pos, tx) {
+		pos.Neighbours(func(neighbour cube.Pos) {
+			if n, ok := tx.Block(neighbour).(FrostedIce); ok {
+				n.melt(neighbour, tx)
+			}
+		}, tx.Range())
+		return
+	}
+	tx.ScheduleBlockUpdate(pos, f, time.Duration(20+r.IntN(21))*time.Second/20)
+}
+
+// melt increases the age of the frosted ice, or turns it into water if it was
+// already fully aged. melt returns true if the frosted ice turned into water.
+func (f FrostedIce) melt(pos cube.Pos, tx *world.Tx) bool {
+	if f.Age < 3 {
+		f.Age++
+		tx.SetBlock(pos, f, nil)
+		return false
+	}
+	tx.SetBlock(pos, Water{Still: true, Depth: 8}, nil)
+	return true
+}
+
+// neighbours returns the amount of frosted ice blocks directly next to the
+// frosted ice.
+func (f FrostedIce) neighbours(pos cube.Pos, tx *world.Tx) (n int) {
+	pos.Neighbours(func(neighbour cube.Pos) {
+		if _, ok := tx.Block(neighbour).(FrostedIce); ok {
+			n++
+		}
+	}, tx.Range())
+	return n
+}
+
+// frostedIceLight returns the light level at a position used to decide if
+// frosted ice melts. Sky light is darkened at night and during rain, while
+// light emitted by blocks is not.
+func frostedIceLight(pos cube.Pos, tx *world.Tx) int {
+	sky, light := int(tx.SkyLight(pos)), int(tx.Light(pos))
+	if light > sky {
+		// The light at the position comes from a block, which is not affected
+		// by the time of day.
+		return light
+	}
+	return sky - tx.World().SkyDarkening()
+}
+
+// EncodeBlock ...
+func (f FrostedIce) EncodeBlock() (string, map[string]any) {
+	return "minecraft:frosted_ice", map[string]any{"age": int32(f.Age)}
+}
+
+// allFrostedIce ...
+func allFrostedIce() (b []world.Block) {
+	for i := 0; i <= 3; i++ {
+		b = append(b, FrostedIce{Age: i})
+	}
+	return
+}
@@ -0,0 +1,71 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Fungus is a small mushroom-like plant that grows in crimson and warped
+// forests.
+type Fungus struct {
+	transparent
+	empty
+
+	// Warped is true for warped fungi. Otherwise, the fungus is a crimson
+	// fungus.
+	Warped bool
+}
+
+// NeighbourUpdateTick ...
+func (f Fungus) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !supportsVegetation(f, tx.Block(pos.Side(cube.FaceDown))) {
+		breakBlock(f, pos, tx)
+	}
+}
+
+// UseOnBlock ...
+func (f Fungus) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, f)
+	if !used {
+		return false
+	}
+	if !supportsVegetation(f, tx.Block(pos.Side(cube.FaceDown))) {
+		return false
+	}
+
+	place(tx, pos, f, user, ctx)
+	return placed(ctx)
+}
+
+// HasLiquidDrops ...
+func (Fungus) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (f Fungus) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(f))
+}
+
+// CompostChance ...
+func (Fungus) CompostChance() float64 {
+	return 0.65
+}
+
+// EncodeItem ...
+func (f Fungus) EncodeItem() (name string, meta int16) {
+	if f.Warped {
+		return "minecraft:warped_fungus", 0
+	}
+	return "minecraft:crimson_fungus", 0
+}
+
+// EncodeBlock ...
+func (f Fungus) EncodeBlock() (string, map[string]any) {
+	if f.Warped {
+		return "minecraft:warped_fungus", nil
+	}
+	return "minecraft:crimson_fungus", nil
+}
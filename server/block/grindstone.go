@@ -96,3 +96,8 @@ func allGrindstones() (grindstones []world.Block) {
 	}
 	return
 }
+
+// VillagePoint ...
+func (Grindstone) VillagePoint() bool {
+	return true
+}
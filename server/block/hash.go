@@ -5,7 +5,8 @@ package block
 import "github.com/df-mc/dragonfly/server/world"
 
 const (
-	hashAir = iota
+	hashActivatorRail = iota
+	hashAir
 	hashAmethyst
 	hashAncientDebris
 	hashAndesite
@@ -16,7 +17,10 @@ const (
 	hashBasalt
 	hashBeacon
 	hashBedrock
+	hashBeeNest
+	hashBeehive
 	hashBeetrootSeeds
+	hashBell
 	hashBlackstone
 	hashBlastFurnace
 	hashBlueIce
@@ -24,19 +28,24 @@ const (
 	hashBookshelf
 	hashBrewingStand
 	hashBricks
+	hashBubbleColumn
 	hashCactus
 	hashCake
 	hashCalcite
 	hashCampfire
 	hashCarpet
 	hashCarrot
+	hashCartographyTable
 	hashChain
 	hashChest
 	hashChiseledQuartz
+	hashChorusFlower
+	hashChorusPlant
 	hashClay
 	hashCoal
 	hashCoalOre
 	hashCobblestone
+	hashCobweb
 	hashCocoaBean
 	hashComposter
 	hashConcrete
@@ -49,11 +58,13 @@ const (
 	hashCoral
 	hashCoralBlock
 	hashCraftingTable
+	hashDaylightDetector
 	hashDeadBush
 	hashDecoratedPot
 	hashDeepslate
 	hashDeepslateBricks
 	hashDeepslateTiles
+	hashDetectorRail
 	hashDiamond
 	hashDiamondOre
 	hashDiorite
@@ -68,6 +79,8 @@ const (
 	hashEmeraldOre
 	hashEnchantingTable
 	hashEndBricks
+	hashEndGateway
+	hashEndPortal
 	hashEndRod
 	hashEndStone
 	hashEnderChest
@@ -76,7 +89,11 @@ const (
 	hashFire
 	hashFletchingTable
 	hashFlower
+	hashFlowerPot
 	hashFroglight
+	hashFrogspawn
+	hashFrostedIce
+	hashFungus
 	hashFurnace
 	hashGlass
 	hashGlassPane
@@ -89,6 +106,7 @@ const (
 	hashGravel
 	hashGrindstone
 	hashHayBale
+	hashHoney
 	hashHoneycomb
 	hashHopper
 	hashInvisibleBedrock
@@ -106,10 +124,12 @@ const (
 	hashLeaves
 	hashLectern
 	hashLight
+	hashLightningRod
 	hashLilyPad
 	hashLitPumpkin
 	hashLog
 	hashLoom
+	hashMagma
 	hashMelon
 	hashMelonSeeds
 	hashMossCarpet
@@ -126,6 +146,8 @@ const (
 	hashNetherite
 	hashNetherrack
 	hashNote
+	hashNylium
+	hashObserver
 	hashObsidian
 	hashPackedIce
 	hashPackedMud
@@ -135,6 +157,7 @@ const (
 	hashPolishedBlackstoneBrick
 	hashPolishedTuff
 	hashPotato
+	hashPoweredRail
 	hashPrismarine
 	hashPumpkin
 	hashPumpkinSeeds
@@ -143,21 +166,28 @@ const (
 	hashQuartz
 	hashQuartzBricks
 	hashQuartzPillar
+	hashRail
 	hashRawCopper
 	hashRawGold
 	hashRawIron
 	hashReinforcedDeepslate
 	hashResin
 	hashResinBricks
+	hashRespawnAnchor
+	hashRoots
 	hashSand
 	hashSandstone
+	hashSculkSensor
+	hashSculkShrieker
 	hashSeaLantern
 	hashSeaPickle
 	hashShortGrass
 	hashShroomlight
+	hashShulkerBox
 	hashSign
 	hashSkull
 	hashSlab
+	hashSlime
 	hashSmithingTable
 	hashSmoker
 	hashSnow
@@ -172,15 +202,19 @@ const (
 	hashStone
 	hashStoneBricks
 	hashStonecutter
+	hashStructureBlock
 	hashSugarCane
 	hashTNT
+	hashTarget
 	hashTerracotta
 	hashTorch
 	hashTuff
 	hashTuffBricks
+	hashTwistingVines
 	hashVines
 	hashWall
 	hashWater
+	hashWeepingVines
 	hashWheatSeeds
 	hashWood
 	hashWoodDoor
@@ -200,6 +234,10 @@ func NextHash() uint64 {
 	return customBlockBase
 }
 
+func (r ActivatorRail) Hash() (uint64, uint64) {
+	return hashActivatorRail, uint64(r.Shape.Uint8()) | uint64(boolByte(r.Powered))<<4
+}
+
 func (Air) Hash() (uint64, uint64) {
 	return hashAir, 0
 }
@@ -244,10 +282,22 @@ func (b Bedrock) Hash() (uint64, uint64) {
 	return hashBedrock, uint64(boolByte(b.InfiniteBurning))
 }
 
+func (n BeeNest) Hash() (uint64, uint64) {
+	return hashBeeNest, uint64(n.Facing) | uint64(n.HoneyLevel)<<2
+}
+
+func (h Beehive) Hash() (uint64, uint64) {
+	return hashBeehive, uint64(h.Facing) | uint64(h.HoneyLevel)<<2
+}
+
 func (b BeetrootSeeds) Hash() (uint64, uint64) {
 	return hashBeetrootSeeds, uint64(b.Growth)
 }
 
+func (b Bell) Hash() (uint64, uint64) {
+	return hashBell, uint64(b.Attach.Uint8()) | uint64(b.Facing)<<2
+}
+
 func (b Blackstone) Hash() (uint64, uint64) {
 	return hashBlackstone, uint64(b.Type.Uint8())
 }
@@ -276,6 +326,10 @@ func (Bricks) Hash() (uint64, uint64) {
 	return hashBricks, 0
 }
 
+func (b BubbleColumn) Hash() (uint64, uint64) {
+	return hashBubbleColumn, uint64(boolByte(b.DragDown))
+}
+
 func (c Cactus) Hash() (uint64, uint64) {
 	return hashCactus, uint64(c.Age)
 }
@@ -300,6 +354,10 @@ func (c Carrot) Hash() (uint64, uint64) {
 	return hashCarrot, uint64(c.Growth)
 }
 
+func (CartographyTable) Hash() (uint64, uint64) {
+	return hashCartographyTable, 0
+}
+
 func (c Chain) Hash() (uint64, uint64) {
 	return hashChain, uint64(c.Axis)
 }
@@ -312,6 +370,14 @@ func (ChiseledQuartz) Hash() (uint64, uint64) {
 	return hashChiseledQuartz, 0
 }
 
+func (c ChorusFlower) Hash() (uint64, uint64) {
+	return hashChorusFlower, uint64(c.Age)
+}
+
+func (ChorusPlant) Hash() (uint64, uint64) {
+	return hashChorusPlant, 0
+}
+
 func (Clay) Hash() (uint64, uint64) {
 	return hashClay, 0
 }
@@ -328,6 +394,10 @@ func (c Cobblestone) Hash() (uint64, uint64) {
 	return hashCobblestone, uint64(boolByte(c.Mossy))
 }
 
+func (Cobweb) Hash() (uint64, uint64) {
+	return hashCobweb, 0
+}
+
 func (c CocoaBean) Hash() (uint64, uint64) {
 	return hashCocoaBean, uint64(c.Facing) | uint64(c.Age)<<2
 }
@@ -376,6 +446,10 @@ func (CraftingTable) Hash() (uint64, uint64) {
 	return hashCraftingTable, 0
 }
 
+func (d DaylightDetector) Hash() (uint64, uint64) {
+	return hashDaylightDetector, uint64(boolByte(d.Inverted)) | uint64(d.Power)<<1
+}
+
 func (DeadBush) Hash() (uint64, uint64) {
 	return hashDeadBush, 0
 }
@@ -396,6 +470,10 @@ func (d DeepslateTiles) Hash() (uint64, uint64) {
 	return hashDeepslateTiles, uint64(boolByte(d.Cracked))
 }
 
+func (r DetectorRail) Hash() (uint64, uint64) {
+	return hashDetectorRail, uint64(r.Shape.Uint8()) | uint64(boolByte(r.Powered))<<4
+}
+
 func (Diamond) Hash() (uint64, uint64) {
 	return hashDiamond, 0
 }
@@ -452,6 +530,14 @@ func (EndBricks) Hash() (uint64, uint64) {
 	return hashEndBricks, 0
 }
 
+func (EndGateway) Hash() (uint64, uint64) {
+	return hashEndGateway, 0
+}
+
+func (EndPortal) Hash() (uint64, uint64) {
+	return hashEndPortal, 0
+}
+
 func (e EndRod) Hash() (uint64, uint64) {
 	return hashEndRod, uint64(e.Facing)
 }
@@ -484,10 +570,26 @@ func (f Flower) Hash() (uint64, uint64) {
 	return hashFlower, uint64(f.Type.Uint8())
 }
 
+func (FlowerPot) Hash() (uint64, uint64) {
+	return hashFlowerPot, 0
+}
+
 func (f Froglight) Hash() (uint64, uint64) {
 	return hashFroglight, uint64(f.Type.Uint8()) | uint64(f.Axis)<<2
 }
 
+func (Frogspawn) Hash() (uint64, uint64) {
+	return hashFrogspawn, 0
+}
+
+func (f FrostedIce) Hash() (uint64, uint64) {
+	return hashFrostedIce, uint64(f.Age)
+}
+
+func (f Fungus) Hash() (uint64, uint64) {
+	return hashFungus, uint64(boolByte(f.Warped))
+}
+
 func (f Furnace) Hash() (uint64, uint64) {
 	return hashFurnace, uint64(f.Facing) | uint64(boolByte(f.Lit))<<2
 }
@@ -536,6 +638,10 @@ func (h HayBale) Hash() (uint64, uint64) {
 	return hashHayBale, uint64(h.Axis)
 }
 
+func (Honey) Hash() (uint64, uint64) {
+	return hashHoney, 0
+}
+
 func (Honeycomb) Hash() (uint64, uint64) {
 	return hashHoneycomb, 0
 }
@@ -604,6 +710,10 @@ func (l Light) Hash() (uint64, uint64) {
 	return hashLight, uint64(l.Level)
 }
 
+func (l LightningRod) Hash() (uint64, uint64) {
+	return hashLightningRod, uint64(l.Facing)
+}
+
 func (LilyPad) Hash() (uint64, uint64) {
 	return hashLilyPad, 0
 }
@@ -620,6 +730,10 @@ func (l Loom) Hash() (uint64, uint64) {
 	return hashLoom, uint64(l.Facing)
 }
 
+func (Magma) Hash() (uint64, uint64) {
+	return hashMagma, 0
+}
+
 func (Melon) Hash() (uint64, uint64) {
 	return hashMelon, 0
 }
@@ -684,6 +798,14 @@ func (Note) Hash() (uint64, uint64) {
 	return hashNote, 0
 }
 
+func (n Nylium) Hash() (uint64, uint64) {
+	return hashNylium, uint64(boolByte(n.Warped))
+}
+
+func (o Observer) Hash() (uint64, uint64) {
+	return hashObserver, uint64(o.Facing) | uint64(boolByte(o.Powered))<<3
+}
+
 func (o Obsidian) Hash() (uint64, uint64) {
 	return hashObsidian, uint64(boolByte(o.Crying))
 }
@@ -720,6 +842,10 @@ func (p Potato) Hash() (uint64, uint64) {
 	return hashPotato, uint64(p.Growth)
 }
 
+func (r PoweredRail) Hash() (uint64, uint64) {
+	return hashPoweredRail, uint64(r.Shape.Uint8()) | uint64(boolByte(r.Powered))<<4
+}
+
 func (p Prismarine) Hash() (uint64, uint64) {
 	return hashPrismarine, uint64(p.Type.Uint8())
 }
@@ -752,6 +878,10 @@ func (q QuartzPillar) Hash() (uint64, uint64) {
 	return hashQuartzPillar, uint64(q.Axis)
 }
 
+func (r Rail) Hash() (uint64, uint64) {
+	return hashRail, uint64(r.Shape.Uint8())
+}
+
 func (RawCopper) Hash() (uint64, uint64) {
 	return hashRawCopper, 0
 }
@@ -776,6 +906,14 @@ func (r ResinBricks) Hash() (uint64, uint64) {
 	return hashResinBricks, uint64(boolByte(r.Chiseled))
 }
 
+func (r RespawnAnchor) Hash() (uint64, uint64) {
+	return hashRespawnAnchor, uint64(r.Charge)
+}
+
+func (r Roots) Hash() (uint64, uint64) {
+	return hashRoots, uint64(boolByte(r.Warped))
+}
+
 func (s Sand) Hash() (uint64, uint64) {
 	return hashSand, uint64(boolByte(s.Red))
 }
@@ -784,6 +922,14 @@ func (s Sandstone) Hash() (uint64, uint64) {
 	return hashSandstone, uint64(s.Type.Uint8()) | uint64(boolByte(s.Red))<<2
 }
 
+func (s SculkSensor) Hash() (uint64, uint64) {
+	return hashSculkSensor, uint64(s.Phase.Uint8())
+}
+
+func (s SculkShrieker) Hash() (uint64, uint64) {
+	return hashSculkShrieker, uint64(boolByte(s.Active)) | uint64(boolByte(s.CanSummon))<<1
+}
+
 func (SeaLantern) Hash() (uint64, uint64) {
 	return hashSeaLantern, 0
 }
@@ -800,8 +946,12 @@ func (Shroomlight) Hash() (uint64, uint64) {
 	return hashShroomlight, 0
 }
 
+func (s ShulkerBox) Hash() (uint64, uint64) {
+	return hashShulkerBox, uint64(s.Type.Uint8())
+}
+
 func (s Sign) Hash() (uint64, uint64) {
-	return hashSign, uint64(s.Wood.Uint8()) | uint64(s.Attach.Uint8())<<4
+	return hashSign, uint64(s.Wood.Uint8()) | uint64(s.Attach.Uint8())<<4 | uint64(boolByte(s.Hanging))<<9 | uint64(boolByte(s.Attached))<<10
 }
 
 func (s Skull) Hash() (uint64, uint64) {
@@ -812,6 +962,10 @@ func (s Slab) Hash() (uint64, uint64) {
 	return hashSlab, world.BlockHash(s.Block) | uint64(boolByte(s.Top))<<32 | uint64(boolByte(s.Double))<<33
 }
 
+func (Slime) Hash() (uint64, uint64) {
+	return hashSlime, 0
+}
+
 func (SmithingTable) Hash() (uint64, uint64) {
 	return hashSmithingTable, 0
 }
@@ -868,6 +1022,10 @@ func (s Stonecutter) Hash() (uint64, uint64) {
 	return hashStonecutter, uint64(s.Facing)
 }
 
+func (s StructureBlock) Hash() (uint64, uint64) {
+	return hashStructureBlock, uint64(s.Mode.Uint8())
+}
+
 func (c SugarCane) Hash() (uint64, uint64) {
 	return hashSugarCane, uint64(c.Age)
 }
@@ -876,6 +1034,10 @@ func (TNT) Hash() (uint64, uint64) {
 	return hashTNT, 0
 }
 
+func (Target) Hash() (uint64, uint64) {
+	return hashTarget, 0
+}
+
 func (Terracotta) Hash() (uint64, uint64) {
 	return hashTerracotta, 0
 }
@@ -892,6 +1054,10 @@ func (t TuffBricks) Hash() (uint64, uint64) {
 	return hashTuffBricks, uint64(boolByte(t.Chiseled))
 }
 
+func (v TwistingVines) Hash() (uint64, uint64) {
+	return hashTwistingVines, uint64(v.Age)
+}
+
 func (v Vines) Hash() (uint64, uint64) {
 	return hashVines, uint64(boolByte(v.NorthDirection)) | uint64(boolByte(v.EastDirection))<<1 | uint64(boolByte(v.SouthDirection))<<2 | uint64(boolByte(v.WestDirection))<<3
 }
@@ -904,6 +1070,10 @@ func (w Water) Hash() (uint64, uint64) {
 	return hashWater, uint64(boolByte(w.Still)) | uint64(w.Depth)<<1 | uint64(boolByte(w.Falling))<<9
 }
 
+func (v WeepingVines) Hash() (uint64, uint64) {
+	return hashWeepingVines, uint64(v.Age)
+}
+
 func (s WheatSeeds) Hash() (uint64, uint64) {
 	return hashWheatSeeds, uint64(s.Growth)
 }
@@ -0,0 +1,93 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// Honey is a sticky, translucent block crafted from honey bottles. Honey
+// blocks slow down entities walking on them, reduce fall damage and let
+// entities slide down their sides slowly.
+type Honey struct {
+	transparent
+}
+
+// Model ...
+func (Honey) Model() world.BlockModel {
+	return model.Honey{}
+}
+
+// EntityLand reduces the fall damage of entities landing on the honey block
+// by 80%.
+func (Honey) EntityLand(_ cube.Pos, _ *world.Tx, e world.Entity, distance *float64) {
+	if _, ok := e.(fallDistanceEntity); ok {
+		*distance *= 0.2
+	}
+}
+
+// EntityInside resets the fall distance of entities sliding down the side of
+// the honey block, as they do not take fall damage while sliding.
+func (h Honey) EntityInside(pos cube.Pos, _ *world.Tx, e world.Entity) {
+	m, ok := e.(interface {
+		fallDistanceEntity
+		Velocity() mgl64.Vec3
+		OnGround() bool
+	})
+	if ok && h.SlidingDown(pos, e.H().Type().BBox(e).Translate(e.Position()), m.Velocity(), m.OnGround()) {
+		m.ResetFallDistance()
+	}
+}
+
+// SlidingDown checks if an entity with the bounding box box and velocity vel
+// slides down the side of the honey block at pos. Entities only slide down
+// the side of a honey block if they are falling against it and are not on the
+// ground.
+func (Honey) SlidingDown(pos cube.Pos, box cube.BBox, vel mgl64.Vec3, onGround bool) bool {
+	if onGround || vel[1] >= -0.08 || box.Min()[1] > float64(pos[1])+0.9375-1e-7 {
+		return false
+	}
+	centre := box.Min().Add(box.Max()).Mul(0.5)
+	d := 0.4375 + box.Width()/2
+	return math.Abs(float64(pos[0])+0.5-centre[0])+1e-7 > d || math.Abs(float64(pos[2])+0.5-centre[2])+1e-7 > d
+}
+
+// SlideVelocity returns the velocity of an entity with velocity vel that is
+// sliding down the side of the honey block. Its fall is slowed down to 0.05
+// blocks per tick, and its horizontal velocity is reduced by the same ratio.
+func (Honey) SlideVelocity(vel mgl64.Vec3) mgl64.Vec3 {
+	if vel[1] < -0.13 {
+		f := -0.05 / vel[1]
+		return mgl64.Vec3{vel[0] * f, -0.05, vel[2] * f}
+	}
+	return mgl64.Vec3{vel[0], -0.05, vel[2]}
+}
+
+// EntityVelocity returns the velocity of an entity with velocity vel that is
+// walking on the honey block. Entities walking on honey blocks are slowed down
+// horizontally.
+func (Honey) EntityVelocity(vel mgl64.Vec3) mgl64.Vec3 {
+	return mgl64.Vec3{vel[0] * 0.4, vel[1], vel[2] * 0.4}
+}
+
+// SideClosed ...
+func (Honey) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// BreakInfo ...
+func (h Honey) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(h))
+}
+
+// EncodeItem ...
+func (Honey) EncodeItem() (name string, meta int16) {
+	return "minecraft:honey_block", 0
+}
+
+// EncodeBlock ...
+func (Honey) EncodeBlock() (string, map[string]any) {
+	return "minecraft:honey_block", nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"time"
 )
@@ -35,6 +36,7 @@ func (j Jukebox) InsertItem(h Hopper, pos cube.Pos, tx *world.Tx) bool {
 			tx.SetBlock(pos, j, nil)
 			_ = h.inventory.SetItem(sourceSlot, sourceStack.Grow(-1))
 			tx.PlaySound(pos.Vec3Centre(), sound.MusicDiscPlay{DiscType: m.DiscType})
+			tx.EmitGameEvent(pos.Vec3Centre(), gameevent.JukeboxPlay{}, nil)
 			return true
 		}
 	}
@@ -59,6 +61,7 @@ func (j Jukebox) BreakInfo() BreakInfo {
 		if _, hasDisc := j.Disc(); hasDisc {
 			dropItem(tx, j.Item, pos.Vec3())
 			tx.PlaySound(pos.Vec3Centre(), sound.MusicDiscEnd{})
+			tx.EmitGameEvent(pos.Vec3Centre(), gameevent.JukeboxStopPlay{}, u)
 		}
 	})
 }
@@ -78,6 +81,7 @@ func (j Jukebox) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User,
 		j.Item = item.Stack{}
 		tx.SetBlock(pos, j, nil)
 		tx.PlaySound(pos.Vec3Centre(), sound.MusicDiscEnd{})
+		tx.EmitGameEvent(pos.Vec3Centre(), gameevent.JukeboxStopPlay{}, u)
 	} else if held, _ := u.HeldItems(); !held.Empty() {
 		if m, ok := held.Item().(item.MusicDisc); ok {
 			j.Item = held
@@ -87,6 +91,7 @@ func (j Jukebox) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User,
 			ctx.SubtractFromCount(1)
 
 			tx.PlaySound(pos.Vec3Centre(), sound.MusicDiscPlay{DiscType: m.DiscType})
+			tx.EmitGameEvent(pos.Vec3Centre(), gameevent.JukeboxPlay{}, u)
 			if u, ok := u.(jukeboxUser); ok {
 				u.SendJukeboxPopup(fmt.Sprintf("Now playing: %v - %v", m.DiscType.Author(), m.DiscType.DisplayName()))
 			}
@@ -3,6 +3,8 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"math/rand/v2"
@@ -240,4 +242,7 @@ type LavaDamageSource struct{}
 func (LavaDamageSource) ReducedByResistance() bool { return true }
 func (LavaDamageSource) ReducedByArmour() bool     { return true }
 func (LavaDamageSource) Fire() bool                { return true }
-func (LavaDamageSource) IgnoreTotem() bool         { return false }
+func (LavaDamageSource) AffectedByEnchantment(e item.EnchantmentType) bool {
+	return e == enchantment.FireProtection
+}
+func (LavaDamageSource) IgnoreTotem() bool { return false }
@@ -7,6 +7,7 @@ import (
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 	"math/rand/v2"
+	"time"
 )
 
 // Leaves are blocks that grow as part of trees which mainly drop saplings and sticks.
@@ -62,31 +63,55 @@ func findLog(pos cube.Pos, tx *world.Tx, visited *[]cube.Pos, distance int) bool
 
 // RandomTick ...
 func (l Leaves) RandomTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
-	if !l.Persistent && l.ShouldUpdate {
-		if findLog(pos, tx, &[]cube.Pos{}, 0) {
-			l.ShouldUpdate = false
-			tx.SetBlock(pos, l, nil)
-			return
-		}
-		ctx := event.C(tx)
-		if tx.World().Handler().HandleLeavesDecay(ctx, pos); ctx.Cancelled() {
-			// Prevent immediate re-updating.
-			l.ShouldUpdate = false
-			tx.SetBlock(pos, l, nil)
-			return
-		}
-		tx.SetBlock(pos, nil, nil)
-		for _, drop := range l.BreakInfo().Drops(item.ToolNone{}, nil) {
-			dropItem(tx, drop, pos.Vec3Centre())
-		}
-	}
+	l.checkDecay(pos, tx)
 }
 
-// NeighbourUpdateTick ...
+// ScheduledTick ...
+func (l Leaves) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	l.checkDecay(pos, tx)
+}
+
+// NeighbourUpdateTick marks non-persistent leaves to be checked for decay and
+// schedules the check after a random delay, so that the leaves of a tree of
+// which the logs were removed decay gradually.
 func (l Leaves) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
 	if !l.Persistent && !l.ShouldUpdate {
 		l.ShouldUpdate = true
-		tx.SetBlock(pos, l, nil)
+		tx.SetBlock(pos, l, leavesStateOpts)
+		tx.ScheduleBlockUpdate(pos, l, leavesDecayDelay+rand.N(leavesDecayDelay*4))
+	}
+}
+
+// leavesStateOpts are the world.SetOpts used to change the state of leaves.
+// Neighbouring leaves are not updated, as they would otherwise keep marking
+// each other to be checked for decay.
+var leavesStateOpts = &world.SetOpts{DisableBlockUpdates: true, DisableLiquidDisplacement: true}
+
+// leavesDecayDelay is the minimum delay after which leaves that had a
+// neighbouring block change are checked for decay.
+const leavesDecayDelay = time.Second * 2
+
+// checkDecay checks if leaves marked to be updated are still within range of
+// a log. If not, the leaves decay and drop their items.
+func (l Leaves) checkDecay(pos cube.Pos, tx *world.Tx) {
+	if l.Persistent || !l.ShouldUpdate {
+		return
+	}
+	if findLog(pos, tx, &[]cube.Pos{}, 0) {
+		l.ShouldUpdate = false
+		tx.SetBlock(pos, l, leavesStateOpts)
+		return
+	}
+	ctx := event.C(tx)
+	if tx.World().Handler().HandleLeavesDecay(ctx, pos); ctx.Cancelled() {
+		// Prevent immediate re-updating.
+		l.ShouldUpdate = false
+		tx.SetBlock(pos, l, leavesStateOpts)
+		return
+	}
+	tx.SetBlock(pos, nil, nil)
+	for _, drop := range l.BreakInfo().Drops(item.ToolNone{}, nil) {
+		dropItem(tx, drop, pos.Vec3Centre())
 	}
 }
 
@@ -0,0 +1,42 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// updateLeaves has the leaves at the position passed handle a neighbour
+// update and runs the decay check scheduled as a result of it.
+func updateLeaves(tx *world.Tx, pos cube.Pos) {
+	tx.Block(pos).(Leaves).NeighbourUpdateTick(pos, pos.Side(cube.FaceDown), tx)
+	if l, ok := tx.Block(pos).(Leaves); ok {
+		l.ScheduledTick(pos, tx, nil)
+	}
+}
+
+func TestLeavesDecayWithoutLog(t *testing.T) {
+	withDroppedItems(func(tx *world.Tx) {
+		logPos, leavesPos, persistentPos := cube.Pos{0, 64, 0}, cube.Pos{1, 64, 0}, cube.Pos{-1, 64, 0}
+		tx.SetBlock(logPos, Log{Wood: OakWood()}, nil)
+		tx.SetBlock(leavesPos, Leaves{Wood: OakWood()}, nil)
+		tx.SetBlock(persistentPos, Leaves{Wood: OakWood(), Persistent: true}, nil)
+
+		updateLeaves(tx, leavesPos)
+		if l, ok := tx.Block(leavesPos).(Leaves); !ok || l.ShouldUpdate {
+			t.Errorf("expected leaves next to a log not to decay, got %#v", tx.Block(leavesPos))
+			return
+		}
+
+		tx.SetBlock(logPos, nil, nil)
+		updateLeaves(tx, leavesPos)
+		updateLeaves(tx, persistentPos)
+		if _, ok := tx.Block(leavesPos).(Air); !ok {
+			t.Errorf("expected leaves to decay after the log was removed, got %#v", tx.Block(leavesPos))
+		}
+		if _, ok := tx.Block(persistentPos).(Leaves); !ok {
+			t.Errorf("expected persistent leaves not to decay, got %#v", tx.Block(persistentPos))
+		}
+	})
+}
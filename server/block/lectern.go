@@ -167,3 +167,8 @@ func allLecterns() (lecterns []world.Block) {
 	}
 	return
 }
+
+// VillagePoint ...
+func (Lectern) VillagePoint() bool {
+	return true
+}
@@ -0,0 +1,110 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// LightningRod is a block that attracts lightning striking close to it during
+// thunderstorms. A lightning rod emits a redstone signal when it is struck.
+type LightningRod struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Facing is the direction the tip of the lightning rod is facing.
+	Facing cube.Face
+	// Powered is true if the lightning rod was struck by lightning recently,
+	// in which case it emits a redstone signal with a strength of 15.
+	Powered bool
+}
+
+// AttractsLightning returns true if the lightning rod is able to redirect
+// lightning striking close to it. Only lightning rods that are not covered by
+// any other blocks are struck, which is checked by the world.
+func (LightningRod) AttractsLightning() bool {
+	return true
+}
+
+// StruckByLightning makes the lightning rod emit a redstone signal for 8 ticks.
+func (l LightningRod) StruckByLightning(pos cube.Pos, tx *world.Tx) {
+	if !l.Powered {
+		l.Powered = true
+		tx.SetBlock(pos, l, nil)
+	}
+	tx.ScheduleBlockUpdate(pos, l, time.Second*2/5)
+}
+
+// ScheduledTick stops the redstone signal of the lightning rod.
+func (l LightningRod) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if l.Powered {
+		l.Powered = false
+		tx.SetBlock(pos, l, nil)
+	}
+}
+
+// UseOnBlock ...
+func (l LightningRod) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(tx, pos, face, l)
+	if !used {
+		return false
+	}
+
+	l.Facing = face
+	if other, ok := tx.Block(pos.Side(face.Opposite())).(LightningRod); ok && other.Facing == face {
+		l.Facing = face.Opposite()
+	}
+	place(tx, pos, l, user, ctx)
+	return placed(ctx)
+}
+
+// SideClosed ...
+func (LightningRod) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// Model ...
+func (l LightningRod) Model() world.BlockModel {
+	return model.EndRod{Axis: l.Facing.Axis()}
+}
+
+// BreakInfo ...
+func (l LightningRod) BreakInfo() BreakInfo {
+	return newBreakInfo(3, func(t item.Tool) bool {
+		return t.ToolType() == item.TypePickaxe && t.HarvestLevel() >= item.ToolTierStone.HarvestLevel
+	}, pickaxeEffective, oneOf(LightningRod{})).withBlastResistance(30)
+}
+
+// DecodeNBT ...
+func (l LightningRod) DecodeNBT(data map[string]any) any {
+	l.Powered = nbtconv.Bool(data, "Powered")
+	return l
+}
+
+// EncodeNBT ...
+func (l LightningRod) EncodeNBT() map[string]any {
+	return map[string]any{"id": "LightningRod", "Powered": boolByte(l.Powered)}
+}
+
+// EncodeItem ...
+func (LightningRod) EncodeItem() (name string, meta int16) {
+	return "minecraft:lightning_rod", 0
+}
+
+// EncodeBlock ...
+func (l LightningRod) EncodeBlock() (string, map[string]any) {
+	return "minecraft:lightning_rod", map[string]any{"facing_direction": int32(l.Facing)}
+}
+
+// allLightningRods ...
+func allLightningRods() (b []world.Block) {
+	for _, f := range cube.Faces() {
+		b = append(b, LightningRod{Facing: f})
+	}
+	return
+}
@@ -65,3 +65,8 @@ func allLooms() (looms []world.Block) {
 	}
 	return
 }
+
+// VillagePoint ...
+func (Loom) VillagePoint() bool {
+	return true
+}
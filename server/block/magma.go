@@ -0,0 +1,73 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// Magma is a light-emitting block found in the Nether. Placed under water,
+// it creates a bubble column that drags entities down.
+type Magma struct {
+	solid
+}
+
+// NeighbourUpdateTick ...
+func (m Magma) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	formBubbleColumn(pos.Side(cube.FaceUp), tx)
+}
+
+// EntityStepOn damages living entities standing on the magma block, unless
+// they are sneaking or wear boots enchanted with Frost Walker.
+func (Magma) EntityStepOn(_ cube.Pos, _ *world.Tx, e world.Entity) {
+	if s, ok := e.(interface{ Sneaking() bool }); ok && s.Sneaking() {
+		return
+	}
+	if a, ok := e.(interface{ Armour() *inventory.Armour }); ok {
+		if _, ok := a.Armour().Boots().Enchantment(enchantment.FrostWalker); ok {
+			return
+		}
+	}
+	if l, ok := e.(livingEntity); ok {
+		l.Hurt(1, MagmaDamageSource{})
+	}
+}
+
+// Instrument ...
+func (Magma) Instrument() sound.Instrument {
+	return sound.BassDrum()
+}
+
+// LightEmissionLevel returns 3.
+func (Magma) LightEmissionLevel() uint8 {
+	return 3
+}
+
+// BreakInfo ...
+func (m Magma) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, pickaxeHarvestable, pickaxeEffective, oneOf(m))
+}
+
+// EncodeItem ...
+func (Magma) EncodeItem() (name string, meta int16) {
+	return "minecraft:magma", 0
+}
+
+// EncodeBlock ...
+func (Magma) EncodeBlock() (string, map[string]any) {
+	return "minecraft:magma", nil
+}
+
+// MagmaDamageSource is used for damage caused by standing on a magma block.
+type MagmaDamageSource struct{}
+
+func (MagmaDamageSource) ReducedByResistance() bool { return true }
+func (MagmaDamageSource) ReducedByArmour() bool     { return true }
+func (MagmaDamageSource) Fire() bool                { return true }
+func (MagmaDamageSource) AffectedByEnchantment(e item.EnchantmentType) bool {
+	return e == enchantment.FireProtection
+}
+func (MagmaDamageSource) IgnoreTotem() bool { return false }
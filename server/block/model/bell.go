@@ -0,0 +1,20 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Bell is a model for the bell block. Only the bell itself has a collision
+// box, the frame that holds it does not.
+type Bell struct{}
+
+// BBox returns a physics.BBox of the bell hanging in the centre of the block.
+func (Bell) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	return []cube.BBox{cube.Box(0.25, 0.25, 0.25, 0.75, 0.8125, 0.75)}
+}
+
+// FaceSolid always returns false.
+func (Bell) FaceSolid(cube.Pos, cube.Face, world.BlockSource) bool {
+	return false
+}
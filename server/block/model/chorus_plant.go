@@ -0,0 +1,34 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// ChorusPlant is a model used by chorus plants. It consists of a core in the
+// centre of the block, which extends towards other chorus plants next to it
+// and towards the block it grows on.
+type ChorusPlant struct{}
+
+// BBox returns a slice of physics.BBox that depends on the blocks surrounding
+// the ChorusPlant.
+func (ChorusPlant) BBox(pos cube.Pos, s world.BlockSource) []cube.BBox {
+	const offset = 0.1875
+
+	boxes := make([]cube.BBox, 0, 7)
+	mainBox := cube.Box(offset, offset, offset, 1-offset, 1-offset, 1-offset)
+	for _, f := range cube.Faces() {
+		pos := pos.Side(f)
+		block := s.Block(pos)
+
+		if _, plant := block.Model().(ChorusPlant); plant || (f == cube.FaceDown && block.Model().FaceSolid(pos, cube.FaceUp, s)) {
+			boxes = append(boxes, mainBox.ExtendTowards(f, offset))
+		}
+	}
+	return append(boxes, mainBox)
+}
+
+// FaceSolid always returns false.
+func (ChorusPlant) FaceSolid(cube.Pos, cube.Face, world.BlockSource) bool {
+	return false
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// DaylightDetector is a model used by daylight detectors.
+type DaylightDetector struct{}
+
+// BBox ...
+func (DaylightDetector) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	return []cube.BBox{cube.Box(0, 0, 0, 1, 0.375, 1)}
+}
+
+// FaceSolid ...
+func (DaylightDetector) FaceSolid(cube.Pos, cube.Face, world.BlockSource) bool {
+	return false
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// FlowerPot is the model for a flower pot. It is a small box placed in the
+// middle of the bottom of the block.
+type FlowerPot struct{}
+
+// BBox returns a physics.BBox that spans the pot in the middle of the block.
+func (FlowerPot) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	return []cube.BBox{cube.Box(0.3125, 0, 0.3125, 0.6875, 0.375, 0.6875)}
+}
+
+// FaceSolid always returns false.
+func (FlowerPot) FaceSolid(cube.Pos, cube.Face, world.BlockSource) bool {
+	return false
+}
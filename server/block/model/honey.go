@@ -0,0 +1,21 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Honey is the model for a Honey block. Its width, depth and height are
+// slightly reduced, so that entities sink into it slightly and may touch its
+// sides.
+type Honey struct{}
+
+// BBox returns a cube.BBox that is slightly smaller than a full block.
+func (Honey) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	return []cube.BBox{cube.Box(0.0625, 0, 0.0625, 0.9375, 0.9375, 0.9375)}
+}
+
+// FaceSolid always returns false.
+func (Honey) FaceSolid(cube.Pos, cube.Face, world.BlockSource) bool {
+	return false
+}
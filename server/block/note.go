@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
 	"github.com/df-mc/dragonfly/server/world/particle"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"time"
@@ -19,10 +20,12 @@ type Note struct {
 	Pitch int
 }
 
-// playNote ...
-func (n Note) playNote(pos cube.Pos, tx *world.Tx) {
+// playNote plays the note of the note block. src is the entity that played the
+// note. It may be nil.
+func (n Note) playNote(pos cube.Pos, tx *world.Tx, src world.Entity) {
 	tx.PlaySound(pos.Vec3(), sound.Note{Instrument: n.instrument(pos, tx), Pitch: n.Pitch})
 	tx.AddParticle(pos.Vec3(), particle.Note{Instrument: n.Instrument(), Pitch: n.Pitch})
+	tx.EmitGameEvent(pos.Vec3Centre(), gameevent.NoteBlockPlay{}, src)
 }
 
 // updateInstrument ...
@@ -47,12 +50,12 @@ func (n Note) EncodeNBT() map[string]any {
 }
 
 // Activate ...
-func (n Note) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
+func (n Note) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
 	if _, ok := tx.Block(pos.Side(cube.FaceUp)).(Air); !ok {
 		return false
 	}
 	n.Pitch = (n.Pitch + 1) % 25
-	n.playNote(pos, tx)
+	n.playNote(pos, tx, u)
 	tx.SetBlock(pos, n, &world.SetOpts{DisableBlockUpdates: true, DisableLiquidDisplacement: true})
 	return true
 }
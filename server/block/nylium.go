@@ -0,0 +1,57 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+)
+
+// Nylium is a variant of netherrack covered in fungal growth, found on the
+// surface of crimson and warped forests.
+type Nylium struct {
+	solid
+	bassDrum
+
+	// Warped is true for warped nylium, which is found in warped forests.
+	// Otherwise, the nylium is crimson nylium.
+	Warped bool
+}
+
+// SoilFor ...
+func (n Nylium) SoilFor(block world.Block) bool {
+	switch block.(type) {
+	case NetherSprouts, Fungus, Roots:
+		return true
+	}
+	return false
+}
+
+// RandomTick turns the nylium back into netherrack if it is covered by a
+// solid block.
+func (n Nylium) RandomTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	above := pos.Side(cube.FaceUp)
+	if tx.Block(above).Model().FaceSolid(above, cube.FaceDown, tx) {
+		tx.SetBlock(pos, Netherrack{}, nil)
+	}
+}
+
+// BreakInfo ...
+func (n Nylium) BreakInfo() BreakInfo {
+	return newBreakInfo(0.4, pickaxeHarvestable, pickaxeEffective, silkTouchOneOf(Netherrack{}, n))
+}
+
+// EncodeItem ...
+func (n Nylium) EncodeItem() (name string, meta int16) {
+	if n.Warped {
+		return "minecraft:warped_nylium", 0
+	}
+	return "minecraft:crimson_nylium", 0
+}
+
+// EncodeBlock ...
+func (n Nylium) EncodeBlock() (string, map[string]any) {
+	if n.Warped {
+		return "minecraft:warped_nylium", nil
+	}
+	return "minecraft:crimson_nylium", nil
+}
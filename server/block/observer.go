@@ -0,0 +1,86 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// Observer is a block that detects changes to the block in front of it. When
+// the state of that block changes, the observer emits a short redstone pulse
+// out of its back.
+type Observer struct {
+	solid
+	bassDrum
+
+	// Facing is the direction that the face of the observer points towards.
+	// The observer detects changes of the block in this direction and emits
+	// its pulse in the opposite direction.
+	Facing cube.Face
+	// Powered is true while the observer emits a redstone pulse.
+	Powered bool
+}
+
+// observerPulse is the delay before an observer emits a pulse after
+// detecting a change, and the duration of the pulse.
+const observerPulse = time.Second / 10
+
+// ObserveBlockChange starts a pulse if the block in front of the observer
+// changed.
+func (o Observer) ObserveBlockChange(pos, changedNeighbour cube.Pos, tx *world.Tx) {
+	if o.Powered || changedNeighbour != pos.Side(o.Facing) {
+		return
+	}
+	tx.ScheduleBlockUpdate(pos, o, observerPulse)
+}
+
+// ScheduledTick starts or ends the pulse of the observer. Changing the state
+// of the observer updates the block behind it, as well as any observer
+// watching it.
+func (o Observer) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	o.Powered = !o.Powered
+	tx.SetBlock(pos, o, nil)
+	if o.Powered {
+		tx.ScheduleBlockUpdate(pos, o, observerPulse)
+	}
+}
+
+// UseOnBlock ...
+func (o Observer) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(tx, pos, face, o)
+	if !used {
+		return
+	}
+	// The face of the observer points away from the user placing it.
+	o.Facing, o.Powered = calculateFace(user, pos).Opposite(), false
+
+	place(tx, pos, o, user, ctx)
+	return placed(ctx)
+}
+
+// BreakInfo ...
+func (o Observer) BreakInfo() BreakInfo {
+	return newBreakInfo(3, pickaxeHarvestable, pickaxeEffective, oneOf(Observer{}))
+}
+
+// EncodeItem ...
+func (Observer) EncodeItem() (name string, meta int16) {
+	return "minecraft:observer", 0
+}
+
+// EncodeBlock ...
+func (o Observer) EncodeBlock() (string, map[string]any) {
+	return "minecraft:observer", map[string]any{"minecraft:facing_direction": o.Facing.String(), "powered_bit": boolByte(o.Powered)}
+}
+
+// allObservers ...
+func allObservers() (b []world.Block) {
+	for _, f := range cube.Faces() {
+		b = append(b, Observer{Facing: f})
+		b = append(b, Observer{Facing: f, Powered: true})
+	}
+	return
+}
@@ -0,0 +1,48 @@
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestObserverDetectsChangeInFront(t *testing.T) {
+	w := world.Config{}.New()
+	defer w.Close()
+	// Neighbour updates are only performed in ticking chunks.
+	w.ForceLoad(world.ChunkPos{}, time.Hour)
+
+	front, behind := cube.Pos{8, 64, 8}, cube.Pos{8, 64, 4}
+	<-w.Exec(func(tx *world.Tx) {
+		tx.SetBlock(front, Observer{Facing: cube.FaceEast}, nil)
+		tx.SetBlock(behind, Observer{Facing: cube.FaceEast}, nil)
+	})
+	<-w.Exec(func(tx *world.Tx) {
+		tx.SetBlock(front.Side(cube.FaceEast), Stone{}, nil)
+		tx.SetBlock(behind.Side(cube.FaceWest), Stone{}, nil)
+	})
+
+	// The observer watching the changed block emits a pulse, after which it
+	// is unpowered again. The observer of which only the back changed never
+	// emits one.
+	deadline := time.Now().Add(5 * time.Second)
+	for pulsed := false; ; {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected observer to emit a pulse after the block in front of it changed, pulsed: %v", pulsed)
+		}
+		var done bool
+		<-w.Exec(func(tx *world.Tx) {
+			if tx.Block(behind).(Observer).Powered {
+				t.Errorf("expected observer not to emit a pulse after the block behind it changed")
+			}
+			powered := tx.Block(front).(Observer).Powered
+			done = pulsed && !powered
+			pulsed = pulsed || powered
+		})
+		if done || t.Failed() {
+			return
+		}
+	}
+}
@@ -35,9 +35,15 @@ func (p Potato) ConsumeDuration() time.Duration {
 	return item.DefaultConsumeDuration
 }
 
+// FoodInfo ...
+func (Potato) FoodInfo() item.FoodInfo {
+	return item.FoodInfo{Food: 1, Saturation: 0.6}
+}
+
 // Consume ...
 func (p Potato) Consume(_ *world.Tx, c item.Consumer) item.Stack {
-	c.Saturate(1, 0.6)
+	info := p.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return item.Stack{}
 }
 
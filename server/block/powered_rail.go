@@ -0,0 +1,124 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// PoweredRail is a rail that accelerates minecarts riding over it while it is
+// powered by redstone. An unpowered powered rail slows down minecarts until
+// they come to a halt.
+type PoweredRail struct {
+	empty
+	transparent
+
+	// Shape is the shape of the rail. Powered rails cannot be curved.
+	Shape RailShape
+	// Powered is true if the rail receives a redstone signal, either directly
+	// or through a line of up to 8 powered rails.
+	Powered bool
+}
+
+// UseOnBlock ...
+func (r PoweredRail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	r.Powered = false
+	return placeRail(r, pos, face, tx, user, ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r PoweredRail) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !checkRailSupport(r, pos, tx) {
+		return
+	}
+	if powered := railSignal(pos, r, tx); powered != r.Powered {
+		r.Powered = powered
+		tx.SetBlock(pos, r, nil)
+	}
+}
+
+// SideClosed ...
+func (PoweredRail) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// HasLiquidDrops ...
+func (PoweredRail) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (r PoweredRail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(PoweredRail{}))
+}
+
+// EncodeItem ...
+func (PoweredRail) EncodeItem() (name string, meta int16) {
+	return "minecraft:golden_rail", 0
+}
+
+// EncodeBlock ...
+func (r PoweredRail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:golden_rail", map[string]any{"rail_direction": int32(r.Shape.Uint8()), "rail_data_bit": boolByte(r.Powered)}
+}
+
+// railShape ...
+func (r PoweredRail) railShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r PoweredRail) withRailShape(s RailShape) railBlock {
+	r.Shape = s
+	return r
+}
+
+// curvable ...
+func (PoweredRail) curvable() bool {
+	return false
+}
+
+// allPoweredRails ...
+func allPoweredRails() (b []world.Block) {
+	for _, s := range RailShapes() {
+		if s.Curved() {
+			continue
+		}
+		b = append(b, PoweredRail{Shape: s}, PoweredRail{Shape: s, Powered: true})
+	}
+	return
+}
+
+// railSignal checks if the rail at the position passed receives a redstone
+// signal. Besides being powered directly, a rail is powered if one of the
+// next 8 rails of the same kind in either direction along the track is.
+func railSignal(pos cube.Pos, r railBlock, tx *world.Tx) bool {
+	if receivesRedstonePower(pos, tx) {
+		return true
+	}
+	name, _ := r.EncodeBlock()
+	for _, d := range cube.Directions() {
+		if !r.railShape().connects(d) {
+			continue
+		}
+		current := pos
+		for range 8 {
+			next, n, ok := railNeighbour(current, d, tx)
+			if !ok || !n.railShape().connects(d.Opposite()) {
+				break
+			}
+			if nextName, _ := n.EncodeBlock(); nextName != name {
+				break
+			}
+			if receivesRedstonePower(next, tx) {
+				return true
+			}
+			if !n.railShape().connects(d) {
+				break
+			}
+			current = next
+		}
+	}
+	return false
+}
@@ -2,8 +2,10 @@ package block
 
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
 )
@@ -35,9 +37,85 @@ func (p Pumpkin) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *worl
 	p.Facing = user.Rotation().Direction().Opposite()
 
 	place(tx, pos, p, user, ctx)
+	if placed(ctx) && p.Carved {
+		p.buildGolem(pos, tx)
+	}
 	return placed(ctx)
 }
 
+// buildGolem checks if the carved pumpkin at the position passed completes
+// the pattern of an iron golem or a snow golem. If so, the blocks of the
+// pattern are removed and the golem is spawned in their place.
+func (Pumpkin) buildGolem(pos cube.Pos, tx *world.Tx) {
+	conf := tx.World().EntityRegistry().Config()
+	opts := world.EntitySpawnOpts{Position: pos.Sub(cube.Pos{0, 2}).Vec3Middle()}
+
+	var (
+		blocks []cube.Pos
+		handle *world.EntityHandle
+	)
+	if blocks = ironGolemPattern(pos, tx); blocks != nil && conf.IronGolem != nil {
+		handle = conf.IronGolem(opts, true)
+	} else if blocks = snowGolemPattern(pos, tx); blocks != nil && conf.SnowGolem != nil {
+		handle = conf.SnowGolem(opts)
+	} else {
+		return
+	}
+	built, ctx := handle, event.C(tx)
+	tx.World().Handler().HandleEntityBuild(ctx, blocks, &handle)
+	if handle != built {
+		// The handler replaced the golem, which will never be added to the
+		// world, so its handle must be closed.
+		_ = built.Close()
+	}
+	if ctx.Cancelled() || handle == nil {
+		if handle != nil {
+			_ = handle.Close()
+		}
+		return
+	}
+	for _, bPos := range blocks {
+		tx.AddParticle(bPos.Vec3Centre(), particle.BlockBreak{Block: tx.Block(bPos)})
+		tx.SetBlock(bPos, nil, nil)
+	}
+	tx.AddEntity(handle)
+}
+
+// ironGolemPattern returns the positions of the blocks that make up an iron
+// golem with its head at the position passed: A T of iron blocks, with air
+// on both sides of the bottom block. The arms of the T may point in either
+// horizontal direction. Nil is returned if there is no iron golem pattern.
+func ironGolemPattern(head cube.Pos, tx *world.Tx) []cube.Pos {
+	body, legs := head.Side(cube.FaceDown), head.Side(cube.FaceDown).Side(cube.FaceDown)
+	if !blockIs[Iron](tx, body) || !blockIs[Iron](tx, legs) {
+		return nil
+	}
+	for _, off := range [...]cube.Pos{{1, 0, 0}, {0, 0, 1}} {
+		left, right := body.Add(off), body.Sub(off)
+		if blockIs[Iron](tx, left) && blockIs[Iron](tx, right) && blockIs[Air](tx, legs.Add(off)) && blockIs[Air](tx, legs.Sub(off)) {
+			return []cube.Pos{head, body, left, right, legs}
+		}
+	}
+	return nil
+}
+
+// snowGolemPattern returns the positions of the blocks that make up a snow
+// golem with its head at the position passed: Two snow blocks stacked on top
+// of each other. Nil is returned if there is no snow golem pattern.
+func snowGolemPattern(head cube.Pos, tx *world.Tx) []cube.Pos {
+	body, legs := head.Side(cube.FaceDown), head.Side(cube.FaceDown).Side(cube.FaceDown)
+	if !blockIs[Snow](tx, body) || !blockIs[Snow](tx, legs) {
+		return nil
+	}
+	return []cube.Pos{head, body, legs}
+}
+
+// blockIs checks if the block at the position passed is of the type T.
+func blockIs[T world.Block](tx *world.Tx, pos cube.Pos) bool {
+	_, ok := tx.Block(pos).(T)
+	return ok
+}
+
 // BreakInfo ...
 func (p Pumpkin) BreakInfo() BreakInfo {
 	return newBreakInfo(1, alwaysHarvestable, axeEffective, oneOf(p))
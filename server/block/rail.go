@@ -0,0 +1,245 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Rail is a block that minecarts ride on. Rails connect to the rails next to
+// them when placed and may curve to connect two rails that are not in line
+// with each other.
+type Rail struct {
+	empty
+	transparent
+
+	// Shape is the shape of the rail, which specifies the directions that it
+	// connects.
+	Shape RailShape
+}
+
+// RailRider is an entity that rides on rails, such as a minecart. Detector
+// rails emit a redstone signal while a RailRider rides over them.
+type RailRider interface {
+	world.Entity
+	// RidingRail returns the position of the rail that the entity is currently
+	// riding on. False is returned if the entity is not riding on a rail.
+	RidingRail() (cube.Pos, bool)
+}
+
+// RailRideHandler represents a rail that does something when a RailRider
+// rides over it.
+type RailRideHandler interface {
+	// RiddenOver is called every tick that a RailRider rides over the rail.
+	RiddenOver(pos cube.Pos, tx *world.Tx, e RailRider)
+}
+
+// UseOnBlock ...
+func (r Rail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	return placeRail(r, pos, face, tx, user, ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r Rail) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	checkRailSupport(r, pos, tx)
+}
+
+// SideClosed ...
+func (Rail) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// HasLiquidDrops ...
+func (Rail) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (r Rail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(Rail{}))
+}
+
+// EncodeItem ...
+func (Rail) EncodeItem() (name string, meta int16) {
+	return "minecraft:rail", 0
+}
+
+// EncodeBlock ...
+func (r Rail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:rail", map[string]any{"rail_direction": int32(r.Shape.Uint8())}
+}
+
+// railShape ...
+func (r Rail) railShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r Rail) withRailShape(s RailShape) railBlock {
+	r.Shape = s
+	return r
+}
+
+// curvable ...
+func (Rail) curvable() bool {
+	return true
+}
+
+// allRails ...
+func allRails() (b []world.Block) {
+	for _, s := range RailShapes() {
+		b = append(b, Rail{Shape: s})
+	}
+	return
+}
+
+// railBlock is implemented by all rails.
+type railBlock interface {
+	world.Block
+	railShape() RailShape
+	withRailShape(s RailShape) railBlock
+	// curvable checks if the rail may be curved. Only regular rails curve.
+	curvable() bool
+}
+
+// placeRail places the rail passed at the position clicked, giving it a shape
+// that connects it to the rails around it. The rails that the new rail
+// connects to are reshaped to connect back to it if they are not yet
+// connected on both ends.
+func placeRail(r railBlock, pos cube.Pos, face cube.Face, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, r)
+	if !used || !railSupported(pos, tx) {
+		return false
+	}
+	r = r.withRailShape(connectedRailShape(pos, r.curvable(), user.Rotation().Direction(), tx))
+	place(tx, pos, r, user, ctx)
+	if !placed(ctx) {
+		return false
+	}
+	for _, d := range cube.Directions() {
+		if !r.railShape().connects(d) {
+			continue
+		}
+		npos, n, ok := railNeighbour(pos, d, tx)
+		if !ok || n.railShape().connects(d.Opposite()) || railConnections(npos, n.railShape(), tx) >= 2 {
+			continue
+		}
+		shape := connectedRailShape(npos, n.curvable(), d, tx)
+		if shape != n.railShape() {
+			tx.SetBlock(npos, n.withRailShape(shape), nil)
+		}
+	}
+	return true
+}
+
+// connectedRailShape returns the shape that a rail at the position passed
+// would have to connect to the rails around it. If no rails are around, the
+// rail runs in the direction passed.
+func connectedRailShape(pos cube.Pos, curvable bool, def cube.Direction, tx *world.Tx) RailShape {
+	n, s := railConnectable(pos, cube.North, tx), railConnectable(pos, cube.South, tx)
+	w, e := railConnectable(pos, cube.West, tx), railConnectable(pos, cube.East, tx)
+
+	var shape RailShape
+	switch {
+	case (n || s) && !w && !e:
+		shape = RailNorthSouth()
+	case (w || e) && !n && !s:
+		shape = RailEastWest()
+	case curvable && s && e:
+		shape = RailSouthEast()
+	case curvable && s && w:
+		shape = RailSouthWest()
+	case curvable && n && w:
+		shape = RailNorthWest()
+	case curvable && n && e:
+		shape = RailNorthEast()
+	case n || s:
+		shape = RailNorthSouth()
+	case w || e:
+		shape = RailEastWest()
+	case def == cube.East || def == cube.West:
+		shape = RailEastWest()
+	default:
+		shape = RailNorthSouth()
+	}
+	// Straight rails ascend towards a rail one block higher on one of their
+	// ends.
+	switch shape {
+	case RailNorthSouth():
+		if railAbove(pos, cube.North, tx) {
+			shape = RailAscendingNorth()
+		} else if railAbove(pos, cube.South, tx) {
+			shape = RailAscendingSouth()
+		}
+	case RailEastWest():
+		if railAbove(pos, cube.East, tx) {
+			shape = RailAscendingEast()
+		} else if railAbove(pos, cube.West, tx) {
+			shape = RailAscendingWest()
+		}
+	}
+	return shape
+}
+
+// railNeighbour returns the rail next to the position passed in the direction
+// passed. The rail may be at the same height, or one block higher or lower.
+func railNeighbour(pos cube.Pos, d cube.Direction, tx *world.Tx) (cube.Pos, railBlock, bool) {
+	side := pos.Side(d.Face())
+	for _, p := range [...]cube.Pos{side, side.Side(cube.FaceUp), side.Side(cube.FaceDown)} {
+		if r, ok := tx.Block(p).(railBlock); ok {
+			return p, r, true
+		}
+	}
+	return cube.Pos{}, nil, false
+}
+
+// railAbove checks if there is a rail one block higher than the position
+// passed in the direction passed.
+func railAbove(pos cube.Pos, d cube.Direction, tx *world.Tx) bool {
+	_, ok := tx.Block(pos.Side(d.Face()).Side(cube.FaceUp)).(railBlock)
+	return ok
+}
+
+// railConnectable checks if a rail at the position passed may connect to the
+// rail next to it in the direction passed: The neighbouring rail must either
+// already connect to the position, or not yet be connected on both ends.
+func railConnectable(pos cube.Pos, d cube.Direction, tx *world.Tx) bool {
+	npos, n, ok := railNeighbour(pos, d, tx)
+	if !ok {
+		return false
+	}
+	return n.railShape().connects(d.Opposite()) || railConnections(npos, n.railShape(), tx) < 2
+}
+
+// railConnections returns the number of rails that the rail at the position
+// passed is connected with: Rails that it has an exit towards and that have
+// an exit back towards it.
+func railConnections(pos cube.Pos, shape RailShape, tx *world.Tx) (n int) {
+	for _, d := range cube.Directions() {
+		if !shape.connects(d) {
+			continue
+		}
+		if _, other, ok := railNeighbour(pos, d, tx); ok && other.railShape().connects(d.Opposite()) {
+			n++
+		}
+	}
+	return n
+}
+
+// railSupported checks if a rail can exist at the position passed. Rails must
+// be placed on top of a block with a solid top face.
+func railSupported(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	return tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx)
+}
+
+// checkRailSupport breaks the rail passed if the block below it no longer
+// supports it.
+func checkRailSupport(r railBlock, pos cube.Pos, tx *world.Tx) bool {
+	if !railSupported(pos, tx) {
+		breakBlock(r, pos, tx)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,151 @@
+package block
+
+import "github.com/df-mc/dragonfly/server/block/cube"
+
+// RailShape represents the shape of a rail: The two directions that it
+// connects and whether it ascends towards one of them.
+type RailShape struct {
+	railShape
+}
+
+type railShape uint8
+
+// RailNorthSouth is the shape of a straight rail running from north to south.
+func RailNorthSouth() RailShape {
+	return RailShape{0}
+}
+
+// RailEastWest is the shape of a straight rail running from east to west.
+func RailEastWest() RailShape {
+	return RailShape{1}
+}
+
+// RailAscendingEast is the shape of a rail running from west to east that
+// ascends towards the east.
+func RailAscendingEast() RailShape {
+	return RailShape{2}
+}
+
+// RailAscendingWest is the shape of a rail running from east to west that
+// ascends towards the west.
+func RailAscendingWest() RailShape {
+	return RailShape{3}
+}
+
+// RailAscendingNorth is the shape of a rail running from south to north that
+// ascends towards the north.
+func RailAscendingNorth() RailShape {
+	return RailShape{4}
+}
+
+// RailAscendingSouth is the shape of a rail running from north to south that
+// ascends towards the south.
+func RailAscendingSouth() RailShape {
+	return RailShape{5}
+}
+
+// RailSouthEast is the shape of a curved rail connecting the south and the
+// east.
+func RailSouthEast() RailShape {
+	return RailShape{6}
+}
+
+// RailSouthWest is the shape of a curved rail connecting the south and the
+// west.
+func RailSouthWest() RailShape {
+	return RailShape{7}
+}
+
+// RailNorthWest is the shape of a curved rail connecting the north and the
+// west.
+func RailNorthWest() RailShape {
+	return RailShape{8}
+}
+
+// RailNorthEast is the shape of a curved rail connecting the north and the
+// east.
+func RailNorthEast() RailShape {
+	return RailShape{9}
+}
+
+// RailShapes returns all possible rail shapes.
+func RailShapes() []RailShape {
+	return []RailShape{
+		RailNorthSouth(), RailEastWest(), RailAscendingEast(), RailAscendingWest(), RailAscendingNorth(),
+		RailAscendingSouth(), RailSouthEast(), RailSouthWest(), RailNorthWest(), RailNorthEast(),
+	}
+}
+
+// railExits holds the exits of every rail shape, indexed by the shape.
+var railExits = [...][2]cube.Pos{
+	{{0, 0, -1}, {0, 0, 1}},
+	{{-1, 0, 0}, {1, 0, 0}},
+	{{-1, -1, 0}, {1, 0, 0}},
+	{{-1, 0, 0}, {1, -1, 0}},
+	{{0, 0, -1}, {0, -1, 1}},
+	{{0, -1, -1}, {0, 0, 1}},
+	{{0, 0, 1}, {1, 0, 0}},
+	{{0, 0, 1}, {-1, 0, 0}},
+	{{0, 0, -1}, {-1, 0, 0}},
+	{{0, 0, -1}, {1, 0, 0}},
+}
+
+// Exits returns the offsets of the two blocks that a rail with this shape
+// connects, relative to the rail. The Y offset of an exit is -1 for the lower
+// end of an ascending rail.
+func (s railShape) Exits() [2]cube.Pos {
+	return railExits[s]
+}
+
+// Ascending checks if the rail ascends towards one of its ends.
+func (s railShape) Ascending() bool {
+	return s >= 2 && s <= 5
+}
+
+// Curved checks if the rail connects two directions that are not opposite.
+func (s railShape) Curved() bool {
+	return s >= 6
+}
+
+// connects checks if the shape has an exit in the direction passed.
+func (s railShape) connects(d cube.Direction) bool {
+	offset := cube.Pos{}.Side(d.Face())
+	for _, exit := range s.Exits() {
+		if exit[0] == offset[0] && exit[2] == offset[2] {
+			return true
+		}
+	}
+	return false
+}
+
+// Uint8 returns the shape as a uint8.
+func (s railShape) Uint8() uint8 {
+	return uint8(s)
+}
+
+// String ...
+func (s railShape) String() string {
+	switch s {
+	case 0:
+		return "north_south"
+	case 1:
+		return "east_west"
+	case 2:
+		return "ascending_east"
+	case 3:
+		return "ascending_west"
+	case 4:
+		return "ascending_north"
+	case 5:
+		return "ascending_south"
+	case 6:
+		return "south_east"
+	case 7:
+		return "south_west"
+	case 8:
+		return "north_west"
+	case 9:
+		return "north_east"
+	}
+	panic("unknown rail shape")
+}
@@ -0,0 +1,55 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// redstoneEmitter is a block that emits a redstone signal.
+type redstoneEmitter interface {
+	// redstonePower returns the strength of the redstone signal, ranging from
+	// 0 to 15, that the block emits out of the face passed.
+	redstonePower(face cube.Face) int
+}
+
+// receivesRedstonePower checks if any of the blocks directly next to the
+// position passed emits a redstone signal towards it.
+func receivesRedstonePower(pos cube.Pos, tx *world.Tx) bool {
+	for _, face := range cube.Faces() {
+		if e, ok := tx.Block(pos.Side(face)).(redstoneEmitter); ok && e.redstonePower(face.Opposite()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// redstonePower ...
+func (o Observer) redstonePower(face cube.Face) int {
+	if o.Powered && face == o.Facing.Opposite() {
+		return 15
+	}
+	return 0
+}
+
+// redstonePower ...
+func (t Target) redstonePower(cube.Face) int {
+	return t.Power
+}
+
+// redstonePower ...
+func (l LightningRod) redstonePower(cube.Face) int {
+	if l.Powered {
+		return 15
+	}
+	return 0
+}
+
+// redstonePower ...
+func (s SculkSensor) redstonePower(cube.Face) int {
+	return s.Power
+}
+
+// redstonePower ...
+func (d DaylightDetector) redstonePower(cube.Face) int {
+	return d.Power
+}
@@ -22,10 +22,13 @@ func init() {
 	world.RegisterBlock(Bookshelf{})
 	world.RegisterBlock(Bricks{})
 	world.RegisterBlock(Calcite{})
+	world.RegisterBlock(CartographyTable{})
+	world.RegisterBlock(ChorusPlant{})
 	world.RegisterBlock(Clay{})
 	world.RegisterBlock(Coal{})
 	world.RegisterBlock(Cobblestone{Mossy: true})
 	world.RegisterBlock(Cobblestone{})
+	world.RegisterBlock(Cobweb{})
 	world.RegisterBlock(CraftingTable{})
 	world.RegisterBlock(DeadBush{})
 	world.RegisterBlock(DeepslateBricks{Cracked: true})
@@ -44,8 +47,14 @@ func init() {
 	world.RegisterBlock(Emerald{})
 	world.RegisterBlock(EnchantingTable{})
 	world.RegisterBlock(EndBricks{})
+	world.RegisterBlock(EndGateway{})
+	world.RegisterBlock(EndPortal{})
 	world.RegisterBlock(EndStone{})
 	world.RegisterBlock(FletchingTable{})
+	world.RegisterBlock(FlowerPot{})
+	world.RegisterBlock(Frogspawn{})
+	world.RegisterBlock(Fungus{Warped: true})
+	world.RegisterBlock(Fungus{})
 	world.RegisterBlock(GlassPane{})
 	world.RegisterBlock(Glass{})
 	world.RegisterBlock(Glowstone{})
@@ -54,6 +63,7 @@ func init() {
 	world.RegisterBlock(Granite{})
 	world.RegisterBlock(Grass{})
 	world.RegisterBlock(Gravel{})
+	world.RegisterBlock(Honey{})
 	world.RegisterBlock(Honeycomb{})
 	world.RegisterBlock(InvisibleBedrock{})
 	world.RegisterBlock(IronBars{})
@@ -61,6 +71,7 @@ func init() {
 	world.RegisterBlock(Jukebox{})
 	world.RegisterBlock(Lapis{})
 	world.RegisterBlock(LilyPad{})
+	world.RegisterBlock(Magma{})
 	world.RegisterBlock(Melon{})
 	world.RegisterBlock(MossCarpet{})
 	world.RegisterBlock(MudBricks{})
@@ -74,6 +85,8 @@ func init() {
 	world.RegisterBlock(Netherite{})
 	world.RegisterBlock(Netherrack{})
 	world.RegisterBlock(Note{})
+	world.RegisterBlock(Nylium{Warped: true})
+	world.RegisterBlock(Nylium{})
 	world.RegisterBlock(Obsidian{Crying: true})
 	world.RegisterBlock(Obsidian{})
 	world.RegisterBlock(PackedIce{})
@@ -89,12 +102,15 @@ func init() {
 	world.RegisterBlock(ResinBricks{Chiseled: true})
 	world.RegisterBlock(ResinBricks{})
 	world.RegisterBlock(Resin{})
+	world.RegisterBlock(Roots{Warped: true})
+	world.RegisterBlock(Roots{})
 	world.RegisterBlock(Sand{Red: true})
 	world.RegisterBlock(Sand{})
 	world.RegisterBlock(SeaLantern{})
 	world.RegisterBlock(Shroomlight{})
 	world.RegisterBlock(SmithingTable{})
 	world.RegisterBlock(Snow{})
+	world.RegisterBlock(Slime{})
 	world.RegisterBlock(SoulSand{})
 	world.RegisterBlock(SoulSoil{})
 	world.RegisterBlock(Sponge{Wet: true})
@@ -103,6 +119,7 @@ func init() {
 	world.RegisterBlock(Stone{Smooth: true})
 	world.RegisterBlock(Stone{})
 	world.RegisterBlock(TNT{})
+	world.RegisterBlock(Target{})
 	world.RegisterBlock(Terracotta{})
 	world.RegisterBlock(Tuff{})
 	world.RegisterBlock(Tuff{Chiseled: true})
@@ -122,11 +139,14 @@ func init() {
 		world.RegisterBlock(LapisOre{Type: ore})
 	}
 
+	registerAll(allActivatorRails())
 	registerAll(allAnvils())
 	registerAll(allBanners())
 	registerAll(allBarrels())
 	registerAll(allBasalt())
+	registerAll(allBeehives())
 	registerAll(allBeetroot())
+	registerAll(allBells())
 	registerAll(allBlackstone())
 	registerAll(allBlastFurnaces())
 	registerAll(allBoneBlock())
@@ -138,6 +158,7 @@ func init() {
 	registerAll(allCarrots())
 	registerAll(allChains())
 	registerAll(allChests())
+	registerAll(allChorusFlowers())
 	registerAll(allCocoaBeans())
 	registerAll(allComposters())
 	registerAll(allConcrete())
@@ -145,6 +166,7 @@ func init() {
 	registerAll(allCoral())
 	registerAll(allCoralBlocks())
 	registerAll(allDeepslate())
+	registerAll(allDetectorRails())
 	registerAll(allDoors())
 	registerAll(allDoubleFlowers())
 	registerAll(allDoubleTallGrass())
@@ -156,6 +178,7 @@ func init() {
 	registerAll(allFire())
 	registerAll(allFlowers())
 	registerAll(allFroglight())
+	registerAll(allFrostedIce())
 	registerAll(allFurnaces())
 	registerAll(allGlazedTerracotta())
 	registerAll(allGrindstones())
@@ -169,6 +192,7 @@ func init() {
 	registerAll(allLeaves())
 	registerAll(allLecterns())
 	registerAll(allLight())
+	registerAll(allLightningRods())
 	registerAll(allLitPumpkins())
 	registerAll(allLogs())
 	registerAll(allLooms())
@@ -176,16 +200,23 @@ func init() {
 	registerAll(allMuddyMangroveRoots())
 	registerAll(allNetherBricks())
 	registerAll(allNetherWart())
+	registerAll(allObservers())
 	registerAll(allPinkPetals())
 	registerAll(allPlanks())
 	registerAll(allPotato())
 	registerAll(allPrismarine())
 	registerAll(allPumpkinStems())
 	registerAll(allPumpkins())
+	registerAll(allPoweredRails())
 	registerAll(allPurpurs())
+	registerAll(allRails())
 	registerAll(allQuartz())
+	registerAll(allRespawnAnchors())
 	registerAll(allSandstones())
+	registerAll(allSculkSensors())
+	registerAll(allSculkShriekers())
 	registerAll(allSeaPickles())
+	registerAll(allShulkerBoxes())
 	registerAll(allSigns())
 	registerAll(allSkulls())
 	registerAll(allSlabs())
@@ -196,16 +227,20 @@ func init() {
 	registerAll(allStairs())
 	registerAll(allStoneBricks())
 	registerAll(allStonecutters())
+	registerAll(allStructureBlocks())
 	registerAll(allSugarCane())
 	registerAll(allTorches())
 	registerAll(allTrapdoors())
+	registerAll(allTwistingVines())
 	registerAll(allVines())
 	registerAll(allWalls())
 	registerAll(allWater())
+	registerAll(allWeepingVines())
 	registerAll(allWheat())
 	registerAll(allWood())
 	registerAll(allWool())
 	registerAll(allDecoratedPots())
+	registerAll(allDaylightDetectors())
 	registerAll(allCopper())
 	registerAll(allCopperDoors())
 	registerAll(allCopperGrates())
@@ -213,6 +248,7 @@ func init() {
 }
 
 func init() {
+	world.RegisterItem(ActivatorRail{})
 	world.RegisterItem(Air{})
 	world.RegisterItem(Amethyst{})
 	world.RegisterItem(AncientDebris{})
@@ -224,7 +260,10 @@ func init() {
 	world.RegisterItem(Basalt{})
 	world.RegisterItem(Beacon{})
 	world.RegisterItem(Bedrock{})
+	world.RegisterItem(BeeNest{})
+	world.RegisterItem(Beehive{})
 	world.RegisterItem(BeetrootSeeds{})
+	world.RegisterItem(Bell{})
 	world.RegisterItem(BlastFurnace{})
 	world.RegisterItem(BlueIce{})
 	world.RegisterItem(Bone{})
@@ -235,13 +274,17 @@ func init() {
 	world.RegisterItem(Cake{})
 	world.RegisterItem(Calcite{})
 	world.RegisterItem(Carrot{})
+	world.RegisterItem(CartographyTable{})
 	world.RegisterItem(Chain{})
 	world.RegisterItem(Chest{})
 	world.RegisterItem(ChiseledQuartz{})
+	world.RegisterItem(ChorusFlower{})
+	world.RegisterItem(ChorusPlant{})
 	world.RegisterItem(Clay{})
 	world.RegisterItem(Coal{})
 	world.RegisterItem(Cobblestone{Mossy: true})
 	world.RegisterItem(Cobblestone{})
+	world.RegisterItem(Cobweb{})
 	world.RegisterItem(CocoaBean{})
 	world.RegisterItem(Composter{})
 	world.RegisterItem(CraftingTable{})
@@ -250,6 +293,7 @@ func init() {
 	world.RegisterItem(DeepslateBricks{})
 	world.RegisterItem(DeepslateTiles{Cracked: true})
 	world.RegisterItem(DeepslateTiles{})
+	world.RegisterItem(DetectorRail{})
 	world.RegisterItem(Diamond{})
 	world.RegisterItem(Diorite{Polished: true})
 	world.RegisterItem(Diorite{})
@@ -267,6 +311,10 @@ func init() {
 	world.RegisterItem(EnderChest{})
 	world.RegisterItem(Farmland{})
 	world.RegisterItem(FletchingTable{})
+	world.RegisterItem(FlowerPot{})
+	world.RegisterItem(Frogspawn{})
+	world.RegisterItem(Fungus{Warped: true})
+	world.RegisterItem(Fungus{})
 	world.RegisterItem(Furnace{})
 	world.RegisterItem(GlassPane{})
 	world.RegisterItem(Glass{})
@@ -278,6 +326,7 @@ func init() {
 	world.RegisterItem(Gravel{})
 	world.RegisterItem(Grindstone{})
 	world.RegisterItem(HayBale{})
+	world.RegisterItem(Honey{})
 	world.RegisterItem(Honeycomb{})
 	world.RegisterItem(Hopper{})
 	world.RegisterItem(InvisibleBedrock{})
@@ -290,9 +339,11 @@ func init() {
 	world.RegisterItem(Ladder{})
 	world.RegisterItem(Lapis{})
 	world.RegisterItem(Lectern{})
+	world.RegisterItem(LightningRod{})
 	world.RegisterItem(LilyPad{})
 	world.RegisterItem(LitPumpkin{})
 	world.RegisterItem(Loom{})
+	world.RegisterItem(Magma{})
 	world.RegisterItem(MelonSeeds{})
 	world.RegisterItem(Melon{})
 	world.RegisterItem(MossCarpet{})
@@ -309,6 +360,9 @@ func init() {
 	world.RegisterItem(Netherite{})
 	world.RegisterItem(Netherrack{})
 	world.RegisterItem(Note{Pitch: 24})
+	world.RegisterItem(Nylium{Warped: true})
+	world.RegisterItem(Nylium{})
+	world.RegisterItem(Observer{})
 	world.RegisterItem(Obsidian{Crying: true})
 	world.RegisterItem(Obsidian{})
 	world.RegisterItem(PackedIce{})
@@ -318,6 +372,7 @@ func init() {
 	world.RegisterItem(PolishedBlackstoneBrick{Cracked: true})
 	world.RegisterItem(PolishedBlackstoneBrick{})
 	world.RegisterItem(Potato{})
+	world.RegisterItem(PoweredRail{})
 	world.RegisterItem(PumpkinSeeds{})
 	world.RegisterItem(Pumpkin{Carved: true})
 	world.RegisterItem(Pumpkin{})
@@ -327,6 +382,7 @@ func init() {
 	world.RegisterItem(QuartzPillar{})
 	world.RegisterItem(Quartz{Smooth: true})
 	world.RegisterItem(Quartz{})
+	world.RegisterItem(Rail{})
 	world.RegisterItem(RawCopper{})
 	world.RegisterItem(RawGold{})
 	world.RegisterItem(RawIron{})
@@ -334,14 +390,20 @@ func init() {
 	world.RegisterItem(ResinBricks{Chiseled: true})
 	world.RegisterItem(ResinBricks{})
 	world.RegisterItem(Resin{})
+	world.RegisterItem(RespawnAnchor{})
+	world.RegisterItem(Roots{Warped: true})
+	world.RegisterItem(Roots{})
 	world.RegisterItem(Sand{Red: true})
 	world.RegisterItem(Sand{})
+	world.RegisterItem(SculkSensor{})
+	world.RegisterItem(SculkShrieker{})
 	world.RegisterItem(SeaLantern{})
 	world.RegisterItem(SeaPickle{})
 	world.RegisterItem(Shroomlight{})
 	world.RegisterItem(SmithingTable{})
 	world.RegisterItem(Smoker{})
 	world.RegisterItem(Snow{})
+	world.RegisterItem(Slime{})
 	world.RegisterItem(SoulSand{})
 	world.RegisterItem(SoulSoil{})
 	world.RegisterItem(Sponge{Wet: true})
@@ -350,23 +412,37 @@ func init() {
 	world.RegisterItem(Stonecutter{})
 	world.RegisterItem(Stone{Smooth: true})
 	world.RegisterItem(Stone{})
+	world.RegisterItem(StructureBlock{})
 	world.RegisterItem(SugarCane{})
 	world.RegisterItem(TNT{})
+	world.RegisterItem(Target{})
 	world.RegisterItem(Terracotta{})
 	world.RegisterItem(Tuff{})
 	world.RegisterItem(Tuff{Chiseled: true})
 	world.RegisterItem(TuffBricks{})
 	world.RegisterItem(TuffBricks{Chiseled: true})
+	world.RegisterItem(TwistingVines{})
 	world.RegisterItem(PolishedTuff{})
 	world.RegisterItem(Vines{})
+	world.RegisterItem(WeepingVines{})
 	world.RegisterItem(WheatSeeds{})
 	world.RegisterItem(DecoratedPot{})
+	world.RegisterItem(DaylightDetector{})
 	world.RegisterItem(ShortGrass{})
 	world.RegisterItem(Fern{})
 	world.RegisterItem(item.Bucket{Content: item.LiquidBucketContent(Lava{})})
 	world.RegisterItem(item.Bucket{Content: item.LiquidBucketContent(Water{})})
 	world.RegisterItem(item.Bucket{Content: item.MilkBucketContent()})
+	world.RegisterItem(item.Bucket{Content: item.EntityBucketContent(Water{}, "axolotl")})
+	world.RegisterItem(item.Bucket{Content: item.EntityBucketContent(Water{}, "tropical_fish")})
+	world.RegisterItem(item.Bucket{Content: item.EntityBucketContent(Water{}, "cod")})
+	world.RegisterItem(item.Bucket{Content: item.EntityBucketContent(Water{}, "salmon")})
+	world.RegisterItem(item.Bucket{Content: item.EntityBucketContent(Water{}, "pufferfish")})
+	world.RegisterItem(item.Bucket{Content: item.EntityBucketContent(Water{}, "tadpole")})
 
+	for _, b := range allBubbleColumns() {
+		world.RegisterBlock(b)
+	}
 	for _, b := range allLight() {
 		world.RegisterItem(b.(world.Item))
 	}
@@ -405,6 +481,7 @@ func init() {
 		world.RegisterItem(Log{Wood: w})
 		world.RegisterItem(Planks{Wood: w})
 		world.RegisterItem(Sign{Wood: w})
+		world.RegisterItem(Sign{Wood: w, Hanging: true})
 		world.RegisterItem(WoodDoor{Wood: w})
 		world.RegisterItem(WoodFenceGate{Wood: w})
 		world.RegisterItem(WoodFence{Wood: w})
@@ -444,6 +521,9 @@ func init() {
 	for _, t := range FroglightTypes() {
 		world.RegisterItem(Froglight{Type: t})
 	}
+	for _, t := range ShulkerBoxTypes() {
+		world.RegisterItem(ShulkerBox{Type: t})
+	}
 	for _, s := range SkullTypes() {
 		world.RegisterItem(Skull{Type: s})
 	}
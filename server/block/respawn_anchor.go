@@ -0,0 +1,154 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+)
+
+// RespawnAnchor is a block that allows players to set their spawn point in
+// the Nether. It is charged using glowstone and uses up one charge every time
+// a player respawns at it. Using a charged respawn anchor outside the Nether
+// makes it explode.
+type RespawnAnchor struct {
+	solid
+	bassDrum
+
+	// Charge is the amount of charges left in the respawn anchor, ranging from
+	// 0 to 4. Every charge allows a player to respawn at the anchor once.
+	Charge int
+}
+
+// LightEmissionLevel ...
+func (r RespawnAnchor) LightEmissionLevel() uint8 {
+	if r.Charge == 0 {
+		return 0
+	}
+	return uint8(r.Charge*4 - 1)
+}
+
+// Activate charges the respawn anchor if the user is holding glowstone. If
+// the respawn anchor is already charged, the spawn point of the user is set
+// to the anchor in the Nether, while the anchor explodes in other dimensions.
+func (r RespawnAnchor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, ctx *item.UseContext) bool {
+	held, _ := u.HeldItems()
+	if _, ok := held.Item().(Glowstone); ok && r.Charge < 4 {
+		r.Charge++
+		tx.SetBlock(pos, r, nil)
+		tx.PlaySound(pos.Vec3Centre(), sound.RespawnAnchorCharge{})
+		ctx.SubtractFromCount(1)
+		return true
+	}
+	if r.Charge == 0 {
+		return false
+	}
+	if tx.World().Dimension() != world.Nether {
+		tx.SetBlock(pos, nil, nil)
+		ExplosionConfig{Size: 5, SpawnFire: true}.Explode(tx, pos.Vec3Centre())
+		return true
+	}
+	s, ok := u.(spawnSetter)
+	if !ok {
+		return false
+	}
+	w := tx.World()
+	if w.PlayerSpawnDimension(s.UUID()) == world.Nether && w.PlayerSpawn(s.UUID()) == pos {
+		// The spawn point of the user was already set to this anchor.
+		return false
+	}
+	w.SetPlayerSpawn(s.UUID(), pos)
+	tx.PlaySound(pos.Vec3Centre(), sound.RespawnAnchorSetSpawn{})
+	s.Messaget(chat.MessageRespawnPointSet)
+	return true
+}
+
+// Respawn uses up one charge of the respawn anchor at the position passed to
+// respawn a player at it. The position that the player should respawn at is
+// returned. If the anchor has no charges left, or if there is no space for a
+// player to stand around it, no charge is used up and false is returned.
+func (r RespawnAnchor) Respawn(pos cube.Pos, tx *world.Tx) (mgl64.Vec3, bool) {
+	if r.Charge == 0 {
+		return mgl64.Vec3{}, false
+	}
+	spawn, ok := respawnAnchorSpawn(pos, tx)
+	if !ok {
+		return mgl64.Vec3{}, false
+	}
+	r.Charge--
+	tx.SetBlock(pos, r, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.RespawnAnchorDeplete{})
+	return spawn.Vec3Middle(), true
+}
+
+// respawnAnchorSpawn finds a position around a respawn anchor that a player
+// can safely stand at. Positions next to the anchor are preferred over the
+// position above it.
+func respawnAnchorSpawn(pos cube.Pos, tx *world.Tx) (cube.Pos, bool) {
+	for _, dy := range []int{0, -1, 1} {
+		for dx := -1; dx <= 1; dx++ {
+			for dz := -1; dz <= 1; dz++ {
+				if dx == 0 && dz == 0 {
+					continue
+				}
+				if p := pos.Add(cube.Pos{dx, dy, dz}); respawnSafe(p, tx) {
+					return p, true
+				}
+			}
+		}
+	}
+	if p := pos.Side(cube.FaceUp); respawnSafe(p, tx) {
+		return p, true
+	}
+	return cube.Pos{}, false
+}
+
+// respawnSafe checks if a player can stand at the position passed without
+// suffocating or drowning.
+func respawnSafe(pos cube.Pos, tx *world.Tx) bool {
+	if pos.OutOfBounds(tx.Range()) || pos.Side(cube.FaceUp).OutOfBounds(tx.Range()) {
+		return false
+	}
+	for _, p := range []cube.Pos{pos, pos.Side(cube.FaceUp)} {
+		if _, ok := tx.Liquid(p); ok || len(tx.Block(p).Model().BBox(p, tx)) > 0 {
+			return false
+		}
+	}
+	below := pos.Side(cube.FaceDown)
+	return len(tx.Block(below).Model().BBox(below, tx)) > 0
+}
+
+// spawnSetter represents a user of a respawn anchor that is able to set its
+// spawn point at it, such as a player.
+type spawnSetter interface {
+	UUID() uuid.UUID
+	Messaget(t chat.Translation, a ...any)
+}
+
+// BreakInfo ...
+func (r RespawnAnchor) BreakInfo() BreakInfo {
+	return newBreakInfo(50, func(t item.Tool) bool {
+		return t.ToolType() == item.TypePickaxe && t.HarvestLevel() >= item.ToolTierDiamond.HarvestLevel
+	}, pickaxeEffective, oneOf(RespawnAnchor{})).withBlastResistance(6000)
+}
+
+// EncodeItem ...
+func (RespawnAnchor) EncodeItem() (name string, meta int16) {
+	return "minecraft:respawn_anchor", 0
+}
+
+// EncodeBlock ...
+func (r RespawnAnchor) EncodeBlock() (string, map[string]any) {
+	return "minecraft:respawn_anchor", map[string]any{"respawn_anchor_charge": int32(r.Charge)}
+}
+
+// allRespawnAnchors returns respawn anchors with all possible charges.
+func allRespawnAnchors() (anchors []world.Block) {
+	for i := 0; i <= 4; i++ {
+		anchors = append(anchors, RespawnAnchor{Charge: i})
+	}
+	return
+}
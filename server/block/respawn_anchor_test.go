@@ -0,0 +1,100 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+// spawnProvider is a world.SpawnDimensionProvider that keeps player spawn
+// points in memory.
+type spawnProvider struct {
+	world.NopProvider
+	pos map[uuid.UUID]cube.Pos
+	dim map[uuid.UUID]world.Dimension
+}
+
+func (p spawnProvider) LoadPlayerSpawn(id uuid.UUID) (cube.Pos, world.Dimension, bool, error) {
+	pos, ok := p.pos[id]
+	return pos, p.dim[id], ok, nil
+}
+
+func (p spawnProvider) SavePlayerSpawn(id uuid.UUID, pos cube.Pos, dim world.Dimension) error {
+	p.pos[id], p.dim[id] = pos, dim
+	return nil
+}
+
+// anchorUser is a testUser that is able to set its spawn point at a respawn
+// anchor.
+type anchorUser struct {
+	testUser
+	id       uuid.UUID
+	messages *[]chat.Translation
+}
+
+func (u anchorUser) UUID() uuid.UUID { return u.id }
+func (u anchorUser) Messaget(t chat.Translation, _ ...any) {
+	*u.messages = append(*u.messages, t)
+}
+
+func TestRespawnAnchorChargeAndRespawn(t *testing.T) {
+	p := spawnProvider{pos: map[uuid.UUID]cube.Pos{}, dim: map[uuid.UUID]world.Dimension{}}
+	w := world.Config{Dim: world.Nether, Provider: p}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		pos := cube.Pos{0, 64, 0}
+		for x := -1; x <= 1; x++ {
+			for z := -1; z <= 1; z++ {
+				tx.SetBlock(pos.Add(cube.Pos{x, -1, z}), Netherrack{}, nil)
+			}
+		}
+		tx.SetBlock(pos, RespawnAnchor{}, nil)
+
+		var messages []chat.Translation
+		u := anchorUser{testUser: testUser{pos: pos.Vec3Centre()}, id: uuid.New(), messages: &messages}
+		activate := func(held world.Item) bool {
+			if held != nil {
+				u.held = item.NewStack(held, 1)
+			} else {
+				u.held = item.Stack{}
+			}
+			return tx.Block(pos).(RespawnAnchor).Activate(pos, cube.FaceUp, tx, u, &item.UseContext{})
+		}
+
+		if activate(nil) {
+			t.Errorf("expected uncharged respawn anchor not to set the spawn point")
+		}
+		activate(Glowstone{})
+		activate(Glowstone{})
+		if r := tx.Block(pos).(RespawnAnchor); r.Charge != 2 {
+			t.Errorf("expected respawn anchor charged with 2 glowstone to have 2 charges, got %v", r.Charge)
+			return
+		}
+		if !activate(nil) || w.PlayerSpawnDimension(u.id) != world.Nether || w.PlayerSpawn(u.id) != pos || len(messages) != 1 {
+			t.Errorf("expected charged respawn anchor to set the spawn point of the user")
+			return
+		}
+		if activate(nil) {
+			t.Errorf("expected respawn anchor not to set the spawn point again")
+		}
+
+		for charge := 1; charge >= 0; charge-- {
+			spawn, ok := tx.Block(pos).(RespawnAnchor).Respawn(pos, tx)
+			if r := tx.Block(pos).(RespawnAnchor); !ok || r.Charge != charge {
+				t.Errorf("expected respawning to use up a charge, %v charges left", r.Charge)
+				return
+			}
+			if d := spawn.Sub(pos.Vec3Middle()); d[1] != 0 || d.Len() > 1.5 {
+				t.Errorf("expected player to respawn next to the respawn anchor, respawned at %v", spawn)
+			}
+		}
+		if _, ok := tx.Block(pos).(RespawnAnchor).Respawn(pos, tx); ok {
+			t.Errorf("expected respawn anchor without charges not to respawn the player")
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// SculkSensor is a block that listens for vibrations created by game events
+// close to it. When it perceives a vibration, it becomes active for a short
+// time and emits a redstone signal.
+type SculkSensor struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Phase is the current phase of the sculk sensor. Only inactive sculk
+	// sensors perceive vibrations.
+	Phase SculkSensorPhase
+	// LastFrequency is the frequency of the last vibration perceived by the
+	// sculk sensor, ranging from 1 to 15. It is 0 if the sculk sensor has never
+	// perceived a vibration.
+	LastFrequency int
+	// Power is the strength of the redstone signal emitted by the sculk sensor
+	// while it is active. Vibrations that originate closer to the sculk sensor
+	// result in a stronger signal.
+	Power int
+}
+
+// sculkSensorRange is the range in blocks within which a sculk sensor
+// perceives vibrations.
+const sculkSensorRange = 8
+
+// GameEventRange ...
+func (SculkSensor) GameEventRange() float64 {
+	return sculkSensorRange
+}
+
+// ReceiveGameEvent activates the sculk sensor if it is currently inactive.
+// Sculk shriekers and wardens close to the sculk sensor are notified of the
+// vibration, along with the entity that caused it.
+func (s SculkSensor) ReceiveGameEvent(pos cube.Pos, ev world.GameEvent, evPos mgl64.Vec3, src world.Entity, tx *world.Tx) {
+	if s.Phase != SculkSensorInactive() || ev.Frequency() == 0 || cube.PosFromVec3(evPos) == pos {
+		return
+	}
+	dist := evPos.Sub(pos.Vec3Centre()).Len()
+	s.Phase, s.LastFrequency = SculkSensorActive(), ev.Frequency()
+	s.Power = max(1, 15-int(math.Floor(15*dist/sculkSensorRange)))
+
+	tx.SetBlock(pos, s, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.SculkSensorPowerOn{})
+	tx.ScheduleBlockUpdate(pos, s, time.Second*3/2)
+	tx.EmitGameEvent(pos.Vec3Centre(), gameevent.SculkSensorTendrilsClicking{}, src)
+}
+
+// ScheduledTick moves the sculk sensor into its next phase.
+func (s SculkSensor) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	switch s.Phase {
+	case SculkSensorActive():
+		s.Phase, s.Power = SculkSensorCooldown(), 0
+		tx.SetBlock(pos, s, nil)
+		tx.PlaySound(pos.Vec3Centre(), sound.SculkSensorPowerOff{})
+		tx.ScheduleBlockUpdate(pos, s, time.Second/2)
+	case SculkSensorCooldown():
+		s.Phase = SculkSensorInactive()
+		tx.SetBlock(pos, s, nil)
+	}
+}
+
+// Model ...
+func (SculkSensor) Model() world.BlockModel {
+	return model.Slab{}
+}
+
+// LightEmissionLevel ...
+func (SculkSensor) LightEmissionLevel() uint8 {
+	return 1
+}
+
+// BreakInfo ...
+func (s SculkSensor) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, hoeEffective, silkTouchOnlyDrop(SculkSensor{})).withXPDropRange(5, 5)
+}
+
+// DecodeNBT ...
+func (s SculkSensor) DecodeNBT(data map[string]any) any {
+	s.LastFrequency = int(nbtconv.Int32(data, "LastVibrationFrequency"))
+	s.Power = int(nbtconv.Int32(data, "Power"))
+	return s
+}
+
+// EncodeNBT ...
+func (s SculkSensor) EncodeNBT() map[string]any {
+	return map[string]any{
+		"id":                     "SculkSensor",
+		"LastVibrationFrequency": int32(s.LastFrequency),
+		"Power":                  int32(s.Power),
+	}
+}
+
+// EncodeItem ...
+func (SculkSensor) EncodeItem() (name string, meta int16) {
+	return "minecraft:sculk_sensor", 0
+}
+
+// EncodeBlock ...
+func (s SculkSensor) EncodeBlock() (string, map[string]any) {
+	return "minecraft:sculk_sensor", map[string]any{"sculk_sensor_phase": int32(s.Phase.Uint8())}
+}
+
+// allSculkSensors ...
+func allSculkSensors() (all []world.Block) {
+	for _, p := range SculkSensorPhases() {
+		all = append(all, SculkSensor{Phase: p})
+	}
+	return
+}
@@ -0,0 +1,49 @@
+package block
+
+// SculkSensorPhase represents the phase that a sculk sensor is in.
+type SculkSensorPhase struct {
+	sculkSensorPhase
+}
+
+type sculkSensorPhase uint8
+
+// SculkSensorInactive is the phase of a sculk sensor that is listening for
+// vibrations.
+func SculkSensorInactive() SculkSensorPhase {
+	return SculkSensorPhase{0}
+}
+
+// SculkSensorActive is the phase of a sculk sensor that recently perceived a
+// vibration and emits a redstone signal.
+func SculkSensorActive() SculkSensorPhase {
+	return SculkSensorPhase{1}
+}
+
+// SculkSensorCooldown is the phase of a sculk sensor that recently stopped
+// being active and does not yet listen for vibrations again.
+func SculkSensorCooldown() SculkSensorPhase {
+	return SculkSensorPhase{2}
+}
+
+// Uint8 ...
+func (s sculkSensorPhase) Uint8() uint8 {
+	return uint8(s)
+}
+
+// String ...
+func (s sculkSensorPhase) String() string {
+	switch s {
+	case 0:
+		return "inactive"
+	case 1:
+		return "active"
+	case 2:
+		return "cooldown"
+	}
+	panic("unknown sculk sensor phase")
+}
+
+// SculkSensorPhases ...
+func SculkSensorPhases() []SculkSensorPhase {
+	return []SculkSensorPhase{SculkSensorInactive(), SculkSensorActive(), SculkSensorCooldown()}
+}
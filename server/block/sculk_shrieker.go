@@ -0,0 +1,241 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// SculkShrieker is a block that shrieks when a player steps on it or when a
+// sculk sensor close to it perceives a vibration caused by a player. Sculk
+// shriekers that can summon wardens raise the warning level of the players
+// around them every time they shriek, and summon a warden once the warning
+// level of a player reaches its maximum.
+type SculkShrieker struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Active specifies if the sculk shrieker is currently shrieking.
+	Active bool
+	// CanSummon specifies if the sculk shrieker is able to summon wardens.
+	// Sculk shriekers placed by players cannot summon wardens.
+	CanSummon bool
+	// WarningLevel is the warning level of the players that triggered the
+	// current shriek, ranging from 0 to 4. A warden is summoned when the
+	// shriek ends with a warning level of 4.
+	WarningLevel int
+}
+
+// WardenWarnable represents an entity, typically a player, that has a warning
+// level that is raised every time it triggers a sculk shrieker.
+type WardenWarnable interface {
+	world.Entity
+	// WardenWarningLevel returns the warning level of the entity, ranging from
+	// 0 to 4.
+	WardenWarningLevel() int
+	// WardenWarnedRecently checks if the warning level of the entity was
+	// raised too recently for it to be raised again.
+	WardenWarnedRecently() bool
+	// IncreaseWardenWarningLevel increases the warning level of the entity by
+	// one.
+	IncreaseWardenWarningLevel()
+}
+
+// sculkShriekerRange is the range in blocks within which a sculk shrieker
+// perceives sculk sensors that are activated.
+const sculkShriekerRange = 8
+
+// GameEventRange ...
+func (SculkShrieker) GameEventRange() float64 {
+	return sculkShriekerRange
+}
+
+// ReceiveGameEvent makes the sculk shrieker shriek if a player stepped on it
+// or if a sculk sensor close to it perceived a vibration caused by a player.
+func (s SculkShrieker) ReceiveGameEvent(pos cube.Pos, ev world.GameEvent, evPos mgl64.Vec3, src world.Entity, tx *world.Tx) {
+	switch ev.(type) {
+	case gameevent.SculkSensorTendrilsClicking:
+	case gameevent.Step, gameevent.HitGround:
+		if cube.PosFromVec3(evPos) != pos {
+			return
+		}
+	default:
+		return
+	}
+	if p, ok := src.(WardenWarnable); ok {
+		s.shriek(pos, p, tx)
+	}
+}
+
+// shriek makes the sculk shrieker shriek as a result of the player passed. If
+// the sculk shrieker can summon wardens, the warning level of the player and
+// other players close to it is raised. The sculk shrieker does not shriek if
+// any of these players were warned recently or if a warden is already close.
+func (s SculkShrieker) shriek(pos cube.Pos, p WardenWarnable, tx *world.Tx) {
+	if s.Active {
+		return
+	}
+	if s.CanSummon {
+		level, ok := warnPlayers(pos, p, tx)
+		if !ok {
+			return
+		}
+		s.WarningLevel = level
+	}
+	s.Active = true
+	tx.SetBlock(pos, s, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.SculkShriekerShriek{})
+	tx.AddParticle(pos.Vec3Centre(), particle.SculkShriek{})
+	tx.EmitGameEvent(pos.Vec3Centre(), gameevent.Shriek{}, p)
+	tx.ScheduleBlockUpdate(pos, s, time.Second*9/2)
+}
+
+// warnPlayers raises the warning level of the player passed and all other
+// players within 16 blocks of the sculk shrieker. The highest warning level
+// among these players is returned. False is returned if no warning level was
+// raised.
+func warnPlayers(pos cube.Pos, p WardenWarnable, tx *world.Tx) (int, bool) {
+	centre := pos.Vec3Centre()
+	for e := range tx.EntitiesWithin(cube.Box(-48, -48, -48, 48, 48, 48).Translate(centre)) {
+		if e.H().Type().EncodeEntity() == "minecraft:warden" {
+			return 0, false
+		}
+	}
+	players := []WardenWarnable{p}
+	for e := range tx.EntitiesWithin(cube.Box(-16, -16, -16, 16, 16, 16).Translate(centre)) {
+		if w, ok := e.(WardenWarnable); ok && e.H() != p.H() {
+			players = append(players, w)
+		}
+	}
+	for _, w := range players {
+		if w.WardenWarnedRecently() {
+			return 0, false
+		}
+	}
+	level := 0
+	for _, w := range players {
+		w.IncreaseWardenWarningLevel()
+		level = max(level, w.WardenWarningLevel())
+	}
+	return level, true
+}
+
+// ScheduledTick ends the shriek of the sculk shrieker. Sculk shriekers that
+// can summon wardens inflict Darkness on the players around them and either
+// summon a warden or warn the players that a warden is approaching.
+func (s SculkShrieker) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if !s.Active {
+		return
+	}
+	if s.CanSummon && s.WarningLevel > 0 && tx.World().Difficulty() != world.DifficultyPeaceful {
+		s.respond(pos, tx)
+	}
+	s.Active, s.WarningLevel = false, 0
+	tx.SetBlock(pos, s, nil)
+}
+
+// respond summons a warden if the warning level of the shriek is at its
+// maximum, or plays a warning sound if it is not. Players within 40 blocks of
+// the sculk shrieker are inflicted with Darkness.
+func (s SculkShrieker) respond(pos cube.Pos, tx *world.Tx) {
+	centre := pos.Vec3Centre()
+	if s.WarningLevel < 4 || !summonWarden(pos, tx) {
+		tx.PlaySound(centre, sound.WardenNearby{Level: min(s.WarningLevel, 3)})
+	}
+	for e := range tx.EntitiesWithin(cube.Box(-40, -40, -40, 40, 40, 40).Translate(centre)) {
+		if l, ok := e.(interface{ AddEffect(e effect.Effect) }); ok {
+			if _, player := e.(WardenWarnable); player {
+				l.AddEffect(effect.New(effect.Darkness, 1, time.Second*12))
+			}
+		}
+	}
+}
+
+// summonWarden attempts to summon a warden close to the sculk shrieker at the
+// position passed. True is returned if a warden was summoned.
+func summonWarden(pos cube.Pos, tx *world.Tx) bool {
+	conf := tx.World().EntityRegistry().Config()
+	if conf.Warden == nil {
+		return false
+	}
+	for range 20 {
+		x, z := rand.IntN(11)-5, rand.IntN(11)-5
+		for y := 6; y >= -6; y-- {
+			spawnPos := pos.Add(cube.Pos{x, y, z})
+			if !wardenSpawnable(spawnPos, tx) {
+				continue
+			}
+			tx.AddEntity(conf.Warden(world.EntitySpawnOpts{Position: spawnPos.Vec3Middle()}))
+			return true
+		}
+	}
+	return false
+}
+
+// wardenSpawnable checks if a warden may be spawned at the position passed. It
+// needs a solid block to stand on that is not leaves, and three blocks of
+// space without any collision or liquid.
+func wardenSpawnable(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	if _, leaves := tx.Block(below).(Leaves); leaves || !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+		return false
+	}
+	for y := 0; y < 3; y++ {
+		p := pos.Add(cube.Pos{0, y, 0})
+		if _, liquid := tx.Liquid(p); liquid || len(tx.Block(p).Model().BBox(p, tx)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Model ...
+func (SculkShrieker) Model() world.BlockModel {
+	return model.Slab{}
+}
+
+// BreakInfo ...
+func (s SculkShrieker) BreakInfo() BreakInfo {
+	return newBreakInfo(3, alwaysHarvestable, hoeEffective, silkTouchOnlyDrop(SculkShrieker{})).withXPDropRange(5, 5)
+}
+
+// DecodeNBT ...
+func (s SculkShrieker) DecodeNBT(data map[string]any) any {
+	s.WarningLevel = int(nbtconv.Int32(data, "WarningLevel"))
+	return s
+}
+
+// EncodeNBT ...
+func (s SculkShrieker) EncodeNBT() map[string]any {
+	return map[string]any{
+		"id":           "SculkShrieker",
+		"WarningLevel": int32(s.WarningLevel),
+	}
+}
+
+// EncodeItem ...
+func (SculkShrieker) EncodeItem() (name string, meta int16) {
+	return "minecraft:sculk_shrieker", 0
+}
+
+// EncodeBlock ...
+func (s SculkShrieker) EncodeBlock() (string, map[string]any) {
+	return "minecraft:sculk_shrieker", map[string]any{"active": boolByte(s.Active), "can_summon": boolByte(s.CanSummon)}
+}
+
+// allSculkShriekers ...
+func allSculkShriekers() (all []world.Block) {
+	for _, active := range []bool{false, true} {
+		all = append(all, SculkShrieker{Active: active})
+		all = append(all, SculkShrieker{Active: active, CanSummon: true})
+	}
+	return
+}
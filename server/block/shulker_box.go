@@ -0,0 +1,261 @@
+package block
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"strings"
+	"sync"
+)
+
+// ShulkerBox is a container block that keeps its contents when it is broken,
+// which makes it possible to carry its items around as a single item. Shulker
+// boxes cannot be placed inside other shulker boxes.
+// The empty value of ShulkerBox is not valid. It must be created using
+// block.NewShulkerBox().
+type ShulkerBox struct {
+	solid
+	transparent
+	bass
+
+	// Type is the type of the shulker box, which determines its colour.
+	Type ShulkerBoxType
+	// Facing is the direction that the lid of the shulker box opens towards.
+	Facing cube.Face
+	// CustomName is the custom name of the shulker box. This name is
+	// displayed when the shulker box is opened, and may include colour codes.
+	CustomName string
+
+	inventory *inventory.Inventory
+	viewerMu  *sync.RWMutex
+	viewers   map[ContainerViewer]struct{}
+}
+
+// NewShulkerBox creates a new initialised shulker box. The inventory is
+// properly initialised.
+func NewShulkerBox() ShulkerBox {
+	s := ShulkerBox{
+		Facing:   cube.FaceUp,
+		viewerMu: new(sync.RWMutex),
+		viewers:  make(map[ContainerViewer]struct{}, 1),
+	}
+	s.inventory = inventory.New(27, func(slot int, _, after item.Stack) {
+		s.viewerMu.RLock()
+		defer s.viewerMu.RUnlock()
+		for viewer := range s.viewers {
+			viewer.ViewSlotChange(slot, after)
+		}
+	})
+	s.inventory.SlotValidatorFunc(func(it item.Stack, _ int) bool {
+		_, nested := it.Item().(ShulkerBox)
+		return !nested
+	})
+	return s
+}
+
+// Inventory returns the inventory of the shulker box. The size of the
+// inventory will be 27.
+func (s ShulkerBox) Inventory(*world.Tx, cube.Pos) *inventory.Inventory {
+	return s.inventory
+}
+
+// WithName returns the shulker box after applying a specific name to the
+// block.
+func (s ShulkerBox) WithName(a ...any) world.Item {
+	s.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	return s
+}
+
+// MaxCount always returns 1.
+func (ShulkerBox) MaxCount() int {
+	return 1
+}
+
+// open opens the shulker box, displaying the animation, playing a sound and
+// pushing entities out of the way of its lid.
+func (s ShulkerBox) open(tx *world.Tx, pos cube.Pos) {
+	for _, v := range tx.Viewers(pos.Vec3()) {
+		v.ViewBlockAction(pos, OpenAction{})
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.ShulkerBoxOpen{})
+	s.pushEntities(tx, pos)
+}
+
+// close closes the shulker box, displaying the animation and playing a sound.
+func (s ShulkerBox) close(tx *world.Tx, pos cube.Pos) {
+	for _, v := range tx.Viewers(pos.Vec3()) {
+		v.ViewBlockAction(pos, CloseAction{})
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.ShulkerBoxClose{})
+}
+
+// pushEntities moves entities that are in the way of the lid of an opening
+// shulker box out of the space that the lid occupies.
+func (s ShulkerBox) pushEntities(tx *world.Tx, pos cube.Pos) {
+	lid := cube.Box(0, 0, 0, 1, 1, 1).ExtendTowards(s.Facing, 0.5).Translate(pos.Vec3())
+	for e := range tx.EntitiesWithin(lid.Grow(2)) {
+		t, ok := e.(interface{ Teleport(pos mgl64.Vec3) })
+		if !ok {
+			continue
+		}
+		box := e.H().Type().BBox(e).Translate(e.Position())
+		if !box.IntersectsWith(lid) {
+			continue
+		}
+		var overlap float64
+		switch s.Facing {
+		case cube.FaceDown:
+			overlap = box.Max()[1] - lid.Min()[1]
+		case cube.FaceUp:
+			overlap = lid.Max()[1] - box.Min()[1]
+		case cube.FaceNorth:
+			overlap = box.Max()[2] - lid.Min()[2]
+		case cube.FaceSouth:
+			overlap = lid.Max()[2] - box.Min()[2]
+		case cube.FaceWest:
+			overlap = box.Max()[0] - lid.Min()[0]
+		case cube.FaceEast:
+			overlap = lid.Max()[0] - box.Min()[0]
+		}
+		t.Teleport(e.Position().Add(cube.Pos{}.Side(s.Facing).Vec3().Mul(overlap + 0.01)))
+	}
+}
+
+// AddViewer adds a viewer to the shulker box, so that it is updated whenever
+// the inventory of the shulker box is changed.
+func (s ShulkerBox) AddViewer(v ContainerViewer, tx *world.Tx, pos cube.Pos) {
+	s.viewerMu.Lock()
+	defer s.viewerMu.Unlock()
+	if len(s.viewers) == 0 {
+		s.open(tx, pos)
+	}
+	s.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the shulker box, so that slot updates in
+// the inventory are no longer sent to it.
+func (s ShulkerBox) RemoveViewer(v ContainerViewer, tx *world.Tx, pos cube.Pos) {
+	s.viewerMu.Lock()
+	defer s.viewerMu.Unlock()
+	if len(s.viewers) == 0 {
+		return
+	}
+	delete(s.viewers, v)
+	if len(s.viewers) == 0 {
+		s.close(tx, pos)
+	}
+}
+
+// Activate ...
+func (s ShulkerBox) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		s.viewerMu.RLock()
+		opened := len(s.viewers) > 0
+		s.viewerMu.RUnlock()
+
+		// The lid of a closed shulker box cannot open if a block is in the way.
+		front := pos.Side(s.Facing)
+		if opened || len(tx.Block(front).Model().BBox(front, tx)) == 0 {
+			opener.OpenBlockContainer(pos, tx)
+		}
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (s ShulkerBox) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) (used bool) {
+	pos, face, used = firstReplaceable(tx, pos, face, s)
+	if !used {
+		return
+	}
+	//noinspection GoAssignmentToReceiver
+	s = s.withItems(s.items())
+	s.Facing = face
+
+	place(tx, pos, s, user, ctx)
+	return placed(ctx)
+}
+
+// BreakInfo ...
+func (s ShulkerBox) BreakInfo() BreakInfo {
+	return newBreakInfo(2, alwaysHarvestable, pickaxeEffective, func(item.Tool, []item.Enchantment) []item.Stack {
+		// The shulker box dropped keeps the items of the shulker box, but
+		// must not share its inventory with the block that was broken.
+		return []item.Stack{item.NewStack(s.withItems(s.items()), 1)}
+	}).withBlastResistance(2)
+}
+
+// items returns the items in the inventory of the shulker box. Nil is
+// returned if the inventory of the shulker box was not initialised.
+func (s ShulkerBox) items() []item.Stack {
+	if s.inventory == nil {
+		return nil
+	}
+	return s.inventory.Slots()
+}
+
+// withItems returns a copy of the shulker box with a new inventory holding the
+// items passed.
+func (s ShulkerBox) withItems(items []item.Stack) ShulkerBox {
+	b := NewShulkerBox()
+	b.Type, b.Facing, b.CustomName = s.Type, s.Facing, s.CustomName
+	for slot, it := range items {
+		if !it.Empty() {
+			_ = b.inventory.SetItem(slot, it)
+		}
+	}
+	return b
+}
+
+// DecodeNBT ...
+func (s ShulkerBox) DecodeNBT(data map[string]any) any {
+	//noinspection GoAssignmentToReceiver
+	s = s.withItems(nil)
+	if _, ok := data["facing"]; ok {
+		s.Facing = cube.Face(nbtconv.Uint8(data, "facing"))
+	}
+	s.CustomName = nbtconv.String(data, "CustomName")
+	nbtconv.InvFromNBT(s.inventory, nbtconv.Slice(data, "Items"))
+	return s
+}
+
+// EncodeNBT ...
+func (s ShulkerBox) EncodeNBT() map[string]any {
+	if s.inventory == nil {
+		//noinspection GoAssignmentToReceiver
+		s = s.withItems(nil)
+	}
+	m := map[string]any{
+		"Items":  nbtconv.InvToNBT(s.inventory),
+		"id":     "ShulkerBox",
+		"facing": uint8(s.Facing),
+	}
+	if s.CustomName != "" {
+		m["CustomName"] = s.CustomName
+	}
+	return m
+}
+
+// EncodeBlock ...
+func (s ShulkerBox) EncodeBlock() (string, map[string]any) {
+	return "minecraft:" + s.Type.String() + "_shulker_box", nil
+}
+
+// EncodeItem ...
+func (s ShulkerBox) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + s.Type.String() + "_shulker_box", 0
+}
+
+// allShulkerBoxes ...
+func allShulkerBoxes() (b []world.Block) {
+	for _, t := range ShulkerBoxTypes() {
+		b = append(b, ShulkerBox{Type: t})
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+func TestShulkerBoxKeepsContents(t *testing.T) {
+	w := world.Config{}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		apples := item.NewStack(item.Apple{}, 16)
+		box := NewShulkerBox()
+		box.CustomName = "Food"
+		_ = box.inventory.SetItem(3, apples)
+		_ = box.inventory.SetItem(4, item.NewStack(NewShulkerBox(), 1))
+		if it, _ := box.inventory.Item(4); !it.Empty() {
+			t.Errorf("expected shulker box not to fit inside another shulker box")
+		}
+		pos := cube.Pos{0, 64, 0}
+		tx.SetBlock(pos, box, nil)
+
+		drops := box.BreakInfo().Drops(item.ToolNone{}, nil)
+		dropped := drops[0].Item().(ShulkerBox)
+		// The items in the shulker box dropped must not be changed if the
+		// shulker box that was broken is changed.
+		_ = box.inventory.SetItem(3, item.Stack{})
+		if it, _ := dropped.inventory.Item(3); !it.Equal(apples) || dropped.CustomName != "Food" {
+			t.Errorf("expected broken shulker box to drop with its apples and name, got %v with name %q", it, dropped.CustomName)
+			return
+		}
+
+		// The shulker box dropped keeps its contents when it is placed again.
+		tx.SetBlock(pos, Stone{}, nil)
+		dropped.UseOnBlock(pos, cube.FaceUp, mgl64.Vec3{}, tx, testUser{}, &item.UseContext{})
+		placed, ok := tx.Block(pos.Side(cube.FaceUp)).(ShulkerBox)
+		if !ok {
+			t.Errorf("expected shulker box to be placed")
+			return
+		}
+		// The shulker box is encoded and decoded the way it is when its chunk
+		// is saved and loaded again.
+		b, _ := nbt.Marshal(placed.EncodeNBT())
+		var data map[string]any
+		_ = nbt.Unmarshal(b, &data)
+		decoded := placed.DecodeNBT(data).(ShulkerBox)
+		if it, _ := decoded.inventory.Item(3); !it.Equal(apples) || decoded.CustomName != "Food" {
+			t.Errorf("expected placed shulker box to keep its apples and name, got %v with name %q", it, decoded.CustomName)
+		}
+	})
+}
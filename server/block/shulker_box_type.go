@@ -0,0 +1,52 @@
+package block
+
+import "github.com/df-mc/dragonfly/server/item"
+
+// ShulkerBoxType represents the colour of a shulker box. Shulker boxes are
+// either undyed or dyed in one of the 16 colours.
+type ShulkerBoxType struct {
+	shulkerBox
+}
+
+type shulkerBox uint8
+
+// NormalShulkerBox returns the undyed shulker box type.
+func NormalShulkerBox() ShulkerBoxType {
+	return ShulkerBoxType{0}
+}
+
+// DyedShulkerBox returns the shulker box type dyed in the colour passed.
+func DyedShulkerBox(c item.Colour) ShulkerBoxType {
+	return ShulkerBoxType{shulkerBox(c.Uint8() + 1)}
+}
+
+// Uint8 ...
+func (s shulkerBox) Uint8() uint8 {
+	return uint8(s)
+}
+
+// Colour returns the colour of the shulker box type. False is returned if the
+// shulker box is undyed.
+func (s shulkerBox) Colour() (item.Colour, bool) {
+	if s == 0 {
+		return item.Colour{}, false
+	}
+	return item.Colours()[s-1], true
+}
+
+// String ...
+func (s shulkerBox) String() string {
+	if c, ok := s.Colour(); ok {
+		return c.String()
+	}
+	return "undyed"
+}
+
+// ShulkerBoxTypes returns all shulker box types.
+func ShulkerBoxTypes() []ShulkerBoxType {
+	types := []ShulkerBoxType{NormalShulkerBox()}
+	for _, c := range item.Colours() {
+		types = append(types, DyedShulkerBox(c))
+	}
+	return types
+}
@@ -11,7 +11,8 @@ import (
 	"time"
 )
 
-// Sign is a non-solid block that can display text on the front and back of the block.
+// Sign is a non-solid block that can display text on the front and back of the block. Signs either stand on the
+// ground or are attached to a wall, while hanging signs hang from the bottom of a block or from a bracket on a wall.
 type Sign struct {
 	transparent
 	empty
@@ -21,8 +22,16 @@ type Sign struct {
 	// Wood is the type of wood of the sign. This field must have one of the values found in the material
 	// package.
 	Wood WoodType
-	// Attach is the attachment of the Sign. It is either of the type WallAttachment or StandingAttachment.
+	// Attach is the attachment of the Sign. It is either of the type WallAttachment or StandingAttachment. For
+	// hanging signs, a WallAttachment means the sign hangs from a bracket on a wall and faces the direction of the
+	// attachment, while a StandingAttachment means the sign hangs from the bottom of the block above it.
 	Attach Attachment
+	// Hanging specifies if the Sign is a hanging sign.
+	Hanging bool
+	// Attached specifies if the chains of a hanging sign below a block meet in a single point. This is the case for
+	// hanging signs placed below blocks without a solid bottom face or while sneaking, and allows the sign to be
+	// rotated in 16 directions rather than 4.
+	Attached bool
 	// Waxed specifies if the Sign has been waxed by a player. If set to true, the Sign can no longer be edited by
 	// anyone and must be destroyed if the text needs to be changed.
 	Waxed bool
@@ -71,16 +80,22 @@ func (s Sign) FuelInfo() item.FuelInfo {
 
 // EncodeItem ...
 func (s Sign) EncodeItem() (name string, meta int16) {
+	if s.Hanging {
+		return "minecraft:" + s.Wood.String() + "_hanging_sign", 0
+	}
 	return "minecraft:" + s.Wood.String() + "_sign", 0
 }
 
 // BreakInfo ...
 func (s Sign) BreakInfo() BreakInfo {
-	return newBreakInfo(1, alwaysHarvestable, axeEffective, oneOf(Sign{Wood: s.Wood}))
+	return newBreakInfo(1, alwaysHarvestable, axeEffective, oneOf(Sign{Wood: s.Wood, Hanging: s.Hanging}))
 }
 
-// Dye dyes the Sign, changing its base colour to that of the colour passed.
+// Dye dyes the Sign, changing its base colour to that of the colour passed. Waxed signs cannot be dyed.
 func (s Sign) Dye(pos cube.Pos, userPos mgl64.Vec3, c item.Colour) (world.Block, bool) {
+	if s.Waxed {
+		return s, false
+	}
 	if s.EditingFrontSide(pos, userPos) {
 		if s.Front.BaseColour == c.SignRGBA() {
 			return s, false
@@ -95,8 +110,11 @@ func (s Sign) Dye(pos cube.Pos, userPos mgl64.Vec3, c item.Colour) (world.Block,
 	return s, true
 }
 
-// Ink inks the sign either glowing or non-glowing.
+// Ink inks the sign either glowing or non-glowing. Waxed signs cannot be inked.
 func (s Sign) Ink(pos cube.Pos, userPos mgl64.Vec3, glowing bool) (world.Block, bool) {
+	if s.Waxed {
+		return s, false
+	}
 	if s.EditingFrontSide(pos, userPos) {
 		if s.Front.Glowing == glowing {
 			return s, false
@@ -144,11 +162,16 @@ type SignEditor interface {
 // UseOnBlock ...
 func (s Sign) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) (used bool) {
 	pos, face, used = firstReplaceable(tx, pos, face, s)
-	if !used || face == cube.FaceDown {
+	if !used {
 		return false
 	}
-
-	if face == cube.FaceUp {
+	if s.Hanging {
+		if !s.attach(pos, face, tx, user) {
+			return false
+		}
+	} else if face == cube.FaceDown {
+		return false
+	} else if face == cube.FaceUp {
 		s.Attach = StandingAttachment(user.Rotation().Orientation().Opposite())
 	} else {
 		s.Attach = WallAttachment(face.Direction())
@@ -160,9 +183,45 @@ func (s Sign) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.T
 	return placed(ctx)
 }
 
+// attach sets the attachment of a hanging sign placed against the face passed. Hanging signs cannot be placed on top
+// of blocks, in which case attach returns false.
+func (s *Sign) attach(pos cube.Pos, face cube.Face, tx *world.Tx, user item.User) bool {
+	switch face {
+	case cube.FaceUp:
+		return false
+	case cube.FaceDown:
+		above := pos.Side(cube.FaceUp)
+		sneaking := false
+		if sn, ok := user.(interface{ Sneaking() bool }); ok {
+			sneaking = sn.Sneaking()
+		}
+		s.Attached = sneaking || !tx.Block(above).Model().FaceSolid(above, cube.FaceDown, tx)
+		o := user.Rotation().Orientation().Opposite()
+		if !s.Attached {
+			// Hanging signs with their chains apart can only face one of the four horizontal directions.
+			o = cube.Orientation((o + 2) / 4 * 4 % 16)
+		}
+		s.Attach = StandingAttachment(o)
+	default:
+		// The board of a hanging sign on a wall sticks out of the wall, so it faces one of the directions
+		// perpendicular to the face it was placed against.
+		d := user.Rotation().Direction().Opposite()
+		if d.Face().Axis() == face.Axis() {
+			d = face.Direction().RotateRight()
+		}
+		s.Attach = WallAttachment(d)
+	}
+	return true
+}
+
 // NeighbourUpdateTick ...
 func (s Sign) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
-	if s.Attach.hanging {
+	if s.Hanging {
+		if s.supported(pos, tx) {
+			return
+		}
+		breakBlock(s, pos, tx)
+	} else if s.Attach.hanging {
 		if _, ok := tx.Block(pos.Side(s.Attach.facing.Opposite().Face())).(Air); ok {
 			breakBlock(s, pos, tx)
 		}
@@ -171,8 +230,35 @@ func (s Sign) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
 	}
 }
 
+// supported checks if a hanging sign at the position passed is still attached to a block. Hanging signs below a
+// block need the block above them, while hanging signs on a wall need a block on at least one side of the bracket.
+func (s Sign) supported(pos cube.Pos, tx *world.Tx) bool {
+	if !s.Attach.hanging {
+		_, air := tx.Block(pos.Side(cube.FaceUp)).(Air)
+		return !air
+	}
+	for _, d := range []cube.Direction{s.Attach.facing.RotateLeft(), s.Attach.facing.RotateRight()} {
+		if _, air := tx.Block(pos.Side(d.Face())).(Air); !air {
+			return true
+		}
+	}
+	return false
+}
+
 // EncodeBlock ...
 func (s Sign) EncodeBlock() (name string, properties map[string]any) {
+	if s.Hanging {
+		var facing, o int32
+		switch {
+		case s.Attach.hanging:
+			facing = int32(s.Attach.facing + 2)
+		case s.Attached:
+			o = int32(s.Attach.o)
+		default:
+			facing = int32(cube.Rotation{s.Attach.o.Yaw()}.Direction() + 2)
+		}
+		return "minecraft:" + s.Wood.String() + "_hanging_sign", map[string]any{"hanging": boolByte(!s.Attach.hanging), "attached_bit": boolByte(s.Attached), "facing_direction": facing, "ground_sign_direction": o}
+	}
 	woodType := s.Wood.String() + "_"
 	switch s.Wood {
 	case OakWood():
@@ -197,44 +283,48 @@ func (s Sign) DecodeNBT(data map[string]any) any {
 		return s
 	}
 
-	front, ok := data["FrontText"].(map[string]any)
-	if ok {
-		s.Front.BaseColour = nbtconv.RGBAFromInt32(nbtconv.Int32(front, "Color"))
-		s.Front.Glowing = nbtconv.Bool(front, "GlowingText")
-		s.Front.Text = nbtconv.String(front, "Text")
-		s.Front.Owner = nbtconv.String(front, "Owner")
+	s.Waxed = nbtconv.Bool(data, "IsWaxed")
+	if front, ok := data["FrontText"].(map[string]any); ok {
+		s.Front = decodeSignText(front)
 	}
-
-	back, ok := data["BackText"].(map[string]any)
-	if ok {
-		s.Back.BaseColour = nbtconv.RGBAFromInt32(nbtconv.Int32(back, "Color"))
-		s.Back.Glowing = nbtconv.Bool(back, "GlowingText")
-		s.Back.Text = nbtconv.String(back, "Text")
-		s.Back.Owner = nbtconv.String(back, "Owner")
+	if back, ok := data["BackText"].(map[string]any); ok {
+		s.Back = decodeSignText(back)
 	}
-
 	return s
 }
 
 // EncodeNBT ...
 func (s Sign) EncodeNBT() map[string]any {
-	m := map[string]any{
-		"id":      "Sign",
-		"IsWaxed": boolByte(s.Waxed),
-		"FrontText": map[string]any{
-			"SignTextColor":  nbtconv.Int32FromRGBA(s.Front.BaseColour),
-			"IgnoreLighting": boolByte(s.Front.Glowing),
-			"Text":           s.Front.Text,
-			"TextOwner":      s.Front.Owner,
-		},
-		"BackText": map[string]any{
-			"SignTextColor":  nbtconv.Int32FromRGBA(s.Back.BaseColour),
-			"IgnoreLighting": boolByte(s.Back.Glowing),
-			"Text":           s.Back.Text,
-			"TextOwner":      s.Back.Owner,
-		},
-	}
-	return m
+	id := "Sign"
+	if s.Hanging {
+		id = "HangingSign"
+	}
+	return map[string]any{
+		"id":        id,
+		"IsWaxed":   boolByte(s.Waxed),
+		"FrontText": encodeSignText(s.Front),
+		"BackText":  encodeSignText(s.Back),
+	}
+}
+
+// decodeSignText decodes the SignText of one side of a sign from the map passed.
+func decodeSignText(m map[string]any) SignText {
+	return SignText{
+		Text:       nbtconv.String(m, "Text"),
+		BaseColour: nbtconv.RGBAFromInt32(nbtconv.Int32(m, "SignTextColor")),
+		Glowing:    nbtconv.Bool(m, "IgnoreLighting"),
+		Owner:      nbtconv.String(m, "TextOwner"),
+	}
+}
+
+// encodeSignText encodes the SignText of one side of a sign into a map.
+func encodeSignText(t SignText) map[string]any {
+	return map[string]any{
+		"SignTextColor":  nbtconv.Int32FromRGBA(t.BaseColour),
+		"IgnoreLighting": boolByte(t.Glowing),
+		"Text":           t.Text,
+		"TextOwner":      t.Owner,
+	}
 }
 
 // allSigns ...
@@ -245,6 +335,13 @@ func allSigns() (signs []world.Block) {
 		}
 		for o := cube.Orientation(0); o <= 15; o++ {
 			signs = append(signs, Sign{Wood: w, Attach: StandingAttachment(o)})
+			signs = append(signs, Sign{Wood: w, Attach: StandingAttachment(o), Hanging: true, Attached: true})
+		}
+		for _, d := range cube.Directions() {
+			signs = append(signs, Sign{Wood: w, Attach: WallAttachment(d), Hanging: true})
+		}
+		for o := cube.Orientation(0); o <= 15; o += 4 {
+			signs = append(signs, Sign{Wood: w, Attach: StandingAttachment(o), Hanging: true})
 		}
 	}
 	return
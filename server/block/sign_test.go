@@ -0,0 +1,69 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// testUser is an item.User standing at a fixed position and holding an item
+// in its main hand. It records if it opened a sign.
+type testUser struct {
+	world.Entity
+	pos    mgl64.Vec3
+	held   item.Stack
+	opened *bool
+}
+
+func (u testUser) Position() mgl64.Vec3                      { return u.pos }
+func (u testUser) HeldItems() (mainHand, offHand item.Stack) { return u.held, item.Stack{} }
+func (u testUser) SetHeldItems(item.Stack, item.Stack)       {}
+func (u testUser) UsingItem() bool                           { return false }
+func (u testUser) ReleaseItem()                              {}
+func (u testUser) UseItem()                                  {}
+func (u testUser) OpenSign(cube.Pos, bool)                   { *u.opened = true }
+
+func TestHangingSignGlowInkAndWax(t *testing.T) {
+	w := world.Config{}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		pos := cube.Pos{0, 64, 0}
+		s := Sign{Wood: OakWood(), Hanging: true, Attach: WallAttachment(cube.North), Front: SignText{Text: "hello"}}
+		tx.SetBlock(pos, s, nil)
+
+		var opened bool
+		// The user stands in front of the sign, so that the front side is
+		// changed.
+		u := testUser{pos: pos.Vec3Centre().Add(s.Attach.Rotation().Vec3().Mul(2)), opened: &opened}
+		use := func(it item.UsableOnBlock) bool {
+			return it.UseOnBlock(pos, cube.FaceNorth, mgl64.Vec3{}, tx, u, &item.UseContext{})
+		}
+
+		if !use(item.InkSac{Glowing: true}) {
+			t.Errorf("expected glow ink sac to be used on the sign")
+			return
+		}
+		if s := tx.Block(pos).(Sign); !s.Front.Glowing || s.Back.Glowing {
+			t.Errorf("expected only the front text to glow, got front %v and back %v", s.Front.Glowing, s.Back.Glowing)
+			return
+		}
+		if !use(item.Honeycomb{}) {
+			t.Errorf("expected honeycomb to be used on the sign")
+			return
+		}
+		if use(item.Honeycomb{}) || use(item.InkSac{}) || use(item.Dye{Colour: item.ColourRed()}) {
+			t.Errorf("expected waxed sign not to be waxed, inked or dyed again")
+		}
+		if s := tx.Block(pos).(Sign); !s.Waxed || !s.Front.Glowing || s.Front.Text != "hello" {
+			t.Errorf("expected sign to remain waxed with glowing text, got %+v", s)
+		}
+		tx.Block(pos).(Sign).Activate(pos, cube.FaceNorth, tx, u, &item.UseContext{})
+		if opened {
+			t.Errorf("expected waxed sign not to be opened for editing")
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// Slime is a bouncy, translucent block crafted from slimeballs. Entities that
+// land on a slime block bounce back up without taking fall damage, unless
+// they are sneaking.
+type Slime struct {
+	solid
+	transparent
+}
+
+// EntityLand prevents entities from taking fall damage when landing on the
+// slime block, unless they are sneaking.
+func (Slime) EntityLand(_ cube.Pos, _ *world.Tx, e world.Entity, distance *float64) {
+	if !suppressesBounce(e) {
+		*distance = 0
+	}
+}
+
+// BounceVelocity returns the velocity of an entity that landed on the slime
+// block with velocity vel. Living entities bounce back up with the speed they
+// landed with and other entities with 80% of it. Entities that are sneaking
+// do not bounce and stop moving vertically.
+func (Slime) BounceVelocity(e world.Entity, vel mgl64.Vec3) mgl64.Vec3 {
+	if suppressesBounce(e) || vel[1] >= 0 {
+		return mgl64.Vec3{vel[0], 0, vel[2]}
+	}
+	if _, living := e.(interface{ Health() float64 }); living {
+		return mgl64.Vec3{vel[0], -vel[1], vel[2]}
+	}
+	return mgl64.Vec3{vel[0], -vel[1] * 0.8, vel[2]}
+}
+
+// EntityVelocity returns the velocity of an entity with velocity vel that is
+// walking on the slime block. Entities that are not bouncing are slowed down
+// horizontally.
+func (Slime) EntityVelocity(e world.Entity, vel mgl64.Vec3) mgl64.Vec3 {
+	if y := math.Abs(vel[1]); y < 0.1 && !suppressesBounce(e) {
+		f := 0.4 + y*0.2
+		return mgl64.Vec3{vel[0] * f, vel[1], vel[2] * f}
+	}
+	return vel
+}
+
+// Friction ...
+func (Slime) Friction() float64 {
+	return 0.8
+}
+
+// BreakInfo ...
+func (s Slime) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(s))
+}
+
+// EncodeItem ...
+func (Slime) EncodeItem() (name string, meta int16) {
+	return "minecraft:slime", 0
+}
+
+// EncodeBlock ...
+func (Slime) EncodeBlock() (string, map[string]any) {
+	return "minecraft:slime", nil
+}
+
+// suppressesBounce checks if the entity passed is sneaking, which prevents it
+// from bouncing on slime blocks.
+func suppressesBounce(e world.Entity) bool {
+	s, ok := e.(interface{ Sneaking() bool })
+	return ok && s.Sneaking()
+}
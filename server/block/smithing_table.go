@@ -36,3 +36,8 @@ func (SmithingTable) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.Us
 	}
 	return false
 }
+
+// VillagePoint ...
+func (SmithingTable) VillagePoint() bool {
+	return true
+}
@@ -132,3 +132,8 @@ func allSmokers() (smokers []world.Block) {
 	}
 	return
 }
+
+// VillagePoint ...
+func (Smoker) VillagePoint() bool {
+	return true
+}
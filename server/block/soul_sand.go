@@ -1,6 +1,7 @@
 package block
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
 )
@@ -10,7 +11,10 @@ type SoulSand struct {
 	solid
 }
 
-// TODO: Implement bubble columns.
+// NeighbourUpdateTick ...
+func (s SoulSand) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	formBubbleColumn(pos.Side(cube.FaceUp), tx)
+}
 
 // SoilFor ...
 func (s SoulSand) SoilFor(block world.Block) bool {
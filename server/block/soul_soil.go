@@ -9,8 +9,11 @@ type SoulSoil struct {
 
 // SoilFor ...
 func (s SoulSoil) SoilFor(block world.Block) bool {
-	_, ok := block.(NetherSprouts)
-	return ok
+	switch block.(type) {
+	case NetherSprouts, Fungus, Roots:
+		return true
+	}
+	return false
 }
 
 // BreakInfo ...
@@ -69,14 +69,22 @@ func (s Sponge) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world
 }
 
 // NeighbourUpdateTick checks for nearby water flow. If water could be found and the sponge is dry, it will absorb the
-// water and be flagged as wet.
+// water and be flagged as wet. Because placing a block updates the block itself too, this also absorbs water
+// directly after the sponge is placed. A wet sponge in a dimension where water evaporates, such as the Nether, dries
+// instantly.
 func (s Sponge) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
-	// The sponge is dry, so it can absorb nearby water.
-	if !s.Wet {
-		if s.absorbWater(pos, tx) > 0 {
-			// Water has been absorbed, so we flag the sponge as wet.
-			s.setWet(pos, tx)
+	if s.Wet {
+		if tx.World().Dimension().WaterEvaporates() {
+			s.Wet = false
+			tx.SetBlock(pos, s, nil)
+			tx.AddParticle(pos.Side(cube.FaceUp).Vec3(), particle.Evaporate{})
 		}
+		return
+	}
+	// The sponge is dry, so it can absorb nearby water.
+	if s.absorbWater(pos, tx) > 0 {
+		// Water has been absorbed, so we flag the sponge as wet.
+		s.setWet(pos, tx)
 	}
 }
 
@@ -89,7 +97,8 @@ func (s Sponge) setWet(pos cube.Pos, tx *world.Tx) {
 }
 
 // absorbWater replaces water blocks near the sponge by air out to a taxicab geometry of 7 in all directions.
-// The maximum for absorbed blocks is 65.
+// The maximum for absorbed blocks is 65, which is never exceeded, even if more water surrounds the last block
+// visited. Removing the water updates the blocks around it, so that any water left flows back in from its sources.
 // The returned int specifies the amount of replaced water blocks.
 func (s Sponge) absorbWater(pos cube.Pos, tx *world.Tx) int {
 	// distanceToSponge binds a world.Position to its distance from the sponge's position.
@@ -102,8 +111,9 @@ func (s Sponge) absorbWater(pos cube.Pos, tx *world.Tx) int {
 	queue = append(queue, distanceToSponge{pos, 0})
 
 	// A sponge can only absorb up to 65 water blocks.
+	const maxAbsorbed = 65
 	replaced := 0
-	for replaced < 65 {
+	for replaced < maxAbsorbed {
 		if len(queue) == 0 {
 			break
 		}
@@ -113,6 +123,9 @@ func (s Sponge) absorbWater(pos cube.Pos, tx *world.Tx) int {
 		queue = queue[1:]
 
 		next.block.Neighbours(func(neighbour cube.Pos) {
+			if replaced >= maxAbsorbed {
+				return
+			}
 			liquid, found := tx.Liquid(neighbour)
 			if found {
 				if _, isWater := liquid.(Water); isWater {
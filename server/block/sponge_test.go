@@ -0,0 +1,70 @@
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestSpongeAbsorbsPool(t *testing.T) {
+	w := world.Config{}.New()
+	defer w.Close()
+
+	centre := cube.Pos{8, 0, 8}
+	<-w.Exec(func(tx *world.Tx) {
+		for x := -3; x <= 3; x++ {
+			for z := -3; z <= 3; z++ {
+				tx.SetBlock(centre.Add(cube.Pos{x, -1, z}), Stone{}, nil)
+				tx.SetLiquid(centre.Add(cube.Pos{x, 0, z}), Water{Still: true, Depth: 8})
+			}
+		}
+		tx.SetLiquid(centre, nil)
+		tx.SetBlock(centre, Sponge{}, nil)
+		Sponge{}.NeighbourUpdateTick(centre, centre, tx)
+
+		if s, ok := tx.Block(centre).(Sponge); !ok || !s.Wet {
+			t.Errorf("expected sponge to be wet after absorbing water, got %#v", tx.Block(centre))
+			return
+		}
+		for x := -3; x <= 3; x++ {
+			for z := -3; z <= 3; z++ {
+				if l, ok := tx.Liquid(centre.Add(cube.Pos{x, 0, z})); ok {
+					t.Errorf("expected all water to be absorbed, found %#v at %v %v", l, x, z)
+					return
+				}
+			}
+		}
+	})
+}
+
+func TestWetSpongeDriesInNether(t *testing.T) {
+	w := world.Config{Dim: world.Nether}.New()
+	defer w.Close()
+
+	pos := cube.Pos{8, 64, 8}
+	<-w.Exec(func(tx *world.Tx) {
+		tx.SetBlock(pos, Sponge{Wet: true}, nil)
+		Sponge{Wet: true}.NeighbourUpdateTick(pos, pos, tx)
+		if s, ok := tx.Block(pos).(Sponge); !ok || s.Wet {
+			t.Errorf("expected wet sponge to dry in the Nether, got %#v", tx.Block(pos))
+		}
+	})
+}
+
+func TestWetSpongeDriesInFurnace(t *testing.T) {
+	f := NewFurnace(cube.North)
+	_ = f.inventory.SetItem(0, item.NewStack(Sponge{Wet: true}, 1))
+	_ = f.inventory.SetItem(1, item.NewStack(item.Coal{}, 1))
+
+	var lit bool
+	for range 201 {
+		lit = f.tickSmelting(time.Second*10, time.Millisecond*100, lit, func(item.SmeltInfo) bool { return true })
+	}
+	product, _ := f.inventory.Item(2)
+	if s, ok := product.Item().(Sponge); !ok || s.Wet || product.Count() != 1 {
+		t.Fatalf("expected a dry sponge to be smelted from a wet sponge, got %v", product)
+	}
+}
@@ -64,3 +64,8 @@ func allStonecutters() (stonecutters []world.Block) {
 	}
 	return
 }
+
+// VillagePoint ...
+func (Stonecutter) VillagePoint() bool {
+	return true
+}
@@ -0,0 +1,280 @@
+package block
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/structure"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+)
+
+// StructureBlock is a block used to save and load structures. It can only be
+// obtained, edited and broken by players in creative mode.
+type StructureBlock struct {
+	solid
+
+	// Mode is the mode of the StructureBlock, which determines what happens
+	// when it is triggered.
+	Mode StructureBlockMode
+	// Name is the name of the structure that is saved or loaded. Corner
+	// structure blocks mark the bounds of save structure blocks with the
+	// same Name.
+	Name string
+	// DataField holds custom data for structure blocks in data mode.
+	DataField string
+	// Offset is the offset of the minimum corner of the structure relative to
+	// the position of the StructureBlock.
+	Offset cube.Pos
+	// Size is the size of the structure saved.
+	Size cube.Pos
+	// Rotation is the amount of quarter turns by which a loaded structure is
+	// rotated clockwise.
+	Rotation int
+	// Mirror specifies how a loaded structure is mirrored.
+	Mirror structure.Mirror
+	// Integrity is the chance, ranging from 0 to 1, for every block in a
+	// loaded structure to be placed. If 0, every block is placed.
+	Integrity float64
+	// Seed is the seed used to select the blocks placed if Integrity is
+	// lower than 1. If 0, a random seed is used every time the structure is
+	// loaded.
+	Seed int64
+	// IgnoreEntities specifies if entities should be left out when saving
+	// and loading structures.
+	IgnoreEntities bool
+	// IncludePlayers specifies if players in the area should be saved. Players
+	// are never saved in a structure, so this field is only kept for the
+	// client.
+	IncludePlayers bool
+	// RemoveBlocks specifies if blocks should be left out when loading a
+	// structure, so that only entities are loaded.
+	RemoveBlocks bool
+	// ShowBoundingBox specifies if the client should render the bounds of the
+	// structure.
+	ShowBoundingBox bool
+	// Powered is true if the StructureBlock is currently receiving redstone
+	// power. The StructureBlock is triggered when it first becomes powered.
+	Powered bool
+}
+
+// Activate ...
+func (s StructureBlock) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
+	if gm, ok := u.(interface{ GameMode() world.GameMode }); !ok || !gm.GameMode().CreativeInventory() {
+		return false
+	}
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos, tx)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (s StructureBlock) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(tx, pos, face, s)
+	if !used {
+		return
+	}
+	if s.Size == (cube.Pos{}) {
+		s.Offset, s.Size = cube.Pos{0, 1, 0}, cube.Pos{5, 5, 5}
+	}
+	place(tx, pos, s, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (s StructureBlock) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	powered := receivesRedstonePower(pos, tx)
+	if powered == s.Powered {
+		return
+	}
+	s.Powered = powered
+	tx.SetBlock(pos, s, nil)
+	if powered {
+		_ = s.Trigger(pos, tx)
+	}
+}
+
+// Trigger saves or loads the structure of the StructureBlock, depending on its
+// Mode. Triggering a StructureBlock in any other mode has no effect.
+func (s StructureBlock) Trigger(pos cube.Pos, tx *world.Tx) error {
+	switch s.Mode {
+	case StructureBlockSave():
+		return s.Save(pos, tx)
+	case StructureBlockLoad():
+		return s.Load(pos, tx)
+	}
+	return nil
+}
+
+// Structure copies the area selected by the StructureBlock at the position
+// passed to a new structure.Structure.
+func (s StructureBlock) Structure(pos cube.Pos, tx *world.Tx) (*structure.Structure, error) {
+	if s.Size[0] <= 0 || s.Size[1] <= 0 || s.Size[2] <= 0 {
+		return nil, fmt.Errorf("structure block at %v has an empty size %v", pos, s.Size)
+	}
+	origin := pos.Add(s.Offset)
+	st := structure.Copy(tx, origin, origin.Add(s.Size).Sub(cube.Pos{1, 1, 1}))
+	if s.IgnoreEntities {
+		st.ClearEntities()
+	}
+	return st, nil
+}
+
+// Save saves the area selected by the StructureBlock at the position passed
+// in the world under the Name of the StructureBlock.
+func (s StructureBlock) Save(pos cube.Pos, tx *world.Tx) error {
+	if s.Name == "" {
+		return fmt.Errorf("structure block at %v has no structure name", pos)
+	}
+	st, err := s.Structure(pos, tx)
+	if err != nil {
+		return err
+	}
+	return tx.World().SaveStructure(s.Name, st.EncodeNBT())
+}
+
+// Load loads the structure saved in the world under the Name of the
+// StructureBlock and places it at the offset of the StructureBlock at the
+// position passed.
+func (s StructureBlock) Load(pos cube.Pos, tx *world.Tx) error {
+	data, ok := tx.World().Structure(s.Name)
+	if !ok {
+		return fmt.Errorf("structure %v does not exist", s.Name)
+	}
+	st, err := structure.DecodeNBT(data)
+	if err != nil {
+		return fmt.Errorf("load structure %v: %w", s.Name, err)
+	}
+	if s.RemoveBlocks {
+		entities := st.Entities()
+		st = structure.New(st.Dimensions())
+		for _, e := range entities {
+			st.AddEntity(e)
+		}
+	}
+	seed := s.Seed
+	if seed == 0 {
+		seed = rand.Int64()
+	}
+	st.Paste(tx, pos.Add(s.Offset), structure.PasteOpts{
+		Rotation:      s.Rotation,
+		Mirror:        s.Mirror,
+		BlockEntities: true,
+		Entities:      !s.IgnoreEntities,
+		Integrity:     s.Integrity,
+		Seed:          seed,
+	})
+	return nil
+}
+
+// structureCornerRange is the maximum distance on every axis between a save
+// structure block and the corner structure blocks marking its bounds.
+const structureCornerRange = 80
+
+// DetectBounds looks for corner structure blocks with the same name as the
+// StructureBlock at the position passed, and returns the StructureBlock with
+// its Offset and Size changed to the area enclosed by them. The corner blocks
+// themselves are not part of the area. False is returned if no corners were
+// found or if they do not enclose any blocks. Only loaded chunks are searched.
+func (s StructureBlock) DetectBounds(pos cube.Pos, tx *world.Tx) (StructureBlock, bool) {
+	if s.Name == "" {
+		return s, false
+	}
+	var corners []cube.Pos
+	r := cube.Pos{structureCornerRange, structureCornerRange, structureCornerRange}
+	for cornerPos, b := range tx.BlockEntitiesWithin(pos.Sub(r), pos.Add(r)) {
+		if corner, ok := b.(StructureBlock); ok && cornerPos != pos && corner.Mode == StructureBlockCorner() && corner.Name == s.Name {
+			corners = append(corners, cornerPos)
+		}
+	}
+	if len(corners) == 0 {
+		return s, false
+	}
+	if len(corners) == 1 {
+		// A single corner spans the area between the corner and the
+		// structure block itself.
+		corners = append(corners, pos)
+	}
+	low, high := corners[0], corners[0]
+	for _, c := range corners[1:] {
+		low = cube.Pos{min(low[0], c[0]), min(low[1], c[1]), min(low[2], c[2])}
+		high = cube.Pos{max(high[0], c[0]), max(high[1], c[1]), max(high[2], c[2])}
+	}
+	size := high.Sub(low).Sub(cube.Pos{1, 1, 1})
+	if size[0] <= 0 || size[1] <= 0 || size[2] <= 0 {
+		return s, false
+	}
+	s.Offset, s.Size = low.Sub(pos).Add(cube.Pos{1, 1, 1}), size
+	return s, true
+}
+
+// EncodeNBT ...
+func (s StructureBlock) EncodeNBT() map[string]any {
+	integrity := s.Integrity
+	if integrity <= 0 {
+		integrity = 1
+	}
+	return map[string]any{
+		"id":               "StructureBlock",
+		"data":             int32(s.Mode.Uint8()),
+		"structureName":    s.Name,
+		"dataField":        s.DataField,
+		"xStructureOffset": int32(s.Offset[0]),
+		"yStructureOffset": int32(s.Offset[1]),
+		"zStructureOffset": int32(s.Offset[2]),
+		"xStructureSize":   int32(s.Size[0]),
+		"yStructureSize":   int32(s.Size[1]),
+		"zStructureSize":   int32(s.Size[2]),
+		"rotation":         uint8(s.Rotation),
+		"mirror":           uint8(s.Mirror),
+		"integrity":        float32(integrity * 100),
+		"seed":             s.Seed,
+		"ignoreEntities":   boolByte(s.IgnoreEntities),
+		"includePlayers":   boolByte(s.IncludePlayers),
+		"removeBlocks":     boolByte(s.RemoveBlocks),
+		"showBoundingBox":  boolByte(s.ShowBoundingBox),
+		"isPowered":        boolByte(s.Powered),
+		"redstoneSaveMode": int32(0),
+		"isMovable":        uint8(0),
+	}
+}
+
+// DecodeNBT ...
+func (s StructureBlock) DecodeNBT(data map[string]any) any {
+	s.Name = nbtconv.String(data, "structureName")
+	s.DataField = nbtconv.String(data, "dataField")
+	s.Offset = cube.Pos{int(nbtconv.Int32(data, "xStructureOffset")), int(nbtconv.Int32(data, "yStructureOffset")), int(nbtconv.Int32(data, "zStructureOffset"))}
+	s.Size = cube.Pos{int(nbtconv.Int32(data, "xStructureSize")), int(nbtconv.Int32(data, "yStructureSize")), int(nbtconv.Int32(data, "zStructureSize"))}
+	s.Rotation = int(nbtconv.Uint8(data, "rotation") % 4)
+	s.Mirror = structure.Mirror(nbtconv.Uint8(data, "mirror") % 4)
+	s.Integrity = float64(nbtconv.Float32(data, "integrity")) / 100
+	s.Seed = nbtconv.Int64(data, "seed")
+	s.IgnoreEntities = nbtconv.Bool(data, "ignoreEntities")
+	s.IncludePlayers = nbtconv.Bool(data, "includePlayers")
+	s.RemoveBlocks = nbtconv.Bool(data, "removeBlocks")
+	s.ShowBoundingBox = nbtconv.Bool(data, "showBoundingBox")
+	s.Powered = nbtconv.Bool(data, "isPowered")
+	return s
+}
+
+// EncodeItem ...
+func (StructureBlock) EncodeItem() (name string, meta int16) {
+	return "minecraft:structure_block", 0
+}
+
+// EncodeBlock ...
+func (s StructureBlock) EncodeBlock() (string, map[string]any) {
+	return "minecraft:structure_block", map[string]any{"structure_block_type": s.Mode.String()}
+}
+
+// allStructureBlocks ...
+func allStructureBlocks() (blocks []world.Block) {
+	for _, m := range StructureBlockModes() {
+		blocks = append(blocks, StructureBlock{Mode: m})
+	}
+	return
+}
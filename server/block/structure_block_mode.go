@@ -0,0 +1,68 @@
+package block
+
+// StructureBlockMode represents the mode of a StructureBlock, which determines
+// what the structure block does when it is triggered.
+type StructureBlockMode struct {
+	structureBlockMode
+}
+
+// StructureBlockData returns the data mode of a structure block. Structure
+// blocks in data mode hold custom data used by structures.
+func StructureBlockData() StructureBlockMode {
+	return StructureBlockMode{0}
+}
+
+// StructureBlockSave returns the save mode of a structure block. Structure
+// blocks in save mode save the area around them as a structure.
+func StructureBlockSave() StructureBlockMode {
+	return StructureBlockMode{1}
+}
+
+// StructureBlockLoad returns the load mode of a structure block. Structure
+// blocks in load mode paste a saved structure into the world.
+func StructureBlockLoad() StructureBlockMode {
+	return StructureBlockMode{2}
+}
+
+// StructureBlockCorner returns the corner mode of a structure block. Structure
+// blocks in corner mode mark the corners of the area saved by a structure
+// block in save mode with the same name.
+func StructureBlockCorner() StructureBlockMode {
+	return StructureBlockMode{3}
+}
+
+// StructureBlockExport returns the export mode of a structure block. Structure
+// blocks in export mode allow the client to export the area around them to a
+// .mcstructure file.
+func StructureBlockExport() StructureBlockMode {
+	return StructureBlockMode{5}
+}
+
+// StructureBlockModes returns all structure block modes.
+func StructureBlockModes() []StructureBlockMode {
+	return []StructureBlockMode{StructureBlockData(), StructureBlockSave(), StructureBlockLoad(), StructureBlockCorner(), StructureBlockExport()}
+}
+
+type structureBlockMode uint8
+
+// Uint8 returns the structure block mode as a uint8.
+func (m structureBlockMode) Uint8() uint8 {
+	return uint8(m)
+}
+
+// String ...
+func (m structureBlockMode) String() string {
+	switch m {
+	case 0:
+		return "data"
+	case 1:
+		return "save"
+	case 2:
+		return "load"
+	case 3:
+		return "corner"
+	case 5:
+		return "export"
+	}
+	panic("unknown structure block mode")
+}
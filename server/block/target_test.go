@@ -0,0 +1,50 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestTargetPower(t *testing.T) {
+	pos := cube.Pos{3, 64, -2}
+	tests := []struct {
+		name string
+		face cube.Face
+		hit  mgl64.Vec3
+		want int
+	}{
+		{name: "centre", face: cube.FaceNorth, hit: mgl64.Vec3{0.5, 0.5, 0}, want: 15},
+		{name: "centre of top", face: cube.FaceUp, hit: mgl64.Vec3{0.5, 1, 0.5}, want: 15},
+		{name: "halfway", face: cube.FaceEast, hit: mgl64.Vec3{1, 0.25, 0.5}, want: 8},
+		{name: "near edge", face: cube.FaceNorth, hit: mgl64.Vec3{0.95, 0.5, 0}, want: 2},
+		{name: "corner", face: cube.FaceSouth, hit: mgl64.Vec3{1, 1, 1}, want: 1},
+	}
+	for _, test := range tests {
+		if got := targetPower(pos, test.face, pos.Vec3().Add(test.hit)); got != test.want {
+			t.Errorf("%v: expected power %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestTargetHitInCentre(t *testing.T) {
+	withDroppedItems(func(tx *world.Tx) {
+		pos := cube.Pos{0, 64, 0}
+		tx.SetBlock(pos, Target{}, nil)
+		// Any entity may be used as the projectile hitting the target.
+		e := tx.AddEntity(world.EntitySpawnOpts{}.New(droppedItemType{}, droppedItemConfig{it: item.NewStack(item.Snowball{}, 1)}))
+
+		tx.Block(pos).(Target).ProjectileHit(pos, tx, e, cube.FaceNorth, pos.Vec3().Add(mgl64.Vec3{0.5, 0.5, 0}))
+		target := tx.Block(pos).(Target)
+		if target.Power != 15 || target.redstonePower(cube.FaceNorth) != 15 {
+			t.Errorf("expected hit in the centre of the target to emit power 15, got %v", target.Power)
+		}
+		target.ProjectileHit(pos, tx, e, cube.FaceNorth, pos.Vec3().Add(mgl64.Vec3{1, 1, 0}))
+		if target := tx.Block(pos).(Target); target.Power != 15 {
+			t.Errorf("expected target to keep its power until it resets, got %v", target.Power)
+		}
+	})
+}
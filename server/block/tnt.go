@@ -17,7 +17,7 @@ type TNT struct {
 }
 
 // ProjectileHit ...
-func (t TNT) ProjectileHit(pos cube.Pos, tx *world.Tx, e world.Entity, _ cube.Face) {
+func (t TNT) ProjectileHit(pos cube.Pos, tx *world.Tx, e world.Entity, _ cube.Face, _ mgl64.Vec3) {
 	if f, ok := e.(flammableEntity); ok && f.OnFireDuration() > 0 {
 		t.Ignite(pos, tx, nil)
 	}
@@ -0,0 +1,112 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+)
+
+// TwistingVines are climbable vines that grow upwards from the floors of
+// warped forests.
+type TwistingVines struct {
+	transparent
+	empty
+
+	// Age is the age of the vines, which can be 0-25. Vines with an age of
+	// 25 won't grow any further.
+	Age int
+}
+
+// EntityInside ...
+func (TwistingVines) EntityInside(_ cube.Pos, _ *world.Tx, e world.Entity) {
+	if fallEntity, ok := e.(fallDistanceEntity); ok {
+		fallEntity.ResetFallDistance()
+	}
+}
+
+// UseOnBlock ...
+func (v TwistingVines) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, v)
+	if !used {
+		return false
+	}
+	if !v.supported(pos, tx) {
+		return false
+	}
+	// When first placed, vines get a random age between 0 and 24.
+	v.Age = rand.IntN(25)
+
+	place(tx, pos, v, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (v TwistingVines) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !v.supported(pos, tx) {
+		breakBlock(v, pos, tx)
+	}
+}
+
+// RandomTick ...
+func (v TwistingVines) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	// Every random tick, there's a 10% chance for the vines to grow if their
+	// age is below 25.
+	if v.Age >= 25 || r.IntN(10) != 0 {
+		return
+	}
+	above := pos.Side(cube.FaceUp)
+	if _, ok := tx.Block(above).(Air); ok {
+		tx.SetBlock(above, TwistingVines{Age: v.Age + 1}, nil)
+	}
+}
+
+// supported checks if the vines at the position passed grow on top of a
+// solid block or other twisting vines.
+func (v TwistingVines) supported(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	b := tx.Block(below)
+	if _, ok := b.(TwistingVines); ok {
+		return true
+	}
+	return b.Model().FaceSolid(below, cube.FaceUp, tx)
+}
+
+// HasLiquidDrops ...
+func (TwistingVines) HasLiquidDrops() bool {
+	return true
+}
+
+// FlammabilityInfo ...
+func (TwistingVines) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(0, 0, true)
+}
+
+// BreakInfo ...
+func (v TwistingVines) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, vinesDrops(v))
+}
+
+// CompostChance ...
+func (TwistingVines) CompostChance() float64 {
+	return 0.5
+}
+
+// EncodeItem ...
+func (TwistingVines) EncodeItem() (name string, meta int16) {
+	return "minecraft:twisting_vines", 0
+}
+
+// EncodeBlock ...
+func (v TwistingVines) EncodeBlock() (string, map[string]any) {
+	return "minecraft:twisting_vines", map[string]any{"twisting_vines_age": int32(v.Age)}
+}
+
+// allTwistingVines returns all possible states of twisting vines.
+func allTwistingVines() (b []world.Block) {
+	for i := 0; i < 26; i++ {
+		b = append(b, TwistingVines{Age: i})
+	}
+	return
+}
@@ -128,6 +128,9 @@ func (w Water) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
 		tx.SetLiquid(pos, nil)
 		return
 	}
+	if formBubbleColumn(pos, tx) {
+		return
+	}
 	tx.ScheduleBlockUpdate(pos, w, time.Second/4)
 }
 
@@ -0,0 +1,124 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+)
+
+// WeepingVines are climbable vines that grow downwards from the ceilings of
+// crimson forests.
+type WeepingVines struct {
+	transparent
+	empty
+
+	// Age is the age of the vines, which can be 0-25. Vines with an age of
+	// 25 won't grow any further.
+	Age int
+}
+
+// EntityInside ...
+func (WeepingVines) EntityInside(_ cube.Pos, _ *world.Tx, e world.Entity) {
+	if fallEntity, ok := e.(fallDistanceEntity); ok {
+		fallEntity.ResetFallDistance()
+	}
+}
+
+// UseOnBlock ...
+func (v WeepingVines) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, v)
+	if !used {
+		return false
+	}
+	if !v.supported(pos, tx) {
+		return false
+	}
+	// When first placed, vines get a random age between 0 and 24.
+	v.Age = rand.IntN(25)
+
+	place(tx, pos, v, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (v WeepingVines) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !v.supported(pos, tx) {
+		breakBlock(v, pos, tx)
+	}
+}
+
+// RandomTick ...
+func (v WeepingVines) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	// Every random tick, there's a 10% chance for the vines to grow if their
+	// age is below 25.
+	if v.Age >= 25 || r.IntN(10) != 0 {
+		return
+	}
+	below := pos.Side(cube.FaceDown)
+	if _, ok := tx.Block(below).(Air); ok {
+		tx.SetBlock(below, WeepingVines{Age: v.Age + 1}, nil)
+	}
+}
+
+// supported checks if the vines at the position passed hang from a solid
+// block or other weeping vines.
+func (v WeepingVines) supported(pos cube.Pos, tx *world.Tx) bool {
+	above := pos.Side(cube.FaceUp)
+	b := tx.Block(above)
+	if _, ok := b.(WeepingVines); ok {
+		return true
+	}
+	return b.Model().FaceSolid(above, cube.FaceDown, tx)
+}
+
+// HasLiquidDrops ...
+func (WeepingVines) HasLiquidDrops() bool {
+	return true
+}
+
+// FlammabilityInfo ...
+func (WeepingVines) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(0, 0, true)
+}
+
+// BreakInfo ...
+func (v WeepingVines) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, vinesDrops(v))
+}
+
+// CompostChance ...
+func (WeepingVines) CompostChance() float64 {
+	return 0.5
+}
+
+// EncodeItem ...
+func (WeepingVines) EncodeItem() (name string, meta int16) {
+	return "minecraft:weeping_vines", 0
+}
+
+// EncodeBlock ...
+func (v WeepingVines) EncodeBlock() (string, map[string]any) {
+	return "minecraft:weeping_vines", map[string]any{"weeping_vines_age": int32(v.Age)}
+}
+
+// vinesDrops returns the drops of weeping and twisting vines, which always
+// drop when broken using shears or silk touch and otherwise have a chance of
+// 1/3 to drop.
+func vinesDrops(it world.Item) func(item.Tool, []item.Enchantment) []item.Stack {
+	return func(t item.Tool, enchantments []item.Enchantment) []item.Stack {
+		if t.ToolType() == item.TypeShears || hasSilkTouch(enchantments) || rand.IntN(3) == 0 {
+			return []item.Stack{item.NewStack(it, 1)}
+		}
+		return nil
+	}
+}
+
+// allWeepingVines returns all possible states of weeping vines.
+func allWeepingVines() (b []world.Block) {
+	for i := 0; i < 26; i++ {
+		b = append(b, WeepingVines{Age: i})
+	}
+	return
+}
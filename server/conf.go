@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"time"
 	_ "unsafe"
 
 	"github.com/df-mc/dragonfly/server/block"
@@ -14,6 +15,7 @@ import (
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/playerdb"
+	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/biome"
 	"github.com/df-mc/dragonfly/server/world/generator"
@@ -66,6 +68,19 @@ type Config struct {
 	// MaxChunkRadius is the maximum view distance that each player may have,
 	// measured in chunks. A chunk radius generally leads to more memory usage.
 	MaxChunkRadius int
+	// DisableClientBlobCache specifies if the client blob cache should be
+	// disabled. By default, players whose client supports it are sent chunks
+	// as hashes of the sub chunks in them, so that only sub chunks that the
+	// client has not cached from an earlier visit are sent over the network.
+	// Disabling the client blob cache saves the memory used to hold sub chunks
+	// until clients have downloaded them, at the cost of bandwidth.
+	DisableClientBlobCache bool
+	// DisableSubChunkRequests specifies if chunks should always be sent to
+	// players as full chunks. By default, players are sent chunks without
+	// their blocks and request the sub chunks they need to render, which
+	// saves bandwidth in worlds with a large height. Clients that do not
+	// support sub chunk requests are always sent full chunks.
+	DisableSubChunkRequests bool
 	// JoinMessage, QuitMessage and ShutdownMessage are the messages to send for
 	// when a player joins or quits the server and when the server shuts down,
 	// kicking all online players. If set, JoinMessage and QuitMessage must have
@@ -77,6 +92,16 @@ type Config struct {
 	// list. By default, StatusProvider will show the server name from the Name
 	// field and the current player count and maximum players.
 	StatusProvider minecraft.ServerStatusProvider
+	// AttackKnockBack is the knock back that players deal to the entities
+	// they attack. If left empty, player.DefaultKnockBack is used. The knock
+	// back of a single player may be changed using
+	// player.Player.SetAttackKnockBack.
+	AttackKnockBack player.KnockBack
+	// LatencyPolicy decides when players are disconnected for having a high
+	// latency for a sustained period of time. If left empty, players are
+	// never disconnected for their latency. The policy of a single player may
+	// be changed using player.Player.SetLatencyPolicy.
+	LatencyPolicy player.LatencyPolicy
 	// PlayerProvider is the player.Provider used for storing and loading player
 	// data. If left as nil, player data will be newly created every time a
 	// player joins the server and no data will be stored.
@@ -105,6 +130,27 @@ type Config struct {
 	// may be added to the Server's worlds. If no entity types are registered,
 	// Entities will be set to entity.DefaultRegistry.
 	Entities world.EntityRegistry
+	// Profiling specifies if the default worlds should record the time spent
+	// ticking each of their chunks. The timings may be obtained by calling
+	// world.World.ChunkProfile.
+	Profiling bool
+	// MetricsExporter is the MetricsExporter that the Metrics of the Server
+	// are exported to once every MetricsInterval, for example so that they
+	// may be collected by Prometheus. If left as nil, the default worlds do
+	// not record any metrics. The Metrics may also be obtained at any time by
+	// calling Server.Metrics.
+	MetricsExporter MetricsExporter
+	// MetricsInterval is the interval at which Metrics are passed to the
+	// MetricsExporter. If left as 0, MetricsInterval defaults to 10 seconds.
+	MetricsInterval time.Duration
+	// IOWorkers and GenWorkers are the amount of goroutines that the default
+	// worlds load and store chunks on and generate new chunks on
+	// respectively. If left as 0, the defaults of world.Config are used.
+	IOWorkers, GenWorkers int
+	// SpawnChunkRadius is the radius in chunks around the spawn of the
+	// overworld that is kept loaded and ticking, even if no players are close
+	// to it. If left as 0, spawn chunks are not kept loaded.
+	SpawnChunkRadius int
 }
 
 // New creates a Server using fields of conf. The Server's worlds are created
@@ -138,6 +184,9 @@ func (conf Config) New() *Server {
 	if conf.MaxChunkRadius == 0 {
 		conf.MaxChunkRadius = 12
 	}
+	if conf.MetricsInterval <= 0 {
+		conf.MetricsInterval = time.Second * 10
+	}
 	if conf.ShutdownMessage.Zero() {
 		conf.ShutdownMessage = chat.MessageServerDisconnect
 	}
@@ -155,9 +204,14 @@ func (conf Config) New() *Server {
 	srv := &Server{
 		conf:     conf,
 		incoming: make(chan incoming),
+		closing:  make(chan struct{}),
 		p:        make(map[uuid.UUID]*onlinePlayer),
+		chunks:   session.NewChunkCache(chunkCacheSize),
 		world:    &world.World{}, nether: &world.World{}, end: &world.World{},
 	}
+	if !conf.DisableClientBlobCache {
+		srv.blobs = session.NewBlobStore(blobStoreSize)
+	}
 	for _, lf := range conf.Listeners {
 		l, err := lf(conf)
 		if err != nil {
@@ -189,6 +243,10 @@ type UserConfig struct {
 		// Address is the address on which the server should listen. Players may
 		// connect to this address in order to join.
 		Address string
+		// DisableClientBlobCache specifies if the client blob cache, which
+		// allows players to reuse chunks they downloaded before instead of
+		// downloading them again, should be disabled.
+		DisableClientBlobCache bool
 	}
 	Server struct {
 		// Name is the name of the server as it shows up in the server list.
@@ -211,6 +269,10 @@ type UserConfig struct {
 		SaveData bool
 		// Folder is the folder that the data of the world resides in.
 		Folder string
+		// SpawnChunkRadius is the radius in chunks around the spawn of the
+		// world that is kept loaded and ticking, even if no players are close
+		// to it. If set to 0, spawn chunks are not kept loaded.
+		SpawnChunkRadius int
 	}
 	Players struct {
 		// MaxCount is the maximum amount of players allowed to join the server
@@ -258,6 +320,8 @@ func (uc UserConfig) Config(log *slog.Logger) (Config, error) {
 		MaxPlayers:              uc.Players.MaxCount,
 		MaxChunkRadius:          uc.Players.MaximumChunkRadius,
 		DisableResourceBuilding: !uc.Resources.AutoBuildPack,
+		DisableClientBlobCache:  uc.Network.DisableClientBlobCache,
+		SpawnChunkRadius:        uc.World.SpawnChunkRadius,
 	}
 	if !uc.Server.DisableJoinQuitMessages {
 		conf.JoinMessage, conf.QuitMessage = chat.MessageJoin, chat.MessageQuit
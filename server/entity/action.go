@@ -51,6 +51,20 @@ type FireworkExplosionAction struct{ action }
 // TotemUseAction is a world.EntityAction that displays the totem use particles and animation.
 type TotemUseAction struct{ action }
 
+// TameAction is a world.EntityAction that displays the particles shown when an
+// attempt is made to tame an entity, such as a wolf.
+type TameAction struct {
+	// Success specifies if the entity was tamed. Hearts are displayed if true,
+	// smoke otherwise.
+	Success bool
+
+	action
+}
+
+// EatGrassAction is a world.EntityAction that makes a sheep lower its head to
+// eat the grass it is standing on.
+type EatGrassAction struct{ action }
+
 // action implements the Action interface. Structures in this package may embed it to gets its functionality
 // out of the box.
 type action struct{}
@@ -0,0 +1,54 @@
+package ai
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/pathfind"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// BehaviourConfig holds optional parameters for a Behaviour. It may be used
+// as the world.EntityConfig of custom mobs, of which the world.EntityType
+// opens an *entity.Mob.
+type BehaviourConfig struct {
+	// Mob holds the parameters of the underlying entity.MobBehaviour.
+	Mob entity.MobBehaviourConfig
+	// Pathfinder is used to find the paths that the mob walks along.
+	Pathfinder pathfind.Pathfinder
+	// Goals is called for every mob created to add its goals to its Brain.
+	// Goals hold state, such as the cooldown of an attack, so every mob must
+	// have its own goals.
+	Goals func(b *Brain)
+}
+
+func (conf BehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a Behaviour using the parameters in conf.
+func (conf BehaviourConfig) New() *Behaviour {
+	b := &Behaviour{MobBehaviour: conf.Mob.New(), brain: NewBrain(conf.Pathfinder)}
+	if conf.Goals != nil {
+		conf.Goals(b.brain)
+	}
+	return b
+}
+
+// Behaviour implements the behaviour of a mob driven by goals. Every tick,
+// the goals of the mob are run by its Brain before the mob moves.
+type Behaviour struct {
+	*entity.MobBehaviour
+	brain *Brain
+}
+
+// Brain returns the Brain that runs the goals of the mob.
+func (b *Behaviour) Brain() *Brain {
+	return b.brain
+}
+
+// Tick runs the goals of the mob and moves it.
+func (b *Behaviour) Tick(e *entity.Ent, tx *world.Tx) *entity.Movement {
+	if !b.Dead() {
+		b.brain.Tick(&entity.Mob{Ent: e}, tx)
+	}
+	return b.MobBehaviour.Tick(e, tx)
+}
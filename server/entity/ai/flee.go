@@ -0,0 +1,85 @@
+package ai
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Flee is a Goal that makes the mob run away from nearby entities matching a
+// filter, such as ocelots fleeing from players.
+type Flee struct {
+	// From returns true for entities that the mob runs away from.
+	From func(e entity.Living) bool
+	// Range is the distance to an entity within which the mob starts running
+	// away from it. If 0, a range of 8 is used.
+	Range float64
+	// Speed is the multiplier of the speed of the mob while it is fleeing. If
+	// 0, a multiplier of 1.25 is used.
+	Speed float64
+
+	dest cube.Pos
+}
+
+// Controls ...
+func (f *Flee) Controls() Control {
+	return ControlMove
+}
+
+// CanStart ...
+func (f *Flee) CanStart(b *Brain, m *entity.Mob, tx *world.Tx) bool {
+	if f.From == nil {
+		return false
+	}
+	threat, ok := tx.NearestEntity(m.Position(), f.rangeOrDefault(), func(e world.Entity) bool {
+		l, ok := e.(entity.Living)
+		return ok && e.H() != m.H() && !l.Dead() && f.From(l)
+	})
+	if !ok {
+		return false
+	}
+	away := m.Position().Sub(threat.Position())
+	away[1] = 0
+	if away.Len() == 0 {
+		away = mgl64.Vec3{1, 0, 0}
+	}
+	dest := cube.PosFromVec3(m.Position().Add(away.Normalize().Mul(f.rangeOrDefault())))
+	for y := 3; y >= -3; y-- {
+		if p := dest.Add(cube.Pos{0, y}); b.nav.Pathfinder().Standable(tx, p) {
+			f.dest = p
+			return true
+		}
+	}
+	return false
+}
+
+// CanContinue ...
+func (f *Flee) CanContinue(b *Brain, _ *entity.Mob, _ *world.Tx) bool {
+	return !b.nav.Done()
+}
+
+// Start ...
+func (f *Flee) Start(b *Brain, m *entity.Mob, tx *world.Tx) {
+	multiplier := f.Speed
+	if multiplier == 0 {
+		multiplier = 1.25
+	}
+	b.nav.MoveTo(m, tx, f.dest.Vec3Middle(), multiplier)
+}
+
+// Tick ...
+func (f *Flee) Tick(*Brain, *entity.Mob, *world.Tx) {}
+
+// Stop ...
+func (f *Flee) Stop(b *Brain, m *entity.Mob, _ *world.Tx) {
+	b.nav.Stop(m)
+}
+
+// rangeOrDefault returns the Range of the goal, or 8 if it is 0.
+func (f *Flee) rangeOrDefault() float64 {
+	if f.Range <= 0 {
+		return 8
+	}
+	return f.Range
+}
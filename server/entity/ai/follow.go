@@ -0,0 +1,87 @@
+package ai
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// repathTicks is the amount of ticks after which the path to a moving target
+// is recalculated.
+const repathTicks = 10
+
+// FollowTarget is a Goal that makes the mob follow its target, staying close
+// to it without attacking it.
+type FollowTarget struct {
+	// Speed is the multiplier of the speed of the mob while it is following
+	// its target. If 0, a multiplier of 1 is used.
+	Speed float64
+	// MinDistance is the distance to the target at which the mob stops
+	// walking towards it. If 0, a distance of 2 is used.
+	MinDistance float64
+	// MaxDistance is the distance to the target at which the mob gives up
+	// following it. If 0, a distance of 16 is used.
+	MaxDistance float64
+
+	ticks int
+}
+
+// Controls ...
+func (f *FollowTarget) Controls() Control {
+	return ControlMove | ControlLook
+}
+
+// CanStart ...
+func (f *FollowTarget) CanStart(b *Brain, m *entity.Mob, tx *world.Tx) bool {
+	target, ok := b.Target(tx)
+	if !ok {
+		return false
+	}
+	dist := target.Position().Sub(m.Position()).Len()
+	return dist > f.minDistance() && dist <= f.maxDistance()
+}
+
+// CanContinue ...
+func (f *FollowTarget) CanContinue(b *Brain, m *entity.Mob, tx *world.Tx) bool {
+	target, ok := b.Target(tx)
+	return ok && target.Position().Sub(m.Position()).Len() <= f.maxDistance()
+}
+
+// Start ...
+func (f *FollowTarget) Start(*Brain, *entity.Mob, *world.Tx) {
+	f.ticks = 0
+}
+
+// Tick ...
+func (f *FollowTarget) Tick(b *Brain, m *entity.Mob, tx *world.Tx) {
+	target, _ := b.Target(tx)
+	m.LookAt(entity.EyePosition(target))
+	if target.Position().Sub(m.Position()).Len() <= f.minDistance() {
+		b.nav.Stop(m)
+		return
+	}
+	if f.ticks--; f.ticks <= 0 || b.nav.Done() {
+		f.ticks = repathTicks
+		b.nav.MoveTo(m, tx, target.Position(), speed(f.Speed))
+	}
+}
+
+// Stop ...
+func (f *FollowTarget) Stop(b *Brain, m *entity.Mob, _ *world.Tx) {
+	b.nav.Stop(m)
+}
+
+// minDistance returns the MinDistance of the goal, or 2 if it is 0.
+func (f *FollowTarget) minDistance() float64 {
+	if f.MinDistance <= 0 {
+		return 2
+	}
+	return f.MinDistance
+}
+
+// maxDistance returns the MaxDistance of the goal, or 16 if it is 0.
+func (f *FollowTarget) maxDistance() float64 {
+	if f.MaxDistance <= 0 {
+		return 16
+	}
+	return f.MaxDistance
+}
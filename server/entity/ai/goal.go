@@ -0,0 +1,169 @@
+// Package ai implements goals that may be composed to give mobs their
+// behaviour, such as wandering around, chasing a target and attacking it in
+// melee. Goals are held by a Brain, which runs them based on their priority.
+package ai
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/pathfind"
+	"github.com/df-mc/dragonfly/server/world"
+	"slices"
+)
+
+// Control is a bitset of the parts of a mob that a Goal controls while it is
+// running. Two goals that control the same part of a mob never run at the
+// same time.
+type Control uint8
+
+const (
+	// ControlMove is set by goals that make the mob move.
+	ControlMove Control = 1 << iota
+	// ControlLook is set by goals that make the mob look somewhere.
+	ControlLook
+	// ControlTarget is set by goals that select the target of the mob.
+	ControlTarget
+)
+
+// Goal is a single behaviour of a mob, such as wandering around or attacking
+// its target. Goals are run by a Brain, which starts a goal when it may start
+// and ticks it until it may no longer continue or until a goal with a higher
+// priority that controls the same parts of the mob takes over.
+type Goal interface {
+	// Controls returns the parts of the mob that the goal controls.
+	Controls() Control
+	// CanStart checks if the goal may start running. It is called every
+	// tick while the goal is not running, so random goals should only
+	// return true occasionally.
+	CanStart(b *Brain, m *entity.Mob, tx *world.Tx) bool
+	// CanContinue checks if the goal should keep running. It is called every
+	// tick while the goal is running.
+	CanContinue(b *Brain, m *entity.Mob, tx *world.Tx) bool
+	// Start is called when the goal starts running.
+	Start(b *Brain, m *entity.Mob, tx *world.Tx)
+	// Tick is called every tick while the goal is running.
+	Tick(b *Brain, m *entity.Mob, tx *world.Tx)
+	// Stop is called when the goal stops running, either because it could
+	// not continue or because it was interrupted.
+	Stop(b *Brain, m *entity.Mob, tx *world.Tx)
+}
+
+// entry is a Goal added to a Brain with its priority.
+type entry struct {
+	priority int
+	goal     Goal
+	running  bool
+}
+
+// Brain runs the goals of a single mob. Goals with a lower priority value
+// take precedence over goals with a higher one: A goal that may start
+// interrupts running goals with a higher priority value that control the same
+// parts of the mob. A Brain also holds the target of the mob and the
+// Navigator used to move it along paths.
+type Brain struct {
+	goals  []*entry
+	nav    *pathfind.Navigator
+	target *world.EntityHandle
+}
+
+// NewBrain returns a Brain without goals, which moves the mob using the
+// Pathfinder passed.
+func NewBrain(p pathfind.Pathfinder) *Brain {
+	return &Brain{nav: pathfind.NewNavigator(p)}
+}
+
+// Add adds a Goal to the Brain with the priority passed. Goals with a lower
+// priority value take precedence over those with a higher one. Goals with the
+// same priority are considered in the order in which they are added.
+func (b *Brain) Add(priority int, g Goal) *Brain {
+	i := slices.IndexFunc(b.goals, func(e *entry) bool {
+		return e.priority > priority
+	})
+	if i == -1 {
+		i = len(b.goals)
+	}
+	b.goals = slices.Insert(b.goals, i, &entry{priority: priority, goal: g})
+	return b
+}
+
+// Navigator returns the Navigator that moves the mob along paths.
+func (b *Brain) Navigator() *pathfind.Navigator {
+	return b.nav
+}
+
+// Target returns the entity that the mob is currently targeting. False is
+// returned if the mob has no target or if the target is dead or no longer in
+// the world.
+func (b *Brain) Target(tx *world.Tx) (entity.Living, bool) {
+	if b.target == nil {
+		return nil, false
+	}
+	e, ok := b.target.Entity(tx)
+	if l, living := e.(entity.Living); ok && living && !l.Dead() {
+		return l, true
+	}
+	return nil, false
+}
+
+// SetTarget changes the target of the mob. Passing nil clears the target.
+func (b *Brain) SetTarget(e world.Entity) {
+	if e == nil {
+		b.target = nil
+		return
+	}
+	b.target = e.H()
+}
+
+// Running returns the goals that are currently running, ordered by priority.
+func (b *Brain) Running() []Goal {
+	var goals []Goal
+	for _, e := range b.goals {
+		if e.running {
+			goals = append(goals, e.goal)
+		}
+	}
+	return goals
+}
+
+// Tick ticks the Brain. Running goals that may not continue are stopped,
+// goals that may start are started, possibly interrupting running goals, and
+// all running goals are ticked. Finally, the mob is moved along the path of
+// its Navigator.
+func (b *Brain) Tick(m *entity.Mob, tx *world.Tx) {
+	for _, e := range b.goals {
+		if e.running && !e.goal.CanContinue(b, m, tx) {
+			e.running = false
+			e.goal.Stop(b, m, tx)
+		}
+	}
+	for _, e := range b.goals {
+		if e.running || !b.available(e) || !e.goal.CanStart(b, m, tx) {
+			continue
+		}
+		for _, other := range b.goals {
+			if other.running && other.goal.Controls()&e.goal.Controls() != 0 {
+				other.running = false
+				other.goal.Stop(b, m, tx)
+			}
+		}
+		e.running = true
+		e.goal.Start(b, m, tx)
+	}
+	for _, e := range b.goals {
+		if e.running {
+			e.goal.Tick(b, m, tx)
+		}
+	}
+	b.nav.Tick(m)
+}
+
+// available checks if the goal of the entry passed could start without
+// interrupting a running goal with the same or a lower priority value that
+// controls the same parts of the mob.
+func (b *Brain) available(e *entry) bool {
+	for _, other := range b.goals {
+		if other.running && other.priority <= e.priority && other.goal.Controls()&e.goal.Controls() != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package ai
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/pathfind"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/generator"
+)
+
+// testMobType is a world.EntityType of a custom mob driven by a Behaviour.
+type testMobType struct{}
+
+func (testMobType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &entity.Mob{Ent: entity.Open(tx, handle, data)}
+}
+func (testMobType) EncodeEntity() string { return "minecraft:zombie" }
+func (testMobType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.95, 0.3)
+}
+func (testMobType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (testMobType) EncodeNBT(*world.EntityData) map[string]any  { return nil }
+
+func TestBrainInterruptsWanderingToChasePlayer(t *testing.T) {
+	flat := generator.NewFlat(biome.Plains{}, []world.Block{block.Stone{}})
+	w := world.Config{Generator: flat}.New()
+	defer w.Close()
+
+	wander, target, attack := &Wander{Chance: 1}, &NearestTarget{Interval: 1}, &MeleeAttack{Damage: 1}
+	conf := BehaviourConfig{
+		Mob:        entity.MobBehaviourConfig{MaxHealth: 20, Speed: 0.1},
+		Pathfinder: pathfind.Pathfinder{Height: 2},
+		Goals: func(b *Brain) {
+			b.Add(1, target).Add(1, attack).Add(2, wander)
+		},
+	}
+	<-w.Exec(func(tx *world.Tx) {
+		opts := world.EntitySpawnOpts{Position: cube.Pos{0, -63, 0}.Vec3Middle()}
+		m := tx.AddEntity(opts.New(testMobType{}, conf)).(*entity.Mob)
+		brain := m.Behaviour().(*Behaviour).Brain()
+
+		// Without a player nearby, the mob can only wander around.
+		for i := 0; !slices.Contains(brain.Running(), Goal(wander)); i++ {
+			if i == 20 {
+				t.Errorf("expected mob to start wandering without a target")
+				return
+			}
+			m.Tick(tx, int64(i))
+		}
+
+		conf := player.Config{Name: "test", GameMode: world.GameModeSurvival, Position: cube.Pos{6, -63, 0}.Vec3Middle()}
+		p := tx.AddEntity(world.EntitySpawnOpts{Position: conf.Position}.New(player.Type, conf)).(*player.Player)
+		m.Tick(tx, 20)
+		running := brain.Running()
+		if !slices.Contains(running, Goal(target)) || !slices.Contains(running, Goal(attack)) {
+			t.Errorf("expected mob to target and chase the player, running goals are %v", running)
+			return
+		}
+		if slices.Contains(running, Goal(wander)) {
+			t.Errorf("expected chasing the player to interrupt wandering")
+		}
+		if l, ok := brain.Target(tx); !ok || l.H() != p.H() {
+			t.Errorf("expected mob to target the player")
+		}
+
+		for i := range 200 {
+			m.Tick(tx, int64(21+i))
+			if p.Health() < p.MaxHealth() {
+				return
+			}
+		}
+		t.Errorf("expected mob to reach and attack the player, mob is at %v", m.Position())
+	})
+}
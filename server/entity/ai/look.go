@@ -0,0 +1,77 @@
+package ai
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+)
+
+// LookAtPlayer is a Goal that makes the mob look at a nearby player for a
+// while every now and then.
+type LookAtPlayer struct {
+	// Range is the distance within which the mob looks at players. If 0, a
+	// range of 8 is used.
+	Range float64
+	// Chance is the chance, 1 in Chance, that the mob starts looking at a
+	// nearby player in a tick. If 0, a chance of 1 in 50 is used.
+	Chance int
+
+	player *world.EntityHandle
+	ticks  int
+}
+
+// Controls ...
+func (l *LookAtPlayer) Controls() Control {
+	return ControlLook
+}
+
+// CanStart ...
+func (l *LookAtPlayer) CanStart(_ *Brain, m *entity.Mob, tx *world.Tx) bool {
+	chance := l.Chance
+	if chance <= 0 {
+		chance = 50
+	}
+	if rand.IntN(chance) != 0 {
+		return false
+	}
+	p, ok := tx.NearestEntity(m.Position(), l.rangeOrDefault(), func(e world.Entity) bool {
+		_, player := e.(interface{ GameMode() world.GameMode })
+		return player
+	})
+	if ok {
+		l.player = p.H()
+	}
+	return ok
+}
+
+// CanContinue ...
+func (l *LookAtPlayer) CanContinue(_ *Brain, m *entity.Mob, tx *world.Tx) bool {
+	p, ok := l.player.Entity(tx)
+	return ok && l.ticks > 0 && p.Position().Sub(m.Position()).Len() <= l.rangeOrDefault()
+}
+
+// Start ...
+func (l *LookAtPlayer) Start(*Brain, *entity.Mob, *world.Tx) {
+	l.ticks = 40 + rand.IntN(40)
+}
+
+// Tick ...
+func (l *LookAtPlayer) Tick(_ *Brain, m *entity.Mob, tx *world.Tx) {
+	l.ticks--
+	if p, ok := l.player.Entity(tx); ok {
+		m.LookAt(entity.EyePosition(p))
+	}
+}
+
+// Stop ...
+func (l *LookAtPlayer) Stop(*Brain, *entity.Mob, *world.Tx) {
+	l.player = nil
+}
+
+// rangeOrDefault returns the Range of the goal, or 8 if it is 0.
+func (l *LookAtPlayer) rangeOrDefault() float64 {
+	if l.Range <= 0 {
+		return 8
+	}
+	return l.Range
+}
@@ -0,0 +1,78 @@
+package ai
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// MeleeAttack is a Goal that makes the mob chase its target and attack it
+// once it is within reach.
+type MeleeAttack struct {
+	// Damage is the damage dealt to the target by every attack, before the
+	// attack damage modifiers of the mob are applied.
+	Damage float64
+	// Speed is the multiplier of the speed of the mob while it is chasing
+	// its target. If 0, a multiplier of 1 is used.
+	Speed float64
+	// Reach is the maximum distance to the target at which the mob is able
+	// to attack it. If 0, a reach of 2 is used.
+	Reach float64
+	// Cooldown is the amount of ticks between two attacks. If 0, a cooldown
+	// of 20 ticks is used.
+	Cooldown int
+
+	ticks, cooldown int
+}
+
+// Controls ...
+func (a *MeleeAttack) Controls() Control {
+	return ControlMove | ControlLook
+}
+
+// CanStart ...
+func (a *MeleeAttack) CanStart(b *Brain, _ *entity.Mob, tx *world.Tx) bool {
+	_, ok := b.Target(tx)
+	return ok
+}
+
+// CanContinue ...
+func (a *MeleeAttack) CanContinue(b *Brain, _ *entity.Mob, tx *world.Tx) bool {
+	_, ok := b.Target(tx)
+	return ok
+}
+
+// Start ...
+func (a *MeleeAttack) Start(*Brain, *entity.Mob, *world.Tx) {
+	a.ticks = 0
+}
+
+// Tick ...
+func (a *MeleeAttack) Tick(b *Brain, m *entity.Mob, tx *world.Tx) {
+	target, _ := b.Target(tx)
+	m.LookAt(entity.EyePosition(target))
+	if a.ticks--; a.ticks <= 0 || b.nav.Done() {
+		a.ticks = repathTicks
+		b.nav.MoveTo(m, tx, target.Position(), speed(a.Speed))
+	}
+	if a.cooldown > 0 {
+		a.cooldown--
+		return
+	}
+	reach := a.Reach
+	if reach <= 0 {
+		reach = 2
+	}
+	if target.Position().Sub(m.Position()).Len() > reach {
+		return
+	}
+	a.cooldown = a.Cooldown
+	if a.cooldown <= 0 {
+		a.cooldown = 20
+	}
+	m.Attack(target, a.Damage)
+}
+
+// Stop ...
+func (a *MeleeAttack) Stop(b *Brain, m *entity.Mob, _ *world.Tx) {
+	b.nav.Stop(m)
+}
@@ -0,0 +1,89 @@
+package ai
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NearestTarget is a Goal that makes the mob target the nearest living entity
+// matching a filter. Entities are looked up using the spatial index of the
+// world, so only entities close to the mob are checked.
+type NearestTarget struct {
+	// Range is the distance within which the mob looks for and keeps its
+	// target. If 0, a range of 16 is used.
+	Range float64
+	// Filter returns true for entities that the mob may target. If nil, the
+	// mob targets players that are able to take damage.
+	Filter func(e entity.Living) bool
+	// Interval is the amount of ticks between every time that the mob looks
+	// for a target. If 0, an interval of 10 ticks is used.
+	Interval int
+
+	ticks int
+}
+
+// Controls ...
+func (t *NearestTarget) Controls() Control {
+	return ControlTarget
+}
+
+// CanStart ...
+func (t *NearestTarget) CanStart(b *Brain, m *entity.Mob, tx *world.Tx) bool {
+	interval := t.Interval
+	if interval <= 0 {
+		interval = 10
+	}
+	if t.ticks++; t.ticks < interval {
+		return false
+	}
+	t.ticks = 0
+	e, ok := tx.NearestEntity(m.Position(), t.rangeOrDefault(), func(e world.Entity) bool {
+		l, ok := e.(entity.Living)
+		return ok && e.H() != m.H() && !l.Dead() && t.filter(l)
+	})
+	if ok {
+		b.SetTarget(e)
+	}
+	return ok
+}
+
+// CanContinue ...
+func (t *NearestTarget) CanContinue(b *Brain, m *entity.Mob, tx *world.Tx) bool {
+	target, ok := b.Target(tx)
+	return ok && t.filter(target) && target.Position().Sub(m.Position()).Len() <= t.rangeOrDefault()
+}
+
+// Start ...
+func (t *NearestTarget) Start(*Brain, *entity.Mob, *world.Tx) {}
+
+// Tick ...
+func (t *NearestTarget) Tick(*Brain, *entity.Mob, *world.Tx) {}
+
+// Stop ...
+func (t *NearestTarget) Stop(b *Brain, _ *entity.Mob, _ *world.Tx) {
+	b.SetTarget(nil)
+}
+
+// rangeOrDefault returns the Range of the goal, or 16 if it is 0.
+func (t *NearestTarget) rangeOrDefault() float64 {
+	if t.Range <= 0 {
+		return 16
+	}
+	return t.Range
+}
+
+// filter checks if the entity passed may be targeted.
+func (t *NearestTarget) filter(e entity.Living) bool {
+	if t.Filter == nil {
+		return AttackablePlayer(e)
+	}
+	return t.Filter(e)
+}
+
+// AttackablePlayer checks if the entity passed is a player in a game mode
+// that allows it to take damage. It may be used as the Filter of a
+// NearestTarget goal.
+func AttackablePlayer(e entity.Living) bool {
+	g, ok := e.(interface{ GameMode() world.GameMode })
+	return ok && g.GameMode().AllowsTakingDamage()
+}
@@ -0,0 +1,84 @@
+package ai
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+)
+
+// Wander is a Goal that makes the mob walk to a random position close to it
+// every now and then.
+type Wander struct {
+	// Speed is the multiplier of the speed of the mob while it is wandering.
+	// If 0, a multiplier of 1 is used.
+	Speed float64
+	// Radius is the maximum horizontal distance in blocks of the positions
+	// that the mob wanders to. If 0, a radius of 10 is used.
+	Radius int
+	// Chance is the chance, 1 in Chance, that the mob starts wandering in a
+	// tick. If 0, a chance of 1 in 120 is used.
+	Chance int
+}
+
+// Controls ...
+func (w *Wander) Controls() Control {
+	return ControlMove
+}
+
+// CanStart ...
+func (w *Wander) CanStart(b *Brain, _ *entity.Mob, _ *world.Tx) bool {
+	chance := w.Chance
+	if chance <= 0 {
+		chance = 120
+	}
+	return b.nav.Done() && rand.IntN(chance) == 0
+}
+
+// CanContinue ...
+func (w *Wander) CanContinue(b *Brain, _ *entity.Mob, _ *world.Tx) bool {
+	return !b.nav.Done()
+}
+
+// Start ...
+func (w *Wander) Start(b *Brain, m *entity.Mob, tx *world.Tx) {
+	radius := w.Radius
+	if radius <= 0 {
+		radius = 10
+	}
+	if pos, ok := randomStandable(b, m, tx, radius); ok {
+		b.nav.MoveTo(m, tx, pos.Vec3Middle(), speed(w.Speed))
+	}
+}
+
+// Tick ...
+func (w *Wander) Tick(*Brain, *entity.Mob, *world.Tx) {}
+
+// Stop ...
+func (w *Wander) Stop(b *Brain, m *entity.Mob, _ *world.Tx) {
+	b.nav.Stop(m)
+}
+
+// randomStandable looks for a random position within the horizontal radius
+// passed around the mob that the mob is able to stand at. False is returned
+// if no such position was found.
+func randomStandable(b *Brain, m *entity.Mob, tx *world.Tx, radius int) (cube.Pos, bool) {
+	centre := cube.PosFromVec3(m.Position())
+	for range 10 {
+		pos := centre.Add(cube.Pos{rand.IntN(radius*2+1) - radius, 0, rand.IntN(radius*2+1) - radius})
+		for y := 3; y >= -3; y-- {
+			if p := pos.Add(cube.Pos{0, y}); b.nav.Pathfinder().Standable(tx, p) {
+				return p, true
+			}
+		}
+	}
+	return cube.Pos{}, false
+}
+
+// speed returns the speed multiplier passed, or 1 if it is 0.
+func speed(multiplier float64) float64 {
+	if multiplier == 0 {
+		return 1
+	}
+	return multiplier
+}
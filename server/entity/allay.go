@@ -0,0 +1,506 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"math/rand/v2"
+	"time"
+)
+
+// NewAllay creates a new allay that does not yet like any item.
+func NewAllay(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(AllayType, allayConf)
+}
+
+var allayConf = AllayBehaviourConfig{}
+
+// AllayBehaviourConfig holds optional parameters for an AllayBehaviour.
+type AllayBehaviourConfig struct {
+	// Owner is the UUID of the player that the allay delivers the items it
+	// collects to.
+	Owner uuid.UUID
+	// Item is the item that the allay likes and collects. If empty, the allay
+	// does not collect any items until it is given one.
+	Item item.Stack
+}
+
+func (conf AllayBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates an AllayBehaviour using the parameters in conf.
+func (conf AllayBehaviourConfig) New() *AllayBehaviour {
+	a := &AllayBehaviour{owner: conf.Owner}
+	if !conf.Item.Empty() {
+		a.liked = conf.Item.Grow(1 - conf.Item.Count())
+	}
+	a.MobBehaviour = MobBehaviourConfig{
+		MaxHealth: 20,
+		Speed:     0.03,
+		Drag:      0.1,
+		Flying:    true,
+		Drops:     a.drops,
+	}.New()
+	return a
+}
+
+const (
+	// allayCollectRadius is the radius in blocks within which an allay looks
+	// for items that it likes.
+	allayCollectRadius = 32
+	// allayOwnerRadius is the maximum distance in blocks between an allay and
+	// its owner for the allay to deliver items to its owner.
+	allayOwnerRadius = 64
+	// allayDeliverDistance is the maximum distance in blocks from which an
+	// allay throws its items towards their destination.
+	allayDeliverDistance = 2.5
+	// allayNoteBlockTicks is the amount of ticks that an allay keeps
+	// delivering its items to a note block after hearing it play.
+	allayNoteBlockTicks = 600
+	// allayPickupCooldown is the amount of ticks after delivering items that
+	// an allay does not collect items, so that it does not pick up the items
+	// that it just delivered.
+	allayPickupCooldown = 60
+	// allayDuplicationCooldown is the amount of ticks after duplicating before
+	// an allay may duplicate again.
+	allayDuplicationCooldown = 600
+	// allayJukeboxRadius is the radius in blocks within which an allay dances
+	// to a playing jukebox.
+	allayJukeboxRadius = 10
+)
+
+// AllayBehaviour implements the behaviour of allays. An allay that is given
+// an item collects items of the same type close to it and delivers them to
+// the player that gave it the item, or to a note block that it heard play
+// recently. Allays dance to jukeboxes and duplicate when given an amethyst
+// shard while dancing.
+type AllayBehaviour struct {
+	*MobBehaviour
+
+	owner       uuid.UUID
+	ownerHandle *world.EntityHandle
+
+	liked     item.Stack
+	inventory item.Stack
+	target    *world.EntityHandle
+
+	noteBlock      *cube.Pos
+	noteBlockTicks int
+	jukebox        *cube.Pos
+
+	pickupCooldown      int
+	duplicationCooldown int
+}
+
+// Owner returns the handle of the player that the allay delivers items to if
+// it is currently in the same world as the allay. Nil is returned otherwise.
+func (a *AllayBehaviour) Owner() *world.EntityHandle {
+	return a.ownerHandle
+}
+
+// OwnerUUID returns the UUID of the player that the allay delivers items to,
+// or uuid.Nil if the allay has no owner.
+func (a *AllayBehaviour) OwnerUUID() uuid.UUID {
+	return a.owner
+}
+
+// LikedItem returns the item that the allay likes and collects. The stack
+// returned is empty if the allay does not like any item.
+func (a *AllayBehaviour) LikedItem() item.Stack {
+	return a.liked
+}
+
+// Inventory returns the items that the allay collected and has not yet
+// delivered.
+func (a *AllayBehaviour) Inventory() item.Stack {
+	return a.inventory
+}
+
+// NoteBlock returns the position of the note block that the allay delivers
+// its items to. False is returned if the allay has not heard a note block
+// play recently.
+func (a *AllayBehaviour) NoteBlock() (cube.Pos, bool) {
+	if a.noteBlock == nil {
+		return cube.Pos{}, false
+	}
+	return *a.noteBlock, true
+}
+
+// Dancing checks if the allay is dancing to a jukebox close to it.
+func (a *AllayBehaviour) Dancing() bool {
+	return a.jukebox != nil
+}
+
+// HeldItems returns the item that the allay likes as its main hand item.
+func (a *AllayBehaviour) HeldItems() (mainHand, offHand item.Stack) {
+	return a.liked, item.Stack{}
+}
+
+// Interact gives the item held by the user to the allay, which then starts
+// collecting items of the same type for the user. If the user does not hold
+// an item, the allay gives back the item it likes. Giving an amethyst shard
+// to a dancing allay makes it duplicate.
+func (a *AllayBehaviour) Interact(m *Mob, user item.User, tx *world.Tx) bool {
+	held, left := user.HeldItems()
+	if _, ok := held.Item().(item.AmethystShard); ok && a.Dancing() && a.duplicationCooldown == 0 {
+		consumeHeldItem(user)
+		a.duplicate(m, tx)
+		return true
+	}
+	switch {
+	case held.Empty() && !a.liked.Empty():
+		user.SetHeldItems(a.liked, left)
+		a.dropInventory(m, tx)
+		a.liked, a.owner, a.ownerHandle, a.target = item.Stack{}, uuid.Nil, nil, nil
+	case !held.Empty() && a.liked.Empty():
+		a.liked = held.Grow(1 - held.Count())
+		a.owner, a.ownerHandle = user.H().UUID(), user.H()
+		consumeHeldItem(user)
+	default:
+		return false
+	}
+	a.StopMoving()
+	for _, v := range tx.Viewers(m.Position()) {
+		v.ViewEntityItems(m)
+	}
+	return true
+}
+
+// duplicate spawns a new allay at the position of the allay. Both allays are
+// unable to duplicate again until their cooldown expires.
+func (a *AllayBehaviour) duplicate(m *Mob, tx *world.Tx) {
+	a.duplicationCooldown = allayDuplicationCooldown
+
+	b := allayConf.New()
+	b.duplicationCooldown = allayDuplicationCooldown
+	opts := world.EntitySpawnOpts{Position: m.Position(), Rotation: m.Rotation()}
+	tx.AddEntity(opts.New(AllayType, mobConfig{b: b}))
+}
+
+// GameEventRange ...
+func (a *AllayBehaviour) GameEventRange() float64 {
+	return 16
+}
+
+// ReceiveGameEvent makes the allay deliver its items to note blocks that it
+// hears play and makes it dance to jukeboxes close to it.
+func (a *AllayBehaviour) ReceiveGameEvent(m *Mob, ev world.GameEvent, pos mgl64.Vec3, _ world.Entity, _ *world.Tx) {
+	blockPos := cube.PosFromVec3(pos)
+	switch ev.(type) {
+	case gameevent.NoteBlockPlay:
+		if !a.liked.Empty() {
+			a.noteBlock, a.noteBlockTicks = &blockPos, allayNoteBlockTicks
+		}
+	case gameevent.JukeboxPlay:
+		if pos.Sub(m.Position()).Len() <= allayJukeboxRadius {
+			a.jukebox = &blockPos
+			a.StopMoving()
+			m.updateState()
+		}
+	case gameevent.JukeboxStopPlay:
+		if a.jukebox != nil && *a.jukebox == blockPos {
+			a.jukebox = nil
+			m.updateState()
+		}
+	}
+}
+
+// Tick ticks the allay, making it collect and deliver items.
+func (a *AllayBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !a.Dead() {
+		a.tickAllay(&Mob{Ent: e}, tx)
+	}
+	return a.MobBehaviour.Tick(e, tx)
+}
+
+// tickAllay performs the allay specific logic of a tick.
+func (a *AllayBehaviour) tickAllay(m *Mob, tx *world.Tx) {
+	if a.pickupCooldown > 0 {
+		a.pickupCooldown--
+	}
+	if a.duplicationCooldown > 0 {
+		a.duplicationCooldown--
+	}
+	if a.noteBlockTicks > 0 {
+		if a.noteBlockTicks--; a.noteBlockTicks == 0 {
+			a.noteBlock = nil
+		}
+	}
+	if a.noteBlock != nil && m.Age()%time.Second == 0 {
+		if _, ok := tx.Block(*a.noteBlock).(block.Note); !ok {
+			a.noteBlock, a.noteBlockTicks = nil, 0
+		}
+	}
+	if a.jukebox != nil {
+		if m.Age()%time.Second == 0 && !allayJukeboxPlaying(m, *a.jukebox, tx) {
+			a.jukebox = nil
+			m.updateState()
+		} else {
+			// Dancing allays stay where they are until the music stops.
+			a.StopMoving()
+			return
+		}
+	}
+	if a.liked.Empty() {
+		a.wander(m, m.Position())
+		return
+	}
+	owner := a.findOwner(m, tx)
+	if a.collect(m, tx) || a.deliver(m, owner, tx) {
+		return
+	}
+	switch {
+	case a.noteBlock != nil:
+		a.wander(m, a.noteBlock.Vec3Centre())
+	case owner != nil:
+		if owner.Position().Sub(m.Position()).Len() > 4 {
+			a.MoveTo(owner.Position().Add(mgl64.Vec3{0, 1}), 1)
+		}
+	default:
+		a.wander(m, m.Position())
+	}
+}
+
+// wander occasionally makes the allay fly to a random position close to the
+// centre passed.
+func (a *AllayBehaviour) wander(m *Mob, centre mgl64.Vec3) {
+	if !a.Moving() && rand.IntN(60) == 0 {
+		a.MoveTo(centre.Add(randomHorizontalOffset(4)).Add(mgl64.Vec3{0, float64(rand.IntN(3))}), 0.6)
+	}
+}
+
+// collect makes the allay fly towards the closest item that it likes and
+// pick it up once it is close enough. False is returned if the allay is not
+// collecting any items.
+func (a *AllayBehaviour) collect(m *Mob, tx *world.Tx) bool {
+	if a.pickupCooldown > 0 || (!a.inventory.Empty() && a.inventory.Count() >= a.inventory.MaxCount()) {
+		a.target = nil
+		return false
+	}
+	if a.target != nil {
+		if e, ok := a.target.Entity(tx); !ok || !a.wants(e) || e.Position().Sub(m.Position()).Len() > allayCollectRadius {
+			a.target = nil
+		}
+	}
+	if a.target == nil && m.Age()%(time.Second/2) == 0 {
+		a.target = a.findItem(m, tx)
+	}
+	if a.target == nil {
+		return false
+	}
+	e, _ := a.target.Entity(tx)
+	if e.Position().Sub(m.Position()).Len() > 1.5 {
+		a.MoveTo(e.Position().Add(mgl64.Vec3{0, 0.5}), 1)
+		return true
+	}
+	ent := e.(*Ent)
+	i := ent.Behaviour().(*ItemBehaviour)
+	collected, left := i.Item(), item.Stack{}
+	if !a.inventory.Empty() {
+		collected, left = a.inventory.AddStack(collected)
+	}
+	a.inventory, a.target = collected, nil
+	for _, v := range tx.Viewers(e.Position()) {
+		v.ViewEntityAction(ent, PickedUpAction{Collector: m})
+	}
+	if !left.Empty() {
+		tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: e.Position()}, left))
+	}
+	_ = ent.Close()
+	return true
+}
+
+// findItem returns the closest item entity that the allay likes and can see.
+// Nil is returned if no such item entity was found.
+func (a *AllayBehaviour) findItem(m *Mob, tx *world.Tx) *world.EntityHandle {
+	var (
+		pos     = m.Position()
+		eyePos  = EyePosition(m)
+		nearest *world.EntityHandle
+		dist    = float64(allayCollectRadius)
+	)
+	for e := range tx.EntitiesWithin(cube.Box(-allayCollectRadius, -allayCollectRadius, -allayCollectRadius, allayCollectRadius, allayCollectRadius, allayCollectRadius).Translate(pos)) {
+		if !a.wants(e) {
+			continue
+		}
+		if d := e.Position().Sub(pos).Len(); d <= dist && lineOfSight(eyePos, e.Position().Add(mgl64.Vec3{0, 0.25}), tx) {
+			nearest, dist = e.H(), d
+		}
+	}
+	return nearest
+}
+
+// wants checks if the entity passed is an item entity holding an item that
+// the allay likes and that fits in its inventory.
+func (a *AllayBehaviour) wants(e world.Entity) bool {
+	ent, ok := e.(*Ent)
+	if !ok || e.H().Type() != ItemType {
+		return false
+	}
+	i, ok := ent.Behaviour().(*ItemBehaviour)
+	if !ok || i.pickupDelay > 0 {
+		return false
+	}
+	if a.noteBlock != nil && e.Position().Sub(a.noteBlock.Side(cube.FaceUp).Vec3Middle()).Len() <= allayDeliverDistance {
+		// Items delivered to the note block are not collected again.
+		return false
+	}
+	s := i.Item()
+	name, meta := s.Item().EncodeItem()
+	likedName, likedMeta := a.liked.Item().EncodeItem()
+	return name == likedName && meta == likedMeta && a.inventory.Comparable(s)
+}
+
+// deliver makes the allay fly towards the note block it heard recently, or
+// its owner otherwise, and throw the items it collected towards it. False is
+// returned if the allay has nothing to deliver or nowhere to deliver it to.
+func (a *AllayBehaviour) deliver(m *Mob, owner world.Entity, tx *world.Tx) bool {
+	if a.inventory.Empty() {
+		return false
+	}
+	var target mgl64.Vec3
+	switch {
+	case a.noteBlock != nil:
+		target = a.noteBlock.Side(cube.FaceUp).Vec3Middle()
+	case owner != nil:
+		target = owner.Position().Add(mgl64.Vec3{0, 0.5})
+	default:
+		return false
+	}
+	from := m.Position().Add(mgl64.Vec3{0, 0.3})
+	if target.Sub(from).Len() > allayDeliverDistance || !lineOfSight(from, target, tx) {
+		a.MoveTo(target.Add(mgl64.Vec3{0, 1}), 1)
+		return true
+	}
+	a.StopMoving()
+	a.LookAt(target)
+	a.throw(from, target, tx)
+	a.pickupCooldown = allayPickupCooldown
+	return true
+}
+
+// throw throws the items in the inventory of the allay from one position
+// towards another. If the position the items are thrown from is inside a
+// block, the items are dropped at the target instead, so that they do not get
+// stuck in walls.
+func (a *AllayBehaviour) throw(from, to mgl64.Vec3, tx *world.Tx) {
+	pos := cube.PosFromVec3(from)
+	var vel mgl64.Vec3
+	if len(tx.Block(pos).Model().BBox(pos, tx)) != 0 {
+		from = to
+	} else if delta := to.Sub(from); delta.Len() > 0.1 {
+		// The items are thrown only as far as the target, which the allay has
+		// a clear line of sight to.
+		vel = delta.Mul(0.1).Add(mgl64.Vec3{0, 0.1})
+	}
+	tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: from, Velocity: vel}, a.inventory))
+	a.inventory = item.Stack{}
+}
+
+// dropInventory drops all items that the allay collected.
+func (a *AllayBehaviour) dropInventory(m *Mob, tx *world.Tx) {
+	if !a.inventory.Empty() {
+		tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: m.Position().Add(mgl64.Vec3{0, 0.3})}, a.inventory))
+		a.inventory = item.Stack{}
+	}
+}
+
+// drops returns the item that the allay likes and the items it collected.
+func (a *AllayBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	var drops []item.Stack
+	for _, s := range [...]item.Stack{a.liked, a.inventory} {
+		if !s.Empty() {
+			drops = append(drops, s)
+		}
+	}
+	return drops
+}
+
+// findOwner looks up the owner of the allay in the world and returns it if it
+// is close enough to the allay to deliver items to.
+func (a *AllayBehaviour) findOwner(m *Mob, tx *world.Tx) world.Entity {
+	if a.owner == uuid.Nil {
+		return nil
+	}
+	var owner world.Entity
+	if a.ownerHandle != nil {
+		if e, ok := a.ownerHandle.Entity(tx); ok {
+			owner = e
+		} else {
+			a.ownerHandle = nil
+		}
+	}
+	if owner == nil {
+		for p := range tx.Players() {
+			if p.H().UUID() == a.owner {
+				owner, a.ownerHandle = p, p.H()
+				break
+			}
+		}
+	}
+	if owner == nil || owner.Position().Sub(m.Position()).Len() > allayOwnerRadius {
+		return nil
+	}
+	return owner
+}
+
+// allayJukeboxPlaying checks if the block at the position passed is a jukebox
+// that is playing a music disc within dancing range of the allay.
+func allayJukeboxPlaying(m *Mob, pos cube.Pos, tx *world.Tx) bool {
+	j, ok := tx.Block(pos).(block.Jukebox)
+	if !ok || pos.Vec3Centre().Sub(m.Position()).Len() > allayJukeboxRadius {
+		return false
+	}
+	_, playing := j.Disc()
+	return playing
+}
+
+// AllayType is a world.EntityType implementation for allays.
+var AllayType allayType
+
+type allayType struct{}
+
+func (allayType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (allayType) EncodeEntity() string      { return "minecraft:allay" }
+func (allayType) ListensToGameEvents() bool { return true }
+func (allayType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.175, 0, -0.175, 0.175, 0.6, 0.175)
+}
+
+func (allayType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := allayConf
+	if id, err := uuid.Parse(nbtconv.String(m, "OwnerUUID")); err == nil {
+		conf.Owner = id
+	}
+	conf.Item = nbtconv.MapItem(m, "Mainhand")
+	a := conf.New()
+	a.MobBehaviour.decodeNBT(m)
+	a.inventory = nbtconv.MapItem(m, "Inventory")
+	a.duplicationCooldown = int(nbtconv.Int32(m, "DuplicationCooldown"))
+	data.Data = a
+}
+
+func (allayType) EncodeNBT(data *world.EntityData) map[string]any {
+	a := data.Data.(*AllayBehaviour)
+	m := map[string]any{"DuplicationCooldown": int32(a.duplicationCooldown)}
+	if a.owner != uuid.Nil {
+		m["OwnerUUID"] = a.owner.String()
+	}
+	if !a.liked.Empty() {
+		m["Mainhand"] = nbtconv.WriteItem(a.liked, true)
+	}
+	if !a.inventory.Empty() {
+		m["Inventory"] = nbtconv.WriteItem(a.inventory, true)
+	}
+	a.MobBehaviour.encodeNBT(m)
+	return m
+}
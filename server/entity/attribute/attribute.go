@@ -0,0 +1,140 @@
+// Package attribute implements the attributes of entities, such as their
+// maximum health and movement speed, and the modifiers that may be applied to
+// them by effects, items and other sources.
+package attribute
+
+import (
+	"math"
+)
+
+// Attribute is a property of an entity, such as its maximum health or its
+// movement speed. The value of an Attribute is computed from a base value and
+// the Modifiers applied to it.
+type Attribute struct {
+	attribute
+}
+
+// MaxHealth is the maximum health of an entity.
+func MaxHealth() Attribute {
+	return Attribute{0}
+}
+
+// MovementSpeed is the velocity added to an entity every tick while it is
+// walking on the ground.
+func MovementSpeed() Attribute {
+	return Attribute{1}
+}
+
+// AttackDamage is the damage dealt by an entity when it attacks another
+// entity.
+func AttackDamage() Attribute {
+	return Attribute{2}
+}
+
+// KnockBackResistance is the resistance of an entity to knock back, ranging
+// from 0 (no resistance) to 1 (full resistance).
+func KnockBackResistance() Attribute {
+	return Attribute{3}
+}
+
+// FollowRange is the distance in blocks within which a mob notices and
+// follows its targets.
+func FollowRange() Attribute {
+	return Attribute{4}
+}
+
+// All returns all existing attributes.
+func All() []Attribute {
+	return []Attribute{MaxHealth(), MovementSpeed(), AttackDamage(), KnockBackResistance(), FollowRange()}
+}
+
+// ByName returns the Attribute with the name passed, such as
+// "minecraft:movement". False is returned if no such attribute exists.
+func ByName(name string) (Attribute, bool) {
+	for _, a := range All() {
+		if a.Name() == name {
+			return a, true
+		}
+	}
+	return Attribute{}, false
+}
+
+type attribute uint8
+
+// Uint8 returns the attribute as a uint8.
+func (a attribute) Uint8() uint8 {
+	return uint8(a)
+}
+
+// Name returns the name of the attribute as used in NBT and by the client.
+func (a attribute) Name() string {
+	switch a {
+	case 0:
+		return "minecraft:health"
+	case 1:
+		return "minecraft:movement"
+	case 2:
+		return "minecraft:attack_damage"
+	case 3:
+		return "minecraft:knockback_resistance"
+	case 4:
+		return "minecraft:follow_range"
+	}
+	panic("unknown attribute")
+}
+
+// Default returns the base value that the attribute has if no other base
+// value is set.
+func (a attribute) Default() float64 {
+	switch a {
+	case 0:
+		return 20
+	case 1:
+		return 0.1
+	case 2:
+		return 1
+	case 3:
+		return 0
+	case 4:
+		return 16
+	}
+	panic("unknown attribute")
+}
+
+// Min returns the minimum value of the attribute. The value of an attribute
+// never drops below its minimum, regardless of the modifiers applied to it.
+func (a attribute) Min() float64 {
+	if a == 0 {
+		return 1
+	}
+	return 0
+}
+
+// Max returns the maximum value of the attribute. The value of an attribute
+// never exceeds its maximum, regardless of the modifiers applied to it.
+func (a attribute) Max() float64 {
+	switch a {
+	case 3:
+		return 1
+	case 4:
+		return 2048
+	}
+	return math.MaxFloat32
+}
+
+// String ...
+func (a attribute) String() string {
+	switch a {
+	case 0:
+		return "max_health"
+	case 1:
+		return "movement_speed"
+	case 2:
+		return "attack_damage"
+	case 3:
+		return "knockback_resistance"
+	case 4:
+		return "follow_range"
+	}
+	panic("unknown attribute")
+}
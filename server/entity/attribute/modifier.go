@@ -0,0 +1,104 @@
+package attribute
+
+import (
+	"github.com/google/uuid"
+)
+
+// Modifier modifies the value of an Attribute. Modifiers are identified by
+// their ID, so that different sources, such as effects and armour, may each
+// add their own modifiers to the same Attribute without replacing those of
+// others. Adding a Modifier with the ID of an existing Modifier replaces it.
+type Modifier struct {
+	// ID is the unique ID of the modifier. Sources that add modifiers usually
+	// use a fixed ID, so that adding the modifier again replaces it rather
+	// than stacking it.
+	ID uuid.UUID
+	// Name is a name describing the source of the modifier, such as "effect.speed".
+	Name string
+	// Amount is the amount by which the Attribute is modified. How it is
+	// applied depends on the Operation of the modifier.
+	Amount float64
+	// Operation is the operation used to apply the Amount to the Attribute.
+	Operation Operation
+}
+
+// Apply applies all modifiers passed to the base value of an Attribute and
+// returns the result. Modifiers with the OperationAdd operation are applied
+// first, after which the OperationMultiplyBase and OperationMultiplyTotal
+// modifiers are applied, in that order.
+func Apply(base float64, modifiers ...Modifier) float64 {
+	for _, m := range modifiers {
+		if m.Operation == OperationAdd() {
+			base += m.Amount
+		}
+	}
+	v := base
+	for _, m := range modifiers {
+		if m.Operation == OperationMultiplyBase() {
+			v += base * m.Amount
+		}
+	}
+	for _, m := range modifiers {
+		if m.Operation == OperationMultiplyTotal() {
+			v *= 1 + m.Amount
+		}
+	}
+	return v
+}
+
+// Operation is the operation by which a Modifier modifies an Attribute.
+type Operation struct {
+	operation
+}
+
+// OperationAdd adds the amount of the modifier to the base value of the
+// attribute.
+func OperationAdd() Operation {
+	return Operation{0}
+}
+
+// OperationMultiplyBase adds the base value of the attribute, multiplied by
+// the amount of the modifier, to the value of the attribute.
+func OperationMultiplyBase() Operation {
+	return Operation{1}
+}
+
+// OperationMultiplyTotal multiplies the value of the attribute by 1 plus the
+// amount of the modifier.
+func OperationMultiplyTotal() Operation {
+	return Operation{2}
+}
+
+// Operations returns all possible operations of a Modifier.
+func Operations() []Operation {
+	return []Operation{OperationAdd(), OperationMultiplyBase(), OperationMultiplyTotal()}
+}
+
+// OperationByID returns the Operation with the ID passed, as returned by
+// Uint8. False is returned if no such operation exists.
+func OperationByID(id uint8) (Operation, bool) {
+	if int(id) >= len(Operations()) {
+		return Operation{}, false
+	}
+	return Operation{operation(id)}, true
+}
+
+type operation uint8
+
+// Uint8 returns the operation as a uint8.
+func (o operation) Uint8() uint8 {
+	return uint8(o)
+}
+
+// String ...
+func (o operation) String() string {
+	switch o {
+	case 0:
+		return "add"
+	case 1:
+		return "multiply_base"
+	case 2:
+		return "multiply_total"
+	}
+	panic("unknown attribute operation")
+}
@@ -0,0 +1,174 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/google/uuid"
+	"slices"
+	"strconv"
+)
+
+// Attributes holds the attributes of an entity, such as its maximum health and
+// movement speed, together with the modifiers applied to them. The value of
+// an attribute is its base value with all its modifiers applied, limited to
+// the minimum and maximum of the attribute.
+type Attributes struct {
+	base      map[attribute.Attribute]float64
+	modifiers map[attribute.Attribute][]attribute.Modifier
+}
+
+// NewAttributes returns a new Attributes with all attributes set to their
+// default base value and without any modifiers.
+func NewAttributes() *Attributes {
+	return &Attributes{base: map[attribute.Attribute]float64{}, modifiers: map[attribute.Attribute][]attribute.Modifier{}}
+}
+
+// Base returns the base value of the attribute passed, which is the value of
+// the attribute before any modifiers are applied.
+func (a *Attributes) Base(attr attribute.Attribute) float64 {
+	if v, ok := a.base[attr]; ok {
+		return v
+	}
+	return attr.Default()
+}
+
+// SetBase changes the base value of the attribute passed.
+func (a *Attributes) SetBase(attr attribute.Attribute, v float64) {
+	a.base[attr] = v
+}
+
+// Value returns the value of the attribute passed, which is its base value
+// with all its modifiers applied.
+func (a *Attributes) Value(attr attribute.Attribute) float64 {
+	return a.Apply(attr, a.Base(attr))
+}
+
+// Apply applies the modifiers of the attribute passed to a value other than
+// the base value of the attribute, such as the attack damage of a held item.
+func (a *Attributes) Apply(attr attribute.Attribute, base float64) float64 {
+	return min(max(attribute.Apply(base, a.modifiers[attr]...), attr.Min()), attr.Max())
+}
+
+// Modifiers returns all modifiers applied to the attribute passed, in the
+// order that they were added.
+func (a *Attributes) Modifiers(attr attribute.Attribute) []attribute.Modifier {
+	return slices.Clone(a.modifiers[attr])
+}
+
+// Modifier returns the modifier with the ID passed that is applied to the
+// attribute passed. False is returned if no such modifier exists.
+func (a *Attributes) Modifier(attr attribute.Attribute, id uuid.UUID) (attribute.Modifier, bool) {
+	i := slices.IndexFunc(a.modifiers[attr], func(m attribute.Modifier) bool { return m.ID == id })
+	if i == -1 {
+		return attribute.Modifier{}, false
+	}
+	return a.modifiers[attr][i], true
+}
+
+// AddModifier applies a modifier to the attribute passed. If a modifier with
+// the same ID is already applied to the attribute, it is replaced.
+func (a *Attributes) AddModifier(attr attribute.Attribute, m attribute.Modifier) {
+	mods := a.modifiers[attr]
+	if i := slices.IndexFunc(mods, func(o attribute.Modifier) bool { return o.ID == m.ID }); i != -1 {
+		mods[i] = m
+		return
+	}
+	a.modifiers[attr] = append(mods, m)
+}
+
+// RemoveModifier removes the modifier with the ID passed from the attribute
+// passed. False is returned if no such modifier was applied.
+func (a *Attributes) RemoveModifier(attr attribute.Attribute, id uuid.UUID) bool {
+	n := len(a.modifiers[attr])
+	a.modifiers[attr] = slices.DeleteFunc(a.modifiers[attr], func(m attribute.Modifier) bool { return m.ID == id })
+	return len(a.modifiers[attr]) != n
+}
+
+// ApplyEquipment replaces the modifiers added by the item previously equipped
+// in the slot passed with those of the item stack passed. Armour adds its
+// knock back resistance, while other items add the attribute modifiers set
+// on them for the slot.
+func (a *Attributes) ApplyEquipment(slot item.EquipmentSlot, s item.Stack) {
+	name := "equipment." + slot.String()
+	for _, attr := range attribute.All() {
+		a.modifiers[attr] = slices.DeleteFunc(a.modifiers[attr], func(m attribute.Modifier) bool { return m.Name == name })
+	}
+	if s.Empty() {
+		return
+	}
+	var n int
+	add := func(attr attribute.Attribute, amount float64, op attribute.Operation) {
+		id := uuid.NewSHA1(equipmentModifierNamespace, []byte(name+"/"+attr.String()+"/"+strconv.Itoa(n)))
+		a.AddModifier(attr, attribute.Modifier{ID: id, Name: name, Amount: amount, Operation: op})
+		n++
+	}
+	if armour, ok := s.Item().(item.Armour); ok && armour.KnockBackResistance() > 0 && slot.Uint8() >= item.EquipmentSlotHelmet().Uint8() {
+		add(attribute.KnockBackResistance(), armour.KnockBackResistance(), attribute.OperationAdd())
+	}
+	for _, m := range s.AttributeModifiers() {
+		attr, ok := attribute.ByName(m.Attribute.Name())
+		op, _ := attribute.OperationByID(m.Operation.Uint8())
+		if ok && m.Slot == slot {
+			add(attr, m.Amount, op)
+		}
+	}
+}
+
+// equipmentModifierNamespace is the namespace used to derive the IDs of
+// modifiers added by equipment.
+var equipmentModifierNamespace = uuid.MustParse("2b1f3ae5-8c83-4b3f-9a62-3f0c1f0b9d6e")
+
+// encodeNBT encodes the attributes into a list of compounds that may be
+// stored in the 'Attributes' tag of an entity.
+func (a *Attributes) encodeNBT() []map[string]any {
+	list := make([]map[string]any, 0, len(attribute.All()))
+	for _, attr := range attribute.All() {
+		mods := make([]map[string]any, 0, len(a.modifiers[attr]))
+		for _, m := range a.modifiers[attr] {
+			mods = append(mods, map[string]any{
+				"UUID":      m.ID.String(),
+				"Name":      m.Name,
+				"Amount":    float32(m.Amount),
+				"Operation": int32(m.Operation.Uint8()),
+			})
+		}
+		list = append(list, map[string]any{
+			"Name":      attr.Name(),
+			"Base":      float32(a.Base(attr)),
+			"Current":   float32(a.Value(attr)),
+			"Min":       float32(attr.Min()),
+			"Max":       float32(attr.Max()),
+			"Modifiers": mods,
+		})
+	}
+	return list
+}
+
+// decodeNBT decodes attributes from the 'Attributes' tag of an entity.
+// Attributes that are not known are ignored.
+func (a *Attributes) decodeNBT(list []any) {
+	for _, v := range list {
+		m, _ := v.(map[string]any)
+		attr, ok := attribute.ByName(nbtconv.String(m, "Name"))
+		if !ok {
+			continue
+		}
+		a.SetBase(attr, float64(nbtconv.Float32(m, "Base")))
+		mods, _ := m["Modifiers"].([]any)
+		for _, v := range mods {
+			mod, _ := v.(map[string]any)
+			op, ok := attribute.OperationByID(uint8(nbtconv.Int32(mod, "Operation")))
+			id, err := uuid.Parse(nbtconv.String(mod, "UUID"))
+			if !ok || err != nil {
+				continue
+			}
+			a.AddModifier(attr, attribute.Modifier{
+				ID:        id,
+				Name:      nbtconv.String(mod, "Name"),
+				Amount:    float64(nbtconv.Float32(mod, "Amount")),
+				Operation: op,
+			})
+		}
+	}
+}
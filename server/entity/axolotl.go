@@ -0,0 +1,394 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+	"time"
+)
+
+// NewAxolotl creates a new adult axolotl with the variant passed.
+func NewAxolotl(opts world.EntitySpawnOpts, variant AxolotlVariant) *world.EntityHandle {
+	conf := axolotlConf
+	conf.Variant = variant
+	return opts.New(AxolotlType, conf)
+}
+
+var axolotlConf = AxolotlBehaviourConfig{}
+
+// AxolotlVariant is the colour of an axolotl.
+type AxolotlVariant int32
+
+const (
+	// AxolotlLucy is the pink, leucistic axolotl variant.
+	AxolotlLucy AxolotlVariant = iota
+	// AxolotlCyan is the light blue axolotl variant.
+	AxolotlCyan
+	// AxolotlGold is the yellow axolotl variant.
+	AxolotlGold
+	// AxolotlWild is the brown axolotl variant.
+	AxolotlWild
+	// AxolotlBlue is the rare, blue axolotl variant. Blue axolotls never
+	// spawn naturally and are only obtained through breeding.
+	AxolotlBlue
+)
+
+// RandomAxolotlVariant returns a random AxolotlVariant out of the variants
+// that spawn naturally. It never returns AxolotlBlue.
+func RandomAxolotlVariant() AxolotlVariant {
+	return AxolotlVariant(rand.IntN(4))
+}
+
+// AxolotlBehaviourConfig holds optional parameters for an AxolotlBehaviour.
+type AxolotlBehaviourConfig struct {
+	// Variant is the colour of the axolotl.
+	Variant AxolotlVariant
+	// Baby specifies if the axolotl is a baby. Babies grow up after 20
+	// minutes.
+	Baby bool
+}
+
+func (conf AxolotlBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates an AxolotlBehaviour using the parameters in conf.
+func (conf AxolotlBehaviourConfig) New() *AxolotlBehaviour {
+	if conf.Variant < AxolotlLucy || conf.Variant > AxolotlBlue {
+		panic("invalid axolotl variant")
+	}
+	a := &AxolotlBehaviour{variant: conf.Variant}
+	if conf.Baby {
+		a.growUpTicks = axolotlGrowUpTicks
+	}
+	a.MobBehaviour = MobBehaviourConfig{
+		MaxHealth:  14,
+		Speed:      0.05,
+		Swimming:   true,
+		Experience: 1 + rand.IntN(3),
+	}.New()
+	return a
+}
+
+const (
+	// axolotlGrowUpTicks is the amount of ticks it takes for a baby axolotl
+	// to grow up.
+	axolotlGrowUpTicks = 24000
+	// axolotlPlayDeadTicks is the amount of ticks that an axolotl plays dead
+	// for after being hurt.
+	axolotlPlayDeadTicks = 200
+	// axolotlLoveTicks is the amount of ticks that an axolotl is in love for
+	// after being fed.
+	axolotlLoveTicks = 600
+	// axolotlBreedCooldown is the amount of ticks after breeding before an
+	// axolotl may breed again.
+	axolotlBreedCooldown = 6000
+)
+
+// AxolotlBehaviour implements the behaviour of axolotls. Axolotls hunt
+// hostile mobs in water and play dead when they are hurt, regenerating
+// health while other mobs ignore them. Players that help an axolotl kill a
+// mob are given Regeneration. Axolotls may be bred using buckets of tropical
+// fish and captured using buckets of water.
+type AxolotlBehaviour struct {
+	*MobBehaviour
+
+	variant       AxolotlVariant
+	growUpTicks   int
+	loveTicks     int
+	breedCooldown int
+
+	playDeadTicks  int
+	target         *world.EntityHandle
+	attackCooldown int
+}
+
+// Variant returns the colour of the axolotl as its variant.
+func (a *AxolotlBehaviour) Variant() int32 {
+	return int32(a.variant)
+}
+
+// Baby checks if the axolotl is a baby.
+func (a *AxolotlBehaviour) Baby() bool {
+	return a.growUpTicks > 0
+}
+
+// Scale returns the scale of the axolotl, which is 0.5 for babies.
+func (a *AxolotlBehaviour) Scale() float64 {
+	if a.Baby() {
+		return 0.5
+	}
+	return 1
+}
+
+// InLove checks if the axolotl was fed and is looking for another axolotl to
+// breed with.
+func (a *AxolotlBehaviour) InLove() bool {
+	return a.loveTicks > 0
+}
+
+// PlayingDead checks if the axolotl is currently playing dead. Other mobs do
+// not target axolotls that are playing dead.
+func (a *AxolotlBehaviour) PlayingDead() bool {
+	return a.playDeadTicks > 0
+}
+
+// Target returns the entity that the axolotl is currently hunting, or nil if
+// it is not hunting any entity.
+func (a *AxolotlBehaviour) Target() *world.EntityHandle {
+	return a.target
+}
+
+// Bucket allows the axolotl to be captured in a bucket of water.
+func (a *AxolotlBehaviour) Bucket(*Mob) (string, bool) {
+	return "axolotl", true
+}
+
+// Interact feeds the axolotl a bucket of tropical fish. Feeding an adult
+// axolotl makes it look for another axolotl to breed with, while feeding a
+// baby axolotl makes it grow up faster.
+func (a *AxolotlBehaviour) Interact(m *Mob, user item.User, _ *world.Tx) bool {
+	held, left := user.HeldItems()
+	b, ok := held.Item().(item.Bucket)
+	if name, fish := b.Content.Entity(); !ok || !fish || name != "tropical_fish" {
+		return false
+	}
+	switch {
+	case a.Baby():
+		a.growUpTicks -= a.growUpTicks / 10
+	case a.InLove() || a.breedCooldown > 0:
+		return false
+	default:
+		a.loveTicks = axolotlLoveTicks
+	}
+	if g, ok := user.(interface{ GameMode() world.GameMode }); !ok || !g.GameMode().CreativeInventory() {
+		// The fish is taken out of the bucket, leaving the water behind.
+		liq, _ := b.Content.Liquid()
+		user.SetHeldItems(item.NewStack(item.Bucket{Content: item.LiquidBucketContent(liq)}, 1), left)
+	}
+	m.updateState()
+	return true
+}
+
+// Hurt makes the axolotl play dead if it is hurt while in water, and makes
+// it hunt the entity that attacked it otherwise.
+func (a *AxolotlBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	if a.Dead() || a.PlayingDead() {
+		return
+	}
+	if a.inWater(m.Ent, m.tx) && m.Health() < m.MaxHealth()/2 && rand.IntN(3) == 0 {
+		a.playDeadTicks, a.target = axolotlPlayDeadTicks, nil
+		a.StopMoving()
+		m.AddEffect(effect.New(effect.Regeneration, 1, time.Second*10))
+		m.updateState()
+		return
+	}
+	if attacker := damageSourceAttacker(src); attacker != nil {
+		if l, ok := attacker.(Living); ok && !attackablePlayer(l) {
+			a.target = attacker.H()
+		}
+	}
+}
+
+// Tick ticks the axolotl, making it hunt its prey and breed.
+func (a *AxolotlBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !a.Dead() {
+		a.tickAxolotl(&Mob{Ent: e}, tx)
+	}
+	return a.MobBehaviour.Tick(e, tx)
+}
+
+// tickAxolotl performs the axolotl specific logic of a tick.
+func (a *AxolotlBehaviour) tickAxolotl(m *Mob, tx *world.Tx) {
+	if a.attackCooldown > 0 {
+		a.attackCooldown--
+	}
+	if a.breedCooldown > 0 {
+		a.breedCooldown--
+	}
+	if a.growUpTicks > 0 {
+		if a.growUpTicks--; a.growUpTicks == 0 {
+			m.updateState()
+		}
+	}
+	if a.loveTicks > 0 {
+		if a.loveTicks--; a.loveTicks == 0 {
+			m.updateState()
+		}
+	}
+	if a.playDeadTicks > 0 {
+		if a.playDeadTicks--; a.playDeadTicks == 0 {
+			m.updateState()
+		}
+		a.StopMoving()
+		return
+	}
+	if a.InLove() && a.breed(m, tx) {
+		return
+	}
+	if a.hunt(m, tx) {
+		return
+	}
+	if !a.Moving() && rand.IntN(80) == 0 {
+		offset := randomHorizontalOffset(6)
+		if a.inWater(m.Ent, tx) {
+			offset[1] = float64(rand.IntN(5) - 2)
+		}
+		a.MoveTo(m.Position().Add(offset), 1)
+	}
+}
+
+// hunt makes the axolotl swim towards its prey and attack it. If the axolotl
+// has no prey, it looks for a hostile mob in water close to it. False is
+// returned if the axolotl has nothing to hunt.
+func (a *AxolotlBehaviour) hunt(m *Mob, tx *world.Tx) bool {
+	if a.Baby() {
+		return false
+	}
+	if a.target == nil && m.Age()%(time.Second/2) == 0 {
+		if prey, ok := nearestEntity(m, tx, 8, func(e Living) bool {
+			mob, ok := e.(*Mob)
+			return ok && hostile(e) && a.inWater(mob.Ent, tx)
+		}); ok {
+			a.target = prey.H()
+		}
+	}
+	if a.target == nil {
+		return false
+	}
+	e, ok := a.target.Entity(tx)
+	t, living := e.(Living)
+	if !ok || !living || t.Dead() || e.Position().Sub(m.Position()).Len() > 16 {
+		a.target = nil
+		return false
+	}
+	a.LookAt(EyePosition(e))
+	if e.Position().Sub(m.Position()).Len() > 1.5 {
+		a.MoveTo(e.Position(), 1.5)
+		return true
+	}
+	a.StopMoving()
+	if a.attackCooldown == 0 {
+		a.attackCooldown = 20
+		if _, vulnerable := t.Hurt(2, AttackDamageSource{Attacker: m}); vulnerable {
+			t.KnockBack(m.Position(), 0.3, 0.2)
+		}
+	}
+	return true
+}
+
+// breed makes the axolotl swim towards another axolotl in love and spawns a
+// baby axolotl once the two meet. False is returned if no other axolotl in
+// love was found.
+func (a *AxolotlBehaviour) breed(m *Mob, tx *world.Tx) bool {
+	mate, ok := nearestEntity(m, tx, 8, func(e Living) bool {
+		if mob, ok := e.(*Mob); ok {
+			other, ok := mob.Behaviour().(*AxolotlBehaviour)
+			return ok && other.InLove() && !other.Baby()
+		}
+		return false
+	})
+	if !ok {
+		return false
+	}
+	if mate.Position().Sub(m.Position()).Len() > 1.5 {
+		a.MoveTo(mate.Position(), 1)
+		return true
+	}
+	other := mate.(*Mob).Behaviour().(*AxolotlBehaviour)
+	a.loveTicks, other.loveTicks = 0, 0
+	a.breedCooldown, other.breedCooldown = axolotlBreedCooldown, axolotlBreedCooldown
+	m.updateState()
+	mate.(*Mob).updateState()
+
+	variant := a.variant
+	if rand.IntN(2) == 0 {
+		variant = other.variant
+	}
+	if rand.IntN(1200) == 0 {
+		variant = AxolotlBlue
+	}
+	opts := world.EntitySpawnOpts{Position: m.Position(), Rotation: cube.Rotation{rand.Float64() * 360}}
+	tx.AddEntity(opts.New(AxolotlType, AxolotlBehaviourConfig{Variant: variant, Baby: true}))
+	for _, orb := range NewExperienceOrbs(m.Position(), 1+rand.IntN(7)) {
+		tx.AddEntity(orb)
+	}
+	return true
+}
+
+// axolotlAssist rewards the entity that killed the mob passed if an axolotl
+// close to the mob was hunting it. The killer is given Regeneration and its
+// Mining Fatigue is removed.
+func axolotlAssist(m *Mob, src world.DamageSource) {
+	killer, ok := damageSourceAttacker(src).(Living)
+	if !ok || !attackablePlayer(killer) {
+		return
+	}
+	for e := range m.tx.EntitiesWithin(cube.Box(-16, -16, -16, 16, 16, 16).Translate(m.Position())) {
+		other, ok := e.(*Mob)
+		if !ok {
+			continue
+		}
+		if a, ok := other.Behaviour().(*AxolotlBehaviour); ok && a.target == m.H() && !other.Dead() {
+			regeneration := time.Second * 5
+			for _, eff := range killer.Effects() {
+				if eff.Type() == effect.Regeneration {
+					regeneration += eff.Duration()
+				}
+			}
+			killer.AddEffect(effect.New(effect.Regeneration, 1, min(regeneration, time.Minute*2)))
+			killer.RemoveEffect(effect.MiningFatigue)
+			return
+		}
+	}
+}
+
+// AxolotlType is a world.EntityType implementation for axolotls.
+var AxolotlType axolotlType
+
+type axolotlType struct{}
+
+func (axolotlType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (axolotlType) EncodeEntity() string { return "minecraft:axolotl" }
+func (axolotlType) BBox(e world.Entity) cube.BBox {
+	if m, ok := e.(*Mob); ok && m.Behaviour().(*AxolotlBehaviour).Baby() {
+		return cube.Box(-0.1875, 0, -0.1875, 0.1875, 0.21, 0.1875)
+	}
+	return cube.Box(-0.375, 0, -0.375, 0.375, 0.42, 0.375)
+}
+
+func (axolotlType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := axolotlConf
+	conf.Variant = RandomAxolotlVariant()
+	if _, ok := m["Variant"]; ok {
+		conf.Variant = AxolotlVariant(nbtconv.Int32(m, "Variant"))
+		if conf.Variant < AxolotlLucy || conf.Variant > AxolotlBlue {
+			conf.Variant = AxolotlLucy
+		}
+	}
+	a := conf.New()
+	a.MobBehaviour.decodeNBT(m)
+	if nbtconv.Bool(m, "IsBaby") {
+		a.growUpTicks = max(int(nbtconv.Int32(m, "GrowUpTicks")), 1)
+	}
+	a.breedCooldown = int(nbtconv.Int32(m, "BreedCooldown"))
+	data.Data = a
+}
+
+func (axolotlType) EncodeNBT(data *world.EntityData) map[string]any {
+	a := data.Data.(*AxolotlBehaviour)
+	m := map[string]any{
+		"Variant":       int32(a.variant),
+		"IsBaby":        boolByte(a.Baby()),
+		"GrowUpTicks":   int32(a.growUpTicks),
+		"BreedCooldown": int32(a.breedCooldown),
+	}
+	a.MobBehaviour.encodeNBT(m)
+	return m
+}
@@ -0,0 +1,488 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// NewBee creates a new bee. The bee looks for a beehive or bee nest close to
+// it to live in.
+func NewBee(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(BeeType, beeConf)
+}
+
+// newBeeFromHive creates a bee leaving the hive at the position passed. The
+// bee is restored from the NBT data passed, if not nil. If target is not nil,
+// the bee attacks it after leaving the hive.
+func newBeeFromHive(opts world.EntitySpawnOpts, data map[string]any, hive cube.Pos, target world.Entity) *world.EntityHandle {
+	conf := beeConf
+	conf.Hive, conf.data = &hive, data
+	if target != nil {
+		conf.Target = target.H()
+	}
+	if name, ok := data["NameTag"].(string); ok {
+		opts.NameTag = name
+	}
+	return opts.New(BeeType, conf)
+}
+
+var beeConf = BeeBehaviourConfig{}
+
+// BeeBehaviourConfig holds optional parameters for a BeeBehaviour.
+type BeeBehaviourConfig struct {
+	// Hive is the position of the beehive or bee nest that the bee lives in.
+	// If nil, the bee looks for a hive close to it.
+	Hive *cube.Pos
+	// Target is the entity that the bee is angry at. If nil, the bee is not
+	// angry.
+	Target *world.EntityHandle
+
+	// data holds NBT data that the bee is restored from when it leaves its
+	// hive.
+	data map[string]any
+}
+
+func (conf BeeBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a BeeBehaviour using the parameters in conf.
+func (conf BeeBehaviourConfig) New() *BeeBehaviour {
+	b := &BeeBehaviour{}
+	b.MobBehaviour = MobBehaviourConfig{MaxHealth: 10, Speed: 0.02, Drag: 0.1, Flying: true, Experience: 1}.New()
+	if conf.data != nil {
+		b.decodeNBT(conf.data)
+		// Bees leave their hive without nectar, as it was turned into honey.
+		b.nectar = false
+	}
+	if conf.Hive != nil {
+		b.hive = conf.Hive
+	}
+	if conf.Target != nil {
+		b.target, b.angryTicks = conf.Target, 400+rand.IntN(400)
+	}
+	// Bees that just left their hive do not immediately enter it again.
+	b.hiveCooldown = 400
+	return b
+}
+
+const (
+	// beePollinationTicks is the amount of ticks that a bee needs to hover
+	// over a flower to collect nectar.
+	beePollinationTicks = 400
+	// beeMaxTicksOutside is the amount of ticks after which a bee returns to
+	// its hive, regardless of whether it found nectar.
+	beeMaxTicksOutside = 2400
+	// beeMaxCropsGrown is the maximum amount of crops that a bee grows with
+	// the nectar that it collected from a single flower.
+	beeMaxCropsGrown = 10
+)
+
+// BeeBehaviour implements the behaviour of bees. Bees fly between flowers and
+// their hive, collecting nectar from flowers and turning it into honey in
+// their hive. On their way back, they help crops grow. Bees attack entities
+// that attack them or harvest their hive, dying shortly after stinging.
+type BeeBehaviour struct {
+	*MobBehaviour
+
+	hive         *cube.Pos
+	hiveCooldown int
+	ticksOutside int
+
+	nectar      bool
+	flower      *cube.Pos
+	pollinating int
+	cropsGrown  int
+
+	target         *world.EntityHandle
+	angryTicks     int
+	attackCooldown int
+	stung          bool
+	dieIn          int
+}
+
+// Hive returns the position of the beehive or bee nest that the bee lives in.
+// False is returned if the bee does not have a hive.
+func (b *BeeBehaviour) Hive() (cube.Pos, bool) {
+	if b.hive == nil {
+		return cube.Pos{}, false
+	}
+	return *b.hive, true
+}
+
+// Nectar checks if the bee is carrying nectar that it collected from a
+// flower.
+func (b *BeeBehaviour) Nectar() bool {
+	return b.nectar
+}
+
+// Stung checks if the bee has stung an entity. Bees that have stung lose
+// their stinger and die shortly after.
+func (b *BeeBehaviour) Stung() bool {
+	return b.stung
+}
+
+// Angry checks if the bee is currently angry at an entity.
+func (b *BeeBehaviour) Angry() bool {
+	return b.angryTicks > 0
+}
+
+// Target returns the entity that the bee is currently angry at, or nil if the
+// bee is not angry.
+func (b *BeeBehaviour) Target() *world.EntityHandle {
+	return b.target
+}
+
+// Hurt makes the bee, as well as other bees close to it, angry at the player
+// that attacked it.
+func (b *BeeBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	attacker := damageSourceAttacker(src)
+	if b.Dead() || attacker == nil {
+		return
+	}
+	if l, ok := attacker.(Living); !ok || !attackablePlayer(l) {
+		return
+	}
+	for e := range m.tx.EntitiesWithin(cube.Box(-8, -8, -8, 8, 8, 8).Translate(m.Position())) {
+		if other, ok := e.(*Mob); ok && !other.Dead() {
+			if bee, ok := other.Behaviour().(*BeeBehaviour); ok && !bee.stung {
+				bee.anger(other, attacker)
+			}
+		}
+	}
+}
+
+// anger makes the bee angry at the entity passed.
+func (b *BeeBehaviour) anger(m *Mob, target world.Entity) {
+	angry := b.Angry()
+	b.target, b.angryTicks = target.H(), 400+rand.IntN(400)
+	if !angry {
+		m.updateState()
+	}
+}
+
+// Tick ticks the bee, making it fly between flowers and its hive or attack
+// its target.
+func (b *BeeBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !b.Dead() {
+		if entered := b.tickBee(&Mob{Ent: e}, tx); entered {
+			return nil
+		}
+	}
+	return b.MobBehaviour.Tick(e, tx)
+}
+
+// tickBee performs the bee specific logic of a tick. True is returned if the
+// bee entered its hive.
+func (b *BeeBehaviour) tickBee(m *Mob, tx *world.Tx) bool {
+	b.ticksOutside++
+	if b.hiveCooldown > 0 {
+		b.hiveCooldown--
+	}
+	if b.attackCooldown > 0 {
+		b.attackCooldown--
+	}
+	if b.stung {
+		if b.dieIn--; b.dieIn <= 0 {
+			b.health.AddHealth(-b.health.Health())
+			b.kill(m, VoidDamageSource{})
+			return false
+		}
+	}
+	if b.angryTicks > 0 {
+		if b.angryTicks--; b.angryTicks == 0 {
+			b.target = nil
+			m.updateState()
+		}
+	}
+	if b.target != nil && b.attack(m, tx) {
+		return false
+	}
+	if b.shouldReturn(m, tx) && b.findHive(m, tx) {
+		return b.returnToHive(m, tx)
+	}
+	if b.nectar {
+		b.growCrops(m, tx)
+	} else if b.pollinate(m, tx) {
+		return false
+	}
+	if !b.Moving() && rand.IntN(40) == 0 {
+		centre := m.Position()
+		if b.hive != nil {
+			centre = b.hive.Vec3Centre()
+		}
+		b.MoveTo(centre.Add(randomHorizontalOffset(8)).Add(mgl64.Vec3{0, float64(rand.IntN(5) - 1)}), 1)
+	}
+	return false
+}
+
+// attack makes the bee fly towards its target and sting it. False is returned
+// if the bee no longer has a target.
+func (b *BeeBehaviour) attack(m *Mob, tx *world.Tx) bool {
+	e, ok := b.target.Entity(tx)
+	t, living := e.(Living)
+	if !ok || !living || t.Dead() || b.stung || !attackablePlayer(t) || e.Position().Sub(m.Position()).Len() > 24 {
+		b.target, b.angryTicks = nil, 0
+		m.updateState()
+		return false
+	}
+	centre := e.Position().Add(mgl64.Vec3{0, e.H().Type().BBox(e).Height() / 2})
+	b.LookAt(centre)
+	if centre.Sub(m.Position()).Len() > 1.2 {
+		b.MoveTo(centre, 1.5)
+		return true
+	}
+	if b.attackCooldown > 0 {
+		return true
+	}
+	b.attackCooldown = 20
+	if _, vulnerable := t.Hurt(2, AttackDamageSource{Attacker: m}); !vulnerable {
+		return true
+	}
+	var poison time.Duration
+	switch tx.World().Difficulty() {
+	case world.DifficultyNormal:
+		poison = time.Second * 10
+	case world.DifficultyHard:
+		poison = time.Second * 18
+	}
+	if poison > 0 {
+		t.AddEffect(effect.New(effect.Poison, 1, poison))
+	}
+	// A bee loses its stinger after stinging, after which it dies within a
+	// minute.
+	b.stung, b.dieIn = true, 600+rand.IntN(600)
+	b.target, b.angryTicks = nil, 0
+	m.updateState()
+	return true
+}
+
+// shouldReturn checks if the bee should return to its hive: Bees return to
+// their hive once they collected nectar, have been outside for too long or if
+// it is night or raining.
+func (b *BeeBehaviour) shouldReturn(m *Mob, tx *world.Tx) bool {
+	if b.hiveCooldown > 0 {
+		return false
+	}
+	if b.nectar || b.ticksOutside > beeMaxTicksOutside {
+		return true
+	}
+	t := tx.World().Time() % 24000
+	return (t > 12000 && t < 23500) || tx.RainingAt(cube.PosFromVec3(m.Position()))
+}
+
+// findHive checks if the hive of the bee still exists, looking for a new hive
+// close to the bee if it does not. False is returned if the bee does not have
+// a hive.
+func (b *BeeBehaviour) findHive(m *Mob, tx *world.Tx) bool {
+	if b.hive != nil {
+		if _, ok := beeHiveOccupants(tx.Block(*b.hive)); ok {
+			return true
+		}
+		b.hive = nil
+	}
+	if m.Age()%(time.Second*10) != 0 {
+		return false
+	}
+	pos := cube.PosFromVec3(m.Position())
+	for x := -8; x <= 8; x++ {
+		for y := -8; y <= 8; y++ {
+			for z := -8; z <= 8; z++ {
+				hivePos := pos.Add(cube.Pos{x, y, z})
+				if n, ok := beeHiveOccupants(tx.Block(hivePos)); ok && n < 3 {
+					b.hive = &hivePos
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// returnToHive makes the bee fly to the entrance of its hive and enter it
+// once it gets close enough. True is returned if the bee entered its hive.
+func (b *BeeBehaviour) returnToHive(m *Mob, tx *world.Tx) bool {
+	entrance := b.hive.Vec3Centre()
+	if facing, ok := beeHiveFacing(tx.Block(*b.hive)); ok {
+		entrance = b.hive.Side(facing.Face()).Vec3Centre()
+	}
+	if entrance.Sub(m.Position()).Len() > 1 {
+		b.MoveTo(entrance, 1)
+		return false
+	}
+	hive, ok := tx.Block(*b.hive).(interface {
+		AddOccupant(pos cube.Pos, tx *world.Tx, o block.BeehiveOccupant, nectar bool) bool
+	})
+	if !ok {
+		return false
+	}
+	ticks := 600
+	if b.nectar {
+		ticks = 2400
+	}
+	data := BeeType.EncodeNBT(m.data)
+	data["NameTag"] = m.NameTag()
+	if !hive.AddOccupant(*b.hive, tx, block.BeehiveOccupant{Data: data, TicksLeft: ticks}, b.nectar) {
+		// The hive is full, so look for another hive.
+		b.hive, b.hiveCooldown = nil, 400
+		return false
+	}
+	_ = m.Close()
+	return true
+}
+
+// pollinate makes the bee look for a flower close to it and collect nectar
+// from it. False is returned if no flower was found.
+func (b *BeeBehaviour) pollinate(m *Mob, tx *world.Tx) bool {
+	if b.flower != nil && !beeFlower(tx.Block(*b.flower)) {
+		b.flower, b.pollinating = nil, 0
+	}
+	if b.flower == nil {
+		if m.Age()%time.Second != 0 {
+			return false
+		}
+		pos := cube.PosFromVec3(m.Position())
+		for range 20 {
+			flowerPos := pos.Add(cube.Pos{rand.IntN(11) - 5, rand.IntN(7) - 3, rand.IntN(11) - 5})
+			if beeFlower(tx.Block(flowerPos)) {
+				b.flower = &flowerPos
+				break
+			}
+		}
+		if b.flower == nil {
+			return false
+		}
+	}
+	hover := b.flower.Vec3Middle().Add(mgl64.Vec3{0, 0.6})
+	if hover.Sub(m.Position()).Len() > 0.8 {
+		b.MoveTo(hover, 1)
+		return true
+	}
+	b.StopMoving()
+	if b.pollinating++; b.pollinating >= beePollinationTicks {
+		b.nectar, b.flower, b.pollinating, b.cropsGrown = true, nil, 0, 0
+		m.updateState()
+	}
+	return true
+}
+
+// growCrops occasionally grows a crop below a bee that carries nectar.
+func (b *BeeBehaviour) growCrops(m *Mob, tx *world.Tx) {
+	if b.cropsGrown >= beeMaxCropsGrown || rand.IntN(30) != 0 {
+		return
+	}
+	pos := cube.PosFromVec3(m.Position())
+	for i := 1; i <= 2; i++ {
+		cropPos := pos.Sub(cube.Pos{0, i})
+		crop := tx.Block(cropPos)
+		if c, ok := crop.(block.Crop); !ok || c.GrowthStage() >= 7 {
+			continue
+		}
+		props := world.BlockProperties(crop)
+		growth, ok := props["growth"].(int32)
+		if !ok {
+			return
+		}
+		props["growth"] = growth + 1
+		name, _ := crop.EncodeBlock()
+		if grown, ok := world.BlockWithProperties(name, props); ok {
+			tx.SetBlock(cropPos, grown, nil)
+			tx.AddParticle(cropPos.Vec3(), particle.BoneMeal{})
+			b.cropsGrown++
+		}
+		return
+	}
+}
+
+// beeFlower checks if the block passed is a flower that bees may collect
+// nectar from.
+func beeFlower(b world.Block) bool {
+	switch f := b.(type) {
+	case block.Flower:
+		return f.Type != block.WitherRose()
+	case block.DoubleFlower, block.PinkPetals:
+		return true
+	}
+	return false
+}
+
+// beeHiveOccupants returns the amount of bees in the block passed if it is a
+// beehive or bee nest.
+func beeHiveOccupants(b world.Block) (int, bool) {
+	switch h := b.(type) {
+	case block.Beehive:
+		return len(h.Occupants), true
+	case block.BeeNest:
+		return len(h.Occupants), true
+	}
+	return 0, false
+}
+
+// beeHiveFacing returns the direction that the entrance of the beehive or bee
+// nest passed faces.
+func beeHiveFacing(b world.Block) (cube.Direction, bool) {
+	switch h := b.(type) {
+	case block.Beehive:
+		return h.Facing, true
+	case block.BeeNest:
+		return h.Facing, true
+	}
+	return 0, false
+}
+
+// decodeNBT decodes the state of the bee from the map passed.
+func (b *BeeBehaviour) decodeNBT(m map[string]any) {
+	b.MobBehaviour.decodeNBT(m)
+	b.nectar = nbtconv.Bool(m, "HasNectar")
+	b.stung = nbtconv.Bool(m, "HasStung")
+	b.cropsGrown = int(nbtconv.Int32(m, "CropsGrownSincePollination"))
+	if _, ok := m["HivePos"]; ok {
+		pos := nbtconv.Pos(m, "HivePos")
+		b.hive = &pos
+	}
+	if b.stung {
+		b.dieIn = 600 + rand.IntN(600)
+	}
+}
+
+// BeeType is a world.EntityType implementation for bees.
+var BeeType beeType
+
+type beeType struct{}
+
+func (beeType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (beeType) EncodeEntity() string { return "minecraft:bee" }
+func (beeType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.35, 0, -0.35, 0.35, 0.6, 0.35)
+}
+
+func (beeType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	b := beeConf.New()
+	b.decodeNBT(m)
+	b.hiveCooldown = 0
+	data.Data = b
+}
+
+func (beeType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*BeeBehaviour)
+	m := map[string]any{
+		"HasNectar":                  boolByte(b.nectar),
+		"HasStung":                   boolByte(b.stung),
+		"CropsGrownSincePollination": int32(b.cropsGrown),
+	}
+	if b.hive != nil {
+		m["HivePos"] = nbtconv.PosToInt32Slice(*b.hive)
+	}
+	b.MobBehaviour.encodeNBT(m)
+	return m
+}
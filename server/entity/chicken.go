@@ -0,0 +1,309 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+)
+
+// NewChicken creates a new adult chicken.
+func NewChicken(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(ChickenType, chickenConf)
+}
+
+var chickenConf ChickenBehaviourConfig
+
+// ChickenBehaviourConfig holds optional parameters for a ChickenBehaviour.
+type ChickenBehaviourConfig struct {
+	// Baby specifies if the chicken is a baby. Babies grow up after 20
+	// minutes.
+	Baby bool
+}
+
+func (conf ChickenBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a ChickenBehaviour using the parameters in conf.
+func (conf ChickenBehaviourConfig) New() *ChickenBehaviour {
+	c := &ChickenBehaviour{eggTicks: chickenEggTicks()}
+	if conf.Baby {
+		c.growUpTicks = chickenGrowUpTicks
+	}
+	c.MobBehaviour = MobBehaviourConfig{
+		MaxHealth:  4,
+		Speed:      0.05,
+		Experience: 1 + rand.IntN(3),
+		Drops:      c.drops,
+	}.New()
+	return c
+}
+
+const (
+	// chickenGrowUpTicks is the amount of ticks it takes for a baby chicken
+	// to grow up.
+	chickenGrowUpTicks = 24000
+	// chickenLoveTicks is the amount of ticks that a chicken is in love for
+	// after being fed.
+	chickenLoveTicks = 600
+	// chickenBreedCooldown is the amount of ticks after breeding before a
+	// chicken may breed again.
+	chickenBreedCooldown = 6000
+)
+
+// chickenEggTicks returns a random amount of ticks, between 5 and 10
+// minutes, until a chicken lays its next egg.
+func chickenEggTicks() int {
+	return 6000 + rand.IntN(6000)
+}
+
+// ChickenBehaviour implements the behaviour of chickens. Chickens wander
+// around, lay an egg every 5 to 10 minutes and flap their wings to fall
+// slowly, so that they never take fall damage. Chickens follow players that
+// hold seeds and may be bred using them.
+type ChickenBehaviour struct {
+	*MobBehaviour
+
+	// eggTicks is the amount of ticks until the chicken lays its next egg.
+	// It only counts down while the chicken is ticked, so a chicken in an
+	// unloaded chunk does not lay any eggs.
+	eggTicks      int
+	growUpTicks   int
+	loveTicks     int
+	breedCooldown int
+}
+
+// Baby checks if the chicken is a baby.
+func (c *ChickenBehaviour) Baby() bool {
+	return c.growUpTicks > 0
+}
+
+// Scale returns the scale of the chicken, which is 0.5 for babies.
+func (c *ChickenBehaviour) Scale() float64 {
+	if c.Baby() {
+		return 0.5
+	}
+	return 1
+}
+
+// InLove checks if the chicken was fed and is looking for another chicken to
+// breed with.
+func (c *ChickenBehaviour) InLove() bool {
+	return c.loveTicks > 0
+}
+
+// Immune makes the chicken immune to fall damage.
+func (c *ChickenBehaviour) Immune(src world.DamageSource) bool {
+	_, fall := src.(FallDamageSource)
+	return fall
+}
+
+// Interact feeds the chicken the seeds held by the user. Feeding an adult
+// chicken makes it look for another chicken to breed with, while feeding a
+// baby chicken makes it grow up faster.
+func (c *ChickenBehaviour) Interact(m *Mob, user item.User, _ *world.Tx) bool {
+	held, _ := user.HeldItems()
+	if !chickenFood(held) {
+		return false
+	}
+	switch {
+	case c.Baby():
+		c.growUpTicks -= c.growUpTicks / 10
+	case c.InLove() || c.breedCooldown > 0:
+		return false
+	default:
+		c.loveTicks = chickenLoveTicks
+	}
+	consumeHeldItem(user)
+	m.updateState()
+	return true
+}
+
+// Tick ticks the chicken, making it lay eggs, breed and follow players that
+// hold seeds.
+func (c *ChickenBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !c.Dead() {
+		c.tickChicken(&Mob{Ent: e}, tx)
+	}
+	if !c.mc.OnGround() && e.data.Vel[1] < 0 {
+		// Chickens flap their wings while falling, which slows down their
+		// fall.
+		e.data.Vel[1] *= 0.6
+	}
+	return c.MobBehaviour.Tick(e, tx)
+}
+
+// tickChicken performs the chicken specific logic of a tick.
+func (c *ChickenBehaviour) tickChicken(m *Mob, tx *world.Tx) {
+	if c.breedCooldown > 0 {
+		c.breedCooldown--
+	}
+	if c.growUpTicks > 0 {
+		if c.growUpTicks--; c.growUpTicks == 0 {
+			m.updateState()
+		}
+	}
+	if c.loveTicks > 0 {
+		if c.loveTicks--; c.loveTicks == 0 {
+			m.updateState()
+		}
+	}
+	if !c.Baby() {
+		if c.eggTicks--; c.eggTicks <= 0 {
+			c.eggTicks = chickenEggTicks()
+			tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: m.Position()}, item.NewStack(item.Egg{}, 1)))
+			tx.PlaySound(m.Position(), sound.Pop{})
+		}
+	}
+	if c.InLove() && c.breed(m, tx) {
+		return
+	}
+	if c.tempt(m, tx) {
+		return
+	}
+	if !c.Moving() && rand.IntN(120) == 0 {
+		c.MoveTo(m.Position().Add(randomHorizontalOffset(6)), 1)
+	}
+}
+
+// tempt makes the chicken follow the closest player holding seeds. False is
+// returned if no player close to the chicken holds seeds.
+func (c *ChickenBehaviour) tempt(m *Mob, tx *world.Tx) bool {
+	p, ok := nearestEntity(m, tx, 10, func(e Living) bool {
+		g, ok := e.(interface{ GameMode() world.GameMode })
+		u, holder := e.(item.User)
+		if !ok || !holder || !g.GameMode().HasCollision() {
+			return false
+		}
+		main, off := u.HeldItems()
+		return chickenFood(main) || chickenFood(off)
+	})
+	if !ok {
+		return false
+	}
+	c.LookAt(EyePosition(p))
+	if p.Position().Sub(m.Position()).Len() > 2.5 {
+		c.MoveTo(p.Position(), 1)
+	} else {
+		c.StopMoving()
+	}
+	return true
+}
+
+// breed makes the chicken walk towards another chicken in love and spawns a
+// baby chicken once the two meet. False is returned if no other chicken in
+// love was found.
+func (c *ChickenBehaviour) breed(m *Mob, tx *world.Tx) bool {
+	mate, ok := nearestEntity(m, tx, 8, func(e Living) bool {
+		if mob, ok := e.(*Mob); ok {
+			other, ok := mob.Behaviour().(*ChickenBehaviour)
+			return ok && other.InLove() && !other.Baby()
+		}
+		return false
+	})
+	if !ok {
+		return false
+	}
+	if mate.Position().Sub(m.Position()).Len() > 1.5 {
+		c.MoveTo(mate.Position(), 1)
+		return true
+	}
+	other := mate.(*Mob).Behaviour().(*ChickenBehaviour)
+	c.loveTicks, other.loveTicks = 0, 0
+	c.breedCooldown, other.breedCooldown = chickenBreedCooldown, chickenBreedCooldown
+	m.updateState()
+	mate.(*Mob).updateState()
+
+	opts := world.EntitySpawnOpts{Position: m.Position(), Rotation: cube.Rotation{rand.Float64() * 360}}
+	tx.AddEntity(opts.New(ChickenType, ChickenBehaviourConfig{Baby: true}))
+	for _, orb := range NewExperienceOrbs(m.Position(), 1+rand.IntN(7)) {
+		tx.AddEntity(orb)
+	}
+	return true
+}
+
+// chickenFood checks if the item stack passed holds seeds that a chicken may
+// be fed.
+func chickenFood(s item.Stack) bool {
+	switch s.Item().(type) {
+	case block.WheatSeeds, block.MelonSeeds, block.PumpkinSeeds, block.BeetrootSeeds:
+		return true
+	}
+	return false
+}
+
+// drops returns the items dropped by a chicken when it dies. Baby chickens
+// do not drop any items.
+func (c *ChickenBehaviour) drops(m *Mob, _ world.DamageSource) []item.Stack {
+	if c.Baby() {
+		return nil
+	}
+	drops := []item.Stack{item.NewStack(item.Chicken{Cooked: m.OnFireDuration() > 0}, 1)}
+	if n := rand.IntN(3); n > 0 {
+		drops = append(drops, item.NewStack(item.Feather{}, n))
+	}
+	return drops
+}
+
+// hatchEgg hatches a thrown egg where it broke. One in eight eggs hatches a
+// baby chicken, and one in 32 of those hatches four baby chickens instead.
+func hatchEgg(e *Ent, tx *world.Tx) {
+	if rand.IntN(8) != 0 {
+		return
+	}
+	n := 1
+	if rand.IntN(32) == 0 {
+		n = 4
+	}
+	for range n {
+		opts := world.EntitySpawnOpts{Position: e.Position().Add(mgl64.Vec3{0, 0.1}), Rotation: cube.Rotation{e.Rotation().Yaw()}}
+		tx.AddEntity(opts.New(ChickenType, ChickenBehaviourConfig{Baby: true}))
+	}
+}
+
+// ChickenType is a world.EntityType implementation for chickens.
+var ChickenType chickenType
+
+type chickenType struct{}
+
+func (chickenType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (chickenType) EncodeEntity() string { return "minecraft:chicken" }
+func (chickenType) BBox(e world.Entity) cube.BBox {
+	if m, ok := e.(*Mob); ok && m.Behaviour().(*ChickenBehaviour).Baby() {
+		return cube.Box(-0.1, 0, -0.1, 0.1, 0.35, 0.1)
+	}
+	return cube.Box(-0.2, 0, -0.2, 0.2, 0.7, 0.2)
+}
+
+func (chickenType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	c := chickenConf.New()
+	c.MobBehaviour.decodeNBT(m)
+	if nbtconv.Bool(m, "IsBaby") {
+		c.growUpTicks = max(int(nbtconv.Int32(m, "GrowUpTicks")), 1)
+	}
+	c.breedCooldown = int(nbtconv.Int32(m, "BreedCooldown"))
+	if _, ok := m["EggLayTime"]; ok {
+		c.eggTicks = max(int(nbtconv.Int32(m, "EggLayTime")), 1)
+	}
+	data.Data = c
+}
+
+func (chickenType) EncodeNBT(data *world.EntityData) map[string]any {
+	c := data.Data.(*ChickenBehaviour)
+	m := map[string]any{
+		"IsBaby":        boolByte(c.Baby()),
+		"GrowUpTicks":   int32(c.growUpTicks),
+		"BreedCooldown": int32(c.breedCooldown),
+		"EggLayTime":    int32(c.eggTicks),
+	}
+	c.MobBehaviour.encodeNBT(m)
+	return m
+}
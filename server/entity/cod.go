@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+)
+
+// NewCod creates a new cod.
+func NewCod(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(CodType, codConf)
+}
+
+var codConf CodBehaviourConfig
+
+// CodBehaviourConfig holds optional parameters for a CodBehaviour.
+type CodBehaviourConfig struct{}
+
+func (conf CodBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a CodBehaviour using the parameters in conf.
+func (conf CodBehaviourConfig) New() *CodBehaviour {
+	c := &CodBehaviour{fish: fish{school: 8, air: fishMaxAir}}
+	c.MobBehaviour = MobBehaviourConfig{MaxHealth: 3, Speed: 0.03, Swimming: true, Experience: 1 + rand.IntN(3), Drops: c.drops}.New()
+	return c
+}
+
+// CodBehaviour implements the behaviour of cod. Cod swim around in oceans in
+// schools of up to eight fish.
+type CodBehaviour struct {
+	*MobBehaviour
+	fish
+}
+
+// Bucket allows the cod to be captured in a bucket of water.
+func (c *CodBehaviour) Bucket(*Mob) (string, bool) {
+	return c.bucket("cod")
+}
+
+// Tick ticks the cod, making it swim around with its school.
+func (c *CodBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !c.Dead() && !c.tickFish(&Mob{Ent: e}, c.MobBehaviour, tx) {
+		return nil
+	}
+	return c.MobBehaviour.Tick(e, tx)
+}
+
+// drops returns the items dropped by a cod when it dies. The cod is dropped
+// cooked if the cod died while on fire.
+func (c *CodBehaviour) drops(m *Mob, _ world.DamageSource) []item.Stack {
+	return fishDrops(item.Cod{Cooked: m.OnFireDuration() > 0})
+}
+
+// CodType is a world.EntityType implementation for cod.
+var CodType codType
+
+type codType struct{}
+
+func (codType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (codType) EncodeEntity() string { return "minecraft:cod" }
+func (codType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.25, 0, -0.25, 0.25, 0.3, 0.25)
+}
+
+func (codType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	c := codConf.New()
+	c.MobBehaviour.decodeNBT(m)
+	c.fish.decodeNBT(m)
+	data.Data = c
+}
+
+func (codType) EncodeNBT(data *world.EntityData) map[string]any {
+	c := data.Data.(*CodBehaviour)
+	m := map[string]any{}
+	c.MobBehaviour.encodeNBT(m)
+	c.fish.encodeNBT(m)
+	return m
+}
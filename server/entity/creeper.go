@@ -0,0 +1,249 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"math/rand/v2"
+	"time"
+)
+
+// NewCreeper creates a new creeper. Charged creepers explode with twice the
+// power of normal creepers.
+func NewCreeper(opts world.EntitySpawnOpts, charged bool) *world.EntityHandle {
+	conf := creeperConf
+	conf.Charged = charged
+	return opts.New(CreeperType, conf)
+}
+
+var creeperConf = CreeperBehaviourConfig{}
+
+// CreeperBehaviourConfig holds optional parameters for a CreeperBehaviour.
+type CreeperBehaviourConfig struct {
+	// Charged specifies if the creeper is charged. Creepers become charged
+	// when they are struck by lightning.
+	Charged bool
+}
+
+func (conf CreeperBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a CreeperBehaviour using the parameters in conf.
+func (conf CreeperBehaviourConfig) New() *CreeperBehaviour {
+	c := &CreeperBehaviour{charged: conf.Charged, swellDir: -1}
+	c.MobBehaviour = MobBehaviourConfig{MaxHealth: 20, Speed: 0.1, Experience: 5, Drops: c.drops}.New()
+	return c
+}
+
+const (
+	// creeperFuse is the amount of ticks that a creeper swells before it
+	// explodes.
+	creeperFuse = 30
+	// creeperStartSwellDist is the distance to its target within which a
+	// creeper starts swelling, while creeperStopSwellDist is the distance
+	// beyond which a swelling creeper stops swelling again.
+	creeperStartSwellDist, creeperStopSwellDist = 3, 7
+)
+
+// CreeperBehaviour implements the behaviour of creepers. Creepers approach
+// players close to them and start swelling once they get close enough,
+// exploding after swelling for 1.5 seconds.
+type CreeperBehaviour struct {
+	*MobBehaviour
+
+	charged  bool
+	ignited  bool
+	swell    int
+	swellDir int
+	target   *world.EntityHandle
+}
+
+// Charged checks if the creeper is charged. Charged creepers explode with
+// twice the power of normal creepers.
+func (c *CreeperBehaviour) Charged() bool {
+	return c.charged
+}
+
+// Swelling checks if the creeper is currently swelling, meaning it will
+// explode unless it stops swelling before its fuse runs out.
+func (c *CreeperBehaviour) Swelling() bool {
+	return c.swellDir > 0
+}
+
+// Ignite ignites the creeper, making it swell and explode regardless of
+// whether a target is close to it.
+func (c *CreeperBehaviour) Ignite() {
+	c.ignited = true
+}
+
+// Target returns the entity that the creeper is currently approaching, or nil
+// if it is not approaching any entity.
+func (c *CreeperBehaviour) Target() *world.EntityHandle {
+	return c.target
+}
+
+// Interact ignites the creeper if the user is holding flint and steel.
+func (c *CreeperBehaviour) Interact(m *Mob, user item.User, tx *world.Tx) bool {
+	held, _ := user.HeldItems()
+	if _, ok := held.Item().(item.FlintAndSteel); !ok || c.ignited {
+		return false
+	}
+	damageHeldItem(user)
+	c.Ignite()
+	tx.PlaySound(m.Position(), sound.Ignite{})
+	return true
+}
+
+// Hurt charges the creeper if it was struck by lightning.
+func (c *CreeperBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	if _, ok := src.(LightningDamageSource); ok && !c.charged {
+		c.charged = true
+		m.updateState()
+	}
+}
+
+// Tick ticks the creeper, making it approach its target and swell when it is
+// close enough to it.
+func (c *CreeperBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !c.Dead() {
+		if exploded := c.tickCreeper(&Mob{Ent: e}, tx); exploded {
+			return nil
+		}
+	}
+	return c.MobBehaviour.Tick(e, tx)
+}
+
+// tickCreeper performs the creeper specific logic of a tick. True is returned
+// if the creeper exploded.
+func (c *CreeperBehaviour) tickCreeper(m *Mob, tx *world.Tx) bool {
+	target, dist := c.findTarget(m, tx)
+	swelling := c.Swelling()
+	switch {
+	case c.ignited || (target != nil && dist < creeperStartSwellDist):
+		c.swellDir = 1
+	case target == nil || dist > creeperStopSwellDist:
+		c.swellDir = -1
+	}
+	if c.Swelling() != swelling {
+		if c.Swelling() && c.swell == 0 {
+			tx.PlaySound(m.Position(), sound.CreeperFuse{})
+			tx.EmitGameEvent(m.Position(), gameevent.PrimeFuse{}, m)
+		}
+		m.updateState()
+	}
+	if c.swell = max(c.swell+c.swellDir, 0); c.swell >= creeperFuse {
+		c.explode(m, tx)
+		return true
+	}
+
+	if c.Swelling() {
+		c.StopMoving()
+		if target != nil {
+			c.LookAt(EyePosition(target))
+		}
+		return false
+	}
+	if target != nil {
+		c.LookAt(EyePosition(target))
+		c.MoveTo(target.Position(), 1)
+	} else if !c.Moving() && rand.IntN(120) == 0 {
+		c.MoveTo(m.Position().Add(randomHorizontalOffset(8)), 0.8)
+	}
+	return false
+}
+
+// findTarget returns the target of the creeper and the distance to it, looking
+// for a new target if the creeper currently has none. Nil is returned if no
+// target was found.
+func (c *CreeperBehaviour) findTarget(m *Mob, tx *world.Tx) (world.Entity, float64) {
+	if c.target != nil {
+		e, ok := c.target.Entity(tx)
+		if l, living := e.(Living); ok && living && !l.Dead() && attackablePlayer(l) {
+			if dist := e.Position().Sub(m.Position()).Len(); dist <= 16 {
+				return e, dist
+			}
+		}
+		c.target = nil
+	}
+	if m.Age()%(time.Second/2) != 0 {
+		return nil, 0
+	}
+	if t, ok := nearestEntity(m, tx, 16, attackablePlayer); ok {
+		c.target = t.H()
+		return t, t.Position().Sub(m.Position()).Len()
+	}
+	return nil, 0
+}
+
+// explode removes the creeper from the world and creates an explosion at its
+// position.
+func (c *CreeperBehaviour) explode(m *Mob, tx *world.Tx) {
+	size := 3.0
+	if c.charged {
+		size *= 2
+	}
+	pos := m.Position()
+	_ = m.Close()
+	block.ExplosionConfig{Size: size, Source: m}.Explode(tx, pos)
+}
+
+// drops returns the items dropped by a creeper when it dies. Creepers killed
+// by an arrow shot by a skeleton drop a random music disc.
+func (c *CreeperBehaviour) drops(_ *Mob, src world.DamageSource) []item.Stack {
+	var drops []item.Stack
+	if n := rand.IntN(3); n > 0 {
+		drops = append(drops, item.NewStack(item.Gunpowder{}, n))
+	}
+	if s, ok := src.(ProjectileDamageSource); ok && s.Owner != nil && skeleton(s.Owner) {
+		discs := creeperDiscs()
+		drops = append(drops, item.NewStack(item.MusicDisc{DiscType: discs[rand.IntN(len(discs))]}, 1))
+	}
+	return drops
+}
+
+// creeperDiscs returns all music discs that may be dropped by a creeper that
+// was killed by a skeleton.
+func creeperDiscs() []sound.DiscType {
+	return []sound.DiscType{
+		sound.Disc13(), sound.DiscCat(), sound.DiscBlocks(), sound.DiscChirp(),
+		sound.DiscFar(), sound.DiscMall(), sound.DiscMellohi(), sound.DiscStal(),
+		sound.DiscStrad(), sound.DiscWard(), sound.Disc11(), sound.DiscWait(),
+	}
+}
+
+// CreeperType is a world.EntityType implementation for creepers.
+var CreeperType creeperType
+
+type creeperType struct{}
+
+func (creeperType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (creeperType) EncodeEntity() string { return "minecraft:creeper" }
+func (creeperType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.8, 0.3)
+}
+
+func (creeperType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	c := creeperConf.New()
+	c.MobBehaviour.decodeNBT(m)
+	c.charged = nbtconv.Bool(m, "IsPowered")
+	c.ignited = nbtconv.Bool(m, "IsIgnited")
+	data.Data = c
+}
+
+func (creeperType) EncodeNBT(data *world.EntityData) map[string]any {
+	c := data.Data.(*CreeperBehaviour)
+	m := map[string]any{
+		"IsPowered": boolByte(c.charged),
+		"IsIgnited": boolByte(c.ignited),
+	}
+	c.MobBehaviour.encodeNBT(m)
+	return m
+}
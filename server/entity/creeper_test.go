@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/generator"
+)
+
+func TestIgnitedCreeperSwellsAndExplodes(t *testing.T) {
+	flat := generator.NewFlat(biome.Plains{}, []world.Block{block.Stone{}})
+	w := world.Config{Entities: DefaultRegistry, Generator: flat}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		tx.World().SetTime(18000)
+		pos := cube.Pos{0, -63, 0}
+		m := tx.AddEntity(NewCreeper(world.EntitySpawnOpts{Position: pos.Vec3Middle()}, false)).(*Mob)
+		c := m.Behaviour().(*CreeperBehaviour)
+		c.Ignite()
+
+		for i := range creeperFuse - 1 {
+			m.Tick(tx, int64(i))
+		}
+		if !c.Swelling() || !inWorld(tx, m) {
+			t.Errorf("expected ignited creeper to swell for %v ticks before exploding", creeperFuse)
+			return
+		}
+		m.Tick(tx, creeperFuse)
+		if inWorld(tx, m) {
+			t.Errorf("expected creeper to explode once its fuse ran out")
+		}
+		if _, ok := tx.Block(pos.Side(cube.FaceDown)).(block.Air); !ok {
+			t.Errorf("expected explosion of the creeper to destroy the block below it")
+		}
+	})
+}
+
+func TestCreeperChargedByLightning(t *testing.T) {
+	flat := generator.NewFlat(biome.Plains{}, []world.Block{block.Stone{}})
+	w := world.Config{Entities: DefaultRegistry, Generator: flat}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		tx.World().SetTime(18000)
+		pos := cube.Pos{0, -63, 0}.Vec3Middle()
+		m := tx.AddEntity(NewCreeper(world.EntitySpawnOpts{Position: pos}, false)).(*Mob)
+		c := m.Behaviour().(*CreeperBehaviour)
+		if c.Charged() {
+			t.Errorf("expected creeper not to be charged when spawned")
+			return
+		}
+
+		l := tx.AddEntity(NewLightningWithDamage(world.EntitySpawnOpts{Position: pos}, 1, false, 0)).(*Ent)
+		for i := range 10 {
+			if !inWorld(tx, l) {
+				break
+			}
+			l.Tick(tx, int64(i))
+		}
+		if !c.Charged() {
+			t.Errorf("expected creeper struck by lightning to be charged")
+		}
+	})
+}
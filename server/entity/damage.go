@@ -19,6 +19,10 @@ type (
 	// void.
 	VoidDamageSource struct{}
 
+	// BorderDamageSource is used for damage caused by an entity being too far
+	// outside the border of a world.
+	BorderDamageSource struct{}
+
 	// SuffocationDamageSource is used for damage caused by an entity
 	// suffocating in a block.
 	SuffocationDamageSource struct{}
@@ -45,7 +49,24 @@ type (
 	}
 
 	// ExplosionDamageSource is used for damage caused by an explosion.
-	ExplosionDamageSource struct{}
+	ExplosionDamageSource struct {
+		// Source holds the entity that caused the explosion, such as a
+		// creeper. Source is nil if the explosion was not caused by an
+		// entity.
+		Source world.Entity
+	}
+
+	// BurnDamageSource is used for damage caused by an entity being on fire.
+	// Unlike block.FireDamageSource, which is used for damage caused by
+	// standing in fire, the damage is not reduced by armour.
+	BurnDamageSource struct{}
+
+	// SonicBoomDamageSource is used for damage caused by the sonic boom of a
+	// warden. Neither armour nor protection enchantments reduce the damage.
+	SonicBoomDamageSource struct {
+		// Warden holds the warden that released the sonic boom.
+		Warden world.Entity
+	}
 )
 
 func (FallDamageSource) ReducedByArmour() bool     { return false }
@@ -71,6 +92,10 @@ func (VoidDamageSource) ReducedByResistance() bool        { return false }
 func (VoidDamageSource) ReducedByArmour() bool            { return false }
 func (VoidDamageSource) Fire() bool                       { return false }
 func (VoidDamageSource) IgnoreTotem() bool                { return true }
+func (BorderDamageSource) ReducedByResistance() bool      { return true }
+func (BorderDamageSource) ReducedByArmour() bool          { return false }
+func (BorderDamageSource) Fire() bool                     { return false }
+func (BorderDamageSource) IgnoreTotem() bool              { return false }
 func (SuffocationDamageSource) ReducedByResistance() bool { return false }
 func (SuffocationDamageSource) ReducedByArmour() bool     { return false }
 func (SuffocationDamageSource) Fire() bool                { return false }
@@ -92,4 +117,16 @@ func (ExplosionDamageSource) Fire() bool                { return false }
 func (ExplosionDamageSource) AffectedByEnchantment(e item.EnchantmentType) bool {
 	return e == enchantment.BlastProtection
 }
-func (ExplosionDamageSource) IgnoreTotem() bool { return false }
+func (ExplosionDamageSource) IgnoreTotem() bool    { return false }
+func (BurnDamageSource) ReducedByResistance() bool { return true }
+func (BurnDamageSource) ReducedByArmour() bool     { return false }
+func (BurnDamageSource) Fire() bool                { return true }
+func (BurnDamageSource) AffectedByEnchantment(e item.EnchantmentType) bool {
+	return e == enchantment.FireProtection
+}
+func (BurnDamageSource) IgnoreTotem() bool               { return false }
+func (SonicBoomDamageSource) ReducedByResistance() bool  { return true }
+func (SonicBoomDamageSource) ReducedByArmour() bool      { return false }
+func (SonicBoomDamageSource) Fire() bool                 { return false }
+func (SonicBoomDamageSource) BypassesEnchantments() bool { return true }
+func (SonicBoomDamageSource) IgnoreTotem() bool          { return false }
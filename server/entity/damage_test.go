@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestBurnDamageBypassesArmour(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		// armoured returns a zombie wearing a full set of diamond armour.
+		armoured := func(pos cube.Pos) *Mob {
+			m := tx.AddEntity(NewZombie(world.EntitySpawnOpts{Position: pos.Vec3Middle()})).(*Mob)
+			tier := item.ArmourTierDiamond{}
+			m.Behaviour().(*ZombieBehaviour).Equipment().Armour().Set(
+				item.NewStack(item.Helmet{Tier: tier}, 1), item.NewStack(item.Chestplate{Tier: tier}, 1),
+				item.NewStack(item.Leggings{Tier: tier}, 1), item.NewStack(item.Boots{Tier: tier}, 1),
+			)
+			return m
+		}
+		attacked, burnt := armoured(cube.Pos{0, 64, 0}), armoured(cube.Pos{4, 64, 0})
+
+		attacked.Hurt(10, AttackDamageSource{})
+		if lost := attacked.MaxHealth() - attacked.Health(); lost >= 5 {
+			t.Errorf("expected armour to reduce melee damage, lost %v of 10 health", lost)
+		}
+		burnt.Hurt(10, BurnDamageSource{})
+		if lost := burnt.MaxHealth() - burnt.Health(); lost != 10 {
+			t.Errorf("expected burning damage not to be reduced by armour, lost %v of 10 health", lost)
+		}
+	})
+}
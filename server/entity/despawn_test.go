@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/generator"
+)
+
+// inWorld checks if the entity passed is still in the world of the
+// transaction passed.
+func inWorld(tx *world.Tx, e world.Entity) bool {
+	for other := range tx.Entities() {
+		if other.H() == e.H() {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNamedMobDoesNotDespawn(t *testing.T) {
+	flat := generator.NewFlat(biome.Plains{}, []world.Block{block.Stone{}})
+	w := world.Config{Entities: DefaultRegistry, Generator: flat}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		tx.World().SetTime(18000)
+		// Without any players in the world, every mob is beyond the instant
+		// despawn distance.
+		unnamed := tx.AddEntity(NewZombie(world.EntitySpawnOpts{Position: cube.Pos{0, -63, 0}.Vec3Middle()})).(*Mob)
+		named := tx.AddEntity(NewZombie(world.EntitySpawnOpts{Position: cube.Pos{8, -63, 0}.Vec3Middle()})).(*Mob)
+		named.SetNameTag("Bob")
+
+		for i := range 40 {
+			if inWorld(tx, unnamed) {
+				unnamed.Tick(tx, int64(i))
+			}
+			named.Tick(tx, int64(i))
+		}
+		if inWorld(tx, unnamed) {
+			t.Errorf("expected zombie without a name tag to despawn")
+		}
+		if !inWorld(tx, named) {
+			t.Errorf("expected zombie with a name tag not to despawn")
+		}
+	})
+}
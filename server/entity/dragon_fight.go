@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/generator/end"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// exitPortalPosition returns the position of the centre of the exit portal at
+// x=0, z=0, at the height of the end portal blocks. If no exit portal exists
+// yet, the position just above the highest block is returned instead.
+func exitPortalPosition(tx *world.Tx) cube.Pos {
+	y, b := tx.HighestBlock(0, 0)
+	if y <= tx.Range().Min() {
+		// There are no blocks at all, so we fall back to the height that the
+		// main island of the End is usually generated at.
+		return cube.Pos{0, 64, 0}
+	}
+	if _, ok := b.(block.DragonEgg); ok {
+		y--
+	}
+	// The exit portal has a bedrock pillar in its centre that is four blocks
+	// high.
+	bottom := y
+	for ; bottom > y-4; bottom-- {
+		if _, ok := tx.Block(cube.Pos{0, bottom, 0}).(block.Bedrock); !ok {
+			break
+		}
+	}
+	if y-bottom == 4 {
+		return cube.Pos{0, bottom + 1, 0}
+	}
+	return cube.Pos{0, y + 1, 0}
+}
+
+// openEndGateway opens the next end gateway around the main island of the End
+// after an ender dragon was defeated. The gateways are opened in an order that
+// depends on the seed of the World. Nothing happens if all gateways are open.
+func openEndGateway(tx *world.Tx) {
+	for _, pos := range end.Gateways(tx.World().Seed()) {
+		if _, ok := tx.Block(pos).(block.EndGateway); !ok {
+			block.EndGateway{}.Place(pos, tx)
+			return
+		}
+	}
+}
+
+// respawnEnderDragon respawns the ender dragon if end crystals were placed on
+// all four sides of the exit portal and no ender dragon is alive. The exit
+// portal is deactivated and the end crystals explode shortly after. True is
+// returned if the ender dragon was respawned.
+func respawnEnderDragon(tx *world.Tx) bool {
+	origin := exitPortalPosition(tx)
+	if _, ok := tx.Block(origin).(block.Bedrock); !ok {
+		// There is no exit portal to place the end crystals on.
+		return false
+	}
+	crystals := make([]*EndCrystal, 0, 4)
+	for _, face := range cube.HorizontalFaces() {
+		pos := origin.Add(cube.Pos{0, 1, 0}).Side(face).Side(face).Side(face)
+		for e := range tx.EntitiesWithin(cube.Box(0, 0, 0, 1, 1, 1).Translate(pos.Vec3()).Grow(0.5)) {
+			if c, ok := e.(*EndCrystal); ok && c.behaviour().fuse == 0 {
+				crystals = append(crystals, c)
+				break
+			}
+		}
+	}
+	if len(crystals) != 4 {
+		return false
+	}
+	for e := range tx.Entities() {
+		if e.H().Type() == EnderDragonType {
+			return false
+		}
+	}
+	end.PlaceExitPortal(tx, origin, false)
+
+	spawn := origin.Vec3Middle().Add(mgl64.Vec3{0, 64})
+	spawn[1] = min(spawn[1], float64(tx.Range().Max()-8))
+	for i, c := range crystals {
+		c.SetBeamTarget(cube.PosFromVec3(spawn))
+		// The end crystals explode one after another.
+		c.behaviour().fuse = 100 + i*10
+	}
+	conf := enderDragonConf
+	conf.Portal, conf.Respawned = &origin, true
+	tx.AddEntity(world.EntitySpawnOpts{Position: spawn}.New(EnderDragonType, conf))
+	return true
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+// NewDragonFireball creates a dragon fireball, as shot by the ender dragon,
+// that accelerates in the direction passed.
+func NewDragonFireball(opts world.EntitySpawnOpts, owner world.Entity, dir mgl64.Vec3) *world.EntityHandle {
+	conf := dragonFireballConf
+	conf.Owner, conf.Direction = owner.H(), dir
+	return opts.New(DragonFireballType, conf)
+}
+
+var dragonFireballConf = DragonFireballBehaviourConfig{}
+
+// DragonFireballBehaviourConfig holds optional parameters for a
+// DragonFireballBehaviour.
+type DragonFireballBehaviourConfig struct {
+	// Owner is the entity that shot the dragon fireball.
+	Owner *world.EntityHandle
+	// Direction is the direction that the dragon fireball accelerates in.
+	Direction mgl64.Vec3
+}
+
+func (conf DragonFireballBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a DragonFireballBehaviour using the parameters in conf.
+func (conf DragonFireballBehaviourConfig) New() *DragonFireballBehaviour {
+	f := &DragonFireballBehaviour{}
+	if conf.Direction.Len() > 0 {
+		f.acceleration = conf.Direction.Normalize().Mul(fireballAcceleration)
+	}
+	f.ProjectileBehaviour = ProjectileBehaviourConfig{
+		Owner:       conf.Owner,
+		Drag:        0.05,
+		Damage:      -1,
+		Hit:         f.hit,
+		IgnoreOwner: true,
+	}.New()
+	return f
+}
+
+// DragonFireballBehaviour implements the behaviour of dragon fireballs. Like
+// large fireballs, dragon fireballs keep accelerating in the direction they
+// were shot in. Instead of exploding, they leave behind a cloud of dragon's
+// breath that harms entities standing in it.
+type DragonFireballBehaviour struct {
+	*ProjectileBehaviour
+
+	acceleration mgl64.Vec3
+}
+
+// Tick accelerates the dragon fireball and moves it.
+func (f *DragonFireballBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	e.data.Vel = e.data.Vel.Add(f.acceleration)
+	return f.ProjectileBehaviour.Tick(e, tx)
+}
+
+// hit creates a cloud of dragon's breath where the dragon fireball hit
+// something.
+func (f *DragonFireballBehaviour) hit(e *Ent, tx *world.Tx, _ trace.Result) {
+	tx.AddEntity(newDragonBreath(e.Position()))
+}
+
+// newDragonBreath creates a cloud of dragon's breath at the position passed.
+// The cloud slowly grows, inflicting Instant Damage on entities inside it.
+func newDragonBreath(pos mgl64.Vec3) *world.EntityHandle {
+	return NewAreaEffectCloudWith(world.EntitySpawnOpts{Position: pos}, potion.Harming(), time.Second*30, time.Second, 0, 3, 0, 4.0/600)
+}
+
+// DragonFireballType is a world.EntityType implementation for dragon
+// fireballs.
+var DragonFireballType dragonFireballType
+
+type dragonFireballType struct{}
+
+func (dragonFireballType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (dragonFireballType) EncodeEntity() string { return "minecraft:dragon_fireball" }
+func (dragonFireballType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.5, 0, -0.5, 0.5, 1, 0.5)
+}
+
+func (dragonFireballType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := dragonFireballConf
+	conf.Direction = nbtconv.Vec3(m, "power")
+	data.Data = conf.New()
+}
+
+func (dragonFireballType) EncodeNBT(data *world.EntityData) map[string]any {
+	return map[string]any{"power": nbtconv.Vec3ToFloat32Slice(data.Data.(*DragonFireballBehaviour).acceleration)}
+}
@@ -1,7 +1,9 @@
 package effect
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
 	"image/color"
 	"time"
 )
@@ -174,8 +176,6 @@ type living interface {
 	Health() float64
 	// MaxHealth returns the maximum health of the entity.
 	MaxHealth() float64
-	// SetMaxHealth changes the maximum health of the entity to the value passed.
-	SetMaxHealth(v float64)
 	// Hurt hurts the entity for a given amount of damage. The source passed represents the cause of the
 	// damage, for example entity.AttackDamageSource if the entity is attacked by another entity.
 	// If the final damage exceeds the health that the player currently has, the entity is killed.
@@ -184,8 +184,10 @@ type living interface {
 	// healing, for example entity.FoodHealingSource if the entity healed by having a full food bar. If the health
 	// added to the original health exceeds the entity's max health, Heal may not add the full amount.
 	Heal(health float64, source world.HealingSource)
-	// speed returns the current speed of the living entity. The default value is different for each entity.
-	Speed() float64
-	// SetSpeed sets the speed of an entity to a new value.
-	SetSpeed(float64)
+	// AddAttributeModifier applies an attribute.Modifier to an attribute of
+	// the entity, replacing any modifier with the same ID.
+	AddAttributeModifier(attr attribute.Attribute, m attribute.Modifier)
+	// RemoveAttributeModifier removes the attribute.Modifier with the ID
+	// passed from an attribute of the entity.
+	RemoveAttributeModifier(attr attribute.Attribute, id uuid.UUID)
 }
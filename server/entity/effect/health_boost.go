@@ -1,7 +1,9 @@
 package effect
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
 	"image/color"
 )
 
@@ -13,17 +15,26 @@ type healthBoost struct {
 	nopLasting
 }
 
+// healthBoostModifierID is the ID of the attribute.Modifier applied to the
+// maximum health of entities by the HealthBoost effect.
+var healthBoostModifierID = uuid.MustParse("5d6f0ba2-1186-46ac-b896-c61c5cee99cc")
+
 // Start ...
 func (healthBoost) Start(e world.Entity, lvl int) {
 	if l, ok := e.(living); ok {
-		l.SetMaxHealth(l.MaxHealth() + 4*float64(lvl))
+		l.AddAttributeModifier(attribute.MaxHealth(), attribute.Modifier{
+			ID:        healthBoostModifierID,
+			Name:      "effect.health_boost",
+			Amount:    4 * float64(lvl),
+			Operation: attribute.OperationAdd(),
+		})
 	}
 }
 
 // End ...
-func (healthBoost) End(e world.Entity, lvl int) {
+func (healthBoost) End(e world.Entity, _ int) {
 	if l, ok := e.(living); ok {
-		l.SetMaxHealth(l.MaxHealth() - 4*float64(lvl))
+		l.RemoveAttributeModifier(attribute.MaxHealth(), healthBoostModifierID)
 	}
 }
 
@@ -0,0 +1,24 @@
+package effect
+
+import (
+	"image/color"
+)
+
+// HeroOfTheVillage is a lasting effect that causes villagers to offer
+// discounts on their trades to the affected entity.
+var HeroOfTheVillage heroOfTheVillage
+
+type heroOfTheVillage struct {
+	nopLasting
+}
+
+// Discount returns the fraction of the original price of a trade that is
+// discounted for an entity with this effect at a specific level.
+func (heroOfTheVillage) Discount(lvl int) float64 {
+	return 0.3 + 0.0625*float64(lvl-1)
+}
+
+// RGBA ...
+func (heroOfTheVillage) RGBA() color.RGBA {
+	return color.RGBA{R: 0x44, G: 0xff, B: 0x44, A: 0xff}
+}
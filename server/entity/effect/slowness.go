@@ -1,7 +1,9 @@
 package effect
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
 	"image/color"
 )
 
@@ -13,25 +15,26 @@ type slowness struct {
 	nopLasting
 }
 
+// slownessModifierID is the ID of the attribute.Modifier applied to the
+// movement speed of entities by the Slowness effect.
+var slownessModifierID = uuid.MustParse("7107de5e-7ce8-4030-940e-514c1f160890")
+
 // Start ...
 func (slowness) Start(e world.Entity, lvl int) {
-	slowness := 1 - float64(lvl)*0.15
-	if slowness <= 0 {
-		slowness = 0.00001
-	}
 	if l, ok := e.(living); ok {
-		l.SetSpeed(l.Speed() * slowness)
+		l.AddAttributeModifier(attribute.MovementSpeed(), attribute.Modifier{
+			ID:        slownessModifierID,
+			Name:      "effect.slowness",
+			Amount:    -min(float64(lvl)*0.15, 1),
+			Operation: attribute.OperationMultiplyTotal(),
+		})
 	}
 }
 
 // End ...
-func (slowness) End(e world.Entity, lvl int) {
-	slowness := 1 - float64(lvl)*0.15
-	if slowness <= 0 {
-		slowness = 0.00001
-	}
+func (slowness) End(e world.Entity, _ int) {
 	if l, ok := e.(living); ok {
-		l.SetSpeed(l.Speed() / slowness)
+		l.RemoveAttributeModifier(attribute.MovementSpeed(), slownessModifierID)
 	}
 }
 
@@ -1,7 +1,9 @@
 package effect
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
 	"image/color"
 )
 
@@ -13,19 +15,26 @@ type speed struct {
 	nopLasting
 }
 
+// speedModifierID is the ID of the attribute.Modifier applied to the
+// movement speed of entities by the Speed effect.
+var speedModifierID = uuid.MustParse("91aeaa56-376b-4498-935b-2f7f68070635")
+
 // Start ...
 func (speed) Start(e world.Entity, lvl int) {
-	speed := 1 + float64(lvl)*0.2
 	if l, ok := e.(living); ok {
-		l.SetSpeed(l.Speed() * speed)
+		l.AddAttributeModifier(attribute.MovementSpeed(), attribute.Modifier{
+			ID:        speedModifierID,
+			Name:      "effect.speed",
+			Amount:    float64(lvl) * 0.2,
+			Operation: attribute.OperationMultiplyTotal(),
+		})
 	}
 }
 
 // End ...
-func (speed) End(e world.Entity, lvl int) {
-	speed := 1 + float64(lvl)*0.2
+func (speed) End(e world.Entity, _ int) {
 	if l, ok := e.(living); ok {
-		l.SetSpeed(l.Speed() / speed)
+		l.RemoveAttributeModifier(attribute.MovementSpeed(), speedModifierID)
 	}
 }
 
@@ -1,6 +1,9 @@
 package effect
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
 	"image/color"
 )
 
@@ -12,11 +15,34 @@ type strength struct {
 	nopLasting
 }
 
+// strengthModifierID is the ID of the attribute.Modifier applied to the
+// attack damage of entities by the Strength effect.
+var strengthModifierID = uuid.MustParse("648d7064-6a60-4f59-8abe-c2c23a6dd7a9")
+
 // Multiplier returns the damage multiplier of the effect.
 func (strength) Multiplier(lvl int) float64 {
 	return 0.3 * float64(lvl)
 }
 
+// Start ...
+func (s strength) Start(e world.Entity, lvl int) {
+	if l, ok := e.(living); ok {
+		l.AddAttributeModifier(attribute.AttackDamage(), attribute.Modifier{
+			ID:        strengthModifierID,
+			Name:      "effect.strength",
+			Amount:    s.Multiplier(lvl),
+			Operation: attribute.OperationMultiplyTotal(),
+		})
+	}
+}
+
+// End ...
+func (strength) End(e world.Entity, _ int) {
+	if l, ok := e.(living); ok {
+		l.RemoveAttributeModifier(attribute.AttackDamage(), strengthModifierID)
+	}
+}
+
 // RGBA ...
 func (strength) RGBA() color.RGBA {
 	return color.RGBA{R: 0xff, G: 0xc7, B: 0x00, A: 0xff}
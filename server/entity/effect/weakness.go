@@ -1,6 +1,9 @@
 package effect
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
 	"image/color"
 )
 
@@ -12,6 +15,10 @@ type weakness struct {
 	nopLasting
 }
 
+// weaknessModifierID is the ID of the attribute.Modifier applied to the
+// attack damage of entities by the Weakness effect.
+var weaknessModifierID = uuid.MustParse("22653b89-116e-49dc-9b6b-9971489b5be5")
+
 // Multiplier returns the damage multiplier of the effect.
 func (weakness) Multiplier(lvl int) float64 {
 	v := 0.2 * float64(lvl)
@@ -21,6 +28,25 @@ func (weakness) Multiplier(lvl int) float64 {
 	return v
 }
 
+// Start ...
+func (w weakness) Start(e world.Entity, lvl int) {
+	if l, ok := e.(living); ok {
+		l.AddAttributeModifier(attribute.AttackDamage(), attribute.Modifier{
+			ID:        weaknessModifierID,
+			Name:      "effect.weakness",
+			Amount:    -w.Multiplier(lvl),
+			Operation: attribute.OperationMultiplyTotal(),
+		})
+	}
+}
+
+// End ...
+func (weakness) End(e world.Entity, _ int) {
+	if l, ok := e.(living); ok {
+		l.RemoveAttributeModifier(attribute.AttackDamage(), weaknessModifierID)
+	}
+}
+
 // RGBA ...
 func (weakness) RGBA() color.RGBA {
 	return color.RGBA{R: 0x48, G: 0x4d, B: 0x48, A: 0xff}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/particle"
 )
@@ -14,12 +15,14 @@ func NewEgg(opts world.EntitySpawnOpts, owner world.Entity) *world.EntityHandle
 	return opts.New(EggType, conf)
 }
 
-// TODO: Spawn chicken(e) 12.5% of the time.
 var eggConf = ProjectileBehaviourConfig{
 	Gravity:       0.03,
 	Drag:          0.01,
 	Particle:      particle.EggSmash{},
 	ParticleCount: 6,
+	Hit: func(e *Ent, tx *world.Tx, _ trace.Result) {
+		hatchEgg(e, tx)
+	},
 }
 
 // EggType is a world.EntityType implementation for Egg.
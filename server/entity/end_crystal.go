@@ -0,0 +1,177 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewEndCrystal creates a new end crystal. If showBase is true, the bedrock
+// base of the end crystal is shown below it.
+func NewEndCrystal(opts world.EntitySpawnOpts, showBase bool) *world.EntityHandle {
+	conf := endCrystalConf
+	conf.ShowBase = showBase
+	return opts.New(EndCrystalType, conf)
+}
+
+var endCrystalConf = EndCrystalBehaviourConfig{}
+
+// EndCrystalBehaviourConfig holds optional parameters for an
+// EndCrystalBehaviour.
+type EndCrystalBehaviourConfig struct {
+	// ShowBase specifies if the bedrock base of the end crystal is shown.
+	ShowBase bool
+	// BeamTarget is the position that the beam of the end crystal points to.
+	// If nil, the end crystal has no beam.
+	BeamTarget *cube.Pos
+}
+
+func (conf EndCrystalBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates an EndCrystalBehaviour using the parameters in conf.
+func (conf EndCrystalBehaviourConfig) New() *EndCrystalBehaviour {
+	return &EndCrystalBehaviour{showBase: conf.ShowBase, beamTarget: conf.BeamTarget}
+}
+
+// EndCrystalBehaviour implements the behaviour of end crystals. End crystals
+// do not move and explode as soon as they take damage.
+type EndCrystalBehaviour struct {
+	showBase   bool
+	beamTarget *cube.Pos
+	exploded   bool
+
+	placed bool
+	fuse   int
+}
+
+// Tick checks if an end crystal placed in the End completes the set of end
+// crystals needed to respawn the ender dragon. End crystals used to respawn
+// the ender dragon explode shortly after. End crystals never move.
+func (b *EndCrystalBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !b.placed {
+		b.placed = true
+		if !b.showBase && tx.World().Dimension() == world.End {
+			respawnEnderDragon(tx)
+		}
+	}
+	if b.fuse > 0 {
+		if b.fuse--; b.fuse == 0 {
+			b.explode(e, tx, block.ExplosionConfig{Size: 6, KeepBlocks: true})
+		}
+	}
+	return nil
+}
+
+// Explode makes the end crystal explode when it is caught in another
+// explosion.
+func (b *EndCrystalBehaviour) Explode(e *Ent, _ mgl64.Vec3, _ float64, _ block.ExplosionConfig) {
+	b.explode(e, e.tx, block.ExplosionConfig{Size: 6})
+}
+
+// explode removes the end crystal from the world and creates an explosion at
+// its position. An end crystal explodes at most once, so that end crystals
+// caught in each other's explosions do not explode infinitely.
+func (b *EndCrystalBehaviour) explode(e *Ent, tx *world.Tx, conf block.ExplosionConfig) {
+	if b.exploded {
+		return
+	}
+	b.exploded = true
+	pos := e.Position()
+	_ = e.Close()
+	conf.Explode(tx, pos)
+}
+
+// EndCrystal is a world.Entity implementation for end crystals. End crystals
+// may be placed on obsidian and bedrock and explode when attacked. They may
+// point a beam to a block, as the end crystals on the obsidian pillars of the
+// End do to heal the ender dragon.
+type EndCrystal struct {
+	*Ent
+}
+
+// Hurt makes the end crystal explode, regardless of the amount of damage dealt
+// to it.
+func (c *EndCrystal) Hurt(dmg float64, _ world.DamageSource) (float64, bool) {
+	b := c.behaviour()
+	if dmg <= 0 || b.exploded {
+		return 0, false
+	}
+	b.explode(c.Ent, c.tx, block.ExplosionConfig{Size: 6})
+	return dmg, true
+}
+
+// ShowBase checks if the bedrock base of the end crystal is shown.
+func (c *EndCrystal) ShowBase() bool {
+	return c.behaviour().showBase
+}
+
+// BeamTarget returns the position that the beam of the end crystal points to.
+// False is returned if the end crystal has no beam.
+func (c *EndCrystal) BeamTarget() (cube.Pos, bool) {
+	if t := c.behaviour().beamTarget; t != nil {
+		return *t, true
+	}
+	return cube.Pos{}, false
+}
+
+// SetBeamTarget makes the beam of the end crystal point to the position
+// passed.
+func (c *EndCrystal) SetBeamTarget(pos cube.Pos) {
+	c.behaviour().beamTarget = &pos
+	c.updateState()
+}
+
+// ResetBeamTarget removes the beam of the end crystal.
+func (c *EndCrystal) ResetBeamTarget() {
+	c.behaviour().beamTarget = nil
+	c.updateState()
+}
+
+// behaviour returns the EndCrystalBehaviour of the end crystal.
+func (c *EndCrystal) behaviour() *EndCrystalBehaviour {
+	return c.Behaviour().(*EndCrystalBehaviour)
+}
+
+// updateState updates the state of the end crystal for all of its viewers.
+func (c *EndCrystal) updateState() {
+	for _, v := range c.tx.Viewers(c.Position()) {
+		v.ViewEntityState(c)
+	}
+}
+
+// EndCrystalType is a world.EntityType implementation for end crystals.
+var EndCrystalType endCrystalType
+
+type endCrystalType struct{}
+
+func (endCrystalType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &EndCrystal{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (endCrystalType) EncodeEntity() string { return "minecraft:ender_crystal" }
+func (endCrystalType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-1, 0, -1, 1, 2, 1)
+}
+
+func (endCrystalType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := endCrystalConf
+	conf.ShowBase = nbtconv.Bool(m, "ShowBottom")
+	if _, ok := m["BeamTarget"]; ok {
+		pos := nbtconv.Pos(m, "BeamTarget")
+		conf.BeamTarget = &pos
+	}
+	data.Data = conf.New()
+}
+
+func (endCrystalType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*EndCrystalBehaviour)
+	m := map[string]any{"ShowBottom": boolByte(b.showBase)}
+	if b.beamTarget != nil {
+		m["BeamTarget"] = nbtconv.PosToInt32Slice(*b.beamTarget)
+	}
+	return m
+}
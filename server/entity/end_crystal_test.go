@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestEndCrystalExplodesWhenAttacked(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	defer w.Close()
+
+	pos := cube.Pos{8, 64, 8}
+	<-w.Exec(func(tx *world.Tx) {
+		tx.SetBlock(pos.Side(cube.FaceDown), block.Obsidian{}, nil)
+		tx.SetBlock(pos.Add(cube.Pos{2, 0, 0}), block.Stone{}, nil)
+		handle := NewEndCrystal(world.EntitySpawnOpts{Position: pos.Vec3Middle()}, false)
+		c := tx.AddEntity(handle).(*EndCrystal)
+
+		if _, ok := c.Hurt(1, AttackDamageSource{}); !ok {
+			t.Errorf("expected end crystal to be hurt when attacked")
+			return
+		}
+		if _, ok := handle.Entity(tx); ok {
+			t.Errorf("expected end crystal to be removed after exploding")
+			return
+		}
+		if _, ok := tx.Block(pos.Add(cube.Pos{2, 0, 0})).(block.Air); !ok {
+			t.Errorf("expected explosion to destroy blocks close to the end crystal, found %#v", tx.Block(pos.Add(cube.Pos{2, 0, 0})))
+		}
+	})
+}
+
+func TestEndCrystalHealsEnderDragonInRange(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		crystal := tx.AddEntity(NewEndCrystal(world.EntitySpawnOpts{Position: mgl64.Vec3{0, 80, 0}}, false)).(*EndCrystal)
+		m := tx.AddEntity(NewEnderDragon(world.EntitySpawnOpts{Position: mgl64.Vec3{10, 80, 0}})).(*Mob)
+		d := m.Behaviour().(*EnderDragonBehaviour)
+		d.health.AddHealth(-50)
+
+		// The ender dragon looks for end crystals at random, one in ten ticks.
+		for i := 0; i < 200 && d.crystal == nil; i++ {
+			d.tickCrystal(m, tx)
+		}
+		if d.crystal != crystal.H() {
+			t.Errorf("expected ender dragon to be healed by the end crystal in range")
+			return
+		}
+		d.tickCrystal(m, tx)
+		if m.Health() <= enderDragonMaxHealth-50 {
+			t.Errorf("expected ender dragon to be healed, health is %v", m.Health())
+			return
+		}
+		if _, ok := crystal.BeamTarget(); !ok {
+			t.Errorf("expected end crystal to point its beam at the ender dragon")
+			return
+		}
+
+		m.data.Pos = mgl64.Vec3{enderDragonCrystalRange + 10, 80, 0}
+		d.tickCrystal(m, tx)
+		if _, ok := crystal.BeamTarget(); ok || d.crystal != nil {
+			t.Errorf("expected end crystal to stop healing the ender dragon out of range")
+		}
+	})
+}
@@ -0,0 +1,573 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/player/bossbar"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/generator/end"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// NewEnderDragon creates a new ender dragon. The dragon circles around the
+// exit portal of the world it is spawned in and perches on top of it.
+func NewEnderDragon(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(EnderDragonType, enderDragonConf)
+}
+
+var enderDragonConf = EnderDragonBehaviourConfig{}
+
+// EnderDragonBehaviourConfig holds optional parameters for an
+// EnderDragonBehaviour.
+type EnderDragonBehaviourConfig struct {
+	// Portal is the position of the centre of the exit portal that the ender
+	// dragon circles around. If nil, the exit portal is looked up at x=0,
+	// z=0 once the dragon is first ticked.
+	Portal *cube.Pos
+	// Respawned specifies if the ender dragon was respawned using end
+	// crystals after a previous ender dragon was defeated. Respawned ender
+	// dragons drop less experience and do not leave a dragon egg behind.
+	Respawned bool
+}
+
+func (conf EnderDragonBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates an EnderDragonBehaviour using the parameters in conf.
+func (conf EnderDragonBehaviourConfig) New() *EnderDragonBehaviour {
+	d := &EnderDragonBehaviour{portal: conf.Portal, respawned: conf.Respawned}
+	d.MobBehaviour = MobBehaviourConfig{
+		MaxHealth:           enderDragonMaxHealth,
+		Speed:               0.06,
+		Drag:                0.1,
+		KnockBackResistance: 1,
+		Flying:              true,
+		FireImmune:          true,
+	}.New()
+	d.lastHealth = d.health.Health()
+	return d
+}
+
+const (
+	// enderDragonMaxHealth is the maximum health of an ender dragon.
+	enderDragonMaxHealth = 200
+	// enderDragonCircleRadius is the radius of the circle that the ender
+	// dragon flies around the exit portal in.
+	enderDragonCircleRadius = 40
+	// enderDragonCircleNodes is the amount of positions on the circle that the
+	// ender dragon flies between.
+	enderDragonCircleNodes = 12
+	// enderDragonTargetRange is the distance from the exit portal within which
+	// the ender dragon attacks players.
+	enderDragonTargetRange = 150
+	// enderDragonCrystalRange is the distance within which an end crystal
+	// heals the ender dragon.
+	enderDragonCrystalRange = 32
+	// enderDragonPerchTicks is the maximum amount of ticks that the ender
+	// dragon stays perched on the exit portal.
+	enderDragonPerchTicks = 200
+	// enderDragonDeathTicks is the amount of ticks that the death animation
+	// of the ender dragon lasts.
+	enderDragonDeathTicks = 200
+)
+
+// enderDragonPhase is a phase of the fight with the ender dragon, which
+// determines how the ender dragon moves and attacks.
+type enderDragonPhase int
+
+const (
+	// enderDragonHoldingPattern is the phase in which the ender dragon circles
+	// around the exit portal.
+	enderDragonHoldingPattern enderDragonPhase = iota
+	// enderDragonStrafing is the phase in which the ender dragon flies towards
+	// a player to shoot a dragon fireball at it.
+	enderDragonStrafing
+	// enderDragonLandingApproach is the phase in which the ender dragon flies
+	// to the space above the exit portal to land on it.
+	enderDragonLandingApproach
+	// enderDragonLanding is the phase in which the ender dragon descends onto
+	// the exit portal.
+	enderDragonLanding
+	// enderDragonPerched is the phase in which the ender dragon sits on the
+	// exit portal and breathes dragon's breath at players close to it.
+	enderDragonPerched
+	// enderDragonTakeoff is the phase in which the ender dragon leaves the exit
+	// portal to start circling around it again.
+	enderDragonTakeoff
+)
+
+// EnderDragonBehaviour implements the behaviour of the ender dragon. The
+// ender dragon circles around the exit portal, occasionally flying towards a
+// player to shoot a dragon fireball at it or perching on the exit portal. End
+// crystals close to the ender dragon heal it. Once defeated, the ender dragon
+// opens an end gateway, activates the exit portal and leaves a dragon egg
+// behind.
+type EnderDragonBehaviour struct {
+	*MobBehaviour
+
+	portal    *cube.Pos
+	respawned bool
+
+	phase      enderDragonPhase
+	phaseTicks int
+	node       int
+	target     *world.EntityHandle
+	perchDmg   float64
+
+	crystal    *world.EntityHandle
+	lastHealth float64
+	dyingTicks int
+}
+
+// Portal returns the position of the centre of the exit portal that the
+// ender dragon circles around.
+func (d *EnderDragonBehaviour) Portal() cube.Pos {
+	if d.portal == nil {
+		return cube.Pos{}
+	}
+	return *d.portal
+}
+
+// Perched checks if the ender dragon is currently sitting on the exit portal.
+func (d *EnderDragonBehaviour) Perched() bool {
+	return d.phase == enderDragonPerched
+}
+
+// BossBar returns the boss bar of the ender dragon shown to players nearby.
+func (d *EnderDragonBehaviour) BossBar() bossbar.BossBar {
+	return bossbar.New("Ender Dragon").WithHealthPercentage(d.health.Health() / d.health.MaxHealth()).WithColour(bossbar.Purple())
+}
+
+// Immune checks if the ender dragon is immune to the damage source passed.
+// The ender dragon is not hurt by effects, such as those of its own breath,
+// and is not hurt by projectiles while it is perched.
+func (d *EnderDragonBehaviour) Immune(src world.DamageSource) bool {
+	switch src.(type) {
+	case effect.InstantDamageSource, effect.PoisonDamageSource, effect.WitherDamageSource:
+		return true
+	case ProjectileDamageSource:
+		return d.Perched()
+	}
+	return false
+}
+
+// HandleProjectileHit deflects projectiles that hit the ender dragon while it
+// is perched, setting them on fire.
+func (d *EnderDragonBehaviour) HandleProjectileHit(_ *Mob, ctx *event.Context[*Ent], hit *ProjectileHit) {
+	if d.Perched() {
+		ctx.Val().SetOnFire(time.Second)
+		hit.Deflect(hit.Velocity.Mul(-0.1), nil)
+	}
+}
+
+// Hurt makes the ender dragon take off from the exit portal after taking
+// enough damage while perched. An ender dragon attacked while circling may
+// start flying towards its attacker.
+func (d *EnderDragonBehaviour) Hurt(_ *Mob, dmg float64, src world.DamageSource) {
+	switch d.phase {
+	case enderDragonPerched:
+		if d.perchDmg += dmg; d.perchDmg >= 50 {
+			d.setPhase(enderDragonTakeoff)
+		}
+	case enderDragonHoldingPattern:
+		if attacker := damageSourceAttacker(src); attacker != nil && rand.IntN(3) == 0 {
+			if l, ok := attacker.(Living); ok && attackablePlayer(l) {
+				d.target = attacker.H()
+				d.setPhase(enderDragonStrafing)
+			}
+		}
+	}
+}
+
+// Tick ticks the ender dragon, moving it according to its current phase.
+func (d *EnderDragonBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	m := &Mob{Ent: e}
+	if d.Dead() {
+		return d.tickDeath(m, tx)
+	}
+	if d.portal == nil {
+		pos := exitPortalPosition(tx)
+		d.portal = &pos
+	}
+	d.effects.Tick(m, tx)
+	if d.Dead() {
+		return nil
+	}
+	d.tickCrystal(m, tx)
+	d.tickPhase(m, tx)
+	if d.phase != enderDragonPerched {
+		d.tickCollisions(m, tx)
+		d.destroyBlocks(m, tx)
+	}
+	if health := d.health.Health(); health != d.lastHealth {
+		d.lastHealth = health
+		m.updateState()
+	}
+	return d.move(e, tx)
+}
+
+// move moves the ender dragon towards its destination. Unlike other mobs, the
+// ender dragon flies through any blocks in its way.
+func (d *EnderDragonBehaviour) move(e *Ent, tx *world.Tx) *Movement {
+	pos, vel := e.data.Pos, e.data.Vel
+	if d.destination != nil {
+		if delta := d.destination.Sub(pos); delta.Len() < 1 {
+			d.destination = nil
+		} else {
+			vel = vel.Add(delta.Normalize().Mul(d.speed() * d.speedMultiplier))
+		}
+	}
+	if d.phase == enderDragonPerched {
+		vel = mgl64.Vec3{}
+	}
+	vel = vel.Mul(1 - d.conf.Drag)
+	if vel.Len() > 0.05 {
+		e.data.Rot = rotationTowards(pos, pos.Add(vel))
+	}
+	if d.lookAt != nil {
+		e.data.Rot = rotationTowards(EyePosition(e), *d.lookAt)
+		d.lookAt = nil
+	}
+	mov := &Movement{v: tx.Viewers(pos), e: e, pos: pos.Add(vel), vel: vel, dpos: vel, dvel: vel.Sub(e.data.Vel), rot: e.data.Rot}
+	e.data.Pos, e.data.Vel = mov.pos, mov.vel
+	return mov
+}
+
+// setPhase changes the phase of the ender dragon.
+func (d *EnderDragonBehaviour) setPhase(phase enderDragonPhase) {
+	d.phase, d.phaseTicks, d.perchDmg = phase, 0, 0
+	d.StopMoving()
+}
+
+// tickPhase performs the logic of the current phase of the ender dragon.
+func (d *EnderDragonBehaviour) tickPhase(m *Mob, tx *world.Tx) {
+	d.phaseTicks++
+	perch := d.perchPosition(tx)
+	switch d.phase {
+	case enderDragonHoldingPattern:
+		d.tickHoldingPattern(m, tx)
+	case enderDragonStrafing:
+		d.tickStrafing(m, tx)
+	case enderDragonLandingApproach:
+		if !d.Moving() && d.phaseTicks > 1 {
+			d.setPhase(enderDragonLanding)
+			d.MoveTo(perch, 0.6)
+			return
+		}
+		d.MoveTo(perch.Add(mgl64.Vec3{0, 12}), 1)
+	case enderDragonLanding:
+		if !d.Moving() {
+			d.setPhase(enderDragonPerched)
+			m.data.Pos = perch
+		}
+	case enderDragonPerched:
+		d.tickPerched(m, tx)
+	case enderDragonTakeoff:
+		if !d.Moving() && d.phaseTicks > 1 {
+			d.setPhase(enderDragonHoldingPattern)
+			return
+		}
+		d.MoveTo(perch.Add(mgl64.Vec3{0, 20}), 1)
+	}
+}
+
+// tickHoldingPattern makes the ender dragon fly to the next position on the
+// circle around the exit portal once it reached its previous destination.
+// Each time it does, the ender dragon may decide to land on the exit portal or
+// to attack a player. The fewer end crystals are left, the more likely the
+// ender dragon is to land.
+func (d *EnderDragonBehaviour) tickHoldingPattern(m *Mob, tx *world.Tx) {
+	if d.Moving() {
+		return
+	}
+	if d.phaseTicks > 1 {
+		crystals := d.crystalsLeft(tx)
+		if rand.IntN(crystals+3) == 0 {
+			d.setPhase(enderDragonLandingApproach)
+			return
+		}
+		if t, ok := nearestEntity(m, tx, enderDragonTargetRange, attackablePlayer); ok && rand.IntN(crystals+2) == 0 {
+			d.target = t.H()
+			d.setPhase(enderDragonStrafing)
+			return
+		}
+	}
+	d.node = (d.node + 1) % enderDragonCircleNodes
+	angle := float64(d.node) / enderDragonCircleNodes * math.Pi * 2
+	centre := d.portal.Vec3Middle()
+	d.MoveTo(centre.Add(mgl64.Vec3{math.Cos(angle) * enderDragonCircleRadius, 20 + rand.Float64()*20, math.Sin(angle) * enderDragonCircleRadius}), 1)
+}
+
+// tickStrafing makes the ender dragon fly towards its target until it can
+// see it, after which it shoots a dragon fireball at it and continues
+// circling around the exit portal.
+func (d *EnderDragonBehaviour) tickStrafing(m *Mob, tx *world.Tx) {
+	target, ok := d.target.Entity(tx)
+	l, living := target.(Living)
+	if !ok || !living || l.Dead() || !attackablePlayer(l) || target.Position().Sub(d.portal.Vec3Middle()).Len() > enderDragonTargetRange || d.phaseTicks > 200 {
+		d.target = nil
+		d.setPhase(enderDragonHoldingPattern)
+		return
+	}
+	d.MoveTo(target.Position().Add(mgl64.Vec3{0, 10}), 1.2)
+	head, eye := enderDragonHeadPosition(m), EyePosition(target)
+	if d.phaseTicks > 20 && head.Sub(eye).Len() <= 64 && lineOfSight(head, eye, tx) {
+		tx.AddEntity(NewDragonFireball(world.EntitySpawnOpts{Position: head}, m, eye.Sub(head)))
+		d.target = nil
+		d.setPhase(enderDragonHoldingPattern)
+	}
+}
+
+// tickPerched makes the ender dragon look at the nearest player while it sits
+// on the exit portal and periodically breathe dragon's breath in front of it.
+func (d *EnderDragonBehaviour) tickPerched(m *Mob, tx *world.Tx) {
+	if d.phaseTicks >= enderDragonPerchTicks {
+		d.setPhase(enderDragonTakeoff)
+		return
+	}
+	target, ok := nearestEntity(m, tx, 20, attackablePlayer)
+	if !ok {
+		return
+	}
+	d.LookAt(EyePosition(target))
+	if d.phaseTicks%100 == 40 {
+		dir := target.Position().Sub(m.Position())
+		dir[1] = 0
+		if dir.Len() == 0 {
+			return
+		}
+		pos := m.Position().Add(dir.Normalize().Mul(math.Min(dir.Len(), 6)))
+		y, _ := tx.HighestBlock(int(math.Floor(pos[0])), int(math.Floor(pos[2])))
+		pos[1] = float64(y + 1)
+		tx.AddEntity(newDragonBreath(pos))
+	}
+}
+
+// perchPosition returns the position on top of the exit portal that the ender
+// dragon lands on.
+func (d *EnderDragonBehaviour) perchPosition(tx *world.Tx) mgl64.Vec3 {
+	y, _ := tx.HighestBlock(d.portal.X(), d.portal.Z())
+	return mgl64.Vec3{float64(d.portal.X()) + 0.5, float64(max(y+1, d.portal.Y())), float64(d.portal.Z()) + 0.5}
+}
+
+// tickCrystal heals the ender dragon using the end crystal closest to it. If
+// the end crystal healing the ender dragon is destroyed, the ender dragon is
+// hurt. An end crystal only heals the ender dragon while it is within
+// enderDragonCrystalRange.
+func (d *EnderDragonBehaviour) tickCrystal(m *Mob, tx *world.Tx) {
+	if d.crystal != nil {
+		e, ok := d.crystal.Entity(tx)
+		if !ok {
+			d.crystal = nil
+			m.Hurt(10, ExplosionDamageSource{})
+			return
+		}
+		c := e.(*EndCrystal)
+		if c.Position().Sub(m.Position()).Len() > enderDragonCrystalRange {
+			// The ender dragon flew out of range of the end crystal.
+			d.releaseCrystal(tx)
+		} else {
+			if target := cube.PosFromVec3(m.Position()); c.behaviour().beamTarget == nil || *c.behaviour().beamTarget != target {
+				c.SetBeamTarget(target)
+			}
+			if m.Age()%(time.Second/2) == 0 {
+				m.Heal(1, EndCrystalHealingSource{})
+			}
+		}
+	}
+	if rand.IntN(10) != 0 {
+		return
+	}
+	var nearest *EndCrystal
+	dist := float64(enderDragonCrystalRange)
+	for e := range tx.EntitiesWithin(cube.Box(-1, -1, -1, 1, 1, 1).Grow(enderDragonCrystalRange).Translate(m.Position())) {
+		if c, ok := e.(*EndCrystal); ok && c.behaviour().fuse == 0 {
+			if l := c.Position().Sub(m.Position()).Len(); l <= dist {
+				nearest, dist = c, l
+			}
+		}
+	}
+	if nearest != nil && nearest.H() == d.crystal {
+		return
+	}
+	d.releaseCrystal(tx)
+	if nearest != nil {
+		d.crystal = nearest.H()
+	}
+}
+
+// releaseCrystal stops the end crystal currently healing the ender dragon
+// from pointing its beam at the ender dragon.
+func (d *EnderDragonBehaviour) releaseCrystal(tx *world.Tx) {
+	if d.crystal == nil {
+		return
+	}
+	if e, ok := d.crystal.Entity(tx); ok {
+		e.(*EndCrystal).ResetBeamTarget()
+	}
+	d.crystal = nil
+}
+
+// crystalsLeft returns the amount of end crystals left around the exit portal
+// that may heal the ender dragon.
+func (d *EnderDragonBehaviour) crystalsLeft(tx *world.Tx) int {
+	n := 0
+	box := cube.Box(-enderDragonTargetRange, float64(tx.Range().Min()), -enderDragonTargetRange, enderDragonTargetRange, float64(tx.Range().Max()), enderDragonTargetRange)
+	for e := range tx.EntitiesWithin(box.Translate(mgl64.Vec3{float64(d.portal.X()), 0, float64(d.portal.Z())})) {
+		if _, ok := e.(*EndCrystal); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// tickCollisions knocks back entities that the ender dragon flies into and
+// hurts entities hit by its head.
+func (d *EnderDragonBehaviour) tickCollisions(m *Mob, tx *world.Tx) {
+	head := enderDragonHeadPosition(m)
+	headBox := cube.Box(-1.5, -1.5, -1.5, 1.5, 1.5, 1.5).Translate(head)
+	for e := range tx.EntitiesWithin(EnderDragonType.BBox(m).Translate(m.Position())) {
+		l, ok := e.(Living)
+		if !ok || e.H() == m.H() || l.Dead() {
+			continue
+		}
+		if headBox.IntersectsWith(e.H().Type().BBox(e).Translate(e.Position())) {
+			l.Hurt(10, AttackDamageSource{Attacker: m})
+		}
+		l.KnockBack(m.Position(), 1.5, 0.4)
+	}
+}
+
+// destroyBlocks removes the blocks that the ender dragon flies through if
+// mob griefing is enabled. Blocks such as end stone, obsidian and bedrock are
+// never destroyed.
+func (d *EnderDragonBehaviour) destroyBlocks(m *Mob, tx *world.Tx) {
+	if !tx.World().MobGriefing() {
+		return
+	}
+	box := EnderDragonType.BBox(m).Translate(m.Position())
+	minPos, maxPos := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+	for x := minPos.X(); x <= maxPos.X(); x++ {
+		for y := max(minPos.Y(), tx.Range().Min()); y <= min(maxPos.Y(), tx.Range().Max()); y++ {
+			for z := minPos.Z(); z <= maxPos.Z(); z++ {
+				pos := cube.Pos{x, y, z}
+				if enderDragonDestroys(tx.Block(pos)) {
+					tx.SetBlock(pos, nil, nil)
+				}
+			}
+		}
+	}
+}
+
+// tickDeath performs the death animation of the ender dragon. The ender
+// dragon slowly rises while it drops experience, after which it opens an end
+// gateway, activates the exit portal and disappears.
+func (d *EnderDragonBehaviour) tickDeath(m *Mob, tx *world.Tx) *Movement {
+	d.releaseCrystal(tx)
+	d.dyingTicks++
+
+	pos := m.Position()
+	if d.dyingTicks >= enderDragonDeathTicks-20 {
+		tx.AddParticle(pos.Add(mgl64.Vec3{rand.Float64()*8 - 4, rand.Float64()*4 - 2 + 2, rand.Float64()*8 - 4}), particle.HugeExplosion{})
+	}
+	xp := 12000
+	if d.respawned {
+		xp = 500
+	}
+	if d.dyingTicks > 150 && d.dyingTicks%5 == 0 {
+		d.dropExperience(pos, int(float64(xp)*0.08), tx)
+	}
+	if d.dyingTicks >= enderDragonDeathTicks {
+		d.dropExperience(pos, int(float64(xp)*0.2), tx)
+		openEndGateway(tx)
+		if d.portal != nil {
+			end.PlaceExitPortal(tx, *d.portal, true)
+			if !d.respawned {
+				y, _ := tx.HighestBlock(d.portal.X(), d.portal.Z())
+				tx.SetBlock(cube.Pos{d.portal.X(), y + 1, d.portal.Z()}, block.DragonEgg{}, nil)
+			}
+		}
+		_ = m.Close()
+		return nil
+	}
+	vel := mgl64.Vec3{0, 0.1}
+	m.data.Rot[0] += 20
+	mov := &Movement{v: tx.Viewers(pos), e: m, pos: pos.Add(vel), vel: vel, dpos: vel, dvel: vel.Sub(m.data.Vel), rot: m.data.Rot}
+	m.data.Pos, m.data.Vel = mov.pos, mov.vel
+	return mov
+}
+
+// dropExperience spawns experience orbs worth the amount of experience passed
+// at a position.
+func (d *EnderDragonBehaviour) dropExperience(pos mgl64.Vec3, amount int, tx *world.Tx) {
+	for _, orb := range NewExperienceOrbs(pos, amount) {
+		tx.AddEntity(orb)
+	}
+}
+
+// decodeNBT decodes the state of the ender dragon from the map passed.
+func (d *EnderDragonBehaviour) decodeNBT(m map[string]any) {
+	d.MobBehaviour.decodeNBT(m)
+	d.lastHealth = d.health.Health()
+	d.respawned = nbtconv.Bool(m, "Respawned")
+	if _, ok := m["Portal"]; ok {
+		pos := nbtconv.Pos(m, "Portal")
+		d.portal = &pos
+	}
+}
+
+// enderDragonHeadPosition returns the position of the head of the ender
+// dragon passed, which is in front of its body.
+func enderDragonHeadPosition(m *Mob) mgl64.Vec3 {
+	yaw := mgl64.DegToRad(m.Rotation().Yaw())
+	return m.Position().Add(mgl64.Vec3{-math.Sin(yaw) * 6.5, 2, math.Cos(yaw) * 6.5})
+}
+
+// enderDragonDestroys checks if the ender dragon destroys the block passed
+// when flying through it.
+func enderDragonDestroys(b world.Block) bool {
+	switch b.(type) {
+	case block.EndStone, block.Obsidian, block.IronBars, block.EndPortal:
+		return false
+	}
+	_, ok := b.(block.Breakable)
+	return ok
+}
+
+// EnderDragonType is a world.EntityType implementation for the ender dragon.
+var EnderDragonType enderDragonType
+
+type enderDragonType struct{}
+
+func (enderDragonType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (enderDragonType) EncodeEntity() string { return "minecraft:ender_dragon" }
+func (enderDragonType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-6.5, 0, -6.5, 6.5, 4, 6.5)
+}
+
+func (enderDragonType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	d := enderDragonConf.New()
+	d.decodeNBT(m)
+	data.Data = d
+}
+
+func (enderDragonType) EncodeNBT(data *world.EntityData) map[string]any {
+	d := data.Data.(*EnderDragonBehaviour)
+	m := map[string]any{"Respawned": boolByte(d.respawned)}
+	if d.portal != nil {
+		m["Portal"] = nbtconv.PosToInt32Slice(*d.portal)
+	}
+	d.MobBehaviour.encodeNBT(m)
+	return m
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/generator/end"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestEnderDragonBossBarAndDeath(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry, Dim: world.End}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		portal := cube.Pos{0, 64, 0}
+		conf := EnderDragonBehaviourConfig{Portal: &portal}
+		m := tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{0, 80, 0}}.New(EnderDragonType, conf)).(*Mob)
+		d := m.Behaviour().(*EnderDragonBehaviour)
+
+		if bar := d.BossBar(); bar.HealthPercentage() != 1 {
+			t.Errorf("expected boss bar of an unharmed ender dragon to be full, got %v", bar.HealthPercentage())
+		}
+		m.Hurt(50, VoidDamageSource{})
+		if bar := d.BossBar(); bar.HealthPercentage() != 0.75 {
+			t.Errorf("expected boss bar to be at 75%% after the ender dragon lost 50 health, got %v", bar.HealthPercentage())
+		}
+
+		m.Hurt(enderDragonMaxHealth, VoidDamageSource{})
+		for i := range enderDragonDeathTicks - 1 {
+			m.Tick(tx, int64(i))
+		}
+		if !inWorld(tx, m) || m.Position()[1] <= 80 {
+			t.Errorf("expected ender dragon to rise for %v ticks after dying, dragon is at %v", enderDragonDeathTicks, m.Position())
+			return
+		}
+		m.Tick(tx, enderDragonDeathTicks)
+		if inWorld(tx, m) {
+			t.Errorf("expected ender dragon to disappear at the end of its death animation")
+		}
+
+		var orbs int
+		for e := range tx.Entities() {
+			if _, ok := e.(*Ent); ok && e.H().Type() == ExperienceOrbType {
+				orbs++
+			}
+		}
+		if orbs == 0 {
+			t.Errorf("expected ender dragon to drop experience")
+		}
+		if _, ok := tx.Block(portal.Add(cube.Pos{0, 4, 0})).(block.DragonEgg); !ok {
+			t.Errorf("expected dragon egg on top of the exit portal, found %v", tx.Block(portal.Add(cube.Pos{0, 4, 0})))
+		}
+		if _, ok := tx.Block(end.Gateways(w.Seed())[0]).(block.EndGateway); !ok {
+			t.Errorf("expected an end gateway to be opened")
+		}
+	})
+}
@@ -0,0 +1,354 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// NewEnderman creates a new enderman that is not carrying a block.
+func NewEnderman(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(EndermanType, endermanConf)
+}
+
+var endermanConf = EndermanBehaviourConfig{}
+
+// EndermanBehaviourConfig holds optional parameters for an EndermanBehaviour.
+type EndermanBehaviourConfig struct {
+	// Carryable is a function that returns true if the enderman may pick up
+	// the block passed. If nil, EndermanCarryable is used.
+	Carryable func(b world.Block) bool
+	// CarriedBlock is the block that the enderman carries when it spawns. If
+	// nil, the enderman does not carry a block.
+	CarriedBlock world.Block
+}
+
+func (conf EndermanBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates an EndermanBehaviour using the parameters in conf.
+func (conf EndermanBehaviourConfig) New() *EndermanBehaviour {
+	if conf.Carryable == nil {
+		conf.Carryable = EndermanCarryable
+	}
+	e := &EndermanBehaviour{conf: conf, carried: conf.CarriedBlock}
+	e.MobBehaviour = MobBehaviourConfig{MaxHealth: 40, Speed: 0.3, Experience: 5, Drops: e.drops}.New()
+	return e
+}
+
+// EndermanCarryable checks if the block passed is one of the blocks that
+// endermen pick up by default.
+func EndermanCarryable(b world.Block) bool {
+	switch b.(type) {
+	case block.Grass, block.Dirt, block.Podzol, block.Sand, block.Gravel, block.Clay, block.Flower,
+		block.Cactus, block.Melon, block.TNT, block.Netherrack, block.SoulSand, block.SoulSoil,
+		block.Mud, block.MuddyMangroveRoots:
+		return true
+	case block.Pumpkin:
+		return !b.(block.Pumpkin).Carved
+	}
+	return false
+}
+
+// endermanStareDist is the maximum distance from which a player is able to
+// provoke an enderman by looking at it.
+const endermanStareDist = 64
+
+// EndermanBehaviour implements the behaviour of endermen. Endermen teleport
+// around randomly and when they are hurt, pick up and place blocks and become
+// hostile towards players that look at them.
+type EndermanBehaviour struct {
+	*MobBehaviour
+	conf EndermanBehaviourConfig
+
+	carried        world.Block
+	target         *world.EntityHandle
+	attackCooldown int
+}
+
+// CarriedBlock returns the block carried by the enderman. False is returned
+// if the enderman is not carrying a block.
+func (e *EndermanBehaviour) CarriedBlock() (world.Block, bool) {
+	return e.carried, e.carried != nil
+}
+
+// Angry checks if the enderman is currently attacking an entity.
+func (e *EndermanBehaviour) Angry() bool {
+	return e.target != nil
+}
+
+// Target returns the entity that the enderman is currently attacking, or nil
+// if it is not attacking any entity.
+func (e *EndermanBehaviour) Target() *world.EntityHandle {
+	return e.target
+}
+
+// Hurt makes the enderman attack the entity that attacked it. If the enderman
+// was not hurt by an entity, it usually teleports away.
+func (e *EndermanBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	if attacker := damageSourceAttacker(src); attacker != nil {
+		e.setTarget(m, attacker.H())
+		return
+	}
+	if _, drowning := src.(DrowningDamageSource); drowning || rand.IntN(10) != 0 {
+		e.teleportRandomly(m, m.tx)
+	}
+}
+
+// HandleProjectileHit makes the enderman teleport out of the way of a
+// projectile that is about to hit it. The hit is cancelled if the enderman
+// managed to teleport.
+func (e *EndermanBehaviour) HandleProjectileHit(m *Mob, ctx *event.Context[*Ent], _ *ProjectileHit) {
+	for range 64 {
+		if e.teleportRandomly(m, m.tx) {
+			ctx.Cancel()
+			return
+		}
+	}
+}
+
+// Tick ticks the enderman, making it look for players staring at it, attack
+// its target and pick up or place blocks.
+func (e *EndermanBehaviour) Tick(ent *Ent, tx *world.Tx) *Movement {
+	if !e.Dead() {
+		e.tickEnderman(&Mob{Ent: ent}, tx)
+	}
+	return e.MobBehaviour.Tick(ent, tx)
+}
+
+// tickEnderman performs the enderman specific logic of a tick.
+func (e *EndermanBehaviour) tickEnderman(m *Mob, tx *world.Tx) {
+	pos := cube.PosFromVec3(m.Position())
+	if _, ok := tx.Liquid(pos); ok || tx.RainingAt(pos) {
+		m.Hurt(1, DrowningDamageSource{})
+		if e.Dead() {
+			return
+		}
+	}
+	if e.attackCooldown > 0 {
+		e.attackCooldown--
+	}
+	// Checking which players are staring at the enderman is relatively
+	// expensive, so it is only done a few times per second, spread out over
+	// different ticks for different endermen.
+	tick := int64(m.Age()/(time.Second/20)) + int64(m.H().UUID().ID())
+	if e.target == nil && tick%5 == 0 {
+		if p, ok := e.staringPlayer(m, tx); ok {
+			e.setTarget(m, p.H())
+		}
+	}
+	if e.target != nil && e.attack(m, tx) {
+		return
+	}
+	if inSunlight(m, tx) && rand.IntN(60) == 0 {
+		e.teleportRandomly(m, tx)
+		return
+	}
+	if e.carried == nil && rand.IntN(20) == 0 {
+		e.pickUpBlock(m, tx)
+	} else if e.carried != nil && rand.IntN(2000) == 0 {
+		e.placeBlock(m, tx)
+	}
+	if !e.Moving() && rand.IntN(120) == 0 {
+		e.MoveTo(m.Position().Add(randomHorizontalOffset(8)), 0.3)
+	}
+}
+
+// setTarget makes the enderman attack the entity passed.
+func (e *EndermanBehaviour) setTarget(m *Mob, target *world.EntityHandle) {
+	if e.target == target {
+		return
+	}
+	e.target = target
+	m.updateState()
+}
+
+// attack makes the enderman move towards its target and attack it. False is
+// returned if the enderman no longer has a target.
+func (e *EndermanBehaviour) attack(m *Mob, tx *world.Tx) bool {
+	ent, ok := e.target.Entity(tx)
+	t, living := ent.(Living)
+	if !ok || !living || t.Dead() || ent.Position().Sub(m.Position()).Len() > endermanStareDist {
+		e.setTarget(m, nil)
+		return false
+	}
+	e.LookAt(EyePosition(ent))
+	dist := ent.Position().Sub(m.Position()).Len()
+	if dist > 16 && rand.IntN(30) == 0 {
+		// Endermen teleport towards their target if it is far away from them.
+		e.teleportTowards(m, ent.Position(), tx)
+		return true
+	}
+	if dist > 2 {
+		e.MoveTo(ent.Position(), 1)
+		return true
+	}
+	e.StopMoving()
+	if e.attackCooldown == 0 {
+		e.attackCooldown = 20
+		if _, vulnerable := t.Hurt(7, AttackDamageSource{Attacker: m}); vulnerable {
+			t.KnockBack(m.Position(), 0.4, 0.4)
+		}
+		for _, v := range tx.Viewers(m.Position()) {
+			v.ViewEntityAction(m, SwingArmAction{})
+		}
+	}
+	return true
+}
+
+// staringPlayer returns a player within 64 blocks that is looking at the
+// upper body of the enderman without wearing a carved pumpkin.
+func (e *EndermanBehaviour) staringPlayer(m *Mob, tx *world.Tx) (world.Entity, bool) {
+	eyes := EyePosition(m)
+	for p := range tx.Players() {
+		l, ok := p.(Living)
+		if !ok || l.Dead() || !attackablePlayer(l) {
+			continue
+		}
+		// Check the cheap conditions first, so that the line of sight is
+		// only checked for players that are actually looking at the
+		// enderman.
+		from := EyePosition(p)
+		delta := eyes.Sub(from)
+		dist := delta.Len()
+		if dist > endermanStareDist || dist == 0 {
+			continue
+		}
+		if p.Rotation().Vec3().Dot(delta.Mul(1/dist)) <= 1-0.025/dist {
+			continue
+		}
+		if a, ok := p.(interface{ Armour() *inventory.Armour }); ok {
+			if pumpkin, ok := a.Armour().Helmet().Item().(block.Pumpkin); ok && pumpkin.Carved {
+				continue
+			}
+		}
+		if lineOfSight(from, eyes, tx) {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// teleportRandomly teleports the enderman to a random position within 32
+// blocks of it. True is returned if a safe position was found.
+func (e *EndermanBehaviour) teleportRandomly(m *Mob, tx *world.Tx) bool {
+	pos := m.Position().Add(mgl64.Vec3{rand.Float64()*64 - 32, float64(rand.IntN(64) - 32), rand.Float64()*64 - 32})
+	return e.teleport(m, cube.PosFromVec3(pos), tx)
+}
+
+// teleportTowards teleports the enderman to a random position closer to the
+// position passed.
+func (e *EndermanBehaviour) teleportTowards(m *Mob, pos mgl64.Vec3, tx *world.Tx) bool {
+	delta := m.Position().Sub(pos)
+	if delta.Len() == 0 {
+		return false
+	}
+	delta = delta.Normalize()
+	dest := m.Position().Sub(delta.Mul(16)).Add(mgl64.Vec3{rand.Float64()*8 - 4, float64(rand.IntN(16) - 8), rand.Float64()*8 - 4})
+	return e.teleport(m, cube.PosFromVec3(dest), tx)
+}
+
+// teleport teleports the enderman to the first safe position found below the
+// position passed. False is returned if no safe position was found.
+func (e *EndermanBehaviour) teleport(m *Mob, pos cube.Pos, tx *world.Tx) bool {
+	for ; pos[1] > tx.Range()[0]; pos[1]-- {
+		below := pos.Side(cube.FaceDown)
+		if !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+			continue
+		}
+		// Endermen are almost three blocks tall, so the block above the two
+		// checked by safeTeleportPos must be free too.
+		head := pos.Add(cube.Pos{0, 2})
+		if !safeTeleportPos(pos, tx) || len(tx.Block(head).Model().BBox(head, tx)) != 0 {
+			return false
+		}
+		from := m.Position()
+		e.StopMoving()
+		m.Teleport(pos.Vec3Middle())
+		tx.PlaySound(from, sound.Teleport{})
+		tx.PlaySound(m.Position(), sound.Teleport{})
+		return true
+	}
+	return false
+}
+
+// pickUpBlock makes the enderman pick up a random carryable block close to
+// it.
+func (e *EndermanBehaviour) pickUpBlock(m *Mob, tx *world.Tx) {
+	pos := cube.PosFromVec3(m.Position()).Add(cube.Pos{rand.IntN(5) - 2, rand.IntN(4), rand.IntN(5) - 2})
+	b := tx.Block(pos)
+	if !e.conf.Carryable(b) {
+		return
+	}
+	tx.SetBlock(pos, nil, nil)
+	e.carried = b
+	m.updateState()
+}
+
+// placeBlock makes the enderman place the block it is carrying at a random
+// position close to it.
+func (e *EndermanBehaviour) placeBlock(m *Mob, tx *world.Tx) {
+	pos := cube.PosFromVec3(m.Position()).Add(cube.Pos{rand.IntN(3) - 1, rand.IntN(3), rand.IntN(3) - 1})
+	below := pos.Side(cube.FaceDown)
+	if _, ok := tx.Block(pos).(block.Air); !ok || !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+		return
+	}
+	tx.SetBlock(pos, e.carried, nil)
+	e.carried = nil
+	m.updateState()
+}
+
+// drops returns the items dropped by an enderman when it dies, including the
+// block it was carrying.
+func (e *EndermanBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	var drops []item.Stack
+	if rand.IntN(2) == 0 {
+		drops = append(drops, item.NewStack(item.EnderPearl{}, 1))
+	}
+	if it, ok := e.carried.(world.Item); ok {
+		drops = append(drops, item.NewStack(it, 1))
+	}
+	return drops
+}
+
+// EndermanType is a world.EntityType implementation for endermen.
+var EndermanType endermanType
+
+type endermanType struct{}
+
+func (endermanType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (endermanType) EncodeEntity() string { return "minecraft:enderman" }
+func (endermanType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 2.9, 0.3)
+}
+
+func (endermanType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	e := endermanConf.New()
+	e.MobBehaviour.decodeNBT(m)
+	if _, ok := m["carriedBlock"]; ok {
+		e.carried = nbtconv.Block(m, "carriedBlock")
+	}
+	data.Data = e
+}
+
+func (endermanType) EncodeNBT(data *world.EntityData) map[string]any {
+	e := data.Data.(*EndermanBehaviour)
+	m := map[string]any{}
+	if e.carried != nil {
+		m["carriedBlock"] = nbtconv.WriteBlock(e.carried)
+	}
+	e.MobBehaviour.encodeNBT(m)
+	return m
+}
@@ -107,14 +107,99 @@ func (e *Ent) NameTag() string {
 }
 
 // SetNameTag changes the name tag of an entity. The name tag is removed if an
-// empty string is passed.
+// empty string is passed. Name tags may span multiple lines by separating them
+// with '\n'.
 func (e *Ent) SetNameTag(s string) {
 	e.data.Name = s
+	e.viewMetadata()
+}
+
+// NameTagVisible checks if the name tag of the entity is shown to viewers. It
+// is true unless changed using SetNameTagVisible.
+func (e *Ent) NameTagVisible() bool {
+	return !e.data.NameHidden
+}
+
+// SetNameTagVisible changes if the name tag of the entity is shown to viewers.
+// Hiding the name tag does not remove it, so that it is shown again once it is
+// made visible.
+func (e *Ent) SetNameTagVisible(v bool) {
+	if e.data.NameHidden == !v {
+		return
+	}
+	e.data.NameHidden = !v
+	e.viewMetadata()
+}
+
+// Metadata returns the custom metadata set on the Ent using SetMetadataFlag,
+// SetMetadataInt and SetMetadataFloat.
+func (e *Ent) Metadata() world.EntityMetadata {
+	return e.data.Metadata
+}
+
+// SetMetadataFlag overrides a flag in the metadata of the Ent, changing how
+// it is shown to viewers without changing its behaviour. This may be used to
+// show an entity as a baby or as sheared, for example.
+func (e *Ent) SetMetadataFlag(flag world.EntityFlag, value bool) {
+	if e.data.Metadata.SetFlag(flag, value) {
+		e.viewMetadata()
+	}
+}
+
+// SetMetadataInt overrides an integer value in the metadata of the Ent, such
+// as its variant or colour.
+func (e *Ent) SetMetadataInt(key world.EntityIntKey, value int) {
+	if e.data.Metadata.SetInt(key, value) {
+		e.viewMetadata()
+	}
+}
+
+// SetMetadataFloat overrides a floating point value in the metadata of the
+// Ent, such as its scale.
+func (e *Ent) SetMetadataFloat(key world.EntityFloatKey, value float64) {
+	if e.data.Metadata.SetFloat(key, value) {
+		e.viewMetadata()
+	}
+}
+
+// ResetMetadata removes all custom metadata set on the Ent.
+func (e *Ent) ResetMetadata() {
+	if e.data.Metadata.Reset() {
+		e.viewMetadata()
+	}
+}
+
+// viewMetadata shows the current metadata of the Ent to all its viewers.
+func (e *Ent) viewMetadata() {
 	for _, v := range e.tx.Viewers(e.Position()) {
 		v.ViewEntityState(e)
 	}
 }
 
+// Mount makes the entity passed start riding the Ent. The rider is moved along
+// with the Ent until it dismounts. False is returned if the rider could not
+// start riding the Ent, for example because all of its seats are taken.
+func (e *Ent) Mount(rider world.Entity) bool {
+	return e.tx.Mount(rider, e)
+}
+
+// Dismount makes the Ent stop riding the entity it is currently riding.
+// Dismount has no effect if the Ent is not riding any entity.
+func (e *Ent) Dismount() {
+	e.tx.Dismount(e)
+}
+
+// Vehicle returns the entity that the Ent is currently riding. False is
+// returned if the Ent is not riding any entity.
+func (e *Ent) Vehicle() (world.Entity, bool) {
+	return e.tx.Vehicle(e)
+}
+
+// Passengers returns the entities currently riding the Ent.
+func (e *Ent) Passengers() []world.Entity {
+	return e.tx.Passengers(e)
+}
+
 // Tick ticks Ent, progressing its lifetime and closing the entity if it is
 // in the void.
 func (e *Ent) Tick(tx *world.Tx, current int64) {
@@ -0,0 +1,391 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+)
+
+// Equipment holds the items held and worn by a mob. The armour worn protects
+// the mob like it protects players, and the items equipped are dropped with a
+// small chance when the mob is killed by a player. Items that were picked up
+// by the mob are always dropped.
+type Equipment struct {
+	mainHand, offHand item.Stack
+	armour            *inventory.Armour
+
+	// guaranteed holds, for the main hand, off-hand, helmet, chestplate,
+	// leggings and boots slots respectively, if the item in the slot is always
+	// dropped when the mob dies.
+	guaranteed    [6]bool
+	canPickUpLoot bool
+	// equipped is true if the mob was given its equipment after spawning.
+	// changed is true if any of the items changed since viewers were last
+	// shown the equipment.
+	equipped, changed bool
+}
+
+const (
+	equipmentMainHand = iota
+	equipmentOffHand
+	equipmentHelmet
+)
+
+// equipmentDropChance is the chance that an item equipped by a mob, other
+// than items picked up, is dropped when the mob is killed by a player.
+const equipmentDropChance = 0.085
+
+// NewEquipment creates an Equipment with no items held or worn.
+func NewEquipment() *Equipment {
+	eq := &Equipment{}
+	eq.armour = inventory.NewArmour(func(slot int, before, after item.Stack) {
+		eq.changed = true
+		if replaced(before, after) {
+			eq.guaranteed[equipmentHelmet+slot] = false
+		}
+	})
+	return eq
+}
+
+// HeldItems returns the items held in the main hand and off-hand.
+func (eq *Equipment) HeldItems() (mainHand, offHand item.Stack) {
+	return eq.mainHand, eq.offHand
+}
+
+// SetHeldItems changes the items held in the main hand and off-hand.
+func (eq *Equipment) SetHeldItems(mainHand, offHand item.Stack) {
+	if replaced(eq.mainHand, mainHand) {
+		eq.guaranteed[equipmentMainHand] = false
+	}
+	if replaced(eq.offHand, offHand) {
+		eq.guaranteed[equipmentOffHand] = false
+	}
+	eq.mainHand, eq.offHand, eq.changed = mainHand, offHand, true
+}
+
+// replaced checks if the item after is a different item than before, rather
+// than the same item with a different durability.
+func replaced(before, after item.Stack) bool {
+	return before.Empty() != after.Empty() || !before.Comparable(after)
+}
+
+// Armour returns the armour worn.
+func (eq *Equipment) Armour() *inventory.Armour {
+	return eq.armour
+}
+
+// CanPickUpLoot checks if the mob picks up items that it walks over if they
+// are better than the items it currently has equipped.
+func (eq *Equipment) CanPickUpLoot() bool {
+	return eq.canPickUpLoot
+}
+
+// SetCanPickUpLoot changes if the mob picks up items that it walks over.
+func (eq *Equipment) SetCanPickUpLoot(v bool) {
+	eq.canPickUpLoot = v
+}
+
+// Equip equips the item passed in the slot that it belongs in: Armour is worn
+// and all other items are held in the main hand. If guaranteedDrop is true,
+// the item is always dropped when the mob dies, undamaged. The item that was
+// previously equipped in the slot is returned.
+func (eq *Equipment) Equip(s item.Stack, guaranteedDrop bool) item.Stack {
+	slot := equipmentSlot(s)
+	before := eq.slot(slot)
+	switch slot {
+	case equipmentMainHand:
+		eq.SetHeldItems(s, eq.offHand)
+	default:
+		_ = eq.armour.Inventory().SetItem(slot-equipmentHelmet, s)
+	}
+	eq.guaranteed[slot] = guaranteedDrop
+	return before
+}
+
+// applyAttributes applies the attribute modifiers of the items held and worn,
+// such as the knock back resistance of netherite armour, to the attributes
+// passed.
+func (eq *Equipment) applyAttributes(a *Attributes) {
+	for i, slot := range item.EquipmentSlots() {
+		a.ApplyEquipment(slot, eq.slot(i))
+	}
+}
+
+// slot returns the item in the equipment slot passed.
+func (eq *Equipment) slot(slot int) item.Stack {
+	switch slot {
+	case equipmentMainHand:
+		return eq.mainHand
+	case equipmentOffHand:
+		return eq.offHand
+	}
+	return eq.armour.Slots()[slot-equipmentHelmet]
+}
+
+// equipmentSlot returns the equipment slot that the item passed is equipped
+// in.
+func equipmentSlot(s item.Stack) int {
+	it := s.Item()
+	if h, ok := it.(item.HelmetType); ok && h.Helmet() {
+		return equipmentHelmet
+	} else if c, ok := it.(item.ChestplateType); ok && c.Chestplate() {
+		return equipmentHelmet + 1
+	} else if l, ok := it.(item.LeggingsType); ok && l.Leggings() {
+		return equipmentHelmet + 2
+	} else if b, ok := it.(item.BootsType); ok && b.Boots() {
+		return equipmentHelmet + 3
+	}
+	return equipmentMainHand
+}
+
+// better checks if the item passed is better than the item currently
+// equipped in the slot that it belongs in. Only weapons and armour are ever
+// considered better.
+func (eq *Equipment) better(s item.Stack) bool {
+	slot := equipmentSlot(s)
+	current := eq.slot(slot)
+	if slot != equipmentMainHand {
+		new, _ := s.Item().(item.Armour)
+		if current.Empty() {
+			return true
+		}
+		old, ok := current.Item().(item.Armour)
+		if !ok || new == nil {
+			return false
+		}
+		if new.DefencePoints() != old.DefencePoints() {
+			return new.DefencePoints() > old.DefencePoints()
+		}
+		return new.Toughness() > old.Toughness() || (new.Toughness() == old.Toughness() && moreDurable(s, current))
+	}
+	_, newBow := s.Item().(item.Bow)
+	_, newWeapon := s.Item().(item.Weapon)
+	if !newBow && !newWeapon {
+		return false
+	}
+	if current.Empty() {
+		return true
+	}
+	if _, oldBow := current.Item().(item.Bow); oldBow || newBow {
+		// Mobs holding bows only swap them for other bows, as they are unable
+		// to shoot with anything else.
+		return oldBow && newBow && moreDurable(s, current)
+	}
+	_, newSword := s.Item().(item.Sword)
+	_, oldSword := current.Item().(item.Sword)
+	if newSword != oldSword {
+		return newSword
+	}
+	if s.AttackDamage() != current.AttackDamage() {
+		return s.AttackDamage() > current.AttackDamage()
+	}
+	return moreDurable(s, current)
+}
+
+// moreDurable checks if a has more enchantments than b, or as many
+// enchantments but more durability left.
+func moreDurable(a, b item.Stack) bool {
+	if len(a.Enchantments()) != len(b.Enchantments()) {
+		return len(a.Enchantments()) > len(b.Enchantments())
+	}
+	return a.Durability() > b.Durability()
+}
+
+// pickUpLoot makes the mob pick up an item entity close to it that holds an
+// item better than the one it currently has equipped. The item replaced is
+// dropped, unless it was equipped when the mob spawned and fails the drop
+// chance.
+func (eq *Equipment) pickUpLoot(m *Mob, tx *world.Tx) {
+	box := m.H().Type().BBox(m).Translate(m.Position()).GrowVec3(mgl64.Vec3{1, 0.5, 1})
+	for e := range tx.EntitiesWithin(box) {
+		ent, ok := e.(*Ent)
+		if !ok || e.H().Type() != ItemType {
+			continue
+		}
+		i := ent.Behaviour().(*ItemBehaviour)
+		if i.pickupDelay > 0 || !eq.better(i.Item()) {
+			continue
+		}
+		s := i.Item()
+		if s.Count() > 1 {
+			tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: e.Position()}, s.Grow(-1)))
+			s = s.Grow(1 - s.Count())
+		}
+		slot := equipmentSlot(s)
+		guaranteed := eq.guaranteed[slot]
+		if old := eq.Equip(s, true); !old.Empty() && (guaranteed || math.Max(rand.Float64()-0.1, 0) < equipmentDropChance) {
+			tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: m.Position()}, old))
+		}
+		// Mobs that picked up items never despawn, so that the items are not
+		// lost.
+		m.behaviour().persistent = true
+		for _, v := range tx.Viewers(e.Position()) {
+			v.ViewEntityAction(e, PickedUpAction{Collector: m})
+		}
+		_ = e.Close()
+		return
+	}
+}
+
+// drops returns the equipped items dropped when the mob is killed. Items that
+// were picked up are always dropped. Other items are only dropped with a small
+// chance if the mob was killed by a player, and are dropped damaged. Items
+// with Curse of Vanishing are never dropped.
+func (eq *Equipment) drops(src world.DamageSource) []item.Stack {
+	_, byPlayer := damageSourceAttacker(src).(interface{ GameMode() world.GameMode })
+
+	var drops []item.Stack
+	for slot := range eq.guaranteed {
+		s := eq.slot(slot)
+		if _, vanishing := s.Enchantment(enchantment.CurseOfVanishing); s.Empty() || vanishing {
+			continue
+		}
+		if eq.guaranteed[slot] {
+			drops = append(drops, s)
+			continue
+		}
+		if !byPlayer || rand.Float64() >= equipmentDropChance {
+			continue
+		}
+		if s.MaxDurability() > 1 {
+			// Dropped equipment is damaged by a random amount.
+			s = s.Damage(rand.IntN(s.MaxDurability() - 1))
+		}
+		drops = append(drops, s)
+	}
+	return drops
+}
+
+// encodeNBT encodes the equipment into the map passed.
+func (eq *Equipment) encodeNBT(m map[string]any) {
+	armour := make([]any, 0, 4)
+	for _, it := range eq.armour.Slots() {
+		armour = append(armour, nbtconv.WriteItem(it, true))
+	}
+	guaranteed := make([]any, 0, len(eq.guaranteed))
+	for _, g := range eq.guaranteed {
+		guaranteed = append(guaranteed, boolByte(g))
+	}
+	m["Mainhand"] = nbtconv.WriteItem(eq.mainHand, true)
+	m["Offhand"] = nbtconv.WriteItem(eq.offHand, true)
+	m["Armor"] = armour
+	m["GuaranteedDrops"] = guaranteed
+	m["CanPickUpLoot"] = boolByte(eq.canPickUpLoot)
+	m["Equipped"] = boolByte(eq.equipped)
+}
+
+// decodeNBT decodes the equipment from the map passed.
+func (eq *Equipment) decodeNBT(m map[string]any) {
+	if _, ok := m["Mainhand"]; ok {
+		eq.mainHand = nbtconv.MapItem(m, "Mainhand")
+	}
+	if _, ok := m["Offhand"]; ok {
+		eq.offHand = nbtconv.MapItem(m, "Offhand")
+	}
+	if armour, ok := m["Armor"].([]any); ok && len(armour) == 4 {
+		var items [4]item.Stack
+		for i, v := range armour {
+			if it, ok := v.(map[string]any); ok {
+				items[i] = nbtconv.Item(it, nil)
+			}
+		}
+		eq.armour.Set(items[0], items[1], items[2], items[3])
+	}
+	if guaranteed, ok := m["GuaranteedDrops"].([]any); ok && len(guaranteed) == len(eq.guaranteed) {
+		for i, v := range guaranteed {
+			g, _ := v.(uint8)
+			eq.guaranteed[i] = g == 1
+		}
+	}
+	eq.canPickUpLoot = nbtconv.Bool(m, "CanPickUpLoot")
+	// Mobs that were stored without this field already have all the equipment
+	// that they should have.
+	eq.equipped = true
+	if _, ok := m["Equipped"]; ok {
+		eq.equipped = nbtconv.Bool(m, "Equipped")
+	}
+}
+
+// localDifficulty returns the clamped local difficulty at the position passed,
+// ranging from 0 to 1. The local difficulty depends on the difficulty of the
+// world and increases as the world gets older and during full moons. It
+// influences how well mobs are equipped when they spawn.
+func localDifficulty(tx *world.Tx, pos mgl64.Vec3) float64 {
+	var diff float64
+	switch tx.World().Difficulty() {
+	case world.DifficultyEasy:
+		diff = 1
+	case world.DifficultyNormal:
+		diff = 2
+	case world.DifficultyHard:
+		diff = 3
+	default:
+		return 0
+	}
+	t := float64(tx.World().Time())
+	age := math.Min(math.Max((t-72000)/1440000, 0), 1) * 0.25
+	moon := [8]float64{1, 0.75, 0.5, 0.25, 0, 0.25, 0.5, 0.75}[int(t/24000)%8]
+	extra := math.Min(moon*0.25, age)
+	if diff == 1 {
+		extra *= 0.5
+	}
+	regional := diff * (0.75 + age + extra)
+	return math.Min(math.Max((regional-2)/2, 0), 1)
+}
+
+// equipRandomArmour equips random armour in the equipment passed, based on the
+// local difficulty passed. The higher the difficulty, the more likely it is
+// that armour is equipped at all. Armour is equipped from the boots upwards,
+// with a chance to stop after every piece.
+func equipRandomArmour(eq *Equipment, difficulty float64, hard bool) {
+	if rand.Float64() >= 0.15*difficulty {
+		return
+	}
+	tier := rand.IntN(2)
+	for range 3 {
+		if rand.Float64() < 0.095 {
+			tier++
+		}
+	}
+	stopChance := 0.25
+	if hard {
+		stopChance = 0.1
+	}
+	tiers := []item.ArmourTier{item.ArmourTierLeather{}, item.ArmourTierGold{}, item.ArmourTierChain{}, item.ArmourTierIron{}, item.ArmourTierDiamond{}}
+	t := tiers[tier]
+	pieces := []world.Item{item.Boots{Tier: t}, item.Leggings{Tier: t}, item.Chestplate{Tier: t}, item.Helmet{Tier: t}}
+	for i, it := range pieces {
+		if i > 0 && rand.Float64() < stopChance {
+			return
+		}
+		if s := item.NewStack(it, 1); eq.slot(equipmentSlot(s)).Empty() {
+			eq.Equip(s, false)
+		}
+	}
+}
+
+// damageArmour damages the armour worn after the mob was hurt by the damage
+// source passed and hurts the attacker if any of the armour has Thorns.
+func (eq *Equipment) damageArmour(m *Mob, dmg float64, src world.DamageSource) {
+	damage := func(s item.Stack, d int) item.Stack {
+		if e, ok := s.Enchantment(enchantment.Unbreaking); ok {
+			d = enchantment.Unbreaking.Reduce(s.Item(), e.Level(), d)
+		}
+		if s = s.Damage(d); s.Empty() {
+			m.tx.PlaySound(m.Position(), sound.ItemBreak{})
+		}
+		return s
+	}
+	eq.armour.Damage(dmg, damage)
+	if l, ok := damageSourceAttacker(src).(Living); ok && l.H() != m.H() {
+		if thorns := eq.armour.ThornsDamage(damage); thorns > 0 {
+			l.Hurt(thorns, enchantment.ThornsDamageSource{Owner: m})
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewFireball creates a large fireball, like the ones shot by ghasts, that
+// accelerates in the direction passed.
+func NewFireball(opts world.EntitySpawnOpts, owner world.Entity, dir mgl64.Vec3) *world.EntityHandle {
+	conf := fireballConf
+	conf.Owner, conf.Direction = owner.H(), dir
+	return opts.New(FireballType, conf)
+}
+
+var fireballConf = FireballBehaviourConfig{ExplosionPower: 1}
+
+// FireballBehaviourConfig holds optional parameters for a FireballBehaviour.
+type FireballBehaviourConfig struct {
+	// Owner is the entity that shot the fireball.
+	Owner *world.EntityHandle
+	// Direction is the direction that the fireball accelerates in.
+	Direction mgl64.Vec3
+	// ExplosionPower is the size of the explosion created when the fireball
+	// hits a block or an entity.
+	ExplosionPower float64
+}
+
+func (conf FireballBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a FireballBehaviour using the parameters in conf.
+func (conf FireballBehaviourConfig) New() *FireballBehaviour {
+	f := &FireballBehaviour{explosionPower: conf.ExplosionPower}
+	if conf.Direction.Len() > 0 {
+		f.acceleration = conf.Direction.Normalize().Mul(fireballAcceleration)
+	}
+	f.ProjectileBehaviour = ProjectileBehaviourConfig{
+		Owner:  conf.Owner,
+		Drag:   0.05,
+		Damage: -1,
+		Hit:    f.hit,
+	}.New()
+	return f
+}
+
+// fireballAcceleration is the velocity added to a fireball every tick in the
+// direction it was shot in.
+const fireballAcceleration = 0.1
+
+// FireballBehaviour implements the behaviour of large fireballs. Fireballs are
+// not affected by gravity, but instead keep accelerating in the direction they
+// were shot in. They explode and start fires when they hit something, and may
+// be deflected by attacking them.
+type FireballBehaviour struct {
+	*ProjectileBehaviour
+
+	acceleration   mgl64.Vec3
+	explosionPower float64
+}
+
+// Tick accelerates the fireball and moves it.
+func (f *FireballBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	e.data.Vel = e.data.Vel.Add(f.acceleration)
+	return f.ProjectileBehaviour.Tick(e, tx)
+}
+
+// Deflect sends the fireball flying in the direction passed. The entity that
+// deflected the fireball becomes its new owner.
+func (f *FireballBehaviour) Deflect(e *Ent, dir mgl64.Vec3, by world.Entity) {
+	if dir.Len() == 0 {
+		return
+	}
+	dir = dir.Normalize()
+	e.SetVelocity(dir)
+	f.acceleration = dir.Mul(fireballAcceleration)
+	f.SetOwner(by.H())
+}
+
+// hit hurts the entity hit by the fireball and makes the fireball explode. A
+// ghast hit by a fireball deflected by a player is killed instantly.
+func (f *FireballBehaviour) hit(e *Ent, tx *world.Tx, target trace.Result) {
+	owner, _ := f.Owner().Entity(tx)
+	if r, ok := target.(trace.EntityResult); ok {
+		if l, ok := r.Entity().(Living); ok {
+			dmg := 6.0
+			if _, player := owner.(interface{ GameMode() world.GameMode }); player && r.Entity().H().Type() == GhastType {
+				dmg = 1000
+			}
+			l.Hurt(dmg, ProjectileDamageSource{Projectile: e, Owner: owner})
+		}
+	}
+	block.ExplosionConfig{Size: f.explosionPower, SpawnFire: true, Source: owner}.Explode(tx, e.Position())
+}
+
+// Fireball is a world.Entity implementation for large fireballs. Fireballs
+// are deflected when they are attacked.
+type Fireball struct {
+	*Ent
+}
+
+// Hurt deflects the fireball in the direction that the attacker is looking
+// in. Fireballs hurt by a source without an attacker are not deflected.
+func (f *Fireball) Hurt(_ float64, src world.DamageSource) (float64, bool) {
+	attacker := damageSourceAttacker(src)
+	if attacker == nil {
+		return 0, false
+	}
+	f.Behaviour().(*FireballBehaviour).Deflect(f.Ent, attacker.Rotation().Vec3(), attacker)
+	return 0, true
+}
+
+// HandleProjectileHit deflects the fireball in the direction that the owner
+// of the projectile hitting it is looking in. The projectile is stopped by the
+// fireball, but does not hurt it.
+func (f *Fireball) HandleProjectileHit(ctx *event.Context[*Ent], hit *ProjectileHit) {
+	hit.Damage = -1
+	p, ok := ctx.Val().Behaviour().(interface{ Owner() *world.EntityHandle })
+	if !ok {
+		return
+	}
+	if owner, ok := p.Owner().Entity(f.tx); ok {
+		f.Behaviour().(*FireballBehaviour).Deflect(f.Ent, owner.Rotation().Vec3(), owner)
+	}
+}
+
+// FireballType is a world.EntityType implementation for large fireballs.
+var FireballType fireballType
+
+type fireballType struct{}
+
+func (fireballType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Fireball{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (fireballType) EncodeEntity() string { return "minecraft:fireball" }
+func (fireballType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.5, 0, -0.5, 0.5, 1, 0.5)
+}
+
+func (fireballType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := fireballConf
+	if power, ok := m["ExplosionPower"].(float32); ok {
+		conf.ExplosionPower = float64(power)
+	}
+	conf.Direction = nbtconv.Vec3(m, "power")
+	data.Data = conf.New()
+}
+
+func (fireballType) EncodeNBT(data *world.EntityData) map[string]any {
+	f := data.Data.(*FireballBehaviour)
+	return map[string]any{"ExplosionPower": float32(f.explosionPower), "power": nbtconv.Vec3ToFloat32Slice(f.acceleration)}
+}
@@ -0,0 +1,252 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	// fishMaxAir is the amount of ticks that a fish survives out of water
+	// before it starts suffocating.
+	fishMaxAir = 300
+)
+
+// fishBehaviour is implemented by the behaviours of fish.
+type fishBehaviour interface {
+	swimming() *fish
+}
+
+// fish implements the behaviour shared by all fish, such as cod and salmon.
+// Fish swim around randomly in water, some of them in schools following a
+// leader, and flop around on land, where they suffocate. Fish that did not
+// come from a bucket despawn once they are far away from all players.
+type fish struct {
+	// school is the maximum size of the schools that the fish swims in. If
+	// 0, the fish does not swim in schools.
+	school int
+	leader *world.EntityHandle
+
+	air        int
+	fromBucket bool
+}
+
+// swimming returns the fish itself so that it can be found when it is
+// embedded in a Behaviour.
+func (f *fish) swimming() *fish {
+	return f
+}
+
+// FromBucket checks if the fish was released from a bucket. Fish released
+// from a bucket never despawn.
+func (f *fish) FromBucket() bool {
+	return f.fromBucket
+}
+
+// Leader returns the fish that leads the school this fish swims in, or nil
+// if the fish does not follow any other fish.
+func (f *fish) Leader() *world.EntityHandle {
+	return f.leader
+}
+
+// AirSupply returns the time that the fish can survive out of water for
+// before it starts suffocating.
+func (f *fish) AirSupply() time.Duration {
+	return time.Duration(f.air) * time.Second / 20
+}
+
+// MaxAirSupply returns the time that a fish can survive out of water for.
+func (f *fish) MaxAirSupply() time.Duration {
+	return fishMaxAir * time.Second / 20
+}
+
+// Breathing checks if the fish is in water.
+func (f *fish) Breathing() bool {
+	return f.air == fishMaxAir
+}
+
+// bucket marks the fish as captured in a bucket, so that it never despawns
+// once released, and returns the name of the bucket content passed.
+func (f *fish) bucket(name string) (string, bool) {
+	f.fromBucket = true
+	return name, true
+}
+
+// fishOf returns the fish of the entity passed if it is a fish.
+func fishOf(e world.Entity) (*fish, bool) {
+	if m, ok := e.(*Mob); ok {
+		if b, ok := m.Behaviour().(fishBehaviour); ok {
+			return b.swimming(), true
+		}
+	}
+	return nil, false
+}
+
+// tickFish performs the logic shared by all fish for a tick. False is
+// returned if the fish despawned.
+func (f *fish) tickFish(m *Mob, b *MobBehaviour, tx *world.Tx) bool {
+	if m.Age()%time.Second == 0 && f.despawn(m, tx) {
+		return false
+	}
+	if !b.inWater(m.Ent, tx) {
+		f.leader = nil
+		f.breathe(m, false)
+		f.flop(m, b, tx)
+		return true
+	}
+	f.breathe(m, true)
+	if f.school > 0 && f.followLeader(m, b, tx) {
+		return true
+	}
+	if !b.Moving() && rand.IntN(40) == 0 {
+		f.wander(m, b, tx)
+	}
+	return true
+}
+
+// breathe refills the air supply of the fish while it is in water and
+// drains it while it is not. Once the fish runs out of air, it starts
+// suffocating.
+func (f *fish) breathe(m *Mob, inWater bool) {
+	before := f.air
+	if inWater {
+		f.air = fishMaxAir
+	} else if f.air--; f.air <= -20 {
+		f.air = 0
+		m.Hurt(2, DrowningDamageSource{})
+	}
+	if (before == fishMaxAir) != (f.air == fishMaxAir) {
+		m.updateState()
+	}
+}
+
+// flop makes the fish hop around randomly while it is on land.
+func (f *fish) flop(m *Mob, b *MobBehaviour, tx *world.Tx) {
+	b.StopMoving()
+	if !b.mc.OnGround() {
+		return
+	}
+	m.data.Vel = m.data.Vel.Add(mgl64.Vec3{(rand.Float64()*2 - 1) * 0.05, 0.4, (rand.Float64()*2 - 1) * 0.05})
+	m.data.Rot = cube.Rotation{rand.Float64() * 360}
+	tx.PlaySound(m.Position(), sound.FishFlop{})
+}
+
+// wander makes the fish swim towards a random position in water close to
+// it.
+func (f *fish) wander(m *Mob, b *MobBehaviour, tx *world.Tx) {
+	target := m.Position().Add(randomHorizontalOffset(8))
+	target[1] += float64(rand.IntN(7) - 3)
+	if l, ok := tx.Liquid(cube.PosFromVec3(target)); ok {
+		if _, water := l.(block.Water); water {
+			b.MoveTo(target, 1)
+		}
+	}
+}
+
+// followLeader makes the fish follow the leader of its school, looking for a
+// new school to join if it has none. False is returned if the fish does not
+// follow any other fish.
+func (f *fish) followLeader(m *Mob, b *MobBehaviour, tx *world.Tx) bool {
+	if f.leader != nil {
+		e, ok := f.leader.Entity(tx)
+		leader, isMob := e.(*Mob)
+		if !ok || !isMob || leader.Dead() || e.Position().Sub(m.Position()).Len() > 16 {
+			f.leader = nil
+		}
+	}
+	if f.leader == nil && m.Age()%time.Second == 0 {
+		f.joinSchool(m, tx)
+	}
+	if f.leader == nil {
+		return false
+	}
+	e, _ := f.leader.Entity(tx)
+	if e.Position().Sub(m.Position()).Len() > 2.5 {
+		b.MoveTo(e.Position().Add(randomHorizontalOffset(1)), 1)
+	}
+	return true
+}
+
+// joinSchool makes the fish join the school of a fish of the same type close
+// to it, if that school is not yet full. Fish that are followed by other fish
+// never join another school.
+func (f *fish) joinSchool(m *Mob, tx *world.Tx) {
+	t := m.H().Type()
+	followers := make(map[*world.EntityHandle]int)
+	var candidates []*Mob
+	for e := range tx.EntitiesWithin(cube.Box(-16, -8, -16, 16, 8, 16).Translate(m.Position())) {
+		other, ok := fishOf(e)
+		if !ok || e.H().Type() != t || e.H() == m.H() {
+			continue
+		}
+		if other.leader != nil {
+			followers[other.leader]++
+			continue
+		}
+		if e.Position().Sub(m.Position()).Len() <= 8 {
+			candidates = append(candidates, e.(*Mob))
+		}
+	}
+	if followers[m.H()] > 0 {
+		return
+	}
+	closest := math.MaxFloat64
+	for _, c := range candidates {
+		if followers[c.H()]+1 >= f.school {
+			continue
+		}
+		if d := c.Position().Sub(m.Position()).Len(); d < closest {
+			f.leader, closest = c.H(), d
+		}
+	}
+}
+
+// despawn closes the fish if it is far away from all players. Unlike other
+// mobs, fish beyond the despawn distance of the world.DespawnPolicy despawn
+// randomly rather than after a delay. Fish that came from a bucket, are
+// persistent or were given a name tag never despawn. True is returned if the
+// fish despawned.
+func (f *fish) despawn(m *Mob, tx *world.Tx) bool {
+	policy := tx.World().DespawnPolicy()
+	if policy.Disabled || f.fromBucket || m.behaviour().persistent || m.NameTag() != "" {
+		return false
+	}
+	closest := closestPlayerDistance(m, tx)
+	// Fish are only checked once every second, so the chance of despawning
+	// is adjusted to that of despawning once in 800 ticks.
+	if closest > policy.InstantDistance || (closest > policy.Distance && rand.IntN(40) == 0) {
+		return despawnMob(m, tx)
+	}
+	return false
+}
+
+// fishDrops returns the items dropped by a fish when it dies: The fish item
+// passed, and rarely some bone meal.
+func fishDrops(it world.Item) []item.Stack {
+	drops := []item.Stack{item.NewStack(it, 1)}
+	if rand.Float64() < 0.05 {
+		drops = append(drops, item.NewStack(item.BoneMeal{}, 1))
+	}
+	return drops
+}
+
+// encodeNBT encodes if the fish came from a bucket into the map passed.
+func (f *fish) encodeNBT(m map[string]any) {
+	m["FromBucket"] = boolByte(f.fromBucket)
+	m["Air"] = int16(f.air)
+}
+
+// decodeNBT decodes if the fish came from a bucket from the map passed.
+func (f *fish) decodeNBT(m map[string]any) {
+	f.fromBucket = nbtconv.Bool(m, "FromBucket")
+	if _, ok := m["Air"]; ok {
+		f.air = int(nbtconv.Int16(m, "Air"))
+	}
+}
@@ -0,0 +1,403 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+)
+
+// NewFrog creates a new frog with the variant passed.
+func NewFrog(opts world.EntitySpawnOpts, variant FrogVariant) *world.EntityHandle {
+	conf := frogConf
+	conf.Variant = variant
+	return opts.New(FrogType, conf)
+}
+
+var frogConf FrogBehaviourConfig
+
+// FrogVariant is the variant of a frog, which depends on the climate of the
+// biome that the frog grew up in.
+type FrogVariant int32
+
+const (
+	// FrogTemperate is the orange frog variant, which grows up in temperate
+	// biomes.
+	FrogTemperate FrogVariant = iota
+	// FrogCold is the green frog variant, which grows up in cold biomes.
+	FrogCold
+	// FrogWarm is the white frog variant, which grows up in warm biomes.
+	FrogWarm
+)
+
+// FrogVariantAt returns the FrogVariant of frogs that grow up at the position
+// passed, based on the biome at that position.
+func FrogVariantAt(pos cube.Pos, tx *world.Tx) FrogVariant {
+	tags := tx.Biome(pos).Tags()
+	switch {
+	case slices.Contains(tags, "spawns_warm_variant_frogs"):
+		return FrogWarm
+	case slices.Contains(tags, "spawns_cold_variant_frogs"):
+		return FrogCold
+	}
+	return FrogTemperate
+}
+
+// Froglight returns the type of froglight produced when a frog of this
+// variant eats a small magma cube.
+func (v FrogVariant) Froglight() block.FroglightType {
+	switch v {
+	case FrogCold:
+		return block.Verdant()
+	case FrogWarm:
+		return block.Pearlescent()
+	}
+	return block.Ochre()
+}
+
+// FrogBehaviourConfig holds optional parameters for a FrogBehaviour.
+type FrogBehaviourConfig struct {
+	// Variant is the variant of the frog.
+	Variant FrogVariant
+}
+
+func (conf FrogBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a FrogBehaviour using the parameters in conf.
+func (conf FrogBehaviourConfig) New() *FrogBehaviour {
+	if conf.Variant < FrogTemperate || conf.Variant > FrogWarm {
+		panic("invalid frog variant")
+	}
+	f := &FrogBehaviour{variant: conf.Variant}
+	f.MobBehaviour = MobBehaviourConfig{
+		MaxHealth:  10,
+		Speed:      0.04,
+		Swimming:   true,
+		Experience: 1 + rand.IntN(3),
+	}.New()
+	return f
+}
+
+const (
+	// frogLoveTicks is the amount of ticks that a frog is in love for after
+	// being fed.
+	frogLoveTicks = 600
+	// frogBreedCooldown is the amount of ticks after breeding before a frog
+	// may breed again.
+	frogBreedCooldown = 6000
+	// frogTongueTicks is the amount of ticks between a frog shooting out its
+	// tongue and swallowing its prey.
+	frogTongueTicks = 6
+	// frogTongueReach is the maximum distance from which a frog can catch its
+	// prey with its tongue.
+	frogTongueReach = 3
+)
+
+// FrogBehaviour implements the behaviour of frogs. Frogs make long jumps on
+// land, swim in water and catch small slimes and magma cubes with their
+// tongue. A frog that eats a small magma cube leaves behind a froglight of a
+// type that depends on the variant of the frog. Frogs may be bred using
+// slimeballs, after which one of them lays frogspawn on the surface of water
+// close to it.
+type FrogBehaviour struct {
+	*MobBehaviour
+
+	variant FrogVariant
+
+	prey        *world.EntityHandle
+	tongueTicks int
+
+	loveTicks     int
+	breedCooldown int
+	// frogspawn is true if the frog bred and is looking for water to lay its
+	// frogspawn in. spawnPos is the position at which it lays the frogspawn,
+	// if one was found.
+	frogspawn bool
+	spawnPos  *cube.Pos
+}
+
+// Variant returns the variant of the frog.
+func (f *FrogBehaviour) Variant() int32 {
+	return int32(f.variant)
+}
+
+// InLove checks if the frog was fed and is looking for another frog to breed
+// with.
+func (f *FrogBehaviour) InLove() bool {
+	return f.loveTicks > 0
+}
+
+// TongueTarget returns the entity that the frog is currently catching with
+// its tongue. False is returned if the frog is not using its tongue.
+func (f *FrogBehaviour) TongueTarget() (*world.EntityHandle, bool) {
+	return f.prey, f.tongueTicks > 0
+}
+
+// Immune makes the frog immune to fall damage, so that its long jumps never
+// hurt it.
+func (f *FrogBehaviour) Immune(src world.DamageSource) bool {
+	_, fall := src.(FallDamageSource)
+	return fall
+}
+
+// Interact feeds the frog the slimeball held by the user, making it look for
+// another frog to breed with.
+func (f *FrogBehaviour) Interact(m *Mob, user item.User, _ *world.Tx) bool {
+	held, _ := user.HeldItems()
+	if _, ok := held.Item().(item.Slimeball); !ok || f.InLove() || f.breedCooldown > 0 {
+		return false
+	}
+	f.loveTicks = frogLoveTicks
+	consumeHeldItem(user)
+	m.updateState()
+	return true
+}
+
+// Tick ticks the frog, making it catch its prey, breed and jump around.
+func (f *FrogBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !f.Dead() {
+		f.tickFrog(&Mob{Ent: e}, tx)
+	}
+	return f.MobBehaviour.Tick(e, tx)
+}
+
+// tickFrog performs the frog specific logic of a tick.
+func (f *FrogBehaviour) tickFrog(m *Mob, tx *world.Tx) {
+	if f.breedCooldown > 0 {
+		f.breedCooldown--
+	}
+	if f.loveTicks > 0 {
+		if f.loveTicks--; f.loveTicks == 0 {
+			m.updateState()
+		}
+	}
+	if f.tongueTicks > 0 {
+		if f.tongueTicks--; f.tongueTicks == 0 {
+			f.swallow(m, tx)
+		}
+		return
+	}
+	if f.hunt(m, tx) {
+		return
+	}
+	if f.frogspawn && f.laySpawn(m, tx) {
+		return
+	}
+	if f.InLove() && f.breed(m, tx) {
+		return
+	}
+	if f.inWater(m.Ent, tx) {
+		if !f.Moving() && rand.IntN(80) == 0 {
+			offset := randomHorizontalOffset(6)
+			offset[1] = float64(rand.IntN(3) - 1)
+			f.MoveTo(m.Position().Add(offset), 1)
+		}
+		return
+	}
+	if !f.Moving() && m.OnGround() && rand.IntN(100) == 0 {
+		f.longJump(m, m.Position().Add(randomHorizontalOffset(5)))
+	}
+}
+
+// longJump makes the frog jump towards the target passed, landing close to it
+// if nothing is in the way. Frogs cover up to about five blocks in one jump.
+func (f *FrogBehaviour) longJump(m *Mob, target mgl64.Vec3) {
+	delta := target.Sub(m.Position())
+	delta[1] = 0
+	dist := delta.Len()
+	if dist < 1 {
+		return
+	}
+	// A frog is in the air for about 16 ticks during a jump, in which the
+	// drag of the air reduces the distance covered to about 14 times its
+	// initial horizontal velocity.
+	speed := math.Min(dist/14, 0.35)
+	m.data.Vel = delta.Normalize().Mul(speed).Add(mgl64.Vec3{0, 0.6})
+	m.data.Rot = rotationTowards(m.Position(), target)
+}
+
+// hunt makes the frog move towards its prey and shoot out its tongue once the
+// prey is within reach. If the frog has no prey, it looks for a small slime or
+// magma cube close to it. False is returned if the frog has nothing to hunt.
+func (f *FrogBehaviour) hunt(m *Mob, tx *world.Tx) bool {
+	if f.prey == nil && m.Age()%(time.Second/2) == 0 {
+		if prey, ok := nearestEntity(m, tx, 10, frogPrey); ok {
+			f.prey = prey.H()
+		}
+	}
+	if f.prey == nil {
+		return false
+	}
+	e, ok := f.prey.Entity(tx)
+	if t, living := e.(Living); !ok || !living || t.Dead() || e.Position().Sub(m.Position()).Len() > 16 {
+		f.prey = nil
+		return false
+	}
+	f.LookAt(EyePosition(e))
+	if e.Position().Sub(m.Position()).Len() > frogTongueReach {
+		if f.inWater(m.Ent, tx) {
+			f.MoveTo(e.Position(), 1.5)
+		} else if m.OnGround() && rand.IntN(10) == 0 {
+			f.longJump(m, e.Position())
+		}
+		return true
+	}
+	f.StopMoving()
+	f.tongueTicks = frogTongueTicks
+	m.updateState()
+	return true
+}
+
+// swallow makes the frog swallow the prey caught with its tongue, if it is
+// still within reach. Slimes leave behind a slimeball, while magma cubes leave
+// behind a froglight of the type matching the variant of the frog.
+func (f *FrogBehaviour) swallow(m *Mob, tx *world.Tx) {
+	defer m.updateState()
+	e, ok := f.prey.Entity(tx)
+	f.prey = nil
+	prey, living := e.(*Mob)
+	if !ok || !living || prey.Dead() || e.Position().Sub(m.Position()).Len() > frogTongueReach+1 {
+		return
+	}
+	drop := item.NewStack(item.Slimeball{}, 1)
+	if e.H().Type() == MagmaCubeType {
+		drop = item.NewStack(block.Froglight{Type: f.variant.Froglight()}, 1)
+	}
+	pos := prey.Position()
+	_ = prey.Close()
+	tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, drop))
+}
+
+// frogPrey checks if the entity passed is prey that a frog may eat: Slimes
+// and magma cubes of the smallest size.
+func frogPrey(e Living) bool {
+	m, ok := e.(*Mob)
+	if !ok {
+		return false
+	}
+	s, ok := m.Behaviour().(*SlimeBehaviour)
+	return ok && s.Size() == 1
+}
+
+// breed makes the frog move towards another frog in love. Once the two meet,
+// the frog becomes ready to lay frogspawn. False is returned if no other frog
+// in love was found.
+func (f *FrogBehaviour) breed(m *Mob, tx *world.Tx) bool {
+	mate, ok := nearestEntity(m, tx, 8, func(e Living) bool {
+		if mob, ok := e.(*Mob); ok {
+			other, ok := mob.Behaviour().(*FrogBehaviour)
+			return ok && other.InLove()
+		}
+		return false
+	})
+	if !ok {
+		return false
+	}
+	if mate.Position().Sub(m.Position()).Len() > 1.5 {
+		f.MoveTo(mate.Position(), 1)
+		return true
+	}
+	other := mate.(*Mob).Behaviour().(*FrogBehaviour)
+	f.loveTicks, other.loveTicks = 0, 0
+	f.breedCooldown, other.breedCooldown = frogBreedCooldown, frogBreedCooldown
+	f.frogspawn = true
+	m.updateState()
+	mate.(*Mob).updateState()
+
+	for _, orb := range NewExperienceOrbs(m.Position(), 1+rand.IntN(7)) {
+		tx.AddEntity(orb)
+	}
+	return true
+}
+
+// laySpawn makes the frog look for the surface of a body of water close to it
+// and lay frogspawn on it once it gets there. False is returned if no water
+// was found yet.
+func (f *FrogBehaviour) laySpawn(m *Mob, tx *world.Tx) bool {
+	if f.spawnPos == nil {
+		pos, ok := frogspawnPosition(m, tx)
+		if !ok {
+			return false
+		}
+		f.spawnPos = &pos
+	}
+	pos := *f.spawnPos
+	if _, ok := tx.Block(pos).(block.Air); !ok {
+		f.spawnPos = nil
+		return false
+	}
+	delta := pos.Vec3Middle().Sub(m.Position())
+	delta[1] = 0
+	if delta.Len() > 1.5 {
+		f.MoveTo(pos.Vec3Middle(), 1)
+		return true
+	}
+	f.frogspawn, f.spawnPos = false, nil
+	f.StopMoving()
+	tx.SetBlock(pos, block.Frogspawn{}, nil)
+	return true
+}
+
+// frogspawnPosition tries to find a position close to the frog passed at
+// which frogspawn may be laid: An empty block on top of a water source. A few
+// random positions are tried every tick. False is returned if none of them
+// is suitable.
+func frogspawnPosition(m *Mob, tx *world.Tx) (cube.Pos, bool) {
+	origin := cube.PosFromVec3(m.Position())
+	for range 10 {
+		pos := origin.Add(cube.Pos{rand.IntN(17) - 8, rand.IntN(7) - 3, rand.IntN(17) - 8})
+		if _, ok := tx.Block(pos).(block.Air); !ok {
+			continue
+		}
+		if liq, ok := tx.Liquid(pos.Side(cube.FaceDown)); ok && liq.LiquidType() == "water" && liq.LiquidDepth() == 8 {
+			return pos, true
+		}
+	}
+	return cube.Pos{}, false
+}
+
+// FrogType is a world.EntityType implementation for frogs.
+var FrogType frogType
+
+type frogType struct{}
+
+func (frogType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (frogType) EncodeEntity() string { return "minecraft:frog" }
+func (frogType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.25, 0, -0.25, 0.25, 0.55, 0.25)
+}
+
+func (frogType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := frogConf
+	conf.Variant = FrogVariant(nbtconv.Int32(m, "Variant"))
+	if conf.Variant < FrogTemperate || conf.Variant > FrogWarm {
+		conf.Variant = FrogTemperate
+	}
+	f := conf.New()
+	f.MobBehaviour.decodeNBT(m)
+	f.breedCooldown = int(nbtconv.Int32(m, "BreedCooldown"))
+	f.frogspawn = nbtconv.Bool(m, "HasFrogspawn")
+	data.Data = f
+}
+
+func (frogType) EncodeNBT(data *world.EntityData) map[string]any {
+	f := data.Data.(*FrogBehaviour)
+	m := map[string]any{
+		"Variant":       int32(f.variant),
+		"BreedCooldown": int32(f.breedCooldown),
+		"HasFrogspawn":  boolByte(f.frogspawn),
+	}
+	f.MobBehaviour.encodeNBT(m)
+	return m
+}
@@ -0,0 +1,201 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// NewGhast creates a new ghast.
+func NewGhast(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(GhastType, ghastConf)
+}
+
+var ghastConf = GhastBehaviourConfig{}
+
+// GhastBehaviourConfig holds optional parameters for a GhastBehaviour.
+type GhastBehaviourConfig struct{}
+
+func (conf GhastBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a GhastBehaviour using the parameters in conf.
+func (conf GhastBehaviourConfig) New() *GhastBehaviour {
+	g := &GhastBehaviour{}
+	g.MobBehaviour = MobBehaviourConfig{MaxHealth: 10, Speed: 0.02, Drag: 0.1, Flying: true, FireImmune: true, Experience: 5, Drops: g.drops}.New()
+	return g
+}
+
+const (
+	// ghastTargetRange is the distance within which ghasts look for players
+	// to shoot at.
+	ghastTargetRange = 64
+	// ghastChargeTicks is the amount of ticks that a ghast charges its
+	// fireball before shooting it. The ghast screams halfway through.
+	ghastChargeTicks = 20
+)
+
+// GhastBehaviour implements the behaviour of ghasts. Ghasts float around
+// slowly and shoot explosive fireballs at players they can see.
+type GhastBehaviour struct {
+	*MobBehaviour
+
+	target *world.EntityHandle
+	charge int
+}
+
+// Charging checks if the ghast is about to shoot a fireball, in which case it
+// opens its eyes and mouth.
+func (g *GhastBehaviour) Charging() bool {
+	return g.charge > ghastChargeTicks/2
+}
+
+// Target returns the player that the ghast is currently shooting at, or nil if
+// it is not attacking any player.
+func (g *GhastBehaviour) Target() *world.EntityHandle {
+	return g.target
+}
+
+// Tick ticks the ghast, making it float around and shoot at players.
+func (g *GhastBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !g.Dead() {
+		g.tickGhast(&Mob{Ent: e}, tx)
+	}
+	return g.MobBehaviour.Tick(e, tx)
+}
+
+// tickGhast performs the ghast specific logic of a tick.
+func (g *GhastBehaviour) tickGhast(m *Mob, tx *world.Tx) {
+	g.float(m, tx)
+
+	charging := g.Charging()
+	if target := g.findTarget(m, tx); target != nil && lineOfSight(EyePosition(m), EyePosition(target), tx) {
+		g.LookAt(EyePosition(target))
+		switch g.charge++; g.charge {
+		case ghastChargeTicks / 2:
+			tx.PlaySound(m.Position(), sound.GhastWarning{})
+		case ghastChargeTicks:
+			g.shoot(m, target, tx)
+			g.charge = -40
+		}
+	} else if g.charge > 0 {
+		g.charge--
+	}
+	if charging != g.Charging() {
+		m.updateState()
+	}
+}
+
+// float makes the ghast fly to a random position close to it once it has
+// reached its previous destination.
+func (g *GhastBehaviour) float(m *Mob, tx *world.Tx) {
+	if g.Moving() && rand.IntN(100) != 0 {
+		return
+	}
+	offset := mgl64.Vec3{rand.Float64()*32 - 16, rand.Float64()*32 - 16, rand.Float64()*32 - 16}
+	dest := m.Position().Add(offset)
+	if dest[1] < float64(tx.Range().Min()) || !lineOfSight(m.Position(), dest, tx) {
+		// The ghast would fly into a block, so it tries another position next
+		// tick.
+		g.StopMoving()
+		return
+	}
+	g.MoveTo(dest, 1)
+}
+
+// findTarget returns the player that the ghast is attacking, looking for a
+// new player if the ghast is not attacking any player. Nil is returned if no
+// player was found.
+func (g *GhastBehaviour) findTarget(m *Mob, tx *world.Tx) world.Entity {
+	if g.target != nil {
+		e, ok := g.target.Entity(tx)
+		if l, living := e.(Living); ok && living && !l.Dead() && attackablePlayer(l) && e.Position().Sub(m.Position()).Len() <= ghastTargetRange {
+			return e
+		}
+		g.target = nil
+	}
+	if m.Age()%time.Second != 0 {
+		return nil
+	}
+	if t, ok := nearestEntity(m, tx, ghastTargetRange, func(e Living) bool {
+		// Ghasts only notice players at roughly the same height as them.
+		return attackablePlayer(e) && math.Abs(e.Position()[1]-m.Position()[1]) <= 4 && lineOfSight(EyePosition(m), EyePosition(e), tx)
+	}); ok {
+		g.target = t.H()
+		return t
+	}
+	return nil
+}
+
+// shoot makes the ghast shoot a fireball at the target passed. The fireball
+// is spawned in front of the ghast, outside its body.
+func (g *GhastBehaviour) shoot(m *Mob, target world.Entity, tx *world.Tx) {
+	centre := m.Position().Add(mgl64.Vec3{0, 2})
+	targetCentre := target.Position().Add(mgl64.Vec3{0, target.H().Type().BBox(target).Height() / 2})
+	dir := targetCentre.Sub(centre)
+	if dir.Len() == 0 {
+		return
+	}
+	dir = dir.Normalize()
+	pos := centre.Add(mgl64.Vec3{dir[0] * 4, 0.5, dir[2] * 4})
+
+	tx.PlaySound(m.Position(), sound.GhastShoot{})
+	tx.AddEntity(NewFireball(world.EntitySpawnOpts{Position: pos}, m, targetCentre.Sub(pos)))
+}
+
+// drops returns the items dropped by a ghast when it dies. Ghast tears are
+// thrown towards the entity that killed the ghast, so that they are less
+// likely to fall into lava.
+func (g *GhastBehaviour) drops(m *Mob, src world.DamageSource) []item.Stack {
+	var drops []item.Stack
+	if n := rand.IntN(3); n > 0 {
+		drops = append(drops, item.NewStack(item.Gunpowder{}, n))
+	}
+	if rand.IntN(2) == 0 {
+		return drops
+	}
+	tear := item.NewStack(item.GhastTear{}, 1)
+	killer := damageSourceAttacker(src)
+	if killer == nil {
+		return append(drops, tear)
+	}
+	pos := m.Position().Add(mgl64.Vec3{0, 2})
+	vel := mgl64.Vec3{0, 0.2}
+	if delta := killer.Position().Sub(pos); delta.Len() > 0 {
+		vel = vel.Add(delta.Normalize().Mul(0.4))
+	}
+	m.tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos, Velocity: vel}, tear))
+	return drops
+}
+
+// GhastType is a world.EntityType implementation for ghasts.
+var GhastType ghastType
+
+type ghastType struct{}
+
+func (ghastType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (ghastType) EncodeEntity() string { return "minecraft:ghast" }
+func (ghastType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-2, 0, -2, 2, 4, 2)
+}
+
+func (ghastType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	g := ghastConf.New()
+	g.decodeNBT(m)
+	data.Data = g
+}
+
+func (ghastType) EncodeNBT(data *world.EntityData) map[string]any {
+	m := map[string]any{}
+	data.Data.(*GhastBehaviour).encodeNBT(m)
+	return m
+}
@@ -4,6 +4,14 @@ type (
 	// FoodHealingSource is a healing source used for when an entity regenerates health automatically when their food
 	// bar is at least 90% filled.
 	FoodHealingSource struct{}
+	// WitherHealingSource is a healing source used for when a wither regenerates health over time or heals after
+	// killing an entity with one of its skulls.
+	WitherHealingSource struct{}
+	// EndCrystalHealingSource is a healing source used for when an ender dragon is healed by the beam of an end
+	// crystal close to it.
+	EndCrystalHealingSource struct{}
 )
 
-func (FoodHealingSource) HealingSource() {}
+func (FoodHealingSource) HealingSource()       {}
+func (WitherHealingSource) HealingSource()     {}
+func (EndCrystalHealingSource) HealingSource() {}
@@ -0,0 +1,167 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// NewIronGolem creates a new iron golem. Iron golems that were created by a
+// player never attack players, not even when they are attacked by them.
+func NewIronGolem(opts world.EntitySpawnOpts, playerCreated bool) *world.EntityHandle {
+	conf := ironGolemConf
+	conf.PlayerCreated = playerCreated
+	return opts.New(IronGolemType, conf)
+}
+
+var ironGolemConf = IronGolemBehaviourConfig{}
+
+// IronGolemBehaviourConfig holds optional parameters for an
+// IronGolemBehaviour.
+type IronGolemBehaviourConfig struct {
+	// PlayerCreated specifies if the iron golem was built by a player.
+	PlayerCreated bool
+}
+
+func (conf IronGolemBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates an IronGolemBehaviour using the parameters in conf.
+func (conf IronGolemBehaviourConfig) New() *IronGolemBehaviour {
+	g := &IronGolemBehaviour{playerCreated: conf.PlayerCreated}
+	g.MobBehaviour = MobBehaviourConfig{MaxHealth: 100, Speed: 0.08, KnockBackResistance: 1, Drops: g.drops}.New()
+	return g
+}
+
+// IronGolemBehaviour implements the behaviour of iron golems. Iron golems
+// attack hostile mobs close to them and any entity that attacks them.
+type IronGolemBehaviour struct {
+	*MobBehaviour
+
+	playerCreated  bool
+	target         *world.EntityHandle
+	attackCooldown int
+}
+
+// PlayerCreated checks if the iron golem was built by a player.
+func (g *IronGolemBehaviour) PlayerCreated() bool {
+	return g.playerCreated
+}
+
+// Target returns the entity that the iron golem is currently attacking, or
+// nil if it is not attacking any entity.
+func (g *IronGolemBehaviour) Target() *world.EntityHandle {
+	return g.target
+}
+
+// Hurt makes the iron golem attack the entity that attacked it, unless it was
+// attacked by a player and the iron golem was built by a player.
+func (g *IronGolemBehaviour) Hurt(_ *Mob, _ float64, src world.DamageSource) {
+	attacker := damageSourceAttacker(src)
+	if attacker == nil {
+		return
+	}
+	if _, ok := attacker.(interface{ GameMode() world.GameMode }); ok && g.playerCreated {
+		return
+	}
+	g.target = attacker.H()
+}
+
+// Tick ticks the iron golem, making it look for hostile mobs nearby and
+// attack its target.
+func (g *IronGolemBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !g.Dead() {
+		g.tickIronGolem(&Mob{Ent: e}, tx)
+	}
+	return g.MobBehaviour.Tick(e, tx)
+}
+
+// tickIronGolem performs the iron golem specific logic of a tick.
+func (g *IronGolemBehaviour) tickIronGolem(m *Mob, tx *world.Tx) {
+	if g.attackCooldown > 0 {
+		g.attackCooldown--
+	}
+	if g.target == nil && m.Age()%time.Second == 0 {
+		if t, ok := nearestEntity(m, tx, 16, func(e Living) bool { return hostile(e) }); ok {
+			g.target = t.H()
+		}
+	}
+	if g.target == nil {
+		if !g.Moving() && rand.IntN(240) == 0 {
+			g.MoveTo(m.Position().Add(randomHorizontalOffset(8)), 0.6)
+		}
+		return
+	}
+	e, ok := g.target.Entity(tx)
+	t, living := e.(Living)
+	if !ok || !living || t.Dead() || e.Position().Sub(m.Position()).Len() > 24 {
+		g.target = nil
+		g.StopMoving()
+		return
+	}
+	g.LookAt(EyePosition(e))
+	if e.Position().Sub(m.Position()).Len() > 2.5 {
+		g.MoveTo(e.Position(), 1)
+		return
+	}
+	g.StopMoving()
+	if g.attackCooldown > 0 {
+		return
+	}
+	g.attackCooldown = 20
+	if _, vulnerable := t.Hurt(7+rand.Float64()*14, AttackDamageSource{Attacker: m}); vulnerable {
+		t.KnockBack(m.Position(), 0.4, 0.6)
+	}
+	for _, v := range tx.Viewers(m.Position()) {
+		v.ViewEntityAction(m, SwingArmAction{})
+	}
+}
+
+// drops returns the items dropped by an iron golem when it dies.
+func (g *IronGolemBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	drops := []item.Stack{item.NewStack(item.IronIngot{}, 3+rand.IntN(3))}
+	if n := rand.IntN(3); n > 0 {
+		drops = append(drops, item.NewStack(block.Flower{Type: block.Poppy()}, n))
+	}
+	return drops
+}
+
+// randomHorizontalOffset returns a random horizontal offset of at most the
+// distance passed on both the X and Z axis.
+func randomHorizontalOffset(dist float64) mgl64.Vec3 {
+	return mgl64.Vec3{rand.Float64()*dist*2 - dist, 0, rand.Float64()*dist*2 - dist}
+}
+
+// IronGolemType is a world.EntityType implementation for iron golems.
+var IronGolemType ironGolemType
+
+type ironGolemType struct{}
+
+func (ironGolemType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (ironGolemType) EncodeEntity() string { return "minecraft:iron_golem" }
+func (ironGolemType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.7, 0, -0.7, 0.7, 2.9, 0.7)
+}
+
+func (ironGolemType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	g := ironGolemConf.New()
+	g.MobBehaviour.decodeNBT(m)
+	g.playerCreated = nbtconv.Bool(m, "IsPlayerCreated")
+	data.Data = g
+}
+
+func (ironGolemType) EncodeNBT(data *world.EntityData) map[string]any {
+	g := data.Data.(*IronGolemBehaviour)
+	m := map[string]any{"IsPlayerCreated": boolByte(g.playerCreated)}
+	g.MobBehaviour.encodeNBT(m)
+	return m
+}
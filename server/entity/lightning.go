@@ -45,6 +45,9 @@ type lightningState struct {
 // on fire when appropriate.
 func (s *lightningState) tick(e *Ent, tx *world.Tx) {
 	pos := e.Position()
+	if s.state == 2 {
+		s.strikeBlock(tx, pos)
+	}
 
 	if s.state--; s.state < 0 {
 		if s.lifetime == 0 {
@@ -81,6 +84,17 @@ func (s *lightningState) dealDamage(e *Ent, tx *world.Tx) {
 	}
 }
 
+// strikeBlock notifies the block directly below the lightning, such as a
+// lightning rod, that it was struck.
+func (s *lightningState) strikeBlock(tx *world.Tx, pos mgl64.Vec3) {
+	bpos := cube.PosFromVec3(pos.Sub(mgl64.Vec3{0, 1e-6}))
+	if b, ok := tx.Block(bpos).(interface {
+		StruckByLightning(pos cube.Pos, tx *world.Tx)
+	}); ok {
+		b.StruckByLightning(bpos, tx)
+	}
+}
+
 // spreadFire attempts to place fire at the position of the lightning and does
 // 4 additional attempts to spread it around that position.
 func (s *lightningState) spreadFire(tx *world.Tx, pos cube.Pos) {
@@ -1,9 +1,11 @@
 package entity
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
 )
 
 // Living represents an entity that is alive and that has health. It is able to take damage and will die upon
@@ -14,7 +16,8 @@ type Living interface {
 	Health() float64
 	// MaxHealth returns the maximum health of the entity.
 	MaxHealth() float64
-	// SetMaxHealth changes the maximum health of the entity to the value passed.
+	// SetMaxHealth changes the base maximum health of the entity to the value passed. Modifiers of the maximum
+	// health attribute, such as that of the HealthBoost effect, are applied on top of it.
 	SetMaxHealth(v float64)
 	// Dead checks if the entity is considered dead. True is returned if the health of the entity is equal to or
 	// lower than 0.
@@ -49,6 +52,14 @@ type Living interface {
 	Effects() []effect.Effect
 	// Speed returns the current speed of the living entity. The default value is different for each entity.
 	Speed() float64
-	// SetSpeed sets the speed of an entity to a new value.
+	// SetSpeed sets the base speed of an entity to a new value. Modifiers of the movement speed attribute, such as
+	// those of the Speed effect, are applied on top of it.
 	SetSpeed(float64)
+	// Attributes returns the attributes of the entity, such as its maximum health and movement speed.
+	Attributes() *Attributes
+	// AddAttributeModifier applies an attribute.Modifier to an attribute of the entity, replacing any modifier with
+	// the same ID.
+	AddAttributeModifier(attr attribute.Attribute, m attribute.Modifier)
+	// RemoveAttributeModifier removes the attribute.Modifier with the ID passed from an attribute of the entity.
+	RemoveAttributeModifier(attr attribute.Attribute, id uuid.UUID)
 }
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestItemRisesInBubbleColumn(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	defer w.Close()
+
+	base := cube.Pos{8, 63, 8}
+	<-w.Exec(func(tx *world.Tx) {
+		tx.SetBlock(base, block.SoulSand{}, nil)
+		for y := 1; y <= 6; y++ {
+			tx.SetBlock(base.Add(cube.Pos{0, y}), block.Water{Still: true, Depth: 8}, nil)
+		}
+		for y := 0; y < 6; y++ {
+			tx.Block(base.Add(cube.Pos{0, y})).(world.NeighbourUpdateTicker).NeighbourUpdateTick(base.Add(cube.Pos{0, y}), base, tx)
+		}
+		if _, ok := tx.Block(base.Add(cube.Pos{0, 1})).(block.BubbleColumn); !ok {
+			t.Errorf("expected bubble column to form above soul sand, found %#v", tx.Block(base.Add(cube.Pos{0, 1})))
+			return
+		}
+
+		start := base.Add(cube.Pos{0, 1}).Vec3Middle()
+		e := tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: start}, item.NewStack(block.Stone{}, 1))).(*Ent)
+		for i := range 20 {
+			e.Tick(tx, int64(i))
+		}
+		if e.Position()[1] <= start[1]+1 {
+			t.Errorf("expected item to rise in the bubble column, moved from %v to %v", start, e.Position())
+		}
+	})
+}
+
+func TestMagmaDamagesMobsStandingOnIt(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	defer w.Close()
+
+	pos := cube.Pos{8, 63, 8}
+	<-w.Exec(func(tx *world.Tx) {
+		tx.SetBlock(pos, block.Magma{}, nil)
+		m := tx.AddEntity(NewSheep(world.EntitySpawnOpts{Position: pos.Side(cube.FaceUp).Vec3Middle()})).(*Mob)
+		for i := range 5 {
+			m.Tick(tx, int64(i))
+		}
+		if m.Health() >= m.MaxHealth() {
+			t.Errorf("expected mob standing on magma to be damaged, health is %v", m.Health())
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"os"
+	"testing"
+	_ "unsafe"
+)
+
+//go:linkname finaliseBlockRegistry github.com/df-mc/dragonfly/server/world.finaliseBlockRegistry
+func finaliseBlockRegistry()
+
+func TestMain(m *testing.M) {
+	// Blocks are normally finalised when a server is created, so this is done
+	// manually before running the tests of the package.
+	finaliseBlockRegistry()
+	os.Exit(m.Run())
+}
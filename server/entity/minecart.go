@@ -0,0 +1,703 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"sync"
+)
+
+// NewMinecart creates a new minecart of the type passed. Chest and hopper
+// minecarts carry an inventory.
+func NewMinecart(opts world.EntitySpawnOpts, t item.MinecartType) *world.EntityHandle {
+	conf := minecartConf
+	conf.Type = t
+	return opts.New(minecartEntityType(t), conf)
+}
+
+// NewFurnaceMinecart creates a new minecart carrying a furnace. A furnace
+// minecart pushes itself forward for as long as it has fuel.
+func NewFurnaceMinecart(opts world.EntitySpawnOpts) *world.EntityHandle {
+	conf := minecartConf
+	conf.Furnace = true
+	return opts.New(MinecartType, conf)
+}
+
+var minecartConf MinecartBehaviourConfig
+
+// MinecartBehaviourConfig holds optional parameters for a MinecartBehaviour.
+type MinecartBehaviourConfig struct {
+	// Type is the type of the minecart. The type must match the type of the
+	// entity that the behaviour is used for.
+	Type item.MinecartType
+	// Furnace specifies if a regular minecart carries a furnace. Furnace
+	// minecarts cannot be ridden, but push themselves forward while burning
+	// fuel.
+	Furnace bool
+}
+
+func (conf MinecartBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a MinecartBehaviour using the parameters in conf.
+func (conf MinecartBehaviourConfig) New() *MinecartBehaviour {
+	b := &MinecartBehaviour{
+		t:             conf.Type,
+		furnace:       conf.Furnace && conf.Type == item.RegularMinecart(),
+		hopperEnabled: true,
+		hurtDir:       1,
+		mc:            &MovementComputer{Gravity: 0.04},
+		viewers:       make(map[block.ContainerViewer]struct{}),
+	}
+	switch conf.Type {
+	case item.ChestMinecart():
+		b.inv = inventory.New(27, b.viewSlotChange)
+	case item.HopperMinecart():
+		b.inv = inventory.New(5, b.viewSlotChange)
+	}
+	return b
+}
+
+const (
+	// minecartSlope is the velocity added to a minecart every tick while it
+	// rides on an ascending rail, pushing it down the slope.
+	minecartSlope = 0.0078125
+	// minecartMaxFuel is the maximum amount of ticks that a furnace minecart
+	// can hold fuel for. Every piece of coal adds 3600 ticks of fuel.
+	minecartMaxFuel = 32000
+)
+
+// MinecartBehaviour implements the behaviour of minecarts. Minecarts ride
+// along rails, slowing down over time, and are accelerated by powered rails.
+// Minecarts that are not on a rail fall down and slide over the ground.
+type MinecartBehaviour struct {
+	t       item.MinecartType
+	furnace bool
+	mc      *MovementComputer
+
+	rail   cube.Pos
+	onRail bool
+
+	// damage is the amount of damage the minecart took recently. It recovers
+	// by 1 every tick, and the minecart is destroyed when it exceeds 40.
+	damage            float64
+	hurtTime, hurtDir int
+	destroyed         bool
+
+	// fuel is the amount of ticks that a furnace minecart burns for. push is
+	// the horizontal direction that the furnace minecart pushes itself in.
+	fuel int
+	push mgl64.Vec3
+
+	hopperEnabled  bool
+	hopperCooldown int
+
+	inv      *inventory.Inventory
+	viewerMu sync.RWMutex
+	viewers  map[block.ContainerViewer]struct{}
+}
+
+// Tick moves the minecart along the rail below it. If the minecart is not on
+// a rail, it falls down and slows down quickly.
+func (b *MinecartBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if b.hurtTime > 0 {
+		if b.hurtTime--; b.hurtTime == 0 {
+			e.viewMetadata()
+		}
+	}
+	if b.damage > 0 {
+		b.damage = math.Max(b.damage-1, 0)
+	}
+	if b.furnace && b.fuel > 0 {
+		if b.fuel--; b.fuel == 0 {
+			b.push = mgl64.Vec3{}
+			e.viewMetadata()
+		}
+	}
+
+	posBefore, velBefore := e.data.Pos, e.data.Vel
+	e.data.Vel[1] -= b.mc.Gravity
+
+	railPos := cube.PosFromVec3(e.data.Pos)
+	if _, _, ok := railAt(tx, railPos.Side(cube.FaceDown)); ok {
+		railPos = railPos.Side(cube.FaceDown)
+	}
+	if shape, rail, ok := railAt(tx, railPos); ok {
+		b.rail, b.onRail = railPos, true
+		b.moveAlongTrack(e, tx, railPos, shape, rail)
+		if h, ok := rail.(block.RailRideHandler); ok {
+			h.RiddenOver(railPos, tx, &Minecart{Ent: e})
+		}
+		if a, ok := rail.(block.ActivatorRail); ok {
+			b.activate(e, tx, a.Powered)
+		}
+	} else {
+		b.onRail = false
+		b.moveOffTrack(e, tx)
+	}
+	if b.t == item.HopperMinecart() && b.hopperEnabled {
+		b.tickHopper(e, tx)
+	}
+
+	if dx, dz := e.data.Pos[0]-posBefore[0], e.data.Pos[2]-posBefore[2]; dx*dx+dz*dz > 0.001 {
+		e.data.Rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-dx, dz)), 0}
+	}
+	return &Movement{v: tx.Viewers(e.data.Pos), e: e,
+		pos: e.data.Pos, vel: e.data.Vel, dpos: e.data.Pos.Sub(posBefore), dvel: e.data.Vel.Sub(velBefore),
+		rot: e.data.Rot, onGround: b.onRail || b.mc.onGround,
+	}
+}
+
+// moveAlongTrack moves the minecart along the rail at the position passed.
+// The velocity of the minecart is redirected along the rail, and the minecart
+// is accelerated by slopes and powered rails and slowed down by unpowered
+// powered rails.
+func (b *MinecartBehaviour) moveAlongTrack(e *Ent, tx *world.Tx, rail cube.Pos, shape block.RailShape, bl world.Block) {
+	before, hadBefore := railPosition(tx, e.data.Pos)
+	x, y, z := e.data.Pos[0], float64(rail[1]), e.data.Pos[2]
+	vel := e.data.Vel
+
+	powered, braking := false, false
+	if r, ok := bl.(block.PoweredRail); ok {
+		powered, braking = r.Powered, !r.Powered
+	}
+	switch shape {
+	case block.RailAscendingEast():
+		vel[0] -= minecartSlope
+		y++
+	case block.RailAscendingWest():
+		vel[0] += minecartSlope
+		y++
+	case block.RailAscendingNorth():
+		vel[2] += minecartSlope
+		y++
+	case block.RailAscendingSouth():
+		vel[2] -= minecartSlope
+		y++
+	}
+
+	// Redirect the horizontal velocity along the rail, in the direction
+	// closest to the current velocity.
+	exits := shape.Exits()
+	dx, dz := float64(exits[1][0]-exits[0][0]), float64(exits[1][2]-exits[0][2])
+	l := math.Hypot(dx, dz)
+	if vel[0]*dx+vel[2]*dz < 0 {
+		dx, dz = -dx, -dz
+	}
+	speed := math.Min(2, math.Hypot(vel[0], vel[2]))
+	vel[0], vel[2] = speed*dx/l, speed*dz/l
+	if braking {
+		if speed < 0.03 {
+			vel = mgl64.Vec3{}
+		} else {
+			vel = mgl64.Vec3{vel[0] * 0.5, 0, vel[2] * 0.5}
+		}
+	}
+
+	// Snap the minecart onto the line between the two exits of the rail.
+	x0, z0 := float64(rail[0])+0.5+float64(exits[0][0])*0.5, float64(rail[2])+0.5+float64(exits[0][2])*0.5
+	x1, z1 := float64(rail[0])+0.5+float64(exits[1][0])*0.5, float64(rail[2])+0.5+float64(exits[1][2])*0.5
+	ex, ez := x1-x0, z1-z0
+	var t float64
+	switch {
+	case ex == 0:
+		t = z - float64(rail[2])
+	case ez == 0:
+		t = x - float64(rail[0])
+	default:
+		t = ((x-x0)*ex + (z-z0)*ez) * 2
+	}
+	x, z = x0+ex*t, z0+ez*t
+
+	scale, maxSpeed := 1.0, b.maxSpeed(e, tx)
+	if len(e.Passengers()) > 0 {
+		scale = 0.75
+	}
+	move := mgl64.Vec3{mgl64.Clamp(vel[0]*scale, -maxSpeed, maxSpeed), 0, mgl64.Clamp(vel[2]*scale, -maxSpeed, maxSpeed)}
+	dPos, _ := b.mc.checkCollision(tx, e, mgl64.Vec3{x, y, z}, move)
+	x, z = x+dPos[0], z+dPos[2]
+
+	// Minecarts moving onto the lower end of an ascending rail move down
+	// with it.
+	bx, bz := int(math.Floor(x))-rail[0], int(math.Floor(z))-rail[2]
+	if exits[0][1] != 0 && bx == exits[0][0] && bz == exits[0][2] {
+		y += float64(exits[0][1])
+	} else if exits[1][1] != 0 && bx == exits[1][0] && bz == exits[1][2] {
+		y += float64(exits[1][1])
+	}
+	vel = b.slowDown(e, tx, vel)
+
+	if after, ok := railPosition(tx, mgl64.Vec3{x, y, z}); ok {
+		if hadBefore {
+			// Moving down a slope speeds the minecart up, while moving up
+			// slows it down.
+			dy := (before[1] - after[1]) * 0.05
+			if h := math.Hypot(vel[0], vel[2]); h > 0 {
+				vel[0], vel[2] = vel[0]/h*(h+dy), vel[2]/h*(h+dy)
+			}
+		}
+		y = after[1]
+	}
+	if nx, nz := int(math.Floor(x)), int(math.Floor(z)); nx != rail[0] || nz != rail[2] {
+		h := math.Hypot(vel[0], vel[2])
+		vel[0], vel[2] = h*float64(nx-rail[0]), h*float64(nz-rail[2])
+	}
+	if powered {
+		if h := math.Hypot(vel[0], vel[2]); h > 0.01 {
+			vel[0], vel[2] = vel[0]+vel[0]/h*0.06, vel[2]+vel[2]/h*0.06
+		} else if shape == block.RailEastWest() {
+			// A minecart standing still on a powered rail is pushed away from
+			// a solid block at either end of the rail.
+			if minecartConductor(tx, rail.Side(cube.FaceWest)) {
+				vel[0] = 0.02
+			} else if minecartConductor(tx, rail.Side(cube.FaceEast)) {
+				vel[0] = -0.02
+			}
+		} else if shape == block.RailNorthSouth() {
+			if minecartConductor(tx, rail.Side(cube.FaceNorth)) {
+				vel[2] = 0.02
+			} else if minecartConductor(tx, rail.Side(cube.FaceSouth)) {
+				vel[2] = -0.02
+			}
+		}
+	}
+	e.data.Pos, e.data.Vel = mgl64.Vec3{x, y, z}, vel
+
+	if b.furnace {
+		// The furnace minecart keeps pushing in the direction it is moving.
+		h, p := vel[0]*vel[0]+vel[2]*vel[2], b.push.LenSqr()
+		if p > 1e-4 && h > 0.001 {
+			b.push = mgl64.Vec3{vel[0], 0, vel[2]}.Mul(math.Sqrt(p) / math.Sqrt(h))
+		}
+	}
+}
+
+// moveOffTrack moves a minecart that is not on a rail. Minecarts on the
+// ground slow down quickly.
+func (b *MinecartBehaviour) moveOffTrack(e *Ent, tx *world.Tx) {
+	maxSpeed := b.maxSpeed(e, tx)
+	vel := e.data.Vel
+	vel[0], vel[2] = mgl64.Clamp(vel[0], -maxSpeed, maxSpeed), mgl64.Clamp(vel[2], -maxSpeed, maxSpeed)
+	if b.mc.onGround {
+		vel = vel.Mul(0.5)
+	}
+	dPos, vel := b.mc.checkCollision(tx, e, e.data.Pos, vel)
+	if !b.mc.onGround {
+		vel = vel.Mul(0.95)
+	}
+	e.data.Pos, e.data.Vel = e.data.Pos.Add(dPos), vel
+}
+
+// slowDown applies the natural slowdown of a minecart riding on a rail to the
+// velocity passed. Minecarts with a passenger slow down less than empty ones.
+// A furnace minecart with fuel pushes itself forward instead.
+func (b *MinecartBehaviour) slowDown(e *Ent, tx *world.Tx, vel mgl64.Vec3) mgl64.Vec3 {
+	if b.furnace {
+		if p := b.push.LenSqr(); p > 1e-7 {
+			b.push = b.push.Normalize()
+			vel = mgl64.Vec3{vel[0]*0.8 + b.push[0], 0, vel[2]*0.8 + b.push[2]}
+		} else {
+			vel = mgl64.Vec3{vel[0] * 0.98, 0, vel[2] * 0.98}
+		}
+	}
+	f := 0.96
+	if len(e.Passengers()) > 0 {
+		f = 0.997
+	} else if _, ok := tx.Liquid(cube.PosFromVec3(e.data.Pos)); ok {
+		f = 0.95
+	}
+	return mgl64.Vec3{vel[0] * f, 0, vel[2] * f}
+}
+
+// maxSpeed returns the maximum speed in blocks per tick that the minecart
+// moves at.
+func (b *MinecartBehaviour) maxSpeed(e *Ent, tx *world.Tx) float64 {
+	speed := 0.4
+	if b.furnace {
+		speed = 0.2
+	}
+	if _, ok := tx.Liquid(cube.PosFromVec3(e.data.Pos)); ok {
+		speed *= 0.5
+	}
+	return speed
+}
+
+// activate handles the minecart riding over an activator rail. A powered
+// activator rail ejects the passengers of a minecart and disables a hopper
+// minecart.
+func (b *MinecartBehaviour) activate(e *Ent, tx *world.Tx, powered bool) {
+	if b.t == item.HopperMinecart() {
+		b.hopperEnabled = !powered
+		return
+	}
+	if !powered {
+		return
+	}
+	for _, p := range e.Passengers() {
+		tx.Dismount(p)
+	}
+}
+
+// tickHopper makes a hopper minecart collect item entities that it rides
+// through and pull items out of the container above it.
+func (b *MinecartBehaviour) tickHopper(e *Ent, tx *world.Tx) {
+	if b.hopperCooldown--; b.hopperCooldown > 0 {
+		return
+	}
+	b.hopperCooldown = 4
+
+	above := cube.PosFromVec3(e.data.Pos).Side(cube.FaceUp)
+	if c, ok := tx.Block(above).(block.Container); ok {
+		src := c.Inventory(tx, above)
+		for slot, stack := range src.Slots() {
+			if stack.Empty() {
+				continue
+			}
+			if _, err := b.inv.AddItem(stack.Grow(-stack.Count() + 1)); err == nil {
+				_ = src.SetItem(slot, stack.Grow(-1))
+				return
+			}
+		}
+	}
+	box := e.H().Type().BBox(e).GrowVec3(mgl64.Vec3{0.25, 0, 0.25}).Extend(mgl64.Vec3{0, 0.5}).Translate(e.data.Pos)
+	for other := range tx.EntitiesWithin(box) {
+		it, ok := other.(*Ent)
+		if !ok || other.H().Type() != ItemType {
+			continue
+		}
+		stack := it.Behaviour().(*ItemBehaviour).Item()
+		n, _ := b.inv.AddItem(stack)
+		if n == 0 {
+			continue
+		}
+		if n < stack.Count() {
+			tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: it.Position()}, stack.Grow(-n)))
+		}
+		_ = it.Close()
+	}
+}
+
+// viewSlotChange shows a change of a slot in the inventory of the minecart to
+// all viewers of the inventory.
+func (b *MinecartBehaviour) viewSlotChange(slot int, _, after item.Stack) {
+	b.viewerMu.RLock()
+	defer b.viewerMu.RUnlock()
+	for v := range b.viewers {
+		v.ViewSlotChange(slot, after)
+	}
+}
+
+// destroy removes the minecart from the world. If drops is true, the minecart
+// drops itself as an item, along with its contents.
+func (b *MinecartBehaviour) destroy(e *Ent, tx *world.Tx, drops bool) {
+	if b.destroyed {
+		return
+	}
+	b.destroyed = true
+	pos := e.Position()
+	if drops {
+		tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, item.NewStack(item.Minecart{Type: b.t}, 1)))
+		if b.furnace {
+			tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, item.NewStack(block.NewFurnace(cube.North), 1)))
+		}
+	}
+	if b.inv != nil {
+		for _, it := range b.inv.Clear() {
+			tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, it))
+		}
+	}
+	_ = e.Close()
+}
+
+// Explode destroys the minecart when it is caught in an explosion.
+func (b *MinecartBehaviour) Explode(e *Ent, _ mgl64.Vec3, _ float64, _ block.ExplosionConfig) {
+	b.destroy(e, e.tx, true)
+}
+
+// railAt returns the shape of the rail at the position passed. False is
+// returned if there is no rail at the position.
+func railAt(tx *world.Tx, pos cube.Pos) (block.RailShape, world.Block, bool) {
+	switch b := tx.Block(pos).(type) {
+	case block.Rail:
+		return b.Shape, b, true
+	case block.PoweredRail:
+		return b.Shape, b, true
+	case block.DetectorRail:
+		return b.Shape, b, true
+	case block.ActivatorRail:
+		return b.Shape, b, true
+	}
+	return block.RailShape{}, nil, false
+}
+
+// railPosition returns the position on the rail closest to the position
+// passed, at the height that a minecart rides at on the rail. False is
+// returned if there is no rail at or directly below the position.
+func railPosition(tx *world.Tx, pos mgl64.Vec3) (mgl64.Vec3, bool) {
+	rail := cube.PosFromVec3(pos)
+	if _, _, ok := railAt(tx, rail.Side(cube.FaceDown)); ok {
+		rail = rail.Side(cube.FaceDown)
+	}
+	shape, _, ok := railAt(tx, rail)
+	if !ok {
+		return mgl64.Vec3{}, false
+	}
+	exits := shape.Exits()
+	start := rail.Vec3().Add(mgl64.Vec3{0.5, 0.0625, 0.5}).Add(exits[0].Vec3().Mul(0.5))
+	end := rail.Vec3().Add(mgl64.Vec3{0.5, 0.0625, 0.5}).Add(exits[1].Vec3().Mul(0.5))
+	d := end.Sub(start)
+	d[1] *= 2
+
+	var t float64
+	switch {
+	case d[0] == 0:
+		t = pos[2] - float64(rail[2])
+	case d[2] == 0:
+		t = pos[0] - float64(rail[0])
+	default:
+		t = ((pos[0]-start[0])*d[0] + (pos[2]-start[2])*d[2]) * 2
+	}
+	res := start.Add(d.Mul(t))
+	if d[1] < 0 {
+		res[1]++
+	} else if d[1] > 0 {
+		res[1] += 0.5
+	}
+	return res, true
+}
+
+// minecartConductor checks if the block at the position passed is a solid
+// block that a minecart standing still on a powered rail is pushed away from.
+func minecartConductor(tx *world.Tx, pos cube.Pos) bool {
+	_, ok := tx.Block(pos).Model().(model.Solid)
+	return ok
+}
+
+// Container represents an entity that carries an inventory which may be
+// opened by players, such as a chest minecart.
+type Container interface {
+	world.Entity
+	// Inventory returns the inventory carried by the entity.
+	Inventory() *inventory.Inventory
+	// AddViewer adds a viewer to the inventory, so that it is updated
+	// whenever the inventory changes.
+	AddViewer(v block.ContainerViewer)
+	// RemoveViewer removes a viewer from the inventory.
+	RemoveViewer(v block.ContainerViewer)
+}
+
+// ContainerOpener represents an entity that is able to open the inventory of
+// a Container entity.
+type ContainerOpener interface {
+	// OpenEntityContainer opens the inventory of the Container passed.
+	OpenEntityContainer(c Container)
+}
+
+// Minecart is a world.Entity implementation for minecarts. Minecarts ride
+// along rails and may carry a passenger, a chest, a hopper or a furnace.
+type Minecart struct {
+	*Ent
+}
+
+// Type returns the type of the minecart.
+func (m *Minecart) Type() item.MinecartType {
+	return m.behaviour().t
+}
+
+// Furnace checks if the minecart carries a furnace.
+func (m *Minecart) Furnace() bool {
+	return m.behaviour().furnace
+}
+
+// Fuel returns the amount of ticks that a furnace minecart has fuel for.
+func (m *Minecart) Fuel() int {
+	return m.behaviour().fuel
+}
+
+// RidingRail returns the position of the rail that the minecart is currently
+// riding on.
+func (m *Minecart) RidingRail() (cube.Pos, bool) {
+	b := m.behaviour()
+	return b.rail, b.onRail
+}
+
+// Inventory returns the inventory of a chest or hopper minecart. Nil is
+// returned for other minecarts.
+func (m *Minecart) Inventory() *inventory.Inventory {
+	return m.behaviour().inv
+}
+
+// AddViewer adds a viewer to the inventory of the minecart.
+func (m *Minecart) AddViewer(v block.ContainerViewer) {
+	b := m.behaviour()
+	b.viewerMu.Lock()
+	defer b.viewerMu.Unlock()
+	b.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the inventory of the minecart.
+func (m *Minecart) RemoveViewer(v block.ContainerViewer) {
+	b := m.behaviour()
+	b.viewerMu.Lock()
+	defer b.viewerMu.Unlock()
+	delete(b.viewers, v)
+}
+
+// HurtTime returns the amount of ticks that the minecart keeps shaking after
+// being hit, along with the direction it shakes in.
+func (m *Minecart) HurtTime() (ticks, direction int) {
+	b := m.behaviour()
+	return b.hurtTime, b.hurtDir
+}
+
+// DisplayBlock returns the block shown inside the minecart. False is returned
+// if the minecart does not show a custom block.
+func (m *Minecart) DisplayBlock() (world.Block, bool) {
+	b := m.behaviour()
+	if !b.furnace {
+		return nil, false
+	}
+	return block.Furnace{Facing: cube.North, Lit: b.fuel > 0}, true
+}
+
+// Hurt damages the minecart, making it shake. The minecart is destroyed and
+// dropped as an item once it takes too much damage in a short time. Players
+// in creative mode destroy minecarts instantly, without any drops.
+func (m *Minecart) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
+	b := m.behaviour()
+	if b.destroyed || dmg <= 0 {
+		return 0, false
+	}
+	creative := false
+	if s, ok := src.(AttackDamageSource); ok {
+		if g, ok := s.Attacker.(interface{ GameMode() world.GameMode }); ok {
+			creative = g.GameMode().CreativeInventory()
+		}
+	}
+	b.hurtDir, b.hurtTime = -b.hurtDir, 10
+	b.damage += dmg * 10
+	m.viewMetadata()
+	if creative || b.damage > 40 {
+		b.destroy(m.Ent, m.tx, !creative)
+	}
+	return dmg, true
+}
+
+// Interact handles a player interacting with the minecart. Regular minecarts
+// are ridden, chest and hopper minecarts have their inventory opened and
+// furnace minecarts are fuelled with coal.
+func (m *Minecart) Interact(user item.User, tx *world.Tx) bool {
+	b := m.behaviour()
+	switch {
+	case b.furnace:
+		held, _ := user.HeldItems()
+		switch held.Item().(type) {
+		case item.Coal, item.Charcoal:
+		default:
+			return false
+		}
+		if b.fuel+3600 > minecartMaxFuel {
+			return false
+		}
+		if b.fuel += 3600; b.fuel == 3600 {
+			m.viewMetadata()
+		}
+		consumeHeldItem(user)
+		b.push = m.Position().Sub(user.Position())
+		b.push[1] = 0
+		return true
+	case b.inv != nil:
+		o, ok := user.(ContainerOpener)
+		if ok {
+			o.OpenEntityContainer(m)
+		}
+		return ok
+	}
+	if s, ok := user.(interface{ Sneaking() bool }); ok && s.Sneaking() {
+		return false
+	}
+	return len(m.Passengers()) == 0 && tx.Mount(user, m)
+}
+
+// behaviour returns the MinecartBehaviour of the minecart.
+func (m *Minecart) behaviour() *MinecartBehaviour {
+	return m.Behaviour().(*MinecartBehaviour)
+}
+
+// MinecartType is a world.EntityType implementation for regular and furnace
+// minecarts.
+var MinecartType = minecartType{t: item.RegularMinecart()}
+
+// ChestMinecartType is a world.EntityType implementation for chest
+// minecarts.
+var ChestMinecartType = minecartType{t: item.ChestMinecart()}
+
+// HopperMinecartType is a world.EntityType implementation for hopper
+// minecarts.
+var HopperMinecartType = minecartType{t: item.HopperMinecart()}
+
+// minecartEntityType returns the entity type of minecarts of the type passed.
+func minecartEntityType(t item.MinecartType) minecartType {
+	return minecartType{t: t}
+}
+
+type minecartType struct {
+	t item.MinecartType
+}
+
+func (m minecartType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Minecart{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (m minecartType) EncodeEntity() string { return "minecraft:" + m.t.String() }
+func (minecartType) NetworkOffset() float64 { return 0.35 }
+func (minecartType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.49, 0, -0.49, 0.49, 0.7, 0.49)
+}
+
+// Seats returns 1: Minecarts may be ridden by a single passenger.
+func (minecartType) Seats(world.Entity) int { return 1 }
+
+// SeatOffset returns the offset at which the passenger sits in the minecart.
+func (minecartType) SeatOffset(world.Entity, int) mgl64.Vec3 {
+	return mgl64.Vec3{0, 0.1875}
+}
+
+func (m minecartType) DecodeNBT(data map[string]any, d *world.EntityData) {
+	conf := minecartConf
+	conf.Type, conf.Furnace = m.t, nbtconv.Bool(data, "Furnace")
+	b := conf.New()
+	if b.inv != nil {
+		nbtconv.InvFromNBT(b.inv, nbtconv.Slice(data, "Items"))
+	}
+	b.fuel = int(nbtconv.Int32(data, "Fuel"))
+	b.push = mgl64.Vec3{nbtconv.Float64(data, "PushX"), 0, nbtconv.Float64(data, "PushZ")}
+	if _, ok := data["Enabled"]; ok {
+		b.hopperEnabled = nbtconv.Bool(data, "Enabled")
+	}
+	d.Data = b
+}
+
+func (m minecartType) EncodeNBT(d *world.EntityData) map[string]any {
+	b := d.Data.(*MinecartBehaviour)
+	data := map[string]any{}
+	if b.furnace {
+		data["Furnace"] = boolByte(true)
+		data["Fuel"] = int32(b.fuel)
+		data["PushX"], data["PushZ"] = b.push[0], b.push[2]
+	}
+	if b.inv != nil {
+		data["Items"] = nbtconv.InvToNBT(b.inv)
+	}
+	if b.t == item.HopperMinecart() {
+		data["Enabled"] = boolByte(b.hopperEnabled)
+	}
+	return data
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/generator"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// rideTrack places a straight track of the rail passed along the x-axis at
+// the z passed, and returns the horizontal velocity of a minecart pushed onto
+// it after riding it for 20 ticks, starting at the first rail.
+func rideTrack(tx *world.Tx, z int, rail world.Block, vel float64) mgl64.Vec3 {
+	for x := 1; x < 32; x++ {
+		tx.SetBlock(cube.Pos{x, -63, z}, rail, nil)
+	}
+	opts := world.EntitySpawnOpts{Position: cube.Pos{1, -63, z}.Vec3Middle(), Velocity: mgl64.Vec3{vel, 0, 0}}
+	m := tx.AddEntity(NewMinecart(opts, item.RegularMinecart())).(*Minecart)
+	for i := range 20 {
+		m.Tick(tx, int64(i))
+	}
+	v := m.Velocity()
+	v[1] = 0
+	return v
+}
+
+func TestMinecartAcceleratesOnPoweredRail(t *testing.T) {
+	flat := generator.NewFlat(biome.Plains{}, []world.Block{block.Stone{}})
+	w := world.Config{Entities: DefaultRegistry, Generator: flat}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		shape := block.RailEastWest()
+		if v := rideTrack(tx, 0, block.Rail{Shape: shape}, 0.1); v[0] <= 0 || v[0] >= 0.1 {
+			t.Errorf("expected minecart to slow down on regular rails, velocity is %v", v)
+		}
+		if v := rideTrack(tx, 2, block.PoweredRail{Shape: shape, Powered: true}, 0.1); v[0] <= 0.1 {
+			t.Errorf("expected minecart to speed up on powered rails, velocity is %v", v)
+		}
+		if v := rideTrack(tx, 4, block.PoweredRail{Shape: shape}, 0.1); v[0] != 0 {
+			t.Errorf("expected minecart to be stopped by unpowered powered rails, velocity is %v", v)
+		}
+		// A minecart standing still on a powered rail is pushed away from the
+		// solid block at the end of the rail.
+		tx.SetBlock(cube.Pos{0, -63, 6}, block.Stone{}, nil)
+		if v := rideTrack(tx, 6, block.PoweredRail{Shape: shape, Powered: true}, 0); v[0] <= 0 {
+			t.Errorf("expected minecart to be pushed away from the block next to the powered rail, velocity is %v", v)
+		}
+	})
+}
@@ -0,0 +1,920 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Mob is a living Ent, such as an animal, a villager or a monster. A Mob has
+// health and effects, may be hurt and killed and is able to walk around in the
+// world. The state of a Mob is held by its Behaviour, which must embed a
+// *MobBehaviour.
+type Mob struct {
+	*Ent
+}
+
+// mobBehaviour is implemented by *MobBehaviour and by any Behaviour that
+// embeds it.
+type mobBehaviour interface {
+	Behaviour
+	living() *MobBehaviour
+}
+
+// behaviour returns the underlying *MobBehaviour of the Mob.
+func (m *Mob) behaviour() *MobBehaviour {
+	return m.Behaviour().(mobBehaviour).living()
+}
+
+// Health returns the current health of the mob.
+func (m *Mob) Health() float64 {
+	return m.behaviour().health.Health()
+}
+
+// MaxHealth returns the maximum health of the mob.
+func (m *Mob) MaxHealth() float64 {
+	return m.behaviour().health.MaxHealth()
+}
+
+// SetMaxHealth changes the base maximum health of the mob to the value
+// passed. Modifiers of the maximum health attribute are applied on top of it.
+func (m *Mob) SetMaxHealth(v float64) {
+	b := m.behaviour()
+	b.attributes.SetBase(attribute.MaxHealth(), v)
+	b.updateAttributes()
+}
+
+// Dead checks if the mob is considered dead. True is returned if the health
+// of the mob is equal to or lower than 0.
+func (m *Mob) Dead() bool {
+	return m.behaviour().Dead()
+}
+
+// Hurt hurts the mob for a given amount of damage. If the mob was hurt
+// recently, it only takes the amount of damage that exceeds the damage it was
+// hurt with previously. If the damage kills the mob, it displays its death
+// animation and drops its loot.
+func (m *Mob) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
+	b := m.behaviour()
+	if _, ok := m.Effect(effect.FireResistance); ((ok || b.conf.FireImmune) && src.Fire()) || b.Dead() || dmg < 0 {
+		return 0, false
+	}
+	if i, ok := m.Behaviour().(interface {
+		Immune(src world.DamageSource) bool
+	}); ok && i.Immune(src) {
+		return 0, false
+	}
+	if res, ok := m.Effect(effect.Resistance); ok {
+		dmg *= effect.Resistance.Multiplier(src, res.Level())
+	}
+	if a, ok := m.Behaviour().(interface{ DefencePoints() float64 }); ok && src.ReducedByArmour() {
+		dmg -= inventory.ArmourReduction(dmg, a.DefencePoints(), 0)
+	}
+	if b.equipment != nil {
+		dmg -= b.equipment.armour.DamageReduction(dmg, src)
+	}
+	damageLeft := dmg
+	if m.Age() < b.immuneUntil {
+		if damageLeft = dmg - b.lastDamage; damageLeft <= 0 {
+			return 0, false
+		}
+	}
+	b.immuneUntil, b.lastDamage = m.Age()+m.tx.World().DamageImmunity(), dmg
+	b.health.AddHealth(-damageLeft)
+	if b.equipment != nil && src.ReducedByArmour() {
+		b.equipment.damageArmour(m, dmg, src)
+	}
+
+	for _, v := range m.tx.Viewers(m.Position()) {
+		v.ViewEntityAction(m, HurtAction{})
+	}
+	if src.Fire() {
+		m.tx.PlaySound(m.Position(), sound.Burning{})
+	}
+	if h, ok := m.Behaviour().(interface {
+		Hurt(m *Mob, dmg float64, src world.DamageSource)
+	}); ok {
+		h.Hurt(m, damageLeft, src)
+	}
+	if _, ok := m.H().Entity(m.tx); ok && b.Dead() {
+		// The Hurt hook of the behaviour may have removed the mob, for example
+		// to convert it into another mob, in which case it is not killed.
+		b.kill(m, src)
+	}
+	return dmg, true
+}
+
+// Heal heals the mob for a given amount of health. The health of the mob
+// will never exceed its maximum health.
+func (m *Mob) Heal(health float64, _ world.HealingSource) {
+	if m.Dead() || health < 0 {
+		return
+	}
+	m.behaviour().health.AddHealth(health)
+}
+
+// KnockBack knocks the mob back with a given force and height, away from the
+// source passed.
+func (m *Mob) KnockBack(src mgl64.Vec3, force, height float64) {
+	if m.Dead() {
+		return
+	}
+	b := m.behaviour()
+	if b.equipment != nil {
+		b.equipment.applyAttributes(b.attributes)
+	}
+	res := b.attributes.Value(attribute.KnockBackResistance())
+	force, height = force*(1-res), height*(1-res)
+
+	velocity := m.Position().Sub(src)
+	velocity[1] = 0
+
+	if velocity.Len() != 0 {
+		velocity = velocity.Normalize().Mul(force)
+	}
+	velocity[1] = height
+	m.SetVelocity(velocity)
+}
+
+// Teleport teleports the mob to the position passed, resetting its velocity
+// and fall distance.
+func (m *Mob) Teleport(pos mgl64.Vec3) {
+	for _, v := range m.tx.Viewers(m.Position()) {
+		v.ViewEntityTeleport(m, pos)
+	}
+	m.tx.EmitGameEvent(m.Position(), gameevent.Teleport{}, m)
+	m.Dismount()
+	m.data.Pos, m.data.Vel = pos, mgl64.Vec3{}
+	m.behaviour().fallDistance = 0
+}
+
+// AddEffect adds an effect.Effect to the mob.
+func (m *Mob) AddEffect(e effect.Effect) {
+	m.behaviour().effects.Add(e, m)
+	m.updateState()
+}
+
+// RemoveEffect removes any effect of the type passed that might currently be
+// active on the mob.
+func (m *Mob) RemoveEffect(e effect.Type) {
+	m.behaviour().effects.Remove(e, m)
+	m.updateState()
+}
+
+// Effect returns the effect instance and true if the mob has the effect. If
+// not found, it will return an empty effect instance and false.
+func (m *Mob) Effect(e effect.Type) (effect.Effect, bool) {
+	return m.behaviour().effects.Effect(e)
+}
+
+// Effects returns any effect currently applied to the mob.
+func (m *Mob) Effects() []effect.Effect {
+	return m.behaviour().effects.Effects()
+}
+
+// Speed returns the current movement speed of the mob.
+func (m *Mob) Speed() float64 {
+	return m.behaviour().speed()
+}
+
+// SetSpeed sets the base movement speed of the mob to a new value. Modifiers
+// of the movement speed attribute are applied on top of it.
+func (m *Mob) SetSpeed(v float64) {
+	m.behaviour().attributes.SetBase(attribute.MovementSpeed(), v)
+}
+
+// Attributes returns the attributes of the mob. Changes to the maximum health
+// of the mob made directly through the Attributes only take effect after
+// calling SetMaxHealth or AddAttributeModifier.
+func (m *Mob) Attributes() *Attributes {
+	return m.behaviour().attributes
+}
+
+// AddAttributeModifier applies an attribute.Modifier to an attribute of the
+// mob, replacing any modifier with the same ID.
+func (m *Mob) AddAttributeModifier(attr attribute.Attribute, mod attribute.Modifier) {
+	b := m.behaviour()
+	b.attributes.AddModifier(attr, mod)
+	b.updateAttributes()
+}
+
+// RemoveAttributeModifier removes the attribute.Modifier with the ID passed
+// from an attribute of the mob.
+func (m *Mob) RemoveAttributeModifier(attr attribute.Attribute, id uuid.UUID) {
+	b := m.behaviour()
+	b.attributes.RemoveModifier(attr, id)
+	b.updateAttributes()
+}
+
+// EyeHeight returns the offset from the position of the mob at which its eyes
+// are found.
+func (m *Mob) EyeHeight() float64 {
+	return m.H().Type().BBox(m).Height() * 0.85
+}
+
+// OnGround checks if the mob is currently standing on the ground.
+func (m *Mob) OnGround() bool {
+	return m.behaviour().mc.OnGround()
+}
+
+// MoveTo makes the mob walk towards the destination passed, multiplying its
+// speed by speedMultiplier. The mob walks in a straight line, so obstacles
+// between the mob and its destination are not avoided.
+func (m *Mob) MoveTo(destination mgl64.Vec3, speedMultiplier float64) {
+	m.behaviour().MoveTo(destination, speedMultiplier)
+}
+
+// StopMoving stops the mob from walking towards its destination.
+func (m *Mob) StopMoving() {
+	m.behaviour().StopMoving()
+}
+
+// Moving checks if the mob is currently walking towards a destination.
+func (m *Mob) Moving() bool {
+	return m.behaviour().Moving()
+}
+
+// LookAt makes the mob look at the position passed during its next tick.
+func (m *Mob) LookAt(pos mgl64.Vec3) {
+	m.behaviour().LookAt(pos)
+}
+
+// Attack makes the mob attack the entity passed in melee, dealing dmg damage
+// with the modifiers of the attack damage attribute of the mob applied. True
+// is returned if the entity was hurt by the attack.
+func (m *Mob) Attack(e Living, dmg float64) bool {
+	for _, v := range m.tx.Viewers(m.Position()) {
+		v.ViewEntityAction(m, SwingArmAction{})
+	}
+	if _, vulnerable := e.Hurt(m.behaviour().attackDamage(dmg), AttackDamageSource{Attacker: m}); !vulnerable {
+		return false
+	}
+	e.KnockBack(m.Position(), 0.4, 0.4)
+	return true
+}
+
+// Interact makes the user passed interact with the mob, for example by
+// right-clicking it. True is returned if the interaction had any effect.
+func (m *Mob) Interact(user item.User, tx *world.Tx) bool {
+	if m.Dead() {
+		return false
+	}
+	if in, ok := m.Behaviour().(interface {
+		Interact(m *Mob, user item.User, tx *world.Tx) bool
+	}); ok {
+		return in.Interact(m, user, tx)
+	}
+	return false
+}
+
+// Bucket returns the name and NBT data of the mob if its behaviour allows it
+// to be captured in a bucket of water, such as that of an axolotl. Bucket
+// implements the item.Bucketable interface.
+func (m *Mob) Bucket() (string, map[string]any, bool) {
+	b, ok := m.Behaviour().(interface {
+		Bucket(m *Mob) (string, bool)
+	})
+	if !ok || m.Dead() {
+		return "", nil, false
+	}
+	name, ok := b.Bucket(m)
+	if !ok {
+		return "", nil, false
+	}
+	data := m.H().Type().EncodeNBT(m.data)
+	if tag := m.NameTag(); tag != "" {
+		data["NameTag"] = tag
+	}
+	return name, data, true
+}
+
+// gameEventListener is implemented by behaviours of mobs that perceive game
+// events, such as allays listening for note blocks.
+type gameEventListener interface {
+	GameEventRange() float64
+	ReceiveGameEvent(m *Mob, ev world.GameEvent, pos mgl64.Vec3, src world.Entity, tx *world.Tx)
+}
+
+// GameEventRange returns the radius within which the mob perceives game
+// events. A negative range is returned if the behaviour of the mob does not
+// listen to game events. GameEventRange implements the
+// world.EntityGameEventListener interface.
+func (m *Mob) GameEventRange() float64 {
+	if l, ok := m.Behaviour().(gameEventListener); ok && !m.Dead() {
+		return l.GameEventRange()
+	}
+	return -1
+}
+
+// ReceiveGameEvent passes a game event emitted close to the mob to its
+// behaviour, if it listens to game events.
+func (m *Mob) ReceiveGameEvent(ev world.GameEvent, pos mgl64.Vec3, src world.Entity, tx *world.Tx) {
+	if l, ok := m.Behaviour().(gameEventListener); ok && !m.Dead() {
+		l.ReceiveGameEvent(m, ev, pos, src, tx)
+	}
+}
+
+// projectileHitHandler is implemented by behaviours of mobs that react to
+// projectiles before being hit by them, such as endermen dodging arrows.
+type projectileHitHandler interface {
+	HandleProjectileHit(m *Mob, ctx *event.Context[*Ent], hit *ProjectileHit)
+}
+
+// HandleProjectileHit passes a projectile about to hit the mob to its
+// behaviour, if it handles projectile hits. HandleProjectileHit implements the
+// ProjectileHitHandler interface.
+func (m *Mob) HandleProjectileHit(ctx *event.Context[*Ent], hit *ProjectileHit) {
+	if h, ok := m.Behaviour().(projectileHitHandler); ok && !m.Dead() {
+		h.HandleProjectileHit(m, ctx, hit)
+	}
+}
+
+// updateState sends the current state of the mob to all of its viewers.
+func (m *Mob) updateState() {
+	for _, v := range m.tx.Viewers(m.Position()) {
+		v.ViewEntityState(m)
+	}
+}
+
+// MobBehaviourConfig holds optional parameters for a MobBehaviour.
+type MobBehaviourConfig struct {
+	// MaxHealth is the maximum health of the mob. If 0, a maximum health of 20
+	// is used.
+	MaxHealth float64
+	// Speed is the velocity added to the mob every tick while it is walking on
+	// the ground. If 0, a speed of 0.1 is used.
+	Speed float64
+	// Gravity is the amount of Y velocity subtracted every tick. If 0, a
+	// gravity of 0.08 is used.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick. Velocity is
+	// multiplied with (1-Drag) every tick. If 0, a drag of 0.02 is used.
+	Drag float64
+	// KnockBackResistance is the fraction of knock back that the mob resists,
+	// ranging from 0 to 1.
+	KnockBackResistance float64
+	// FireImmune specifies if the mob is immune to fire and lava.
+	FireImmune bool
+	// Flying specifies if the mob flies. Flying mobs are not affected by
+	// gravity, do not take fall damage and fly straight towards the
+	// destination set using MoveTo, rather than walking towards it.
+	Flying bool
+	// Swimming specifies if the mob swims. While in water, swimming mobs are
+	// not affected by gravity and swim straight towards their destination,
+	// like flying mobs do.
+	Swimming bool
+	// Experience is the amount of experience dropped by the mob when it is
+	// killed by another entity.
+	Experience int
+	// Drops returns the items dropped by the mob when it dies. If nil, the mob
+	// does not drop any items.
+	Drops func(m *Mob, src world.DamageSource) []item.Stack
+	// Interact is called when a user interacts with the mob. It returns true
+	// if the interaction had any effect.
+	Interact func(m *Mob, user item.User, tx *world.Tx) bool
+	// Tick is called for every tick that the mob is alive, before it moves.
+	Tick func(m *Mob, tx *world.Tx)
+	// Equip is called once when the mob is ticked for the first time after
+	// spawning, with the clamped local difficulty at its position ranging
+	// from 0 to 1. If non-nil, the mob has an Equipment holding the items it
+	// holds and wears, which Equip may add items to.
+	Equip func(m *Mob, eq *Equipment, difficulty float64)
+	// Persistent specifies if the mob is persistent when it spawns, so that it
+	// never despawns when it is far away from all players. Mobs spawned by
+	// players, such as those spawned using a spawn egg, are persistent.
+	Persistent bool
+}
+
+func (conf MobBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a MobBehaviour using the parameters in conf.
+func (conf MobBehaviourConfig) New() *MobBehaviour {
+	if conf.MaxHealth == 0 {
+		conf.MaxHealth = 20
+	}
+	if conf.Speed == 0 {
+		conf.Speed = 0.1
+	}
+	if conf.Gravity == 0 {
+		conf.Gravity = 0.08
+	}
+	if conf.Drag == 0 {
+		conf.Drag = 0.02
+	}
+	gravity := conf.Gravity
+	if conf.Flying {
+		gravity = 0
+	}
+	b := &MobBehaviour{
+		conf:       conf,
+		health:     NewHealthManager(conf.MaxHealth, conf.MaxHealth),
+		effects:    NewEffectManager(),
+		attributes: NewAttributes(),
+		mc:         &MovementComputer{Gravity: gravity, Drag: conf.Drag, DragBeforeGravity: true},
+		persistent: conf.Persistent,
+	}
+	b.attributes.SetBase(attribute.MaxHealth(), conf.MaxHealth)
+	b.attributes.SetBase(attribute.MovementSpeed(), conf.Speed)
+	b.attributes.SetBase(attribute.KnockBackResistance(), conf.KnockBackResistance)
+	if conf.Equip != nil {
+		b.equipment = NewEquipment()
+	}
+	return b
+}
+
+// MobBehaviour implements the Behaviour of a Mob. It handles health, effects,
+// fall damage and death, and moves the mob towards the destination set using
+// MoveTo. Behaviours of specific mobs may embed a *MobBehaviour to build on
+// top of it.
+type MobBehaviour struct {
+	conf       MobBehaviourConfig
+	mc         *MovementComputer
+	health     *HealthManager
+	effects    *EffectManager
+	attributes *Attributes
+
+	equipment *Equipment
+
+	lastDamage   float64
+	immuneUntil  time.Duration
+	fallDistance float64
+	deathTicks   int
+
+	persistent bool
+	farTime    time.Duration
+
+	destination     *mgl64.Vec3
+	speedMultiplier float64
+	lookAt          *mgl64.Vec3
+}
+
+// speed returns the movement speed of the mob, with all modifiers of its
+// movement speed attribute applied.
+func (b *MobBehaviour) speed() float64 {
+	return b.attributes.Value(attribute.MovementSpeed())
+}
+
+// updateAttributes applies the values of the attributes of the mob that are
+// tracked elsewhere, such as its maximum health.
+func (b *MobBehaviour) updateAttributes() {
+	b.health.SetMaxHealth(b.attributes.Value(attribute.MaxHealth()))
+}
+
+// attackDamage applies the attack damage modifiers of the mob, such as those
+// of the Strength effect and of the items it holds, to the damage passed.
+func (b *MobBehaviour) attackDamage(dmg float64) float64 {
+	if b.equipment != nil {
+		b.equipment.applyAttributes(b.attributes)
+	}
+	return b.attributes.Apply(attribute.AttackDamage(), dmg)
+}
+
+// living returns the MobBehaviour itself so that a Mob can find it when it is
+// embedded in another Behaviour.
+func (b *MobBehaviour) living() *MobBehaviour {
+	return b
+}
+
+// Dead checks if the health of the mob has dropped to 0.
+func (b *MobBehaviour) Dead() bool {
+	return b.health.Health() <= mgl64.Epsilon
+}
+
+// Equipment returns the items held and worn by the mob. Nil is returned if
+// the mob cannot hold or wear any items.
+func (b *MobBehaviour) Equipment() *Equipment {
+	return b.equipment
+}
+
+// Interact calls MobBehaviourConfig.Interact, if set.
+func (b *MobBehaviour) Interact(m *Mob, user item.User, tx *world.Tx) bool {
+	if b.conf.Interact != nil {
+		return b.conf.Interact(m, user, tx)
+	}
+	return false
+}
+
+// Persistent checks if the mob is persistent, meaning it never despawns when
+// it is far away from all players. Mobs become persistent when they pick up
+// items.
+func (b *MobBehaviour) Persistent() bool {
+	return b.persistent
+}
+
+// SetPersistent sets if the mob is persistent. Persistent mobs never despawn
+// when they are far away from all players.
+func (b *MobBehaviour) SetPersistent(persistent bool) {
+	b.persistent = persistent
+}
+
+// MoveTo makes the mob walk towards the destination passed. The speed of the
+// mob is multiplied by speedMultiplier while it is walking. The mob stops
+// once it reaches its destination or when StopMoving is called.
+func (b *MobBehaviour) MoveTo(destination mgl64.Vec3, speedMultiplier float64) {
+	b.destination, b.speedMultiplier = &destination, speedMultiplier
+}
+
+// StopMoving stops the mob from walking towards the destination previously
+// set using MoveTo.
+func (b *MobBehaviour) StopMoving() {
+	b.destination = nil
+}
+
+// Moving checks if the mob is currently walking towards a destination.
+func (b *MobBehaviour) Moving() bool {
+	return b.destination != nil
+}
+
+// LookAt makes the mob look at the position passed during its next tick.
+func (b *MobBehaviour) LookAt(pos mgl64.Vec3) {
+	b.lookAt = &pos
+}
+
+// Tick ticks the mob, applying its effects and moving it towards its
+// destination.
+func (b *MobBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	m := &Mob{Ent: e}
+	if b.Dead() {
+		if b.deathTicks++; b.deathTicks >= 20 {
+			_ = e.Close()
+		}
+		return nil
+	}
+	if e.Age()%time.Second == 0 && b.despawn(m, tx) {
+		return nil
+	}
+	b.effects.Tick(m, tx)
+	if e.OnFireDuration() > 0 && e.Age()%time.Second == 0 {
+		m.Hurt(1, BurnDamageSource{})
+	}
+	if b.equipment != nil {
+		b.tickEquipment(m, tx)
+	}
+	if b.conf.Tick != nil {
+		b.conf.Tick(m, tx)
+	}
+	if b.Dead() {
+		return nil
+	}
+	inWater := b.inWater(e, tx)
+	if b.conf.Swimming {
+		b.mc.Gravity, b.mc.Drag = b.conf.Gravity, b.conf.Drag
+		if inWater {
+			// Water slows down swimming mobs much more than air does.
+			b.mc.Gravity, b.mc.Drag = 0, 0.2
+		}
+	}
+	b.walk(e, tx, b.conf.Flying || (b.conf.Swimming && inWater))
+
+	yBefore := e.data.Pos[1]
+	mov := b.mc.TickMovement(e, e.data.Pos, e.data.Vel, e.data.Rot, tx)
+	e.data.Pos, e.data.Vel = mov.pos, mov.vel
+	if inWater || b.mc.slidingDown {
+		b.fallDistance = 0
+	} else if !b.conf.Flying {
+		b.updateFallState(m, tx, yBefore-mov.pos[1])
+	}
+	if b.mc.OnGround() {
+		stepOn(m, tx)
+	}
+	return mov
+}
+
+// stepOn calls EntityStepOn on the block that the entity passed is standing
+// on if it implements block.EntityStepper.
+func stepOn(e world.Entity, tx *world.Tx) {
+	pos := cube.PosFromVec3(e.Position().Sub(mgl64.Vec3{0, 0.2}))
+	if s, ok := tx.Block(pos).(block.EntityStepper); ok {
+		s.EntityStepOn(pos, tx, e)
+	}
+}
+
+// despawn despawns the mob if it was far away from all players for long
+// enough, as configured by the world.DespawnPolicy of the World. Only hostile
+// mobs despawn this way, and mobs that are persistent or have a name tag never
+// despawn. despawn is called once every second. True is returned if the mob
+// despawned.
+func (b *MobBehaviour) despawn(m *Mob, tx *world.Tx) bool {
+	policy := tx.World().DespawnPolicy()
+	if policy.Disabled || b.persistent || m.NameTag() != "" || !hostile(m) {
+		b.farTime = 0
+		return false
+	}
+	switch closest := closestPlayerDistance(m, tx); {
+	case closest > policy.InstantDistance:
+		return despawnMob(m, tx)
+	case closest > policy.Distance:
+		if b.farTime += time.Second; b.farTime >= policy.Delay {
+			return despawnMob(m, tx)
+		}
+	default:
+		b.farTime = 0
+	}
+	return false
+}
+
+// closestPlayerDistance returns the distance from the mob passed to the
+// closest player in its world. math.MaxFloat64 is returned if there are no
+// players in the world.
+func closestPlayerDistance(m *Mob, tx *world.Tx) float64 {
+	closest := math.MaxFloat64
+	for p := range tx.Players() {
+		closest = min(closest, p.Position().Sub(m.Position()).Len())
+	}
+	return closest
+}
+
+// despawnMob despawns the mob passed, unless the world.Handler of its world
+// cancels it. True is returned if the mob despawned.
+func despawnMob(m *Mob, tx *world.Tx) bool {
+	ctx := event.C(tx)
+	if tx.World().Handler().HandleDespawn(ctx, m); ctx.Cancelled() {
+		return false
+	}
+	_ = m.Close()
+	return true
+}
+
+// tickEquipment equips the mob after it spawned, makes it pick up loot and
+// shows viewers the equipment of the mob if it changed.
+func (b *MobBehaviour) tickEquipment(m *Mob, tx *world.Tx) {
+	eq := b.equipment
+	if !eq.equipped {
+		eq.equipped = true
+		b.conf.Equip(m, eq, localDifficulty(tx, m.Position()))
+	}
+	if eq.canPickUpLoot {
+		eq.pickUpLoot(m, tx)
+	}
+	if eq.changed {
+		eq.changed = false
+		for _, v := range tx.Viewers(m.Position()) {
+			v.ViewEntityItems(m)
+			v.ViewEntityArmour(m)
+		}
+	}
+}
+
+// inWater checks if the mob is currently in water.
+func (b *MobBehaviour) inWater(e *Ent, tx *world.Tx) bool {
+	l, ok := tx.Liquid(cube.PosFromVec3(e.data.Pos))
+	_, water := l.(block.Water)
+	return ok && water
+}
+
+// walk updates the velocity and rotation of the mob so that it walks towards
+// its destination and looks at the position set using LookAt. If direct is
+// true, the mob moves straight towards its destination, as it does while
+// flying or swimming.
+func (b *MobBehaviour) walk(e *Ent, tx *world.Tx, direct bool) {
+	pos := e.data.Pos
+	if b.destination != nil && direct {
+		if delta := b.destination.Sub(pos); delta.Len() < 0.5 {
+			b.destination = nil
+		} else {
+			e.data.Vel = e.data.Vel.Add(delta.Normalize().Mul(b.speed() * b.speedMultiplier))
+			if b.lookAt == nil {
+				e.data.Rot = rotationTowards(pos, *b.destination)
+			}
+		}
+	} else if b.destination != nil {
+		delta := b.destination.Sub(pos)
+		delta[1] = 0
+		if delta.Len() < 0.5 {
+			b.destination = nil
+		} else if b.mc.OnGround() {
+			dir := delta.Normalize()
+			e.data.Vel = e.data.Vel.Add(dir.Mul(b.speed() * b.speedMultiplier))
+
+			// Jump up if the mob is walking into a block that it can step on.
+			front := cube.PosFromVec3(pos.Add(dir.Mul(0.8)))
+			above := front.Side(cube.FaceUp)
+			if len(tx.Block(front).Model().BBox(front, tx)) > 0 && len(tx.Block(above).Model().BBox(above, tx)) == 0 {
+				e.data.Vel[1] = 0.42
+			}
+			if b.lookAt == nil {
+				e.data.Rot = rotationTowards(pos, pos.Add(dir))
+			}
+		}
+	}
+	if b.lookAt != nil {
+		e.data.Rot = rotationTowards(EyePosition(e), *b.lookAt)
+		b.lookAt = nil
+	}
+}
+
+// rotationTowards returns the rotation needed to look from one position to
+// another.
+func rotationTowards(from, to mgl64.Vec3) cube.Rotation {
+	delta := to.Sub(from)
+	yaw := mgl64.RadToDeg(math.Atan2(delta[2], delta[0])) - 90
+	pitch := -mgl64.RadToDeg(math.Atan2(delta[1], math.Hypot(delta[0], delta[2])))
+	return cube.Rotation{yaw, pitch}
+}
+
+// updateFallState updates the fall distance of the mob and hurts it when it
+// lands on the ground after falling.
+func (b *MobBehaviour) updateFallState(m *Mob, tx *world.Tx, distanceThisTick float64) {
+	if !b.mc.OnGround() {
+		if distanceThisTick > 0 {
+			b.fallDistance += distanceThisTick
+		} else {
+			b.fallDistance = 0
+		}
+		return
+	}
+	if distance := b.fallDistance; distance > 0 {
+		b.fallDistance = 0
+
+		pos := cube.PosFromVec3(m.Position())
+		if bl := tx.Block(pos); len(bl.Model().BBox(pos, tx)) == 0 {
+			pos = pos.Side(cube.FaceDown)
+		}
+		if h, ok := tx.Block(pos).(block.EntityLander); ok {
+			h.EntityLand(pos, tx, m, &distance)
+		}
+		dmg := distance - 3
+		if boost, ok := m.Effect(effect.JumpBoost); ok {
+			dmg -= float64(boost.Level())
+		}
+		if dmg >= 0.5 {
+			tx.PlaySound(m.Position(), sound.Fall{Distance: distance})
+			m.Hurt(math.Ceil(dmg), FallDamageSource{})
+		}
+	}
+}
+
+// kill makes the mob display its death animation and drops its loot.
+func (b *MobBehaviour) kill(m *Mob, src world.DamageSource) {
+	for _, v := range m.tx.Viewers(m.Position()) {
+		v.ViewEntityAction(m, DeathAction{})
+	}
+	b.destination = nil
+	axolotlAssist(m, src)
+	m.Dismount()
+	for _, passenger := range m.Passengers() {
+		m.tx.Dismount(passenger)
+	}
+
+	pos := m.Position()
+	var drops []item.Stack
+	if b.conf.Drops != nil {
+		drops = b.conf.Drops(m, src)
+	}
+	if b.equipment != nil {
+		drops = append(drops, b.equipment.drops(src)...)
+	}
+	for _, it := range drops {
+		opts := world.EntitySpawnOpts{Position: pos, Velocity: mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1}}
+		m.tx.AddEntity(NewItem(opts, it))
+	}
+	if b.conf.Experience > 0 {
+		switch src.(type) {
+		case AttackDamageSource, ProjectileDamageSource:
+			for _, orb := range NewExperienceOrbs(pos, b.conf.Experience) {
+				m.tx.AddEntity(orb)
+			}
+		}
+	}
+}
+
+// encodeNBT encodes the health and attributes of the mob into the map passed.
+func (b *MobBehaviour) encodeNBT(m map[string]any) {
+	m["Health"] = float32(b.health.Health())
+	m["MaxHealth"] = float32(b.health.MaxHealth())
+	m["Attributes"] = b.attributes.encodeNBT()
+	m["Persistent"] = boolByte(b.persistent)
+	if b.equipment != nil {
+		b.equipment.encodeNBT(m)
+	}
+}
+
+// decodeNBT decodes the health and attributes of the mob from the map
+// passed. Mobs saved before attributes were stored only have their maximum
+// health stored.
+func (b *MobBehaviour) decodeNBT(m map[string]any) {
+	if attributes := nbtconv.Slice(m, "Attributes"); attributes != nil {
+		b.attributes.decodeNBT(attributes)
+	} else if _, ok := m["MaxHealth"]; ok {
+		b.attributes.SetBase(attribute.MaxHealth(), float64(nbtconv.Float32(m, "MaxHealth")))
+	}
+	b.updateAttributes()
+	if _, ok := m["Health"]; ok {
+		b.health.AddHealth(float64(nbtconv.Float32(m, "Health")) - b.health.Health())
+	}
+	if b.equipment != nil {
+		b.equipment.decodeNBT(m)
+	}
+	b.persistent = b.persistent || nbtconv.Bool(m, "Persistent")
+}
+
+// mobConfig is a world.EntityConfig that applies a Behaviour that was already
+// created. It is used when one mob converts into another.
+type mobConfig struct {
+	b Behaviour
+}
+
+func (conf mobConfig) Apply(data *world.EntityData) {
+	data.Data = conf.b
+}
+
+// transform replaces the mob passed with a new mob of the entity type passed,
+// which uses the behaviour passed. The new mob keeps the position, rotation,
+// name tag and persistence of the mob that it replaces.
+func transform(m *Mob, t world.EntityType, b Behaviour, tx *world.Tx) *Mob {
+	opts := world.EntitySpawnOpts{Position: m.Position(), Rotation: m.Rotation(), NameTag: m.NameTag()}
+	if m.behaviour().persistent {
+		b.(mobBehaviour).living().persistent = true
+	}
+	nm := tx.AddEntity(opts.New(t, mobConfig{b: b})).(*Mob)
+	_ = m.Close()
+	return nm
+}
+
+// hostile checks if the entity passed is a hostile mob, which golems attack.
+func hostile(e world.Entity) bool {
+	t := e.H().Type()
+	return zombie(e) || skeleton(e) || t == PillagerType || t == VindicatorType || t == EndermanType || t == SlimeType || t == MagmaCubeType || t == PhantomType || t == GhastType
+}
+
+// nearestEntity returns the living entity closest to the mob within the
+// radius passed for which f returns true. False is returned if no such entity
+// was found.
+func nearestEntity(m *Mob, tx *world.Tx, radius float64, f func(e Living) bool) (Living, bool) {
+	e, ok := tx.NearestEntity(m.Position(), radius, func(e world.Entity) bool {
+		l, ok := e.(Living)
+		return ok && e.H() != m.H() && !l.Dead() && !playingDead(e) && f(l)
+	})
+	if !ok {
+		return nil, false
+	}
+	return e.(Living), true
+}
+
+// playingDead checks if the entity passed is a mob that is playing dead, such
+// as an axolotl. Mobs never target entities that are playing dead.
+func playingDead(e world.Entity) bool {
+	if m, ok := e.(*Mob); ok {
+		p, ok := m.Behaviour().(interface{ PlayingDead() bool })
+		return ok && p.PlayingDead()
+	}
+	return false
+}
+
+// consumeHeldItem subtracts one from the count of the item held in the main
+// hand of the user passed, unless the user is in a game mode with a creative
+// inventory.
+func consumeHeldItem(user item.User) {
+	if g, ok := user.(interface{ GameMode() world.GameMode }); ok && g.GameMode().CreativeInventory() {
+		return
+	}
+	held, left := user.HeldItems()
+	user.SetHeldItems(held.Grow(-1), left)
+}
+
+// damageHeldItem damages the item held in the main hand of the user passed by
+// one, unless the user is in a game mode with a creative inventory.
+func damageHeldItem(user item.User) {
+	if g, ok := user.(interface{ GameMode() world.GameMode }); ok && g.GameMode().CreativeInventory() {
+		return
+	}
+	held, left := user.HeldItems()
+	user.SetHeldItems(held.Damage(1), left)
+}
+
+// attackablePlayer checks if the entity passed is a player that hostile mobs
+// may attack, which is the case for players in a game mode that allows them
+// to take damage.
+func attackablePlayer(e Living) bool {
+	g, ok := e.(interface{ GameMode() world.GameMode })
+	return ok && g.GameMode().AllowsTakingDamage()
+}
+
+// lineOfSight checks if there are no blocks with a collision box between the
+// two positions passed.
+func lineOfSight(from, to mgl64.Vec3, tx *world.Tx) bool {
+	clear := true
+	trace.TraverseBlocks(from, to, func(pos cube.Pos) bool {
+		if _, ok := trace.BlockIntercept(pos, tx, tx.Block(pos), from, to); ok {
+			clear = false
+		}
+		return clear
+	})
+	return clear
+}
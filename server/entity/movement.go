@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
@@ -12,8 +13,15 @@ import (
 type MovementComputer struct {
 	Gravity, Drag     float64
 	DragBeforeGravity bool
+	// WaterFriction is the friction applied to the horizontal velocity of the
+	// entity while it is in water, instead of the friction resulting from
+	// Drag. If 0, the entity is not slowed down by water.
+	WaterFriction float64
 
 	onGround bool
+	// slidingDown is true if the entity slid down the side of a honey block
+	// during the last movement tick.
+	slidingDown bool
 }
 
 // Movement represents the movement of a world.Entity as a result of a call to MovementComputer.TickMovement. The
@@ -67,7 +75,13 @@ func (c *MovementComputer) TickMovement(e world.Entity, pos, vel mgl64.Vec3, rot
 
 	velBefore := vel
 	vel = c.applyHorizontalForces(tx, pos, c.applyVerticalForces(vel))
+	vel = c.applyBubbleColumnForces(tx, pos, vel)
+	vel = c.applyCobwebForces(tx, e, pos, vel)
+	vel = c.applyHoneyForces(tx, e, pos, vel)
+	vel = c.applySurfaceForces(tx, e, pos, vel)
+	fallVel := vel
 	dPos, vel := c.checkCollision(tx, e, pos, vel)
+	vel = c.applySlimeBounce(tx, e, pos.Add(dPos), fallVel, vel)
 
 	return &Movement{v: viewers, e: e,
 		pos: pos.Add(dPos), vel: vel, dpos: dPos, dvel: vel.Sub(velBefore),
@@ -98,10 +112,101 @@ func (c *MovementComputer) applyVerticalForces(vel mgl64.Vec3) mgl64.Vec3 {
 	return vel
 }
 
+// applyBubbleColumnForces pushes the entity up or drags it down if it is
+// inside a bubble column.
+func (c *MovementComputer) applyBubbleColumnForces(tx *world.Tx, pos, vel mgl64.Vec3) mgl64.Vec3 {
+	bPos := cube.PosFromVec3(pos)
+	col, ok := tx.Block(bPos).(block.BubbleColumn)
+	if !ok {
+		return vel
+	}
+	_, surface := tx.Block(bPos.Side(cube.FaceUp)).(block.Air)
+	return col.EntityVelocity(vel, surface)
+}
+
+// applyCobwebForces slows down the entity if any part of it is inside a
+// cobweb.
+func (c *MovementComputer) applyCobwebForces(tx *world.Tx, e world.Entity, pos, vel mgl64.Vec3) mgl64.Vec3 {
+	if web, _, ok := blockInside[block.Cobweb](tx, e.H().Type().BBox(e).Translate(pos)); ok {
+		return web.EntityVelocity(vel)
+	}
+	return vel
+}
+
+// applyHoneyForces slows down the fall of the entity if it is sliding down
+// the side of a honey block.
+func (c *MovementComputer) applyHoneyForces(tx *world.Tx, e world.Entity, pos, vel mgl64.Vec3) mgl64.Vec3 {
+	box := e.H().Type().BBox(e).Translate(pos)
+	h, hPos, ok := blockInside[block.Honey](tx, box)
+	if c.slidingDown = ok && h.SlidingDown(hPos, box, vel, c.onGround); c.slidingDown {
+		return h.SlideVelocity(vel)
+	}
+	return vel
+}
+
+// applySurfaceForces slows down the entity if it is on the ground on a slime
+// or honey block.
+func (c *MovementComputer) applySurfaceForces(tx *world.Tx, e world.Entity, pos, vel mgl64.Vec3) mgl64.Vec3 {
+	if !c.onGround {
+		return vel
+	}
+	switch b := tx.Block(blockUnder(pos)).(type) {
+	case block.Slime:
+		return b.EntityVelocity(e, vel)
+	case block.Honey:
+		return b.EntityVelocity(vel)
+	}
+	return vel
+}
+
+// applySlimeBounce makes the entity bounce back up if it landed on a slime
+// block. fallVel is the velocity of the entity before it collided with the
+// slime block. Entities only bounce if they were falling faster than gravity
+// alone accelerates them in a single tick, so that entities resting on a
+// slime block do not bounce continuously.
+func (c *MovementComputer) applySlimeBounce(tx *world.Tx, e world.Entity, pos, fallVel, vel mgl64.Vec3) mgl64.Vec3 {
+	if !c.onGround || -fallVel[1] <= c.Gravity {
+		return vel
+	}
+	if slime, ok := tx.Block(blockUnder(pos)).(block.Slime); ok {
+		return slime.BounceVelocity(e, mgl64.Vec3{vel[0], fallVel[1], vel[2]})
+	}
+	return vel
+}
+
+// blockUnder returns the position of the block that an entity at the
+// position passed is standing on. The block is found slightly below the
+// entity, so that blocks lower than a full block, such as honey blocks, are
+// found as well.
+func blockUnder(pos mgl64.Vec3) cube.Pos {
+	return cube.PosFromVec3(pos.Sub(mgl64.Vec3{0, 0.2}))
+}
+
+// blockInside returns the first block of type T that any part of the
+// cube.BBox passed is inside of, along with its position. False is returned
+// if the box is not inside a block of type T.
+func blockInside[T world.Block](tx *world.Tx, box cube.BBox) (T, cube.Pos, bool) {
+	min, max := box.Min(), box.Max()
+	for y := int(math.Floor(min[1])); y <= int(math.Floor(max[1])); y++ {
+		for x := int(math.Floor(min[0])); x <= int(math.Floor(max[0])); x++ {
+			for z := int(math.Floor(min[2])); z <= int(math.Floor(max[2])); z++ {
+				pos := cube.Pos{x, y, z}
+				if b, ok := tx.Block(pos).(T); ok {
+					return b, pos, true
+				}
+			}
+		}
+	}
+	var zero T
+	return zero, cube.Pos{}, false
+}
+
 // applyHorizontalForces applies friction to the velocity based on the Drag value, reducing it on the X and Z axes.
 func (c *MovementComputer) applyHorizontalForces(tx *world.Tx, pos, vel mgl64.Vec3) mgl64.Vec3 {
 	friction := 1 - c.Drag
-	if c.onGround {
+	if l, ok := tx.Liquid(cube.PosFromVec3(pos)); ok && l.LiquidType() == "water" && c.WaterFriction != 0 {
+		friction = c.WaterFriction
+	} else if c.onGround {
 		if f, ok := tx.Block(cube.PosFromVec3(pos).Side(cube.FaceDown)).(interface {
 			Friction() float64
 		}); ok {
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestCobwebSlowsMovement(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		// Both items are thrown sideways, but only one of them through a
+		// column of cobwebs.
+		web, free := cube.Pos{8, 70, 8}, cube.Pos{24, 70, 8}
+		for y := 60; y <= 70; y++ {
+			tx.SetBlock(cube.Pos{web[0], y, web[2]}, block.Cobweb{}, nil)
+		}
+		vel := mgl64.Vec3{0.1, 0, 0}
+		slowed := tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: web.Vec3Middle(), Velocity: vel}, item.NewStack(block.Stone{}, 1))).(*Ent)
+		unslowed := tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: free.Vec3Middle(), Velocity: vel}, item.NewStack(block.Stone{}, 1))).(*Ent)
+		for i := range 5 {
+			slowed.Tick(tx, int64(i))
+			unslowed.Tick(tx, int64(i))
+		}
+
+		webMoved, freeMoved := slowed.Position().Sub(web.Vec3Middle()), unslowed.Position().Sub(free.Vec3Middle())
+		if webMoved[0] >= freeMoved[0]/2 {
+			t.Errorf("expected cobweb to slow down horizontal movement, moved %v in a cobweb and %v outside", webMoved[0], freeMoved[0])
+		}
+		if webMoved[1] <= freeMoved[1]/2 {
+			t.Errorf("expected cobweb to slow down falling, fell %v in a cobweb and %v outside", -webMoved[1], -freeMoved[1])
+		}
+	})
+}
+
+func TestSlimeBounce(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		// Both items fall 10 blocks, but only one of them lands on a slime
+		// block.
+		slime, stone := cube.Pos{8, 60, 8}, cube.Pos{24, 60, 8}
+		tx.SetBlock(slime, block.Slime{}, nil)
+		tx.SetBlock(stone, block.Stone{}, nil)
+		bouncing := tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: slime.Vec3Middle().Add(mgl64.Vec3{0, 10})}, item.NewStack(block.Stone{}, 1))).(*Ent)
+		landing := tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: stone.Vec3Middle().Add(mgl64.Vec3{0, 10})}, item.NewStack(block.Stone{}, 1))).(*Ent)
+
+		var bounced bool
+		for i := range 60 {
+			bouncing.Tick(tx, int64(i))
+			landing.Tick(tx, int64(i))
+			if bouncing.Velocity()[1] > 0.1 {
+				bounced = true
+			}
+			if landing.Velocity()[1] > 0 {
+				t.Errorf("expected item landing on stone not to bounce, got velocity %v", landing.Velocity())
+				return
+			}
+		}
+		if !bounced {
+			t.Errorf("expected item landing on a slime block to bounce")
+		}
+	})
+}
+
+func TestHoneySlide(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		// Both items fall, but only one of them against the side of a wall of
+		// honey blocks. The item touches the side of the honey blocks, which
+		// are slightly smaller than a full block.
+		honey, free := cube.Pos{8, 60, 8}, cube.Pos{24, 60, 8}
+		for y := 50; y <= 70; y++ {
+			tx.SetBlock(cube.Pos{honey[0], y, honey[2]}, block.Honey{}, nil)
+		}
+		offset := mgl64.Vec3{0.6, 10, 0}
+		sliding := tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: honey.Vec3Middle().Add(offset)}, item.NewStack(block.Stone{}, 1))).(*Ent)
+		falling := tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: free.Vec3Middle().Add(offset)}, item.NewStack(block.Stone{}, 1))).(*Ent)
+		for i := range 20 {
+			sliding.Tick(tx, int64(i))
+			falling.Tick(tx, int64(i))
+		}
+
+		if v := sliding.Velocity()[1]; v < -0.05-1e-6 {
+			t.Errorf("expected item sliding down a honey block to fall at most 0.05 blocks per tick, got %v", -v)
+		}
+		slid, fell := sliding.Position().Sub(honey.Vec3Middle().Add(offset)), falling.Position().Sub(free.Vec3Middle().Add(offset))
+		if slid[1] <= fell[1]/2 {
+			t.Errorf("expected honey block to slow down falling, slid %v down a honey block and fell %v freely", -slid[1], -fell[1])
+		}
+	})
+}
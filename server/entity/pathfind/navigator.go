@@ -0,0 +1,109 @@
+package pathfind
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// stuckTicks is the amount of ticks after which a Navigator gives up on a
+// path if the mob has not reached the next position of the path.
+const stuckTicks = 60
+
+// Mob is a mob that a Navigator is able to move along a Path, such as an
+// *entity.Mob.
+type Mob interface {
+	// Position returns the current position of the mob.
+	Position() mgl64.Vec3
+	// MoveTo makes the mob walk towards the destination passed in a straight
+	// line, multiplying its speed by speedMultiplier.
+	MoveTo(destination mgl64.Vec3, speedMultiplier float64)
+	// StopMoving stops the mob from walking towards its destination.
+	StopMoving()
+}
+
+// Navigator moves a mob along a Path found by a Pathfinder. It makes the mob
+// walk towards the positions of the path one by one using Mob.MoveTo.
+type Navigator struct {
+	p Pathfinder
+
+	path        Path
+	index       int
+	speed       float64
+	destination cube.Pos
+	ticks       int
+}
+
+// NewNavigator returns a Navigator that finds paths using the Pathfinder
+// passed.
+func NewNavigator(p Pathfinder) *Navigator {
+	return &Navigator{p: p.withDefaults()}
+}
+
+// MoveTo finds a path from the mob to the position passed and makes the mob
+// walk along it, multiplying its speed by speedMultiplier. If the position
+// cannot be reached, the mob walks to the position closest to it. False is
+// returned if the mob is unable to move closer to the position at all.
+func (n *Navigator) MoveTo(m Mob, tx *world.Tx, pos mgl64.Vec3, speedMultiplier float64) bool {
+	from, to := cube.PosFromVec3(m.Position()), cube.PosFromVec3(pos)
+	path, _ := n.p.FindPath(tx, from, to)
+	if len(path) == 0 {
+		n.Stop(m)
+		return false
+	}
+	n.path, n.index, n.speed, n.destination, n.ticks = path, 0, speedMultiplier, to, 0
+	return true
+}
+
+// Pathfinder returns the Pathfinder that the Navigator finds paths with.
+func (n *Navigator) Pathfinder() Pathfinder {
+	return n.p
+}
+
+// Destination returns the position that the mob is currently navigating to.
+// False is returned if the mob is not following a path.
+func (n *Navigator) Destination() (cube.Pos, bool) {
+	return n.destination, !n.Done()
+}
+
+// Done checks if the mob has reached the end of its path, or if it is not
+// following a path at all.
+func (n *Navigator) Done() bool {
+	return n.index >= len(n.path)
+}
+
+// Stop makes the mob stop following its path.
+func (n *Navigator) Stop(m Mob) {
+	if !n.Done() {
+		m.StopMoving()
+	}
+	n.path, n.index = nil, 0
+}
+
+// Tick moves the mob towards the next position of its path, skipping
+// positions that it has already reached.
+func (n *Navigator) Tick(m Mob) {
+	if n.Done() {
+		return
+	}
+	pos := m.Position()
+	for !n.Done() {
+		next := n.path[n.index].Vec3Middle()
+		if math.Hypot(next[0]-pos[0], next[2]-pos[2]) >= 0.5 || math.Abs(next[1]-pos[1]) >= 1 {
+			break
+		}
+		n.index, n.ticks = n.index+1, 0
+	}
+	if n.Done() {
+		m.StopMoving()
+		return
+	}
+	if n.ticks++; n.ticks > stuckTicks {
+		// The mob has not been able to reach the next position of its path,
+		// possibly because the world changed, so we give up.
+		n.Stop(m)
+		return
+	}
+	m.MoveTo(n.path[n.index].Vec3Middle(), n.speed)
+}
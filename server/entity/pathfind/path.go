@@ -0,0 +1,226 @@
+// Package pathfind implements finding paths that mobs are able to walk along
+// and moving mobs along them.
+package pathfind
+
+import (
+	"container/heap"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"math"
+)
+
+// Pathfinder finds paths that a mob can walk along to reach a destination.
+// Paths are found using A* over the blocks that the mob is able to stand in.
+// The mob may step up one block at a time and drop down at most MaxFall
+// blocks.
+type Pathfinder struct {
+	// Height is the amount of blocks that the mob needs to fit through a
+	// gap. If 0, a height of 2 is used.
+	Height int
+	// MaxFall is the maximum amount of blocks that the mob drops down in a
+	// single step of a path. If 0, a maximum fall of 3 is used.
+	MaxFall int
+	// MaxNodes is the maximum amount of positions visited while looking for
+	// a path. If 0, at most 512 positions are visited.
+	MaxNodes int
+	// BreakDoors specifies if the mob is able to break down wooden doors. If
+	// true, paths may lead through closed wooden doors.
+	BreakDoors bool
+}
+
+// Path is a list of positions that a mob walks through in order to reach its
+// destination. The position that the mob started at is not part of the
+// path.
+type Path []cube.Pos
+
+// FindPath looks for a path from one position to another. If the
+// destination cannot be reached, a path to the position closest to it is
+// returned along with false.
+func (p Pathfinder) FindPath(tx *world.Tx, from, to cube.Pos) (Path, bool) {
+	p = p.withDefaults()
+	start := &node{pos: from, h: distance(from, to)}
+	nodes := map[cube.Pos]*node{from: start}
+	open := &nodeHeap{start}
+	closest := start
+
+	for visited := 0; open.Len() > 0 && visited < p.MaxNodes; visited++ {
+		n := heap.Pop(open).(*node)
+		n.closed = true
+		if n.pos == to {
+			return n.path(), true
+		}
+		if n.h < closest.h {
+			closest = n
+		}
+		for _, next := range p.neighbours(tx, n.pos) {
+			g := n.g + distance(n.pos, next)
+			nn, ok := nodes[next]
+			if !ok {
+				nn = &node{pos: next, h: distance(next, to), g: math.Inf(1), index: -1}
+				nodes[next] = nn
+			}
+			if nn.closed || g >= nn.g {
+				continue
+			}
+			nn.parent, nn.g = n, g
+			if nn.index == -1 {
+				heap.Push(open, nn)
+			} else {
+				heap.Fix(open, nn.index)
+			}
+		}
+	}
+	return closest.path(), false
+}
+
+// withDefaults returns a copy of the Pathfinder with the default values set
+// for all fields that are 0.
+func (p Pathfinder) withDefaults() Pathfinder {
+	if p.Height == 0 {
+		p.Height = 2
+	}
+	if p.MaxFall == 0 {
+		p.MaxFall = 3
+	}
+	if p.MaxNodes == 0 {
+		p.MaxNodes = 512
+	}
+	return p
+}
+
+// neighbours returns the positions next to pos that a mob standing at pos is
+// able to move to.
+func (p Pathfinder) neighbours(tx *world.Tx, pos cube.Pos) []cube.Pos {
+	neighbours := make([]cube.Pos, 0, 4)
+	for _, face := range cube.HorizontalFaces() {
+		next := pos.Side(face)
+		switch {
+		case p.standable(tx, next):
+			neighbours = append(neighbours, next)
+		case p.passable(tx, next, p.Height):
+			// There is no ground to stand on, so the mob will drop down.
+			for y := 1; y <= p.MaxFall; y++ {
+				below := next.Sub(cube.Pos{0, y})
+				if p.standable(tx, below) {
+					neighbours = append(neighbours, below)
+					break
+				}
+				if !p.passable(tx, below, 1) {
+					break
+				}
+			}
+		default:
+			// The mob may be able to jump up onto the block in its way, as
+			// long as there is room above its head to do so.
+			above := next.Side(cube.FaceUp)
+			if p.standable(tx, above) && p.passable(tx, pos.Add(cube.Pos{0, p.Height}), 1) {
+				neighbours = append(neighbours, above)
+			}
+		}
+	}
+	return neighbours
+}
+
+// Standable checks if a mob is able to stand at the position passed.
+func (p Pathfinder) Standable(tx *world.Tx, pos cube.Pos) bool {
+	return p.withDefaults().standable(tx, pos)
+}
+
+// standable checks if a mob is able to stand at the position passed. The
+// default values of the Pathfinder must already be set.
+func (p Pathfinder) standable(tx *world.Tx, pos cube.Pos) bool {
+	if pos.OutOfBounds(tx.Range()) || !p.passable(tx, pos, p.Height) {
+		return false
+	}
+	below := pos.Side(cube.FaceDown)
+	boxes := tx.Block(below).Model().BBox(below, tx)
+	if len(boxes) == 0 {
+		return false
+	}
+	for _, box := range boxes {
+		if box.Max()[1] > 1 {
+			// Blocks taller than a full block, such as fences, cannot be
+			// walked on.
+			return false
+		}
+	}
+	return true
+}
+
+// passable checks if the blocks from pos up to height blocks above it have no
+// collision boxes and do not hold lava. Wooden doors are passable if the mob
+// is able to break them down.
+func (p Pathfinder) passable(tx *world.Tx, pos cube.Pos, height int) bool {
+	for y := range height {
+		at := pos.Add(cube.Pos{0, y})
+		if at.OutOfBounds(tx.Range()) {
+			return y > 0
+		}
+		b := tx.Block(at)
+		if _, door := b.(block.WoodDoor); door && p.BreakDoors {
+			continue
+		}
+		if len(b.Model().BBox(at, tx)) != 0 {
+			return false
+		}
+		if l, ok := tx.Liquid(at); ok {
+			if _, lava := l.(block.Lava); lava {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// distance returns the Euclidean distance between two positions.
+func distance(a, b cube.Pos) float64 {
+	return a.Vec3().Sub(b.Vec3()).Len()
+}
+
+// node is a position visited while looking for a path.
+type node struct {
+	pos    cube.Pos
+	parent *node
+	// g is the length of the shortest path found to the node and h the
+	// estimated distance from the node to the destination.
+	g, h float64
+	// index is the index of the node in the nodeHeap, or -1 if it is not in
+	// the heap.
+	index  int
+	closed bool
+}
+
+// path returns the Path leading up to the node, excluding the start.
+func (n *node) path() Path {
+	var path Path
+	for ; n.parent != nil; n = n.parent {
+		path = append(path, n.pos)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// nodeHeap is a heap of nodes ordered by their estimated path length.
+type nodeHeap []*node
+
+func (h nodeHeap) Len() int           { return len(h) }
+func (h nodeHeap) Less(i, j int) bool { return h[i].g+h[i].h < h[j].g+h[j].h }
+func (h nodeHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+func (h *nodeHeap) Push(x any) {
+	n := x.(*node)
+	n.index = len(*h)
+	*h = append(*h, n)
+}
+func (h *nodeHeap) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	n.index = -1
+	return n
+}
@@ -0,0 +1,263 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// NewPhantom creates a new phantom. Phantoms circle around the position they
+// are spawned at until they find a player to swoop down on.
+func NewPhantom(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(PhantomType, phantomConf)
+}
+
+var phantomConf = PhantomBehaviourConfig{}
+
+// PhantomBehaviourConfig holds optional parameters for a PhantomBehaviour.
+type PhantomBehaviourConfig struct{}
+
+func (conf PhantomBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a PhantomBehaviour using the parameters in conf.
+func (conf PhantomBehaviourConfig) New() *PhantomBehaviour {
+	p := &PhantomBehaviour{circleRadius: 8 + rand.Float64()*8, swoopCooldown: 40 + rand.IntN(60)}
+	p.MobBehaviour = MobBehaviourConfig{MaxHealth: 20, Speed: 0.05, Drag: 0.1, Flying: true, Experience: 5, Drops: p.drops}.New()
+	return p
+}
+
+const (
+	// phantomTargetRange is the horizontal distance within which phantoms
+	// look for players to attack.
+	phantomTargetRange = 64
+	// phantomMaxSwoopTicks is the maximum amount of ticks that a single
+	// swoop of a phantom lasts before it gives up and climbs back up.
+	phantomMaxSwoopTicks = 100
+)
+
+// PhantomBehaviour implements the behaviour of phantoms. Phantoms circle high
+// above the ground and periodically swoop down on players to attack them,
+// climbing back up after every hit. They burn in sunlight.
+type PhantomBehaviour struct {
+	*MobBehaviour
+
+	anchor        *mgl64.Vec3
+	circleAngle   float64
+	circleRadius  float64
+	clockwise     bool
+	swoopCooldown int
+	swoopTicks    int
+
+	target *world.EntityHandle
+}
+
+// Anchor returns the position that the phantom circles around. False is
+// returned if the phantom has not yet picked a position to circle around.
+func (p *PhantomBehaviour) Anchor() (mgl64.Vec3, bool) {
+	if p.anchor == nil {
+		return mgl64.Vec3{}, false
+	}
+	return *p.anchor, true
+}
+
+// Swooping checks if the phantom is currently swooping down on its target.
+func (p *PhantomBehaviour) Swooping() bool {
+	return p.swoopTicks > 0
+}
+
+// Target returns the player that the phantom is currently attacking, or nil
+// if it is not attacking any player.
+func (p *PhantomBehaviour) Target() *world.EntityHandle {
+	return p.target
+}
+
+// Hurt makes the phantom climb back up if it was swooping down when it was
+// hurt.
+func (p *PhantomBehaviour) Hurt(*Mob, float64, world.DamageSource) {
+	if p.swoopTicks > 0 {
+		p.endSwoop()
+	}
+}
+
+// Tick ticks the phantom, making it circle and swoop down on players and burn
+// in sunlight.
+func (p *PhantomBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !p.Dead() {
+		p.tickPhantom(&Mob{Ent: e}, tx)
+	}
+	return p.MobBehaviour.Tick(e, tx)
+}
+
+// tickPhantom performs the phantom specific logic of a tick.
+func (p *PhantomBehaviour) tickPhantom(m *Mob, tx *world.Tx) {
+	if inSunlight(m, tx) && m.OnFireDuration() <= 0 {
+		m.SetOnFire(time.Second * 8)
+	}
+	if p.anchor == nil {
+		anchor := m.Position()
+		p.anchor = &anchor
+	}
+	target := p.findTarget(m, tx)
+	if target != nil && p.swoopTicks > 0 {
+		p.swoop(m, target)
+		return
+	}
+	if target != nil {
+		if p.swoopCooldown--; p.swoopCooldown <= 0 {
+			p.swoopTicks = 1
+			return
+		}
+		if m.Age()%(time.Second*5) == 0 {
+			// Phantoms keep circling high above their target while waiting
+			// for their next swoop.
+			anchor := target.Position().Add(mgl64.Vec3{0, 20 + float64(rand.IntN(10))})
+			p.anchor = &anchor
+		}
+	}
+	p.circle(m)
+}
+
+// findTarget returns the player that the phantom is attacking, looking for a
+// new player if the phantom is not attacking any player. Nil is returned if no
+// player was found.
+func (p *PhantomBehaviour) findTarget(m *Mob, tx *world.Tx) world.Entity {
+	if p.target != nil {
+		e, ok := p.target.Entity(tx)
+		if l, living := e.(Living); ok && living && attackablePlayer(l) && phantomHorizontalDist(e.Position(), m.Position()) <= phantomTargetRange {
+			return e
+		}
+		p.target, p.swoopTicks = nil, 0
+	}
+	if m.Age()%time.Second != 0 {
+		return nil
+	}
+	if t, ok := nearestEntity(m, tx, phantomTargetRange, func(e Living) bool {
+		return attackablePlayer(e) && lineOfSight(EyePosition(m), EyePosition(e), tx)
+	}); ok {
+		p.target = t.H()
+		return t
+	}
+	return nil
+}
+
+// circle makes the phantom fly in a circle around its anchor. The direction
+// and radius of the circle occasionally change.
+func (p *PhantomBehaviour) circle(m *Mob) {
+	if rand.IntN(350) == 0 {
+		p.circleRadius = 8 + rand.Float64()*8
+	}
+	if rand.IntN(250) == 0 {
+		p.clockwise = !p.clockwise
+	}
+	step := 2 / p.circleRadius * (math.Pi / 20)
+	if p.clockwise {
+		step = -step
+	}
+	p.circleAngle += step
+	dest := p.anchor.Add(mgl64.Vec3{math.Cos(p.circleAngle) * p.circleRadius, 0, math.Sin(p.circleAngle) * p.circleRadius})
+	if delta := dest.Sub(m.Position()); delta.Len() > 0 {
+		if front := cube.PosFromVec3(m.Position().Add(delta.Normalize())); len(m.tx.Block(front).Model().BBox(front, m.tx)) > 0 {
+			// The phantom is about to fly into a block, so climb over it.
+			anchor := p.anchor.Add(mgl64.Vec3{0, 2})
+			p.anchor, dest[1] = &anchor, dest[1]+2
+		}
+	}
+	p.MoveTo(dest, 1)
+}
+
+// swoop makes the phantom dive towards its target, hurting it once it reaches
+// it. The phantom climbs back up after hitting its target or if the swoop
+// took too long.
+func (p *PhantomBehaviour) swoop(m *Mob, target world.Entity) {
+	centre := target.Position().Add(mgl64.Vec3{0, target.H().Type().BBox(target).Height() / 2})
+	p.LookAt(centre)
+	p.MoveTo(centre, 1.8)
+
+	if p.swoopTicks++; p.swoopTicks > phantomMaxSwoopTicks {
+		p.endSwoop()
+		return
+	}
+	if !PhantomType.BBox(m).Translate(m.Position()).Grow(0.2).IntersectsWith(target.H().Type().BBox(target).Translate(target.Position())) {
+		return
+	}
+	dmg := 6.0
+	switch m.tx.World().Difficulty() {
+	case world.DifficultyEasy:
+		dmg = 4
+	case world.DifficultyHard:
+		dmg = 9
+	}
+	target.(Living).Hurt(dmg, AttackDamageSource{Attacker: m})
+	p.endSwoop()
+}
+
+// endSwoop makes the phantom stop swooping and climb back up to circle above
+// its target before swooping again.
+func (p *PhantomBehaviour) endSwoop() {
+	p.swoopTicks, p.swoopCooldown = 0, 40+rand.IntN(60)
+	if p.anchor != nil {
+		anchor := p.anchor.Add(mgl64.Vec3{0, 4})
+		p.anchor = &anchor
+	}
+	p.StopMoving()
+}
+
+// drops returns the items dropped by a phantom when it dies.
+func (p *PhantomBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	if n := rand.IntN(2); n > 0 {
+		return []item.Stack{item.NewStack(item.PhantomMembrane{}, n)}
+	}
+	return nil
+}
+
+// decodeNBT decodes the state of the phantom from the map passed.
+func (p *PhantomBehaviour) decodeNBT(m map[string]any) {
+	p.MobBehaviour.decodeNBT(m)
+	if _, ok := m["AnchorPos"]; ok {
+		anchor := nbtconv.Pos(m, "AnchorPos").Vec3Centre()
+		p.anchor = &anchor
+	}
+}
+
+// phantomHorizontalDist returns the horizontal distance between two
+// positions.
+func phantomHorizontalDist(a, b mgl64.Vec3) float64 {
+	return math.Hypot(a[0]-b[0], a[2]-b[2])
+}
+
+// PhantomType is a world.EntityType implementation for phantoms.
+var PhantomType phantomType
+
+type phantomType struct{}
+
+func (phantomType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (phantomType) EncodeEntity() string { return "minecraft:phantom" }
+func (phantomType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.45, 0, -0.45, 0.45, 0.5, 0.45)
+}
+
+func (phantomType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	p := phantomConf.New()
+	p.decodeNBT(m)
+	data.Data = p
+}
+
+func (phantomType) EncodeNBT(data *world.EntityData) map[string]any {
+	p := data.Data.(*PhantomBehaviour)
+	m := map[string]any{}
+	if p.anchor != nil {
+		m["AnchorPos"] = nbtconv.PosToInt32Slice(cube.PosFromVec3(*p.anchor))
+	}
+	p.MobBehaviour.encodeNBT(m)
+	return m
+}
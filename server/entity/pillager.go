@@ -0,0 +1,203 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+)
+
+// NewPillager creates a new pillager holding a crossbow.
+func NewPillager(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(PillagerType, pillagerConf)
+}
+
+// NewPatrolCaptain creates a new pillager that leads a patrol walking
+// towards the target passed. The captain wears an illager banner and gives
+// Bad Omen to the player that kills it.
+func NewPatrolCaptain(opts world.EntitySpawnOpts, target mgl64.Vec3) *world.EntityHandle {
+	conf := pillagerConf
+	conf.Captain, conf.PatrolTarget = true, &target
+	return opts.New(PillagerType, conf)
+}
+
+var pillagerConf = PillagerBehaviourConfig{}
+
+// PillagerBehaviourConfig holds optional parameters for a
+// PillagerBehaviour.
+type PillagerBehaviourConfig struct {
+	// Captain specifies if the pillager is the captain of its patrol.
+	Captain bool
+	// PatrolTarget is the position that the patrol of the pillager walks
+	// towards. If nil, the pillager is not part of a patrol.
+	PatrolTarget *mgl64.Vec3
+}
+
+func (conf PillagerBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a PillagerBehaviour using the parameters in conf.
+func (conf PillagerBehaviourConfig) New() *PillagerBehaviour {
+	p := &PillagerBehaviour{}
+	p.captain, p.patrolTarget = conf.Captain, conf.PatrolTarget
+	p.MobBehaviour = MobBehaviourConfig{MaxHealth: 24, Speed: 0.12, Experience: 5, Drops: p.drops, Equip: p.equip}.New()
+	p.Equipment().SetHeldItems(item.NewStack(item.Crossbow{}, 1), item.Stack{})
+	return p
+}
+
+const (
+	// pillagerAttackDist is the distance to its target within which a
+	// pillager stops approaching it and starts shooting.
+	pillagerAttackDist = 8
+	// pillagerChargeTicks is the amount of ticks that it takes for a
+	// pillager to charge its crossbow.
+	pillagerChargeTicks = 25
+)
+
+// PillagerBehaviour implements the behaviour of pillagers. Pillagers are
+// illagers that shoot at players, villagers and iron golems with their
+// crossbows. They roam the world in patrols and take part in raids.
+type PillagerBehaviour struct {
+	*MobBehaviour
+	raider
+
+	chargeTicks int
+	cooldown    int
+}
+
+// HeldItems returns the items held by the pillager. By default, pillagers
+// hold a crossbow in their main hand.
+func (p *PillagerBehaviour) HeldItems() (mainHand, offHand item.Stack) {
+	return p.Equipment().HeldItems()
+}
+
+// Armour returns the armour worn by the pillager. The captain of a patrol
+// wears an illager banner on its head.
+func (p *PillagerBehaviour) Armour() *inventory.Armour {
+	return p.Equipment().Armour()
+}
+
+// UsingItem checks if the pillager is currently charging its crossbow.
+func (p *PillagerBehaviour) UsingItem() bool {
+	return p.chargeTicks > 0
+}
+
+// Hurt makes the pillager attack the entity that attacked it.
+func (p *PillagerBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	p.hurt(m, src)
+}
+
+// Tick ticks the pillager, making it shoot at its target.
+func (p *PillagerBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !p.Dead() {
+		p.tickPillager(&Mob{Ent: e}, tx)
+	}
+	return p.MobBehaviour.Tick(e, tx)
+}
+
+// tickPillager performs the pillager specific logic of a tick.
+func (p *PillagerBehaviour) tickPillager(m *Mob, tx *world.Tx) {
+	if p.cooldown > 0 {
+		p.cooldown--
+	}
+	target := p.findTarget(m, tx)
+	if target == nil {
+		p.stopCharging(m)
+		p.wander(m, p.MobBehaviour, tx)
+		return
+	}
+	p.LookAt(EyePosition(target))
+	if target.Position().Sub(m.Position()).Len() > pillagerAttackDist {
+		p.MoveTo(target.Position(), 1)
+	} else {
+		p.StopMoving()
+	}
+	held, _ := p.HeldItems()
+	if _, ok := held.Item().(item.Crossbow); !ok || p.cooldown > 0 || !lineOfSight(EyePosition(m), EyePosition(target), tx) {
+		p.stopCharging(m)
+		return
+	}
+	if p.chargeTicks++; p.chargeTicks == 1 {
+		m.updateState()
+	} else if p.chargeTicks >= pillagerChargeTicks {
+		p.stopCharging(m)
+		p.shoot(m, target, tx)
+		p.cooldown = 40
+	}
+}
+
+// stopCharging makes the pillager stop charging its crossbow.
+func (p *PillagerBehaviour) stopCharging(m *Mob) {
+	if p.chargeTicks > 0 {
+		p.chargeTicks = 0
+		m.updateState()
+	}
+}
+
+// shoot makes the pillager shoot an arrow from its crossbow at the target
+// passed.
+func (p *PillagerBehaviour) shoot(m *Mob, target world.Entity, tx *world.Tx) {
+	from := EyePosition(m)
+	delta := target.Position().Add(mgl64.Vec3{0, target.H().Type().BBox(target).Height() / 3}).Sub(from)
+	delta[1] += math.Hypot(delta[0], delta[2]) * 0.1
+	if delta.Len() == 0 {
+		return
+	}
+	difficulty, _ := world.DifficultyID(tx.World().Difficulty())
+	inaccuracy := float64(14-difficulty*4) * 0.005
+	dir := delta.Normalize().Add(mgl64.Vec3{rand.NormFloat64() * inaccuracy, rand.NormFloat64() * inaccuracy, rand.NormFloat64() * inaccuracy})
+
+	opts := world.EntitySpawnOpts{Position: from, Velocity: dir.Normalize().Mul(1.6), Rotation: m.Rotation()}
+	tx.AddEntity(NewArrow(opts, m))
+	tx.PlaySound(from, sound.CrossbowShoot{})
+	tx.EmitGameEvent(from, gameevent.ProjectileShoot{}, m)
+}
+
+// drops returns the items dropped by a pillager when it dies.
+func (p *PillagerBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	if n := rand.IntN(3); n > 0 {
+		return []item.Stack{item.NewStack(item.Arrow{}, n)}
+	}
+	return nil
+}
+
+// equip gives the pillager an illager banner when it spawns if it is the
+// captain of a patrol.
+func (p *PillagerBehaviour) equip(_ *Mob, eq *Equipment, _ float64) {
+	p.equipBanner(eq)
+}
+
+// PillagerType is a world.EntityType implementation for pillagers.
+var PillagerType pillagerType
+
+type pillagerType struct{}
+
+func (pillagerType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (pillagerType) EncodeEntity() string { return "minecraft:pillager" }
+func (pillagerType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.9, 0.3)
+}
+
+func (pillagerType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	p := pillagerConf.New()
+	p.MobBehaviour.decodeNBT(m)
+	p.raider.decodeNBT(m)
+	data.Data = p
+}
+
+func (pillagerType) EncodeNBT(data *world.EntityData) map[string]any {
+	p := data.Data.(*PillagerBehaviour)
+	m := map[string]any{}
+	p.MobBehaviour.encodeNBT(m)
+	p.raider.encodeNBT(m)
+	return m
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/block/cube/trace"
 	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/potion"
 	"github.com/df-mc/dragonfly/server/world"
@@ -12,6 +13,7 @@ import (
 	"iter"
 	"math"
 	"math/rand/v2"
+	"slices"
 	"time"
 )
 
@@ -77,6 +79,9 @@ type ProjectileBehaviourConfig struct {
 	// PickupItem is the item that is given to a player when it picks up this
 	// projectile. If left as an empty item.Stack, no item is given upon pickup.
 	PickupItem item.Stack
+	// IgnoreOwner specifies if the projectile should never hit its owner. By
+	// default, a projectile only ignores its owner shortly after being shot.
+	IgnoreOwner bool
 	// CollisionPosition specifies the position that the projectile is stuck
 	// in. If non-empty, the entity will not move.
 	CollisionPosition cube.Pos
@@ -109,6 +114,10 @@ type ProjectileBehaviour struct {
 
 	collisionPos cube.Pos
 	collided     bool
+
+	// passed holds the entities that the projectile flew past, because their
+	// hit was cancelled or the projectile was deflected by them.
+	passed []*world.EntityHandle
 }
 
 // Owner returns the owner of the projectile.
@@ -116,6 +125,13 @@ func (lt *ProjectileBehaviour) Owner() *world.EntityHandle {
 	return lt.conf.Owner
 }
 
+// SetOwner changes the owner of the projectile. This happens when a projectile
+// is deflected, after which the entity that deflected it is held responsible
+// for any damage it deals.
+func (lt *ProjectileBehaviour) SetOwner(owner *world.EntityHandle) {
+	lt.conf.Owner = owner
+}
+
 // Explode adds velocity to a projectile to blast it away from the explosion's
 // source.
 func (lt *ProjectileBehaviour) Explode(e *Ent, src mgl64.Vec3, impact float64, _ block.ExplosionConfig) {
@@ -158,6 +174,18 @@ func (lt *ProjectileBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
 	if result == nil {
 		return m
 	}
+	var hit *ProjectileHit
+	if r, ok := result.(trace.EntityResult); ok {
+		hit = &ProjectileHit{Result: r, Velocity: vel, Damage: lt.damage(r.Entity(), vel)}
+		if h, ok := r.Entity().(ProjectileHitHandler); ok {
+			ctx := event.C(e)
+			h.HandleProjectileHit(ctx, hit)
+			if ctx.Cancelled() || hit.deflected {
+				lt.missEntity(e, r.Entity(), hit, vel, m)
+				return m
+			}
+		}
+	}
 
 	for i := 0; i < lt.conf.ParticleCount; i++ {
 		tx.AddParticle(result.Position(), lt.conf.Particle)
@@ -168,13 +196,18 @@ func (lt *ProjectileBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
 
 	switch r := result.(type) {
 	case trace.EntityResult:
-		if l, ok := r.Entity().(Living); ok && lt.conf.Damage >= 0 {
-			lt.hitEntity(l, e, vel)
+		if l, ok := r.Entity().(Living); ok && hit.Damage >= 0 {
+			lt.hitEntity(l, e, vel, hit.Damage)
+		} else if h, ok := r.Entity().(hurtable); ok && hit.Damage >= 0 {
+			// Entities such as end crystals and fireballs are not living, but
+			// still react to being hit by a projectile.
+			owner, _ := lt.conf.Owner.Entity(tx)
+			h.Hurt(hit.Damage, ProjectileDamageSource{Projectile: e, Owner: owner})
 		}
 	case trace.BlockResult:
 		bpos := r.BlockPosition()
 		if h, ok := tx.Block(bpos).(block.ProjectileHitter); ok {
-			h.ProjectileHit(bpos, tx, e, r.Face())
+			h.ProjectileHit(bpos, tx, e, r.Face(), r.Position())
 		}
 		if lt.conf.SurviveBlockCollision {
 			lt.hitBlockSurviving(e, r, m, tx)
@@ -254,16 +287,41 @@ func (lt *ProjectileBehaviour) hitBlockSurviving(e *Ent, r trace.BlockResult, m
 	}
 }
 
+// damage returns the damage that a projectile moving with the velocity passed
+// deals to the entity passed. A negative number is returned if the projectile
+// does not hurt entities at all.
+func (lt *ProjectileBehaviour) damage(target world.Entity, vel mgl64.Vec3) float64 {
+	if lt.conf.Damage < 0 {
+		return -1
+	}
+	dmg := math.Ceil(lt.conf.Damage * vel.Len())
+	if _, ok := target.(Living); ok && lt.conf.Critical {
+		dmg += rand.Float64() * dmg / 2
+	}
+	return dmg
+}
+
+// missEntity is called when the hit of a projectile on an entity was cancelled
+// or deflected by a ProjectileHitHandler. The projectile keeps flying, either
+// with its old velocity or with the velocity it was deflected with, and will
+// not hit the entity again.
+func (lt *ProjectileBehaviour) missEntity(e *Ent, target world.Entity, hit *ProjectileHit, vel mgl64.Vec3, m *Movement) {
+	lt.passed = append(lt.passed, target.H())
+	if hit.deflected {
+		vel = hit.vel
+		if hit.owner != nil {
+			lt.SetOwner(hit.owner)
+		}
+	}
+	e.data.Vel, m.vel = vel, vel
+}
+
 // hitEntity is called when a projectile hits a Living. It deals damage to the
 // entity and knocks it back. Additionally, it applies any potion effects and
 // fire if applicable.
-func (lt *ProjectileBehaviour) hitEntity(l Living, e *Ent, vel mgl64.Vec3) {
+func (lt *ProjectileBehaviour) hitEntity(l Living, e *Ent, vel mgl64.Vec3, dmg float64) {
 	owner, _ := lt.conf.Owner.Entity(e.tx)
 	src := ProjectileDamageSource{Projectile: e, Owner: owner}
-	dmg := math.Ceil(lt.conf.Damage * vel.Len())
-	if lt.conf.Critical {
-		dmg += rand.Float64() * dmg / 2
-	}
 	if _, vulnerable := l.Hurt(dmg, src); vulnerable {
 		l.KnockBack(l.Position().Sub(vel), 0.45+lt.conf.KnockBackForceAddend, 0.3608+lt.conf.KnockBackHeightAddend)
 
@@ -330,7 +388,8 @@ func (lt *ProjectileBehaviour) ignores(e *Ent) trace.EntityFilter {
 			for other := range seq {
 				g, ok := other.(interface{ GameMode() world.GameMode })
 				_, living := other.(Living)
-				if (ok && !g.GameMode().HasCollision()) || e.H() == other.H() || !living || (e.data.Age < time.Second/4 && lt.conf.Owner == other.H()) {
+				_, hurtable := other.(hurtable)
+				if (ok && !g.GameMode().HasCollision()) || e.H() == other.H() || (!living && !hurtable) || ((lt.conf.IgnoreOwner || e.data.Age < time.Second/4) && lt.conf.Owner == other.H()) || slices.Contains(lt.passed, other.H()) {
 					continue
 				}
 				if !yield(other) {
@@ -340,3 +399,50 @@ func (lt *ProjectileBehaviour) ignores(e *Ent) trace.EntityFilter {
 		}
 	}
 }
+
+// hurtable is an entity that is not Living but may still be hurt, such as an
+// end crystal, which explodes when hurt.
+type hurtable interface {
+	Hurt(dmg float64, src world.DamageSource) (float64, bool)
+}
+
+// ProjectileHit holds the details of a projectile about to hit an entity. It
+// is passed to the ProjectileHitHandler of the entity hit, which may change it
+// to influence the outcome of the hit.
+type ProjectileHit struct {
+	// Result is the result of the ray trace of the projectile that hit the
+	// entity.
+	Result trace.EntityResult
+	// Velocity is the velocity that the projectile had when it hit the
+	// entity.
+	Velocity mgl64.Vec3
+	// Damage is the damage that the projectile deals to the entity. If Damage
+	// is negative, the entity is not hurt or knocked back, but the projectile
+	// is still stopped by the entity.
+	Damage float64
+
+	deflected bool
+	vel       mgl64.Vec3
+	owner     *world.EntityHandle
+}
+
+// Deflect deflects the projectile instead of letting it hit the entity. The
+// projectile continues flying with the velocity passed. If by is not nil, it
+// becomes the new owner of the projectile, making it responsible for any
+// damage that the projectile deals afterwards.
+func (h *ProjectileHit) Deflect(vel mgl64.Vec3, by world.Entity) {
+	h.deflected, h.vel = true, vel
+	if by != nil {
+		h.owner = by.H()
+	}
+}
+
+// ProjectileHitHandler is implemented by entities that handle being hit by a
+// projectile before the projectile has any effect on them.
+type ProjectileHitHandler interface {
+	// HandleProjectileHit handles the projectile held by ctx about to hit the
+	// entity. ctx.Cancel() may be called to cancel the hit, after which the
+	// projectile flies on as if it missed the entity. The hit may also be
+	// changed or deflected through the ProjectileHit passed.
+	HandleProjectileHit(ctx *event.Context[*Ent], hit *ProjectileHit)
+}
@@ -0,0 +1,192 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+	"time"
+)
+
+// NewPufferfish creates a new pufferfish.
+func NewPufferfish(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(PufferfishType, pufferfishConf)
+}
+
+var pufferfishConf PufferfishBehaviourConfig
+
+// PufferfishBehaviourConfig holds optional parameters for a
+// PufferfishBehaviour.
+type PufferfishBehaviourConfig struct{}
+
+func (conf PufferfishBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a PufferfishBehaviour using the parameters in conf.
+func (conf PufferfishBehaviourConfig) New() *PufferfishBehaviour {
+	p := &PufferfishBehaviour{fish: fish{air: fishMaxAir}}
+	p.MobBehaviour = MobBehaviourConfig{MaxHealth: 3, Speed: 0.03, Swimming: true, Experience: 1 + rand.IntN(3), Drops: p.drops}.New()
+	return p
+}
+
+// PufferfishBehaviour implements the behaviour of a pufferfish. Pufferfish
+// swim around on their own and puff up when players or other mobs come
+// close, poisoning anything that touches them while puffed up.
+type PufferfishBehaviour struct {
+	*MobBehaviour
+	fish
+
+	puffState int
+	// inflateTicks is the number of ticks that the pufferfish has been
+	// threatened for. deflateTicks is the number of ticks since it stopped
+	// being threatened.
+	inflateTicks, deflateTicks int
+}
+
+// PuffState returns how far the pufferfish is puffed up, ranging from 0 for a
+// deflated pufferfish to 2 for a pufferfish that is fully puffed up.
+func (p *PufferfishBehaviour) PuffState() int {
+	return p.puffState
+}
+
+// Bucket allows the pufferfish to be captured in a bucket of water.
+func (p *PufferfishBehaviour) Bucket(*Mob) (string, bool) {
+	return p.bucket("pufferfish")
+}
+
+// Tick ticks the pufferfish, making it puff up when threatened and poison
+// entities that touch it.
+func (p *PufferfishBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	m := &Mob{Ent: e}
+	if !p.Dead() {
+		if !p.tickFish(m, p.MobBehaviour, tx) {
+			return nil
+		}
+		p.tickPuff(m, tx)
+		if p.puffState > 0 {
+			p.sting(m, tx)
+		}
+	}
+	return p.MobBehaviour.Tick(e, tx)
+}
+
+// tickPuff inflates the pufferfish while it is threatened and slowly deflates
+// it once it no longer is.
+func (p *PufferfishBehaviour) tickPuff(m *Mob, tx *world.Tx) {
+	if p.threatened(m, tx) {
+		p.deflateTicks = 0
+		if p.inflateTicks++; p.puffState == 0 {
+			p.setPuffState(m, 1)
+		} else if p.puffState == 1 && p.inflateTicks > 40 {
+			p.setPuffState(m, 2)
+		}
+		return
+	}
+	p.inflateTicks = 0
+	if p.puffState == 0 {
+		return
+	}
+	if p.deflateTicks++; p.puffState == 2 && p.deflateTicks > 60 {
+		p.setPuffState(m, 1)
+	} else if p.puffState == 1 && p.deflateTicks > 100 {
+		p.setPuffState(m, 0)
+	}
+}
+
+// setPuffState changes the puff state of the pufferfish and updates it for
+// viewers.
+func (p *PufferfishBehaviour) setPuffState(m *Mob, state int) {
+	p.puffState = state
+	m.updateState()
+}
+
+// threatened checks if a player that is not in creative or spectator mode,
+// or a mob other than a fish, is close to the pufferfish.
+func (p *PufferfishBehaviour) threatened(m *Mob, tx *world.Tx) bool {
+	for e := range tx.EntitiesWithin(m.H().Type().BBox(m).Grow(2).Translate(m.Position())) {
+		if pufferfishTarget(m, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// sting hurts and poisons all entities that touch the pufferfish. The damage
+// and duration of the poison grow the further the pufferfish is puffed up.
+func (p *PufferfishBehaviour) sting(m *Mob, tx *world.Tx) {
+	for e := range tx.EntitiesWithin(m.H().Type().BBox(m).Grow(0.3).Translate(m.Position())) {
+		if !pufferfishTarget(m, e) {
+			continue
+		}
+		l := e.(Living)
+		if _, vulnerable := l.Hurt(float64(1+p.puffState), AttackDamageSource{Attacker: m}); vulnerable {
+			l.AddEffect(effect.New(effect.Poison, 1, time.Duration(p.puffState)*time.Second*3))
+		}
+	}
+}
+
+// pufferfishTarget checks if the entity passed is one that a pufferfish puffs
+// up for and stings.
+func pufferfishTarget(m *Mob, e world.Entity) bool {
+	if e.H() == m.H() {
+		return false
+	}
+	if l, ok := e.(Living); !ok || l.Dead() {
+		return false
+	}
+	if g, ok := e.(interface{ GameMode() world.GameMode }); ok {
+		return g.GameMode().AllowsTakingDamage()
+	}
+	_, isFish := fishOf(e)
+	_, isMob := e.(*Mob)
+	return isMob && !isFish
+}
+
+// drops returns the items dropped by a pufferfish when it dies.
+func (p *PufferfishBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	return fishDrops(item.Pufferfish{})
+}
+
+// PufferfishType is a world.EntityType implementation for pufferfish.
+var PufferfishType pufferfishType
+
+type pufferfishType struct{}
+
+func (pufferfishType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (pufferfishType) EncodeEntity() string { return "minecraft:pufferfish" }
+func (pufferfishType) BBox(e world.Entity) cube.BBox {
+	// A pufferfish grows as it puffs up: It is half its full size while
+	// deflated and 70% of its full size while partially puffed up.
+	size := 0.7
+	if m, ok := e.(*Mob); ok {
+		switch m.Behaviour().(*PufferfishBehaviour).puffState {
+		case 0:
+			size *= 0.5
+		case 1:
+			size *= 0.7
+		}
+	}
+	return cube.Box(-size/2, 0, -size/2, size/2, size, size/2)
+}
+
+func (pufferfishType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	p := pufferfishConf.New()
+	p.MobBehaviour.decodeNBT(m)
+	p.fish.decodeNBT(m)
+	p.puffState = int(min(max(nbtconv.Int32(m, "PuffState"), 0), 2))
+	data.Data = p
+}
+
+func (pufferfishType) EncodeNBT(data *world.EntityData) map[string]any {
+	p := data.Data.(*PufferfishBehaviour)
+	m := map[string]any{"PuffState": int32(p.puffState)}
+	p.MobBehaviour.encodeNBT(m)
+	p.fish.encodeNBT(m)
+	return m
+}
@@ -0,0 +1,425 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/player/bossbar"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// StartRaid starts a raid on the village passed, caused by a player with Bad
+// Omen at the level passed. If a raid is already going on in the village, its
+// Bad Omen level is raised instead, which adds a bonus wave to the raid.
+// False is returned if no raid was started or raised, such as on peaceful
+// difficulty or if the raid in the village already ended.
+func StartRaid(tx *world.Tx, v world.Village, omen int) bool {
+	if tx.World().Difficulty() == world.DifficultyPeaceful {
+		return false
+	}
+	for e := range tx.EntitiesWithin(v.Bounds()) {
+		r, ok := raidOf(e)
+		if !ok {
+			continue
+		}
+		if r.status != raidOngoing || r.omen >= maxBadOmenLevel {
+			return false
+		}
+		r.omen = min(r.omen+omen, maxBadOmenLevel)
+		r.waves = raidWaves(tx.World().Difficulty(), r.omen)
+		return true
+	}
+	centre := v.Centre()
+	y, _ := tx.HighestBlock(centre[0], centre[2])
+	conf := raidConf
+	conf.Omen = omen
+	tx.AddEntity(world.EntitySpawnOpts{Position: cube.Pos{centre[0], y + 1, centre[2]}.Vec3Middle()}.New(RaidType, conf))
+	return true
+}
+
+var raidConf RaidBehaviourConfig
+
+// RaidBehaviourConfig holds optional parameters for a RaidBehaviour.
+type RaidBehaviourConfig struct {
+	// Omen is the level of Bad Omen that caused the raid. If 0, a level of 1
+	// is used.
+	Omen int
+}
+
+func (conf RaidBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a RaidBehaviour using the parameters in conf.
+func (conf RaidBehaviourConfig) New() *RaidBehaviour {
+	return &RaidBehaviour{
+		omen:    max(conf.Omen, 1),
+		ticks:   raidWaveDelay,
+		raiders: make(map[uuid.UUID]int),
+		heroes:  make(map[uuid.UUID]struct{}),
+	}
+}
+
+const (
+	// raidWaveDelay is the amount of ticks between two waves of a raid, and
+	// before the first wave of a raid.
+	raidWaveDelay = 300
+	// raidTimeout is the amount of ticks after which a raid that is still
+	// going on is lost.
+	raidTimeout = 48000
+	// raidEndTicks is the amount of ticks that the boss bar of a raid remains
+	// shown after the raid ended.
+	raidEndTicks = 600
+	// raidRadius is the distance from the centre of a raid within which
+	// raiders and heroes are tracked.
+	raidRadius = 96
+	// raidMissingTicks is the amount of ticks after which a raider that is
+	// not loaded or too far away from the raid is no longer counted as part
+	// of the raid.
+	raidMissingTicks = 1200
+	// heroOfTheVillageDuration is the duration of the Hero of the Village
+	// effect given to the heroes of a raid.
+	heroOfTheVillageDuration = time.Minute * 40
+)
+
+// raidStatus is the status of a raid.
+type raidStatus uint8
+
+const (
+	raidOngoing raidStatus = iota
+	raidVictory
+	raidDefeat
+)
+
+// raidWaveRaiders holds the amount of pillagers and vindicators spawned in
+// every wave of a raid. Bonus waves spawn the raiders of the last wave.
+var raidWaveRaiders = [...][2]int{{4, 0}, {3, 2}, {3, 0}, {4, 1}, {4, 4}, {4, 2}, {2, 5}}
+
+// RaidBehaviour implements the behaviour of a raid. A raid is an invisible
+// entity at the centre of the village that is raided, which spawns waves of
+// raiders and shows a boss bar to players nearby that tracks the raiders that
+// are left. Because the raid is an entity, an ongoing raid is saved and
+// resumed along with the chunk that it is in.
+type RaidBehaviour struct {
+	omen   int
+	wave   int
+	waves  int
+	status raidStatus
+	// ticks is the amount of ticks until the next wave is spawned while the
+	// raid is going on, or the amount of ticks until the raid is removed
+	// after it ended.
+	ticks       int
+	activeTicks int
+
+	// raiders holds the raiders of the current wave that are still alive,
+	// mapped to the amount of ticks that they have been missing for.
+	raiders  map[uuid.UUID]int
+	waveSize int
+	heroes   map[uuid.UUID]struct{}
+
+	lastBar bossbar.BossBar
+}
+
+// Omen returns the level of Bad Omen of the raid.
+func (r *RaidBehaviour) Omen() int {
+	return r.omen
+}
+
+// Wave returns the current wave of the raid and the total amount of waves.
+func (r *RaidBehaviour) Wave() (wave, waves int) {
+	return r.wave, r.waves
+}
+
+// Raiders returns the amount of raiders of the current wave that are still
+// alive.
+func (r *RaidBehaviour) Raiders() int {
+	return len(r.raiders)
+}
+
+// Ongoing checks if the raid is still going on.
+func (r *RaidBehaviour) Ongoing() bool {
+	return r.status == raidOngoing
+}
+
+// Victory checks if the raid was won by the village.
+func (r *RaidBehaviour) Victory() bool {
+	return r.status == raidVictory
+}
+
+// Immobile always returns true.
+func (r *RaidBehaviour) Immobile() bool {
+	return true
+}
+
+// BossBar returns the boss bar of the raid shown to players nearby. Before a
+// wave arrives, the bar fills up. While a wave is going on, the bar tracks
+// the raiders that are left.
+func (r *RaidBehaviour) BossBar() bossbar.BossBar {
+	switch r.status {
+	case raidVictory:
+		return bossbar.New("Raid - Victory").WithColour(bossbar.Red())
+	case raidDefeat:
+		return bossbar.New("Raid - Defeat").WithColour(bossbar.Red()).WithHealthPercentage(0)
+	}
+	if len(r.raiders) == 0 {
+		progress := 1 - float64(r.ticks)/raidWaveDelay
+		return bossbar.New("Raid").WithColour(bossbar.Red()).WithHealthPercentage(mgl64.Clamp(progress, 0, 1))
+	}
+	title := "Raid"
+	if len(r.raiders) <= 2 {
+		title = fmt.Sprintf("Raid - Raiders Remaining: %v", len(r.raiders))
+	}
+	progress := float64(len(r.raiders)) / float64(max(r.waveSize, 1))
+	return bossbar.New(title).WithColour(bossbar.Red()).WithHealthPercentage(mgl64.Clamp(progress, 0, 1))
+}
+
+// Tick ticks the raid, spawning new waves of raiders and ending the raid once
+// all waves were defeated, the raid timed out or the village was destroyed.
+func (r *RaidBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	defer r.updateBar(e, tx)
+	if r.status != raidOngoing {
+		if r.ticks--; r.ticks <= 0 {
+			_ = e.Close()
+		}
+		return nil
+	}
+	if r.waves == 0 {
+		r.waves = raidWaves(tx.World().Difficulty(), r.omen)
+	}
+	if r.activeTicks++; r.activeTicks >= raidTimeout || tx.World().Difficulty() == world.DifficultyPeaceful {
+		r.end(raidDefeat, e, tx)
+		return nil
+	}
+	if e.Age()%time.Second == 0 {
+		if _, ok := tx.Village(cube.PosFromVec3(e.Position())); !ok {
+			// The village was destroyed: All of its beds and job site blocks
+			// were broken.
+			r.end(raidDefeat, e, tx)
+			return nil
+		}
+		r.trackRaiders(e, tx)
+	}
+	if len(r.raiders) > 0 {
+		return nil
+	}
+	if r.wave >= r.waves {
+		r.end(raidVictory, e, tx)
+		return nil
+	}
+	if r.ticks == raidWaveDelay {
+		tx.PlaySound(e.Position(), sound.RaidHorn{})
+	}
+	if r.ticks--; r.ticks <= 0 {
+		r.spawnWave(e, tx)
+		r.ticks = raidWaveDelay
+	}
+	return nil
+}
+
+// trackRaiders updates the raiders of the raid that are still alive and the
+// players that took part in the raid. Raiders that are not found close to the
+// raid, for example because they are in a chunk that was unloaded, are only
+// removed from the raid after they have been missing for raidMissingTicks.
+func (r *RaidBehaviour) trackRaiders(e *Ent, tx *world.Tx) {
+	id := e.H().UUID()
+	found := make(map[uuid.UUID]struct{}, len(r.raiders))
+	for other := range tx.EntitiesWithin(cube.Box(0, 0, 0, 0, 0, 0).Translate(e.Position()).Grow(raidRadius)) {
+		if _, ok := other.(interface{ GameMode() world.GameMode }); ok {
+			r.heroes[other.H().UUID()] = struct{}{}
+			continue
+		}
+		if rd, ok := raiderOf(other); ok && rd.raid == id && !other.(*Mob).Dead() {
+			found[other.H().UUID()] = struct{}{}
+			r.raiders[other.H().UUID()] = 0
+		}
+	}
+	for raider, missing := range r.raiders {
+		if _, ok := found[raider]; ok {
+			continue
+		}
+		if missing += 20; missing >= raidMissingTicks {
+			delete(r.raiders, raider)
+			continue
+		}
+		r.raiders[raider] = missing
+	}
+}
+
+// removeRaider removes a raider from the raid, for example because it was
+// killed.
+func (r *RaidBehaviour) removeRaider(id uuid.UUID) {
+	delete(r.raiders, id)
+}
+
+// spawnWave spawns the next wave of raiders of the raid. The raiders spawn
+// together at a random position around the village and walk towards its
+// centre.
+func (r *RaidBehaviour) spawnWave(e *Ent, tx *world.Tx) {
+	r.wave++
+	counts := raidWaveRaiders[min(r.wave, len(raidWaveRaiders))-1]
+
+	pos := r.spawnPosition(e, tx)
+	r.waveSize = 0
+	for i := range counts[0] + counts[1] {
+		var (
+			t world.EntityType
+			b Behaviour
+		)
+		if i < counts[0] {
+			p := pillagerConf.New()
+			p.raid, p.raidCentre = e.H().UUID(), e.Position()
+			t, b = PillagerType, p
+		} else {
+			v := vindicatorConf.New()
+			v.raid, v.raidCentre = e.H().UUID(), e.Position()
+			t, b = VindicatorType, v
+		}
+		h := world.EntitySpawnOpts{Position: pos.Add(randomHorizontalOffset(1))}.New(t, mobConfig{b: b})
+		tx.AddEntity(h)
+		r.raiders[h.UUID()] = 0
+		r.waveSize++
+	}
+}
+
+// spawnPosition returns a random position around the village on the surface
+// that a wave of raiders spawns at.
+func (r *RaidBehaviour) spawnPosition(e *Ent, tx *world.Tx) mgl64.Vec3 {
+	centre := e.Position()
+	for dist := 32.0; dist > 0; dist -= 8 {
+		angle := rand.Float64() * math.Pi * 2
+		x, z := int(math.Floor(centre[0]+math.Cos(angle)*dist)), int(math.Floor(centre[2]+math.Sin(angle)*dist))
+		y, _ := tx.HighestBlock(x, z)
+		pos := cube.Pos{x, y + 1, z}
+		if !pos.OutOfBounds(tx.Range()) && zombieSpawnable(pos, tx) {
+			return pos.Vec3Middle()
+		}
+	}
+	return centre
+}
+
+// end ends the raid with the status passed. If the raid was won, all heroes
+// of the raid that are still close to the village are given Hero of the
+// Village.
+func (r *RaidBehaviour) end(status raidStatus, e *Ent, tx *world.Tx) {
+	r.status, r.ticks = status, raidEndTicks
+	if status != raidVictory {
+		return
+	}
+	for other := range tx.EntitiesWithin(cube.Box(0, 0, 0, 0, 0, 0).Translate(e.Position()).Grow(raidRadius)) {
+		if _, hero := r.heroes[other.H().UUID()]; !hero {
+			continue
+		}
+		if l, ok := other.(Living); ok && !l.Dead() {
+			l.AddEffect(effect.New(effect.HeroOfTheVillage, r.omen, heroOfTheVillageDuration))
+		}
+	}
+}
+
+// updateBar shows the boss bar of the raid to viewers if it changed.
+func (r *RaidBehaviour) updateBar(e *Ent, tx *world.Tx) {
+	if bar := r.BossBar(); bar != r.lastBar {
+		r.lastBar = bar
+		for _, v := range tx.Viewers(e.Position()) {
+			v.ViewEntityState(e)
+		}
+	}
+}
+
+// raidWaves returns the amount of waves of a raid on the difficulty passed
+// with the level of Bad Omen passed. Raids with a Bad Omen level higher than
+// 1 have a bonus wave.
+func raidWaves(d world.Difficulty, omen int) int {
+	waves := 5
+	switch d {
+	case world.DifficultyEasy:
+		waves = 3
+	case world.DifficultyHard:
+		waves = 7
+	}
+	if omen > 1 {
+		waves++
+	}
+	return waves
+}
+
+// raidOf returns the RaidBehaviour of the entity passed if it is a raid.
+func raidOf(e world.Entity) (*RaidBehaviour, bool) {
+	if ent, ok := e.(*Ent); ok {
+		r, ok := ent.Behaviour().(*RaidBehaviour)
+		return r, ok
+	}
+	return nil, false
+}
+
+// raidAt looks for the raid with the UUID passed at the position passed.
+// False is returned if the raid is not found, for example because its chunk
+// is not loaded.
+func raidAt(tx *world.Tx, pos mgl64.Vec3, id uuid.UUID) (*RaidBehaviour, bool) {
+	for e := range tx.EntitiesWithin(cube.Box(-1, -1, -1, 1, 1, 1).Translate(pos)) {
+		if r, ok := raidOf(e); ok && e.H().UUID() == id {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// RaidType is a world.EntityType implementation for raids.
+var RaidType raidType
+
+type raidType struct{}
+
+func (raidType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+func (raidType) EncodeEntity() string        { return "dragonfly:raid" }
+func (raidType) BBox(world.Entity) cube.BBox { return cube.BBox{} }
+func (raidType) NetworkEncodeEntity() string { return "minecraft:falling_block" }
+
+func (raidType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	r := raidConf.New()
+	r.omen = int(nbtconv.Int32(m, "BadOmenLevel"))
+	r.wave, r.waves = int(nbtconv.Int32(m, "GroupsSpawned")), int(nbtconv.Int32(m, "NumGroups"))
+	r.status = raidStatus(nbtconv.Uint8(m, "Status"))
+	r.ticks, r.activeTicks = int(nbtconv.Int32(m, "Ticks")), int(nbtconv.Int32(m, "ActiveTicks"))
+	r.waveSize = int(nbtconv.Int32(m, "TotalRaiders"))
+	for _, v := range nbtconv.Slice(m, "Raiders") {
+		if id, err := uuid.Parse(fmt.Sprint(v)); err == nil {
+			r.raiders[id] = 0
+		}
+	}
+	for _, v := range nbtconv.Slice(m, "Heroes") {
+		if id, err := uuid.Parse(fmt.Sprint(v)); err == nil {
+			r.heroes[id] = struct{}{}
+		}
+	}
+	data.Data = r
+}
+
+func (raidType) EncodeNBT(data *world.EntityData) map[string]any {
+	r := data.Data.(*RaidBehaviour)
+	raiders, heroes := make([]any, 0, len(r.raiders)), make([]any, 0, len(r.heroes))
+	for id := range r.raiders {
+		raiders = append(raiders, id.String())
+	}
+	for id := range r.heroes {
+		heroes = append(heroes, id.String())
+	}
+	return map[string]any{
+		"BadOmenLevel":  int32(r.omen),
+		"GroupsSpawned": int32(r.wave),
+		"NumGroups":     int32(r.waves),
+		"Status":        uint8(r.status),
+		"Ticks":         int32(r.ticks),
+		"ActiveTicks":   int32(r.activeTicks),
+		"TotalRaiders":  int32(r.waveSize),
+		"Raiders":       raiders,
+		"Heroes":        heroes,
+	}
+}
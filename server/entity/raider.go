@@ -0,0 +1,235 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	// raiderFollowRange is the distance within which raiders look for and
+	// pursue their targets.
+	raiderFollowRange = 32
+	// badOmenDuration is the duration of the Bad Omen effect given to players
+	// that kill the captain of a patrol.
+	badOmenDuration = time.Minute * 100
+	// maxBadOmenLevel is the highest level of Bad Omen that a player may
+	// have.
+	maxBadOmenLevel = 5
+)
+
+// raiderBehaviour is implemented by the behaviours of mobs that take part in
+// patrols and raids.
+type raiderBehaviour interface {
+	raiding() *raider
+}
+
+// raider implements the behaviour shared by the illagers that take part in
+// patrols and raids, such as pillagers and vindicators. Patrols walk through
+// the world led by their captain, who carries an illager banner. Raiders that
+// were spawned by a raid walk towards the village that is being raided.
+type raider struct {
+	target *world.EntityHandle
+
+	captain      bool
+	patrolTarget *mgl64.Vec3
+
+	raid       uuid.UUID
+	raidCentre mgl64.Vec3
+}
+
+// raiding returns the raider itself so that it can be found when it is
+// embedded in a Behaviour.
+func (r *raider) raiding() *raider {
+	return r
+}
+
+// Captain checks if the raider is the captain of its patrol.
+func (r *raider) Captain() bool {
+	return r.captain
+}
+
+// Patrolling checks if the raider is part of a patrol.
+func (r *raider) Patrolling() bool {
+	return r.patrolTarget != nil
+}
+
+// Raiding checks if the raider was spawned by a raid.
+func (r *raider) Raiding() bool {
+	return r.raid != uuid.Nil
+}
+
+// Target returns the entity that the raider is currently pursuing, or nil if
+// it is not pursuing any entity.
+func (r *raider) Target() *world.EntityHandle {
+	return r.target
+}
+
+// raiderOf returns the raider of the entity passed if it is a mob that takes
+// part in patrols and raids.
+func raiderOf(e world.Entity) (*raider, bool) {
+	if m, ok := e.(*Mob); ok {
+		if b, ok := m.Behaviour().(raiderBehaviour); ok {
+			return b.raiding(), true
+		}
+	}
+	return nil, false
+}
+
+// equipBanner makes the raider wear an illager banner if it is the captain
+// of a patrol. The banner is always dropped when the captain dies.
+func (r *raider) equipBanner(eq *Equipment) {
+	if !r.captain {
+		return
+	}
+	eq.Equip(item.NewStack(block.Banner{Colour: item.ColourWhite(), Illager: true}, 1), true)
+}
+
+// hurt makes the raider target the entity that attacked it. If the raider
+// died, its raid is notified or, if it was the captain of a patrol, the
+// player that killed it is given Bad Omen.
+func (r *raider) hurt(m *Mob, src world.DamageSource) {
+	attacker := damageSourceAttacker(src)
+	if attacker != nil {
+		if _, ok := raiderOf(attacker); !ok {
+			r.target = attacker.H()
+		}
+	}
+	if !m.Dead() {
+		return
+	}
+	if r.raid != uuid.Nil {
+		if raid, ok := raidAt(m.tx, r.raidCentre, r.raid); ok {
+			raid.removeRaider(m.H().UUID())
+		}
+		return
+	}
+	p, ok := attacker.(interface {
+		Living
+		Effect(e effect.Type) (effect.Effect, bool)
+		GameMode() world.GameMode
+	})
+	if !ok || !r.captain {
+		return
+	}
+	lvl := 1
+	if omen, ok := p.Effect(effect.BadOmen); ok {
+		lvl = min(omen.Level()+1, maxBadOmenLevel)
+	}
+	p.AddEffect(effect.New(effect.BadOmen, lvl, badOmenDuration))
+}
+
+// findTarget returns the current target of the raider, looking for a new
+// target if the raider currently has none. Raiders attack players, villagers
+// and iron golems. Nil is returned if no target was found.
+func (r *raider) findTarget(m *Mob, tx *world.Tx) Living {
+	if r.target != nil {
+		e, ok := r.target.Entity(tx)
+		if l, living := e.(Living); ok && living && !l.Dead() && !playingDead(e) && e.Position().Sub(m.Position()).Len() <= raiderFollowRange {
+			if _, player := e.(interface{ GameMode() world.GameMode }); !player || attackablePlayer(l) {
+				return l
+			}
+		}
+		r.target = nil
+	}
+	if m.Age()%(time.Second/2) != 0 {
+		return nil
+	}
+	if t, ok := nearestEntity(m, tx, raiderFollowRange, attackablePlayer); ok {
+		r.target = t.H()
+		return t
+	}
+	if t, ok := nearestEntity(m, tx, 16, func(e Living) bool {
+		t := e.H().Type()
+		return t == VillagerType || t == IronGolemType
+	}); ok {
+		r.target = t.H()
+		return t
+	}
+	return nil
+}
+
+// wander moves the raider while it has no target. Raiders of a raid walk
+// towards the centre of the village they are raiding, while raiders in a
+// patrol follow the patrol. Other raiders walk around randomly.
+func (r *raider) wander(m *Mob, b *MobBehaviour, tx *world.Tx) {
+	switch {
+	case r.raid != uuid.Nil:
+		if horizontalDistance(m.Position(), r.raidCentre) > 12 {
+			if !b.Moving() || m.Age()%time.Second == 0 {
+				b.MoveTo(r.raidCentre, 1)
+			}
+			return
+		}
+	case r.patrolTarget != nil:
+		r.patrol(m, b, tx)
+		return
+	}
+	if !b.Moving() && rand.IntN(120) == 0 {
+		b.MoveTo(m.Position().Add(randomHorizontalOffset(8)), 0.8)
+	}
+}
+
+// patrol moves the raider along with its patrol. The captain of the patrol
+// walks towards the target of the patrol and picks a new target once it gets
+// there, while the other members follow the target of their captain.
+func (r *raider) patrol(m *Mob, b *MobBehaviour, tx *world.Tx) {
+	if !r.captain && m.Age()%time.Second == 0 {
+		if c, ok := nearestEntity(m, tx, 16, func(e Living) bool {
+			other, ok := raiderOf(e)
+			return ok && other.captain && other.patrolTarget != nil
+		}); ok {
+			captain, _ := raiderOf(c)
+			target := *captain.patrolTarget
+			r.patrolTarget = &target
+		}
+	}
+	if horizontalDistance(m.Position(), *r.patrolTarget) < 10 {
+		if !r.captain {
+			return
+		}
+		target := r.patrolTarget.Add(randomHorizontalOffset(64))
+		r.patrolTarget = &target
+	}
+	if !b.Moving() || m.Age()%time.Second == 0 {
+		b.MoveTo(*r.patrolTarget, 0.7)
+	}
+}
+
+// horizontalDistance returns the distance between two positions, ignoring
+// the difference in height.
+func horizontalDistance(a, b mgl64.Vec3) float64 {
+	d := a.Sub(b)
+	d[1] = 0
+	return d.Len()
+}
+
+// encodeNBT encodes the patrol and raid of the raider into the map passed.
+func (r *raider) encodeNBT(m map[string]any) {
+	m["PatrolLeader"] = boolByte(r.captain)
+	if r.patrolTarget != nil {
+		m["PatrolTarget"] = nbtconv.Vec3ToFloat32Slice(*r.patrolTarget)
+	}
+	if r.raid != uuid.Nil {
+		m["RaidID"] = r.raid.String()
+		m["RaidCentre"] = nbtconv.Vec3ToFloat32Slice(r.raidCentre)
+	}
+}
+
+// decodeNBT decodes the patrol and raid of the raider from the map passed.
+func (r *raider) decodeNBT(m map[string]any) {
+	r.captain = nbtconv.Bool(m, "PatrolLeader")
+	if _, ok := m["PatrolTarget"]; ok {
+		target := nbtconv.Vec3(m, "PatrolTarget")
+		r.patrolTarget = &target
+	}
+	if id, err := uuid.Parse(nbtconv.String(m, "RaidID")); err == nil {
+		r.raid, r.raidCentre = id, nbtconv.Vec3(m, "RaidCentre")
+	}
+}
@@ -10,31 +10,79 @@ import (
 // DefaultRegistry is a world.EntityRegistry that registers all default entities
 // implemented by Dragonfly.
 var DefaultRegistry = conf.New([]world.EntityType{
+	AllayType,
 	AreaEffectCloudType,
 	ArrowType,
+	AxolotlType,
+	BeeType,
 	BottleOfEnchantingType,
+	ChestMinecartType,
+	ChickenType,
+	CodType,
+	CreeperType,
+	DragonFireballType,
+	DrownedType,
 	EggType,
+	EndCrystalType,
+	EnderDragonType,
+	EndermanType,
 	EnderPearlType,
 	ExperienceOrbType,
 	FallingBlockType,
+	FireballType,
 	FireworkType,
+	FrogType,
+	GhastType,
+	HopperMinecartType,
+	IronGolemType,
 	ItemType,
 	LightningType,
 	LingeringPotionType,
+	MagmaCubeType,
+	MinecartType,
+	PhantomType,
+	PillagerType,
+	PufferfishType,
+	RaidType,
+	SalmonType,
+	SheepType,
+	ShulkerBulletType,
+	ShulkerType,
+	SkeletonType,
+	SlimeType,
+	SnowGolemType,
 	SnowballType,
 	SplashPotionType,
+	StrayType,
 	TNTType,
+	TadpoleType,
 	TextType,
+	TropicalFishType,
+	VillagerType,
+	VindicatorType,
+	WardenType,
+	WitherSkullType,
+	DangerousWitherSkullType,
+	WitherType,
+	WolfType,
+	ZombieType,
+	ZombieVillagerType,
 })
 
 var conf = world.EntityRegistryConfig{
 	TNT:                NewTNT,
+	Bee:                newBeeFromHive,
 	Egg:                NewEgg,
+	EndCrystal:         NewEndCrystal,
 	Snowball:           NewSnowball,
 	BottleOfEnchanting: NewBottleOfEnchanting,
 	EnderPearl:         NewEnderPearl,
 	FallingBlock:       NewFallingBlock,
 	Lightning:          NewLightning,
+	IronGolem:          NewIronGolem,
+	SnowGolem:          NewSnowGolem,
+	Tadpole:            NewTadpole,
+	Warden:             NewWarden,
 	Firework: func(opts world.EntitySpawnOpts, firework world.Item, owner world.Entity, sidewaysVelocityMultiplier, upwardsAcceleration float64, attached bool) *world.EntityHandle {
 		return newFirework(opts, firework.(item.Firework), owner, sidewaysVelocityMultiplier, upwardsAcceleration, attached)
 	},
@@ -44,6 +92,9 @@ var conf = world.EntityRegistryConfig{
 	LingeringPotion: func(opts world.EntitySpawnOpts, t any, owner world.Entity) *world.EntityHandle {
 		return NewLingeringPotion(opts, t.(potion.Potion), owner)
 	},
+	Minecart: func(opts world.EntitySpawnOpts, t any) *world.EntityHandle {
+		return NewMinecart(opts, t.(item.MinecartType))
+	},
 	SplashPotion: func(opts world.EntitySpawnOpts, t any, owner world.Entity) *world.EntityHandle {
 		return NewSplashPotion(opts, t.(potion.Potion), owner)
 	},
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+)
+
+// NewSalmon creates a new salmon.
+func NewSalmon(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(SalmonType, salmonConf)
+}
+
+var salmonConf SalmonBehaviourConfig
+
+// SalmonBehaviourConfig holds optional parameters for a SalmonBehaviour.
+type SalmonBehaviourConfig struct{}
+
+func (conf SalmonBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a SalmonBehaviour using the parameters in conf.
+func (conf SalmonBehaviourConfig) New() *SalmonBehaviour {
+	s := &SalmonBehaviour{fish: fish{school: 5, air: fishMaxAir}}
+	s.MobBehaviour = MobBehaviourConfig{MaxHealth: 3, Speed: 0.03, Swimming: true, Experience: 1 + rand.IntN(3), Drops: s.drops}.New()
+	return s
+}
+
+// SalmonBehaviour implements the behaviour of salmon. Salmon swim around in
+// cold oceans and rivers in schools of up to five fish.
+type SalmonBehaviour struct {
+	*MobBehaviour
+	fish
+}
+
+// Bucket allows the salmon to be captured in a bucket of water.
+func (s *SalmonBehaviour) Bucket(*Mob) (string, bool) {
+	return s.bucket("salmon")
+}
+
+// Tick ticks the salmon, making it swim around with its school.
+func (s *SalmonBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !s.Dead() && !s.tickFish(&Mob{Ent: e}, s.MobBehaviour, tx) {
+		return nil
+	}
+	return s.MobBehaviour.Tick(e, tx)
+}
+
+// drops returns the items dropped by a salmon when it dies. The salmon is
+// dropped cooked if it died while on fire.
+func (s *SalmonBehaviour) drops(m *Mob, _ world.DamageSource) []item.Stack {
+	return fishDrops(item.Salmon{Cooked: m.OnFireDuration() > 0})
+}
+
+// SalmonType is a world.EntityType implementation for salmon.
+var SalmonType salmonType
+
+type salmonType struct{}
+
+func (salmonType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (salmonType) EncodeEntity() string { return "minecraft:salmon" }
+func (salmonType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.35, 0, -0.35, 0.35, 0.4, 0.35)
+}
+
+func (salmonType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	s := salmonConf.New()
+	s.MobBehaviour.decodeNBT(m)
+	s.fish.decodeNBT(m)
+	data.Data = s
+}
+
+func (salmonType) EncodeNBT(data *world.EntityData) map[string]any {
+	s := data.Data.(*SalmonBehaviour)
+	m := map[string]any{}
+	s.MobBehaviour.encodeNBT(m)
+	s.fish.encodeNBT(m)
+	return m
+}
@@ -0,0 +1,404 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+)
+
+// NewSheep creates a new adult sheep with a random wool colour. Most sheep
+// are white, while some are black, grey, light grey, brown or pink.
+func NewSheep(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(SheepType, SheepBehaviourConfig{Colour: randomSheepColour()})
+}
+
+// SheepBehaviourConfig holds optional parameters for a SheepBehaviour.
+type SheepBehaviourConfig struct {
+	// Colour is the colour of the wool of the sheep. The zero value is
+	// white.
+	Colour item.Colour
+	// Sheared specifies if the sheep has been sheared and has no wool.
+	Sheared bool
+	// Baby specifies if the sheep is a baby. Babies grow up after 20
+	// minutes.
+	Baby bool
+}
+
+func (conf SheepBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a SheepBehaviour using the parameters in conf.
+func (conf SheepBehaviourConfig) New() *SheepBehaviour {
+	s := &SheepBehaviour{colour: conf.Colour, sheared: conf.Sheared}
+	if conf.Baby {
+		s.growUpTicks = sheepGrowUpTicks
+	}
+	s.MobBehaviour = MobBehaviourConfig{
+		MaxHealth:  8,
+		Speed:      0.05,
+		Experience: 1 + rand.IntN(3),
+		Drops:      s.drops,
+	}.New()
+	return s
+}
+
+const (
+	// sheepGrowUpTicks is the amount of ticks it takes for a baby sheep to
+	// grow up.
+	sheepGrowUpTicks = 24000
+	// sheepLoveTicks is the amount of ticks that a sheep is in love for after
+	// being fed.
+	sheepLoveTicks = 600
+	// sheepBreedCooldown is the amount of ticks after breeding before a sheep
+	// may breed again.
+	sheepBreedCooldown = 6000
+	// sheepEatTicks is the amount of ticks that the animation of a sheep
+	// eating grass lasts.
+	sheepEatTicks = 40
+)
+
+// SheepBehaviour implements the behaviour of sheep. Sheep wander around and
+// occasionally eat grass, which regrows their wool after they were sheared.
+// Sheep may be sheared for wool, dyed and bred using wheat. Babies bred from
+// two sheep of different colours get the colour obtained by mixing the dyes
+// of their parents, if the dyes mix.
+type SheepBehaviour struct {
+	*MobBehaviour
+
+	colour  item.Colour
+	sheared bool
+
+	// eatTicks is the amount of ticks left in the animation of the sheep
+	// eating grass. The grass is eaten close to the end of the animation.
+	eatTicks      int
+	growUpTicks   int
+	loveTicks     int
+	breedCooldown int
+}
+
+// Colour returns the colour of the wool of the sheep.
+func (s *SheepBehaviour) Colour() item.Colour {
+	return s.colour
+}
+
+// Sheared checks if the sheep was sheared and has not yet regrown its wool.
+func (s *SheepBehaviour) Sheared() bool {
+	return s.sheared
+}
+
+// Baby checks if the sheep is a baby.
+func (s *SheepBehaviour) Baby() bool {
+	return s.growUpTicks > 0
+}
+
+// Scale returns the scale of the sheep, which is 0.5 for babies.
+func (s *SheepBehaviour) Scale() float64 {
+	if s.Baby() {
+		return 0.5
+	}
+	return 1
+}
+
+// InLove checks if the sheep was fed and is looking for another sheep to
+// breed with.
+func (s *SheepBehaviour) InLove() bool {
+	return s.loveTicks > 0
+}
+
+// Interact shears, dyes or feeds the sheep, depending on the item held by the
+// user.
+func (s *SheepBehaviour) Interact(m *Mob, user item.User, tx *world.Tx) bool {
+	held, _ := user.HeldItems()
+	switch it := held.Item().(type) {
+	case item.Shears:
+		if s.sheared || s.Baby() {
+			return false
+		}
+		s.sheared = true
+		for range 1 + rand.IntN(3) {
+			tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: m.Position().Add(mgl64.Vec3{0, 1})}, item.NewStack(block.Wool{Colour: s.colour}, 1)))
+		}
+		tx.PlaySound(m.Position(), sound.Shear{})
+		tx.EmitGameEvent(m.Position(), gameevent.Shear{}, user)
+		damageHeldItem(user)
+	case item.Dye:
+		if s.colour == it.Colour {
+			return false
+		}
+		s.colour = it.Colour
+		consumeHeldItem(user)
+	case item.Wheat:
+		switch {
+		case s.Baby():
+			s.growUpTicks -= s.growUpTicks / 10
+		case s.InLove() || s.breedCooldown > 0:
+			return false
+		default:
+			s.loveTicks = sheepLoveTicks
+		}
+		consumeHeldItem(user)
+	default:
+		return false
+	}
+	m.updateState()
+	return true
+}
+
+// Tick ticks the sheep, making it eat grass, breed and follow players that
+// hold wheat.
+func (s *SheepBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !s.Dead() {
+		s.tickSheep(&Mob{Ent: e}, tx)
+	}
+	return s.MobBehaviour.Tick(e, tx)
+}
+
+// tickSheep performs the sheep specific logic of a tick.
+func (s *SheepBehaviour) tickSheep(m *Mob, tx *world.Tx) {
+	if s.breedCooldown > 0 {
+		s.breedCooldown--
+	}
+	if s.growUpTicks > 0 {
+		if s.growUpTicks--; s.growUpTicks == 0 {
+			m.updateState()
+		}
+	}
+	if s.loveTicks > 0 {
+		if s.loveTicks--; s.loveTicks == 0 {
+			m.updateState()
+		}
+	}
+	if s.eatTicks > 0 {
+		if s.eatTicks--; s.eatTicks == 4 {
+			s.eatGrass(m, tx)
+		}
+		return
+	}
+	if s.InLove() && s.breed(m, tx) {
+		return
+	}
+	if s.tempt(m, tx) {
+		return
+	}
+	// Babies eat grass much more often than adults, as eating grass makes
+	// them grow up faster.
+	chance := 1000
+	if s.Baby() {
+		chance = 50
+	}
+	if !s.Moving() && rand.IntN(chance) == 0 && s.grassAt(m, tx) {
+		s.eatTicks = sheepEatTicks
+		s.StopMoving()
+		for _, v := range tx.Viewers(m.Position()) {
+			v.ViewEntityAction(m, EatGrassAction{})
+		}
+		return
+	}
+	if !s.Moving() && rand.IntN(120) == 0 {
+		s.MoveTo(m.Position().Add(randomHorizontalOffset(6)), 1)
+	}
+}
+
+// grassAt checks if the sheep is standing in short grass or on top of a grass
+// block, either of which it may eat.
+func (s *SheepBehaviour) grassAt(m *Mob, tx *world.Tx) bool {
+	pos := cube.PosFromVec3(m.Position())
+	if g, ok := tx.Block(pos).(block.ShortGrass); ok && !g.Double {
+		return true
+	}
+	_, ok := tx.Block(pos.Side(cube.FaceDown)).(block.Grass)
+	return ok
+}
+
+// eatGrass makes the sheep eat the short grass it is standing in, or else the
+// grass block it is standing on, turning it into dirt. Eating grass regrows
+// the wool of the sheep and makes babies grow up faster.
+func (s *SheepBehaviour) eatGrass(m *Mob, tx *world.Tx) {
+	pos := cube.PosFromVec3(m.Position())
+	if g, ok := tx.Block(pos).(block.ShortGrass); ok && !g.Double {
+		tx.SetBlock(pos, nil, nil)
+	} else if _, ok := tx.Block(pos.Side(cube.FaceDown)).(block.Grass); ok {
+		tx.SetBlock(pos.Side(cube.FaceDown), block.Dirt{}, nil)
+	} else {
+		return
+	}
+	tx.EmitGameEvent(m.Position(), gameevent.Eat{}, m)
+	if s.Baby() {
+		s.growUpTicks = max(s.growUpTicks-1200, 1)
+	}
+	s.sheared = false
+	m.updateState()
+}
+
+// tempt makes the sheep follow the closest player holding wheat. False is
+// returned if no player close to the sheep holds wheat.
+func (s *SheepBehaviour) tempt(m *Mob, tx *world.Tx) bool {
+	p, ok := nearestEntity(m, tx, 10, func(e Living) bool {
+		g, ok := e.(interface{ GameMode() world.GameMode })
+		u, holder := e.(item.User)
+		if !ok || !holder || !g.GameMode().HasCollision() {
+			return false
+		}
+		main, off := u.HeldItems()
+		_, mainWheat := main.Item().(item.Wheat)
+		_, offWheat := off.Item().(item.Wheat)
+		return mainWheat || offWheat
+	})
+	if !ok {
+		return false
+	}
+	s.LookAt(EyePosition(p))
+	if p.Position().Sub(m.Position()).Len() > 2.5 {
+		s.MoveTo(p.Position(), 1)
+	} else {
+		s.StopMoving()
+	}
+	return true
+}
+
+// breed makes the sheep walk towards another sheep in love and spawns a baby
+// sheep once the two meet. False is returned if no other sheep in love was
+// found.
+func (s *SheepBehaviour) breed(m *Mob, tx *world.Tx) bool {
+	mate, ok := nearestEntity(m, tx, 8, func(e Living) bool {
+		if mob, ok := e.(*Mob); ok {
+			other, ok := mob.Behaviour().(*SheepBehaviour)
+			return ok && other.InLove() && !other.Baby()
+		}
+		return false
+	})
+	if !ok {
+		return false
+	}
+	if mate.Position().Sub(m.Position()).Len() > 1.5 {
+		s.MoveTo(mate.Position(), 1)
+		return true
+	}
+	other := mate.(*Mob).Behaviour().(*SheepBehaviour)
+	s.loveTicks, other.loveTicks = 0, 0
+	s.breedCooldown, other.breedCooldown = sheepBreedCooldown, sheepBreedCooldown
+	m.updateState()
+	mate.(*Mob).updateState()
+
+	opts := world.EntitySpawnOpts{Position: m.Position(), Rotation: cube.Rotation{rand.Float64() * 360}}
+	tx.AddEntity(opts.New(SheepType, SheepBehaviourConfig{Colour: mixSheepColours(s.colour, other.colour), Baby: true}))
+	for _, orb := range NewExperienceOrbs(m.Position(), 1+rand.IntN(7)) {
+		tx.AddEntity(orb)
+	}
+	return true
+}
+
+// sheepColourMixes holds the colours obtained by combining two dyes of
+// different colours, indexed by the two colours combined.
+var sheepColourMixes = map[[2]item.Colour]item.Colour{
+	{item.ColourRed(), item.ColourYellow()}:  item.ColourOrange(),
+	{item.ColourRed(), item.ColourWhite()}:   item.ColourPink(),
+	{item.ColourRed(), item.ColourBlue()}:    item.ColourPurple(),
+	{item.ColourBlue(), item.ColourWhite()}:  item.ColourLightBlue(),
+	{item.ColourBlue(), item.ColourGreen()}:  item.ColourCyan(),
+	{item.ColourGreen(), item.ColourWhite()}: item.ColourLime(),
+	{item.ColourBlack(), item.ColourWhite()}: item.ColourGrey(),
+	{item.ColourGrey(), item.ColourWhite()}:  item.ColourLightGrey(),
+	{item.ColourPurple(), item.ColourPink()}: item.ColourMagenta(),
+}
+
+// mixSheepColours returns the colour of a baby sheep bred from two sheep with
+// the colours passed. If the dyes of the two colours may be combined into a
+// dye of another colour, that colour is returned. Otherwise, the colour of
+// one of the parents is picked at random.
+func mixSheepColours(a, b item.Colour) item.Colour {
+	if a == b {
+		return a
+	}
+	if c, ok := sheepColourMixes[[2]item.Colour{a, b}]; ok {
+		return c
+	}
+	if c, ok := sheepColourMixes[[2]item.Colour{b, a}]; ok {
+		return c
+	}
+	if rand.IntN(2) == 0 {
+		return a
+	}
+	return b
+}
+
+// randomSheepColour returns the colour of a naturally spawned sheep.
+func randomSheepColour() item.Colour {
+	switch n := rand.IntN(100000); {
+	case n < 5000:
+		return item.ColourBlack()
+	case n < 10000:
+		return item.ColourGrey()
+	case n < 15000:
+		return item.ColourLightGrey()
+	case n < 18000:
+		return item.ColourBrown()
+	case n < 18164:
+		return item.ColourPink()
+	}
+	return item.ColourWhite()
+}
+
+// drops returns the items dropped by a sheep when it dies. Adult sheep drop
+// mutton and, unless they were sheared, one wool of their colour. Baby sheep
+// do not drop any items.
+func (s *SheepBehaviour) drops(m *Mob, _ world.DamageSource) []item.Stack {
+	if s.Baby() {
+		return nil
+	}
+	drops := []item.Stack{item.NewStack(item.Mutton{Cooked: m.OnFireDuration() > 0}, 1+rand.IntN(2))}
+	if !s.sheared {
+		drops = append(drops, item.NewStack(block.Wool{Colour: s.colour}, 1))
+	}
+	return drops
+}
+
+// SheepType is a world.EntityType implementation for sheep.
+var SheepType sheepType
+
+type sheepType struct{}
+
+func (sheepType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (sheepType) EncodeEntity() string { return "minecraft:sheep" }
+func (sheepType) BBox(e world.Entity) cube.BBox {
+	if m, ok := e.(*Mob); ok && m.Behaviour().(*SheepBehaviour).Baby() {
+		return cube.Box(-0.225, 0, -0.225, 0.225, 0.65, 0.225)
+	}
+	return cube.Box(-0.45, 0, -0.45, 0.45, 1.3, 0.45)
+}
+
+func (sheepType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	s := SheepBehaviourConfig{
+		Colour:  colourFromUint8(nbtconv.Uint8(m, "Color")),
+		Sheared: nbtconv.Bool(m, "Sheared"),
+	}.New()
+	s.MobBehaviour.decodeNBT(m)
+	if nbtconv.Bool(m, "IsBaby") {
+		s.growUpTicks = max(int(nbtconv.Int32(m, "GrowUpTicks")), 1)
+	}
+	s.breedCooldown = int(nbtconv.Int32(m, "BreedCooldown"))
+	data.Data = s
+}
+
+func (sheepType) EncodeNBT(data *world.EntityData) map[string]any {
+	s := data.Data.(*SheepBehaviour)
+	m := map[string]any{
+		"Color":         s.colour.Uint8(),
+		"Sheared":       boolByte(s.sheared),
+		"IsBaby":        boolByte(s.Baby()),
+		"GrowUpTicks":   int32(s.growUpTicks),
+		"BreedCooldown": int32(s.breedCooldown),
+	}
+	s.MobBehaviour.encodeNBT(m)
+	return m
+}
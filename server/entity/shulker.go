@@ -0,0 +1,336 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// NewShulker creates a new shulker. The shulker attaches itself to a solid
+// block next to the position it is spawned at, or to the block below it if
+// there is no such block.
+func NewShulker(opts world.EntitySpawnOpts) *world.EntityHandle {
+	conf := shulkerConf
+	conf.AttachFace = -1
+	return opts.New(ShulkerType, conf)
+}
+
+var shulkerConf = ShulkerBehaviourConfig{AttachFace: cube.FaceDown}
+
+// ShulkerBehaviourConfig holds optional parameters for a ShulkerBehaviour.
+type ShulkerBehaviourConfig struct {
+	// AttachFace is the face of the shulker pointing towards the block it is
+	// attached to. If set to -1, the shulker attaches itself to the first
+	// solid block found around it during its first tick.
+	AttachFace cube.Face
+}
+
+func (conf ShulkerBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a ShulkerBehaviour using the parameters in conf.
+func (conf ShulkerBehaviourConfig) New() *ShulkerBehaviour {
+	s := &ShulkerBehaviour{attachFace: conf.AttachFace, attackCooldown: 20}
+	s.MobBehaviour = MobBehaviourConfig{MaxHealth: 30, KnockBackResistance: 1, Flying: true, Drag: 1, Experience: 5, Drops: s.drops}.New()
+	return s
+}
+
+const (
+	// shulkerTargetRange is the distance within which shulkers look for
+	// players to shoot at.
+	shulkerTargetRange = 16
+	// shulkerPeekOpen is the peek amount of a shulker that is fully open,
+	// which it is while attacking.
+	shulkerPeekOpen = 100
+	// shulkerPeekIdle is the peek amount of a shulker that occasionally peeks
+	// out of its shell while it has no target.
+	shulkerPeekIdle = 30
+)
+
+// ShulkerBehaviour implements the behaviour of shulkers. Shulkers are attached
+// to a face of a block and never move, except for teleporting away when the
+// block they are attached to is broken or when they are hurt. They open their
+// shell to shoot homing shulker bullets at players, and are well protected by
+// their shell while it is closed.
+type ShulkerBehaviour struct {
+	*MobBehaviour
+
+	attachFace cube.Face
+	peek       int
+	peekTicks  int
+
+	target         *world.EntityHandle
+	attackCooldown int
+}
+
+// AttachFace returns the face of the shulker pointing towards the block that
+// it is attached to.
+func (s *ShulkerBehaviour) AttachFace() cube.Face {
+	if s.attachFace < 0 {
+		return cube.FaceDown
+	}
+	return s.attachFace
+}
+
+// Peek returns how far the shulker has opened its shell, ranging from 0
+// (closed) to 100 (fully open).
+func (s *ShulkerBehaviour) Peek() int {
+	return s.peek
+}
+
+// Closed checks if the shell of the shulker is fully closed.
+func (s *ShulkerBehaviour) Closed() bool {
+	return s.peek == 0
+}
+
+// Variant returns the colour of the shulker. Shulkers spawned by Dragonfly
+// are never dyed, which is variant 16.
+func (s *ShulkerBehaviour) Variant() int32 {
+	return 16
+}
+
+// Target returns the player that the shulker is currently shooting at, or nil
+// if it is not attacking any player.
+func (s *ShulkerBehaviour) Target() *world.EntityHandle {
+	return s.target
+}
+
+// DefencePoints returns the armour points of the shulker. A closed shell
+// provides 20 armour points, while an open shell provides none.
+func (s *ShulkerBehaviour) DefencePoints() float64 {
+	if s.Closed() {
+		return 20
+	}
+	return 0
+}
+
+// Hurt makes the shulker target its attacker. A shulker that is closed or
+// badly hurt may teleport away, and a shulker hit by the bullet of another
+// shulker may duplicate.
+func (s *ShulkerBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	if s.Dead() {
+		return
+	}
+	if attacker := damageSourceAttacker(src); attacker != nil && attacker.H() != m.H() {
+		if l, ok := attacker.(Living); ok && attackablePlayer(l) {
+			s.target = attacker.H()
+		}
+	}
+	if (s.Closed() || s.health.Health() < s.health.MaxHealth()/2) && rand.IntN(4) == 0 {
+		s.teleport(m, m.tx)
+		return
+	}
+	if p, ok := src.(ProjectileDamageSource); ok && p.Projectile != nil && p.Projectile.H().Type() == ShulkerBulletType {
+		s.hitByBullet(m, m.tx)
+	}
+}
+
+// hitByBullet makes the shulker teleport away, leaving a new shulker behind
+// at its old position. The more shulkers there are around, the less likely a
+// new one is spawned.
+func (s *ShulkerBehaviour) hitByBullet(m *Mob, tx *world.Tx) {
+	pos := m.Position()
+	if s.Closed() || !s.teleport(m, tx) {
+		return
+	}
+	n := 0
+	for e := range tx.EntitiesWithin(ShulkerType.BBox(m).Translate(pos).Grow(8)) {
+		if e.H().Type() == ShulkerType {
+			n++
+		}
+	}
+	if rand.Float64() < float64(n-1)/5 {
+		return
+	}
+	opts := world.EntitySpawnOpts{Position: pos}
+	tx.AddEntity(NewShulker(opts))
+}
+
+// Tick ticks the shulker, keeping it attached to its block, opening and
+// closing its shell and shooting at players.
+func (s *ShulkerBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !s.Dead() {
+		s.tickShulker(&Mob{Ent: e}, tx)
+	}
+	return s.MobBehaviour.Tick(e, tx)
+}
+
+// tickShulker performs the shulker specific logic of a tick.
+func (s *ShulkerBehaviour) tickShulker(m *Mob, tx *world.Tx) {
+	pos := cube.PosFromVec3(m.Position())
+	if s.attachFace < 0 || !shulkerCanAttach(pos, s.attachFace, tx) {
+		if face, ok := shulkerAttachableFace(pos, tx); ok {
+			s.attachFace = face
+			m.updateState()
+		} else if s.attachFace >= 0 && s.teleport(m, tx) {
+			return
+		} else if s.attachFace < 0 {
+			s.attachFace = cube.FaceDown
+		}
+	}
+	m.data.Pos, m.data.Vel = pos.Vec3Middle(), mgl64.Vec3{}
+
+	target := s.findTarget(m, tx)
+	peek := 0
+	switch {
+	case target != nil:
+		peek = shulkerPeekOpen
+		s.LookAt(EyePosition(target))
+		if s.attackCooldown--; s.attackCooldown <= 0 {
+			s.attackCooldown = 20 + rand.IntN(10)*10
+			s.shoot(m, target, tx)
+		}
+	case s.peekTicks > 0:
+		s.peekTicks--
+		peek = shulkerPeekIdle
+	case rand.IntN(40) == 0:
+		s.peekTicks = 20 * (rand.IntN(3) + 1)
+		peek = shulkerPeekIdle
+	}
+	if peek != s.peek {
+		s.peek = peek
+		m.updateState()
+	}
+}
+
+// findTarget returns the player that the shulker is attacking, looking for a
+// new player if the shulker is not attacking any player. Nil is returned if
+// no player was found.
+func (s *ShulkerBehaviour) findTarget(m *Mob, tx *world.Tx) world.Entity {
+	if s.target != nil {
+		e, ok := s.target.Entity(tx)
+		if l, living := e.(Living); ok && living && !l.Dead() && attackablePlayer(l) && e.Position().Sub(m.Position()).Len() <= shulkerTargetRange {
+			return e
+		}
+		s.target = nil
+	}
+	if m.Age()%time.Second != 0 {
+		return nil
+	}
+	if t, ok := nearestEntity(m, tx, shulkerTargetRange, func(e Living) bool {
+		return attackablePlayer(e) && lineOfSight(EyePosition(m), EyePosition(e), tx)
+	}); ok {
+		s.target, s.attackCooldown = t.H(), 20
+		return t
+	}
+	return nil
+}
+
+// shoot makes the shulker shoot a homing bullet at the target passed. The
+// bullet is spawned just outside the shell of the shulker, so that it does
+// not immediately hit the shulker itself.
+func (s *ShulkerBehaviour) shoot(m *Mob, target world.Entity, tx *world.Tx) {
+	offset := cube.Pos{}.Side(s.AttachFace().Opposite()).Vec3().Mul(0.7)
+	opts := world.EntitySpawnOpts{Position: m.Position().Add(mgl64.Vec3{0, 0.35}).Add(offset)}
+	tx.AddEntity(NewShulkerBullet(opts, m, target, s.AttachFace().Axis()))
+}
+
+// teleport attempts to teleport the shulker to a random position within 8
+// blocks that it can attach itself to. It returns true if the shulker was
+// teleported.
+func (s *ShulkerBehaviour) teleport(m *Mob, tx *world.Tx) bool {
+	pos := cube.PosFromVec3(m.Position())
+	for range 5 {
+		dest := pos.Add(cube.Pos{rand.IntN(17) - 8, rand.IntN(17) - 8, rand.IntN(17) - 8})
+		if dest.OutOfBounds(tx.Range()) || !shulkerFree(m, dest, tx) {
+			continue
+		}
+		face, ok := shulkerAttachableFace(dest, tx)
+		if !ok {
+			continue
+		}
+		tx.PlaySound(m.Position(), sound.Teleport{})
+		s.attachFace, s.peek, s.peekTicks, s.target = face, 0, 0, nil
+		m.Teleport(dest.Vec3Middle())
+		tx.PlaySound(m.Position(), sound.Teleport{})
+		return true
+	}
+	return false
+}
+
+// drops returns the items dropped by a shulker when it dies.
+func (s *ShulkerBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	if rand.IntN(2) == 0 {
+		return []item.Stack{item.NewStack(item.ShulkerShell{}, 1)}
+	}
+	return nil
+}
+
+// decodeNBT decodes the state of the shulker from the map passed.
+func (s *ShulkerBehaviour) decodeNBT(m map[string]any) {
+	s.MobBehaviour.decodeNBT(m)
+	if v, ok := m["AttachFace"].(uint8); ok && int(v) < len(cube.Faces()) {
+		s.attachFace = cube.Face(v)
+	}
+	if v, ok := m["Peek"].(uint8); ok {
+		s.peek = int(v)
+	}
+}
+
+// shulkerCanAttach checks if a shulker at the position passed can attach
+// itself to the block on the face passed.
+func shulkerCanAttach(pos cube.Pos, face cube.Face, tx *world.Tx) bool {
+	side := pos.Side(face)
+	return tx.Block(side).Model().FaceSolid(side, face.Opposite(), tx)
+}
+
+// shulkerAttachableFace returns a face of the position passed that a shulker
+// can attach itself to, preferring the block below it. False is returned if
+// there is no such face.
+func shulkerAttachableFace(pos cube.Pos, tx *world.Tx) (cube.Face, bool) {
+	for _, face := range cube.Faces() {
+		if shulkerCanAttach(pos, face, tx) {
+			return face, true
+		}
+	}
+	return 0, false
+}
+
+// shulkerFree checks if the position passed is empty and not occupied by
+// another shulker.
+func shulkerFree(m *Mob, pos cube.Pos, tx *world.Tx) bool {
+	if len(tx.Block(pos).Model().BBox(pos, tx)) > 0 {
+		return false
+	}
+	if _, ok := tx.Liquid(pos); ok {
+		return false
+	}
+	for e := range tx.EntitiesWithin(cube.Box(0, 0, 0, 1, 1, 1).Translate(pos.Vec3())) {
+		if e.H() != m.H() && e.H().Type() == ShulkerType {
+			return false
+		}
+	}
+	return true
+}
+
+// ShulkerType is a world.EntityType implementation for shulkers.
+var ShulkerType shulkerType
+
+type shulkerType struct{}
+
+func (shulkerType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (shulkerType) EncodeEntity() string { return "minecraft:shulker" }
+func (shulkerType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.5, 0, -0.5, 0.5, 1, 0.5)
+}
+
+func (shulkerType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	s := shulkerConf.New()
+	s.decodeNBT(m)
+	data.Data = s
+}
+
+func (shulkerType) EncodeNBT(data *world.EntityData) map[string]any {
+	s := data.Data.(*ShulkerBehaviour)
+	m := map[string]any{"AttachFace": uint8(s.AttachFace()), "Peek": uint8(s.peek)}
+	s.MobBehaviour.encodeNBT(m)
+	return m
+}
@@ -0,0 +1,187 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// NewShulkerBullet creates a shulker bullet shot by an owner that homes in on
+// the target passed. The bullet does not move along the axis passed during
+// its first steps, which is usually the axis of the face that the shulker
+// shooting it is attached to.
+func NewShulkerBullet(opts world.EntitySpawnOpts, owner, target world.Entity, axis cube.Axis) *world.EntityHandle {
+	conf := shulkerBulletConf
+	conf.Owner, conf.Target, conf.Axis = owner.H(), target.H(), axis
+	return opts.New(ShulkerBulletType, conf)
+}
+
+var shulkerBulletConf = ShulkerBulletBehaviourConfig{Axis: cube.Y}
+
+// ShulkerBulletBehaviourConfig holds optional parameters for a
+// ShulkerBulletBehaviour.
+type ShulkerBulletBehaviourConfig struct {
+	// Owner is the entity that shot the bullet.
+	Owner *world.EntityHandle
+	// Target is the entity that the bullet homes in on. If nil, the bullet
+	// falls down until it hits a block.
+	Target *world.EntityHandle
+	// Axis is the axis that the bullet does not move along when it picks its
+	// first direction.
+	Axis cube.Axis
+}
+
+func (conf ShulkerBulletBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a ShulkerBulletBehaviour using the parameters in conf.
+func (conf ShulkerBulletBehaviourConfig) New() *ShulkerBulletBehaviour {
+	b := &ShulkerBulletBehaviour{target: conf.Target, axis: conf.Axis}
+	b.ProjectileBehaviour = ProjectileBehaviourConfig{
+		Owner:       conf.Owner,
+		Damage:      -1,
+		Particle:    particle.HugeExplosion{},
+		Hit:         b.hit,
+		IgnoreOwner: true,
+	}.New()
+	return b
+}
+
+// shulkerBulletSpeed is the speed in blocks per tick that a shulker bullet
+// flies at.
+const shulkerBulletSpeed = 0.15
+
+// ShulkerBulletBehaviour implements the behaviour of shulker bullets. Shulker
+// bullets fly towards their target in a zig-zag pattern, moving along one axis
+// at a time. They give entities hit Levitation and break when hitting a block
+// or being attacked.
+type ShulkerBulletBehaviour struct {
+	*ProjectileBehaviour
+
+	target    *world.EntityHandle
+	axis      cube.Axis
+	steps     int
+	targetVel mgl64.Vec3
+}
+
+// Target returns the entity that the bullet homes in on, or nil if the bullet
+// has no target.
+func (b *ShulkerBulletBehaviour) Target() *world.EntityHandle {
+	return b.target
+}
+
+// Tick steers the bullet towards its target and moves it. Bullets without a
+// target fall down.
+func (b *ShulkerBulletBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	target, ok := b.target.Entity(tx)
+	if l, living := target.(Living); ok && living && !l.Dead() && !playingDead(target) {
+		if b.steps--; b.steps <= 0 {
+			b.selectDirection(e, target, tx)
+		}
+		for i := range 3 {
+			b.targetVel[i] = mgl64.Clamp(b.targetVel[i]*1.025, -1, 1)
+		}
+		e.data.Vel = e.data.Vel.Add(b.targetVel.Sub(e.data.Vel).Mul(0.2))
+	} else {
+		b.target = nil
+		e.data.Vel[1] -= 0.04
+	}
+	return b.ProjectileBehaviour.Tick(e, tx)
+}
+
+// selectDirection picks the next direction that the bullet moves in. Unless
+// the bullet is already close to its target, it moves a block along one of
+// the axes it did not move along previously, towards the target.
+func (b *ShulkerBulletBehaviour) selectDirection(e *Ent, target world.Entity, tx *world.Tx) {
+	pos := e.Position()
+	h := target.H().Type().BBox(target).Height() / 2
+	dest := cube.PosFromVec3(target.Position().Add(mgl64.Vec3{0, h}))
+	goal := mgl64.Vec3{float64(dest[0]) + 0.5, float64(dest[1]) + h, float64(dest[2]) + 0.5}
+
+	current := cube.PosFromVec3(pos)
+	if d := dest.Sub(current); d.Vec3().Len() >= 2 {
+		var faces []cube.Face
+		for _, f := range cube.Faces() {
+			v := cube.Pos{}.Side(f)
+			if f.Axis() == b.axis || v[0]*d[0]+v[1]*d[1]+v[2]*d[2] <= 0 {
+				// Only faces on other axes that point towards the target are
+				// considered.
+				continue
+			}
+			if side := current.Side(f); len(tx.Block(side).Model().BBox(side, tx)) == 0 {
+				faces = append(faces, f)
+			}
+		}
+		face := cube.Face(rand.IntN(len(cube.Faces())))
+		if len(faces) > 0 {
+			face = faces[rand.IntN(len(faces))]
+		}
+		b.axis = face.Axis()
+		goal = pos.Add(cube.Pos{}.Side(face).Vec3())
+	}
+	b.targetVel = mgl64.Vec3{}
+	if delta := goal.Sub(pos); delta.Len() > 0 {
+		b.targetVel = delta.Normalize().Mul(shulkerBulletSpeed)
+	}
+	b.steps = 10 + rand.IntN(5)*10
+}
+
+// hit hurts the entity hit by the bullet and gives it Levitation.
+func (b *ShulkerBulletBehaviour) hit(e *Ent, tx *world.Tx, target trace.Result) {
+	r, ok := target.(trace.EntityResult)
+	if !ok {
+		return
+	}
+	l, ok := r.Entity().(Living)
+	if !ok {
+		return
+	}
+	owner, _ := b.Owner().Entity(tx)
+	if _, vulnerable := l.Hurt(4, ProjectileDamageSource{Projectile: e, Owner: owner}); vulnerable {
+		l.AddEffect(effect.New(effect.Levitation, 1, time.Second*10))
+	}
+}
+
+// ShulkerBullet is a world.Entity implementation for shulker bullets. Shulker
+// bullets break when they are attacked.
+type ShulkerBullet struct {
+	*Ent
+}
+
+// Hurt breaks the shulker bullet, regardless of the amount of damage dealt to
+// it.
+func (s *ShulkerBullet) Hurt(dmg float64, _ world.DamageSource) (float64, bool) {
+	if dmg <= 0 {
+		return 0, false
+	}
+	s.tx.AddParticle(s.Position(), particle.HugeExplosion{})
+	_ = s.Close()
+	return dmg, true
+}
+
+// ShulkerBulletType is a world.EntityType implementation for shulker bullets.
+var ShulkerBulletType shulkerBulletType
+
+type shulkerBulletType struct{}
+
+func (shulkerBulletType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &ShulkerBullet{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (shulkerBulletType) EncodeEntity() string { return "minecraft:shulker_bullet" }
+func (shulkerBulletType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.15625, 0, -0.15625, 0.15625, 0.3125, 0.15625)
+}
+
+// DecodeNBT creates a shulker bullet without a target, as the target of a
+// bullet is not saved. Such a bullet falls down until it hits a block.
+func (shulkerBulletType) DecodeNBT(_ map[string]any, data *world.EntityData) {
+	data.Data = shulkerBulletConf.New()
+}
+func (shulkerBulletType) EncodeNBT(*world.EntityData) map[string]any { return nil }
@@ -0,0 +1,332 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// NewSkeleton creates a new skeleton holding a bow.
+func NewSkeleton(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(SkeletonType, skeletonConf)
+}
+
+// NewStray creates a new stray holding a bow. Strays are a variant of
+// skeletons that shoot arrows of slowness.
+func NewStray(opts world.EntitySpawnOpts) *world.EntityHandle {
+	conf := skeletonConf
+	conf.Stray = true
+	return opts.New(StrayType, conf)
+}
+
+var skeletonConf = SkeletonBehaviourConfig{}
+
+// SkeletonBehaviourConfig holds optional parameters for a SkeletonBehaviour.
+type SkeletonBehaviourConfig struct {
+	// Stray specifies if the skeleton is a stray. Strays shoot arrows that
+	// inflict slowness on the entities they hit.
+	Stray bool
+}
+
+func (conf SkeletonBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a SkeletonBehaviour using the parameters in conf.
+func (conf SkeletonBehaviourConfig) New() *SkeletonBehaviour {
+	s := &SkeletonBehaviour{stray: conf.Stray}
+	s.MobBehaviour = MobBehaviourConfig{MaxHealth: 20, Speed: 0.1, Experience: 5, Drops: s.drops, Equip: s.equip}.New()
+	s.Equipment().SetHeldItems(item.NewStack(item.Bow{}, 1), item.Stack{})
+	return s
+}
+
+const (
+	// skeletonMinAttackDist and skeletonMaxAttackDist are the distances to
+	// its target between which a skeleton strafes around it while shooting.
+	// Skeletons back off when they get closer than the minimum distance and
+	// approach their target when it is further away than the maximum.
+	skeletonMinAttackDist, skeletonMaxAttackDist = 8, 15
+)
+
+// SkeletonBehaviour implements the behaviour of skeletons and strays.
+// Skeletons shoot arrows at players while strafing around them and burn in
+// sunlight unless they wear a helmet.
+type SkeletonBehaviour struct {
+	*MobBehaviour
+
+	stray bool
+
+	target          *world.EntityHandle
+	drawTicks       int
+	cooldown        int
+	strafeTicks     int
+	strafeClockwise bool
+}
+
+// Stray checks if the skeleton is a stray.
+func (s *SkeletonBehaviour) Stray() bool {
+	return s.stray
+}
+
+// HeldItems returns the items held by the skeleton. By default, skeletons
+// hold a bow in their main hand.
+func (s *SkeletonBehaviour) HeldItems() (mainHand, offHand item.Stack) {
+	return s.Equipment().HeldItems()
+}
+
+// Armour returns the armour worn by the skeleton. Skeletons wearing a helmet
+// do not burn in sunlight.
+func (s *SkeletonBehaviour) Armour() *inventory.Armour {
+	return s.Equipment().Armour()
+}
+
+// UsingItem checks if the skeleton is currently drawing its bow.
+func (s *SkeletonBehaviour) UsingItem() bool {
+	return s.drawTicks > 0
+}
+
+// Target returns the entity that the skeleton is currently shooting at, or
+// nil if it is not attacking any entity.
+func (s *SkeletonBehaviour) Target() *world.EntityHandle {
+	return s.target
+}
+
+// Hurt makes the skeleton attack the entity that attacked it.
+func (s *SkeletonBehaviour) Hurt(_ *Mob, _ float64, src world.DamageSource) {
+	if attacker := damageSourceAttacker(src); attacker != nil && !skeleton(attacker) {
+		s.target = attacker.H()
+	}
+}
+
+// Tick ticks the skeleton, making it shoot at its target and burn in
+// sunlight.
+func (s *SkeletonBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !s.Dead() {
+		s.tickSkeleton(&Mob{Ent: e}, tx)
+	}
+	return s.MobBehaviour.Tick(e, tx)
+}
+
+// tickSkeleton performs the skeleton specific logic of a tick.
+func (s *SkeletonBehaviour) tickSkeleton(m *Mob, tx *world.Tx) {
+	// TODO: Skeletons should convert into strays after being stuck in powder
+	//  snow for 7 seconds once powder snow is implemented.
+	if s.Armour().Helmet().Empty() && inSunlight(m, tx) && m.OnFireDuration() <= 0 {
+		m.SetOnFire(time.Second * 8)
+	}
+	if s.cooldown > 0 {
+		s.cooldown--
+	}
+	target := s.findTarget(m, tx)
+	if target == nil {
+		s.stopDrawing(m)
+		if !s.Moving() && rand.IntN(120) == 0 {
+			s.MoveTo(m.Position().Add(randomHorizontalOffset(8)), 0.8)
+		}
+		return
+	}
+	s.LookAt(EyePosition(target))
+	dist := target.Position().Sub(m.Position()).Len()
+	s.strafe(m, target, dist)
+
+	if dist > skeletonMaxAttackDist {
+		s.stopDrawing(m)
+		return
+	}
+	held, _ := s.HeldItems()
+	if _, ok := held.Item().(item.Bow); !ok || s.cooldown > 0 {
+		return
+	}
+	if s.drawTicks++; s.drawTicks == 1 {
+		m.updateState()
+	} else if s.drawTicks >= 20 {
+		s.stopDrawing(m)
+		s.shoot(m, target, tx)
+		s.cooldown = 40
+		if tx.World().Difficulty() == world.DifficultyHard {
+			// Skeletons shoot twice as fast on hard difficulty.
+			s.cooldown = 20
+		}
+	}
+}
+
+// findTarget returns the current target of the skeleton, looking for a new
+// target if the skeleton currently has none. Nil is returned if no target
+// was found.
+func (s *SkeletonBehaviour) findTarget(m *Mob, tx *world.Tx) world.Entity {
+	if s.target != nil {
+		e, ok := s.target.Entity(tx)
+		if l, living := e.(Living); ok && living && !l.Dead() && e.Position().Sub(m.Position()).Len() <= 24 {
+			return e
+		}
+		s.target = nil
+	}
+	if m.Age()%(time.Second/2) != 0 {
+		return nil
+	}
+	if t, ok := nearestEntity(m, tx, 16, attackablePlayer); ok {
+		s.target = t.H()
+		return t
+	}
+	return nil
+}
+
+// strafe moves the skeleton so that it stays between the minimum and maximum
+// attack distance of its target, circling around the target while it is in
+// range.
+func (s *SkeletonBehaviour) strafe(m *Mob, target world.Entity, dist float64) {
+	if dist > skeletonMaxAttackDist {
+		s.MoveTo(target.Position(), 1)
+		return
+	}
+	if s.strafeTicks++; s.strafeTicks >= 20 {
+		s.strafeTicks = 0
+		if rand.Float64() < 0.3 {
+			s.strafeClockwise = !s.strafeClockwise
+		}
+	}
+	away := m.Position().Sub(target.Position())
+	away[1] = 0
+	if away.Len() == 0 {
+		return
+	}
+	away = away.Normalize()
+	side := mgl64.Vec3{-away[2], 0, away[0]}
+	if s.strafeClockwise {
+		side = side.Mul(-1)
+	}
+	dir := side
+	if dist < skeletonMinAttackDist {
+		dir = dir.Add(away)
+	}
+	s.MoveTo(m.Position().Add(dir.Mul(2)), 0.5)
+}
+
+// stopDrawing makes the skeleton stop drawing its bow.
+func (s *SkeletonBehaviour) stopDrawing(m *Mob) {
+	if s.drawTicks > 0 {
+		s.drawTicks = 0
+		m.updateState()
+	}
+}
+
+// shoot makes the skeleton shoot an arrow at the target passed. The accuracy
+// of the arrow depends on the difficulty of the world.
+func (s *SkeletonBehaviour) shoot(m *Mob, target world.Entity, tx *world.Tx) {
+	from := EyePosition(m)
+	delta := target.Position().Add(mgl64.Vec3{0, target.H().Type().BBox(target).Height() / 3}).Sub(from)
+	// Aim slightly above the target to compensate for the gravity of the
+	// arrow.
+	delta[1] += math.Hypot(delta[0], delta[2]) * 0.2
+	if delta.Len() == 0 {
+		return
+	}
+	difficulty, _ := world.DifficultyID(tx.World().Difficulty())
+	inaccuracy := float64(14-difficulty*4) * 0.0075
+	dir := delta.Normalize().Add(mgl64.Vec3{rand.NormFloat64() * inaccuracy, rand.NormFloat64() * inaccuracy, rand.NormFloat64() * inaccuracy})
+
+	tip := potion.Potion{}
+	if s.stray {
+		tip = potion.Slowness()
+	}
+	opts := world.EntitySpawnOpts{Position: from, Velocity: dir.Normalize().Mul(1.6), Rotation: m.Rotation()}
+	tx.AddEntity(NewTippedArrow(opts, m, tip))
+	tx.PlaySound(from, sound.BowShoot{})
+	tx.EmitGameEvent(from, gameevent.ProjectileShoot{}, m)
+}
+
+// drops returns the items dropped by a skeleton when it dies.
+func (s *SkeletonBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	var drops []item.Stack
+	if n := rand.IntN(3); n > 0 {
+		drops = append(drops, item.NewStack(item.Bone{}, n))
+	}
+	if n := rand.IntN(3); n > 0 {
+		drops = append(drops, item.NewStack(item.Arrow{}, n))
+	}
+	return drops
+}
+
+// equip gives the skeleton random armour when it spawns, depending on the
+// local difficulty.
+func (s *SkeletonBehaviour) equip(m *Mob, eq *Equipment, difficulty float64) {
+	equipRandomArmour(eq, difficulty, m.tx.World().Difficulty() == world.DifficultyHard)
+	eq.SetCanPickUpLoot(rand.Float64() < 0.55*difficulty)
+}
+
+// skeleton checks if the entity passed is a skeleton or a stray.
+func skeleton(e world.Entity) bool {
+	t := e.H().Type()
+	return t == SkeletonType || t == StrayType
+}
+
+// inSunlight checks if the mob passed is directly exposed to sunlight, which
+// is the case during the day if the mob has direct access to the sky and is
+// not in water or in the rain.
+func inSunlight(m *Mob, tx *world.Tx) bool {
+	if t := tx.World().Time() % 24000; t > 12000 && t < 23500 {
+		return false
+	}
+	pos := cube.PosFromVec3(EyePosition(m))
+	if _, ok := tx.Liquid(pos); ok || tx.RainingAt(pos) {
+		return false
+	}
+	return tx.SkyLight(pos) == 15
+}
+
+// SkeletonType is a world.EntityType implementation for skeletons.
+var SkeletonType skeletonType
+
+// StrayType is a world.EntityType implementation for strays.
+var StrayType strayType
+
+type skeletonType struct{}
+
+func (skeletonType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (skeletonType) EncodeEntity() string { return "minecraft:skeleton" }
+func (skeletonType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.99, 0.3)
+}
+
+func (skeletonType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	data.Data = decodeSkeletonNBT(m, false)
+}
+
+func (skeletonType) EncodeNBT(data *world.EntityData) map[string]any {
+	return encodeSkeletonNBT(data.Data.(*SkeletonBehaviour))
+}
+
+type strayType struct{ skeletonType }
+
+func (strayType) EncodeEntity() string { return "minecraft:stray" }
+
+func (strayType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	data.Data = decodeSkeletonNBT(m, true)
+}
+
+// decodeSkeletonNBT decodes a SkeletonBehaviour from the map passed.
+func decodeSkeletonNBT(m map[string]any, stray bool) *SkeletonBehaviour {
+	conf := skeletonConf
+	conf.Stray = stray
+	s := conf.New()
+	s.MobBehaviour.decodeNBT(m)
+	return s
+}
+
+// encodeSkeletonNBT encodes the SkeletonBehaviour passed into a map.
+func encodeSkeletonNBT(s *SkeletonBehaviour) map[string]any {
+	m := map[string]any{}
+	s.MobBehaviour.encodeNBT(m)
+	return m
+}
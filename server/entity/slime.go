@@ -0,0 +1,320 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// NewSlime creates a new slime with the size passed. The size must be 1, 2 or
+// 4.
+func NewSlime(opts world.EntitySpawnOpts, size int) *world.EntityHandle {
+	conf := slimeConf
+	conf.Size = size
+	return opts.New(SlimeType, conf)
+}
+
+// NewMagmaCube creates a new magma cube with the size passed. The size must be
+// 1, 2 or 4.
+func NewMagmaCube(opts world.EntitySpawnOpts, size int) *world.EntityHandle {
+	conf := slimeConf
+	conf.Size, conf.Magma = size, true
+	return opts.New(MagmaCubeType, conf)
+}
+
+var slimeConf = SlimeBehaviourConfig{}
+
+// SlimeBehaviourConfig holds optional parameters for a SlimeBehaviour.
+type SlimeBehaviourConfig struct {
+	// Size is the size of the slime: 1, 2 or 4. The size of a slime affects
+	// its bounding box, health and the damage it deals. If 0, a random size
+	// is picked.
+	Size int
+	// Magma specifies if the slime is a magma cube. Magma cubes are immune
+	// to fire and deal more damage than slimes.
+	Magma bool
+}
+
+func (conf SlimeBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a SlimeBehaviour using the parameters in conf.
+func (conf SlimeBehaviourConfig) New() *SlimeBehaviour {
+	if conf.Size == 0 {
+		conf.Size = 1 << rand.IntN(3)
+	}
+	if conf.Size != 1 && conf.Size != 2 && conf.Size != 4 {
+		panic("slime size must be 1, 2 or 4")
+	}
+	s := &SlimeBehaviour{size: conf.Size, magma: conf.Magma, jumpDelay: rand.IntN(20) + 10}
+	s.MobBehaviour = MobBehaviourConfig{
+		MaxHealth:  float64(conf.Size * conf.Size),
+		Speed:      0.2 + 0.1*float64(conf.Size),
+		FireImmune: conf.Magma,
+		Experience: conf.Size,
+		Drops:      s.drops,
+	}.New()
+	return s
+}
+
+// SlimeBehaviour implements the behaviour of slimes and magma cubes. Slimes
+// hop towards players close to them and damage them on contact. When a slime
+// larger than size 1 dies, it splits into 2 to 4 smaller slimes.
+type SlimeBehaviour struct {
+	*MobBehaviour
+
+	size  int
+	magma bool
+
+	target         *world.EntityHandle
+	jumpDelay      int
+	attackCooldown int
+}
+
+// Size returns the size of the slime: 1, 2 or 4.
+func (s *SlimeBehaviour) Size() int {
+	return s.size
+}
+
+// Scale returns the scale of the slime that is shown to viewers, which is
+// equal to its size.
+func (s *SlimeBehaviour) Scale() float64 {
+	return float64(s.size)
+}
+
+// Magma checks if the slime is a magma cube.
+func (s *SlimeBehaviour) Magma() bool {
+	return s.magma
+}
+
+// Target returns the entity that the slime is currently hopping towards, or
+// nil if it is not attacking any entity.
+func (s *SlimeBehaviour) Target() *world.EntityHandle {
+	return s.target
+}
+
+// Hurt splits the slime into smaller slimes if the damage killed it, and
+// makes it attack the entity that attacked it otherwise.
+func (s *SlimeBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	if s.Dead() {
+		s.split(m)
+		return
+	}
+	if attacker := damageSourceAttacker(src); attacker != nil {
+		if l, ok := attacker.(Living); ok && attackablePlayer(l) {
+			s.target = attacker.H()
+		}
+	}
+}
+
+// split spawns 2 to 4 slimes of half the size of the slime at its position,
+// unless the slime is already of the smallest size.
+func (s *SlimeBehaviour) split(m *Mob) {
+	if s.size <= 1 {
+		return
+	}
+	var t world.EntityType = SlimeType
+	if s.magma {
+		t = MagmaCubeType
+	}
+	conf := SlimeBehaviourConfig{Size: s.size / 2, Magma: s.magma}
+	offset := float64(s.size) / 4
+	for range 2 + rand.IntN(3) {
+		opts := world.EntitySpawnOpts{
+			Position: m.Position().Add(mgl64.Vec3{(rand.Float64()*2 - 1) * offset, 0.5, (rand.Float64()*2 - 1) * offset}),
+			Rotation: cube.Rotation{rand.Float64() * 360},
+			NameTag:  m.NameTag(),
+		}
+		m.tx.AddEntity(opts.New(t, conf))
+	}
+}
+
+// Tick ticks the slime, making it hop towards its target and damage it on
+// contact.
+func (s *SlimeBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !s.Dead() {
+		s.tickSlime(&Mob{Ent: e}, tx)
+	}
+	return s.MobBehaviour.Tick(e, tx)
+}
+
+// tickSlime performs the slime specific logic of a tick.
+func (s *SlimeBehaviour) tickSlime(m *Mob, tx *world.Tx) {
+	if s.attackCooldown > 0 {
+		s.attackCooldown--
+	}
+	target := s.findTarget(m, tx)
+	if target != nil {
+		s.LookAt(EyePosition(target))
+		s.attack(m, target)
+	}
+	if !m.OnGround() {
+		return
+	}
+	if s.jumpDelay > 0 {
+		s.jumpDelay--
+		return
+	}
+	s.jumpDelay = rand.IntN(20) + 10
+	var dir mgl64.Vec3
+	if target != nil {
+		// Slimes hop towards their target more frequently.
+		s.jumpDelay /= 3
+		if delta := target.Position().Sub(m.Position()); math.Hypot(delta[0], delta[2]) > 0 {
+			dir = mgl64.Vec3{delta[0], 0, delta[2]}.Normalize()
+		}
+	} else {
+		dir = m.Rotation().Vec3()
+		dir[1] = 0
+		if yaw := rand.Float64() * math.Pi * 2; rand.IntN(3) == 0 || dir.Len() == 0 {
+			dir = mgl64.Vec3{math.Cos(yaw), 0, math.Sin(yaw)}
+		}
+		dir = dir.Normalize()
+		m.data.Rot = rotationTowards(m.Position(), m.Position().Add(dir))
+	}
+	jump := 0.42
+	if s.magma {
+		// Magma cubes jump higher the larger they are.
+		jump += 0.1 * float64(s.size)
+	}
+	m.SetVelocity(dir.Mul(s.speed() * 0.5).Add(mgl64.Vec3{0, jump}))
+}
+
+// findTarget returns the current target of the slime, looking for a new
+// target if the slime currently has none. Nil is returned if no target was
+// found.
+func (s *SlimeBehaviour) findTarget(m *Mob, tx *world.Tx) world.Entity {
+	if s.target != nil {
+		e, ok := s.target.Entity(tx)
+		if l, living := e.(Living); ok && living && !l.Dead() && attackablePlayer(l) && e.Position().Sub(m.Position()).Len() <= 16 {
+			return e
+		}
+		s.target = nil
+	}
+	if m.Age()%(time.Second/2) != 0 {
+		return nil
+	}
+	if t, ok := nearestEntity(m, tx, 16, attackablePlayer); ok {
+		s.target = t.H()
+		return t
+	}
+	return nil
+}
+
+// attack damages the target passed if the slime is touching it. Slimes of
+// size 1 do not deal damage, but magma cubes of size 1 do.
+func (s *SlimeBehaviour) attack(m *Mob, target world.Entity) {
+	dmg := float64(s.size)
+	if s.magma {
+		dmg += 2
+	} else if s.size == 1 {
+		return
+	}
+	reach := 0.6*float64(s.size) + 0.4
+	if s.attackCooldown > 0 || target.Position().Sub(m.Position()).Len() > reach {
+		return
+	}
+	s.attackCooldown = 10
+	if l, ok := target.(Living); ok {
+		if _, vulnerable := l.Hurt(dmg, AttackDamageSource{Attacker: m}); vulnerable {
+			l.KnockBack(m.Position(), 0.4, 0.4)
+		}
+	}
+}
+
+// drops returns the items dropped by a slime when it dies. Only slimes of size
+// 1 drop slimeballs, while magma cubes larger than size 1 may drop magma
+// cream.
+func (s *SlimeBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	switch {
+	case s.magma && s.size > 1 && rand.IntN(4) == 0:
+		return []item.Stack{item.NewStack(item.MagmaCream{}, 1)}
+	case !s.magma && s.size == 1:
+		if n := rand.IntN(3); n > 0 {
+			return []item.Stack{item.NewStack(item.Slimeball{}, n)}
+		}
+	}
+	return nil
+}
+
+// SlimeChunk checks if the chunk at the position passed is a slime chunk in a
+// world with the seed passed. Slimes may spawn in slime chunks below Y=40,
+// regardless of the light level.
+func SlimeChunk(seed int64, pos world.ChunkPos) bool {
+	x, z := pos[0], pos[1]
+	s := seed + int64(x*x*0x4c1906) + int64(x*0x5ac0db) + int64(z*z)*0x4307a7 + int64(z*0x5f24f) ^ 0x3ad8025f
+
+	// The chunk is a slime chunk if the first integer in [0, 10) generated
+	// by a linear congruential generator seeded with s is 0.
+	const multiplier, mask = 0x5deece66d, 1<<48 - 1
+	state := (s ^ multiplier) & mask
+	for {
+		state = (state*multiplier + 0xb) & mask
+		bits := int32(state >> 17)
+		if val := bits % 10; bits-val+9 >= 0 {
+			return val == 0
+		}
+	}
+}
+
+// SlimeType is a world.EntityType implementation for slimes.
+var SlimeType slimeType
+
+// MagmaCubeType is a world.EntityType implementation for magma cubes.
+var MagmaCubeType magmaCubeType
+
+type slimeType struct{}
+
+func (slimeType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (slimeType) EncodeEntity() string { return "minecraft:slime" }
+func (slimeType) BBox(e world.Entity) cube.BBox {
+	size := 1.0
+	if m, ok := e.(*Mob); ok {
+		size = float64(m.Behaviour().(*SlimeBehaviour).size)
+	}
+	half := 0.26 * size
+	return cube.Box(-half, 0, -half, half, half*2, half)
+}
+
+func (slimeType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	data.Data = decodeSlimeNBT(m, false)
+}
+
+func (slimeType) EncodeNBT(data *world.EntityData) map[string]any {
+	return encodeSlimeNBT(data.Data.(*SlimeBehaviour))
+}
+
+type magmaCubeType struct{ slimeType }
+
+func (magmaCubeType) EncodeEntity() string { return "minecraft:magma_cube" }
+
+func (magmaCubeType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	data.Data = decodeSlimeNBT(m, true)
+}
+
+// decodeSlimeNBT decodes a SlimeBehaviour from the map passed.
+func decodeSlimeNBT(m map[string]any, magma bool) *SlimeBehaviour {
+	size := int(nbtconv.Uint8(m, "Size"))
+	if size != 1 && size != 2 && size != 4 {
+		size = 1
+	}
+	s := SlimeBehaviourConfig{Size: size, Magma: magma}.New()
+	s.MobBehaviour.decodeNBT(m)
+	return s
+}
+
+// encodeSlimeNBT encodes the SlimeBehaviour passed into a map.
+func encodeSlimeNBT(s *SlimeBehaviour) map[string]any {
+	m := map[string]any{"Size": uint8(s.size)}
+	s.MobBehaviour.encodeNBT(m)
+	return m
+}
@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+)
+
+// NewSnowGolem creates a new snow golem.
+func NewSnowGolem(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(SnowGolemType, snowGolemConf)
+}
+
+var snowGolemConf = SnowGolemBehaviourConfig{}
+
+// SnowGolemBehaviourConfig holds optional parameters for a
+// SnowGolemBehaviour.
+type SnowGolemBehaviourConfig struct{}
+
+func (conf SnowGolemBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a SnowGolemBehaviour using the parameters in conf.
+func (conf SnowGolemBehaviourConfig) New() *SnowGolemBehaviour {
+	g := &SnowGolemBehaviour{}
+	g.MobBehaviour = MobBehaviourConfig{MaxHealth: 4, Speed: 0.1, Drops: g.drops}.New()
+	return g
+}
+
+// SnowGolemBehaviour implements the behaviour of snow golems. Snow golems
+// throw snowballs at hostile mobs close to them, and take damage in water,
+// in the rain and in hot biomes.
+type SnowGolemBehaviour struct {
+	*MobBehaviour
+
+	target         *world.EntityHandle
+	attackCooldown int
+}
+
+// Target returns the entity that the snow golem is currently throwing
+// snowballs at, or nil if it is not attacking any entity.
+func (g *SnowGolemBehaviour) Target() *world.EntityHandle {
+	return g.target
+}
+
+// Tick ticks the snow golem, making it throw snowballs at its target and
+// melt when it is in a hot or wet place.
+func (g *SnowGolemBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !g.Dead() {
+		g.tickSnowGolem(&Mob{Ent: e}, tx)
+	}
+	return g.MobBehaviour.Tick(e, tx)
+}
+
+// tickSnowGolem performs the snow golem specific logic of a tick.
+func (g *SnowGolemBehaviour) tickSnowGolem(m *Mob, tx *world.Tx) {
+	pos := cube.PosFromVec3(m.Position())
+	if _, ok := tx.Liquid(pos); ok || tx.RainingAt(pos) {
+		m.Hurt(1, DrowningDamageSource{})
+	} else if tx.Biome(pos).Temperature() > 1 {
+		m.Hurt(1, block.FireDamageSource{})
+	}
+	if g.Dead() {
+		return
+	}
+	// TODO: Snow golems should leave a trail of snow layers in cold biomes
+	//  once snow layers are implemented.
+
+	if g.attackCooldown > 0 {
+		g.attackCooldown--
+	}
+	if g.target == nil {
+		if t, ok := nearestEntity(m, tx, 10, func(e Living) bool { return hostile(e) }); ok {
+			g.target = t.H()
+		} else if !g.Moving() && rand.IntN(120) == 0 {
+			g.MoveTo(m.Position().Add(randomHorizontalOffset(8)), 0.5)
+		}
+		return
+	}
+	t, ok := g.target.Entity(tx)
+	if !ok || t.Position().Sub(m.Position()).Len() > 10 {
+		g.target = nil
+		return
+	}
+	if l, ok := t.(Living); ok && l.Dead() {
+		g.target = nil
+		return
+	}
+	g.StopMoving()
+	g.LookAt(EyePosition(t))
+	if g.attackCooldown > 0 {
+		return
+	}
+	g.attackCooldown = 20
+	g.throwSnowball(m, t, tx)
+}
+
+// throwSnowball makes the snow golem throw a snowball at the target passed.
+func (g *SnowGolemBehaviour) throwSnowball(m *Mob, target world.Entity, tx *world.Tx) {
+	from := EyePosition(m)
+	delta := EyePosition(target).Sub(from).Sub(mgl64.Vec3{0, 0.5})
+	// Aim slightly above the target to compensate for the gravity of the
+	// snowball.
+	delta[1] += math.Hypot(delta[0], delta[2]) * 0.2
+	if delta.Len() == 0 {
+		return
+	}
+	opts := world.EntitySpawnOpts{Position: from, Velocity: delta.Normalize().Mul(1.6)}
+	tx.AddEntity(NewSnowball(opts, m))
+	tx.PlaySound(from, sound.ItemThrow{})
+}
+
+// drops returns the items dropped by a snow golem when it dies.
+func (g *SnowGolemBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	if n := rand.IntN(16); n > 0 {
+		return []item.Stack{item.NewStack(item.Snowball{}, n)}
+	}
+	return nil
+}
+
+// SnowGolemType is a world.EntityType implementation for snow golems.
+var SnowGolemType snowGolemType
+
+type snowGolemType struct{}
+
+func (snowGolemType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (snowGolemType) EncodeEntity() string { return "minecraft:snow_golem" }
+func (snowGolemType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.35, 0, -0.35, 0.35, 1.9, 0.35)
+}
+
+func (snowGolemType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	g := snowGolemConf.New()
+	g.MobBehaviour.decodeNBT(m)
+	data.Data = g
+}
+
+func (snowGolemType) EncodeNBT(data *world.EntityData) map[string]any {
+	m := map[string]any{}
+	data.Data.(*SnowGolemBehaviour).MobBehaviour.encodeNBT(m)
+	return m
+}
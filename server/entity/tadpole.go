@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewTadpole creates a new tadpole.
+func NewTadpole(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(TadpoleType, tadpoleConf)
+}
+
+var tadpoleConf TadpoleBehaviourConfig
+
+// TadpoleBehaviourConfig holds optional parameters for a TadpoleBehaviour.
+type TadpoleBehaviourConfig struct{}
+
+func (conf TadpoleBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a TadpoleBehaviour using the parameters in conf.
+func (conf TadpoleBehaviourConfig) New() *TadpoleBehaviour {
+	t := &TadpoleBehaviour{fish: fish{air: fishMaxAir}, growUpTicks: tadpoleGrowUpTicks}
+	t.MobBehaviour = MobBehaviourConfig{MaxHealth: 6, Speed: 0.02, Swimming: true}.New()
+	return t
+}
+
+// tadpoleGrowUpTicks is the amount of ticks it takes for a tadpole to grow up
+// into a frog.
+const tadpoleGrowUpTicks = 24000
+
+// TadpoleBehaviour implements the behaviour of tadpoles. Tadpoles hatch from
+// frogspawn and swim around in water like fish, until they grow up into a
+// frog after 20 minutes. The variant of the frog depends on the biome that the
+// tadpole grows up in. Feeding a tadpole slimeballs makes it grow up faster.
+type TadpoleBehaviour struct {
+	*MobBehaviour
+	fish
+
+	growUpTicks int
+}
+
+// Bucket allows the tadpole to be captured in a bucket of water.
+func (t *TadpoleBehaviour) Bucket(*Mob) (string, bool) {
+	return t.bucket("tadpole")
+}
+
+// Interact feeds the tadpole the slimeball held by the user, which makes it
+// grow up faster.
+func (t *TadpoleBehaviour) Interact(m *Mob, user item.User, _ *world.Tx) bool {
+	held, _ := user.HeldItems()
+	if _, ok := held.Item().(item.Slimeball); !ok {
+		return false
+	}
+	t.growUpTicks -= t.growUpTicks / 10
+	consumeHeldItem(user)
+	m.updateState()
+	return true
+}
+
+// Tick ticks the tadpole, making it swim around and grow up into a frog.
+func (t *TadpoleBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if t.Dead() {
+		return t.MobBehaviour.Tick(e, tx)
+	}
+	m := &Mob{Ent: e}
+	if t.growUpTicks--; t.growUpTicks <= 0 {
+		t.growUp(m, tx)
+		return nil
+	}
+	if !t.tickFish(m, t.MobBehaviour, tx) {
+		return nil
+	}
+	return t.MobBehaviour.Tick(e, tx)
+}
+
+// growUp replaces the tadpole with a frog of the variant that matches the
+// biome that the tadpole is in.
+func (t *TadpoleBehaviour) growUp(m *Mob, tx *world.Tx) {
+	f := FrogBehaviourConfig{Variant: FrogVariantAt(cube.PosFromVec3(m.Position()), tx)}.New()
+	transform(m, FrogType, f, tx)
+}
+
+// TadpoleType is a world.EntityType implementation for tadpoles.
+var TadpoleType tadpoleType
+
+type tadpoleType struct{}
+
+func (tadpoleType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (tadpoleType) EncodeEntity() string { return "minecraft:tadpole" }
+func (tadpoleType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.2, 0, -0.2, 0.2, 0.3, 0.2)
+}
+
+func (tadpoleType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	t := tadpoleConf.New()
+	t.MobBehaviour.decodeNBT(m)
+	t.fish.decodeNBT(m)
+	if _, ok := m["GrowUpTicks"]; ok {
+		t.growUpTicks = max(int(nbtconv.Int32(m, "GrowUpTicks")), 1)
+	}
+	data.Data = t
+}
+
+func (tadpoleType) EncodeNBT(data *world.EntityData) map[string]any {
+	t := data.Data.(*TadpoleBehaviour)
+	m := map[string]any{"GrowUpTicks": int32(t.growUpTicks)}
+	t.MobBehaviour.encodeNBT(m)
+	t.fish.encodeNBT(m)
+	return m
+}
@@ -0,0 +1,138 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+)
+
+// NewTropicalFish creates a new tropical fish with the variant passed.
+func NewTropicalFish(opts world.EntitySpawnOpts, variant TropicalFishVariant) *world.EntityHandle {
+	conf := tropicalFishConf
+	conf.Variant = variant
+	return opts.New(TropicalFishType, conf)
+}
+
+var tropicalFishConf TropicalFishBehaviourConfig
+
+// TropicalFishBehaviourConfig holds optional parameters for a
+// TropicalFishBehaviour.
+type TropicalFishBehaviourConfig struct {
+	// Variant is the pattern and colours of the tropical fish.
+	Variant TropicalFishVariant
+}
+
+func (conf TropicalFishBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a TropicalFishBehaviour using the parameters in conf.
+func (conf TropicalFishBehaviourConfig) New() *TropicalFishBehaviour {
+	if conf.Variant.Pattern > TropicalFishClayfish {
+		conf.Variant.Pattern = TropicalFishKob
+	}
+	t := &TropicalFishBehaviour{fish: fish{school: 8, air: fishMaxAir}, variant: conf.Variant}
+	t.MobBehaviour = MobBehaviourConfig{MaxHealth: 3, Speed: 0.03, Swimming: true, Experience: 1 + rand.IntN(3), Drops: t.drops}.New()
+	return t
+}
+
+// TropicalFishBehaviour implements the behaviour of a tropical fish. Tropical
+// fish swim around in warm oceans in schools of up to eight fish and come in
+// many different patterns and colours.
+type TropicalFishBehaviour struct {
+	*MobBehaviour
+	fish
+
+	variant TropicalFishVariant
+}
+
+// TropicalFishVariant returns the pattern and colours of the tropical fish.
+func (t *TropicalFishBehaviour) TropicalFishVariant() TropicalFishVariant {
+	return t.variant
+}
+
+// Variant returns the shape of the tropical fish: 0 for small tropical fish
+// and 1 for large ones.
+func (t *TropicalFishBehaviour) Variant() int32 {
+	return int32(t.variant.shape())
+}
+
+// MarkVariant returns the index of the pattern of the tropical fish within
+// the patterns of its shape.
+func (t *TropicalFishBehaviour) MarkVariant() int32 {
+	return int32(t.variant.Pattern % 6)
+}
+
+// Colours returns the colour of the body of the tropical fish and the colour
+// of its pattern.
+func (t *TropicalFishBehaviour) Colours() (base, pattern item.Colour) {
+	return t.variant.BaseColour, t.variant.PatternColour
+}
+
+// Bucket allows the tropical fish to be captured in a bucket of water.
+func (t *TropicalFishBehaviour) Bucket(*Mob) (string, bool) {
+	return t.bucket("tropical_fish")
+}
+
+// Tick ticks the tropical fish, making it swim around with its school.
+func (t *TropicalFishBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !t.Dead() && !t.tickFish(&Mob{Ent: e}, t.MobBehaviour, tx) {
+		return nil
+	}
+	return t.MobBehaviour.Tick(e, tx)
+}
+
+// drops returns the items dropped by a tropical fish when it dies.
+func (t *TropicalFishBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	return fishDrops(item.TropicalFish{})
+}
+
+// TropicalFishType is a world.EntityType implementation for tropical fish.
+var TropicalFishType tropicalFishType
+
+type tropicalFishType struct{}
+
+func (tropicalFishType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (tropicalFishType) EncodeEntity() string { return "minecraft:tropicalfish" }
+func (tropicalFishType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.25, 0, -0.25, 0.25, 0.4, 0.25)
+}
+
+func (tropicalFishType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := tropicalFishConf
+	conf.Variant = RandomTropicalFishVariant()
+	if _, ok := m["BucketVariantTag"]; ok {
+		// Buckets of tropical fish hold the packed variant, which is also
+		// used by clients to show the fish in the bucket.
+		if v, ok := TropicalFishVariantFromUint32(uint32(nbtconv.Int32(m, "BucketVariantTag"))); ok {
+			conf.Variant = v
+		}
+	} else if _, ok := m["Variant"]; ok {
+		if v, ok := tropicalFishVariantFrom(uint8(nbtconv.Int32(m, "Variant")), uint8(nbtconv.Int32(m, "MarkVariant")), nbtconv.Uint8(m, "Color"), nbtconv.Uint8(m, "Color2")); ok {
+			conf.Variant = v
+		}
+	}
+	t := conf.New()
+	t.MobBehaviour.decodeNBT(m)
+	t.fish.decodeNBT(m)
+	data.Data = t
+}
+
+func (tropicalFishType) EncodeNBT(data *world.EntityData) map[string]any {
+	t := data.Data.(*TropicalFishBehaviour)
+	m := map[string]any{
+		"Variant":          t.Variant(),
+		"MarkVariant":      t.MarkVariant(),
+		"Color":            t.variant.BaseColour.Uint8(),
+		"Color2":           t.variant.PatternColour.Uint8(),
+		"BucketVariantTag": int32(t.variant.Uint32()),
+	}
+	t.MobBehaviour.encodeNBT(m)
+	t.fish.encodeNBT(m)
+	return m
+}
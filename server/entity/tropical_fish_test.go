@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestTropicalFishVariantRoundTrip(t *testing.T) {
+	colours := item.Colours()
+	for pattern := TropicalFishKob; pattern <= TropicalFishClayfish; pattern++ {
+		v := TropicalFishVariant{Pattern: pattern, BaseColour: colours[int(pattern)], PatternColour: colours[len(colours)-1-int(pattern)]}
+		if unpacked, ok := TropicalFishVariantFromUint32(v.Uint32()); !ok || unpacked != v {
+			t.Errorf("expected variant %+v to be unpacked from %#x, got %+v", v, v.Uint32(), unpacked)
+		}
+
+		// Fish are decoded both from their own NBT and from the NBT of a
+		// bucket holding them, which only has the packed variant.
+		data := &world.EntityData{}
+		TropicalFishBehaviourConfig{Variant: v}.Apply(data)
+		m := TropicalFishType.EncodeNBT(data)
+		TropicalFishType.DecodeNBT(m, data)
+		if decoded := data.Data.(*TropicalFishBehaviour).TropicalFishVariant(); decoded != v {
+			t.Errorf("expected variant %+v to be decoded from NBT, got %+v", v, decoded)
+		}
+		TropicalFishType.DecodeNBT(map[string]any{"BucketVariantTag": m["BucketVariantTag"]}, data)
+		if decoded := data.Data.(*TropicalFishBehaviour).TropicalFishVariant(); decoded != v {
+			t.Errorf("expected variant %+v to be decoded from bucket NBT, got %+v", v, decoded)
+		}
+	}
+	// Only two shapes of tropical fish exist.
+	if _, ok := TropicalFishVariantFromUint32(2); ok {
+		t.Errorf("expected packed variant with an unknown shape to be invalid")
+	}
+}
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"math/rand/v2"
+)
+
+// TropicalFishPattern is the pattern on the body of a tropical fish. The
+// first six patterns are those of small tropical fish, while the last six are
+// those of large tropical fish.
+type TropicalFishPattern uint8
+
+const (
+	TropicalFishKob TropicalFishPattern = iota
+	TropicalFishSunstreak
+	TropicalFishSnooper
+	TropicalFishDasher
+	TropicalFishBrinely
+	TropicalFishSpotty
+	TropicalFishFlopper
+	TropicalFishStripey
+	TropicalFishGlitter
+	TropicalFishBlockfish
+	TropicalFishBetty
+	TropicalFishClayfish
+)
+
+// Large checks if the pattern is that of a large tropical fish.
+func (p TropicalFishPattern) Large() bool {
+	return p >= TropicalFishFlopper
+}
+
+// TropicalFishVariant is the appearance of a tropical fish: Its pattern and
+// the colours of its body and its pattern.
+type TropicalFishVariant struct {
+	// Pattern is the pattern on the body of the fish, which also determines
+	// its shape.
+	Pattern TropicalFishPattern
+	// BaseColour is the colour of the body of the fish.
+	BaseColour item.Colour
+	// PatternColour is the colour of the pattern on the body of the fish.
+	PatternColour item.Colour
+}
+
+// RandomTropicalFishVariant returns a TropicalFishVariant with a random
+// pattern and random colours.
+func RandomTropicalFishVariant() TropicalFishVariant {
+	colours := item.Colours()
+	return TropicalFishVariant{
+		Pattern:       TropicalFishPattern(rand.IntN(12)),
+		BaseColour:    colours[rand.IntN(len(colours))],
+		PatternColour: colours[rand.IntN(len(colours))],
+	}
+}
+
+// Uint32 packs the variant into a single integer, as stored in the bucket
+// that a tropical fish is captured in. The lowest byte holds the shape of the
+// fish, the second byte the index of its pattern for that shape, and the two
+// highest bytes the base and pattern colour respectively.
+func (v TropicalFishVariant) Uint32() uint32 {
+	return uint32(v.shape()) | uint32(v.Pattern%6)<<8 | uint32(v.BaseColour.Uint8())<<16 | uint32(v.PatternColour.Uint8())<<24
+}
+
+// TropicalFishVariantFromUint32 unpacks a variant packed using
+// TropicalFishVariant.Uint32. False is returned if the integer does not hold
+// a valid variant.
+func TropicalFishVariantFromUint32(packed uint32) (TropicalFishVariant, bool) {
+	return tropicalFishVariantFrom(uint8(packed), uint8(packed>>8), uint8(packed>>16), uint8(packed>>24))
+}
+
+// tropicalFishVariantFrom returns a variant from its shape, pattern index,
+// base colour and pattern colour. False is returned if any of these is out of
+// range.
+func tropicalFishVariantFrom(shape, pattern, base, patternColour uint8) (TropicalFishVariant, bool) {
+	colours := item.Colours()
+	if shape > 1 || pattern > 5 || int(base) >= len(colours) || int(patternColour) >= len(colours) {
+		return TropicalFishVariant{}, false
+	}
+	return TropicalFishVariant{
+		Pattern:       TropicalFishPattern(shape*6 + pattern),
+		BaseColour:    colours[base],
+		PatternColour: colours[patternColour],
+	}, true
+}
+
+// shape returns 0 for small tropical fish and 1 for large tropical fish.
+func (v TropicalFishVariant) shape() uint8 {
+	if v.Pattern.Large() {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,280 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// NewVillager creates a new villager with the profession passed. Villagers
+// created with ProfessionNone look for a job site nearby to claim a
+// profession.
+func NewVillager(opts world.EntitySpawnOpts, p VillagerProfession) *world.EntityHandle {
+	return opts.New(VillagerType, VillagerBehaviourConfig{Profession: p})
+}
+
+// villagerLevelExperience holds the experience a villager needs to reach
+// each level, indexed by the level minus one.
+var villagerLevelExperience = [...]int{0, 10, 70, 150, 250}
+
+// VillagerLevelExperience returns the total experience a villager needs to
+// reach the level passed, ranging from 1 (novice) to 5 (master).
+func VillagerLevelExperience(level int) int {
+	return villagerLevelExperience[min(max(level, 1), 5)-1]
+}
+
+// Trader represents an entity, typically a player, that is able to trade
+// with villagers.
+type Trader interface {
+	item.User
+	// OpenTrading opens the trading window of the villager passed.
+	OpenTrading(v *Mob)
+}
+
+// VillagerBehaviourConfig holds optional parameters for a VillagerBehaviour.
+type VillagerBehaviourConfig struct {
+	// Profession is the profession of the villager. If the profession has no
+	// job site registered nearby, the villager claims a job site of the
+	// profession when it finds one.
+	Profession VillagerProfession
+}
+
+func (conf VillagerBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a VillagerBehaviour using the parameters in conf.
+func (conf VillagerBehaviourConfig) New() *VillagerBehaviour {
+	v := &VillagerBehaviour{
+		MobBehaviour: MobBehaviourConfig{Speed: 0.1}.New(),
+		villagerData: villagerData{profession: conf.Profession, level: 1},
+	}
+	v.unlockOffers()
+	return v
+}
+
+// VillagerBehaviour implements the behaviour of villagers. Villagers with a
+// profession offer trades that depend on their profession, and unlock new
+// trades as they gain experience from trading.
+type VillagerBehaviour struct {
+	*MobBehaviour
+	villagerData
+
+	jobSite      *cube.Pos
+	customer     *world.EntityHandle
+	restockTicks int
+}
+
+// Customer returns the entity currently trading with the villager, or nil if
+// no entity is trading with it.
+func (v *VillagerBehaviour) Customer() *world.EntityHandle {
+	return v.customer
+}
+
+// Interact opens the trading window of the villager if the user is a Trader
+// and the villager has any offers.
+func (v *VillagerBehaviour) Interact(m *Mob, user item.User, _ *world.Tx) bool {
+	t, ok := user.(Trader)
+	if !ok || !v.profession.Trades() || len(v.offers) == 0 || (v.customer != nil && v.customer != user.H()) {
+		return false
+	}
+	v.customer = user.H()
+	v.StopMoving()
+	t.OpenTrading(m)
+	return true
+}
+
+// StopTrading stops the villager from trading with its current customer.
+func (v *VillagerBehaviour) StopTrading() {
+	v.customer = nil
+}
+
+// Trade completes the offer at the index passed the amount of times passed
+// for the customer of the villager. The villager gains experience from the
+// trade and may level up, unlocking new offers. Trade does not take the
+// inputs from or give the output to the customer. False is returned if the
+// offer does not exist or does not have enough uses left.
+func (v *VillagerBehaviour) Trade(m *Mob, index, times int, tx *world.Tx) bool {
+	if index < 0 || index >= len(v.offers) || times < 1 || v.offers[index].uses+times > v.offers[index].MaxUses {
+		return false
+	}
+	offer := &v.offers[index]
+	offer.uses += times
+	v.experience += offer.Experience * times
+
+	xp := 0
+	for range times {
+		xp += 3 + rand.IntN(4)
+	}
+	for v.level < 5 && v.experience >= VillagerLevelExperience(v.level+1) {
+		v.level++
+		v.unlockOffers()
+		m.AddEffect(effect.New(effect.Regeneration, 1, time.Second*10))
+		xp += 5
+	}
+	for _, orb := range NewExperienceOrbs(m.Position().Add(mgl64.Vec3{0, 0.5}), xp) {
+		tx.AddEntity(orb)
+	}
+	m.updateState()
+	return true
+}
+
+// Hurt converts the villager into a zombie villager if it is killed by a
+// zombie. The chance of this happening depends on the difficulty of the
+// world: Villagers are never converted on easy difficulty, half of the time
+// on normal difficulty and always on hard difficulty.
+func (v *VillagerBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	s, ok := src.(AttackDamageSource)
+	if !ok || !v.Dead() || s.Attacker == nil || !zombie(s.Attacker) {
+		return
+	}
+	switch m.tx.World().Difficulty() {
+	case world.DifficultyNormal:
+		if rand.IntN(2) == 0 {
+			return
+		}
+	case world.DifficultyHard:
+	default:
+		return
+	}
+	infectVillager(m, v, m.tx)
+}
+
+// Tick ticks the villager, making it look for a job site, restock its offers
+// and wander around when it is not trading.
+func (v *VillagerBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !v.Dead() {
+		v.tickVillager(&Mob{Ent: e}, tx)
+	}
+	return v.MobBehaviour.Tick(e, tx)
+}
+
+// tickVillager performs the villager specific logic of a tick.
+func (v *VillagerBehaviour) tickVillager(m *Mob, tx *world.Tx) {
+	pos := m.Position()
+	if v.customer != nil {
+		c, ok := v.customer.Entity(tx)
+		if !ok || c.Position().Sub(pos).Len() > 16 {
+			v.customer = nil
+		} else {
+			v.StopMoving()
+			v.LookAt(EyePosition(c))
+		}
+	}
+	if v.restockTicks++; v.restockTicks >= 6000 && v.jobSite != nil {
+		v.restockTicks = 0
+		for i := range v.offers {
+			v.offers[i].restock()
+		}
+	}
+	if m.Age()%(time.Second*5) == 0 {
+		v.updateJobSite(m, tx)
+	}
+	if v.customer == nil && !v.Moving() && rand.IntN(120) == 0 {
+		dest := pos.Add(mgl64.Vec3{rand.Float64()*16 - 8, 0, rand.Float64()*16 - 8})
+		if v.jobSite != nil && v.jobSite.Vec3Centre().Sub(pos).Len() > 16 {
+			dest = v.jobSite.Vec3Centre()
+		}
+		v.MoveTo(dest, 0.5)
+	}
+}
+
+// updateJobSite verifies that the job site of the villager still exists, or
+// looks for a new job site if the villager does not have one. Only blocks in
+// loaded chunks are checked, so that villagers never load or generate chunks.
+func (v *VillagerBehaviour) updateJobSite(m *Mob, tx *world.Tx) {
+	if v.jobSite != nil {
+		if b, ok := tx.LoadedBlock(*v.jobSite); !ok || v.profession.JobSite(b) {
+			// A job site in a chunk that is not loaded is kept until it can
+			// be checked again.
+			return
+		}
+		v.jobSite = nil
+		if v.experience == 0 {
+			// Villagers that have never traded lose their profession when
+			// their job site is removed.
+			v.profession, v.level, v.offers = ProfessionNone(), 1, nil
+			m.updateState()
+		}
+	}
+	if v.profession == ProfessionNitwit() {
+		return
+	}
+	centre := cube.PosFromVec3(m.Position())
+	for x := -8; x <= 8; x++ {
+		for y := -4; y <= 4; y++ {
+			for z := -8; z <= 8; z++ {
+				pos := centre.Add(cube.Pos{x, y, z})
+				b, loaded := tx.LoadedBlock(pos)
+				if !loaded {
+					continue
+				}
+				p, ok := professionJobSite(b)
+				if !ok || (v.profession != ProfessionNone() && p != v.profession) || jobSiteClaimed(tx, pos) {
+					continue
+				}
+				v.jobSite = &pos
+				if v.profession == ProfessionNone() {
+					v.profession = p
+					v.unlockOffers()
+					m.updateState()
+				}
+				return
+			}
+		}
+	}
+}
+
+// jobSiteClaimed checks if a villager near the position passed has already
+// claimed it as its job site.
+func jobSiteClaimed(tx *world.Tx, pos cube.Pos) bool {
+	for e := range tx.EntitiesWithin(cube.Box(-48, -48, -48, 48, 48, 48).Translate(pos.Vec3())) {
+		if m, ok := e.(*Mob); ok {
+			if v, ok := m.Behaviour().(*VillagerBehaviour); ok && v.jobSite != nil && *v.jobSite == pos {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// VillagerType is a world.EntityType implementation for villagers.
+var VillagerType villagerType
+
+type villagerType struct{}
+
+func (villagerType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (villagerType) EncodeEntity() string { return "minecraft:villager_v2" }
+func (villagerType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.9, 0.3)
+}
+
+func (villagerType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	v := VillagerBehaviourConfig{}.New()
+	v.MobBehaviour.decodeNBT(m)
+	v.villagerData.decodeNBT(m)
+	if _, ok := m["JobSite"]; ok {
+		pos := nbtconv.Pos(m, "JobSite")
+		v.jobSite = &pos
+	}
+	data.Data = v
+}
+
+func (villagerType) EncodeNBT(data *world.EntityData) map[string]any {
+	v := data.Data.(*VillagerBehaviour)
+	m := map[string]any{}
+	if v.jobSite != nil {
+		m["JobSite"] = nbtconv.PosToInt32Slice(*v.jobSite)
+	}
+	v.MobBehaviour.encodeNBT(m)
+	v.villagerData.encodeNBT(m)
+	return m
+}
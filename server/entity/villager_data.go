@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+	"maps"
+	"math/rand/v2"
+	"slices"
+)
+
+// villagerData holds the trading related data of a villager. It is shared by
+// villagers and zombie villagers, so that a villager keeps its profession and
+// trades when it is converted into a zombie villager and cured again.
+type villagerData struct {
+	profession        VillagerProfession
+	level, experience int
+	offers            []TradeOffer
+	// reputation holds the reputation that players have with the villager,
+	// indexed by their UUID. Reputation is gained by curing the villager and
+	// results in a discount on all of its offers.
+	reputation map[uuid.UUID]int
+}
+
+// cureReputation is the reputation a player gains with a villager by curing
+// it. maxReputation is the maximum reputation that a player may have.
+const (
+	cureReputation = 125
+	maxReputation  = 300
+)
+
+// Profession returns the current profession of the villager.
+func (v *villagerData) Profession() VillagerProfession {
+	return v.profession
+}
+
+// Level returns the current level of the villager, ranging from 1 (novice) to
+// 5 (master).
+func (v *villagerData) Level() int {
+	return v.level
+}
+
+// Experience returns the experience the villager has gained from trading.
+func (v *villagerData) Experience() int {
+	return v.experience
+}
+
+// Offers returns all trade offers that the villager currently has unlocked.
+func (v *villagerData) Offers() []TradeOffer {
+	return slices.Clone(v.offers)
+}
+
+// Reputation returns the reputation that the customer passed has with the
+// villager. The reputation is subtracted from the price of every offer,
+// multiplied by the PriceMultiplier of the offer.
+func (v *villagerData) Reputation(customer world.Entity) int {
+	return v.reputation[customer.H().UUID()]
+}
+
+// Variant returns the profession of the villager as its variant, which
+// determines what the villager looks like.
+func (v *villagerData) Variant() int32 {
+	return int32(v.profession.Uint8())
+}
+
+// TradeTier returns the zero-based trade tier of the villager.
+func (v *villagerData) TradeTier() int {
+	return v.level - 1
+}
+
+// TradeExperience returns the experience of the villager.
+func (v *villagerData) TradeExperience() int {
+	return v.experience
+}
+
+// cure adds the reputation gained by curing the villager to the player with
+// the UUID passed.
+func (v *villagerData) cure(id uuid.UUID) {
+	if v.reputation == nil {
+		v.reputation = map[uuid.UUID]int{}
+	}
+	v.reputation[id] = min(v.reputation[id]+cureReputation, maxReputation)
+}
+
+// unlockOffers adds two random trades of the current tier of the villager to
+// its offers.
+func (v *villagerData) unlockOffers() {
+	if !v.profession.Trades() {
+		return
+	}
+	trades := VillagerTrades(v.profession, v.level)
+	rand.Shuffle(len(trades), func(i, j int) {
+		trades[i], trades[j] = trades[j], trades[i]
+	})
+	for _, t := range trades[:min(len(trades), 2)] {
+		v.offers = append(v.offers, TradeOffer{VillagerTrade: t, Tier: v.level})
+	}
+}
+
+// clone returns a deep copy of the villager data.
+func (v *villagerData) clone() villagerData {
+	c := *v
+	c.offers = slices.Clone(v.offers)
+	c.reputation = maps.Clone(v.reputation)
+	return c
+}
+
+// encodeNBT encodes the villager data into the map passed.
+func (v *villagerData) encodeNBT(m map[string]any) {
+	offers := make([]any, 0, len(v.offers))
+	for _, o := range v.offers {
+		offers = append(offers, o.encodeNBT())
+	}
+	gossips := make([]any, 0, len(v.reputation))
+	for id, rep := range v.reputation {
+		gossips = append(gossips, map[string]any{"PlayerUUID": id.String(), "Reputation": int32(rep)})
+	}
+	m["Variant"] = int32(v.profession.Uint8())
+	m["TradeTier"] = int32(v.level - 1)
+	m["TradeExperience"] = int32(v.experience)
+	m["Offers"] = map[string]any{"Recipes": offers}
+	m["Gossips"] = gossips
+}
+
+// decodeNBT decodes the villager data from the map passed.
+func (v *villagerData) decodeNBT(m map[string]any) {
+	v.profession = VillagerProfession{profession(nbtconv.Int32(m, "Variant"))}
+	v.level = max(int(nbtconv.Int32(m, "TradeTier"))+1, 1)
+	v.experience = int(nbtconv.Int32(m, "TradeExperience"))
+	v.offers = nil
+	if offers, ok := m["Offers"].(map[string]any); ok {
+		for _, o := range nbtconv.Slice(offers, "Recipes") {
+			if om, ok := o.(map[string]any); ok {
+				v.offers = append(v.offers, decodeTradeOffer(om))
+			}
+		}
+	}
+	for _, g := range nbtconv.Slice(m, "Gossips") {
+		gm, ok := g.(map[string]any)
+		if !ok {
+			continue
+		}
+		if id, err := uuid.Parse(nbtconv.String(gm, "PlayerUUID")); err == nil {
+			if v.reputation == nil {
+				v.reputation = map[uuid.UUID]int{}
+			}
+			v.reputation[id] = int(nbtconv.Int32(gm, "Reputation"))
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// VillagerProfession represents the profession of a villager. The profession
+// of a villager determines the trades it offers. Villagers obtain a
+// profession by claiming a job site block nearby.
+type VillagerProfession struct {
+	profession
+}
+
+// ProfessionNone returns the profession of a villager that has not yet
+// claimed a job site.
+func ProfessionNone() VillagerProfession {
+	return VillagerProfession{0}
+}
+
+// ProfessionFarmer returns the farmer profession. Its job site is a composter.
+func ProfessionFarmer() VillagerProfession {
+	return VillagerProfession{1}
+}
+
+// ProfessionFisherman returns the fisherman profession. Its job site is a
+// barrel.
+func ProfessionFisherman() VillagerProfession {
+	return VillagerProfession{2}
+}
+
+// ProfessionShepherd returns the shepherd profession. Its job site is a loom.
+func ProfessionShepherd() VillagerProfession {
+	return VillagerProfession{3}
+}
+
+// ProfessionFletcher returns the fletcher profession. Its job site is a
+// fletching table.
+func ProfessionFletcher() VillagerProfession {
+	return VillagerProfession{4}
+}
+
+// ProfessionLibrarian returns the librarian profession. Its job site is a
+// lectern.
+func ProfessionLibrarian() VillagerProfession {
+	return VillagerProfession{5}
+}
+
+// ProfessionCartographer returns the cartographer profession. Its job site is
+// a cartography table.
+func ProfessionCartographer() VillagerProfession {
+	return VillagerProfession{6}
+}
+
+// ProfessionCleric returns the cleric profession. Its job site is a brewing
+// stand.
+func ProfessionCleric() VillagerProfession {
+	return VillagerProfession{7}
+}
+
+// ProfessionArmourer returns the armourer profession. Its job site is a blast
+// furnace.
+func ProfessionArmourer() VillagerProfession {
+	return VillagerProfession{8}
+}
+
+// ProfessionWeaponsmith returns the weaponsmith profession. Its job site is a
+// grindstone.
+func ProfessionWeaponsmith() VillagerProfession {
+	return VillagerProfession{9}
+}
+
+// ProfessionToolsmith returns the toolsmith profession. Its job site is a
+// smithing table.
+func ProfessionToolsmith() VillagerProfession {
+	return VillagerProfession{10}
+}
+
+// ProfessionButcher returns the butcher profession. Its job site is a smoker.
+func ProfessionButcher() VillagerProfession {
+	return VillagerProfession{11}
+}
+
+// ProfessionLeatherworker returns the leatherworker profession. Its job site
+// is a cauldron.
+func ProfessionLeatherworker() VillagerProfession {
+	return VillagerProfession{12}
+}
+
+// ProfessionMason returns the mason profession. Its job site is a
+// stonecutter.
+func ProfessionMason() VillagerProfession {
+	return VillagerProfession{13}
+}
+
+// ProfessionNitwit returns the nitwit profession. Nitwits never claim a job
+// site and do not trade.
+func ProfessionNitwit() VillagerProfession {
+	return VillagerProfession{14}
+}
+
+// VillagerProfessions returns a list of all villager professions.
+func VillagerProfessions() []VillagerProfession {
+	return []VillagerProfession{ProfessionNone(), ProfessionFarmer(), ProfessionFisherman(), ProfessionShepherd(),
+		ProfessionFletcher(), ProfessionLibrarian(), ProfessionCartographer(), ProfessionCleric(), ProfessionArmourer(),
+		ProfessionWeaponsmith(), ProfessionToolsmith(), ProfessionButcher(), ProfessionLeatherworker(),
+		ProfessionMason(), ProfessionNitwit()}
+}
+
+type profession uint8
+
+// Uint8 returns the profession as a uint8.
+func (p profession) Uint8() uint8 {
+	return uint8(p)
+}
+
+// Trades checks if villagers with this profession are able to trade.
+func (p profession) Trades() bool {
+	return p != ProfessionNone().profession && p != ProfessionNitwit().profession
+}
+
+// JobSite checks if the block passed is a job site of the profession.
+func (p profession) JobSite(b world.Block) bool {
+	switch b.(type) {
+	case block.Composter:
+		return p == ProfessionFarmer().profession
+	case block.Barrel:
+		return p == ProfessionFisherman().profession
+	case block.Loom:
+		return p == ProfessionShepherd().profession
+	case block.FletchingTable:
+		return p == ProfessionFletcher().profession
+	case block.Lectern:
+		return p == ProfessionLibrarian().profession
+	case block.BrewingStand:
+		return p == ProfessionCleric().profession
+	case block.BlastFurnace:
+		return p == ProfessionArmourer().profession
+	case block.Grindstone:
+		return p == ProfessionWeaponsmith().profession
+	case block.SmithingTable:
+		return p == ProfessionToolsmith().profession
+	case block.Smoker:
+		return p == ProfessionButcher().profession
+	case block.Stonecutter:
+		return p == ProfessionMason().profession
+	}
+	// TODO: Cartography tables and cauldrons are not yet implemented.
+	return false
+}
+
+// Name returns the display name of the profession.
+func (p profession) Name() string {
+	switch p {
+	case 0:
+		return "Villager"
+	case 1:
+		return "Farmer"
+	case 2:
+		return "Fisherman"
+	case 3:
+		return "Shepherd"
+	case 4:
+		return "Fletcher"
+	case 5:
+		return "Librarian"
+	case 6:
+		return "Cartographer"
+	case 7:
+		return "Cleric"
+	case 8:
+		return "Armourer"
+	case 9:
+		return "Weaponsmith"
+	case 10:
+		return "Toolsmith"
+	case 11:
+		return "Butcher"
+	case 12:
+		return "Leatherworker"
+	case 13:
+		return "Mason"
+	case 14:
+		return "Nitwit"
+	}
+	panic("unknown villager profession")
+}
+
+// String ...
+func (p profession) String() string {
+	switch p {
+	case 0:
+		return "none"
+	case 1:
+		return "farmer"
+	case 2:
+		return "fisherman"
+	case 3:
+		return "shepherd"
+	case 4:
+		return "fletcher"
+	case 5:
+		return "librarian"
+	case 6:
+		return "cartographer"
+	case 7:
+		return "cleric"
+	case 8:
+		return "armorer"
+	case 9:
+		return "weaponsmith"
+	case 10:
+		return "toolsmith"
+	case 11:
+		return "butcher"
+	case 12:
+		return "leatherworker"
+	case 13:
+		return "mason"
+	case 14:
+		return "nitwit"
+	}
+	panic("unknown villager profession")
+}
+
+// professionJobSite returns the profession that claims the block passed as
+// its job site, if any.
+func professionJobSite(b world.Block) (VillagerProfession, bool) {
+	for _, p := range VillagerProfessions() {
+		if p.JobSite(b) {
+			return p, true
+		}
+	}
+	return VillagerProfession{}, false
+}
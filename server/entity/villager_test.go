@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/generator"
+)
+
+func TestVillagerClaimsJobSite(t *testing.T) {
+	flat := generator.NewFlat(biome.Plains{}, []world.Block{block.Stone{}})
+	w := world.Config{Entities: DefaultRegistry, Generator: flat}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		pos := cube.Pos{5, -63, 5}
+		tx.SetBlock(pos, block.FletchingTable{}, nil)
+		m := tx.AddEntity(NewVillager(world.EntitySpawnOpts{Position: cube.Pos{8, -63, 8}.Vec3Middle()}, ProfessionNone())).(*Mob)
+		v := m.Behaviour().(*VillagerBehaviour)
+
+		v.updateJobSite(m, tx)
+		if v.jobSite == nil || *v.jobSite != pos {
+			t.Errorf("expected villager to claim the fletching table at %v, got %v", pos, v.jobSite)
+			return
+		}
+		if v.profession != ProfessionFletcher() {
+			t.Errorf("expected villager to become a fletcher, got profession %v", v.profession.Uint8())
+		}
+	})
+}
+
+func TestVillagerJobSiteDoesNotLoadChunks(t *testing.T) {
+	flat := generator.NewFlat(biome.Plains{}, []world.Block{block.Stone{}})
+	w := world.Config{Entities: DefaultRegistry, Generator: flat}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		// The villager is in the corner of the chunk, so that it searches the
+		// neighbouring chunks, which are not loaded, for a job site too.
+		m := tx.AddEntity(NewVillager(world.EntitySpawnOpts{Position: cube.Pos{1, -63, 1}.Vec3Middle()}, ProfessionNone())).(*Mob)
+		m.Behaviour().(*VillagerBehaviour).updateJobSite(m, tx)
+
+		for _, pos := range []cube.Pos{{-1, -63, 1}, {1, -63, -1}, {-1, -63, -1}} {
+			if _, ok := tx.LoadedBlock(pos); ok {
+				t.Errorf("expected chunk of %v not to be loaded when looking for a job site", pos)
+			}
+		}
+	})
+}
@@ -0,0 +1,317 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"math"
+	"slices"
+)
+
+// VillagerTrade is a trade that villagers of a specific profession may offer
+// to their customers. Trades are registered per profession and tier using
+// RegisterVillagerTrade.
+type VillagerTrade struct {
+	// Input is the item that the customer pays with. Its count is the base
+	// price of the trade, which is adjusted by demand and discounts.
+	Input item.Stack
+	// SecondInput is an optional second item that the customer must pay with.
+	// Unlike Input, its count is never adjusted.
+	SecondInput item.Stack
+	// Output is the item that the customer receives from the trade.
+	Output item.Stack
+	// MaxUses is the amount of times the trade may be used before the villager
+	// must restock.
+	MaxUses int
+	// Experience is the amount of experience the villager gains every time
+	// the trade is used.
+	Experience int
+	// PriceMultiplier specifies how strongly the price of the trade is
+	// affected by demand.
+	PriceMultiplier float64
+}
+
+// TradeOffer is a VillagerTrade offered by a specific villager. Next to the
+// trade itself, a TradeOffer tracks how often it was used since the villager
+// last restocked and how much demand there is for it.
+type TradeOffer struct {
+	VillagerTrade
+	// Tier is the tier of the villager at which the offer was unlocked,
+	// ranging from 1 (novice) to 5 (master).
+	Tier int
+
+	uses, demand int
+}
+
+// Uses returns the amount of times the offer was used since the villager
+// last restocked.
+func (o TradeOffer) Uses() int {
+	return o.uses
+}
+
+// Demand returns the current demand for the offer. Demand increases if the
+// offer is used often and raises the price of the offer.
+func (o TradeOffer) Demand() int {
+	return o.demand
+}
+
+// Disabled checks if the offer is out of stock, meaning it was used the
+// maximum amount of times since the villager last restocked.
+func (o TradeOffer) Disabled() bool {
+	return o.uses >= o.MaxUses
+}
+
+// Price returns the first input of the offer with its count adjusted to the
+// price that the customer passed has to pay. The price increases with the
+// demand for the offer and is discounted by the reputation of the customer
+// with the villager and if the customer has the Hero of the Village effect.
+func (o TradeOffer) Price(customer world.Entity, reputation int) item.Stack {
+	base := o.Input.Count()
+	price := base + max(0, int(math.Floor(float64(base*o.demand)*o.PriceMultiplier)))
+	price -= int(math.Floor(float64(reputation) * o.PriceMultiplier))
+	if e, ok := customer.(interface {
+		Effect(e effect.Type) (effect.Effect, bool)
+	}); ok {
+		if hero, ok := e.Effect(effect.HeroOfTheVillage); ok {
+			price -= max(int(math.Floor(effect.HeroOfTheVillage.Discount(hero.Level())*float64(base))), 1)
+		}
+	}
+	return o.Input.Grow(min(max(price, 1), o.Input.MaxCount()) - base)
+}
+
+// restock resets the uses of the offer and updates its demand based on how
+// often it was used.
+func (o *TradeOffer) restock() {
+	o.demand = max(0, o.demand+o.uses-(o.MaxUses-o.uses))
+	o.uses = 0
+}
+
+// encodeNBT encodes the offer into a map that can be stored on disk.
+func (o TradeOffer) encodeNBT() map[string]any {
+	m := map[string]any{
+		"buyA":             nbtconv.WriteItem(o.Input, true),
+		"sell":             nbtconv.WriteItem(o.Output, true),
+		"tier":             int32(o.Tier - 1),
+		"uses":             int32(o.uses),
+		"maxUses":          int32(o.MaxUses),
+		"demand":           int32(o.demand),
+		"traderExp":        int32(o.Experience),
+		"priceMultiplierA": float32(o.PriceMultiplier),
+	}
+	if !o.SecondInput.Empty() {
+		m["buyB"] = nbtconv.WriteItem(o.SecondInput, true)
+	}
+	return m
+}
+
+// decodeTradeOffer decodes a TradeOffer from a map previously produced by
+// TradeOffer.encodeNBT.
+func decodeTradeOffer(m map[string]any) TradeOffer {
+	return TradeOffer{
+		VillagerTrade: VillagerTrade{
+			Input:           nbtconv.MapItem(m, "buyA"),
+			SecondInput:     nbtconv.MapItem(m, "buyB"),
+			Output:          nbtconv.MapItem(m, "sell"),
+			MaxUses:         int(nbtconv.Int32(m, "maxUses")),
+			Experience:      int(nbtconv.Int32(m, "traderExp")),
+			PriceMultiplier: float64(nbtconv.Float32(m, "priceMultiplierA")),
+		},
+		Tier:   int(nbtconv.Int32(m, "tier")) + 1,
+		uses:   int(nbtconv.Int32(m, "uses")),
+		demand: int(nbtconv.Int32(m, "demand")),
+	}
+}
+
+// villagerTrades holds the trades registered for each profession, indexed by
+// tier.
+var villagerTrades = map[VillagerProfession]*[5][]VillagerTrade{}
+
+// RegisterVillagerTrade registers a trade that villagers with the profession
+// passed may offer once they reach the tier passed. Tiers range from 1
+// (novice) to 5 (master). Every time a villager reaches a new tier, it picks
+// two of the trades registered for that tier at random.
+// RegisterVillagerTrade panics if the tier is out of range.
+func RegisterVillagerTrade(p VillagerProfession, tier int, t VillagerTrade) {
+	if tier < 1 || tier > 5 {
+		panic("villager trade tier must be between 1 and 5")
+	}
+	tiers, ok := villagerTrades[p]
+	if !ok {
+		tiers = &[5][]VillagerTrade{}
+		villagerTrades[p] = tiers
+	}
+	tiers[tier-1] = append(tiers[tier-1], t)
+}
+
+// ClearVillagerTrades removes all trades registered for the profession
+// passed, including the default ones, so that they may be replaced with
+// custom trades using RegisterVillagerTrade. Villagers that already unlocked
+// offers keep them.
+func ClearVillagerTrades(p VillagerProfession) {
+	delete(villagerTrades, p)
+}
+
+// VillagerTrades returns all trades registered for the profession and tier
+// passed.
+func VillagerTrades(p VillagerProfession, tier int) []VillagerTrade {
+	tiers, ok := villagerTrades[p]
+	if !ok || tier < 1 || tier > 5 {
+		return nil
+	}
+	return slices.Clone(tiers[tier-1])
+}
+
+// emeralds returns an item.Stack of n emeralds.
+func emeralds(n int) item.Stack {
+	return item.NewStack(item.Emerald{}, n)
+}
+
+// villagerBuys returns a VillagerTrade in which the villager buys n of the item passed
+// in exchange for emeralds.
+func villagerBuys(it world.Item, n, emeraldCount, maxUses, xp int) VillagerTrade {
+	return VillagerTrade{Input: item.NewStack(it, n), Output: emeralds(emeraldCount), MaxUses: maxUses, Experience: xp, PriceMultiplier: 0.05}
+}
+
+// villagerSells returns a VillagerTrade in which the villager sells n of the item
+// passed in exchange for emeralds.
+func villagerSells(it world.Item, n, emeraldCount, maxUses, xp int, multiplier float64) VillagerTrade {
+	return VillagerTrade{Input: emeralds(emeraldCount), Output: item.NewStack(it, n), MaxUses: maxUses, Experience: xp, PriceMultiplier: multiplier}
+}
+
+// init registers the default trades of all professions.
+func init() {
+	for tier, trades := range [][]VillagerTrade{
+		{villagerBuys(item.Wheat{}, 20, 1, 16, 2), villagerBuys(block.Potato{}, 26, 1, 16, 2), villagerBuys(block.Carrot{}, 22, 1, 16, 2), villagerBuys(item.Beetroot{}, 15, 1, 16, 2), villagerSells(item.Bread{}, 6, 1, 16, 1, 0.05)},
+		{villagerBuys(block.Pumpkin{}, 6, 1, 12, 10), villagerSells(item.PumpkinPie{}, 4, 1, 12, 5, 0.05), villagerSells(item.Apple{}, 4, 1, 16, 5, 0.05)},
+		{villagerSells(item.Cookie{}, 18, 3, 12, 10, 0.05), villagerBuys(block.Melon{}, 4, 1, 12, 20)},
+		{villagerSells(item.PumpkinPie{}, 1, 1, 12, 15, 0.05)},
+		{villagerSells(item.GoldenCarrot{}, 3, 3, 12, 30, 0.05), villagerSells(item.GlisteringMelonSlice{}, 3, 4, 12, 30, 0.05)},
+	} {
+		for _, t := range trades {
+			RegisterVillagerTrade(ProfessionFarmer(), tier+1, t)
+		}
+	}
+	for tier, trades := range [][]VillagerTrade{
+		{villagerBuys(item.Coal{}, 10, 1, 16, 2), villagerSells(item.Cod{Cooked: true}, 6, 1, 16, 1, 0.05)},
+		{villagerBuys(item.Cod{}, 15, 1, 16, 10), villagerSells(item.Salmon{Cooked: true}, 6, 1, 16, 5, 0.05)},
+		{villagerBuys(item.Salmon{}, 13, 1, 16, 20)},
+		{villagerBuys(item.TropicalFish{}, 6, 1, 12, 30)},
+		{villagerBuys(item.Pufferfish{}, 4, 1, 12, 30)},
+	} {
+		for _, t := range trades {
+			RegisterVillagerTrade(ProfessionFisherman(), tier+1, t)
+		}
+	}
+	for tier, trades := range [][]VillagerTrade{
+		{villagerBuys(block.Wool{Colour: item.ColourWhite()}, 18, 1, 16, 2), villagerBuys(block.Wool{Colour: item.ColourBrown()}, 18, 1, 16, 2), villagerSells(item.Shears{}, 1, 2, 12, 1, 0.05)},
+		{villagerBuys(item.Dye{Colour: item.ColourWhite()}, 12, 1, 16, 10), villagerSells(block.Wool{Colour: item.ColourWhite()}, 1, 1, 16, 5, 0.05)},
+		{villagerBuys(item.Dye{Colour: item.ColourYellow()}, 12, 1, 16, 20), villagerSells(block.Carpet{Colour: item.ColourWhite()}, 4, 1, 16, 10, 0.05)},
+		{villagerBuys(item.Dye{Colour: item.ColourRed()}, 12, 1, 16, 30), villagerBuys(item.Dye{Colour: item.ColourBlack()}, 12, 1, 16, 30)},
+		{villagerSells(block.Banner{Colour: item.ColourWhite()}, 1, 3, 12, 30, 0.05)},
+	} {
+		for _, t := range trades {
+			RegisterVillagerTrade(ProfessionShepherd(), tier+1, t)
+		}
+	}
+	for tier, trades := range [][]VillagerTrade{
+		{villagerBuys(item.Stick{}, 32, 1, 16, 2), villagerSells(item.Arrow{}, 16, 1, 12, 1, 0.05)},
+		{villagerBuys(item.Flint{}, 26, 1, 12, 10), villagerSells(item.Bow{}, 1, 2, 12, 5, 0.05)},
+		{villagerSells(item.Crossbow{}, 1, 3, 12, 10, 0.05)},
+		{villagerBuys(item.Feather{}, 24, 1, 12, 30)},
+		{villagerSells(item.Arrow{}, 5, 2, 12, 30, 0.05)},
+	} {
+		for _, t := range trades {
+			RegisterVillagerTrade(ProfessionFletcher(), tier+1, t)
+		}
+	}
+	for tier, trades := range [][]VillagerTrade{
+		{villagerBuys(item.Paper{}, 24, 1, 16, 2), villagerSells(block.Bookshelf{}, 1, 9, 12, 1, 0.05)},
+		{villagerBuys(item.Book{}, 4, 1, 12, 10), villagerSells(block.Lantern{Type: block.NormalFire()}, 1, 1, 12, 5, 0.05)},
+		{villagerBuys(item.InkSac{}, 5, 1, 12, 20), villagerSells(block.Glass{}, 4, 1, 12, 10, 0.05)},
+		{villagerBuys(item.BookAndQuill{}, 2, 1, 12, 30), villagerSells(item.Compass{}, 1, 4, 12, 15, 0.05)},
+		{villagerSells(item.Clock{}, 1, 5, 12, 30, 0.05)},
+	} {
+		for _, t := range trades {
+			RegisterVillagerTrade(ProfessionLibrarian(), tier+1, t)
+		}
+	}
+	for tier, trades := range [][]VillagerTrade{
+		{villagerBuys(item.RottenFlesh{}, 32, 1, 16, 2), villagerSells(item.LapisLazuli{}, 1, 1, 12, 1, 0.05)},
+		{villagerBuys(item.GoldIngot{}, 3, 1, 12, 10), villagerSells(item.GoldNugget{}, 9, 1, 12, 5, 0.05)},
+		{villagerBuys(item.RabbitFoot{}, 2, 1, 12, 20), villagerSells(item.GlowstoneDust{}, 4, 1, 12, 10, 0.05)},
+		{villagerBuys(item.Scute{}, 4, 1, 12, 30), villagerBuys(item.GlassBottle{}, 9, 1, 12, 30), villagerSells(item.EnderPearl{}, 1, 5, 12, 15, 0.05)},
+		{villagerBuys(block.NetherWart{}, 22, 1, 12, 30), villagerSells(item.BottleOfEnchanting{}, 1, 3, 12, 30, 0.05)},
+	} {
+		for _, t := range trades {
+			RegisterVillagerTrade(ProfessionCleric(), tier+1, t)
+		}
+	}
+	for tier, trades := range [][]VillagerTrade{
+		{villagerBuys(item.Coal{}, 15, 1, 16, 2), villagerSells(item.Helmet{Tier: item.ArmourTierIron{}}, 1, 5, 12, 1, 0.2), villagerSells(item.Chestplate{Tier: item.ArmourTierIron{}}, 1, 9, 12, 1, 0.2)},
+		{villagerBuys(item.IronIngot{}, 4, 1, 12, 10), villagerSells(item.Leggings{Tier: item.ArmourTierIron{}}, 1, 7, 12, 5, 0.2), villagerSells(item.Boots{Tier: item.ArmourTierIron{}}, 1, 4, 12, 5, 0.2)},
+		{villagerBuys(item.Bucket{Content: item.LiquidBucketContent(block.Lava{Still: true, Depth: 8})}, 1, 1, 12, 20), villagerBuys(item.Diamond{}, 1, 1, 12, 20), villagerSells(item.Helmet{Tier: item.ArmourTierChain{}}, 1, 1, 12, 10, 0.2)},
+		{villagerSells(item.Chestplate{Tier: item.ArmourTierChain{}}, 1, 4, 12, 15, 0.2), villagerSells(item.Leggings{Tier: item.ArmourTierChain{}}, 1, 3, 12, 15, 0.2)},
+		{villagerSells(item.Helmet{Tier: item.ArmourTierDiamond{}}, 1, 27, 3, 30, 0.2), villagerSells(item.Chestplate{Tier: item.ArmourTierDiamond{}}, 1, 35, 3, 30, 0.2)},
+	} {
+		for _, t := range trades {
+			RegisterVillagerTrade(ProfessionArmourer(), tier+1, t)
+		}
+	}
+	for tier, trades := range [][]VillagerTrade{
+		{villagerBuys(item.Coal{}, 15, 1, 16, 2), villagerSells(item.Axe{Tier: item.ToolTierIron}, 1, 3, 12, 1, 0.2)},
+		{villagerBuys(item.IronIngot{}, 4, 1, 12, 10), villagerSells(item.Sword{Tier: item.ToolTierIron}, 1, 4, 12, 5, 0.2)},
+		{villagerBuys(item.Flint{}, 24, 1, 12, 20)},
+		{villagerBuys(item.Diamond{}, 1, 1, 12, 30), villagerSells(item.Axe{Tier: item.ToolTierDiamond}, 1, 17, 3, 15, 0.2)},
+		{villagerSells(item.Sword{Tier: item.ToolTierDiamond}, 1, 13, 3, 30, 0.2)},
+	} {
+		for _, t := range trades {
+			RegisterVillagerTrade(ProfessionWeaponsmith(), tier+1, t)
+		}
+	}
+	for tier, trades := range [][]VillagerTrade{
+		{villagerBuys(item.Coal{}, 15, 1, 16, 2), villagerSells(item.Axe{Tier: item.ToolTierStone}, 1, 1, 12, 1, 0.2), villagerSells(item.Shovel{Tier: item.ToolTierStone}, 1, 1, 12, 1, 0.2), villagerSells(item.Pickaxe{Tier: item.ToolTierStone}, 1, 1, 12, 1, 0.2), villagerSells(item.Hoe{Tier: item.ToolTierStone}, 1, 1, 12, 1, 0.2)},
+		{villagerBuys(item.IronIngot{}, 4, 1, 12, 10)},
+		{villagerBuys(item.Flint{}, 30, 1, 12, 20), villagerSells(item.Pickaxe{Tier: item.ToolTierIron}, 1, 2, 3, 10, 0.2)},
+		{villagerBuys(item.Diamond{}, 1, 1, 12, 30), villagerSells(item.Shovel{Tier: item.ToolTierDiamond}, 1, 5, 3, 15, 0.2)},
+		{villagerSells(item.Pickaxe{Tier: item.ToolTierDiamond}, 1, 13, 3, 30, 0.2)},
+	} {
+		for _, t := range trades {
+			RegisterVillagerTrade(ProfessionToolsmith(), tier+1, t)
+		}
+	}
+	for tier, trades := range [][]VillagerTrade{
+		{villagerBuys(item.Chicken{}, 14, 1, 16, 2), villagerBuys(item.Porkchop{}, 7, 1, 16, 2), villagerBuys(item.Rabbit{}, 4, 1, 16, 2), villagerSells(item.RabbitStew{}, 1, 1, 12, 1, 0.05)},
+		{villagerBuys(item.Coal{}, 15, 1, 16, 2), villagerSells(item.Porkchop{Cooked: true}, 5, 1, 16, 5, 0.05), villagerSells(item.Chicken{Cooked: true}, 8, 1, 16, 5, 0.05)},
+		{villagerBuys(item.Mutton{}, 7, 1, 16, 20), villagerBuys(item.Beef{}, 10, 1, 16, 20)},
+		{villagerBuys(item.DriedKelp{}, 10, 1, 12, 30)},
+		{villagerBuys(item.Beef{}, 10, 1, 16, 30)},
+	} {
+		for _, t := range trades {
+			RegisterVillagerTrade(ProfessionButcher(), tier+1, t)
+		}
+	}
+	for tier, trades := range [][]VillagerTrade{
+		{villagerBuys(item.Leather{}, 6, 1, 16, 2), villagerSells(item.Leggings{Tier: item.ArmourTierLeather{}}, 1, 3, 12, 1, 0.2), villagerSells(item.Chestplate{Tier: item.ArmourTierLeather{}}, 1, 7, 12, 1, 0.2)},
+		{villagerBuys(item.Flint{}, 26, 1, 12, 10), villagerSells(item.Helmet{Tier: item.ArmourTierLeather{}}, 1, 5, 12, 5, 0.2), villagerSells(item.Boots{Tier: item.ArmourTierLeather{}}, 1, 4, 12, 5, 0.2)},
+		{villagerBuys(item.RabbitHide{}, 9, 1, 12, 20)},
+		{villagerBuys(item.Scute{}, 4, 1, 12, 30)},
+		{villagerSells(item.Helmet{Tier: item.ArmourTierLeather{}}, 1, 5, 12, 30, 0.2)},
+	} {
+		for _, t := range trades {
+			RegisterVillagerTrade(ProfessionLeatherworker(), tier+1, t)
+		}
+	}
+	for tier, trades := range [][]VillagerTrade{
+		{villagerBuys(item.ClayBall{}, 10, 1, 16, 2), villagerSells(item.Brick{}, 10, 1, 16, 1, 0.05)},
+		{villagerBuys(block.Stone{}, 20, 1, 16, 10), villagerSells(block.Stone{Smooth: true}, 4, 1, 16, 5, 0.05)},
+		{villagerBuys(block.Granite{}, 16, 1, 16, 20), villagerBuys(block.Andesite{}, 16, 1, 16, 20), villagerBuys(block.Diorite{}, 16, 1, 16, 20), villagerSells(block.Terracotta{}, 1, 1, 12, 10, 0.05)},
+		{villagerBuys(item.NetherQuartz{}, 12, 1, 12, 30), villagerSells(block.GlazedTerracotta{Colour: item.ColourWhite()}, 1, 1, 12, 15, 0.05)},
+		{villagerSells(block.Quartz{}, 1, 1, 12, 30, 0.05)},
+	} {
+		for _, t := range trades {
+			RegisterVillagerTrade(ProfessionMason(), tier+1, t)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+)
+
+// NewVindicator creates a new vindicator holding an iron axe.
+func NewVindicator(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(VindicatorType, vindicatorConf)
+}
+
+var vindicatorConf VindicatorBehaviourConfig
+
+// VindicatorBehaviourConfig holds optional parameters for a
+// VindicatorBehaviour.
+type VindicatorBehaviourConfig struct{}
+
+func (conf VindicatorBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a VindicatorBehaviour using the parameters in conf.
+func (conf VindicatorBehaviourConfig) New() *VindicatorBehaviour {
+	v := &VindicatorBehaviour{}
+	v.MobBehaviour = MobBehaviourConfig{MaxHealth: 24, Speed: 0.12, Experience: 5, Drops: v.drops, Equip: v.equip}.New()
+	v.Equipment().SetHeldItems(item.NewStack(item.Axe{Tier: item.ToolTierIron}, 1), item.Stack{})
+	return v
+}
+
+// VindicatorBehaviour implements the behaviour of vindicators. Vindicators
+// are illagers that attack players, villagers and iron golems in melee with
+// their axes. They take part in raids.
+type VindicatorBehaviour struct {
+	*MobBehaviour
+	raider
+
+	attackCooldown int
+}
+
+// HeldItems returns the items held by the vindicator. By default,
+// vindicators hold an iron axe in their main hand.
+func (v *VindicatorBehaviour) HeldItems() (mainHand, offHand item.Stack) {
+	return v.Equipment().HeldItems()
+}
+
+// Armour returns the armour worn by the vindicator.
+func (v *VindicatorBehaviour) Armour() *inventory.Armour {
+	return v.Equipment().Armour()
+}
+
+// Hurt makes the vindicator attack the entity that attacked it.
+func (v *VindicatorBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	v.hurt(m, src)
+}
+
+// Tick ticks the vindicator, making it pursue and attack its target.
+func (v *VindicatorBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !v.Dead() {
+		v.tickVindicator(&Mob{Ent: e}, tx)
+	}
+	return v.MobBehaviour.Tick(e, tx)
+}
+
+// tickVindicator performs the vindicator specific logic of a tick.
+func (v *VindicatorBehaviour) tickVindicator(m *Mob, tx *world.Tx) {
+	if v.attackCooldown > 0 {
+		v.attackCooldown--
+	}
+	target := v.findTarget(m, tx)
+	if target == nil {
+		v.wander(m, v.MobBehaviour, tx)
+		return
+	}
+	v.LookAt(EyePosition(target))
+	v.MoveTo(target.Position(), 1)
+
+	if target.Position().Sub(m.Position()).Len() > 2 || v.attackCooldown > 0 {
+		return
+	}
+	v.attackCooldown = 20
+	dmg := 5.0
+	switch tx.World().Difficulty() {
+	case world.DifficultyEasy:
+		dmg = 3.5
+	case world.DifficultyHard:
+		dmg = 7.5
+	}
+	if held, _ := v.HeldItems(); !held.Empty() {
+		dmg += held.AttackDamage() - 1
+	}
+	if _, vulnerable := target.Hurt(v.attackDamage(dmg), AttackDamageSource{Attacker: m}); vulnerable {
+		target.KnockBack(m.Position(), 0.4, 0.4)
+	}
+	for _, viewer := range tx.Viewers(m.Position()) {
+		viewer.ViewEntityAction(m, SwingArmAction{})
+	}
+}
+
+// drops returns the items dropped by a vindicator when it dies.
+func (v *VindicatorBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	if rand.IntN(2) == 0 {
+		return []item.Stack{item.NewStack(item.Emerald{}, 1)}
+	}
+	return nil
+}
+
+// equip gives the vindicator an illager banner when it spawns if it is the
+// captain of a patrol.
+func (v *VindicatorBehaviour) equip(_ *Mob, eq *Equipment, _ float64) {
+	v.equipBanner(eq)
+}
+
+// VindicatorType is a world.EntityType implementation for vindicators.
+var VindicatorType vindicatorType
+
+type vindicatorType struct{}
+
+func (vindicatorType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (vindicatorType) EncodeEntity() string { return "minecraft:vindicator" }
+func (vindicatorType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.9, 0.3)
+}
+
+func (vindicatorType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	v := vindicatorConf.New()
+	v.MobBehaviour.decodeNBT(m)
+	v.raider.decodeNBT(m)
+	data.Data = v
+}
+
+func (vindicatorType) EncodeNBT(data *world.EntityData) map[string]any {
+	v := data.Data.(*VindicatorBehaviour)
+	m := map[string]any{}
+	v.MobBehaviour.encodeNBT(m)
+	v.raider.encodeNBT(m)
+	return m
+}
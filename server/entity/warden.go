@@ -0,0 +1,574 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// NewWarden creates a warden that emerges from the ground after being
+// spawned.
+func NewWarden(opts world.EntitySpawnOpts) *world.EntityHandle {
+	conf := wardenConf
+	conf.Emerging = true
+	return opts.New(WardenType, conf)
+}
+
+var wardenConf = WardenBehaviourConfig{}
+
+// WardenBehaviourConfig holds optional parameters for a WardenBehaviour.
+type WardenBehaviourConfig struct {
+	// Emerging specifies if the warden emerges from the ground after being
+	// spawned. The warden is invulnerable while emerging.
+	Emerging bool
+}
+
+func (conf WardenBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a WardenBehaviour using the parameters in conf.
+func (conf WardenBehaviourConfig) New() *WardenBehaviour {
+	w := &WardenBehaviour{anger: make(map[*world.EntityHandle]int), sniffCooldown: wardenSniffInterval()}
+	if conf.Emerging {
+		w.action, w.actionTicks = wardenEmerging, wardenEmergeTicks
+	}
+	w.MobBehaviour = MobBehaviourConfig{
+		MaxHealth:           500,
+		Speed:               0.13,
+		KnockBackResistance: 1,
+		Experience:          5,
+	}.New()
+	return w
+}
+
+const (
+	// wardenAngryThreshold is the anger from which the warden attacks the
+	// entity it is angry at.
+	wardenAngryThreshold = 80
+	// wardenMaxAnger is the maximum anger the warden may have towards a
+	// single entity.
+	wardenMaxAnger = 150
+	// wardenCalmTicks is the amount of ticks after which a warden that is not
+	// angry at any entity digs back into the ground.
+	wardenCalmTicks = 1200
+	// wardenSonicBoomRange and wardenSonicBoomHeight are the horizontal and
+	// vertical distances within which the warden uses its sonic boom.
+	wardenSonicBoomRange, wardenSonicBoomHeight = 15, 20
+
+	wardenEmergeTicks    = 134
+	wardenDigTicks       = 100
+	wardenRoarTicks      = 84
+	wardenSniffTicks     = 84
+	wardenSonicBoomTicks = 60
+	// wardenSonicBoomDelay is the amount of ticks that the warden charges its
+	// sonic boom before releasing it.
+	wardenSonicBoomDelay = 34
+)
+
+// wardenAction is an action performed by a warden that it cannot interrupt.
+type wardenAction uint8
+
+const (
+	wardenIdle wardenAction = iota
+	wardenEmerging
+	wardenDigging
+	wardenRoaring
+	wardenSniffing
+	wardenSonicBoom
+)
+
+// WardenBehaviour implements the behaviour of the warden. The warden is blind
+// and instead finds entities by the vibrations they cause and by sniffing.
+// Every entity that the warden perceives makes it angrier at that entity, and
+// once its anger is high enough, the warden roars and attacks the entity,
+// either in melee or with a sonic boom that passes through blocks and is not
+// reduced by armour. Players close to the warden are periodically inflicted
+// with Darkness. After a minute without being angry at any entity, the warden
+// digs back into the ground and disappears.
+type WardenBehaviour struct {
+	*MobBehaviour
+
+	anger     map[*world.EntityHandle]int
+	heartbeat int
+
+	action      wardenAction
+	actionTicks int
+	roarTarget  *world.EntityHandle
+	target      *world.EntityHandle
+	disturbance *mgl64.Vec3
+
+	calmTicks         int
+	vibrationCooldown int
+	sniffCooldown     int
+	attackCooldown    int
+	sonicBoomCooldown int
+}
+
+// Anger returns the anger of the warden towards the entity passed, ranging
+// from 0 to 150. The warden attacks entities that it has an anger of at least
+// 80 towards.
+func (w *WardenBehaviour) Anger(e world.Entity) int {
+	return w.anger[e.H()]
+}
+
+// IncreaseAnger increases the anger of the warden towards the entity passed
+// by the amount passed. The anger of the warden towards a single entity never
+// exceeds 150, and slowly decreases over time.
+func (w *WardenBehaviour) IncreaseAnger(e world.Entity, amount int) {
+	if amount <= 0 {
+		return
+	}
+	w.anger[e.H()] = min(w.anger[e.H()]+amount, wardenMaxAnger)
+	w.calmTicks = 0
+}
+
+// Target returns the entity that the warden is currently attacking, or nil if
+// it is not attacking any entity.
+func (w *WardenBehaviour) Target() *world.EntityHandle {
+	return w.target
+}
+
+// Emerging checks if the warden is emerging from the ground.
+func (w *WardenBehaviour) Emerging() bool {
+	return w.action == wardenEmerging
+}
+
+// Digging checks if the warden is digging back into the ground.
+func (w *WardenBehaviour) Digging() bool {
+	return w.action == wardenDigging
+}
+
+// Roaring checks if the warden is roaring at the entity it is about to
+// attack.
+func (w *WardenBehaviour) Roaring() bool {
+	return w.action == wardenRoaring
+}
+
+// Sniffing checks if the warden is sniffing for entities close to it.
+func (w *WardenBehaviour) Sniffing() bool {
+	return w.action == wardenSniffing
+}
+
+// SonicBooming checks if the warden is charging or releasing a sonic boom.
+func (w *WardenBehaviour) SonicBooming() bool {
+	return w.action == wardenSonicBoom
+}
+
+// HeartbeatInterval returns the amount of ticks between two beats of the
+// heart of the warden. The heart of the warden beats faster the angrier it
+// is.
+func (w *WardenBehaviour) HeartbeatInterval() int {
+	return 40 - int(math.Floor(math.Min(float64(w.heartbeat)/wardenAngryThreshold, 1)*30))
+}
+
+// Immune makes the warden immune to all damage but the void while it is
+// emerging from or digging into the ground.
+func (w *WardenBehaviour) Immune(src world.DamageSource) bool {
+	if _, ok := src.(VoidDamageSource); ok {
+		return false
+	}
+	return w.action == wardenEmerging || w.action == wardenDigging
+}
+
+// Hurt makes the warden very angry at the entity that hurt it. If the warden
+// is not yet attacking another entity, it immediately attacks its attacker.
+func (w *WardenBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	attacker := damageSourceAttacker(src)
+	if attacker == nil || attacker.H() == m.H() {
+		return
+	}
+	if l, ok := attacker.(Living); ok && wardenAttackable(l) {
+		w.IncreaseAnger(attacker, wardenAngryThreshold+20)
+		if w.target == nil && w.action != wardenRoaring {
+			w.target = attacker.H()
+		}
+	}
+}
+
+// GameEventRange ...
+func (w *WardenBehaviour) GameEventRange() float64 {
+	return 16
+}
+
+// ReceiveGameEvent makes the warden investigate the position at which a game
+// event was emitted, and makes it angrier at the entity that caused it. The
+// warden is less angry at entities shooting projectiles than at entities
+// causing vibrations themselves.
+func (w *WardenBehaviour) ReceiveGameEvent(_ *Mob, _ world.GameEvent, pos mgl64.Vec3, src world.Entity, tx *world.Tx) {
+	if w.action == wardenEmerging || w.action == wardenDigging || w.vibrationCooldown > 0 {
+		return
+	}
+	if src != nil && src.H().Type() == WardenType {
+		return
+	}
+	w.vibrationCooldown, w.calmTicks = 40, 0
+	w.disturbance = &pos
+
+	anger := 35
+	if e, ok := src.(*Ent); ok {
+		if p, ok := e.Behaviour().(interface{ Owner() *world.EntityHandle }); ok {
+			// Vibrations caused by projectiles make the warden angry at the
+			// entity that shot the projectile.
+			src, _ = p.Owner().Entity(tx)
+			anger = 10
+		}
+	}
+	if l, ok := src.(Living); ok && wardenAttackable(l) {
+		w.IncreaseAnger(src, anger)
+	}
+}
+
+// Tick ticks the warden, updating its anger and making it attack the entity
+// it is angriest at.
+func (w *WardenBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !w.Dead() {
+		m := &Mob{Ent: e}
+		if !w.tickAction(m, tx) {
+			return nil
+		}
+		w.tickWarden(m, tx)
+	}
+	return w.MobBehaviour.Tick(e, tx)
+}
+
+// tickAction ticks the action that the warden is currently performing. False
+// is returned if the warden was removed from the world.
+func (w *WardenBehaviour) tickAction(m *Mob, tx *world.Tx) bool {
+	if w.action == wardenIdle {
+		return true
+	}
+	m.data.Vel[0], m.data.Vel[2] = 0, 0
+	w.StopMoving()
+
+	w.actionTicks--
+	switch w.action {
+	case wardenDigging:
+		if w.actionTicks <= 0 {
+			// Wardens that dig back into the ground disappear without dropping
+			// anything.
+			_ = m.Close()
+			return false
+		}
+	case wardenRoaring:
+		if t, ok := w.roarTarget.Entity(tx); ok {
+			w.LookAt(EyePosition(t))
+		}
+		if w.actionTicks <= 0 {
+			w.target, w.roarTarget = w.roarTarget, nil
+		}
+	case wardenSniffing:
+		if w.actionTicks <= 0 {
+			w.sniff(m, tx)
+		}
+	case wardenSonicBoom:
+		if t, ok := w.target.Entity(tx); ok {
+			w.LookAt(EyePosition(t))
+			if w.actionTicks == wardenSonicBoomTicks-wardenSonicBoomDelay {
+				w.releaseSonicBoom(m, t, tx)
+			}
+		}
+		if w.actionTicks <= 0 {
+			w.sonicBoomCooldown = 40
+		}
+	}
+	if w.actionTicks <= 0 {
+		w.action = wardenIdle
+		m.updateState()
+	}
+	return true
+}
+
+// startAction makes the warden start performing the action passed for the
+// amount of ticks passed.
+func (w *WardenBehaviour) startAction(m *Mob, action wardenAction, ticks int) {
+	w.action, w.actionTicks = action, ticks
+	w.StopMoving()
+	m.updateState()
+}
+
+// tickWarden performs the warden specific logic of a tick.
+func (w *WardenBehaviour) tickWarden(m *Mob, tx *world.Tx) {
+	if w.vibrationCooldown > 0 {
+		w.vibrationCooldown--
+	}
+	if w.attackCooldown > 0 {
+		w.attackCooldown--
+	}
+	if w.sonicBoomCooldown > 0 {
+		w.sonicBoomCooldown--
+	}
+	if m.Age()%time.Second == 0 {
+		w.tickAnger(tx)
+	}
+	if m.Age()%(time.Second*6) == 0 {
+		w.applyDarkness(m, tx)
+	}
+	if h := w.topAnger(tx); h != w.heartbeat {
+		w.heartbeat = h
+		m.updateState()
+	}
+	if w.action != wardenIdle {
+		return
+	}
+	if target := w.validTarget(tx); target != nil {
+		w.attack(m, target, tx)
+		return
+	}
+	if suspect := w.topSuspect(tx); suspect != nil && w.anger[suspect.H()] >= wardenAngryThreshold {
+		w.roarTarget = suspect.H()
+		w.startAction(m, wardenRoaring, wardenRoarTicks)
+		return
+	}
+	if len(w.anger) == 0 {
+		if w.calmTicks++; w.calmTicks >= wardenCalmTicks {
+			w.startAction(m, wardenDigging, wardenDigTicks)
+			return
+		}
+	}
+	w.investigate(m)
+}
+
+// tickAnger lowers the anger of the warden towards every entity by one and
+// forgets about entities that it may no longer attack.
+func (w *WardenBehaviour) tickAnger(tx *world.Tx) {
+	for handle, anger := range w.anger {
+		e, ok := handle.Entity(tx)
+		l, living := e.(Living)
+		if anger--; anger <= 0 || !ok || !living || l.Dead() || !wardenAttackable(l) {
+			delete(w.anger, handle)
+			continue
+		}
+		w.anger[handle] = anger
+	}
+}
+
+// topSuspect returns the entity that the warden is angriest at. Players are
+// preferred over other entities that the warden is equally angry at. Nil is
+// returned if the warden is not angry at any entity in the world.
+func (w *WardenBehaviour) topSuspect(tx *world.Tx) world.Entity {
+	var (
+		top   world.Entity
+		anger int
+	)
+	for handle, a := range w.anger {
+		e, ok := handle.Entity(tx)
+		if !ok {
+			continue
+		}
+		_, player := e.(interface{ GameMode() world.GameMode })
+		if a > anger || (a == anger && player) {
+			top, anger = e, a
+		}
+	}
+	return top
+}
+
+// topAnger returns the anger of the warden towards the entity it is angriest
+// at.
+func (w *WardenBehaviour) topAnger(tx *world.Tx) int {
+	if top := w.topSuspect(tx); top != nil {
+		return w.anger[top.H()]
+	}
+	return 0
+}
+
+// validTarget returns the entity that the warden is attacking. If the warden
+// is no longer angry enough at its target, or if the target may no longer be
+// attacked, the warden stops attacking it and nil is returned.
+func (w *WardenBehaviour) validTarget(tx *world.Tx) world.Entity {
+	if w.target == nil {
+		return nil
+	}
+	e, ok := w.target.Entity(tx)
+	l, living := e.(Living)
+	if !ok || !living || l.Dead() || !wardenAttackable(l) || w.anger[w.target] < wardenAngryThreshold {
+		w.target = nil
+		return nil
+	}
+	return e
+}
+
+// attack makes the warden attack the target passed. The warden walks towards
+// its target and hits it once it is close enough. Targets that are out of
+// reach are hit using a sonic boom instead.
+func (w *WardenBehaviour) attack(m *Mob, target world.Entity, tx *world.Tx) {
+	w.LookAt(EyePosition(target))
+	delta := target.Position().Sub(m.Position())
+	horizontal := math.Hypot(delta[0], delta[2])
+
+	if horizontal > 2.5 || math.Abs(delta[1]) > 3 {
+		w.MoveTo(target.Position(), 1.2)
+		if w.sonicBoomCooldown == 0 && horizontal <= wardenSonicBoomRange && math.Abs(delta[1]) <= wardenSonicBoomHeight {
+			w.startAction(m, wardenSonicBoom, wardenSonicBoomTicks)
+			tx.PlaySound(m.Position(), sound.WardenSonicCharge{})
+		}
+		return
+	}
+	w.StopMoving()
+	if w.attackCooldown > 0 {
+		return
+	}
+	w.attackCooldown = 18
+	// The warden only uses its sonic boom if it has not hit anything in melee
+	// for a while.
+	w.sonicBoomCooldown = max(w.sonicBoomCooldown, 40)
+
+	dmg := 30.0
+	switch tx.World().Difficulty() {
+	case world.DifficultyEasy:
+		dmg = 16
+	case world.DifficultyHard:
+		dmg = 45
+	}
+	if l, ok := target.(Living); ok {
+		if _, vulnerable := l.Hurt(dmg, AttackDamageSource{Attacker: m}); vulnerable {
+			l.KnockBack(m.Position(), 0.4, 0.4)
+		}
+	}
+	for _, v := range tx.Viewers(m.Position()) {
+		v.ViewEntityAction(m, SwingArmAction{})
+	}
+}
+
+// releaseSonicBoom releases the sonic boom charged by the warden at the
+// target passed, if the target is still within range. The sonic boom passes
+// through blocks.
+func (w *WardenBehaviour) releaseSonicBoom(m *Mob, target world.Entity, tx *world.Tx) {
+	delta := target.Position().Sub(m.Position())
+	if math.Hypot(delta[0], delta[2]) > wardenSonicBoomRange || math.Abs(delta[1]) > wardenSonicBoomHeight {
+		return
+	}
+	from := m.Position().Add(mgl64.Vec3{0, 1.6})
+	to := target.Position()
+	to[1] += (EyePosition(target)[1] - to[1]) / 2
+
+	dir := to.Sub(from)
+	if dir.Len() == 0 {
+		return
+	}
+	dist, dir := dir.Len(), dir.Normalize()
+	for i := 1.0; i < dist+1; i++ {
+		tx.AddParticle(from.Add(dir.Mul(math.Min(i, dist))), particle.SonicExplosion{})
+	}
+	tx.PlaySound(m.Position(), sound.WardenSonicBoom{})
+	if l, ok := target.(Living); ok {
+		if _, vulnerable := l.Hurt(10, SonicBoomDamageSource{Warden: m}); vulnerable {
+			l.KnockBack(m.Position(), 2.5, dir[1]*0.5)
+		}
+	}
+}
+
+// applyDarkness inflicts Darkness on all players within 20 blocks of the
+// warden that do not already have Darkness for a while longer.
+func (w *WardenBehaviour) applyDarkness(m *Mob, tx *world.Tx) {
+	for e := range tx.EntitiesWithin(cube.Box(-20, -20, -20, 20, 20, 20).Translate(m.Position())) {
+		l, ok := e.(Living)
+		if !ok || l.Dead() || !attackablePlayer(l) {
+			continue
+		}
+		if eff, ok := e.(interface {
+			Effect(e effect.Type) (effect.Effect, bool)
+		}); ok {
+			if d, ok := eff.Effect(effect.Darkness); ok && d.Duration() >= time.Second*10 {
+				continue
+			}
+		}
+		l.AddEffect(effect.New(effect.Darkness, 1, time.Second*13))
+	}
+}
+
+// investigate makes the warden walk towards the position of the last
+// disturbance that it perceived. If the warden has nothing to investigate, it
+// sniffs for entities around it or wanders around.
+func (w *WardenBehaviour) investigate(m *Mob) {
+	if w.disturbance != nil {
+		if m.Position().Sub(*w.disturbance).Len() < 2 {
+			w.disturbance = nil
+			w.StopMoving()
+		} else if !w.Moving() {
+			w.MoveTo(*w.disturbance, 0.7)
+		}
+		return
+	}
+	if w.sniffCooldown--; w.sniffCooldown <= 0 && m.OnGround() {
+		w.sniffCooldown = wardenSniffInterval()
+		w.startAction(m, wardenSniffing, wardenSniffTicks)
+		return
+	}
+	if !w.Moving() && rand.IntN(240) == 0 {
+		w.MoveTo(m.Position().Add(randomHorizontalOffset(8)), 0.5)
+	}
+}
+
+// sniff makes the warden investigate the entity closest to it that it may
+// attack. If the entity is very close to the warden, the warden also becomes
+// angrier at it.
+func (w *WardenBehaviour) sniff(m *Mob, tx *world.Tx) {
+	t, ok := nearestEntity(m, tx, 24, wardenAttackable)
+	if !ok {
+		return
+	}
+	pos, delta := t.Position(), t.Position().Sub(m.Position())
+	if math.Hypot(delta[0], delta[2]) <= 6 && math.Abs(delta[1]) <= 20 {
+		w.IncreaseAnger(t, 35)
+	}
+	w.disturbance = &pos
+}
+
+// wardenSniffInterval returns a random amount of ticks until a warden next
+// sniffs for entities around it.
+func wardenSniffInterval() int {
+	return 100 + rand.IntN(100)
+}
+
+// wardenAttackable checks if the warden attacks the entity passed. The warden
+// attacks players and all mobs but other wardens.
+func wardenAttackable(e Living) bool {
+	if _, ok := e.(*Mob); !ok {
+		return attackablePlayer(e)
+	}
+	return e.H().Type() != WardenType
+}
+
+// decodeNBT decodes the state of the warden from the map passed.
+func (w *WardenBehaviour) decodeNBT(m map[string]any) {
+	w.MobBehaviour.decodeNBT(m)
+	if v, ok := m["DigCooldown"].(int32); ok {
+		w.calmTicks = max(wardenCalmTicks-int(v), 0)
+	}
+}
+
+// WardenType is a world.EntityType implementation for the warden.
+var WardenType wardenType
+
+type wardenType struct{}
+
+func (wardenType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (wardenType) EncodeEntity() string      { return "minecraft:warden" }
+func (wardenType) ListensToGameEvents() bool { return true }
+func (wardenType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.45, 0, -0.45, 0.45, 2.9, 0.45)
+}
+
+func (wardenType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	w := wardenConf.New()
+	w.decodeNBT(m)
+	data.Data = w
+}
+
+func (wardenType) EncodeNBT(data *world.EntityData) map[string]any {
+	w := data.Data.(*WardenBehaviour)
+	m := map[string]any{"DigCooldown": int32(wardenCalmTicks - w.calmTicks)}
+	w.MobBehaviour.encodeNBT(m)
+	return m
+}
@@ -0,0 +1,428 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player/bossbar"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// NewWither creates a new wither. The wither starts out invulnerable with a
+// third of its health, which it regains before exploding and starting to
+// attack.
+func NewWither(opts world.EntitySpawnOpts) *world.EntityHandle {
+	conf := witherConf
+	conf.InvulnerableTicks = witherSpawnTicks
+	return opts.New(WitherType, conf)
+}
+
+var witherConf = WitherBehaviourConfig{}
+
+// WitherBehaviourConfig holds optional parameters for a WitherBehaviour.
+type WitherBehaviourConfig struct {
+	// InvulnerableTicks is the amount of ticks that the wither remains
+	// invulnerable after being spawned. While invulnerable, the wither does
+	// not move or attack and slowly regains health. If larger than 0, the
+	// wither starts with a third of its maximum health.
+	InvulnerableTicks int
+}
+
+func (conf WitherBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a WitherBehaviour using the parameters in conf.
+func (conf WitherBehaviourConfig) New() *WitherBehaviour {
+	w := &WitherBehaviour{invulnerableTicks: conf.InvulnerableTicks, attackCooldown: witherAttackInterval}
+	w.MobBehaviour = MobBehaviourConfig{
+		MaxHealth:  witherMaxHealth,
+		Drag:       0.09,
+		Flying:     true,
+		FireImmune: true,
+		Experience: 50,
+		Drops:      w.drops,
+	}.New()
+	if w.invulnerableTicks > 0 {
+		w.health.AddHealth(-witherMaxHealth * 2 / 3)
+	}
+	return w
+}
+
+const (
+	// witherMaxHealth is the maximum health of a wither.
+	witherMaxHealth = 600
+	// witherSpawnTicks is the amount of ticks that a newly spawned wither
+	// remains invulnerable.
+	witherSpawnTicks = 220
+	// witherTargetRange is the distance within which the main head of the
+	// wither looks for entities to attack.
+	witherTargetRange = 40
+	// witherAttackRange is the distance within which the main head of the
+	// wither shoots at its target.
+	witherAttackRange = 20
+	// witherAttackInterval is the amount of ticks between two skulls shot by
+	// the main head of the wither.
+	witherAttackInterval = 40
+)
+
+// WitherBehaviour implements the behaviour of the wither. After an initial
+// invulnerable phase that ends with a large explosion, the wither flies
+// towards its target and shoots wither skulls at up to three different
+// entities with its three heads. Below half health, the wither is protected
+// by armour that makes it immune to arrows, and it breaks the blocks it
+// touches.
+type WitherBehaviour struct {
+	*MobBehaviour
+
+	invulnerableTicks int
+	lastHealth        float64
+
+	targets         [3]*world.EntityHandle
+	attackCooldown  int
+	nextHeadUpdate  [2]int
+	idleHeadUpdates [2]int
+
+	destroyBlocksTicks int
+}
+
+// InvulnerableTicks returns the amount of ticks that the wither remains
+// invulnerable for after being spawned.
+func (w *WitherBehaviour) InvulnerableTicks() int {
+	return w.invulnerableTicks
+}
+
+// Armoured checks if the wither is below half health, in which case it is
+// protected by armour that makes it immune to arrows.
+func (w *WitherBehaviour) Armoured() bool {
+	return w.health.Health() <= w.health.MaxHealth()/2
+}
+
+// HeadTargets returns the entities that the three heads of the wither are
+// looking at. The first head is the main head. Nil is returned for heads that
+// have no target.
+func (w *WitherBehaviour) HeadTargets() [3]*world.EntityHandle {
+	return w.targets
+}
+
+// BossBar returns the boss bar of the wither shown to players nearby. While
+// the wither is invulnerable after being spawned, the bar fills up as the
+// wither regains its health.
+func (w *WitherBehaviour) BossBar() bossbar.BossBar {
+	progress := w.health.Health() / w.health.MaxHealth()
+	if w.invulnerableTicks > 0 {
+		progress = 1 - float64(w.invulnerableTicks)/witherSpawnTicks
+	}
+	return bossbar.New("Wither").WithHealthPercentage(mgl64.Clamp(progress, 0, 1))
+}
+
+// Immune checks if the wither is immune to the damage source passed. The
+// wither is immune to all damage but the void while it is invulnerable, to
+// the Wither effect and its own skulls, and to arrows while it is armoured.
+func (w *WitherBehaviour) Immune(src world.DamageSource) bool {
+	if _, ok := src.(VoidDamageSource); ok {
+		return false
+	}
+	if w.invulnerableTicks > 0 {
+		return true
+	}
+	switch s := src.(type) {
+	case effect.WitherDamageSource:
+		return true
+	case ProjectileDamageSource:
+		if s.Owner != nil && s.Owner.H().Type() == WitherType {
+			return true
+		}
+		return w.Armoured() && s.Projectile != nil && s.Projectile.H().Type() == ArrowType
+	}
+	return false
+}
+
+// Hurt makes the wither target its attacker and break the blocks around it
+// shortly after being hurt.
+func (w *WitherBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	if w.destroyBlocksTicks <= 0 {
+		w.destroyBlocksTicks = 20
+	}
+	if attacker := damageSourceAttacker(src); attacker != nil && attacker.H() != m.H() {
+		if l, ok := attacker.(Living); ok && witherAttackable(l) {
+			w.targets[0] = attacker.H()
+		}
+	}
+}
+
+// Tick ticks the wither, moving it towards its target and shooting skulls.
+func (w *WitherBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !w.Dead() {
+		w.tickWither(&Mob{Ent: e}, tx)
+	}
+	return w.MobBehaviour.Tick(e, tx)
+}
+
+// tickWither performs the wither specific logic of a tick.
+func (w *WitherBehaviour) tickWither(m *Mob, tx *world.Tx) {
+	if w.invulnerableTicks > 0 {
+		w.tickSpawning(m, tx)
+	} else {
+		w.tickHeads(m, tx)
+		w.move(m)
+		w.tickBlockDestruction(m, tx)
+		if m.Age()%time.Second == 0 {
+			m.Heal(1, WitherHealingSource{})
+		}
+	}
+	if health := w.health.Health(); health != w.lastHealth {
+		w.lastHealth = health
+		m.updateState()
+	}
+}
+
+// tickSpawning ticks the invulnerable phase of a newly spawned wither. The
+// wither regains its health during this phase, after which it explodes.
+func (w *WitherBehaviour) tickSpawning(m *Mob, tx *world.Tx) {
+	m.data.Vel = mgl64.Vec3{}
+	if w.invulnerableTicks--; w.invulnerableTicks%10 == 0 {
+		// The wither heals a total of two thirds of its maximum health during
+		// the phase, so that it has full health once the phase ends.
+		m.Heal(float64(witherMaxHealth)*2/3/(witherSpawnTicks/10), WitherHealingSource{})
+	}
+	if w.invulnerableTicks == 0 {
+		block.ExplosionConfig{Size: 7, KeepBlocks: !tx.World().MobGriefing(), Source: m}.Explode(tx, m.Position())
+		tx.PlaySound(m.Position(), sound.WitherSpawn{})
+	}
+}
+
+// tickHeads updates the targets of the heads of the wither and makes the
+// heads shoot at their targets.
+func (w *WitherBehaviour) tickHeads(m *Mob, tx *world.Tx) {
+	if target := w.mainTarget(m, tx); target != nil {
+		w.LookAt(EyePosition(target))
+		if w.attackCooldown--; w.attackCooldown <= 0 && target.Position().Sub(m.Position()).Len() <= witherAttackRange && lineOfSight(EyePosition(m), EyePosition(target), tx) {
+			w.attackCooldown = witherAttackInterval
+			w.shootAt(m, 0, target, rand.Float64() < 0.001, tx)
+		}
+	}
+
+	tick := int(m.Age() / (time.Second / 20))
+	for i := range w.nextHeadUpdate {
+		if tick < w.nextHeadUpdate[i] {
+			continue
+		}
+		head := i + 1
+		w.nextHeadUpdate[i] = tick + 10 + rand.IntN(10)
+
+		if d := tx.World().Difficulty(); d == world.DifficultyNormal || d == world.DifficultyHard {
+			// Heads without a target occasionally shoot blue skulls at random
+			// positions around the wither.
+			if w.idleHeadUpdates[i]++; w.idleHeadUpdates[i] > 15 {
+				pos := m.Position().Add(mgl64.Vec3{rand.Float64()*20 - 10, rand.Float64()*10 - 5, rand.Float64()*20 - 10})
+				w.shoot(m, head, pos, true, tx)
+				w.idleHeadUpdates[i] = 0
+			}
+		}
+		if w.targets[head] == nil {
+			w.targets[head] = w.randomTarget(m, tx)
+			continue
+		}
+		target, ok := w.targets[head].Entity(tx)
+		if l, living := target.(Living); !ok || !living || l.Dead() || !witherAttackable(l) || target.Position().Sub(m.Position()).Len() > 30 || !lineOfSight(EyePosition(m), EyePosition(target), tx) {
+			w.targets[head] = nil
+			continue
+		}
+		w.shootAt(m, head, target, false, tx)
+		w.nextHeadUpdate[i] = tick + 40 + rand.IntN(20)
+		w.idleHeadUpdates[i] = 0
+	}
+}
+
+// mainTarget returns the entity that the main head of the wither is
+// attacking, looking for a new target if the current one is no longer valid.
+func (w *WitherBehaviour) mainTarget(m *Mob, tx *world.Tx) world.Entity {
+	if w.targets[0] != nil {
+		e, ok := w.targets[0].Entity(tx)
+		if l, living := e.(Living); ok && living && !l.Dead() && witherAttackable(l) && e.Position().Sub(m.Position()).Len() <= witherTargetRange {
+			return e
+		}
+		w.targets[0] = nil
+	}
+	if m.Age()%(time.Second/2) != 0 {
+		return nil
+	}
+	if t, ok := nearestEntity(m, tx, witherTargetRange, witherAttackable); ok {
+		w.targets[0] = t.H()
+		return t
+	}
+	return nil
+}
+
+// randomTarget returns a random entity close to the wither that one of its
+// side heads may attack, or nil if there is no such entity.
+func (w *WitherBehaviour) randomTarget(m *Mob, tx *world.Tx) *world.EntityHandle {
+	var targets []*world.EntityHandle
+	box := WitherType.BBox(m).Translate(m.Position()).GrowVec3(mgl64.Vec3{20, 8, 20})
+	for e := range tx.EntitiesWithin(box) {
+		if l, ok := e.(Living); ok && e.H() != m.H() && !l.Dead() && witherAttackable(l) {
+			targets = append(targets, e.H())
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	return targets[rand.IntN(len(targets))]
+}
+
+// shootAt makes the head passed shoot a wither skull at the target passed.
+func (w *WitherBehaviour) shootAt(m *Mob, head int, target world.Entity, dangerous bool, tx *world.Tx) {
+	h := target.Position()
+	h[1] += (EyePosition(target)[1] - h[1]) / 2
+	w.shoot(m, head, h, dangerous, tx)
+}
+
+// shoot makes the head passed shoot a wither skull at a position.
+func (w *WitherBehaviour) shoot(m *Mob, head int, pos mgl64.Vec3, dangerous bool, tx *world.Tx) {
+	origin := witherHeadPosition(m, head)
+	dir := pos.Sub(origin)
+	if dir.Len() == 0 {
+		return
+	}
+	tx.PlaySound(origin, sound.WitherShoot{})
+	tx.AddEntity(NewWitherSkull(world.EntitySpawnOpts{Position: origin}, m, dir, dangerous))
+}
+
+// move makes the wither fly towards its main target. The wither stays a few
+// blocks above its target unless it is armoured.
+func (w *WitherBehaviour) move(m *Mob) {
+	vel := m.data.Vel
+	vel[1] *= 0.6
+
+	target, ok := w.targets[0].Entity(m.tx)
+	if w.targets[0] != nil && ok {
+		pos, targetPos := m.Position(), target.Position()
+		if pos[1] < targetPos[1] || (!w.Armoured() && pos[1] < targetPos[1]+5) {
+			vel[1] = math.Max(0, vel[1])
+			vel[1] += 0.3 - vel[1]*0.6
+		}
+		if delta := (mgl64.Vec3{targetPos[0] - pos[0], 0, targetPos[2] - pos[2]}); delta.Len() > 3 {
+			dir := delta.Normalize()
+			vel[0] += dir[0]*0.3 - vel[0]*0.6
+			vel[2] += dir[2]*0.3 - vel[2]*0.6
+		}
+	}
+	m.data.Vel = vel
+}
+
+// tickBlockDestruction makes the wither break the blocks around it shortly
+// after it was hurt. While armoured, the wither immediately breaks any blocks
+// that it touches.
+func (w *WitherBehaviour) tickBlockDestruction(m *Mob, tx *world.Tx) {
+	if w.destroyBlocksTicks > 0 {
+		if w.destroyBlocksTicks--; w.destroyBlocksTicks == 0 {
+			w.destroyBlocks(m, tx)
+		}
+		return
+	}
+	if w.Armoured() {
+		w.destroyBlocks(m, tx)
+	}
+}
+
+// destroyBlocks breaks all blocks in the space occupied by the wither. Blocks
+// are only broken if mob griefing is enabled in the world, and blocks that
+// cannot be broken, such as bedrock, are never broken.
+func (w *WitherBehaviour) destroyBlocks(m *Mob, tx *world.Tx) {
+	if !tx.World().MobGriefing() {
+		return
+	}
+	base, destroyed := cube.PosFromVec3(m.Position()), false
+	for x := -1; x <= 1; x++ {
+		for y := 0; y <= 3; y++ {
+			for z := -1; z <= 1; z++ {
+				pos := base.Add(cube.Pos{x, y, z})
+				b := tx.Block(pos)
+				breakable, ok := b.(block.Breakable)
+				if !ok {
+					continue
+				}
+				tx.SetBlock(pos, nil, nil)
+				tx.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: b})
+				for _, drop := range breakable.BreakInfo().Drops(item.ToolNone{}, nil) {
+					opts := world.EntitySpawnOpts{Position: pos.Vec3Centre(), Velocity: mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1}}
+					tx.AddEntity(NewItem(opts, drop))
+				}
+				destroyed = true
+			}
+		}
+	}
+	if destroyed {
+		tx.PlaySound(m.Position(), sound.WitherBreakBlock{})
+	}
+}
+
+// drops returns the items dropped by the wither when it dies.
+func (w *WitherBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	return []item.Stack{item.NewStack(item.NetherStar{}, 1)}
+}
+
+// decodeNBT decodes the state of the wither from the map passed.
+func (w *WitherBehaviour) decodeNBT(m map[string]any) {
+	w.MobBehaviour.decodeNBT(m)
+	if v, ok := m["Invul"].(int32); ok {
+		w.invulnerableTicks = int(v)
+	}
+}
+
+// witherHeadPosition returns the position of the head of the wither passed.
+// Head 0 is the main head in the middle, while heads 1 and 2 are the side
+// heads.
+func witherHeadPosition(m *Mob, head int) mgl64.Vec3 {
+	pos := m.Position()
+	if head <= 0 {
+		return pos.Add(mgl64.Vec3{0, 3})
+	}
+	yaw := mgl64.DegToRad(m.Rotation().Yaw() + float64(180*(head-1)))
+	return pos.Add(mgl64.Vec3{math.Cos(yaw) * 1.3, 2.2, math.Sin(yaw) * 1.3})
+}
+
+// witherAttackable checks if the wither attacks the entity passed. The wither
+// attacks players and all mobs that are not undead.
+func witherAttackable(e Living) bool {
+	if _, ok := e.(*Mob); !ok {
+		return attackablePlayer(e)
+	}
+	t := e.H().Type()
+	return !zombie(e) && !skeleton(e) && t != PhantomType && t != WitherType
+}
+
+// WitherType is a world.EntityType implementation for the wither.
+var WitherType witherType
+
+type witherType struct{}
+
+func (witherType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (witherType) EncodeEntity() string { return "minecraft:wither" }
+func (witherType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.45, 0, -0.45, 0.45, 3.5, 0.45)
+}
+
+func (witherType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	w := witherConf.New()
+	w.decodeNBT(m)
+	data.Data = w
+}
+
+func (witherType) EncodeNBT(data *world.EntityData) map[string]any {
+	w := data.Data.(*WitherBehaviour)
+	m := map[string]any{"Invul": int32(w.invulnerableTicks)}
+	w.MobBehaviour.encodeNBT(m)
+	return m
+}
@@ -0,0 +1,178 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+// NewWitherSkull creates a wither skull, as shot by the wither, that
+// accelerates in the direction passed. If dangerous is true, a blue wither
+// skull is created, which moves slower but is able to destroy blocks that are
+// usually resistant to explosions.
+func NewWitherSkull(opts world.EntitySpawnOpts, owner world.Entity, dir mgl64.Vec3, dangerous bool) *world.EntityHandle {
+	conf := witherSkullConf
+	conf.Owner, conf.Direction, conf.Dangerous = owner.H(), dir, dangerous
+	if dangerous {
+		return opts.New(DangerousWitherSkullType, conf)
+	}
+	return opts.New(WitherSkullType, conf)
+}
+
+var witherSkullConf = WitherSkullBehaviourConfig{}
+
+// WitherSkullBehaviourConfig holds optional parameters for a
+// WitherSkullBehaviour.
+type WitherSkullBehaviourConfig struct {
+	// Owner is the entity that shot the wither skull.
+	Owner *world.EntityHandle
+	// Direction is the direction that the wither skull accelerates in.
+	Direction mgl64.Vec3
+	// Dangerous specifies if the wither skull is a blue wither skull. Blue
+	// wither skulls move slower, but their explosions destroy blocks such as
+	// obsidian.
+	Dangerous bool
+}
+
+func (conf WitherSkullBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a WitherSkullBehaviour using the parameters in conf.
+func (conf WitherSkullBehaviourConfig) New() *WitherSkullBehaviour {
+	s := &WitherSkullBehaviour{dangerous: conf.Dangerous}
+	if conf.Direction.Len() > 0 {
+		s.acceleration = conf.Direction.Normalize().Mul(witherSkullAcceleration)
+	}
+	drag := 0.05
+	if conf.Dangerous {
+		drag = 0.27
+	}
+	s.ProjectileBehaviour = ProjectileBehaviourConfig{
+		Owner:  conf.Owner,
+		Drag:   drag,
+		Damage: -1,
+		Hit:    s.hit,
+	}.New()
+	return s
+}
+
+// witherSkullAcceleration is the velocity added to a wither skull every tick
+// in the direction it was shot in.
+const witherSkullAcceleration = 0.1
+
+// WitherSkullBehaviour implements the behaviour of wither skulls. Like
+// fireballs, wither skulls are not affected by gravity but keep accelerating
+// in the direction they were shot in. They explode when they hit something and
+// inflict Wither on entities hit.
+type WitherSkullBehaviour struct {
+	*ProjectileBehaviour
+
+	acceleration mgl64.Vec3
+	dangerous    bool
+}
+
+// Dangerous checks if the wither skull is a blue wither skull.
+func (s *WitherSkullBehaviour) Dangerous() bool {
+	return s.dangerous
+}
+
+// Tick accelerates the wither skull and moves it.
+func (s *WitherSkullBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	e.data.Vel = e.data.Vel.Add(s.acceleration)
+	return s.ProjectileBehaviour.Tick(e, tx)
+}
+
+// hit hurts the entity hit by the wither skull, inflicting Wither on it, and
+// makes the skull explode. The wither heals when one of its skulls kills an
+// entity.
+func (s *WitherSkullBehaviour) hit(e *Ent, tx *world.Tx, target trace.Result) {
+	owner, _ := s.Owner().Entity(tx)
+	if r, ok := target.(trace.EntityResult); ok {
+		if l, ok := r.Entity().(Living); ok {
+			s.hitEntity(e, l, owner, tx)
+		}
+	}
+	block.ExplosionConfig{
+		Size:               1,
+		KeepBlocks:         !tx.World().MobGriefing(),
+		MaxBlastResistance: s.maxBlastResistance(),
+		Source:             owner,
+	}.Explode(tx, e.Position())
+}
+
+// hitEntity hurts the living entity passed. Wither is inflicted on the entity
+// on normal and hard difficulty.
+func (s *WitherSkullBehaviour) hitEntity(e *Ent, l Living, owner world.Entity, tx *world.Tx) {
+	src := ProjectileDamageSource{Projectile: e, Owner: owner}
+	dmg := 5.0
+	if _, ok := owner.(Living); ok {
+		dmg = 8
+	}
+	if _, vulnerable := l.Hurt(dmg, src); !vulnerable {
+		return
+	}
+	if o, ok := owner.(Living); ok && l.Dead() {
+		o.Heal(5, WitherHealingSource{})
+	}
+	var dur time.Duration
+	switch tx.World().Difficulty() {
+	case world.DifficultyNormal:
+		dur = time.Second * 10
+	case world.DifficultyHard:
+		dur = time.Second * 40
+	}
+	if dur > 0 && !l.Dead() {
+		l.AddEffect(effect.New(effect.Wither, 2, dur))
+	}
+}
+
+// maxBlastResistance returns the blast resistance that blocks hit by the
+// explosion of the skull are limited to. Blue wither skulls limit it to 0.8,
+// so that they can destroy almost any block.
+func (s *WitherSkullBehaviour) maxBlastResistance() float64 {
+	if s.dangerous {
+		return 0.8
+	}
+	return 0
+}
+
+// WitherSkullType is a world.EntityType implementation for wither skulls.
+var WitherSkullType witherSkullType
+
+// DangerousWitherSkullType is a world.EntityType implementation for blue
+// wither skulls.
+var DangerousWitherSkullType = witherSkullType{dangerous: true}
+
+type witherSkullType struct {
+	dangerous bool
+}
+
+func (t witherSkullType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (t witherSkullType) EncodeEntity() string {
+	if t.dangerous {
+		return "minecraft:wither_skull_dangerous"
+	}
+	return "minecraft:wither_skull"
+}
+func (witherSkullType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.15625, 0, -0.15625, 0.15625, 0.3125, 0.15625)
+}
+
+func (t witherSkullType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := witherSkullConf
+	conf.Direction, conf.Dangerous = nbtconv.Vec3(m, "power"), t.dangerous
+	data.Data = conf.New()
+}
+
+func (witherSkullType) EncodeNBT(data *world.EntityData) map[string]any {
+	return map[string]any{"power": nbtconv.Vec3ToFloat32Slice(data.Data.(*WitherSkullBehaviour).acceleration)}
+}
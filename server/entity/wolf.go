@@ -0,0 +1,398 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/pathfind"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"math/rand/v2"
+)
+
+// NewWolf creates a new wild wolf.
+func NewWolf(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(WolfType, wolfConf)
+}
+
+var wolfConf = WolfBehaviourConfig{}
+
+// WolfBehaviourConfig holds optional parameters for a WolfBehaviour.
+type WolfBehaviourConfig struct {
+	// Owner is the UUID of the player that owns the wolf. If set, the wolf is
+	// spawned tamed.
+	Owner uuid.UUID
+}
+
+func (conf WolfBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a WolfBehaviour using the parameters in conf.
+func (conf WolfBehaviourConfig) New() *WolfBehaviour {
+	w := &WolfBehaviour{collar: item.ColourRed(), nav: pathfind.NewNavigator(pathfind.Pathfinder{Height: 1})}
+	w.MobBehaviour = MobBehaviourConfig{MaxHealth: 8, Speed: 0.12, Experience: 3}.New()
+	if conf.Owner != uuid.Nil {
+		w.tame(conf.Owner)
+	}
+	return w
+}
+
+// WolfBehaviour implements the behaviour of wolves. Wild wolves may be tamed
+// by feeding them bones. Tamed wolves follow their owner, may be told to sit
+// and defend their owner from entities that attack it.
+type WolfBehaviour struct {
+	*MobBehaviour
+
+	owner       uuid.UUID
+	ownerHandle *world.EntityHandle
+	sitting     bool
+	collar      item.Colour
+
+	angryTicks     int
+	target         *world.EntityHandle
+	attackCooldown int
+
+	// nav moves the wolf along paths towards its owner or target, which are
+	// recalculated every wolfRepathTicks ticks while they are moving.
+	nav    *pathfind.Navigator
+	repath int
+}
+
+// wolfRepathTicks is the amount of ticks after which the path of a wolf to
+// its owner or target is recalculated.
+const wolfRepathTicks = 10
+
+// Tamed checks if the wolf has been tamed by a player.
+func (w *WolfBehaviour) Tamed() bool {
+	return w.owner != uuid.Nil
+}
+
+// OwnerUUID returns the UUID of the player that owns the wolf, or uuid.Nil if
+// the wolf is not tamed.
+func (w *WolfBehaviour) OwnerUUID() uuid.UUID {
+	return w.owner
+}
+
+// Owner returns the handle of the owner of the wolf if it is currently in the
+// same world as the wolf. Nil is returned otherwise.
+func (w *WolfBehaviour) Owner() *world.EntityHandle {
+	return w.ownerHandle
+}
+
+// Sitting checks if the wolf is currently sitting. Sitting wolves do not
+// follow their owner.
+func (w *WolfBehaviour) Sitting() bool {
+	return w.sitting
+}
+
+// Angry checks if the wolf is currently angry at an entity that attacked it.
+func (w *WolfBehaviour) Angry() bool {
+	return w.angryTicks > 0
+}
+
+// CollarColour returns the colour of the collar of the wolf. Only tamed wolves
+// wear a collar.
+func (w *WolfBehaviour) CollarColour() item.Colour {
+	return w.collar
+}
+
+// Target returns the entity that the wolf is currently attacking, or nil if
+// it is not attacking any entity.
+func (w *WolfBehaviour) Target() *world.EntityHandle {
+	return w.target
+}
+
+// Interact tames a wild wolf if the user is holding a bone. The owner of a
+// tamed wolf may dye its collar by using a dye on it, or make it sit or
+// stand up otherwise.
+func (w *WolfBehaviour) Interact(m *Mob, user item.User, _ *world.Tx) bool {
+	held, _ := user.HeldItems()
+	if !w.Tamed() {
+		if _, ok := held.Item().(item.Bone); !ok || w.Angry() {
+			return false
+		}
+		consumeHeldItem(user)
+		success := rand.IntN(3) == 0
+		if success {
+			w.tame(user.H().UUID())
+			w.sitting, w.target = true, nil
+			w.StopMoving()
+			m.SetMaxHealth(20)
+			m.Heal(20, FoodHealingSource{})
+		}
+		for _, v := range m.tx.Viewers(m.Position()) {
+			v.ViewEntityAction(m, TameAction{Success: success})
+		}
+		m.updateState()
+		return true
+	}
+	if user.H().UUID() != w.owner {
+		return false
+	}
+	if dye, ok := held.Item().(item.Dye); ok {
+		if dye.Colour == w.collar {
+			return false
+		}
+		consumeHeldItem(user)
+		w.collar = dye.Colour
+	} else {
+		w.sitting = !w.sitting
+		w.target = nil
+		w.StopMoving()
+	}
+	m.updateState()
+	return true
+}
+
+// tame makes the player with the UUID passed the owner of the wolf.
+func (w *WolfBehaviour) tame(owner uuid.UUID) {
+	w.owner, w.angryTicks = owner, 0
+}
+
+// Hurt makes the wolf attack the entity that attacked it. Wild wolves become
+// angry when they are attacked, while tamed wolves stand up if they were
+// sitting.
+func (w *WolfBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	attacker := damageSourceAttacker(src)
+	if attacker == nil || attacker.H().UUID() == w.owner {
+		return
+	}
+	if !w.Tamed() {
+		w.angryTicks = 400 + rand.IntN(400)
+	}
+	w.sitting, w.target = false, attacker.H()
+	m.updateState()
+}
+
+// Tick ticks the wolf, making it follow its owner and attack its target.
+func (w *WolfBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !w.Dead() {
+		m := &Mob{Ent: e}
+		w.tickWolf(m, tx)
+		w.nav.Tick(m)
+	}
+	return w.MobBehaviour.Tick(e, tx)
+}
+
+// tickWolf performs the wolf specific logic of a tick.
+func (w *WolfBehaviour) tickWolf(m *Mob, tx *world.Tx) {
+	if w.attackCooldown > 0 {
+		w.attackCooldown--
+	}
+	if w.angryTicks > 0 {
+		if w.angryTicks--; w.angryTicks == 0 {
+			w.target = nil
+			m.updateState()
+		}
+	}
+	owner := w.findOwner(tx)
+	if w.sitting {
+		w.nav.Stop(m)
+		w.StopMoving()
+		return
+	}
+	if w.target != nil && w.attack(m, tx) {
+		return
+	}
+	if owner == nil {
+		if w.nav.Done() && rand.IntN(120) == 0 {
+			w.nav.MoveTo(m, tx, m.Position().Add(randomHorizontalOffset(8)), 0.5)
+		}
+		return
+	}
+	switch dist := owner.Position().Sub(m.Position()).Len(); {
+	case dist > 12:
+		w.teleportToOwner(m, owner, tx)
+	case dist > 6:
+		w.navigate(m, tx, owner.Position(), 1)
+	case dist < 2:
+		w.nav.Stop(m)
+	}
+}
+
+// navigate makes the wolf walk along a path to the position passed. The path
+// is only recalculated every wolfRepathTicks ticks or once the wolf reached
+// the end of its current path, as the position passed usually belongs to a
+// moving entity.
+func (w *WolfBehaviour) navigate(m *Mob, tx *world.Tx, pos mgl64.Vec3, speedMultiplier float64) {
+	if w.repath--; w.repath <= 0 || w.nav.Done() {
+		w.repath = wolfRepathTicks
+		w.nav.MoveTo(m, tx, pos, speedMultiplier)
+	}
+}
+
+// findOwner looks up the owner of the wolf in the world and returns it if it
+// was found.
+func (w *WolfBehaviour) findOwner(tx *world.Tx) world.Entity {
+	if !w.Tamed() {
+		return nil
+	}
+	if w.ownerHandle != nil {
+		if owner, ok := w.ownerHandle.Entity(tx); ok {
+			return owner
+		}
+		w.ownerHandle = nil
+	}
+	for p := range tx.Players() {
+		if p.H().UUID() == w.owner {
+			w.ownerHandle = p.H()
+			return p
+		}
+	}
+	return nil
+}
+
+// attack makes the wolf move towards its target and attack it. False is
+// returned if the wolf no longer has a target.
+func (w *WolfBehaviour) attack(m *Mob, tx *world.Tx) bool {
+	e, ok := w.target.Entity(tx)
+	t, living := e.(Living)
+	if !ok || !living || t.Dead() || e.Position().Sub(m.Position()).Len() > 24 {
+		w.target = nil
+		return false
+	}
+	w.LookAt(EyePosition(e))
+	if e.Position().Sub(m.Position()).Len() > 1.5 {
+		w.navigate(m, tx, e.Position(), 1.2)
+		return true
+	}
+	w.nav.Stop(m)
+	if w.attackCooldown == 0 {
+		w.attackCooldown = 20
+		if _, vulnerable := t.Hurt(4, AttackDamageSource{Attacker: m}); vulnerable {
+			t.KnockBack(m.Position(), 0.4, 0.4)
+		}
+	}
+	return true
+}
+
+// teleportToOwner teleports the wolf to a random safe position close to its
+// owner. The wolf is not teleported if no safe position was found.
+func (w *WolfBehaviour) teleportToOwner(m *Mob, owner world.Entity, tx *world.Tx) {
+	ownerPos := cube.PosFromVec3(owner.Position())
+	for range 10 {
+		x, y, z := rand.IntN(7)-3, rand.IntN(3)-1, rand.IntN(7)-3
+		if x > -2 && x < 2 && z > -2 && z < 2 {
+			// Don't teleport the wolf right on top of its owner.
+			continue
+		}
+		pos := ownerPos.Add(cube.Pos{x, y, z})
+		if safeTeleportPos(pos, tx) {
+			w.nav.Stop(m)
+			m.Teleport(pos.Vec3Middle())
+			return
+		}
+	}
+}
+
+// safeTeleportPos checks if the position passed is safe for a mob to teleport
+// to: The block below must have a solid top face, while the block at the
+// position and the one above it must not have a collision box or contain a
+// liquid.
+func safeTeleportPos(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	if !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+		return false
+	}
+	for _, p := range [...]cube.Pos{pos, pos.Side(cube.FaceUp)} {
+		if _, ok := tx.Liquid(p); ok || len(tx.Block(p).Model().BBox(p, tx)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// AlertTamed makes all mobs tamed by the owner passed attack the target
+// passed, for example because the target attacked the owner or because it
+// was attacked by the owner. Only tamed mobs close to the owner are alerted.
+func AlertTamed(owner, target world.Entity, tx *world.Tx) {
+	if target == nil || target.H() == owner.H() {
+		return
+	}
+	id := owner.H().UUID()
+	for e := range tx.EntitiesWithin(cube.Box(-16, -16, -16, 16, 16, 16).Translate(owner.Position())) {
+		m, ok := e.(*Mob)
+		if !ok || e.H() == target.H() {
+			continue
+		}
+		if w, ok := m.Behaviour().(*WolfBehaviour); ok && w.owner == id && !w.sitting && !m.Dead() {
+			if t, ok := target.(*Mob); ok {
+				if other, ok := t.Behaviour().(*WolfBehaviour); ok && other.owner == id {
+					// Wolves never attack other wolves of their owner.
+					continue
+				}
+			}
+			w.target = target.H()
+		}
+	}
+}
+
+// damageSourceAttacker returns the entity responsible for the damage source
+// passed, or nil if no entity was responsible.
+func damageSourceAttacker(src world.DamageSource) world.Entity {
+	switch s := src.(type) {
+	case AttackDamageSource:
+		return s.Attacker
+	case ProjectileDamageSource:
+		return s.Owner
+	case SonicBoomDamageSource:
+		return s.Warden
+	case ExplosionDamageSource:
+		return s.Source
+	}
+	return nil
+}
+
+// WolfType is a world.EntityType implementation for wolves.
+var WolfType wolfType
+
+type wolfType struct{}
+
+func (wolfType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (wolfType) EncodeEntity() string { return "minecraft:wolf" }
+func (wolfType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 0.85, 0.3)
+}
+
+func (wolfType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	w := wolfConf.New()
+	if id, err := uuid.Parse(nbtconv.String(m, "OwnerUUID")); err == nil {
+		w.tame(id)
+	}
+	w.MobBehaviour.decodeNBT(m)
+	w.sitting = nbtconv.Bool(m, "Sitting")
+	w.angryTicks = int(nbtconv.Int32(m, "AngerTime"))
+	if _, ok := m["Color"]; ok {
+		w.collar = colourFromUint8(nbtconv.Uint8(m, "Color"))
+	}
+	data.Data = w
+}
+
+func (wolfType) EncodeNBT(data *world.EntityData) map[string]any {
+	w := data.Data.(*WolfBehaviour)
+	m := map[string]any{
+		"Sitting":   boolByte(w.sitting),
+		"AngerTime": int32(w.angryTicks),
+		"Color":     w.collar.Uint8(),
+	}
+	if w.Tamed() {
+		m["OwnerUUID"] = w.owner.String()
+	}
+	w.MobBehaviour.encodeNBT(m)
+	return m
+}
+
+// colourFromUint8 returns the item.Colour with the uint8 value passed.
+func colourFromUint8(v uint8) item.Colour {
+	for _, c := range item.Colours() {
+		if c.Uint8() == v {
+			return c
+		}
+	}
+	return item.ColourWhite()
+}
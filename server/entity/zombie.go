@@ -0,0 +1,596 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/pathfind"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// NewZombie creates a new zombie.
+func NewZombie(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(ZombieType, zombieConf)
+}
+
+// NewBabyZombie creates a new baby zombie. Baby zombies are smaller and
+// faster than adult zombies and never grow up.
+func NewBabyZombie(opts world.EntitySpawnOpts) *world.EntityHandle {
+	conf := zombieConf
+	conf.Baby = true
+	return opts.New(ZombieType, conf)
+}
+
+// NewDrowned creates a new drowned. Drowned are zombies that are able to swim.
+func NewDrowned(opts world.EntitySpawnOpts) *world.EntityHandle {
+	conf := zombieConf
+	conf.Drowned = true
+	return opts.New(DrownedType, conf)
+}
+
+var zombieConf = ZombieBehaviourConfig{}
+
+// ZombieBehaviourConfig holds optional parameters for a ZombieBehaviour.
+type ZombieBehaviourConfig struct {
+	// Baby specifies if the zombie is a baby zombie.
+	Baby bool
+	// Drowned specifies if the zombie is a drowned.
+	Drowned bool
+}
+
+func (conf ZombieBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a ZombieBehaviour using the parameters in conf.
+func (conf ZombieBehaviourConfig) New() *ZombieBehaviour {
+	z := &ZombieBehaviour{baby: conf.Baby, drowned: conf.Drowned, conversionTicks: -1}
+	z.reinforcementChance = rand.Float64() * 0.1
+	speed, xp := 0.1, 5
+	if conf.Baby {
+		speed, xp = 0.15, 12
+	}
+	z.MobBehaviour = MobBehaviourConfig{Speed: speed, Swimming: conf.Drowned, Experience: xp, Drops: z.drops, Equip: func(m *Mob, eq *Equipment, difficulty float64) {
+		equipZombie(m, eq, difficulty, conf.Drowned)
+	}}.New()
+	return z
+}
+
+const (
+	// zombieFollowRange is the distance within which zombies look for and
+	// pursue their targets.
+	zombieFollowRange = 35
+	// zombieRepathTicks is the amount of ticks after which the path of a
+	// zombie to its target is recalculated.
+	zombieRepathTicks = 10
+	// zombieDoorBreakTicks is the amount of ticks that it takes for a zombie
+	// to break down a wooden door.
+	zombieDoorBreakTicks = 240
+	// zombieDrownedConversionTicks is the amount of ticks that a zombie must
+	// be submerged in water before it starts converting into a drowned. The
+	// conversion itself takes zombieConversionTicks.
+	zombieDrownedConversionTicks, zombieConversionTicks = 600, 300
+)
+
+// ZombieBehaviour implements the behaviour of zombies, baby zombies and
+// drowned. Zombies pursue players, villagers and iron golems and attack them
+// in melee, burn in sunlight and may call for reinforcements when they are
+// hurt. Zombies that are submerged in water for too long convert into
+// drowned.
+type ZombieBehaviour struct {
+	*MobBehaviour
+	zombieAttacker
+
+	baby    bool
+	drowned bool
+
+	// submergedTicks is the amount of ticks that the zombie has been
+	// submerged in water. conversionTicks is the amount of ticks left until
+	// the zombie converts into a drowned, or -1 if it is not converting.
+	submergedTicks  int
+	conversionTicks int
+}
+
+// Baby checks if the zombie is a baby zombie.
+func (z *ZombieBehaviour) Baby() bool {
+	return z.baby
+}
+
+// Scale returns the scale of the zombie, which is 0.5 for baby zombies.
+func (z *ZombieBehaviour) Scale() float64 {
+	if z.baby {
+		return 0.5
+	}
+	return 1
+}
+
+// HeldItems returns the items held by the zombie.
+func (z *ZombieBehaviour) HeldItems() (mainHand, offHand item.Stack) {
+	return z.Equipment().HeldItems()
+}
+
+// Armour returns the armour worn by the zombie. Zombies wearing a helmet do
+// not burn in sunlight.
+func (z *ZombieBehaviour) Armour() *inventory.Armour {
+	return z.Equipment().Armour()
+}
+
+// Drowned checks if the zombie is a drowned.
+func (z *ZombieBehaviour) Drowned() bool {
+	return z.drowned
+}
+
+// Converting checks if the zombie is currently converting into a drowned.
+func (z *ZombieBehaviour) Converting() bool {
+	return z.conversionTicks >= 0
+}
+
+// ConversionTime returns the time left until the zombie converts into a
+// drowned. 0 is returned if it is not converting.
+func (z *ZombieBehaviour) ConversionTime() time.Duration {
+	return time.Duration(max(z.conversionTicks, 0)) * time.Second / 20
+}
+
+// Hurt makes the zombie attack the entity that attacked it, possibly calling
+// for reinforcements.
+func (z *ZombieBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	z.hurt(m, src)
+}
+
+// Tick ticks the zombie, making it pursue and attack its target and convert
+// into a drowned if it is submerged in water for too long.
+func (z *ZombieBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !z.Dead() {
+		m := &Mob{Ent: e}
+		if z.tickConversion(m, tx) {
+			return nil
+		}
+		z.tickZombie(m, z.MobBehaviour, tx)
+	}
+	return z.MobBehaviour.Tick(e, tx)
+}
+
+// tickConversion tracks the time that the zombie has been submerged in water
+// and converts it into a drowned once it has been submerged for long enough.
+// True is returned if the zombie was converted.
+func (z *ZombieBehaviour) tickConversion(m *Mob, tx *world.Tx) bool {
+	if z.drowned {
+		return false
+	}
+	if z.Converting() {
+		if z.conversionTicks--; z.conversionTicks <= 0 {
+			conf := zombieConf
+			conf.Baby, conf.Drowned = z.baby, true
+			b := conf.New()
+			// Drowned keep the equipment of the zombie that they converted
+			// from.
+			b.equipment = z.equipment
+			transform(m, DrownedType, b, tx)
+			return true
+		}
+		return false
+	}
+	if l, ok := tx.Liquid(cube.PosFromVec3(EyePosition(m))); ok {
+		if _, water := l.(block.Water); water {
+			if z.submergedTicks++; z.submergedTicks >= zombieDrownedConversionTicks {
+				z.conversionTicks = zombieConversionTicks
+				m.updateState()
+			}
+			return false
+		}
+	}
+	z.submergedTicks = 0
+	return false
+}
+
+// drops returns the items dropped by a zombie when it dies.
+func (z *ZombieBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	var drops []item.Stack
+	if n := rand.IntN(3); n > 0 {
+		drops = append(drops, item.NewStack(item.RottenFlesh{}, n))
+	}
+	if z.drowned {
+		if rand.Float64() < 0.11 {
+			drops = append(drops, item.NewStack(item.CopperIngot{}, 1))
+		}
+		return drops
+	}
+	if rand.Float64() < 0.025 {
+		rare := []world.Item{item.IronIngot{}, block.Carrot{}, block.Potato{}}
+		drops = append(drops, item.NewStack(rare[rand.IntN(len(rare))], 1))
+	}
+	return drops
+}
+
+// equipZombie gives a zombie random equipment when it spawns, depending on
+// the local difficulty. Zombies may wear armour and hold an iron sword or
+// shovel, while drowned may hold a nautilus shell in their off-hand.
+func equipZombie(m *Mob, eq *Equipment, difficulty float64, drowned bool) {
+	hard := m.tx.World().Difficulty() == world.DifficultyHard
+	eq.SetCanPickUpLoot(rand.Float64() < 0.55*difficulty)
+	if drowned {
+		if rand.Float64() < 0.03 {
+			mainHand, _ := eq.HeldItems()
+			eq.SetHeldItems(mainHand, item.NewStack(item.NautilusShell{}, 1))
+		}
+		return
+	}
+	equipRandomArmour(eq, difficulty, hard)
+	weaponChance := 0.01
+	if hard {
+		weaponChance = 0.05
+	}
+	if rand.Float64() < weaponChance {
+		if rand.IntN(3) == 0 {
+			eq.Equip(item.NewStack(item.Sword{Tier: item.ToolTierIron}, 1), false)
+		} else {
+			eq.Equip(item.NewStack(item.Shovel{Tier: item.ToolTierIron}, 1), false)
+		}
+	}
+}
+
+// zombieAttacker implements the hostile behaviour shared by all kinds of
+// zombies. Zombies burn in sunlight, pursue and attack players, villagers and
+// iron golems and break down wooden doors on hard difficulty.
+type zombieAttacker struct {
+	target         *world.EntityHandle
+	attackCooldown int
+
+	door      *cube.Pos
+	doorTicks int
+
+	// nav moves the zombie along paths towards its target, which are
+	// recalculated every zombieRepathTicks ticks while it is moving.
+	nav    *pathfind.Navigator
+	repath int
+
+	// reinforcementChance is the chance that the zombie calls for
+	// reinforcements when it is hurt by another entity on hard difficulty.
+	reinforcementChance float64
+}
+
+// Target returns the entity that the zombie is currently pursuing, or nil if
+// it is not pursuing any entity.
+func (z *zombieAttacker) Target() *world.EntityHandle {
+	return z.target
+}
+
+// hurt makes the zombie target the entity that attacked it. On hard
+// difficulty, the zombie may call for reinforcements, spawning another zombie
+// close to it that targets the attacker too.
+func (z *zombieAttacker) hurt(m *Mob, src world.DamageSource) {
+	attacker := damageSourceAttacker(src)
+	if attacker == nil || m.Dead() {
+		return
+	}
+	if _, ok := attacker.(Living); !ok || zombie(attacker) {
+		return
+	}
+	z.target = attacker.H()
+	if m.tx.World().Difficulty() != world.DifficultyHard || rand.Float64() >= z.reinforcementChance {
+		return
+	}
+	if pos, ok := zombieReinforcementPos(m, m.tx); ok {
+		b := zombieConf.New()
+		b.target = attacker.H()
+		b.reinforcementChance = max(b.reinforcementChance-0.05, 0)
+		m.tx.AddEntity(world.EntitySpawnOpts{Position: pos.Vec3Middle()}.New(ZombieType, mobConfig{b: b}))
+		z.reinforcementChance = max(z.reinforcementChance-0.05, 0)
+	}
+}
+
+// zombieReinforcementPos looks for a position between 7 and 40 blocks away
+// from the mob passed that a reinforcement zombie may spawn at. False is
+// returned if no such position was found.
+func zombieReinforcementPos(m *Mob, tx *world.Tx) (cube.Pos, bool) {
+	centre := cube.PosFromVec3(m.Position())
+	offset := func() int {
+		return (7 + rand.IntN(34)) * (rand.IntN(3) - 1)
+	}
+	for range 50 {
+		pos := centre.Add(cube.Pos{offset(), offset(), offset()})
+		if pos.OutOfBounds(tx.Range()) || !zombieSpawnable(pos, tx) {
+			continue
+		}
+		nearPlayer := false
+		for e := range tx.EntitiesWithin(cube.Box(-7, -7, -7, 7, 7, 7).Translate(pos.Vec3Middle())) {
+			if _, ok := e.(interface{ GameMode() world.GameMode }); ok {
+				nearPlayer = true
+				break
+			}
+		}
+		if !nearPlayer {
+			return pos, true
+		}
+	}
+	return cube.Pos{}, false
+}
+
+// zombieSpawnable checks if a zombie fits at the position passed while
+// standing on a solid block.
+func zombieSpawnable(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	if len(tx.Block(below).Model().BBox(below, tx)) == 0 {
+		return false
+	}
+	for _, p := range []cube.Pos{pos, pos.Side(cube.FaceUp)} {
+		if _, ok := tx.Liquid(p); ok || len(tx.Block(p).Model().BBox(p, tx)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// tickZombie performs the logic shared by all zombies for a tick.
+func (z *zombieAttacker) tickZombie(m *Mob, b *MobBehaviour, tx *world.Tx) {
+	eq := b.Equipment()
+	if eq.Armour().Helmet().Empty() && inSunlight(m, tx) && m.OnFireDuration() <= 0 {
+		m.SetOnFire(time.Second * 8)
+	}
+	if z.attackCooldown > 0 {
+		z.attackCooldown--
+	}
+	nav := z.navigator(m, tx)
+	z.pursue(m, b, tx)
+	nav.Tick(m)
+}
+
+// pursue makes the zombie walk towards its target and attack it, or wander
+// around if it has no target.
+func (z *zombieAttacker) pursue(m *Mob, b *MobBehaviour, tx *world.Tx) {
+	eq := b.Equipment()
+	target := z.findTarget(m, tx)
+	if target == nil {
+		z.stopBreakingDoor(tx)
+		if z.nav.Done() && rand.IntN(120) == 0 {
+			z.nav.MoveTo(m, tx, m.Position().Add(randomHorizontalOffset(8)), 0.8)
+		}
+		return
+	}
+	if z.breakDoor(m, b, target, tx) {
+		return
+	}
+	b.LookAt(EyePosition(target))
+	z.navigate(m, tx, target.Position(), 1)
+
+	if target.Position().Sub(m.Position()).Len() > 2 || z.attackCooldown > 0 {
+		return
+	}
+	z.attackCooldown = 20
+	dmg := 3.0
+	switch tx.World().Difficulty() {
+	case world.DifficultyEasy:
+		dmg = 2
+	case world.DifficultyHard:
+		dmg = 4
+	}
+	if held, _ := eq.HeldItems(); !held.Empty() {
+		// Zombies deal the damage of the weapon they are holding on top of
+		// their own damage.
+		dmg += held.AttackDamage() - 1
+	}
+	if _, vulnerable := target.Hurt(b.attackDamage(dmg), AttackDamageSource{Attacker: m}); vulnerable {
+		target.KnockBack(m.Position(), 0.4, 0.4)
+	}
+	for _, v := range tx.Viewers(m.Position()) {
+		v.ViewEntityAction(m, SwingArmAction{})
+	}
+}
+
+// navigator returns the Navigator that moves the zombie along paths. Zombies
+// only find paths through wooden doors on hard difficulty, as they are only
+// able to break them down on hard difficulty, so the Navigator is replaced if
+// the difficulty changed.
+func (z *zombieAttacker) navigator(m *Mob, tx *world.Tx) *pathfind.Navigator {
+	hard := tx.World().Difficulty() == world.DifficultyHard
+	if z.nav == nil || z.nav.Pathfinder().BreakDoors != hard {
+		if z.nav != nil {
+			z.nav.Stop(m)
+		}
+		height := int(math.Ceil(m.H().Type().BBox(m).Height()))
+		z.nav = pathfind.NewNavigator(pathfind.Pathfinder{Height: height, BreakDoors: hard})
+	}
+	return z.nav
+}
+
+// navigate makes the zombie walk along a path to the position passed. The
+// path is only recalculated every zombieRepathTicks ticks or once the zombie
+// reached the end of its current path, as the position passed usually
+// belongs to a moving entity.
+func (z *zombieAttacker) navigate(m *Mob, tx *world.Tx, pos mgl64.Vec3, speedMultiplier float64) {
+	if z.repath--; z.repath <= 0 || z.nav.Done() {
+		z.repath = zombieRepathTicks
+		z.nav.MoveTo(m, tx, pos, speedMultiplier)
+	}
+}
+
+// findTarget returns the current target of the zombie, looking for a new
+// target if the zombie currently has none. Zombies prefer attacking players
+// over villagers and iron golems. Nil is returned if no target was found.
+func (z *zombieAttacker) findTarget(m *Mob, tx *world.Tx) Living {
+	if z.target != nil {
+		e, ok := z.target.Entity(tx)
+		if l, living := e.(Living); ok && living && !l.Dead() && !playingDead(e) && e.Position().Sub(m.Position()).Len() <= zombieFollowRange {
+			if _, player := e.(interface{ GameMode() world.GameMode }); !player || attackablePlayer(l) {
+				return l
+			}
+		}
+		z.target = nil
+	}
+	if m.Age()%(time.Second/2) != 0 {
+		return nil
+	}
+	if t, ok := nearestEntity(m, tx, zombieFollowRange, attackablePlayer); ok {
+		z.target = t.H()
+		return t
+	}
+	if t, ok := nearestEntity(m, tx, 16, func(e Living) bool {
+		t := e.H().Type()
+		return t == VillagerType || t == IronGolemType
+	}); ok {
+		z.target = t.H()
+		return t
+	}
+	return nil
+}
+
+// breakDoor makes the zombie break down a closed wooden door that blocks its
+// way to its target. Zombies only break doors on hard difficulty. True is
+// returned if the zombie is busy breaking a door.
+func (z *zombieAttacker) breakDoor(m *Mob, b *MobBehaviour, target world.Entity, tx *world.Tx) bool {
+	pos, ok := zombieBlockingDoor(m, target, tx)
+	if !ok || tx.World().Difficulty() != world.DifficultyHard {
+		z.stopBreakingDoor(tx)
+		return false
+	}
+	if z.door == nil || *z.door != pos {
+		z.stopBreakingDoor(tx)
+		z.door, z.doorTicks = &pos, 0
+		for _, v := range tx.Viewers(pos.Vec3Centre()) {
+			v.ViewBlockAction(pos, block.StartCrackAction{BreakTime: zombieDoorBreakTicks * time.Second / 20})
+		}
+	}
+	z.nav.Stop(m)
+	b.StopMoving()
+	b.LookAt(pos.Vec3Centre())
+	if z.doorTicks++; z.doorTicks%20 == 0 {
+		for _, v := range tx.Viewers(m.Position()) {
+			v.ViewEntityAction(m, SwingArmAction{})
+		}
+	}
+	if z.doorTicks < zombieDoorBreakTicks {
+		return true
+	}
+	door := tx.Block(pos)
+	tx.SetBlock(pos, nil, nil)
+	for _, other := range []cube.Pos{pos.Side(cube.FaceUp), pos.Side(cube.FaceDown)} {
+		if _, ok := tx.Block(other).(block.WoodDoor); ok {
+			tx.SetBlock(other, nil, nil)
+		}
+	}
+	tx.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: door})
+	tx.PlaySound(pos.Vec3Centre(), sound.DoorCrash{})
+	z.door, z.doorTicks = nil, 0
+	return true
+}
+
+// stopBreakingDoor makes the zombie stop breaking the door that it was
+// breaking, if any.
+func (z *zombieAttacker) stopBreakingDoor(tx *world.Tx) {
+	if z.door == nil {
+		return
+	}
+	for _, v := range tx.Viewers(z.door.Vec3Centre()) {
+		v.ViewBlockAction(*z.door, block.StopCrackAction{})
+	}
+	z.door, z.doorTicks = nil, 0
+}
+
+// zombieBlockingDoor returns the position of a closed wooden door directly in
+// front of the mob passed, in the direction of its target. False is returned
+// if there is no such door.
+func zombieBlockingDoor(m *Mob, target world.Entity, tx *world.Tx) (cube.Pos, bool) {
+	dir := target.Position().Sub(m.Position())
+	dir[1] = 0
+	if dir.Len() < 0.5 {
+		return cube.Pos{}, false
+	}
+	front := cube.PosFromVec3(m.Position().Add(dir.Normalize().Mul(0.8)))
+	for _, pos := range []cube.Pos{front, front.Side(cube.FaceUp)} {
+		if d, ok := tx.Block(pos).(block.WoodDoor); ok && !d.Open {
+			return pos, true
+		}
+	}
+	return cube.Pos{}, false
+}
+
+// zombie checks if the entity passed is any kind of zombie. Zombies are able
+// to infect villagers that they kill.
+func zombie(e world.Entity) bool {
+	t := e.H().Type()
+	return t == ZombieType || t == DrownedType || t == ZombieVillagerType
+}
+
+// ZombieType is a world.EntityType implementation for zombies.
+var ZombieType zombieType
+
+// DrownedType is a world.EntityType implementation for drowned.
+var DrownedType drownedType
+
+type zombieType struct{}
+
+func (zombieType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (zombieType) EncodeEntity() string { return "minecraft:zombie" }
+func (zombieType) BBox(e world.Entity) cube.BBox {
+	if m, ok := e.(*Mob); ok && m.Behaviour().(*ZombieBehaviour).Baby() {
+		return cube.Box(-0.15, 0, -0.15, 0.15, 0.95, 0.15)
+	}
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.9, 0.3)
+}
+
+func (zombieType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	data.Data = decodeZombie(m, false)
+}
+
+func (zombieType) EncodeNBT(data *world.EntityData) map[string]any {
+	return encodeZombie(data.Data.(*ZombieBehaviour))
+}
+
+type drownedType struct{}
+
+func (drownedType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (drownedType) EncodeEntity() string { return "minecraft:drowned" }
+func (drownedType) BBox(e world.Entity) cube.BBox {
+	return ZombieType.BBox(e)
+}
+
+func (drownedType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	data.Data = decodeZombie(m, true)
+}
+
+func (drownedType) EncodeNBT(data *world.EntityData) map[string]any {
+	return encodeZombie(data.Data.(*ZombieBehaviour))
+}
+
+// decodeZombie decodes a ZombieBehaviour from the map passed.
+func decodeZombie(m map[string]any, drowned bool) *ZombieBehaviour {
+	conf := zombieConf
+	conf.Baby, conf.Drowned = nbtconv.Bool(m, "IsBaby"), drowned
+	z := conf.New()
+	z.MobBehaviour.decodeNBT(m)
+	z.submergedTicks = int(nbtconv.Int32(m, "InWaterTime"))
+	z.conversionTicks = -1
+	if _, ok := m["DrownedConversionTime"]; ok {
+		z.conversionTicks = int(nbtconv.Int32(m, "DrownedConversionTime"))
+	}
+	if _, ok := m["ReinforcementChance"]; ok {
+		z.reinforcementChance = float64(nbtconv.Float32(m, "ReinforcementChance"))
+	}
+	return z
+}
+
+// encodeZombie encodes the ZombieBehaviour passed to a map.
+func encodeZombie(z *ZombieBehaviour) map[string]any {
+	m := map[string]any{
+		"IsBaby":                boolByte(z.baby),
+		"InWaterTime":           int32(z.submergedTicks),
+		"DrownedConversionTime": int32(z.conversionTicks),
+		"ReinforcementChance":   float32(z.reinforcementChance),
+	}
+	z.MobBehaviour.encodeNBT(m)
+	return m
+}
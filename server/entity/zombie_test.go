@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/generator"
+)
+
+func TestZombiePursuesTargetAroundWall(t *testing.T) {
+	flat := generator.NewFlat(biome.Plains{}, []world.Block{block.Stone{}})
+	w := world.Config{Entities: DefaultRegistry, Generator: flat}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		tx.World().SetTime(18000)
+		// The wall is too high for the zombie to jump over, so that it can
+		// only reach its target by walking around it.
+		for z := 4; z <= 12; z++ {
+			for y := -63; y <= -61; y++ {
+				tx.SetBlock(cube.Pos{12, y, z}, block.Stone{}, nil)
+			}
+		}
+		villager := tx.AddEntity(NewVillager(world.EntitySpawnOpts{Position: cube.Pos{15, -63, 8}.Vec3Middle()}, ProfessionNone())).(*Mob)
+		m := tx.AddEntity(NewZombie(world.EntitySpawnOpts{Position: cube.Pos{9, -63, 8}.Vec3Middle()})).(*Mob)
+		m.Behaviour().(*ZombieBehaviour).target = villager.H()
+
+		for i := range 300 {
+			m.Tick(tx, int64(i))
+			if m.Position()[0] > 13 {
+				return
+			}
+		}
+		t.Errorf("expected zombie to walk around the wall to its target, zombie is at %v", m.Position())
+	})
+}
+
+func TestZombieWalksToDoorToBreakIt(t *testing.T) {
+	flat := generator.NewFlat(biome.Plains{}, []world.Block{block.Stone{}})
+	w := world.Config{Entities: DefaultRegistry, Generator: flat}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		tx.World().SetTime(18000)
+		tx.World().SetDifficulty(world.DifficultyHard)
+		// The target is in a room of which the only entrance is a door on the
+		// side facing away from the zombie.
+		for x := 14; x <= 18; x++ {
+			for z := 6; z <= 10; z++ {
+				if x != 14 && x != 18 && z != 6 && z != 10 {
+					continue
+				}
+				for y := -63; y <= -61; y++ {
+					tx.SetBlock(cube.Pos{x, y, z}, block.Stone{}, nil)
+				}
+			}
+		}
+		door := block.WoodDoor{Wood: block.OakWood(), Facing: cube.North}
+		tx.SetBlock(cube.Pos{16, -63, 10}, door, nil)
+		door.Top = true
+		tx.SetBlock(cube.Pos{16, -62, 10}, door, nil)
+
+		villager := tx.AddEntity(NewVillager(world.EntitySpawnOpts{Position: cube.Pos{16, -63, 8}.Vec3Middle()}, ProfessionNone())).(*Mob)
+		m := tx.AddEntity(NewZombie(world.EntitySpawnOpts{Position: cube.Pos{9, -63, 8}.Vec3Middle()})).(*Mob)
+		z := m.Behaviour().(*ZombieBehaviour)
+		z.target = villager.H()
+
+		for i := range 300 {
+			m.Tick(tx, int64(i))
+			if z.door != nil {
+				return
+			}
+		}
+		t.Errorf("expected zombie to walk to the door and break it, zombie is at %v", m.Position())
+	})
+}
@@ -0,0 +1,231 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"math/rand/v2"
+	"time"
+)
+
+// NewZombieVillager creates a new zombie villager with the profession passed.
+func NewZombieVillager(opts world.EntitySpawnOpts, p VillagerProfession) *world.EntityHandle {
+	conf := zombieVillagerConf
+	conf.Profession = p
+	return opts.New(ZombieVillagerType, conf)
+}
+
+var zombieVillagerConf = ZombieVillagerBehaviourConfig{}
+
+// ZombieVillagerBehaviourConfig holds optional parameters for a
+// ZombieVillagerBehaviour.
+type ZombieVillagerBehaviourConfig struct {
+	// Profession is the profession that the zombie villager has once it is
+	// cured.
+	Profession VillagerProfession
+}
+
+func (conf ZombieVillagerBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a ZombieVillagerBehaviour using the parameters in conf.
+func (conf ZombieVillagerBehaviourConfig) New() *ZombieVillagerBehaviour {
+	z := &ZombieVillagerBehaviour{
+		villagerData:    villagerData{profession: conf.Profession, level: 1},
+		conversionTicks: -1,
+	}
+	z.reinforcementChance = rand.Float64() * 0.1
+	z.MobBehaviour = MobBehaviourConfig{Speed: 0.1, Experience: 5, Drops: z.drops, Equip: func(m *Mob, eq *Equipment, difficulty float64) {
+		equipZombie(m, eq, difficulty, false)
+	}}.New()
+	z.unlockOffers()
+	return z
+}
+
+// ZombieVillagerBehaviour implements the behaviour of zombie villagers.
+// Zombie villagers attack players, villagers and iron golems like other
+// zombies do. A zombie villager may be cured by giving it a golden apple while it is
+// weakened, after which it converts back into a villager with the same
+// profession and trades.
+type ZombieVillagerBehaviour struct {
+	*MobBehaviour
+	villagerData
+	zombieAttacker
+
+	// conversionTicks is the amount of ticks left until the zombie villager
+	// is cured, or -1 if it is not being cured.
+	conversionTicks int
+	// curer is the UUID of the player that started curing the zombie
+	// villager.
+	curer uuid.UUID
+}
+
+// HeldItems returns the items held by the zombie villager.
+func (z *ZombieVillagerBehaviour) HeldItems() (mainHand, offHand item.Stack) {
+	return z.Equipment().HeldItems()
+}
+
+// Armour returns the armour worn by the zombie villager. Zombie villagers
+// wearing a helmet do not burn in sunlight.
+func (z *ZombieVillagerBehaviour) Armour() *inventory.Armour {
+	return z.Equipment().Armour()
+}
+
+// Converting checks if the zombie villager is currently being cured.
+func (z *ZombieVillagerBehaviour) Converting() bool {
+	return z.conversionTicks >= 0
+}
+
+// ConversionTime returns the time left until the zombie villager is cured. 0
+// is returned if it is not being cured.
+func (z *ZombieVillagerBehaviour) ConversionTime() time.Duration {
+	return time.Duration(max(z.conversionTicks, 0)) * time.Second / 20
+}
+
+// Interact starts curing the zombie villager if the user is holding a golden
+// apple and the zombie villager has the weakness effect.
+func (z *ZombieVillagerBehaviour) Interact(m *Mob, user item.User, _ *world.Tx) bool {
+	held, _ := user.HeldItems()
+	if _, ok := held.Item().(item.GoldenApple); !ok || z.Converting() {
+		return false
+	}
+	if _, ok := m.Effect(effect.Weakness); !ok {
+		return false
+	}
+	consumeHeldItem(user)
+	z.StartConversion(m, user.H(), time.Duration(3600+rand.IntN(2401))*time.Second/20)
+	return true
+}
+
+// StartConversion starts curing the zombie villager, converting it into a
+// villager once the duration passed has elapsed. The curer, which may be nil,
+// receives a permanent discount on the trades of the villager.
+func (z *ZombieVillagerBehaviour) StartConversion(m *Mob, curer *world.EntityHandle, dur time.Duration) {
+	z.conversionTicks = int(dur.Milliseconds() / 50)
+	z.curer = uuid.Nil
+	if curer != nil {
+		z.curer = curer.UUID()
+	}
+	m.RemoveEffect(effect.Weakness)
+	m.AddEffect(effect.New(effect.Strength, 1, dur))
+}
+
+// Hurt makes the zombie villager attack the entity that attacked it,
+// possibly calling for reinforcements.
+func (z *ZombieVillagerBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	z.hurt(m, src)
+}
+
+// Tick ticks the zombie villager, making it pursue and attack its target and
+// converting it into a villager once it has been cured.
+func (z *ZombieVillagerBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !z.Dead() {
+		m := &Mob{Ent: e}
+		if z.Converting() {
+			if z.conversionTicks -= z.conversionRate(e.Position(), tx); z.conversionTicks <= 0 {
+				z.convert(m, tx)
+				return nil
+			}
+		}
+		z.tickZombie(m, z.MobBehaviour, tx)
+	}
+	return z.MobBehaviour.Tick(e, tx)
+}
+
+// conversionRate returns the amount of ticks that the conversion of the
+// zombie villager progresses this tick. Iron bars close to the zombie
+// villager speed up the conversion.
+func (z *ZombieVillagerBehaviour) conversionRate(pos mgl64.Vec3, tx *world.Tx) int {
+	rate := 1
+	if rand.Float64() >= 0.01 {
+		return rate
+	}
+	centre, n := cube.PosFromVec3(pos), 0
+	for x := -4; x < 4 && n < 14; x++ {
+		for y := -4; y < 4 && n < 14; y++ {
+			for z := -4; z < 4 && n < 14; z++ {
+				// TODO: Beds should also speed up the conversion once they are implemented.
+				if _, ok := tx.Block(centre.Add(cube.Pos{x, y, z})).(block.IronBars); ok {
+					if n++; rand.Float64() < 0.3 {
+						rate++
+					}
+				}
+			}
+		}
+	}
+	return rate
+}
+
+// convert replaces the zombie villager with a villager that has the same
+// profession and trades.
+func (z *ZombieVillagerBehaviour) convert(m *Mob, tx *world.Tx) {
+	v := VillagerBehaviourConfig{}.New()
+	v.villagerData = z.clone()
+	if z.curer != uuid.Nil {
+		v.cure(z.curer)
+	}
+	transform(m, VillagerType, v, tx).AddEffect(effect.New(effect.Nausea, 1, time.Second*10))
+}
+
+// drops returns the items dropped by a zombie villager when it dies.
+func (z *ZombieVillagerBehaviour) drops(*Mob, world.DamageSource) []item.Stack {
+	if n := rand.IntN(3); n > 0 {
+		return []item.Stack{item.NewStack(item.RottenFlesh{}, n)}
+	}
+	return nil
+}
+
+// infectVillager converts the villager passed into a zombie villager with
+// the same profession and trades.
+func infectVillager(m *Mob, v *VillagerBehaviour, tx *world.Tx) {
+	z := zombieVillagerConf.New()
+	z.villagerData = v.clone()
+	transform(m, ZombieVillagerType, z, tx)
+}
+
+// ZombieVillagerType is a world.EntityType implementation for zombie
+// villagers.
+var ZombieVillagerType zombieVillagerType
+
+type zombieVillagerType struct{}
+
+func (zombieVillagerType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (zombieVillagerType) EncodeEntity() string { return "minecraft:zombie_villager_v2" }
+func (zombieVillagerType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.9, 0.3)
+}
+
+func (zombieVillagerType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	z := zombieVillagerConf.New()
+	z.MobBehaviour.decodeNBT(m)
+	z.villagerData.decodeNBT(m)
+	z.conversionTicks = -1
+	if _, ok := m["ConversionTime"]; ok {
+		z.conversionTicks = int(nbtconv.Int32(m, "ConversionTime"))
+	}
+	if id, err := uuid.Parse(nbtconv.String(m, "ConversionPlayer")); err == nil {
+		z.curer = id
+	}
+	data.Data = z
+}
+
+func (zombieVillagerType) EncodeNBT(data *world.EntityData) map[string]any {
+	z := data.Data.(*ZombieVillagerBehaviour)
+	m := map[string]any{"ConversionTime": int32(z.conversionTicks)}
+	if z.curer != uuid.Nil {
+		m["ConversionPlayer"] = z.curer.String()
+	}
+	z.MobBehaviour.encodeNBT(m)
+	z.villagerData.encodeNBT(m)
+	return m
+}
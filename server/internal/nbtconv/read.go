@@ -63,6 +63,25 @@ func TickDuration[T constraints.Integer](m map[string]any, k string) time.Durati
 	return v * time.Millisecond * 50
 }
 
+// AnyTickDuration reads a uint8/int16/int32/int64 value from a map at key k
+// and converts it from ticks to a time.Duration. Unlike TickDuration, the
+// value may be of any of these types, which is useful for values that were
+// written with different types by different versions of the game.
+func AnyTickDuration(m map[string]any, k string) time.Duration {
+	var v time.Duration
+	switch t := m[k].(type) {
+	case uint8:
+		v = time.Duration(t)
+	case int16:
+		v = time.Duration(t)
+	case int32:
+		v = time.Duration(t)
+	case int64:
+		v = time.Duration(t)
+	}
+	return v * time.Millisecond * 50
+}
+
 // Float32 reads a float32 value from a map at key k.
 func Float32(m map[string]any, k string) float32 {
 	v, _ := m[k].(float32)
@@ -162,6 +181,7 @@ func Item(data map[string]any, s *item.Stack) item.Stack {
 	}
 
 	readAnvilCost(tag, s)
+	readAttributeModifiers(tag, s)
 	readDamage(tag, s, disk)
 	readDisplay(tag, s)
 	readDragonflyData(tag, s)
@@ -213,6 +233,28 @@ func readAnvilCost(m map[string]any, s *item.Stack) {
 	*s = s.WithAnvilCost(int(Int32(m, "RepairCost")))
 }
 
+// readAttributeModifiers reads the attribute modifiers stored in the AttributeModifiers tag of the NBT passed and
+// stores them into an item.Stack.
+func readAttributeModifiers(m map[string]any, s *item.Stack) {
+	modifiers, ok := m["AttributeModifiers"].([]map[string]any)
+	if !ok {
+		for _, mod := range Slice(m, "AttributeModifiers") {
+			if v, ok := mod.(map[string]any); ok {
+				modifiers = append(modifiers, v)
+			}
+		}
+	}
+	for _, mod := range modifiers {
+		attr, ok := item.AttributeByName(String(mod, "AttributeName"))
+		slot, ok2 := item.EquipmentSlotByName(String(mod, "Slot"))
+		op := int(Int32(mod, "Operation"))
+		if !ok || !ok2 || op < 0 || op >= len(item.AttributeOperations()) {
+			continue
+		}
+		*s = s.WithAttributeModifier(attr, Float64(mod, "Amount"), item.AttributeOperations()[op], slot)
+	}
+}
+
 // readEnchantments reads the enchantments stored in the ench tag of the NBT passed and stores it into an item.Stack.
 func readEnchantments(m map[string]any, s *item.Stack) {
 	enchantments, ok := m["ench"].([]map[string]any)
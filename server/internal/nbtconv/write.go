@@ -21,6 +21,7 @@ func WriteItem(s item.Stack, disk bool) map[string]any {
 		}
 	}
 	writeAnvilCost(tag, s)
+	writeAttributeModifiers(tag, s)
 	writeDamage(tag, s, disk)
 	writeDisplay(tag, s)
 	writeDragonflyData(tag, s)
@@ -151,6 +152,24 @@ func writeAnvilCost(m map[string]any, s item.Stack) {
 	}
 }
 
+// writeAttributeModifiers writes the attribute modifiers of an item to a map for NBT encoding.
+func writeAttributeModifiers(m map[string]any, s item.Stack) {
+	if modifiers := s.AttributeModifiers(); len(modifiers) != 0 {
+		list := make([]map[string]any, 0, len(modifiers))
+		for _, mod := range modifiers {
+			list = append(list, map[string]any{
+				"Name":          mod.Attribute.Name(),
+				"AttributeName": mod.Attribute.Name(),
+				"Amount":        mod.Amount,
+				"Operation":     int32(mod.Operation.Uint8()),
+				"Operable":      byte(1),
+				"Slot":          mod.Slot.String(),
+			})
+		}
+		m["AttributeModifiers"] = list
+	}
+}
+
 // writeUnbreakable writes the unbreakable tag to an item stack if it is unbreakable.
 func writeUnbreakable(m map[string]any, s item.Stack) {
 	if s.Unbreakable() {
@@ -9,9 +9,15 @@ type Apple struct {
 	defaultFood
 }
 
+// FoodInfo ...
+func (Apple) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 4, Saturation: 2.4}
+}
+
 // Consume ...
 func (a Apple) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(4, 2.4)
+	info := a.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
@@ -0,0 +1,233 @@
+package item
+
+// AttributeModifier modifies an Attribute of the entity that has the item
+// holding it equipped in a specific EquipmentSlot. AttributeModifiers are
+// displayed in the tooltip of the item.
+type AttributeModifier struct {
+	// Attribute is the Attribute that the modifier modifies.
+	Attribute Attribute
+	// Amount is the amount by which the Attribute is modified. How it is
+	// applied depends on the Operation of the modifier.
+	Amount float64
+	// Operation is the operation used to apply the Amount to the Attribute.
+	Operation AttributeOperation
+	// Slot is the EquipmentSlot that the item must be equipped in for the
+	// modifier to apply.
+	Slot EquipmentSlot
+}
+
+// ApplyAttributeModifiers applies all modifiers passed to the base value of
+// an attribute and returns the result. Modifiers with the
+// AttributeOperationAdd operation are applied first, after which the
+// AttributeOperationMultiplyBase and AttributeOperationMultiplyTotal modifiers
+// are applied, in that order.
+func ApplyAttributeModifiers(base float64, modifiers ...AttributeModifier) float64 {
+	for _, m := range modifiers {
+		if m.Operation == AttributeOperationAdd() {
+			base += m.Amount
+		}
+	}
+	v := base
+	for _, m := range modifiers {
+		if m.Operation == AttributeOperationMultiplyBase() {
+			v += base * m.Amount
+		}
+	}
+	for _, m := range modifiers {
+		if m.Operation == AttributeOperationMultiplyTotal() {
+			v *= 1 + m.Amount
+		}
+	}
+	return v
+}
+
+// Attribute represents an attribute of an entity that may be modified by an
+// AttributeModifier.
+type Attribute struct {
+	attribute
+}
+
+// AttributeAttackDamage is the damage dealt by an entity when it attacks
+// another entity.
+func AttributeAttackDamage() Attribute {
+	return Attribute{0}
+}
+
+// AttributeKnockBackResistance is the resistance of an entity to knock back,
+// ranging from 0 (no resistance) to 1 (full resistance).
+func AttributeKnockBackResistance() Attribute {
+	return Attribute{1}
+}
+
+// Attributes returns all attributes that may be modified by an
+// AttributeModifier.
+func Attributes() []Attribute {
+	return []Attribute{AttributeAttackDamage(), AttributeKnockBackResistance()}
+}
+
+// AttributeByName returns the Attribute with the name passed, such as
+// "minecraft:attack_damage". False is returned if no such attribute exists.
+func AttributeByName(name string) (Attribute, bool) {
+	for _, a := range Attributes() {
+		if a.Name() == name {
+			return a, true
+		}
+	}
+	return Attribute{}, false
+}
+
+type attribute uint8
+
+// Uint8 returns the attribute as a uint8.
+func (a attribute) Uint8() uint8 {
+	return uint8(a)
+}
+
+// Name returns the name of the attribute as used in item NBT.
+func (a attribute) Name() string {
+	switch a {
+	case 0:
+		return "minecraft:attack_damage"
+	case 1:
+		return "minecraft:knockback_resistance"
+	}
+	panic("unknown attribute")
+}
+
+// String ...
+func (a attribute) String() string {
+	switch a {
+	case 0:
+		return "attack_damage"
+	case 1:
+		return "knockback_resistance"
+	}
+	panic("unknown attribute")
+}
+
+// AttributeOperation is the operation by which an AttributeModifier modifies
+// an Attribute.
+type AttributeOperation struct {
+	attributeOperation
+}
+
+// AttributeOperationAdd adds the amount of the modifier to the base value of
+// the attribute.
+func AttributeOperationAdd() AttributeOperation {
+	return AttributeOperation{0}
+}
+
+// AttributeOperationMultiplyBase adds the base value of the attribute,
+// multiplied by the amount of the modifier, to the value of the attribute.
+func AttributeOperationMultiplyBase() AttributeOperation {
+	return AttributeOperation{1}
+}
+
+// AttributeOperationMultiplyTotal multiplies the value of the attribute by 1
+// plus the amount of the modifier.
+func AttributeOperationMultiplyTotal() AttributeOperation {
+	return AttributeOperation{2}
+}
+
+// AttributeOperations returns all possible operations of an
+// AttributeModifier.
+func AttributeOperations() []AttributeOperation {
+	return []AttributeOperation{AttributeOperationAdd(), AttributeOperationMultiplyBase(), AttributeOperationMultiplyTotal()}
+}
+
+type attributeOperation uint8
+
+// Uint8 returns the operation as a uint8.
+func (o attributeOperation) Uint8() uint8 {
+	return uint8(o)
+}
+
+// String ...
+func (o attributeOperation) String() string {
+	switch o {
+	case 0:
+		return "add"
+	case 1:
+		return "multiply_base"
+	case 2:
+		return "multiply_total"
+	}
+	panic("unknown attribute operation")
+}
+
+// EquipmentSlot is a slot that an item may be equipped in.
+type EquipmentSlot struct {
+	equipmentSlot
+}
+
+// EquipmentSlotMainHand is the main hand of an entity.
+func EquipmentSlotMainHand() EquipmentSlot {
+	return EquipmentSlot{0}
+}
+
+// EquipmentSlotOffHand is the off hand of an entity.
+func EquipmentSlotOffHand() EquipmentSlot {
+	return EquipmentSlot{1}
+}
+
+// EquipmentSlotHelmet is the helmet slot of an entity.
+func EquipmentSlotHelmet() EquipmentSlot {
+	return EquipmentSlot{2}
+}
+
+// EquipmentSlotChestplate is the chestplate slot of an entity.
+func EquipmentSlotChestplate() EquipmentSlot {
+	return EquipmentSlot{3}
+}
+
+// EquipmentSlotLeggings is the leggings slot of an entity.
+func EquipmentSlotLeggings() EquipmentSlot {
+	return EquipmentSlot{4}
+}
+
+// EquipmentSlotBoots is the boots slot of an entity.
+func EquipmentSlotBoots() EquipmentSlot {
+	return EquipmentSlot{5}
+}
+
+// EquipmentSlots returns all equipment slots.
+func EquipmentSlots() []EquipmentSlot {
+	return []EquipmentSlot{EquipmentSlotMainHand(), EquipmentSlotOffHand(), EquipmentSlotHelmet(), EquipmentSlotChestplate(), EquipmentSlotLeggings(), EquipmentSlotBoots()}
+}
+
+// EquipmentSlotByName returns the EquipmentSlot with the name passed, such as
+// "mainhand". False is returned if no such slot exists.
+func EquipmentSlotByName(name string) (EquipmentSlot, bool) {
+	for _, s := range EquipmentSlots() {
+		if s.String() == name {
+			return s, true
+		}
+	}
+	return EquipmentSlot{}, false
+}
+
+type equipmentSlot uint8
+
+// Uint8 returns the equipment slot as a uint8.
+func (s equipmentSlot) Uint8() uint8 {
+	return uint8(s)
+}
+
+// String returns the name of the equipment slot as used in item NBT.
+func (s equipmentSlot) String() string {
+	switch s {
+	case 0:
+		return "mainhand"
+	case 1:
+		return "offhand"
+	case 2:
+		return "head"
+	case 3:
+		return "chest"
+	case 4:
+		return "legs"
+	case 5:
+		return "feet"
+	}
+	panic("unknown equipment slot")
+}
@@ -7,9 +7,15 @@ type BakedPotato struct {
 	defaultFood
 }
 
+// FoodInfo ...
+func (BakedPotato) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 5, Saturation: 6}
+}
+
 // Consume ...
-func (BakedPotato) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(5, 6)
+func (b BakedPotato) Consume(_ *world.Tx, c Consumer) Stack {
+	info := b.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
@@ -10,13 +10,18 @@ type Beef struct {
 	Cooked bool
 }
 
-// Consume ...
-func (b Beef) Consume(_ *world.Tx, c Consumer) Stack {
+// FoodInfo ...
+func (b Beef) FoodInfo() FoodInfo {
 	if b.Cooked {
-		c.Saturate(8, 12.8)
-	} else {
-		c.Saturate(3, 1.8)
+		return FoodInfo{Food: 8, Saturation: 12.8}
 	}
+	return FoodInfo{Food: 3, Saturation: 1.8}
+}
+
+// Consume ...
+func (b Beef) Consume(_ *world.Tx, c Consumer) Stack {
+	info := b.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
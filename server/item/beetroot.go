@@ -9,9 +9,15 @@ type Beetroot struct {
 	defaultFood
 }
 
+// FoodInfo ...
+func (Beetroot) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 1, Saturation: 1.2}
+}
+
 // Consume ...
 func (b Beetroot) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(1, 1.2)
+	info := b.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
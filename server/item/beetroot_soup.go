@@ -14,9 +14,15 @@ func (BeetrootSoup) MaxCount() int {
 	return 1
 }
 
+// FoodInfo ...
+func (BeetrootSoup) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 6, Saturation: 7.2}
+}
+
 // Consume ...
-func (BeetrootSoup) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(6, 7.2)
+func (b BeetrootSoup) Consume(_ *world.Tx, c Consumer) Stack {
+	info := b.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return NewStack(Bowl{}, 1)
 }
 
@@ -7,9 +7,15 @@ type Bread struct {
 	defaultFood
 }
 
+// FoodInfo ...
+func (Bread) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 5, Saturation: 6}
+}
+
 // Consume ...
-func (Bread) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(5, 6)
+func (b Bread) Consume(_ *world.Tx, c Consumer) Stack {
+	info := b.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
 	"time"
 )
 
@@ -12,6 +13,9 @@ import (
 type BucketContent struct {
 	liquid world.Liquid
 	milk   bool
+
+	entity string
+	data   map[string]any
 }
 
 // LiquidBucketContent returns a new BucketContent with the liquid passed in.
@@ -24,6 +28,20 @@ func MilkBucketContent() BucketContent {
 	return BucketContent{milk: true}
 }
 
+// EntityBucketContent returns a new BucketContent with the liquid passed in and
+// an entity captured in it. The name is the name of the entity without the
+// 'minecraft:' prefix, such as 'axolotl'. Entities are captured in a bucket by
+// using a bucket of water on a Bucketable entity.
+func EntityBucketContent(l world.Liquid, name string) BucketContent {
+	return BucketContent{liquid: l, entity: name}
+}
+
+// Entity returns the name of the entity captured in a Bucket with this
+// BucketContent. If no entity is captured, false is returned.
+func (b BucketContent) Entity() (string, bool) {
+	return b.entity, b.entity != ""
+}
+
 // Liquid returns the world.Liquid that a Bucket with this BucketContent places.
 // If this BucketContent does not place a liquid block, false is returned.
 func (b BucketContent) Liquid() (world.Liquid, bool) {
@@ -34,6 +52,8 @@ func (b BucketContent) Liquid() (world.Liquid, bool) {
 func (b BucketContent) String() string {
 	if b.milk {
 		return "milk"
+	} else if b.entity != "" {
+		return b.entity
 	} else if b.liquid != nil {
 		return b.liquid.LiquidType()
 	}
@@ -48,6 +68,17 @@ func (b BucketContent) LiquidType() string {
 	return "milk"
 }
 
+// Bucketable represents an entity that may be captured by using a Bucket of
+// water on it, such as an axolotl.
+type Bucketable interface {
+	world.Entity
+	// Bucket returns the name of the entity as used in the BucketContent it is
+	// captured in, such as 'axolotl', and the NBT data that the entity is
+	// restored from once the bucket is emptied. False is returned if the
+	// entity cannot currently be captured.
+	Bucket() (name string, data map[string]any, ok bool)
+}
+
 // Bucket is a tool used to carry water, lava and fish.
 type Bucket struct {
 	// Content is the content that the bucket has. By default, this value resolves to an empty bucket.
@@ -107,21 +138,74 @@ func (b Bucket) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world
 		return b.fillFrom(pos, tx, ctx)
 	}
 	liq := b.Content.liquid.WithDepth(8, false)
-	if bl := tx.Block(pos); canDisplace(bl, liq) || replaceableWith(bl, liq) {
-		tx.SetLiquid(pos, liq)
-	} else if bl := tx.Block(pos.Side(face)); canDisplace(bl, liq) || replaceableWith(bl, liq) {
-		tx.SetLiquid(pos.Side(face), liq)
-	} else {
-		return false
+	placePos := pos
+	if bl := tx.Block(pos); !canDisplace(bl, liq) && !replaceableWith(bl, liq) {
+		if bl = tx.Block(pos.Side(face)); !canDisplace(bl, liq) && !replaceableWith(bl, liq) {
+			return false
+		}
+		placePos = pos.Side(face)
+	}
+	tx.SetLiquid(placePos, liq)
+	if b.Content.entity != "" {
+		b.releaseEntity(placePos, tx)
 	}
 
-	tx.PlaySound(pos.Vec3Centre(), sound.BucketEmpty{Liquid: b.Content.liquid})
+	tx.PlaySound(pos.Vec3Centre(), sound.BucketEmpty{Liquid: b.Content.liquid, Entity: b.Content.entity != ""})
 	ctx.NewItem = NewStack(Bucket{}, 1)
 	ctx.NewItemSurvivalOnly = true
 	ctx.SubtractFromCount(1)
 	return true
 }
 
+// UseOnEntity captures the entity passed if it is Bucketable and the bucket
+// holds only water.
+func (b Bucket) UseOnEntity(e world.Entity, tx *world.Tx, _ User, ctx *UseContext) bool {
+	bucketable, ok := e.(Bucketable)
+	if !ok || b.Content.entity != "" || b.Content.liquid == nil || b.Content.liquid.LiquidType() != "water" {
+		return false
+	}
+	name, data, ok := bucketable.Bucket()
+	if !ok {
+		return false
+	}
+	pos := e.Position()
+	_ = e.Close()
+	tx.PlaySound(pos, sound.BucketFill{Liquid: b.Content.liquid, Entity: true})
+
+	ctx.NewItem = NewStack(Bucket{Content: BucketContent{liquid: b.Content.liquid, entity: name, data: data}}, 1)
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// releaseEntity spawns the entity captured in the bucket at the position
+// passed. Nothing happens if the entity is not registered in the world.
+func (b Bucket) releaseEntity(pos cube.Pos, tx *world.Tx) {
+	name := b.Content.entity
+	if name == "tropical_fish" {
+		// The bucket of tropical fish is the only bucket whose name does not
+		// match the identifier of the entity captured in it.
+		name = "tropicalfish"
+	}
+	t, ok := tx.World().EntityRegistry().Lookup("minecraft:" + name)
+	if !ok {
+		return
+	}
+	opts := world.EntitySpawnOpts{Position: pos.Vec3Middle(), Rotation: cube.Rotation{rand.Float64() * 360}}
+	opts.NameTag, _ = b.Content.data["NameTag"].(string)
+	tx.AddEntity(opts.New(t, bucketEntityConfig{t: t, data: b.Content.data}))
+}
+
+// bucketEntityConfig is a world.EntityConfig that restores an entity captured
+// in a bucket from its NBT data.
+type bucketEntityConfig struct {
+	t    world.EntityType
+	data map[string]any
+}
+
+func (conf bucketEntityConfig) Apply(data *world.EntityData) {
+	conf.t.DecodeNBT(conf.data, data)
+}
+
 // fillFrom fills a bucket from the liquid at the position passed in the world. If there is no liquid or if
 // the liquid is no source, fillFrom returns false.
 func (b Bucket) fillFrom(pos cube.Pos, tx *world.Tx, ctx *UseContext) bool {
@@ -150,6 +234,22 @@ func (b Bucket) EncodeItem() (name string, meta int16) {
 	return "minecraft:bucket", 0
 }
 
+// EncodeNBT ...
+func (b Bucket) EncodeNBT() map[string]any {
+	if b.Content.entity == "" || b.Content.data == nil {
+		return nil
+	}
+	return map[string]any{"EntityData": b.Content.data}
+}
+
+// DecodeNBT ...
+func (b Bucket) DecodeNBT(data map[string]any) any {
+	if b.Content.entity != "" {
+		b.Content.data, _ = data["EntityData"].(map[string]any)
+	}
+	return b
+}
+
 type replaceable interface {
 	ReplaceableBy(b world.Block) bool
 }
@@ -0,0 +1,176 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	_ "unsafe"
+)
+
+// BundleCapacity is the capacity of a Bundle. Every item takes up 64 divided by its max count of the
+// capacity, so a Bundle can hold 64 cobblestone, 16 ender pearls or a single sword.
+const BundleCapacity = 64
+
+// Bundle is an item that stores a stack's worth of mixed items in a single slot. Items are put into and
+// taken out of a bundle from the inventory.
+type Bundle struct {
+	// ID is the unique ID of the bundle. It is used by the client to refer to the contents of the bundle.
+	ID int32
+	// Contents holds the stacks of items in the bundle, with the most recently inserted stack first.
+	Contents []Stack
+}
+
+// NewBundle returns an empty Bundle with a new, random ID.
+func NewBundle() Bundle {
+	return Bundle{ID: rand.Int32N(1<<31-1) + 1}
+}
+
+// MaxCount always returns 1.
+func (Bundle) MaxCount() int {
+	return 1
+}
+
+// Weight returns the part of the capacity of the Bundle taken up by its contents, ranging from 0 to
+// BundleCapacity.
+func (b Bundle) Weight() int {
+	w := 0
+	for _, s := range b.Contents {
+		w += bundleWeight(s.Item()) * s.Count()
+	}
+	return w
+}
+
+// Fullness returns how full the Bundle is, ranging from 0 for an empty bundle to 1 for a full bundle.
+func (b Bundle) Fullness() float64 {
+	return float64(b.Weight()) / BundleCapacity
+}
+
+// Empty checks if the Bundle holds no items.
+func (b Bundle) Empty() bool {
+	return len(b.Contents) == 0
+}
+
+// Insert inserts as many items of the stack passed into the Bundle as fit in its remaining capacity. A new
+// Bundle with the items inserted is returned, along with the amount of items that were inserted, which may be
+// lower than the count of the stack. Shulker boxes and empty stacks cannot be inserted.
+func (b Bundle) Insert(s Stack) (Bundle, int) {
+	if s.Empty() || !Bundleable(s) {
+		return b, 0
+	}
+	if inner, ok := s.Item().(Bundle); ok && inner.ID == b.ID && b.ID != 0 {
+		// A bundle can never be put into itself.
+		return b, 0
+	}
+	n := min(s.Count(), (BundleCapacity-b.Weight())/bundleWeight(s.Item()))
+	if n <= 0 {
+		return b, 0
+	}
+	if b.ID == 0 {
+		b.ID = NewBundle().ID
+	}
+	contents, remaining := slices.Clone(b.Contents), n
+	for i, existing := range contents {
+		if existing.Comparable(s) && existing.Count() < existing.MaxCount() {
+			// Add to an existing stack first, moving it to the front so that it is the first to be taken out.
+			added := min(remaining, existing.MaxCount()-existing.Count())
+			contents = slices.Insert(slices.Delete(contents, i, i+1), 0, existing.Grow(added))
+			remaining -= added
+			break
+		}
+	}
+	if remaining > 0 {
+		contents = slices.Insert(contents, 0, s.Grow(remaining-s.Count()))
+	}
+	b.Contents = contents
+	return b, n
+}
+
+// Remove removes the most recently inserted stack from the Bundle. The new Bundle is returned along with the
+// stack removed. False is returned if the Bundle was empty.
+func (b Bundle) Remove() (Bundle, Stack, bool) {
+	if len(b.Contents) == 0 {
+		return b, Stack{}, false
+	}
+	s := b.Contents[0]
+	b.Contents = slices.Clone(b.Contents[1:])
+	return b, s, true
+}
+
+// Use drops the contents of the Bundle in front of the user.
+func (b Bundle) Use(tx *world.Tx, user User, _ *UseContext) bool {
+	if b.Empty() {
+		return false
+	}
+	create := tx.World().EntityRegistry().Config().Item
+	pos := user.Position().Add(mgl64.Vec3{0, 1.4})
+	for _, s := range b.Contents {
+		opts := world.EntitySpawnOpts{Position: pos, Velocity: mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1}}
+		tx.AddEntity(create(opts, s))
+	}
+	mainHand, offHand := user.HeldItems()
+	b.Contents = nil
+	user.SetHeldItems(mainHand.WithItem(b), offHand)
+	return true
+}
+
+// DecodeNBT ...
+func (b Bundle) DecodeNBT(data map[string]any) any {
+	b.ID, _ = data["bundle_id"].(int32)
+	b.Contents = nil
+	items, _ := data["storage_item_component_content"].([]any)
+	for _, v := range items {
+		m, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		if s := readItem(m, nil); !s.Empty() {
+			b.Contents = append(b.Contents, s)
+		}
+	}
+	return b
+}
+
+// EncodeNBT ...
+func (b Bundle) EncodeNBT() map[string]any {
+	data := map[string]any{"bundle_id": b.ID}
+	if len(b.Contents) > 0 {
+		items := make([]any, 0, len(b.Contents))
+		for _, s := range b.Contents {
+			items = append(items, writeItem(s, true))
+		}
+		data["storage_item_component_content"] = items
+	}
+	return data
+}
+
+// EncodeItem ...
+func (Bundle) EncodeItem() (name string, meta int16) {
+	return "minecraft:bundle", 0
+}
+
+// Bundleable checks if the stack passed may be put into a Bundle. Shulker boxes can never be put into
+// bundles.
+func Bundleable(s Stack) bool {
+	name, _ := s.Item().EncodeItem()
+	return !strings.HasSuffix(name, "shulker_box")
+}
+
+// bundleWeight returns the part of the capacity of a Bundle taken up by a single item of the type passed.
+func bundleWeight(it world.Item) int {
+	if b, ok := it.(Bundle); ok {
+		// Bundles inside bundles take up their own contents in addition to a fixed weight.
+		return 4 + b.Weight()
+	}
+	m, ok := it.(MaxCounter)
+	if !ok || m.MaxCount() <= 0 {
+		return 1
+	}
+	return BundleCapacity / m.MaxCount()
+}
+
+// noinspection ALL
+//
+//go:linkname readItem github.com/df-mc/dragonfly/server/internal/nbtconv.Item
+func readItem(data map[string]any, s *Stack) Stack
@@ -0,0 +1,40 @@
+package item_test
+
+import (
+	"testing"
+
+	// The item package links to the item encoding of nbtconv, so the package
+	// must be part of the test binary.
+	_ "github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+func TestBundleFullness(t *testing.T) {
+	b, n := item.NewBundle().Insert(item.NewStack(item.Apple{}, 32))
+	if n != 32 || b.Fullness() != 0.5 {
+		t.Fatalf("expected 32 apples to fill half of the bundle, inserted %v with fullness %v", n, b.Fullness())
+	}
+	// Ender pearls stack to 16, so every pearl takes up 4 of the capacity.
+	b, n = b.Insert(item.NewStack(item.EnderPearl{}, 16))
+	if n != 8 || b.Fullness() != 1 {
+		t.Fatalf("expected 8 ender pearls to fill the rest of the bundle, inserted %v with fullness %v", n, b.Fullness())
+	}
+	if _, n = b.Insert(item.NewStack(item.Apple{}, 1)); n != 0 {
+		t.Errorf("expected no apples to fit in a full bundle, inserted %v", n)
+	}
+
+	b, s, _ := b.Remove()
+	if s.Count() != 8 || b.Fullness() != 0.5 {
+		t.Errorf("expected the ender pearls inserted last to be removed first, removed %v with fullness %v", s, b.Fullness())
+	}
+
+	if sword, n := item.NewBundle().Insert(item.NewStack(item.Sword{Tier: item.ToolTierIron}, 1)); n != 1 || sword.Fullness() != 1 {
+		t.Errorf("expected a single sword to fill a bundle, inserted %v with fullness %v", n, sword.Fullness())
+	}
+	// A bundle inside a bundle takes up a fixed weight of 4 on top of its own
+	// contents.
+	outer, n := item.NewBundle().Insert(item.NewStack(b, 1))
+	if n != 1 || outer.Weight() != 36 {
+		t.Errorf("expected a half full bundle to take up 36 of the capacity, inserted %v with weight %v", n, outer.Weight())
+	}
+}
@@ -15,15 +15,20 @@ type Chicken struct {
 	Cooked bool
 }
 
+// FoodInfo ...
+func (c Chicken) FoodInfo() FoodInfo {
+	if c.Cooked {
+		return FoodInfo{Food: 6, Saturation: 7.2}
+	}
+	return FoodInfo{Food: 2, Saturation: 1.2}
+}
+
 // Consume ...
 func (c Chicken) Consume(_ *world.Tx, co Consumer) Stack {
-	if c.Cooked {
-		co.Saturate(6, 7.2)
-	} else {
-		co.Saturate(2, 1.2)
-		if rand.Float64() < 0.3 {
-			co.AddEffect(effect.New(effect.Hunger, 1, 30*time.Second))
-		}
+	info := c.FoodInfo()
+	co.Saturate(info.Food, info.Saturation)
+	if !c.Cooked && rand.Float64() < 0.3 {
+		co.AddEffect(effect.New(effect.Hunger, 1, 30*time.Second))
 	}
 	return Stack{}
 }
@@ -0,0 +1,79 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// ChorusFruit is a food item obtained from chorus plants in the End. Eating
+// it teleports the consumer to a random position nearby.
+type ChorusFruit struct{}
+
+// AlwaysConsumable ...
+func (ChorusFruit) AlwaysConsumable() bool {
+	return true
+}
+
+// ConsumeDuration ...
+func (ChorusFruit) ConsumeDuration() time.Duration {
+	return DefaultConsumeDuration
+}
+
+// FoodInfo ...
+func (ChorusFruit) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 4, Saturation: 2.4}
+}
+
+// Consume ...
+func (f ChorusFruit) Consume(tx *world.Tx, c Consumer) Stack {
+	info := f.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
+	if t, ok := c.(interface{ Teleport(pos mgl64.Vec3) }); ok {
+		f.teleport(tx, c, t)
+	}
+	return Stack{}
+}
+
+// teleport makes up to 16 attempts to teleport the consumer to a random
+// position within 8 blocks, where there is space for the consumer to stand.
+func (ChorusFruit) teleport(tx *world.Tx, c Consumer, t interface{ Teleport(pos mgl64.Vec3) }) {
+	passable := func(pos cube.Pos) bool {
+		return len(tx.Block(pos).Model().BBox(pos, tx)) == 0
+	}
+	origin := c.Position()
+	for range 16 {
+		pos := cube.PosFromVec3(origin.Add(mgl64.Vec3{rand.Float64()*16 - 8, rand.Float64()*16 - 8, rand.Float64()*16 - 8}))
+		pos[1] = min(max(pos[1], tx.Range().Min()+1), tx.Range().Max()-1)
+		// Move down until the block below is solid, so that the consumer
+		// lands on the ground.
+		for pos[1] > tx.Range().Min()+1 && passable(pos.Side(cube.FaceDown)) {
+			pos = pos.Side(cube.FaceDown)
+		}
+		if passable(pos.Side(cube.FaceDown)) || !passable(pos) || !passable(pos.Side(cube.FaceUp)) {
+			continue
+		}
+		tx.PlaySound(origin, sound.Teleport{})
+		t.Teleport(pos.Vec3Middle())
+		tx.PlaySound(pos.Vec3Middle(), sound.Teleport{})
+		return
+	}
+}
+
+// SmeltInfo ...
+func (ChorusFruit) SmeltInfo() SmeltInfo {
+	return newSmeltInfo(NewStack(PoppedChorusFruit{}, 1), 0.1)
+}
+
+// CompostChance ...
+func (ChorusFruit) CompostChance() float64 {
+	return 0.65
+}
+
+// EncodeItem ...
+func (ChorusFruit) EncodeItem() (name string, meta int16) {
+	return "minecraft:chorus_fruit", 0
+}
@@ -10,13 +10,18 @@ type Cod struct {
 	Cooked bool
 }
 
-// Consume ...
-func (c Cod) Consume(_ *world.Tx, co Consumer) Stack {
+// FoodInfo ...
+func (c Cod) FoodInfo() FoodInfo {
 	if c.Cooked {
-		co.Saturate(5, 6)
-	} else {
-		co.Saturate(2, 0.4)
+		return FoodInfo{Food: 5, Saturation: 6}
 	}
+	return FoodInfo{Food: 2, Saturation: 0.4}
+}
+
+// Consume ...
+func (c Cod) Consume(_ *world.Tx, co Consumer) Stack {
+	info := c.FoodInfo()
+	co.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
@@ -7,9 +7,15 @@ type Cookie struct {
 	defaultFood
 }
 
+// FoodInfo ...
+func (Cookie) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 2, Saturation: 0.4}
+}
+
 // Consume ...
-func (Cookie) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(2, 0.4)
+func (co Cookie) Consume(_ *world.Tx, c Consumer) Stack {
+	info := co.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
@@ -18,9 +18,15 @@ func (DriedKelp) ConsumeDuration() time.Duration {
 	return DefaultConsumeDuration / 2
 }
 
+// FoodInfo ...
+func (DriedKelp) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 1, Saturation: 0.2}
+}
+
 // Consume ...
-func (DriedKelp) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(1, 0.2)
+func (d DriedKelp) Consume(_ *world.Tx, c Consumer) Stack {
+	info := d.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
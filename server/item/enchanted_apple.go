@@ -19,9 +19,15 @@ func (EnchantedApple) ConsumeDuration() time.Duration {
 	return DefaultConsumeDuration
 }
 
+// FoodInfo ...
+func (EnchantedApple) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 4, Saturation: 9.6}
+}
+
 // Consume ...
-func (EnchantedApple) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(4, 9.6)
+func (e EnchantedApple) Consume(_ *world.Tx, c Consumer) Stack {
+	info := e.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	c.AddEffect(effect.New(effect.Absorption, 4, 2*time.Minute))
 	c.AddEffect(effect.New(effect.Regeneration, 2, 30*time.Second))
 	c.AddEffect(effect.New(effect.FireResistance, 1, 5*time.Minute))
@@ -3,7 +3,9 @@ package item
 // EnchantedBook is an item that lets players add enchantments to certain items using an anvil.
 type EnchantedBook struct{}
 
-// MaxCount ...
+// MaxCount always returns 1: Enchanted books never stack, not even if they
+// hold the exact same enchantments. Two books with the same enchantment and
+// level may instead be combined in an anvil into a book of a higher level.
 func (b EnchantedBook) MaxCount() int {
 	return 1
 }
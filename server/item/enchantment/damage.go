@@ -17,6 +17,15 @@ type AffectedDamageSource interface {
 	AffectedByEnchantment(e item.EnchantmentType) bool
 }
 
+// BypassingDamageSource represents a world.DamageSource whose damage may not
+// be reduced by any enchantment, not even by protection.
+type BypassingDamageSource interface {
+	world.DamageSource
+	// BypassesEnchantments specifies if the world.DamageSource bypasses all
+	// protection enchantments.
+	BypassesEnchantments() bool
+}
+
 // DamageModifier is an item.EnchantmentType that can reduce damage through a
 // modifier if an AffectedDamageSource returns true for it.
 type DamageModifier interface {
@@ -28,6 +37,9 @@ type DamageModifier interface {
 // in a range of [0, 0.8], where 0.8 means incoming damage would be reduced by
 // 80%.
 func ProtectionFactor(src world.DamageSource, enchantments []item.Enchantment) float64 {
+	if b, ok := src.(BypassingDamageSource); ok && b.BypassesEnchantments() {
+		return 0
+	}
 	f := 0.0
 	for _, e := range enchantments {
 		t := e.Type()
@@ -31,10 +31,20 @@ func (depthStrider) Rarity() item.EnchantmentRarity {
 	return item.EnchantmentRarityRare
 }
 
+// WaterFriction returns the friction applied to the horizontal movement of
+// the wearer in water for the level passed. The effect of the enchantment is
+// halved while the wearer is not on the ground.
+func (depthStrider) WaterFriction(level int, onGround bool) float64 {
+	d := float64(min(level, 3))
+	if !onGround {
+		d /= 2
+	}
+	return 0.8 + (0.546-0.8)*d/3
+}
+
 // CompatibleWithEnchantment ...
-func (depthStrider) CompatibleWithEnchantment(item.EnchantmentType) bool {
-	// TODO: Frost Walker
-	return true
+func (depthStrider) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	return t != FrostWalker
 }
 
 // CompatibleWithItem ...
@@ -37,6 +37,12 @@ func (soulSpeed) Treasure() bool {
 	return true
 }
 
+// SpeedIncrease returns the movement speed added to the wearer while walking
+// on soul sand or soul soil for the level passed.
+func (soulSpeed) SpeedIncrease(level int) float64 {
+	return 0.03 * (1 + float64(level)*0.35)
+}
+
 // CompatibleWithEnchantment ...
 func (soulSpeed) CompatibleWithEnchantment(item.EnchantmentType) bool {
 	return true
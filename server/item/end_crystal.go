@@ -0,0 +1,37 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// EndCrystal is an item that may be placed on obsidian or bedrock to create
+// an end crystal entity, which explodes when it is attacked.
+type EndCrystal struct{}
+
+// UseOnBlock ...
+func (EndCrystal) UseOnBlock(pos cube.Pos, _ cube.Face, _ mgl64.Vec3, tx *world.Tx, _ User, ctx *UseContext) bool {
+	if name, _ := tx.Block(pos).EncodeBlock(); name != "minecraft:obsidian" && name != "minecraft:bedrock" {
+		return false
+	}
+	above := pos.Side(cube.FaceUp)
+	if tx.Block(above) != air() {
+		return false
+	}
+	box := cube.Box(0, 0, 0, 1, 2, 1).Translate(above.Vec3())
+	for range tx.EntitiesWithin(box) {
+		// End crystals cannot be placed if any entity is in the way.
+		return false
+	}
+	create := tx.World().EntityRegistry().Config().EndCrystal
+	tx.AddEntity(create(world.EntitySpawnOpts{Position: above.Vec3Middle()}, false))
+
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// EncodeItem ...
+func (EndCrystal) EncodeItem() (name string, meta int16) {
+	return "minecraft:end_crystal", 0
+}
@@ -19,9 +19,15 @@ func (e GoldenApple) ConsumeDuration() time.Duration {
 	return DefaultConsumeDuration
 }
 
+// FoodInfo ...
+func (GoldenApple) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 4, Saturation: 9.6}
+}
+
 // Consume ...
 func (e GoldenApple) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(4, 9.6)
+	info := e.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	c.AddEffect(effect.New(effect.Absorption, 1, 2*time.Minute))
 	c.AddEffect(effect.New(effect.Regeneration, 2, 5*time.Second))
 	return Stack{}
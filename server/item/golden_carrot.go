@@ -8,9 +8,15 @@ type GoldenCarrot struct {
 	defaultFood
 }
 
+// FoodInfo ...
+func (GoldenCarrot) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 6, Saturation: 14.4}
+}
+
 // Consume ...
-func (GoldenCarrot) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(6, 14.4)
+func (g GoldenCarrot) Consume(_ *world.Tx, c Consumer) Stack {
+	info := g.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
@@ -0,0 +1,44 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+	"time"
+)
+
+// HoneyBottle is a food item obtained by using a glass bottle on a full bee
+// nest or beehive. Drinking it removes poison.
+type HoneyBottle struct{}
+
+// MaxCount ...
+func (HoneyBottle) MaxCount() int {
+	return 16
+}
+
+// AlwaysConsumable ...
+func (HoneyBottle) AlwaysConsumable() bool {
+	return true
+}
+
+// ConsumeDuration ...
+func (HoneyBottle) ConsumeDuration() time.Duration {
+	return time.Second * 2
+}
+
+// FoodInfo ...
+func (HoneyBottle) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 6, Saturation: 1.2}
+}
+
+// Consume ...
+func (h HoneyBottle) Consume(_ *world.Tx, c Consumer) Stack {
+	info := h.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
+	c.RemoveEffect(effect.Poison)
+	return NewStack(GlassBottle{}, 1)
+}
+
+// EncodeItem ...
+func (HoneyBottle) EncodeItem() (name string, meta int16) {
+	return "minecraft:honey_bottle", 0
+}
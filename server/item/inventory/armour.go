@@ -122,17 +122,27 @@ func (a *Armour) DamageReduction(dmg float64, src world.DamageSource) float64 {
 		}
 	}
 
-	dmg -= dmg * enchantment.ProtectionFactor(src, enchantments)
 	if src.ReducedByArmour() {
-		// Armour in Bedrock edition reduces the damage taken by 4% for each effective armour point. Effective
-		// armour point decreases as damage increases, with 1 point lost for every 2 HP of damage. The defense
-		// reduction is decreased by the toughness armor value. Effective armour points will at minimum be 20% of
-		// armour points.
-		dmg -= dmg * 0.04 * math.Max(defencePoints*0.2, defencePoints-dmg/(2+toughness/4))
+		dmg -= ArmourReduction(dmg, defencePoints, toughness)
 	}
+	// Enchantments only reduce the damage left after it was reduced by the
+	// armour points.
+	dmg -= dmg * enchantment.ProtectionFactor(src, enchantments)
 	return original - dmg
 }
 
+// ArmourReduction returns the amount of damage reduced by the defence points
+// and toughness passed for an amount of damage. Armour reduces the damage
+// taken by 4% for each effective armour point. Effective armour points
+// decrease as damage increases, with 1 point lost for every 2 HP of damage,
+// which is reduced by the toughness of the armour. Effective armour points are
+// at least 20% of the defence points and at most 20, so that armour never
+// reduces more than 80% of the damage.
+func ArmourReduction(dmg, defencePoints, toughness float64) float64 {
+	effective := math.Max(defencePoints*0.2, defencePoints-dmg/(2+toughness/4))
+	return dmg * 0.04 * math.Min(effective, 20)
+}
+
 // HighestEnchantmentLevel looks up the highest level of an item.EnchantmentType
 // that any of the Armour items have and returns it, or 0 if none of the items
 // have the enchantment.
@@ -152,11 +162,15 @@ func (a *Armour) HighestEnchantmentLevel(t item.EnchantmentType) int {
 type DamageFunc func(s item.Stack, d int) item.Stack
 
 // Damage deals damage (hearts) to Armour. The resulting item damage depends on the
-// dmg passed and the DamageFunc used.
+// dmg passed and the DamageFunc used. Only pieces of armour that provide defence
+// points are damaged, so that items such as elytra and carved pumpkins do not
+// lose durability when the wearer is hurt.
 func (a *Armour) Damage(dmg float64, f DamageFunc) {
 	armourDamage := int(math.Max(math.Floor(dmg/4), 1))
 	for slot, it := range a.Slots() {
-		_ = a.inv.SetItem(slot, f(it, armourDamage))
+		if armour, ok := it.Item().(item.Armour); ok && armour.DefencePoints() > 0 {
+			_ = a.inv.SetItem(slot, f(it, armourDamage))
+		}
 	}
 }
 
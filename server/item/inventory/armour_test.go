@@ -0,0 +1,68 @@
+package inventory_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+)
+
+// attackSource is a world.DamageSource that is reduced by armour, like an
+// attack by another entity.
+type attackSource struct{}
+
+func (attackSource) ReducedByArmour() bool     { return true }
+func (attackSource) ReducedByResistance() bool { return true }
+func (attackSource) Fire() bool                { return false }
+func (attackSource) IgnoreTotem() bool         { return false }
+
+// armourSet returns Armour with a full set of armour of the tier passed, of
+// which every piece has the enchantments passed.
+func armourSet(tier item.ArmourTier, enchantments ...item.Enchantment) *inventory.Armour {
+	a := inventory.NewArmour(nil)
+	if tier == nil {
+		return a
+	}
+	a.Set(
+		item.NewStack(item.Helmet{Tier: tier}, 1).WithEnchantments(enchantments...),
+		item.NewStack(item.Chestplate{Tier: tier}, 1).WithEnchantments(enchantments...),
+		item.NewStack(item.Leggings{Tier: tier}, 1).WithEnchantments(enchantments...),
+		item.NewStack(item.Boots{Tier: tier}, 1).WithEnchantments(enchantments...),
+	)
+	return a
+}
+
+func TestArmourDamageReduction(t *testing.T) {
+	protection := item.NewEnchantment(enchantment.Protection, 4)
+	tests := []struct {
+		name     string
+		armour   *inventory.Armour
+		dmg      float64
+		expected float64
+	}{
+		// Without armour, no damage is reduced.
+		{name: "none", armour: armourSet(nil), dmg: 10, expected: 0},
+		// 15 armour points lose 5 effective points for 10 damage, and 10
+		// effective points reduce 40% of the damage.
+		{name: "iron", armour: armourSet(item.ArmourTierIron{}), dmg: 10, expected: 4},
+		// Effective armour points are never fewer than 20% of the armour
+		// points: 3 points reduce 12% of the damage.
+		{name: "iron high damage", armour: armourSet(item.ArmourTierIron{}), dmg: 40, expected: 4.8},
+		// 8 toughness halves the effective points lost: 20 points minus 2.5
+		// reduce 70% of the damage.
+		{name: "diamond", armour: armourSet(item.ArmourTierDiamond{}), dmg: 10, expected: 7},
+		// Protection IV on four pieces reduces 64% of the damage left after
+		// armour: 3 of 10 damage is left, of which 1.92 is reduced.
+		{name: "diamond protection", armour: armourSet(item.ArmourTierDiamond{}, protection), dmg: 10, expected: 8.92},
+		// 100 damage leaves the 7 armour points of leather at their minimum of
+		// 1.4 effective points, but protection still reduces 64% of the rest.
+		{name: "leather protection high damage", armour: armourSet(item.ArmourTierLeather{}, protection), dmg: 100, expected: 100 - 100*(1-0.04*7*0.2)*(1-0.64)},
+	}
+	for _, test := range tests {
+		if reduced := test.armour.DamageReduction(test.dmg, attackSource{}); math.Abs(reduced-test.expected) > 1e-9 {
+			t.Errorf("%v: expected %v of %v damage to be reduced, got %v", test.name, test.expected, test.dmg, reduced)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package inventory_test
+
+import (
+	"testing"
+
+	// nbtconv provides the NBT functions that the item package links to.
+	_ "github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+)
+
+func TestEnchantedBooksDoNotStack(t *testing.T) {
+	inv := inventory.New(9, nil)
+	book := item.NewStack(item.EnchantedBook{}, 1).WithEnchantments(item.NewEnchantment(enchantment.Sharpness, 3))
+	for range 2 {
+		if _, err := inv.AddItem(book); err != nil {
+			t.Fatalf("add enchanted book: %v", err)
+		}
+	}
+	for slot := range 2 {
+		if it, _ := inv.Item(slot); it.Count() != 1 {
+			t.Fatalf("expected 1 enchanted book in slot %v, got %v", slot, it.Count())
+		}
+	}
+}
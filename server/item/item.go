@@ -75,6 +75,25 @@ type Consumable interface {
 	Consume(tx *world.Tx, c Consumer) Stack
 }
 
+// Food represents a Consumable item that restores food points and saturation
+// when it is consumed.
+type Food interface {
+	Consumable
+	// FoodInfo returns the food points and saturation restored by consuming
+	// the item.
+	FoodInfo() FoodInfo
+}
+
+// FoodInfo holds the nutritional values of a Food item.
+type FoodInfo struct {
+	// Food is the amount of food points restored by consuming the item. Every
+	// food point is half a drumstick in the food bar.
+	Food int
+	// Saturation is the amount of saturation points restored by consuming the
+	// item. The saturation of a Consumer never exceeds its food level.
+	Saturation float64
+}
+
 // Consumer represents a User that is able to consume Consumable items.
 type Consumer interface {
 	User
@@ -0,0 +1,76 @@
+package item
+
+import "math/rand/v2"
+
+// EmptyMap is an unused map. It may be combined with a filled Map in a cartography table to create a copy of it.
+type EmptyMap struct{}
+
+// EncodeItem ...
+func (EmptyMap) EncodeItem() (name string, meta int16) {
+	return "minecraft:empty_map", 0
+}
+
+// MaxMapScale is the maximum scale of a Map. A map with this scale can no longer be zoomed out.
+const MaxMapScale = 4
+
+// Map is a filled map, which shows the area of a world around the position it was created at. Maps may be copied,
+// zoomed out and locked using a cartography table.
+type Map struct {
+	// ID is the unique ID of the map. Maps with the same ID show the same area of a world.
+	ID int64
+	// Scale is the scale of the map, ranging from 0 to MaxMapScale. Every increase of the scale doubles the size of
+	// the area shown by the map.
+	Scale int
+	// Locked specifies if the map is locked. The contents of locked maps no longer change.
+	Locked bool
+}
+
+// NewMap returns a Map with a new, random ID and the scale passed.
+func NewMap(scale int) Map {
+	return Map{ID: rand.Int64(), Scale: min(max(scale, 0), MaxMapScale)}
+}
+
+// Zoom returns a copy of the Map with a scale one higher than the current one and a new ID. False is returned if
+// the map is locked or already has the maximum scale.
+func (m Map) Zoom() (Map, bool) {
+	if m.Locked || m.Scale >= MaxMapScale {
+		return m, false
+	}
+	return NewMap(m.Scale + 1), true
+}
+
+// Lock returns a locked copy of the Map. The copy keeps the ID of the map, so that it shows the same contents as the
+// map it was locked from. False is returned if the map is already locked.
+func (m Map) Lock() (Map, bool) {
+	if m.Locked {
+		return m, false
+	}
+	m.Locked = true
+	return m, true
+}
+
+// DecodeNBT ...
+func (m Map) DecodeNBT(data map[string]any) any {
+	m.ID, _ = data["map_uuid"].(int64)
+	if v, ok := data["map_scale"].(int32); ok {
+		m.Scale = min(max(int(v), 0), MaxMapScale)
+	}
+	if v, ok := data["map_is_locked"].(uint8); ok {
+		m.Locked = v == 1
+	}
+	return m
+}
+
+// EncodeNBT ...
+func (m Map) EncodeNBT() map[string]any {
+	data := map[string]any{"map_uuid": m.ID, "map_scale": int32(m.Scale)}
+	if m.Locked {
+		data["map_is_locked"] = uint8(1)
+	}
+	return data
+}
+
+// EncodeItem ...
+func (Map) EncodeItem() (name string, meta int16) {
+	return "minecraft:filled_map", 0
+}
@@ -18,9 +18,15 @@ func (m MelonSlice) ConsumeDuration() time.Duration {
 	return DefaultConsumeDuration
 }
 
+// FoodInfo ...
+func (MelonSlice) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 2, Saturation: 1.2}
+}
+
 // Consume ...
 func (m MelonSlice) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(2, 1.2)
+	info := m.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
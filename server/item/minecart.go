@@ -0,0 +1,104 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"strings"
+)
+
+// Minecart is an item that may be placed on a rail to create a minecart
+// entity, which rides along the rails it is placed on.
+type Minecart struct {
+	// Type is the type of the minecart, which specifies what the minecart
+	// carries.
+	Type MinecartType
+}
+
+// MaxCount always returns 1.
+func (Minecart) MaxCount() int {
+	return 1
+}
+
+// UseOnBlock ...
+func (m Minecart) UseOnBlock(pos cube.Pos, _ cube.Face, _ mgl64.Vec3, tx *world.Tx, _ User, ctx *UseContext) bool {
+	name, properties := tx.Block(pos).EncodeBlock()
+	if !strings.HasSuffix(name, "rail") {
+		return false
+	}
+	spawnPos := pos.Vec3Middle().Add(mgl64.Vec3{0, 0.0625})
+	if d, ok := properties["rail_direction"].(int32); ok && d >= 2 && d <= 5 {
+		// Minecarts placed on ascending rails are placed half way up the
+		// slope.
+		spawnPos[1] += 0.5
+	}
+	create := tx.World().EntityRegistry().Config().Minecart
+	tx.AddEntity(create(world.EntitySpawnOpts{Position: spawnPos}, m.Type))
+
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// EncodeItem ...
+func (m Minecart) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + m.Type.String(), 0
+}
+
+// MinecartType represents the type of a minecart.
+type MinecartType struct {
+	minecart
+}
+
+// RegularMinecart is a minecart without any contents, which may be ridden.
+func RegularMinecart() MinecartType {
+	return MinecartType{0}
+}
+
+// ChestMinecart is a minecart that carries a chest.
+func ChestMinecart() MinecartType {
+	return MinecartType{1}
+}
+
+// HopperMinecart is a minecart that carries a hopper, which collects items
+// that it rides over.
+func HopperMinecart() MinecartType {
+	return MinecartType{2}
+}
+
+// MinecartTypes returns all minecart types.
+func MinecartTypes() []MinecartType {
+	return []MinecartType{RegularMinecart(), ChestMinecart(), HopperMinecart()}
+}
+
+type minecart uint8
+
+// Uint8 returns the minecart type as a uint8.
+func (m minecart) Uint8() uint8 {
+	return uint8(m)
+}
+
+// Name ...
+func (m minecart) Name() string {
+	switch m {
+	case 0:
+		return "Minecart"
+	case 1:
+		return "Minecart with Chest"
+	case 2:
+		return "Minecart with Hopper"
+	}
+	panic("unknown minecart type")
+}
+
+// String ...
+func (m minecart) String() string {
+	switch m {
+	case 0:
+		return "minecart"
+	case 1:
+		return "chest_minecart"
+	case 2:
+		return "hopper_minecart"
+	}
+	panic("unknown minecart type")
+}
@@ -14,9 +14,15 @@ func (MushroomStew) MaxCount() int {
 	return 1
 }
 
+// FoodInfo ...
+func (MushroomStew) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 6, Saturation: 7.2}
+}
+
 // Consume ...
-func (MushroomStew) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(6, 7.2)
+func (m MushroomStew) Consume(_ *world.Tx, c Consumer) Stack {
+	info := m.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return NewStack(Bowl{}, 1)
 }
 
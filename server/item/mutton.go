@@ -10,13 +10,18 @@ type Mutton struct {
 	Cooked bool
 }
 
-// Consume ...
-func (m Mutton) Consume(_ *world.Tx, c Consumer) Stack {
+// FoodInfo ...
+func (m Mutton) FoodInfo() FoodInfo {
 	if m.Cooked {
-		c.Saturate(6, 9.6)
-	} else {
-		c.Saturate(2, 1.2)
+		return FoodInfo{Food: 6, Saturation: 9.6}
 	}
+	return FoodInfo{Food: 2, Saturation: 1.2}
+}
+
+// Consume ...
+func (m Mutton) Consume(_ *world.Tx, c Consumer) Stack {
+	info := m.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
@@ -0,0 +1,35 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NameTag is an item used to give a custom name to a mob. The name given is
+// the custom name of the name tag, which is usually set using an anvil.
+type NameTag struct{}
+
+// nameTagged represents an entity that may be given a name tag.
+type nameTagged interface {
+	NameTag() string
+	SetNameTag(s string)
+}
+
+// UseOnEntity names the entity passed after the custom name of the name tag
+// held by the user. Name tags without a custom name and players cannot be
+// used.
+func (NameTag) UseOnEntity(e world.Entity, _ *world.Tx, user User, ctx *UseContext) bool {
+	held, _ := user.HeldItems()
+	n, ok := e.(nameTagged)
+	name := held.CustomName()
+	if !ok || name == "" || name == n.NameTag() || e.H().Type().EncodeEntity() == "minecraft:player" {
+		return false
+	}
+	n.SetNameTag(name)
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// EncodeItem ...
+func (NameTag) EncodeItem() (name string, meta int16) {
+	return "minecraft:name_tag", 0
+}
@@ -12,9 +12,15 @@ type PoisonousPotato struct {
 	defaultFood
 }
 
+// FoodInfo ...
+func (PoisonousPotato) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 2, Saturation: 1.2}
+}
+
 // Consume ...
 func (p PoisonousPotato) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(2, 1.2)
+	info := p.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	if rand.Float64() < 0.6 {
 		c.AddEffect(effect.New(effect.Poison, 1, 5*time.Second))
 	}
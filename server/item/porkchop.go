@@ -10,13 +10,18 @@ type Porkchop struct {
 	Cooked bool
 }
 
-// Consume ...
-func (p Porkchop) Consume(_ *world.Tx, c Consumer) Stack {
+// FoodInfo ...
+func (p Porkchop) FoodInfo() FoodInfo {
 	if p.Cooked {
-		c.Saturate(8, 12.8)
-	} else {
-		c.Saturate(3, 1.8)
+		return FoodInfo{Food: 8, Saturation: 12.8}
 	}
+	return FoodInfo{Food: 3, Saturation: 1.8}
+}
+
+// Consume ...
+func (p Porkchop) Consume(_ *world.Tx, c Consumer) Stack {
+	info := p.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
@@ -11,9 +11,15 @@ type Pufferfish struct {
 	defaultFood
 }
 
+// FoodInfo ...
+func (Pufferfish) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 1, Saturation: 0.2}
+}
+
 // Consume ...
 func (p Pufferfish) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(1, 0.2)
+	info := p.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	c.AddEffect(effect.New(effect.Hunger, 3, 15*time.Second))
 	c.AddEffect(effect.New(effect.Poison, 2, time.Minute))
 	c.AddEffect(effect.New(effect.Nausea, 2, 15*time.Second))
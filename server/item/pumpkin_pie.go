@@ -7,9 +7,15 @@ type PumpkinPie struct {
 	defaultFood
 }
 
+// FoodInfo ...
+func (PumpkinPie) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 8, Saturation: 4.8}
+}
+
 // Consume ...
-func (PumpkinPie) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(8, 4.8)
+func (p PumpkinPie) Consume(_ *world.Tx, c Consumer) Stack {
+	info := p.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
@@ -10,13 +10,18 @@ type Rabbit struct {
 	Cooked bool
 }
 
-// Consume ...
-func (r Rabbit) Consume(_ *world.Tx, c Consumer) Stack {
+// FoodInfo ...
+func (r Rabbit) FoodInfo() FoodInfo {
 	if r.Cooked {
-		c.Saturate(5, 6)
-	} else {
-		c.Saturate(3, 1.8)
+		return FoodInfo{Food: 5, Saturation: 6}
 	}
+	return FoodInfo{Food: 3, Saturation: 1.8}
+}
+
+// Consume ...
+func (r Rabbit) Consume(_ *world.Tx, c Consumer) Stack {
+	info := r.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
@@ -14,9 +14,15 @@ func (RabbitStew) MaxCount() int {
 	return 1
 }
 
+// FoodInfo ...
+func (RabbitStew) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 10, Saturation: 12}
+}
+
 // Consume ...
-func (RabbitStew) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(10, 12)
+func (r RabbitStew) Consume(_ *world.Tx, c Consumer) Stack {
+	info := r.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return NewStack(Bowl{}, 1)
 }
 
@@ -28,8 +28,10 @@ func init() {
 	world.RegisterItem(Bread{})
 	world.RegisterItem(Brick{})
 	world.RegisterItem(Bucket{})
+	world.RegisterItem(Bundle{})
 	world.RegisterItem(CarrotOnAStick{})
 	world.RegisterItem(Charcoal{})
+	world.RegisterItem(ChorusFruit{})
 	world.RegisterItem(Chicken{Cooked: true})
 	world.RegisterItem(Chicken{})
 	world.RegisterItem(ClayBall{})
@@ -49,8 +51,10 @@ func init() {
 	world.RegisterItem(Egg{})
 	world.RegisterItem(Elytra{})
 	world.RegisterItem(Emerald{})
+	world.RegisterItem(EmptyMap{})
 	world.RegisterItem(EnchantedApple{})
 	world.RegisterItem(EnchantedBook{})
+	world.RegisterItem(EndCrystal{})
 	world.RegisterItem(EnderPearl{})
 	world.RegisterItem(Feather{})
 	world.RegisterItem(FermentedSpiderEye{})
@@ -68,6 +72,7 @@ func init() {
 	world.RegisterItem(GoldenCarrot{})
 	world.RegisterItem(Gunpowder{})
 	world.RegisterItem(HeartOfTheSea{})
+	world.RegisterItem(HoneyBottle{})
 	world.RegisterItem(Honeycomb{})
 	world.RegisterItem(InkSac{Glowing: true})
 	world.RegisterItem(InkSac{})
@@ -76,10 +81,12 @@ func init() {
 	world.RegisterItem(LapisLazuli{})
 	world.RegisterItem(Leather{})
 	world.RegisterItem(MagmaCream{})
+	world.RegisterItem(Map{})
 	world.RegisterItem(MelonSlice{})
 	world.RegisterItem(MushroomStew{})
 	world.RegisterItem(Mutton{Cooked: true})
 	world.RegisterItem(Mutton{})
+	world.RegisterItem(NameTag{})
 	world.RegisterItem(NautilusShell{})
 	world.RegisterItem(NetherBrick{})
 	world.RegisterItem(NetherQuartz{})
@@ -117,6 +124,7 @@ func init() {
 	world.RegisterItem(SpiderEye{})
 	world.RegisterItem(Spyglass{})
 	world.RegisterItem(Stick{})
+	world.RegisterItem(String{})
 	world.RegisterItem(Sugar{})
 	world.RegisterItem(Totem{})
 	world.RegisterItem(TropicalFish{})
@@ -164,6 +172,9 @@ func init() {
 	for _, stew := range StewTypes() {
 		world.RegisterItem(SuspiciousStew{Type: stew})
 	}
+	for _, t := range MinecartTypes() {
+		world.RegisterItem(Minecart{Type: t})
+	}
 	for _, sherd := range SherdTypes() {
 		world.RegisterItem(PotterySherd{Type: sherd})
 	}
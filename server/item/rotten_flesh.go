@@ -12,9 +12,15 @@ type RottenFlesh struct {
 	defaultFood
 }
 
+// FoodInfo ...
+func (RottenFlesh) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 4, Saturation: 0.8}
+}
+
 // Consume ...
-func (RottenFlesh) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(4, 0.8)
+func (r RottenFlesh) Consume(_ *world.Tx, c Consumer) Stack {
+	info := r.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	if rand.Float64() < 0.8 {
 		c.AddEffect(effect.New(effect.Hunger, 1, 30*time.Second))
 	}
@@ -10,13 +10,18 @@ type Salmon struct {
 	Cooked bool
 }
 
-// Consume ...
-func (s Salmon) Consume(_ *world.Tx, c Consumer) Stack {
+// FoodInfo ...
+func (s Salmon) FoodInfo() FoodInfo {
 	if s.Cooked {
-		c.Saturate(6, 9.6)
-	} else {
-		c.Saturate(2, 0.4)
+		return FoodInfo{Food: 6, Saturation: 9.6}
 	}
+	return FoodInfo{Food: 2, Saturation: 0.4}
+}
+
+// Consume ...
+func (s Salmon) Consume(_ *world.Tx, c Consumer) Stack {
+	info := s.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
@@ -33,6 +33,13 @@ type carvable interface {
 	Carve(f cube.Face) (world.Block, bool)
 }
 
+// cobweb checks if the world.Block passed is a cobweb. The block package
+// cannot be imported here, so the block is identified by its name.
+func cobweb(b world.Block) bool {
+	name, _ := b.EncodeBlock()
+	return name == "minecraft:web"
+}
+
 // ToolType ...
 func (s Shears) ToolType() ToolType {
 	return TypeShears
@@ -43,8 +50,12 @@ func (s Shears) HarvestLevel() int {
 	return 1
 }
 
-// BaseMiningEfficiency ...
-func (s Shears) BaseMiningEfficiency(world.Block) float64 {
+// BaseMiningEfficiency returns 1.5, unless the block passed is cobweb, in
+// which case 15 is returned.
+func (s Shears) BaseMiningEfficiency(b world.Block) float64 {
+	if cobweb(b) {
+		return 15
+	}
 	return 1.5
 }
 
@@ -11,9 +11,15 @@ type SpiderEye struct {
 	defaultFood
 }
 
+// FoodInfo ...
+func (SpiderEye) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 2, Saturation: 3.2}
+}
+
 // Consume ...
-func (SpiderEye) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(2, 3.2)
+func (s SpiderEye) Consume(_ *world.Tx, c Consumer) Stack {
+	info := s.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	c.AddEffect(effect.New(effect.Poison, 1, time.Second*5))
 	return Stack{}
 }
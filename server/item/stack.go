@@ -31,6 +31,8 @@ type Stack struct {
 	data map[string]any
 
 	enchantments map[EnchantmentType]Enchantment
+
+	attributeModifiers []AttributeModifier
 }
 
 // NewStack returns a new stack using the item type and the count passed. NewStack panics if the count passed
@@ -306,6 +308,49 @@ func (s Stack) Enchantments() []Enchantment {
 	return e
 }
 
+// WithAttributeModifier returns a copy of the Stack with an AttributeModifier
+// added to it. The modifier modifies the Attribute passed by the amount passed
+// using the AttributeOperation passed while the item is equipped in the
+// EquipmentSlot passed. AttributeModifiers are shown in the tooltip of the
+// item.
+func (s Stack) WithAttributeModifier(attr Attribute, amount float64, op AttributeOperation, slot EquipmentSlot) Stack {
+	s.attributeModifiers = append(slices.Clip(s.attributeModifiers), AttributeModifier{Attribute: attr, Amount: amount, Operation: op, Slot: slot})
+	return s
+}
+
+// WithoutAttributeModifiers returns a copy of the Stack with all
+// AttributeModifiers of the attributes passed removed. If no attributes are
+// passed, all AttributeModifiers are removed.
+func (s Stack) WithoutAttributeModifiers(attributes ...Attribute) Stack {
+	if len(attributes) == 0 {
+		s.attributeModifiers = nil
+		return s
+	}
+	s.attributeModifiers = slices.DeleteFunc(slices.Clone(s.attributeModifiers), func(m AttributeModifier) bool {
+		return slices.Contains(attributes, m.Attribute)
+	})
+	return s
+}
+
+// AttributeModifiers returns all AttributeModifiers added to the Stack, in
+// the order that they were added.
+func (s Stack) AttributeModifiers() []AttributeModifier {
+	return slices.Clone(s.attributeModifiers)
+}
+
+// AttributeModifiersFor returns the AttributeModifiers of the Stack that
+// modify the Attribute passed while the item is equipped in the
+// EquipmentSlot passed.
+func (s Stack) AttributeModifiersFor(attr Attribute, slot EquipmentSlot) []AttributeModifier {
+	var modifiers []AttributeModifier
+	for _, m := range s.attributeModifiers {
+		if m.Attribute == attr && m.Slot == slot {
+			modifiers = append(modifiers, m)
+		}
+	}
+	return modifiers
+}
+
 // AnvilCost returns the number of experience levels to add to the base level cost when repairing, combining, or
 // renaming this item with an anvil.
 func (s Stack) AnvilCost() int {
@@ -337,6 +382,7 @@ func (s Stack) WithItem(t world.Item) Stack {
 		WithAnvilCost(s.anvilCost)
 	cp.unbreakable = s.unbreakable && s.MaxDurability() != -1
 	cp.data = s.data
+	cp.attributeModifiers = s.attributeModifiers
 	return cp
 }
 
@@ -394,6 +440,9 @@ func (s Stack) Comparable(s2 Stack) bool {
 			return false
 		}
 	}
+	if !slices.Equal(s.attributeModifiers, s2.attributeModifiers) {
+		return false
+	}
 	if !reflect.DeepEqual(s.data, s2.data) {
 		return false
 	}
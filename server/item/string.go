@@ -0,0 +1,10 @@
+package item
+
+// String is an item obtained from cobwebs and spiders, used to craft bows,
+// fishing rods and wool.
+type String struct{}
+
+// EncodeItem ...
+func (String) EncodeItem() (name string, meta int16) {
+	return "minecraft:string", 0
+}
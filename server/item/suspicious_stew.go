@@ -27,12 +27,18 @@ func (s SuspiciousStew) EncodeItem() (name string, meta int16) {
 	return "minecraft:suspicious_stew", int16(s.Type.Uint8())
 }
 
+// FoodInfo ...
+func (SuspiciousStew) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 6, Saturation: 7.2}
+}
+
 // Consume ...
 func (s SuspiciousStew) Consume(_ *world.Tx, c Consumer) Stack {
 	for _, effect := range s.Type.Effects() {
 		c.AddEffect(effect)
 	}
-	c.Saturate(6, 7.2)
+	info := s.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 
 	return NewStack(Bowl{}, 1)
 }
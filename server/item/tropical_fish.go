@@ -7,9 +7,15 @@ type TropicalFish struct {
 	defaultFood
 }
 
+// FoodInfo ...
+func (TropicalFish) FoodInfo() FoodInfo {
+	return FoodInfo{Food: 1, Saturation: 0.2}
+}
+
 // Consume ...
-func (TropicalFish) Consume(_ *world.Tx, c Consumer) Stack {
-	c.Saturate(1, 0.2)
+func (t TropicalFish) Consume(_ *world.Tx, c Consumer) Stack {
+	info := t.FoodInfo()
+	c.Saturate(info.Food, info.Saturation)
 	return Stack{}
 }
 
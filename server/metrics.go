@@ -0,0 +1,62 @@
+package server
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"time"
+)
+
+// Metrics holds metrics of a Server at a point in time, such as may be
+// exported to a monitoring system. Metrics are obtained through
+// Server.Metrics.
+type Metrics struct {
+	// Players is the amount of players connected to the Server.
+	Players int
+	// Worlds holds the metrics of the overworld, nether and end of the Server,
+	// in that order.
+	Worlds []WorldMetrics
+}
+
+// WorldMetrics holds the metrics of a single world of a Server.
+type WorldMetrics struct {
+	// Name is the name of the world.
+	Name string
+	// Dimension is the dimension of the world.
+	Dimension world.Dimension
+	world.TickMetrics
+}
+
+// MetricsExporter exports the Metrics of a Server to a monitoring system. A
+// MetricsExporter may, for example, hold the Metrics last exported and serve
+// them to Prometheus over HTTP.
+type MetricsExporter interface {
+	// ExportMetrics is called with the Metrics of the Server once every
+	// Config.MetricsInterval for as long as the Server is running. It is
+	// always called from the same goroutine.
+	ExportMetrics(m Metrics)
+}
+
+// Metrics returns the current Metrics of the Server. The world.TickMetrics of
+// the worlds of the Server are only recorded if Config.MetricsExporter is
+// set and are zero otherwise. Metrics may be called from any goroutine.
+func (srv *Server) Metrics() Metrics {
+	m := Metrics{Players: srv.PlayerCount()}
+	for _, w := range []*world.World{srv.world, srv.nether, srv.end} {
+		m.Worlds = append(m.Worlds, WorldMetrics{Name: w.Name(), Dimension: w.Dimension(), TickMetrics: w.TickMetrics()})
+	}
+	return m
+}
+
+// exportMetrics passes the Metrics of the Server to the MetricsExporter of
+// the Config once every Config.MetricsInterval until the Server is closed.
+func (srv *Server) exportMetrics() {
+	t := time.NewTicker(srv.conf.MetricsInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			srv.conf.MetricsExporter.ExportMetrics(srv.Metrics())
+		case <-srv.closing:
+			return
+		}
+	}
+}
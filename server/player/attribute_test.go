@@ -0,0 +1,38 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/entity/attribute"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestSwordAttackDamageModifierInMainHand(t *testing.T) {
+	sword := item.NewStack(item.Sword{Tier: item.ToolTierDiamond}, 1).
+		WithAttributeModifier(item.AttributeAttackDamage(), 5, item.AttributeOperationAdd(), item.EquipmentSlotMainHand())
+	base := sword.AttackDamage()
+
+	withPlayer(t, Config{}, func(tx *world.Tx, p *Player) {
+		p.SetHeldItems(sword, item.Stack{})
+		p.updateEquipmentAttributes()
+		if dmg := p.Attributes().Apply(attribute.AttackDamage(), base); dmg != base+5 {
+			t.Errorf("expected sword in the main hand to deal %v attack damage, got %v", base+5, dmg)
+		}
+
+		p.SetHeldItems(item.Stack{}, sword)
+		p.updateEquipmentAttributes()
+		if dmg := p.Attributes().Apply(attribute.AttackDamage(), base); dmg != base {
+			t.Errorf("expected main hand modifier of sword in the off hand not to apply, got %v attack damage", dmg)
+		}
+
+		p.SetHeldItems(sword, item.Stack{})
+		conf := Config{Name: "target", Position: p.Position().Add(mgl64.Vec3{1, 0, 0})}
+		target := tx.AddEntity(world.EntitySpawnOpts{Position: conf.Position}.New(Type, conf)).(*Player)
+		p.AttackEntity(target)
+		if lost := target.MaxHealth() - target.Health(); lost != base+5 {
+			t.Errorf("expected attack with the sword to deal %v damage, dealt %v", base+5, lost)
+		}
+	})
+}
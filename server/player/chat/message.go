@@ -0,0 +1,95 @@
+package chat
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+)
+
+// DefaultFormat is the format that chat messages are written in if no other
+// format is set. The first %v is replaced with the name of the sender and the
+// second %v with the text of the message.
+const DefaultFormat = "<%v> %v"
+
+// Message is a message sent to a Chat by a Subscriber, such as a player.
+// Before being written, a Message passes through the handler of its sender,
+// which may rewrite its text, change the format it is written in or route it
+// to specific recipients.
+type Message struct {
+	// Sender is the Subscriber that sent the message.
+	Sender Subscriber
+	// Name is the name of the sender as shown in the message.
+	Name string
+	// Text is the text of the message as written by the sender.
+	Text string
+	// Format is the format used to produce the line sent to recipients. It
+	// should contain two %v verbs, which are replaced with Name and Text
+	// respectively. Format accepts the colouring formats of text.Colourf. If
+	// empty, DefaultFormat is used.
+	Format string
+	// Recipients is the list of Subscribers that the message is sent to. If
+	// nil, the message is sent to all subscribers of the Chat it is written
+	// to. Subscribers that are listed multiple times, including the sender,
+	// only receive the message once.
+	Recipients []Subscriber
+	// Raw is an optional rich-text version of the message. If not empty, it
+	// is sent instead of the formatted line to recipients that implement
+	// RawTextSubscriber.
+	Raw RawText
+}
+
+// String returns the line that the Message is written as, which is its Name
+// and Text filled out in its Format.
+func (m Message) String() string {
+	if m.Format == "" {
+		return fmt.Sprintf(DefaultFormat, m.Name, m.Text)
+	}
+	// Only the format is coloured, so that colour tags in the name or text
+	// of the message are not parsed.
+	return fmt.Sprintf(text.Colourf("%s", m.Format), m.Name, m.Text)
+}
+
+// send sends the Message to a Subscriber. Subscribers implementing
+// RawTextSubscriber receive the raw text of the Message if it has any.
+func (m Message) send(s Subscriber) {
+	if raw, ok := s.(RawTextSubscriber); ok && len(m.Raw) > 0 {
+		raw.MessageRaw(m.Raw)
+		return
+	}
+	s.Message(m.String())
+}
+
+// WriteMessage writes a Message to the chat. If the Recipients of the Message
+// are set, the Message is sent only to them, regardless of whether they are
+// subscribed to the chat. Every recipient receives the Message at most once.
+func (chat *Chat) WriteMessage(m Message) {
+	chat.m.Lock()
+	defer chat.m.Unlock()
+	if m.Recipients == nil {
+		for _, subscriber := range chat.subscribers {
+			m.send(subscriber)
+		}
+		return
+	}
+	sent := make(map[uuid.UUID]struct{}, len(m.Recipients))
+	for _, subscriber := range m.Recipients {
+		if _, ok := sent[subscriber.UUID()]; ok {
+			continue
+		}
+		sent[subscriber.UUID()] = struct{}{}
+		m.send(subscriber)
+	}
+}
+
+// Subscribers returns a list of all Subscribers currently subscribed to the
+// chat. It may be used as a starting point to route a Message to specific
+// recipients.
+func (chat *Chat) Subscribers() []Subscriber {
+	chat.m.Lock()
+	defer chat.m.Unlock()
+	subscribers := make([]Subscriber, 0, len(chat.subscribers))
+	for _, subscriber := range chat.subscribers {
+		subscribers = append(subscribers, subscriber)
+	}
+	return subscribers
+}
@@ -0,0 +1,128 @@
+package chat
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// RawText is a rich-text message in the JSON raw text format of Minecraft:
+// Bedrock Edition. A RawText is made up of components, which are rendered
+// one after another by the client. Unlike regular messages, RawText may hold
+// translations and entity selectors that are resolved by the client itself.
+// Note that Bedrock Edition clients do not support the click and hover events
+// of Java Edition's JSON text, so RawText does not have them either.
+type RawText []RawComponent
+
+// RawComponent is a single component of a RawText. Exactly one of its fields
+// should be set.
+type RawComponent struct {
+	// Text is plain text shown as is. It may contain formatting codes.
+	Text string `json:"text,omitempty"`
+	// Translate is a translation key resolved by the client, such as
+	// 'tile.respawn_anchor.respawnSet'. The parameters of the translation
+	// are filled out using With.
+	Translate string `json:"translate,omitempty"`
+	// With holds the parameters of the translation in Translate.
+	With RawText `json:"with,omitempty"`
+	// Selector is an entity selector, such as '@p', that is replaced with the
+	// names of the entities it selects.
+	Selector string `json:"selector,omitempty"`
+	// Score shows the score of an entity on a scoreboard objective.
+	Score *RawScore `json:"score,omitempty"`
+}
+
+// RawScore is a score shown in a RawComponent.
+type RawScore struct {
+	// Name is the name or selector of the entity whose score is shown.
+	Name string `json:"name"`
+	// Objective is the scoreboard objective that the score is taken from.
+	Objective string `json:"objective"`
+}
+
+// Text returns a RawText holding a single component with the plain text
+// passed.
+func Text(s string) RawText {
+	return RawText{{Text: s}}
+}
+
+// Translated returns a RawText holding a single component that translates
+// the key passed on the client, filling out its parameters with the plain
+// text values passed.
+func Translated(key string, with ...string) RawText {
+	c := RawComponent{Translate: key}
+	for _, w := range with {
+		c.With = append(c.With, RawComponent{Text: w})
+	}
+	return RawText{c}
+}
+
+// Selector returns a RawText holding a single component showing the names
+// of the entities selected by the entity selector passed.
+func Selector(selector string) RawText {
+	return RawText{{Selector: selector}}
+}
+
+// Append returns a RawText with the components of all RawTexts passed added
+// after those of r.
+func (r RawText) Append(texts ...RawText) RawText {
+	res := append(RawText(nil), r...)
+	for _, t := range texts {
+		res = append(res, t...)
+	}
+	return res
+}
+
+// String returns the plain text of the RawText, which may be used for
+// recipients that cannot display raw text. Translations, selectors and
+// scores are not resolved and are shown by their keys.
+func (r RawText) String() string {
+	var sb strings.Builder
+	for _, c := range r {
+		switch {
+		case c.Translate != "":
+			sb.WriteString(c.Translate)
+			if len(c.With) > 0 {
+				params := make([]string, len(c.With))
+				for i, w := range c.With {
+					params[i] = RawText{w}.String()
+				}
+				sb.WriteString("(" + strings.Join(params, ", ") + ")")
+			}
+		case c.Selector != "":
+			sb.WriteString(c.Selector)
+		case c.Score != nil:
+			sb.WriteString(c.Score.Name + ":" + c.Score.Objective)
+		default:
+			sb.WriteString(c.Text)
+		}
+	}
+	return sb.String()
+}
+
+// MarshalJSON encodes the RawText in the JSON format understood by the
+// client, which wraps the list of components in a 'rawtext' object.
+func (r RawText) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		RawText []RawComponent `json:"rawtext"`
+	}{RawText: r})
+}
+
+// UnmarshalJSON decodes a RawText from the JSON format understood by the
+// client.
+func (r *RawText) UnmarshalJSON(b []byte) error {
+	var v struct {
+		RawText []RawComponent `json:"rawtext"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*r = v.RawText
+	return nil
+}
+
+// RawTextSubscriber is a Subscriber that is able to display RawText.
+type RawTextSubscriber interface {
+	Subscriber
+	// MessageRaw sends a RawText message to the RawTextSubscriber.
+	MessageRaw(r RawText)
+}
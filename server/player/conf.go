@@ -3,6 +3,7 @@ package player
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/player/skin"
@@ -44,7 +45,19 @@ type Config struct {
 	EnderChestInventory    *inventory.Inventory
 	FireTicks              int64
 	FallDistance           float64
+	TicksSinceRest         int64
+	WardenWarningLevel     int
 	Effects                []effect.Effect
+	// Attributes holds the attributes of the player and the modifiers applied
+	// to them. If nil, all attributes start at their default values. The base
+	// maximum health is always taken from MaxHealth.
+	Attributes *entity.Attributes
+	// AttackKnockBack is the KnockBack that the player deals to entities it
+	// attacks. If left empty, DefaultKnockBack is used.
+	AttackKnockBack KnockBack
+	// LatencyPolicy decides when the player is disconnected for having a high
+	// latency. If left empty, the player is never disconnected for it.
+	LatencyPolicy LatencyPolicy
 }
 
 // Apply applies fields from a Config to a world.EntityData, filling out empty
@@ -54,6 +67,14 @@ func (cfg Config) Apply(data *world.EntityData) {
 
 	data.Name, data.Pos, data.Rot = conf.Name, conf.Position, conf.Rotation
 	slot := uint32(conf.HeldSlot)
+	attrs := conf.Attributes
+	if attrs == nil {
+		attrs = entity.NewAttributes()
+	}
+	attrs.SetBase(attribute.MaxHealth(), conf.MaxHealth)
+	// The player is not sprinting when it is created, so a sprinting modifier
+	// saved with its attributes should not carry over.
+	attrs.RemoveModifier(attribute.MovementSpeed(), sprintModifierID)
 	pdata := &playerData{
 		xuid:                conf.XUID,
 		ui:                  inventory.New(54, nil),
@@ -62,7 +83,8 @@ func (cfg Config) Apply(data *world.EntityData) {
 		offHand:             conf.OffHand,
 		armour:              conf.Armour,
 		hunger:              newHungerManager(),
-		health:              entity.NewHealthManager(conf.Health, conf.MaxHealth), // 20, 20
+		health:              entity.NewHealthManager(conf.Health, attrs.Value(attribute.MaxHealth())),
+		attributes:          attrs,
 		experience:          entity.NewExperienceManager(),
 		effects:             entity.NewEffectManager(conf.Effects...),
 		locale:              conf.Locale,
@@ -74,9 +96,10 @@ func (cfg Config) Apply(data *world.EntityData) {
 		enchantSeed:         conf.EnchantmentSeed,
 		s:                   conf.Session,
 		h:                   NopHandler{},
-		speed:               0.1,
 		flightSpeed:         0.05,
 		verticalFlightSpeed: 1.0,
+		attackKnockBack:     conf.AttackKnockBack,
+		latencyPolicy:       conf.LatencyPolicy,
 		scale:               1.0,
 		airSupplyTicks:      conf.AirSupply,
 		maxAirSupplyTicks:   conf.MaxAirSupply,
@@ -84,6 +107,10 @@ func (cfg Config) Apply(data *world.EntityData) {
 		nameTag:             conf.Name,
 		fireTicks:           conf.FireTicks,
 		fallDistance:        conf.FallDistance,
+		ticksSinceRest:      conf.TicksSinceRest,
+		wardenWarningLevel:  conf.WardenWarningLevel,
+		phantomSpawnTicks:   1200 + rand.IntN(1200),
+		patrolSpawnTicks:    12000 + rand.IntN(1200),
 	}
 	pdata.hunger.foodLevel, pdata.hunger.foodTick, pdata.hunger.exhaustionLevel, pdata.hunger.saturationLevel = conf.Food, conf.FoodTick, conf.Exhaustion, conf.Saturation
 	pdata.experience.Add(conf.Experience)
@@ -119,6 +146,9 @@ func fillDefaults(conf Config) Config {
 	if conf.MaxHealth == 0 {
 		conf.MaxHealth, conf.Health = 20, 20
 	}
+	if conf.AttackKnockBack == (KnockBack{}) {
+		conf.AttackKnockBack = DefaultKnockBack()
+	}
 	if conf.GameMode == nil {
 		conf.GameMode = world.GameModeSurvival
 	}
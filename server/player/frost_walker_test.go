@@ -0,0 +1,42 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestFrostWalkerFreezesWater(t *testing.T) {
+	for _, enchanted := range []bool{false, true} {
+		withPlayer(t, Config{}, func(tx *world.Tx, p *Player) {
+			// The player stands on a single block of stone in a lake of
+			// water.
+			below := cube.PosFromVec3(p.Position()).Side(cube.FaceDown)
+			for x := -6; x <= 6; x++ {
+				for z := -6; z <= 6; z++ {
+					tx.SetBlock(below.Add(cube.Pos{x, 0, z}), block.Water{Still: true, Depth: 8}, nil)
+				}
+			}
+			tx.SetBlock(below, block.Stone{}, nil)
+
+			boots := item.NewStack(item.Boots{Tier: item.ArmourTierDiamond{}}, 1)
+			if enchanted {
+				boots = boots.WithEnchantments(item.NewEnchantment(enchantment.FrostWalker, 2))
+			}
+			p.Armour().SetBoots(boots)
+			p.Tick(tx, 0)
+			p.Move(mgl64.Vec3{0.2, 0, 0}, 0, 0)
+
+			_, near := tx.Block(below.Add(cube.Pos{3, 0, 0})).(block.FrostedIce)
+			_, far := tx.Block(below.Add(cube.Pos{5, 0, 0})).(block.FrostedIce)
+			if near != enchanted || far {
+				t.Errorf("expected water within 4 blocks to freeze only with Frost Walker II (enchanted: %v), got ice nearby: %v, ice far away: %v", enchanted, near, far)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// walk has the player passed walk 2 blocks along the x-axis on a floor of
+// stone below it.
+func walk(tx *world.Tx, p *Player) {
+	for x := -2; x <= 4; x++ {
+		tx.SetBlock(cube.PosFromVec3(p.Position()).Add(cube.Pos{x, -1}), block.Stone{}, nil)
+	}
+	// Players without a session are only on the ground after their movement
+	// was ticked.
+	p.Tick(tx, 0)
+	for range 10 {
+		p.Move(mgl64.Vec3{0.2, 0, 0}, 0, 0)
+	}
+}
+
+func TestFootstepsActivateSculkSensor(t *testing.T) {
+	withPlayer(t, Config{}, func(tx *world.Tx, p *Player) {
+		pos := cube.PosFromVec3(p.Position()).Add(cube.Pos{4, 0, 4})
+		tx.SetBlock(pos, block.SculkSensor{}, nil)
+		walk(tx, p)
+		if s := tx.Block(pos).(block.SculkSensor); s.Phase != block.SculkSensorActive() || s.Power == 0 {
+			t.Errorf("expected footsteps to activate the sculk sensor, got %#v", s)
+		}
+	})
+}
+
+func TestSneakingDoesNotActivateSculkSensor(t *testing.T) {
+	withPlayer(t, Config{}, func(tx *world.Tx, p *Player) {
+		pos := cube.PosFromVec3(p.Position()).Add(cube.Pos{4, 0, 4})
+		tx.SetBlock(pos, block.SculkSensor{}, nil)
+		p.StartSneaking()
+		walk(tx, p)
+		if s := tx.Block(pos).(block.SculkSensor); s.Phase != block.SculkSensorInactive() {
+			t.Errorf("expected sneaking not to activate the sculk sensor, got %#v", s)
+		}
+	})
+}
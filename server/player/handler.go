@@ -3,8 +3,11 @@ package player
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
@@ -34,9 +37,9 @@ type Handler interface {
 	// After is true if the player is sneaking after toggling (changing their sneaking state).
 	HandleToggleSneak(ctx *Context, after bool)
 	// HandleChat handles a message sent in the chat by a player. ctx.Cancel() may be called to cancel the
-	// message being sent in chat.
-	// The message may be changed by assigning to *message.
-	HandleChat(ctx *Context, message *string)
+	// message being sent in chat, in which case it is not sent to anyone, including the player itself.
+	// The text, format and recipients of the message may be changed through the fields of *message.
+	HandleChat(ctx *Context, message *chat.Message)
 	// HandleFoodLoss handles the food bar of a player depleting naturally, for example because the player was
 	// sprinting and jumping. ctx.Cancel() may be called to cancel the food points being lost.
 	HandleFoodLoss(ctx *Context, from int, to *int)
@@ -52,6 +55,13 @@ type Handler interface {
 	// the original cause of the immunity frame. In this case, the damage is
 	// reduced but the player is still knocked back.
 	HandleHurt(ctx *Context, damage *float64, immune bool, attackImmunity *time.Duration, src world.DamageSource)
+	// HandleProjectileHit handles the player being about to be hit by a
+	// projectile, before the projectile has any effect on the player.
+	// ctx.Cancel() may be called to cancel the hit, after which the projectile
+	// flies on as if it missed the player. The damage dealt may be changed
+	// through hit, and the projectile may be deflected by calling
+	// hit.Deflect().
+	HandleProjectileHit(ctx *Context, projectile *entity.Ent, hit *entity.ProjectileHit)
 	// HandleDeath handles the player dying to a particular damage cause.
 	HandleDeath(p *Player, src world.DamageSource, keepInv *bool)
 	// HandleRespawn handles the respawning of the player in the world. The spawn position passed may be
@@ -120,10 +130,22 @@ type Handler interface {
 	HandlePunchAir(ctx *Context)
 	// HandleSignEdit handles the player editing a sign. It is called for every keystroke while editing a sign and
 	// has both the old text passed and the text after the edit. This typically only has a change of one character.
-	HandleSignEdit(ctx *Context, pos cube.Pos, frontSide bool, oldText, newText string)
+	// The new text may be changed by assigning to *newText, for example to filter or censor it.
+	HandleSignEdit(ctx *Context, pos cube.Pos, frontSide bool, oldText string, newText *string)
 	// HandleLecternPageTurn handles the player turning a page in a lectern. ctx.Cancel() may be called to cancel the
 	// page turn. The page number may be changed by assigning to *page.
 	HandleLecternPageTurn(ctx *Context, pos cube.Pos, oldPage int, newPage *int)
+	// HandleContainerOpen handles the player opening a container, such as a chest, a crafting table, the
+	// inventory of a chest minecart or a container opened using Player.OpenContainer. inv is the inventory of
+	// the container, or nil if the container does not hold items of its own, like a crafting table. pos is the
+	// position of the block or entity of the container. ctx.Cancel() may be called to prevent the container
+	// from being opened.
+	HandleContainerOpen(ctx *Context, inv *inventory.Inventory, pos cube.Pos)
+	// HandleContainerClose handles the player closing the container that it had opened. inv and pos are the
+	// same as those passed to HandleContainerOpen. ctx.Cancel() may be called to open the container again if
+	// the player closed it itself. A container that is closed because the player opened another one, moved
+	// away from it, changed worlds or disconnected cannot be kept open.
+	HandleContainerClose(ctx *Context, inv *inventory.Inventory, pos cube.Pos)
 	// HandleItemDamage handles the event wherein the item either held by the player or as armour takes
 	// damage through usage.
 	// The type of the item may be checked to determine whether it was armour or a tool used. The damage to
@@ -138,12 +160,23 @@ type Handler interface {
 	// ctx.Cancel() may be called to prevent the player from dropping the entity.Item passed on the ground.
 	// e.Item() may be called to obtain the item stack dropped.
 	HandleItemDrop(ctx *Context, s item.Stack)
+	// HandleItemMove handles the player moving an item stack from one inventory slot to another, for example by
+	// clicking or shift-clicking it into another container. It is moved from srcSlot in src to dstSlot in dst.
+	// The stack in the destination slot may be obtained using dst.Item(dstSlot). ctx.Cancel() may be called to
+	// cancel the move, after which the client is resent the contents of both inventories.
+	HandleItemMove(ctx *Context, it item.Stack, src *inventory.Inventory, srcSlot int, dst *inventory.Inventory, dstSlot int)
 	// HandleTransfer handles a player being transferred to another server. ctx.Cancel() may be called to
 	// cancel the transfer.
 	HandleTransfer(ctx *Context, addr *net.UDPAddr)
 	// HandleCommandExecution handles the command execution of a player, who wrote a command in the chat.
 	// ctx.Cancel() may be called to cancel the command execution.
 	HandleCommandExecution(ctx *Context, command cmd.Command, args []string)
+	// HandleHighLatency handles the latency of a player being higher than the
+	// threshold of its LatencyPolicy for the duration of the policy.
+	// ctx.Cancel() may be called to prevent the player from being
+	// disconnected. If cancelled, HandleHighLatency is called again if the
+	// latency stays high for another duration of the policy.
+	HandleHighLatency(ctx *Context, latency time.Duration)
 	// HandleQuit handles the closing of a player. It is always called when the player is disconnected,
 	// regardless of the reason.
 	HandleQuit(p *Player)
@@ -171,16 +204,18 @@ func (NopHandler) HandleToggleSprint(*Context, bool)
 func (NopHandler) HandleToggleSneak(*Context, bool)                                        {}
 func (NopHandler) HandleCommandExecution(*Context, cmd.Command, []string)                  {}
 func (NopHandler) HandleTransfer(*Context, *net.UDPAddr)                                   {}
-func (NopHandler) HandleChat(*Context, *string)                                            {}
+func (NopHandler) HandleChat(*Context, *chat.Message)                                      {}
 func (NopHandler) HandleSkinChange(*Context, *skin.Skin)                                   {}
 func (NopHandler) HandleFireExtinguish(*Context, cube.Pos)                                 {}
 func (NopHandler) HandleStartBreak(*Context, cube.Pos)                                     {}
 func (NopHandler) HandleBlockBreak(*Context, cube.Pos, *[]item.Stack, *int)                {}
 func (NopHandler) HandleBlockPlace(*Context, cube.Pos, world.Block)                        {}
 func (NopHandler) HandleBlockPick(*Context, cube.Pos, world.Block)                         {}
-func (NopHandler) HandleSignEdit(*Context, cube.Pos, bool, string, string)                 {}
+func (NopHandler) HandleSignEdit(*Context, cube.Pos, bool, string, *string)                {}
 func (NopHandler) HandleLecternPageTurn(*Context, cube.Pos, int, *int)                     {}
 func (NopHandler) HandleItemPickup(*Context, *item.Stack)                                  {}
+func (NopHandler) HandleContainerOpen(*Context, *inventory.Inventory, cube.Pos)            {}
+func (NopHandler) HandleContainerClose(*Context, *inventory.Inventory, cube.Pos)           {}
 func (NopHandler) HandleItemUse(*Context)                                                  {}
 func (NopHandler) HandleItemUseOnBlock(*Context, cube.Pos, cube.Face, mgl64.Vec3)          {}
 func (NopHandler) HandleItemUseOnEntity(*Context, world.Entity)                            {}
@@ -193,7 +228,11 @@ func (NopHandler) HandlePunchAir(*Context)
 func (NopHandler) HandleHurt(*Context, *float64, bool, *time.Duration, world.DamageSource) {}
 func (NopHandler) HandleHeal(*Context, *float64, world.HealingSource)                      {}
 func (NopHandler) HandleFoodLoss(*Context, int, *int)                                      {}
+func (NopHandler) HandleProjectileHit(*Context, *entity.Ent, *entity.ProjectileHit)        {}
 func (NopHandler) HandleDeath(*Player, world.DamageSource, *bool)                          {}
 func (NopHandler) HandleRespawn(*Player, *mgl64.Vec3, **world.World)                       {}
+func (NopHandler) HandleHighLatency(*Context, time.Duration)                               {}
 func (NopHandler) HandleQuit(*Player)                                                      {}
 func (NopHandler) HandleDiagnostics(*Player, session.Diagnostics)                          {}
+func (NopHandler) HandleItemMove(*Context, item.Stack, *inventory.Inventory, int, *inventory.Inventory, int) {
+}
@@ -27,6 +27,14 @@ func (m *hungerManager) Food() int {
 	return m.foodLevel
 }
 
+// Saturation returns the current saturation level of a player. The level
+// returned is always between 0 and the food level of the player.
+func (m *hungerManager) Saturation() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.saturationLevel
+}
+
 // SetFood sets the food level of a player. The level passed must be in a range of 0-20. If the level passed
 // is negative, the food level will be set to 0. If the level exceeds 20, the food level will be set to 20.
 func (m *hungerManager) SetFood(level int) {
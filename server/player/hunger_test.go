@@ -0,0 +1,50 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// withPlayer runs f with a Player, created with the Config passed, in a new
+// World.
+func withPlayer(t *testing.T, conf Config, f func(tx *world.Tx, p *Player)) {
+	t.Helper()
+	w := world.Config{}.New()
+	defer w.Close()
+
+	conf.Name = "test"
+	<-w.Exec(func(tx *world.Tx) {
+		opts := world.EntitySpawnOpts{Position: mgl64.Vec3{0, 64, 0}}
+		f(tx, tx.AddEntity(opts.New(Type, conf)).(*Player))
+	})
+}
+
+func TestQuickRegenerationHealsBySaturation(t *testing.T) {
+	conf := Config{Health: 10, MaxHealth: 20, Food: 20, Saturation: 3, FoodTick: 20}
+	withPlayer(t, conf, func(tx *world.Tx, p *Player) {
+		p.tickFood()
+		if p.Health() != 10.5 {
+			t.Errorf("expected quick regeneration with 3 saturation to heal 0.5, health is %v", p.Health())
+		}
+	})
+
+	conf.Saturation = 20
+	withPlayer(t, conf, func(tx *world.Tx, p *Player) {
+		p.tickFood()
+		if p.Health() != 11 {
+			t.Errorf("expected quick regeneration with 20 saturation to heal 1, health is %v", p.Health())
+		}
+	})
+}
+
+func TestRegenerationHealsOne(t *testing.T) {
+	conf := Config{Health: 10, MaxHealth: 20, Food: 18, FoodTick: 1}
+	withPlayer(t, conf, func(tx *world.Tx, p *Player) {
+		p.tickFood()
+		if p.Health() != 11 {
+			t.Errorf("expected regeneration to heal 1, health is %v", p.Health())
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+)
+
+// KnockBack holds the parameters of the knock back that a Player deals to the
+// entities it attacks. The values are combined into a horizontal force and a
+// height, which are then passed to the KnockBack method of the entity
+// attacked. The entity attacked reduces both by its knock back resistance, so
+// the resistance always applies to the combined knock back.
+type KnockBack struct {
+	// Force is the base horizontal force of the knock back and Height its
+	// base vertical force.
+	Force, Height float64
+	// SprintForce and SprintHeight are added to Force and Height if the
+	// player is sprinting when it attacks.
+	SprintForce, SprintHeight float64
+	// EnchantmentForce and EnchantmentHeight are added to Force and Height
+	// for every level of the Knockback enchantment on the item used to
+	// attack.
+	EnchantmentForce, EnchantmentHeight float64
+}
+
+// DefaultKnockBack returns the KnockBack that players deal if no other
+// KnockBack is set.
+func DefaultKnockBack() KnockBack {
+	return KnockBack{
+		Force:             0.45,
+		Height:            0.3608,
+		SprintForce:       0.5,
+		SprintHeight:      0.1,
+		EnchantmentForce:  enchantment.Knockback.Force(1),
+		EnchantmentHeight: enchantment.Knockback.Force(1),
+	}
+}
+
+// Base returns the horizontal force and height of the knock back dealt by an
+// attack before the Knockback enchantment is applied. Sprinting specifies if
+// the attacker is sprinting.
+func (kb KnockBack) Base(sprinting bool) (force, height float64) {
+	if sprinting {
+		return kb.Force + kb.SprintForce, kb.Height + kb.SprintHeight
+	}
+	return kb.Force, kb.Height
+}
+
+// Enchanted adds the knock back of the Knockback enchantment on the item
+// stack passed, if any, to the force and height passed.
+func (kb KnockBack) Enchanted(s item.Stack, force, height float64) (float64, float64) {
+	if k, ok := s.Enchantment(enchantment.Knockback); ok {
+		force += kb.EnchantmentForce * float64(k.Level())
+		height += kb.EnchantmentHeight * float64(k.Level())
+	}
+	return force, height
+}
@@ -0,0 +1,40 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestSprintAttackKnockBack(t *testing.T) {
+	withPlayer(t, Config{Food: 20}, func(tx *world.Tx, p *Player) {
+		attack := func(name string) mgl64.Vec3 {
+			conf := Config{Name: name, Position: p.Position().Add(mgl64.Vec3{1, 0, 0})}
+			target := tx.AddEntity(world.EntitySpawnOpts{Position: conf.Position}.New(Type, conf)).(*Player)
+			if !p.AttackEntity(target) {
+				t.Errorf("expected attack on %v to succeed", name)
+			}
+			return target.Velocity()
+		}
+
+		kb := DefaultKnockBack()
+		if v, expected := attack("walking"), (mgl64.Vec3{kb.Force, kb.Height, 0}); !v.ApproxEqual(expected) {
+			t.Errorf("expected attack while walking to knock back with %v, got %v", expected, v)
+		}
+		p.StartSprinting()
+		if !p.Sprinting() {
+			t.Errorf("expected player to be sprinting")
+			return
+		}
+		if v, expected := attack("sprinting"), (mgl64.Vec3{kb.Force + kb.SprintForce, kb.Height + kb.SprintHeight, 0}); !v.ApproxEqual(expected) {
+			t.Errorf("expected attack while sprinting to knock back with %v, got %v", expected, v)
+		}
+
+		p.SetAttackKnockBack(KnockBack{Force: 0.2, Height: 0.2})
+		p.StartSprinting()
+		if v, expected := attack("configured"), (mgl64.Vec3{0.2, 0.2, 0}); !v.ApproxEqual(expected) {
+			t.Errorf("expected attack with configured knock back to knock back with %v, got %v", expected, v)
+		}
+	})
+}
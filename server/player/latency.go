@@ -0,0 +1,88 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/session"
+	"time"
+)
+
+// LatencyPolicy decides when a Player is disconnected for having a high
+// latency for a sustained period of time. The zero value of LatencyPolicy
+// never disconnects players.
+type LatencyPolicy struct {
+	// Threshold is the latency above which the latency of a player is
+	// considered high. If 0, the latency of players is never considered high.
+	Threshold time.Duration
+	// Recovery is the latency that the latency of a player must drop below
+	// after it was high for it to be considered normal again. Because
+	// Recovery is lower than Threshold, a latency fluctuating around the
+	// Threshold does not repeatedly reset the time it was high for. If 0,
+	// Recovery is set to 80% of the Threshold.
+	Recovery time.Duration
+	// Duration is the time that the latency of a player must be high for
+	// before Handler.HandleHighLatency is called and the player is
+	// disconnected. If 0, Duration is set to 10 seconds.
+	Duration time.Duration
+	// Message is the message that players are disconnected with. If empty,
+	// a default message is used.
+	Message string
+}
+
+// withDefaults returns the LatencyPolicy with its empty fields set to their
+// defaults.
+func (pol LatencyPolicy) withDefaults() LatencyPolicy {
+	if pol.Recovery == 0 {
+		pol.Recovery = pol.Threshold * 4 / 5
+	}
+	if pol.Duration == 0 {
+		pol.Duration = time.Second * 10
+	}
+	if pol.Message == "" {
+		pol.Message = "Your connection is too slow."
+	}
+	return pol
+}
+
+// SetLatencyPolicy changes the LatencyPolicy that decides when the player is
+// disconnected for having a high latency.
+func (p *Player) SetLatencyPolicy(pol LatencyPolicy) {
+	p.latencyPolicy = pol
+	p.latencyHigh, p.latencyHighFor = false, 0
+}
+
+// LatencyPolicy returns the LatencyPolicy that decides when the player is
+// disconnected for having a high latency.
+func (p *Player) LatencyPolicy() LatencyPolicy {
+	return p.latencyPolicy
+}
+
+// tickLatency checks the latency of the player against its LatencyPolicy once
+// every second. If the latency was high for the Duration of the policy,
+// Handler.HandleHighLatency is called and the player is disconnected unless
+// the event is cancelled.
+func (p *Player) tickLatency(current int64) {
+	if p.latencyPolicy.Threshold <= 0 || current%20 != 0 || p.session() == session.Nop {
+		return
+	}
+	pol, latency := p.latencyPolicy.withDefaults(), p.Latency()
+	switch {
+	case latency > pol.Threshold:
+		p.latencyHigh = true
+	case latency < pol.Recovery:
+		p.latencyHigh, p.latencyHighFor = false, 0
+	}
+	if !p.latencyHigh {
+		return
+	}
+	if p.latencyHighFor += time.Second; p.latencyHighFor < pol.Duration {
+		return
+	}
+	// The time is reset, so that the handler is only called again if the
+	// latency stays high for another Duration.
+	p.latencyHighFor = 0
+	ctx := event.C(p)
+	if p.Handler().HandleHighLatency(ctx, latency); ctx.Cancelled() {
+		return
+	}
+	p.Disconnect(pol.Message)
+}
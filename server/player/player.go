@@ -17,6 +17,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/model"
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/item"
@@ -31,6 +32,7 @@ import (
 	"github.com/df-mc/dragonfly/server/player/title"
 	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
 	"github.com/df-mc/dragonfly/server/world/particle"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
@@ -42,6 +44,7 @@ type playerData struct {
 	xuid              string
 	locale            language.Tag
 	nameTag, scoreTag string
+	nameTagHidden     bool
 	absorptionHealth  float64
 	scale             float64
 
@@ -61,6 +64,24 @@ type playerData struct {
 	glideTicks   int64
 	fireTicks    int64
 	fallDistance float64
+	// stepDistance is the horizontal distance walked by the player since it
+	// last emitted a gameevent.Step.
+	stepDistance float64
+	// ticksSinceRest is the amount of ticks that have passed since the player
+	// last slept or died. phantomSpawnTicks is the amount of ticks until the
+	// next attempt to spawn phantoms around the player.
+	ticksSinceRest    int64
+	phantomSpawnTicks int
+	// patrolSpawnTicks is the amount of ticks until the next attempt to spawn
+	// a pillager patrol near the player.
+	patrolSpawnTicks int
+	// wardenWarningLevel is the amount of times the player recently triggered
+	// a sculk shrieker. wardenWarningTicks is the amount of ticks that have
+	// passed since the warning level last changed, and wardenWarningCooldown
+	// the amount of ticks until it may be increased again.
+	wardenWarningLevel    int
+	wardenWarningTicks    int64
+	wardenWarningCooldown int
 
 	breathing         bool
 	airSupplyTicks    int
@@ -68,11 +89,17 @@ type playerData struct {
 
 	cooldowns map[string]time.Time
 
-	speed               float64
 	flightSpeed         float64
 	verticalFlightSpeed float64
 
+	attackKnockBack KnockBack
+
+	latencyPolicy  LatencyPolicy
+	latencyHigh    bool
+	latencyHighFor time.Duration
+
 	health     *entity.HealthManager
+	attributes *entity.Attributes
 	experience *entity.ExperienceManager
 	effects    *entity.EffectManager
 
@@ -236,6 +263,13 @@ func (p *Player) Messaget(t chat.Translation, a ...any) {
 	p.session().SendTranslation(t, p.locale, a)
 }
 
+// MessageRaw sends a rich-text message to the player. Unlike messages sent
+// using Message, a chat.RawText may hold translations and entity selectors
+// that are resolved by the client of the player.
+func (p *Player) MessageRaw(r chat.RawText) {
+	p.session().SendRawText(r)
+}
+
 // SendPopup sends a formatted popup to the player. The popup is shown above the hotbar of the player and
 // overwrites/is overwritten by the name of the item equipped.
 // The popup is formatted following the rules of fmt.Sprintln without a newline at the end.
@@ -271,6 +305,40 @@ func (p *Player) FallDistance() float64 {
 	return p.fallDistance
 }
 
+// SleepTimer returns the time that has passed since the player last slept or
+// died. Phantoms start spawning around players that have not slept for three
+// in-game days.
+func (p *Player) SleepTimer() time.Duration {
+	return time.Duration(p.ticksSinceRest) * time.Second / 20
+}
+
+// ResetSleepTimer resets the time since the player last slept, as happens
+// when the player sleeps in a bed.
+func (p *Player) ResetSleepTimer() {
+	p.ticksSinceRest = 0
+}
+
+// WardenWarningLevel returns the warning level of the player, ranging from 0
+// to 4. The warning level increases every time the player triggers a sculk
+// shrieker and decreases by one every ten minutes. Sculk shriekers summon a
+// warden once the warning level reaches 4.
+func (p *Player) WardenWarningLevel() int {
+	return p.wardenWarningLevel
+}
+
+// WardenWarnedRecently checks if the warning level of the player was
+// increased less than ten seconds ago, in which case it cannot be increased
+// again yet.
+func (p *Player) WardenWarnedRecently() bool {
+	return p.wardenWarningCooldown > 0
+}
+
+// IncreaseWardenWarningLevel increases the warning level of the player by
+// one, up to a maximum of 4.
+func (p *Player) IncreaseWardenWarningLevel() {
+	p.wardenWarningLevel, p.wardenWarningTicks, p.wardenWarningCooldown = min(p.wardenWarningLevel+1, 4), 0, 200
+}
+
 // SendTitle sends a title to the player. The title may be configured to change the duration it is displayed
 // and the text it shows.
 // If non-empty, the subtitle is shown in a smaller font below the title. The same counts for the action text
@@ -288,6 +356,28 @@ func (p *Player) SendTitle(t title.Title) {
 	}
 }
 
+// SetTitleDurations changes the durations of the title currently shown to the
+// player and of titles sent using SendTitle afterwards, similar to how the
+// /title times command works. Calling SendTitle with a title.Title overwrites
+// the durations set using SetTitleDurations.
+func (p *Player) SetTitleDurations(fadeIn, stay, fadeOut time.Duration) {
+	p.session().SetTitleDurations(fadeIn, stay, fadeOut)
+}
+
+// ClearTitle removes the title and subtitle currently shown to the player, if
+// any.
+func (p *Player) ClearTitle() {
+	p.session().ClearTitle()
+}
+
+// SendActionBar sends an action bar message to the player. The message is
+// shown above the hotbar of the player and is formatted following the rules of
+// fmt.Sprintln without a newline at the end. SendActionBar may be called every
+// tick to update the message without it flickering.
+func (p *Player) SendActionBar(a ...any) {
+	p.session().SendActionBarMessage(format(a))
+}
+
 // SendScoreboard sends a scoreboard to the player. The scoreboard will be present indefinitely until removed
 // by the caller.
 // SendScoreboard may be called at any time to change the scoreboard of the player.
@@ -315,14 +405,15 @@ func (p *Player) RemoveBossBar() {
 }
 
 // Chat writes a message in the global chat (chat.Global). The message is prefixed with the name of the
-// player and is formatted following the rules of fmt.Sprintln.
+// player and is formatted following the rules of fmt.Sprintln. The Handler of the player may change the
+// text, format and recipients of the message before it is written, or cancel it altogether.
 func (p *Player) Chat(msg ...any) {
-	message := format(msg)
+	message := chat.Message{Sender: p, Name: p.Name(), Text: format(msg)}
 	ctx := event.C(p)
 	if p.Handler().HandleChat(ctx, &message); ctx.Cancelled() {
 		return
 	}
-	_, _ = fmt.Fprintf(chat.Global, "<%v> %v\n", p.Name(), message)
+	chat.Global.WriteMessage(message)
 }
 
 // ExecuteCommand executes a command passed as the player. If the command could not be found, or if the usage
@@ -436,6 +527,56 @@ func (p *Player) NameTag() string {
 	return p.nameTag
 }
 
+// NameTagVisible checks if the name tag of the Player is shown to viewers. It is true unless changed using
+// SetNameTagVisible.
+func (p *Player) NameTagVisible() bool {
+	return !p.nameTagHidden
+}
+
+// SetNameTagVisible changes if the name tag of the Player is shown to viewers. Hiding the name tag does not
+// change it, so that the same name tag is shown again once it is made visible.
+func (p *Player) SetNameTagVisible(v bool) {
+	p.nameTagHidden = !v
+	p.updateState()
+}
+
+// Metadata returns the custom metadata set on the player using
+// SetMetadataFlag, SetMetadataInt and SetMetadataFloat.
+func (p *Player) Metadata() world.EntityMetadata {
+	return p.data.Metadata
+}
+
+// SetMetadataFlag overrides a flag in the metadata of the player, changing
+// how it is shown to the player and its viewers without changing its
+// behaviour.
+func (p *Player) SetMetadataFlag(flag world.EntityFlag, value bool) {
+	if p.data.Metadata.SetFlag(flag, value) {
+		p.updateState()
+	}
+}
+
+// SetMetadataInt overrides an integer value in the metadata of the player.
+func (p *Player) SetMetadataInt(key world.EntityIntKey, value int) {
+	if p.data.Metadata.SetInt(key, value) {
+		p.updateState()
+	}
+}
+
+// SetMetadataFloat overrides a floating point value in the metadata of the
+// player, such as its scale.
+func (p *Player) SetMetadataFloat(key world.EntityFloatKey, value float64) {
+	if p.data.Metadata.SetFloat(key, value) {
+		p.updateState()
+	}
+}
+
+// ResetMetadata removes all custom metadata set on the player.
+func (p *Player) ResetMetadata() {
+	if p.data.Metadata.Reset() {
+		p.updateState()
+	}
+}
+
 // SetScoreTag changes the score tag displayed over the player in-game. The score tag is displayed under the player's
 // name tag.
 func (p *Player) SetScoreTag(a ...any) {
@@ -449,17 +590,18 @@ func (p *Player) ScoreTag() string {
 	return p.scoreTag
 }
 
-// SetSpeed sets the speed of the player. The value passed is the blocks/tick speed that the player will then
-// obtain.
+// SetSpeed sets the base speed of the player. The value passed is the blocks/tick speed that the player will
+// obtain without any modifiers, such as those of sprinting or the Speed effect, applied.
 func (p *Player) SetSpeed(speed float64) {
-	p.speed = speed
-	p.session().SendSpeed(speed)
+	p.attributes.SetBase(attribute.MovementSpeed(), speed)
+	p.updateAttributes()
 }
 
-// Speed returns the speed of the player, returning a value that indicates the blocks/tick speed. The default
-// speed of a player is 0.1.
+// Speed returns the speed of the player, returning a value that indicates the blocks/tick speed. This speed
+// includes the modifiers applied to the movement speed of the player. The default base speed of a player is
+// 0.1.
 func (p *Player) Speed() float64 {
-	return p.speed
+	return p.attributes.Value(attribute.MovementSpeed())
 }
 
 // SetFlightSpeed sets the flight speed of the player. The value passed represents the base speed, which is
@@ -489,23 +631,77 @@ func (p *Player) VerticalFlightSpeed() float64 {
 	return p.verticalFlightSpeed
 }
 
+// SetAttackKnockBack changes the KnockBack that the player deals to entities it attacks.
+func (p *Player) SetAttackKnockBack(kb KnockBack) {
+	p.attackKnockBack = kb
+}
+
+// AttackKnockBack returns the KnockBack that the player deals to entities it attacks. By default, this is
+// DefaultKnockBack.
+func (p *Player) AttackKnockBack() KnockBack {
+	return p.attackKnockBack
+}
+
 // Health returns the current health of the player. It will always be lower than Player.MaxHealth().
 func (p *Player) Health() float64 {
 	return p.health.Health()
 }
 
-// MaxHealth returns the maximum amount of health that a player may have. The MaxHealth will always be higher
-// than Player.Health().
+// MaxHealth returns the maximum amount of health that a player may have, including modifiers such as those of
+// the Health Boost effect. The MaxHealth will always be higher than Player.Health().
 func (p *Player) MaxHealth() float64 {
 	return p.health.MaxHealth()
 }
 
-// SetMaxHealth sets the maximum health of the player. If the current health of the player is higher than the
-// new maximum health, the health is set to the new maximum.
+// SetMaxHealth sets the base maximum health of the player. If the current health of the player is higher than
+// the new maximum health, the health is set to the new maximum.
 // SetMaxHealth panics if the max health passed is 0 or lower.
 func (p *Player) SetMaxHealth(health float64) {
-	p.health.SetMaxHealth(health)
-	p.session().SendHealth(p.Health(), p.MaxHealth(), p.absorptionHealth)
+	p.attributes.SetBase(attribute.MaxHealth(), health)
+	p.updateAttributes()
+}
+
+// Attributes returns the attributes of the player, such as its maximum health and movement speed. Changes
+// made to the attributes directly are not sent to the player until one of SetSpeed, SetMaxHealth,
+// AddAttributeModifier or RemoveAttributeModifier is called.
+func (p *Player) Attributes() *entity.Attributes {
+	return p.attributes
+}
+
+// AddAttributeModifier applies an attribute.Modifier to an attribute of the player. A modifier with the same
+// ID previously applied to the attribute is replaced.
+func (p *Player) AddAttributeModifier(attr attribute.Attribute, m attribute.Modifier) {
+	p.attributes.AddModifier(attr, m)
+	p.updateAttributes()
+}
+
+// RemoveAttributeModifier removes the attribute.Modifier with the ID passed from an attribute of the player.
+func (p *Player) RemoveAttributeModifier(attr attribute.Attribute, id uuid.UUID) {
+	if p.attributes.RemoveModifier(attr, id) {
+		p.updateAttributes()
+	}
+}
+
+// updateAttributes updates the maximum health of the player to match its attributes and sends the attributes
+// to the player.
+func (p *Player) updateAttributes() {
+	if maxHealth := p.attributes.Value(attribute.MaxHealth()); maxHealth != p.health.MaxHealth() {
+		p.health.SetMaxHealth(maxHealth)
+		p.session().SendHealth(p.Health(), p.MaxHealth(), p.absorptionHealth)
+	}
+	p.session().SendAttributes(p.attributes)
+}
+
+// updateEquipmentAttributes replaces the attribute modifiers of the items equipped by the player with those
+// of the items it currently holds and wears.
+func (p *Player) updateEquipmentAttributes() {
+	mainHand, offHand := p.HeldItems()
+	p.attributes.ApplyEquipment(item.EquipmentSlotMainHand(), mainHand)
+	p.attributes.ApplyEquipment(item.EquipmentSlotOffHand(), offHand)
+	p.attributes.ApplyEquipment(item.EquipmentSlotHelmet(), p.armour.Helmet())
+	p.attributes.ApplyEquipment(item.EquipmentSlotChestplate(), p.armour.Chestplate())
+	p.attributes.ApplyEquipment(item.EquipmentSlotLeggings(), p.armour.Leggings())
+	p.attributes.ApplyEquipment(item.EquipmentSlotBoots(), p.armour.Boots())
 }
 
 // addHealth adds health to the player's current health.
@@ -544,6 +740,91 @@ func (p *Player) updateFallState(distanceThisTick float64) {
 	}
 }
 
+// updateStepState emits a gameevent.Step every time the player has walked far
+// enough on the ground without sneaking.
+func (p *Player) updateStepState(distanceThisTick float64) {
+	if !p.OnGround() || p.Sneaking() || p.Flying() {
+		return
+	}
+	if p.stepDistance += distanceThisTick * 0.6; p.stepDistance >= 1 {
+		p.stepDistance = 0
+		p.tx.EmitGameEvent(p.Position(), gameevent.Step{}, p)
+	}
+}
+
+// updateFrostWalker freezes water below the player into frosted ice if the player is walking while wearing
+// boots enchanted with Frost Walker.
+func (p *Player) updateFrostWalker() {
+	e, ok := p.armour.Boots().Enchantment(enchantment.FrostWalker)
+	if !ok || !p.OnGround() || p.Flying() {
+		return
+	}
+	r := enchantment.FrostWalker.Radius(e.Level())
+	pos := p.Position()
+	below := cube.PosFromVec3(pos).Side(cube.FaceDown)
+	for x := -r; x <= r; x++ {
+		for z := -r; z <= r; z++ {
+			bPos := below.Add(cube.Pos{x, 0, z})
+			centre := bPos.Vec3Centre()
+			if bPos.OutOfBounds(p.tx.Range()) || math.Hypot(centre[0]-pos[0], centre[2]-pos[2]) > float64(r) {
+				continue
+			}
+			if _, ok := p.tx.Block(bPos.Side(cube.FaceUp)).(block.Air); !ok {
+				continue
+			}
+			if w, ok := p.tx.Block(bPos).(block.Water); !ok || w.Depth != 8 || w.Falling {
+				continue
+			}
+			if p.entityInside(bPos) {
+				continue
+			}
+			ice := block.FrostedIce{}
+			p.tx.SetBlock(bPos, ice, nil)
+			ice.ScheduleMelt(bPos, p.tx)
+		}
+	}
+}
+
+// entityInside checks if any entity is inside the block at the position passed, in which case water at the
+// position is not frozen so that the entity does not get stuck in the ice.
+func (p *Player) entityInside(pos cube.Pos) bool {
+	for range p.tx.EntitiesWithin(cube.Box(0, 0, 0, 1, 1, 1).Translate(pos.Vec3())) {
+		return true
+	}
+	return false
+}
+
+// waterFriction returns the friction applied to the horizontal movement of the player in water, which is
+// reduced if the player wears boots enchanted with Depth Strider.
+func (p *Player) waterFriction() float64 {
+	e, _ := p.armour.Boots().Enchantment(enchantment.DepthStrider)
+	return enchantment.DepthStrider.WaterFriction(e.Level(), p.OnGround())
+}
+
+// soulSpeedModifierID is the ID of the movement speed modifier applied to the player while it is walking on
+// soul sand or soul soil with boots enchanted with Soul Speed.
+var soulSpeedModifierID = uuid.MustParse("3a5a6c46-6d9e-4f3c-9b0a-1f0e7d2c5b8e")
+
+// updateSoulSpeed increases the speed of the player while it is walking on soul sand or soul soil with boots
+// enchanted with Soul Speed, occasionally damaging the boots, and removes the increase once it stops doing so.
+func (p *Player) updateSoulSpeed(moved bool) {
+	boots := p.armour.Boots()
+	e, ok := boots.Enchantment(enchantment.SoulSpeed)
+	if ok && p.OnGround() && !p.Flying() {
+		switch p.tx.Block(cube.PosFromVec3(p.Position()).Side(cube.FaceDown)).(type) {
+		case block.SoulSand, block.SoulSoil:
+			if _, applied := p.attributes.Modifier(attribute.MovementSpeed(), soulSpeedModifierID); !applied {
+				p.AddAttributeModifier(attribute.MovementSpeed(), attribute.Modifier{ID: soulSpeedModifierID, Name: "soul speed", Amount: enchantment.SoulSpeed.SpeedIncrease(e.Level()), Operation: attribute.OperationAdd()})
+			}
+			if moved && rand.Float64() < 0.04 {
+				p.armour.SetBoots(p.damageItem(boots, 1))
+			}
+			return
+		}
+	}
+	p.RemoveAttributeModifier(attribute.MovementSpeed(), soulSpeedModifierID)
+}
+
 // fall is called when a falling entity hits the ground.
 func (p *Player) fall(distance float64) {
 	pos := cube.PosFromVec3(p.Position())
@@ -556,6 +837,7 @@ func (p *Player) fall(distance float64) {
 	if h, ok := b.(block.EntityLander); ok {
 		h.EntityLand(pos, p.tx, p, &distance)
 	}
+	p.tx.EmitGameEvent(p.Position(), gameevent.HitGround{}, p)
 	dmg := distance - 3
 	if boost, ok := p.Effect(effect.JumpBoost); ok {
 		dmg -= float64(boost.Level())
@@ -566,6 +848,16 @@ func (p *Player) fall(distance float64) {
 	p.Hurt(math.Ceil(dmg), entity.FallDamageSource{})
 }
 
+// HandleProjectileHit passes a projectile that is about to hit the player to
+// the Handler of the player. HandleProjectileHit implements the
+// entity.ProjectileHitHandler interface.
+func (p *Player) HandleProjectileHit(ctx *event.Context[*entity.Ent], hit *entity.ProjectileHit) {
+	pctx := event.C(p)
+	if p.Handler().HandleProjectileHit(pctx, ctx.Val(), hit); pctx.Cancelled() {
+		ctx.Cancel()
+	}
+}
+
 // Hurt hurts the player for a given amount of damage. The source passed
 // represents the cause of the damage, for example entity.AttackDamageSource if
 // the player is attacked by another entity. If the final damage exceeds the
@@ -588,7 +880,7 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 		}
 	}
 
-	immunity := time.Second / 2
+	immunity := p.tx.World().DamageImmunity()
 	ctx := event.C(p)
 	if p.Handler().HandleHurt(ctx, &damageLeft, immune, &immunity, src); ctx.Cancelled() {
 		return 0, false
@@ -629,6 +921,7 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 			if thornsDmg := p.Armour().ThornsDamage(p.damageItem); thornsDmg > 0 {
 				l.Hurt(thornsDmg, enchantment.ThornsDamageSource{Owner: p})
 			}
+			entity.AlertTamed(p, l, p.tx)
 		}
 	}
 
@@ -684,7 +977,7 @@ func (p *Player) FinalDamageFrom(dmg float64, src world.DamageSource) float64 {
 // Explode ...
 func (p *Player) Explode(explosionPos mgl64.Vec3, impact float64, c block.ExplosionConfig) {
 	diff := p.Position().Sub(explosionPos)
-	p.Hurt(math.Floor((impact*impact+impact)*3.5*c.Size*2+1), entity.ExplosionDamageSource{})
+	p.Hurt(math.Floor((impact*impact+impact)*3.5*c.Size*2+1), entity.ExplosionDamageSource{Source: c.Source})
 	p.knockBack(explosionPos, impact, diff[1]/diff.Len()*impact)
 }
 
@@ -722,7 +1015,8 @@ func (p *Player) knockBack(src mgl64.Vec3, force, height float64) {
 	}
 	velocity[1] = height
 
-	p.SetVelocity(velocity.Mul(1 - p.Armour().KnockBackResistance()))
+	p.updateEquipmentAttributes()
+	p.SetVelocity(velocity.Mul(1 - p.attributes.Value(attribute.KnockBackResistance())))
 }
 
 // setAttackImmunity sets the duration the player is immune to entity attacks.
@@ -744,6 +1038,13 @@ func (p *Player) SetFood(level int) {
 	p.sendFood()
 }
 
+// Saturation returns the current saturation level of the player. Saturation
+// is depleted before the food level when the player is exhausted, and never
+// exceeds the food level of the player.
+func (p *Player) Saturation() float64 {
+	return p.hunger.Saturation()
+}
+
 // AddFood adds a number of points to the food level of the player. If the new food level is negative or if
 // it exceeds 20, it will be set to 0 or 20 respectively.
 func (p *Player) AddFood(points int) {
@@ -852,6 +1153,10 @@ func (p *Player) kill(src world.DamageSource) {
 	p.Handler().HandleDeath(p, src, &keepInv)
 	p.StopSneaking()
 	p.StopSprinting()
+	p.Dismount()
+	for _, passenger := range p.Passengers() {
+		p.tx.Dismount(passenger)
+	}
 
 	pos := p.Position()
 	if !keepInv {
@@ -937,18 +1242,27 @@ func (p *Player) respawn(f func(p *Player)) {
 	// We can use the principle here that returning through a portal of a specific dimension inside that dimension will
 	// always bring us back to the overworld.
 	w := p.tx.World().PortalDestination(p.tx.World().Dimension())
-	pos := w.PlayerSpawn(p.UUID()).Vec3Middle()
+	if dim := w.PlayerSpawnDimension(p.UUID()); dim != w.Dimension() {
+		// The player set its spawn in another dimension, such as at a respawn anchor in the nether.
+		w = w.PortalDestination(dim)
+	}
+	spawnPos := w.PlayerSpawn(p.UUID())
+	pos, spawnWorld := spawnPos.Vec3Middle(), w
 
 	p.addHealth(p.MaxHealth())
 	p.hunger.Reset()
 	p.sendFood()
 	p.Extinguish()
 	p.ResetFallDistance()
+	p.ResetSleepTimer()
 
 	p.Handler().HandleRespawn(p, &pos, &w)
+	// Spawn points in the nether are set using respawn anchors, which need to be charged to be able to respawn at
+	// them. The anchor is only used if the handler did not change where the player respawns.
+	anchored := w == spawnWorld && w.Dimension() == world.Nether && pos == spawnPos.Vec3Middle()
 
-	handle := p.tx.RemoveEntity(p)
-	w.Exec(func(tx *world.Tx) {
+	id, handle := p.UUID(), p.tx.RemoveEntity(p)
+	spawn := func(tx *world.Tx, pos mgl64.Vec3) *Player {
 		np := tx.AddEntity(handle).(*Player)
 		np.Teleport(pos)
 		np.session().SendRespawn(pos, p)
@@ -956,9 +1270,32 @@ func (p *Player) respawn(f func(p *Player)) {
 		if f != nil {
 			f(np)
 		}
+		return np
+	}
+	w.Exec(func(tx *world.Tx) {
+		if !anchored {
+			spawn(tx, pos)
+			return
+		}
+		if anchor, ok := tx.Block(spawnPos).(block.RespawnAnchor); ok {
+			if anchorPos, ok := anchor.Respawn(spawnPos, tx); ok {
+				spawn(tx, anchorPos)
+				return
+			}
+		}
+		// The respawn anchor was destroyed, ran out of charges or was obstructed, so the player respawns at the
+		// spawn of the overworld instead.
+		overworld := tx.World().PortalDestination(world.Nether)
+		overworld.SetPlayerSpawn(id, overworld.Spawn())
+		overworld.Exec(func(tx *world.Tx) {
+			spawn(tx, tx.World().Spawn().Vec3Middle()).Messaget(chat.MessageRespawnAnchorNotValid)
+		})
 	})
 }
 
+// sprintModifierID is the ID of the movement speed modifier applied to the player while it is sprinting.
+var sprintModifierID = uuid.MustParse("662a6b8d-da3e-4c1c-8813-96ea6097278d")
+
 // StartSprinting makes a player start sprinting, increasing the speed of the player by 30% and making
 // particles show up under the feet. The player will only start sprinting if its food level is high enough.
 // If the player is sneaking when calling StartSprinting, it is stopped from sneaking.
@@ -972,7 +1309,7 @@ func (p *Player) StartSprinting() {
 	}
 	p.StopSneaking()
 	p.sprinting = true
-	p.SetSpeed(p.speed * 1.3)
+	p.AddAttributeModifier(attribute.MovementSpeed(), attribute.Modifier{ID: sprintModifierID, Name: "sprinting", Amount: 0.3, Operation: attribute.OperationMultiplyTotal()})
 	p.updateState()
 }
 
@@ -991,7 +1328,7 @@ func (p *Player) StopSprinting() {
 		return
 	}
 	p.sprinting = false
-	p.SetSpeed(p.speed / 1.3)
+	p.RemoveAttributeModifier(attribute.MovementSpeed(), sprintModifierID)
 	p.updateState()
 }
 
@@ -1071,12 +1408,14 @@ func (p *Player) StartCrawling() {
 	if p.crawling {
 		return
 	}
-	for _, corner := range p.H().Type().BBox(p).Translate(p.Position()).Corners() {
-		if _, isAir := p.tx.Block(cube.PosFromVec3(corner).Add(cube.Pos{0, 1, 0})).(block.Air); !isAir {
-			p.crawling = true
-			break
-		}
+	// The player may only crawl if it would not fit in the gap it is in while
+	// standing, such as when it is in a 1-block high gap.
+	s := p.Scale()
+	standing := cube.Box(-0.3*s, 0, -0.3*s, 0.3*s, 1.8*s, 0.3*s).Translate(p.Position()).Grow(-1e-4)
+	if !p.collidesWithBlocks(standing) {
+		return
 	}
+	p.crawling = true
 	p.StopSneaking()
 	p.updateState()
 }
@@ -1462,6 +1801,11 @@ func (p *Player) UseItem() {
 		useCtx.CountSub, useCtx.NewItem = 1, usable.Consume(p.tx, p)
 		p.handleUseContext(useCtx)
 		p.tx.PlaySound(p.Position().Add(mgl64.Vec3{0, 1.5}), sound.Burp{})
+		if d, ok := i.Item().(item.Drinkable); ok && d.Drinkable() {
+			p.tx.EmitGameEvent(p.Position(), gameevent.Drink{}, p)
+		} else {
+			p.tx.EmitGameEvent(p.Position(), gameevent.Eat{}, p)
+		}
 	}
 }
 
@@ -1556,7 +1900,7 @@ func (p *Player) UsingItem() bool {
 // returns immediately.
 // UseItemOnBlock does nothing if the block at the cube.Pos passed is of the type block.Air.
 func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec3) {
-	if _, ok := p.tx.Block(pos).(block.Air); ok || !p.canReach(pos.Vec3Centre()) {
+	if _, ok := p.tx.Block(pos).(block.Air); ok || !p.canReachBlock(pos) {
 		// The client used its item on a block that does not exist server-side or one it couldn't reach. Stop trying
 		// to use the item immediately.
 		p.resendBlocks(pos, face)
@@ -1627,11 +1971,13 @@ func (p *Player) UseItemOnEntity(e world.Entity) bool {
 	}
 	i, left := p.HeldItems()
 	usable, ok := i.Item().(item.UsableOnEntity)
-	if !ok {
-		return true
-	}
 	useCtx := p.useContext()
-	if !usable.UseOnEntity(e, p.tx, p, useCtx) {
+	if !ok || !usable.UseOnEntity(e, p.tx, p, useCtx) {
+		if in, ok := e.(interface {
+			Interact(user item.User, tx *world.Tx) bool
+		}); ok {
+			in.Interact(p, p.tx)
+		}
 		return true
 	}
 	p.SwingArm()
@@ -1656,7 +2002,7 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 	}
 
 	var (
-		force, height  = 0.45, 0.3608
+		force, height  = p.attackKnockBack.Base(p.Sprinting())
 		_, slowFalling = p.Effect(effect.SlowFalling)
 		_, blind       = p.Effect(effect.Blindness)
 		critical       = !p.Sprinting() && !p.Flying() && p.FallDistance() > 0 && !slowFalling && !blind
@@ -1669,17 +2015,20 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 	p.SwingArm()
 
 	i, _ := p.HeldItems()
+	p.updateEquipmentAttributes()
+	dmg := p.attributes.Apply(attribute.AttackDamage(), i.AttackDamage())
 	if !isLiving {
+		// Some entities, such as end crystals, are not living but may still
+		// be damaged by attacking them.
+		if d, ok := e.(interface {
+			Hurt(dmg float64, src world.DamageSource) (float64, bool)
+		}); ok {
+			_, vulnerable := d.Hurt(dmg, entity.AttackDamageSource{Attacker: p})
+			return vulnerable
+		}
 		return false
 	}
 
-	dmg := i.AttackDamage()
-	if strength, ok := p.Effect(effect.Strength); ok {
-		dmg += dmg * effect.Strength.Multiplier(strength.Level())
-	}
-	if weakness, ok := p.Effect(effect.Weakness); ok {
-		dmg -= dmg * effect.Weakness.Multiplier(weakness.Level())
-	}
 	if s, ok := i.Enchantment(enchantment.Sharpness); ok {
 		dmg += enchantment.Sharpness.Addend(s.Level())
 		for _, v := range p.tx.Viewers(living.Position()) {
@@ -1697,6 +2046,7 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 	if !vulnerable {
 		return true
 	}
+	entity.AlertTamed(p, living, p.tx)
 	if critical {
 		for _, v := range p.tx.Viewers(living.Position()) {
 			v.ViewEntityAction(living, entity.CriticalHitAction{})
@@ -1705,11 +2055,7 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 
 	p.Exhaust(0.1)
 
-	if k, ok := i.Enchantment(enchantment.Knockback); ok {
-		inc := enchantment.Knockback.Force(k.Level())
-		force += inc
-		height += inc
-	}
+	force, height = p.attackKnockBack.Enchanted(i, force, height)
 	living.KnockBack(p.Position(), force, height)
 
 	if f, ok := i.Enchantment(enchantment.FireAspect); ok {
@@ -1731,7 +2077,7 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 // player might be breaking before this method is called.
 func (p *Player) StartBreaking(pos cube.Pos, face cube.Face) {
 	p.AbortBreaking()
-	if _, air := p.tx.Block(pos).(block.Air); air || !p.canReach(pos.Vec3Centre()) {
+	if _, air := p.tx.Block(pos).(block.Air); air || !p.canReachBlock(pos) {
 		// The block was either out of range or air, so it can't be broken by the player.
 		return
 	}
@@ -1870,7 +2216,7 @@ func (p *Player) PlaceBlock(pos cube.Pos, b world.Block, ctx *item.UseContext) {
 // placeBlock makes the player place the block passed at the position passed, granted it is within the range
 // of the player. A bool is returned indicating if a block was placed successfully.
 func (p *Player) placeBlock(pos cube.Pos, b world.Block, ignoreBBox bool) bool {
-	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() {
+	if !p.canReachBlock(pos) || !p.GameMode().AllowsEditing() {
 		p.resendBlocks(pos, cube.Faces()...)
 		return false
 	}
@@ -1891,6 +2237,7 @@ func (p *Player) placeBlock(pos cube.Pos, b world.Block, ignoreBBox bool) bool {
 	}
 	p.tx.SetBlock(pos, b, nil)
 	p.tx.PlaySound(pos.Vec3(), sound.BlockPlace{Block: b})
+	p.tx.EmitGameEvent(pos.Vec3Centre(), gameevent.BlockPlace{}, p)
 	p.SwingArm()
 	return true
 }
@@ -1933,7 +2280,7 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 		// Don't do anything if the position broken is already air.
 		return
 	}
-	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() {
+	if !p.canReachBlock(pos) || !p.GameMode().AllowsEditing() {
 		p.resendBlocks(pos)
 		return
 	}
@@ -1959,6 +2306,7 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 	p.SwingArm()
 	p.tx.SetBlock(pos, nil, nil)
 	p.tx.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: b})
+	p.tx.EmitGameEvent(pos.Vec3Centre(), gameevent.BlockDestroy{}, p)
 
 	if breakable, ok := b.(block.Breakable); ok {
 		info := breakable.BreakInfo()
@@ -2060,6 +2408,7 @@ func (p *Player) Teleport(pos mgl64.Vec3) {
 	if p.Handler().HandleTeleport(ctx, pos); ctx.Cancelled() {
 		return
 	}
+	p.Dismount()
 	p.teleport(pos)
 }
 
@@ -2074,6 +2423,30 @@ func (p *Player) teleport(pos mgl64.Vec3) {
 	p.ResetFallDistance()
 }
 
+// Mount makes the entity passed start riding the player. The rider is moved along with the player until it
+// dismounts. False is returned if the rider could not start riding the player, for example because another
+// entity is already riding it.
+func (p *Player) Mount(rider world.Entity) bool {
+	return p.tx.Mount(rider, p)
+}
+
+// Dismount makes the player stop riding the entity it is currently riding. Dismount has no effect if the
+// player is not riding any entity.
+func (p *Player) Dismount() {
+	p.tx.Dismount(p)
+}
+
+// Vehicle returns the entity that the player is currently riding. False is returned if the player is not
+// riding any entity.
+func (p *Player) Vehicle() (world.Entity, bool) {
+	return p.tx.Vehicle(p)
+}
+
+// Passengers returns the entities currently riding the player.
+func (p *Player) Passengers() []world.Entity {
+	return p.tx.Passengers(p)
+}
+
 // Move moves the player from one position to another in the world, by adding the delta passed to the current
 // position of the player.
 // Move also rotates the player, adding deltaYaw and deltaPitch to the respective values.
@@ -2092,7 +2465,28 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 	var (
 		pos         = p.Position()
 		res, resRot = pos.Add(deltaPos), p.Rotation().Add(cube.Rotation{deltaYaw, deltaPitch})
+		border      = p.tx.World().Border()
+		clamped     bool
 	)
+	if border.Distance(res) > max(border.Distance(pos), 0) {
+		// The player tried to move past the world border. If it was already
+		// outside the border, it may only move back towards it.
+		clamped = true
+		if border.Contains(pos) {
+			res = border.Clamp(res)
+		} else {
+			res[0], res[2] = pos[0], pos[2]
+		}
+	}
+	if delta := res.Sub(pos); delta[0] != 0 || delta[2] != 0 {
+		// Sneaking players cannot walk off the edge of the block they are
+		// standing on. The client already prevents this, so the movement is
+		// only corrected if it takes the player past the edge by more than
+		// edgeTolerance.
+		if backedOff := p.backOffFromEdge(delta); backedOff.Sub(delta).Len() > edgeTolerance {
+			clamped, res = true, pos.Add(backedOff)
+		}
+	}
 	ctx := event.C(p)
 	if p.Handler().HandleMove(ctx, res, resRot); ctx.Cancelled() {
 		if p.session() != session.Nop && pos.ApproxEqual(p.Position()) {
@@ -2108,6 +2502,12 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 
 	p.data.Pos = res
 	p.data.Rot = resRot
+	if clamped {
+		if p.session() != session.Nop {
+			p.session().ViewEntityTeleport(p, res)
+		}
+		deltaPos = res.Sub(pos)
+	}
 	if deltaPos.Len() <= 3 {
 		// Only update velocity if the player is not moving too fast to prevent potential OOMs.
 		p.data.Vel = deltaPos
@@ -2138,6 +2538,11 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 
 	p.onGround = p.checkOnGround(deltaPos)
 	p.updateFallState(deltaPos[1])
+	p.updateStepState(horizontalVel.Len())
+	if horizontalVel.Len() > 0 {
+		p.updateFrostWalker()
+	}
+	p.updateSoulSpeed(horizontalVel.Len() > 0)
 
 	if p.Swimming() {
 		p.Exhaust(0.01 * horizontalVel.Len())
@@ -2335,6 +2740,17 @@ func (p *Player) Drop(s item.Stack) int {
 	return s.Count()
 }
 
+// MoveItem is called by the session of the player when it moves the item stack passed from srcSlot in src to
+// dstSlot in dst. MoveItem does not move the item itself: It calls Handler.HandleItemMove and returns an error if
+// the move was cancelled, in which case the session reverts the move.
+func (p *Player) MoveItem(it item.Stack, src *inventory.Inventory, srcSlot int, dst *inventory.Inventory, dstSlot int) error {
+	ctx := event.C(p)
+	if p.Handler().HandleItemMove(ctx, it, src, srcSlot, dst, dstSlot); ctx.Cancelled() {
+		return fmt.Errorf("move item: move of %v from slot %v to slot %v was cancelled", it, srcSlot, dstSlot)
+	}
+	return nil
+}
+
 // OpenBlockContainer opens a block container, such as a chest, at the position passed. If no container was
 // present at that location, OpenBlockContainer does nothing.
 // OpenBlockContainer will also do nothing if the player has no session connected to it.
@@ -2344,6 +2760,65 @@ func (p *Player) OpenBlockContainer(pos cube.Pos, tx *world.Tx) {
 	}
 }
 
+// OpenContainer opens a virtual container of the session.ContainerType passed for the player, showing the
+// contents of inv. The container is not backed by a block in the world, so that it may be used to show menus
+// to the player. Items moved into or out of the container by the player are moved in inv, which may be
+// handled using inv.Handle. Changes made to inv while it is opened may be shown to the player by calling
+// OpenContainer with the same inventory again. The container is closed automatically if the player moves
+// away from it. OpenContainer panics if the size of inv does not match that of the session.ContainerType, and
+// does nothing if the player has no session connected to it.
+func (p *Player) OpenContainer(inv *inventory.Inventory, t session.ContainerType) {
+	if p.session() != session.Nop {
+		p.session().OpenContainer(inv, t, p.tx)
+	}
+}
+
+// CloseContainer closes the container that the player currently has opened. If the player has no container
+// opened, CloseContainer does nothing.
+func (p *Player) CloseContainer() {
+	if p.session() != session.Nop {
+		p.session().CloseContainer(p.tx)
+	}
+}
+
+// ContainerOpening is called by the session of the player when it is about to open a container for the
+// player. It calls Handler.HandleContainerOpen and returns an error if the opening was cancelled, in which
+// case the session does not open the container.
+func (p *Player) ContainerOpening(inv *inventory.Inventory, pos cube.Pos) error {
+	ctx := event.C(p)
+	if p.Handler().HandleContainerOpen(ctx, inv, pos); ctx.Cancelled() {
+		return fmt.Errorf("open container: opening of container at %v was cancelled", pos)
+	}
+	return nil
+}
+
+// ContainerClosing is called by the session of the player when the container opened by the player is closed.
+// It calls Handler.HandleContainerClose. If cancellable is true and closing the container was cancelled, an
+// error is returned, in which case the session opens the container again.
+func (p *Player) ContainerClosing(inv *inventory.Inventory, pos cube.Pos, cancellable bool) error {
+	ctx := event.C(p)
+	if p.Handler().HandleContainerClose(ctx, inv, pos); ctx.Cancelled() && cancellable {
+		return fmt.Errorf("close container: closing of container at %v was cancelled", pos)
+	}
+	return nil
+}
+
+// OpenTrading opens the trading window of the villager passed, allowing the player to trade with it. OpenTrading
+// does nothing if the player has no session connected to it.
+func (p *Player) OpenTrading(v *entity.Mob) {
+	if p.session() != session.Nop {
+		p.session().OpenTrading(v, p.tx)
+	}
+}
+
+// OpenEntityContainer opens the inventory of the entity passed, such as a chest minecart, for the player.
+// OpenEntityContainer does nothing if the player has no session connected to it.
+func (p *Player) OpenEntityContainer(c entity.Container) {
+	if p.session() != session.Nop {
+		p.session().OpenEntityContainer(c, p.tx)
+	}
+}
+
 // HideEntity hides a world.Entity from the Player so that it can under no circumstance see it. Hidden entities can be
 // made visible again through a call to ShowEntity.
 func (p *Player) HideEntity(e world.Entity) {
@@ -2395,11 +2870,22 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 
 	p.checkBlockCollisions(p.data.Vel)
 	p.onGround = p.checkOnGround(mgl64.Vec3{})
+	if p.onGround {
+		pos := cube.PosFromVec3(p.Position().Sub(mgl64.Vec3{0, 0.2}))
+		if s, ok := p.tx.Block(pos).(block.EntityStepper); ok {
+			s.EntityStepOn(pos, p.tx, p)
+		}
+	}
 
 	p.effects.Tick(p, p.tx)
 
 	p.tickFood()
 	p.tickAirSupply()
+	p.tickInsomnia(tx)
+	p.tickWardenWarning()
+	p.tickBadOmen(tx, current)
+	p.tickPatrols(tx)
+	p.tickFish(tx, current)
 
 	if p.Position()[1] < float64(p.tx.Range()[0]) {
 		p.Hurt(4, entity.VoidDamageSource{})
@@ -2407,6 +2893,8 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 	if p.insideOfSolid() {
 		p.Hurt(1, entity.SuffocationDamageSource{})
 	}
+	p.tickBorder(tx, current)
+	p.tickLatency(current)
 
 	if p.OnFireDuration() > 0 {
 		p.fireTicks -= 1
@@ -2414,7 +2902,7 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 			p.Extinguish()
 		}
 		if p.OnFireDuration()%time.Second == 0 {
-			p.Hurt(1, block.FireDamageSource{})
+			p.Hurt(1, entity.BurnDamageSource{})
 		}
 	}
 
@@ -2452,6 +2940,7 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 	p.prevWorld = tx.World()
 
 	if p.session() == session.Nop && !p.Immobile() {
+		p.mc.WaterFriction = p.waterFriction()
 		m := p.mc.TickMovement(p, p.Position(), p.Velocity(), p.Rotation(), p.tx)
 		m.Send()
 
@@ -2482,6 +2971,228 @@ func (p *Player) tickAirSupply() {
 	}
 }
 
+// tickBorder hurts the player every second if it is further outside the
+// border of the world than its damage buffer allows. The damage increases
+// with the distance of the player to the border.
+func (p *Player) tickBorder(tx *world.Tx, current int64) {
+	if current%20 != 0 {
+		return
+	}
+	b := tx.World().Border()
+	if dist := b.Distance(p.Position()) - b.DamageBuffer; dist > 0 && b.Damage > 0 {
+		p.Hurt(max(1, math.Floor(dist*b.Damage)), entity.BorderDamageSource{})
+	}
+}
+
+// tickInsomnia increases the time since the player last slept and
+// periodically spawns phantoms above the player if it has not slept for a
+// long time. The longer the player has not slept, the more likely it is for
+// phantoms to spawn.
+func (p *Player) tickInsomnia(tx *world.Tx) {
+	if !p.GameMode().AllowsTakingDamage() {
+		return
+	}
+	p.ticksSinceRest = min(p.ticksSinceRest+1, math.MaxInt32)
+	if p.phantomSpawnTicks--; p.phantomSpawnTicks > 0 {
+		return
+	}
+	p.phantomSpawnTicks = 1200 + rand.IntN(1200)
+
+	w := tx.World()
+	pos := cube.PosFromVec3(p.Position())
+	if !w.Insomnia() || w.Dimension() != world.Overworld || pos[1] < 63 || tx.HighestLightBlocker(pos[0], pos[2]) > pos[1] {
+		return
+	}
+	if t := w.Time() % 24000; (t < 13000 || t > 23000) && !tx.ThunderingAt(pos) {
+		return
+	}
+	difficulty, _ := world.DifficultyID(w.Difficulty())
+	if difficulty == 0 || float64(difficulty) <= rand.Float64()*3 {
+		return
+	}
+	// Phantoms only start spawning after three in-game days without sleep.
+	if p.ticksSinceRest < 72000 || rand.Int64N(p.ticksSinceRest) < 72000 {
+		return
+	}
+	spawnPos := pos.Add(cube.Pos{rand.IntN(21) - 10, 20 + rand.IntN(15), rand.IntN(21) - 10})
+	if _, ok := tx.Liquid(spawnPos); ok || len(tx.Block(spawnPos).Model().BBox(spawnPos, tx)) > 0 {
+		return
+	}
+	for range 1 + rand.IntN(difficulty+1) {
+		tx.AddEntity(entity.NewPhantom(world.EntitySpawnOpts{Position: spawnPos.Vec3Centre()}))
+	}
+}
+
+// tickBadOmen starts a raid in the village that the player is in if it has
+// the Bad Omen effect. The effect is consumed once the raid is started or its
+// level is raised.
+func (p *Player) tickBadOmen(tx *world.Tx, current int64) {
+	if current%20 != 0 || !p.GameMode().AllowsTakingDamage() {
+		return
+	}
+	omen, ok := p.Effect(effect.BadOmen)
+	if !ok {
+		return
+	}
+	if v, ok := tx.Village(cube.PosFromVec3(p.Position())); ok && entity.StartRaid(tx, v, omen.Level()) {
+		p.RemoveEffect(effect.BadOmen)
+	}
+}
+
+// tickPatrols periodically spawns a patrol of pillagers, led by a captain,
+// close to the player. Patrols only spawn during the day once the world is
+// five days old and walk towards a village nearby.
+func (p *Player) tickPatrols(tx *world.Tx) {
+	if !p.GameMode().AllowsTakingDamage() {
+		return
+	}
+	if p.patrolSpawnTicks--; p.patrolSpawnTicks > 0 {
+		return
+	}
+	p.patrolSpawnTicks = 12000 + rand.IntN(1200)
+
+	w := tx.World()
+	if w.Dimension() != world.Overworld || w.Difficulty() == world.DifficultyPeaceful || w.Time() < 120000 {
+		return
+	}
+	if t := w.Time() % 24000; t >= 12000 || rand.IntN(5) != 0 {
+		return
+	}
+	offset := mgl64.Vec2{24 + rand.Float64()*24}
+	offset = mgl64.Rotate2D(rand.Float64() * math.Pi * 2).Mul2x1(offset)
+	x, z := int(math.Floor(p.Position()[0]+offset[0])), int(math.Floor(p.Position()[2]+offset[1]))
+	y, _ := tx.HighestBlock(x, z)
+	spawnPos := cube.Pos{x, y + 1, z}
+	if _, ok := tx.Village(spawnPos); ok || !patrolSpawnable(spawnPos, tx) {
+		return
+	}
+	villages := tx.Villages(spawnPos, 128)
+	if len(villages) == 0 {
+		return
+	}
+	target := villages[rand.IntN(len(villages))].Centre().Vec3Centre()
+	tx.AddEntity(entity.NewPatrolCaptain(world.EntitySpawnOpts{Position: spawnPos.Vec3Middle()}, target))
+
+	difficulty, _ := world.DifficultyID(w.Difficulty())
+	for range rand.IntN(difficulty + 1) {
+		pos := spawnPos.Add(cube.Pos{rand.IntN(5) - 2, 0, rand.IntN(5) - 2})
+		pos[1], _ = tx.HighestBlock(pos[0], pos[2])
+		if pos = pos.Side(cube.FaceUp); !patrolSpawnable(pos, tx) {
+			continue
+		}
+		conf := entity.PillagerBehaviourConfig{PatrolTarget: &target}
+		tx.AddEntity(world.EntitySpawnOpts{Position: pos.Vec3Middle()}.New(entity.PillagerType, conf))
+	}
+}
+
+// tickFish occasionally spawns a group of fish in the water around the
+// player. The fish that spawn depend on the biome of the water: Tropical fish
+// and pufferfish in warm oceans, salmon in cold oceans and rivers and cod in
+// all other oceans. No fish spawn if there are already many fish close to the
+// player.
+func (p *Player) tickFish(tx *world.Tx, current int64) {
+	if current%20 != 0 || rand.IntN(20) != 0 || tx.World().Dimension() != world.Overworld {
+		return
+	}
+	offset := mgl64.Vec2{16 + rand.Float64()*32}
+	offset = mgl64.Rotate2D(rand.Float64() * math.Pi * 2).Mul2x1(offset)
+	x, z := int(math.Floor(p.Position()[0]+offset[0])), int(math.Floor(p.Position()[2]+offset[1]))
+	spawnPos, ok := fishSpawnPos(x, z, tx)
+	if !ok {
+		return
+	}
+	fish := 0
+	for e := range tx.EntitiesWithin(cube.Box(-64, -64, -64, 64, 64, 64).Translate(p.Position())) {
+		switch e.H().Type() {
+		case entity.CodType, entity.PufferfishType, entity.SalmonType, entity.TropicalFishType:
+			fish++
+		}
+	}
+	if fish >= 12 {
+		return
+	}
+
+	tags := tx.Biome(spawnPos).Tags()
+	var spawn func(opts world.EntitySpawnOpts) *world.EntityHandle
+	var size int
+	switch {
+	case slices.Contains(tags, "warm") || slices.Contains(tags, "lukewarm"):
+		if rand.IntN(6) == 0 {
+			spawn, size = entity.NewPufferfish, 1+rand.IntN(3)
+			break
+		}
+		// Tropical fish in the same group all share the same variant, so
+		// that they are able to form a school.
+		variant := entity.RandomTropicalFishVariant()
+		spawn = func(opts world.EntitySpawnOpts) *world.EntityHandle {
+			return entity.NewTropicalFish(opts, variant)
+		}
+		size = 1 + rand.IntN(8)
+	case slices.Contains(tags, "river") || slices.Contains(tags, "frozen") ||
+		(slices.Contains(tags, "cold") && rand.IntN(6) != 0):
+		spawn, size = entity.NewSalmon, 1+rand.IntN(5)
+	case slices.Contains(tags, "ocean"):
+		spawn, size = entity.NewCod, 3+rand.IntN(4)
+	default:
+		return
+	}
+	for range min(size, 12-fish) {
+		pos := spawnPos.Add(cube.Pos{rand.IntN(5) - 2, rand.IntN(3) - 1, rand.IntN(5) - 2})
+		if l, ok := tx.Liquid(pos); !ok || l.LiquidType() != "water" {
+			continue
+		}
+		tx.AddEntity(spawn(world.EntitySpawnOpts{Position: pos.Vec3Middle(), Rotation: cube.Rotation{rand.Float64() * 360}}))
+	}
+}
+
+// fishSpawnPos finds a position for fish to spawn at in the column passed. A
+// random position below the surface of the water is returned, or false if
+// the highest block in the column is not water.
+func fishSpawnPos(x, z int, tx *world.Tx) (cube.Pos, bool) {
+	y, _ := tx.HighestBlock(x, z)
+	if l, ok := tx.Liquid(cube.Pos{x, y, z}); !ok || l.LiquidType() != "water" {
+		return cube.Pos{}, false
+	}
+	depth := 0
+	for depth < 16 {
+		if l, ok := tx.Liquid(cube.Pos{x, y - depth - 1, z}); !ok || l.LiquidType() != "water" {
+			break
+		}
+		depth++
+	}
+	return cube.Pos{x, y - rand.IntN(depth+1), z}, true
+}
+
+// patrolSpawnable checks if a member of a patrol can spawn at the position
+// passed: It must be standing on a solid block without any blocks or liquids
+// in the way of its body.
+func patrolSpawnable(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	if _, ok := tx.Liquid(below); ok || len(tx.Block(below).Model().BBox(below, tx)) == 0 {
+		return false
+	}
+	for _, pos := range []cube.Pos{pos, pos.Side(cube.FaceUp)} {
+		if _, ok := tx.Liquid(pos); ok || len(tx.Block(pos).Model().BBox(pos, tx)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// tickWardenWarning lowers the warden warning level of the player by one for
+// every ten minutes in which it was not increased.
+func (p *Player) tickWardenWarning() {
+	if p.wardenWarningCooldown > 0 {
+		p.wardenWarningCooldown--
+	}
+	if p.wardenWarningLevel == 0 {
+		return
+	}
+	if p.wardenWarningTicks++; p.wardenWarningTicks >= 12000 {
+		p.wardenWarningLevel, p.wardenWarningTicks = p.wardenWarningLevel-1, 0
+	}
+}
+
 // tickFood ticks food related functionality, such as the depletion of the food bar and regeneration if it
 // is full enough.
 func (p *Player) tickFood() {
@@ -2489,13 +3200,21 @@ func (p *Player) tickFood() {
 		if p.tx.World().Difficulty().FoodRegenerates() {
 			p.AddFood(1)
 		}
-		if p.hunger.foodTick%20 == 0 {
-			p.regenerate(true)
+		if p.hunger.foodTick%20 == 0 && p.tx.World().NaturalRegeneration() {
+			if p.hunger.canQuicklyRegenerate() {
+				// Quick regeneration uses up saturation, but never more than 6
+				// exhaustion points at a time. Every 6 points of exhaustion
+				// heal one point of health.
+				sat := min(p.hunger.Saturation(), 6)
+				p.regenerate(sat/6, sat)
+			} else {
+				p.regenerate(1, 6)
+			}
 		}
 	}
 	if p.hunger.foodTick == 1 {
-		if p.hunger.canRegenerate() {
-			p.regenerate(false)
+		if p.hunger.canRegenerate() && p.tx.World().NaturalRegeneration() {
+			p.regenerate(1, 6)
 		} else if p.hunger.starving() {
 			p.starve()
 		}
@@ -2511,15 +3230,14 @@ func (p *Player) tickFood() {
 	}
 }
 
-// regenerate attempts to regenerate half a heart of health, typically caused by a full food bar.
-func (p *Player) regenerate(exhaust bool) {
+// regenerate attempts to regenerate the amount of health passed, typically caused by a full food bar. The
+// player is exhausted by the amount of exhaustion passed if it was healed.
+func (p *Player) regenerate(health, exhaustion float64) {
 	if p.Health() == p.MaxHealth() {
 		return
 	}
-	p.Heal(1, entity.FoodHealingSource{})
-	if exhaust {
-		p.Exhaust(6)
-	}
+	p.Heal(health, entity.FoodHealingSource{})
+	p.Exhaust(exhaustion)
 }
 
 // starve deals starvation damage to the player if the difficult allows it. In peaceful mode, no damage will
@@ -2685,6 +3403,68 @@ func (p *Player) checkEntityInsiders(entityBBox cube.BBox) {
 	}
 }
 
+// edgeTolerance is the distance that a sneaking player may move past the edge
+// of the block it is standing on before its movement is corrected. The client
+// backs off from edges in steps of 0.05 blocks and its collision checks
+// differ slightly from those of the server, so small differences are allowed.
+const edgeTolerance = 0.1
+
+// backOffFromEdge reduces the horizontal movement passed so that a sneaking
+// player on the ground does not move off the edge of the block it is standing
+// on. The movement is reduced in steps of 0.05 blocks, like the client does.
+func (p *Player) backOffFromEdge(deltaPos mgl64.Vec3) mgl64.Vec3 {
+	if !p.sneaking || p.Flying() || !p.OnGround() || deltaPos[1] > 0 {
+		return deltaPos
+	}
+	const step, stepHeight = 0.05, 0.6
+	box := Type.BBox(p).Translate(p.Position())
+	supported := func(dx, dz float64) bool {
+		return p.collidesWithBlocks(box.Translate(mgl64.Vec3{dx, -stepHeight, dz}))
+	}
+	if !supported(0, 0) {
+		// The player is not standing on anything it could fall off of.
+		return deltaPos
+	}
+	reduce := func(v float64) float64 {
+		if v < step && v >= -step {
+			return 0
+		} else if v > 0 {
+			return v - step
+		}
+		return v + step
+	}
+	dx, dz := deltaPos[0], deltaPos[2]
+	for dx != 0 && !supported(dx, 0) {
+		dx = reduce(dx)
+	}
+	for dz != 0 && !supported(0, dz) {
+		dz = reduce(dz)
+	}
+	for dx != 0 && dz != 0 && !supported(dx, dz) {
+		dx, dz = reduce(dx), reduce(dz)
+	}
+	return mgl64.Vec3{dx, deltaPos[1], dz}
+}
+
+// collidesWithBlocks checks if the box passed intersects with the collision
+// box of any block in the world.
+func (p *Player) collidesWithBlocks(box cube.BBox) bool {
+	low, high := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+	for x := low[0]; x <= high[0]; x++ {
+		for z := low[2]; z <= high[2]; z++ {
+			for y := low[1]; y <= high[1]; y++ {
+				pos := cube.Pos{x, y, z}
+				for _, bb := range p.tx.Block(pos).Model().BBox(pos, p.tx) {
+					if bb.Translate(pos.Vec3()).IntersectsWith(box) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
 // checkOnGround checks if the player is currently considered to be on the ground.
 func (p *Player) checkOnGround(deltaPos mgl64.Vec3) bool {
 	box := Type.BBox(p).Translate(p.Position()).Extend(mgl64.Vec3{0, -0.05}).Extend(deltaPos.Mul(-1.0))
@@ -2787,21 +3567,16 @@ func (p *Player) EditSign(pos cube.Pos, frontText, backText string) error {
 	}
 
 	ctx := event.C(p)
-	if frontText != sign.Front.Text {
-		if p.Handler().HandleSignEdit(ctx, pos, true, sign.Front.Text, frontText); ctx.Cancelled() {
-			p.resendBlock(pos)
-			return nil
-		}
-		sign.Front.Text = frontText
-		sign.Front.Owner = p.XUID()
-	} else {
-		if p.Handler().HandleSignEdit(ctx, pos, false, sign.Back.Text, backText); ctx.Cancelled() {
-			p.resendBlock(pos)
-			return nil
-		}
-		sign.Back.Text = backText
-		sign.Back.Owner = p.XUID()
+	frontSide, text := frontText != sign.Front.Text, backText
+	side := &sign.Back
+	if frontSide {
+		text, side = frontText, &sign.Front
 	}
+	if p.Handler().HandleSignEdit(ctx, pos, frontSide, side.Text, &text); ctx.Cancelled() {
+		p.resendBlock(pos)
+		return nil
+	}
+	side.Text, side.Owner = text, p.XUID()
 	p.tx.SetBlock(pos, sign, nil)
 	return nil
 }
@@ -2963,6 +3738,12 @@ func (p *Player) canReach(pos mgl64.Vec3) bool {
 		(dist <= 8.0 || (dist <= 14.0 && p.GameMode().CreativeInventory()))
 }
 
+// canReachBlock checks if a player can interact with the block at the position passed. Besides being within
+// range of the player, the block must be within the border of the world.
+func (p *Player) canReachBlock(pos cube.Pos) bool {
+	return p.canReach(pos.Vec3Centre()) && p.tx.World().Border().ContainsBlock(pos)
+}
+
 // Disconnect closes the player and removes it from the world.
 // Disconnect, unlike Close, allows a custom message to be passed to show to the player when it is
 // disconnected. The message is formatted following the rules of fmt.Sprintln without a newline at the end.
@@ -3028,7 +3809,7 @@ func (p *Player) Data() Config {
 		Rotation:            p.Rotation(),
 		Velocity:            p.Velocity(),
 		Health:              p.Health(),
-		MaxHealth:           p.MaxHealth(),
+		MaxHealth:           p.attributes.Base(attribute.MaxHealth()),
 		FoodTick:            p.hunger.foodTick,
 		Food:                p.hunger.foodLevel,
 		Exhaustion:          p.hunger.exhaustionLevel,
@@ -3044,7 +3825,12 @@ func (p *Player) Data() Config {
 		EnderChestInventory: p.enderChest,
 		FireTicks:           p.fireTicks,
 		FallDistance:        p.fallDistance,
+		TicksSinceRest:      p.ticksSinceRest,
+		WardenWarningLevel:  p.wardenWarningLevel,
 		Effects:             p.Effects(),
+		Attributes:          p.attributes,
+		AttackKnockBack:     p.attackKnockBack,
+		LatencyPolicy:       p.latencyPolicy,
 	}
 }
 
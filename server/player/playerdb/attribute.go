@@ -0,0 +1,48 @@
+package playerdb
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
+	"github.com/google/uuid"
+)
+
+func attributesToData(attrs *entity.Attributes) []jsonAttribute {
+	if attrs == nil {
+		return nil
+	}
+	data := make([]jsonAttribute, 0, len(attribute.All()))
+	for _, attr := range attribute.All() {
+		mods := attrs.Modifiers(attr)
+		d := jsonAttribute{Name: attr.Name(), Base: attrs.Base(attr), Modifiers: make([]jsonAttributeModifier, len(mods))}
+		for i, m := range mods {
+			d.Modifiers[i] = jsonAttributeModifier{
+				UUID:      m.ID.String(),
+				Name:      m.Name,
+				Amount:    m.Amount,
+				Operation: m.Operation.Uint8(),
+			}
+		}
+		data = append(data, d)
+	}
+	return data
+}
+
+func dataToAttributes(data []jsonAttribute) *entity.Attributes {
+	attrs := entity.NewAttributes()
+	for _, d := range data {
+		attr, ok := attribute.ByName(d.Name)
+		if !ok {
+			continue
+		}
+		attrs.SetBase(attr, d.Base)
+		for _, m := range d.Modifiers {
+			id, err := uuid.Parse(m.UUID)
+			op, ok := attribute.OperationByID(m.Operation)
+			if err != nil || !ok {
+				continue
+			}
+			attrs.AddModifier(attr, attribute.Modifier{ID: id, Name: m.Name, Amount: m.Amount, Operation: op})
+		}
+	}
+	return attrs
+}
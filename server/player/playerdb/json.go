@@ -33,8 +33,11 @@ func (p *Provider) fromJson(d jsonData, lookupWorld func(world.Dimension) *world
 		EnchantmentSeed:     d.EnchantmentSeed,
 		GameMode:            mode,
 		Effects:             dataToEffects(d.Effects),
+		Attributes:          dataToAttributes(d.Attributes),
 		FireTicks:           d.FireTicks,
 		FallDistance:        d.FallDistance,
+		TicksSinceRest:      d.TicksSinceRest,
+		WardenWarningLevel:  d.WardenWarningLevel,
 		Inventory:           inventory.New(36, nil),
 		EnderChestInventory: inventory.New(27, nil),
 		OffHand:             inventory.New(1, nil),
@@ -62,26 +65,29 @@ func (p *Provider) toJson(d player.Config, w *world.World) jsonData {
 	mode, _ := world.GameModeID(d.GameMode)
 	offHand, _ := d.OffHand.Item(0)
 	return jsonData{
-		UUID:            d.UUID.String(),
-		Username:        d.Name,
-		Position:        d.Position,
-		Velocity:        d.Velocity,
-		Yaw:             d.Rotation.Yaw(),
-		Pitch:           d.Rotation.Pitch(),
-		Health:          d.Health,
-		MaxHealth:       d.MaxHealth,
-		Hunger:          d.Food,
-		FoodTick:        d.FoodTick,
-		ExhaustionLevel: d.Exhaustion,
-		SaturationLevel: d.Saturation,
-		Experience:      d.Experience,
-		AirSupply:       d.AirSupply,
-		MaxAirSupply:    d.MaxAirSupply,
-		EnchantmentSeed: d.EnchantmentSeed,
-		GameMode:        uint8(mode),
-		Effects:         effectsToData(d.Effects),
-		FireTicks:       d.FireTicks,
-		FallDistance:    d.FallDistance,
+		UUID:               d.UUID.String(),
+		Username:           d.Name,
+		Position:           d.Position,
+		Velocity:           d.Velocity,
+		Yaw:                d.Rotation.Yaw(),
+		Pitch:              d.Rotation.Pitch(),
+		Health:             d.Health,
+		MaxHealth:          d.MaxHealth,
+		Hunger:             d.Food,
+		FoodTick:           d.FoodTick,
+		ExhaustionLevel:    d.Exhaustion,
+		SaturationLevel:    d.Saturation,
+		Experience:         d.Experience,
+		AirSupply:          d.AirSupply,
+		MaxAirSupply:       d.MaxAirSupply,
+		EnchantmentSeed:    d.EnchantmentSeed,
+		GameMode:           uint8(mode),
+		Effects:            effectsToData(d.Effects),
+		Attributes:         attributesToData(d.Attributes),
+		FireTicks:          d.FireTicks,
+		FallDistance:       d.FallDistance,
+		TicksSinceRest:     d.TicksSinceRest,
+		WardenWarningLevel: d.WardenWarningLevel,
 		Inventory: invToData(InventoryData{
 			Items:        d.Inventory.Slots(),
 			Boots:        d.Armour.Boots(),
@@ -113,8 +119,11 @@ type jsonData struct {
 	Inventory                        jsonInventoryData
 	EnderChestInventory              []jsonSlot
 	Effects                          []jsonEffect
+	Attributes                       []jsonAttribute
 	FireTicks                        int64
 	FallDistance                     float64
+	TicksSinceRest                   int64
+	WardenWarningLevel               int
 	Dimension                        uint8
 }
 
@@ -141,3 +150,16 @@ type jsonEffect struct {
 	ParticlesHidden bool
 	Infinite        bool
 }
+
+type jsonAttribute struct {
+	Name      string
+	Base      float64
+	Modifiers []jsonAttributeModifier
+}
+
+type jsonAttributeModifier struct {
+	UUID      string
+	Name      string
+	Amount    float64
+	Operation uint8
+}
@@ -40,6 +40,14 @@ import (
 	"golang.org/x/text/language"
 )
 
+// blobStoreSize is the maximum amount of bytes of chunk blobs held by the
+// Server for players with the client blob cache enabled.
+const blobStoreSize = 64 << 20
+
+// chunkCacheSize is the maximum amount of bytes of encoded chunks shared
+// between players by the Server.
+const chunkCacheSize = 32 << 20
+
 // Server implements a Dragonfly server. It runs the main server loop and
 // handles the connections of players trying to join the server.
 type Server struct {
@@ -49,12 +57,20 @@ type Server struct {
 	started atomic.Pointer[time.Time]
 
 	world, nether, end *world.World
+	// blobs holds the chunk blobs sent to players with the client blob cache
+	// enabled. It is nil if the client blob cache is disabled.
+	blobs *session.BlobStore
+	// chunks holds the chunks encoded to be sent to players, so that chunks
+	// viewed by many players are only encoded once.
+	chunks *session.ChunkCache
 
 	customBlocks []protocol.BlockEntry
 	customItems  []protocol.ItemEntry
 
 	listeners []Listener
 	incoming  chan incoming
+	// closing is closed once the Server starts closing.
+	closing chan struct{}
 
 	pmu sync.RWMutex
 	// p holds a map of all players currently connected to the server. When they
@@ -114,6 +130,9 @@ func (srv *Server) Listen() {
 	srv.conf.Log.Info("Dragonfly server started.", "mc-version", protocol.CurrentVersion, "go-version", info.GoVersion, "commit", revision)
 	srv.startListening()
 	go srv.wait()
+	if srv.conf.MetricsExporter != nil {
+		go srv.exportMetrics()
+	}
 }
 
 // Accept accepts incoming players into the server, returning an iterator that
@@ -299,6 +318,7 @@ func (srv *Server) Close() error {
 // close stops the server, storing player and world data to disk.
 func (srv *Server) close() {
 	srv.conf.Log.Info("Server closing...")
+	close(srv.closing)
 
 	srv.conf.Log.Debug("Disconnecting players...")
 	for p := range srv.Players(nil) {
@@ -436,11 +456,14 @@ func (srv *Server) finaliseConn(ctx context.Context, conn session.Conn, l Listen
 	}
 
 	data.PlayerPosition = vec64To32(d.Position).Add(mgl32.Vec3{0, 1.62})
-	dim, _ := world.DimensionID(w.Dimension())
+	// Custom dimensions are shown as one of the dimensions known to the
+	// client. Their range is sent once the player spawns.
+	dim, _ := world.DimensionID(world.ClientDimension(w.Dimension()))
 	data.Dimension = int32(dim)
 	data.Yaw, data.Pitch = float32(d.Rotation.Yaw()), float32(d.Rotation.Pitch())
 
 	data.EmoteChatMuted = srv.conf.MuteEmoteChat
+	subChunkRequests := srv.subChunkRequests(conn)
 
 	if err := conn.StartGameContext(ctx, data); err != nil {
 		_ = l.Disconnect(conn, "Connection timeout.")
@@ -454,7 +477,23 @@ func (srv *Server) finaliseConn(ctx context.Context, conn session.Conn, l Listen
 		return
 	}
 	_ = conn.WritePacket(&packet.ItemRegistry{Items: srv.customItems})
-	srv.incoming <- srv.createPlayer(id, conn, d, w)
+	srv.incoming <- srv.createPlayer(id, conn, d, w, subChunkRequests)
+}
+
+// subChunkRequestProtocol is the ID of the protocol of Minecraft 1.18.0, the
+// first version in which clients request the sub chunks they need.
+const subChunkRequestProtocol = 475
+
+// subChunkRequests checks if chunks are sent to the session.Conn passed using
+// sub chunk requests once its game is started. This depends on the protocol
+// negotiated with the client: Connections of which the protocol is not known
+// or older than subChunkRequestProtocol are sent full chunks.
+func (srv *Server) subChunkRequests(conn session.Conn) bool {
+	if srv.conf.DisableSubChunkRequests {
+		return false
+	}
+	c, ok := conn.(interface{ Proto() minecraft.Protocol })
+	return ok && c.Proto().ID() >= subChunkRequestProtocol
 }
 
 // defaultGameData returns a minecraft.GameData as sent for a new player. It
@@ -541,15 +580,18 @@ func (srv *Server) handleSessionClose(tx *world.Tx, c session.Controllable) {
 
 // createPlayer creates a new player instance using the UUID and connection
 // passed.
-func (srv *Server) createPlayer(id uuid.UUID, conn session.Conn, conf player.Config, w *world.World) incoming {
+func (srv *Server) createPlayer(id uuid.UUID, conn session.Conn, conf player.Config, w *world.World, subChunkRequests bool) incoming {
 	srv.pwg.Add(1)
 
 	s := session.Config{
-		Log:            srv.conf.Log,
-		MaxChunkRadius: srv.conf.MaxChunkRadius,
-		JoinMessage:    srv.conf.JoinMessage,
-		QuitMessage:    srv.conf.QuitMessage,
-		HandleStop:     srv.handleSessionClose,
+		Log:              srv.conf.Log,
+		MaxChunkRadius:   srv.conf.MaxChunkRadius,
+		Blobs:            srv.blobs,
+		Chunks:           srv.chunks,
+		SubChunkRequests: subChunkRequests,
+		JoinMessage:      srv.conf.JoinMessage,
+		QuitMessage:      srv.conf.QuitMessage,
+		HandleStop:       srv.handleSessionClose,
 	}.New(conn)
 
 	conf.Name = conn.IdentityData().DisplayName
@@ -558,6 +600,8 @@ func (srv *Server) createPlayer(id uuid.UUID, conn session.Conn, conf player.Con
 	conf.Locale, _ = language.Parse(strings.Replace(conn.ClientData().LanguageCode, "_", "-", 1))
 	conf.Skin = srv.parseSkin(conn.ClientData())
 	conf.Session = s
+	conf.AttackKnockBack = srv.conf.AttackKnockBack
+	conf.LatencyPolicy = srv.conf.LatencyPolicy
 
 	handle := world.EntitySpawnOpts{Position: conf.Position, ID: id}.New(player.Type, conf)
 	s.SetHandle(handle, conf.Skin)
@@ -579,6 +623,10 @@ func (srv *Server) createWorld(dim world.Dimension, nether, end **world.World) *
 		RandomTickSpeed: srv.conf.RandomTickSpeed,
 		ReadOnly:        srv.conf.ReadOnlyWorld,
 		Entities:        srv.conf.Entities,
+		Profiling:       srv.conf.Profiling,
+		Metrics:         srv.conf.MetricsExporter != nil,
+		IOWorkers:       srv.conf.IOWorkers,
+		GenWorkers:      srv.conf.GenWorkers,
 		PortalDestination: func(dim world.Dimension) *world.World {
 			if dim == world.Nether {
 				return *nether
@@ -588,6 +636,9 @@ func (srv *Server) createWorld(dim world.Dimension, nether, end **world.World) *
 			return nil
 		},
 	}
+	if dim == world.Overworld {
+		conf.SpawnChunkRadius = srv.conf.SpawnChunkRadius
+	}
 	w := conf.New()
 	logger.Info("Opened dimension.", "name", w.Name())
 	return w
@@ -0,0 +1,120 @@
+package session
+
+import (
+	"container/list"
+	"sync"
+)
+
+// BlobStore holds the payloads of chunk blobs sent to clients that have the
+// client blob cache enabled, keyed by their hash. Clients that do not have a
+// blob cached request its payload after receiving its hash, so the payload is
+// held until the client has either reported the blob as cached or received
+// it. A BlobStore is shared between sessions, so that a blob sent to many
+// clients, such as the sub chunks around the spawn of a world, is only held
+// once. A nil BlobStore holds no blobs, which disables the client blob cache.
+// BlobStore is safe for concurrent use.
+type BlobStore struct {
+	budget int
+
+	mu     sync.Mutex
+	size   int
+	blobs  map[uint64]*storedBlob
+	unused *list.List
+}
+
+// storedBlob is a blob held by a BlobStore.
+type storedBlob struct {
+	hash uint64
+	data []byte
+	// refs is the amount of sessions that sent the hash of the blob to their
+	// client and still wait for the client to respond to it. The blob is only
+	// removed from the BlobStore when refs is 0.
+	refs int
+	// e is the element of the blob in the list of unused blobs, if refs
+	// is 0.
+	e *list.Element
+}
+
+// NewBlobStore creates a BlobStore that holds up to budget bytes of blobs.
+// Blobs that are no longer awaited by any client are kept until the budget is
+// reached, so that they may still be served to clients that request them
+// again. If the blobs that clients wait for exceed the budget, new blobs are
+// sent to clients without using the client blob cache until blobs are freed.
+func NewBlobStore(budget int) *BlobStore {
+	return &BlobStore{budget: budget, blobs: make(map[uint64]*storedBlob), unused: list.New()}
+}
+
+// acquire adds a reference to the blob with the hash passed, adding it to the
+// BlobStore if it did not yet hold it. False is returned if the blob could
+// not be added without exceeding the budget of the BlobStore.
+func (store *BlobStore) acquire(hash uint64, data []byte) bool {
+	if store == nil {
+		return false
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if b, ok := store.blobs[hash]; ok {
+		if b.refs == 0 {
+			store.unused.Remove(b.e)
+			b.e = nil
+		}
+		b.refs++
+		return true
+	}
+	for store.size+len(data) > store.budget && store.unused.Len() > 0 {
+		store.remove(store.unused.Back().Value.(*storedBlob))
+	}
+	if store.size+len(data) > store.budget {
+		return false
+	}
+	store.blobs[hash] = &storedBlob{hash: hash, data: data, refs: 1}
+	store.size += len(data)
+	return true
+}
+
+// release removes a reference to the blob with the hash passed that was
+// previously added using acquire. Once a blob has no references left, it may
+// be removed to make space for new blobs.
+func (store *BlobStore) release(hash uint64) {
+	if store == nil {
+		return
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	b, ok := store.blobs[hash]
+	if !ok || b.refs == 0 {
+		return
+	}
+	if b.refs--; b.refs == 0 {
+		b.e = store.unused.PushFront(b)
+	}
+}
+
+// blob returns the payload of the blob with the hash passed. False is
+// returned if the BlobStore does not hold the blob.
+func (store *BlobStore) blob(hash uint64) ([]byte, bool) {
+	if store == nil {
+		return nil, false
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	b, ok := store.blobs[hash]
+	if !ok {
+		return nil, false
+	}
+	if b.refs == 0 {
+		store.unused.MoveToFront(b.e)
+	}
+	return b.data, true
+}
+
+// remove removes an unreferenced blob from the BlobStore. store.mu must be
+// held while calling remove.
+func (store *BlobStore) remove(b *storedBlob) {
+	store.unused.Remove(b.e)
+	delete(store.blobs, b.hash)
+	store.size -= len(b.data)
+}
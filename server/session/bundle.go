@@ -0,0 +1,146 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// windowIDDynamic is the window ID with which the contents of dynamic
+// containers, such as bundles, are sent.
+const windowIDDynamic = 125
+
+// invByContainer returns the inventory that the container name passed refers
+// to. Bundles are referred to using a dynamic container ID equal to the ID of
+// the bundle. If no inventory is found, false is returned.
+func (s *Session) invByContainer(c protocol.FullContainerName, tx *world.Tx) (*inventory.Inventory, bool) {
+	if c.ContainerID != protocol.ContainerDynamic {
+		return s.invByID(int32(c.ContainerID), tx)
+	}
+	id, ok := c.DynamicContainerID.Value()
+	if !ok {
+		return nil, false
+	}
+	return s.bundleInv(int32(id))
+}
+
+// bundleInv returns an inventory holding the contents of the bundle with the
+// ID passed. The bundle must be held in the inventory, off-hand or cursor of
+// the player. Changes to the inventory are written back to the bundle.
+func (s *Session) bundleInv(id int32) (*inventory.Inventory, bool) {
+	_, _, it, ok := s.findBundle(id)
+	if !ok {
+		delete(s.bundles, id)
+		return nil, false
+	}
+	b := it.Item().(item.Bundle)
+	if inv, ok := s.bundles[id]; ok && bundleContentsEqual(inv.Items(), b.Contents) {
+		return inv, true
+	}
+	inv := inventory.New(item.BundleCapacity, nil)
+	for i, content := range b.Contents {
+		_ = inv.SetItem(i, content)
+	}
+	inv.SlotFunc(func(int, item.Stack, item.Stack) {
+		if holder, slot, it, ok := s.findBundle(id); ok {
+			b := it.Item().(item.Bundle)
+			b.Contents = inv.Items()
+			_ = holder.SetItem(slot, it.WithItem(b))
+		}
+	})
+	if s.bundles == nil {
+		s.bundles = map[int32]*inventory.Inventory{}
+	}
+	s.bundles[id] = inv
+	return inv, true
+}
+
+// findBundle looks for the bundle with the ID passed in the inventory,
+// off-hand and cursor of the player and returns the inventory and slot it is
+// in, along with the stack holding it.
+func (s *Session) findBundle(id int32) (*inventory.Inventory, int, item.Stack, bool) {
+	for _, inv := range []*inventory.Inventory{s.inv, s.offHand, s.ui} {
+		for slot, it := range inv.Slots() {
+			if b, ok := it.Item().(item.Bundle); ok && b.ID == id {
+				return inv, slot, it, true
+			}
+		}
+	}
+	return nil, 0, item.Stack{}, false
+}
+
+// verifyBundlePlacement checks if the stack passed may be placed in the slot
+// passed. If the slot is in a bundle, the bundle must have enough capacity
+// left to hold the stack.
+func (s *Session) verifyBundlePlacement(slot protocol.StackRequestSlotInfo, it item.Stack, tx *world.Tx) error {
+	if slot.Container.ContainerID != protocol.ContainerDynamic || it.Empty() {
+		return nil
+	}
+	inv, ok := s.invByContainer(slot.Container, tx)
+	if !ok {
+		return fmt.Errorf("could not find bundle with id %v", slot.Container.DynamicContainerID)
+	}
+	if !item.Bundleable(it) {
+		return fmt.Errorf("%v cannot be put in a bundle", it)
+	}
+	if b, ok := it.Item().(item.Bundle); ok && inv == s.bundles[b.ID] {
+		return fmt.Errorf("a bundle cannot be put in itself")
+	}
+	var others item.Bundle
+	for i, content := range inv.Slots() {
+		if i != int(slot.Slot) && !content.Empty() {
+			others.Contents = append(others.Contents, content)
+		}
+	}
+	if _, n := others.Insert(it); n != it.Count() {
+		return fmt.Errorf("bundle has capacity left for %v items, but %v were placed", n, it.Count())
+	}
+	return nil
+}
+
+// sendBundleContents sends the contents of the bundles held by the stacks
+// passed to the client, so that the contents can be shown in the inventory.
+func (s *Session) sendBundleContents(stacks ...item.Stack) {
+	for _, it := range stacks {
+		b, ok := it.Item().(item.Bundle)
+		if !ok {
+			continue
+		}
+		pk := &packet.InventoryContent{
+			WindowID:    windowIDDynamic,
+			Content:     make([]protocol.ItemInstance, item.BundleCapacity),
+			Container:   protocol.FullContainerName{ContainerID: protocol.ContainerDynamic, DynamicContainerID: protocol.Option(uint32(b.ID))},
+			StorageItem: instanceFromItem(it),
+		}
+		contents := b.Contents
+		if inv, ok := s.bundles[b.ID]; ok && bundleContentsEqual(inv.Items(), b.Contents) {
+			// Keep the layout that the client last saw.
+			contents = inv.Slots()
+		}
+		for i := range pk.Content {
+			var content item.Stack
+			if i < len(contents) {
+				content = contents[i]
+			}
+			pk.Content[i] = instanceFromItem(content)
+		}
+		s.writePacket(pk)
+	}
+}
+
+// bundleContentsEqual checks if two lists of non-empty stacks held by a bundle
+// are equal.
+func bundleContentsEqual(a, b []item.Stack) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Count() != b[i].Count() || !a[i].Comparable(b[i]) {
+			return false
+		}
+	}
+	return true
+}
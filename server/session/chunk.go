@@ -2,66 +2,133 @@ package session
 
 import (
 	"bytes"
+	"fmt"
+	"slices"
 
-	"github.com/cespare/xxhash/v2"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/chunk"
-	"github.com/sandertv/gophertunnel/minecraft/nbt"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 )
 
-// subChunkRequests is set to true to enable the sub-chunk request system. This can (likely) cause unexpected issues,
-// but also solves issues with block entities such as item frames and lecterns as of v1.19.10.
-const subChunkRequests = true
+const (
+	// maxQueuedSubChunks is the maximum amount of sub chunks that the client
+	// may have requested that the Session has not yet sent. Clients that
+	// request more sub chunks are disconnected.
+	maxQueuedSubChunks = 4096
+	// subChunksPerTick is the amount of requested sub chunks that the Session
+	// sends to the client every tick. Requests of the client that exceed it
+	// are queued and answered in the next ticks.
+	subChunksPerTick = 512
+)
 
 // ViewChunk ...
-func (s *Session) ViewChunk(pos world.ChunkPos, dim world.Dimension, blockEntities map[cube.Pos]world.Block, c *chunk.Chunk) {
-	if !s.conn.ClientCacheEnabled() {
-		s.sendNetworkChunk(pos, dim, c, blockEntities)
+func (s *Session) ViewChunk(pos world.ChunkPos, dim world.Dimension, col *world.Column) {
+	enc := s.conf.Chunks.chunk(col)
+	if !s.clientCache() {
+		s.sendNetworkChunk(pos, dim, col.Chunk, enc)
 		return
 	}
-	s.sendBlobHashes(pos, dim, c, blockEntities)
+	s.sendBlobHashes(pos, dim, col.Chunk, enc)
+}
+
+// queueSubChunkRequest queues a sub chunk request of the client to be
+// answered. An error is returned if the client has too many sub chunks
+// requested that were not yet sent.
+func (s *Session) queueSubChunkRequest(pk *packet.SubChunkRequest, tx *world.Tx) error {
+	if n := s.queuedSubChunks + len(pk.Offsets); n > maxQueuedSubChunks {
+		return fmt.Errorf("too many sub chunks requested: %v exceeds limit %v", n, maxQueuedSubChunks)
+	}
+	s.subChunkQueue = append(s.subChunkQueue, pk)
+	s.queuedSubChunks += len(pk.Offsets)
+	s.answerSubChunkRequests(tx)
+	return nil
+}
+
+// answerSubChunkRequests answers the queued sub chunk requests of the client
+// in the order they were received, until the amount of sub chunks that may
+// be sent in the current tick is used up.
+func (s *Session) answerSubChunkRequests(tx *world.Tx) {
+	for len(s.subChunkQueue) > 0 && s.subChunkBudget > 0 {
+		pk := s.subChunkQueue[0]
+		s.subChunkQueue[0] = nil
+		s.subChunkQueue = s.subChunkQueue[1:]
+		s.queuedSubChunks -= len(pk.Offsets)
+		s.subChunkBudget -= len(pk.Offsets)
+
+		if pk.Dimension != s.dimensionID(tx.World().Dimension()) {
+			// Outdated sub chunk request from a previous dimension.
+			s.writePacket(&packet.SubChunk{
+				Dimension:       pk.Dimension,
+				Position:        pk.Position,
+				CacheEnabled:    s.clientCache(),
+				SubChunkEntries: []protocol.SubChunkEntry{},
+			})
+			continue
+		}
+		s.ViewSubChunks(world.SubChunkPos(pk.Position), pk.Offsets, tx)
+	}
 }
 
 // ViewSubChunks ...
 func (s *Session) ViewSubChunks(center world.SubChunkPos, offsets []protocol.SubChunkOffset, tx *world.Tx) {
-	r := tx.Range()
+	s.writeSubChunks(center, offsets, tx.Range(), tx.World().Dimension())
+}
+
+// writeSubChunks writes the sub chunks at the offsets passed from the centre
+// sub chunk to the client. Sub chunks in chunks that the client has not
+// loaded are written as not found.
+func (s *Session) writeSubChunks(center world.SubChunkPos, offsets []protocol.SubChunkOffset, r cube.Range, dim world.Dimension) {
 
 	entries := make([]protocol.SubChunkEntry, 0, len(offsets))
-	transaction := make(map[uint64]struct{})
+	transaction := newChunkTransaction()
+	var (
+		lastCol *world.Column
+		enc     *encodedChunk
+	)
 	for _, offset := range offsets {
 		ind := int16(center.Y()) + int16(offset[1]) - int16(r[0])>>4
 		if ind < 0 || ind > int16(r.Height()>>4) {
 			entries = append(entries, protocol.SubChunkEntry{Result: protocol.SubChunkResultIndexOutOfBounds, Offset: offset})
 			continue
 		}
-		col, ok := s.chunkLoader.Chunk(world.ChunkPos{
-			center.X() + int32(offset[0]),
-			center.Z() + int32(offset[2]),
-		})
+		pos := world.SubChunkPos{center.X() + int32(offset[0]), center.Y() + int32(offset[1]), center.Z() + int32(offset[2])}
+		col, ok := s.chunkLoader.Chunk(world.ChunkPos{pos.X(), pos.Z()})
 		if !ok {
 			entries = append(entries, protocol.SubChunkEntry{Result: protocol.SubChunkResultChunkNotFound, Offset: offset})
 			continue
 		}
-		entries = append(entries, s.subChunkEntry(offset, ind, col, transaction))
+		if col != lastCol {
+			// Offsets generally hold many sub chunks of the same column, so
+			// the column is only looked up in the ChunkCache once for them.
+			lastCol, enc = col, s.conf.Chunks.chunk(col)
+		}
+		entry := s.subChunkEntry(offset, ind, col, enc)
+		if entry.BlobHash != 0 {
+			transaction.hashes[entry.BlobHash] = struct{}{}
+			transaction.subChunks[pos] = false
+		}
+		entries = append(entries, entry)
 	}
-	if s.conn.ClientCacheEnabled() && len(transaction) > 0 {
+	if len(transaction.hashes) > 0 {
 		s.blobMu.Lock()
 		s.openChunkTransactions = append(s.openChunkTransactions, transaction)
 		s.blobMu.Unlock()
 	}
-	dim, _ := world.DimensionID(tx.World().Dimension())
 	s.writePacket(&packet.SubChunk{
-		Dimension:       int32(dim),
+		Dimension:       s.dimensionID(dim),
 		Position:        protocol.SubChunkPos(center),
-		CacheEnabled:    s.conn.ClientCacheEnabled(),
+		CacheEnabled:    s.clientCache(),
 		SubChunkEntries: entries,
 	})
 }
 
-func (s *Session) subChunkEntry(offset protocol.SubChunkOffset, ind int16, col *world.Column, transaction map[uint64]struct{}) protocol.SubChunkEntry {
+// subChunkEntry returns an entry holding the sub chunk at the index passed in
+// the column passed, using the encodedChunk of the column. If the client blob
+// cache is used, the sub chunk is tracked as a blob and the hash of the blob
+// is set as BlobHash of the entry returned.
+func (s *Session) subChunkEntry(offset protocol.SubChunkOffset, ind int16, col *world.Column, enc *encodedChunk) protocol.SubChunkEntry {
 	chunkMap := col.Chunk.HeightMap()
 	subMapType, subMap := byte(protocol.HeightMapDataHasData), make([]int8, 256)
 	higher, lower := true, true
@@ -96,158 +163,254 @@ func (s *Session) subChunkEntry(offset protocol.SubChunkOffset, ind int16, col *
 		}
 	}
 
-	serialisedSubChunk := chunk.EncodeSubChunk(col.Chunk, chunk.NetworkEncoding, int(ind))
-
-	blockEntityBuf := bytes.NewBuffer(nil)
-	enc := nbt.NewEncoderWithEncoding(blockEntityBuf, nbt.NetworkLittleEndian)
-	for pos, b := range col.BlockEntities {
-		if n, ok := b.(world.NBTer); ok && col.Chunk.SubIndex(int16(pos.Y())) == ind {
-			d := n.EncodeNBT()
-			d["x"], d["y"], d["z"] = int32(pos[0]), int32(pos[1]), int32(pos[2])
-			_ = enc.Encode(d)
-		}
-	}
-
+	serialisedSubChunk, blockEntities := enc.subChunks[ind], enc.blockEntities[ind]
 	entry := protocol.SubChunkEntry{
 		Result:              protocol.SubChunkResultSuccess,
-		RawPayload:          append(serialisedSubChunk, blockEntityBuf.Bytes()...),
+		RawPayload:          slices.Concat(serialisedSubChunk, blockEntities),
 		HeightMapType:       subMapType,
 		HeightMapData:       subMap,
 		RenderHeightMapType: subMapType,
 		RenderHeightMapData: subMap,
 		Offset:              offset,
 	}
-	if s.conn.ClientCacheEnabled() {
-		if hash := xxhash.Sum64(serialisedSubChunk); s.trackBlob(hash, serialisedSubChunk) {
-			transaction[hash] = struct{}{}
-
+	if s.clientCache() {
+		if hash := enc.subHashes[ind]; s.trackBlob(hash, serialisedSubChunk) {
 			entry.BlobHash = hash
-			entry.RawPayload = blockEntityBuf.Bytes()
+			entry.RawPayload = blockEntities
 		}
 	}
 	return entry
 }
 
-// dimensionID returns the dimension ID of the world that the session is in.
+// dimensionID returns the ID of the dimension that the world.Dimension passed
+// is shown as to the client. Custom dimensions are shown as one of the
+// dimensions that the client knows.
 func (s *Session) dimensionID(dim world.Dimension) int32 {
-	d, _ := world.DimensionID(dim)
+	d, _ := world.DimensionID(world.ClientDimension(dim))
 	return int32(d)
 }
 
 // sendBlobHashes sends chunk blob hashes of the data of the chunk and stores the data in a map of blobs. Only
 // data that the client doesn't yet have will be sent over the network.
-func (s *Session) sendBlobHashes(pos world.ChunkPos, dim world.Dimension, c *chunk.Chunk, blockEntities map[cube.Pos]world.Block) {
-	if subChunkRequests {
-		biomes := chunk.EncodeBiomes(c, chunk.NetworkEncoding)
-		if hash := xxhash.Sum64(biomes); s.trackBlob(hash, biomes) {
+func (s *Session) sendBlobHashes(pos world.ChunkPos, dim world.Dimension, c *chunk.Chunk, enc *encodedChunk) {
+	if s.subChunkRequests {
+		if s.trackBlob(enc.biomeHash, enc.biomes) {
 			s.writePacket(&packet.LevelChunk{
 				Dimension:       s.dimensionID(dim),
 				SubChunkCount:   protocol.SubChunkRequestModeLimited,
 				Position:        protocol.ChunkPos(pos),
 				HighestSubChunk: c.HighestFilledSubChunk(),
-				BlobHashes:      []uint64{hash},
+				BlobHashes:      []uint64{enc.biomeHash},
 				RawPayload:      []byte{0},
 				CacheEnabled:    true,
 			})
 			return
 		}
+		s.sendNetworkChunk(pos, dim, c, enc)
+		return
 	}
 
 	var (
-		data   = chunk.Encode(c, chunk.NetworkEncoding)
-		count  = uint32(len(data.SubChunks))
-		blobs  = append(data.SubChunks, data.Biomes)
-		hashes = make([]uint64, len(blobs))
-		m      = make(map[uint64]struct{}, len(blobs))
+		hashes      = append(slices.Clone(enc.subHashes), enc.biomeHash)
+		blobs       = append(slices.Clone(enc.subChunks), enc.biomes)
+		transaction = newChunkTransaction()
 	)
-	for i, blob := range blobs {
-		h := xxhash.Sum64(blob)
-		hashes[i], m[h] = h, struct{}{}
-	}
-
-	s.blobMu.Lock()
-	s.openChunkTransactions = append(s.openChunkTransactions, m)
-	if l := len(s.blobs); l > 4096 {
-		s.blobMu.Unlock()
-		s.conf.Log.Error("too many blobs pending", "n", l)
+	if !s.trackBlobs(hashes, blobs) {
+		s.sendNetworkChunk(pos, dim, c, enc)
 		return
 	}
-	for i := range hashes {
-		s.blobs[hashes[i]] = blobs[i]
+	for _, hash := range hashes {
+		transaction.hashes[hash] = struct{}{}
 	}
+	s.blobMu.Lock()
+	s.openChunkTransactions = append(s.openChunkTransactions, transaction)
 	s.blobMu.Unlock()
 
-	// Length of 1 byte for the border block count.
-	raw := bytes.NewBuffer(make([]byte, 1, 32))
-	enc := nbt.NewEncoderWithEncoding(raw, nbt.NetworkLittleEndian)
-	for bp, b := range blockEntities {
-		if n, ok := b.(world.NBTer); ok {
-			d := n.EncodeNBT()
-			d["x"], d["y"], d["z"] = int32(bp[0]), int32(bp[1]), int32(bp[2])
-			_ = enc.Encode(d)
-		}
-	}
-
 	s.writePacket(&packet.LevelChunk{
 		Dimension:     s.dimensionID(dim),
 		Position:      protocol.ChunkPos{pos.X(), pos.Z()},
-		SubChunkCount: count,
+		SubChunkCount: uint32(len(enc.subChunks)),
 		CacheEnabled:  true,
 		BlobHashes:    hashes,
-		RawPayload:    raw.Bytes(),
+		// Length of 1 byte for the border block count.
+		RawPayload: append([]byte{0}, enc.allBlockEntities()...),
 	})
 }
 
 // sendNetworkChunk sends a network encoded chunk to the client.
-func (s *Session) sendNetworkChunk(pos world.ChunkPos, dim world.Dimension, c *chunk.Chunk, blockEntities map[cube.Pos]world.Block) {
-	if subChunkRequests {
+func (s *Session) sendNetworkChunk(pos world.ChunkPos, dim world.Dimension, c *chunk.Chunk, enc *encodedChunk) {
+	if s.subChunkRequests {
 		s.writePacket(&packet.LevelChunk{
 			Dimension:       s.dimensionID(dim),
 			SubChunkCount:   protocol.SubChunkRequestModeLimited,
 			Position:        protocol.ChunkPos(pos),
 			HighestSubChunk: c.HighestFilledSubChunk(),
-			RawPayload:      append(chunk.EncodeBiomes(c, chunk.NetworkEncoding), 0),
+			RawPayload:      append(slices.Clone(enc.biomes), 0),
 		})
 		return
 	}
 
-	data := chunk.Encode(c, chunk.NetworkEncoding)
 	chunkBuf := bytes.NewBuffer(nil)
-	for _, s := range data.SubChunks {
+	for _, s := range enc.subChunks {
 		_, _ = chunkBuf.Write(s)
 	}
-	_, _ = chunkBuf.Write(data.Biomes)
+	_, _ = chunkBuf.Write(enc.biomes)
 
 	// Length of 1 byte for the border block count.
 	chunkBuf.WriteByte(0)
-
-	enc := nbt.NewEncoderWithEncoding(chunkBuf, nbt.NetworkLittleEndian)
-	for bp, b := range blockEntities {
-		if n, ok := b.(world.NBTer); ok {
-			d := n.EncodeNBT()
-			d["x"], d["y"], d["z"] = int32(bp[0]), int32(bp[1]), int32(bp[2])
-			_ = enc.Encode(d)
-		}
+	for _, b := range enc.blockEntities {
+		_, _ = chunkBuf.Write(b)
 	}
 
 	s.writePacket(&packet.LevelChunk{
 		Dimension:     s.dimensionID(dim),
 		Position:      protocol.ChunkPos{pos.X(), pos.Z()},
-		SubChunkCount: uint32(len(data.SubChunks)),
-		RawPayload:    append([]byte(nil), chunkBuf.Bytes()...),
+		SubChunkCount: uint32(len(enc.subChunks)),
+		RawPayload:    chunkBuf.Bytes(),
 	})
 }
 
-// trackBlob attempts to track the given blob. If the player has too many pending blobs, it returns false and closes the
-// connection.
+// clientCache checks if chunks are sent to the client using the client blob
+// cache. This is the case if the client supports it and the Session has a
+// BlobStore.
+func (s *Session) clientCache() bool {
+	return s.conf.Blobs != nil && s.conn.ClientCacheEnabled()
+}
+
+// chunkTransaction is a set of blobs sent to the client in a single packet
+// that the client has not yet reported as cached or requested.
+type chunkTransaction struct {
+	hashes map[uint64]struct{}
+	// subChunks holds the positions of the sub chunks that the blobs were
+	// encoded from. The value of a position is true if a block in the sub
+	// chunk changed after the blobs were sent.
+	subChunks map[world.SubChunkPos]bool
+}
+
+// newChunkTransaction creates an empty chunkTransaction.
+func newChunkTransaction() *chunkTransaction {
+	return &chunkTransaction{hashes: make(map[uint64]struct{}), subChunks: make(map[world.SubChunkPos]bool)}
+}
+
+// trackBlob attempts to track the given blob until the client reports it as
+// cached or requests it. If the client has too many pending blobs or the
+// BlobStore of the Session is full, false is returned and the blob must be
+// sent to the client directly instead.
 func (s *Session) trackBlob(hash uint64, blob []byte) bool {
 	s.blobMu.Lock()
+	defer s.blobMu.Unlock()
+	ok, _ := s.addBlob(hash, blob)
+	return ok
+}
+
+// trackBlobs attempts to track all blobs passed, like trackBlob. If any of the
+// blobs cannot be tracked, the blobs that were tracked by this call are
+// released again and false is returned, so that either all or none of the
+// blobs are sent to the client as blob hashes.
+func (s *Session) trackBlobs(hashes []uint64, blobs [][]byte) bool {
+	s.blobMu.Lock()
+	defer s.blobMu.Unlock()
+	added := make([]uint64, 0, len(hashes))
+	for i, blob := range blobs {
+		ok, isNew := s.addBlob(hashes[i], blob)
+		if !ok {
+			for _, hash := range added {
+				delete(s.blobs, hash)
+				s.conf.Blobs.release(hash)
+			}
+			return false
+		}
+		if isNew {
+			added = append(added, hashes[i])
+		}
+	}
+	return true
+}
+
+// addBlob tracks the blob passed if it is not yet tracked. It returns false if
+// the blob could not be tracked, and true as second value if the blob was not
+// tracked before. s.blobMu must be held while calling addBlob.
+func (s *Session) addBlob(hash uint64, blob []byte) (ok, isNew bool) {
+	if _, ok := s.blobs[hash]; ok {
+		return true, false
+	}
 	if l := len(s.blobs); l > 4096 {
-		s.blobMu.Unlock()
 		s.conf.Log.Error("too many blobs pending", "n", l)
-		return false
+		return false, false
 	}
-	s.blobs[hash] = blob
-	s.blobMu.Unlock()
-	return true
+	if !s.conf.Blobs.acquire(hash, blob) {
+		return false, false
+	}
+	s.blobs[hash] = struct{}{}
+	return true, true
+}
+
+// resolveBlob resolves a blob hash reported by the client as cached or
+// requested, removing it from all open chunk transactions and releasing it
+// from the BlobStore. The positions of sub chunks that changed in the
+// transactions that were fully resolved as a result are appended to changed.
+// s.blobMu must be held while calling resolveBlob.
+func (s *Session) resolveBlob(hash uint64, changed []world.SubChunkPos) []world.SubChunkPos {
+	leftover := s.openChunkTransactions[:0]
+	for _, t := range s.openChunkTransactions {
+		delete(t.hashes, hash)
+		if len(t.hashes) != 0 {
+			leftover = append(leftover, t)
+			continue
+		}
+		for pos, c := range t.subChunks {
+			if c && !slices.Contains(changed, pos) {
+				changed = append(changed, pos)
+			}
+		}
+	}
+	clear(s.openChunkTransactions[len(leftover):])
+	s.openChunkTransactions = leftover
+	if _, ok := s.blobs[hash]; ok {
+		delete(s.blobs, hash)
+		s.conf.Blobs.release(hash)
+	}
+	return changed
+}
+
+// markSubChunkChanged marks the sub chunk at the position passed as changed
+// in all open chunk transactions that hold it. The client may drop block
+// updates for sub chunks of which it has not yet received all blobs, so these
+// sub chunks are sent again once their transaction is resolved.
+func (s *Session) markSubChunkChanged(pos world.SubChunkPos) {
+	if !s.clientCache() {
+		return
+	}
+	s.blobMu.Lock()
+	defer s.blobMu.Unlock()
+	for _, t := range s.openChunkTransactions {
+		if _, ok := t.subChunks[pos]; ok {
+			t.subChunks[pos] = true
+		}
+	}
+}
+
+// resendSubChunks sends the sub chunks at the positions passed to the client
+// again.
+func (s *Session) resendSubChunks(subs []world.SubChunkPos) {
+	if !s.subChunkRequests || len(subs) == 0 {
+		return
+	}
+	w := s.chunkLoader.World()
+	for _, pos := range subs {
+		s.writeSubChunks(pos, []protocol.SubChunkOffset{{}}, w.Range(), w.Dimension())
+	}
+}
+
+// releaseBlobs releases all blobs that the client has not yet reported and
+// discards all open chunk transactions, for example when the client changes
+// worlds or disconnects.
+func (s *Session) releaseBlobs() {
+	s.blobMu.Lock()
+	defer s.blobMu.Unlock()
+	for hash := range s.blobs {
+		s.conf.Blobs.release(hash)
+	}
+	clear(s.blobs)
+	s.openChunkTransactions = nil
 }
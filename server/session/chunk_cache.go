@@ -0,0 +1,139 @@
+package session
+
+import (
+	"bytes"
+	"container/list"
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// ChunkCache holds chunks encoded to be sent over the network, keyed by the
+// revision of the world.Column they were encoded from. The encoding of a
+// chunk is the same for every client, so a ChunkCache is shared between
+// sessions and a chunk viewed by many sessions, such as a chunk around the
+// spawn of a world, is only encoded once for as long as it does not change.
+// The least recently used chunks are removed once the ChunkCache exceeds its
+// budget. A nil ChunkCache holds no chunks, in which case chunks are encoded
+// every time they are sent. ChunkCache is safe for concurrent use.
+type ChunkCache struct {
+	budget int
+
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[uint64]*list.Element
+}
+
+// cachedChunk is an entry in a ChunkCache.
+type cachedChunk struct {
+	revision uint64
+	c        *encodedChunk
+	size     int
+}
+
+// NewChunkCache creates a ChunkCache that holds up to budget bytes of encoded
+// chunks.
+func NewChunkCache(budget int) *ChunkCache {
+	return &ChunkCache{budget: budget, order: list.New(), entries: make(map[uint64]*list.Element)}
+}
+
+// chunk returns the world.Column passed encoded to be sent over the network.
+// If the ChunkCache holds an encoding of the same revision of the Column, it
+// is returned instead of encoding the Column again.
+func (cache *ChunkCache) chunk(col *world.Column) *encodedChunk {
+	if cache == nil {
+		return encodeChunk(col)
+	}
+	rev := col.Revision()
+	cache.mu.Lock()
+	if e, ok := cache.entries[rev]; ok {
+		cache.order.MoveToFront(e)
+		cache.mu.Unlock()
+		return e.Value.(cachedChunk).c
+	}
+	cache.mu.Unlock()
+
+	// The Column is encoded without holding the lock, so that sessions in
+	// other worlds are not held up by it.
+	c := encodeChunk(col)
+	size := c.size()
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if _, ok := cache.entries[rev]; ok || size > cache.budget {
+		return c
+	}
+	cache.entries[rev] = cache.order.PushFront(cachedChunk{revision: rev, c: c, size: size})
+	cache.size += size
+	for cache.size > cache.budget {
+		e := cache.order.Back()
+		cache.size -= e.Value.(cachedChunk).size
+		cache.order.Remove(e)
+		delete(cache.entries, e.Value.(cachedChunk).revision)
+	}
+	return c
+}
+
+// encodedChunk is a world.Column encoded to be sent over the network. An
+// encodedChunk is shared between sessions, so neither it nor any of the
+// slices it holds may be changed after it was created.
+type encodedChunk struct {
+	// subChunks holds the network encoding of every sub chunk and subHashes
+	// the hashes of these encodings, which are used as their blob hashes.
+	subChunks [][]byte
+	subHashes []uint64
+	// biomes holds the network encoding of the biomes and biomeHash its hash.
+	biomes    []byte
+	biomeHash uint64
+	// blockEntities holds the network NBT of the block entities in every sub
+	// chunk.
+	blockEntities [][]byte
+}
+
+// encodeChunk encodes the world.Column passed to be sent over the network.
+func encodeChunk(col *world.Column) *encodedChunk {
+	data := chunk.Encode(col.Chunk, chunk.NetworkEncoding)
+	c := &encodedChunk{
+		subChunks:     data.SubChunks,
+		subHashes:     make([]uint64, len(data.SubChunks)),
+		biomes:        data.Biomes,
+		biomeHash:     xxhash.Sum64(data.Biomes),
+		blockEntities: make([][]byte, len(data.SubChunks)),
+	}
+	for i, sub := range data.SubChunks {
+		c.subHashes[i] = xxhash.Sum64(sub)
+	}
+	buf := bytes.NewBuffer(nil)
+	enc := nbt.NewEncoderWithEncoding(buf, nbt.NetworkLittleEndian)
+	for pos, b := range col.BlockEntities {
+		if n, ok := b.(world.NBTer); ok {
+			d := n.EncodeNBT()
+			d["x"], d["y"], d["z"] = int32(pos[0]), int32(pos[1]), int32(pos[2])
+			_ = enc.Encode(d)
+
+			ind := col.Chunk.SubIndex(int16(pos[1]))
+			c.blockEntities[ind] = append(c.blockEntities[ind], buf.Bytes()...)
+			buf.Reset()
+		}
+	}
+	return c
+}
+
+// allBlockEntities returns the network NBT of all block entities in the
+// chunk.
+func (c *encodedChunk) allBlockEntities() []byte {
+	return bytes.Join(c.blockEntities, nil)
+}
+
+// size returns the approximate amount of bytes taken up by the encodedChunk.
+func (c *encodedChunk) size() int {
+	n := len(c.biomes) + len(c.subHashes)*8
+	for i := range c.subChunks {
+		n += len(c.subChunks[i]) + len(c.blockEntities[i])
+	}
+	return n
+}
@@ -0,0 +1,240 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// virtualContainerRange is the maximum distance in blocks between a player and
+// the fake block of a virtual container before the container is closed.
+const virtualContainerRange = 8
+
+// ContainerType is the type of a virtual container opened using
+// Session.OpenContainer. It determines what the container looks like to the
+// client and how many slots it has.
+type ContainerType struct {
+	containerType
+}
+
+// ContainerChest returns the ContainerType of a chest, which has 27 slots.
+func ContainerChest() ContainerType {
+	return ContainerType{0}
+}
+
+// ContainerDoubleChest returns the ContainerType of a double chest, which has
+// 54 slots.
+func ContainerDoubleChest() ContainerType {
+	return ContainerType{1}
+}
+
+// ContainerBarrel returns the ContainerType of a barrel, which has 27 slots.
+func ContainerBarrel() ContainerType {
+	return ContainerType{2}
+}
+
+// ContainerHopper returns the ContainerType of a hopper, which has 5 slots.
+func ContainerHopper() ContainerType {
+	return ContainerType{3}
+}
+
+type containerType uint8
+
+// Size returns the amount of slots that a container of the ContainerType has.
+func (t containerType) Size() int {
+	switch t {
+	case 1:
+		return 54
+	case 3:
+		return 5
+	}
+	return 27
+}
+
+// String ...
+func (t containerType) String() string {
+	switch t {
+	case 0:
+		return "chest"
+	case 1:
+		return "double chest"
+	case 2:
+		return "barrel"
+	case 3:
+		return "hopper"
+	}
+	panic("should never happen")
+}
+
+// windowType returns the protocol container type used to open a window of the
+// ContainerType.
+func (t containerType) windowType() byte {
+	if t == 3 {
+		return protocol.ContainerTypeHopper
+	}
+	return protocol.ContainerTypeContainer
+}
+
+// block returns the fake block sent to the client to display a container of
+// the ContainerType, along with the ID of its block entity.
+func (t containerType) block() (world.Block, string) {
+	switch t {
+	case 2:
+		return block.Barrel{Facing: cube.FaceUp}, "Barrel"
+	case 3:
+		return block.Hopper{Facing: cube.FaceDown}, "Hopper"
+	}
+	return block.Chest{}, "Chest"
+}
+
+// virtualContainer is a container opened using Session.OpenContainer. It is not
+// backed by a block in the world, but displayed using fake blocks that only
+// exist client-side.
+type virtualContainer struct {
+	// blocks holds the positions of the fake blocks sent to the client.
+	blocks []cube.Pos
+}
+
+// OpenContainer opens a virtual container of the ContainerType passed,
+// showing the contents of the inventory passed. The container is not backed by
+// a block in the world: It is displayed using a fake block sent to the client
+// close to its controllable, which is replaced by the actual block again once
+// the container is closed. The container is closed automatically if the
+// controllable moves too far away from it.
+// If inv is already opened, its contents are sent to the client again, so that
+// changes made to inv while it is opened can be shown. OpenContainer panics if
+// the size of inv does not match that of the ContainerType.
+func (s *Session) OpenContainer(inv *inventory.Inventory, t ContainerType, tx *world.Tx) {
+	if inv.Size() != t.Size() {
+		panic(fmt.Sprintf("open container: inventory of size %v cannot be opened as %v with size %v", inv.Size(), t, t.Size()))
+	}
+	if s.containerOpened.Load() && s.openedVirtual.Load() != nil && s.openedWindow.Load() == inv {
+		s.sendInv(inv, s.openedWindowID.Load())
+		return
+	}
+	s.closeCurrentContainer(tx)
+
+	c, ok := s.controllable(tx)
+	if !ok {
+		return
+	}
+	pos := cube.PosFromVec3(c.Position()).Sub(cube.Pos{0, 2, 0})
+	pos[1] = max(min(pos[1], tx.Range().Max()), tx.Range().Min())
+	if c.ContainerOpening(inv, pos) != nil {
+		return
+	}
+
+	v := &virtualContainer{blocks: []cube.Pos{pos}}
+	if t == ContainerDoubleChest() {
+		v.blocks = append(v.blocks, pos.Side(cube.FaceEast))
+	}
+	b, id := t.block()
+	for i, p := range v.blocks {
+		blockPos := protocol.BlockPos{int32(p[0]), int32(p[1]), int32(p[2])}
+		nbtData := map[string]any{"id": id, "x": int32(p[0]), "y": int32(p[1]), "z": int32(p[2])}
+		if len(v.blocks) == 2 {
+			// The two halves of a double chest must be paired to be displayed
+			// as a single container.
+			pair := v.blocks[1-i]
+			nbtData["pairx"], nbtData["pairz"], nbtData["pairlead"] = int32(pair[0]), int32(pair[2]), boolByte(i == 0)
+		}
+		s.writePacket(&packet.UpdateBlock{
+			Position:          blockPos,
+			NewBlockRuntimeID: world.BlockRuntimeID(b),
+			Flags:             packet.BlockUpdateNetwork,
+		})
+		s.writePacket(&packet.BlockActorData{Position: blockPos, NBTData: nbtData})
+	}
+
+	nextID := s.nextWindowID()
+	s.containerOpened.Store(true)
+	s.openedWindow.Store(inv)
+	s.openedPos.Store(&pos)
+	s.openedVirtual.Store(v)
+	s.openedContainerID.Store(uint32(t.windowType()))
+	s.writePacket(&packet.ContainerOpen{
+		WindowID:                nextID,
+		ContainerType:           t.windowType(),
+		ContainerPosition:       protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
+		ContainerEntityUniqueID: -1,
+	})
+	s.sendInv(inv, uint32(nextID))
+}
+
+// CloseContainer closes the container that the session currently has opened.
+// If no container is opened, CloseContainer does nothing.
+func (s *Session) CloseContainer(tx *world.Tx) {
+	s.closeCurrentContainer(tx)
+}
+
+// closeDistantContainer closes the virtual container that the session has
+// opened if the Controllable passed moved too far away from it.
+func (s *Session) closeDistantContainer(tx *world.Tx, c Controllable) {
+	if !s.containerOpened.Load() || s.openedVirtual.Load() == nil {
+		return
+	}
+	if s.openedPos.Load().Vec3Centre().Sub(c.Position()).Len() > virtualContainerRange {
+		s.closeCurrentContainer(tx)
+	}
+}
+
+// reopenContainer opens the container currently opened by the session again
+// after the client closed it, for example because closing the container was
+// cancelled.
+func (s *Session) reopenContainer(tx *world.Tx) {
+	windowID, pos := byte(s.openedWindowID.Load()), *s.openedPos.Load()
+	s.writePacket(&packet.ContainerClose{WindowID: windowID})
+
+	entityID := int64(-1)
+	if h := s.openedEntity.Load(); h != nil {
+		entityID = int64(s.handleRuntimeID(h))
+	}
+	s.writePacket(&packet.ContainerOpen{
+		WindowID:                windowID,
+		ContainerType:           byte(s.openedContainerID.Load()),
+		ContainerPosition:       protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
+		ContainerEntityUniqueID: entityID,
+	})
+	if inv := s.openedInventory(tx); inv != nil {
+		s.sendInv(inv, uint32(windowID))
+	}
+}
+
+// openedInventory returns the inventory of the container currently opened by
+// the session, or nil if the container does not hold items of its own, such as
+// a crafting table.
+func (s *Session) openedInventory(tx *world.Tx) *inventory.Inventory {
+	if s.openedEntity.Load() != nil || s.openedVirtual.Load() != nil {
+		return s.openedWindow.Load()
+	}
+	switch s.openedBlock(tx).(type) {
+	case block.Container, block.EnderChest:
+		return s.openedWindow.Load()
+	}
+	return nil
+}
+
+// openedBlock returns the block of the container currently opened by the
+// session. Air is returned for virtual containers, which are not backed by a
+// block in the world.
+func (s *Session) openedBlock(tx *world.Tx) world.Block {
+	if s.openedVirtual.Load() != nil {
+		return block.Air{}
+	}
+	return tx.Block(*s.openedPos.Load())
+}
+
+// controllable returns the Controllable of the session if it is in the world
+// of the transaction passed.
+func (s *Session) controllable(tx *world.Tx) (Controllable, bool) {
+	e, ok := s.ent.Entity(tx)
+	if !ok {
+		return nil, false
+	}
+	c, ok := e.(Controllable)
+	return c, ok
+}
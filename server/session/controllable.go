@@ -3,6 +3,7 @@ package session
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
@@ -57,12 +58,14 @@ type Controllable interface {
 	PickBlock(pos cube.Pos)
 	AttackEntity(e world.Entity) bool
 	Drop(s item.Stack) (n int)
+	MoveItem(it item.Stack, src *inventory.Inventory, srcSlot int, dst *inventory.Inventory, dstSlot int) error
 	SwingArm()
 	PunchAir()
 
 	Health() float64
 	MaxHealth() float64
 	Absorption() float64
+	Attributes() *entity.Attributes
 	Food() int
 
 	ExperienceLevel() int
@@ -94,6 +97,7 @@ type Controllable interface {
 	Gliding() bool
 	StopGliding()
 	Jump()
+	Dismount()
 
 	StartBreaking(pos cube.Pos, face cube.Face)
 	ContinueBreaking(face cube.Face)
@@ -106,6 +110,8 @@ type Controllable interface {
 	EditSign(pos cube.Pos, frontText, backText string) error
 	TurnLecternPage(pos cube.Pos, page int) error
 
+	ContainerOpening(inv *inventory.Inventory, pos cube.Pos) error
+	ContainerClosing(inv *inventory.Inventory, pos cube.Pos, cancellable bool) error
 	EnderChestInventory() *inventory.Inventory
 	MoveItemsToInventory()
 
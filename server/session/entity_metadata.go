@@ -1,15 +1,18 @@
 package session
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/player/bossbar"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 	"math"
 	"time"
 )
@@ -34,13 +37,84 @@ func (s *Session) parseEntityMetadata(e world.Entity) protocol.EntityMetadata {
 	if e.H().Type() == entity.LingeringPotionType {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagLingering)
 	}
+	s.entityMutex.RLock()
+	offset, riding := s.seatOffsets[e.H()]
+	s.entityMutex.RUnlock()
+	if riding {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagRiding)
+		m[protocol.EntityDataKeySeatOffset] = vec64To32(offset)
+	}
 	s.addSpecificMetadata(e, m)
-	if ent, ok := e.(*entity.Ent); ok {
+	if ent, ok := e.(interface{ Behaviour() entity.Behaviour }); ok {
 		s.addSpecificMetadata(ent.Behaviour(), m)
 	}
+	if md, ok := e.(customMetadata); ok {
+		addCustomMetadata(md.Metadata(), m)
+	}
 	return m
 }
 
+// entityFlags maps the flags that may be set in a world.EntityMetadata to the
+// flags in the entity metadata sent to clients.
+var entityFlags = [...]uint8{
+	world.EntityFlagOnFire:      protocol.EntityDataFlagOnFire,
+	world.EntityFlagSneaking:    protocol.EntityDataFlagSneaking,
+	world.EntityFlagSprinting:   protocol.EntityDataFlagSprinting,
+	world.EntityFlagInvisible:   protocol.EntityDataFlagInvisible,
+	world.EntityFlagInLove:      protocol.EntityDataFlagInLove,
+	world.EntityFlagSaddled:     protocol.EntityDataFlagSaddled,
+	world.EntityFlagPowered:     protocol.EntityDataFlagPowered,
+	world.EntityFlagIgnited:     protocol.EntityDataFlagIgnited,
+	world.EntityFlagBaby:        protocol.EntityDataFlagBaby,
+	world.EntityFlagSilent:      protocol.EntityDataFlagSilent,
+	world.EntityFlagSitting:     protocol.EntityDataFlagSitting,
+	world.EntityFlagAngry:       protocol.EntityDataFlagAngry,
+	world.EntityFlagTamed:       protocol.EntityDataFlagTamed,
+	world.EntityFlagSheared:     protocol.EntityDataFlagSheared,
+	world.EntityFlagElder:       protocol.EntityDataFlagElder,
+	world.EntityFlagChested:     protocol.EntityDataFlagChested,
+	world.EntityFlagDancing:     protocol.EntityDataFlagDancing,
+	world.EntityFlagCelebrating: protocol.EntityDataFlagCelebrating,
+}
+
+// addCustomMetadata adds the custom metadata set on an entity to the entity
+// metadata m, overriding any values that were already set.
+func addCustomMetadata(md world.EntityMetadata, m protocol.EntityMetadata) {
+	for flag, value := range md.Flags() {
+		key, index := uint32(protocol.EntityDataKeyFlags), entityFlags[flag]
+		if index >= 64 {
+			key, index = protocol.EntityDataKeyFlagsTwo, index&63
+		}
+		if m.Flag(key, index) != value {
+			// SetFlag toggles the flag, so it is only called if the flag does
+			// not yet have the value it is overridden with.
+			m.SetFlag(key, index)
+		}
+	}
+	for key, value := range md.Ints() {
+		switch key {
+		case world.EntityVariant:
+			m[protocol.EntityDataKeyVariant] = int32(value)
+		case world.EntityMarkVariant:
+			m[protocol.EntityDataKeyMarkVariant] = int32(value)
+		case world.EntityColour:
+			m[protocol.EntityDataKeyColorIndex] = byte(value)
+		case world.EntityColour2:
+			m[protocol.EntityDataKeyColorTwoIndex] = byte(value)
+		}
+	}
+	for key, value := range md.Floats() {
+		switch key {
+		case world.EntityScale:
+			m[protocol.EntityDataKeyScale] = float32(value)
+		case world.EntityWidth:
+			m[protocol.EntityDataKeyWidth] = float32(value)
+		case world.EntityHeight:
+			m[protocol.EntityDataKeyHeight] = float32(value)
+		}
+	}
+}
+
 func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if sn, ok := e.(sneaker); ok && sn.Sneaking() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSneaking)
@@ -103,10 +177,18 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagIgnited)
 	}
 	if n, ok := e.(named); ok {
-		m[protocol.EntityDataKeyName] = n.NameTag()
-		m[protocol.EntityDataKeyAlwaysShowNameTag] = uint8(1)
-		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagAlwaysShowName)
-		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagShowName)
+		name := n.NameTag()
+		if v, ok := e.(nameTagVisibility); ok && !v.NameTagVisible() {
+			// The name is not sent at all if it is hidden, as players have
+			// their name tag shown regardless of the flags below.
+			name = ""
+		}
+		m[protocol.EntityDataKeyName] = name
+		if name != "" {
+			m[protocol.EntityDataKeyAlwaysShowNameTag] = uint8(1)
+			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagAlwaysShowName)
+			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagShowName)
+		}
 	}
 	if sc, ok := e.(scoreTag); ok {
 		m[protocol.EntityDataKeyScore] = sc.ScoreTag()
@@ -131,9 +213,8 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if l, ok := e.(living); ok && s.ent.UUID() == l.UUID() {
 		deathPos, deathDimension, died := l.DeathPosition()
 		if died {
-			dim, _ := world.DimensionID(deathDimension)
 			m[protocol.EntityDataKeyPlayerLastDeathPosition] = vec64To32(deathPos)
-			m[protocol.EntityDataKeyPlayerLastDeathDimension] = int32(dim)
+			m[protocol.EntityDataKeyPlayerLastDeathDimension] = s.dimensionID(deathDimension)
 		}
 		m[protocol.EntityDataKeyPlayerHasDied] = boolByte(died)
 	}
@@ -166,6 +247,127 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if mv, ok := e.(markVariable); ok {
 		m[protocol.EntityDataKeyMarkVariant] = mv.MarkVariant()
 	}
+	if c, ok := e.(twoColoured); ok {
+		base, pattern := c.Colours()
+		m[protocol.EntityDataKeyColorIndex] = base.Uint8()
+		m[protocol.EntityDataKeyColorTwoIndex] = pattern.Uint8()
+	}
+	if p, ok := e.(puffer); ok {
+		m[protocol.EntityDataKeyPuffedState] = byte(p.PuffState())
+	}
+	if c, ok := e.(converting); ok && c.Converting() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagShaking)
+	}
+	if t, ok := e.(tameable); ok && t.Tamed() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagTamed)
+		if c, ok := e.(collared); ok {
+			m[protocol.EntityDataKeyColorIndex] = c.CollarColour().Uint8()
+		}
+	}
+	if si, ok := e.(sitter); ok && si.Sitting() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSitting)
+	}
+	if a, ok := e.(angry); ok && a.Angry() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagAngry)
+	}
+	if sw, ok := e.(swelling); ok && sw.Swelling() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagIgnited)
+	}
+	if c, ok := e.(charging); ok && c.Charging() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagCharging)
+	}
+	if c, ok := e.(charged); ok && c.Charged() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagPowered)
+	}
+	if c, ok := e.(blockCarrier); ok {
+		if b, ok := c.CarriedBlock(); ok {
+			m[protocol.EntityDataKeyCarryBlockRuntimeID] = int32(world.BlockRuntimeID(b))
+		}
+	}
+	if b, ok := e.(beamer); ok {
+		if pos, ok := b.BeamTarget(); ok {
+			m[protocol.EntityDataKeyBlockTarget] = protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
+		}
+	}
+	if b, ok := e.(baseShower); ok && b.ShowBase() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagShowBottom)
+	}
+	if d, ok := e.(blockDisplayer); ok {
+		if b, ok := d.DisplayBlock(); ok {
+			m[protocol.EntityDataKeyDisplayTileRuntimeID] = int32(world.BlockRuntimeID(b))
+			m[protocol.EntityDataKeyDisplayOffset] = int32(6)
+			m[protocol.EntityDataKeyCustomDisplay] = byte(1)
+		}
+	}
+	if h, ok := e.(shakeable); ok {
+		ticks, direction := h.HurtTime()
+		m[protocol.EntityDataKeyHurt] = int32(ticks)
+		m[protocol.EntityDataKeyHurtDirection] = int32(direction)
+	}
+	if w, ok := e.(woolly); ok {
+		m[protocol.EntityDataKeyColorIndex] = w.Colour().Uint8()
+		if w.Sheared() {
+			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSheared)
+		}
+	}
+	if b, ok := e.(baby); ok && b.Baby() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagBaby)
+	}
+	if l, ok := e.(lover); ok && l.InLove() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagInLove)
+	}
+	if d, ok := e.(dancer); ok && d.Dancing() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagDancing)
+	}
+	if p, ok := e.(deadPlayer); ok && p.PlayingDead() {
+		m.SetFlag(protocol.EntityDataKeyFlagsTwo, protocol.EntityDataFlagPlayingDead&63)
+	}
+	if t, ok := e.(tongued); ok {
+		if h, ok := t.TongueTarget(); ok && h != nil {
+			m[protocol.EntityDataKeyTarget] = int64(s.handleRuntimeID(h))
+		}
+	}
+	if sh, ok := e.(shulker); ok {
+		m[protocol.EntityDataKeyAttachFace] = byte(sh.AttachFace())
+		m[protocol.EntityDataKeyPeekID] = int32(sh.Peek())
+	}
+	if w, ok := e.(wither); ok {
+		m[protocol.EntityDataKeyInvulnerableTicks] = int32(w.InvulnerableTicks())
+		for i, key := range []uint32{protocol.EntityDataKeyTargetA, protocol.EntityDataKeyTargetB, protocol.EntityDataKeyTargetC} {
+			var id int64
+			if t := w.HeadTargets()[i]; t != nil {
+				id = int64(s.handleRuntimeID(t))
+			}
+			m[key] = id
+		}
+		if w.Armoured() {
+			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagPowered)
+		}
+	}
+	if w, ok := e.(warden); ok {
+		m[protocol.EntityDataKeyHeartbeatIntervalTicks] = int32(w.HeartbeatInterval())
+		m[protocol.EntityDataKeyHeartbeatSoundEvent] = int32(packet.SoundEventHeartbeat)
+		if w.Emerging() {
+			m.SetFlag(protocol.EntityDataKeyFlagsTwo, protocol.EntityDataFlagEmerging&63)
+		}
+		if w.Digging() {
+			m.SetFlag(protocol.EntityDataKeyFlagsTwo, protocol.EntityDataFlagDigging&63)
+		}
+		if w.Roaring() {
+			m.SetFlag(protocol.EntityDataKeyFlagsTwo, protocol.EntityDataFlagRoaring&63)
+		}
+		if w.Sniffing() {
+			m.SetFlag(protocol.EntityDataKeyFlagsTwo, protocol.EntityDataFlagSniffing&63)
+		}
+		if w.SonicBooming() {
+			m.SetFlag(protocol.EntityDataKeyFlagsTwo, protocol.EntityDataFlagSonicBoom&63)
+		}
+	}
+	if t, ok := e.(tradeLevelled); ok {
+		m[protocol.EntityDataKeyTradeTier] = int32(t.TradeTier())
+		m[protocol.EntityDataKeyMaxTradeTier] = int32(4)
+		m[protocol.EntityDataKeyTradeExperience] = int32(t.TradeExperience())
+	}
 }
 
 type sneaker interface {
@@ -194,6 +396,10 @@ type breather interface {
 	MaxAirSupply() time.Duration
 }
 
+type customMetadata interface {
+	Metadata() world.EntityMetadata
+}
+
 type immobile interface {
 	Immobile() bool
 }
@@ -214,6 +420,10 @@ type named interface {
 	NameTag() string
 }
 
+type nameTagVisibility interface {
+	NameTagVisible() bool
+}
+
 type scoreTag interface {
 	ScoreTag() string
 }
@@ -276,3 +486,117 @@ type variable interface {
 type markVariable interface {
 	MarkVariant() int32
 }
+
+type twoColoured interface {
+	Colours() (base, pattern item.Colour)
+}
+
+type puffer interface {
+	PuffState() int
+}
+
+type tradeLevelled interface {
+	TradeTier() int
+	TradeExperience() int
+}
+
+type converting interface {
+	Converting() bool
+}
+
+type tameable interface {
+	Tamed() bool
+}
+
+type collared interface {
+	CollarColour() item.Colour
+}
+
+type woolly interface {
+	Colour() item.Colour
+	Sheared() bool
+}
+
+type sitter interface {
+	Sitting() bool
+}
+
+type angry interface {
+	Angry() bool
+}
+
+type blockCarrier interface {
+	CarriedBlock() (world.Block, bool)
+}
+
+type beamer interface {
+	BeamTarget() (cube.Pos, bool)
+}
+
+type baseShower interface {
+	ShowBase() bool
+}
+
+type blockDisplayer interface {
+	DisplayBlock() (world.Block, bool)
+}
+
+type shakeable interface {
+	HurtTime() (ticks, direction int)
+}
+
+type swelling interface {
+	Swelling() bool
+}
+
+type charged interface {
+	Charged() bool
+}
+
+type charging interface {
+	Charging() bool
+}
+
+type baby interface {
+	Baby() bool
+}
+
+type lover interface {
+	InLove() bool
+}
+
+type tongued interface {
+	TongueTarget() (*world.EntityHandle, bool)
+}
+
+type deadPlayer interface {
+	PlayingDead() bool
+}
+
+type dancer interface {
+	Dancing() bool
+}
+
+type shulker interface {
+	AttachFace() cube.Face
+	Peek() int
+}
+
+type wither interface {
+	InvulnerableTicks() int
+	HeadTargets() [3]*world.EntityHandle
+	Armoured() bool
+}
+
+type warden interface {
+	Emerging() bool
+	Digging() bool
+	Roaring() bool
+	Sniffing() bool
+	SonicBooming() bool
+	HeartbeatInterval() int
+}
+
+type boss interface {
+	BossBar() bossbar.BossBar
+}
@@ -26,7 +26,7 @@ func (h *ItemStackRequestHandler) handleCraftRecipeOptional(a *protocol.CraftRec
 	}
 
 	pos := *s.openedPos.Load()
-	anvil, ok := tx.Block(pos).(block.Anvil)
+	anvil, ok := s.openedBlock(tx).(block.Anvil)
 	if !ok {
 		return fmt.Errorf("no anvil container opened")
 	}
@@ -56,38 +56,9 @@ func (h *ItemStackRequestHandler) handleCraftRecipeOptional(a *protocol.CraftRec
 	// The material input may be empty (if the player is only renaming, for example).
 	var actionCost, renameCost, repairCount int
 	if !material.Empty() {
-		// First check if we are trying to repair the item with a material.
-		if repairable, ok := input.Item().(item.Repairable); ok && repairable.RepairableBy(material) {
-			result, actionCost, repairCount, err = repairItemWithMaterial(input, material, result)
-			if err != nil {
-				return err
-			}
-		} else {
-			_, book := material.Item().(item.EnchantedBook)
-			_, durable := input.Item().(item.Durable)
-
-			// Ensure that the input item is repairable, or the material item is an enchanted book. If not, this is an
-			// invalid scenario, and we should return an error.
-			enchantedBook := book && len(material.Enchantments()) > 0
-			if !enchantedBook && (input.Item() != material.Item() || !durable) {
-				return fmt.Errorf("input item is not repairable/same type or material item is not an enchanted book")
-			}
-
-			// If the material is another durable item, we just need to increase the durability of the result by the
-			// material's durability at 12%.
-			if durable && !enchantedBook {
-				result, actionCost = repairItemWithDurable(input, material, result)
-			}
-
-			// Merge enchantments on the material item onto the result item.
-			var hasCompatible, hasIncompatible bool
-			result, hasCompatible, hasIncompatible, actionCost = mergeEnchantments(input, material, result, actionCost, enchantedBook)
-
-			// If we don't have any compatible enchantments and the input item isn't durable, then this is an invalid
-			// scenario, and we should return an error.
-			if !durable && hasIncompatible && !hasCompatible {
-				return fmt.Errorf("no compatible enchantments but have incompatible ones")
-			}
+		result, actionCost, repairCount, err = combineAnvilItems(input, material)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -167,6 +138,48 @@ func (h *ItemStackRequestHandler) handleCraftRecipeOptional(a *protocol.CraftRec
 	return h.createResults(s, tx, result)
 }
 
+// combineAnvilItems combines the input and material item stacks put in an anvil. The material is either used to
+// repair the input, or its enchantments are merged onto the input, for example if both are enchanted books. It
+// returns the resulting item stack, the cost of the action and the number of material items used for repairing.
+func combineAnvilItems(input, material item.Stack) (result item.Stack, actionCost, repairCount int, err error) {
+	result = input
+	// First check if we are trying to repair the item with a material.
+	if repairable, ok := input.Item().(item.Repairable); ok && repairable.RepairableBy(material) {
+		return repairItemWithMaterial(input, material, result)
+	}
+	_, book := material.Item().(item.EnchantedBook)
+	_, durable := input.Item().(item.Durable)
+
+	// Ensure that the input item is repairable, or the material item is an enchanted book. If not, this is an
+	// invalid scenario, and we should return an error.
+	enchantedBook := book && len(material.Enchantments()) > 0
+	if !enchantedBook && (input.Item() != material.Item() || !durable) {
+		return item.Stack{}, 0, 0, fmt.Errorf("input item is not repairable/same type or material item is not an enchanted book")
+	}
+	if _, ok := input.Item().(item.EnchantedBook); ok && input.Count() > 1 {
+		// Enchanted books never stack, so two books can only be combined one at a time.
+		return item.Stack{}, 0, 0, fmt.Errorf("enchanted books cannot be combined in stacks")
+	}
+
+	// If the material is another durable item, we just need to increase the durability of the result by the
+	// material's durability at 12%.
+	if durable && !enchantedBook {
+		result, actionCost = repairItemWithDurable(input, material, result)
+	}
+
+	// Merge enchantments on the material item onto the result item. Two enchanted books are combined in the same
+	// way, so that two books with the same enchantment and level result in a book of one level higher.
+	var hasCompatible, hasIncompatible bool
+	result, hasCompatible, hasIncompatible, actionCost = mergeEnchantments(input, material, result, actionCost, enchantedBook)
+
+	// If we don't have any compatible enchantments and the input item isn't durable, then this is an invalid
+	// scenario, and we should return an error.
+	if !durable && hasIncompatible && !hasCompatible {
+		return item.Stack{}, 0, 0, fmt.Errorf("no compatible enchantments but have incompatible ones")
+	}
+	return result, actionCost, 0, nil
+}
+
 // repairItemWithMaterial is a helper function that repairs an item stack with a given material stack. It returns the new item
 // stack, the cost, and the repaired items count.
 func repairItemWithMaterial(input item.Stack, material item.Stack, result item.Stack) (item.Stack, int, int, error) {
@@ -228,7 +241,6 @@ func mergeEnchantments(input item.Stack, material item.Stack, result item.Stack,
 			hasIncompatible = true
 			continue
 		}
-		hasCompatible = true
 
 		resultLevel := enchant.Level()
 		levelCost := resultLevel
@@ -247,6 +259,7 @@ func mergeEnchantments(input item.Stack, material item.Stack, result item.Stack,
 			// Update the level cost. (result level - existing level)
 			levelCost = resultLevel - existingEnchant.Level()
 		}
+		hasCompatible = true
 
 		// Now calculate the rarity cost. This is just the application cost of the rarity, however if the
 		// material is an enchanted book, then the rarity cost gets halved. If the new rarity cost is under one,
@@ -0,0 +1,40 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+)
+
+func TestCombineEnchantedBooks(t *testing.T) {
+	book := item.NewStack(item.EnchantedBook{}, 1).WithEnchantments(item.NewEnchantment(enchantment.Sharpness, 3))
+
+	result, cost, _, err := combineAnvilItems(book, book)
+	if err != nil {
+		t.Fatalf("expected two Sharpness III books to combine: %v", err)
+	}
+	if _, ok := result.Item().(item.EnchantedBook); !ok {
+		t.Fatalf("expected an enchanted book as result, got %v", result)
+	}
+	if e, ok := result.Enchantment(enchantment.Sharpness); !ok || e.Level() != 4 {
+		t.Fatalf("expected a Sharpness IV book as result, got %v", result.Enchantments())
+	}
+	if cost <= 0 {
+		t.Fatalf("expected combining books to have a cost, got %v", cost)
+	}
+}
+
+func TestCombineEnchantedBooksAtMaxLevel(t *testing.T) {
+	book := item.NewStack(item.EnchantedBook{}, 1).WithEnchantments(item.NewEnchantment(enchantment.Sharpness, 5))
+	if result, _, _, err := combineAnvilItems(book, book); err == nil {
+		t.Fatalf("expected two Sharpness V books not to combine, got %v", result.Enchantments())
+	}
+}
+
+func TestCombineStackedEnchantedBooks(t *testing.T) {
+	book := item.NewStack(item.EnchantedBook{}, 1).WithEnchantments(item.NewEnchantment(enchantment.Sharpness, 3))
+	if _, _, _, err := combineAnvilItems(book.Grow(1), book); err == nil {
+		t.Fatalf("expected a stack of enchanted books not to be combined")
+	}
+}
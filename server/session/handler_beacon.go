@@ -24,7 +24,7 @@ func (h *ItemStackRequestHandler) handleBeaconPayment(a *protocol.BeaconPaymentS
 		return fmt.Errorf("no beacon container opened")
 	}
 	pos := *s.openedPos.Load()
-	beacon, ok := tx.Block(pos).(block.Beacon)
+	beacon, ok := s.openedBlock(tx).(block.Beacon)
 	if !ok {
 		return fmt.Errorf("no beacon container opened")
 	}
@@ -0,0 +1,89 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+)
+
+const (
+	// cartographyInputSlot is the slot index of the input map in the cartography table.
+	cartographyInputSlot = 0x0c
+	// cartographyAdditionalSlot is the slot index of the additional item in the cartography table.
+	cartographyAdditionalSlot = 0x0d
+)
+
+// cartographyRecipes holds the UUIDs of the multi recipes sent to the client so that it is able to use the
+// cartography table. The operation performed is determined by the items in the table, so the recipes carry no data.
+var cartographyRecipes = []string{
+	// Map cloning.
+	"442d85ed-8272-4543-a6f1-418f90ded05d",
+	// Map extending.
+	"8b36268c-1829-483c-a0f1-993b7156a8f2",
+	// Map upgrading.
+	"98c84b38-1085-46bd-b1ce-dd38c159e6cc",
+	// Map locking.
+	"602234e4-cac1-4353-8bb7-b1ebff70024b",
+}
+
+// handleCartography handles a CraftRecipe stack request action made using a cartography table. A filled map in the
+// input slot is copied if combined with an empty map, zoomed out if combined with paper or locked if combined with
+// a glass pane.
+func (h *ItemStackRequestHandler) handleCartography(a *protocol.CraftRecipeStackRequestAction, s *Session, tx *world.Tx) error {
+	timesCrafted := int(a.NumberOfCrafts)
+	if timesCrafted < 1 {
+		return fmt.Errorf("times crafted must be at least 1")
+	}
+	input, _ := h.itemInSlot(protocol.StackRequestSlotInfo{
+		Container: protocol.FullContainerName{ContainerID: protocol.ContainerCartographyInput},
+		Slot:      cartographyInputSlot,
+	}, s, tx)
+	additional, _ := h.itemInSlot(protocol.StackRequestSlotInfo{
+		Container: protocol.FullContainerName{ContainerID: protocol.ContainerCartographyAdditional},
+		Slot:      cartographyAdditionalSlot,
+	}, s, tx)
+	if input.Count() < timesCrafted || additional.Count() < timesCrafted {
+		return fmt.Errorf("input item count is less than number of crafts")
+	}
+	m, ok := input.Item().(item.Map)
+	if !ok {
+		return fmt.Errorf("input item is not a filled map")
+	}
+
+	var result item.Stack
+	switch additional.Item().(type) {
+	case item.EmptyMap:
+		// Copying a map results in two maps with the same ID, one of which replaces the input map.
+		result = input.Grow(2 - input.Count())
+	case item.Paper:
+		zoomed, ok := m.Zoom()
+		if !ok {
+			return fmt.Errorf("map cannot be zoomed out any further")
+		}
+		result = input.Grow(1 - input.Count()).WithItem(zoomed)
+	case block.GlassPane:
+		locked, ok := m.Lock()
+		if !ok {
+			return fmt.Errorf("map is already locked")
+		}
+		result = input.Grow(1 - input.Count()).WithItem(locked)
+	default:
+		return fmt.Errorf("additional item cannot be used in a cartography table")
+	}
+	if timesCrafted > 1 && result.Item().(item.Map).ID != m.ID {
+		// Every zoomed out map gets a new ID, so they cannot be created in bulk.
+		return fmt.Errorf("maps can only be zoomed out one at a time")
+	}
+
+	h.setItemInSlot(protocol.StackRequestSlotInfo{
+		Container: protocol.FullContainerName{ContainerID: protocol.ContainerCartographyInput},
+		Slot:      cartographyInputSlot,
+	}, input.Grow(-timesCrafted), s, tx)
+	h.setItemInSlot(protocol.StackRequestSlotInfo{
+		Container: protocol.FullContainerName{ContainerID: protocol.ContainerCartographyAdditional},
+		Slot:      cartographyAdditionalSlot,
+	}, additional.Grow(-timesCrafted), s, tx)
+	return h.createResults(s, tx, repeatStacks([]item.Stack{result}, timesCrafted)...)
+}
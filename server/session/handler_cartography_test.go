@@ -0,0 +1,47 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+)
+
+// cartographyResult puts the filled map and additional item passed in a
+// cartography table and returns the result of combining them.
+func cartographyResult(t *testing.T, m item.Map, additional world.Item) item.Stack {
+	s, h := newTestSession(), newTestHandler()
+	_ = s.ui.SetItem(cartographyInputSlot, item.NewStack(m, 1))
+	_ = s.ui.SetItem(cartographyAdditionalSlot, item.NewStack(additional, 1))
+
+	var result item.Stack
+	withOpenedBlock(s, block.CartographyTable{}, func(tx *world.Tx) {
+		if err := h.handleCartography(&protocol.CraftRecipeStackRequestAction{NumberOfCrafts: 1}, s, tx); err != nil {
+			t.Errorf("expected map to be combined with %v: %v", additional, err)
+			return
+		}
+		if in, _ := s.ui.Item(cartographyInputSlot); !in.Empty() {
+			t.Errorf("expected input map to be used, found %v", in)
+		}
+		result, _ = s.ui.Item(craftingResult)
+	})
+	return result
+}
+
+func TestCartographyTableClonesMap(t *testing.T) {
+	m := item.NewMap(2)
+	result := cartographyResult(t, m, item.EmptyMap{})
+	if result.Item() != m || result.Count() != 2 {
+		t.Errorf("expected two copies of %#v, got %v", m, result)
+	}
+}
+
+func TestCartographyTableLocksMap(t *testing.T) {
+	m := item.NewMap(2)
+	result := cartographyResult(t, m, block.GlassPane{})
+	if locked, ok := result.Item().(item.Map); !ok || !locked.Locked || locked.ID != m.ID || locked.Scale != m.Scale {
+		t.Errorf("expected a locked copy of %#v showing the same contents, got %v", m, result)
+	}
+}
@@ -16,39 +16,29 @@ func (c *ClientCacheBlobStatusHandler) Handle(p packet.Packet, s *Session, _ *wo
 
 	resp := &packet.ClientCacheMissResponse{Blobs: make([]protocol.CacheBlob, 0, len(pk.MissHashes))}
 
+	var changed []world.SubChunkPos
 	s.blobMu.Lock()
 	for _, hit := range pk.HitHashes {
-		delete(s.blobs, hit)
-		c.resolveBlob(hit, s)
+		changed = s.resolveBlob(hit, changed)
 	}
 	for _, miss := range pk.MissHashes {
-		blob, ok := s.blobs[miss]
-		if !ok {
-			// This is expected to happen sometimes, for example when we send the same block storage or biomes a lot of
-			// times in a short timeframe. There is no need to log this, it'll just cause unnecessary noise that doesn't
-			// actually aid in terms of information.
-			continue
+		// Blobs are stored by their hash, so the payload sent is always the
+		// one that the hash was computed from, even if the sub chunk changed
+		// since. Those changes are sent once the transaction is resolved.
+		if blob, ok := s.conf.Blobs.blob(miss); ok {
+			resp.Blobs = append(resp.Blobs, protocol.CacheBlob{Hash: miss, Payload: blob})
 		}
-		resp.Blobs = append(resp.Blobs, protocol.CacheBlob{Hash: miss, Payload: blob})
-		c.resolveBlob(miss, s)
+		// The blob might not be found if the client requests the same blob
+		// multiple times and it was removed from the BlobStore in between.
+		// There is no need to log this, it'll just cause unnecessary noise
+		// that doesn't actually aid in terms of information.
+		changed = s.resolveBlob(miss, changed)
 	}
 	s.blobMu.Unlock()
 
 	if len(resp.Blobs) > 0 {
 		s.writePacket(resp)
 	}
+	s.resendSubChunks(changed)
 	return nil
 }
-
-// resolveBlob resolves a blob hash in the session passed. It assumes s.blobMu is locked upon calling.
-func (c *ClientCacheBlobStatusHandler) resolveBlob(hash uint64, s *Session) {
-	leftover := make([]map[uint64]struct{}, 0, len(s.openChunkTransactions))
-	for _, m := range s.openChunkTransactions {
-		delete(m, hash)
-		if len(m) != 0 {
-			leftover = append(leftover, m)
-		}
-	}
-	s.openChunkTransactions = leftover
-	delete(s.blobs, hash)
-}
@@ -20,7 +20,13 @@ func (h *ContainerCloseHandler) Handle(p packet.Packet, s *Session, tx *world.Tx
 		s.writePacket(&packet.ContainerClose{})
 		s.invOpened = false
 	case byte(s.openedWindowID.Load()):
-		s.closeCurrentContainer(tx)
+		if s.containerOpened.Load() && s.tradingWith.Load() == nil {
+			if err := c.ContainerClosing(s.openedInventory(tx), *s.openedPos.Load(), true); err != nil {
+				s.reopenContainer(tx)
+				return nil
+			}
+		}
+		s.closeContainer(tx)
 	case 0xff:
 		// TODO: Handle closing the crafting grid.
 	default:
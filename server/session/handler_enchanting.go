@@ -48,6 +48,9 @@ func (h *ItemStackRequestHandler) handleEnchant(a *protocol.CraftRecipeStackRequ
 	cost := int(a.RecipeNetworkID + 1)
 	requirement := allCosts[a.RecipeNetworkID]
 	enchants := allEnchants[a.RecipeNetworkID]
+	if requirement == 0 || len(enchants) == 0 {
+		return fmt.Errorf("enchanting option %v is not available", a.RecipeNetworkID)
+	}
 
 	// If we don't have infinite resources, we need to deduct Lapis Lazuli and experience.
 	if !c.GameMode().CreativeInventory() {
@@ -105,6 +108,10 @@ func (s *Session) sendEnchantmentOptions(tx *world.Tx, c Controllable, pos cube.
 	// Build the protocol variant of the enchantment options.
 	options := make([]protocol.EnchantmentOption, 0, 3)
 	for i := 0; i < 3; i++ {
+		if selectedCosts[i] == 0 || len(selectedEnchants[i]) == 0 {
+			// The option is not available, for example because there are too few bookshelves around the table.
+			continue
+		}
 		// First build the enchantment instances for each selected enchantment.
 		enchants := make([]protocol.EnchantmentInstance, 0, len(selectedEnchants[i]))
 		for _, enchant := range selectedEnchants[i] {
@@ -133,11 +140,13 @@ func (s *Session) sendEnchantmentOptions(tx *world.Tx, c Controllable, pos cube.
 	s.writePacket(&packet.PlayerEnchantOptions{Options: options})
 }
 
-// determineAvailableEnchantments returns a list of pseudo-random enchantments for the given item stack.
+// determineAvailableEnchantments returns the level requirements and pseudo-random enchantments of the three
+// enchanting options for the given item stack. An option that is not available has a level requirement of 0 and
+// no enchantments.
 func (s *Session) determineAvailableEnchantments(tx *world.Tx, c Controllable, pos cube.Pos, stack item.Stack) ([]int, [][]item.Enchantment) {
 	// First ensure that the item is enchantable and does not already have any enchantments.
 	enchantable, ok := stack.Item().(item.Enchantable)
-	if !ok {
+	if !ok || enchantable.EnchantmentValue() <= 0 {
 		// We can't enchant this item.
 		return nil, nil
 	}
@@ -153,24 +162,44 @@ func (s *Session) determineAvailableEnchantments(tx *world.Tx, c Controllable, p
 	bookshelves := searchBookshelves(tx, pos)
 	value := enchantable.EnchantmentValue()
 
-	// Calculate the base cost, used to calculate the upper, middle, and lower level costs.
-	baseCost := random.IntN(8) + 1 + (bookshelves >> 1) + random.IntN(bookshelves+1)
-
-	// Calculate the upper, middle, and lower level costs.
-	upperLevelCost := max(baseCost/3, 1)
-	middleLevelCost := baseCost*2/3 + 1
-	lowerLevelCost := max(baseCost, bookshelves*2)
-
-	// Create a list of available enchantments for each slot.
-	return []int{
-			upperLevelCost,
-			middleLevelCost,
-			lowerLevelCost,
-		}, [][]item.Enchantment{
-			createEnchantments(random, stack, value, upperLevelCost),
-			createEnchantments(random, stack, value, middleLevelCost),
-			createEnchantments(random, stack, value, lowerLevelCost),
+	costs, enchants := make([]int, 3), make([][]item.Enchantment, 3)
+	for slot := range costs {
+		if costs[slot] = enchantingCost(random, slot, bookshelves); costs[slot] < slot+1 {
+			// The level requirement of an option must be at least the amount of levels it costs.
+			costs[slot] = 0
+		}
+	}
+	for slot, cost := range costs {
+		if cost == 0 {
+			continue
+		}
+		// Every option uses its own random source, so that the enchantments of an option don't depend on the
+		// enchantments selected for the other options.
+		slotSeed := seed + uint64(slot)
+		slotRandom := rand.New(rand.NewPCG(slotSeed, slotSeed))
+		enchants[slot] = createEnchantments(slotRandom, stack, value, cost)
+		if _, book := stack.Item().(item.Book); book && len(enchants[slot]) > 1 {
+			// Books always lose one of their enchantments to make up for being able to hold any enchantment.
+			ind := slotRandom.IntN(len(enchants[slot]))
+			enchants[slot] = slices.Delete(enchants[slot], ind, ind+1)
 		}
+	}
+	return costs, enchants
+}
+
+// enchantingCost returns the level requirement of the enchanting option in the slot passed. The requirement of the
+// last option is always at least twice the amount of bookshelves, so that 15 bookshelves allow enchanting at level 30.
+func enchantingCost(random *rand.Rand, slot, bookshelves int) int {
+	bookshelves = min(bookshelves, maxBookshelves)
+	base := random.IntN(8) + 1 + (bookshelves >> 1) + random.IntN(bookshelves+1)
+	switch slot {
+	case 0:
+		return max(base/3, 1)
+	case 1:
+		return base*2/3 + 1
+	default:
+		return max(base, bookshelves*2)
+	}
 }
 
 // treasureEnchantment represents an enchantment that may be a treasure enchantment.
@@ -181,12 +210,10 @@ type treasureEnchantment interface {
 
 // createEnchantments creates a list of enchantments for the given item stack and returns them.
 func createEnchantments(random *rand.Rand, stack item.Stack, value, level int) []item.Enchantment {
-	// Calculate the "random bonus" for this level. This factor is used in calculating the enchantment cost, used
-	// during the selection of enchantments.
-	randomBonus := (random.Float64() + random.Float64() - 1.0) * 0.15
-
-	// Calculate the enchantment cost and clamp it to ensure it is always at least one with triangular distribution.
+	// Calculate the enchantment cost, used during the selection of enchantments. The cost is modified by a "random
+	// bonus" and clamped to ensure it is always at least one with triangular distribution.
 	cost := level + 1 + random.IntN(value/4+1) + random.IntN(value/4+1)
+	randomBonus := (random.Float64() + random.Float64() - 1.0) * 0.15
 	cost = clamp(int(math.Round(float64(cost)+float64(cost)*randomBonus)), 1, math.MaxInt32)
 
 	// Books are applicable to all enchantments, so make sure we have a flag for them here.
@@ -261,38 +288,30 @@ func createEnchantments(random *rand.Rand, stack item.Stack, value, level int) [
 	return selectedEnchants
 }
 
+// maxBookshelves is the maximum amount of bookshelves that affect the enchantments offered by an enchanting table.
+const maxBookshelves = 15
+
 // searchBookshelves searches for nearby bookshelves around the position passed, and returns the amount found.
+// Bookshelves count if they are placed on the ring of blocks two blocks away from the enchanting table, at the
+// height of the table or one block above it, with an air block between the bookshelf and the table.
 func searchBookshelves(tx *world.Tx, pos cube.Pos) (shelves int) {
-	for x := -1; x <= 1; x++ {
-		for z := -1; z <= 1; z++ {
+	for x := -2; x <= 2; x++ {
+		for z := -2; z <= 2; z++ {
+			if x > -2 && x < 2 && z > -2 && z < 2 {
+				// Only the ring of blocks two blocks away from the table can hold bookshelves.
+				continue
+			}
 			for y := 0; y <= 1; y++ {
-				if x == 0 && z == 0 {
-					// Ignore the center block.
+				if _, ok := tx.Block(pos.Add(cube.Pos{x, y, z})).(block.Bookshelf); !ok {
 					continue
 				}
-				if _, ok := tx.Block(pos.Add(cube.Pos{x, y, z})).(block.Air); !ok {
-					// There must be a one block space between the bookshelf and the player.
+				// There must be a one block space between the bookshelf and the table. Note that for the
+				// bookshelves next to the corners, this is the block straight between the bookshelf and the table.
+				if _, ok := tx.Block(pos.Add(cube.Pos{x / 2, y, z / 2})).(block.Air); !ok {
 					continue
 				}
-
-				// Check for a bookshelf two blocks away.
-				if _, ok := tx.Block(pos.Add(cube.Pos{x * 2, y, z * 2})).(block.Bookshelf); ok {
-					shelves++
-				}
-				if x != 0 && z != 0 {
-					// Check for a bookshelf two blocks away on the X axis.
-					if _, ok := tx.Block(pos.Add(cube.Pos{x * 2, y, z})).(block.Bookshelf); ok {
-						shelves++
-					}
-					// Check for a bookshelf two blocks away on the Z axis.
-					if _, ok := tx.Block(pos.Add(cube.Pos{x, y, z * 2})).(block.Bookshelf); ok {
-						shelves++
-					}
-				}
-
-				if shelves >= 15 {
-					// We've found enough bookshelves.
-					return 15
+				if shelves++; shelves >= maxBookshelves {
+					return maxBookshelves
 				}
 			}
 		}
@@ -0,0 +1,68 @@
+package session
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestEnchantingCostByBookshelves(t *testing.T) {
+	tests := []struct {
+		bookshelves, min, max int
+	}{
+		{bookshelves: 0, min: 1, max: 8},
+		{bookshelves: 8, min: 16, max: 20},
+		{bookshelves: 15, min: 30, max: 30},
+		// Bookshelves beyond 15 do not increase the level requirement further.
+		{bookshelves: 32, min: 30, max: 30},
+	}
+	random := rand.New(rand.NewPCG(1, 2))
+	for _, test := range tests {
+		lowest, highest := 100, 0
+		for range 2000 {
+			cost := enchantingCost(random, 2, test.bookshelves)
+			lowest, highest = min(lowest, cost), max(highest, cost)
+		}
+		if lowest != test.min || highest != test.max {
+			t.Errorf("expected level requirement of the last option with %v bookshelves to range from %v to %v, got %v to %v", test.bookshelves, test.min, test.max, lowest, highest)
+		}
+	}
+}
+
+func TestSearchBookshelves(t *testing.T) {
+	w := world.Config{}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		pos := cube.Pos{0, 64, 0}
+		for z := -2; z <= 2; z++ {
+			tx.SetBlock(pos.Add(cube.Pos{-2, 0, z}), block.Bookshelf{}, nil)
+		}
+		if n := searchBookshelves(tx, pos); n != 5 {
+			t.Errorf("expected 5 bookshelves to be found, got %v", n)
+		}
+		// A block between the table and a bookshelf stops the bookshelf from
+		// counting. Bookshelves next to the corners share this block with
+		// the bookshelf in the middle.
+		tx.SetBlock(pos.Add(cube.Pos{-1, 0, 0}), block.Stone{}, nil)
+		if n := searchBookshelves(tx, pos); n != 2 {
+			t.Errorf("expected 2 bookshelves to be found with a block in between, got %v", n)
+		}
+
+		for x := -2; x <= 2; x++ {
+			for z := -2; z <= 2; z++ {
+				for y := 0; y <= 1; y++ {
+					if x == -2 || x == 2 || z == -2 || z == 2 {
+						tx.SetBlock(pos.Add(cube.Pos{x, y, z}), block.Bookshelf{}, nil)
+					}
+				}
+			}
+		}
+		if n := searchBookshelves(tx, pos); n != maxBookshelves {
+			t.Errorf("expected at most %v bookshelves to be found, got %v", maxBookshelves, n)
+		}
+	})
+}
@@ -25,7 +25,7 @@ func (h *ItemStackRequestHandler) handleGrindstoneCraft(s *Session, tx *world.Tx
 	if !s.containerOpened.Load() {
 		return fmt.Errorf("no grindstone container opened")
 	}
-	if _, ok := tx.Block(*s.openedPos.Load()).(block.Grindstone); !ok {
+	if _, ok := s.openedBlock(tx).(block.Grindstone); !ok {
 		return fmt.Errorf("no grindstone container opened")
 	}
 
@@ -19,8 +19,9 @@ import (
 type ItemStackRequestHandler struct {
 	currentRequest int32
 
-	changes         map[byte]map[byte]changeInfo
+	changes         map[protocol.FullContainerName]map[byte]changeInfo
 	responseChanges map[int32]map[*inventory.Inventory]map[byte]responseChange
+	touched         map[*inventory.Inventory]struct{}
 
 	pendingResults []item.Stack
 
@@ -36,10 +37,14 @@ type responseChange struct {
 }
 
 // changeInfo holds information on a slot change initiated by an item stack request. It holds both the new and the old
-// item information and is used for reverting and verifying.
+// item information and is used for reverting and verifying. before always holds the item in the slot before the
+// first change made to it in the request, so that reverting restores the slot to its state before the request.
 type changeInfo struct {
 	after  protocol.StackResponseSlotInfo
 	before item.Stack
+
+	inv  *inventory.Inventory
+	slot int
 }
 
 // Handle ...
@@ -69,7 +74,6 @@ func (h *ItemStackRequestHandler) handleRequest(req protocol.ItemStackRequest, s
 			return
 		}
 		h.resolve(req.RequestID, s)
-		h.ignoreDestroy = false
 	}()
 
 	for _, action := range req.Actions {
@@ -87,13 +91,19 @@ func (h *ItemStackRequestHandler) handleRequest(req protocol.ItemStackRequest, s
 		case *protocol.BeaconPaymentStackRequestAction:
 			err = h.handleBeaconPayment(a, s, tx)
 		case *protocol.CraftRecipeStackRequestAction:
+			if s.tradingWith.Load() != nil {
+				err = h.handleTrade(a, s, tx, c)
+				break
+			}
 			if s.containerOpened.Load() {
 				var special bool
-				switch tx.Block(*s.openedPos.Load()).(type) {
+				switch s.openedBlock(tx).(type) {
 				case block.SmithingTable:
 					err, special = h.handleSmithing(a, s, tx), true
 				case block.Stonecutter:
 					err, special = h.handleStonecutting(a, s, tx), true
+				case block.CartographyTable:
+					err, special = h.handleCartography(a, s, tx), true
 				case block.EnchantingTable:
 					err, special = h.handleEnchant(a, s, tx, c), true
 				}
@@ -159,16 +169,25 @@ func (h *ItemStackRequestHandler) handleTransfer(from, to protocol.StackRequestS
 	if dest.Empty() {
 		dest = i.Grow(-math.MaxInt32)
 	}
+	if from.Container != to.Container {
+		if err := s.verifyBundlePlacement(to, dest.Grow(int(count)), tx); err != nil {
+			return err
+		}
+	}
 
-	invA, _ := s.invByID(int32(from.Container.ContainerID), tx)
-	invB, _ := s.invByID(int32(to.Container.ContainerID), tx)
+	invA, _ := s.invByContainer(from.Container, tx)
+	invB, _ := s.invByContainer(to.Container, tx)
 
+	moved := i.Grow(int(count) - i.Count())
 	ctx := event.C(inventory.Holder(c))
-	_ = call(ctx, int(from.Slot), i.Grow(int(count)-i.Count()), invA.Handler().HandleTake)
-	err := call(ctx, int(to.Slot), i.Grow(int(count)-i.Count()), invB.Handler().HandlePlace)
+	_ = call(ctx, int(from.Slot), moved, invA.Handler().HandleTake)
+	err := call(ctx, int(to.Slot), moved, invB.Handler().HandlePlace)
 	if err != nil {
 		return err
 	}
+	if err := h.moveItem(moved, from, to, s, tx, c); err != nil {
+		return err
+	}
 
 	h.setItemInSlot(from, i.Grow(-int(count)), s, tx)
 	h.setItemInSlot(to, dest.Grow(int(count)), s, tx)
@@ -183,9 +202,17 @@ func (h *ItemStackRequestHandler) handleSwap(a *protocol.SwapStackRequestAction,
 	}
 	i, _ := h.itemInSlot(a.Source, s, tx)
 	dest, _ := h.itemInSlot(a.Destination, s, tx)
+	if a.Source.Container != a.Destination.Container {
+		if err := s.verifyBundlePlacement(a.Destination, i, tx); err != nil {
+			return err
+		}
+		if err := s.verifyBundlePlacement(a.Source, dest, tx); err != nil {
+			return err
+		}
+	}
 
-	invA, _ := s.invByID(int32(a.Source.Container.ContainerID), tx)
-	invB, _ := s.invByID(int32(a.Destination.Container.ContainerID), tx)
+	invA, _ := s.invByContainer(a.Source.Container, tx)
+	invB, _ := s.invByContainer(a.Destination.Container, tx)
 
 	ctx := event.C(inventory.Holder(c))
 	_ = call(ctx, int(a.Source.Slot), i, invA.Handler().HandleTake)
@@ -195,6 +222,12 @@ func (h *ItemStackRequestHandler) handleSwap(a *protocol.SwapStackRequestAction,
 	if err != nil {
 		return err
 	}
+	if err := h.moveItem(i, a.Source, a.Destination, s, tx, c); err != nil {
+		return err
+	}
+	if err := h.moveItem(dest, a.Destination, a.Source, s, tx, c); err != nil {
+		return err
+	}
 
 	h.setItemInSlot(a.Source, dest, s, tx)
 	h.setItemInSlot(a.Destination, i, s, tx)
@@ -203,11 +236,22 @@ func (h *ItemStackRequestHandler) handleSwap(a *protocol.SwapStackRequestAction,
 	return nil
 }
 
+// moveItem notifies the Controllable of the item stack passed being moved from one slot to another. An error is
+// returned if the move was cancelled, which leads to the request being rejected. Nothing happens for empty stacks.
+func (h *ItemStackRequestHandler) moveItem(it item.Stack, from, to protocol.StackRequestSlotInfo, s *Session, tx *world.Tx, c Controllable) error {
+	if it.Empty() {
+		return nil
+	}
+	invA, _ := s.invByContainer(from.Container, tx)
+	invB, _ := s.invByContainer(to.Container, tx)
+	return c.MoveItem(it, invA, h.slotIndex(from, s, invA), invB, h.slotIndex(to, s, invB))
+}
+
 // collectRewards checks if the source inventory has rewards for the player, for example, experience rewards when
 // smelting. If it does, it will drop the rewards at the player's location.
 func (h *ItemStackRequestHandler) collectRewards(s *Session, inv *inventory.Inventory, slot int, tx *world.Tx, c Controllable) {
-	if inv == s.openedWindow.Load() && s.containerOpened.Load() && slot == inv.Size()-1 {
-		if f, ok := tx.Block(*s.openedPos.Load()).(smelter); ok {
+	if inv == s.openedWindow.Load() && s.containerOpened.Load() && s.tradingWith.Load() == nil && slot == inv.Size()-1 {
+		if f, ok := s.openedBlock(tx).(smelter); ok {
 			for _, o := range entity.NewExperienceOrbs(entity.EyePosition(c), f.ResetExperience()) {
 				tx.AddEntity(o)
 			}
@@ -246,7 +290,7 @@ func (h *ItemStackRequestHandler) handleDrop(a *protocol.DropStackRequestAction,
 		return fmt.Errorf("client attempted to drop %v items, but only %v present", a.Count, i.Count())
 	}
 
-	inv, _ := s.invByID(int32(a.Source.Container.ContainerID), tx)
+	inv, _ := s.invByContainer(a.Source.Container, tx)
 	if err := call(event.C(inventory.Holder(c)), int(a.Source.Slot), i.Grow(int(a.Count)-i.Count()), inv.Handler().HandleDrop); err != nil {
 		return err
 	}
@@ -327,7 +371,8 @@ func (h *ItemStackRequestHandler) verifySlot(slot protocol.StackRequestSlotInfo,
 	if len(h.responseChanges) > 256 {
 		return fmt.Errorf("too many unacknowledged request slot changes")
 	}
-	inv, _ := s.invByID(int32(slot.Container.ContainerID), tx)
+	inv, _ := s.invByContainer(slot.Container, tx)
+	h.touched[inv] = struct{}{}
 
 	i, err := h.itemInSlot(slot, s, tx)
 	if err != nil {
@@ -371,7 +416,7 @@ func (h *ItemStackRequestHandler) resolveID(inv *inventory.Inventory, slot proto
 // info passed from the client has the right stack network ID in any of the stored slots. If this is the case,
 // that entry is removed, so that the maps are cleaned up eventually.
 func (h *ItemStackRequestHandler) tryAcknowledgeChanges(s *Session, tx *world.Tx, slot protocol.StackRequestSlotInfo) error {
-	inv, ok := s.invByID(int32(slot.Container.ContainerID), tx)
+	inv, ok := s.invByContainer(slot.Container, tx)
 	if !ok {
 		return fmt.Errorf("could not find container with id %v", slot.Container.ContainerID)
 	}
@@ -396,32 +441,33 @@ func (h *ItemStackRequestHandler) tryAcknowledgeChanges(s *Session, tx *world.Tx
 
 // itemInSlot looks for the item in the slot as indicated by the slot info passed.
 func (h *ItemStackRequestHandler) itemInSlot(slot protocol.StackRequestSlotInfo, s *Session, tx *world.Tx) (item.Stack, error) {
-	inv, ok := s.invByID(int32(slot.Container.ContainerID), tx)
+	inv, ok := s.invByContainer(slot.Container, tx)
 	if !ok {
 		return item.Stack{}, fmt.Errorf("unable to find container with ID %v", slot.Container.ContainerID)
 	}
 
-	sl := int(slot.Slot)
-	if inv == s.offHand {
-		sl = 0
-	}
-
-	i, err := inv.Item(sl)
+	i, err := inv.Item(h.slotIndex(slot, s, inv))
 	if err != nil {
 		return i, err
 	}
 	return i, nil
 }
 
-// setItemInSlot sets an item stack in the slot of a container present in the slot info.
-func (h *ItemStackRequestHandler) setItemInSlot(slot protocol.StackRequestSlotInfo, i item.Stack, s *Session, tx *world.Tx) {
-	inv, _ := s.invByID(int32(slot.Container.ContainerID), tx)
-
-	sl := int(slot.Slot)
+// slotIndex returns the index in the inventory passed that the slot info points to. The client sends a slot of 1
+// for the offhand, while the offhand inventory only has a single slot.
+func (h *ItemStackRequestHandler) slotIndex(slot protocol.StackRequestSlotInfo, s *Session, inv *inventory.Inventory) int {
 	if inv == s.offHand {
-		sl = 0
+		return 0
 	}
+	return int(slot.Slot)
+}
+
+// setItemInSlot sets an item stack in the slot of a container present in the slot info.
+func (h *ItemStackRequestHandler) setItemInSlot(slot protocol.StackRequestSlotInfo, i item.Stack, s *Session, tx *world.Tx) {
+	inv, _ := s.invByContainer(slot.Container, tx)
+	h.touched[inv] = struct{}{}
 
+	sl := h.slotIndex(slot, s, inv)
 	before, _ := inv.Item(sl)
 	_ = inv.SetItem(sl, i)
 
@@ -433,12 +479,19 @@ func (h *ItemStackRequestHandler) setItemInSlot(slot protocol.StackRequestSlotIn
 		DurabilityCorrection: int32(i.MaxDurability() - i.Durability()),
 	}
 
-	if h.changes[slot.Container.ContainerID] == nil {
-		h.changes[slot.Container.ContainerID] = map[byte]changeInfo{}
+	if h.changes[slot.Container] == nil {
+		h.changes[slot.Container] = map[byte]changeInfo{}
+	}
+	if prev, ok := h.changes[slot.Container][slot.Slot]; ok {
+		// The slot was already changed earlier in this request. Keep the item that was in the slot before the
+		// first change, so that a revert does not restore an intermediate state.
+		before = prev.before
 	}
-	h.changes[slot.Container.ContainerID][slot.Slot] = changeInfo{
+	h.changes[slot.Container][slot.Slot] = changeInfo{
 		after:  respSlot,
 		before: before,
+		inv:    inv,
+		slot:   sl,
 	}
 
 	if h.responseChanges[h.currentRequest] == nil {
@@ -462,7 +515,7 @@ func (h *ItemStackRequestHandler) resolve(id int32, s *Session) {
 			slots = append(slots, slot.after)
 		}
 		info = append(info, protocol.StackResponseContainerInfo{
-			Container: protocol.FullContainerName{ContainerID: container},
+			Container: container,
 			SlotInfo:  slots,
 		})
 	}
@@ -472,8 +525,10 @@ func (h *ItemStackRequestHandler) resolve(id int32, s *Session) {
 		ContainerInfo: info,
 	}}})
 
-	h.changes = map[byte]map[byte]changeInfo{}
+	h.changes = map[protocol.FullContainerName]map[byte]changeInfo{}
+	h.touched = map[*inventory.Inventory]struct{}{}
 	h.pendingResults = nil
+	h.ignoreDestroy = false
 }
 
 // reject rejects the item stack request sent by the client so that it is reverted client-side.
@@ -486,15 +541,35 @@ func (h *ItemStackRequestHandler) reject(id int32, s *Session, tx *world.Tx) {
 	})
 
 	// Revert changes that we already made for valid actions.
-	for container, slots := range h.changes {
-		for slot, info := range slots {
-			inv, _ := s.invByID(int32(container), tx)
-			_ = inv.SetItem(int(slot), info.before)
+	for _, slots := range h.changes {
+		for _, info := range slots {
+			_ = info.inv.SetItem(info.slot, info.before)
+		}
+	}
+	// The client will never refer to the stack network IDs of a rejected request, so there is no need to keep
+	// track of them.
+	delete(h.responseChanges, id)
+
+	// The client predicts the outcome of the request, so just rejecting it could leave it with items that don't
+	// exist server-side. Resend the full contents of every inventory involved so that the client ends up in
+	// the same state as the server.
+	for inv := range h.touched {
+		if windowID, ok := s.windowIDByInv(inv); ok {
+			s.sendInv(inv, windowID)
+		}
+	}
+	for id, inv := range s.bundles {
+		if _, ok := h.touched[inv]; ok {
+			if _, _, it, ok := s.findBundle(id); ok {
+				s.sendBundleContents(it)
+			}
 		}
 	}
 
-	h.changes = map[byte]map[byte]changeInfo{}
+	h.changes = map[protocol.FullContainerName]map[byte]changeInfo{}
+	h.touched = map[*inventory.Inventory]struct{}{}
 	h.pendingResults = nil
+	h.ignoreDestroy = false
 }
 
 // call uses an event.Context, slot and item.Stack to call the event handler function passed. An error is returned if
@@ -0,0 +1,104 @@
+package session
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/item/recipe"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// withOpenedBlock runs f in a world in which the Session passed has the block
+// passed opened as a container.
+func withOpenedBlock(s *Session, b world.Block, f func(tx *world.Tx)) {
+	w := world.Config{}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	s.openedPos.Store(&pos)
+	s.containerOpened.Store(true)
+	<-w.Exec(func(tx *world.Tx) {
+		tx.SetBlock(pos, b, nil)
+		f(tx)
+	})
+}
+
+// cancelMoves is a Controllable that cancels every item move.
+type cancelMoves struct {
+	Controllable
+}
+
+func (cancelMoves) MoveItem(it item.Stack, _ *inventory.Inventory, srcSlot int, _ *inventory.Inventory, dstSlot int) error {
+	return fmt.Errorf("move of %v from slot %v to slot %v was cancelled", it, srcSlot, dstSlot)
+}
+
+// newTestSession returns a Session without a connection, of which the
+// packets written are buffered in its packets channel.
+func newTestSession() *Session {
+	return &Session{
+		inv:             inventory.New(36, nil),
+		ui:              inventory.New(54, nil),
+		offHand:         inventory.New(1, nil),
+		armour:          inventory.NewArmour(nil),
+		packets:         make(chan packet.Packet, 16),
+		closeBackground: make(chan struct{}),
+		recipes:         map[uint32]recipe.Recipe{},
+	}
+}
+
+// newTestHandler returns an ItemStackRequestHandler that is ready to handle
+// requests.
+func newTestHandler() *ItemStackRequestHandler {
+	return &ItemStackRequestHandler{
+		changes:         map[protocol.FullContainerName]map[byte]changeInfo{},
+		responseChanges: map[int32]map[*inventory.Inventory]map[byte]responseChange{},
+		touched:         map[*inventory.Inventory]struct{}{},
+	}
+}
+
+func TestRejectedShiftClickIsReverted(t *testing.T) {
+	s := newTestSession()
+	chest := inventory.New(27, nil)
+	s.openedWindow.Store(chest)
+	s.openedWindowID.Store(1)
+	s.openedVirtual.Store(&virtualContainer{})
+	s.containerOpened.Store(true)
+
+	apples := item.NewStack(item.Apple{}, 16)
+	_ = chest.SetItem(0, apples)
+	h := newTestHandler()
+	// Shift-clicking the apples moves them from the chest into the inventory.
+	a := &protocol.PlaceStackRequestAction{}
+	a.Count = 16
+	a.Source = protocol.StackRequestSlotInfo{Container: protocol.FullContainerName{ContainerID: protocol.ContainerLevelEntity}, StackNetworkID: item_id(apples)}
+	a.Destination = protocol.StackRequestSlotInfo{Container: protocol.FullContainerName{ContainerID: protocol.ContainerInventory}, Slot: 9}
+	err := h.handleRequest(protocol.ItemStackRequest{RequestID: 1, Actions: []protocol.StackRequestAction{a}}, s, nil, cancelMoves{})
+	if err == nil {
+		t.Fatalf("expected shift-click to be rejected if the move is cancelled")
+	}
+	if it, _ := chest.Item(0); !it.Equal(apples) {
+		t.Errorf("expected apples to remain in the chest, found %v", it)
+	}
+	if it, _ := s.inv.Item(9); !it.Empty() {
+		t.Errorf("expected no apples to be added to the inventory, found %v", it)
+	}
+
+	resp := (<-s.packets).(*packet.ItemStackResponse)
+	if resp.Responses[0].Status != protocol.ItemStackResponseStatusError {
+		t.Errorf("expected the request to be rejected, got status %v", resp.Responses[0].Status)
+	}
+	resent := map[uint32]bool{}
+	for len(s.packets) > 0 {
+		if pk, ok := (<-s.packets).(*packet.InventoryContent); ok {
+			resent[pk.WindowID] = true
+		}
+	}
+	if !resent[1] || !resent[protocol.WindowIDInventory] {
+		t.Errorf("expected both the chest and the inventory to be resent, resent windows %v", resent)
+	}
+}
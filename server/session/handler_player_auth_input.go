@@ -21,6 +21,7 @@ func (h PlayerAuthInputHandler) Handle(p packet.Packet, s *Session, tx *world.Tx
 	if err := h.handleMovement(pk, s, c); err != nil {
 		return err
 	}
+	s.closeDistantContainer(tx, c)
 	return h.handleActions(pk, s, tx, c)
 }
 
@@ -46,6 +47,11 @@ func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Se
 
 	newPos := vec32To64(pk.Position)
 	deltaPos, deltaYaw, deltaPitch := newPos.Sub(pos), float64(pk.Yaw)-yaw, float64(pk.Pitch)-pitch
+	if s.riding() {
+		// The position of a riding player is controlled by its vehicle. Only
+		// the rotation sent by the client is used.
+		deltaPos = mgl64.Vec3{}
+	}
 	if mgl64.FloatEqual(deltaPos.Len(), 0) && mgl64.FloatEqual(deltaYaw, 0) && mgl64.FloatEqual(deltaPitch, 0) {
 		// The PlayerAuthInput packet is sent every tick, so don't do anything if the position and rotation
 		// were unchanged.
@@ -69,6 +75,10 @@ func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Se
 
 // handleActions handles the actions with the world that are present in the PlayerAuthInput packet.
 func (h PlayerAuthInputHandler) handleActions(pk *packet.PlayerAuthInput, s *Session, tx *world.Tx, c Controllable) error {
+	// Input flags are handled first, so that changes in pose, such as starting
+	// to sneak, apply to the collision box used when placing a block in the
+	// same tick.
+	h.handleInputFlags(pk.InputData, s, c)
 	if pk.InputData.Load(packet.InputFlagPerformItemInteraction) {
 		if err := h.handleUseItemData(pk.ItemInteractionData, s, c); err != nil {
 			return err
@@ -79,7 +89,6 @@ func (h PlayerAuthInputHandler) handleActions(pk *packet.PlayerAuthInput, s *Ses
 			return err
 		}
 	}
-	h.handleInputFlags(pk.InputData, s, c)
 
 	if pk.InputData.Load(packet.InputFlagPerformItemStackRequest) {
 		s.inTransaction.Store(true)
@@ -20,6 +20,10 @@ func (h *ServerBoundLoadingScreenHandler) Handle(p packet.Packet, s *Session, _
 	v, ok := pk.LoadingScreenID.Value()
 	if !ok || h.expectedID.Load() == 0 {
 		return nil
+	} else if v < h.expectedID.Load() {
+		// The loading screen of a dimension change that was followed by
+		// another dimension change right away.
+		return nil
 	} else if v != h.expectedID.Load() {
 		return fmt.Errorf("expected loading screen ID %d, got %d", h.expectedID.Load(), v)
 	} else if pk.Type == packet.LoadingScreenTypeEnd {
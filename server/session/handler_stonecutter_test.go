@@ -0,0 +1,39 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/recipe"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+)
+
+func TestStonecutterCutsStoneIntoStairs(t *testing.T) {
+	s, h := newTestSession(), newTestHandler()
+	stairs := block.Stairs{Block: block.Stone{}}
+	for _, r := range recipe.Recipes() {
+		in, ok := r.Input()[0].(item.Stack)
+		if r.Block() == "stonecutter" && ok && in.Item() == (block.Stone{}) && r.Output()[0].Item() == stairs {
+			s.recipes[1] = r
+		}
+	}
+	if _, ok := s.recipes[1]; !ok {
+		t.Fatalf("expected a stonecutter recipe cutting stone into stairs")
+	}
+	_ = s.ui.SetItem(stonecutterInputSlot, item.NewStack(block.Stone{}, 3))
+
+	withOpenedBlock(s, block.Stonecutter{}, func(tx *world.Tx) {
+		if err := h.handleStonecutting(&protocol.CraftRecipeStackRequestAction{RecipeNetworkID: 1, NumberOfCrafts: 1}, s, tx); err != nil {
+			t.Errorf("expected stone to be cut into stairs: %v", err)
+			return
+		}
+		if in, _ := s.ui.Item(stonecutterInputSlot); in.Count() != 2 {
+			t.Errorf("expected one stone to be used, %v left", in.Count())
+		}
+		if out, _ := s.ui.Item(craftingResult); out.Item() != stairs || out.Count() != 1 {
+			t.Errorf("expected stone stairs as result, got %v", out)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/structure"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// StructureBlockUpdateHandler handles the StructureBlockUpdate packet, sent when a player changes the settings of a
+// structure block in its UI.
+type StructureBlockUpdateHandler struct{}
+
+// Handle ...
+func (StructureBlockUpdateHandler) Handle(p packet.Packet, _ *Session, tx *world.Tx, c Controllable) error {
+	pk := p.(*packet.StructureBlockUpdate)
+	pos := blockPosFromProtocol(pk.Position)
+	if !c.GameMode().CreativeInventory() {
+		return fmt.Errorf("cannot edit structure block at %v outside of creative mode", pos)
+	}
+	if !canReach(c, pos.Vec3Middle()) {
+		return fmt.Errorf("block at %v is not within reach", pos)
+	}
+	b, ok := tx.Block(pos).(block.StructureBlock)
+	if !ok {
+		return fmt.Errorf("block at %v is not a structure block", pos)
+	}
+	mode, ok := structureBlockMode(pk.StructureBlockType)
+	if !ok {
+		return fmt.Errorf("invalid structure block type %v", pk.StructureBlockType)
+	}
+	b.Mode = mode
+	b.Name = pk.StructureName
+	b.DataField = pk.DataField
+	b.IncludePlayers = pk.IncludePlayers
+	b.ShowBoundingBox = pk.ShowBoundingBox
+	applyStructureSettings(&b, pk.Settings)
+
+	if pk.ShouldTrigger && b.Mode == block.StructureBlockSave() {
+		// Corner structure blocks with the same name take priority over the size set in the UI.
+		if detected, ok := b.DetectBounds(pos, tx); ok {
+			b = detected
+		}
+	}
+	tx.SetBlock(pos, b, nil)
+	if pk.ShouldTrigger {
+		return b.Trigger(pos, tx)
+	}
+	return nil
+}
+
+// structureBlockMode converts a structure block type sent over the network to a block.StructureBlockMode.
+func structureBlockMode(t int32) (block.StructureBlockMode, bool) {
+	for _, m := range block.StructureBlockModes() {
+		if int32(m.Uint8()) == t {
+			return m, true
+		}
+	}
+	return block.StructureBlockMode{}, false
+}
+
+// applyStructureSettings applies the protocol.StructureSettings passed to a block.StructureBlock.
+func applyStructureSettings(b *block.StructureBlock, settings protocol.StructureSettings) {
+	b.Offset = blockPosFromProtocol(settings.Offset)
+	b.Size = cube.Pos{max(int(settings.Size[0]), 0), max(int(settings.Size[1]), 0), max(int(settings.Size[2]), 0)}
+	b.Rotation = int(settings.Rotation % 4)
+	b.Mirror = structure.Mirror(settings.Mirror % 4)
+	b.Integrity = mgl64.Clamp(float64(settings.Integrity), 0, 1)
+	b.Seed = int64(settings.Seed)
+	b.IgnoreEntities = settings.IgnoreEntities
+	b.RemoveBlocks = settings.IgnoreBlocks
+}
@@ -0,0 +1,60 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/structure"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// StructureTemplateDataRequestHandler handles the StructureTemplateDataRequest packet, sent by the client to request
+// the data of a structure, for example to preview it in the UI of a structure block or to export it to a file.
+type StructureTemplateDataRequestHandler struct{}
+
+// Handle ...
+func (StructureTemplateDataRequestHandler) Handle(p packet.Packet, s *Session, tx *world.Tx, c Controllable) error {
+	pk := p.(*packet.StructureTemplateDataRequest)
+	pos := blockPosFromProtocol(pk.Position)
+	if !c.GameMode().CreativeInventory() {
+		return fmt.Errorf("cannot request structure template data outside of creative mode")
+	}
+	if !canReach(c, pos.Vec3Middle()) {
+		return fmt.Errorf("block at %v is not within reach", pos)
+	}
+
+	resp := &packet.StructureTemplateDataResponse{StructureName: pk.StructureName, ResponseType: packet.StructureTemplateResponseExport}
+	switch pk.RequestType {
+	case packet.StructureTemplateRequestExportFromSave:
+		b, ok := tx.Block(pos).(block.StructureBlock)
+		if !ok {
+			return fmt.Errorf("block at %v is not a structure block", pos)
+		}
+		b.Name = pk.StructureName
+		applyStructureSettings(&b, pk.Settings)
+		if st, err := b.Structure(pos, tx); err == nil {
+			resp.Success, resp.StructureTemplate = true, st.EncodeNBT()
+		}
+	case packet.StructureTemplateRequestExportFromLoad:
+		resp.StructureTemplate, resp.Success = tx.World().Structure(pk.StructureName)
+	case packet.StructureTemplateRequestQuerySavedStructure:
+		resp.ResponseType = packet.StructureTemplateResponseQuery
+		if data, ok := tx.World().Structure(pk.StructureName); ok {
+			// The client only needs the size of the structure to render the bounding box of a structure block in
+			// load mode, so the full structure is not sent.
+			if st, err := structure.DecodeNBT(data); err == nil {
+				d := st.Dimensions()
+				resp.Success = true
+				resp.StructureTemplate = map[string]any{
+					"format_version":         int32(1),
+					"size":                   []int32{int32(d[0]), int32(d[1]), int32(d[2])},
+					"structure_world_origin": []int32{0, 0, 0},
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("unknown structure template data request type %v", pk.RequestType)
+	}
+	s.writePacket(resp)
+	return nil
+}
@@ -0,0 +1,70 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+)
+
+const (
+	// tradeIngredientOneSlot is the slot index of the first input item in the trading window.
+	tradeIngredientOneSlot = 0x04
+	// tradeIngredientTwoSlot is the slot index of the second input item in the trading window.
+	tradeIngredientTwoSlot = 0x05
+)
+
+// handleTrade handles a CraftRecipe stack request action made in the trading window of a villager. The recipe
+// network ID of the action refers to one of the offers of the villager.
+func (h *ItemStackRequestHandler) handleTrade(a *protocol.CraftRecipeStackRequestAction, s *Session, tx *world.Tx, c Controllable) error {
+	m, v, ok := s.tradingVillager(s.tradingWith.Load(), tx)
+	if !ok {
+		return fmt.Errorf("villager traded with is no longer present")
+	}
+	offers := v.Offers()
+	index := int(a.RecipeNetworkID) - 1
+	if index < 0 || index >= len(offers) {
+		return fmt.Errorf("trade offer with network id %v does not exist", a.RecipeNetworkID)
+	}
+	offer := offers[index]
+
+	timesTraded := int(a.NumberOfCrafts)
+	if timesTraded < 1 {
+		return fmt.Errorf("times traded must be at least 1")
+	}
+	if offer.Uses()+timesTraded > offer.MaxUses {
+		return fmt.Errorf("trade offer with network id %v does not have %v uses left", a.RecipeNetworkID, timesTraded)
+	}
+
+	slots := []protocol.StackRequestSlotInfo{
+		{Container: protocol.FullContainerName{ContainerID: protocol.ContainerTradeTwoIngredientOne}, Slot: tradeIngredientOneSlot},
+		{Container: protocol.FullContainerName{ContainerID: protocol.ContainerTradeTwoIngredientTwo}, Slot: tradeIngredientTwoSlot},
+	}
+	expectedInputs := []item.Stack{offer.Price(c, v.Reputation(c)), offer.SecondInput}
+	inputs := make([]item.Stack, len(slots))
+	for i, slot := range slots {
+		inputs[i], _ = h.itemInSlot(slot, s, tx)
+		expected := expectedInputs[i]
+		if expected.Empty() {
+			continue
+		}
+		if !inputs[i].Comparable(expected) {
+			return fmt.Errorf("input item %v is not the same as expected input %v", inputs[i], expected)
+		}
+		if inputs[i].Count() < expected.Count()*timesTraded {
+			return fmt.Errorf("input item count %v is less than required count %v", inputs[i].Count(), expected.Count()*timesTraded)
+		}
+	}
+
+	if !v.Trade(m, index, timesTraded, tx) {
+		return fmt.Errorf("villager rejected trade offer with network id %v", a.RecipeNetworkID)
+	}
+	for i, slot := range slots {
+		if !expectedInputs[i].Empty() {
+			h.setItemInSlot(slot, inputs[i].Grow(-expectedInputs[i].Count()*timesTraded), s, tx)
+		}
+	}
+	// Offers may have been unlocked or run out of stock, so the client needs the updated offers.
+	s.sendTrades(m, tx)
+	return h.createResults(s, tx, repeatStacks([]item.Stack{offer.Output}, timesTraded)...)
+}
@@ -0,0 +1,22 @@
+package session
+
+import (
+	"os"
+	"testing"
+	_ "unsafe"
+)
+
+//go:linkname finaliseBlockRegistry github.com/df-mc/dragonfly/server/world.finaliseBlockRegistry
+func finaliseBlockRegistry()
+
+//go:linkname registerVanilla github.com/df-mc/dragonfly/server/item/recipe.registerVanilla
+func registerVanilla()
+
+func TestMain(m *testing.M) {
+	// Blocks and recipes are normally finalised and registered when a server
+	// is created, so this is done manually before running the tests of the
+	// package.
+	finaliseBlockRegistry()
+	registerVanilla()
+	os.Exit(m.Run())
+}
@@ -15,6 +15,8 @@ import (
 	_ "unsafe" // Imported for compiler directives.
 
 	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item"
@@ -65,11 +67,43 @@ func (s *Session) StartShowingEntity(e world.Entity) {
 
 // closeCurrentContainer closes the container the player might currently have open.
 func (s *Session) closeCurrentContainer(tx *world.Tx) {
+	if !s.containerOpened.Load() {
+		return
+	}
+	if c, ok := s.controllable(tx); ok && s.tradingWith.Load() == nil {
+		_ = c.ContainerClosing(s.openedInventory(tx), *s.openedPos.Load(), false)
+	}
+	s.closeContainer(tx)
+}
+
+// closeContainer closes the container the player might currently have open
+// without calling Controllable.ContainerClosing.
+func (s *Session) closeContainer(tx *world.Tx) {
 	if !s.containerOpened.Load() {
 		return
 	}
 	s.closeWindow()
 
+	if v := s.openedVirtual.Swap(nil); v != nil {
+		// Replace the fake blocks of the container with the actual blocks.
+		for _, pos := range v.blocks {
+			s.ViewBlockUpdate(pos, tx.Block(pos), 0)
+		}
+		return
+	}
+
+	if h := s.tradingWith.Swap(nil); h != nil {
+		if _, v, ok := s.tradingVillager(h, tx); ok {
+			v.StopTrading()
+		}
+		return
+	}
+	if h := s.openedEntity.Swap(nil); h != nil {
+		if e, ok := h.Entity(tx); ok {
+			e.(entity.Container).RemoveViewer(s)
+		}
+		return
+	}
 	pos := *s.openedPos.Load()
 	b := tx.Block(pos)
 	if container, ok := b.(block.Container); ok {
@@ -181,6 +215,12 @@ func (s *Session) sendRecipes() {
 			})
 		}
 	}
+	for i, id := range cartographyRecipes {
+		recipes = append(recipes, &protocol.MultiRecipe{
+			UUID:            uuid.MustParse(id),
+			RecipeNetworkID: uint32(len(recipe.Recipes()) + i + 1),
+		})
+	}
 	s.writePacket(&packet.CraftingData{Recipes: recipes, PotionRecipes: potionRecipes, PotionContainerChangeRecipes: potionContainerChange, ClearRecipes: true})
 }
 
@@ -225,6 +265,26 @@ func (s *Session) sendInv(inv *inventory.Inventory, windowID uint32) {
 		pk.Content = append(pk.Content, instanceFromItem(i))
 	}
 	s.writePacket(pk)
+	s.sendBundleContents(inv.Items()...)
+}
+
+// windowIDByInv returns the window ID that the contents of the inventory passed are sent with. False is
+// returned if the inventory is not currently viewed by the client.
+func (s *Session) windowIDByInv(inv *inventory.Inventory) (uint32, bool) {
+	switch inv {
+	case s.inv:
+		return protocol.WindowIDInventory, true
+	case s.ui:
+		return protocol.WindowIDUI, true
+	case s.offHand:
+		return protocol.WindowIDOffHand, true
+	case s.armour.Inventory():
+		return protocol.WindowIDArmour, true
+	}
+	if s.containerOpened.Load() && inv == s.openedWindow.Load() {
+		return s.openedWindowID.Load(), true
+	}
+	return 0, false
 }
 
 // sendItem sends the item stack passed to the client with the window ID and slot passed.
@@ -234,6 +294,7 @@ func (s *Session) sendItem(item item.Stack, slot int, windowID uint32) {
 		Slot:     uint32(slot),
 		NewItem:  instanceFromItem(item),
 	})
+	s.sendBundleContents(item)
 }
 
 const (
@@ -269,48 +330,67 @@ func (s *Session) invByID(id int32, tx *world.Tx) (*inventory.Inventory, bool) {
 		if !s.containerOpened.Load() {
 			return nil, false
 		}
+		if s.openedVirtual.Load() != nil {
+			if id == protocol.ContainerLevelEntity || id == protocol.ContainerBarrel {
+				return s.openedWindow.Load(), true
+			}
+			return nil, false
+		}
 		switch id {
 		case protocol.ContainerLevelEntity:
 			return s.openedWindow.Load(), true
 		case protocol.ContainerBarrel:
-			if _, barrel := tx.Block(*s.openedPos.Load()).(block.Barrel); barrel {
+			if _, barrel := s.openedBlock(tx).(block.Barrel); barrel {
+				return s.openedWindow.Load(), true
+			}
+		case protocol.ContainerShulkerBox:
+			if _, shulkerBox := s.openedBlock(tx).(block.ShulkerBox); shulkerBox {
 				return s.openedWindow.Load(), true
 			}
 		case protocol.ContainerBeaconPayment:
-			if _, beacon := tx.Block(*s.openedPos.Load()).(block.Beacon); beacon {
+			if _, beacon := s.openedBlock(tx).(block.Beacon); beacon {
 				return s.ui, true
 			}
 		case protocol.ContainerBrewingStandInput, protocol.ContainerBrewingStandResult, protocol.ContainerBrewingStandFuel:
-			if _, brewingStand := tx.Block(*s.openedPos.Load()).(block.BrewingStand); brewingStand {
+			if _, brewingStand := s.openedBlock(tx).(block.BrewingStand); brewingStand {
 				return s.openedWindow.Load(), true
 			}
 		case protocol.ContainerAnvilInput, protocol.ContainerAnvilMaterial:
-			if _, anvil := tx.Block(*s.openedPos.Load()).(block.Anvil); anvil {
+			if _, anvil := s.openedBlock(tx).(block.Anvil); anvil {
 				return s.ui, true
 			}
 		case protocol.ContainerSmithingTableTemplate, protocol.ContainerSmithingTableInput, protocol.ContainerSmithingTableMaterial:
-			if _, smithing := tx.Block(*s.openedPos.Load()).(block.SmithingTable); smithing {
+			if _, smithing := s.openedBlock(tx).(block.SmithingTable); smithing {
 				return s.ui, true
 			}
 		case protocol.ContainerLoomInput, protocol.ContainerLoomDye, protocol.ContainerLoomMaterial:
-			if _, loom := tx.Block(*s.openedPos.Load()).(block.Loom); loom {
+			if _, loom := s.openedBlock(tx).(block.Loom); loom {
 				return s.ui, true
 			}
 		case protocol.ContainerStonecutterInput:
-			if _, ok := tx.Block(*s.openedPos.Load()).(block.Stonecutter); ok {
+			if _, ok := s.openedBlock(tx).(block.Stonecutter); ok {
+				return s.ui, true
+			}
+		case protocol.ContainerCartographyInput, protocol.ContainerCartographyAdditional:
+			if _, ok := s.openedBlock(tx).(block.CartographyTable); ok {
 				return s.ui, true
 			}
 		case protocol.ContainerGrindstoneInput, protocol.ContainerGrindstoneAdditional:
-			if _, ok := tx.Block(*s.openedPos.Load()).(block.Grindstone); ok {
+			if _, ok := s.openedBlock(tx).(block.Grindstone); ok {
 				return s.ui, true
 			}
 		case protocol.ContainerEnchantingInput, protocol.ContainerEnchantingMaterial:
-			if _, enchanting := tx.Block(*s.openedPos.Load()).(block.EnchantingTable); enchanting {
+			if _, enchanting := s.openedBlock(tx).(block.EnchantingTable); enchanting {
+				return s.ui, true
+			}
+		case protocol.ContainerTradeIngredientOne, protocol.ContainerTradeIngredientTwo, protocol.ContainerTradeResultPreview,
+			protocol.ContainerTradeTwoIngredientOne, protocol.ContainerTradeTwoIngredientTwo, protocol.ContainerTradeTwoResultPreview:
+			if s.tradingWith.Load() != nil {
 				return s.ui, true
 			}
 		case protocol.ContainerFurnaceIngredient, protocol.ContainerFurnaceFuel, protocol.ContainerFurnaceResult,
 			protocol.ContainerBlastFurnaceIngredient, protocol.ContainerSmokerIngredient:
-			if _, ok := tx.Block(*s.openedPos.Load()).(smelter); ok {
+			if _, ok := s.openedBlock(tx).(smelter); ok {
 				return s.openedWindow.Load(), true
 			}
 		}
@@ -330,20 +410,40 @@ func (s *Session) Disconnect(message string) {
 	}
 }
 
-// SendSpeed sends the speed of the player in an UpdateAttributes packet, so that it is updated client-side.
-func (s *Session) SendSpeed(speed float64) {
-	s.writePacket(&packet.UpdateAttributes{
-		EntityRuntimeID: selfEntityRuntimeID,
-		Attributes: []protocol.Attribute{{
+// SendAttributes sends the attributes of the player, together with the modifiers applied to them, in an
+// UpdateAttributes packet, so that they are updated client-side. The maximum health of the player is not
+// included, as it is sent along with the health of the player in SendHealth.
+func (s *Session) SendAttributes(a *entity.Attributes) {
+	attrs := make([]protocol.Attribute, 0, len(attribute.All())-1)
+	for _, attr := range attribute.All() {
+		if attr == attribute.MaxHealth() {
+			continue
+		}
+		mods := a.Modifiers(attr)
+		modifiers := make([]protocol.AttributeModifier, len(mods))
+		for i, m := range mods {
+			modifiers[i] = protocol.AttributeModifier{
+				ID:           m.ID.String(),
+				Name:         m.Name,
+				Amount:       float32(m.Amount),
+				Operation:    int32(m.Operation.Uint8()),
+				Serializable: true,
+			}
+		}
+		attrs = append(attrs, protocol.Attribute{
 			AttributeValue: protocol.AttributeValue{
-				Name:  "minecraft:movement",
-				Value: float32(speed),
-				Max:   math.MaxFloat32,
+				Name:  attr.Name(),
+				Value: float32(a.Value(attr)),
+				Max:   float32(attr.Max()),
+				Min:   float32(attr.Min()),
 			},
-			DefaultMax: math.MaxFloat32,
-			Default:    0.1,
-		}},
-	})
+			DefaultMin: float32(attr.Min()),
+			DefaultMax: float32(attr.Max()),
+			Default:    float32(attr.Default()),
+			Modifiers:  modifiers,
+		})
+	}
+	s.writePacket(&packet.UpdateAttributes{EntityRuntimeID: selfEntityRuntimeID, Attributes: attrs})
 }
 
 // SendFood ...
@@ -623,7 +723,7 @@ func (s *Session) broadcastInvFunc(tx *world.Tx, c Controllable) inventory.SlotF
 func (s *Session) broadcastEnderChestFunc(tx *world.Tx, _ Controllable) inventory.SlotFunc {
 	return func(slot int, _, after item.Stack) {
 		if !s.inTransaction.Load() {
-			if _, ok := tx.Block(*s.openedPos.Load()).(block.EnderChest); ok {
+			if _, ok := s.openedBlock(tx).(block.EnderChest); ok {
 				s.ViewSlotChange(slot, after)
 			}
 		}
@@ -666,7 +766,7 @@ func (s *Session) uiInventoryFunc(tx *world.Tx, c Controllable) inventory.SlotFu
 	return func(slot int, _, after item.Stack) {
 		if slot == enchantingInputSlot && s.containerOpened.Load() {
 			pos := *s.openedPos.Load()
-			if _, enchanting := tx.Block(pos).(block.EnchantingTable); enchanting {
+			if _, enchanting := s.openedBlock(tx).(block.EnchantingTable); enchanting {
 				s.sendEnchantmentOptions(tx, c, pos, after)
 			}
 		}
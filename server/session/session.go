@@ -40,6 +40,9 @@ type Session struct {
 	conn     Conn
 	handlers map[uint32]packetHandler
 	packets  chan packet.Packet
+	// latency holds the latency of the connection, smoothed over multiple
+	// samples taken every second. It is 0 until the first sample is taken.
+	latency atomic.Int64
 
 	currentScoreboard atomic.Pointer[string]
 	currentLines      atomic.Pointer[[]string]
@@ -57,11 +60,17 @@ type Session struct {
 	entityRuntimeIDs map[*world.EntityHandle]uint64
 	entities         map[uint64]*world.EntityHandle
 	hiddenEntities   map[uuid.UUID]struct{}
+	// seatOffsets holds the seat offsets of entities shown to the session
+	// that are currently riding another entity.
+	seatOffsets map[*world.EntityHandle]mgl64.Vec3
 
 	// heldSlot is the slot in the inventory that the controllable is holding.
 	heldSlot                     *uint32
 	inv, offHand, enderChest, ui *inventory.Inventory
 	armour                       *inventory.Armour
+	// bundles holds the inventories used to hold the contents of bundles
+	// while they are changed by the client, indexed by the bundle ID.
+	bundles map[int32]*inventory.Inventory
 
 	// joinSkin is the first skin that the player joined with. It is sent on
 	// spawn for the player list, but otherwise updated immediately when the
@@ -75,18 +84,36 @@ type Session struct {
 	openedContainerID              atomic.Uint32
 	openedWindow                   atomic.Pointer[inventory.Inventory]
 	openedPos                      atomic.Pointer[cube.Pos]
+	tradingWith                    atomic.Pointer[world.EntityHandle]
+	openedEntity                   atomic.Pointer[world.EntityHandle]
+	openedVirtual                  atomic.Pointer[virtualContainer]
 	swingingArm                    atomic.Bool
 	changingSlot                   atomic.Bool
 	changingDimension              atomic.Bool
 	moving                         bool
 
+	// dimensionRanges holds the ranges sent to the client for the dimensions
+	// it knows, if they differ from the default range of the dimension.
+	dimensionRanges map[world.Dimension]cube.Range
+
 	recipes map[uint32]recipe.Recipe
 
+	// subChunkRequests specifies if chunks are sent to the client as a
+	// LevelChunk without blocks, after which the client requests the sub
+	// chunks it needs, rather than as full chunks.
+	subChunkRequests bool
+	subChunkQueue    []*packet.SubChunkRequest
+	queuedSubChunks  int
+	subChunkBudget   int
+
 	blobMu                sync.Mutex
-	blobs                 map[uint64][]byte
-	openChunkTransactions []map[uint64]struct{}
+	blobs                 map[uint64]struct{}
+	openChunkTransactions []*chunkTransaction
 	invOpened             bool
 
+	titleMu sync.Mutex
+	title   titleState
+
 	hudMu      sync.RWMutex
 	hudUpdates map[hud.Element]bool
 	hiddenHud  map[hud.Element]struct{}
@@ -144,6 +171,22 @@ type Config struct {
 
 	MaxChunkRadius int
 
+	// SubChunkRequests specifies if chunks are sent to the client without
+	// their blocks, letting the client request the sub chunks it needs. If
+	// false, chunks are sent to the client as full chunks. It is decided
+	// when the game is started for the client.
+	SubChunkRequests bool
+
+	// Blobs is the BlobStore that holds the chunk blobs sent to clients with
+	// the client blob cache enabled. If nil, chunks are never sent using the
+	// client blob cache.
+	Blobs *BlobStore
+
+	// Chunks is the ChunkCache that chunks encoded to be sent to the client
+	// are shared through with other sessions. If nil, chunks are encoded
+	// every time they are sent.
+	Chunks *ChunkCache
+
 	JoinMessage, QuitMessage chat.Translation
 
 	HandleStop func(*world.Tx, Controllable)
@@ -162,14 +205,15 @@ func (conf Config) New(conn Conn) *Session {
 
 	s := &Session{}
 	*s = Session{
-		openChunkTransactions:  make([]map[uint64]struct{}, 0, 8),
+		openChunkTransactions:  make([]*chunkTransaction, 0, 8),
 		closeBackground:        make(chan struct{}),
 		handlers:               map[uint32]packetHandler{},
 		packets:                make(chan packet.Packet, 256),
 		entityRuntimeIDs:       map[*world.EntityHandle]uint64{},
 		entities:               map[uint64]*world.EntityHandle{},
 		hiddenEntities:         map[uuid.UUID]struct{}{},
-		blobs:                  map[uint64][]byte{},
+		seatOffsets:            map[*world.EntityHandle]mgl64.Vec3{},
+		blobs:                  map[uint64]struct{}{},
 		chunkRadius:            int32(r),
 		maxChunkRadius:         int32(conf.MaxChunkRadius),
 		conn:                   conn,
@@ -182,6 +226,8 @@ func (conf Config) New(conn Conn) *Session {
 		debugShapes:            make(map[int]debug.Shape),
 		debugShapesAdd:         make(chan debug.Shape, 256),
 		debugShapesRemove:      make(chan int, 256),
+		dimensionRanges:        make(map[world.Dimension]cube.Range),
+		subChunkRequests:       conf.SubChunkRequests,
 	}
 	s.openedWindow.Store(inventory.New(1, nil))
 	s.openedPos.Store(&cube.Pos{})
@@ -197,7 +243,6 @@ func (conf Config) New(conn Conn) *Session {
 	s.writePacket(&packet.CreativeContent{Groups: groups, Items: items})
 	s.sendRecipes()
 	s.sendArmourTrimData()
-	s.SendSpeed(0.1)
 	go func() {
 		for {
 			select {
@@ -226,11 +271,20 @@ func (s *Session) SetHandle(handle *world.EntityHandle, skin skin.Skin) {
 // The function passed will be called when the session stops running.
 func (s *Session) Spawn(c Controllable, tx *world.Tx) {
 	s.SendHealth(c.Health(), c.MaxHealth(), c.Absorption())
+	s.SendAttributes(c.Attributes())
 	s.SendExperience(c.ExperienceLevel(), c.ExperienceProgress())
 	s.SendFood(c.Food(), 0, 0)
 
 	pos := c.Position()
+	if s.sendDimensionData(tx.World().Dimension()) {
+		// The client only applies the range of a dimension when it changes to
+		// it, so the dimension it spawned in is entered again.
+		s.reenterDimension(tx.World().Dimension(), c)
+	}
 	s.chunkLoader = world.NewLoader(int(s.chunkRadius), tx.World(), s)
+	s.subChunkBudget = subChunksPerTick
+	s.answerSubChunkRequests(tx)
+
 	s.chunkLoader.Move(tx, pos)
 	s.writePacket(&packet.NetworkChunkPublisherUpdate{
 		Position: protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
@@ -281,6 +335,7 @@ func (s *Session) close(tx *world.Tx, c Controllable) {
 	_ = s.armour.Close()
 
 	s.chunkLoader.Close(tx)
+	s.releaseBlobs()
 
 	if !s.conf.QuitMessage.Zero() {
 		chat.Global.Writet(s.conf.QuitMessage, s.conn.IdentityData().DisplayName)
@@ -315,11 +370,31 @@ func (s *Session) Addr() net.Addr {
 	return s.conn.RemoteAddr()
 }
 
-// Latency returns the latency of the connection.
+// Latency returns the latency of the connection, averaged over the last few
+// seconds so that short spikes in latency have little effect on it.
 func (s *Session) Latency() time.Duration {
+	if l := s.latency.Load(); l != 0 {
+		return time.Duration(l)
+	}
 	return s.conn.Latency()
 }
 
+// sampleLatency takes a sample of the latency of the connection and adds it to
+// the smoothed latency returned by Latency.
+func (s *Session) sampleLatency() {
+	sample := int64(s.conn.Latency())
+	if l := s.latency.Load(); l != 0 {
+		// An exponential moving average is used, in which a sample taken
+		// loses half of its weight after about 5 samples.
+		sample = l + (sample-l)/8
+	}
+	if sample <= 0 {
+		// A latency of 0 means no sample was taken yet.
+		sample = 1
+	}
+	s.latency.Store(sample)
+}
+
 // ClientData returns the login.ClientData of the underlying *minecraft.Conn.
 func (s *Session) ClientData() login.ClientData {
 	return s.conn.ClientData()
@@ -382,6 +457,7 @@ func (s *Session) background() {
 				c := e.(Controllable)
 
 				if i++; i%20 == 0 {
+					s.sampleLatency()
 					// Enum resending happens relatively often and frequent updates are more important than with full
 					// command changes. Those are generally only related to permission changes, which doesn't happen often.
 					s.resendEnums(enums, enumValues, c)
@@ -406,6 +482,9 @@ func (s *Session) sendChunks(tx *world.Tx, c Controllable) {
 	if w := tx.World(); s.chunkLoader.World() != w && w != nil {
 		s.handleWorldSwitch(w, tx, c)
 	}
+	s.subChunkBudget = subChunksPerTick
+	s.answerSubChunkRequests(tx)
+
 	pos := c.Position()
 	s.chunkLoader.Move(tx, pos)
 	s.writePacket(&packet.NetworkChunkPublisherUpdate{
@@ -425,17 +504,21 @@ func (s *Session) sendChunks(tx *world.Tx, c Controllable) {
 
 // handleWorldSwitch handles the player of the Session switching worlds.
 func (s *Session) handleWorldSwitch(w *world.World, tx *world.Tx, c Controllable) {
-	if s.conn.ClientCacheEnabled() {
-		s.blobMu.Lock()
-		s.blobs = map[uint64][]byte{}
-		s.openChunkTransactions = nil
-		s.blobMu.Unlock()
+	s.releaseBlobs()
+
+	if from, to := s.chunkLoader.World().Dimension(), w.Dimension(); from != to {
+		s.sendDimensionData(to)
+		if s.dimensionID(from) == s.dimensionID(to) {
+			// Both dimensions are shown as the same dimension to the client,
+			// which does not change to the dimension that it is already in.
+			s.reenterDimension(to, c)
+		} else {
+			s.changeDimension(s.dimensionID(to), false, c)
+		}
 	}
-
-	dim, _ := world.DimensionID(w.Dimension())
-	same := w.Dimension() == s.chunkLoader.World().Dimension()
-	if !same {
-		s.changeDimension(int32(dim), false, c)
+	if s.openedVirtual.Load() != nil {
+		// The fake blocks of a virtual container do not exist in the new world.
+		s.closeCurrentContainer(tx)
 	}
 	s.ViewEntityTeleport(c, c.Position())
 	s.chunkLoader.ChangeWorld(tx, w)
@@ -456,6 +539,7 @@ func (s *Session) changeDimension(dim int32, silent bool, c Controllable) {
 	})
 	s.writePacket(&packet.StopSound{StopAll: silent})
 	s.writePacket(&packet.PlayStatus{Status: packet.PlayStatusPlayerSpawn})
+	s.resendTitle()
 
 	// As of v1.19.50, the dimension ack that is meant to be sent by the client is now sent by the server. The client
 	// still sends the ack, but after the server has sent it. Thanks to Mojang for another groundbreaking change.
@@ -465,6 +549,56 @@ func (s *Session) changeDimension(dim int32, silent bool, c Controllable) {
 	})
 }
 
+// reenterDimension makes the client change to the dimension that the
+// world.Dimension passed is shown as, while it is already in that dimension.
+// The client is first moved to another dimension silently, so that it fully
+// reloads the dimension, including its range.
+func (s *Session) reenterDimension(dim world.Dimension, c Controllable) {
+	other := world.Dimension(world.Nether)
+	if world.ClientDimension(dim) == world.Nether {
+		other = world.Overworld
+	}
+	s.changeDimension(s.dimensionID(other), true, c)
+	s.changeDimension(s.dimensionID(dim), false, c)
+}
+
+// sendDimensionData sends the range of the world.Dimension passed to the
+// client if it differs from the range that the client uses for the dimension
+// that it is shown as. True is returned if the range was sent.
+func (s *Session) sendDimensionData(dim world.Dimension) bool {
+	clientDim, r := world.ClientDimension(dim), dim.Range()
+	current, ok := s.dimensionRanges[clientDim]
+	if !ok {
+		current = clientDim.Range()
+	}
+	if current == r {
+		return false
+	}
+	s.dimensionRanges[clientDim] = r
+	s.writePacket(&packet.DimensionData{Definitions: []protocol.DimensionDefinition{{
+		Name:      dimensionNames[clientDim],
+		Range:     [2]int32{int32(r[0]), int32(r[1])},
+		Generator: dimensionGenerators[clientDim],
+	}}})
+	return true
+}
+
+var (
+	// dimensionNames holds the names of the dimensions known to the client.
+	dimensionNames = map[world.Dimension]string{
+		world.Overworld: "minecraft:overworld",
+		world.Nether:    "minecraft:nether",
+		world.End:       "minecraft:the_end",
+	}
+	// dimensionGenerators holds the generators of the dimensions known to the
+	// client.
+	dimensionGenerators = map[world.Dimension]int32{
+		world.Overworld: protocol.GeneratorOverworld,
+		world.Nether:    protocol.GeneratorNether,
+		world.End:       protocol.GeneratorEnd,
+	}
+)
+
 // ChangingDimension returns whether the session is currently changing dimension or not.
 func (s *Session) ChangingDimension() bool {
 	return s.changingDimension.Load()
@@ -491,39 +625,41 @@ func (s *Session) handlePacket(pk packet.Packet, tx *world.Tx, c Controllable) (
 // registerHandlers registers all packet handlers found in the packetHandler package.
 func (s *Session) registerHandlers() {
 	s.handlers = map[uint32]packetHandler{
-		packet.IDActorEvent:                nil,
-		packet.IDAdventureSettings:         nil, // Deprecated, the client still sends this though.
-		packet.IDAnimate:                   nil,
-		packet.IDAnvilDamage:               nil,
-		packet.IDBlockActorData:            &BlockActorDataHandler{},
-		packet.IDBlockPickRequest:          &BlockPickRequestHandler{},
-		packet.IDBookEdit:                  &BookEditHandler{},
-		packet.IDBossEvent:                 nil,
-		packet.IDClientCacheBlobStatus:     &ClientCacheBlobStatusHandler{},
-		packet.IDCommandRequest:            &CommandRequestHandler{},
-		packet.IDContainerClose:            &ContainerCloseHandler{},
-		packet.IDEmote:                     &EmoteHandler{},
-		packet.IDEmoteList:                 nil,
-		packet.IDFilterText:                nil,
-		packet.IDInteract:                  &InteractHandler{},
-		packet.IDInventoryTransaction:      &InventoryTransactionHandler{},
-		packet.IDItemStackRequest:          &ItemStackRequestHandler{changes: map[byte]map[byte]changeInfo{}, responseChanges: map[int32]map[*inventory.Inventory]map[byte]responseChange{}},
-		packet.IDLecternUpdate:             &LecternUpdateHandler{},
-		packet.IDMobEquipment:              &MobEquipmentHandler{},
-		packet.IDModalFormResponse:         &ModalFormResponseHandler{forms: make(map[uint32]form.Form)},
-		packet.IDMovePlayer:                nil,
-		packet.IDNPCRequest:                &NPCRequestHandler{},
-		packet.IDPlayerAction:              &PlayerActionHandler{},
-		packet.IDPlayerAuthInput:           &PlayerAuthInputHandler{},
-		packet.IDPlayerSkin:                &PlayerSkinHandler{},
-		packet.IDRequestAbility:            &RequestAbilityHandler{},
-		packet.IDRequestChunkRadius:        &RequestChunkRadiusHandler{},
-		packet.IDRespawn:                   &RespawnHandler{},
-		packet.IDSetPlayerInventoryOptions: nil,
-		packet.IDSubChunkRequest:           &SubChunkRequestHandler{},
-		packet.IDText:                      &TextHandler{},
-		packet.IDServerBoundLoadingScreen:  &ServerBoundLoadingScreenHandler{},
-		packet.IDServerBoundDiagnostics:    &ServerBoundDiagnosticsHandler{},
+		packet.IDActorEvent:                   nil,
+		packet.IDAdventureSettings:            nil, // Deprecated, the client still sends this though.
+		packet.IDAnimate:                      nil,
+		packet.IDAnvilDamage:                  nil,
+		packet.IDBlockActorData:               &BlockActorDataHandler{},
+		packet.IDBlockPickRequest:             &BlockPickRequestHandler{},
+		packet.IDBookEdit:                     &BookEditHandler{},
+		packet.IDBossEvent:                    nil,
+		packet.IDClientCacheBlobStatus:        &ClientCacheBlobStatusHandler{},
+		packet.IDCommandRequest:               &CommandRequestHandler{},
+		packet.IDContainerClose:               &ContainerCloseHandler{},
+		packet.IDEmote:                        &EmoteHandler{},
+		packet.IDEmoteList:                    nil,
+		packet.IDFilterText:                   nil,
+		packet.IDInteract:                     &InteractHandler{},
+		packet.IDInventoryTransaction:         &InventoryTransactionHandler{},
+		packet.IDItemStackRequest:             &ItemStackRequestHandler{changes: map[protocol.FullContainerName]map[byte]changeInfo{}, responseChanges: map[int32]map[*inventory.Inventory]map[byte]responseChange{}, touched: map[*inventory.Inventory]struct{}{}},
+		packet.IDLecternUpdate:                &LecternUpdateHandler{},
+		packet.IDMobEquipment:                 &MobEquipmentHandler{},
+		packet.IDModalFormResponse:            &ModalFormResponseHandler{forms: make(map[uint32]form.Form)},
+		packet.IDMovePlayer:                   nil,
+		packet.IDNPCRequest:                   &NPCRequestHandler{},
+		packet.IDPlayerAction:                 &PlayerActionHandler{},
+		packet.IDPlayerAuthInput:              &PlayerAuthInputHandler{},
+		packet.IDPlayerSkin:                   &PlayerSkinHandler{},
+		packet.IDRequestAbility:               &RequestAbilityHandler{},
+		packet.IDRequestChunkRadius:           &RequestChunkRadiusHandler{},
+		packet.IDRespawn:                      &RespawnHandler{},
+		packet.IDSetPlayerInventoryOptions:    nil,
+		packet.IDStructureBlockUpdate:         &StructureBlockUpdateHandler{},
+		packet.IDStructureTemplateDataRequest: &StructureTemplateDataRequestHandler{},
+		packet.IDSubChunkRequest:              &SubChunkRequestHandler{},
+		packet.IDText:                         &TextHandler{},
+		packet.IDServerBoundLoadingScreen:     &ServerBoundLoadingScreenHandler{},
+		packet.IDServerBoundDiagnostics:       &ServerBoundDiagnosticsHandler{},
 	}
 }
 
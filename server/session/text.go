@@ -1,6 +1,7 @@
 package session
 
 import (
+	"encoding/json"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/scoreboard"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
@@ -28,6 +29,19 @@ func (s *Session) SendTranslation(t chat.Translation, l language.Tag, a []any) {
 	})
 }
 
+// SendRawText sends a rich-text message in the JSON raw text format.
+func (s *Session) SendRawText(r chat.RawText) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		s.conf.Log.Error("encode raw text: " + err.Error())
+		return
+	}
+	s.writePacket(&packet.Text{
+		TextType: packet.TextTypeObject,
+		Message:  string(b),
+	})
+}
+
 // SendTip ...
 func (s *Session) SendTip(message string) {
 	s.writePacket(&packet.Text{
@@ -153,8 +167,34 @@ func (s *Session) RemoveBossBar() {
 
 const tickLength = time.Second / 20
 
+// Default durations of titles, used by the client until other durations are
+// set.
+const (
+	defaultTitleFadeIn  = tickLength * 10
+	defaultTitleStay    = tickLength * 70
+	defaultTitleFadeOut = tickLength * 20
+)
+
+// titleState holds the title last sent to the client, so that it may be sent
+// again if the client changes dimension while it is still shown.
+type titleState struct {
+	text, subtitle        string
+	fadeIn, stay, fadeOut time.Duration
+	durationsSet          bool
+	shownAt               time.Time
+}
+
 // SetTitleDurations ...
 func (s *Session) SetTitleDurations(fadeInDuration, remainDuration, fadeOutDuration time.Duration) {
+	s.titleMu.Lock()
+	s.title.fadeIn, s.title.stay, s.title.fadeOut, s.title.durationsSet = fadeInDuration, remainDuration, fadeOutDuration, true
+	s.titleMu.Unlock()
+
+	s.writeTitleDurations(fadeInDuration, remainDuration, fadeOutDuration)
+}
+
+// writeTitleDurations writes the durations of titles to the client.
+func (s *Session) writeTitleDurations(fadeInDuration, remainDuration, fadeOutDuration time.Duration) {
 	s.writePacket(&packet.SetTitle{
 		ActionType:      packet.TitleActionSetDurations,
 		FadeInDuration:  int32(fadeInDuration / tickLength),
@@ -165,14 +205,65 @@ func (s *Session) SetTitleDurations(fadeInDuration, remainDuration, fadeOutDurat
 
 // SendTitle ...
 func (s *Session) SendTitle(text string) {
+	s.titleMu.Lock()
+	s.title.text, s.title.shownAt = text, time.Now()
+	s.titleMu.Unlock()
+
 	s.writePacket(&packet.SetTitle{ActionType: packet.TitleActionSetTitle, Text: text})
 }
 
 // SendSubtitle ...
 func (s *Session) SendSubtitle(text string) {
+	s.titleMu.Lock()
+	s.title.subtitle = text
+	s.titleMu.Unlock()
+
 	s.writePacket(&packet.SetTitle{ActionType: packet.TitleActionSetSubtitle, Text: text})
 }
 
+// ClearTitle ...
+func (s *Session) ClearTitle() {
+	s.titleMu.Lock()
+	s.title.text, s.title.subtitle = "", ""
+	s.titleMu.Unlock()
+
+	s.writePacket(&packet.SetTitle{ActionType: packet.TitleActionClear})
+}
+
+// resendTitle sends the title last sent to the client again if it should
+// still be shown. The client removes titles when it changes dimension, so
+// resendTitle is called after a dimension change to keep showing the title
+// for the rest of its duration.
+func (s *Session) resendTitle() {
+	s.titleMu.Lock()
+	t := s.title
+	s.titleMu.Unlock()
+
+	if t.text == "" {
+		return
+	}
+	if !t.durationsSet {
+		t.fadeIn, t.stay, t.fadeOut = defaultTitleFadeIn, defaultTitleStay, defaultTitleFadeOut
+	}
+	left := t.fadeIn + t.stay - time.Since(t.shownAt)
+	if left <= 0 {
+		return
+	}
+	// The title fades in again immediately and is only shown for the time it
+	// had left before the dimension change.
+	s.writeTitleDurations(0, left, t.fadeOut)
+	if t.subtitle != "" {
+		s.writePacket(&packet.SetTitle{ActionType: packet.TitleActionSetSubtitle, Text: t.subtitle})
+	}
+	s.writePacket(&packet.SetTitle{ActionType: packet.TitleActionSetTitle, Text: t.text})
+	if t.durationsSet {
+		// Restore the durations set so that titles sent later use them again.
+		s.writeTitleDurations(t.fadeIn, t.stay, t.fadeOut)
+	} else {
+		s.writeTitleDurations(defaultTitleFadeIn, defaultTitleStay, defaultTitleFadeOut)
+	}
+}
+
 // SendActionBarMessage ...
 func (s *Session) SendActionBarMessage(text string) {
 	s.writePacket(&packet.SetTitle{ActionType: packet.TitleActionSetActionBar, Text: text})
@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"image/color"
 	"math/rand/v2"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,12 +15,14 @@ import (
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player/bossbar"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/particle"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl32"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 )
@@ -125,7 +128,7 @@ func (s *Session) ViewEntity(e world.Entity) {
 				EntityMetadata:  metadata,
 			})
 			return
-		case entity.TextType:
+		case entity.TextType, entity.RaidType:
 			metadata[protocol.EntityDataKeyVariant] = int32(world.BlockRuntimeID(block.Air{}))
 		case entity.FallingBlockType:
 			metadata[protocol.EntityDataKeyVariant] = int32(world.BlockRuntimeID(v.Behaviour().(*entity.FallingBlockBehaviour).Block()))
@@ -151,6 +154,29 @@ func (s *Session) ViewEntity(e world.Entity) {
 		Yaw:             float32(yaw),
 		HeadYaw:         float32(yaw),
 	})
+	if bar, ok := entityBossBar(e); ok {
+		s.writePacket(&packet.BossEvent{
+			BossEntityUniqueID: int64(runtimeID),
+			EventType:          packet.BossEventShow,
+			BossBarTitle:       bar.Text(),
+			HealthPercentage:   float32(bar.HealthPercentage()),
+			Colour:             uint32(bar.Colour().Uint8()),
+		})
+	}
+}
+
+// entityBossBar returns the boss bar shown for an entity, such as the wither,
+// to players viewing it. False is returned if the entity has no boss bar.
+func entityBossBar(e world.Entity) (bossbar.BossBar, bool) {
+	if b, ok := e.(boss); ok {
+		return b.BossBar(), true
+	}
+	if ent, ok := e.(interface{ Behaviour() entity.Behaviour }); ok {
+		if b, ok := ent.Behaviour().(boss); ok {
+			return b.BossBar(), true
+		}
+	}
+	return bossbar.BossBar{}, false
 }
 
 // ViewEntityGameMode ...
@@ -176,6 +202,7 @@ func (s *Session) HideEntity(e world.Entity) {
 
 	s.entityMutex.Lock()
 	id, ok := s.entityRuntimeIDs[e.H()]
+	delete(s.seatOffsets, e.H())
 	if _, controllable := e.(Controllable); !controllable {
 		delete(s.entityRuntimeIDs, e.H())
 		delete(s.entities, id)
@@ -185,13 +212,18 @@ func (s *Session) HideEntity(e world.Entity) {
 		// The entity was already removed some other way. We don't need to send a packet.
 		return
 	}
+	if _, ok := entityBossBar(e); ok {
+		s.writePacket(&packet.BossEvent{BossEntityUniqueID: int64(id), EventType: packet.BossEventHide})
+	}
 	s.writePacket(&packet.RemoveActor{EntityUniqueID: int64(id)})
 }
 
 // ViewEntityMovement ...
 func (s *Session) ViewEntityMovement(e world.Entity, pos mgl64.Vec3, rot cube.Rotation, onGround bool) {
 	id := s.entityRuntimeID(e)
-	if (id == selfEntityRuntimeID && s.moving) || s.entityHidden(e) {
+	if (id == selfEntityRuntimeID && s.moving) || s.entityHidden(e) || s.entityRiding(e) {
+		// Riding entities are moved along with their vehicle by the client, so
+		// their movement is not sent to avoid them rubber-banding.
 		return
 	}
 
@@ -226,6 +258,76 @@ func entityOffset(e world.Entity) mgl64.Vec3 {
 	return mgl64.Vec3{}
 }
 
+// ViewEntityMount ...
+func (s *Session) ViewEntityMount(rider, vehicle world.Entity, offset mgl64.Vec3, driver bool) {
+	if s.entityHidden(rider) || s.entityHidden(vehicle) {
+		return
+	}
+	s.entityMutex.Lock()
+	riderID, riderShown := s.entityRuntimeIDs[rider.H()]
+	vehicleID, vehicleShown := s.entityRuntimeIDs[vehicle.H()]
+	if riderShown && vehicleShown {
+		// The client positions riders relative to the network position of
+		// their vehicle, which may be offset from the actual position.
+		s.seatOffsets[rider.H()] = offset.Add(entityOffset(rider)).Sub(entityOffset(vehicle))
+	}
+	s.entityMutex.Unlock()
+	if !riderShown || !vehicleShown {
+		// The link will be shown once both entities are viewed.
+		return
+	}
+	linkType := byte(protocol.EntityLinkPassenger)
+	if driver {
+		linkType = protocol.EntityLinkRider
+	}
+	s.writePacket(&packet.SetActorLink{EntityLink: protocol.EntityLink{
+		RiddenEntityUniqueID: int64(vehicleID),
+		RiderEntityUniqueID:  int64(riderID),
+		Type:                 linkType,
+		RiderInitiated:       rider.H() == s.ent,
+	}})
+	s.ViewEntityState(rider)
+}
+
+// ViewEntityDismount ...
+func (s *Session) ViewEntityDismount(rider, vehicle world.Entity) {
+	s.entityMutex.Lock()
+	_, riding := s.seatOffsets[rider.H()]
+	delete(s.seatOffsets, rider.H())
+	riderID := s.entityRuntimeIDs[rider.H()]
+	vehicleID := s.entityRuntimeIDs[vehicle.H()]
+	s.entityMutex.Unlock()
+	if !riding {
+		return
+	}
+	s.writePacket(&packet.SetActorLink{EntityLink: protocol.EntityLink{
+		RiddenEntityUniqueID: int64(vehicleID),
+		RiderEntityUniqueID:  int64(riderID),
+		Type:                 protocol.EntityLinkRemove,
+	}})
+	s.ViewEntityState(rider)
+}
+
+// entityRiding checks if the entity passed is shown to the Session as riding
+// another entity.
+func (s *Session) entityRiding(e world.Entity) bool {
+	return s.handleRiding(e.H())
+}
+
+// riding checks if the Controllable of the Session is riding an entity.
+func (s *Session) riding() bool {
+	return s.handleRiding(s.ent)
+}
+
+// handleRiding checks if the entity with the world.EntityHandle passed is
+// shown to the Session as riding another entity.
+func (s *Session) handleRiding(e *world.EntityHandle) bool {
+	s.entityMutex.RLock()
+	defer s.entityMutex.RUnlock()
+	_, ok := s.seatOffsets[e]
+	return ok
+}
+
 // ViewTime ...
 func (s *Session) ViewTime(time int) {
 	s.writePacket(&packet.SetTime{Time: int32(time)})
@@ -270,9 +372,15 @@ func (s *Session) ViewEntityItems(e world.Entity) {
 		// Don't view the items of the entity if the entity is the Controllable entity of the session.
 		return
 	}
-	c, ok := e.(item.Carrier)
+	c, ok := e.(interface {
+		HeldItems() (mainHand, offHand item.Stack)
+	})
 	if !ok {
-		return
+		if c, ok = behaviour(e).(interface {
+			HeldItems() (mainHand, offHand item.Stack)
+		}); !ok {
+			return
+		}
 	}
 
 	mainHand, offHand := c.HeldItems()
@@ -290,6 +398,15 @@ func (s *Session) ViewEntityItems(e world.Entity) {
 	})
 }
 
+// behaviour returns the entity.Behaviour of the entity passed, or nil if it
+// does not have one.
+func behaviour(e world.Entity) entity.Behaviour {
+	if ent, ok := e.(interface{ Behaviour() entity.Behaviour }); ok {
+		return ent.Behaviour()
+	}
+	return nil
+}
+
 // ViewEntityArmour ...
 func (s *Session) ViewEntityArmour(e world.Entity) {
 	runtimeID := s.entityRuntimeID(e)
@@ -301,7 +418,11 @@ func (s *Session) ViewEntityArmour(e world.Entity) {
 		Armour() *inventory.Armour
 	})
 	if !ok {
-		return
+		if armoured, ok = behaviour(e).(interface {
+			Armour() *inventory.Armour
+		}); !ok {
+			return
+		}
 	}
 
 	inv := armoured.Armour()
@@ -428,6 +549,16 @@ func (s *Session) ViewParticle(pos mgl64.Vec3, p world.Particle) {
 			EventData: (int32(pa.Colour.A) << 24) | (int32(pa.Colour.R) << 16) | (int32(pa.Colour.G) << 8) | int32(pa.Colour.B),
 			Position:  vec64To32(pos),
 		})
+	case particle.SonicExplosion:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventSonicExplosion,
+			Position:  vec64To32(pos),
+		})
+	case particle.SculkShriek:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventParticleSculkShriek,
+			Position:  vec64To32(pos),
+		})
 	case particle.EntityFlame:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventParticleLegacyEvent | 19,
@@ -516,8 +647,29 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		return
 	case sound.Explosion:
 		pk.SoundType = packet.SoundEventExplode
+	case sound.CreeperFuse:
+		pk.SoundType, pk.EntityType = packet.SoundEventFuse, "minecraft:creeper"
 	case sound.Thunder:
 		pk.SoundType, pk.EntityType = packet.SoundEventThunder, "minecraft:lightning_bolt"
+	case sound.WitherSpawn:
+		pk.SoundType, pk.EntityType = packet.SoundEventSpawn, "minecraft:wither"
+	case sound.WitherShoot:
+		pk.SoundType, pk.EntityType = packet.SoundEventShoot, "minecraft:wither"
+	case sound.WitherBreakBlock:
+		pk.SoundType, pk.EntityType = packet.SoundEventBreakBlock, "minecraft:wither"
+	case sound.WardenNearby:
+		switch so.Level {
+		case 1:
+			pk.SoundType = packet.SoundEventWardenNearbyClose
+		case 2:
+			pk.SoundType = packet.SoundEventWardenNearbyCloser
+		default:
+			pk.SoundType = packet.SoundEventWardenNearbyClosest
+		}
+	case sound.WardenSonicCharge:
+		pk.SoundType, pk.EntityType = packet.SoundEventSonicCharge, "minecraft:warden"
+	case sound.WardenSonicBoom:
+		pk.SoundType, pk.EntityType = packet.SoundEventSonicBoom, "minecraft:warden"
 	case sound.Click:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventSoundClick,
@@ -643,6 +795,8 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventFallSmall
 	case sound.Burp:
 		pk.SoundType = packet.SoundEventBurp
+	case sound.FishFlop:
+		pk.SoundType, pk.EntityType = packet.SoundEventFlop, "minecraft:cod"
 	case sound.DoorOpen:
 		pk.SoundType, pk.ExtraData = packet.SoundEventDoorOpen, int32(world.BlockRuntimeID(so.Block))
 	case sound.DoorClose:
@@ -687,6 +841,10 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventEnderChestOpen
 	case sound.BarrelClose:
 		pk.SoundType = packet.SoundEventBarrelClose
+	case sound.ShulkerBoxOpen:
+		pk.SoundType = packet.SoundEventShulkerBoxOpen
+	case sound.ShulkerBoxClose:
+		pk.SoundType = packet.SoundEventShulkerBoxClosed
 	case sound.BarrelOpen:
 		pk.SoundType = packet.SoundEventBarrelOpen
 	case sound.BlockBreaking:
@@ -697,6 +855,18 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType, pk.ExtraData = packet.SoundEventItemUseOn, int32(world.BlockRuntimeID(so.Block))
 	case sound.Fizz:
 		pk.SoundType = packet.SoundEventFizz
+	case sound.SculkSensorPowerOn:
+		pk.SoundType = packet.SoundEventSculkSensorPowerOn
+	case sound.SculkSensorPowerOff:
+		pk.SoundType = packet.SoundEventSculkSensorPowerOff
+	case sound.SculkShriekerShriek:
+		pk.SoundType = packet.SoundEventSculkShriekerShriek
+	case sound.BellRing:
+		pk.SoundType = packet.SoundEventBell
+	case sound.RaidHorn:
+		pk.SoundType = packet.SoundEventRaidHorn
+	case sound.Shear:
+		pk.SoundType = packet.SoundEventShear
 	case sound.GlassBreak:
 		pk.SoundType = packet.SoundEventGlass
 	case sound.Attack:
@@ -705,12 +875,20 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 			pk.SoundType = packet.SoundEventAttackNoDamage
 		}
 	case sound.BucketFill:
+		if so.Entity {
+			pk.SoundType = packet.SoundEventBucketFillFish
+			break
+		}
 		if _, water := so.Liquid.(block.Water); water {
 			pk.SoundType = packet.SoundEventBucketFillWater
 			break
 		}
 		pk.SoundType = packet.SoundEventBucketFillLava
 	case sound.BucketEmpty:
+		if so.Entity {
+			pk.SoundType = packet.SoundEventBucketEmptyFish
+			break
+		}
 		if _, water := so.Liquid.(block.Water); water {
 			pk.SoundType = packet.SoundEventBucketEmptyWater
 			break
@@ -809,6 +987,22 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		return
 	case sound.ComposterEmpty:
 		pk.SoundType = packet.SoundEventComposterEmpty
+	case sound.BeehiveEnter:
+		pk.SoundType = packet.SoundEventBeehiveEnter
+	case sound.BeehiveExit:
+		pk.SoundType = packet.SoundEventBeehiveExit
+	case sound.FrogspawnHatch:
+		pk.SoundType = packet.SoundEventFrogspawnHatched
+	case sound.RespawnAnchorCharge:
+		pk.SoundType = packet.SoundEventRespawnAnchorCharge
+	case sound.RespawnAnchorSetSpawn:
+		pk.SoundType = packet.SoundEventRespawnAnchorSetSpawn
+	case sound.RespawnAnchorDeplete:
+		pk.SoundType = packet.SoundEventRespawnAnchorDeplete
+	case sound.BeehiveShear:
+		pk.SoundType = packet.SoundEventBeehiveShear
+	case sound.BottleFill:
+		pk.SoundType = packet.SoundEventBottleFill
 	case sound.ComposterFill:
 		pk.SoundType = packet.SoundEventComposterFill
 	case sound.ComposterFillLayer:
@@ -929,6 +1123,7 @@ func (s *Session) ViewBrewingUpdate(prevBrewTime, brewTime time.Duration, prevFu
 
 // ViewBlockUpdate ...
 func (s *Session) ViewBlockUpdate(pos cube.Pos, b world.Block, layer int) {
+	s.markSubChunkChanged(world.SubChunkPos{int32(pos[0] >> 4), int32(pos[1] >> 4), int32(pos[2] >> 4)})
 	blockPos := protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
 	s.writePacket(&packet.UpdateBlock{
 		Position:          blockPos,
@@ -947,6 +1142,74 @@ func (s *Session) ViewBlockUpdate(pos cube.Pos, b world.Block, layer int) {
 	}
 }
 
+const (
+	// subChunkBlocksThreshold is the amount of blocks changed in a sub chunk
+	// at once above which they are sent in a single UpdateSubChunkBlocks
+	// packet instead of an UpdateBlock packet for every block.
+	subChunkBlocksThreshold = 4
+	// subChunkResendThreshold is the amount of blocks changed in a sub chunk
+	// at once above which the whole sub chunk is sent again instead.
+	subChunkResendThreshold = 1024
+)
+
+// ViewBlockUpdates ...
+func (s *Session) ViewBlockUpdates(pos world.ChunkPos, updates []world.BlockUpdate) {
+	if len(updates) <= subChunkBlocksThreshold {
+		for _, u := range updates {
+			s.ViewBlockUpdate(u.Pos, u.Block, u.Layer)
+		}
+		return
+	}
+	subs := make(map[int32][]world.BlockUpdate)
+	for _, u := range updates {
+		subs[int32(u.Pos[1]>>4)] = append(subs[int32(u.Pos[1]>>4)], u)
+	}
+	for y, sub := range subs {
+		switch {
+		case len(sub) <= subChunkBlocksThreshold:
+			for _, u := range sub {
+				s.ViewBlockUpdate(u.Pos, u.Block, u.Layer)
+			}
+		case len(sub) > subChunkResendThreshold && s.subChunkRequests:
+			w := s.chunkLoader.World()
+			s.writeSubChunks(world.SubChunkPos{pos[0], y, pos[1]}, []protocol.SubChunkOffset{{}}, w.Range(), w.Dimension())
+		default:
+			s.viewSubChunkBlocks(world.SubChunkPos{pos[0], y, pos[1]}, sub)
+		}
+	}
+}
+
+// viewSubChunkBlocks sends the block updates passed, which must all be in the
+// sub chunk at the position passed, in a single UpdateSubChunkBlocks packet.
+func (s *Session) viewSubChunkBlocks(pos world.SubChunkPos, updates []world.BlockUpdate) {
+	s.markSubChunkChanged(pos)
+	pk := &packet.UpdateSubChunkBlocks{Position: protocol.SubChunkPos(pos)}
+	for _, u := range updates {
+		entry := protocol.BlockChangeEntry{
+			BlockPos:       protocol.BlockPos{int32(u.Pos[0]), int32(u.Pos[1]), int32(u.Pos[2])},
+			BlockRuntimeID: world.BlockRuntimeID(u.Block),
+			Flags:          packet.BlockUpdateNetwork,
+		}
+		if u.Layer == 1 {
+			pk.Extra = append(pk.Extra, entry)
+			continue
+		}
+		pk.Blocks = append(pk.Blocks, entry)
+	}
+	s.writePacket(pk)
+	for _, u := range updates {
+		if v, ok := u.Block.(world.NBTer); ok && u.Layer == 0 {
+			if nbtData := v.EncodeNBT(); nbtData != nil {
+				nbtData["x"], nbtData["y"], nbtData["z"] = int32(u.Pos.X()), int32(u.Pos.Y()), int32(u.Pos.Z())
+				s.writePacket(&packet.BlockActorData{
+					Position: protocol.BlockPos{int32(u.Pos[0]), int32(u.Pos[1]), int32(u.Pos[2])},
+					NBTData:  nbtData,
+				})
+			}
+		}
+	}
+}
+
 // ViewEntityAction ...
 func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
 	switch act := a.(type) {
@@ -981,6 +1244,15 @@ func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
 			EntityRuntimeID: s.entityRuntimeID(e),
 		})
 	case entity.DeathAction:
+		if e.H().Type() == entity.EnderDragonType {
+			// The ender dragon has a separate death animation, during which
+			// it slowly rises into the air.
+			s.writePacket(&packet.ActorEvent{
+				EntityRuntimeID: s.entityRuntimeID(e),
+				EventType:       packet.ActorEventDragonStartDeathAnim,
+			})
+			return
+		}
 		s.writePacket(&packet.ActorEvent{
 			EntityRuntimeID: s.entityRuntimeID(e),
 			EventType:       packet.ActorEventDeath,
@@ -1022,11 +1294,25 @@ func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
 				EventData: (rid << 16) | int32(meta),
 			})
 		}
+	case entity.EatGrassAction:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventEatGrass,
+		})
 	case entity.TotemUseAction:
 		s.writePacket(&packet.ActorEvent{
 			EntityRuntimeID: s.entityRuntimeID(e),
 			EventType:       packet.ActorEventTalismanActivate,
 		})
+	case entity.TameAction:
+		ev := packet.ActorEventTamingFailed
+		if act.Success {
+			ev = packet.ActorEventTamingSucceeded
+		}
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       uint8(ev),
+		})
 	}
 }
 
@@ -1036,6 +1322,18 @@ func (s *Session) ViewEntityState(e world.Entity) {
 		EntityRuntimeID: s.entityRuntimeID(e),
 		EntityMetadata:  s.parseEntityMetadata(e),
 	})
+	if bar, ok := entityBossBar(e); ok {
+		s.writePacket(&packet.BossEvent{
+			BossEntityUniqueID: int64(s.entityRuntimeID(e)),
+			EventType:          packet.BossEventHealthPercentage,
+			HealthPercentage:   float32(bar.HealthPercentage()),
+		})
+		s.writePacket(&packet.BossEvent{
+			BossEntityUniqueID: int64(s.entityRuntimeID(e)),
+			EventType:          packet.BossEventTitle,
+			BossBarTitle:       bar.Text(),
+		})
+	}
 }
 
 // ViewEntityAnimation ...
@@ -1053,20 +1351,32 @@ func (s *Session) ViewEntityAnimation(e world.Entity, a world.EntityAnimation) {
 
 // OpenBlockContainer ...
 func (s *Session) OpenBlockContainer(pos cube.Pos, tx *world.Tx) {
-	if s.containerOpened.Load() && *s.openedPos.Load() == pos {
+	if s.containerOpened.Load() && s.openedVirtual.Load() == nil && *s.openedPos.Load() == pos {
 		return
 	}
 	s.closeCurrentContainer(tx)
 
 	b := tx.Block(pos)
+	// The client does not send a ContainerClose packet for lecterns.
+	_, lectern := b.(block.Lectern)
+	if !lectern {
+		var inv *inventory.Inventory
+		switch b := b.(type) {
+		case block.Container:
+			inv = b.Inventory(tx, pos)
+		case block.EnderChest:
+			inv = s.enderChest
+		}
+		if c, ok := s.controllable(tx); ok && c.ContainerOpening(inv, pos) != nil {
+			return
+		}
+	}
 	if container, ok := b.(block.Container); ok {
 		s.openNormalContainer(container, pos, tx)
 		return
 	}
 	// We hit a special kind of window like beacons, which are not actually opened server-side.
 	nextID := s.nextWindowID()
-	// The client does not send a ContainerClose packet for lecterns.
-	_, lectern := b.(block.Lectern)
 	if !lectern {
 		s.containerOpened.Store(true)
 		inv := inventory.New(1, nil)
@@ -1092,8 +1402,12 @@ func (s *Session) OpenBlockContainer(pos cube.Pos, tx *world.Tx) {
 		containerType = protocol.ContainerTypeGrindstone
 	case block.Stonecutter:
 		containerType = protocol.ContainerTypeStonecutter
+	case block.CartographyTable:
+		containerType = protocol.ContainerTypeCartography
 	case block.SmithingTable:
 		containerType = protocol.ContainerTypeSmithingTable
+	case block.StructureBlock:
+		containerType = protocol.ContainerTypeStructureEditor
 	case block.EnderChest:
 		b.AddViewer(tx, pos)
 
@@ -1135,6 +1449,7 @@ func (s *Session) openNormalContainer(b block.Container, pos cube.Pos, tx *world
 		containerType = protocol.ContainerTypeHopper
 	}
 
+	s.openedContainerID.Store(uint32(containerType))
 	s.writePacket(&packet.ContainerOpen{
 		WindowID:                nextID,
 		ContainerType:           containerType,
@@ -1144,6 +1459,119 @@ func (s *Session) openNormalContainer(b block.Container, pos cube.Pos, tx *world
 	s.sendInv(b.Inventory(tx, pos), uint32(nextID))
 }
 
+// OpenEntityContainer opens the inventory of an entity, such as a chest minecart.
+func (s *Session) OpenEntityContainer(c entity.Container, tx *world.Tx) {
+	inv := c.Inventory()
+	if inv == nil || (s.containerOpened.Load() && s.openedEntity.Load() == c.H()) {
+		return
+	}
+	s.closeCurrentContainer(tx)
+
+	pos := cube.PosFromVec3(c.Position())
+	if co, ok := s.controllable(tx); ok && co.ContainerOpening(inv, pos) != nil {
+		return
+	}
+	c.AddViewer(s)
+	nextID := s.nextWindowID()
+	s.containerOpened.Store(true)
+	s.openedWindow.Store(inv)
+	s.openedPos.Store(&pos)
+	s.openedEntity.Store(c.H())
+
+	containerType := byte(protocol.ContainerTypeCartChest)
+	if c.H().Type() == entity.HopperMinecartType {
+		containerType = protocol.ContainerTypeCartHopper
+	}
+	s.openedContainerID.Store(uint32(containerType))
+	s.writePacket(&packet.ContainerOpen{
+		WindowID:                nextID,
+		ContainerType:           containerType,
+		ContainerPosition:       protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
+		ContainerEntityUniqueID: int64(s.entityRuntimeID(c)),
+	})
+	s.sendInv(inv, uint32(nextID))
+}
+
+// OpenTrading opens the trading window of the villager passed and sends its offers to the client.
+func (s *Session) OpenTrading(v *entity.Mob, tx *world.Tx) {
+	if _, ok := v.Behaviour().(*entity.VillagerBehaviour); !ok {
+		return
+	}
+	s.closeCurrentContainer(tx)
+
+	s.nextWindowID()
+	s.containerOpened.Store(true)
+	s.openedWindow.Store(inventory.New(1, nil))
+	s.openedContainerID.Store(uint32(protocol.ContainerTypeTrade))
+	s.tradingWith.Store(v.H())
+	s.sendTrades(v, tx)
+}
+
+// sendTrades sends the offers of the villager passed to the client. The offers are sent with the prices that
+// apply to the player, and the network ID of each offer is its index in the offers of the villager plus one.
+func (s *Session) sendTrades(v *entity.Mob, tx *world.Tx) {
+	b := v.Behaviour().(*entity.VillagerBehaviour)
+	customer, _ := s.ent.Entity(tx)
+
+	offers := b.Offers()
+	recipes := make([]any, 0, len(offers))
+	for i, o := range offers {
+		price := o.Price(customer, b.Reputation(customer))
+		r := map[string]any{
+			"buyA":             nbtconv.WriteItem(price, true),
+			"buyCountA":        int32(price.Count()),
+			"sell":             nbtconv.WriteItem(o.Output, true),
+			"tier":             int32(o.Tier - 1),
+			"uses":             int32(o.Uses()),
+			"maxUses":          int32(o.MaxUses),
+			"traderExp":        int32(o.Experience),
+			"rewardExp":        uint8(1),
+			"demand":           int32(0),
+			"priceMultiplierA": float32(0),
+			"priceMultiplierB": float32(0),
+			"netId":            int32(i + 1),
+		}
+		if !o.SecondInput.Empty() {
+			r["buyB"], r["buyCountB"] = nbtconv.WriteItem(o.SecondInput, true), int32(o.SecondInput.Count())
+		}
+		recipes = append(recipes, r)
+	}
+	requirements := make([]any, 0, 5)
+	for tier := range 5 {
+		requirements = append(requirements, map[string]any{strconv.Itoa(tier): int32(entity.VillagerLevelExperience(tier + 1))})
+	}
+	data, err := nbt.Marshal(map[string]any{"Recipes": recipes, "TierExpRequirements": requirements})
+	if err != nil {
+		panic("should never happen")
+	}
+	s.writePacket(&packet.UpdateTrade{
+		WindowID:          byte(s.openedWindowID.Load()),
+		WindowType:        protocol.ContainerTypeTrade,
+		TradeTier:         int32(b.TradeTier()),
+		VillagerUniqueID:  int64(s.entityRuntimeID(v)),
+		EntityUniqueID:    selfEntityRuntimeID,
+		DisplayName:       b.Profession().Name(),
+		NewTradeUI:        true,
+		DemandBasedPrices: true,
+		SerialisedOffers:  data,
+	})
+}
+
+// tradingVillager returns the villager behind the handle passed and its behaviour if it is still present in
+// the world of the transaction passed.
+func (s *Session) tradingVillager(h *world.EntityHandle, tx *world.Tx) (*entity.Mob, *entity.VillagerBehaviour, bool) {
+	e, ok := h.Entity(tx)
+	if !ok {
+		return nil, nil, false
+	}
+	m, ok := e.(*entity.Mob)
+	if !ok {
+		return nil, nil, false
+	}
+	v, ok := m.Behaviour().(*entity.VillagerBehaviour)
+	return m, v, ok
+}
+
 // ViewSlotChange ...
 func (s *Session) ViewSlotChange(slot int, newItem item.Stack) {
 	if !s.containerOpened.Load() {
@@ -1201,6 +1629,29 @@ func (s *Session) ViewBlockAction(pos cube.Pos, a world.BlockAction) {
 			Position: blockPos,
 			NBTData:  nbt,
 		})
+	case block.EndGatewayBeamAction:
+		// End gateways share the block event type used for chests, which
+		// makes them show their beam.
+		s.writePacket(&packet.BlockEvent{
+			Position:  blockPos,
+			EventType: packet.BlockEventChangeChestState,
+		})
+	case block.BellRingAction:
+		// Bells use the legacy horizontal directions, which start at south
+		// and rotate clockwise.
+		dir := map[cube.Direction]int32{cube.South: 0, cube.West: 1, cube.North: 2, cube.East: 3}[t.Direction]
+		s.writePacket(&packet.BlockActorData{
+			Position: blockPos,
+			NBTData: map[string]any{
+				"id":        "Bell",
+				"x":         blockPos.X(),
+				"y":         blockPos.Y(),
+				"z":         blockPos.Z(),
+				"Ringing":   uint8(1),
+				"Ticks":     int32(0),
+				"Direction": dir,
+			},
+		})
 	}
 }
 
@@ -0,0 +1,98 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// subChunkUpdates returns n block updates setting stone in the sub chunk at
+// 0, 0, 0.
+func subChunkUpdates(n int) []world.BlockUpdate {
+	updates := make([]world.BlockUpdate, n)
+	for i := range updates {
+		updates[i] = world.BlockUpdate{Pos: cube.Pos{i & 15, i >> 8, (i >> 4) & 15}, Block: block.Stone{}}
+	}
+	return updates
+}
+
+// viewedBlockUpdates returns the packets written by a Session viewing the block
+// updates passed in the chunk at 0, 0.
+func viewedBlockUpdates(t *testing.T, subChunkRequests bool, updates []world.BlockUpdate) []packet.Packet {
+	w := world.Config{}.New()
+	defer w.Close()
+
+	s := newTestSession()
+	s.subChunkRequests = subChunkRequests
+	s.chunkLoader = world.NewLoader(1, w, world.NopViewer{})
+	deadline := time.Now().Add(5 * time.Second)
+	for loaded := false; !loaded; {
+		if time.Now().After(deadline) {
+			t.Fatalf("chunk 0, 0 was not loaded")
+		}
+		<-w.Exec(func(tx *world.Tx) {
+			s.chunkLoader.Load(tx, 1)
+			_, loaded = s.chunkLoader.Chunk(world.ChunkPos{})
+		})
+	}
+	<-w.Exec(func(tx *world.Tx) {
+		s.ViewBlockUpdates(world.ChunkPos{}, updates)
+	})
+
+	var packets []packet.Packet
+	for len(s.packets) > 0 {
+		packets = append(packets, <-s.packets)
+	}
+	return packets
+}
+
+func TestViewBlockUpdatesFewBlocks(t *testing.T) {
+	packets := viewedBlockUpdates(t, true, subChunkUpdates(subChunkBlocksThreshold))
+	if len(packets) != subChunkBlocksThreshold {
+		t.Fatalf("expected %v packets, got %v", subChunkBlocksThreshold, len(packets))
+	}
+	for _, pk := range packets {
+		if _, ok := pk.(*packet.UpdateBlock); !ok {
+			t.Errorf("expected an UpdateBlock packet for every block changed, got %T", pk)
+		}
+	}
+}
+
+func TestViewBlockUpdatesSubChunkBlocks(t *testing.T) {
+	packets := viewedBlockUpdates(t, true, subChunkUpdates(subChunkResendThreshold))
+	if len(packets) != 1 {
+		t.Fatalf("expected 1 packet, got %v", len(packets))
+	}
+	pk, ok := packets[0].(*packet.UpdateSubChunkBlocks)
+	if !ok {
+		t.Fatalf("expected an UpdateSubChunkBlocks packet, got %T", packets[0])
+	}
+	if len(pk.Blocks) != subChunkResendThreshold {
+		t.Errorf("expected %v blocks in the packet, got %v", subChunkResendThreshold, len(pk.Blocks))
+	}
+}
+
+func TestViewBlockUpdatesResendsSubChunk(t *testing.T) {
+	updates := subChunkUpdates(subChunkResendThreshold + 1)
+	packets := viewedBlockUpdates(t, true, updates)
+	if len(packets) != 1 {
+		t.Fatalf("expected 1 packet, got %v", len(packets))
+	}
+	if _, ok := packets[0].(*packet.SubChunk); !ok {
+		t.Errorf("expected the sub chunk to be sent again, got %T", packets[0])
+	}
+
+	// Clients that don't request sub chunks can't be sent a single sub chunk,
+	// so the blocks are sent in an UpdateSubChunkBlocks packet instead.
+	packets = viewedBlockUpdates(t, false, updates)
+	if len(packets) != 1 {
+		t.Fatalf("expected 1 packet, got %v", len(packets))
+	}
+	if _, ok := packets[0].(*packet.UpdateSubChunkBlocks); !ok {
+		t.Errorf("expected an UpdateSubChunkBlocks packet without sub chunk requests, got %T", packets[0])
+	}
+}
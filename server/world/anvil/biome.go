@@ -0,0 +1,56 @@
+package anvil
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"strings"
+)
+
+// javaBiomes maps the names of Java Edition biomes to the names of the
+// Bedrock Edition biomes they are converted to, for biomes that are named
+// differently in both editions.
+var javaBiomes = map[string]string{
+	"badlands":                 "mesa",
+	"dark_forest":              "roofed_forest",
+	"end_barrens":              "the_end",
+	"end_highlands":            "the_end",
+	"end_midlands":             "the_end",
+	"eroded_badlands":          "mesa_bryce",
+	"ice_spikes":               "ice_plains_spikes",
+	"mushroom_fields":          "mushroom_island",
+	"nether_wastes":            "hell",
+	"old_growth_birch_forest":  "birch_forest_mutated",
+	"old_growth_pine_taiga":    "mega_taiga",
+	"old_growth_spruce_taiga":  "redwood_taiga_mutated",
+	"small_end_islands":        "the_end",
+	"snowy_beach":              "cold_beach",
+	"snowy_plains":             "ice_plains",
+	"snowy_taiga":              "cold_taiga",
+	"soul_sand_valley":         "soulsand_valley",
+	"sparse_jungle":            "jungle_edge",
+	"stony_shore":              "stone_beach",
+	"swamp":                    "swampland",
+	"the_void":                 "plains",
+	"windswept_forest":         "extreme_hills_plus_trees",
+	"windswept_gravelly_hills": "extreme_hills_mutated",
+	"windswept_hills":          "extreme_hills",
+	"windswept_savanna":        "savanna_mutated",
+	"wooded_badlands":          "mesa_plateau_stone",
+}
+
+// biomeID returns the ID of the Bedrock Edition biome that the Java Edition
+// biome with the name passed is converted to. Biomes that do not exist in
+// Bedrock Edition, such as custom biomes of data packs, are converted to
+// plains.
+func biomeID(name string) uint32 {
+	name = strings.TrimPrefix(name, "minecraft:")
+	if n, ok := javaBiomes[name]; ok {
+		name = n
+	}
+	if b, ok := world.BiomeByName(name); ok {
+		return uint32(b.EncodeBiome())
+	}
+	if b, ok := world.BiomeByName("plains"); ok {
+		return uint32(b.EncodeBiome())
+	}
+	return 0
+}
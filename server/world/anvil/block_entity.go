@@ -0,0 +1,154 @@
+package anvil
+
+import (
+	"encoding/json"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"strings"
+)
+
+// blockEntityIDs maps the IDs of Java Edition block entities to the IDs of
+// their Bedrock Edition counterparts.
+var blockEntityIDs = map[string]string{
+	"banner":                  "Banner",
+	"barrel":                  "Barrel",
+	"beacon":                  "Beacon",
+	"bed":                     "Bed",
+	"beehive":                 "Beehive",
+	"bell":                    "Bell",
+	"blast_furnace":           "BlastFurnace",
+	"brewing_stand":           "BrewingStand",
+	"brushable_block":         "BrushableBlock",
+	"calibrated_sculk_sensor": "CalibratedSculkSensor",
+	"campfire":                "Campfire",
+	"chest":                   "Chest",
+	"chiseled_bookshelf":      "ChiseledBookshelf",
+	"command_block":           "CommandBlock",
+	"comparator":              "Comparator",
+	"conduit":                 "Conduit",
+	"crafter":                 "Crafter",
+	"creaking_heart":          "CreakingHeart",
+	"daylight_detector":       "DaylightDetector",
+	"decorated_pot":           "DecoratedPot",
+	"dispenser":               "Dispenser",
+	"dropper":                 "Dropper",
+	"enchanting_table":        "EnchantTable",
+	"end_gateway":             "EndGateway",
+	"end_portal":              "EndPortal",
+	"ender_chest":             "EnderChest",
+	"furnace":                 "Furnace",
+	"hanging_sign":            "HangingSign",
+	"hopper":                  "Hopper",
+	"jigsaw":                  "JigsawBlock",
+	"jukebox":                 "Jukebox",
+	"lectern":                 "Lectern",
+	"mob_spawner":             "MobSpawner",
+	"piston":                  "PistonArm",
+	"sculk_catalyst":          "SculkCatalyst",
+	"sculk_sensor":            "SculkSensor",
+	"sculk_shrieker":          "SculkShrieker",
+	"shulker_box":             "ShulkerBox",
+	"sign":                    "Sign",
+	"skull":                   "Skull",
+	"smoker":                  "Smoker",
+	"structure_block":         "StructureBlock",
+	"trapped_chest":           "Chest",
+	"trial_spawner":           "TrialSpawner",
+	"vault":                   "Vault",
+}
+
+// blockEntity converts the Java Edition block entity passed to Bedrock
+// Edition block entity data. The items held by containers, custom names and
+// the text of signs are converted. Other data specific to Java Edition is
+// dropped. False is returned if the block entity has no Bedrock Edition
+// counterpart.
+func blockEntity(m map[string]any) (cube.Pos, map[string]any, bool) {
+	id, ok := blockEntityIDs[strings.TrimPrefix(nbtconv.String(m, "id"), "minecraft:")]
+	if !ok {
+		return cube.Pos{}, nil, false
+	}
+	pos := cube.Pos{int(nbtconv.Int32(m, "x")), int(nbtconv.Int32(m, "y")), int(nbtconv.Int32(m, "z"))}
+	data := map[string]any{"id": id, "x": int32(pos[0]), "y": int32(pos[1]), "z": int32(pos[2])}
+	if name, ok := m["CustomName"]; ok {
+		data["CustomName"] = text(name)
+	}
+	if items := nbtconv.Slice(m, "Items"); items != nil {
+		data["Items"] = convertItems(items)
+	}
+	if id == "Sign" || id == "HangingSign" {
+		data["Text"] = signText(m)
+	}
+	return pos, data, true
+}
+
+// convertItems converts a list of Java Edition items held by a container to
+// their Bedrock Edition format. Items are matched by name, so items named
+// differently in Bedrock Edition are lost when the container is loaded.
+func convertItems(items []any) []any {
+	converted := make([]any, 0, len(items))
+	for _, v := range items {
+		it, _ := v.(map[string]any)
+		count := nbtconv.Int32(it, "count")
+		if count == 0 {
+			// Items saved before 1.20.5 store their count in a byte.
+			count = int32(nbtconv.Uint8(it, "Count"))
+		}
+		converted = append(converted, map[string]any{
+			"Name":   nbtconv.String(it, "id"),
+			"Count":  uint8(min(count, 255)),
+			"Damage": int16(0),
+			"Slot":   nbtconv.Uint8(it, "Slot"),
+		})
+	}
+	return converted
+}
+
+// signText returns the text on the front of the Java Edition sign passed,
+// with the lines separated by newlines.
+func signText(m map[string]any) string {
+	var lines []string
+	if front, ok := m["front_text"].(map[string]any); ok {
+		for _, line := range nbtconv.Slice(front, "messages") {
+			lines = append(lines, text(line))
+		}
+	} else {
+		// Signs saved before 1.20 store their lines in separate tags.
+		for _, k := range []string{"Text1", "Text2", "Text3", "Text4"} {
+			lines = append(lines, text(m[k]))
+		}
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// text returns the plain text of a Java Edition text component. Text
+// components are stored as JSON strings, or as NBT compounds since 1.21.5.
+// Formatting and click events are dropped.
+func text(v any) string {
+	switch v := v.(type) {
+	case string:
+		var component any
+		if err := json.Unmarshal([]byte(v), &component); err != nil {
+			return v
+		}
+		switch c := component.(type) {
+		case string:
+			return c
+		case []any, map[string]any:
+			return text(c)
+		}
+		return v
+	case []any:
+		var sb strings.Builder
+		for _, c := range v {
+			sb.WriteString(text(c))
+		}
+		return sb.String()
+	case map[string]any:
+		s, _ := v["text"].(string)
+		if extra, ok := v["extra"].([]any); ok {
+			s += text(extra)
+		}
+		return s
+	}
+	return ""
+}
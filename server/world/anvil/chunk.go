@@ -0,0 +1,300 @@
+package anvil
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"maps"
+	"math/bits"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// minDataVersion is the lowest data version of chunks that can be read. It is
+// the data version of the first snapshot of 1.18, which introduced the chunk
+// format read by the converter.
+const minDataVersion = 2844
+
+// converter converts chunks in the Java Edition format to chunk.Columns.
+// Converted block states are cached, so that every state only has to be
+// converted once.
+type converter struct {
+	conf Config
+
+	once                 sync.Once
+	defaults             map[string]map[string]any
+	air, water, fallback uint32
+	mu                   sync.RWMutex
+	states               map[string]state
+	unknown              map[string]struct{}
+}
+
+// state is a Java Edition block state converted to Bedrock Edition.
+type state struct {
+	// rid is the runtime ID of the Bedrock Edition block.
+	rid uint32
+	// water specifies if the block is waterlogged. If true, water is placed
+	// in the second layer of the block.
+	water bool
+	// data is the block entity data implied by the state, or nil if it
+	// implies none.
+	data map[string]any
+}
+
+// newConverter returns a converter using the Config passed.
+func newConverter(conf Config) *converter {
+	return &converter{conf: conf, states: map[string]state{}, unknown: map[string]struct{}{}}
+}
+
+// init builds the tables needed to convert block states. It is called
+// lazily, as the block registry must be finalised before it may be used.
+func (c *converter) init() {
+	c.once.Do(func() {
+		c.defaults = map[string]map[string]any{}
+		for _, b := range world.Blocks() {
+			name, properties := b.EncodeBlock()
+			if _, ok := c.defaults[name]; !ok {
+				c.defaults[name] = properties
+			}
+		}
+		water, _ := c.lookup("minecraft:water", nil)
+		c.air, c.water, c.fallback = world.BlockRuntimeID(nil), world.BlockRuntimeID(water), world.BlockRuntimeID(c.conf.Fallback)
+	})
+}
+
+// column decodes the Java Edition chunk NBT passed and converts it to a
+// chunk.Column with the range passed.
+func (c *converter) column(data []byte, r cube.Range) (*chunk.Column, error) {
+	c.init()
+
+	var m map[string]any
+	if err := nbt.UnmarshalEncoding(data, &m, nbt.BigEndian); err != nil {
+		return nil, fmt.Errorf("decode chunk nbt: %w", err)
+	}
+	if ver := nbtconv.Int32(m, "DataVersion"); ver < minDataVersion {
+		return nil, fmt.Errorf("chunk data version %v is unsupported: chunks must be saved by 1.18 or later", ver)
+	}
+	if strings.TrimPrefix(nbtconv.String(m, "Status"), "minecraft:") != "full" {
+		// The chunk was not fully generated yet, so we let the world generate
+		// it instead.
+		return nil, leveldb.ErrNotFound
+	}
+	baseX, baseZ := int(nbtconv.Int32(m, "xPos"))<<4, int(nbtconv.Int32(m, "zPos"))<<4
+
+	col := &chunk.Column{Chunk: chunk.New(c.air, r)}
+	blockEntities := map[cube.Pos]map[string]any{}
+	for _, v := range nbtconv.Slice(m, "sections") {
+		sec, _ := v.(map[string]any)
+		c.section(col.Chunk, sec, baseX, baseZ, blockEntities)
+	}
+	for _, v := range nbtconv.Slice(m, "block_entities") {
+		be, _ := v.(map[string]any)
+		pos, data, ok := blockEntity(be)
+		if !ok || pos[1] < r.Min() || pos[1] > r.Max() {
+			continue
+		}
+		if implied, ok := blockEntities[pos]; ok {
+			maps.Copy(data, implied)
+		}
+		blockEntities[pos] = data
+	}
+	for pos, data := range blockEntities {
+		col.BlockEntities = append(col.BlockEntities, chunk.BlockEntity{Pos: pos, Data: data})
+	}
+	return col, nil
+}
+
+// section converts the blocks and biomes of a Java Edition chunk section and
+// sets them in the chunk passed. Block entity data implied by the block
+// states in the section is added to blockEntities.
+func (c *converter) section(ch *chunk.Chunk, sec map[string]any, baseX, baseZ int, blockEntities map[cube.Pos]map[string]any) {
+	r := ch.Range()
+	baseY := int(int8(nbtconv.Uint8(sec, "Y"))) << 4
+	if baseY+15 < r.Min() || baseY > r.Max() {
+		return
+	}
+	if states, ok := sec["block_states"].(map[string]any); ok {
+		palette := nbtconv.Slice(states, "palette")
+		converted := make([]state, len(palette))
+		for i, v := range palette {
+			e, _ := v.(map[string]any)
+			converted[i] = c.state(e)
+		}
+		for i, index := range unpack(states["data"], len(palette), 4, 4096) {
+			if int(index) >= len(converted) {
+				continue
+			}
+			s := converted[index]
+			x, y, z := uint8(i&15), baseY+i>>8, uint8(i>>4&15)
+			if (s.rid == c.air && !s.water) || y < r.Min() || y > r.Max() {
+				continue
+			}
+			ch.SetBlock(x, int16(y), z, 0, s.rid)
+			if s.water {
+				ch.SetBlock(x, int16(y), z, 1, c.water)
+			}
+			if s.data != nil {
+				pos := cube.Pos{baseX + int(x), y, baseZ + int(z)}
+				data := maps.Clone(s.data)
+				data["x"], data["y"], data["z"] = int32(pos[0]), int32(pos[1]), int32(pos[2])
+				blockEntities[pos] = data
+			}
+		}
+	}
+	if biomes, ok := sec["biomes"].(map[string]any); ok {
+		palette := nbtconv.Slice(biomes, "palette")
+		ids := make([]uint32, len(palette))
+		for i, v := range palette {
+			name, _ := v.(string)
+			ids[i] = biomeID(name)
+		}
+		for i, index := range unpack(biomes["data"], len(palette), 1, 64) {
+			if int(index) >= len(ids) {
+				continue
+			}
+			// Java Edition stores biomes in cells of 4x4x4 blocks.
+			cx, cy, cz := uint8(i&3)<<2, baseY+(i>>4)<<2, uint8(i>>2&3)<<2
+			for y := cy; y < cy+4; y++ {
+				if y < r.Min() || y > r.Max() {
+					continue
+				}
+				for x := cx; x < cx+4; x++ {
+					for z := cz; z < cz+4; z++ {
+						ch.SetBiome(x, int16(y), z, ids[index])
+					}
+				}
+			}
+		}
+	}
+}
+
+// state converts the Java Edition block state in the palette entry passed to
+// a Bedrock Edition block state. If no Bedrock Edition block exists for it,
+// the state holds the fallback block of the Config.
+func (c *converter) state(e map[string]any) state {
+	name := nbtconv.String(e, "Name")
+	properties, _ := e["Properties"].(map[string]any)
+	p := make(map[string]string, len(properties))
+	keys := make([]string, 0, len(properties))
+	for k, v := range properties {
+		p[k], _ = v.(string)
+		keys = append(keys, k+"="+p[k])
+	}
+	slices.Sort(keys)
+	key := name + "[" + strings.Join(keys, ",") + "]"
+
+	c.mu.RLock()
+	s, ok := c.states[key]
+	c.mu.RUnlock()
+	if ok {
+		return s
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok = c.convert(name, p)
+	if !ok {
+		if _, logged := c.unknown[name]; !logged {
+			c.unknown[name] = struct{}{}
+			c.conf.Log.Debug("no bedrock edition block for java edition block, using fallback", "block", name)
+		}
+		s = state{rid: c.fallback}
+	}
+	c.states[key] = s
+	return s
+}
+
+// convert converts the Java Edition block state with the name and properties
+// passed to a Bedrock Edition block state. False is returned if no Bedrock
+// Edition block exists for it.
+func (c *converter) convert(name string, p map[string]string) (state, bool) {
+	water := p["waterlogged"] == "true"
+	switch strings.TrimPrefix(name, "minecraft:") {
+	case "kelp", "kelp_plant", "seagrass", "tall_seagrass", "bubble_column":
+		// These blocks are always waterlogged, so they don't have a
+		// waterlogged property.
+		water = true
+	}
+	bedrockName, bedrockProperties, data := c.translate(name, p)
+	b, ok := c.lookup(bedrockName, bedrockProperties)
+	if !ok {
+		return state{}, false
+	}
+	return state{rid: world.BlockRuntimeID(b), water: water, data: data}, true
+}
+
+// unpack unpacks n palette indices from the packed long array passed, as
+// stored in Java Edition chunk sections. paletteLen is the length of the
+// palette that the indices point into. If the array is not present, which is
+// the case for palettes with a single entry, all indices are 0.
+func unpack(v any, paletteLen, minBits, n int) []uint16 {
+	indices := make([]uint16, n)
+	data := int64s(v)
+	if paletteLen <= 1 || len(data) == 0 {
+		return indices
+	}
+	size := max(minBits, bits.Len(uint(paletteLen-1)))
+	perLong, mask := 64/size, uint64(1)<<size-1
+	for i := range indices {
+		j := i / perLong
+		if j >= len(data) {
+			break
+		}
+		indices[i] = uint16(uint64(data[j]) >> ((i % perLong) * size) & mask)
+	}
+	return indices
+}
+
+// int64s returns the values of a TAG_Long_Array decoded from NBT, which is
+// decoded as a Go array of int64 with a length only known at runtime.
+func int64s(v any) []int64 {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || (rv.Kind() != reflect.Array && rv.Kind() != reflect.Slice) || rv.Type().Elem().Kind() != reflect.Int64 {
+		return nil
+	}
+	s := make([]int64, rv.Len())
+	for i := range s {
+		s[i] = rv.Index(i).Int()
+	}
+	if swappedLongArrays {
+		unswap(s)
+	}
+	return s
+}
+
+// swappedLongArrays specifies if the NBT decoder swaps the bytes of big
+// endian long arrays at the wrong offsets. Some versions of the decoder do so
+// on little endian machines, which garbles all but the first value of the
+// array. This is checked by decoding an array with known values.
+var swappedLongArrays = func() bool {
+	b, _ := nbt.MarshalEncoding(map[string]any{"a": [2]int64{1, 2}}, nbt.BigEndian)
+	var m map[string]any
+	_ = nbt.UnmarshalEncoding(b, &m, nbt.BigEndian)
+	a, _ := m["a"].([2]int64)
+	return a != [2]int64{1, 2}
+}()
+
+// unswap restores the values of a long array decoded by a decoder that swaps
+// bytes at the wrong offsets, by reverting its swaps in reverse order.
+func unswap(s []int64) {
+	b := make([]byte, len(s)*8)
+	for i, v := range s {
+		binary.LittleEndian.PutUint64(b[i*8:], uint64(v))
+	}
+	for i := len(s) - 1; i >= 0; i-- {
+		off := i * 4
+		for j := 0; j < 4; j++ {
+			b[off+j], b[off+7-j] = b[off+7-j], b[off+j]
+		}
+	}
+	for i := range s {
+		s[i] = int64(binary.BigEndian.Uint64(b[i*8:]))
+	}
+}
@@ -0,0 +1,58 @@
+package anvil
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"log/slog"
+	"strings"
+)
+
+// javaConverter is the converter used by ConvertBlock.
+var javaConverter = newConverter(Config{Log: slog.Default()})
+
+// ConvertBlock converts a Java Edition block state to a Bedrock Edition block.
+// The state is formatted as in commands and Sponge schematics, for example
+// "minecraft:oak_stairs[facing=north,half=bottom]". Block entity data implied
+// by the state, such as the colour of a bed, is decoded into the block
+// returned. If the state is waterlogged, water is returned as liquid. False is
+// returned if the state has no Bedrock Edition counterpart.
+// ConvertBlock may only be called after the block registry is finalised.
+func ConvertBlock(state string) (b world.Block, liquid world.Liquid, ok bool) {
+	c := javaConverter
+	c.init()
+
+	name, properties := state, map[string]string{}
+	if i := strings.IndexByte(state, '['); i != -1 && strings.HasSuffix(state, "]") {
+		name = state[:i]
+		for _, p := range strings.Split(state[i+1:len(state)-1], ",") {
+			if k, v, found := strings.Cut(p, "="); found {
+				properties[strings.TrimSpace(k)] = strings.TrimSpace(v)
+			}
+		}
+	}
+	if !strings.Contains(name, ":") {
+		name = "minecraft:" + name
+	}
+	s, ok := c.convert(name, properties)
+	if !ok {
+		return nil, nil, false
+	}
+	b, _ = world.BlockByRuntimeID(s.rid)
+	if nbter, ok := b.(world.NBTer); ok && s.data != nil {
+		b = nbter.DecodeNBT(s.data).(world.Block)
+	}
+	if s.water {
+		water, _ := world.BlockByRuntimeID(c.water)
+		liquid, _ = water.(world.Liquid)
+	}
+	return b, liquid, true
+}
+
+// ConvertBlockEntity converts the data of a Java Edition block entity to its
+// Bedrock Edition format. The data must hold the ID of the block entity and
+// its position in x, y and z fields. The items held by containers, custom
+// names and the text of signs are converted. False is returned if the block
+// entity has no Bedrock Edition counterpart.
+func ConvertBlockEntity(data map[string]any) (map[string]any, bool) {
+	_, converted, ok := blockEntity(data)
+	return converted, ok
+}
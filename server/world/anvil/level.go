@@ -0,0 +1,104 @@
+package anvil
+
+import (
+	"compress/gzip"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"os"
+)
+
+// defaultBorderSize is the size of the world border of Java Edition worlds
+// of which the border was never changed. Borders of this size are treated as
+// if the world has no border at all.
+const defaultBorderSize = 59999968
+
+// readLevelDat reads the Java Edition level.dat file at the path passed and
+// returns the world.Settings stored in it.
+func readLevelDat(path string) (*world.Settings, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+	var m map[string]any
+	if err := nbt.NewDecoderWithEncoding(r, nbt.BigEndian).Decode(&m); err != nil {
+		return nil, fmt.Errorf("decode nbt: %w", err)
+	}
+	data, ok := m["Data"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("missing Data compound")
+	}
+	return levelSettings(data), nil
+}
+
+// levelSettings returns the world.Settings stored in the Data compound of a
+// Java Edition level.dat.
+func levelSettings(data map[string]any) *world.Settings {
+	rules, _ := data["GameRules"].(map[string]any)
+	rule := func(name string) bool {
+		switch v := rules[name].(type) {
+		case string:
+			return v != "false"
+		case uint8:
+			return v != 0
+		}
+		// Game rules that are not present have their default value, which is
+		// true for all game rules read.
+		return true
+	}
+	mode, ok := world.GameModeByID(int(nbtconv.Int32(data, "GameType")))
+	if !ok {
+		mode = world.GameModeSurvival
+	}
+	difficulty, ok := world.DifficultyByID(int(nbtconv.Uint8(data, "Difficulty")))
+	if !ok {
+		difficulty = world.DifficultyNormal
+	}
+	s := &world.Settings{
+		Name:                nbtconv.String(data, "LevelName"),
+		Seed:                nbtconv.Int64(data, "RandomSeed"),
+		Spawn:               cube.Pos{int(nbtconv.Int32(data, "SpawnX")), int(nbtconv.Int32(data, "SpawnY")), int(nbtconv.Int32(data, "SpawnZ"))},
+		Time:                nbtconv.Int64(data, "DayTime"),
+		TimeCycle:           rule("doDaylightCycle"),
+		RainTime:            int64(nbtconv.Int32(data, "rainTime")),
+		Raining:             nbtconv.Bool(data, "raining"),
+		ThunderTime:         int64(nbtconv.Int32(data, "thunderTime")),
+		Thundering:          nbtconv.Bool(data, "thundering"),
+		WeatherCycle:        rule("doWeatherCycle"),
+		CurrentTick:         nbtconv.Int64(data, "Time"),
+		DefaultGameMode:     mode,
+		Difficulty:          difficulty,
+		TickRange:           6,
+		Insomnia:            rule("doInsomnia"),
+		MobGriefing:         rule("mobGriefing"),
+		NaturalRegeneration: rule("naturalRegeneration"),
+	}
+	if gen, ok := data["WorldGenSettings"].(map[string]any); ok {
+		// Since 1.16, the seed is stored in the WorldGenSettings compound.
+		s.Seed = nbtconv.Int64(gen, "seed")
+	}
+	if size := nbtconv.Float64(data, "BorderSize"); size > 0 && size < defaultBorderSize {
+		target, lerp := nbtconv.Float64(data, "BorderSizeLerpTarget"), nbtconv.Int64(data, "BorderSizeLerpTime")
+		if lerp <= 0 || target <= 0 {
+			target = size
+		}
+		s.Border = world.Border{
+			Centre:       mgl64.Vec2{nbtconv.Float64(data, "BorderCenterX"), nbtconv.Float64(data, "BorderCenterZ")},
+			From:         size,
+			To:           target,
+			ResizeStart:  s.CurrentTick,
+			ResizeEnd:    s.CurrentTick + lerp/50,
+			DamageBuffer: nbtconv.Float64(data, "BorderSafeZone"),
+			Damage:       nbtconv.Float64(data, "BorderDamagePerBlock"),
+		}
+	}
+	return s
+}
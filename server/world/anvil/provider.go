@@ -0,0 +1,181 @@
+// Package anvil implements a read-only world.Provider for worlds in the Anvil
+// format of Minecraft: Java Edition. Blocks, biomes and block entities are
+// converted to their Bedrock Edition counterparts as chunks are loaded, so
+// that existing Java Edition maps can be opened directly.
+package anvil
+
+import (
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/google/uuid"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Config holds the optional parameters of a Provider.
+type Config struct {
+	// Log is the Logger that will be used to log errors and debug messages to.
+	// If set to nil, Log is set to slog.Default().
+	Log *slog.Logger
+	// Fallback is the block that Java Edition blocks without a Bedrock
+	// Edition counterpart are converted to. If set to nil, such blocks are
+	// converted to air.
+	Fallback world.Block
+}
+
+// Open creates a new Provider reading the Java Edition world under the path
+// passed. The level.dat of the world is parsed immediately. If it is not
+// present, default settings are used. If it cannot be parsed, an error is
+// returned.
+func (conf Config) Open(dir string) (*Provider, error) {
+	if conf.Log == nil {
+		conf.Log = slog.Default()
+	}
+	conf.Log = conf.Log.With("provider", "anvil")
+
+	p := &Provider{conf: conf, dir: dir, regions: map[regionKey]*region{}}
+	p.conv = newConverter(conf)
+	if _, err := os.Stat(filepath.Join(dir, "level.dat")); os.IsNotExist(err) {
+		p.set = (world.NopProvider{}).Settings()
+		return p, nil
+	}
+	set, err := readLevelDat(filepath.Join(dir, "level.dat"))
+	if err != nil {
+		return nil, fmt.Errorf("open anvil: read level.dat: %w", err)
+	}
+	p.set = set
+	return p, nil
+}
+
+// Provider implements a read-only world provider for the Anvil world format
+// of Minecraft: Java Edition. Chunks are read from the region files of the
+// world and converted when loaded. Changes made to the world are not saved:
+// storing columns and settings is a no-op.
+type Provider struct {
+	conf Config
+	dir  string
+	set  *world.Settings
+	conv *converter
+
+	mu      sync.Mutex
+	regions map[regionKey]*region
+}
+
+// Open creates a new Provider reading the Java Edition world under the path
+// passed using default options. If the level.dat of the world cannot be
+// parsed, an error is returned.
+func Open(dir string) (*Provider, error) {
+	var conf Config
+	return conf.Open(dir)
+}
+
+// Settings returns the world.Settings read from the level.dat of the world.
+func (p *Provider) Settings() *world.Settings {
+	return p.set
+}
+
+// SaveSettings is a no-op, as the Provider does not write to the world.
+func (p *Provider) SaveSettings(*world.Settings) {}
+
+// LoadPlayerSpawnPosition always returns false for exists. Java Edition
+// stores player data by the UUIDs of Java Edition accounts, which differ from
+// those of Bedrock Edition players.
+func (p *Provider) LoadPlayerSpawnPosition(uuid.UUID) (cube.Pos, bool, error) {
+	return cube.Pos{}, false, nil
+}
+
+// SavePlayerSpawnPosition is a no-op, as the Provider does not write to the
+// world.
+func (p *Provider) SavePlayerSpawnPosition(uuid.UUID, cube.Pos) error {
+	return nil
+}
+
+// LoadColumn reads the chunk at a position and dimension from the region
+// files of the world and converts it to a chunk.Column. If the chunk does not
+// exist or has not been fully generated, errors.Is(err, leveldb.ErrNotFound)
+// equals true.
+func (p *Provider) LoadColumn(pos world.ChunkPos, dim world.Dimension) (*chunk.Column, error) {
+	r, err := p.region(regionKey{x: pos[0] >> 5, z: pos[1] >> 5, dim: dim})
+	if err != nil {
+		return nil, fmt.Errorf("load column %v (%v): %w", pos, dim, err)
+	}
+	data, err := r.chunk(pos)
+	if err != nil {
+		return nil, fmt.Errorf("load column %v (%v): %w", pos, dim, err)
+	}
+	col, err := p.conv.column(data, dim.Range())
+	if err != nil {
+		return nil, fmt.Errorf("load column %v (%v): %w", pos, dim, err)
+	}
+	return col, nil
+}
+
+// StoreColumn is a no-op, as the Provider does not write to the world.
+func (p *Provider) StoreColumn(world.ChunkPos, world.Dimension, *chunk.Column) error {
+	return nil
+}
+
+// Close closes all region files opened by the Provider.
+func (p *Provider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+	for k, r := range p.regions {
+		if r != nil {
+			errs = append(errs, r.Close())
+		}
+		delete(p.regions, k)
+	}
+	return errors.Join(errs...)
+}
+
+// region returns the region file with the key passed, opening it if it was
+// not yet opened. If the region file does not exist, an error wrapping
+// leveldb.ErrNotFound is returned.
+func (p *Provider) region(k regionKey) (*region, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if r, ok := p.regions[k]; ok {
+		if r == nil {
+			return nil, leveldb.ErrNotFound
+		}
+		return r, nil
+	}
+	r, err := openRegion(filepath.Join(p.dir, dimensionDir(k.dim), "region", fmt.Sprintf("r.%v.%v.mca", k.x, k.z)))
+	if errors.Is(err, os.ErrNotExist) {
+		// Remember that the region file does not exist, so that we don't try
+		// to open it again for every chunk in it.
+		p.regions[k] = nil
+		return nil, leveldb.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	p.regions[k] = r
+	return r, nil
+}
+
+// regionKey identifies a region file of a dimension.
+type regionKey struct {
+	x, z int32
+	dim  world.Dimension
+}
+
+// dimensionDir returns the directory relative to the world directory that
+// holds the data of the dimension passed.
+func dimensionDir(dim world.Dimension) string {
+	switch dim {
+	case world.Nether:
+		return "DIM-1"
+	case world.End:
+		return "DIM1"
+	}
+	return ""
+}
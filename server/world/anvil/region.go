@@ -0,0 +1,96 @@
+package anvil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/goleveldb/leveldb"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// sectorSize is the size in bytes of the sectors that region files are
+// divided into.
+const sectorSize = 4096
+
+// region is an opened region (.mca) file, which holds up to 32x32 chunks.
+type region struct {
+	*os.File
+	dir       string
+	locations [1024]uint32
+}
+
+// openRegion opens the region file at the path passed and reads its header.
+func openRegion(path string) (*region, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &region{File: f, dir: filepath.Dir(path)}
+	header := make([]byte, sectorSize)
+	if _, err := io.ReadFull(f, header); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("read region header of %v: %w", filepath.Base(path), err)
+	}
+	for i := range r.locations {
+		r.locations[i] = binary.BigEndian.Uint32(header[i*4:])
+	}
+	return r, nil
+}
+
+// chunk reads the chunk at the position passed and returns its decompressed
+// NBT data. If the chunk is not present in the region, an error wrapping
+// leveldb.ErrNotFound is returned.
+func (r *region) chunk(pos world.ChunkPos) ([]byte, error) {
+	loc := r.locations[(pos[0]&31)+(pos[1]&31)*32]
+	offset, sectors := int64(loc>>8)*sectorSize, int(loc&0xff)
+	if offset == 0 || sectors == 0 {
+		return nil, leveldb.ErrNotFound
+	}
+	header := make([]byte, 5)
+	if _, err := r.ReadAt(header, offset); err != nil {
+		return nil, fmt.Errorf("read chunk header: %w", err)
+	}
+	length, compression := int(binary.BigEndian.Uint32(header)), header[4]
+	if length < 1 || length > sectors*sectorSize {
+		return nil, fmt.Errorf("invalid chunk length %v", length)
+	}
+
+	var data io.Reader = io.NewSectionReader(r, offset+5, int64(length-1))
+	if compression&0x80 != 0 {
+		// Chunks too large to fit in the region file are stored in a
+		// separate file next to it.
+		external, err := os.ReadFile(filepath.Join(r.dir, fmt.Sprintf("c.%v.%v.mcc", pos[0], pos[1])))
+		if err != nil {
+			return nil, fmt.Errorf("read external chunk: %w", err)
+		}
+		data, compression = bytes.NewReader(external), compression&0x7f
+	}
+	return decompress(data, compression)
+}
+
+// decompress decompresses the chunk data read from the reader passed using
+// the compression type passed.
+func decompress(data io.Reader, compression byte) ([]byte, error) {
+	switch compression {
+	case 1:
+		r, err := gzip.NewReader(data)
+		if err != nil {
+			return nil, fmt.Errorf("decompress chunk: %w", err)
+		}
+		return io.ReadAll(r)
+	case 2:
+		r, err := zlib.NewReader(data)
+		if err != nil {
+			return nil, fmt.Errorf("decompress chunk: %w", err)
+		}
+		return io.ReadAll(r)
+	case 3:
+		return io.ReadAll(data)
+	}
+	return nil, fmt.Errorf("unsupported chunk compression type %v", compression)
+}
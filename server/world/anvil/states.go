@@ -0,0 +1,501 @@
+package anvil
+
+import (
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"strconv"
+	"strings"
+)
+
+// javaNames maps the names of Java Edition blocks to the names of the Bedrock
+// Edition blocks they are converted to, for blocks that are named differently
+// in both editions. Blocks not present in the map have the same name in both.
+var javaNames = map[string]string{
+	"cave_air":                     "air",
+	"void_air":                     "air",
+	"grass":                        "short_grass",
+	"dirt_path":                    "grass_path",
+	"rooted_dirt":                  "dirt_with_roots",
+	"snow_block":                   "snow",
+	"snow":                         "snow_layer",
+	"cobweb":                       "web",
+	"magma_block":                  "magma",
+	"slime_block":                  "slime",
+	"dead_bush":                    "deadbush",
+	"sugar_cane":                   "reeds",
+	"melon":                        "melon_block",
+	"lily_pad":                     "waterlily",
+	"jack_o_lantern":               "lit_pumpkin",
+	"nether_quartz_ore":            "quartz_ore",
+	"spawner":                      "mob_spawner",
+	"note_block":                   "noteblock",
+	"bricks":                       "brick_block",
+	"nether_bricks":                "nether_brick",
+	"red_nether_bricks":            "red_nether_brick",
+	"end_stone_bricks":             "end_bricks",
+	"end_stone_brick_stairs":       "end_brick_stairs",
+	"prismarine_brick_stairs":      "prismarine_bricks_stairs",
+	"stone_stairs":                 "normal_stone_stairs",
+	"cobblestone_stairs":           "stone_stairs",
+	"stone_slab":                   "normal_stone_slab",
+	"terracotta":                   "hardened_clay",
+	"light_gray_glazed_terracotta": "silver_glazed_terracotta",
+	"shulker_box":                  "undyed_shulker_box",
+	"flowering_azalea_leaves":      "azalea_leaves_flowered",
+	"oak_door":                     "wooden_door",
+	"oak_button":                   "wooden_button",
+	"oak_pressure_plate":           "wooden_pressure_plate",
+	"oak_trapdoor":                 "trapdoor",
+	"oak_fence_gate":               "fence_gate",
+	"powered_rail":                 "golden_rail",
+	"repeater":                     "unpowered_repeater",
+	"comparator":                   "unpowered_comparator",
+	"tripwire":                     "trip_wire",
+	"moving_piston":                "moving_block",
+	"nether_portal":                "portal",
+	"stonecutter":                  "stonecutter_block",
+	"kelp_plant":                   "kelp",
+	"twisting_vines_plant":         "twisting_vines",
+	"weeping_vines_plant":          "weeping_vines",
+	"big_dripleaf_stem":            "big_dripleaf",
+	"small_dripleaf":               "small_dripleaf_block",
+	"frogspawn":                    "frog_spawn",
+	"beetroots":                    "beetroot",
+	"attached_melon_stem":          "melon_stem",
+	"attached_pumpkin_stem":        "pumpkin_stem",
+}
+
+// translate translates a Java Edition block state with the name and
+// properties passed to the name and properties of a Bedrock Edition block
+// state. If the state implies block entity data in Bedrock Edition, such as
+// the colour of a bed, this data is returned too. Properties without a
+// Bedrock Edition counterpart are dropped.
+func (c *converter) translate(name string, p map[string]string) (string, map[string]any, map[string]any) {
+	name = strings.TrimPrefix(name, "minecraft:")
+	props, data := map[string]any{}, map[string]any(nil)
+	if strings.HasPrefix(name, "attached_") {
+		props["growth"] = 7
+	}
+	if n, ok := javaNames[name]; ok {
+		name = n
+	}
+
+	switch {
+	case name == "water" || name == "lava":
+		level, _ := strconv.Atoi(p["level"])
+		if level != 0 {
+			name = "flowing_" + name
+		}
+		return "minecraft:" + name, map[string]any{"liquid_depth": level}, nil
+	case name == "water_cauldron" || name == "lava_cauldron" || name == "powder_snow_cauldron":
+		level, _ := strconv.Atoi(p["level"])
+		if name == "lava_cauldron" {
+			level = 3
+		}
+		props["cauldron_liquid"], props["fill_level"] = strings.TrimSuffix(name, "_cauldron"), level*2
+		name = "cauldron"
+		delete(p, "level")
+	case name == "light":
+		name = "light_block_" + p["level"]
+		delete(p, "level")
+	case strings.HasPrefix(name, "potted_"):
+		plant := strings.TrimSuffix(strings.TrimPrefix(name, "potted_"), "_bush")
+		if plant == "dead" {
+			plant = "dead_bush"
+		}
+		plantName, plantProps, _ := c.translate(plant, map[string]string{})
+		if b, ok := c.lookup(plantName, plantProps); ok {
+			data = map[string]any{"id": "FlowerPot", "PlantBlock": nbtconv.WriteBlock(b)}
+		}
+		name = "flower_pot"
+	case strings.HasSuffix(name, "_bed"):
+		data = map[string]any{"id": "Bed", "color": colour(strings.TrimSuffix(name, "_bed")).Uint8()}
+		name = "bed"
+	case strings.HasSuffix(name, "_wall_banner"):
+		data = map[string]any{"id": "Banner", "Base": int32(^colour(strings.TrimSuffix(name, "_wall_banner")).Uint8() & 0xf)}
+		name = "wall_banner"
+	case strings.HasSuffix(name, "_banner"):
+		data = map[string]any{"id": "Banner", "Base": int32(^colour(strings.TrimSuffix(name, "_banner")).Uint8() & 0xf)}
+		name = "standing_banner"
+	case name == "piston_head":
+		name = "piston_arm_collision"
+		if p["type"] == "sticky" {
+			name = "sticky_piston_arm_collision"
+		}
+	case strings.HasSuffix(name, "_wall_skull") || strings.HasSuffix(name, "_wall_head"):
+		name = strings.Replace(name, "_wall", "", 1)
+		data = map[string]any{"id": "Skull", "Rotation": float32(0)}
+	case strings.HasSuffix(name, "_skull") || strings.HasSuffix(name, "_head"):
+		rotation, _ := strconv.Atoi(p["rotation"])
+		props["facing_direction"] = 1
+		data = map[string]any{"id": "Skull", "Rotation": float32(rotation) * 22.5}
+		delete(p, "rotation")
+	case strings.HasSuffix(name, "_wall_hanging_sign"):
+		name = strings.TrimSuffix(name, "_wall_hanging_sign") + "_hanging_sign"
+	case strings.HasSuffix(name, "_hanging_sign"):
+		props["hanging"] = true
+	case strings.HasSuffix(name, "_wall_sign"):
+		name = signName(strings.TrimSuffix(name, "_wall_sign"), "wall_sign")
+	case strings.HasSuffix(name, "_sign"):
+		name = signName(strings.TrimSuffix(name, "_sign"), "standing_sign")
+	case strings.HasSuffix(name, "_slab"):
+		switch p["type"] {
+		case "double":
+			if strings.HasSuffix(name, "cut_copper_slab") {
+				name = strings.TrimSuffix(name, "cut_copper_slab") + "double_cut_copper_slab"
+			} else {
+				name = strings.TrimSuffix(name, "_slab") + "_double_slab"
+			}
+		case "top":
+			props["minecraft:vertical_half"] = "top"
+		}
+	case name == "wall_torch" || name == "soul_wall_torch" || name == "redstone_wall_torch":
+		name = strings.Replace(name, "wall_", "", 1)
+		props["torch_facing_direction"] = p["facing"]
+		delete(p, "facing")
+	case name == "torch" || name == "soul_torch" || name == "redstone_torch":
+		props["torch_facing_direction"] = "top"
+	case name == "lever":
+		axis := "east_west"
+		if p["facing"] == "north" || p["facing"] == "south" {
+			axis = "north_south"
+		}
+		switch p["face"] {
+		case "floor":
+			props["lever_direction"] = "up_" + axis
+		case "ceiling":
+			props["lever_direction"] = "down_" + axis
+		default:
+			props["lever_direction"] = p["facing"]
+		}
+		props["open_bit"] = p["powered"] == "true"
+		delete(p, "face")
+		delete(p, "facing")
+		delete(p, "powered")
+	case strings.HasSuffix(name, "_button"):
+		switch p["face"] {
+		case "floor":
+			p["facing"] = "up"
+		case "ceiling":
+			p["facing"] = "down"
+		}
+		delete(p, "face")
+	case name == "cave_vines" || name == "cave_vines_plant":
+		switch {
+		case p["berries"] == "true" && name == "cave_vines":
+			name = "cave_vines_head_with_berries"
+		case p["berries"] == "true":
+			name = "cave_vines_body_with_berries"
+		default:
+			name = "cave_vines"
+		}
+	case name == "tall_seagrass":
+		name, props["sea_grass_type"] = "seagrass", "double_bot"
+		if p["half"] == "upper" {
+			props["sea_grass_type"] = "double_top"
+		}
+		delete(p, "half")
+	case name == "bamboo":
+		props["bamboo_leaf_size"] = map[string]string{"none": "no_leaves", "small": "small_leaves", "large": "large_leaves"}[p["leaves"]]
+		props["bamboo_stalk_thickness"] = "thin"
+		if p["age"] == "1" {
+			props["bamboo_stalk_thickness"] = "thick"
+		}
+		delete(p, "age")
+	case name == "campfire" || name == "soul_campfire":
+		props["extinguished"] = p["lit"] == "false"
+		delete(p, "lit")
+	case name == "sea_pickle":
+		props["dead_bit"] = p["waterlogged"] != "true"
+	case name == "daylight_detector" && p["inverted"] == "true":
+		name = "daylight_detector_inverted"
+	}
+
+	for k, v := range p {
+		translateProperty(name, k, v, props)
+	}
+	name = c.variant(name, p)
+	c.faces(name, p, props)
+	return "minecraft:" + name, props, data
+}
+
+// variant returns the name of the Bedrock Edition block that a block state
+// with the name and Java Edition properties passed is converted to, for
+// blocks that have separate lit or powered variants in Bedrock Edition, such
+// as furnaces and repeaters.
+func (c *converter) variant(name string, p map[string]string) string {
+	if v, ok := p["lit"]; ok && !c.has("minecraft:"+name, "lit") {
+		if v == "true" && c.exists("minecraft:lit_"+name) {
+			return "lit_" + name
+		} else if v == "false" && c.exists("minecraft:unlit_"+name) {
+			return "unlit_" + name
+		}
+	}
+	if p["powered"] == "true" && strings.HasPrefix(name, "unpowered_") {
+		return strings.Replace(name, "unpowered_", "powered_", 1)
+	}
+	return name
+}
+
+// faces sets the properties of blocks that may be attached to several faces
+// at once, such as vines and glow lichen, from the boolean Java Edition
+// properties named after the faces.
+func (c *converter) faces(name string, p map[string]string, props map[string]any) {
+	name = "minecraft:" + name
+	bits := func(faces map[string]int) (v int) {
+		for face, bit := range faces {
+			if p[face] == "true" {
+				v |= bit
+			}
+		}
+		return v
+	}
+	switch {
+	case c.has(name, "vine_direction_bits"):
+		props["vine_direction_bits"] = bits(map[string]int{"south": 1, "west": 2, "north": 4, "east": 8})
+	case c.has(name, "multi_face_direction_bits"):
+		props["multi_face_direction_bits"] = bits(map[string]int{"down": 1, "up": 2, "south": 4, "west": 8, "north": 16, "east": 32})
+	case c.has(name, "huge_mushroom_bits"):
+		all := bits(map[string]int{"down": 1, "up": 2, "south": 4, "west": 8, "north": 16, "east": 32}) == 63
+		switch {
+		case name == "minecraft:mushroom_stem" && all:
+			props["huge_mushroom_bits"] = 15
+		case name == "minecraft:mushroom_stem":
+			props["huge_mushroom_bits"] = 10
+		case all:
+			props["huge_mushroom_bits"] = 14
+		case p["up"] == "true":
+			props["huge_mushroom_bits"] = 5
+		}
+	}
+}
+
+// translateProperty translates a single Java Edition block property to the
+// Bedrock Edition properties of the block with the name passed. Some Java
+// Edition properties correspond to differently named properties depending on
+// the block, in which case all candidates are set: Only the properties that
+// the Bedrock Edition block actually has are used.
+func translateProperty(name, k, v string, props map[string]any) {
+	b, n := v == "true", 0
+	if i, err := strconv.Atoi(v); err == nil {
+		n = i
+	}
+	switch k {
+	case "axis":
+		props["pillar_axis"] = v
+	case "facing":
+		facing(name, v, props)
+	case "half":
+		if v == "upper" || v == "lower" {
+			props["upper_block_bit"] = v == "upper"
+		} else {
+			props["upside_down_bit"] = v == "top"
+		}
+	case "open":
+		props["open_bit"] = b
+	case "hinge":
+		props["door_hinge_bit"] = v == "right"
+	case "powered":
+		props["powered_bit"], props["button_pressed_bit"] = b, b
+	case "persistent":
+		props["persistent_bit"] = b
+	case "age":
+		props["age"], props["growth"], props["growing_plant_age"], props["kelp_age"] = n, n, n, n
+	case "stage":
+		props["age_bit"] = n
+	case "level":
+		props["composter_fill_level"] = n
+	case "layers":
+		props["height"] = n - 1
+	case "moisture":
+		props["moisturized_amount"] = n
+	case "rotation":
+		props["ground_sign_direction"] = n
+	case "bites":
+		props["bite_counter"] = n
+	case "candles":
+		props["candles"] = n - 1
+	case "lit":
+		props["lit"] = b
+	case "pickles":
+		props["cluster_count"] = n - 1
+	case "hanging":
+		props["hanging"] = b
+	case "charges":
+		props["respawn_anchor_charge"] = n
+	case "honey_level":
+		props["honey_level"] = n
+	case "power":
+		props["redstone_signal"] = n
+	case "triggered":
+		props["triggered_bit"] = b
+	case "delay":
+		props["repeater_delay"] = n - 1
+	case "mode":
+		props["output_subtract_bit"] = v == "subtract"
+	case "eye":
+		props["end_portal_eye_bit"] = b
+	case "occupied":
+		props["occupied_bit"] = b
+	case "part":
+		props["head_piece_bit"] = v == "head"
+	case "in_wall":
+		props["in_wall_bit"] = b
+	case "attached":
+		props["attached_bit"] = b
+	case "disarmed":
+		props["disarmed_bit"] = b
+	case "conditional":
+		props["conditional_bit"] = b
+	case "drag":
+		props["drag_down"] = b
+	case "has_bottle_0", "has_bottle_1", "has_bottle_2":
+		props["brewing_stand_slot_"+string(rune('a'+k[11]-'0'))+"_bit"] = b
+	case "shape":
+		if dir, ok := railDirections[v]; ok {
+			props["rail_direction"] = dir
+		}
+	case "thickness":
+		props["dripstone_thickness"] = strings.Replace(v, "tip_merge", "merge", 1)
+	case "vertical_direction":
+		props["hanging"] = v == "down"
+	case "tilt":
+		props["big_dripleaf_tilt"] = map[string]string{"none": "none", "unstable": "unstable", "partial": "partial_tilt", "full": "full_tilt"}[v]
+	case "eggs":
+		props["turtle_egg_count"] = map[int]string{1: "one_egg", 2: "two_egg", 3: "three_egg", 4: "four_egg"}[n]
+	case "hatch":
+		props["cracked_state"] = map[int]string{0: "no_cracks", 1: "cracked", 2: "max_cracked"}[n]
+	case "sculk_sensor_phase":
+		props["sculk_sensor_phase"] = map[string]int{"inactive": 0, "active": 1, "cooldown": 2}[v]
+	case "distance":
+		props["stability"] = n
+	case "flower_amount", "segment_amount":
+		props["growth"] = n - 1
+	case "face":
+		props["attachment"] = map[string]string{"floor": "standing", "wall": "side", "ceiling": "hanging"}[v]
+	case "attachment":
+		props["attachment"] = map[string]string{"floor": "standing", "ceiling": "hanging", "single_wall": "side", "double_wall": "multiple"}[v]
+	case "up":
+		props["wall_post_bit"] = b
+	case "north", "east", "south", "west":
+		if conn, ok := map[string]string{"none": "none", "low": "short", "tall": "tall"}[v]; ok {
+			props["wall_connection_type_"+k] = conn
+		}
+	}
+}
+
+// facing sets the direction properties of the block with the name passed
+// from the Java Edition facing direction passed. Bedrock Edition encodes
+// directions in a number of different ways, all of which are set.
+func facing(name, v string, props map[string]any) {
+	props["minecraft:cardinal_direction"] = v
+	props["minecraft:facing_direction"] = v
+	props["minecraft:block_face"] = v
+	if dir, ok := map[string]int{"down": 0, "up": 1, "north": 2, "south": 3, "west": 4, "east": 5}[v]; ok {
+		props["facing_direction"] = dir
+	}
+	horizontal := map[string]int{"south": 0, "west": 1, "north": 2, "east": 3}
+	if strings.HasSuffix(name, "_stairs") || strings.HasSuffix(name, "trapdoor") {
+		horizontal = map[string]int{"east": 0, "west": 1, "south": 2, "north": 3}
+	}
+	if dir, ok := horizontal[v]; ok {
+		props["direction"], props["weirdo_direction"] = dir, dir
+	}
+}
+
+// railDirections maps the shapes of Java Edition rails to the rail_direction
+// property of Bedrock Edition rails.
+var railDirections = map[string]int{
+	"north_south": 0, "east_west": 1,
+	"ascending_east": 2, "ascending_west": 3, "ascending_north": 4, "ascending_south": 5,
+	"south_east": 6, "south_west": 7, "north_west": 8, "north_east": 9,
+}
+
+// signName returns the name of the Bedrock Edition sign of the kind passed
+// made of the wood passed.
+func signName(wood, kind string) string {
+	switch wood {
+	case "oak":
+		return kind
+	case "dark_oak":
+		return "darkoak_" + kind
+	}
+	return wood + "_" + kind
+}
+
+// colour returns the item.Colour with the name passed, as found at the start
+// of the names of coloured Java Edition blocks.
+func colour(name string) item.Colour {
+	for _, c := range item.Colours() {
+		if c.String() == name {
+			return c
+		}
+	}
+	return item.ColourWhite()
+}
+
+// lookup returns the Bedrock Edition block with the name and properties
+// passed. Properties that the block does not have are ignored, and those
+// that are missing are set to their defaults. If the properties do not form
+// a valid state of the block, the block with default properties is returned.
+// False is returned if no block with the name exists.
+func (c *converter) lookup(name string, props map[string]any) (world.Block, bool) {
+	defaults, ok := c.defaults[name]
+	if !ok {
+		return nil, false
+	}
+	merged := make(map[string]any, len(defaults))
+	for k, def := range defaults {
+		merged[k] = def
+		if v, ok := props[k]; ok {
+			if converted, ok := convertValue(v, def); ok {
+				merged[k] = converted
+			}
+		}
+	}
+	if b, ok := world.BlockByName(name, merged); ok {
+		return b, true
+	}
+	return world.BlockByName(name, defaults)
+}
+
+// convertValue converts a property value produced by translate to the type
+// of the default value of the property.
+func convertValue(v, def any) (any, bool) {
+	switch def.(type) {
+	case string:
+		s, ok := v.(string)
+		return s, ok && s != ""
+	case bool:
+		b, ok := v.(bool)
+		return b, ok
+	case uint8:
+		switch v := v.(type) {
+		case bool:
+			if v {
+				return uint8(1), true
+			}
+			return uint8(0), true
+		case int:
+			return uint8(v), v >= 0 && v <= 255
+		}
+	case int32:
+		n, ok := v.(int)
+		return int32(n), ok
+	}
+	return nil, false
+}
+
+// has checks if the Bedrock Edition block with the name passed has a
+// property with the key passed.
+func (c *converter) has(name, key string) bool {
+	_, ok := c.defaults[name][key]
+	return ok
+}
+
+// exists checks if a Bedrock Edition block with the name passed exists.
+func (c *converter) exists(name string) bool {
+	_, ok := c.defaults[name]
+	return ok
+}
@@ -1,6 +1,11 @@
 package world
 
-import "image/color"
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+)
 
 // Biome is a region in a world with distinct geographical features, flora, temperatures, humidity ratings,
 // and sky, water, grass and foliage colours.
@@ -20,6 +25,7 @@ type Biome interface {
 	// String returns the biome name as a string.
 	String() string
 	// EncodeBiome encodes the biome into an int value that is used to identify the biome over the network.
+	// Custom biomes must return an ID higher than that of any vanilla biome.
 	EncodeBiome() int
 }
 
@@ -29,12 +35,21 @@ var biomes = map[int]Biome{}
 
 var biomeByName = map[string]Biome{}
 
-// RegisterBiome registers a biome to the map so that it can be saved and loaded with the world.
+// RegisterBiome registers a biome to the map so that it can be saved and loaded with the world. Biomes
+// registered after the vanilla biomes are custom biomes: They are sent to clients along with the vanilla
+// biomes, using the ID returned by EncodeBiome, which must be higher than that of any vanilla biome and fit in
+// an int16. Providers store custom biomes by their name, so that their IDs may change between runs.
 func RegisterBiome(b Biome) {
 	id := b.EncodeBiome()
 	if _, ok := biomes[id]; ok {
 		panic("cannot register the same biome (" + b.String() + ") twice")
 	}
+	if _, ok := biomeByName[b.String()]; ok {
+		panic("cannot register two biomes with the same name (" + b.String() + ")")
+	}
+	if biomesFinalised && (id <= maxVanillaBiomeID || id > math.MaxInt16) {
+		panic(fmt.Sprintf("custom biome %v has ID %v, must be between %v and %v", b, id, maxVanillaBiomeID+1, math.MaxInt16))
+	}
 	biomes[id] = b
 	biomeByName[b.String()] = b
 }
@@ -51,17 +66,38 @@ func BiomeByName(name string) (Biome, bool) {
 	return e, ok
 }
 
-// Biomes returns a slice of all registered biomes.
+// Biomes returns a slice of all registered biomes, sorted by their ID.
 func Biomes() []Biome {
 	bs := make([]Biome, 0, len(biomes))
 	for _, b := range biomes {
 		bs = append(bs, b)
 	}
+	slices.SortFunc(bs, func(a, b Biome) int {
+		return a.EncodeBiome() - b.EncodeBiome()
+	})
 	return bs
 }
 
+// CustomBiome checks if the Biome passed is a custom biome, meaning it was
+// registered after the vanilla biomes.
+func CustomBiome(b Biome) bool {
+	return b.EncodeBiome() > maxVanillaBiomeID
+}
+
 // ocean returns an ocean biome.
 func ocean() Biome {
 	o, _ := BiomeByID(0)
 	return o
 }
+
+// biomeByIDOr returns the Biome with the ID passed, or the fallback Biome if
+// no biome with that ID is registered. This is the case for custom biomes that
+// were saved to a world by a server that registered them, but that are not
+// registered now. The ID of such biomes is left unchanged in chunks, so that
+// they are restored once the biome is registered again.
+func biomeByIDOr(id int, fallback Biome) Biome {
+	if b, ok := biomes[id]; ok {
+		return b
+	}
+	return fallback
+}
@@ -9,6 +9,9 @@ import (
 var (
 	// maxVanillaBiomeID is the highest ID used by vanilla biomes.
 	maxVanillaBiomeID int
+	// biomesFinalised is true once all vanilla biomes have been registered.
+	// Biomes registered afterwards are custom biomes.
+	biomesFinalised bool
 )
 
 // finaliseBiomeRegistry is called after all vanilla biomes have been registered.
@@ -19,10 +22,11 @@ var (
 func finaliseBiomeRegistry() {
 	for _, b := range biomes {
 		id := b.EncodeBiome()
-		if id > maxVanillaBiomeID {
+		if CustomBiome(b) {
 			maxVanillaBiomeID = id
 		}
 	}
+	biomesFinalised = true
 }
 
 // ashyBiome represents a biome that has any form of ash.
@@ -37,7 +41,10 @@ type sporingBiome interface {
 	Spores() (blueSpores float64, redSpores float64)
 }
 
-// BiomeDefinitions returns the list of biome definitions along with the associated StringList.
+// BiomeDefinitions returns the list of biome definitions along with the associated StringList. The
+// definitions are sorted by the ID of their biome, so that the list is the same every time. Custom biomes
+// are included with their ID, so that clients can show them. Their fog, sky and grass colours cannot be sent
+// to clients and must be defined in a resource pack instead.
 func BiomeDefinitions() ([]protocol.BiomeDefinition, []string) {
 	var (
 		internedStrings     []string
@@ -55,7 +62,7 @@ func BiomeDefinitions() ([]protocol.BiomeDefinition, []string) {
 	}
 
 	encodedBiomes := make([]protocol.BiomeDefinition, 0, len(biomes))
-	for _, b := range biomes {
+	for _, b := range Biomes() {
 		nameIndex := intern(b.String())
 
 		tags := b.Tags()
@@ -8,6 +8,7 @@ import (
 	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/segmentio/fasthash/fnv1"
 	"image"
+	"maps"
 	"math"
 	"math/bits"
 	"math/rand/v2"
@@ -136,6 +137,13 @@ func finaliseBlockRegistry() {
 			hashes.Put(h, int64(rid))
 		}
 	}
+	// Collision boxes can only be computed once air is known, so this is done
+	// after all blocks have been finalised. Blocks are checked in isolation,
+	// so that blocks connecting to neighbours still report their base box.
+	for rid, b := range blocks {
+		_, liquid := b.(Liquid)
+		chunk.MotionBlockingBlocks[rid] = liquid || len(b.Model().BBox(cube.Pos{}, airSource{})) != 0
+	}
 }
 
 // finaliseBlock stores the necessary information for the provided block to be quickly accessed at runtime.
@@ -165,6 +173,12 @@ func finaliseBlock(rid uint32, b Block) {
 	if _, ok := b.(LiquidDisplacer); ok {
 		liquidDisplacingBlocks[rid] = true
 	}
+	if _, ok := b.(BlockChangeObserver); ok {
+		observerBlocks[rid] = true
+	}
+	if p, ok := b.(VillagePoint); ok && p.VillagePoint() {
+		villagePointBlocks[rid] = true
+	}
 }
 
 // BlockHash returns a unique identifier of the block including the block states. This function is used internally
@@ -228,6 +242,44 @@ func BlockByName(name string, properties map[string]any) (Block, bool) {
 	return blocks[rid], true
 }
 
+// BlockProperties returns the state properties of the Block passed, as
+// returned by its EncodeBlock method. The map returned is a copy and may be
+// modified freely, for example to pass it to BlockWithProperties.
+func BlockProperties(b Block) map[string]any {
+	_, properties := b.EncodeBlock()
+	return maps.Clone(properties)
+}
+
+// BlockWithProperties returns the registered Block with the name and state
+// properties passed. Unlike BlockByName, integer properties may be passed as
+// any integer type and only implemented blocks are returned. If no block with
+// the name exists, if the properties do not form a valid state of the block
+// or if the block has not been implemented, the bool returned is false.
+func BlockWithProperties(name string, properties map[string]any) (Block, bool) {
+	defaults, ok := blockProperties[name]
+	if !ok || len(properties) != len(defaults) {
+		return nil, false
+	}
+	converted := make(map[string]any, len(properties))
+	for k, v := range properties {
+		def, ok := defaults[k]
+		if !ok {
+			return nil, false
+		}
+		if converted[k], ok = convertProperty(v, def); !ok {
+			return nil, false
+		}
+	}
+	b, ok := BlockByName(name, converted)
+	if !ok {
+		return nil, false
+	}
+	if _, unknown := b.(unknownBlock); unknown {
+		return nil, false
+	}
+	return b, true
+}
+
 // Blocks returns a slice of all registered blocks.
 func Blocks() []Block {
 	return slices.Clone(blocks)
@@ -275,6 +327,15 @@ type NeighbourUpdateTicker interface {
 	NeighbourUpdateTick(pos, changedNeighbour cube.Pos, tx *Tx)
 }
 
+// BlockChangeObserver represents a block that is notified when the state of a block adjacent to it changes,
+// such as an observer. Unlike a NeighbourUpdateTicker, a BlockChangeObserver is also notified of changes
+// made with block updates disabled, but it is not notified if a block is set to the state it already had.
+type BlockChangeObserver interface {
+	// ObserveBlockChange handles the state of a neighbouring block changing. The position of that block and
+	// the position of this block is passed.
+	ObserveBlockChange(pos, changedNeighbour cube.Pos, tx *Tx)
+}
+
 // NBTer represents either an item or a block which may decode NBT data and encode to NBT data. Typically,
 // this is done to store additional data.
 type NBTer interface {
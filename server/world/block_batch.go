@@ -0,0 +1,81 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// BlockUpdate is a change of a block in a chunk, passed to
+// BlockUpdatesViewer.ViewBlockUpdates.
+type BlockUpdate struct {
+	// Pos is the position of the block changed.
+	Pos cube.Pos
+	// Block is the block at Pos after all changes made to it in the
+	// transaction.
+	Block Block
+	// Layer is the layer of the block changed. Layer 0 holds regular blocks
+	// and layer 1 the liquids that blocks are submerged in.
+	Layer int
+}
+
+// queueBlockUpdate queues the block at the position and layer passed in the
+// Column c to be sent to the viewers of the chunk at the end of the current
+// transaction. Nothing is queued if the Column has no viewers.
+func (w *World) queueBlockUpdate(c *Column, pos cube.Pos, layer int) {
+	if len(c.viewers) == 0 {
+		return
+	}
+	if c.updates == nil {
+		c.updates = make(map[cube.Pos]uint8)
+	}
+	if len(c.updates) == 0 {
+		w.updated = append(w.updated, chunkPosFromBlockPos(pos))
+	}
+	c.updates[pos] |= 1 << layer
+}
+
+// flushBlockUpdates sends all block updates queued during a transaction to
+// the viewers of the chunks they were made in. Every position is sent only
+// once per layer, with the block that is at the position when the
+// transaction finishes. Viewers that implement BlockUpdatesViewer are sent all
+// updates in a chunk at once.
+func (w *World) flushBlockUpdates() {
+	for _, pos := range w.updated {
+		c, ok := w.chunks[pos]
+		if !ok || len(c.updates) == 0 {
+			continue
+		}
+		updates := make([]BlockUpdate, 0, len(c.updates))
+		for bpos, layers := range c.updates {
+			if layers&1 != 0 {
+				updates = append(updates, BlockUpdate{Pos: bpos, Block: w.queuedBlock(c, bpos)})
+			}
+			if layers&2 != 0 {
+				rid := c.Block(uint8(bpos[0]), int16(bpos[1]), uint8(bpos[2]), 1)
+				updates = append(updates, BlockUpdate{Pos: bpos, Block: blockByRuntimeIDOrAir(rid), Layer: 1})
+			}
+		}
+		clear(c.updates)
+		for _, v := range c.viewers {
+			if bv, ok := v.(BlockUpdatesViewer); ok {
+				bv.ViewBlockUpdates(pos, updates)
+				continue
+			}
+			for _, u := range updates {
+				v.ViewBlockUpdate(u.Pos, u.Block, u.Layer)
+			}
+		}
+	}
+	w.updated = w.updated[:0]
+}
+
+// queuedBlock returns the block in the first layer at the position passed in
+// the Column c, including its block entity data if it has any.
+func (w *World) queuedBlock(c *Column, pos cube.Pos) Block {
+	rid := c.Block(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0)
+	if nbtBlocks[rid] {
+		if b, ok := c.BlockEntities[pos]; ok {
+			return b
+		}
+	}
+	return blockByRuntimeIDOrAir(rid)
+}
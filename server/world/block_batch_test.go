@@ -0,0 +1,117 @@
+package world_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// batchViewer is a world.BlockUpdatesViewer that counts the block updates it
+// views and the sub chunks they are in. Sessions send the block updates of a
+// sub chunk in a single packet.
+type batchViewer struct {
+	world.NopViewer
+	updates, packets *int
+}
+
+func (v batchViewer) ViewBlockUpdates(_ world.ChunkPos, updates []world.BlockUpdate) {
+	*v.updates += len(updates)
+	if v.packets == nil {
+		return
+	}
+	subs := make(map[int]struct{})
+	for _, u := range updates {
+		subs[u.Pos[1]>>4] = struct{}{}
+	}
+	*v.packets += len(subs)
+}
+
+// singleViewer is a world.Viewer that counts the block updates it views one
+// by one.
+type singleViewer struct {
+	world.NopViewer
+	updates *int
+}
+
+func (v singleViewer) ViewBlockUpdate(cube.Pos, world.Block, int) {
+	*v.updates++
+}
+
+// withViewedChunk runs f in a world in which the chunk at 0, 0 is viewed by
+// the viewer passed.
+func withViewedChunk(tb testing.TB, v world.Viewer, f func(w *world.World)) {
+	w := world.Config{}.New()
+	defer w.Close()
+
+	l := world.NewLoader(1, w, v)
+	deadline := time.Now().Add(5 * time.Second)
+	for loaded := false; !loaded; {
+		if time.Now().After(deadline) {
+			tb.Fatalf("chunk 0, 0 was not loaded")
+		}
+		<-w.Exec(func(tx *world.Tx) {
+			l.Move(tx, mgl64.Vec3{8, 0, 8})
+			l.Load(tx, 9)
+			_, loaded = l.Chunk(world.ChunkPos{})
+		})
+	}
+	f(w)
+}
+
+// setBlocks sets n blocks in the chunk at 0, 0, starting at y = 0, to the
+// block passed.
+func setBlocks(tx *world.Tx, n int, b world.Block) {
+	for i := range n {
+		tx.SetBlock(cube.Pos{i & 15, i >> 8, (i >> 4) & 15}, b, nil)
+	}
+}
+
+func TestBlockUpdatesBatched(t *testing.T) {
+	var batched, single int
+	withViewedChunk(t, batchViewer{updates: &batched}, func(w *world.World) {
+		<-w.Exec(func(tx *world.Tx) {
+			setBlocks(tx, 1000, block.Stone{})
+			// Setting the same blocks twice in a transaction must only
+			// result in a single update for each of them.
+			setBlocks(tx, 1000, block.Dirt{})
+		})
+	})
+	withViewedChunk(t, singleViewer{updates: &single}, func(w *world.World) {
+		<-w.Exec(func(tx *world.Tx) {
+			setBlocks(tx, 1000, block.Stone{})
+		})
+	})
+	if batched != 1000 || single != 1000 {
+		t.Fatalf("expected 1000 block updates for both viewers, got %v batched and %v single", batched, single)
+	}
+}
+
+func BenchmarkSetBlocksBatched(b *testing.B) {
+	var updates, packets int
+	withViewedChunk(b, batchViewer{updates: &updates, packets: &packets}, func(w *world.World) {
+		blocks := []world.Block{block.Stone{}, block.Dirt{}}
+		for i := 0; b.Loop(); i++ {
+			<-w.Exec(func(tx *world.Tx) {
+				setBlocks(tx, 10000, blocks[i%2])
+			})
+		}
+	})
+	b.ReportMetric(float64(packets)/float64(b.N), "packets/op")
+}
+
+func BenchmarkSetBlocksSingle(b *testing.B) {
+	var updates int
+	withViewedChunk(b, singleViewer{updates: &updates}, func(w *world.World) {
+		blocks := []world.Block{block.Stone{}, block.Dirt{}}
+		for i := 0; b.Loop(); i++ {
+			<-w.Exec(func(tx *world.Tx) {
+				setBlocks(tx, 10000, blocks[i%2])
+			})
+		}
+	})
+	b.ReportMetric(float64(updates)/float64(b.N), "packets/op")
+}
@@ -0,0 +1,53 @@
+package world_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestBlockPropertiesRoundTrip(t *testing.T) {
+	for _, b := range []world.Block{
+		// Bool and int properties.
+		block.Stairs{Block: block.Planks{Wood: block.OakWood()}, UpsideDown: true, Facing: cube.East},
+		block.Farmland{Hydration: 3},
+		// String property.
+		block.Log{Wood: block.OakWood(), Axis: cube.X},
+	} {
+		name, _ := b.EncodeBlock()
+		got, ok := world.BlockWithProperties(name, world.BlockProperties(b))
+		if !ok {
+			t.Fatalf("expected %v to be found by its properties", name)
+		}
+		if world.BlockRuntimeID(got) != world.BlockRuntimeID(b) {
+			t.Errorf("expected %#v from round trip, got %#v", b, got)
+		}
+	}
+}
+
+func TestBlockWithPropertiesConvertsIntegers(t *testing.T) {
+	got, ok := world.BlockWithProperties("minecraft:farmland", map[string]any{"moisturized_amount": 7})
+	if !ok || got != (block.Farmland{Hydration: 7}) {
+		t.Fatalf("expected farmland with hydration 7, got %#v (%v)", got, ok)
+	}
+}
+
+func TestBlockWithPropertiesRejectsInvalid(t *testing.T) {
+	props := world.BlockProperties(block.Log{Wood: block.OakWood(), Axis: cube.Y})
+	props["pillar_axis"] = "w"
+	if _, ok := world.BlockWithProperties("minecraft:oak_log", props); ok {
+		t.Errorf("expected invalid string property to be rejected")
+	}
+	props = world.BlockProperties(block.Log{Wood: block.OakWood(), Axis: cube.Y})
+	props["pillar_axis"] = true
+	if _, ok := world.BlockWithProperties("minecraft:oak_log", props); ok {
+		t.Errorf("expected property of the wrong type to be rejected")
+	}
+	props = world.BlockProperties(block.Log{Wood: block.OakWood(), Axis: cube.Y})
+	delete(props, "pillar_axis")
+	if _, ok := world.BlockWithProperties("minecraft:oak_log", props); ok {
+		t.Errorf("expected missing property to be rejected")
+	}
+}
@@ -12,3 +12,8 @@ type BlockSource interface {
 type worldSource struct{ w *World }
 
 func (w worldSource) Block(pos cube.Pos) Block { return w.w.block(pos) }
+
+// airSource is a BlockSource that holds only air.
+type airSource struct{}
+
+func (airSource) Block(cube.Pos) Block { return air() }
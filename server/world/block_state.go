@@ -38,6 +38,12 @@ var (
 	// liquidDisplacingBlocks holds a list of LiquidDisplacer implementations for blocks registered that implement the LiquidDisplacer interface.
 	// These are indexed by their runtime IDs. Blocks that do not implement LiquidDisplacer have a false value in this slice.
 	liquidDisplacingBlocks []bool
+	// observerBlocks holds a list of BlockChangeObserver implementations for blocks registered that implement the BlockChangeObserver interface.
+	// These are indexed by their runtime IDs. Blocks that do not implement BlockChangeObserver have a false value in this slice.
+	observerBlocks []bool
+	// villagePointBlocks holds a list of VillagePoint implementations for blocks registered that are a point of interest of a village.
+	// These are indexed by their runtime IDs. Blocks that are not a village point have a false value in this slice.
+	villagePointBlocks []bool
 	// airRID is the runtime ID of an air block.
 	airRID uint32
 )
@@ -92,8 +98,11 @@ func registerBlockState(s blockState) {
 	randomTickBlocks = slices.Insert(randomTickBlocks, int(rid), false)
 	liquidBlocks = slices.Insert(liquidBlocks, int(rid), false)
 	liquidDisplacingBlocks = slices.Insert(liquidDisplacingBlocks, int(rid), false)
+	observerBlocks = slices.Insert(observerBlocks, int(rid), false)
+	villagePointBlocks = slices.Insert(villagePointBlocks, int(rid), false)
 	chunk.FilteringBlocks = slices.Insert(chunk.FilteringBlocks, int(rid), 15)
 	chunk.LightBlocks = slices.Insert(chunk.LightBlocks, int(rid), 0)
+	chunk.MotionBlockingBlocks = slices.Insert(chunk.MotionBlockingBlocks, int(rid), true)
 	stateRuntimeIDs[h] = rid
 }
 
@@ -181,3 +190,66 @@ func hashProperties(properties map[string]any) string {
 
 	return b.String()
 }
+
+// convertProperty converts the block property value v to the type of the
+// property value def, which is a value of the same property as registered in
+// the block states. False is returned if v could not be converted.
+func convertProperty(v, def any) (any, bool) {
+	if b, ok := v.(bool); ok {
+		// Boolean properties are stored as uint8 in the block states, but
+		// many blocks encode them as a bool. Both hash to the same value.
+		switch def.(type) {
+		case bool, uint8:
+			return b, true
+		}
+		return nil, false
+	}
+	switch def.(type) {
+	case string:
+		str, ok := v.(string)
+		return str, ok
+	case bool, uint8:
+		if n, ok := propertyInt(v); ok && n >= 0 && n <= math.MaxUint8 {
+			return uint8(n), true
+		}
+	case int32:
+		if n, ok := propertyInt(v); ok && n >= math.MinInt32 && n <= math.MaxInt32 {
+			return int32(n), true
+		}
+	}
+	return nil, false
+}
+
+// propertyInt returns the integer value of a block property value v of any
+// integer type. False is returned if v is not an integer.
+func propertyInt(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int8:
+		return int64(n), true
+	case int16:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint8:
+		return int64(n), true
+	case uint16:
+		return int64(n), true
+	case uint32:
+		return int64(n), true
+	case uint64:
+		if n > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(n), true
+	case uint:
+		if n > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(n), true
+	}
+	return 0, false
+}
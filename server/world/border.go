@@ -0,0 +1,115 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"time"
+)
+
+// Border is a square border around a centre point in a World. Players cannot
+// move past the border or interact with blocks outside of it, and they are
+// hurt when they are too far outside of it. A Border may be resizing, in which
+// case its size changes linearly from From to To over the ticks between
+// ResizeStart and ResizeEnd.
+// Bedrock Edition clients cannot display a Border themselves, so servers that
+// want players to see it should render it, for example using particles.
+type Border struct {
+	// Centre is the centre of the border on the X and Z axes.
+	Centre mgl64.Vec2
+	// From is the length in blocks of the sides of the border at the tick
+	// ResizeStart. To is the length at the tick ResizeEnd. If the border is
+	// not resizing, From and To are equal. If To is 0, the World has no
+	// border.
+	From, To float64
+	// ResizeStart and ResizeEnd are the values of Settings.CurrentTick at
+	// which resizing of the border started and will end respectively.
+	ResizeStart, ResizeEnd int64
+	// DamageBuffer is the distance in blocks that players may be outside of
+	// the border without being hurt.
+	DamageBuffer float64
+	// Damage is the damage dealt to players every second for every block
+	// that they are outside of the border, past the DamageBuffer.
+	Damage float64
+
+	tick int64
+}
+
+// Enabled checks if the Border is enabled. If not, the World has no border.
+func (b Border) Enabled() bool {
+	return b.To > 0
+}
+
+// Size returns the current length in blocks of the sides of the Border.
+func (b Border) Size() float64 {
+	switch {
+	case b.tick >= b.ResizeEnd:
+		return b.To
+	case b.tick <= b.ResizeStart:
+		return b.From
+	}
+	progress := float64(b.tick-b.ResizeStart) / float64(b.ResizeEnd-b.ResizeStart)
+	return b.From + (b.To-b.From)*progress
+}
+
+// Resizing checks if the Border is currently resizing.
+func (b Border) Resizing() bool {
+	return b.tick < b.ResizeEnd && b.From != b.To
+}
+
+// Remaining returns the time left until the Border finishes resizing. If it
+// is not resizing, Remaining returns 0.
+func (b Border) Remaining() time.Duration {
+	if !b.Resizing() {
+		return 0
+	}
+	return time.Duration(b.ResizeEnd-b.tick) * time.Second / 20
+}
+
+// Distance returns the distance in blocks from the position passed to the
+// closest side of the Border. The distance is positive if the position is
+// outside the Border and 0 or negative if it is inside. If the Border is
+// not enabled, Distance always returns negative infinity.
+func (b Border) Distance(pos mgl64.Vec3) float64 {
+	if !b.Enabled() {
+		return math.Inf(-1)
+	}
+	half := b.Size() / 2
+	return max(math.Abs(pos[0]-b.Centre[0])-half, math.Abs(pos[2]-b.Centre[1])-half)
+}
+
+// Contains checks if the position passed is within the Border.
+func (b Border) Contains(pos mgl64.Vec3) bool {
+	return b.Distance(pos) <= 0
+}
+
+// ContainsBlock checks if the centre of the block at the position passed is
+// within the Border.
+func (b Border) ContainsBlock(pos cube.Pos) bool {
+	return b.Contains(pos.Vec3Centre())
+}
+
+// Clamp returns the position passed moved to the closest position within the
+// Border. The Y value of the position is left unchanged.
+func (b Border) Clamp(pos mgl64.Vec3) mgl64.Vec3 {
+	if !b.Enabled() {
+		return pos
+	}
+	half := b.Size() / 2
+	pos[0] = min(max(pos[0], b.Centre[0]-half), b.Centre[0]+half)
+	pos[2] = min(max(pos[2], b.Centre[1]-half), b.Centre[1]+half)
+	return pos
+}
+
+// containsChunk checks if any part of the chunk at the position passed is
+// within the Border. If the Border is growing, the size it grows to is used,
+// so that chunks are loaded before the Border reaches them.
+func (b Border) containsChunk(pos ChunkPos) bool {
+	if !b.Enabled() {
+		return true
+	}
+	half := max(b.Size(), b.To) / 2
+	minX, minZ := float64(pos[0]<<4), float64(pos[1]<<4)
+	return minX+16 > b.Centre[0]-half && minX < b.Centre[0]+half &&
+		minZ+16 > b.Centre[1]-half && minZ < b.Centre[1]+half
+}
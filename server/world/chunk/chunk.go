@@ -18,10 +18,18 @@ type Chunk struct {
 	recalculateHeightMap bool
 	// heightMap is the height map of the chunk.
 	heightMap HeightMap
+	// recalculateSurface is true if the highest and motionBlocking height maps must be fully recalculated
+	// on the next query. Once calculated, both height maps are updated incrementally in SetBlock.
+	recalculateSurface bool
+	// highest holds, for every column, the Y value above the highest non-air block on layer 0.
+	highest HeightMap
+	// motionBlocking holds, for every column, the Y value above the highest block that blocks motion on
+	// any layer.
+	motionBlocking HeightMap
 	// sub holds all sub chunks part of the chunk. The pointers held by the array are nil if no sub chunk is
 	// allocated at the indices.
 	sub []*SubChunk
-	// biomes is an array of biome IDs. There is one biome ID for every column in the chunk.
+	// biomes is an array of biome storages, one for every sub chunk. There is one biome ID for every block in the chunk.
 	biomes []*PalettedStorage
 }
 
@@ -40,9 +48,68 @@ func New(air uint32, r cube.Range) *Chunk {
 		biomes:               biomes,
 		recalculateHeightMap: true,
 		heightMap:            make(HeightMap, 256),
+		recalculateSurface:   true,
+		highest:              make(HeightMap, 256),
+		motionBlocking:       make(HeightMap, 256),
 	}
 }
 
+// Clone returns a deep copy of the chunk. The block and biome storages of the
+// chunk are copied along with their palettes, so that the chunk returned
+// shares no memory with the original and may be read from a different
+// goroutine while the original is still being modified. Any height maps that
+// are calculated lazily are calculated before the chunk is copied, so that
+// reading from the copy never modifies it.
+func (chunk *Chunk) Clone() *Chunk {
+	chunk.calculateSurface()
+	c := &Chunk{
+		r:              chunk.r,
+		air:            chunk.air,
+		heightMap:      slices.Clone(chunk.HeightMap()),
+		highest:        slices.Clone(chunk.highest),
+		motionBlocking: slices.Clone(chunk.motionBlocking),
+		sub:            make([]*SubChunk, len(chunk.sub)),
+		biomes:         make([]*PalettedStorage, len(chunk.biomes)),
+	}
+	for i, sub := range chunk.sub {
+		c.sub[i] = sub.Clone()
+	}
+	for i, b := range chunk.biomes {
+		c.biomes[i] = b.Clone()
+	}
+	return c
+}
+
+// Snapshot returns a copy of the block and biome storages of the chunk, which
+// may be encoded from a different goroutine while the original is still being
+// modified. Unlike Clone, Snapshot does not copy the light of the chunk or
+// calculate its height maps, which makes it considerably cheaper. The chunk
+// returned recalculates its height maps when they are first read and holds
+// no light.
+func (chunk *Chunk) Snapshot() *Chunk {
+	c := &Chunk{
+		r:                    chunk.r,
+		air:                  chunk.air,
+		recalculateHeightMap: true,
+		heightMap:            make(HeightMap, 256),
+		recalculateSurface:   true,
+		highest:              make(HeightMap, 256),
+		motionBlocking:       make(HeightMap, 256),
+		sub:                  make([]*SubChunk, len(chunk.sub)),
+		biomes:               make([]*PalettedStorage, len(chunk.biomes)),
+	}
+	for i, sub := range chunk.sub {
+		c.sub[i] = &SubChunk{air: sub.air, storages: make([]*PalettedStorage, len(sub.storages))}
+		for j, storage := range sub.storages {
+			c.sub[i].storages[j] = storage.Clone()
+		}
+	}
+	for i, b := range chunk.biomes {
+		c.biomes[i] = b.Clone()
+	}
+	return c
+}
+
 // Equals returns if the chunk passed is equal to the current one
 func (chunk *Chunk) Equals(c *Chunk) bool {
 	if !chunk.recalculateHeightMap && !c.recalculateHeightMap && !slices.Equal(c.heightMap, chunk.heightMap) {
@@ -92,15 +159,29 @@ func (chunk *Chunk) SetBlock(x uint8, y int16, z uint8, layer uint8, block uint3
 		return
 	}
 	sub.Layer(layer).Set(x, uint8(y), z, block)
-	chunk.recalculateHeightMap = true
+	if !chunk.recalculateHeightMap {
+		chunk.updateHeightMap(chunk.heightMap, x&15, y, z&15, blocksLight)
+	}
+	if !chunk.recalculateSurface {
+		chunk.updateSurface(x, y, z)
+	}
 }
 
-// Biome returns the biome ID at a specific column in the chunk.
+// Biome returns the biome ID at a specific position in the chunk.
 func (chunk *Chunk) Biome(x uint8, y int16, z uint8) uint32 {
 	return chunk.biomes[chunk.SubIndex(y)].At(x, uint8(y), z)
 }
 
-// SetBiome sets the biome ID at a specific column in the chunk.
+// ReplaceBiomes calls the function passed for every biome ID present in the
+// chunk. The biome ID returned by the function replaces the biome ID passed.
+// The function must not return the same ID for two different biome IDs.
+func (chunk *Chunk) ReplaceBiomes(f func(v uint32) uint32) {
+	for _, b := range chunk.biomes {
+		b.Palette().Replace(f)
+	}
+}
+
+// SetBiome sets the biome ID at a specific position in the chunk.
 func (chunk *Chunk) SetBiome(x uint8, y int16, z uint8, biome uint32) {
 	chunk.biomes[chunk.SubIndex(y)].Set(x, uint8(y), z, biome)
 }
@@ -153,23 +234,107 @@ func (chunk *Chunk) highestLightBlocker(x, z uint8, addOne bool) int16 {
 	return int16(chunk.r[0])
 }
 
-// HighestBlock iterates from the highest non-empty sub chunk downwards to find the Y value of the highest
-// non-air block at an x and z. If no blocks are present in the column, the minimum height is returned.
+// HighestBlock returns the Y value of the highest non-air block at an x and z. If no blocks are present in
+// the column, the minimum height is returned.
 func (chunk *Chunk) HighestBlock(x, z uint8) int16 {
-	for index := int16(len(chunk.sub) - 1); index >= 0; index-- {
-		if sub := chunk.sub[index]; !sub.Empty() {
-			for y := 15; y >= 0; y-- {
-				if rid := sub.storages[0].At(x, uint8(y), z); rid != chunk.air {
-					return int16(y) | chunk.SubY(index)
-				}
+	chunk.calculateSurface()
+	return max(chunk.highest.At(x&15, z&15)-1, int16(chunk.r[0]))
+}
+
+// HighestMotionBlocking returns the Y value of the highest block at an x and z that blocks motion, such as
+// solid blocks and liquids. If no such blocks are present in the column, the minimum height is returned.
+func (chunk *Chunk) HighestMotionBlocking(x, z uint8) int16 {
+	chunk.calculateSurface()
+	return max(chunk.motionBlocking.At(x&15, z&15)-1, int16(chunk.r[0]))
+}
+
+// calculateSurface fully recalculates the highest and motionBlocking height maps if needed.
+func (chunk *Chunk) calculateSurface() {
+	if !chunk.recalculateSurface {
+		return
+	}
+	top := int16(chunk.r[1])
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			chunk.highest.Set(x, z, chunk.scanDown(x, top, z, chunk.nonAir))
+			chunk.motionBlocking.Set(x, z, chunk.scanDown(x, top, z, blocksMotion))
+		}
+	}
+	chunk.recalculateSurface = false
+}
+
+// updateSurface updates the highest and motionBlocking height maps after the block at the x, y and z passed
+// was changed. The column is only scanned if the highest block in it was removed.
+func (chunk *Chunk) updateSurface(x uint8, y int16, z uint8) {
+	x, z = x&15, z&15
+	chunk.updateHeightMap(chunk.highest, x, y, z, chunk.nonAir)
+	chunk.updateHeightMap(chunk.motionBlocking, x, y, z, blocksMotion)
+}
+
+// updateHeightMap updates the height map h after the block at the x, y and z passed was changed, where f
+// checks if a block counts towards the height map. The column is only scanned if the highest block in it
+// was removed.
+func (chunk *Chunk) updateHeightMap(h HeightMap, x uint8, y int16, z uint8, f func(sub *SubChunk, x, y, z uint8) bool) {
+	switch present, current := f(chunk.SubChunk(y), x, uint8(y), z), h.At(x, z); {
+	case present && y+1 > current:
+		h.Set(x, z, y+1)
+	case !present && y+1 == current:
+		h.Set(x, z, chunk.scanDown(x, y-1, z, f))
+	}
+}
+
+// InvalidateHeightMaps marks all height maps of the chunk to be fully recalculated the next time they are
+// used. It must be called after blocks were set directly in the sub chunks of the chunk, bypassing
+// SetBlock.
+func (chunk *Chunk) InvalidateHeightMaps() {
+	chunk.recalculateHeightMap, chunk.recalculateSurface = true, true
+}
+
+// scanDown iterates downwards from the Y value passed until a block is found for which f returns true. The
+// Y value above that block is returned, or the minimum height if no such block was found. Empty sub chunks
+// are skipped.
+func (chunk *Chunk) scanDown(x uint8, y int16, z uint8, f func(sub *SubChunk, x, y, z uint8) bool) int16 {
+	if y < int16(chunk.r[0]) {
+		return int16(chunk.r[0])
+	}
+	for index := chunk.SubIndex(y); index >= 0; index-- {
+		sub, minY := chunk.sub[index], chunk.SubY(index)
+		if sub.Empty() {
+			y = minY - 1
+			continue
+		}
+		for ; y >= minY; y-- {
+			if f(sub, x, uint8(y), z) {
+				return y + 1
 			}
 		}
 	}
 	return int16(chunk.r[0])
 }
 
-// HeightMap returns the height map of the chunk. If the chunk is edited, the height map will be recalculated on the
-// next call to this function.
+// nonAir checks if the block on layer 0 at the position passed in a sub chunk is not air.
+func (chunk *Chunk) nonAir(sub *SubChunk, x, y, z uint8) bool {
+	return len(sub.storages) > 0 && sub.storages[0].At(x, y, z) != chunk.air
+}
+
+// blocksLight checks if the block on layer 0 at the position passed in a sub chunk completely blocks light.
+func blocksLight(sub *SubChunk, x, y, z uint8) bool {
+	return len(sub.storages) > 0 && FilteringBlocks[sub.storages[0].At(x, y, z)] == 15
+}
+
+// blocksMotion checks if any of the layers at the position passed in a sub chunk holds a block that blocks
+// motion.
+func blocksMotion(sub *SubChunk, x, y, z uint8) bool {
+	for _, storage := range sub.storages {
+		if MotionBlockingBlocks[storage.At(x, y, z)] {
+			return true
+		}
+	}
+	return false
+}
+
+// HeightMap returns the height map of the chunk. Once calculated, the height map is updated incrementally as
+// blocks are changed using SetBlock.
 func (chunk *Chunk) HeightMap() HeightMap {
 	if chunk.recalculateHeightMap {
 		for x := uint8(0); x < 16; x++ {
@@ -10,6 +10,10 @@ type Column struct {
 	BlockEntities   []BlockEntity
 	Tick            int64
 	ScheduledBlocks []ScheduledBlockUpdate
+	// Undecorated is true if the terrain of the column was generated, but the
+	// column was not yet decorated, because not all of its neighbours were
+	// generated yet.
+	Undecorated bool
 }
 
 type BlockEntity struct {
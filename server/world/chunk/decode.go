@@ -48,7 +48,8 @@ func NetworkDecodeBuffer(air uint32, buf *bytes.Buffer, count int, r cube.Range)
 				// This should never happen and there is no way to handle this.
 				return nil, fmt.Errorf("first biome storage pointed to previous one")
 			}
-			b = last
+			// The storage is cloned so that setting a biome in one sub chunk does not change the other.
+			b = last.Clone()
 		} else {
 			last = b
 		}
@@ -129,11 +130,17 @@ func decodeSubChunk(buf *bytes.Buffer, c *Chunk, index *byte, e Encoding) (*SubC
 	return sub, nil
 }
 
-// decodeBiomes reads the paletted storages holding biomes from buf and stores it into the Chunk passed.
+// decodeBiomes reads the paletted storages holding biomes from buf and stores it into the Chunk passed. If buf holds
+// fewer storages than the Chunk has sub chunks, for example because the height of the world changed, the biomes of the
+// last storage are extended upwards.
 func decodeBiomes(buf *bytes.Buffer, c *Chunk, e Encoding) error {
 	var last *PalettedStorage
 	if buf.Len() != 0 {
 		for i := 0; i < len(c.sub); i++ {
+			if buf.Len() == 0 {
+				c.biomes[i] = last.Clone()
+				continue
+			}
 			b, err := decodePalettedStorage(buf, e, BiomePaletteEncoding)
 			if err != nil {
 				return err
@@ -146,8 +153,9 @@ func decodeBiomes(buf *bytes.Buffer, c *Chunk, e Encoding) error {
 			}
 			if b == nil {
 				// This means this paletted storage had the flag pointing to the previous one. It basically means we should
-				// inherit whatever palette we decoded last.
-				b = last
+				// inherit whatever palette we decoded last. The storage is cloned so that setting a biome in one sub
+				// chunk does not change the other.
+				b = last.Clone()
 			} else {
 				last = b
 			}
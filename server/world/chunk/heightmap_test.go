@@ -0,0 +1,90 @@
+package chunk
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// Runtime IDs of the blocks used in the tests of the height maps. Stone
+// blocks both light and motion, while a flower blocks neither. A lantern
+// blocks motion and emits light.
+const (
+	testAir uint32 = iota
+	testStone
+	testFlower
+	testLantern
+)
+
+var testRange = cube.Range{-64, 319}
+
+// withTestBlocks sets up LightBlocks, FilteringBlocks and MotionBlockingBlocks
+// for the test blocks and returns a new empty chunk.
+func withTestBlocks(t testing.TB) *Chunk {
+	light, filtering, motionBlocking := LightBlocks, FilteringBlocks, MotionBlockingBlocks
+	t.Cleanup(func() {
+		LightBlocks, FilteringBlocks, MotionBlockingBlocks = light, filtering, motionBlocking
+	})
+	LightBlocks = []uint8{testAir: 0, testStone: 0, testFlower: 0, testLantern: 15}
+	FilteringBlocks = []uint8{testAir: 0, testStone: 15, testFlower: 0, testLantern: 0}
+	MotionBlockingBlocks = []bool{testAir: false, testStone: true, testFlower: false, testLantern: true}
+	return New(testAir, testRange)
+}
+
+// checkHeights checks the heights returned by the height maps of the chunk
+// at x = 0 and z = 0.
+func checkHeights(t *testing.T, c *Chunk, highest, motionBlocking, lightBlocking int16) {
+	t.Helper()
+	if y := c.HighestBlock(0, 0); y != highest {
+		t.Errorf("expected highest block at %v, got %v", highest, y)
+	}
+	if y := c.HighestMotionBlocking(0, 0); y != motionBlocking {
+		t.Errorf("expected highest motion blocking block at %v, got %v", motionBlocking, y)
+	}
+	if y := c.HeightMap().At(0, 0); y != lightBlocking {
+		t.Errorf("expected height map value %v, got %v", lightBlocking, y)
+	}
+}
+
+func TestHeightMapSetAtTop(t *testing.T) {
+	c := withTestBlocks(t)
+	top := int16(testRange[1])
+	checkHeights(t, c, int16(testRange[0]), int16(testRange[0]), int16(testRange[0]))
+
+	c.SetBlock(0, top, 0, 0, testStone)
+	checkHeights(t, c, top, top, top+1)
+
+	c.SetBlock(0, top, 0, 0, testFlower)
+	checkHeights(t, c, top, int16(testRange[0]), int16(testRange[0]))
+}
+
+func TestHeightMapRemoveAtTop(t *testing.T) {
+	c := withTestBlocks(t)
+	top := int16(testRange[1])
+	c.SetBlock(0, 10, 0, 0, testStone)
+	c.SetBlock(0, 11, 0, 0, testFlower)
+	c.SetBlock(0, top, 0, 0, testStone)
+	checkHeights(t, c, top, top, top+1)
+
+	c.SetBlock(0, top, 0, 0, testAir)
+	checkHeights(t, c, 11, 10, 11)
+
+	c.SetBlock(0, 11, 0, 0, testAir)
+	checkHeights(t, c, 10, 10, 11)
+
+	c.SetBlock(0, 10, 0, 0, testAir)
+	checkHeights(t, c, int16(testRange[0]), int16(testRange[0]), int16(testRange[0]))
+}
+
+func TestInvalidateHeightMaps(t *testing.T) {
+	c := withTestBlocks(t)
+	checkHeights(t, c, int16(testRange[0]), int16(testRange[0]), int16(testRange[0]))
+
+	// Setting blocks directly in a sub chunk bypasses the incremental updates
+	// of the height maps, so they remain outdated until invalidated.
+	c.SubChunk(20).Layer(0).Set(0, 20&15, 0, testStone)
+	checkHeights(t, c, int16(testRange[0]), int16(testRange[0]), int16(testRange[0]))
+
+	c.InvalidateHeightMaps()
+	checkHeights(t, c, 20, 20, 21)
+}
@@ -7,7 +7,7 @@ import (
 
 // insertBlockLightNodes iterates over the chunk and looks for blocks that have a light level of at least 1.
 // If one is found, a node is added for it to the node queue.
-func (a *lightArea) insertBlockLightNodes(queue *list.List) {
+func (a *Area) insertBlockLightNodes(queue *list.List) {
 	a.iterSubChunks(anyLightBlocks, func(pos cube.Pos) {
 		if level := a.highest(pos, LightBlocks); level > 0 {
 			queue.PushBack(node(pos, level, BlockLight))
@@ -29,20 +29,14 @@ func anyLightBlocks(sub *SubChunk) bool {
 
 // insertSkyLightNodes iterates over the chunk and inserts a light node anywhere at the highest block in the
 // chunk. In addition, any skylight above those nodes will be set to 15.
-func (a *lightArea) insertSkyLightNodes(queue *list.List) {
-	a.iterHeightmap(func(x, z int, height, highestNeighbour, highestY, lowestY int) {
+func (a *Area) insertSkyLightNodes(queue *list.List) {
+	a.iterHeightmap(func(x, z int, height, highestNeighbour, highestY int) {
 		pos := cube.Pos{x, height, z}
 		if height <= a.r.Max() {
-			// Only set light if we're not at the top of the world.
-			a.setLight(pos, SkyLight, 15)
-
-			if pos[1] > lowestY {
-				if level := a.highest(pos.Sub(cube.Pos{0, 1}), FilteringBlocks); level != 15 && level != 0 {
-					// If we hit a block like water or leaves (something that diffuses but does not block light), we
-					// need a node above this block regardless of the neighbours.
-					queue.PushBack(node(pos, 15, SkyLight))
-				}
-			}
+			// Only insert a node if we're not at the top of the world. The node is needed regardless of the
+			// neighbours, so that light spreads down into blocks like water or leaves (which diffuse but do not
+			// block light) below it, and sideways below the height map of neighbouring columns.
+			queue.PushBack(node(pos, 15, SkyLight))
 		}
 		for y := pos[1]; y < highestY; y++ {
 			// We can do a bit of an optimisation here: We don't need to insert nodes if the neighbours are
@@ -60,7 +54,7 @@ func (a *lightArea) insertSkyLightNodes(queue *list.List) {
 
 // insertLightSpreadingNodes inserts light nodes into the node queue passed which, when propagated, will
 // spread into the neighbouring chunks.
-func (a *lightArea) insertLightSpreadingNodes(queue *list.List, lt light) {
+func (a *Area) insertLightSpreadingNodes(queue *list.List, lt light) {
 	a.iterEdges(a.nodesNeeded(lt), func(pa, pb cube.Pos) {
 		la, lb := a.light(pa, lt), a.light(pb, lt)
 		if la == lb || la-1 == lb || lb-1 == la {
@@ -77,7 +71,7 @@ func (a *lightArea) insertLightSpreadingNodes(queue *list.List, lt light) {
 
 // nodesNeeded checks if any light nodes of a specific light type are needed between two neighbouring SubChunks when
 // spreading light between them.
-func (a *lightArea) nodesNeeded(lt light) func(sa, sb *SubChunk) bool {
+func (a *Area) nodesNeeded(lt light) func(sa, sb *SubChunk) bool {
 	if lt == SkyLight {
 		return func(sa, sb *SubChunk) bool {
 			return &sa.skyLight[0] != &sb.skyLight[0]
@@ -89,9 +83,9 @@ func (a *lightArea) nodesNeeded(lt light) func(sa, sb *SubChunk) bool {
 	}
 }
 
-// propagate spreads the next light node in the node queue passed through the lightArea a. propagate adds the neighbours
+// propagate spreads the next light node in the node queue passed through the Area a. propagate adds the neighbours
 // of the node to the queue for as long as it is able to spread.
-func (a *lightArea) propagate(queue *list.List) {
+func (a *Area) propagate(queue *list.List) {
 	n := queue.Remove(queue.Front()).(lightNode)
 	if a.light(n.pos, n.lt) >= n.level {
 		return
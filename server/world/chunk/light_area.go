@@ -8,27 +8,28 @@ import (
 	"math"
 )
 
-// lightArea represents a square area of N*N chunks. It is used for light calculation specifically.
-type lightArea struct {
+// Area represents a square area of N*N chunks. It is used for light calculation specifically.
+type Area struct {
 	baseX, baseZ int
 	c            []*Chunk
 	w            int
 	r            cube.Range
 }
 
-// LightArea creates a lightArea with the lower corner of the lightArea at baseX and baseY. The length of the Chunk
-// slice must be a square of a number, so 1, 4, 9 etc.
-func LightArea(c []*Chunk, baseX, baseY int) *lightArea {
+// LightArea creates an Area with the lower corner of the Area at baseX and baseY. The length of the Chunk
+// slice must be a square of a number, so 1, 4, 9 etc. Chunks in the slice may be nil if they are not loaded, in
+// which case light does not spread into them, but the Chunk in the centre of the Area must not be nil.
+func LightArea(c []*Chunk, baseX, baseY int) *Area {
 	w := int(math.Sqrt(float64(len(c))))
 	if len(c) != w*w {
 		panic("area must have a square chunk area")
 	}
-	return &lightArea{c: c, w: w, baseX: baseX << 4, baseZ: baseY << 4, r: c[0].r}
+	return &Area{c: c, w: w, baseX: baseX << 4, baseZ: baseY << 4, r: c[len(c)/2].r}
 }
 
-// Fill executes the light 'filling' stage, where the lightArea is filled with light coming only from the
-// individual chunks within the lightArea itself, without light crossing chunk borders.
-func (a *lightArea) Fill() {
+// Fill executes the light 'filling' stage, where the Area is filled with light coming only from the
+// individual chunks within the Area itself, without light crossing chunk borders.
+func (a *Area) Fill() {
 	a.initialiseLightSlices()
 	queue := list.New()
 	a.insertBlockLightNodes(queue)
@@ -39,10 +40,10 @@ func (a *lightArea) Fill() {
 	}
 }
 
-// Spread executes the light 'spreading' stage, where the lightArea has light spread from every Chunk into the
+// Spread executes the light 'spreading' stage, where the Area has light spread from every Chunk into the
 // neighbouring chunks. The neighbouring chunks must have passed the light 'filling' stage before this
-// function is called for an lightArea that includes them.
-func (a *lightArea) Spread() {
+// function is called for an Area that includes them.
+func (a *Area) Spread() {
 	queue := list.New()
 	a.insertLightSpreadingNodes(queue, BlockLight)
 	a.insertLightSpreadingNodes(queue, SkyLight)
@@ -53,22 +54,22 @@ func (a *lightArea) Spread() {
 }
 
 // light returns the light at a cube.Pos with the light type l.
-func (a *lightArea) light(pos cube.Pos, l light) uint8 {
+func (a *Area) light(pos cube.Pos, l light) uint8 {
 	return l.light(a.sub(pos), uint8(pos[0]&0xf), uint8(pos[1]&0xf), uint8(pos[2]&0xf))
 }
 
 // light sets the light at a cube.Pos with the light type l.
-func (a *lightArea) setLight(pos cube.Pos, l light, v uint8) {
+func (a *Area) setLight(pos cube.Pos, l light, v uint8) {
 	l.setLight(a.sub(pos), uint8(pos[0]&0xf), uint8(pos[1]&0xf), uint8(pos[2]&0xf), v)
 }
 
 // neighbours returns all neighbour lightNode of the one passed. If one of these nodes would otherwise fall outside the
-// lightArea, it is not returned.
-func (a *lightArea) neighbours(n lightNode) iter.Seq[lightNode] {
+// Area, it is not returned.
+func (a *Area) neighbours(n lightNode) iter.Seq[lightNode] {
 	return func(yield func(lightNode) bool) {
 		for _, f := range cube.Faces() {
 			nn := lightNode{pos: n.pos.Side(f), lt: n.lt}
-			if nn.pos[1] <= a.r.Max() && nn.pos[1] >= a.r.Min() && nn.pos[0] >= a.baseX && nn.pos[2] >= a.baseZ && nn.pos[0] < a.baseX+a.w*16 && nn.pos[2] < a.baseZ+a.w*16 {
+			if nn.pos[1] <= a.r.Max() && nn.pos[1] >= a.r.Min() && nn.pos[0] >= a.baseX && nn.pos[2] >= a.baseZ && nn.pos[0] < a.baseX+a.w*16 && nn.pos[2] < a.baseZ+a.w*16 && a.chunk(nn.pos) != nil {
 				if !yield(nn) {
 					return
 				}
@@ -77,9 +78,9 @@ func (a *lightArea) neighbours(n lightNode) iter.Seq[lightNode] {
 	}
 }
 
-// iterSubChunks iterates over all blocks of the lightArea on a per-SubChunk basis. A filter function may be passed to
+// iterSubChunks iterates over all blocks of the Area on a per-SubChunk basis. A filter function may be passed to
 // specify if a SubChunk should be iterated over. If it returns false, it will not be iterated over.
-func (a *lightArea) iterSubChunks(filter func(sub *SubChunk) bool, f func(pos cube.Pos)) {
+func (a *Area) iterSubChunks(filter func(sub *SubChunk) bool, f func(pos cube.Pos)) {
 	for cx := 0; cx < a.w; cx++ {
 		for cz := 0; cz < a.w; cz++ {
 			baseX, baseZ, c := a.baseX+(cx<<4), a.baseZ+(cz<<4), a.c[a.chunkIndex(cx, cz)]
@@ -97,9 +98,9 @@ func (a *lightArea) iterSubChunks(filter func(sub *SubChunk) bool, f func(pos cu
 	}
 }
 
-// iterEdges iterates over all chunk edges within the lightArea and calls the function f with the cube.Pos at either
+// iterEdges iterates over all chunk edges within the Area and calls the function f with the cube.Pos at either
 // side of the edge.
-func (a *lightArea) iterEdges(filter func(a, b *SubChunk) bool, f func(a, b cube.Pos)) {
+func (a *Area) iterEdges(filter func(a, b *SubChunk) bool, f func(a, b cube.Pos)) {
 	minY, maxY := a.r[0]>>4, a.r[1]>>4
 	// First iterate over chunk X, Y and Z, so we can filter out a complete 16x16 sheet of blocks if the
 	// filter function returns false.
@@ -107,7 +108,7 @@ func (a *lightArea) iterEdges(filter func(a, b *SubChunk) bool, f func(a, b cube
 		u := cu << 4
 		for cv := 0; cv < a.w; cv++ {
 			v := cv << 4
-			for cy := minY; cy < maxY; cy++ {
+			for cy := minY; cy <= maxY; cy++ {
 				baseY := cy << 4
 
 				xa, za := cube.Pos{a.baseX + u, baseY, a.baseZ + v}, cube.Pos{a.baseX + v, baseY, a.baseZ + u}
@@ -135,11 +136,10 @@ func (a *lightArea) iterEdges(filter func(a, b *SubChunk) bool, f func(a, b cube
 	}
 }
 
-// iterHeightmap iterates over the height map of the lightArea and calls the function f with the height map value, the
+// iterHeightmap iterates over the height map of the Area and calls the function f with the height map value, the
 // height map value of the highest neighbour and the Y value of the highest non-empty SubChunk.
-func (a *lightArea) iterHeightmap(f func(x, z int, height, highestNeighbour, highestY, lowestY int)) {
+func (a *Area) iterHeightmap(f func(x, z int, height, highestNeighbour, highestY int)) {
 	m, highestY := a.c[0].HeightMap(), a.c[0].Range().Min()
-	lowestY := highestY
 	for index := range a.c[0].sub {
 		if a.c[0].sub[index].Empty() {
 			continue
@@ -148,14 +148,14 @@ func (a *lightArea) iterHeightmap(f func(x, z int, height, highestNeighbour, hig
 	}
 	for x := uint8(0); x < 16; x++ {
 		for z := uint8(0); z < 16; z++ {
-			f(int(x)+a.baseX, int(z)+a.baseZ, int(m.At(x, z)), int(m.HighestNeighbour(x, z)), highestY, lowestY)
+			f(int(x)+a.baseX, int(z)+a.baseZ, int(m.At(x, z)), int(m.HighestNeighbour(x, z)), highestY)
 		}
 	}
 }
 
 // iterSubChunk iterates over the coordinates of a SubChunk (0-15 on all axes) and calls the function f for each of
 // those coordinates.
-func (a *lightArea) iterSubChunk(f func(x, y, z int)) {
+func (a *Area) iterSubChunk(f func(x, y, z int)) {
 	for y := 0; y < 16; y++ {
 		for x := 0; x < 16; x++ {
 			for z := 0; z < 16; z++ {
@@ -167,7 +167,7 @@ func (a *lightArea) iterSubChunk(f func(x, y, z int)) {
 
 // highest looks up through the blocks at first and second layer at the cube.Pos passed and runs their runtime IDs
 // through the slice m passed, finding the highest value in this slice between those runtime IDs and returning it.
-func (a *lightArea) highest(pos cube.Pos, m []uint8) uint8 {
+func (a *Area) highest(pos cube.Pos, m []uint8) uint8 {
 	x, y, z, sub := uint8(pos[0]&0xf), uint8(pos[1]&0xf), uint8(pos[2]&0xf), a.sub(pos)
 	storages, l := sub.storages, len(sub.storages)
 
@@ -195,7 +195,7 @@ var (
 // initialiseLightSlices initialises all light slices in the sub chunks of all chunks either with full light if there is
 // no sub chunk with any blocks above it, or with empty light if there is. The sub chunks with empty light are then
 // ready to be properly calculated.
-func (a *lightArea) initialiseLightSlices() {
+func (a *Area) initialiseLightSlices() {
 	for _, c := range a.c {
 		index := len(c.sub) - 1
 		for index >= 0 {
@@ -219,17 +219,17 @@ func (a *lightArea) initialiseLightSlices() {
 }
 
 // sub returns the SubChunk corresponding to a cube.Pos.
-func (a *lightArea) sub(pos cube.Pos) *SubChunk {
+func (a *Area) sub(pos cube.Pos) *SubChunk {
 	return a.chunk(pos).SubChunk(int16(pos[1]))
 }
 
 // chunk returns the Chunk corresponding to a cube.Pos.
-func (a *lightArea) chunk(pos cube.Pos) *Chunk {
+func (a *Area) chunk(pos cube.Pos) *Chunk {
 	x, z := pos[0]-a.baseX, pos[2]-a.baseZ
 	return a.c[a.chunkIndex(x>>4, z>>4)]
 }
 
-// chunkIndex finds the index in the chunk slice of an lightArea for a Chunk at a specific x and z.
-func (a *lightArea) chunkIndex(x, z int) int {
+// chunkIndex finds the index in the chunk slice of an Area for a Chunk at a specific x and z.
+func (a *Area) chunkIndex(x, z int) int {
 	return x + (z * a.w)
 }
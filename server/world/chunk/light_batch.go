@@ -0,0 +1,71 @@
+package chunk
+
+import (
+	"sync"
+)
+
+// FillAll executes the light 'filling' stage for all Areas passed, using up to workers goroutines at
+// once. The areas passed must not share any chunks, so that they may be filled at the same time. FillAll
+// returns once all areas are filled.
+func FillAll(areas []*Area, workers int) {
+	parallel(areas, workers, (*Area).Fill)
+}
+
+// SpreadAll executes the light 'spreading' stage for all Areas passed, using up to workers goroutines at
+// once. Unlike FillAll, the areas passed may share chunks: Areas are spread in phases, so that areas spread at
+// the same time never overlap. All areas passed must have the same size, and no two areas may be centred
+// around the same chunk. The chunks in the areas must have passed the light 'filling' stage. SpreadAll
+// returns once light was spread in all areas.
+func SpreadAll(areas []*Area, workers int) {
+	if len(areas) == 0 {
+		return
+	}
+	w := areas[0].w
+	// Areas whose centres are at least w chunks apart on either axis cannot
+	// overlap, so areas are grouped by the position of their centre modulo w.
+	phases := make([][]*Area, w*w)
+	for _, a := range areas {
+		if a.w != w {
+			panic("spread all: areas must have the same size")
+		}
+		cx, cz := floorMod(a.baseX>>4, w), floorMod(a.baseZ>>4, w)
+		phases[cx*w+cz] = append(phases[cx*w+cz], a)
+	}
+	for _, phase := range phases {
+		parallel(phase, workers, (*Area).Spread)
+	}
+}
+
+// parallel calls f for all Areas passed, using up to workers goroutines at once, and waits for all
+// calls to return.
+func parallel(areas []*Area, workers int, f func(a *Area)) {
+	if workers <= 1 || len(areas) <= 1 {
+		for _, a := range areas {
+			f(a)
+		}
+		return
+	}
+	var (
+		wg   sync.WaitGroup
+		next = make(chan *Area)
+	)
+	for range min(workers, len(areas)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for a := range next {
+				f(a)
+			}
+		}()
+	}
+	for _, a := range areas {
+		next <- a
+	}
+	close(next)
+	wg.Wait()
+}
+
+// floorMod returns a modulo b, which is always positive for positive b.
+func floorMod(a, b int) int {
+	return ((a % b) + b) % b
+}
@@ -0,0 +1,69 @@
+package chunk
+
+import (
+	"testing"
+)
+
+// lightBenchmarkSize is the width of the square of chunks lit in the light
+// benchmarks, which amounts to 1024 chunks.
+const lightBenchmarkSize = 32
+
+// lightBenchmarkChunks returns a square of chunks with stone terrain up to about
+// y=0 and a lantern above the terrain in every chunk.
+func lightBenchmarkChunks(b *testing.B) []*Chunk {
+	withTestBlocks(b)
+	chunks := make([]*Chunk, lightBenchmarkSize*lightBenchmarkSize)
+	for i := range chunks {
+		c := New(testAir, testRange)
+		for x := uint8(0); x < 16; x++ {
+			for z := uint8(0); z < 16; z++ {
+				// Vary the height of the terrain so that sky light spreads
+				// sideways too.
+				for y := int16(-64); y < int16(x^z)%8; y++ {
+					c.SetBlock(x, y, z, 0, testStone)
+				}
+			}
+		}
+		c.SetBlock(8, 64, 8, 0, testLantern)
+		chunks[i] = c
+	}
+	return chunks
+}
+
+// benchmarkLight benchmarks filling and spreading light in 1024 chunks using
+// the number of workers passed.
+func benchmarkLight(b *testing.B, workers int) {
+	chunks := lightBenchmarkChunks(b)
+	fill := make([]*Area, 0, len(chunks))
+	spread := make([]*Area, 0, len(chunks))
+	for x := range lightBenchmarkSize {
+		for z := range lightBenchmarkSize {
+			fill = append(fill, LightArea([]*Chunk{chunks[x+z*lightBenchmarkSize]}, x, z))
+			if x == 0 || z == 0 || x == lightBenchmarkSize-1 || z == lightBenchmarkSize-1 {
+				// Like in the world, light is only spread from chunks of which
+				// all neighbours are present.
+				continue
+			}
+			neighbours := make([]*Chunk, 0, 9)
+			for dz := -1; dz <= 1; dz++ {
+				for dx := -1; dx <= 1; dx++ {
+					neighbours = append(neighbours, chunks[x+dx+(z+dz)*lightBenchmarkSize])
+				}
+			}
+			spread = append(spread, LightArea(neighbours, x-1, z-1))
+		}
+	}
+
+	for b.Loop() {
+		FillAll(fill, workers)
+		SpreadAll(spread, workers)
+	}
+}
+
+func BenchmarkLight1024ChunksSerial(b *testing.B) {
+	benchmarkLight(b, 1)
+}
+
+func BenchmarkLight1024ChunksWorkers(b *testing.B) {
+	benchmarkLight(b, 8)
+}
@@ -14,6 +14,9 @@ var (
 	// FilteringBlocks is a map for checking if a block runtime ID filters light, and if so, how many levels.
 	// Light is able to propagate through these blocks, but will have its level reduced.
 	FilteringBlocks = make([]uint8, 0, 7000)
+	// MotionBlockingBlocks is a list indexed by block runtime IDs that holds true if a block blocks motion,
+	// meaning it has a collision box or is a liquid. It is used to maintain the motion blocking height map.
+	MotionBlockingBlocks = make([]bool, 0, 7000)
 )
 
 type (
@@ -0,0 +1,119 @@
+package chunk
+
+import (
+	"container/list"
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// Update updates the light in the Area after the block at the cube.Pos passed was changed, so that it
+// matches the light that the Fill and Spread stages would have produced. heightBefore is the value of the
+// height map in the column of the block before it was changed. Light that can no longer reach blocks because
+// of the change is removed, after which light is spread again from the edges of the area that it was removed
+// from and from the changed block itself. The block must be in the Chunk at the centre of the Area, and
+// the area should be 3x3 chunks, so that all blocks that light could spread to are in it.
+func (a *Area) Update(pos cube.Pos, heightBefore int) {
+	x, z := uint8(pos[0]&0xf), uint8(pos[2]&0xf)
+	heightAfter := int(a.chunk(pos).HeightMap().At(x, z))
+
+	a.update(BlockLight, []cube.Pos{pos}, nil)
+
+	// If the height map changed, the blocks between the old and new height either lost or gained direct
+	// access to the sky.
+	removed, lit := []cube.Pos{pos}, []cube.Pos(nil)
+	for y := heightBefore; y < heightAfter && y <= a.r.Max(); y++ {
+		removed = append(removed, cube.Pos{pos[0], y, pos[2]})
+	}
+	for y := heightAfter; y < heightBefore && y <= a.r.Max(); y++ {
+		lit = append(lit, cube.Pos{pos[0], y, pos[2]})
+	}
+	a.update(SkyLight, removed, lit)
+}
+
+// update removes the light of type lt from the positions in removed and from any blocks that it spread to,
+// and then sets full light at the positions in lit and spreads light again into the blocks that light was
+// removed from.
+func (a *Area) update(lt light, removed, lit []cube.Pos) {
+	decrease, increase := list.New(), list.New()
+	for _, pos := range removed {
+		if level := a.light(pos, lt); level > 0 {
+			a.setLight(pos, lt, 0)
+			decrease.PushBack(node(pos, level, lt))
+		}
+	}
+	for decrease.Len() != 0 {
+		a.removeLight(decrease, increase)
+	}
+
+	for _, pos := range lit {
+		a.setLight(pos, lt, 15)
+		increase.PushBack(node(pos, 15, lt))
+	}
+	for _, pos := range removed {
+		// The blocks themselves might still emit light or be lit by the sky, and their neighbours may spread
+		// light into them again.
+		if level := a.source(pos, lt); level > a.light(pos, lt) {
+			a.setLight(pos, lt, level)
+			increase.PushBack(node(pos, level, lt))
+		}
+		for neighbour := range a.neighbours(node(pos, 0, lt)) {
+			increase.PushBack(neighbour)
+		}
+	}
+	for increase.Len() != 0 {
+		a.spreadLight(increase)
+	}
+}
+
+// removeLight removes the light of the next light node in the queue passed from all neighbours that might have
+// been lit by it. Neighbours that are lit by other sources are added to the increase queue, so that light may
+// be spread from them again.
+func (a *Area) removeLight(queue, increase *list.List) {
+	n := queue.Remove(queue.Front()).(lightNode)
+	for neighbour := range a.neighbours(n) {
+		level := a.light(neighbour.pos, n.lt)
+		switch {
+		case level == 0:
+			continue
+		case level >= n.level:
+			// The neighbour has at least as much light as the node, so it must have been lit by another
+			// source.
+			increase.PushBack(neighbour)
+			continue
+		}
+		a.setLight(neighbour.pos, n.lt, 0)
+		neighbour.level = level
+		queue.PushBack(neighbour)
+
+		if source := a.source(neighbour.pos, n.lt); source > 0 {
+			a.setLight(neighbour.pos, n.lt, source)
+			increase.PushBack(node(neighbour.pos, source, n.lt))
+		}
+	}
+}
+
+// spreadLight spreads the light currently present at the position of the next light node in the queue passed
+// into its neighbours. Unlike propagate, spreadLight expects the light of the node to already be set.
+func (a *Area) spreadLight(queue *list.List) {
+	n := queue.Remove(queue.Front()).(lightNode)
+	level := a.light(n.pos, n.lt)
+	for neighbour := range a.neighbours(n) {
+		filter := a.highest(neighbour.pos, FilteringBlocks) + 1
+		if level > filter && a.light(neighbour.pos, n.lt) < level-filter {
+			a.setLight(neighbour.pos, n.lt, level-filter)
+			queue.PushBack(neighbour)
+		}
+	}
+}
+
+// source returns the level of light of type lt that the block at the position passed receives regardless of
+// its neighbours. For block light, this is the light emitted by the block. For sky light, this is full light if
+// the block is at or above the height map of its column.
+func (a *Area) source(pos cube.Pos, lt light) uint8 {
+	if lt == BlockLight {
+		return a.highest(pos, LightBlocks)
+	}
+	if int16(pos[1]) >= a.chunk(pos).HeightMap().At(uint8(pos[0]&0xf), uint8(pos[2]&0xf)) {
+		return 15
+	}
+	return 0
+}
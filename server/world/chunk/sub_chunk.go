@@ -1,5 +1,7 @@
 package chunk
 
+import "slices"
+
 // SubChunk is a cube of blocks located in a chunk. It has a size of 16x16x16 blocks and forms part of a stack
 // that forms a Chunk.
 type SubChunk struct {
@@ -29,6 +31,21 @@ func NewSubChunk(air uint32) *SubChunk {
 	return &SubChunk{air: air}
 }
 
+// Clone returns a deep copy of the SubChunk, including its block storages and
+// light data.
+func (sub *SubChunk) Clone() *SubChunk {
+	c := &SubChunk{
+		air:        sub.air,
+		storages:   make([]*PalettedStorage, len(sub.storages)),
+		blockLight: slices.Clone(sub.blockLight),
+		skyLight:   slices.Clone(sub.skyLight),
+	}
+	for i, storage := range sub.storages {
+		c.storages[i] = storage.Clone()
+	}
+	return c
+}
+
 // Empty checks if the SubChunk is considered empty. This is the case if the SubChunk has 0 block storages or if it has
 // a single one that is completely filled with air.
 func (sub *SubChunk) Empty() bool {
@@ -0,0 +1,238 @@
+package world
+
+import (
+	"bytes"
+	"compress/flate"
+	"container/list"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"io"
+	"sync"
+)
+
+// ColumnCacheStats holds statistics of the cache of recently unloaded columns
+// of a World. They may be used to tune Config.ColumnCacheSize.
+type ColumnCacheStats struct {
+	// Hits is the amount of times a chunk was loaded from the cache instead
+	// of the Provider of the World.
+	Hits uint64
+	// Misses is the amount of times a chunk was not found in the cache and
+	// had to be loaded from the Provider of the World.
+	Misses uint64
+	// Columns is the amount of columns currently held in the cache.
+	Columns int
+	// Size is the amount of bytes taken up by the columns currently held in
+	// the cache.
+	Size int
+}
+
+// columnCache is a least recently used cache of columns that were unloaded
+// from a World. Columns are held in a compressed form and the cache never
+// holds more bytes than its budget. columnCache is safe for concurrent use.
+type columnCache struct {
+	budget int
+	r      cube.Range
+
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[ChunkPos]*list.Element
+	hits    uint64
+	misses  uint64
+}
+
+// cachedColumn is an entry in a columnCache.
+type cachedColumn struct {
+	pos  ChunkPos
+	data []byte
+}
+
+// newColumnCache creates a columnCache that holds up to budget bytes of
+// columns with the cube.Range passed. If budget is 0 or lower, nil is
+// returned, which is a columnCache that never holds any columns.
+func newColumnCache(budget int, r cube.Range) *columnCache {
+	if budget <= 0 {
+		return nil
+	}
+	return &columnCache{budget: budget, r: r, order: list.New(), entries: make(map[ChunkPos]*list.Element)}
+}
+
+// put compresses the chunk.Column passed and adds it to the cache, replacing
+// any column already cached at the same position. The least recently used
+// columns are removed from the cache until it fits within its budget.
+func (cache *columnCache) put(pos ChunkPos, col *chunk.Column) error {
+	if cache == nil {
+		return nil
+	}
+	data, err := encodeCachedColumn(col)
+	if err != nil {
+		return fmt.Errorf("encode cached column: %w", err)
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.remove(pos)
+	if len(data) > cache.budget {
+		return nil
+	}
+	cache.entries[pos] = cache.order.PushFront(cachedColumn{pos: pos, data: data})
+	cache.size += len(data)
+	for cache.size > cache.budget {
+		cache.remove(cache.order.Back().Value.(cachedColumn).pos)
+	}
+	return nil
+}
+
+// take removes the column at the position passed from the cache and returns
+// it. False is returned if the cache did not hold a column at that position.
+func (cache *columnCache) take(pos ChunkPos) (*chunk.Column, bool, error) {
+	if cache == nil {
+		return nil, false, nil
+	}
+	cache.mu.Lock()
+	e, ok := cache.entries[pos]
+	if !ok {
+		cache.misses++
+		cache.mu.Unlock()
+		return nil, false, nil
+	}
+	cache.hits++
+	cache.remove(pos)
+	cache.mu.Unlock()
+
+	col, err := decodeCachedColumn(e.Value.(cachedColumn).data, cache.r)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode cached column: %w", err)
+	}
+	return col, true, nil
+}
+
+// remove removes the column at the position passed from the cache, if it
+// holds one. cache.mu must be held while calling remove.
+func (cache *columnCache) remove(pos ChunkPos) {
+	if e, ok := cache.entries[pos]; ok {
+		cache.size -= len(e.Value.(cachedColumn).data)
+		cache.order.Remove(e)
+		delete(cache.entries, pos)
+	}
+}
+
+// stats returns the ColumnCacheStats of the cache.
+func (cache *columnCache) stats() ColumnCacheStats {
+	if cache == nil {
+		return ColumnCacheStats{}
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return ColumnCacheStats{Hits: cache.hits, Misses: cache.misses, Columns: len(cache.entries), Size: cache.size}
+}
+
+// encodedColumn is the NBT representation of a chunk.Column held by a
+// columnCache.
+type encodedColumn struct {
+	SubChunks       [][]byte
+	Biomes          []byte
+	Entities        []encodedEntity
+	BlockEntities   []encodedBlockEntity
+	Tick            int64
+	ScheduledBlocks []encodedScheduledUpdate
+	Undecorated     bool
+}
+
+type encodedEntity struct {
+	ID   int64
+	Data map[string]any
+}
+
+type encodedBlockEntity struct {
+	Pos  [3]int32
+	Data map[string]any
+}
+
+type encodedScheduledUpdate struct {
+	Pos   [3]int32
+	Block int32
+	Tick  int64
+}
+
+// encodeCachedColumn encodes a chunk.Column to NBT and compresses it.
+func encodeCachedColumn(col *chunk.Column) ([]byte, error) {
+	data := chunk.Encode(col.Chunk, chunk.DiskEncoding)
+	c := encodedColumn{
+		SubChunks:       data.SubChunks,
+		Biomes:          data.Biomes,
+		Entities:        make([]encodedEntity, 0, len(col.Entities)),
+		BlockEntities:   make([]encodedBlockEntity, 0, len(col.BlockEntities)),
+		Tick:            col.Tick,
+		ScheduledBlocks: make([]encodedScheduledUpdate, 0, len(col.ScheduledBlocks)),
+		Undecorated:     col.Undecorated,
+	}
+	for _, e := range col.Entities {
+		c.Entities = append(c.Entities, encodedEntity{ID: e.ID, Data: e.Data})
+	}
+	for _, be := range col.BlockEntities {
+		c.BlockEntities = append(c.BlockEntities, encodedBlockEntity{Pos: posToInt32(be.Pos), Data: be.Data})
+	}
+	for _, t := range col.ScheduledBlocks {
+		c.ScheduledBlocks = append(c.ScheduledBlocks, encodedScheduledUpdate{Pos: posToInt32(t.Pos), Block: int32(t.Block), Tick: t.Tick})
+	}
+	b, err := nbt.MarshalEncoding(c, nbt.LittleEndian)
+	if err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, len(b)/4))
+	w, _ := flate.NewWriter(buf, flate.BestSpeed)
+	_, _ = w.Write(b)
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return bytes.Clone(buf.Bytes()), nil
+}
+
+// decodeCachedColumn decompresses and decodes a chunk.Column encoded using
+// encodeCachedColumn.
+func decodeCachedColumn(b []byte, r cube.Range) (*chunk.Column, error) {
+	b, err := io.ReadAll(flate.NewReader(bytes.NewReader(b)))
+	if err != nil {
+		return nil, err
+	}
+	var c encodedColumn
+	if err := nbt.UnmarshalEncoding(b, &c, nbt.LittleEndian); err != nil {
+		return nil, err
+	}
+	ch, err := chunk.DiskDecode(chunk.SerialisedData{SubChunks: c.SubChunks, Biomes: c.Biomes}, r)
+	if err != nil {
+		return nil, err
+	}
+	col := &chunk.Column{
+		Chunk:           ch,
+		Entities:        make([]chunk.Entity, 0, len(c.Entities)),
+		BlockEntities:   make([]chunk.BlockEntity, 0, len(c.BlockEntities)),
+		Tick:            c.Tick,
+		ScheduledBlocks: make([]chunk.ScheduledBlockUpdate, 0, len(c.ScheduledBlocks)),
+		Undecorated:     c.Undecorated,
+	}
+	for _, e := range c.Entities {
+		col.Entities = append(col.Entities, chunk.Entity{ID: e.ID, Data: e.Data})
+	}
+	for _, be := range c.BlockEntities {
+		col.BlockEntities = append(col.BlockEntities, chunk.BlockEntity{Pos: posFromInt32(be.Pos), Data: be.Data})
+	}
+	for _, t := range c.ScheduledBlocks {
+		col.ScheduledBlocks = append(col.ScheduledBlocks, chunk.ScheduledBlockUpdate{Pos: posFromInt32(t.Pos), Block: uint32(t.Block), Tick: t.Tick})
+	}
+	return col, nil
+}
+
+// posToInt32 converts a cube.Pos to an array of int32s so that it may be
+// encoded to NBT.
+func posToInt32(pos cube.Pos) [3]int32 {
+	return [3]int32{int32(pos[0]), int32(pos[1]), int32(pos[2])}
+}
+
+// posFromInt32 converts an array of int32s back to a cube.Pos.
+func posFromInt32(pos [3]int32) cube.Pos {
+	return cube.Pos{int(pos[0]), int(pos[1]), int(pos[2])}
+}
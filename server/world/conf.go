@@ -1,8 +1,10 @@
 package world
 
 import (
+	"fmt"
 	"log/slog"
 	"math/rand/v2"
+	"runtime"
 	"time"
 )
 
@@ -10,7 +12,8 @@ import (
 // influence the World.
 type Config struct {
 	// Log is the Logger that will be used to log errors and debug messages to.
-	// If set to nil, slog.Default() is set.
+	// If set to nil, slog.Default() is set. Records logged by the World have
+	// the name and dimension of the World added to them.
 	Log *slog.Logger
 	// Dim is the Dimension of the World. If set to nil, the World will use
 	// Overworld as its dimension. The dimension set here influences, among
@@ -55,6 +58,48 @@ type Config struct {
 	// Entities is an EntityRegistry with all Entity types registered that may
 	// be added to the World.
 	Entities EntityRegistry
+	// Profiling specifies if the time spent ticking every chunk of the World
+	// should be recorded. The timings recorded may be obtained through
+	// World.ChunkProfile. Profiling is disabled by default, in which case it
+	// has no measurable overhead.
+	Profiling bool
+	// Metrics specifies if the World should record metrics such as the amount
+	// of ticks it runs per second and the time spent on them. The metrics
+	// recorded may be obtained through World.TickMetrics. Metrics are not
+	// recorded by default, in which case recording them has no overhead.
+	Metrics bool
+	// IOWorkers is the amount of goroutines that chunks are loaded from and
+	// stored to the Provider on. If set to 0, IOWorkers defaults to 2.
+	IOWorkers int
+	// GenWorkers is the amount of goroutines that new chunks are generated
+	// on. If set to 0, GenWorkers defaults to the amount of CPUs available.
+	// The Generator must be safe for concurrent use if GenWorkers is higher
+	// than 1. Chunks closest to the players in the World are loaded and
+	// generated first. Light of newly loaded chunks is also spread on up to
+	// GenWorkers goroutines.
+	GenWorkers int
+	// Despawn is the DespawnPolicy that decides when mobs that are far away
+	// from all players despawn. Fields of Despawn left empty are set to their
+	// defaults.
+	Despawn DespawnPolicy
+	// SpawnChunkRadius is the radius in chunks around the spawn of the World
+	// that is kept loaded and ticking permanently, even if no players are
+	// close to it. If set to 0, the default, spawn chunks are only loaded
+	// while players are close to them, like any other chunk.
+	SpawnChunkRadius int
+	// UnloadDelay is the time that a chunk stays loaded after it was last
+	// viewed by a player and no longer has any tickets. Chunks are not
+	// ticked during this time. If set to 0, UnloadDelay defaults to 30
+	// seconds. Setting UnloadDelay to a negative value unloads chunks as soon
+	// as they are no longer used.
+	UnloadDelay time.Duration
+	// ColumnCacheSize is the maximum amount of bytes that chunks that were
+	// recently unloaded may take up when kept in memory in a compressed form,
+	// so that loading them again shortly after does not require reading them
+	// from the Provider. If set to 0, the default, no chunks are kept in
+	// memory after unloading. Statistics of the cache may be obtained using
+	// World.ColumnCacheStats.
+	ColumnCacheSize int
 }
 
 // New creates a new World using the Config conf. The World returned will start
@@ -78,14 +123,31 @@ func (conf Config) New() *World {
 	if conf.RandomTickSpeed == 0 {
 		conf.RandomTickSpeed = 3
 	}
+	if conf.IOWorkers <= 0 {
+		conf.IOWorkers = 2
+	}
+	if conf.GenWorkers <= 0 {
+		conf.GenWorkers = runtime.GOMAXPROCS(0)
+	}
+	conf.Despawn = conf.Despawn.withDefaults()
+	if conf.UnloadDelay == 0 {
+		conf.UnloadDelay = time.Second * 30
+	}
 	if conf.RandSource == nil {
 		t := uint64(time.Now().UnixNano())
 		conf.RandSource = rand.NewPCG(t, t)
 	}
 	s := conf.Provider.Settings()
+	// Attributes of the World are bound to the Logger once, so that every
+	// record logged by the World can be attributed to it without further
+	// allocations.
+	conf.Log = conf.Log.With("world", s.Name, "dimension", fmt.Sprint(conf.Dim))
 	w := &World{
 		scheduledUpdates: newScheduledTickQueue(s.CurrentTick),
 		entities:         make(map[*EntityHandle]ChunkPos),
+		entityIndex:      newEntityIndex(),
+		profiler:         newProfiler(conf.Profiling),
+		metrics:          newTickRecorder(conf.Metrics),
 		viewers:          make(map[*Loader]Viewer),
 		chunks:           make(map[ChunkPos]*Column),
 		queueClosing:     make(chan struct{}),
@@ -96,8 +158,15 @@ func (conf Config) New() *World {
 		conf:             conf,
 		ra:               conf.Dim.Range(),
 		set:              s,
+		events:           &EventBus{dim: conf.Dim},
+		loading:          make(map[ChunkPos]*chunkRequest),
+		storing:          make(map[ChunkPos]chan struct{}),
+		failed:           make(map[ChunkPos]*Column),
+		cache:            newColumnCache(conf.ColumnCacheSize, conf.Dim.Range()),
 	}
 	w.weather = weather{w: w}
+	w.io = newWorkerPool("chunk io", conf.IOWorkers, conf.Log)
+	w.gen = newWorkerPool("chunk generation", conf.GenWorkers, conf.Log)
 	var h Handler = NopHandler{}
 	w.handler.Store(&h)
 
@@ -0,0 +1,39 @@
+package world
+
+import "time"
+
+// DespawnPolicy decides when mobs that are far away from all players despawn,
+// so that mobs do not accumulate in areas that no player is near. Mobs that
+// are persistent, such as mobs with a name tag or mobs that picked up items,
+// never despawn.
+type DespawnPolicy struct {
+	// Disabled specifies if mobs never despawn because of their distance to
+	// players.
+	Disabled bool
+	// Distance is the distance in blocks to the closest player beyond which
+	// mobs may despawn. Mobs closer to a player than Distance never despawn.
+	// If 0, a distance of 32 blocks is used.
+	Distance float64
+	// Delay is the time that a mob must stay beyond Distance of all players
+	// before it despawns. If 0, a delay of 30 seconds is used.
+	Delay time.Duration
+	// InstantDistance is the distance in blocks to the closest player beyond
+	// which mobs despawn immediately, regardless of Delay. If 0, a distance
+	// of 128 blocks is used.
+	InstantDistance float64
+}
+
+// withDefaults returns the DespawnPolicy with the defaults set for all fields
+// that are left empty.
+func (p DespawnPolicy) withDefaults() DespawnPolicy {
+	if p.Distance <= 0 {
+		p.Distance = 32
+	}
+	if p.Delay <= 0 {
+		p.Delay = time.Second * 30
+	}
+	if p.InstantDistance <= 0 {
+		p.InstantDistance = 128
+	}
+	return p
+}
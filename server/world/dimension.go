@@ -1,6 +1,7 @@
 package world
 
 import (
+	"fmt"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"time"
 )
@@ -37,6 +38,52 @@ func DimensionID(dim Dimension) (int, bool) {
 	return dimensionReg.LookupID(dim)
 }
 
+// RegisterDimension registers a custom Dimension, so that Worlds with that
+// Dimension can be saved and loaded, and returns the ID assigned to it. IDs
+// are assigned in the order that dimensions are registered in, following the
+// IDs of Overworld, Nether and End. Chunks are stored under the ID of their
+// Dimension, so custom dimensions must always be registered in the same
+// order. The Range of a custom dimension must start and end at the edges of
+// sub chunks. RegisterDimension panics if the Dimension passed was already
+// registered.
+//
+// Clients only know Overworld, Nether and End, so custom dimensions are shown
+// as one of these, which decides the sky and fog that clients render. A custom
+// dimension may implement a ClientDimension() Dimension method to return the
+// dimension it is shown as. If it does not, it is shown as Overworld. The Range
+// of the custom dimension is still used by clients.
+func RegisterDimension(d Dimension) int {
+	if r := d.Range(); r[0]&0xf != 0 || r[1]&0xf != 0xf {
+		panic(fmt.Sprintf("register dimension %v: range %v does not align with sub chunks", d, r))
+	}
+	return dimensionReg.Register(d)
+}
+
+// ClientDimension returns the Dimension that the Dimension passed is shown as
+// to clients, which is always Overworld, Nether or End. Overworld, Nether and
+// End are shown as themselves.
+func ClientDimension(dim Dimension) Dimension {
+	switch dim {
+	case Overworld, Nether, End:
+		return dim
+	}
+	if c, ok := dim.(clientDimension); ok {
+		switch d := c.ClientDimension(); d {
+		case Overworld, Nether, End:
+			return d
+		}
+	}
+	return Overworld
+}
+
+// clientDimension is implemented by custom dimensions that are shown to
+// clients as a Dimension other than Overworld.
+type clientDimension interface {
+	// ClientDimension returns the Dimension that the dimension is shown as to
+	// clients. It must return Overworld, Nether or End.
+	ClientDimension() Dimension
+}
+
 type dimensionRegistry struct {
 	dimensions map[int]Dimension
 	ids        map[Dimension]int
@@ -62,6 +109,20 @@ func (reg *dimensionRegistry) Lookup(id int) (Dimension, bool) {
 	return dim, ok
 }
 
+// Register registers the Dimension passed with the ID following the highest
+// ID registered so far and returns that ID.
+func (reg *dimensionRegistry) Register(dim Dimension) int {
+	if _, ok := reg.ids[dim]; ok {
+		panic(fmt.Sprintf("register dimension %v: already registered", dim))
+	}
+	id := 0
+	for existing := range reg.dimensions {
+		id = max(id, existing+1)
+	}
+	reg.dimensions[id], reg.ids[dim] = dim, id
+	return id
+}
+
 // LookupID looks up the ID that a Dimension was registered with. If not found,
 // false is returned.
 func (reg *dimensionRegistry) LookupID(dim Dimension) (int, bool) {
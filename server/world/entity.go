@@ -52,6 +52,15 @@ type EntityHandle struct {
 	w            *World
 
 	data EntityData
+	// cell is the cell of the entityIndex of the entity's world that the
+	// entity was last indexed in.
+	cell entityCell
+
+	// vehicle is the entity that the entity is riding, or nil if it is not
+	// riding any entity. passengers are the entities riding the entity, in
+	// the order of their seats.
+	vehicle    *EntityHandle
+	passengers []*EntityHandle
 
 	// TODO Handler? Handle world change here?
 }
@@ -98,6 +107,22 @@ func NewEntity(t EntityType, conf EntityConfig) *EntityHandle {
 	return opts.New(t, conf)
 }
 
+// NewEntityFromNBT creates an EntityHandle from NBT data in the format
+// returned by EntityHandle.EncodeNBT, as found in world saves and structures.
+// The EntityType is looked up in the EntityRegistry passed using the
+// "identifier" field of the data. The entity is assigned a new unique ID.
+// False is returned if the identifier is missing or unknown.
+func NewEntityFromNBT(reg EntityRegistry, data map[string]any) (*EntityHandle, bool) {
+	name, _ := data["identifier"].(string)
+	t, ok := reg.Lookup(name)
+	if !ok {
+		return nil, false
+	}
+	id := uuid.New()
+	handle := entityFromData(t, int64(binary.LittleEndian.Uint64(id[8:])), data)
+	return handle, true
+}
+
 // entityFromData reads an entity from the decoded NBT data passed and returns
 // an EntityHandle.
 func entityFromData(t EntityType, id int64, data map[string]any) *EntityHandle {
@@ -189,7 +214,15 @@ func (e *EntityHandle) execWorld(f func(tx *Tx, e Entity), weak bool) bool {
 	// transaction turns out to be invalidated (ret == false), we simply try
 	// again, this time with e.execWorld(f, true) to make this goroutine bypass
 	// any goroutines still awaiting e.cond.
-	ret := e.weakExec(func(tx *Tx) { f(tx, e.mustEntity(tx)) })
+	ret := e.weakExec(func(tx *Tx) {
+		f(tx, e.mustEntity(tx))
+		if e.w == tx.World() {
+			// Entities such as players are usually moved in transactions
+			// outside of world ticks, so they are re-indexed right away.
+			tx.World().entityIndex.move(e)
+			tx.World().entityChanged(e)
+		}
+	})
 	e.cond.L.Unlock()
 
 	if !ret {
@@ -270,6 +303,20 @@ func (e *EntityHandle) decodeNBT(m map[string]any) {
 	e.data.Age = time.Duration(readInt16(m, "Age")) * (time.Second / 20)
 	e.data.FireDuration = time.Duration(readInt16(m, "Fire")) * time.Second / 20
 	e.data.Name, _ = m["NameTag"].(string)
+	if visible, ok := m["CustomNameVisible"].(uint8); ok {
+		e.data.NameHidden = visible == 0
+	}
+}
+
+// EncodeNBT encodes the entity to NBT data, in the format in which entities
+// are stored in world saves. The data holds the name of the EntityType in its
+// "identifier" field. EncodeNBT must only be called from a transaction of the
+// world the entity is in.
+func (e *EntityHandle) EncodeNBT() map[string]any {
+	data := e.encodeNBT()
+	maps.Copy(data, e.t.EncodeNBT(&e.data))
+	data["identifier"] = e.t.EncodeEntity()
+	return data
 }
 
 // encodeNBT encodes the position, velocity, rotation, age, on-fire duration and
@@ -283,16 +330,25 @@ func (e *EntityHandle) encodeNBT() map[string]any {
 		"Fire":    int16(e.data.FireDuration.Seconds() * 20),
 		"Age":     int16(e.data.Age / (time.Second * 20)),
 		"NameTag": e.data.Name,
+		// CustomNameVisible is stored as the inverse of NameHidden, so
+		// that the name tag of entities saved without it is visible.
+		"CustomNameVisible": boolByte(!e.data.NameHidden),
 	}
 }
 
 // EntityData holds data shared by every entity. It is kept in an EntityHandle.
 type EntityData struct {
-	Pos, Vel     mgl64.Vec3
-	Rot          cube.Rotation
-	Name         string
+	Pos, Vel mgl64.Vec3
+	Rot      cube.Rotation
+	Name     string
+	// NameHidden specifies if the name tag of the entity is hidden from
+	// viewers, even if Name is not empty.
+	NameHidden   bool
 	FireDuration time.Duration
 	Age          time.Duration
+	// Metadata holds custom metadata set on the entity, such as cosmetic
+	// flags, that is shown to viewers on top of the state of the entity.
+	Metadata EntityMetadata
 
 	Data any
 }
@@ -366,13 +422,20 @@ type EntityRegistryConfig struct {
 	TNT                func(opts EntitySpawnOpts, fuse time.Duration) *EntityHandle
 	BottleOfEnchanting func(opts EntitySpawnOpts, owner Entity) *EntityHandle
 	Arrow              func(opts EntitySpawnOpts, damage float64, owner Entity, critical, disallowPickup, obtainArrowOnPickup bool, punchLevel int, tip any) *EntityHandle
+	Bee                func(opts EntitySpawnOpts, data map[string]any, hive cube.Pos, target Entity) *EntityHandle
 	Egg                func(opts EntitySpawnOpts, owner Entity) *EntityHandle
+	EndCrystal         func(opts EntitySpawnOpts, showBase bool) *EntityHandle
 	EnderPearl         func(opts EntitySpawnOpts, owner Entity) *EntityHandle
 	Firework           func(opts EntitySpawnOpts, firework Item, owner Entity, sidewaysVelocityMultiplier, upwardsAcceleration float64, attached bool) *EntityHandle
 	LingeringPotion    func(opts EntitySpawnOpts, t any, owner Entity) *EntityHandle
+	Minecart           func(opts EntitySpawnOpts, t any) *EntityHandle
 	Snowball           func(opts EntitySpawnOpts, owner Entity) *EntityHandle
 	SplashPotion       func(opts EntitySpawnOpts, t any, owner Entity) *EntityHandle
 	Lightning          func(opts EntitySpawnOpts) *EntityHandle
+	IronGolem          func(opts EntitySpawnOpts, playerCreated bool) *EntityHandle
+	SnowGolem          func(opts EntitySpawnOpts) *EntityHandle
+	Tadpole            func(opts EntitySpawnOpts) *EntityHandle
+	Warden             func(opts EntitySpawnOpts) *EntityHandle
 }
 
 // New creates an EntityRegistry using conf and the EntityTypes passed.
@@ -439,3 +502,10 @@ func readInt16(m map[string]any, k string) int16 {
 	v, _ := m[k].(int16)
 	return v
 }
+
+func boolByte(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
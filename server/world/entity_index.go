@@ -0,0 +1,135 @@
+package world
+
+import (
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// entityCell is the position of a cell of 16x16x16 blocks in an entityIndex.
+type entityCell [3]int32
+
+// entityCellFromVec3 returns the entityCell that the position passed is in.
+func entityCellFromVec3(pos mgl64.Vec3) entityCell {
+	return entityCell{int32(math.Floor(pos[0])) >> 4, int32(math.Floor(pos[1])) >> 4, int32(math.Floor(pos[2])) >> 4}
+}
+
+// entityIndex is a spatial index of the entities in a World. Entities are
+// grouped by the cell of 16x16x16 blocks that they are in, so that the
+// entities close to a position can be found without checking every entity in
+// the World. The cell of an entity is cached in its EntityHandle, which makes
+// re-indexing an entity that did not leave its cell nearly free.
+type entityIndex struct {
+	cells map[entityCell][]*EntityHandle
+}
+
+// newEntityIndex creates an empty entityIndex.
+func newEntityIndex() *entityIndex {
+	return &entityIndex{cells: make(map[entityCell][]*EntityHandle)}
+}
+
+// insert adds the EntityHandle passed to the cell of its current position.
+func (i *entityIndex) insert(handle *EntityHandle) {
+	handle.cell = entityCellFromVec3(handle.data.Pos)
+	i.cells[handle.cell] = append(i.cells[handle.cell], handle)
+}
+
+// remove removes the EntityHandle passed from the cell it was last indexed in.
+func (i *entityIndex) remove(handle *EntityHandle) {
+	entities := i.cells[handle.cell]
+	for n, other := range entities {
+		if other != handle {
+			continue
+		}
+		// The order of entities within a cell does not matter, so we can
+		// move the last entity into the free slot.
+		last := len(entities) - 1
+		entities[n], entities[last] = entities[last], nil
+		if last == 0 {
+			delete(i.cells, handle.cell)
+		} else {
+			i.cells[handle.cell] = entities[:last]
+		}
+		return
+	}
+}
+
+// move re-indexes the EntityHandle passed if it left the cell that it was
+// last indexed in.
+func (i *entityIndex) move(handle *EntityHandle) {
+	if entityCellFromVec3(handle.data.Pos) == handle.cell {
+		return
+	}
+	i.remove(handle)
+	i.insert(handle)
+}
+
+// maxEntityIndexRings is the maximum amount of rings of cells that are
+// searched by entityIndex.nearest. For larger distances, searching all cells
+// that contain entities is cheaper than searching every cell in range.
+const maxEntityIndexRings = 16
+
+// nearest returns the entity closest to pos within maxDist for which filter
+// returns true. Cells are searched in rings of increasing distance around the
+// cell of pos, so that the search may stop as soon as no entity in a further
+// ring could be closer than the closest entity found so far.
+func (i *entityIndex) nearest(tx *Tx, pos mgl64.Vec3, maxDist float64, filter func(Entity) bool) (Entity, bool) {
+	s := nearestSearch{tx: tx, pos: pos, filter: filter, distSq: maxDist * maxDist}
+	if maxDist/16 >= maxEntityIndexRings {
+		for _, entities := range i.cells {
+			s.search(entities)
+		}
+		return s.nearest, s.nearest != nil
+	}
+	centre, rings := entityCellFromVec3(pos), int32(math.Ceil(maxDist/16))
+	for ring := int32(0); ring <= rings; ring++ {
+		if s.nearest != nil && float64((ring-1)*16) > math.Sqrt(s.distSq) {
+			// Every entity in this ring and the rings after it is at least
+			// (ring-1)*16 blocks away, which is further than the entity found.
+			break
+		}
+		for x := centre[0] - ring; x <= centre[0]+ring; x++ {
+			for y := centre[1] - ring; y <= centre[1]+ring; y++ {
+				step := int32(1)
+				if ring > 0 && x != centre[0]-ring && x != centre[0]+ring && y != centre[1]-ring && y != centre[1]+ring {
+					// Only the cells on the edge of the ring are searched, as
+					// the cells inside it were searched in earlier rings.
+					step = ring * 2
+				}
+				for z := centre[2] - ring; z <= centre[2]+ring; z += step {
+					if entities, ok := i.cells[entityCell{x, y, z}]; ok {
+						s.search(entities)
+					}
+				}
+			}
+		}
+	}
+	return s.nearest, s.nearest != nil
+}
+
+// nearestSearch holds the state of a search for the entity nearest to a
+// position.
+type nearestSearch struct {
+	tx     *Tx
+	pos    mgl64.Vec3
+	filter func(Entity) bool
+
+	nearest Entity
+	distSq  float64
+}
+
+// search checks if any of the entities passed is closer to the position
+// searched around than the nearest entity found so far.
+func (s *nearestSearch) search(entities []*EntityHandle) {
+	for _, handle := range entities {
+		d := handle.data.Pos.Sub(s.pos)
+		distSq := d.Dot(d)
+		if distSq > s.distSq {
+			continue
+		}
+		e := handle.mustEntity(s.tx)
+		if s.filter != nil && !s.filter(e) {
+			continue
+		}
+		s.nearest, s.distSq = e, distSq
+	}
+}
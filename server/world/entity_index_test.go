@@ -0,0 +1,78 @@
+package world_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// withEntities runs f in a transaction of a world holding n text entities
+// spread over an area of 512x512 blocks. The positions queried by f are
+// passed in queries.
+func withEntities(tb testing.TB, n int, f func(tx *world.Tx, queries []mgl64.Vec3)) {
+	w := world.Config{Entities: entity.DefaultRegistry}.New()
+	defer w.Close()
+
+	r := rand.New(rand.NewPCG(1, 2))
+	randomPos := func() mgl64.Vec3 {
+		return mgl64.Vec3{r.Float64()*512 - 256, 64 + r.Float64()*16, r.Float64()*512 - 256}
+	}
+	queries := make([]mgl64.Vec3, 64)
+	for i := range queries {
+		queries[i] = randomPos()
+	}
+	<-w.Exec(func(tx *world.Tx) {
+		for range n {
+			tx.AddEntity(entity.NewText("", randomPos()))
+		}
+	})
+	<-w.Exec(func(tx *world.Tx) {
+		f(tx, queries)
+	})
+}
+
+// nearestLinear returns the entity nearest to pos within maxDist by checking
+// every entity in the world.
+func nearestLinear(tx *world.Tx, pos mgl64.Vec3, maxDist float64) (world.Entity, bool) {
+	var nearest world.Entity
+	for e := range tx.Entities() {
+		if dist := e.Position().Sub(pos).Len(); dist <= maxDist {
+			nearest, maxDist = e, dist
+		}
+	}
+	return nearest, nearest != nil
+}
+
+func TestNearestEntityMatchesLinearScan(t *testing.T) {
+	withEntities(t, 1000, func(tx *world.Tx, queries []mgl64.Vec3) {
+		for _, maxDist := range []float64{4, 16, 48, 512} {
+			for _, pos := range queries {
+				want, wantOk := nearestLinear(tx, pos, maxDist)
+				got, ok := tx.NearestEntity(pos, maxDist, nil)
+				if ok != wantOk || (ok && got.H() != want.H()) {
+					t.Errorf("nearest entity to %v within %v: expected %v (%v), got %v (%v)", pos, maxDist, want, wantOk, got, ok)
+					return
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkNearestEntity(b *testing.B) {
+	withEntities(b, 1000, func(tx *world.Tx, queries []mgl64.Vec3) {
+		for i := 0; b.Loop(); i++ {
+			tx.NearestEntity(queries[i%len(queries)], 16, nil)
+		}
+	})
+}
+
+func BenchmarkNearestEntityLinear(b *testing.B) {
+	withEntities(b, 1000, func(tx *world.Tx, queries []mgl64.Vec3) {
+		for i := 0; b.Loop(); i++ {
+			nearestLinear(tx, queries[i%len(queries)], 16)
+		}
+	})
+}
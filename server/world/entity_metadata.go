@@ -0,0 +1,206 @@
+package world
+
+import (
+	"iter"
+	"maps"
+	"math"
+)
+
+// EntityFlag is a cosmetic state of an entity that may be set through its
+// metadata, such as being on fire or being a baby. Setting a flag only
+// changes how the entity is shown to viewers: It does not change the
+// behaviour of the entity.
+type EntityFlag uint8
+
+const (
+	EntityFlagOnFire EntityFlag = iota
+	EntityFlagSneaking
+	EntityFlagSprinting
+	EntityFlagInvisible
+	EntityFlagInLove
+	EntityFlagSaddled
+	EntityFlagPowered
+	EntityFlagIgnited
+	EntityFlagBaby
+	EntityFlagSilent
+	EntityFlagSitting
+	EntityFlagAngry
+	EntityFlagTamed
+	EntityFlagSheared
+	EntityFlagElder
+	EntityFlagChested
+	EntityFlagDancing
+	EntityFlagCelebrating
+	entityFlagCount
+)
+
+// EntityIntKey is a key of an integer value in the metadata of an entity,
+// such as its variant or colour.
+type EntityIntKey uint8
+
+const (
+	// EntityVariant is the variant of an entity, such as the type of a cat
+	// or the colour of a horse.
+	EntityVariant EntityIntKey = iota
+	// EntityMarkVariant is the secondary variant of an entity, such as the
+	// markings of a horse.
+	EntityMarkVariant
+	// EntityColour is the colour of an entity, such as the wool colour of a
+	// sheep. It ranges from 0 to 15.
+	EntityColour
+	// EntityColour2 is the secondary colour of an entity, such as the pattern
+	// colour of a tropical fish. It ranges from 0 to 15.
+	EntityColour2
+	entityIntKeyCount
+)
+
+// EntityFloatKey is a key of a floating point value in the metadata of an
+// entity, such as its scale.
+type EntityFloatKey uint8
+
+const (
+	// EntityScale is the scale at which the entity is rendered. It ranges
+	// from 0 to 64.
+	EntityScale EntityFloatKey = iota
+	// EntityWidth is the width of the hitbox of the entity shown to viewers.
+	// It ranges from 0 to 64.
+	EntityWidth
+	// EntityHeight is the height of the hitbox of the entity shown to
+	// viewers. It ranges from 0 to 64.
+	EntityHeight
+	entityFloatKeyCount
+)
+
+// EntityMetadata holds custom metadata set on an entity. The values in an
+// EntityMetadata are sent to viewers on top of the metadata that follows from
+// the state of the entity, overriding it where the two overlap. The zero
+// value is an empty EntityMetadata ready to use.
+//
+// Only a known set of flags and values may be set, and values are clamped to
+// ranges that clients accept, so that no combination of values can cause
+// viewers to crash. Flags that require additional data to be valid, such as
+// riding or sleeping, cannot be set.
+type EntityMetadata struct {
+	flags  map[EntityFlag]bool
+	ints   map[EntityIntKey]int32
+	floats map[EntityFloatKey]float32
+}
+
+// SetFlag overrides the flag passed with the value passed. The flag stays
+// overridden until Reset is called. SetFlag returns false if the flag is not
+// known or if the metadata did not change.
+func (m *EntityMetadata) SetFlag(flag EntityFlag, value bool) bool {
+	if flag >= entityFlagCount {
+		return false
+	}
+	if current, ok := m.flags[flag]; ok && current == value {
+		return false
+	}
+	if m.flags == nil {
+		m.flags = make(map[EntityFlag]bool)
+	}
+	m.flags[flag] = value
+	return true
+}
+
+// SetInt overrides the integer value under the key passed. Values are clamped
+// to the range of the key. SetInt returns false if the key is not known or if
+// the metadata did not change.
+func (m *EntityMetadata) SetInt(key EntityIntKey, value int) bool {
+	if key >= entityIntKeyCount {
+		return false
+	}
+	v := int32(max(min(value, math.MaxInt32), 0))
+	if key == EntityColour || key == EntityColour2 {
+		v = min(v, 15)
+	}
+	if current, ok := m.ints[key]; ok && current == v {
+		return false
+	}
+	if m.ints == nil {
+		m.ints = make(map[EntityIntKey]int32)
+	}
+	m.ints[key] = v
+	return true
+}
+
+// SetFloat overrides the floating point value under the key passed. Values
+// are clamped to the range of the key. SetFloat returns false if the key is
+// not known, if the value is NaN or infinite, or if the metadata did not
+// change.
+func (m *EntityMetadata) SetFloat(key EntityFloatKey, value float64) bool {
+	if key >= entityFloatKeyCount || math.IsNaN(value) || math.IsInf(value, 0) {
+		return false
+	}
+	v := float32(max(min(value, 64), 0))
+	if current, ok := m.floats[key]; ok && current == v {
+		return false
+	}
+	if m.floats == nil {
+		m.floats = make(map[EntityFloatKey]float32)
+	}
+	m.floats[key] = v
+	return true
+}
+
+// Reset removes all values set in the EntityMetadata, so that viewers are
+// shown the metadata that follows from the state of the entity again.
+// Reset returns false if the metadata was already empty.
+func (m *EntityMetadata) Reset() bool {
+	if len(m.flags)+len(m.ints)+len(m.floats) == 0 {
+		return false
+	}
+	*m = EntityMetadata{}
+	return true
+}
+
+// Flag returns the value that the flag passed is overridden with. False is
+// returned if the flag was not set.
+func (m EntityMetadata) Flag(flag EntityFlag) (value, ok bool) {
+	value, ok = m.flags[flag]
+	return value, ok
+}
+
+// Int returns the integer value under the key passed. False is returned if
+// no value was set under the key.
+func (m EntityMetadata) Int(key EntityIntKey) (int, bool) {
+	v, ok := m.ints[key]
+	return int(v), ok
+}
+
+// Float returns the floating point value under the key passed. False is
+// returned if no value was set under the key.
+func (m EntityMetadata) Float(key EntityFloatKey) (float64, bool) {
+	v, ok := m.floats[key]
+	return float64(v), ok
+}
+
+// Flags returns an iterator over all flags set in the EntityMetadata and the
+// values they are overridden with.
+func (m EntityMetadata) Flags() iter.Seq2[EntityFlag, bool] {
+	return maps.All(m.flags)
+}
+
+// Ints returns an iterator over all integer values set in the
+// EntityMetadata.
+func (m EntityMetadata) Ints() iter.Seq2[EntityIntKey, int] {
+	return func(yield func(EntityIntKey, int) bool) {
+		for k, v := range m.ints {
+			if !yield(k, int(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Floats returns an iterator over all floating point values set in the
+// EntityMetadata.
+func (m EntityMetadata) Floats() iter.Seq2[EntityFloatKey, float64] {
+	return func(yield func(EntityFloatKey, float64) bool) {
+		for k, v := range m.floats {
+			if !yield(k, float64(v)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Event is a change in a World that may be subscribed to through the
+// EventBus of the World. Event is implemented by BlockChangeEvent,
+// EntitySpawnEvent, EntityDespawnEvent, ChunkLoadEvent and ChunkUnloadEvent.
+type Event interface {
+	// within checks if the event took place (partially) within the cube.BBox
+	// passed.
+	within(box cube.BBox) bool
+}
+
+// BlockChangeEvent is published when a block in the World is changed using
+// Tx.SetBlock. Blocks changed through Tx.BuildStructure or as a result of
+// generating new chunks do not publish a BlockChangeEvent.
+type BlockChangeEvent struct {
+	// Pos is the position of the block that was changed.
+	Pos cube.Pos
+	// Before and After are the blocks at Pos before and after the change.
+	Before, After Block
+}
+
+// EntitySpawnEvent is published when an Entity is added to the World.
+type EntitySpawnEvent struct {
+	// Entity is the handle of the Entity that was added. The handle may be used
+	// to obtain the Entity in a transaction of the World.
+	Entity *EntityHandle
+	// Pos is the position that the Entity was added at.
+	Pos mgl64.Vec3
+}
+
+// EntityDespawnEvent is published when an Entity is removed from the World.
+type EntityDespawnEvent struct {
+	// Entity is the handle of the Entity that was removed.
+	Entity *EntityHandle
+	// Pos is the position of the Entity at the time it was removed.
+	Pos mgl64.Vec3
+}
+
+// ChunkLoadEvent is published when a chunk is loaded from the Provider of
+// the World or generated by its Generator.
+type ChunkLoadEvent struct {
+	// Pos is the position of the chunk that was loaded.
+	Pos ChunkPos
+}
+
+// ChunkUnloadEvent is published when a chunk is unloaded from the World.
+type ChunkUnloadEvent struct {
+	// Pos is the position of the chunk that was unloaded.
+	Pos ChunkPos
+}
+
+func (e BlockChangeEvent) within(box cube.BBox) bool {
+	return box.Vec3Within(e.Pos.Vec3Centre())
+}
+
+func (e EntitySpawnEvent) within(box cube.BBox) bool {
+	return box.Vec3Within(e.Pos)
+}
+
+func (e EntityDespawnEvent) within(box cube.BBox) bool {
+	return box.Vec3Within(e.Pos)
+}
+
+func (e ChunkLoadEvent) within(box cube.BBox) bool {
+	return chunkBox(e.Pos, box).IntersectsWith(box)
+}
+
+func (e ChunkUnloadEvent) within(box cube.BBox) bool {
+	return chunkBox(e.Pos, box).IntersectsWith(box)
+}
+
+// chunkBox returns the cube.BBox of the chunk at the ChunkPos passed, spanning
+// the full height of the cube.BBox passed.
+func chunkBox(pos ChunkPos, box cube.BBox) cube.BBox {
+	x, z := float64(pos[0]<<4), float64(pos[1]<<4)
+	return cube.Box(x, box.Min()[1], z, x+16, box.Max()[1], z+16)
+}
+
+// DeliveryPolicy specifies what happens when an Event is published to a
+// Subscription whose buffer is full.
+type DeliveryPolicy int
+
+const (
+	// DeliveryDrop drops events published while the buffer of the
+	// Subscription is full. The World never waits for a subscriber with this
+	// policy, so slow subscribers cannot stall the World.
+	DeliveryDrop DeliveryPolicy = iota
+	// DeliveryBlock makes the World wait until the buffer of the Subscription
+	// has room for an event. No events are lost with this policy, but a slow
+	// subscriber will stall the World, including its ticking.
+	DeliveryBlock
+)
+
+// SubscriptionConfig holds the parameters of a Subscription to the EventBus
+// of a World.
+type SubscriptionConfig struct {
+	// Dimension is the Dimension that the World must be in for events to be
+	// delivered. If nil, events are delivered regardless of the Dimension.
+	// Setting Dimension is useful when subscribing to multiple worlds using
+	// the same SubscriptionConfig.
+	Dimension Dimension
+	// Region is the area that an event must take place in for it to be
+	// delivered. If nil, events are delivered regardless of where they take
+	// place. For chunk events, it is sufficient for the chunk to intersect
+	// with the Region.
+	Region *cube.BBox
+	// BufferSize is the size of the buffer of the channel that events are
+	// delivered on. If 0, a buffer size of 256 is used.
+	BufferSize int
+	// Policy is the DeliveryPolicy used when the buffer of the Subscription
+	// is full. By default, DeliveryDrop is used.
+	Policy DeliveryPolicy
+}
+
+// EventBus publishes changes in a World, such as blocks being changed and
+// entities being spawned, to subscribers. Unlike a Handler, an EventBus may
+// have any number of subscribers, which receive events after they happen on
+// a channel. An EventBus may be obtained by calling World.Events.
+type EventBus struct {
+	dim Dimension
+
+	mu   sync.RWMutex
+	subs []subscriber
+	// n is the number of subscribers of the EventBus, stored separately so
+	// that publishers may quickly check if any event needs to be published.
+	n atomic.Int32
+}
+
+// subscriber is implemented by Subscriptions of any Event type.
+type subscriber interface {
+	publish(ev Event)
+	close()
+}
+
+// Subscribe subscribes to events of the type E published on the EventBus
+// passed. E may be any of the Event implementations, or Event itself to
+// subscribe to all events. Events are published from within the transaction
+// in which the change happened and are delivered in the order in which they
+// were published. The Subscription must be closed once it is no longer used.
+// Subscribe returns a Subscription that is closed immediately if bus is nil.
+func Subscribe[E Event](bus *EventBus, conf SubscriptionConfig) *Subscription[E] {
+	if conf.BufferSize <= 0 {
+		conf.BufferSize = 256
+	}
+	s := &Subscription[E]{bus: bus, conf: conf, ch: make(chan E, conf.BufferSize), done: make(chan struct{})}
+	if bus == nil || (conf.Dimension != nil && conf.Dimension != bus.dim) {
+		// Events are never delivered to this Subscription, so we don't need
+		// to add it to the EventBus at all.
+		s.bus = nil
+		s.close()
+		return s
+	}
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	bus.subs = append(bus.subs, s)
+	bus.n.Add(1)
+	return s
+}
+
+// active checks if the EventBus has any subscribers.
+func (bus *EventBus) active() bool {
+	return bus.n.Load() > 0
+}
+
+// publish publishes an Event to all subscribers of the EventBus.
+func (bus *EventBus) publish(ev Event) {
+	bus.mu.RLock()
+	defer bus.mu.RUnlock()
+	for _, s := range bus.subs {
+		s.publish(ev)
+	}
+}
+
+// remove removes a subscriber from the EventBus. It returns false if the
+// subscriber was already removed.
+func (bus *EventBus) remove(s subscriber) bool {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	i := slices.Index(bus.subs, s)
+	if i == -1 {
+		return false
+	}
+	bus.subs = slices.Delete(bus.subs, i, i+1)
+	bus.n.Add(-1)
+	return true
+}
+
+// close closes all subscriptions of the EventBus.
+func (bus *EventBus) close() {
+	bus.mu.RLock()
+	subs := slices.Clone(bus.subs)
+	bus.mu.RUnlock()
+
+	for _, s := range subs {
+		s.close()
+	}
+}
+
+// Subscription is a subscription to events of the type E published on the
+// EventBus of a World. Events are received on the channel returned by C.
+type Subscription[E Event] struct {
+	bus  *EventBus
+	conf SubscriptionConfig
+
+	ch      chan E
+	done    chan struct{}
+	once    sync.Once
+	dropped atomic.Uint64
+}
+
+// C returns the channel that events are delivered on. The channel is closed
+// when the Subscription is closed, either by calling Close or by the World
+// being closed.
+func (s *Subscription[E]) C() <-chan E {
+	return s.ch
+}
+
+// Dropped returns the number of events that were dropped because the buffer
+// of the Subscription was full. Dropped always returns 0 for subscriptions
+// with the DeliveryBlock policy.
+func (s *Subscription[E]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close closes the Subscription. No more events are delivered after Close
+// returns and the channel returned by C is closed.
+func (s *Subscription[E]) Close() {
+	s.close()
+}
+
+// publish delivers the Event passed to the Subscription if it is of the type
+// E and took place in the region of the Subscription.
+func (s *Subscription[E]) publish(ev Event) {
+	e, ok := ev.(E)
+	if !ok || (s.conf.Region != nil && !ev.within(*s.conf.Region)) {
+		return
+	}
+	if s.conf.Policy == DeliveryBlock {
+		select {
+		case s.ch <- e:
+		case <-s.done:
+		}
+		return
+	}
+	select {
+	case s.ch <- e:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// close removes the Subscription from its EventBus and closes its channel.
+func (s *Subscription[E]) close() {
+	s.once.Do(func() {
+		// Closing done first makes sure that a publisher blocked on this
+		// Subscription releases the EventBus, so that we may remove the
+		// Subscription from it.
+		close(s.done)
+		if s.bus != nil {
+			s.bus.remove(s)
+		}
+		close(s.ch)
+	})
+}
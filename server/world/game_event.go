@@ -0,0 +1,149 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/sliceutil"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// GameEvent represents an event in the world that creates a vibration, such
+// as a block being placed or an entity stepping on a block. Game events are
+// emitted using Tx.EmitGameEvent and may be perceived by GameEventListeners
+// and EntityGameEventListeners close to where they were emitted.
+type GameEvent interface {
+	// Frequency returns the frequency of the vibration created by the game
+	// event, ranging from 1 to 15. Game events with a frequency of 0 do not
+	// create vibrations that sculk sensors perceive, but may be perceived by
+	// other listeners, such as sculk shriekers and wardens.
+	Frequency() int
+}
+
+// GameEventListener is a Block that perceives GameEvents emitted close to it,
+// such as a sculk sensor. Only blocks that also implement NBTer are able to
+// listen to game events: They are registered as listener in the chunk they
+// are in once their block entity is created, so that emitting a game event
+// only needs to consider the listeners of the chunks close to it.
+type GameEventListener interface {
+	NBTer
+	// GameEventRange returns the radius in blocks within which the block
+	// perceives game events.
+	GameEventRange() float64
+	// ReceiveGameEvent is called when a game event is emitted at evPos,
+	// within the range of the block at pos. src is the entity that caused the
+	// game event. It may be nil.
+	ReceiveGameEvent(pos cube.Pos, ev GameEvent, evPos mgl64.Vec3, src Entity, tx *Tx)
+}
+
+// EntityGameEventListener is an Entity that perceives GameEvents emitted close
+// to it. Only entities with an EntityType that implements
+// GameEventListenerType and returns true from ListensToGameEvents are
+// registered as listener in the chunk they are in and perceive game events.
+type EntityGameEventListener interface {
+	Entity
+	// GameEventRange returns the radius in blocks within which the entity
+	// perceives game events. Ranges above 16 are capped to 16.
+	GameEventRange() float64
+	// ReceiveGameEvent is called when a game event is emitted at evPos,
+	// within the range of the entity. src is the entity that caused the game
+	// event. It may be nil.
+	ReceiveGameEvent(ev GameEvent, evPos mgl64.Vec3, src Entity, tx *Tx)
+}
+
+// GameEventListenerType is an EntityType that opts in to its entities being
+// registered as game event listeners. The entities must implement
+// EntityGameEventListener.
+type GameEventListenerType interface {
+	EntityType
+	// ListensToGameEvents returns true if entities of the EntityType listen
+	// to game events.
+	ListensToGameEvents() bool
+}
+
+// maxGameEventRange is the maximum range in blocks within which game events
+// may be perceived by listeners.
+const maxGameEventRange = 16
+
+// emitGameEvent passes the GameEvent emitted at the position passed to all
+// listeners within range of it. Only the listeners registered in chunks that
+// are already loaded are checked, so that emitting a game event without any
+// listeners close to it is cheap.
+func (w *World) emitGameEvent(tx *Tx, pos mgl64.Vec3, ev GameEvent, src Entity) {
+	minPos := chunkPosFromVec3(pos.Sub(mgl64.Vec3{maxGameEventRange, 0, maxGameEventRange}))
+	maxPos := chunkPosFromVec3(pos.Add(mgl64.Vec3{maxGameEventRange, 0, maxGameEventRange}))
+
+	// Listeners are collected first, as they might change blocks or entities
+	// in the chunks while handling the game event.
+	var (
+		blockListeners  []cube.Pos
+		entityListeners []EntityGameEventListener
+	)
+	for x := minPos[0]; x <= maxPos[0]; x++ {
+		for z := minPos[1]; z <= maxPos[1]; z++ {
+			c, ok := w.chunks[ChunkPos{x, z}]
+			if !ok {
+				continue
+			}
+			for bPos := range c.gameEventBlocks {
+				if l, ok := c.BlockEntities[bPos].(GameEventListener); ok && bPos.Vec3Centre().Sub(pos).Len() <= min(l.GameEventRange(), maxGameEventRange) {
+					blockListeners = append(blockListeners, bPos)
+				}
+			}
+			for _, handle := range c.gameEventEntities {
+				dist := handle.data.Pos.Sub(pos).Len()
+				if dist > maxGameEventRange || (src != nil && handle == src.H()) {
+					continue
+				}
+				if l, ok := handle.mustEntity(tx).(EntityGameEventListener); ok && dist <= l.GameEventRange() {
+					entityListeners = append(entityListeners, l)
+				}
+			}
+		}
+	}
+	for _, bPos := range blockListeners {
+		if l, ok := w.block(bPos).(GameEventListener); ok {
+			l.ReceiveGameEvent(bPos, ev, pos, src, tx)
+		}
+	}
+	for _, l := range entityListeners {
+		l.ReceiveGameEvent(ev, pos, src, tx)
+	}
+}
+
+// setBlockEntity sets the block entity at the position passed in the Column
+// to the Block passed. The Block is registered as game event listener of the
+// Column if it is a GameEventListener.
+func (c *Column) setBlockEntity(pos cube.Pos, b Block) {
+	c.BlockEntities[pos] = b
+	if _, ok := b.(GameEventListener); !ok {
+		delete(c.gameEventBlocks, pos)
+		return
+	}
+	if c.gameEventBlocks == nil {
+		c.gameEventBlocks = make(map[cube.Pos]struct{})
+	}
+	c.gameEventBlocks[pos] = struct{}{}
+}
+
+// removeBlockEntity removes the block entity at the position passed from the
+// Column.
+func (c *Column) removeBlockEntity(pos cube.Pos) {
+	delete(c.BlockEntities, pos)
+	delete(c.gameEventBlocks, pos)
+}
+
+// addEntity adds the EntityHandle passed to the entities of the Column. The
+// EntityHandle is registered as game event listener of the Column if its
+// EntityType listens to game events.
+func (c *Column) addEntity(handle *EntityHandle) {
+	c.Entities = append(c.Entities, handle)
+	if t, ok := handle.t.(GameEventListenerType); ok && t.ListensToGameEvents() {
+		c.gameEventEntities = append(c.gameEventEntities, handle)
+	}
+}
+
+// removeEntity removes the EntityHandle passed from the entities of the
+// Column.
+func (c *Column) removeEntity(handle *EntityHandle) {
+	c.Entities = sliceutil.DeleteVal(c.Entities, handle)
+	c.gameEventEntities = sliceutil.DeleteVal(c.gameEventEntities, handle)
+}
@@ -0,0 +1,174 @@
+// Package gameevent implements game events that may be emitted in a world using world.Tx.EmitGameEvent. Game
+// events create vibrations that are perceived by listeners such as sculk sensors. The frequency of each game
+// event is the frequency of the vibration it creates.
+package gameevent
+
+// Step is emitted when an entity that is not sneaking takes a step on the ground.
+type Step struct{ frequency1 }
+
+// Swim is emitted when an entity moves through water.
+type Swim struct{ frequency1 }
+
+// Flap is emitted when a flying entity flaps its wings.
+type Flap struct{ frequency1 }
+
+// ProjectileLand is emitted when a projectile hits a block or an entity.
+type ProjectileLand struct{ frequency2 }
+
+// HitGround is emitted when an entity lands on the ground after falling.
+type HitGround struct{ frequency2 }
+
+// Splash is emitted when an entity falls into water.
+type Splash struct{ frequency2 }
+
+// ItemInteractFinish is emitted when an entity finishes using an item, such as when it releases a bow.
+type ItemInteractFinish struct{ frequency3 }
+
+// ProjectileShoot is emitted when an entity shoots or throws a projectile.
+type ProjectileShoot struct{ frequency3 }
+
+// InstrumentPlay is emitted when an entity plays an instrument, such as a goat horn.
+type InstrumentPlay struct{ frequency3 }
+
+// EntityAction is emitted when an entity performs an action, such as a sniffer digging.
+type EntityAction struct{ frequency4 }
+
+// ElytraGlide is emitted when an entity glides using elytra.
+type ElytraGlide struct{ frequency4 }
+
+// Unequip is emitted when an entity unequips an item, such as a piece of armour.
+type Unequip struct{ frequency4 }
+
+// EntityDismount is emitted when an entity stops riding another entity.
+type EntityDismount struct{ frequency5 }
+
+// Equip is emitted when an entity equips an item, such as a piece of armour.
+type Equip struct{ frequency5 }
+
+// EntityMount is emitted when an entity starts riding another entity.
+type EntityMount struct{ frequency6 }
+
+// EntityInteract is emitted when an entity interacts with another entity.
+type EntityInteract struct{ frequency6 }
+
+// Shear is emitted when an entity is sheared.
+type Shear struct{ frequency6 }
+
+// EntityDamage is emitted when an entity takes damage.
+type EntityDamage struct{ frequency7 }
+
+// Drink is emitted when an entity finishes drinking an item, such as a potion.
+type Drink struct{ frequency8 }
+
+// Eat is emitted when an entity finishes eating food.
+type Eat struct{ frequency8 }
+
+// ContainerClose is emitted when a container, such as a chest, is closed.
+type ContainerClose struct{ frequency9 }
+
+// BlockClose is emitted when a block, such as a door or a trapdoor, is closed.
+type BlockClose struct{ frequency9 }
+
+// BlockDeactivate is emitted when a block, such as a button or a lever, is deactivated.
+type BlockDeactivate struct{ frequency9 }
+
+// BlockDetach is emitted when a block is detached from another block, such as tripwire being cut.
+type BlockDetach struct{ frequency9 }
+
+// ContainerOpen is emitted when a container, such as a chest, is opened.
+type ContainerOpen struct{ frequency10 }
+
+// BlockOpen is emitted when a block, such as a door or a trapdoor, is opened.
+type BlockOpen struct{ frequency10 }
+
+// BlockActivate is emitted when a block, such as a button or a lever, is activated.
+type BlockActivate struct{ frequency10 }
+
+// BlockAttach is emitted when a block is attached to another block.
+type BlockAttach struct{ frequency10 }
+
+// PrimeFuse is emitted when TNT is primed.
+type PrimeFuse struct{ frequency10 }
+
+// NoteBlockPlay is emitted when a note block plays a note.
+type NoteBlockPlay struct{ frequency10 }
+
+// JukeboxPlay is emitted when a jukebox starts playing a music disc.
+type JukeboxPlay struct{ frequency10 }
+
+// BlockChange is emitted when the state of a block changes, such as when a crop grows or a note block is
+// tuned.
+type BlockChange struct{ frequency11 }
+
+// JukeboxStopPlay is emitted when a jukebox stops playing a music disc.
+type JukeboxStopPlay struct{ frequency11 }
+
+// BlockDestroy is emitted when a block is broken.
+type BlockDestroy struct{ frequency12 }
+
+// FluidPickup is emitted when a fluid is picked up, such as by using a bucket.
+type FluidPickup struct{ frequency12 }
+
+// BlockPlace is emitted when a block is placed.
+type BlockPlace struct{ frequency13 }
+
+// FluidPlace is emitted when a fluid is placed, such as by using a bucket.
+type FluidPlace struct{ frequency13 }
+
+// EntityPlace is emitted when an entity is placed, such as an armour stand or a boat.
+type EntityPlace struct{ frequency14 }
+
+// LightningStrike is emitted when lightning strikes.
+type LightningStrike struct{ frequency14 }
+
+// Teleport is emitted when an entity teleports.
+type Teleport struct{ frequency14 }
+
+// EntityDie is emitted when an entity dies.
+type EntityDie struct{ frequency15 }
+
+// Explode is emitted when an explosion occurs.
+type Explode struct{ frequency15 }
+
+// SculkSensorTendrilsClicking is emitted when a sculk sensor perceives a vibration. It is perceived by sculk
+// shriekers and wardens, but not by other sculk sensors.
+type SculkSensorTendrilsClicking struct{ noFrequency }
+
+// Shriek is emitted when a sculk shrieker shrieks. It is only perceived by wardens.
+type Shriek struct{ noFrequency }
+
+type (
+	noFrequency struct{}
+	frequency1  struct{}
+	frequency2  struct{}
+	frequency3  struct{}
+	frequency4  struct{}
+	frequency5  struct{}
+	frequency6  struct{}
+	frequency7  struct{}
+	frequency8  struct{}
+	frequency9  struct{}
+	frequency10 struct{}
+	frequency11 struct{}
+	frequency12 struct{}
+	frequency13 struct{}
+	frequency14 struct{}
+	frequency15 struct{}
+)
+
+func (noFrequency) Frequency() int { return 0 }
+func (frequency1) Frequency() int  { return 1 }
+func (frequency2) Frequency() int  { return 2 }
+func (frequency3) Frequency() int  { return 3 }
+func (frequency4) Frequency() int  { return 4 }
+func (frequency5) Frequency() int  { return 5 }
+func (frequency6) Frequency() int  { return 6 }
+func (frequency7) Frequency() int  { return 7 }
+func (frequency8) Frequency() int  { return 8 }
+func (frequency9) Frequency() int  { return 9 }
+func (frequency10) Frequency() int { return 10 }
+func (frequency11) Frequency() int { return 11 }
+func (frequency12) Frequency() int { return 12 }
+func (frequency13) Frequency() int { return 13 }
+func (frequency14) Frequency() int { return 14 }
+func (frequency15) Frequency() int { return 15 }
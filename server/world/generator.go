@@ -1,6 +1,7 @@
 package world
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world/chunk"
 )
 
@@ -18,3 +19,267 @@ type NopGenerator struct{}
 
 // GenerateChunk ...
 func (NopGenerator) GenerateChunk(ChunkPos, *chunk.Chunk) {}
+
+// Decorator is a Generator that decorates chunks in a second stage of
+// generation. A chunk is decorated once the terrain of the chunk itself and of
+// all eight of its neighbours has been generated using GenerateChunk, so that
+// features crossing chunk borders, such as trees, large ore veins, lakes and
+// villages, may be placed.
+//
+// DecorateChunk is called while the World is in a transaction, so it should
+// not take long. Because neighbours of a chunk may already be decorated when
+// it is decorated itself, features placed by different chunks may overlap.
+type Decorator interface {
+	Generator
+	// DecorateChunk decorates the chunk at the position passed. Blocks may be
+	// read and set in the chunk and its eight neighbours through the
+	// DecorationArea passed.
+	DecorateChunk(pos ChunkPos, area *DecorationArea)
+}
+
+// DecorationArea is an area of 3x3 chunks passed to a Decorator, centred
+// around the chunk decorated. Blocks may be read and set anywhere inside the
+// area, but not outside of it.
+type DecorationArea struct {
+	centre ChunkPos
+	cols   [9]*Column
+	// modified holds, for every chunk in the area, if any of its blocks was
+	// changed.
+	modified [9]bool
+}
+
+// Range returns the vertical range of the chunks in the DecorationArea.
+func (a *DecorationArea) Range() cube.Range {
+	return a.cols[4].Range()
+}
+
+// Contains checks if the block position passed is inside the DecorationArea.
+func (a *DecorationArea) Contains(pos cube.Pos) bool {
+	_, ok := a.index(pos)
+	return ok
+}
+
+// Block returns the runtime ID of the block at the position passed. If the
+// position is outside the DecorationArea, the runtime ID of air is returned.
+func (a *DecorationArea) Block(pos cube.Pos) uint32 {
+	i, ok := a.index(pos)
+	if !ok {
+		return airRID
+	}
+	return a.cols[i].Block(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0)
+}
+
+// SetBlock sets the block with the runtime ID passed at the position passed.
+// Nothing happens if the position is outside the DecorationArea. Blocks with
+// NBT data are set with their default NBT data. PlaceBlock should be used to
+// set a block with specific NBT data.
+func (a *DecorationArea) SetBlock(pos cube.Pos, rid uint32) {
+	a.setBlock(pos, rid, nil)
+}
+
+// PlaceBlock sets the Block passed at the position passed, including the NBT
+// data of the Block if it has any. Nothing happens if the position is outside
+// the DecorationArea.
+func (a *DecorationArea) PlaceBlock(pos cube.Pos, b Block) {
+	a.setBlock(pos, BlockRuntimeID(b), b)
+}
+
+// setBlock sets the block with the runtime ID passed at the position passed.
+// If the block has NBT data, b is stored as block entity, or the block with
+// the runtime ID passed if b is nil.
+func (a *DecorationArea) setBlock(pos cube.Pos, rid uint32, b Block) {
+	i, ok := a.index(pos)
+	if !ok {
+		return
+	}
+	col := a.cols[i]
+	col.SetBlock(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0, rid)
+	if nbtBlocks[rid] {
+		if b == nil {
+			b = blockByRuntimeIDOrAir(rid)
+		}
+		col.setBlockEntity(pos, b)
+	} else {
+		col.removeBlockEntity(pos)
+	}
+	a.modified[i] = true
+}
+
+// setLiquid sets the liquid with the runtime ID passed in the second layer of
+// the block at the position passed. Nothing happens if the position is
+// outside the DecorationArea.
+func (a *DecorationArea) setLiquid(pos cube.Pos, rid uint32) {
+	if i, ok := a.index(pos); ok {
+		a.cols[i].SetBlock(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 1, rid)
+		a.modified[i] = true
+	}
+}
+
+// HighestBlock returns the Y value of the highest non-air block in the column
+// at x and z. If the column is outside the DecorationArea, the lowest Y value
+// of the area is returned.
+func (a *DecorationArea) HighestBlock(x, z int) int {
+	i, ok := a.index(cube.Pos{x, a.Range().Min(), z})
+	if !ok {
+		return a.Range().Min()
+	}
+	return int(a.cols[i].HighestBlock(uint8(x), uint8(z)))
+}
+
+// index returns the index of the chunk that the position passed is in, and
+// false if the position is outside the DecorationArea.
+func (a *DecorationArea) index(pos cube.Pos) (int, bool) {
+	if pos.OutOfBounds(a.Range()) {
+		return 0, false
+	}
+	cx, cz := int32(pos[0]>>4)-a.centre[0], int32(pos[2]>>4)-a.centre[1]
+	if cx < -1 || cx > 1 || cz < -1 || cz > 1 {
+		return 0, false
+	}
+	return int((cx+1)*3 + cz + 1), true
+}
+
+// chunkPos returns the position of the chunk at index i in the
+// DecorationArea.
+func (a *DecorationArea) chunkPos(i int) ChunkPos {
+	return ChunkPos{a.centre[0] + int32(i/3) - 1, a.centre[1] + int32(i%3) - 1}
+}
+
+// StructureGenerator is a Generator that places structures, such as villages
+// or dungeons, in a second stage of generation, after the terrain of a chunk
+// and all eight of its neighbours has been generated and before the chunk is
+// decorated if the StructureGenerator is also a Decorator.
+//
+// Structures may be much larger than a chunk. Rather than placing a structure
+// all at once, every chunk places the part of each structure that overlaps
+// it when it is generated, so that parts of a structure in chunks that are not
+// yet generated are placed once those chunks are generated. Because chunks
+// are only decorated once, structures are never placed twice in a chunk, even
+// if it is unloaded and loaded again.
+type StructureGenerator interface {
+	Generator
+	// StructurePlacers returns the StructurePlacers that decide where
+	// structures are placed in the World.
+	StructurePlacers() []StructurePlacer
+}
+
+// StructurePlacer decides in which chunks structures of one kind start.
+type StructurePlacer interface {
+	// Radius returns the maximum distance in chunks that a structure started
+	// by the StructurePlacer may extend from the chunk that it started in.
+	Radius() int
+	// Start returns the structures that start in the chunk at the position
+	// passed in a World with the seed passed. Start is called for every
+	// chunk within Radius of a chunk generated, so it must return the same
+	// structures every time it is called for the same chunk and seed. It
+	// should therefore only use GenerationRand to make random decisions.
+	Start(pos ChunkPos, seed int64) []StructureStart
+}
+
+// StructureStart is a structure started by a StructurePlacer in a chunk.
+type StructureStart interface {
+	// Bounds returns the minimum and maximum block positions that the
+	// structure may occupy. Place is only called for chunks that overlap
+	// with these bounds.
+	Bounds() (min, max cube.Pos)
+	// Place places the structure using the GenerationContext passed. Blocks
+	// set outside the chunk that is being generated are ignored, so Place is
+	// called once for every chunk the structure overlaps, and must place the
+	// exact same structure every time it is called.
+	Place(ctx *GenerationContext)
+}
+
+// GenerationContext is passed to a StructureStart to place the part of the
+// structure that overlaps the chunk being generated. Blocks may be read in
+// the chunk and its eight neighbours, but only set in the chunk itself.
+type GenerationContext struct {
+	pos  ChunkPos
+	seed int64
+	area *DecorationArea
+}
+
+// Chunk returns the position of the chunk that is being generated.
+func (ctx *GenerationContext) Chunk() ChunkPos {
+	return ctx.pos
+}
+
+// Seed returns the seed of the World that the chunk is generated in.
+func (ctx *GenerationContext) Seed() int64 {
+	return ctx.seed
+}
+
+// Range returns the vertical range of the chunk that is being generated.
+func (ctx *GenerationContext) Range() cube.Range {
+	return ctx.area.Range()
+}
+
+// Contains checks if the block position passed is in the chunk that is being
+// generated, meaning a block may be set at that position.
+func (ctx *GenerationContext) Contains(pos cube.Pos) bool {
+	return chunkPosFromBlockPos(pos) == ctx.pos && !pos.OutOfBounds(ctx.Range())
+}
+
+// Block returns the runtime ID of the block at the position passed. If the
+// position is outside the chunk and its eight neighbours, the runtime ID of
+// air is returned.
+func (ctx *GenerationContext) Block(pos cube.Pos) uint32 {
+	return ctx.area.Block(pos)
+}
+
+// HighestBlock returns the Y value of the highest non-air block in the column
+// at x and z. If the column is outside the chunk and its eight neighbours,
+// the lowest Y value of the chunk is returned.
+func (ctx *GenerationContext) HighestBlock(x, z int) int {
+	return ctx.area.HighestBlock(x, z)
+}
+
+// SetBlock sets the block with the runtime ID passed at the position passed.
+// Nothing happens if the position is outside the chunk being generated.
+func (ctx *GenerationContext) SetBlock(pos cube.Pos, rid uint32) {
+	if ctx.Contains(pos) {
+		ctx.area.SetBlock(pos, rid)
+	}
+}
+
+// PlaceBlock sets the Block passed at the position passed, including the NBT
+// data of the Block if it has any. Nothing happens if the position is outside
+// the chunk being generated.
+func (ctx *GenerationContext) PlaceBlock(pos cube.Pos, b Block) {
+	if ctx.Contains(pos) {
+		ctx.area.PlaceBlock(pos, b)
+	}
+}
+
+// PlaceStructure places the part of the Structure passed that overlaps the
+// chunk being generated, with its lowest corner at the position passed.
+// Liquids returned by the Structure are placed in the same position as the
+// blocks returned, or in place of the block if it is nil. Blocks are placed
+// with their NBT data, like with PlaceBlock.
+func (ctx *GenerationContext) PlaceStructure(pos cube.Pos, s Structure) {
+	dim := s.Dimensions()
+	base := cube.Pos{int(ctx.pos[0]) << 4, 0, int(ctx.pos[1]) << 4}
+	minX, maxX := max(pos[0], base[0]), min(pos[0]+dim[0], base[0]+16)
+	minZ, maxZ := max(pos[2], base[2]), min(pos[2]+dim[2], base[2]+16)
+	minY, maxY := max(pos[1], ctx.Range().Min()), min(pos[1]+dim[1], ctx.Range().Max()+1)
+
+	blockAt := func(x, y, z int) Block {
+		return blockByRuntimeIDOrAir(ctx.Block(pos.Add(cube.Pos{x, y, z})))
+	}
+	for x := minX; x < maxX; x++ {
+		for y := minY; y < maxY; y++ {
+			for z := minZ; z < maxZ; z++ {
+				b, liq := s.At(x-pos[0], y-pos[1], z-pos[2], blockAt)
+				at := cube.Pos{x, y, z}
+				switch {
+				case b != nil:
+					ctx.PlaceBlock(at, b)
+					if liq != nil {
+						ctx.area.setLiquid(at, BlockRuntimeID(liq))
+					}
+				case liq != nil:
+					ctx.PlaceBlock(at, liq)
+				}
+			}
+		}
+	}
+}
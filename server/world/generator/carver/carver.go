@@ -0,0 +1,258 @@
+// Package carver implements a world.Generator that carves caves, ravines and
+// aquifers into the terrain generated by another world.Generator.
+package carver
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math/rand/v2"
+)
+
+const (
+	// lavaLevel is the Y value at and below which carved space is filled with
+	// lava if aquifers are enabled.
+	lavaLevel = -55
+	// aquiferSize is the width in blocks of the square regions that share a
+	// single aquifer water level.
+	aquiferSize = 64
+	// bedrockMargin is the amount of layers above the bottom of the world that
+	// are never carved, so that the bedrock floor stays intact.
+	bedrockMargin = 5
+)
+
+// Config holds the settings of a Carver. The zero value results in vanilla-
+// like caves and ravines with aquifers.
+type Config struct {
+	// Seed is the seed used to generate the noise and ravines of the Carver.
+	// It is usually the same as the seed of the base generator.
+	Seed int64
+	// Disabled disables carving entirely. The Carver then only generates the
+	// terrain of its base generator.
+	Disabled bool
+	// CaveFrequency scales the amount of cheese, spaghetti and noodle caves
+	// carved into the terrain. A value of 2 carves roughly twice as much as
+	// the default. If 0, a frequency of 1 is used. If negative, no noise
+	// caves are carved.
+	CaveFrequency float64
+	// RavineChance is the chance of a ravine starting in any chunk. If 0, a
+	// chance of 1/50 is used. If negative, no ravines are carved.
+	RavineChance float64
+	// DisableAquifers leaves all carved space filled with air, instead of
+	// filling space below the local water level with water and space at the
+	// bottom of the world with lava.
+	DisableAquifers bool
+}
+
+// New creates a Carver using the Config conf that carves the terrain
+// generated by the world.Generator base.
+func (conf Config) New(base world.Generator) *Carver {
+	if conf.CaveFrequency == 0 {
+		conf.CaveFrequency = 1
+	}
+	if conf.RavineChance == 0 {
+		conf.RavineChance = 1.0 / 50
+	}
+	seed := uint64(conf.Seed)
+	c := &Carver{
+		conf:  conf,
+		base:  base,
+		caves: newCaves(rand.New(rand.NewPCG(seed, seed^0x2545f4914f6cdd1d))),
+
+		carvable: make(map[uint32]struct{}),
+		air:      world.BlockRuntimeID(block.Air{}),
+		water:    world.BlockRuntimeID(block.Water{Still: true, Depth: 8}),
+		lava:     world.BlockRuntimeID(block.Lava{Still: true, Depth: 8}),
+	}
+	for _, b := range carvableBlocks() {
+		c.carvable[world.BlockRuntimeID(b)] = struct{}{}
+	}
+	return c
+}
+
+// Carver is a world.Generator that carves caves and ravines into the terrain
+// of a base world.Generator, after the base generator has generated a chunk.
+// Like the terrain of the base generator, the caves carved are fully
+// determined by the seed of the Carver and never depend on neighbouring
+// chunks. A Carver may be created using Config.New and is safe for concurrent
+// use if its base generator is.
+//
+// Caves are only carved through natural stone, dirt and sand blocks, and
+// never through blocks that support other blocks, such as the ground below
+// plants and trees. Carved space below the water level of the aquifer it is
+// in is filled with water, and carved space close to the bottom of the world
+// is filled with lava.
+type Carver struct {
+	conf  Config
+	base  world.Generator
+	caves caves
+
+	carvable         map[uint32]struct{}
+	air, water, lava uint32
+}
+
+// Base returns the world.Generator that generates the terrain carved by the
+// Carver.
+func (c *Carver) Base() world.Generator {
+	return c.base
+}
+
+// GenerateChunk generates the chunk at the position passed using the base
+// generator and carves caves and ravines into it.
+func (c *Carver) GenerateChunk(pos world.ChunkPos, ch *chunk.Chunk) {
+	c.base.GenerateChunk(pos, ch)
+	if c.conf.Disabled {
+		return
+	}
+	m := newMask(ch.Range())
+	if c.conf.CaveFrequency > 0 {
+		c.caves.carve(m, pos, ch, c.conf.CaveFrequency)
+	}
+	if c.conf.RavineChance > 0 {
+		c.carveRavines(m, pos)
+	}
+	c.apply(m, pos, ch)
+}
+
+// DecorateChunk decorates the chunk at the position passed using the base
+// generator, if it is a world.Decorator. Decoration happens after carving, so
+// features such as trees are placed on the carved terrain.
+func (c *Carver) DecorateChunk(pos world.ChunkPos, area *world.DecorationArea) {
+	if d, ok := c.base.(world.Decorator); ok {
+		d.DecorateChunk(pos, area)
+	}
+}
+
+// StructurePlacers returns the world.StructurePlacers of the base generator,
+// if it is a world.StructureGenerator. Structures are placed after carving.
+func (c *Carver) StructurePlacers() []world.StructurePlacer {
+	if g, ok := c.base.(world.StructureGenerator); ok {
+		return g.StructurePlacers()
+	}
+	return nil
+}
+
+// apply replaces all blocks of the chunk set in the mask m with air, water or
+// lava. The columns are carved top-down, so that a block is only carved if
+// the block above it was either carved too or is not supported by it.
+// Heightmaps are updated by the chunk as blocks are set, so they remain
+// consistent with the carved terrain.
+func (c *Carver) apply(m *mask, pos world.ChunkPos, ch *chunk.Chunk) {
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	minY, maxY := int16(ch.Range().Min()), int16(ch.Range().Max())
+	for x := range uint8(16) {
+		for z := range uint8(16) {
+			carvedAbove, level := false, c.aquiferLevel(baseX+int(x), baseZ+int(z))
+			for y := maxY; y >= minY+bedrockMargin; y-- {
+				if !m.carved(x, y, z) || !c.canCarve(ch.Block(x, y, z, 0)) {
+					carvedAbove = false
+					continue
+				}
+				fill := c.fill(int(y), level)
+				if !carvedAbove && y < maxY {
+					above := ch.Block(x, y+1, z, 0)
+					if above != c.air && !c.canCarve(above) && (fill == c.air || !isLiquid(above)) {
+						// The block above is not part of the terrain, like
+						// a plant or the trunk of a tree, or is a liquid
+						// that would flow into the cave.
+						carvedAbove = false
+						continue
+					}
+				}
+				if fill == c.air && liquidBeside(ch, x, y, z) {
+					carvedAbove = false
+					continue
+				}
+				ch.SetBlock(x, y, z, 0, fill)
+				carvedAbove = true
+			}
+		}
+	}
+}
+
+// canCarve checks if the block with the runtime ID passed may be carved.
+func (c *Carver) canCarve(rid uint32) bool {
+	_, ok := c.carvable[rid]
+	return ok
+}
+
+// fill returns the block that carved space at the Y value passed is filled
+// with, in a column with the aquifer level passed.
+func (c *Carver) fill(y, level int) uint32 {
+	switch {
+	case c.conf.DisableAquifers:
+		return c.air
+	case y <= lavaLevel:
+		return c.lava
+	case y <= level:
+		return c.water
+	}
+	return c.air
+}
+
+// aquiferLevel returns the water level of the aquifer that the column at x, z
+// is in. Every aquifer spans a square region of aquiferSize blocks, so that
+// the water in a cave has a flat surface. Some regions have no aquifer at
+// all, in which case a level below the lava level is returned.
+func (c *Carver) aquiferLevel(x, z int) int {
+	rx, rz := floorDiv(x, aquiferSize), floorDiv(z, aquiferSize)
+	r := c.regionRand(rx, rz, 0x41515549)
+	if r.IntN(5) < 3 {
+		return lavaLevel
+	}
+	return -48 + r.IntN(72)
+}
+
+// regionRand returns a random source for the region at rx, rz, derived from
+// the seed of the Carver and the salt passed, so that different features do
+// not share the same random values.
+func (c *Carver) regionRand(rx, rz int, salt uint64) *rand.Rand {
+	h := uint64(rx)*0x9e3779b97f4a7c15 ^ uint64(rz)*0xc2b2ae3d27d4eb4f
+	return rand.New(rand.NewPCG(uint64(c.conf.Seed)^salt, h))
+}
+
+// liquidBeside checks if any of the horizontal neighbours of the block at x,
+// y, z inside the chunk is a liquid, which would flow into the block if it
+// were carved.
+func liquidBeside(ch *chunk.Chunk, x uint8, y int16, z uint8) bool {
+	return (x > 0 && isLiquid(ch.Block(x-1, y, z, 0))) ||
+		(x < 15 && isLiquid(ch.Block(x+1, y, z, 0))) ||
+		(z > 0 && isLiquid(ch.Block(x, y, z-1, 0))) ||
+		(z < 15 && isLiquid(ch.Block(x, y, z+1, 0)))
+}
+
+// isLiquid checks if the block with the runtime ID passed is a liquid.
+func isLiquid(rid uint32) bool {
+	b, ok := world.BlockByRuntimeID(rid)
+	if !ok {
+		return false
+	}
+	_, liquid := b.(world.Liquid)
+	return liquid
+}
+
+// carvableBlocks returns all blocks that caves and ravines may be carved
+// through.
+func carvableBlocks() []world.Block {
+	blocks := []world.Block{
+		block.Stone{}, block.Deepslate{}, block.Granite{}, block.Diorite{}, block.Andesite{}, block.Tuff{},
+		block.Dirt{}, block.Dirt{Coarse: true}, block.Grass{}, block.Gravel{}, block.Sand{}, block.Sand{Red: true},
+		block.Sandstone{}, block.Terracotta{}, block.Calcite{},
+	}
+	for _, t := range []block.OreType{block.StoneOre(), block.DeepslateOre()} {
+		blocks = append(blocks,
+			block.CoalOre{Type: t}, block.IronOre{Type: t}, block.CopperOre{Type: t}, block.GoldOre{Type: t},
+			block.LapisOre{Type: t}, block.DiamondOre{Type: t}, block.EmeraldOre{Type: t},
+		)
+	}
+	return blocks
+}
+
+// floorDiv divides a by b, rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
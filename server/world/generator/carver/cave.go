@@ -0,0 +1,113 @@
+package carver
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/generator/internal/noise"
+	"math"
+	"math/rand/v2"
+)
+
+const (
+	// cellWidth and cellHeight are the size of the cells in which cave noise
+	// is interpolated.
+	cellWidth, cellHeight = 4, 4
+	// cheeseThreshold is the value above which cheese noise carves a cave at a
+	// cave frequency of 1.
+	cheeseThreshold = 0.36
+	// spaghettiWidth and noodleWidth are the distances from 0 within which
+	// both noise values of spaghetti and noodle caves carve a tunnel at a
+	// cave frequency of 1.
+	spaghettiWidth, noodleWidth = 0.05, 0.03
+	// surfaceMargin is the amount of blocks below the surface within which
+	// cheese caves become smaller, so that large caverns don't break through
+	// the surface.
+	surfaceMargin = 24
+)
+
+// caves holds the noise used to carve the three kinds of noise caves:
+// Cheese caves are large caverns, spaghetti caves long winding tunnels and
+// noodle caves thin tunnels between them.
+type caves struct {
+	cheese                 noise.Octaves
+	spaghettiA, spaghettiB noise.Octaves
+	noodleA, noodleB       noise.Octaves
+}
+
+// newCaves creates the cave noise using the random source passed.
+func newCaves(r *rand.Rand) caves {
+	return caves{
+		cheese:     noise.NewOctaves(r, 3, 1.0/96),
+		spaghettiA: noise.NewOctaves(r, 2, 1.0/80),
+		spaghettiB: noise.NewOctaves(r, 2, 1.0/80),
+		noodleA:    noise.NewOctaves(r, 1, 1.0/36),
+		noodleB:    noise.NewOctaves(r, 1, 1.0/36),
+	}
+}
+
+// carve marks all blocks of the chunk at the position passed that are part of
+// a noise cave in the mask m. frequency scales the amount of blocks carved.
+func (cv caves) carve(m *mask, pos world.ChunkPos, ch *chunk.Chunk, frequency float64) {
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	minY, maxY := ch.Range().Min(), ch.Range().Max()
+	grid := func(o noise.Octaves, stretch float64) *noise.Grid {
+		return noise.NewGrid(baseX, baseZ, minY, maxY, cellWidth, cellHeight, func(x, y, z float64) float64 {
+			return o.At3(x, y*stretch, z)
+		})
+	}
+	// Cheese caves are stretched horizontally, so that caverns are wider
+	// than they are high.
+	cheese := grid(cv.cheese, 1.6)
+	spaghettiA, spaghettiB := grid(cv.spaghettiA, 1), grid(cv.spaghettiB, 1)
+	noodleA, noodleB := grid(cv.noodleA, 1), grid(cv.noodleB, 1)
+
+	threshold := cheeseThreshold / frequency
+	spaghetti, noodle := spaghettiWidth*frequency, noodleWidth*frequency
+	for x := range 16 {
+		for z := range 16 {
+			surface := int(ch.HighestBlock(uint8(x), uint8(z)))
+			for y := minY + bedrockMargin; y <= surface; y++ {
+				bias := noise.Smoothstep(float64(surface-surfaceMargin), float64(surface), float64(y))
+				switch {
+				case cheese.At(x, y, z) > threshold+bias:
+				case math.Abs(spaghettiA.At(x, y, z)) < spaghetti && math.Abs(spaghettiB.At(x, y, z)) < spaghetti:
+				case math.Abs(noodleA.At(x, y, z)) < noodle && math.Abs(noodleB.At(x, y, z)) < noodle:
+				default:
+					continue
+				}
+				m.set(x, y, z)
+			}
+		}
+	}
+}
+
+// mask holds, for every block in a chunk, whether it should be carved.
+type mask struct {
+	minY, height int
+	carve        []bool
+}
+
+// newMask creates an empty mask for a chunk with the range passed.
+func newMask(r cube.Range) *mask {
+	return &mask{minY: r.Min(), height: r.Height() + 1, carve: make([]bool, 256*(r.Height()+1))}
+}
+
+// set marks the block at x, y, z as carved, with x and z relative to the
+// chunk. Positions outside the chunk are ignored.
+func (m *mask) set(x, y, z int) {
+	if x < 0 || x > 15 || z < 0 || z > 15 || y < m.minY || y >= m.minY+m.height {
+		return
+	}
+	m.carve[m.index(x, y, z)] = true
+}
+
+// carved checks if the block at x, y, z was marked as carved.
+func (m *mask) carved(x uint8, y int16, z uint8) bool {
+	return m.carve[m.index(int(x), int(y), int(z))]
+}
+
+// index returns the index of the block at x, y, z in the mask.
+func (m *mask) index(x, y, z int) int {
+	return (x<<4|z)*m.height + y - m.minY
+}
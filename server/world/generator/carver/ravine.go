@@ -0,0 +1,71 @@
+package carver
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"math"
+)
+
+const (
+	// ravineRadius is the distance in chunks from which ravines may reach a
+	// chunk. It is large enough for the longest ravine plus its width.
+	ravineRadius = 7
+	// ravineMinLength and ravineExtraLength define the length of a ravine in
+	// steps of one block.
+	ravineMinLength, ravineExtraLength = 64, 32
+	// ravineStretch is how many times higher a ravine is than it is wide.
+	ravineStretch = 2.5
+)
+
+// carveRavines marks all blocks of the chunk at the position passed that are
+// part of a ravine in the mask m. Ravines are random walks that may start in
+// any chunk in the ravineRadius around the chunk. Because the path of every
+// ravine only depends on the chunk it starts in, the parts of a ravine carved
+// in different chunks always line up.
+func (c *Carver) carveRavines(m *mask, pos world.ChunkPos) {
+	baseX, baseZ := float64(int(pos[0])<<4), float64(int(pos[1])<<4)
+	for sx := int(pos[0]) - ravineRadius; sx <= int(pos[0])+ravineRadius; sx++ {
+		for sz := int(pos[1]) - ravineRadius; sz <= int(pos[1])+ravineRadius; sz++ {
+			r := c.regionRand(sx, sz, 0x52415649)
+			if r.Float64() >= c.conf.RavineChance {
+				continue
+			}
+			x, y, z := float64(sx<<4+r.IntN(16)), float64(20+r.IntN(48)), float64(sz<<4+r.IntN(16))
+			yaw, pitch := r.Float64()*math.Pi*2, (r.Float64()-0.5)/4
+			width := (r.Float64()*2 + r.Float64()) * 2
+			length := ravineMinLength + r.IntN(ravineExtraLength+1)
+			for step := range length {
+				// Ravines are narrow at both ends and widest in the middle.
+				radius := 1.5 + math.Sin(float64(step)*math.Pi/float64(length))*width
+				x += math.Cos(yaw) * math.Cos(pitch)
+				y += math.Sin(pitch)
+				z += math.Sin(yaw) * math.Cos(pitch)
+				yaw += (r.Float64() - r.Float64()) * 0.1
+				pitch = pitch*0.7 + (r.Float64()-r.Float64())*0.1
+
+				if x+radius < baseX || x-radius >= baseX+16 || z+radius < baseZ || z-radius >= baseZ+16 {
+					continue
+				}
+				carveEllipsoid(m, x-baseX, y, z-baseZ, radius, radius*ravineStretch)
+			}
+		}
+	}
+}
+
+// carveEllipsoid marks all blocks inside the ellipsoid at x, y, z with the
+// horizontal and vertical radii passed in the mask m. x and z are relative to
+// the chunk of the mask.
+func carveEllipsoid(m *mask, x, y, z, horizontal, vertical float64) {
+	for bx := int(math.Floor(x - horizontal)); bx <= int(math.Ceil(x+horizontal)); bx++ {
+		for bz := int(math.Floor(z - horizontal)); bz <= int(math.Ceil(z+horizontal)); bz++ {
+			dx, dz := (float64(bx)+0.5-x)/horizontal, (float64(bz)+0.5-z)/horizontal
+			if dx*dx+dz*dz >= 1 {
+				continue
+			}
+			for by := int(math.Floor(y - vertical)); by <= int(math.Ceil(y+vertical)); by++ {
+				if dy := (float64(by) + 0.5 - y) / vertical; dx*dx+dy*dy+dz*dz < 1 {
+					m.set(bx, by, bz)
+				}
+			}
+		}
+	}
+}
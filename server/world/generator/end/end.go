@@ -0,0 +1,252 @@
+// Package end implements a world.Generator that generates vanilla-like End
+// terrain: The main island with its ring of obsidian pillars, surrounded by a
+// void and the outer islands beyond it.
+package end
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/generator/internal/noise"
+	"math"
+	"math/rand/v2"
+)
+
+const (
+	// islandY is the Y value around which the islands of the End are
+	// generated.
+	islandY = 56
+	// outerIslandDistance is the distance in chunks from the centre of the
+	// End beyond which outer islands may generate. Between the main island
+	// and this distance is a void.
+	outerIslandDistance = 64
+	// islandFrequency is the frequency at which the island noise is sampled
+	// for every chunk. Neighbouring chunks have similar values, so that outer
+	// islands generate in clusters.
+	islandFrequency = 0.37
+	// cellWidth and cellHeight are the size of the cells in which the
+	// density of the terrain is interpolated.
+	cellWidth, cellHeight = 8, 4
+)
+
+// Generator generates vanilla-like End terrain. The terrain generated is
+// fully determined by the seed the Generator is created with, so the same
+// seed always generates the same End. A Generator may be constructed using
+// New and is safe for concurrent use.
+//
+// The main island is generated around the centre of the End, with the
+// obsidian pillars returned by Pillars placed in a ring on top of it. Outer
+// islands decorated with chorus plants are generated beyond roughly 1000
+// blocks from the centre. The exit portal is not generated, as it is only
+// placed once the dragon fight starts, using PlaceExitPortal.
+type Generator struct {
+	seed int64
+	// minY and maxY are the bounds of the End dimension.
+	minY, maxY int16
+
+	islands *noise.Perlin
+	terrain noise.Octaves
+	pillars []Pillar
+
+	air, endStone, chorusPlant, chorusFlower, biome uint32
+}
+
+// New creates a Generator that generates terrain using the seed passed.
+func New(seed int64) *Generator {
+	r := rand.New(rand.NewPCG(uint64(seed), uint64(seed)^0x656e64))
+	rng := world.End.Range()
+	return &Generator{
+		seed: seed,
+		minY: int16(rng.Min()),
+		maxY: int16(rng.Max()),
+
+		islands: noise.NewPerlin(r),
+		terrain: noise.NewOctaves(r, 3, 1.0/48),
+		pillars: Pillars(seed),
+
+		air:          world.BlockRuntimeID(block.Air{}),
+		endStone:     world.BlockRuntimeID(block.EndStone{}),
+		chorusPlant:  world.BlockRuntimeID(block.ChorusPlant{}),
+		chorusFlower: world.BlockRuntimeID(block.ChorusFlower{Age: 5}),
+		biome:        uint32(biome.End{}.EncodeBiome()),
+	}
+}
+
+// Seed returns the seed that the Generator was created with.
+func (g *Generator) Seed() int64 {
+	return g.seed
+}
+
+// Pillars returns the obsidian pillars generated on the main island. The
+// pillars are the same as those returned by the Pillars function for the
+// seed of the Generator.
+func (g *Generator) Pillars() []Pillar {
+	return append([]Pillar(nil), g.pillars...)
+}
+
+// GenerateChunk generates the terrain of the chunk at the position passed.
+func (g *Generator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	r := g.chunkRand(pos)
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+
+	// The island density only depends on x and z, so it is computed once for
+	// every column of corners of the cells.
+	islands := make(map[[2]int]float64, 9)
+	grid := noise.NewGrid(baseX, baseZ, int(g.minY), int(g.maxY), cellWidth, cellHeight, func(x, y, z float64) float64 {
+		key := [2]int{int(x), int(z)}
+		island, ok := islands[key]
+		if !ok {
+			island = g.island(int(x), int(z))
+			islands[key] = island
+		}
+		return g.density(island, x, y, z)
+	})
+	for x := range uint8(16) {
+		for z := range uint8(16) {
+			for y := g.minY; y <= g.maxY; y++ {
+				if grid.At(int(x), int(y), int(z)) > 0 {
+					c.SetBlock(x, y, z, 0, g.endStone)
+				}
+				c.SetBiome(x, y, z, g.biome)
+			}
+		}
+	}
+	g.placePillars(c, baseX, baseZ)
+	if pos[0]*pos[0]+pos[1]*pos[1] > outerIslandDistance*outerIslandDistance {
+		g.decorate(c, r)
+	}
+}
+
+// chunkRand returns a random source for the chunk at the position passed,
+// derived from the seed of the Generator.
+func (g *Generator) chunkRand(pos world.ChunkPos) *rand.Rand {
+	h := uint64(pos[0])*0x9e3779b97f4a7c15 ^ uint64(pos[1])*0xc2b2ae3d27d4eb4f
+	return rand.New(rand.NewPCG(uint64(g.seed)^0x656e64, h))
+}
+
+// island returns the island density at x, z, ranging from -100 to 80. It is
+// positive on the main island and on the outer islands, which are scattered
+// around the End using the island noise, and negative in the void between
+// them.
+func (g *Generator) island(x, z int) float64 {
+	i, j := floorDiv(x, 8), floorDiv(z, 8)
+	k, l := floorDiv(i, 2), floorDiv(j, 2)
+	m, n := i-k*2, j-l*2
+
+	f := clampIsland(100 - math.Sqrt(float64(i*i+j*j))*8)
+	for o := -12; o <= 12; o++ {
+		for p := -12; p <= 12; p++ {
+			q, r := k+o, l+p
+			if q*q+r*r <= outerIslandDistance*outerIslandDistance || g.islands.At(float64(q)*islandFrequency, float64(r)*islandFrequency) >= -0.65 {
+				continue
+			}
+			// Every outer island has a size derived from its position, so
+			// that it changes steeply or slowly towards its edges.
+			size := float64((abs(q)*3439+abs(r)*147)%13 + 9)
+			s, t := float64(m-o*2), float64(n-p*2)
+			f = max(f, clampIsland(100-math.Sqrt(s*s+t*t)*size))
+		}
+	}
+	return f
+}
+
+// density returns the density of the terrain at x, y, z with the island
+// density passed. Blocks with a positive density are solid. The islands are
+// shaped like lenses around islandY, with a flat top and a deep bottom.
+func (g *Generator) density(island, x, y, z float64) float64 {
+	d := (island-8)/128 + g.terrain.At3(x, y, z)*0.08
+	if y > islandY {
+		d -= (y - islandY) / 32
+	} else {
+		d -= (islandY - y) / 64
+	}
+	return d
+}
+
+// placePillars places the parts of the obsidian pillars that are inside the
+// chunk with its lowest X and Z values at baseX and baseZ.
+func (g *Generator) placePillars(c *chunk.Chunk, baseX, baseZ int) {
+	for _, p := range g.pillars {
+		minX, minZ, maxX, maxZ := p.bounds()
+		minX, minZ = max(minX, baseX), max(minZ, baseZ)
+		maxX, maxZ = min(maxX, baseX+15), min(maxZ, baseZ+15)
+		for x := minX; x <= maxX; x++ {
+			for z := minZ; z <= maxZ; z++ {
+				for y := int(g.minY); y <= min(p.Height+10, int(g.maxY)); y++ {
+					if b, ok := p.blockAt(x, y, z); ok {
+						c.SetBlock(uint8(x-baseX), int16(y), uint8(z-baseZ), 0, world.BlockRuntimeID(b))
+					}
+				}
+			}
+		}
+	}
+}
+
+// decorate grows chorus plants on the end stone of the outer islands in the
+// chunk passed.
+func (g *Generator) decorate(c *chunk.Chunk, r *rand.Rand) {
+	for range r.IntN(5) {
+		x, z := uint8(4+r.IntN(8)), uint8(4+r.IntN(8))
+		y := g.maxY
+		for y > g.minY && c.Block(x, y, z, 0) == g.air {
+			y--
+		}
+		if c.Block(x, y, z, 0) != g.endStone || y+1 >= g.maxY {
+			continue
+		}
+		g.growChorusPlant(c, x, y+1, z, 0, r)
+	}
+}
+
+// growChorusPlant grows a stem of a chorus plant starting at x, y, z, which
+// may branch out into further stems. depth is the amount of times the plant
+// has branched out before. The stem ends with a chorus flower.
+func (g *Generator) growChorusPlant(c *chunk.Chunk, x uint8, y int16, z uint8, depth int, r *rand.Rand) {
+	height := int16(1 + r.IntN(4))
+	if depth == 0 {
+		height++
+	}
+	for i := range height {
+		if y+i+1 >= g.maxY || c.Block(x, y+i, z, 0) != g.air {
+			return
+		}
+		c.SetBlock(x, y+i, z, 0, g.chorusPlant)
+	}
+	top := y + height - 1
+
+	branched := false
+	if depth < 4 {
+		for _, off := range [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			if r.IntN(depth+2) != 0 {
+				continue
+			}
+			bx, bz := int(x)+off[0], int(z)+off[1]
+			// Branches are kept inside the chunk, as the Generator may not
+			// place blocks in neighbouring chunks.
+			if bx < 0 || bx > 15 || bz < 0 || bz > 15 || c.Block(uint8(bx), top, uint8(bz), 0) != g.air {
+				continue
+			}
+			c.SetBlock(uint8(bx), top, uint8(bz), 0, g.chorusPlant)
+			g.growChorusPlant(c, uint8(bx), top+1, uint8(bz), depth+1, r)
+			branched = true
+		}
+	}
+	if !branched && top+1 < g.maxY && c.Block(x, top+1, z, 0) == g.air {
+		c.SetBlock(x, top+1, z, 0, g.chorusFlower)
+	}
+}
+
+// clampIsland clamps an island density to the range -100 to 80.
+func clampIsland(f float64) float64 {
+	return min(max(f, -100), 80)
+}
+
+// floorDiv divides a by b, rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
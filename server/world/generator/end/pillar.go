@@ -0,0 +1,122 @@
+package end
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+)
+
+const (
+	// pillarCount is the amount of obsidian pillars on the main island.
+	pillarCount = 10
+	// pillarDistance is the distance of the obsidian pillars from the centre
+	// of the main island.
+	pillarDistance = 42
+)
+
+// Pillar is one of the obsidian pillars placed in a ring around the centre of
+// the main island of the End. An end crystal is placed on top of every
+// pillar, which heals the ender dragon during the dragon fight.
+type Pillar struct {
+	// X and Z are the coordinates of the centre of the pillar.
+	X, Z int
+	// Radius is the radius of the pillar and Height the Y value of the top
+	// of the pillar, at which a block of bedrock is placed.
+	Radius, Height int
+	// Guarded is true if the top of the pillar is surrounded by a cage of
+	// iron bars that protects its end crystal.
+	Guarded bool
+}
+
+// Pillars returns the obsidian pillars of the End generated using the seed
+// passed. The positions of the pillars are the same for every seed, but
+// their sizes are shuffled using the seed, so the same seed always results
+// in the same pillars.
+func Pillars(seed int64) []Pillar {
+	sizes := make([]int, pillarCount)
+	for i := range sizes {
+		sizes[i] = i
+	}
+	r := rand.New(rand.NewPCG(uint64(seed), 0x70696c6c6172))
+	r.Shuffle(len(sizes), func(i, j int) {
+		sizes[i], sizes[j] = sizes[j], sizes[i]
+	})
+
+	pillars := make([]Pillar, pillarCount)
+	for i, size := range sizes {
+		angle := 2 * (-math.Pi + math.Pi/pillarCount*float64(i))
+		pillars[i] = Pillar{
+			X:       int(math.Floor(pillarDistance * math.Cos(angle))),
+			Z:       int(math.Floor(pillarDistance * math.Sin(angle))),
+			Radius:  2 + size/3,
+			Height:  76 + size*3,
+			Guarded: size == 1 || size == 2,
+		}
+	}
+	return pillars
+}
+
+// Top returns the position of the bedrock block on top of the pillar.
+func (p Pillar) Top() cube.Pos {
+	return cube.Pos{p.X, p.Height, p.Z}
+}
+
+// CrystalPosition returns the position at which the end crystal of the
+// pillar is placed.
+func (p Pillar) CrystalPosition() mgl64.Vec3 {
+	return mgl64.Vec3{float64(p.X) + 0.5, float64(p.Height + 1), float64(p.Z) + 0.5}
+}
+
+// Place places the pillar in the transaction passed. Place may be used to
+// rebuild the pillars when the ender dragon is respawned. It does not spawn
+// the end crystal of the pillar.
+func (p Pillar) Place(tx *world.Tx) {
+	minX, minZ, maxX, maxZ := p.bounds()
+	for x := minX; x <= maxX; x++ {
+		for z := minZ; z <= maxZ; z++ {
+			for y := tx.Range().Min(); y <= p.Height+10; y++ {
+				if b, ok := p.blockAt(x, y, z); ok {
+					tx.SetBlock(cube.Pos{x, y, z}, b, nil)
+				}
+			}
+		}
+	}
+}
+
+// bounds returns the minimum and maximum X and Z values of the blocks of the
+// pillar, including the cage of a guarded pillar.
+func (p Pillar) bounds() (minX, minZ, maxX, maxZ int) {
+	r := max(p.Radius, 2)
+	return p.X - r, p.Z - r, p.X + r, p.Z + r
+}
+
+// blockAt returns the block of the pillar at x, y, z. If the pillar does not
+// change the block at that position, false is returned.
+func (p Pillar) blockAt(x, y, z int) (world.Block, bool) {
+	dx, dz := x-p.X, z-p.Z
+	switch {
+	case dx == 0 && dz == 0 && y == p.Height:
+		return block.Bedrock{}, true
+	case p.Guarded && y >= p.Height && y <= p.Height+3 && abs(dx) <= 2 && abs(dz) <= 2 && (abs(dx) == 2 || abs(dz) == 2 || y == p.Height+3):
+		return block.IronBars{}, true
+	case dx*dx+dz*dz > p.Radius*p.Radius+1:
+		return nil, false
+	case y < p.Height:
+		return block.Obsidian{}, true
+	case y > 65:
+		// The pillar clears any terrain above it.
+		return block.Air{}, true
+	}
+	return nil, false
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,79 @@
+package end
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"math"
+	"math/rand/v2"
+)
+
+const (
+	// gatewayCount is the amount of end gateways that may be opened around
+	// the main island, one for every defeated ender dragon.
+	gatewayCount = 20
+	// gatewayDistance is the distance of the end gateways from the centre of
+	// the main island, and gatewayY the Y value they are placed at.
+	gatewayDistance, gatewayY = 96, 75
+)
+
+// PlaceExitPortal places the exit portal at the centre of the main island,
+// with its centre at the position passed. If active is true, the exit portal
+// is filled with end portal blocks. Placing an inactive exit portal over an
+// active one deactivates it.
+func PlaceExitPortal(tx *world.Tx, origin cube.Pos, active bool) {
+	for x := -4; x <= 4; x++ {
+		for z := -4; z <= 4; z++ {
+			for y := -1; y <= 32; y++ {
+				// Distances are compared multiplied by 4, so that the radii of
+				// 2.5 and 3.5 blocks may be compared using integers.
+				dist := (x*x + y*y + z*z) * 4
+				inside := dist < 25
+				if !inside && dist >= 49 {
+					continue
+				}
+				var b world.Block
+				switch {
+				case y < 0 && inside:
+					b = block.Bedrock{}
+				case y < 0:
+					b = block.EndStone{}
+				case y > 0:
+					b = nil
+				case !inside:
+					b = block.Bedrock{}
+				case active:
+					b = block.EndPortal{}
+				}
+				tx.SetBlock(origin.Add(cube.Pos{x, y, z}), b, nil)
+			}
+		}
+	}
+	for y := 0; y < 4; y++ {
+		tx.SetBlock(origin.Add(cube.Pos{0, y, 0}), block.Bedrock{}, nil)
+	}
+	torch := origin.Add(cube.Pos{0, 2, 0})
+	for _, face := range cube.HorizontalFaces() {
+		tx.SetBlock(torch.Side(face), block.Torch{Facing: face.Opposite(), Type: block.NormalFire()}, nil)
+	}
+}
+
+// Gateways returns the positions of the end gateways around the main island
+// of the End, in the order in which they are opened when ender dragons are
+// defeated. Like the pillars, the order depends on the seed passed.
+func Gateways(seed int64) []cube.Pos {
+	gateways := make([]cube.Pos, gatewayCount)
+	for i := range gateways {
+		angle := 2 * (-math.Pi + math.Pi/gatewayCount*float64(i))
+		gateways[i] = cube.Pos{
+			int(math.Floor(gatewayDistance * math.Cos(angle))),
+			gatewayY,
+			int(math.Floor(gatewayDistance * math.Sin(angle))),
+		}
+	}
+	r := rand.New(rand.NewPCG(uint64(seed), 0x67617465776179))
+	r.Shuffle(len(gateways), func(i, j int) {
+		gateways[i], gateways[j] = gateways[j], gateways[i]
+	})
+	return gateways
+}
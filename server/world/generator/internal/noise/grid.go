@@ -0,0 +1,56 @@
+package noise
+
+// Grid holds the density of terrain sampled at the corners of cells in a
+// chunk. The density of the blocks inside of a cell is interpolated between
+// those corners, which is much cheaper than sampling noise for every block.
+type Grid struct {
+	width, height   int
+	cellsXZ, cellsY int
+	minY            int
+	values          []float64
+}
+
+// NewGrid creates a Grid for the chunk with its lowest X and Z values at
+// baseX and baseZ, spanning from minY to maxY. The density function f is
+// sampled at the corners of cells of the width and height passed. width must
+// be a divisor of 16 and height a divisor of maxY-minY+1.
+func NewGrid(baseX, baseZ, minY, maxY, width, height int, f func(x, y, z float64) float64) *Grid {
+	g := &Grid{
+		width: width, height: height,
+		cellsXZ: 16/width + 1, cellsY: (maxY-minY+1)/height + 1,
+		minY: minY,
+	}
+	g.values = make([]float64, g.cellsXZ*g.cellsY*g.cellsXZ)
+	for cx := range g.cellsXZ {
+		for cz := range g.cellsXZ {
+			for cy := range g.cellsY {
+				g.values[g.index(cx, cy, cz)] = f(float64(baseX+cx*width), float64(minY+cy*height), float64(baseZ+cz*width))
+			}
+		}
+	}
+	return g
+}
+
+// At returns the interpolated density at x, y and z, where x and z are
+// relative to the chunk of the Grid and range from 0 to 15.
+func (g *Grid) At(x, y, z int) float64 {
+	y -= g.minY
+	cx, cy, cz := x/g.width, y/g.height, z/g.width
+	tx := float64(x%g.width) / float64(g.width)
+	ty := float64(y%g.height) / float64(g.height)
+	tz := float64(z%g.width) / float64(g.width)
+
+	plane := func(cy int) float64 {
+		return Lerp(tz,
+			Lerp(tx, g.values[g.index(cx, cy, cz)], g.values[g.index(cx+1, cy, cz)]),
+			Lerp(tx, g.values[g.index(cx, cy, cz+1)], g.values[g.index(cx+1, cy, cz+1)]),
+		)
+	}
+	return Lerp(ty, plane(cy), plane(cy+1))
+}
+
+// index returns the index in the values of the Grid of the corner at cx, cy,
+// cz.
+func (g *Grid) index(cx, cy, cz int) int {
+	return (cx*g.cellsY+cy)*g.cellsXZ + cz
+}
@@ -0,0 +1,198 @@
+// Package noise implements the seeded noise functions and interpolation
+// helpers shared by the terrain generators.
+package noise
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// Perlin is a 2D and 3D gradient noise function as described by Ken Perlin.
+// The permutation table is shuffled using a seed, so that different seeds
+// produce different noise. A Perlin is immutable once created and may be
+// sampled from multiple goroutines at the same time.
+type Perlin struct {
+	perm [512]uint8
+	// offX, offY and offZ offset the coordinates sampled so that noise
+	// functions with the same permutation do not all return 0 at the origin.
+	offX, offY, offZ float64
+}
+
+// NewPerlin creates a Perlin noise function using the random source passed
+// to shuffle its permutation table.
+func NewPerlin(r *rand.Rand) *Perlin {
+	p := &Perlin{offX: r.Float64() * 256, offY: r.Float64() * 256, offZ: r.Float64() * 256}
+	for i := range 256 {
+		p.perm[i] = uint8(i)
+	}
+	r.Shuffle(256, func(i, j int) {
+		p.perm[i], p.perm[j] = p.perm[j], p.perm[i]
+	})
+	copy(p.perm[256:], p.perm[:256])
+	return p
+}
+
+// gradients2D holds the gradients used by Perlin.At. All gradients have the
+// same length, so that no axis is favoured.
+var gradients2D = [8][2]float64{
+	{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+	{math.Sqrt2 / 2, math.Sqrt2 / 2}, {-math.Sqrt2 / 2, math.Sqrt2 / 2},
+	{math.Sqrt2 / 2, -math.Sqrt2 / 2}, {-math.Sqrt2 / 2, -math.Sqrt2 / 2},
+}
+
+// gradients3D holds the gradients used by Perlin.At3: The vectors from the
+// centre of a cube to the middle of its 12 edges, with 4 of them repeated so
+// that a gradient may be selected using the lowest 4 bits of a hash.
+var gradients3D = [16][3]float64{
+	{1, 1, 0}, {-1, 1, 0}, {1, -1, 0}, {-1, -1, 0},
+	{1, 0, 1}, {-1, 0, 1}, {1, 0, -1}, {-1, 0, -1},
+	{0, 1, 1}, {0, -1, 1}, {0, 1, -1}, {0, -1, -1},
+	{1, 1, 0}, {0, -1, 1}, {-1, 1, 0}, {0, -1, -1},
+}
+
+// At samples the noise at x and z. The value returned ranges roughly from -1
+// to 1.
+func (p *Perlin) At(x, z float64) float64 {
+	x, z = x+p.offX, z+p.offZ
+	fx, fz := math.Floor(x), math.Floor(z)
+	x, z = x-fx, z-fz
+	xi, zi := int(fx)&255, int(fz)&255
+
+	a, b := int(p.perm[xi]), int(p.perm[xi+1])
+	aa, ab := p.perm[a+zi]&7, p.perm[a+zi+1]&7
+	ba, bb := p.perm[b+zi]&7, p.perm[b+zi+1]&7
+
+	u, v := fade(x), fade(z)
+	x1 := Lerp(u, dot(aa, x, z), dot(ba, x-1, z))
+	x2 := Lerp(u, dot(ab, x, z-1), dot(bb, x-1, z-1))
+	// The maximum value of 2D perlin noise is sqrt(0.5), so the result is
+	// scaled to fill the range -1 to 1.
+	return Lerp(v, x1, x2) * math.Sqrt2
+}
+
+// At3 samples the noise at x, y and z. The value returned ranges roughly
+// from -1 to 1.
+func (p *Perlin) At3(x, y, z float64) float64 {
+	x, y, z = x+p.offX, y+p.offY, z+p.offZ
+	fx, fy, fz := math.Floor(x), math.Floor(y), math.Floor(z)
+	x, y, z = x-fx, y-fy, z-fz
+	xi, yi, zi := int(fx)&255, int(fy)&255, int(fz)&255
+
+	a, b := int(p.perm[xi])+yi, int(p.perm[xi+1])+yi
+	aa, ab := int(p.perm[a])+zi, int(p.perm[a+1])+zi
+	ba, bb := int(p.perm[b])+zi, int(p.perm[b+1])+zi
+
+	u, v, w := fade(x), fade(y), fade(z)
+	return Lerp(w,
+		Lerp(v,
+			Lerp(u, dot3(p.perm[aa], x, y, z), dot3(p.perm[ba], x-1, y, z)),
+			Lerp(u, dot3(p.perm[ab], x, y-1, z), dot3(p.perm[bb], x-1, y-1, z)),
+		),
+		Lerp(v,
+			Lerp(u, dot3(p.perm[aa+1], x, y, z-1), dot3(p.perm[ba+1], x-1, y, z-1)),
+			Lerp(u, dot3(p.perm[ab+1], x, y-1, z-1), dot3(p.perm[bb+1], x-1, y-1, z-1)),
+		),
+	)
+}
+
+// dot returns the dot product of the 2D gradient at index g and the vector
+// x, z.
+func dot(g uint8, x, z float64) float64 {
+	grad := gradients2D[g]
+	return grad[0]*x + grad[1]*z
+}
+
+// dot3 returns the dot product of the 3D gradient selected by the hash h and
+// the vector x, y, z.
+func dot3(h uint8, x, y, z float64) float64 {
+	grad := gradients3D[h&15]
+	return grad[0]*x + grad[1]*y + grad[2]*z
+}
+
+// fade is the quintic smoothing function used to interpolate between the
+// gradients of Perlin.
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+// Lerp linearly interpolates between a and b by t.
+func Lerp(t, a, b float64) float64 {
+	return a + t*(b-a)
+}
+
+// Octaves is a fractal noise function, summing multiple octaves of Perlin
+// noise with decreasing amplitude and increasing frequency. Like Perlin, an
+// Octaves is safe for concurrent use.
+type Octaves struct {
+	layers    []*Perlin
+	frequency float64
+	// norm normalises the sum of all octaves to the range -1 to 1.
+	norm float64
+}
+
+// NewOctaves creates an Octaves noise function with n octaves, where the
+// first octave has the frequency passed.
+func NewOctaves(r *rand.Rand, n int, frequency float64) Octaves {
+	o := Octaves{layers: make([]*Perlin, n), frequency: frequency}
+	amplitude := 1.0
+	for i := range n {
+		o.layers[i] = NewPerlin(r)
+		o.norm += amplitude
+		amplitude /= 2
+	}
+	o.norm = 1 / o.norm
+	return o
+}
+
+// At samples the noise at x and z. The value returned ranges from -1 to 1,
+// but is mostly between -0.6 and 0.6.
+func (o Octaves) At(x, z float64) float64 {
+	var sum float64
+	frequency, amplitude := o.frequency, 1.0
+	for _, l := range o.layers {
+		sum += l.At(x*frequency, z*frequency) * amplitude
+		frequency *= 2
+		amplitude /= 2
+	}
+	return sum * o.norm
+}
+
+// At3 samples the noise at x, y and z. Like At, the value returned ranges
+// from -1 to 1, but is mostly between -0.6 and 0.6.
+func (o Octaves) At3(x, y, z float64) float64 {
+	var sum float64
+	frequency, amplitude := o.frequency, 1.0
+	for _, l := range o.layers {
+		sum += l.At3(x*frequency, y*frequency, z*frequency) * amplitude
+		frequency *= 2
+		amplitude /= 2
+	}
+	return sum * o.norm
+}
+
+// Spline is a piecewise linear function defined by a set of points sorted by
+// their x value.
+type Spline [][2]float64
+
+// At returns the value of the Spline at x. Values of x outside the points of
+// the Spline are clamped to the first or last point.
+func (s Spline) At(x float64) float64 {
+	if x <= s[0][0] {
+		return s[0][1]
+	}
+	for i := 1; i < len(s); i++ {
+		if x <= s[i][0] {
+			a, b := s[i-1], s[i]
+			return Lerp((x-a[0])/(b[0]-a[0]), a[1], b[1])
+		}
+	}
+	return s[len(s)-1][1]
+}
+
+// Smoothstep returns 0 if x is at or below edge0, 1 if x is at or above
+// edge1, and smoothly interpolates between those values in between. edge0
+// may be larger than edge1 to reverse the direction.
+func Smoothstep(edge0, edge1, x float64) float64 {
+	t := min(max((x-edge0)/(edge1-edge0), 0), 1)
+	return t * t * (3 - 2*t)
+}
@@ -0,0 +1,86 @@
+package nether
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+)
+
+// climate is a Nether biome along with the blocks used for its floors and
+// the vegetation that grows in it.
+type climate struct {
+	biome world.Biome
+	// temperature and humidity are the point in the climate noise at which
+	// the biome is generated. offset makes the biome less likely to be
+	// selected.
+	temperature, humidity, offset float64
+
+	// top is the block placed at the surface of floors, or altTop where the
+	// patch noise is positive if it is not 0. filler is placed in the
+	// fillerDepth blocks below it.
+	top, altTop, filler uint32
+	fillerDepth         int
+	// lavaShore is the block placed at floors at the level of the lava sea,
+	// if not 0.
+	lavaShore uint32
+	// shores is true if the floors near the lava sea are covered with
+	// patches of soul sand and gravel.
+	shores bool
+
+	// vegetation is the vegetation that grows in the biome.
+	vegetation vegetation
+}
+
+// newClimates creates the climates of the five Nether biomes.
+func newClimates() []climate {
+	var (
+		netherrack         = world.BlockRuntimeID(block.Netherrack{})
+		soulSand, soulSoil = world.BlockRuntimeID(block.SoulSand{}), world.BlockRuntimeID(block.SoulSoil{})
+		basalt, blackstone = world.BlockRuntimeID(block.Basalt{}), world.BlockRuntimeID(block.Blackstone{})
+		crimson, warped    = world.BlockRuntimeID(block.Nylium{}), world.BlockRuntimeID(block.Nylium{Warped: true})
+		magma              = world.BlockRuntimeID(block.Magma{})
+	)
+	return []climate{
+		{
+			biome: biome.NetherWastes{},
+			top:   netherrack, filler: netherrack,
+			shores: true,
+		},
+		{
+			biome: biome.SoulSandValley{}, humidity: -0.5,
+			top: soulSand, altTop: soulSoil, filler: soulSoil, fillerDepth: 3,
+		},
+		{
+			biome: biome.CrimsonForest{}, temperature: 0.4,
+			top: crimson, filler: netherrack,
+			vegetation: vegetation{hugeFungi: 0.02, fungi: 0.02, roots: 0.08, weepingVines: 0.04},
+		},
+		{
+			biome: biome.WarpedForest{}, humidity: 0.5, offset: 0.375,
+			top: warped, filler: netherrack,
+			vegetation: vegetation{warped: true, hugeFungi: 0.02, fungi: 0.02, roots: 0.06, sprouts: 0.06, twistingVines: 0.015},
+		},
+		{
+			biome: biome.BasaltDeltas{}, temperature: -0.5, offset: 0.175,
+			top: basalt, altTop: blackstone, filler: basalt, fillerDepth: 3,
+			lavaShore: magma,
+		},
+	}
+}
+
+// climate selects the climate closest to the temperature and humidity
+// passed.
+func (g *Generator) climate(temperature, humidity float64) *climate {
+	var (
+		closest *climate
+		best    float64
+	)
+	for i := range g.climates {
+		c := &g.climates[i]
+		dt, dh := temperature-c.temperature, humidity-c.humidity
+		if d := dt*dt + dh*dh + c.offset*c.offset; closest == nil || d < best {
+			closest, best = c, d
+		}
+	}
+	return closest
+}
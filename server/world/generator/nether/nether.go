@@ -0,0 +1,239 @@
+// Package nether implements a world.Generator that generates vanilla-like
+// Nether terrain, with caverns, a lava sea and the five Nether biomes.
+package nether
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/generator/internal/noise"
+	"math/rand/v2"
+)
+
+const (
+	// lavaLevel is the Y value up to which open space in the Nether is
+	// filled with lava.
+	lavaLevel = 31
+	// cellWidth and cellHeight are the size of the cells in which the
+	// density of the terrain is interpolated.
+	cellWidth, cellHeight = 4, 8
+)
+
+// Generator generates vanilla-like Nether terrain. The terrain generated is
+// fully determined by the seed the Generator is created with, so the same
+// seed always generates the same Nether, and portals keep leading to the
+// same place across restarts. A Generator may be constructed using New and
+// is safe for concurrent use.
+//
+// The terrain is shaped by 3D noise between a bedrock floor and roof, which
+// results in large caverns. Open space below lavaLevel is filled with lava.
+// Temperature and humidity noise select the biome of every column, which
+// decides the blocks on the floors of the caverns and the vegetation
+// growing on them.
+type Generator struct {
+	seed int64
+	// minY and maxY are the bounds of the Nether dimension.
+	minY, maxY int16
+
+	terrain, temperature, humidity, patch noise.Octaves
+
+	climates []climate
+	plants   plants
+	veins    []vein
+
+	air, netherrack, lava, bedrock, glowstone, soulSand, gravel uint32
+}
+
+// New creates a Generator that generates terrain using the seed passed.
+func New(seed int64) *Generator {
+	r := rand.New(rand.NewPCG(uint64(seed), uint64(seed)^0x6e657468))
+	rng := world.Nether.Range()
+	return &Generator{
+		seed: seed,
+		minY: int16(rng.Min()),
+		maxY: int16(rng.Max()),
+
+		terrain:     noise.NewOctaves(r, 4, 1.0/96),
+		temperature: noise.NewOctaves(r, 3, 1.0/384),
+		humidity:    noise.NewOctaves(r, 3, 1.0/384),
+		patch:       noise.NewOctaves(r, 2, 1.0/24),
+
+		climates: newClimates(),
+		plants:   newPlants(),
+		veins:    newVeins(),
+
+		air:        world.BlockRuntimeID(block.Air{}),
+		netherrack: world.BlockRuntimeID(block.Netherrack{}),
+		lava:       world.BlockRuntimeID(block.Lava{Still: true, Depth: 8}),
+		bedrock:    world.BlockRuntimeID(block.Bedrock{}),
+		glowstone:  world.BlockRuntimeID(block.Glowstone{}),
+		soulSand:   world.BlockRuntimeID(block.SoulSand{}),
+		gravel:     world.BlockRuntimeID(block.Gravel{}),
+	}
+}
+
+// Seed returns the seed that the Generator was created with.
+func (g *Generator) Seed() int64 {
+	return g.seed
+}
+
+// GenerateChunk generates the terrain of the chunk at the position passed.
+func (g *Generator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	r := g.chunkRand(pos)
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+
+	g.generateTerrain(c, baseX, baseZ, r)
+
+	var climates [256]*climate
+	for x := range uint8(16) {
+		for z := range uint8(16) {
+			fx, fz := float64(baseX+int(x)), float64(baseZ+int(z))
+			cl := g.climate(g.temperature.At(fx, fz)*1.8, g.humidity.At(fx, fz)*1.8)
+			climates[int(x)<<4|int(z)] = cl
+			g.surface(c, x, z, cl, g.patch.At(fx, fz))
+		}
+	}
+	g.generateVeins(c, r)
+	g.generateGlowstone(c, r)
+	for x := range uint8(16) {
+		for z := range uint8(16) {
+			g.decorate(c, x, z, climates[int(x)<<4|int(z)], r)
+		}
+	}
+}
+
+// chunkRand returns a random source for the chunk at the position passed,
+// derived from the seed of the Generator.
+func (g *Generator) chunkRand(pos world.ChunkPos) *rand.Rand {
+	h := uint64(pos[0])*0x9e3779b97f4a7c15 ^ uint64(pos[1])*0xc2b2ae3d27d4eb4f
+	return rand.New(rand.NewPCG(uint64(g.seed)^0x6e657468, h))
+}
+
+// density returns the density of the terrain at x, y, z. Blocks with a
+// positive density are solid.
+func (g *Generator) density(x, y, z float64) float64 {
+	d := g.terrain.At3(x, y*1.4, z) * 1.6
+	// The terrain becomes denser towards the floor and the roof of the
+	// Nether, so that the caverns are closed off by solid blocks.
+	d += noise.Smoothstep(float64(lavaLevel-6), float64(g.minY)+4, y) * 1.5
+	d += noise.Smoothstep(float64(g.maxY)-22, float64(g.maxY)-2, y) * 1.5
+	return d + 0.05
+}
+
+// generateTerrain fills the chunk with netherrack, the lava sea and the
+// bedrock floor and roof.
+func (g *Generator) generateTerrain(c *chunk.Chunk, baseX, baseZ int, r *rand.Rand) {
+	grid := noise.NewGrid(baseX, baseZ, int(g.minY), int(g.maxY), cellWidth, cellHeight, g.density)
+	for x := range uint8(16) {
+		for z := range uint8(16) {
+			for y := g.minY; y <= g.maxY; y++ {
+				var b uint32
+				switch {
+				case y < g.minY+5 && r.IntN(5) >= int(y-g.minY), y > g.maxY-5 && r.IntN(5) >= int(g.maxY-y):
+					// The bedrock floor and roof become less likely to be
+					// bedrock further away from the bounds of the Nether.
+					b = g.bedrock
+				case grid.At(int(x), int(y), int(z)) > 0:
+					b = g.netherrack
+				case y <= lavaLevel:
+					b = g.lava
+				default:
+					continue
+				}
+				c.SetBlock(x, y, z, 0, b)
+			}
+		}
+	}
+}
+
+// surface covers the floors of the column at x, z with the blocks of the
+// climate passed and sets the biome of the column. patch is the value of
+// the patch noise at the column, used to mix two kinds of blocks.
+func (g *Generator) surface(c *chunk.Chunk, x, z uint8, cl *climate, patch float64) {
+	depth := -1
+	for y := g.maxY - 1; y > g.minY; y-- {
+		if c.Block(x, y, z, 0) != g.netherrack {
+			depth = -1
+			continue
+		}
+		var b uint32
+		switch above := c.Block(x, y+1, z, 0); {
+		case above == g.lava:
+			depth, b = 0, cl.filler
+		case above == g.air:
+			depth, b = 0, g.top(cl, y, patch)
+		case depth >= 0 && depth < cl.fillerDepth:
+			depth, b = depth+1, cl.filler
+		default:
+			depth = -1
+			continue
+		}
+		if b != g.netherrack {
+			c.SetBlock(x, y, z, 0, b)
+		}
+	}
+	biome := uint32(cl.biome.EncodeBiome())
+	for y := g.minY; y <= g.maxY; y++ {
+		c.SetBiome(x, y, z, biome)
+	}
+}
+
+// top returns the block placed on a floor at the Y value passed.
+func (g *Generator) top(cl *climate, y int16, patch float64) uint32 {
+	switch {
+	case cl.lavaShore != 0 && y <= lavaLevel+1:
+		return cl.lavaShore
+	case cl.shores && y >= lavaLevel-1 && y <= lavaLevel+4 && patch > 0.2:
+		return g.soulSand
+	case cl.shores && y >= lavaLevel-1 && y <= lavaLevel+4 && patch < -0.2:
+		return g.gravel
+	case cl.altTop != 0 && patch > 0:
+		return cl.altTop
+	}
+	return cl.top
+}
+
+// generateGlowstone generates clusters of glowstone hanging from the
+// ceilings of the chunk passed. Like huge fungi, clusters are kept inside
+// the chunk.
+func (g *Generator) generateGlowstone(c *chunk.Chunk, r *rand.Rand) {
+	for range 10 {
+		x, z := uint8(3+r.IntN(10)), uint8(3+r.IntN(10))
+		y := g.minY + 5 + int16(r.IntN(int(g.maxY-g.minY)-10))
+		if c.Block(x, y, z, 0) != g.air {
+			continue
+		}
+		for y < g.maxY-5 && c.Block(x, y+1, z, 0) == g.air {
+			y++
+		}
+		if c.Block(x, y+1, z, 0) != g.netherrack {
+			continue
+		}
+		c.SetBlock(x, y, z, 0, g.glowstone)
+		for range 150 {
+			gx, gy, gz := int(x)+r.IntN(7)-3, y-int16(r.IntN(7)), int(z)+r.IntN(7)-3
+			if gy <= g.minY || c.Block(uint8(gx), gy, uint8(gz), 0) != g.air {
+				continue
+			}
+			// Glowstone only grows next to exactly one other block of
+			// glowstone, which results in branching clusters.
+			if g.glowstoneNeighbours(c, gx, gy, gz) == 1 {
+				c.SetBlock(uint8(gx), gy, uint8(gz), 0, g.glowstone)
+			}
+		}
+	}
+}
+
+// glowstoneNeighbours counts the glowstone blocks directly next to x, y, z.
+func (g *Generator) glowstoneNeighbours(c *chunk.Chunk, x int, y int16, z int) (n int) {
+	for _, off := range [6][3]int{{-1, 0, 0}, {1, 0, 0}, {0, -1, 0}, {0, 1, 0}, {0, 0, -1}, {0, 0, 1}} {
+		nx, ny, nz := x+off[0], y+int16(off[1]), z+off[2]
+		if nx < 0 || nx > 15 || nz < 0 || nz > 15 || ny < g.minY || ny > g.maxY {
+			continue
+		}
+		if c.Block(uint8(nx), ny, uint8(nz), 0) == g.glowstone {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,83 @@
+package nether
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math/rand/v2"
+)
+
+// vein is a type of ore or other block generated in blobs in netherrack.
+type vein struct {
+	block uint32
+	// count is the amount of veins generated per chunk and size the amount of
+	// blocks in every vein.
+	count, size int
+	// minY and maxY are the bounds of the Y values veins are generated at.
+	minY, maxY int
+	// triangular is true if veins are most likely to be generated halfway
+	// between minY and maxY, rather than uniformly between them.
+	triangular bool
+	// hidden is true if blocks of the vein are never placed next to air.
+	hidden bool
+}
+
+// newVeins resolves the blocks of all veins generated by the Generator.
+func newVeins() []vein {
+	debris := world.BlockRuntimeID(block.AncientDebris{})
+	return []vein{
+		{block: world.BlockRuntimeID(block.Magma{}), count: 4, size: 33, minY: lavaLevel - 4, maxY: lavaLevel + 5},
+		{block: world.BlockRuntimeID(block.NetherQuartzOre{}), count: 16, size: 14, minY: 10, maxY: 117},
+		{block: world.BlockRuntimeID(block.NetherGoldOre{}), count: 10, size: 10, minY: 10, maxY: 117},
+		// Ancient debris is mostly found around Y=15, with an additional
+		// small vein anywhere in the Nether. Like in vanilla, it is never
+		// exposed to air.
+		{block: debris, count: 1, size: 3, minY: 8, maxY: 24, triangular: true, hidden: true},
+		{block: debris, count: 1, size: 2, minY: 8, maxY: 119, hidden: true},
+	}
+}
+
+// generateVeins generates all veins of the Generator in the chunk passed.
+// Veins only replace netherrack.
+func (g *Generator) generateVeins(c *chunk.Chunk, r *rand.Rand) {
+	minY, maxY := int(g.minY), int(g.maxY)
+	for _, v := range g.veins {
+		low, high := max(v.minY, minY), min(v.maxY, maxY)
+		if low >= high {
+			continue
+		}
+		for range v.count {
+			y := low + r.IntN(high-low)
+			if v.triangular {
+				y = low + (r.IntN(high-low+1)+r.IntN(high-low+1))/2
+			}
+			// Every vein is a random walk starting at a random position in
+			// the chunk, which results in a blob of blocks.
+			x, z := r.IntN(16), r.IntN(16)
+			for range v.size {
+				if c.Block(uint8(x), int16(y), uint8(z), 0) == g.netherrack && (!v.hidden || !g.exposed(c, x, y, z)) {
+					c.SetBlock(uint8(x), int16(y), uint8(z), 0, v.block)
+				}
+				x = min(max(x+r.IntN(3)-1, 0), 15)
+				y = min(max(y+r.IntN(3)-1, minY), maxY)
+				z = min(max(z+r.IntN(3)-1, 0), 15)
+			}
+		}
+	}
+}
+
+// exposed checks if any of the blocks directly next to x, y, z in the chunk
+// is air.
+func (g *Generator) exposed(c *chunk.Chunk, x, y, z int) bool {
+	for _, f := range cube.Faces() {
+		pos := cube.Pos{x, y, z}.Side(f)
+		if pos[0] < 0 || pos[0] > 15 || pos[2] < 0 || pos[2] > 15 || pos[1] < int(g.minY) || pos[1] > int(g.maxY) {
+			continue
+		}
+		if c.Block(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0) == g.air {
+			return true
+		}
+	}
+	return false
+}
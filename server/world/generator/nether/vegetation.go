@@ -0,0 +1,176 @@
+package nether
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math/rand/v2"
+)
+
+// vegetation describes the plants that grow in a climate. All chances are
+// per floor or ceiling of a column.
+type vegetation struct {
+	// warped is true if the vegetation is that of a warped forest.
+	warped bool
+	// hugeFungi, fungi, roots and sprouts are the chances of the respective
+	// vegetation growing on a floor covered with nylium.
+	hugeFungi, fungi, roots, sprouts float64
+	// weepingVines is the chance of weeping vines hanging from a ceiling and
+	// twistingVines the chance of twisting vines growing on a floor.
+	weepingVines, twistingVines float64
+}
+
+// plants holds the block runtime IDs of the vegetation placed by the
+// Generator. Blocks of crimson and warped variants are indexed by 0 and 1
+// respectively.
+type plants struct {
+	nylium, stem, wart, fungus, roots [2]uint32
+	sprouts, shroomlight              uint32
+	// weepingVines and twistingVines hold the vines of every age, indexed by
+	// their age.
+	weepingVines, twistingVines [26]uint32
+}
+
+// newPlants resolves the blocks of all vegetation.
+func newPlants() plants {
+	var p plants
+	for i, warped := range [2]bool{false, true} {
+		wood := block.CrimsonWood()
+		if warped {
+			wood = block.WarpedWood()
+		}
+		p.nylium[i] = world.BlockRuntimeID(block.Nylium{Warped: warped})
+		p.stem[i] = world.BlockRuntimeID(block.Log{Wood: wood})
+		p.wart[i] = world.BlockRuntimeID(block.NetherWartBlock{Warped: warped})
+		p.fungus[i] = world.BlockRuntimeID(block.Fungus{Warped: warped})
+		p.roots[i] = world.BlockRuntimeID(block.Roots{Warped: warped})
+	}
+	for age := range 26 {
+		p.weepingVines[age] = world.BlockRuntimeID(block.WeepingVines{Age: age})
+		p.twistingVines[age] = world.BlockRuntimeID(block.TwistingVines{Age: age})
+	}
+	p.sprouts = world.BlockRuntimeID(block.NetherSprouts{})
+	p.shroomlight = world.BlockRuntimeID(block.Shroomlight{})
+	return p
+}
+
+// decorate grows vegetation on all floors and ceilings of the column at x,
+// z.
+func (g *Generator) decorate(c *chunk.Chunk, x, z uint8, cl *climate, r *rand.Rand) {
+	v := cl.vegetation
+	if v == (vegetation{}) {
+		return
+	}
+	variant := 0
+	if v.warped {
+		variant = 1
+	}
+	for y := g.maxY - 1; y > g.minY; y-- {
+		if c.Block(x, y, z, 0) != g.air {
+			continue
+		}
+		above, below := c.Block(x, y+1, z, 0), c.Block(x, y-1, z, 0)
+		if above == g.netherrack && r.Float64() < v.weepingVines {
+			g.growVines(c, x, y, z, -1, g.plants.weepingVines, r)
+			continue
+		}
+		if below != g.plants.nylium[variant] {
+			continue
+		}
+		switch f := r.Float64(); {
+		case f < v.hugeFungi:
+			g.growHugeFungus(c, x, y, z, variant, r)
+		case f < v.hugeFungi+v.twistingVines:
+			g.growVines(c, x, y, z, 1, g.plants.twistingVines, r)
+		case f < v.hugeFungi+v.twistingVines+v.fungi:
+			// Fungi of the other variant occasionally grow among the fungi
+			// of the forest.
+			if r.IntN(10) == 0 {
+				c.SetBlock(x, y, z, 0, g.plants.fungus[1-variant])
+				break
+			}
+			c.SetBlock(x, y, z, 0, g.plants.fungus[variant])
+		case f < v.hugeFungi+v.twistingVines+v.fungi+v.roots:
+			c.SetBlock(x, y, z, 0, g.plants.roots[variant])
+		case f < v.hugeFungi+v.twistingVines+v.fungi+v.roots+v.sprouts:
+			c.SetBlock(x, y, z, 0, g.plants.sprouts)
+		}
+	}
+}
+
+// growVines grows vines of up to 8 blocks long from x, y, z, growing
+// downwards if dir is -1 or upwards if dir is 1. vines holds the vines of
+// every age.
+func (g *Generator) growVines(c *chunk.Chunk, x uint8, y int16, z uint8, dir int16, vines [26]uint32, r *rand.Rand) {
+	n := 1 + r.IntN(8)
+	for i := range n {
+		next := y + dir
+		if i == n-1 || next <= g.minY || next >= g.maxY || c.Block(x, next, z, 0) != g.air {
+			// The tip of the vines gets a random age, so that it may keep
+			// growing.
+			c.SetBlock(x, y, z, 0, vines[17+r.IntN(9)])
+			return
+		}
+		c.SetBlock(x, y, z, 0, vines[25])
+		y = next
+	}
+}
+
+// growHugeFungus grows a huge fungus with its stem starting at x, y, z. Like
+// trees in the overworld, huge fungi are only grown if their cap fits
+// entirely inside the chunk and there is enough space above the floor.
+func (g *Generator) growHugeFungus(c *chunk.Chunk, x uint8, y int16, z uint8, variant int, r *rand.Rand) {
+	const radius = 2
+	height := int16(4 + r.IntN(9))
+	if x < radius || z < radius || x > 15-radius || z > 15-radius || y+height+1 >= g.maxY {
+		return
+	}
+	for ly := range height + 1 {
+		if c.Block(x, y+ly, z, 0) != g.air {
+			return
+		}
+	}
+	capBlock := func(lx, ly, lz int16) {
+		bx, bz := uint8(int16(x)+lx), uint8(int16(z)+lz)
+		if c.Block(bx, y+ly, bz, 0) != g.air {
+			return
+		}
+		if r.IntN(16) == 0 {
+			c.SetBlock(bx, y+ly, bz, 0, g.plants.shroomlight)
+			return
+		}
+		c.SetBlock(bx, y+ly, bz, 0, g.plants.wart[variant])
+	}
+	// The cap consists of a flat top with a radius of 1, with a hollow ring
+	// with a radius of 2 hanging down from it.
+	for lx := int16(-1); lx <= 1; lx++ {
+		for lz := int16(-1); lz <= 1; lz++ {
+			capBlock(lx, height, lz)
+		}
+	}
+	for ly := height - min(3, height/2); ly < height; ly++ {
+		for lx := int16(-radius); lx <= radius; lx++ {
+			for lz := int16(-radius); lz <= radius; lz++ {
+				if abs(lx) != radius && abs(lz) != radius {
+					continue
+				}
+				if abs(lx) == radius && abs(lz) == radius && ly < height-1 {
+					// Corners of the ring are only filled at the top.
+					continue
+				}
+				capBlock(lx, ly, lz)
+			}
+		}
+	}
+	for ly := range height {
+		c.SetBlock(x, y+ly, z, 0, g.plants.stem[variant])
+	}
+}
+
+// abs returns the absolute value of x.
+func abs(x int16) int16 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,179 @@
+package overworld
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+)
+
+// column holds the terrain parameters sampled for a single column of blocks.
+type column struct {
+	// height is the Y value of the highest solid block in the column.
+	height int
+	// continentalness, erosion, temperature and humidity are the values of
+	// the respective noise at the column, ranging from -1 to 1.
+	continentalness, erosion float64
+	temperature, humidity    float64
+	// river is true if the column is part of a river.
+	river bool
+}
+
+// climate is a set of biomes that generate under similar conditions, along
+// with the blocks used for their surface and the vegetation that grows on
+// them.
+type climate struct {
+	biome world.Biome
+	// top is the block placed at the surface of the column, filler the block
+	// placed in the fillerDepth blocks below it.
+	top, filler uint32
+	fillerDepth int
+	// underwater is the top block used if the surface is below sea level.
+	underwater uint32
+	// vegetation is the vegetation that grows on the surface of the biome.
+	vegetation vegetation
+}
+
+// climates holds all climates that may be selected by the Generator.
+type climates struct {
+	deepFrozenOcean, frozenOcean, deepColdOcean, coldOcean, deepOcean, ocean climate
+	deepLukewarmOcean, lukewarmOcean, deepWarmOcean, warmOcean               climate
+	river, frozenRiver, beach, snowyBeach, stonyShore                        climate
+	snowyPlains, snowyTaiga, taiga, plains, forest, birchForest, swamp       climate
+	savanna, jungle, desert, meadow, snowySlopes, frozenPeaks, stonyPeaks    climate
+}
+
+// newClimates creates the climates used by the Generator.
+func newClimates() *climates {
+	var (
+		grass, dirt, stone = world.BlockRuntimeID(block.Grass{}), world.BlockRuntimeID(block.Dirt{}), world.BlockRuntimeID(block.Stone{})
+		sand, sandstone    = world.BlockRuntimeID(block.Sand{}), world.BlockRuntimeID(block.Sandstone{})
+		gravel, snow       = world.BlockRuntimeID(block.Gravel{}), world.BlockRuntimeID(block.Snow{})
+	)
+	land := func(b world.Biome, v vegetation) climate {
+		return climate{biome: b, top: grass, filler: dirt, fillerDepth: 3, underwater: dirt, vegetation: v}
+	}
+	ocean := func(b world.Biome, floor uint32) climate {
+		return climate{biome: b, top: floor, filler: floor, fillerDepth: 3, underwater: floor}
+	}
+	return &climates{
+		deepFrozenOcean:   ocean(biome.DeepFrozenOcean{}, gravel),
+		frozenOcean:       ocean(biome.FrozenOcean{}, gravel),
+		deepColdOcean:     ocean(biome.DeepColdOcean{}, gravel),
+		coldOcean:         ocean(biome.ColdOcean{}, gravel),
+		deepOcean:         ocean(biome.DeepOcean{}, gravel),
+		ocean:             ocean(biome.Ocean{}, sand),
+		deepLukewarmOcean: ocean(biome.DeepLukewarmOcean{}, sand),
+		lukewarmOcean:     ocean(biome.LukewarmOcean{}, sand),
+		deepWarmOcean:     ocean(biome.DeepWarmOcean{}, sand),
+		warmOcean:         ocean(biome.WarmOcean{}, sand),
+
+		river:       climate{biome: biome.River{}, top: sand, filler: dirt, fillerDepth: 3, underwater: sand},
+		frozenRiver: climate{biome: biome.FrozenRiver{}, top: gravel, filler: dirt, fillerDepth: 3, underwater: gravel},
+		beach:       climate{biome: biome.Beach{}, top: sand, filler: sand, fillerDepth: 4, underwater: sand},
+		snowyBeach:  climate{biome: biome.SnowyBeach{}, top: sand, filler: sand, fillerDepth: 4, underwater: sand},
+		stonyShore:  climate{biome: biome.StonyShore{}, top: stone, filler: stone, underwater: gravel},
+
+		snowyPlains: land(biome.SnowyPlains{}, vegetation{trees: 0.001, tree: spruceTree, grass: 0.02}),
+		snowyTaiga:  land(biome.SnowyTaiga{}, vegetation{trees: 0.04, tree: spruceTree, grass: 0.05, ferns: true}),
+		taiga:       land(biome.Taiga{}, vegetation{trees: 0.06, tree: spruceTree, grass: 0.15, ferns: true}),
+		plains:      land(biome.Plains{}, vegetation{trees: 0.002, tree: oakTree, grass: 0.35, flowers: 0.02}),
+		forest:      land(biome.Forest{}, vegetation{trees: 0.07, tree: oakTree, alt: birchTree, altChance: 0.2, grass: 0.15, flowers: 0.01}),
+		birchForest: land(biome.BirchForest{}, vegetation{trees: 0.07, tree: birchTree, grass: 0.15, flowers: 0.01}),
+		swamp:       land(biome.Swamp{}, vegetation{trees: 0.02, tree: oakTree, grass: 0.1, flowers: 0.005}),
+		savanna:     land(biome.Savanna{}, vegetation{trees: 0.008, tree: acaciaTree, grass: 0.4}),
+		jungle:      land(biome.Jungle{}, vegetation{trees: 0.1, tree: jungleTree, grass: 0.4, ferns: true, flowers: 0.005}),
+		desert:      climate{biome: biome.Desert{}, top: sand, filler: sandstone, fillerDepth: 4, underwater: sand, vegetation: vegetation{cacti: 0.006, deadBushes: 0.005}},
+		meadow:      land(biome.Meadow{}, vegetation{grass: 0.5, flowers: 0.08}),
+		snowySlopes: climate{biome: biome.SnowySlopes{}, top: snow, filler: snow, fillerDepth: 2, underwater: stone},
+		frozenPeaks: climate{biome: biome.FrozenPeaks{}, top: snow, filler: stone, fillerDepth: 1, underwater: stone},
+		stonyPeaks:  climate{biome: biome.StonyPeaks{}, top: stone, filler: stone, underwater: stone},
+	}
+}
+
+// climate selects the climate of a column based on its terrain parameters.
+func (c *climates) climate(col column) *climate {
+	temp, humidity := col.temperature, col.humidity
+	switch {
+	case col.river && col.height < seaLevel:
+		if temp < -0.45 {
+			return &c.frozenRiver
+		}
+		return &c.river
+	case col.continentalness < coastContinentalness && col.height < seaLevel:
+		deep := col.height < seaLevel-20
+		switch {
+		case temp < -0.45:
+			return pick(deep, &c.deepFrozenOcean, &c.frozenOcean)
+		case temp < -0.15:
+			return pick(deep, &c.deepColdOcean, &c.coldOcean)
+		case temp < 0.35:
+			return pick(deep, &c.deepOcean, &c.ocean)
+		case temp < 0.6:
+			return pick(deep, &c.deepLukewarmOcean, &c.lukewarmOcean)
+		}
+		return pick(deep, &c.deepWarmOcean, &c.warmOcean)
+	case col.continentalness < coastContinentalness+0.05 && col.height <= seaLevel+3:
+		switch {
+		case col.erosion < -0.4:
+			return &c.stonyShore
+		case temp < -0.45:
+			return &c.snowyBeach
+		}
+		return &c.beach
+	case col.height >= 150:
+		return pick(temp < 0, &c.frozenPeaks, &c.stonyPeaks)
+	case col.height >= 115:
+		return pick(temp < 0, &c.snowySlopes, &c.meadow)
+	case temp < -0.45:
+		return pick(humidity < 0, &c.snowyPlains, &c.snowyTaiga)
+	case temp < -0.15:
+		return pick(humidity < -0.1, &c.plains, &c.taiga)
+	case temp < 0.35:
+		switch {
+		case humidity < -0.1:
+			return &c.plains
+		case humidity < 0.15:
+			return &c.forest
+		case humidity < 0.35 || col.height > seaLevel+6:
+			return &c.birchForest
+		}
+		return &c.swamp
+	case temp < 0.6:
+		switch {
+		case humidity < -0.2:
+			return &c.savanna
+		case humidity < 0.3:
+			return &c.plains
+		}
+		return &c.jungle
+	}
+	return pick(humidity < 0.3, &c.desert, &c.jungle)
+}
+
+// pick returns a if cond is true, or b otherwise.
+func pick(cond bool, a, b *climate) *climate {
+	if cond {
+		return a
+	}
+	return b
+}
+
+// caveBiomeDepth is the number of blocks below the surface of a column from
+// which cave biomes replace the biome of the surface.
+const caveBiomeDepth = 24
+
+// caveBiome selects the biome generated deep below the surface of a column.
+// Lush caves generate under humid land and dripstone caves far inland. If
+// neither applies, nil is returned and the column keeps its surface biome all
+// the way down.
+func caveBiome(col column) world.Biome {
+	switch {
+	case col.continentalness < coastContinentalness:
+		return nil
+	case col.humidity > 0.55:
+		return biome.LushCaves{}
+	case col.continentalness > 0.6:
+		return biome.DripstoneCaves{}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package overworld
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math/rand/v2"
+)
+
+// vein is a type of ore or other block generated in blobs underground.
+type vein struct {
+	// stone and deepslate are the blocks that replace stone and deepslate
+	// respectively. If either is 0, the vein does not replace that block.
+	stone, deepslate uint32
+	// count is the amount of veins generated per chunk and size the amount of
+	// blocks in every vein.
+	count, size int
+	// minY and maxY are the bounds of the Y values veins are generated at.
+	minY, maxY int
+}
+
+// newVeins resolves the blocks of all veins generated by the Generator.
+func newVeins() []vein {
+	ore := func(b func(t block.OreType) world.Block, count, size, minY, maxY int) vein {
+		return vein{
+			stone:     world.BlockRuntimeID(b(block.StoneOre())),
+			deepslate: world.BlockRuntimeID(b(block.DeepslateOre())),
+			count:     count, size: size, minY: minY, maxY: maxY,
+		}
+	}
+	stone := func(b world.Block, count, size, minY, maxY int) vein {
+		return vein{stone: world.BlockRuntimeID(b), count: count, size: size, minY: minY, maxY: maxY}
+	}
+	return []vein{
+		stone(block.Dirt{}, 7, 33, 0, 160),
+		stone(block.Gravel{}, 8, 33, -64, 160),
+		stone(block.Granite{}, 4, 40, 0, 60),
+		stone(block.Diorite{}, 4, 40, 0, 60),
+		stone(block.Andesite{}, 4, 40, 0, 60),
+		{deepslate: world.BlockRuntimeID(block.Tuff{}), count: 4, size: 40, minY: -64, maxY: 0},
+		ore(func(t block.OreType) world.Block { return block.CoalOre{Type: t} }, 20, 17, 0, 192),
+		ore(func(t block.OreType) world.Block { return block.IronOre{Type: t} }, 10, 9, -64, 72),
+		ore(func(t block.OreType) world.Block { return block.CopperOre{Type: t} }, 8, 10, -16, 112),
+		ore(func(t block.OreType) world.Block { return block.GoldOre{Type: t} }, 4, 9, -64, 32),
+		ore(func(t block.OreType) world.Block { return block.LapisOre{Type: t} }, 2, 7, -64, 64),
+		ore(func(t block.OreType) world.Block { return block.DiamondOre{Type: t} }, 3, 8, -64, 16),
+	}
+}
+
+// generateVeins generates all veins of the Generator in the chunk passed.
+// Veins only replace stone and deepslate, so they never show up in the
+// surface or in water.
+func (g *Generator) generateVeins(c *chunk.Chunk, r *rand.Rand) {
+	minY, maxY := c.Range().Min(), c.Range().Max()
+	for _, v := range g.veins {
+		low, high := max(v.minY, minY), min(v.maxY, maxY)
+		if low >= high {
+			continue
+		}
+		for range v.count {
+			// Every vein is a random walk starting at a random position in
+			// the chunk, which results in a blob of blocks.
+			x, y, z := r.IntN(16), low+r.IntN(high-low), r.IntN(16)
+			for range v.size {
+				switch c.Block(uint8(x), int16(y), uint8(z), 0) {
+				case g.stone:
+					if v.stone != 0 {
+						c.SetBlock(uint8(x), int16(y), uint8(z), 0, v.stone)
+					}
+				case g.deepslate:
+					if v.deepslate != 0 {
+						c.SetBlock(uint8(x), int16(y), uint8(z), 0, v.deepslate)
+					}
+				}
+				x = min(max(x+r.IntN(3)-1, 0), 15)
+				y = min(max(y+r.IntN(3)-1, minY), maxY)
+				z = min(max(z+r.IntN(3)-1, 0), 15)
+			}
+		}
+	}
+}
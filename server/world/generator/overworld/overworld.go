@@ -0,0 +1,247 @@
+// Package overworld implements a world.Generator that generates vanilla-like
+// overworld terrain, with oceans, rivers, mountains and a variety of biomes.
+package overworld
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/generator/internal/noise"
+	"math"
+	"math/rand/v2"
+)
+
+const (
+	// seaLevel is the Y value up to which oceans and rivers are filled with
+	// water.
+	seaLevel = 62
+	// coastContinentalness is the continentalness below which columns under
+	// sea level are considered part of an ocean.
+	coastContinentalness = -0.1
+)
+
+// Generator generates vanilla-like overworld terrain. The terrain generated
+// is fully determined by the seed the Generator is created with, so the same
+// seed always generates the same world. A Generator may be constructed using
+// New and is safe for concurrent use.
+//
+// The terrain is a height field shaped by several layers of noise:
+// Continentalness decides between oceans and land, erosion decides how
+// mountainous the land is and peaks and valleys shapes the mountains
+// themselves. Temperature and humidity noise then select the biome of every
+// column, with lush caves or dripstone caves deep below the surface of humid
+// and far inland columns. Trees are grown in a second stage using
+// DecorateChunk, so that they may cross chunk borders.
+//
+// The Generator does not carve caves itself. Caves, ravines and aquifers may
+// be added by wrapping the Generator in a carver.Carver.
+//
+// To generate terrain matching the seed of a world saved on disk, the
+// Generator may be created using the Seed of the world.Settings returned by
+// the world.Provider.
+type Generator struct {
+	seed int64
+
+	continentalness, erosion, peaks, detail, river noise.Octaves
+	temperature, humidity                          noise.Octaves
+
+	climates *climates
+	trees    [treeKinds]tree
+	plants   plants
+	veins    []vein
+
+	air, water, stone, deepslate, bedrock, grass, sand, dirt uint32
+}
+
+// New creates a Generator that generates terrain using the seed passed.
+func New(seed int64) *Generator {
+	r := rand.New(rand.NewPCG(uint64(seed), uint64(seed)^0x5f3759df))
+	return &Generator{
+		seed: seed,
+
+		continentalness: noise.NewOctaves(r, 6, 1.0/1024),
+		erosion:         noise.NewOctaves(r, 4, 1.0/512),
+		peaks:           noise.NewOctaves(r, 4, 1.0/256),
+		detail:          noise.NewOctaves(r, 3, 1.0/48),
+		river:           noise.NewOctaves(r, 4, 1.0/640),
+		temperature:     noise.NewOctaves(r, 3, 1.0/1280),
+		humidity:        noise.NewOctaves(r, 3, 1.0/1024),
+
+		climates: newClimates(),
+		trees:    newTrees(),
+		plants:   newPlants(),
+		veins:    newVeins(),
+
+		air:       world.BlockRuntimeID(block.Air{}),
+		water:     world.BlockRuntimeID(block.Water{Still: true, Depth: 8}),
+		stone:     world.BlockRuntimeID(block.Stone{}),
+		deepslate: world.BlockRuntimeID(block.Deepslate{}),
+		bedrock:   world.BlockRuntimeID(block.Bedrock{}),
+		grass:     world.BlockRuntimeID(block.Grass{}),
+		sand:      world.BlockRuntimeID(block.Sand{}),
+		dirt:      world.BlockRuntimeID(block.Dirt{}),
+	}
+}
+
+// Seed returns the seed that the Generator was created with.
+func (g *Generator) Seed() int64 {
+	return g.seed
+}
+
+// GenerateChunk generates the terrain of the chunk at the position passed.
+func (g *Generator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	r := g.chunkRand(pos, 0)
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+
+	var (
+		columns  [256]column
+		climates [256]*climate
+	)
+	for x := range uint8(16) {
+		for z := range uint8(16) {
+			i := int(x)<<4 | int(z)
+			columns[i] = g.column(baseX+int(x), baseZ+int(z))
+			climates[i] = g.climates.climate(columns[i])
+			g.fillColumn(c, x, z, columns[i], climates[i], r)
+		}
+	}
+	g.generateVeins(c, r)
+	for x := range uint8(16) {
+		for z := range uint8(16) {
+			i := int(x)<<4 | int(z)
+			g.decorate(c, x, z, int16(columns[i].height), climates[i], r)
+		}
+	}
+}
+
+// DecorateChunk grows the trees of the chunk at the position passed. Trees
+// are grown once all neighbours of the chunk are generated, so that their
+// canopy may extend into those neighbours.
+func (g *Generator) DecorateChunk(pos world.ChunkPos, area *world.DecorationArea) {
+	r := g.chunkRand(pos, decorationSalt)
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	for x := range 16 {
+		for z := range 16 {
+			v := g.climates.climate(g.column(baseX+x, baseZ+z)).vegetation
+			if v.trees == 0 || r.Float64() >= v.trees {
+				continue
+			}
+			top := cube.Pos{baseX + x, area.HighestBlock(baseX+x, baseZ+z), baseZ + z}
+			if g.plants.small(area.Block(top)) {
+				// Small plants are replaced by the trunk of the tree.
+				top = top.Side(cube.FaceDown)
+			}
+			if top[1] < seaLevel || area.Block(top) != g.grass {
+				continue
+			}
+			kind := v.tree
+			if v.altChance > 0 && r.Float64() < v.altChance {
+				kind = v.alt
+			}
+			g.growTree(area, top.Side(cube.FaceUp), g.trees[kind], r)
+		}
+	}
+}
+
+// decorationSalt is mixed into the random source of a chunk when it is
+// decorated, so that decoration does not repeat the random values used to
+// generate the terrain.
+const decorationSalt = 0x7e3a91c4d2b5f607
+
+// chunkRand returns a random source for the chunk at the position passed,
+// derived from the seed of the Generator and the salt passed.
+func (g *Generator) chunkRand(pos world.ChunkPos, salt uint64) *rand.Rand {
+	h := uint64(pos[0])*0x9e3779b97f4a7c15 ^ uint64(pos[1])*0xc2b2ae3d27d4eb4f
+	return rand.New(rand.NewPCG(uint64(g.seed)^salt, h))
+}
+
+// column samples the terrain parameters of the column at x, z.
+func (g *Generator) column(x, z int) column {
+	fx, fz := float64(x), float64(z)
+	col := column{
+		continentalness: clampNoise(g.continentalness.At(fx, fz) * 1.6),
+		erosion:         clampNoise(g.erosion.At(fx, fz) * 1.6),
+		temperature:     clampNoise(g.temperature.At(fx, fz) * 1.8),
+		humidity:        clampNoise(g.humidity.At(fx, fz) * 1.8),
+	}
+	// Peaks and valleys folds the noise so that both very high and very
+	// low values result in peaks, with valleys in between.
+	pv := 1 - math.Abs(3*math.Abs(clampNoise(g.peaks.At(fx, fz)*1.6))-2)
+
+	base := continentalnessHeight.At(col.continentalness)
+	inland := noise.Smoothstep(-0.05, 0.3, col.continentalness)
+	roughness := noise.Smoothstep(-0.05, -0.55, col.erosion)
+
+	height := base + inland*(roughness*(pv+1)/2*120+(1-roughness)*(pv+1)*6)
+	height += g.detail.At(fx, fz) * (3 + 10*roughness*inland)
+
+	// Rivers are carved where the river noise is close to 0, but only on
+	// land that is not too mountainous.
+	if rv := math.Abs(g.river.At(fx, fz)); rv < 0.04 && col.continentalness > coastContinentalness {
+		depth := noise.Smoothstep(0.04, 0.015, rv) * (1 - roughness*0.8)
+		if riverHeight := seaLevel - 4.0; height > riverHeight && depth > 0 {
+			height = noise.Lerp(depth, height, riverHeight)
+			col.river = depth > 0.5
+		}
+	}
+	col.height = int(height)
+	return col
+}
+
+// continentalnessHeight maps continentalness to the base height of the
+// terrain, from deep oceans to the inland.
+var continentalnessHeight = noise.Spline{
+	{-1, 24}, {-0.45, 32}, {-0.2, 46}, {coastContinentalness, 58}, {0, 64}, {0.25, 70}, {0.5, 78}, {1, 92},
+}
+
+// clampNoise clamps a noise value to the range -1 to 1.
+func clampNoise(v float64) float64 {
+	return min(max(v, -1), 1)
+}
+
+// fillColumn fills the column at x, z with stone, the surface blocks of the
+// climate, and water up to sea level. It also sets the biome of the column,
+// with a cave biome deep below the surface if the column has one.
+func (g *Generator) fillColumn(c *chunk.Chunk, x, z uint8, col column, cl *climate, r *rand.Rand) {
+	minY, maxY := int16(c.Range().Min()), int16(c.Range().Max())
+	height := min(max(int16(col.height), minY+5), maxY)
+
+	top := cl.top
+	if height < seaLevel {
+		top = cl.underwater
+	}
+	for y := minY; y <= height; y++ {
+		var b uint32
+		switch {
+		case y < minY+5 && r.IntN(5) >= int(y-minY):
+			// The bottom five layers become less likely to be bedrock
+			// further up.
+			b = g.bedrock
+		case y < 0 || (y < 8 && r.IntN(8) >= int(y)):
+			b = g.deepslate
+		case y == height:
+			b = top
+		case y > height-int16(cl.fillerDepth):
+			b = cl.filler
+		default:
+			b = g.stone
+		}
+		c.SetBlock(x, y, z, 0, b)
+	}
+	for y := height + 1; y <= seaLevel; y++ {
+		c.SetBlock(x, y, z, 0, g.water)
+	}
+	caveTop := minY - 1
+	if cave := caveBiome(col); cave != nil {
+		caveTop = height - caveBiomeDepth
+		b := uint32(cave.EncodeBiome())
+		for y := minY; y <= caveTop; y++ {
+			c.SetBiome(x, y, z, b)
+		}
+	}
+	b := uint32(cl.biome.EncodeBiome())
+	for y := caveTop + 1; y <= maxY; y++ {
+		c.SetBiome(x, y, z, b)
+	}
+}
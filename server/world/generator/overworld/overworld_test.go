@@ -0,0 +1,43 @@
+package overworld_test
+
+import (
+	"os"
+	"testing"
+	_ "unsafe"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/generator/carver"
+	"github.com/df-mc/dragonfly/server/world/generator/overworld"
+)
+
+//go:linkname finaliseBlockRegistry github.com/df-mc/dragonfly/server/world.finaliseBlockRegistry
+func finaliseBlockRegistry()
+
+func TestMain(m *testing.M) {
+	// Blocks are normally finalised when a server is created, so this is done
+	// manually before running the tests of the package.
+	finaliseBlockRegistry()
+	os.Exit(m.Run())
+}
+
+// benchmarkGenerateChunk generates a new chunk using g for every iteration of
+// b, walking along a row of chunks so that different terrain is generated.
+func benchmarkGenerateChunk(b *testing.B, g world.Generator) {
+	air := world.BlockRuntimeID(block.Air{})
+	b.ReportAllocs()
+	var x int32
+	for b.Loop() {
+		g.GenerateChunk(world.ChunkPos{x, 0}, chunk.New(air, world.Overworld.Range()))
+		x++
+	}
+}
+
+func BenchmarkGenerateChunk(b *testing.B) {
+	benchmarkGenerateChunk(b, overworld.New(0))
+}
+
+func BenchmarkGenerateChunkCarved(b *testing.B) {
+	benchmarkGenerateChunk(b, carver.Config{}.New(overworld.New(0)))
+}
@@ -0,0 +1,184 @@
+package overworld
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math/rand/v2"
+	"slices"
+)
+
+// vegetation describes the plants that grow on the surface of a climate. All
+// chances are per column of the chunk.
+type vegetation struct {
+	// trees is the chance of a tree growing on a column. tree is the kind of
+	// tree grown, or alt, with a chance of altChance.
+	trees          float64
+	tree, alt      treeKind
+	altChance      float64
+	grass, flowers float64
+	// ferns specifies if some of the grass is replaced with ferns.
+	ferns bool
+	// cacti and deadBushes are the chances of cacti and dead bushes growing
+	// on sand.
+	cacti, deadBushes float64
+}
+
+// treeKind is a kind of tree that may be grown by the Generator.
+type treeKind uint8
+
+const (
+	oakTree treeKind = iota
+	birchTree
+	spruceTree
+	jungleTree
+	acaciaTree
+	treeKinds
+)
+
+// tree holds the blocks and the size of a kind of tree.
+type tree struct {
+	log, leaves uint32
+	// minHeight is the minimum height of the trunk, to which up to
+	// extraHeight blocks are added.
+	minHeight, extraHeight int
+	// conical is true for trees with a conical canopy, such as spruce trees.
+	conical bool
+}
+
+// newTrees resolves the blocks of all tree kinds.
+func newTrees() [treeKinds]tree {
+	t := func(wood block.WoodType, minHeight, extraHeight int, conical bool) tree {
+		return tree{
+			log:         world.BlockRuntimeID(block.Log{Wood: wood}),
+			leaves:      world.BlockRuntimeID(block.Leaves{Wood: wood}),
+			minHeight:   minHeight,
+			extraHeight: extraHeight,
+			conical:     conical,
+		}
+	}
+	return [treeKinds]tree{
+		oakTree:    t(block.OakWood(), 4, 3, false),
+		birchTree:  t(block.BirchWood(), 5, 3, false),
+		spruceTree: t(block.SpruceWood(), 6, 4, true),
+		jungleTree: t(block.JungleWood(), 6, 5, false),
+		acaciaTree: t(block.AcaciaWood(), 5, 2, false),
+	}
+}
+
+// plants holds the block runtime IDs of the small plants placed by the
+// Generator.
+type plants struct {
+	shortGrass, fern, cactus, deadBush uint32
+	flowers                            []uint32
+}
+
+// newPlants resolves the blocks of all small plants.
+func newPlants() plants {
+	p := plants{
+		shortGrass: world.BlockRuntimeID(block.ShortGrass{}),
+		fern:       world.BlockRuntimeID(block.Fern{}),
+		cactus:     world.BlockRuntimeID(block.Cactus{}),
+		deadBush:   world.BlockRuntimeID(block.DeadBush{}),
+	}
+	for _, f := range []block.FlowerType{block.Dandelion(), block.Poppy(), block.AzureBluet(), block.OxeyeDaisy(), block.Cornflower()} {
+		p.flowers = append(p.flowers, world.BlockRuntimeID(block.Flower{Type: f}))
+	}
+	return p
+}
+
+// decorate grows small plants on top of the column at x, z, of which the
+// highest block is at y. Trees are grown later on, by DecorateChunk.
+func (g *Generator) decorate(c *chunk.Chunk, x, z uint8, y int16, cl *climate, r *rand.Rand) {
+	v, top := cl.vegetation, c.Block(x, y, z, 0)
+	if int(y) < seaLevel || y+2 > int16(c.Range().Max()) || c.Block(x, y+1, z, 0) != g.air {
+		return
+	}
+	switch {
+	case top == g.grass:
+		if r.Float64() < v.flowers {
+			c.SetBlock(x, y+1, z, 0, g.plants.flowers[r.IntN(len(g.plants.flowers))])
+		} else if r.Float64() < v.grass {
+			if v.ferns && r.IntN(3) == 0 {
+				c.SetBlock(x, y+1, z, 0, g.plants.fern)
+				return
+			}
+			c.SetBlock(x, y+1, z, 0, g.plants.shortGrass)
+		}
+	case top == g.sand:
+		if r.Float64() < v.cacti {
+			for i := range int16(1 + r.IntN(3)) {
+				c.SetBlock(x, y+1+i, z, 0, g.plants.cactus)
+			}
+		} else if r.Float64() < v.deadBushes {
+			c.SetBlock(x, y+1, z, 0, g.plants.deadBush)
+		}
+	}
+}
+
+// small checks if the block with the runtime ID passed is one of the small
+// plants that may be replaced by a tree.
+func (p plants) small(rid uint32) bool {
+	return rid == p.shortGrass || rid == p.fern || slices.Contains(p.flowers, rid)
+}
+
+// growTree grows a tree with its trunk starting at pos. The canopy of the
+// tree may extend into the neighbouring chunks of the DecorationArea.
+func (g *Generator) growTree(a *world.DecorationArea, pos cube.Pos, t tree, r *rand.Rand) {
+	height := t.minHeight + r.IntN(t.extraHeight+1)
+	if pos[1]+height+1 > a.Range().Max() {
+		return
+	}
+	leaves := func(lx, ly, lz int) {
+		if p := pos.Add(cube.Pos{lx, ly, lz}); a.Block(p) == g.air {
+			a.SetBlock(p, t.leaves)
+		}
+	}
+	if t.conical {
+		// Spruce trees have layers of leaves alternating between a radius
+		// of 1 and 2, starting just above the trunk.
+		leaves(0, height, 0)
+		for ly, i := height-1, 0; ly >= 2; ly, i = ly-1, i+1 {
+			rad := 1 + i%2
+			for lx := -rad; lx <= rad; lx++ {
+				for lz := -rad; lz <= rad; lz++ {
+					if rad == 2 && abs(lx) == 2 && abs(lz) == 2 {
+						continue
+					}
+					leaves(lx, ly, lz)
+				}
+			}
+		}
+	} else {
+		// Other trees have a rounded canopy of two layers with a radius of
+		// 2 and two layers with a radius of 1 on top.
+		for ly := height - 3; ly <= height; ly++ {
+			rad := 2
+			if ly >= height-1 {
+				rad = 1
+			}
+			for lx := -rad; lx <= rad; lx++ {
+				for lz := -rad; lz <= rad; lz++ {
+					if abs(lx) == rad && abs(lz) == rad && (ly == height || r.IntN(2) == 0) {
+						// Corners of the canopy are randomly left out.
+						continue
+					}
+					leaves(lx, ly, lz)
+				}
+			}
+		}
+	}
+	for ly := range height {
+		a.SetBlock(pos.Add(cube.Pos{0, ly, 0}), t.log)
+	}
+	a.SetBlock(pos.Side(cube.FaceDown), g.dirt)
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
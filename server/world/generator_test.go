@@ -0,0 +1,96 @@
+package world_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// wall is a structure of 24x2x1 blocks that crosses the border between the
+// chunks at 0, 0 and 1, 0. Its second layer holds a sign in every block, with
+// the X of the sign as text.
+type wall struct{}
+
+func (wall) Dimensions() [3]int { return [3]int{24, 2, 1} }
+
+func (wall) At(x, y, _ int, _ func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	if y == 0 {
+		return block.Stone{}, nil
+	}
+	return block.Sign{Wood: block.OakWood(), Attach: block.StandingAttachment(0), Front: block.SignText{Text: string(rune('a' + x))}}, nil
+}
+
+type wallStart struct{}
+
+func (wallStart) Bounds() (min, max cube.Pos) { return cube.Pos{4, 0, 8}, cube.Pos{27, 1, 8} }
+func (wallStart) Place(ctx *world.GenerationContext) {
+	ctx.PlaceStructure(cube.Pos{4, 0, 8}, wall{})
+}
+
+type wallPlacer struct{}
+
+func (wallPlacer) Radius() int { return 1 }
+func (wallPlacer) Start(pos world.ChunkPos, _ int64) []world.StructureStart {
+	if pos == (world.ChunkPos{0, 0}) {
+		return []world.StructureStart{wallStart{}}
+	}
+	return nil
+}
+
+type wallGenerator struct{ world.NopGenerator }
+
+func (wallGenerator) StructurePlacers() []world.StructurePlacer {
+	return []world.StructurePlacer{wallPlacer{}}
+}
+
+// generateWall generates the chunks around the position passed in a new
+// World with a wallGenerator and returns the blocks of the wall once both
+// chunks it crosses are generated.
+func generateWall(t *testing.T, centre mgl64.Vec3) []world.Block {
+	w := world.Config{Generator: wallGenerator{}}.New()
+	defer w.Close()
+
+	l := world.NewLoader(6, w, world.NopViewer{})
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		var blocks []world.Block
+		<-w.Exec(func(tx *world.Tx) {
+			l.Move(tx, centre)
+			l.Load(tx, 169)
+			for x := 4; x < 28; x++ {
+				for y := 0; y < 2; y++ {
+					blocks = append(blocks, tx.Block(cube.Pos{x, y, 8}))
+				}
+			}
+		})
+		if _, ok := blocks[0].(block.Stone); ok {
+			if _, ok := blocks[len(blocks)-2].(block.Stone); ok {
+				return blocks
+			}
+		}
+		time.Sleep(time.Second / 20)
+	}
+	t.Fatalf("structure was not placed")
+	return nil
+}
+
+func TestStructureAcrossChunksIsDeterministic(t *testing.T) {
+	a := generateWall(t, mgl64.Vec3{-24, 0, 8})
+	b := generateWall(t, mgl64.Vec3{40, 0, 8})
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("block %v differs depending on generation order: %#v != %#v", i, a[i], b[i])
+		}
+		x := i / 2
+		if i%2 == 1 {
+			sign, ok := a[i].(block.Sign)
+			if !ok || sign.Front.Text != string(rune('a'+x)) {
+				t.Fatalf("expected sign with text %q at x %v, got %#v", string(rune('a'+x)), x+4, a[i])
+			}
+		}
+	}
+}
@@ -52,12 +52,22 @@ type Handler interface {
 	// Leaves decaying happens when there is no wood block neighbouring it.
 	// ctx.Cancel() may be called to prevent leaves from decaying.
 	HandleLeavesDecay(ctx *Context, pos cube.Pos)
+	// HandleEntityBuild handles an entity, such as an iron golem, being built
+	// from the blocks at the positions passed. The blocks are removed from the
+	// world if the event is not cancelled. The entity spawned may be replaced
+	// by changing the value that handle points to.
+	HandleEntityBuild(ctx *Context, blocks []cube.Pos, handle **EntityHandle)
 	// HandleEntitySpawn handles an Entity being spawned into a World through a
 	// call to Tx.AddEntity.
 	HandleEntitySpawn(tx *Tx, e Entity)
 	// HandleEntityDespawn handles an Entity being despawned from a World
 	// through a call to Tx.RemoveEntity.
 	HandleEntityDespawn(tx *Tx, e Entity)
+	// HandleDespawn handles a mob despawning because it was far away from all
+	// players, as configured by the DespawnPolicy of the World. ctx.Cancel()
+	// may be called to keep the mob in the World. HandleEntityDespawn is called
+	// after HandleDespawn if the mob despawns.
+	HandleDespawn(ctx *Context, e Entity)
 	// HandleExplosion handles an explosion in the world. ctx.Cancel() may be called
 	// to cancel the explosion.
 	// The affected entities, affected blocks, item drop chance, and whether the
@@ -86,7 +96,9 @@ func (NopHandler) HandleFireSpread(*Context, cube.Pos, cube.Pos)
 func (NopHandler) HandleBlockBurn(*Context, cube.Pos)                                            {}
 func (NopHandler) HandleCropTrample(*Context, cube.Pos)                                          {}
 func (NopHandler) HandleLeavesDecay(*Context, cube.Pos)                                          {}
+func (NopHandler) HandleEntityBuild(*Context, []cube.Pos, **EntityHandle)                        {}
 func (NopHandler) HandleEntitySpawn(*Tx, Entity)                                                 {}
 func (NopHandler) HandleEntityDespawn(*Tx, Entity)                                               {}
+func (NopHandler) HandleDespawn(*Context, Entity)                                                {}
 func (NopHandler) HandleExplosion(*Context, mgl64.Vec3, *[]Entity, *[]cube.Pos, *float64, *bool) {}
 func (NopHandler) HandleClose(*Tx)                                                               {}
@@ -0,0 +1,49 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// lightState holds the properties of a block position that affect light. It is
+// captured before a block is changed, so that light only needs to be updated if
+// one of these properties changed.
+type lightState struct {
+	emission, filter uint8
+	height           int16
+}
+
+// lightStateAt returns the lightState of the position passed in the Column c.
+func lightStateAt(c *Column, pos cube.Pos) lightState {
+	x, y, z := uint8(pos[0]&0xf), int16(pos[1]), uint8(pos[2]&0xf)
+	s := lightState{height: c.HeightMap().At(x, z)}
+	for layer := uint8(0); layer < 2; layer++ {
+		rid := c.Block(x, y, z, layer)
+		s.emission, s.filter = max(s.emission, chunk.LightBlocks[rid]), max(s.filter, chunk.FilteringBlocks[rid])
+	}
+	return s
+}
+
+// updateLight updates the block and sky light around the position passed in
+// the Column c after the block at that position was changed, if the lightState
+// before differs from the current one. Light is only updated in the chunks
+// around c that are loaded. The light is not sent to viewers, as clients
+// calculate light themselves.
+func (w *World) updateLight(c *Column, pos cube.Pos, before lightState) {
+	if lightStateAt(c, pos) == before {
+		return
+	}
+	centre := chunkPosFromBlockPos(pos)
+	area := make([]*chunk.Chunk, 0, 9)
+	for z := int32(-1); z <= 1; z++ {
+		for x := int32(-1); x <= 1; x++ {
+			if col, ok := w.chunks[ChunkPos{centre[0] + x, centre[1] + z}]; ok {
+				area = append(area, col.Chunk)
+				continue
+			}
+			area = append(area, nil)
+		}
+	}
+	area[4] = c.Chunk
+	chunk.LightArea(area, int(centre[0])-1, int(centre[1])-1).Update(pos, int(before.height))
+}
@@ -4,7 +4,9 @@ import (
 	"github.com/go-gl/mathgl/mgl64"
 	"maps"
 	"math"
+	"slices"
 	"sync"
+	"sync/atomic"
 )
 
 // Loader implements the loading of the world. A loader can typically be moved around the world to load
@@ -18,7 +20,14 @@ type Loader struct {
 	mu        sync.RWMutex
 	pos       ChunkPos
 	loadQueue []ChunkPos
+	// centrePos holds pos, packed into an int64, so that it may be read
+	// without locking mu.
+	centrePos atomic.Int64
 	loaded    map[ChunkPos]*Column
+	// placeholders holds the positions of chunks that could not be loaded and
+	// for which an empty chunk was shown to the viewer instead. These chunks
+	// remain in the load queue, so that they are shown once they are loaded.
+	placeholders map[ChunkPos]struct{}
 
 	closed bool
 }
@@ -28,7 +37,7 @@ type Loader struct {
 // The Viewer passed will handle the loading of chunks, including the viewing of entities that were loaded in
 // those chunks.
 func NewLoader(chunkRadius int, world *World, v Viewer) *Loader {
-	l := &Loader{r: chunkRadius, loaded: make(map[ChunkPos]*Column), viewer: v}
+	l := &Loader{r: chunkRadius, loaded: make(map[ChunkPos]*Column), placeholders: make(map[ChunkPos]struct{}), viewer: v}
 	l.world(world)
 	return l
 }
@@ -53,6 +62,7 @@ func (l *Loader) ChangeWorld(tx *Tx, new *World) {
 		}
 	})
 	clear(l.loaded)
+	clear(l.placeholders)
 	l.w.viewerMu.Lock()
 	delete(l.w.viewers, l)
 	l.w.viewerMu.Unlock()
@@ -80,39 +90,67 @@ func (l *Loader) Move(tx *Tx, pos mgl64.Vec3) {
 		return
 	}
 	l.pos = chunkPos
+	l.centrePos.Store(int64(chunkPos[0])<<32 | int64(uint32(chunkPos[1])))
 	l.evictUnused(tx)
 	l.populateLoadQueue()
 }
 
 // Load loads n chunks around the centre of the chunk, starting with the middle and working outwards. For
 // every chunk loaded, the Viewer passed through construction in New has its ViewChunk method called.
+// Chunks that are not yet loaded in the World are requested to be loaded on the workers of the World and
+// are passed to the Viewer in a later call to Load once they are ready, so that Load never blocks on
+// loading or generating chunks.
 // Load does nothing for n <= 0.
 func (l *Loader) Load(tx *Tx, n int) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	if l.closed || l.w == nil {
+	if l.closed || l.w == nil || n <= 0 {
 		return
 	}
-	for i := 0; i < n; i++ {
-		if len(l.loadQueue) == 0 {
-			break
+	// Only chunks close to the front of the queue are requested, so that the
+	// workers are not flooded with requests for chunks far away, which might
+	// no longer be needed by the time they are loaded.
+	requests := n * 4
+	for i := 0; i < len(l.loadQueue) && n > 0; {
+		pos := l.loadQueue[i]
+		c, ok := tx.w.chunks[pos]
+		if f, failed := tx.w.failed[pos]; !ok && failed {
+			// The chunk could not be loaded. An empty chunk is shown in its
+			// place, but the loader does not view it, so that the chunk is
+			// loaded and shown once the World retries loading it.
+			if _, shown := l.placeholders[pos]; !shown {
+				l.viewer.ViewChunk(pos, l.w.Dimension(), f)
+				l.placeholders[pos] = struct{}{}
+			}
+			i++
+			continue
 		}
-
-		pos := l.loadQueue[0]
-		c := tx.w.chunk(pos)
-
-		l.viewer.ViewChunk(pos, l.w.Dimension(), c.BlockEntities, c.Chunk)
+		if !ok {
+			if requests--; requests < 0 {
+				break
+			}
+			tx.w.requestChunk(pos)
+			i++
+			continue
+		}
+		l.viewer.ViewChunk(pos, l.w.Dimension(), c)
 		l.w.addViewer(tx, c, l)
 
 		l.loaded[pos] = c
-
-		// Shift the first element from the load queue off so that we can take a new one during the next
-		// iteration.
-		l.loadQueue = l.loadQueue[1:]
+		delete(l.placeholders, pos)
+		l.loadQueue = slices.Delete(l.loadQueue, i, i+1)
+		n--
 	}
 }
 
+// centre returns the position of the chunk that the Loader is centred around.
+// Unlike reading pos, centre may be called without holding mu.
+func (l *Loader) centre() ChunkPos {
+	v := l.centrePos.Load()
+	return ChunkPos{int32(v >> 32), int32(v)}
+}
+
 // Chunk attempts to return a chunk at the given ChunkPos. If the chunk is not loaded, the second return value will
 // be false.
 func (l *Loader) Chunk(pos ChunkPos) (*Column, bool) {
@@ -132,6 +170,7 @@ func (l *Loader) Close(tx *Tx) {
 		tx.World().removeViewer(tx, pos, l)
 	}
 	l.loaded = map[ChunkPos]*Column{}
+	clear(l.placeholders)
 
 	l.w.viewerMu.Lock()
 	delete(l.w.viewers, l)
@@ -153,13 +192,24 @@ func (l *Loader) world(new *World) {
 // and should therefore be removed.
 func (l *Loader) evictUnused(tx *Tx) {
 	for pos := range l.loaded {
-		diffX, diffZ := pos[0]-l.pos[0], pos[1]-l.pos[1]
-		dist := math.Sqrt(float64(diffX*diffX) + float64(diffZ*diffZ))
-		if int(dist) > l.r {
+		if l.outOfRange(pos) {
 			delete(l.loaded, pos)
 			l.w.removeViewer(tx, pos, l)
 		}
 	}
+	for pos := range l.placeholders {
+		if l.outOfRange(pos) {
+			delete(l.placeholders, pos)
+		}
+	}
+}
+
+// outOfRange checks if the chunk at the position passed is outside the chunk
+// radius of the loader.
+func (l *Loader) outOfRange(pos ChunkPos) bool {
+	diffX, diffZ := pos[0]-l.pos[0], pos[1]-l.pos[1]
+	dist := math.Sqrt(float64(diffX*diffX) + float64(diffZ*diffZ))
+	return int(dist) > l.r
 }
 
 // populateLoadQueue populates the load queue of the loader. This method is called once to create the order in
@@ -169,6 +219,7 @@ func (l *Loader) populateLoadQueue() {
 	// We'll first load the chunk positions to load in a map indexed by the distance to the center (basically,
 	// what precedence it should have), and put them in the loadQueue in that order.
 	queue := map[int32][]ChunkPos{}
+	border := l.w.Border()
 
 	r := int32(l.r)
 	for x := -r; x <= r; x++ {
@@ -184,6 +235,10 @@ func (l *Loader) populateLoadQueue() {
 				// The chunk was already loaded, so we don't need to do anything.
 				continue
 			}
+			if !border.containsChunk(pos) {
+				// Chunks entirely outside the world border are never loaded.
+				continue
+			}
 			if m, ok := queue[chunkDistance]; ok {
 				queue[chunkDistance] = append(m, pos)
 				continue
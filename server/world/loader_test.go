@@ -0,0 +1,110 @@
+package world
+
+import (
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// failOnce is a Provider that fails to load the first column requested.
+type failOnce struct {
+	NopProvider
+	failed atomic.Bool
+}
+
+func (p *failOnce) LoadColumn(pos ChunkPos, dim Dimension) (*chunk.Column, error) {
+	if p.failed.CompareAndSwap(false, true) {
+		return nil, errors.New("corrupted chunk")
+	}
+	return p.NopProvider.LoadColumn(pos, dim)
+}
+
+// chunkRecorder is a Viewer that records the chunks it views.
+type chunkRecorder struct {
+	NopViewer
+	viewed *[]*Column
+}
+
+func (v chunkRecorder) ViewChunk(_ ChunkPos, _ Dimension, c *Column) {
+	*v.viewed = append(*v.viewed, c)
+}
+
+// loadUntil calls Load on the Loader passed until f returns true.
+func loadUntil(t *testing.T, w *World, l *Loader, f func(tx *Tx) bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for done := false; !done; {
+		if time.Now().After(deadline) {
+			t.Fatalf("chunk was not loaded in time")
+		}
+		<-w.Exec(func(tx *Tx) {
+			l.Load(tx, 1)
+			done = f(tx)
+		})
+	}
+}
+
+func TestLoaderShowsChunkLoadedAfterFailure(t *testing.T) {
+	w := Config{Provider: &failOnce{}}.New()
+	defer w.Close()
+
+	var viewed []*Column
+	l := NewLoader(1, w, chunkRecorder{viewed: &viewed})
+	pos := ChunkPos{}
+	loadUntil(t, w, l, func(tx *Tx) bool { return len(viewed) > 0 })
+	<-w.Exec(func(tx *Tx) {
+		if _, ok := l.Chunk(pos); ok {
+			t.Errorf("expected chunk that failed to load not to be loaded by the loader")
+		}
+		if slices.Contains(viewed[0].loaders, l) {
+			t.Errorf("expected loader not to view the chunk that failed to load")
+		}
+		// Failed chunks are cleared periodically, after which they are loaded
+		// again.
+		clear(w.failed)
+	})
+
+	loadUntil(t, w, l, func(tx *Tx) bool {
+		_, ok := l.Chunk(pos)
+		return ok
+	})
+	<-w.Exec(func(tx *Tx) {
+		c, _ := l.Chunk(pos)
+		if c != w.chunks[pos] || viewed[len(viewed)-1] != c {
+			t.Errorf("expected the loaded chunk to replace the empty chunk shown before")
+		}
+		if !slices.Contains(c.loaders, l) {
+			t.Errorf("expected loader to view the loaded chunk")
+		}
+	})
+}
+
+func TestLoaderQueuesChunksInsideChangedBorder(t *testing.T) {
+	w := Config{}.New()
+	defer w.Close()
+
+	// The border initially only contains the chunk at 0, 0.
+	w.SetBorder(mgl64.Vec2{8, 8}, 16)
+	l := NewLoader(4, w, NopViewer{})
+	queued := func() int {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+		return len(l.loadQueue)
+	}
+	if n := queued(); n != 1 {
+		t.Fatalf("expected only the chunk inside the border to be queued, got %v chunks", n)
+	}
+	w.SetBorder(mgl64.Vec2{8, 8}, 48)
+	if n := queued(); n != 9 {
+		t.Fatalf("expected the 9 chunks inside the new border to be queued, got %v chunks", n)
+	}
+	// Chunks are queued as soon as the border starts growing towards them.
+	w.ResizeBorder(80, time.Hour)
+	if n := queued(); n != 25 {
+		t.Fatalf("expected the 25 chunks inside the growing border to be queued, got %v chunks", n)
+	}
+}
@@ -0,0 +1,120 @@
+package world
+
+import (
+	"context"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"log/slog"
+)
+
+// Log returns a Logger that logs to the Logger of the World of the Tx. Besides
+// the attributes of the World, such as its name and dimension, records logged
+// are enriched with what the transaction is doing at the time, such as the
+// position of the block or the entity being ticked. These attributes are only
+// added to records that pass the level configured for the Logger, so logging
+// through the Logger is as cheap as logging through Config.Log directly.
+// The Logger returned must not be used after the transaction finishes.
+func (tx *Tx) Log() *slog.Logger {
+	if tx.log == nil {
+		tx.log = slog.New(txHandler{h: tx.World().conf.Log.Handler(), tx: tx})
+	}
+	return tx.log
+}
+
+// txOperation is an operation that a Tx may be performing while records are
+// logged using Tx.Log.
+type txOperation uint8
+
+const (
+	txOperationNone txOperation = iota
+	txOperationBlockTick
+	txOperationRandomTick
+	txOperationScheduledTick
+	txOperationNeighbourUpdate
+	txOperationEntityTick
+)
+
+// String ...
+func (op txOperation) String() string {
+	switch op {
+	case txOperationBlockTick:
+		return "block tick"
+	case txOperationRandomTick:
+		return "random tick"
+	case txOperationScheduledTick:
+		return "scheduled tick"
+	case txOperationNeighbourUpdate:
+		return "neighbour update"
+	case txOperationEntityTick:
+		return "entity tick"
+	}
+	return "none"
+}
+
+// txContext holds the operation a Tx is currently performing and the block
+// position or entity that it is performed on.
+type txContext struct {
+	op  txOperation
+	pos cube.Pos
+	e   *EntityHandle
+}
+
+// setBlockContext sets the operation of the Tx to op, performed on the block
+// at the position passed.
+func (tx *Tx) setBlockContext(op txOperation, pos cube.Pos) {
+	tx.ctx = txContext{op: op, pos: pos}
+}
+
+// setEntityContext sets the operation of the Tx to op, performed on the
+// entity passed.
+func (tx *Tx) setEntityContext(op txOperation, e *EntityHandle) {
+	tx.ctx = txContext{op: op, e: e}
+}
+
+// resetContext resets the operation of the Tx after it was finished.
+func (tx *Tx) resetContext() {
+	tx.ctx = txContext{}
+}
+
+// txHandler is a slog.Handler that adds the txContext of a Tx to records
+// before passing them to the slog.Handler of the World.
+type txHandler struct {
+	h  slog.Handler
+	tx *Tx
+}
+
+// Enabled ...
+func (h txHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.h.Enabled(ctx, level)
+}
+
+// Handle adds attributes describing the current operation of the Tx to the
+// record passed, if any, and passes it to the underlying slog.Handler.
+func (h txHandler) Handle(ctx context.Context, r slog.Record) error {
+	if c := h.tx.ctx; c.op != txOperationNone && !h.tx.closed {
+		r = r.Clone()
+		r.AddAttrs(slog.String("op", c.op.String()))
+		if c.e != nil {
+			r.AddAttrs(
+				slog.String("entity", c.e.Type().EncodeEntity()),
+				slog.String("uuid", c.e.UUID().String()),
+				slog.String("chunk", chunkPosFromVec3(c.e.data.Pos).String()),
+			)
+		} else {
+			r.AddAttrs(
+				slog.String("pos", c.pos.String()),
+				slog.String("chunk", chunkPosFromBlockPos(c.pos).String()),
+			)
+		}
+	}
+	return h.h.Handle(ctx, r)
+}
+
+// WithAttrs ...
+func (h txHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return txHandler{h: h.h.WithAttrs(attrs), tx: h.tx}
+}
+
+// WithGroup ...
+func (h txHandler) WithGroup(name string) slog.Handler {
+	return txHandler{h: h.h.WithGroup(name), tx: h.tx}
+}
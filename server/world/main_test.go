@@ -0,0 +1,19 @@
+package world_test
+
+import (
+	"os"
+	"testing"
+	_ "unsafe"
+
+	_ "github.com/df-mc/dragonfly/server/block"
+)
+
+//go:linkname finaliseBlockRegistry github.com/df-mc/dragonfly/server/world.finaliseBlockRegistry
+func finaliseBlockRegistry()
+
+func TestMain(m *testing.M) {
+	// Blocks are normally registered and finalised when a server is created,
+	// so this is done manually before running the tests of the package.
+	finaliseBlockRegistry()
+	os.Exit(m.Run())
+}
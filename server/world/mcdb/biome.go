@@ -0,0 +1,156 @@
+package mcdb
+
+import (
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"maps"
+	"math"
+	"slices"
+)
+
+// biomeIDs maps the IDs that custom biomes are stored with in chunks to the
+// IDs that they are registered with and back. The IDs that custom biomes are
+// stored with are kept in the world along with their names, so that chunks
+// keep referring to the same custom biomes if the IDs that they are
+// registered with change. Custom biomes that are stored in the world but are
+// not registered keep their IDs in chunks, so that they are restored once the
+// biome is registered again.
+type biomeIDs struct {
+	// table maps the names of custom biomes to the IDs that they are stored
+	// with.
+	table map[string]int16
+	// toRuntime and toDisk hold the stored IDs that differ from the IDs at
+	// runtime. Both are empty in the common case that they are equal.
+	toRuntime, toDisk map[uint32]uint32
+}
+
+// loadBiomeIDs reads the IDs that custom biomes are stored with from the DB
+// and assigns IDs to custom biomes registered that are not yet stored. The IDs
+// are written back to the DB if any IDs were assigned.
+func (db *DB) loadBiomeIDs() error {
+	ids := biomeIDs{table: make(map[string]int16), toRuntime: make(map[uint32]uint32), toDisk: make(map[uint32]uint32)}
+	data, err := db.ldb.Get([]byte(keyBiomeIDs), nil)
+	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		return fmt.Errorf("read biome IDs: %w", err)
+	}
+	if err == nil {
+		var m map[string]any
+		if err := nbt.UnmarshalEncoding(data, &m, nbt.LittleEndian); err != nil {
+			return fmt.Errorf("decode biome IDs: %w", err)
+		}
+		for name, v := range m {
+			switch id := v.(type) {
+			case int16:
+				ids.table[name] = id
+			case int32:
+				ids.table[name] = int16(id)
+			}
+		}
+	}
+	stored := make(map[uint32]bool, len(ids.table))
+	for _, id := range ids.table {
+		stored[uint32(id)] = true
+	}
+
+	var assigned bool
+	runtime := make(map[uint32]bool)
+	for _, b := range world.Biomes() {
+		if !world.CustomBiome(b) {
+			continue
+		}
+		id := uint32(b.EncodeBiome())
+		runtime[id] = true
+
+		diskID, ok := ids.table[b.String()]
+		if !ok {
+			// The biome is stored with the ID it is registered with if no
+			// other biome is stored with that ID yet.
+			d, ok := freeBiomeID(id, stored)
+			if !ok {
+				return fmt.Errorf("assign ID to custom biome %v: no IDs left", b)
+			}
+			diskID, assigned = int16(d), true
+			ids.table[b.String()], stored[d] = diskID, true
+		}
+		if uint32(diskID) != id {
+			ids.toRuntime[uint32(diskID)], ids.toDisk[id] = id, uint32(diskID)
+		}
+	}
+	// Custom biomes that are not registered keep the ID that they are stored
+	// with, unless a registered biome uses that ID. They are then moved to an
+	// ID that no other biome uses while loaded, and moved back when stored.
+	for _, name := range slices.Sorted(maps.Keys(ids.table)) {
+		diskID := uint32(ids.table[name])
+		if _, ok := world.BiomeByName(name); ok || !runtime[diskID] {
+			continue
+		}
+		id := uint32(math.MaxInt16)
+		for ; runtime[id] || stored[id]; id-- {
+		}
+		runtime[id] = true
+		ids.toRuntime[diskID], ids.toDisk[id] = id, diskID
+	}
+	db.biomeIDs = ids
+
+	if !assigned {
+		return nil
+	}
+	m := make(map[string]any, len(ids.table))
+	for name, id := range ids.table {
+		m[name] = id
+	}
+	data, err = nbt.MarshalEncoding(m, nbt.LittleEndian)
+	if err != nil {
+		return fmt.Errorf("encode biome IDs: %w", err)
+	}
+	if err := db.ldb.Put([]byte(keyBiomeIDs), data, nil); err != nil {
+		return fmt.Errorf("write biome IDs: %w", err)
+	}
+	return nil
+}
+
+// freeBiomeID returns the first ID starting from the ID passed that is not
+// present in used. False is returned if all IDs from id are used.
+func freeBiomeID(id uint32, used map[uint32]bool) (uint32, bool) {
+	for ; id <= math.MaxInt16; id++ {
+		if !used[id] {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// runtimeBiomes replaces the IDs of custom biomes stored in the chunk passed
+// with the IDs that the biomes are registered with.
+func (ids biomeIDs) runtimeBiomes(c *chunk.Chunk) {
+	if len(ids.toRuntime) == 0 {
+		return
+	}
+	c.ReplaceBiomes(func(v uint32) uint32 {
+		if id, ok := ids.toRuntime[v]; ok {
+			return id
+		}
+		return v
+	})
+}
+
+// diskBiomes returns a copy of the chunk passed with the IDs of custom biomes
+// replaced with the IDs that they are stored with. If the IDs are the same,
+// the chunk passed is returned.
+func (ids biomeIDs) diskBiomes(c *chunk.Chunk) *chunk.Chunk {
+	if len(ids.toDisk) == 0 {
+		return c
+	}
+	c = c.Clone()
+	c.ReplaceBiomes(func(v uint32) uint32 {
+		if id, ok := ids.toDisk[v]; ok {
+			return id
+		}
+		return v
+	})
+	return c
+}
@@ -60,5 +60,9 @@ func (conf Config) Open(dir string) (*DB, error) {
 		return nil, fmt.Errorf("open db: leveldb: %w", err)
 	}
 	db.ldb = ldb
+	if err := db.loadBiomeIDs(); err != nil {
+		_ = ldb.Close()
+		return nil, fmt.Errorf("open db: %w", err)
+	}
 	return db, nil
 }
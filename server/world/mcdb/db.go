@@ -27,6 +27,8 @@ type DB struct {
 	dir  string
 	ldat *leveldat.Data
 	set  *world.Settings
+
+	biomeIDs biomeIDs
 }
 
 // Open creates a new provider reading and writing from/to files under the path
@@ -57,15 +59,28 @@ type playerData struct {
 
 // LoadPlayerSpawnPosition loads the players spawn position stored in the level.dat from their UUID.
 func (db *DB) LoadPlayerSpawnPosition(id uuid.UUID) (pos cube.Pos, exists bool, err error) {
+	pos, _, exists, err = db.LoadPlayerSpawn(id)
+	return pos, exists, err
+}
+
+// LoadPlayerSpawn loads the players spawn position and the dimension it is in from their UUID. Spawn positions saved
+// without a dimension are assumed to be in the overworld.
+func (db *DB) LoadPlayerSpawn(id uuid.UUID) (pos cube.Pos, dim world.Dimension, exists bool, err error) {
 	serverData, _, exists, err := db.loadPlayerData(id)
 	if !exists || err != nil {
-		return cube.Pos{}, exists, err
+		return cube.Pos{}, world.Overworld, exists, err
 	}
 	x, y, z := serverData["SpawnX"], serverData["SpawnY"], serverData["SpawnZ"]
 	if x == nil || y == nil || z == nil {
-		return cube.Pos{}, true, fmt.Errorf("error reading spawn fields from server data for player %v", id)
+		return cube.Pos{}, world.Overworld, true, fmt.Errorf("error reading spawn fields from server data for player %v", id)
 	}
-	return cube.Pos{int(x.(int32)), int(y.(int32)), int(z.(int32))}, true, nil
+	dim = world.Overworld
+	if dimID, ok := serverData["SpawnDimension"].(int32); ok {
+		if dim, ok = world.DimensionByID(int(dimID)); !ok {
+			return cube.Pos{}, world.Overworld, true, fmt.Errorf("unknown spawn dimension %v in server data for player %v", dimID, id)
+		}
+	}
+	return cube.Pos{int(x.(int32)), int(y.(int32)), int(z.(int32))}, dim, true, nil
 }
 
 // loadPlayerData loads the data stored in a LevelDB database for a specific UUID.
@@ -95,8 +110,14 @@ func (db *DB) loadPlayerData(id uuid.UUID) (serverData map[string]interface{}, k
 	return serverData, d.ServerID, true, nil
 }
 
-// SavePlayerSpawnPosition saves the player spawn position passed to the levelDB database.
+// SavePlayerSpawnPosition saves the player spawn position passed to the levelDB database. The spawn position is saved
+// as being in the overworld.
 func (db *DB) SavePlayerSpawnPosition(id uuid.UUID, pos cube.Pos) error {
+	return db.SavePlayerSpawn(id, pos, world.Overworld)
+}
+
+// SavePlayerSpawn saves the player spawn position and its dimension passed to the levelDB database.
+func (db *DB) SavePlayerSpawn(id uuid.UUID, pos cube.Pos, dim world.Dimension) error {
 	_, err := db.ldb.Get([]byte("player_"+id.String()), nil)
 	d := make(map[string]interface{})
 	k := "player_server_" + id.String()
@@ -113,6 +134,8 @@ func (db *DB) SavePlayerSpawnPosition(id uuid.UUID, pos cube.Pos) error {
 		return err
 	}
 	d["SpawnX"], d["SpawnY"], d["SpawnZ"] = int32(pos.X()), int32(pos.Y()), int32(pos.Z())
+	dimID, _ := world.DimensionID(dim)
+	d["SpawnDimension"] = int32(dimID)
 
 	data, err := nbt.MarshalEncoding(d, nbt.LittleEndian)
 	if err != nil {
@@ -124,6 +147,35 @@ func (db *DB) SavePlayerSpawnPosition(id uuid.UUID, pos cube.Pos) error {
 	return nil
 }
 
+// LoadStructure loads the NBT data of a structure saved by a structure block
+// under the name passed.
+func (db *DB) LoadStructure(name string) (map[string]any, bool, error) {
+	data, err := db.ldb.Get([]byte(keyStructureTemplate+name), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("read structure %v: %w", name, err)
+	}
+	var m map[string]any
+	if err := nbt.UnmarshalEncoding(data, &m, nbt.LittleEndian); err != nil {
+		return nil, true, fmt.Errorf("decode structure %v: %w", name, err)
+	}
+	return m, true, nil
+}
+
+// StoreStructure stores the NBT data of a structure under the name passed, so
+// that it may be loaded by structure blocks.
+func (db *DB) StoreStructure(name string, data map[string]any) error {
+	b, err := nbt.MarshalEncoding(data, nbt.LittleEndian)
+	if err != nil {
+		return fmt.Errorf("encode structure %v: %w", name, err)
+	}
+	if err := db.ldb.Put([]byte(keyStructureTemplate+name), b, nil); err != nil {
+		return fmt.Errorf("write structure %v: %w", name, err)
+	}
+	return nil
+}
+
 // LoadColumn reads a world.Column from the DB at a position and dimension in
 // the DB. If no column at that position exists, errors.Is(err,
 // leveldb.ErrNotFound) equals true.
@@ -149,10 +201,14 @@ func (db *DB) column(k dbKey) (*chunk.Column, error) {
 	if ver != chunkVersion {
 		db.conf.Log.Debug("column: unsupported chunk version, trying to load anyway", "X", k.pos[0], "Z", k.pos[1], "dimension", fmt.Sprint(k.dim), "ver", ver)
 	}
+	var biomes2D []byte
 	cdata.Biomes, err = db.biomes(k)
-	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+	if errors.Is(err, leveldb.ErrNotFound) {
 		// Some chunks still use 2D chunk data and might not have this field, in
-		// which case we can just move on.
+		// which case the 2D biomes are read instead.
+		biomes2D, err = db.biomes2D(k)
+	}
+	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
 		return nil, fmt.Errorf("read biomes: %w", err)
 	}
 	cdata.SubChunks, err = db.subChunks(k)
@@ -163,6 +219,10 @@ func (db *DB) column(k dbKey) (*chunk.Column, error) {
 	if err != nil {
 		return nil, fmt.Errorf("decode chunk data: %w", err)
 	}
+	if biomes2D != nil {
+		extrudeBiomes(col.Chunk, biomes2D)
+	}
+	db.biomeIDs.runtimeBiomes(col.Chunk)
 	col.Entities, err = db.entities(k)
 	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
 		// Not all chunks need to have entities, so an ErrNotFound is fine here.
@@ -177,9 +237,27 @@ func (db *DB) column(k dbKey) (*chunk.Column, error) {
 	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
 		return nil, fmt.Errorf("read scheduled updates: %w", err)
 	}
+	finalisation, err := db.finalisation(k)
+	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		return nil, fmt.Errorf("read finalisation: %w", err)
+	}
+	col.Undecorated = finalisation == finalisationGenerated
 	return col, nil
 }
 
+// finalisation reads the state of generation of a chunk. Chunks without a
+// finalisation are treated as fully finalised by the caller.
+func (db *DB) finalisation(k dbKey) (uint32, error) {
+	p, err := db.ldb.Get(k.Sum(keyFinalisation), nil)
+	if err != nil {
+		return 0, err
+	}
+	if n := len(p); n != 4 {
+		return 0, fmt.Errorf("expected 4 finalisation bytes, got %v", n)
+	}
+	return binary.LittleEndian.Uint32(p), nil
+}
+
 func (db *DB) version(k dbKey) (byte, error) {
 	p, err := db.ldb.Get(k.Sum(keyVersion), nil)
 	if errors.Is(err, leveldb.ErrNotFound) {
@@ -211,6 +289,34 @@ func (db *DB) biomes(k dbKey) ([]byte, error) {
 	return biomes[512:], nil
 }
 
+// biomes2D reads the biomes of a chunk saved before biomes were stored
+// 3-dimensionally. One biome ID is stored for every column of the chunk.
+func (db *DB) biomes2D(k dbKey) ([]byte, error) {
+	data, err := db.ldb.Get(k.Sum(key2DData), nil)
+	if err != nil {
+		return nil, err
+	}
+	// Like the 3D data, the 2D data starts with a 512-byte heightmap, which is
+	// followed by 256 biome IDs.
+	if n := len(data); n != 768 {
+		return nil, fmt.Errorf("expected 768 bytes for 2D data, got %v", n)
+	}
+	return data[512:], nil
+}
+
+// extrudeBiomes sets the biomes of every column in the chunk passed to the
+// biome ID found for that column in 2D biome data, from the bottom of the
+// chunk to the top.
+func extrudeBiomes(c *chunk.Chunk, biomes []byte) {
+	r := c.Range()
+	for i, b := range biomes {
+		x, z := uint8(i&0xf), uint8(i>>4)
+		for y := r.Min(); y <= r.Max(); y++ {
+			c.SetBiome(x, int16(y), z, uint32(b))
+		}
+	}
+}
+
 func (db *DB) subChunks(k dbKey) ([][]byte, error) {
 	r := k.dim.Range()
 	sub := make([][]byte, (r.Height()>>4)+1)
@@ -324,6 +430,16 @@ func (db *DB) scheduledUpdates(k dbKey) ([]chunk.ScheduledBlockUpdate, int64, er
 	return updates, int64(m.CurrentTick), nil
 }
 
+// HasColumn checks if a world.Column is stored at a position and dimension in
+// the DB without loading it.
+func (db *DB) HasColumn(pos world.ChunkPos, dim world.Dimension) (bool, error) {
+	k := dbKey{pos: pos, dim: dim}
+	if ok, err := db.ldb.Has(k.Sum(keyVersion), nil); ok || err != nil {
+		return ok, err
+	}
+	return db.ldb.Has(k.Sum(keyVersionOld), nil)
+}
+
 // StoreColumn stores a world.Column at a position and dimension in the DB. An
 // error is returned if storing was unsuccessful.
 func (db *DB) StoreColumn(pos world.ChunkPos, dim world.Dimension, col *chunk.Column) error {
@@ -335,14 +451,18 @@ func (db *DB) StoreColumn(pos world.ChunkPos, dim world.Dimension, col *chunk.Co
 }
 
 func (db *DB) storeColumn(k dbKey, col *chunk.Column) error {
-	data := chunk.Encode(col.Chunk, chunk.DiskEncoding)
+	data := chunk.Encode(db.biomeIDs.diskBiomes(col.Chunk), chunk.DiskEncoding)
 	n := 7 + len(data.SubChunks) + len(col.Entities)
 	batch := leveldb.MakeBatch(n)
 
 	db.storeVersion(batch, k, chunkVersion)
 	db.storeBiomes(batch, k, data.Biomes)
 	db.storeSubChunks(batch, k, data.SubChunks, col.Chunk.Range())
-	db.storeFinalisation(batch, k, finalisationPopulated)
+	finalisation := uint32(finalisationPopulated)
+	if col.Undecorated {
+		finalisation = finalisationGenerated
+	}
+	db.storeFinalisation(batch, k, finalisation)
 	db.storeEntities(batch, k, col.Entities)
 	db.storeBlockEntities(batch, k, col.BlockEntities)
 	db.storeScheduledUpdates(batch, k, col.Tick, col.ScheduledBlocks)
@@ -358,6 +478,9 @@ var emptyHeightmap = make([]byte, 512)
 
 func (db *DB) storeBiomes(batch *leveldb.Batch, k dbKey, biomes []byte) {
 	batch.Put(k.Sum(key3DData), append(emptyHeightmap, biomes...))
+	// Chunks loaded from 2D data were migrated to 3D biomes, so the 2D data is
+	// no longer needed.
+	batch.Delete(k.Sum(key2DData))
 }
 
 func (db *DB) storeSubChunks(batch *leveldb.Batch, k dbKey, subChunks [][]byte, r cube.Range) {
@@ -49,6 +49,12 @@ const (
 	keyBiomeData          = "BiomeData"
 	keyScoreboard         = "scoreboard"
 	keyLocalPlayer        = "~local_player"
+	// keyBiomeIDs holds an NBT compound that maps the names of custom biomes
+	// to the IDs that they are stored with in chunks.
+	keyBiomeIDs = "BiomeIdsTable"
+	// keyStructureTemplate prefixes the names of structures saved by
+	// structure blocks. The structures are stored in the .mcstructure format.
+	keyStructureTemplate = "structuretemplate_"
 )
 
 const (
@@ -3,6 +3,7 @@ package leveldat
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"math"
 	"time"
@@ -15,6 +16,13 @@ import (
 type Data struct {
 	BaseGameVersion                string `nbt:"baseGameVersion"`
 	BiomeOverride                  string
+	BorderCenterX, BorderCenterZ   float64
+	BorderSize                     float64
+	BorderSizeLerpTarget           float64
+	BorderResizeStart              int64
+	BorderResizeEnd                int64
+	BorderSafeZone                 float64
+	BorderDamagePerBlock           float64
 	ConfirmedPlatformLockedContent bool
 	CenterMapsToOrigin             bool
 	CheatsEnabled                  bool  `nbt:"cheatsEnabled"`
@@ -229,25 +237,39 @@ func (d *Data) Settings() *world.Settings {
 	difficulty, _ := world.DifficultyByID(int(d.Difficulty))
 	mode, _ := world.GameModeByID(int(d.GameType))
 	return &world.Settings{
-		Name:            d.LevelName,
-		Spawn:           cube.Pos{int(d.SpawnX), int(d.SpawnY), int(d.SpawnZ)},
-		Time:            d.Time,
-		TimeCycle:       d.DoDayLightCycle,
-		RainTime:        int64(d.RainTime),
-		Raining:         d.RainLevel > 0,
-		ThunderTime:     int64(d.LightningTime),
-		Thundering:      d.LightningLevel > 0,
-		WeatherCycle:    d.DoWeatherCycle,
-		CurrentTick:     d.CurrentTick,
-		DefaultGameMode: mode,
-		Difficulty:      difficulty,
-		TickRange:       d.ServerChunkTickRange,
+		Name:                d.LevelName,
+		Seed:                d.RandomSeed,
+		Spawn:               cube.Pos{int(d.SpawnX), int(d.SpawnY), int(d.SpawnZ)},
+		Time:                d.Time,
+		TimeCycle:           d.DoDayLightCycle,
+		RainTime:            int64(d.RainTime),
+		Raining:             d.RainLevel > 0,
+		ThunderTime:         int64(d.LightningTime),
+		Thundering:          d.LightningLevel > 0,
+		WeatherCycle:        d.DoWeatherCycle,
+		CurrentTick:         d.CurrentTick,
+		DefaultGameMode:     mode,
+		Difficulty:          difficulty,
+		TickRange:           d.ServerChunkTickRange,
+		Insomnia:            d.DoInsomnia,
+		MobGriefing:         d.MobGriefing,
+		NaturalRegeneration: d.NaturalRegeneration,
+		Border: world.Border{
+			Centre:       mgl64.Vec2{d.BorderCenterX, d.BorderCenterZ},
+			From:         d.BorderSize,
+			To:           d.BorderSizeLerpTarget,
+			ResizeStart:  d.BorderResizeStart,
+			ResizeEnd:    d.BorderResizeEnd,
+			DamageBuffer: d.BorderSafeZone,
+			Damage:       d.BorderDamagePerBlock,
+		},
 	}
 }
 
 // PutSettings updates d with the Settings stored in s.
 func (d *Data) PutSettings(s *world.Settings) {
 	d.LevelName = s.Name
+	d.RandomSeed = s.Seed
 	d.SpawnX, d.SpawnY, d.SpawnZ = int32(s.Spawn.X()), int32(s.Spawn.Y()), int32(s.Spawn.Z())
 	d.LimitedWorldOriginX, d.LimitedWorldOriginY, d.LimitedWorldOriginZ = d.SpawnX, d.SpawnY, d.SpawnZ
 	d.Time = s.Time
@@ -263,6 +285,13 @@ func (d *Data) PutSettings(s *world.Settings) {
 	}
 	d.CurrentTick = s.CurrentTick
 	d.ServerChunkTickRange = s.TickRange
+	d.DoInsomnia = s.Insomnia
+	d.MobGriefing = s.MobGriefing
+	d.NaturalRegeneration = s.NaturalRegeneration
+	d.BorderCenterX, d.BorderCenterZ = s.Border.Centre[0], s.Border.Centre[1]
+	d.BorderSize, d.BorderSizeLerpTarget = s.Border.From, s.Border.To
+	d.BorderResizeStart, d.BorderResizeEnd = s.Border.ResizeStart, s.Border.ResizeEnd
+	d.BorderSafeZone, d.BorderDamagePerBlock = s.Border.DamageBuffer, s.Border.Damage
 	mode, _ := world.GameModeID(s.DefaultGameMode)
 	d.GameType = int32(mode)
 	difficulty, _ := world.DifficultyID(s.Difficulty)
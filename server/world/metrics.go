@@ -0,0 +1,117 @@
+package world
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// TickMetrics holds metrics of a World over its most recent ticks, such as may
+// be exported to a monitoring system. TickMetrics are obtained through
+// World.TickMetrics.
+type TickMetrics struct {
+	// TPS is the amount of ticks that the World ran per second over the
+	// metrics window. A World that keeps up runs 20 ticks per second.
+	TPS float64
+	// TickP50, TickP95 and TickP99 are the 50th, 95th and 99th percentiles of
+	// the time spent running a single tick of the World over the metrics
+	// window. TickMax is the time spent on the longest tick of the window.
+	// Ticks that take longer than 50ms cause the World to fall behind.
+	TickP50, TickP95, TickP99, TickMax time.Duration
+	// Chunks is the amount of chunks loaded in the World.
+	Chunks int
+	// Entities is the amount of entities in the World, including players.
+	Entities int
+	// QueueDepth is the amount of transactions waiting to be run by the
+	// World. A QueueDepth that keeps growing means that the World is not able
+	// to keep up with the transactions submitted to it.
+	QueueDepth int
+}
+
+const (
+	// metricsWindow is the amount of most recent ticks of which the durations
+	// are included in the TickMetrics of a World.
+	metricsWindow = 100
+	// metricsInterval is the amount of ticks after which new TickMetrics of a
+	// World are published.
+	metricsInterval = 20
+)
+
+// tickRecorder records the duration of the ticks of a World and publishes
+// TickMetrics once every metricsInterval ticks. Ticks are recorded by the
+// goroutine that runs transactions without any locking, so that the metrics
+// published may be read from any goroutine while adding as little overhead
+// to ticks as possible. All methods of tickRecorder may be called on a nil
+// *tickRecorder, in which case they do nothing, so that recording metrics has
+// no overhead when disabled.
+type tickRecorder struct {
+	n, i      int
+	starts    [metricsWindow]time.Time
+	durations [metricsWindow]time.Duration
+
+	mu   sync.Mutex
+	last TickMetrics
+}
+
+// newTickRecorder returns a new tickRecorder if enabled is true, or nil
+// otherwise.
+func newTickRecorder(enabled bool) *tickRecorder {
+	if !enabled {
+		return nil
+	}
+	return &tickRecorder{}
+}
+
+// start returns the current time if the tickRecorder is enabled.
+func (r *tickRecorder) start() time.Time {
+	if r == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// tick records a tick of the World passed that started at the time passed.
+// Once every metricsInterval ticks, new TickMetrics are published.
+func (r *tickRecorder) tick(w *World, start time.Time) {
+	if r == nil {
+		return
+	}
+	r.starts[r.i], r.durations[r.i] = start, time.Since(start)
+	r.i, r.n = (r.i+1)%metricsWindow, min(r.n+1, metricsWindow)
+	if r.i%metricsInterval != 0 {
+		return
+	}
+	m := TickMetrics{Chunks: len(w.chunks), Entities: len(w.entities)}
+	if r.n > 1 {
+		// r.i is the index of the oldest tick recorded once the window is
+		// full, and 0 before that.
+		oldest, newest := r.i%r.n, (r.i+metricsWindow-1)%metricsWindow
+		m.TPS = float64(r.n-1) / r.starts[newest].Sub(r.starts[oldest]).Seconds()
+	}
+	durations := slices.Clone(r.durations[:r.n])
+	slices.Sort(durations)
+	m.TickP50, m.TickP95, m.TickP99 = percentile(durations, 0.5), percentile(durations, 0.95), percentile(durations, 0.99)
+	m.TickMax = durations[len(durations)-1]
+
+	r.mu.Lock()
+	r.last = m
+	r.mu.Unlock()
+}
+
+// metrics returns the TickMetrics last published. False is returned if the
+// tickRecorder is not enabled.
+func (r *tickRecorder) metrics() (TickMetrics, bool) {
+	if r == nil {
+		return TickMetrics{}, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last, true
+}
+
+// percentile returns the q-th quantile of the sorted durations passed using
+// the nearest-rank method. durations must not be empty.
+func percentile(durations []time.Duration, q float64) time.Duration {
+	return durations[max(int(math.Ceil(q*float64(len(durations))))-1, 0)]
+}
@@ -32,3 +32,6 @@ type Effect struct {
 
 // EntityFlame is a particle shown when an entity is set on fire.
 type EntityFlame struct{ particle }
+
+// SonicExplosion is a particle shown along the path of the sonic boom of a warden.
+type SonicExplosion struct{ particle }
@@ -0,0 +1,228 @@
+package world
+
+import (
+	"container/heap"
+	"fmt"
+	"log/slog"
+	"math"
+	"runtime/debug"
+	"slices"
+	"sync"
+)
+
+// workerPool runs jobs on a fixed amount of goroutines. Jobs submitted to a
+// workerPool are queued without bound, so that submitting a job never blocks,
+// even if all workers are busy. Jobs that do not load a chunk are run first,
+// in the order in which they were submitted. Jobs loading chunks are run in
+// order of their distance to the closest loader, so that the chunks that
+// players are waiting for are loaded first.
+type workerPool struct {
+	name string
+	log  *slog.Logger
+
+	mu    sync.Mutex
+	cond  *sync.Cond
+	tasks []func()
+	// chunks holds the queued jobs that load chunks, ordered by their
+	// priority. centres are the positions of the chunks that the loaders of
+	// the World were centred around when setCentres was last called, which
+	// the priorities of the jobs are computed with.
+	chunks  jobHeap
+	centres []ChunkPos
+	seq     uint64
+	closed  bool
+
+	wg sync.WaitGroup
+}
+
+// job is a job loading a chunk queued in a workerPool.
+type job struct {
+	f func()
+	// req is the chunk request that the job loads the chunk at pos for.
+	req *chunkRequest
+	pos ChunkPos
+
+	// priority is the priority of the job. Jobs with a lower priority are run
+	// first. Jobs with the same priority are run in the order of seq, which
+	// is the order in which they were submitted.
+	priority int64
+	seq      uint64
+}
+
+// prioritise updates the priority of the job, given the positions of the
+// loaders passed.
+func (j *job) prioritise(centres []ChunkPos) {
+	if j.req.urgent.Load() {
+		// A transaction of the World is blocked waiting for the chunk.
+		j.priority = -1
+		return
+	}
+	j.priority = math.MaxInt64
+	for _, c := range centres {
+		dx, dz := int64(j.pos[0]-c[0]), int64(j.pos[1]-c[1])
+		j.priority = min(j.priority, dx*dx+dz*dz)
+	}
+}
+
+// jobHeap is a heap of jobs ordered by their priority.
+type jobHeap []*job
+
+func (h jobHeap) Len() int { return len(h) }
+func (h jobHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority < h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+func (h jobHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *jobHeap) Push(x any)   { *h = append(*h, x.(*job)) }
+func (h *jobHeap) Pop() any {
+	old := *h
+	j := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return j
+}
+
+// newWorkerPool creates a workerPool with n workers, which start waiting for
+// jobs immediately. The name passed is used when logging jobs that panicked.
+func newWorkerPool(name string, n int, log *slog.Logger) *workerPool {
+	p := &workerPool{name: name, log: log}
+	p.cond = sync.NewCond(&p.mu)
+	p.wg.Add(n)
+	for range n {
+		go p.work()
+	}
+	return p
+}
+
+// setCentres sets the positions of the chunks that the loaders of the World
+// are centred around, which are used to prioritise the jobs loading chunks.
+// The priorities of all queued jobs are recomputed, so setCentres should be
+// called at most once per tick.
+func (p *workerPool) setCentres(centres []ChunkPos) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if slices.Equal(p.centres, centres) {
+		return
+	}
+	p.centres = centres
+	for _, j := range p.chunks {
+		j.prioritise(centres)
+	}
+	heap.Init(&p.chunks)
+}
+
+// expedite makes sure that the job loading the chunk for the chunkRequest
+// passed is run before other jobs loading chunks, if it is queued in the
+// pool. req.urgent must be set before expedite is called, so that a job
+// submitted concurrently is also run first.
+func (p *workerPool) expedite(req *chunkRequest) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, j := range p.chunks {
+		if j.req == req {
+			j.priority = -1
+			heap.Fix(&p.chunks, i)
+			return
+		}
+	}
+}
+
+// submit queues a job to be run by one of the workers of the pool. If the
+// pool was closed, the job is run on the calling goroutine instead.
+func (p *workerPool) submit(f func()) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		p.run(f)
+		return
+	}
+	p.tasks = append(p.tasks, f)
+	p.cond.Signal()
+	p.mu.Unlock()
+}
+
+// submitChunk queues a job that loads the chunk at the position passed for
+// the chunkRequest passed. Like submit, the job is run on the calling
+// goroutine if the pool was closed.
+func (p *workerPool) submitChunk(pos ChunkPos, req *chunkRequest, f func()) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		p.run(f)
+		return
+	}
+	j := &job{f: f, req: req, pos: pos, seq: p.seq}
+	p.seq++
+	j.prioritise(p.centres)
+	heap.Push(&p.chunks, j)
+	p.cond.Signal()
+	p.mu.Unlock()
+}
+
+// wait submits a job to the pool and waits for it to finish.
+func (p *workerPool) wait(f func()) {
+	done := make(chan struct{})
+	p.submit(func() {
+		defer close(done)
+		f()
+	})
+	<-done
+}
+
+// close stops the pool from accepting new jobs and waits until all jobs that
+// were already queued have been run.
+func (p *workerPool) close() {
+	p.mu.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// work runs jobs from the queue of the pool until the pool is closed and the
+// queue is empty.
+func (p *workerPool) work() {
+	defer p.wg.Done()
+	for {
+		p.mu.Lock()
+		for len(p.tasks) == 0 && len(p.chunks) == 0 && !p.closed {
+			p.cond.Wait()
+		}
+		f := p.next()
+		p.mu.Unlock()
+		if f == nil {
+			return
+		}
+		p.run(f)
+	}
+}
+
+// next removes the job that should be run next from the queue and returns
+// it, or nil if the queue is empty. next must be called while p.mu is held.
+func (p *workerPool) next() func() {
+	if len(p.tasks) > 0 {
+		f := p.tasks[0]
+		p.tasks[0] = nil
+		p.tasks = p.tasks[1:]
+		return f
+	}
+	if len(p.chunks) > 0 {
+		return heap.Pop(&p.chunks).(*job).f
+	}
+	return nil
+}
+
+// run runs a job, recovering and logging a panic so that a single failing job
+// does not bring down the world.
+func (p *workerPool) run(f func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.log.Error(fmt.Sprintf("%v worker: panic: %v", p.name, r), "stack", string(debug.Stack()))
+		}
+	}()
+	f()
+}
@@ -0,0 +1,132 @@
+package world
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// queueChunks submits n jobs loading chunks in a square around the origin to
+// the workerPool passed, which must not have any workers.
+func queueChunks(p *workerPool, n int) {
+	for i := range n {
+		pos := ChunkPos{int32(i%100 - 50), int32(i/100 - 50)}
+		p.submitChunk(pos, &chunkRequest{}, func() {})
+	}
+}
+
+// loaderCentres returns n loader centres spread over a line.
+func loaderCentres(n int) []ChunkPos {
+	centres := make([]ChunkPos, n)
+	for i := range centres {
+		centres[i] = ChunkPos{int32(i*7 - 50), int32(i*3 - 50)}
+	}
+	return centres
+}
+
+func TestWorkerPoolOrder(t *testing.T) {
+	p := newWorkerPool("test", 0, slog.Default())
+	p.setCentres([]ChunkPos{{10, 10}})
+
+	var order []string
+	far, near, urgent := &chunkRequest{}, &chunkRequest{}, &chunkRequest{}
+	p.submitChunk(ChunkPos{100, 100}, far, func() { order = append(order, "far") })
+	p.submitChunk(ChunkPos{200, 200}, urgent, func() { order = append(order, "urgent") })
+	p.submitChunk(ChunkPos{11, 10}, near, func() { order = append(order, "near") })
+	p.submit(func() { order = append(order, "task") })
+
+	urgent.urgent.Store(true)
+	p.expedite(urgent)
+
+	p.mu.Lock()
+	for f := p.next(); f != nil; f = p.next() {
+		f()
+	}
+	p.mu.Unlock()
+
+	want := []string{"task", "urgent", "near", "far"}
+	if len(order) != len(want) {
+		t.Fatalf("expected jobs to run in order %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected jobs to run in order %v, got %v", want, order)
+		}
+	}
+}
+
+func BenchmarkWorkerPoolNext(b *testing.B) {
+	p := newWorkerPool("bench", 0, slog.Default())
+	p.setCentres(loaderCentres(100))
+	b.ReportAllocs()
+	for b.Loop() {
+		queueChunks(p, 10000)
+		p.mu.Lock()
+		for f := p.next(); f != nil; f = p.next() {
+		}
+		p.mu.Unlock()
+	}
+}
+
+func BenchmarkWorkerPoolSetCentres(b *testing.B) {
+	p := newWorkerPool("bench", 0, slog.Default())
+	queueChunks(p, 10000)
+	a, c := loaderCentres(100), loaderCentres(101)
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		if i%2 == 0 {
+			p.setCentres(a)
+		} else {
+			p.setCentres(c)
+		}
+	}
+}
+
+// slowGenerator is a Generator that takes a millisecond to generate a chunk,
+// like a heavy terrain generator would.
+type slowGenerator struct{}
+
+func (slowGenerator) GenerateChunk(ChunkPos, *chunk.Chunk) {
+	time.Sleep(time.Millisecond)
+}
+
+// benchmarkSlowGeneratorTicks measures the time spent on the transaction run
+// every tick for a loader that flies into new terrain of a World with a
+// slowGenerator, moving one chunk every tick. If inline is true, the chunks
+// around the loader are generated during the transaction, like they were
+// before chunks were generated on the workers of the World.
+func benchmarkSlowGeneratorTicks(b *testing.B, inline bool) {
+	var total time.Duration
+	var ticks int
+	for b.Loop() {
+		w := Config{Generator: slowGenerator{}}.New()
+		l := NewLoader(4, w, NopViewer{})
+		for x := range 16 {
+			start := time.Now()
+			<-w.Exec(func(tx *Tx) {
+				l.Move(tx, mgl64.Vec3{float64(x * 16), 0, 0})
+				if inline {
+					for _, pos := range l.loadQueue {
+						w.chunk(pos)
+					}
+				}
+				l.Load(tx, len(l.loadQueue))
+			})
+			total += time.Since(start)
+			ticks++
+		}
+		w.Close()
+	}
+	b.ReportMetric(float64(total.Nanoseconds())/float64(ticks), "ns/tick")
+}
+
+func BenchmarkSlowGeneratorTicksInline(b *testing.B) {
+	benchmarkSlowGeneratorTicks(b, true)
+}
+
+func BenchmarkSlowGeneratorTicksWorkers(b *testing.B) {
+	benchmarkSlowGeneratorTicks(b, false)
+}
@@ -0,0 +1,324 @@
+package world
+
+import (
+	"context"
+	"errors"
+	"github.com/df-mc/goleveldb/leveldb"
+	"time"
+)
+
+// preGenBatchSize is the amount of chunks that World.PreGenerate requests at
+// once.
+const preGenBatchSize = 64
+
+// PreGenProgress is the progress of a pre-generation started using
+// World.PreGenerate.
+type PreGenProgress struct {
+	// Total is the amount of chunks in the area pre-generated.
+	Total int
+	// Done is the amount of chunks in the area handled so far, including
+	// chunks that were already stored in the Provider.
+	Done int
+	// Generated is the amount of chunks generated and stored so far.
+	Generated int
+	// Err is non-nil if pre-generation stopped before all chunks were handled,
+	// because the context.Context passed was cancelled or the World was
+	// closed. Err is only set in the last progress sent.
+	Err error
+}
+
+// PreGenerate generates all chunks within radius chunks of the chunk at
+// centre that are not yet stored in the Provider of the World and stores them.
+// Chunks are handled in batches, in a spiral around centre, and are stored and
+// released as soon as all chunks around them are generated, so that only a
+// small part of the area is kept in memory at once. Chunks already stored are
+// skipped, so that an interrupted pre-generation may be resumed cheaply by
+// calling PreGenerate again with the same area.
+//
+// Pre-generation runs in the background and is throttled so that the World
+// stays responsive: Chunks requested by players are generated before those of
+// the pre-generation, and at least one tick passes between batches.
+// Pre-generation stops once ctx is cancelled or the World is closed. Chunks at
+// the border of the area, or next to chunks that were already stored, are
+// decorated once all of their neighbours are loaded.
+//
+// The progress of the pre-generation is sent over the channel returned after
+// every batch, and the channel is closed once pre-generation stops. Progress
+// is dropped if the previous progress was not yet read, but the last progress
+// sent is always kept.
+func (w *World) PreGenerate(ctx context.Context, centre ChunkPos, radius int) <-chan PreGenProgress {
+	ch := make(chan PreGenProgress, 1)
+	g := &preGenerator{w: w, centre: centre, radius: radius}
+	g.progress.Total = (2*radius + 1) * (2*radius + 1)
+
+	go func() {
+		defer close(ch)
+		g.progress.Err = g.run(ctx, func(p PreGenProgress) {
+			select {
+			case ch <- p:
+			default:
+			}
+		})
+		if g.progress.Err != nil && !errors.Is(g.progress.Err, errWorldClosed) {
+			// Store the chunks generated so far, so that they need not be
+			// generated again when pre-generation is resumed.
+			_ = g.release(true)
+		}
+		// The last progress replaces any progress that wasn't read yet.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- g.progress
+	}()
+	return ch
+}
+
+// preGenerator generates the chunks in an area of a World for
+// World.PreGenerate.
+type preGenerator struct {
+	w      *World
+	centre ChunkPos
+	radius int
+
+	// next is the spiral index of the next chunk handled.
+	next int
+	// loaded holds the positions of the chunks loaded by the preGenerator
+	// that have not yet been released.
+	loaded   []ChunkPos
+	progress PreGenProgress
+}
+
+// run generates all chunks in the area of the preGenerator, calling report
+// after every batch. It returns an error if it was stopped early.
+func (g *preGenerator) run(ctx context.Context, report func(PreGenProgress)) error {
+	if g.w.conf.ReadOnly {
+		return errors.New("pre-generate: world is read-only")
+	}
+	for g.next < g.progress.Total {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		end := min(g.next+preGenBatchSize, g.progress.Total)
+		missing := make([]ChunkPos, 0, end-g.next)
+		for i := g.next; i < end; i++ {
+			off := spiralPos(i)
+			pos := ChunkPos{g.centre[0] + off[0], g.centre[1] + off[1]}
+			if ok, err := g.w.hasColumn(pos); err != nil {
+				// Don't risk overwriting a chunk that is stored but could not
+				// be read.
+				g.w.conf.Log.Error("pre-generate: "+err.Error(), "X", pos[0], "Z", pos[1])
+			} else if !ok {
+				missing = append(missing, pos)
+			}
+		}
+		if err := g.generate(ctx, missing); err != nil {
+			return err
+		}
+		g.progress.Done, g.progress.Generated = end, g.progress.Generated+len(missing)
+		g.next = end
+		if err := g.release(false); err != nil {
+			return err
+		}
+		report(g.progress)
+
+		// Wait for at least a tick before the next batch, so that the World
+		// keeps processing its other transactions.
+		select {
+		case <-time.After(time.Second / 20):
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-g.w.closing:
+			return errWorldClosed
+		}
+	}
+	return nil
+}
+
+// generate requests all chunks at the positions passed and waits for them to
+// be generated.
+func (g *preGenerator) generate(ctx context.Context, positions []ChunkPos) error {
+	if len(positions) == 0 {
+		return nil
+	}
+	reqs := make([]*chunkRequest, 0, len(positions))
+	err := g.exec(func(tx *Tx) {
+		for _, pos := range positions {
+			if c, ok := g.w.chunks[pos]; ok {
+				// The chunk was generated for a player but never stored.
+				c.modified = true
+				continue
+			}
+			reqs = append(reqs, g.w.requestChunk(pos))
+			g.loaded = append(g.loaded, pos)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	for _, req := range reqs {
+		select {
+		case <-req.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// release stores and closes all chunks loaded by the preGenerator that no
+// other chunks need to be loaded for anymore, or all chunks loaded if all is
+// true. Chunks that are viewed by a player are marked to be stored, but are
+// not closed. release waits until the chunks closed are stored.
+func (g *preGenerator) release(all bool) error {
+	var closed []ChunkPos
+	err := g.exec(func(tx *Tx) {
+		kept := g.loaded[:0]
+		for _, pos := range g.loaded {
+			if req, ok := g.w.loading[pos]; ok {
+				select {
+				case <-req.done:
+					g.w.installChunk(pos, req)
+				default:
+					// Pre-generation was stopped before the chunk was
+					// generated.
+					continue
+				}
+			}
+			c, ok := g.w.chunks[pos]
+			if !ok {
+				// The chunk failed to load or was already closed.
+				continue
+			}
+			c.modified = true
+			if !all && !g.handledAround(pos) {
+				// Neighbours of the chunk might still be decorated, which
+				// requires the chunk to be loaded.
+				kept = append(kept, pos)
+				continue
+			}
+			if len(c.viewers) == 0 {
+				g.w.closeChunk(tx, pos, c)
+				closed = append(closed, pos)
+			}
+		}
+		g.loaded = kept
+	})
+	for _, pos := range closed {
+		g.w.awaitStore(pos)
+	}
+	return err
+}
+
+// handledAround checks if all chunks within two chunks of the position
+// passed, which includes all chunks that are decorated using the chunk at the
+// position, were handled already or are outside the area of the
+// preGenerator.
+func (g *preGenerator) handledAround(pos ChunkPos) bool {
+	for x := int32(-2); x <= 2; x++ {
+		for z := int32(-2); z <= 2; z++ {
+			off := ChunkPos{pos[0] + x - g.centre[0], pos[1] + z - g.centre[1]}
+			if max(abs(int(off[0])), abs(int(off[1]))) <= g.radius && spiralIndex(off) >= g.next {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// exec runs f in a transaction on the World of the preGenerator and waits for
+// it to finish. An error is returned if the World was closed.
+func (g *preGenerator) exec(f ExecFunc) error {
+	select {
+	case <-g.w.closing:
+		return errWorldClosed
+	default:
+	}
+	closed := false
+	select {
+	case <-g.w.Exec(func(tx *Tx) {
+		if closed = g.w.closed; !closed {
+			f(tx)
+		}
+	}):
+	case <-g.w.closing:
+		return errWorldClosed
+	}
+	if closed {
+		return errWorldClosed
+	}
+	return nil
+}
+
+// errWorldClosed is returned by World.PreGenerate if the World was closed
+// before all chunks were generated.
+var errWorldClosed = errors.New("pre-generate: world closed")
+
+// hasColumn checks if the Provider of the World has a column stored at the
+// position passed. If the Provider does not implement ColumnChecker, the
+// column is loaded to check if it exists.
+func (w *World) hasColumn(pos ChunkPos) (bool, error) {
+	if c, ok := w.conf.Provider.(ColumnChecker); ok {
+		return c.HasColumn(pos, w.conf.Dim)
+	}
+	_, err := w.conf.Provider.LoadColumn(pos, w.conf.Dim)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// spiralPos returns the offset from the centre of the chunk with index i in a
+// square spiral. The spiral starts at the centre and walks around it in rings,
+// so that ring k holds the 8k chunks at a distance of k chunks.
+func spiralPos(i int) ChunkPos {
+	if i == 0 {
+		return ChunkPos{}
+	}
+	k := 1
+	for (2*k+1)*(2*k+1) <= i {
+		k++
+	}
+	j := i - (2*k-1)*(2*k-1)
+	off := int32(j % (2 * k))
+	k32 := int32(k)
+	switch j / (2 * k) {
+	case 0:
+		return ChunkPos{-k32 + off, -k32}
+	case 1:
+		return ChunkPos{k32, -k32 + off}
+	case 2:
+		return ChunkPos{k32 - off, k32}
+	default:
+		return ChunkPos{-k32, k32 - off}
+	}
+}
+
+// spiralIndex returns the index of the chunk at the offset from the centre
+// passed in the spiral walked by spiralPos.
+func spiralIndex(off ChunkPos) int {
+	x, z := int(off[0]), int(off[1])
+	k := max(abs(x), abs(z))
+	if k == 0 {
+		return 0
+	}
+	base := (2*k - 1) * (2*k - 1)
+	switch {
+	case z == -k && x < k:
+		return base + x + k
+	case x == k && z < k:
+		return base + 2*k + z + k
+	case z == k && x > -k:
+		return base + 4*k + k - x
+	default:
+		return base + 6*k + k - z
+	}
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
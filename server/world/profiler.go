@@ -0,0 +1,131 @@
+package world
+
+import (
+	"cmp"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ChunkTiming holds the time spent ticking a single chunk during a profiling
+// window of a World.
+type ChunkTiming struct {
+	// Pos is the position of the chunk.
+	Pos ChunkPos
+	// BlockTicks is the time spent on scheduled block updates, neighbour
+	// updates and ticking blocks such as furnaces in the chunk.
+	BlockTicks time.Duration
+	// EntityTicks is the time spent ticking the entities in the chunk.
+	EntityTicks time.Duration
+	// RandomTicks is the time spent on random block ticks in the chunk.
+	RandomTicks time.Duration
+}
+
+// Total returns the total time spent ticking the chunk.
+func (t ChunkTiming) Total() time.Duration {
+	return t.BlockTicks + t.EntityTicks + t.RandomTicks
+}
+
+// profilingWindow is the amount of ticks over which the tick times of chunks
+// are aggregated before they become available through World.ChunkProfile.
+const profilingWindow = 100
+
+// profiler records the time spent ticking every chunk of a World. Timings are
+// recorded by the goroutine that runs transactions and published once every
+// profilingWindow ticks, after which they may be read from any goroutine. All
+// methods of profiler may be called on a nil *profiler, in which case they do
+// nothing, so that profiling has no overhead when disabled.
+type profiler struct {
+	ticks   int
+	current map[ChunkPos]*ChunkTiming
+
+	mu   sync.Mutex
+	last []ChunkTiming
+}
+
+// newProfiler returns a new profiler if enabled is true, or nil otherwise.
+func newProfiler(enabled bool) *profiler {
+	if !enabled {
+		return nil
+	}
+	return &profiler{current: make(map[ChunkPos]*ChunkTiming)}
+}
+
+// start returns the current time if the profiler is enabled. The time
+// returned holds a monotonic clock reading, so that durations measured from
+// it are not affected by changes of the wall clock.
+func (p *profiler) start() time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// blockTick records a block tick at the position passed that started at the
+// time passed.
+func (p *profiler) blockTick(pos cube.Pos, start time.Time) {
+	if p != nil {
+		p.timing(chunkPosFromBlockPos(pos)).BlockTicks += time.Since(start)
+	}
+}
+
+// randomTick records a random tick at the position passed that started at the
+// time passed.
+func (p *profiler) randomTick(pos cube.Pos, start time.Time) {
+	if p != nil {
+		p.timing(chunkPosFromBlockPos(pos)).RandomTicks += time.Since(start)
+	}
+}
+
+// entityTick records the tick of an entity in the chunk passed that started
+// at the time passed.
+func (p *profiler) entityTick(pos ChunkPos, start time.Time) {
+	if p != nil {
+		p.timing(pos).EntityTicks += time.Since(start)
+	}
+}
+
+// timing returns the ChunkTiming of the chunk passed in the current window.
+func (p *profiler) timing(pos ChunkPos) *ChunkTiming {
+	t, ok := p.current[pos]
+	if !ok {
+		t = &ChunkTiming{Pos: pos}
+		p.current[pos] = t
+	}
+	return t
+}
+
+// tick ends a world tick. Once every profilingWindow ticks, the timings
+// recorded are published and a new window is started.
+func (p *profiler) tick() {
+	if p == nil {
+		return
+	}
+	if p.ticks++; p.ticks < profilingWindow {
+		return
+	}
+	timings := make([]ChunkTiming, 0, len(p.current))
+	for _, t := range p.current {
+		timings = append(timings, *t)
+	}
+	slices.SortFunc(timings, func(a, b ChunkTiming) int {
+		return cmp.Compare(b.Total(), a.Total())
+	})
+	p.ticks = 0
+	clear(p.current)
+
+	p.mu.Lock()
+	p.last = timings
+	p.mu.Unlock()
+}
+
+// profile returns the timings of the last completed window.
+func (p *profiler) profile() []ChunkTiming {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.last)
+}
@@ -31,6 +31,46 @@ type Provider interface {
 	StoreColumn(pos ChunkPos, dim Dimension, col *chunk.Column) error
 }
 
+// StructureProvider is a Provider that is also able to store structures by
+// name, such as those saved using structure blocks. Providers are not required
+// to implement StructureProvider: Structures cannot be saved in a World with
+// a Provider that does not.
+type StructureProvider interface {
+	Provider
+	// LoadStructure loads the NBT data of the structure with the name passed.
+	// If no structure with the name exists, the bool returned is false.
+	LoadStructure(name string) (map[string]any, bool, error)
+	// StoreStructure stores the NBT data of a structure under the name passed,
+	// overwriting any structure stored under the same name.
+	StoreStructure(name string, data map[string]any) error
+}
+
+// ColumnChecker is a Provider that is also able to check if a column is
+// stored without loading it. Providers are not required to implement
+// ColumnChecker: World.PreGenerate loads columns to check if they exist if a
+// Provider does not.
+type ColumnChecker interface {
+	Provider
+	// HasColumn checks if a column is stored at a position and dimension.
+	HasColumn(pos ChunkPos, dim Dimension) (bool, error)
+}
+
+// SpawnDimensionProvider is a Provider that is also able to store the
+// Dimension that a player spawn point is in, such as the Nether for spawn
+// points set using respawn anchors. Providers are not required to implement
+// SpawnDimensionProvider: Player spawn points of a Provider that does not are
+// always in the Overworld, and spawn points set in other dimensions are not
+// saved.
+type SpawnDimensionProvider interface {
+	Provider
+	// LoadPlayerSpawn loads the player spawn point and the Dimension it is in
+	// if found, otherwise an error will be returned.
+	LoadPlayerSpawn(uuid uuid.UUID) (pos cube.Pos, dim Dimension, exists bool, err error)
+	// SavePlayerSpawn saves the player spawn point and the Dimension it is
+	// in.
+	SavePlayerSpawn(uuid uuid.UUID, pos cube.Pos, dim Dimension) error
+}
+
 // Compile time check to make sure NopProvider implements Provider.
 var _ Provider = (*NopProvider)(nil)
 
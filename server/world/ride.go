@@ -0,0 +1,167 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/internal/sliceutil"
+	"github.com/go-gl/mathgl/mgl64"
+	"slices"
+)
+
+// RideableEntityType is an EntityType of entities that may be ridden by other
+// entities in a specific way. Entities of an EntityType that does not
+// implement RideableEntityType may still be ridden by a single passenger,
+// which sits on top of the entity's bounding box.
+type RideableEntityType interface {
+	EntityType
+	// Seats returns the maximum amount of passengers that may ride the Entity
+	// passed at the same time.
+	Seats(e Entity) int
+	// SeatOffset returns the offset from the position of the Entity passed at
+	// which the passenger in the seat passed sits. The passenger in seat 0 is
+	// the driver of the Entity.
+	SeatOffset(e Entity, seat int) mgl64.Vec3
+}
+
+// seats returns the maximum amount of passengers of a vehicle.
+func seats(vehicle Entity) int {
+	if r, ok := vehicle.H().Type().(RideableEntityType); ok {
+		return r.Seats(vehicle)
+	}
+	return 1
+}
+
+// seatOffset returns the offset from the position of a vehicle at which the
+// passenger in the seat passed sits.
+func seatOffset(vehicle Entity, seat int) mgl64.Vec3 {
+	if r, ok := vehicle.H().Type().(RideableEntityType); ok {
+		return r.SeatOffset(vehicle, seat)
+	}
+	return mgl64.Vec3{0, vehicle.H().Type().BBox(vehicle).Height()}
+}
+
+// mount makes the rider passed start riding the vehicle passed. False is
+// returned if either entity is not in the World, if the vehicle has no free
+// seats or if the rider is (indirectly) being ridden by the vehicle.
+func (w *World) mount(tx *Tx, rider, vehicle Entity) bool {
+	r, v := rider.H(), vehicle.H()
+	if _, ok := w.entities[r]; !ok {
+		return false
+	}
+	if _, ok := w.entities[v]; !ok {
+		return false
+	}
+	for h := v; h != nil; h = h.vehicle {
+		if h == r {
+			// The vehicle is the rider itself or one of its passengers:
+			// Linking the two would create a cycle.
+			return false
+		}
+	}
+	if r.vehicle == v {
+		return true
+	}
+	// Entities are re-opened, as the implementations passed may be wrappers
+	// of the actual entities, such as an embedded base entity.
+	rider, vehicle = r.mustEntity(tx), v.mustEntity(tx)
+	if len(v.passengers) >= seats(vehicle) {
+		return false
+	}
+	w.dismount(tx, r)
+
+	r.vehicle, v.passengers = v, append(v.passengers, r)
+	r.data.Vel = mgl64.Vec3{}
+	w.positionPassengers(tx, v)
+
+	seat := len(v.passengers) - 1
+	for _, viewer := range w.entityViewers(r) {
+		viewer.ViewEntityMount(rider, vehicle, seatOffset(vehicle, seat), seat == 0)
+	}
+	return true
+}
+
+// dismount makes the entity with the EntityHandle passed stop riding its
+// vehicle, if it is riding one.
+func (w *World) dismount(tx *Tx, r *EntityHandle) {
+	v := r.vehicle
+	if v == nil {
+		return
+	}
+	r.vehicle, v.passengers = nil, sliceutil.DeleteVal(v.passengers, r)
+
+	rider, vehicle := r.mustEntity(tx), v.mustEntity(tx)
+	for _, viewer := range w.entityViewers(r) {
+		viewer.ViewEntityDismount(rider, vehicle)
+	}
+	// The remaining passengers may have moved up a seat.
+	w.positionPassengers(tx, v)
+	for seat, p := range v.passengers {
+		passenger := p.mustEntity(tx)
+		for _, viewer := range w.entityViewers(p) {
+			viewer.ViewEntityMount(passenger, vehicle, seatOffset(vehicle, seat), seat == 0)
+		}
+	}
+}
+
+// unlink removes all links of the entity with the EntityHandle passed: It
+// stops riding its vehicle and all of its passengers are dismounted. unlink is
+// called when an entity leaves the World, so that no links to entities
+// outside the World remain.
+func (w *World) unlink(tx *Tx, handle *EntityHandle) {
+	w.dismount(tx, handle)
+	for _, p := range slices.Clone(handle.passengers) {
+		w.dismount(tx, p)
+	}
+}
+
+// positionPassengers moves all passengers of the vehicle with the
+// EntityHandle passed, and the passengers of those passengers, to their seats.
+// The movement is not shown to viewers, as they move passengers along with
+// their vehicles themselves.
+func (w *World) positionPassengers(tx *Tx, v *EntityHandle) {
+	if len(v.passengers) == 0 {
+		return
+	}
+	vehicle := v.mustEntity(tx)
+	for seat, p := range v.passengers {
+		p.data.Pos, p.data.Vel = v.data.Pos.Add(seatOffset(vehicle, seat)), mgl64.Vec3{}
+		w.positionPassengers(tx, p)
+	}
+}
+
+// syncPassengers moves the passengers of all vehicles in the World to their
+// seats.
+func (w *World) syncPassengers(tx *Tx) {
+	for handle := range w.entities {
+		if handle.vehicle == nil {
+			// Only start at the lowest vehicle so that passengers of
+			// passengers are moved after their own vehicle.
+			w.positionPassengers(tx, handle)
+		}
+	}
+}
+
+// entityViewers returns the viewers of the chunk that the entity with the
+// EntityHandle passed is currently stored in.
+func (w *World) entityViewers(handle *EntityHandle) []Viewer {
+	pos, ok := w.entities[handle]
+	if !ok {
+		return nil
+	}
+	if c, ok := w.chunks[pos]; ok {
+		return c.viewers
+	}
+	return nil
+}
+
+// showLinks shows the links of an Entity with its vehicle and passengers to a
+// viewer that has just started viewing the Entity.
+func showLinks(tx *Tx, e Entity, viewer Viewer) {
+	handle := e.H()
+	if handle.vehicle != nil {
+		vehicle := handle.vehicle.mustEntity(tx)
+		seat := slices.Index(handle.vehicle.passengers, handle)
+		viewer.ViewEntityMount(e, vehicle, seatOffset(vehicle, seat), seat == 0)
+	}
+	for seat, p := range handle.passengers {
+		viewer.ViewEntityMount(p.mustEntity(tx), e, seatOffset(e, seat), seat == 0)
+	}
+}
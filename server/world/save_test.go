@@ -0,0 +1,93 @@
+package world_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// storeCounter is a world.Provider that counts the columns stored to it.
+type storeCounter struct {
+	world.NopProvider
+	stored *atomic.Int64
+}
+
+func (p storeCounter) StoreColumn(world.ChunkPos, world.Dimension, *chunk.Column) error {
+	p.stored.Add(1)
+	return nil
+}
+
+// withEntityChunks runs f in a world in which n chunks are force loaded, each
+// holding a text entity, and which were all saved once.
+func withEntityChunks(tb testing.TB, n int, f func(w *world.World, stored *atomic.Int64, entities []*world.EntityHandle)) {
+	stored := &atomic.Int64{}
+	w := world.Config{Provider: storeCounter{stored: stored}, Entities: entity.DefaultRegistry}.New()
+	defer w.Close()
+
+	entities := make([]*world.EntityHandle, n)
+	for i := range n {
+		w.ForceLoad(world.ChunkPos{int32(i), 0}, time.Hour)
+	}
+	<-w.Exec(func(tx *world.Tx) {
+		for i := range entities {
+			entities[i] = entity.NewText("", mgl64.Vec3{float64(i*16 + 8), 64, 8})
+			tx.AddEntity(entities[i])
+		}
+	})
+	w.Save()
+	stored.Store(0)
+	f(w, stored, entities)
+}
+
+func TestSaveSkipsUnchangedEntities(t *testing.T) {
+	withEntityChunks(t, 8, func(w *world.World, stored *atomic.Int64, entities []*world.EntityHandle) {
+		w.Save()
+		if n := stored.Load(); n != 0 {
+			t.Fatalf("expected no chunks to be stored if no entities changed, got %v", n)
+		}
+		entities[3].ExecWorld(func(tx *world.Tx, e world.Entity) {
+			e.(*entity.Ent).SetNameTag("changed")
+		})
+		w.Save()
+		if n := stored.Load(); n != 1 {
+			t.Fatalf("expected only the chunk of the changed entity to be stored, got %v", n)
+		}
+	})
+}
+
+// saveBenchmarkChunks is the number of chunks with entities saved in the save
+// benchmarks.
+const saveBenchmarkChunks = 2000
+
+func BenchmarkSaveUnchangedEntities(b *testing.B) {
+	withEntityChunks(b, saveBenchmarkChunks, func(w *world.World, stored *atomic.Int64, _ []*world.EntityHandle) {
+		for b.Loop() {
+			w.Save()
+		}
+		b.ReportMetric(float64(stored.Load())/float64(b.N), "chunks/op")
+	})
+}
+
+// BenchmarkSaveChangedEntities changes all entities before every save, so that
+// all chunks are stored like they were before unchanged chunks with entities
+// were skipped.
+func BenchmarkSaveChangedEntities(b *testing.B) {
+	withEntityChunks(b, saveBenchmarkChunks, func(w *world.World, stored *atomic.Int64, entities []*world.EntityHandle) {
+		for b.Loop() {
+			b.StopTimer()
+			for _, handle := range entities {
+				handle.ExecWorld(func(tx *world.Tx, e world.Entity) {
+					e.(*entity.Ent).SetNameTag("changed")
+				})
+			}
+			b.StartTimer()
+			w.Save()
+		}
+		b.ReportMetric(float64(stored.Load())/float64(b.N), "chunks/op")
+	})
+}
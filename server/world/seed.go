@@ -0,0 +1,57 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"math/rand/v2"
+)
+
+// SeedHash mixes the seed of a World with the values passed, such as the
+// coordinates of a chunk or block, into a single hash. SeedHash always
+// returns the same hash for the same seed and values, so it may be used to
+// make random decisions that must be the same every time a World is loaded.
+//
+// The hash is computed by starting with h = mix(uint64(seed)) and then
+// setting h = mix(h ^ uint64(v)) for every value v in order, where mix is the
+// finaliser of SplitMix64:
+//
+//	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+//	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+//	z = z ^ (z >> 31)
+//
+// This mixing function will not be changed, so that worlds generated using
+// it remain the same across versions.
+func SeedHash(seed int64, values ...int64) uint64 {
+	h := mixSeed(uint64(seed))
+	for _, v := range values {
+		h = mixSeed(h ^ uint64(v))
+	}
+	return h
+}
+
+// mixSeed is the finaliser of SplitMix64, used by SeedHash.
+func mixSeed(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// GenerationRand returns a rand.Rand seeded using the seed of a World, the
+// position of a chunk and a salt, which is usually unique to the kind of
+// structure or feature. The rand.Rand returned is a PCG seeded with
+// SeedHash(seed, pos.X(), pos.Z()) and the salt, so it produces the same
+// numbers every time GenerationRand is called with the same arguments.
+// GenerationRand used to seed the PCG with the seed and coordinates combined
+// without SeedHash, so it produces different numbers than it did in earlier
+// versions. Structures placed using it in chunks generated by earlier
+// versions may therefore not line up with those in newly generated chunks.
+func GenerationRand(seed int64, pos ChunkPos, salt uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(SeedHash(seed, int64(pos[0]), int64(pos[1])), salt))
+}
+
+// PositionRand returns a rand.Rand seeded using the seed of a World, a block
+// position and a salt. The rand.Rand returned is a PCG seeded with
+// SeedHash(seed, pos.X(), pos.Y(), pos.Z()) and the salt, so it produces the
+// same numbers every time PositionRand is called with the same arguments.
+func PositionRand(seed int64, pos cube.Pos, salt uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(SeedHash(seed, int64(pos[0]), int64(pos[1]), int64(pos[2])), salt))
+}
@@ -0,0 +1,52 @@
+package world_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// The values in these tests must never change: Worlds generated using
+// SeedHash, GenerationRand and PositionRand must remain the same across
+// versions.
+
+func TestSeedHash(t *testing.T) {
+	// The first output of SplitMix64 seeded with 1234567 is the finaliser
+	// applied to the seed plus the golden gamma.
+	gamma := uint64(0x9e3779b97f4a7c15)
+	if h := world.SeedHash(int64(1234567 + gamma)); h != 6457827717110365317 {
+		t.Errorf("expected SeedHash to use the SplitMix64 finaliser, got %#x", h)
+	}
+	for _, c := range []struct {
+		seed   int64
+		values []int64
+		want   uint64
+	}{
+		{seed: 12345, want: 0xf36cf1164265dd51},
+		{seed: 12345, values: []int64{1, -2}, want: 0x3d7bdb4b052f47a5},
+		{seed: -987654321, values: []int64{100, 64, -100}, want: 0xba1dda9340200ad},
+	} {
+		if h := world.SeedHash(c.seed, c.values...); h != c.want {
+			t.Errorf("SeedHash(%v, %v): expected %#x, got %#x", c.seed, c.values, c.want, h)
+		}
+	}
+}
+
+func TestGenerationRand(t *testing.T) {
+	r := world.GenerationRand(12345, world.ChunkPos{3, -7}, 42)
+	for _, want := range []uint64{0x5ed059b42cf7b1ee, 0xe9d52ad1d76d20b5, 0x1a53a606777fc189} {
+		if v := r.Uint64(); v != want {
+			t.Fatalf("expected GenerationRand to produce %#x, got %#x", want, v)
+		}
+	}
+}
+
+func TestPositionRand(t *testing.T) {
+	r := world.PositionRand(12345, cube.Pos{10, 64, -20}, 42)
+	for _, want := range []uint64{0xbb0058fcee678148, 0x9daefd354d4fba67, 0x6ec318418f5f5af7} {
+		if v := r.Uint64(); v != want {
+			t.Fatalf("expected PositionRand to produce %#x, got %#x", want, v)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // Settings holds the settings of a World. These are typically saved to a level.dat file. It is safe to pass the same
@@ -14,6 +15,9 @@ type Settings struct {
 
 	// Name is the display name of the World.
 	Name string
+	// Seed is the seed of the World. It is not used by the World itself, but Generators may use it to generate
+	// terrain deterministically.
+	Seed int64
 	// Spawn is the spawn position of the World. New players that join the world will be spawned here.
 	Spawn cube.Pos
 	// Time is the current time of the World. It advances every tick if TimeCycle is set to true.
@@ -41,16 +45,34 @@ type Settings struct {
 	// TickRange is the radius in chunks around a Viewer that has its blocks and entities ticked when the world is
 	// ticked. If set to 0, blocks and entities will never be ticked.
 	TickRange int32
+	// Insomnia specifies if phantoms spawn around players that have not slept for a long time. Disabling it does not
+	// stop players from tracking the time since they last slept.
+	Insomnia bool
+	// MobGriefing specifies if mobs, such as the wither, are able to destroy blocks in the World.
+	MobGriefing bool
+	// NaturalRegeneration specifies if players regenerate health when their food bar is full enough. Disabling it
+	// does not affect healing from other sources, such as the Regeneration effect.
+	NaturalRegeneration bool
+	// Border is the border of the World. Worlds that share their Settings, such as the dimensions of a server,
+	// also share their Border. By default, a World has no border.
+	Border Border
+	// DamageImmunity is the duration that entities are immune to further damage after being hurt. Damage dealt
+	// during this window only applies if it exceeds the damage that started it. If set to 0, a DamageImmunity of
+	// 0.5 seconds (10 ticks) is used. DamageImmunity is not saved to a level.dat file.
+	DamageImmunity time.Duration
 }
 
 // defaultSettings returns the default Settings for a new World.
 func defaultSettings() *Settings {
 	return &Settings{
-		Name:            "World",
-		DefaultGameMode: GameModeSurvival,
-		Difficulty:      DifficultyNormal,
-		TimeCycle:       true,
-		WeatherCycle:    true,
-		TickRange:       6,
+		Name:                "World",
+		DefaultGameMode:     GameModeSurvival,
+		Difficulty:          DifficultyNormal,
+		TimeCycle:           true,
+		WeatherCycle:        true,
+		TickRange:           6,
+		Insomnia:            true,
+		MobGriefing:         true,
+		NaturalRegeneration: true,
 	}
 }
@@ -0,0 +1,147 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"iter"
+	"maps"
+	"sync"
+)
+
+// ChunkSnapshot is an immutable, point-in-time copy of the blocks and biomes
+// of a chunk. Unlike a Tx, a ChunkSnapshot does not block the World while it
+// is held, so it may be used to perform expensive read-only passes over
+// terrain on any goroutine. Changes made to the World after the snapshot was
+// taken are not reflected in it. A ChunkSnapshot is safe for simultaneous use
+// by multiple goroutines.
+type ChunkSnapshot struct {
+	pos ChunkPos
+	c   *chunk.Chunk
+	// surface makes sure the height maps of c, which are calculated when they
+	// are first read, are calculated only once.
+	surface sync.Once
+	// biome is the default Biome of the World the snapshot was taken in.
+	biome Biome
+	// blockEntities holds the block entities of the chunk at the time the
+	// snapshot was taken. It is nil if they were not requested.
+	blockEntities map[cube.Pos]Block
+}
+
+// ChunkSnapshot takes a ChunkSnapshot of the chunk at the ChunkPos passed. The
+// block and biome storages of the chunk are copied when the snapshot is
+// taken, after which the snapshot no longer depends on the World. Block
+// entities are not included in the snapshot: Blocks in the snapshot that
+// carry additional data, such as chests and signs, are returned without it.
+// Use ChunkSnapshotWithBlockEntities to include it. False is returned if the
+// chunk is not currently loaded.
+// ChunkSnapshot waits for a transaction on the World to complete and must
+// therefore not be called from within a transaction.
+func (w *World) ChunkSnapshot(pos ChunkPos) (*ChunkSnapshot, bool) {
+	return w.chunkSnapshot(pos, false)
+}
+
+// ChunkSnapshotWithBlockEntities takes a ChunkSnapshot of the chunk at the
+// ChunkPos passed, like ChunkSnapshot, but also copies the block entities in
+// the chunk. The blocks are copied by value: Data shared by reference, such as
+// the inventory of a chest, is not copied and reflects later changes.
+func (w *World) ChunkSnapshotWithBlockEntities(pos ChunkPos) (*ChunkSnapshot, bool) {
+	return w.chunkSnapshot(pos, true)
+}
+
+// chunkSnapshot takes a ChunkSnapshot of the chunk at a ChunkPos in a
+// transaction, copying its block entities if blockEntities is true.
+func (w *World) chunkSnapshot(pos ChunkPos, blockEntities bool) (*ChunkSnapshot, bool) {
+	var s *ChunkSnapshot
+	<-w.Exec(func(tx *Tx) {
+		c, ok := w.chunks[pos]
+		if !ok {
+			return
+		}
+		// Only the block and biome storages are copied here. The height maps
+		// of the copy are calculated outside the transaction when needed.
+		s = &ChunkSnapshot{pos: pos, c: c.Chunk.Snapshot(), biome: w.defaultBiome()}
+		if blockEntities {
+			s.blockEntities = maps.Clone(c.BlockEntities)
+		}
+	})
+	return s, s != nil
+}
+
+// Position returns the ChunkPos of the chunk that the ChunkSnapshot was taken
+// of.
+func (s *ChunkSnapshot) Position() ChunkPos {
+	return s.pos
+}
+
+// Range returns the vertical range of the chunk in the ChunkSnapshot.
+func (s *ChunkSnapshot) Range() cube.Range {
+	return s.c.Range()
+}
+
+// Block returns the Block at the position passed in the ChunkSnapshot. The
+// position is in world coordinates and must be within the chunk of the
+// snapshot: Air is returned for positions outside of it. If the snapshot was
+// taken without block entities, blocks are returned without their additional
+// data.
+func (s *ChunkSnapshot) Block(pos cube.Pos) Block {
+	if !s.within(pos) {
+		return air()
+	}
+	rid := s.c.Block(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0)
+	if nbtBlocks[rid] && s.blockEntities != nil {
+		if b, ok := s.blockEntities[pos]; ok {
+			return b
+		}
+	}
+	return blockByRuntimeIDOrAir(rid)
+}
+
+// Liquid returns the Liquid at the position passed in the ChunkSnapshot. The
+// Liquid may be in the foreground or in any other layer. If no Liquid is
+// present at the position, false is returned.
+func (s *ChunkSnapshot) Liquid(pos cube.Pos) (Liquid, bool) {
+	if !s.within(pos) {
+		return nil, false
+	}
+	x, y, z := uint8(pos[0]), int16(pos[1]), uint8(pos[2])
+	if liq, ok := blockByRuntimeIDOrAir(s.c.Block(x, y, z, 0)).(Liquid); ok {
+		return liq, true
+	}
+	liq, ok := blockByRuntimeIDOrAir(s.c.Block(x, y, z, 1)).(Liquid)
+	return liq, ok
+}
+
+// Biome returns the Biome at the position passed in the ChunkSnapshot. The
+// position is in world coordinates. The default biome of the World, which is
+// also used for biomes that are not registered, is returned for positions
+// outside the chunk of the snapshot.
+func (s *ChunkSnapshot) Biome(pos cube.Pos) Biome {
+	if !s.within(pos) {
+		return s.biome
+	}
+	return biomeByIDOr(int(s.c.Biome(uint8(pos[0]), int16(pos[1]), uint8(pos[2]))), s.biome)
+}
+
+// HighestBlock returns the Y value of the highest non-air block at the x and
+// z passed in world coordinates. If no blocks are present in the column, the
+// minimum height of the chunk is returned.
+func (s *ChunkSnapshot) HighestBlock(x, z int) int {
+	s.surface.Do(func() {
+		// Reading any column calculates the height maps of all columns.
+		s.c.HighestBlock(0, 0)
+	})
+	return int(s.c.HighestBlock(uint8(x), uint8(z)))
+}
+
+// BlockEntities returns an iterator over the block entities in the
+// ChunkSnapshot and their positions. The iterator yields no values if the
+// snapshot was taken without block entities.
+func (s *ChunkSnapshot) BlockEntities() iter.Seq2[cube.Pos, Block] {
+	return maps.All(s.blockEntities)
+}
+
+// within checks if a position is within the chunk and vertical range of the
+// ChunkSnapshot.
+func (s *ChunkSnapshot) within(pos cube.Pos) bool {
+	return chunkPosFromBlockPos(pos) == s.pos && !pos.OutOfBounds(s.c.Range())
+}
@@ -51,6 +51,12 @@ type EnderChestOpen struct{ sound }
 // EnderChestClose is played when a ender chest is closed.
 type EnderChestClose struct{ sound }
 
+// ShulkerBoxOpen is played when a shulker box is opened.
+type ShulkerBoxOpen struct{ sound }
+
+// ShulkerBoxClose is played when a shulker box is closed.
+type ShulkerBoxClose struct{ sound }
+
 // BarrelOpen is played when a barrel is opened.
 type BarrelOpen struct{ sound }
 
@@ -170,9 +176,40 @@ type BlastFurnaceCrackle struct{ sound }
 // SmokerCrackle is a sound played every one to five seconds from a smoker.
 type SmokerCrackle struct{ sound }
 
+// BeehiveEnter is a sound played when a bee enters a beehive or bee nest.
+type BeehiveEnter struct{ sound }
+
+// BeehiveExit is a sound played when a bee leaves a beehive or bee nest.
+type BeehiveExit struct{ sound }
+
+// BeehiveShear is a sound played when honeycomb is sheared off a beehive or
+// bee nest.
+type BeehiveShear struct{ sound }
+
+// FrogspawnHatch is a sound played when frogspawn hatches into tadpoles.
+type FrogspawnHatch struct{ sound }
+
+// RespawnAnchorCharge is a sound played when a respawn anchor is charged
+// with glowstone.
+type RespawnAnchorCharge struct{ sound }
+
+// RespawnAnchorSetSpawn is a sound played when a player sets its spawn point
+// at a respawn anchor.
+type RespawnAnchorSetSpawn struct{ sound }
+
+// RespawnAnchorDeplete is a sound played when the last charge of a respawn
+// anchor is used up by a player respawning at it.
+type RespawnAnchorDeplete struct{ sound }
+
+// BottleFill is a sound played when a glass bottle is filled.
+type BottleFill struct{ sound }
+
 // ComposterEmpty is a sound played when a composter has been emptied.
 type ComposterEmpty struct{ sound }
 
+// BellRing is a sound played when a bell is rung.
+type BellRing struct{ sound }
+
 // ComposterFill is a sound played when a composter has been filled, but not gone up a layer.
 type ComposterFill struct{ sound }
 
@@ -210,6 +247,15 @@ type DecoratedPotInserted struct {
 // DecoratedPotInsertFailed is a sound played when an item fails to be inserted into a decorated pot.
 type DecoratedPotInsertFailed struct{ sound }
 
+// SculkSensorPowerOn is a sound played when a sculk sensor perceives a vibration and becomes active.
+type SculkSensorPowerOn struct{ sound }
+
+// SculkSensorPowerOff is a sound played when a sculk sensor stops being active.
+type SculkSensorPowerOff struct{ sound }
+
+// SculkShriekerShriek is a sound played when a sculk shrieker shrieks.
+type SculkShriekerShriek struct{ sound }
+
 // sound implements the world.Sound interface.
 type sound struct{}
 
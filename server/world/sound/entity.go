@@ -23,6 +23,9 @@ type Fall struct {
 	sound
 }
 
+// FishFlop is a sound played when a fish flops around on land.
+type FishFlop struct{ sound }
+
 // Burp is a sound played when a player finishes eating an item.
 type Burp struct{ sound }
 
@@ -32,6 +35,10 @@ type Pop struct{ sound }
 // Explosion is a sound played when an explosion happens, such as from a creeper or TNT.
 type Explosion struct{ sound }
 
+// CreeperFuse is a sound played when a creeper starts swelling before it
+// explodes.
+type CreeperFuse struct{ sound }
+
 // Thunder is a sound played when lightning strikes the ground.
 type Thunder struct{ sound }
 
@@ -47,6 +54,33 @@ type GhastWarning struct{ sound }
 // GhastShoot is a sound played when a ghast shoots a fire charge.
 type GhastShoot struct{ sound }
 
+// WitherSpawn is a sound played when a wither finishes spawning and explodes.
+type WitherSpawn struct{ sound }
+
+// WitherShoot is a sound played when a wither shoots a wither skull.
+type WitherShoot struct{ sound }
+
+// WitherBreakBlock is a sound played when a wither breaks the blocks around
+// it.
+type WitherBreakBlock struct{ sound }
+
+// WardenNearby is a sound played by a sculk shrieker to warn players that a
+// warden will be summoned if they continue to trigger sculk shriekers.
+type WardenNearby struct {
+	sound
+
+	// Level is the warning level of the sound, ranging from 1 to 3. Higher
+	// levels warn that the warden is closer to being summoned.
+	Level int
+}
+
+// WardenSonicCharge is a sound played when a warden starts charging its sonic
+// boom.
+type WardenSonicCharge struct{ sound }
+
+// WardenSonicBoom is a sound played when a warden releases its sonic boom.
+type WardenSonicBoom struct{ sound }
+
 // FireworkLaunch is a sound played when a firework is launched.
 type FireworkLaunch struct{ sound }
 
@@ -58,3 +92,9 @@ type FireworkBlast struct{ sound }
 
 // FireworkTwinkle is a sound played when a firework explodes and should twinkle.
 type FireworkTwinkle struct{ sound }
+
+// Shear is a sound played when a sheep is sheared.
+type Shear struct{ sound }
+
+// RaidHorn is a sound played when a new wave of a raid is about to arrive.
+type RaidHorn struct{ sound }
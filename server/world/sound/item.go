@@ -32,6 +32,9 @@ type EquipItem struct {
 type BucketFill struct {
 	// Liquid is the liquid that the bucket is filled up with.
 	Liquid world.Liquid
+	// Entity specifies if an entity, such as an axolotl, was captured in the
+	// bucket.
+	Entity bool
 
 	sound
 }
@@ -40,6 +43,8 @@ type BucketFill struct {
 type BucketEmpty struct {
 	// Liquid is the liquid that the bucket places into the world.
 	Liquid world.Liquid
+	// Entity specifies if an entity captured in the bucket was released.
+	Entity bool
 
 	sound
 }
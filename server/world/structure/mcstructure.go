@@ -0,0 +1,194 @@
+package structure
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/worldupgrader/blockupgrader"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"io"
+	"maps"
+	"strconv"
+)
+
+// ReadMCStructure reads a Structure from a .mcstructure file, as saved by
+// structure blocks in Bedrock Edition. Block states from older versions are
+// upgraded to the current version. Blocks that do not exist are left out of
+// the Structure.
+func ReadMCStructure(r io.Reader) (*Structure, error) {
+	var m map[string]any
+	if err := nbt.NewDecoderWithEncoding(r, nbt.LittleEndian).Decode(&m); err != nil {
+		return nil, fmt.Errorf("read mcstructure: decode nbt: %w", err)
+	}
+	s, err := DecodeNBT(m)
+	if err != nil {
+		return nil, fmt.Errorf("read mcstructure: %w", err)
+	}
+	return s, nil
+}
+
+// DecodeNBT decodes a Structure from NBT data in the .mcstructure format, as
+// stored by world.StructureProvider implementations.
+func DecodeNBT(m map[string]any) (*Structure, error) {
+	size, ok := m["size"].([]int32)
+	if !ok || len(size) != 3 {
+		return nil, fmt.Errorf("invalid size")
+	}
+	s := New([3]int{int(size[0]), int(size[1]), int(size[2])})
+	origin, _ := m["structure_world_origin"].([]int32)
+	if len(origin) != 3 {
+		origin = []int32{0, 0, 0}
+	}
+
+	data, _ := m["structure"].(map[string]any)
+	layers, _ := data["block_indices"].([]any)
+	paletteData, _ := data["palette"].(map[string]any)
+	def, _ := paletteData["default"].(map[string]any)
+	entries, _ := def["block_palette"].([]any)
+	positionData, _ := def["block_position_data"].(map[string]any)
+
+	palette := make([]world.Block, len(entries))
+	for i, e := range entries {
+		entry, _ := e.(map[string]any)
+		palette[i] = decodePaletteEntry(entry)
+	}
+
+	w, h, l := s.size[0], s.size[1], s.size[2]
+	for layer, indices := range layers {
+		indices, _ := indices.([]int32)
+		if len(indices) != w*h*l {
+			if len(indices) == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("expected %v block indices in layer %v, got %v", w*h*l, layer, len(indices))
+		}
+		for i, index := range indices {
+			if index < 0 || int(index) >= len(palette) || palette[index] == nil {
+				continue
+			}
+			// Indices in .mcstructure files are ordered with Z increasing
+			// fastest, followed by Y and then X.
+			x, y, z := i/(h*l), (i/l)%h, i%l
+			b := palette[index]
+			if layer == 0 {
+				if nbter, ok := b.(world.NBTer); ok {
+					if blockData, ok := positionData[strconv.Itoa(i)].(map[string]any); ok {
+						if entityData, ok := blockData["block_entity_data"].(map[string]any); ok {
+							b = nbter.DecodeNBT(entityData).(world.Block)
+						}
+					}
+				}
+				s.SetBlock(x, y, z, b)
+			} else if liq, ok := b.(world.Liquid); ok && layer == 1 {
+				s.SetLiquid(x, y, z, liq)
+			}
+		}
+	}
+
+	entities, _ := data["entities"].([]any)
+	for _, e := range entities {
+		entity, ok := e.(map[string]any)
+		if !ok {
+			continue
+		}
+		pos := nbtconv.Vec3(entity, "Pos")
+		entity["Pos"] = nbtconv.Vec3ToFloat32Slice(pos.Sub(mgl64.Vec3{float64(origin[0]), float64(origin[1]), float64(origin[2])}))
+		s.entities = append(s.entities, entity)
+	}
+	return s, nil
+}
+
+// decodePaletteEntry decodes a block from an entry in the block palette of a
+// .mcstructure file. Nil is returned if the block does not exist.
+func decodePaletteEntry(entry map[string]any) world.Block {
+	name, _ := entry["name"].(string)
+	version, _ := entry["version"].(int32)
+	states, _ := entry["states"].(map[string]any)
+	if states == nil {
+		states = map[string]any{}
+	}
+	upgraded := blockupgrader.Upgrade(blockupgrader.BlockState{Name: name, Properties: states, Version: version})
+	b, ok := world.BlockByName(upgraded.Name, upgraded.Properties)
+	if !ok {
+		return nil
+	}
+	return b
+}
+
+// WriteMCStructure writes the Structure to w in the .mcstructure format, so
+// that it may be loaded by structure blocks in Bedrock Edition.
+func (s *Structure) WriteMCStructure(w io.Writer) error {
+	if err := nbt.NewEncoderWithEncoding(w, nbt.LittleEndian).Encode(s.EncodeNBT()); err != nil {
+		return fmt.Errorf("write mcstructure: encode nbt: %w", err)
+	}
+	return nil
+}
+
+// EncodeNBT encodes the Structure to NBT data in the .mcstructure format.
+func (s *Structure) EncodeNBT() map[string]any {
+	width, h, l := s.size[0], s.size[1], s.size[2]
+	blocks, liquids := make([]int32, width*h*l), make([]int32, width*h*l)
+
+	var entries []any
+	lookup := map[uint32]int32{}
+	paletteIndex := func(b world.Block) int32 {
+		rid := world.BlockRuntimeID(b)
+		if index, ok := lookup[rid]; ok {
+			return index
+		}
+		name, properties := b.EncodeBlock()
+		index := int32(len(entries))
+		entries = append(entries, map[string]any{"name": name, "states": maps.Clone(properties), "version": chunk.CurrentBlockVersion})
+		lookup[rid] = index
+		return index
+	}
+
+	positionData := map[string]any{}
+	for x := range width {
+		for y := range h {
+			for z := range l {
+				i := (x*h+y)*l + z
+				blocks[i], liquids[i] = -1, -1
+
+				b := s.Block(x, y, z)
+				if b == nil {
+					continue
+				}
+				blocks[i] = paletteIndex(b)
+				if nbter, ok := b.(world.NBTer); ok {
+					data := nbter.EncodeNBT()
+					data["x"], data["y"], data["z"] = int32(x), int32(y), int32(z)
+					positionData[strconv.Itoa(i)] = map[string]any{"block_entity_data": data}
+				}
+				if liq, ok := s.Liquid(x, y, z); ok {
+					liquids[i] = paletteIndex(liq)
+				}
+			}
+		}
+	}
+	if entries == nil {
+		entries = []any{}
+	}
+	entities := make([]any, len(s.entities))
+	for i, e := range s.entities {
+		entities[i] = e
+	}
+
+	return map[string]any{
+		"format_version":         int32(1),
+		"size":                   []int32{int32(width), int32(h), int32(l)},
+		"structure_world_origin": []int32{0, 0, 0},
+		"structure": map[string]any{
+			"block_indices": []any{blocks, liquids},
+			"entities":      entities,
+			"palette": map[string]any{
+				"default": map[string]any{
+					"block_palette":       entries,
+					"block_position_data": positionData,
+				},
+			},
+		},
+	}
+}
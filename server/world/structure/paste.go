@@ -0,0 +1,253 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"maps"
+	"math"
+)
+
+// Mirror is a way to mirror a Structure when it is pasted.
+type Mirror int
+
+const (
+	// MirrorNone leaves the Structure as it is.
+	MirrorNone Mirror = iota
+	// MirrorX mirrors the Structure along the X axis, swapping east and west.
+	MirrorX
+	// MirrorZ mirrors the Structure along the Z axis, swapping north and
+	// south.
+	MirrorZ
+	// MirrorXZ mirrors the Structure along both the X and the Z axis.
+	MirrorXZ
+)
+
+// x checks if the Mirror mirrors along the X axis.
+func (m Mirror) x() bool {
+	return m&MirrorX != 0
+}
+
+// z checks if the Mirror mirrors along the Z axis.
+func (m Mirror) z() bool {
+	return m&MirrorZ != 0
+}
+
+// PasteOpts holds options that change how a Structure is pasted.
+type PasteOpts struct {
+	// Rotation is the amount of quarter turns by which the Structure is
+	// rotated clockwise around the Y axis. The Structure is rotated after it
+	// is mirrored. The blocks in the Structure are rotated with it.
+	Rotation int
+	// Mirror specifies how the Structure is mirrored.
+	Mirror Mirror
+	// IgnoreAir specifies if air in the Structure should be ignored, so that
+	// the blocks in the world at those positions are left untouched.
+	IgnoreAir bool
+	// BlockEntities specifies if the block entity data of blocks, such as the
+	// items in a chest, should be pasted. If false, blocks with block entity
+	// data are pasted without their data.
+	BlockEntities bool
+	// Entities specifies if the entities in the Structure should be pasted.
+	Entities bool
+	// Integrity is the chance, ranging from 0 to 1, for every block in the
+	// Structure to be pasted. Blocks that are not pasted are left out as if
+	// the Structure held no block at that position. If 0, every block is
+	// pasted.
+	Integrity float64
+	// Seed is the seed used to select the blocks left out if Integrity is
+	// lower than 1. The same blocks are left out every time a Structure is
+	// pasted with the same Seed.
+	Seed int64
+}
+
+// Paste pastes the Structure into the world at the position passed, which is
+// the minimum corner of the area that the Structure will occupy. Paste writes
+// directly to the chunks of the world, so it is much faster than setting
+// blocks one by one, but very large structures still take a while to paste.
+// PasteAsync may be used to avoid holding up the world for too long.
+func (s *Structure) Paste(tx *world.Tx, pos cube.Pos, opts PasteOpts) {
+	t := s.transform(opts)
+	tx.BuildStructure(pos, t)
+	if opts.Entities {
+		t.addEntities(tx, pos)
+	}
+}
+
+// PasteAsync pastes the Structure into the world at the position passed, like
+// Paste, but splits the work up into separate transactions for every chunk
+// column that the Structure occupies, so that the world is not held up while
+// the Structure is pasted. The channel returned is closed once the complete
+// Structure has been pasted. The Structure must not be modified until then.
+func (s *Structure) PasteAsync(w *world.World, pos cube.Pos, opts PasteOpts) <-chan struct{} {
+	t := s.transform(opts)
+	c := make(chan struct{})
+	go func() {
+		defer close(c)
+		for minX := pos[0]; minX < pos[0]+t.size[0]; minX = (minX>>4 + 1) << 4 {
+			for minZ := pos[2]; minZ < pos[2]+t.size[2]; minZ = (minZ>>4 + 1) << 4 {
+				r := region{
+					t:    t,
+					off:  [3]int{minX - pos[0], 0, minZ - pos[2]},
+					size: [3]int{min((minX>>4+1)<<4, pos[0]+t.size[0]) - minX, t.size[1], min((minZ>>4+1)<<4, pos[2]+t.size[2]) - minZ},
+				}
+				<-w.Exec(func(tx *world.Tx) {
+					tx.BuildStructure(cube.Pos{minX, pos[1], minZ}, r)
+				})
+			}
+		}
+		if opts.Entities {
+			<-w.Exec(func(tx *world.Tx) {
+				t.addEntities(tx, pos)
+			})
+		}
+	}()
+	return c
+}
+
+// transformed is a Structure with PasteOpts applied. It implements
+// world.Structure.
+type transformed struct {
+	s    *Structure
+	opts PasteOpts
+	// turns is the amount of clockwise quarter turns, in the range 0-3.
+	turns int
+	// size is the size of the Structure after rotating it.
+	size [3]int
+
+	palette       []world.Block
+	blockEntities map[int]world.Block
+}
+
+// transform returns a transformed view on the Structure using the PasteOpts
+// passed.
+func (s *Structure) transform(opts PasteOpts) *transformed {
+	t := &transformed{s: s, opts: opts, turns: ((opts.Rotation % 4) + 4) % 4, size: s.size, palette: make([]world.Block, len(s.palette))}
+	if t.turns%2 == 1 {
+		t.size[0], t.size[2] = s.size[2], s.size[0]
+	}
+	for i, b := range s.palette {
+		b = transformBlock(b, t.turns, opts.Mirror)
+		if opts.IgnoreAir {
+			if name, _ := b.EncodeBlock(); name == "minecraft:air" {
+				b = nil
+			}
+		}
+		if _, ok := b.(world.NBTer); ok {
+			// Don't carry over the data of whichever block with this state
+			// happened to be added to the palette first.
+			b, _ = world.BlockByRuntimeID(world.BlockRuntimeID(b))
+		}
+		t.palette[i] = b
+	}
+	if opts.BlockEntities {
+		t.blockEntities = make(map[int]world.Block, len(s.blockEntities))
+		for i, b := range s.blockEntities {
+			t.blockEntities[i] = transformBlock(b, t.turns, opts.Mirror)
+		}
+	}
+	return t
+}
+
+// Dimensions returns the size of the Structure after rotating it.
+func (t *transformed) Dimensions() [3]int {
+	return t.size
+}
+
+// At returns the block and liquid at a position in the rotated and mirrored
+// Structure.
+func (t *transformed) At(x, y, z int, _ func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	w, l := t.s.size[0], t.s.size[2]
+	switch t.turns {
+	case 1:
+		x, z = z, l-1-x
+	case 2:
+		x, z = w-1-x, l-1-z
+	case 3:
+		x, z = w-1-z, x
+	}
+	if t.opts.Mirror.x() {
+		x = w - 1 - x
+	}
+	if t.opts.Mirror.z() {
+		z = l - 1 - z
+	}
+	i, ok := t.s.index(x, y, z)
+	if !ok || t.s.indices[i] == -1 || !t.intact(i) {
+		return nil, nil
+	}
+	b, ok := t.blockEntities[i]
+	if !ok {
+		b = t.palette[t.s.indices[i]]
+	}
+	return b, t.s.liquids[i]
+}
+
+// intact checks if the block at the index passed should be pasted, based on
+// the Integrity and Seed of the PasteOpts.
+func (t *transformed) intact(i int) bool {
+	if t.opts.Integrity <= 0 || t.opts.Integrity >= 1 {
+		return true
+	}
+	// Hash the seed and the index using splitmix64 so that the same blocks
+	// are selected regardless of the order in which they are pasted.
+	h := uint64(t.opts.Seed) + uint64(i)*0x9e3779b97f4a7c15
+	h = (h ^ (h >> 30)) * 0xbf58476d1ce4e5b9
+	h = (h ^ (h >> 27)) * 0x94d049bb133111eb
+	h ^= h >> 31
+	return float64(h>>11)/(1<<53) < t.opts.Integrity
+}
+
+// addEntities adds the entities in the Structure to the world, with the
+// Structure placed at the position passed.
+func (t *transformed) addEntities(tx *world.Tx, pos cube.Pos) {
+	reg := tx.World().EntityRegistry()
+	w, l := float64(t.s.size[0]), float64(t.s.size[2])
+	for _, data := range t.s.entities {
+		data = maps.Clone(data)
+
+		p := nbtconv.Vec3(data, "Pos")
+		x, y, z := p[0], p[1], p[2]
+		yaw := nbtconv.Float32(data, "Yaw")
+		if t.opts.Mirror.x() {
+			x, yaw = w-x, -yaw
+		}
+		if t.opts.Mirror.z() {
+			z, yaw = l-z, 180-yaw
+		}
+		switch t.turns {
+		case 1:
+			x, z = l-z, x
+		case 2:
+			x, z = w-x, l-z
+		case 3:
+			x, z = z, w-x
+		}
+		yaw = float32(math.Mod(float64(yaw)+float64(t.turns*90), 360))
+
+		data["Pos"] = nbtconv.Vec3ToFloat32Slice(mgl64.Vec3{x, y, z}.Add(pos.Vec3()))
+		data["Yaw"] = yaw
+		if handle, ok := world.NewEntityFromNBT(reg, data); ok {
+			tx.AddEntity(handle)
+		}
+	}
+}
+
+// region is a cuboid part of a transformed Structure. It is used to paste
+// a Structure one chunk column at a time.
+type region struct {
+	t    *transformed
+	off  [3]int
+	size [3]int
+}
+
+// Dimensions returns the size of the region.
+func (r region) Dimensions() [3]int {
+	return r.size
+}
+
+// At returns the block and liquid at a position in the region.
+func (r region) At(x, y, z int, f func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	return r.t.At(x+r.off[0], y+r.off[1], z+r.off[2], f)
+}
@@ -0,0 +1,101 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"reflect"
+)
+
+var (
+	directionType   = reflect.TypeFor[cube.Direction]()
+	faceType        = reflect.TypeFor[cube.Face]()
+	axisType        = reflect.TypeFor[cube.Axis]()
+	orientationType = reflect.TypeFor[cube.Orientation]()
+)
+
+// transformBlock mirrors and then rotates the block passed by the amount of
+// clockwise quarter turns passed. Blocks are transformed by changing the
+// exported fields of types cube.Direction, cube.Face, cube.Axis and
+// cube.Orientation. If the transformed block is not a valid block state, the
+// block passed is returned unchanged.
+func transformBlock(b world.Block, turns int, mirror Mirror) world.Block {
+	if b == nil || (turns == 0 && mirror == MirrorNone) {
+		return b
+	}
+	v := reflect.ValueOf(b)
+	if v.Kind() != reflect.Struct {
+		return b
+	}
+	cp := reflect.New(v.Type()).Elem()
+	cp.Set(v)
+
+	changed := false
+	for i := range cp.NumField() {
+		f := cp.Field(i)
+		if !f.CanSet() {
+			continue
+		}
+		switch f.Type() {
+		case directionType:
+			f.SetInt(int64(transformDirection(cube.Direction(f.Int()), turns, mirror)))
+		case faceType:
+			f.SetInt(int64(transformFace(cube.Face(f.Int()), turns, mirror)))
+		case axisType:
+			a := cube.Axis(f.Int())
+			for range turns {
+				a = a.RotateRight()
+			}
+			f.SetInt(int64(a))
+		case orientationType:
+			f.SetInt(int64(transformOrientation(cube.Orientation(f.Int()), turns, mirror)))
+		default:
+			continue
+		}
+		changed = true
+	}
+	if !changed {
+		return b
+	}
+	transformed := cp.Interface().(world.Block)
+	name, properties := transformed.EncodeBlock()
+	if _, ok := world.BlockByName(name, properties); !ok {
+		return b
+	}
+	return transformed
+}
+
+// transformDirection mirrors and then rotates a cube.Direction.
+func transformDirection(d cube.Direction, turns int, mirror Mirror) cube.Direction {
+	switch {
+	case mirror.x() && (d == cube.East || d == cube.West):
+		d = d.Opposite()
+	case mirror.z() && (d == cube.North || d == cube.South):
+		d = d.Opposite()
+	}
+	for range turns {
+		d = d.RotateRight()
+	}
+	return d
+}
+
+// transformFace mirrors and then rotates a cube.Face. Vertical faces are
+// never changed.
+func transformFace(f cube.Face, turns int, mirror Mirror) cube.Face {
+	if f.Axis() == cube.Y {
+		return f
+	}
+	return transformDirection(f.Direction(), turns, mirror).Face()
+}
+
+// transformOrientation mirrors and then rotates a cube.Orientation.
+func transformOrientation(o cube.Orientation, turns int, mirror Mirror) cube.Orientation {
+	// An orientation of 0 faces south, and every step rotates the
+	// orientation clockwise by 22.5 degrees.
+	if mirror.x() {
+		o = (16 - o) % 16
+	}
+	if mirror.z() {
+		o = (24 - o) % 16
+	}
+	return (o + cube.Orientation(turns*4)) % 16
+}
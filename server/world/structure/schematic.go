@@ -0,0 +1,147 @@
+package structure
+
+import (
+	"compress/gzip"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/anvil"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"io"
+	"maps"
+	"reflect"
+)
+
+// ReadSchematic reads a Structure from a Sponge schematic (.schem) file, as
+// saved by Java Edition tools such as WorldEdit. Both version 2 and 3 of the
+// format are supported. Java Edition block states are converted to their
+// Bedrock Edition counterparts, and blocks without a counterpart are left out
+// of the Structure. The block entity data of signs and containers is
+// converted, but entities are not, as their data differs too much between the
+// two editions.
+// ReadSchematic may only be called after the block registry is finalised.
+func ReadSchematic(r io.Reader) (*Structure, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("read schematic: %w", err)
+	}
+	defer zr.Close()
+
+	var m map[string]any
+	if err := nbt.NewDecoderWithEncoding(zr, nbt.BigEndian).Decode(&m); err != nil {
+		return nil, fmt.Errorf("read schematic: decode nbt: %w", err)
+	}
+	if nested, ok := m["Schematic"].(map[string]any); ok {
+		// Version 3 schematics nest their data in a 'Schematic' compound.
+		m = nested
+	}
+	w := int(uint16(nbtconv.Int16(m, "Width")))
+	h := int(uint16(nbtconv.Int16(m, "Height")))
+	l := int(uint16(nbtconv.Int16(m, "Length")))
+	s := New([3]int{w, h, l})
+
+	blocks := m
+	if v3, ok := m["Blocks"].(map[string]any); ok {
+		blocks = v3
+	}
+	paletteData, _ := blocks["Palette"].(map[string]any)
+	data := byteArray(blocks["BlockData"])
+	if v3, ok := blocks["Data"]; ok {
+		data = byteArray(v3)
+	}
+
+	type entry struct {
+		b   world.Block
+		liq world.Liquid
+	}
+	palette := make(map[int32]entry, len(paletteData))
+	for state, index := range paletteData {
+		index, _ := index.(int32)
+		if b, liq, ok := anvil.ConvertBlock(state); ok {
+			palette[index] = entry{b: b, liq: liq}
+		}
+	}
+
+	// Block data is a sequence of varints holding an index into the palette
+	// for every block, with X increasing fastest, followed by Z and then Y.
+	for i, off := 0, 0; i < w*h*l; i++ {
+		var index int32
+		for shift := 0; ; shift += 7 {
+			if off >= len(data) || shift > 28 {
+				return nil, fmt.Errorf("read schematic: unexpected end of block data at block %v", i)
+			}
+			v := data[off]
+			off++
+			index |= int32(v&0x7f) << shift
+			if v&0x80 == 0 {
+				break
+			}
+		}
+		e, ok := palette[index]
+		if !ok {
+			continue
+		}
+		x, y, z := i%w, i/(w*l), (i/w)%l
+		s.SetBlock(x, y, z, e.b)
+		if e.liq != nil {
+			s.SetLiquid(x, y, z, e.liq)
+		}
+	}
+
+	blockEntities, _ := blocks["BlockEntities"].([]any)
+	if blockEntities == nil {
+		blockEntities, _ = m["TileEntities"].([]any)
+	}
+	for _, v := range blockEntities {
+		be, _ := v.(map[string]any)
+		pos := int32Array(be["Pos"])
+		if len(pos) != 3 {
+			continue
+		}
+		x, y, z := int(pos[0]), int(pos[1]), int(pos[2])
+		nbter, ok := s.Block(x, y, z).(world.NBTer)
+		if !ok {
+			continue
+		}
+		// Version 2 schematics store the block entity data alongside the ID
+		// and position, while version 3 stores it in a separate compound.
+		java := maps.Clone(be)
+		if v3, ok := be["Data"].(map[string]any); ok {
+			java = maps.Clone(v3)
+		}
+		java["id"], java["x"], java["y"], java["z"] = be["Id"], pos[0], pos[1], pos[2]
+		if converted, ok := anvil.ConvertBlockEntity(java); ok {
+			s.SetBlock(x, y, z, nbter.DecodeNBT(converted).(world.Block))
+		}
+	}
+	return s, nil
+}
+
+// byteArray returns the bytes of an NBT byte array decoded into an any value.
+func byteArray(v any) []byte {
+	if b, ok := v.([]byte); ok {
+		return b
+	}
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Array || val.Type().Elem().Kind() != reflect.Uint8 {
+		return nil
+	}
+	b := make([]byte, val.Len())
+	reflect.Copy(reflect.ValueOf(b), val)
+	return b
+}
+
+// int32Array returns the values of an NBT int array decoded into an any
+// value.
+func int32Array(v any) []int32 {
+	if s, ok := v.([]int32); ok {
+		return s
+	}
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Array || val.Type().Elem().Kind() != reflect.Int32 {
+		return nil
+	}
+	s := make([]int32, val.Len())
+	reflect.Copy(reflect.ValueOf(s), val)
+	return s
+}
@@ -0,0 +1,187 @@
+// Package structure implements in-memory structures that may be loaded from
+// .mcstructure files and Sponge schematics (.schem), pasted into a world with
+// rotation and mirroring applied and saved back to .mcstructure files.
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"maps"
+	"slices"
+)
+
+// Structure is a cuboid of blocks, optionally with liquids, block entities
+// and entities, held in memory. Positions in the Structure without a block
+// are left untouched when it is pasted. Structure implements the
+// world.Structure interface, so it may be built directly using
+// world.Tx.BuildStructure, although Paste offers more options.
+// A Structure is not safe for concurrent modification.
+type Structure struct {
+	size [3]int
+
+	palette []world.Block
+	lookup  map[uint32]int32
+	// indices holds an index into palette for every position in the
+	// structure, or -1 for positions without a block.
+	indices []int32
+	// blockEntities holds the blocks with block entity data in the
+	// structure, indexed like indices.
+	blockEntities map[int]world.Block
+	liquids       map[int]world.Liquid
+	entities      []map[string]any
+}
+
+// New returns an empty Structure with the size passed. Initially, no position
+// in the Structure holds a block.
+func New(size [3]int) *Structure {
+	size = [3]int{max(size[0], 0), max(size[1], 0), max(size[2], 0)}
+	s := &Structure{
+		size:          size,
+		lookup:        map[uint32]int32{},
+		indices:       make([]int32, size[0]*size[1]*size[2]),
+		blockEntities: map[int]world.Block{},
+		liquids:       map[int]world.Liquid{},
+	}
+	for i := range s.indices {
+		s.indices[i] = -1
+	}
+	return s
+}
+
+// Dimensions returns the width, height and length of the Structure.
+func (s *Structure) Dimensions() [3]int {
+	return s.size
+}
+
+// Block returns the block at a position in the Structure. Nil is returned if
+// there is no block at the position, or if the position is outside the
+// Structure.
+func (s *Structure) Block(x, y, z int) world.Block {
+	i, ok := s.index(x, y, z)
+	if !ok || s.indices[i] == -1 {
+		return nil
+	}
+	if b, ok := s.blockEntities[i]; ok {
+		return b
+	}
+	return s.palette[s.indices[i]]
+}
+
+// SetBlock sets the block at a position in the Structure. Passing nil
+// removes the block, so that the position is left untouched when the
+// Structure is pasted. Positions outside the Structure are ignored.
+func (s *Structure) SetBlock(x, y, z int, b world.Block) {
+	i, ok := s.index(x, y, z)
+	if !ok {
+		return
+	}
+	delete(s.blockEntities, i)
+	if b == nil {
+		s.indices[i] = -1
+		return
+	}
+	rid := world.BlockRuntimeID(b)
+	index, ok := s.lookup[rid]
+	if !ok {
+		index = int32(len(s.palette))
+		s.palette = append(s.palette, b)
+		s.lookup[rid] = index
+	}
+	s.indices[i] = index
+	if _, ok := b.(world.NBTer); ok {
+		s.blockEntities[i] = b
+	}
+}
+
+// Liquid returns the liquid at a position in the Structure, in addition to
+// the block at that position. False is returned if there is no liquid.
+func (s *Structure) Liquid(x, y, z int) (world.Liquid, bool) {
+	i, ok := s.index(x, y, z)
+	if !ok {
+		return nil, false
+	}
+	l, ok := s.liquids[i]
+	return l, ok
+}
+
+// SetLiquid sets the liquid at a position in the Structure, in addition to
+// the block at that position. Passing nil removes the liquid.
+func (s *Structure) SetLiquid(x, y, z int, l world.Liquid) {
+	i, ok := s.index(x, y, z)
+	if !ok {
+		return
+	}
+	if l == nil {
+		delete(s.liquids, i)
+		return
+	}
+	s.liquids[i] = l
+}
+
+// Entities returns the NBT data of the entities in the Structure, as returned
+// by world.EntityHandle.EncodeNBT. The positions of the entities are relative
+// to the origin of the Structure.
+func (s *Structure) Entities() []map[string]any {
+	return slices.Clone(s.entities)
+}
+
+// AddEntity adds the NBT data of an entity to the Structure. The position
+// stored in the data must be relative to the origin of the Structure.
+func (s *Structure) AddEntity(data map[string]any) {
+	s.entities = append(s.entities, maps.Clone(data))
+}
+
+// ClearEntities removes all entities from the Structure.
+func (s *Structure) ClearEntities() {
+	s.entities = nil
+}
+
+// At returns the block and liquid at a position in the Structure. At
+// implements the world.Structure interface.
+func (s *Structure) At(x, y, z int, _ func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	l, _ := s.Liquid(x, y, z)
+	return s.Block(x, y, z), l
+}
+
+// Copy copies the blocks, liquids, block entities and entities in the area
+// between two corners in the world to a new Structure. Positions outside the
+// height range of the world are left empty.
+func Copy(tx *world.Tx, a, b cube.Pos) *Structure {
+	low := cube.Pos{min(a[0], b[0]), min(a[1], b[1]), min(a[2], b[2])}
+	high := cube.Pos{max(a[0], b[0]), max(a[1], b[1]), max(a[2], b[2])}
+	s := New([3]int{high[0] - low[0] + 1, high[1] - low[1] + 1, high[2] - low[2] + 1})
+
+	r := tx.Range()
+	for y := max(low[1], r.Min()); y <= min(high[1], r.Max()); y++ {
+		for x := low[0]; x <= high[0]; x++ {
+			for z := low[2]; z <= high[2]; z++ {
+				pos := cube.Pos{x, y, z}
+				rel := pos.Sub(low)
+				s.SetBlock(rel[0], rel[1], rel[2], tx.Block(pos))
+				if l, ok := tx.Liquid(pos); ok && world.BlockRuntimeID(l) != world.BlockRuntimeID(tx.Block(pos)) {
+					s.SetLiquid(rel[0], rel[1], rel[2], l)
+				}
+			}
+		}
+	}
+	box := cube.Box(float64(low[0]), float64(low[1]), float64(low[2]), float64(high[0]+1), float64(high[1]+1), float64(high[2]+1))
+	for e := range tx.EntitiesWithin(box) {
+		if _, player := e.(interface{ GameMode() world.GameMode }); player {
+			continue
+		}
+		data := e.H().EncodeNBT()
+		data["Pos"] = nbtconv.Vec3ToFloat32Slice(e.Position().Sub(low.Vec3()))
+		s.entities = append(s.entities, data)
+	}
+	return s
+}
+
+// index returns the index of a position in the Structure. False is returned
+// if the position is outside the Structure.
+func (s *Structure) index(x, y, z int) (int, bool) {
+	if x < 0 || y < 0 || z < 0 || x >= s.size[0] || y >= s.size[1] || z >= s.size[2] {
+		return 0, false
+	}
+	return (y*s.size[2]+z)*s.size[0] + x, true
+}
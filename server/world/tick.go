@@ -2,7 +2,6 @@ package world
 
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
-	"github.com/df-mc/dragonfly/server/internal/sliceutil"
 	"maps"
 	"math/rand/v2"
 	"slices"
@@ -37,6 +36,19 @@ func (t ticker) tickLoop(w *World) {
 func (t ticker) tick(tx *Tx) {
 	viewers, loaders := tx.World().allViewers()
 	w := tx.World()
+	defer w.metrics.tick(w, w.metrics.start())
+	w.updateLoaderCentres(loaders)
+	// Entities may have been moved by anything since the last tick, so they
+	// are re-indexed even if the world is not ticked any further.
+	for handle := range w.entities {
+		w.entityIndex.move(handle)
+	}
+	w.installFinished(tx)
+	if w.frozen.Load() && !t.step(w) {
+		// The World is frozen and no ticks were added using World.Step, so
+		// nothing besides transactions and chunk loading is processed.
+		return
+	}
 
 	w.set.Lock()
 	if s := w.set.Spawn; s[1] > tx.Range()[1] {
@@ -44,8 +56,9 @@ func (t ticker) tick(tx *Tx) {
 		// the player should spawn at the highest position in the world.
 		w.set.Spawn[1] = w.highestObstructingBlock(s[0], s[2]) + 1
 	}
-	if len(viewers) == 0 && w.set.CurrentTick != 0 {
-		// Don't continue ticking if no viewers are in the world.
+	if len(viewers) == 0 && w.conf.SpawnChunkRadius <= 0 && !w.forceLoaded() && w.set.CurrentTick != 0 {
+		// Don't continue ticking if no viewers are in the world and no chunks
+		// are otherwise kept loaded.
 		w.set.Unlock()
 		return
 	}
@@ -59,7 +72,7 @@ func (t ticker) tick(tx *Tx) {
 		}
 	}
 
-	rain, thunder, tick, tim := w.set.Raining, w.set.Thundering && w.set.Raining, w.set.CurrentTick, int(w.set.Time)
+	rain, thunder, tick, tim, spawn := w.set.Raining, w.set.Thundering && w.set.Raining, w.set.CurrentTick, int(w.set.Time), w.set.Spawn
 	w.set.Unlock()
 
 	if tick%20 == 0 {
@@ -76,10 +89,27 @@ func (t ticker) tick(tx *Tx) {
 		w.tickLightning(tx)
 	}
 
+	t.updateChunkLevels(tx, loaders, spawn, tick)
 	t.tickEntities(tx, tick)
+	w.syncPassengers(tx)
 	w.scheduledUpdates.tick(tx, tick)
-	t.tickBlocksRandomly(tx, loaders, tick)
+	t.tickBlocksRandomly(tx, tick)
 	t.performNeighbourUpdates(tx)
+	w.profiler.tick()
+}
+
+// step attempts to consume one of the ticks added to a frozen World using
+// World.Step. It returns true if a tick was consumed and should be run.
+func (t ticker) step(w *World) bool {
+	for {
+		n := w.steps.Load()
+		if n <= 0 {
+			return false
+		}
+		if w.steps.CompareAndSwap(n, n-1) {
+			return true
+		}
+	}
 }
 
 // performNeighbourUpdates performs all block updates that came as a result of a neighbouring block being changed.
@@ -88,8 +118,27 @@ func (t ticker) performNeighbourUpdates(tx *Tx) {
 	clear(tx.World().neighbourUpdates)
 	tx.World().neighbourUpdates = tx.World().neighbourUpdates[:0]
 
+	prof := tx.World().profiler
 	for _, update := range updates {
 		pos, changedNeighbour := update.pos, update.neighbour
+		if c, ok := tx.World().chunks[chunkPosFromBlockPos(pos)]; !ok || c.level < chunkLevelBorder {
+			// Block updates are not performed in chunks that aren't ticked.
+			// They are kept in the chunk until it is ticked again, or dropped
+			// if the chunk isn't loaded at all.
+			if ok {
+				c.neighbourUpdates = append(c.neighbourUpdates, update)
+			}
+			continue
+		}
+		tx.setBlockContext(txOperationNeighbourUpdate, pos)
+		start := prof.start()
+		if update.observed {
+			if observer, ok := tx.Block(pos).(BlockChangeObserver); ok {
+				observer.ObserveBlockChange(pos, changedNeighbour, tx)
+			}
+			prof.blockTick(pos, start)
+			continue
+		}
 		if ticker, ok := tx.Block(pos).(NeighbourUpdateTicker); ok {
 			ticker.NeighbourUpdateTick(pos, changedNeighbour, tx)
 		}
@@ -98,35 +147,22 @@ func (t ticker) performNeighbourUpdates(tx *Tx) {
 				ticker.NeighbourUpdateTick(pos, changedNeighbour, tx)
 			}
 		}
+		prof.blockTick(pos, start)
 	}
+	tx.resetContext()
 }
 
-// tickBlocksRandomly executes random block ticks in each sub chunk in the world that has at least one viewer
-// registered from the viewers passed.
-func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64) {
+// tickBlocksRandomly executes random block ticks in each sub chunk in the world that is fully ticking, and
+// ticks the block entities in those chunks.
+func (t ticker) tickBlocksRandomly(tx *Tx, tick int64) {
 	var (
-		r             = int32(tx.World().tickRange())
 		g             randUint4
 		blockEntities []cube.Pos
 		randomBlocks  []cube.Pos
 	)
-	if r == 0 {
-		// NOP if the simulation distance is 0.
-		return
-	}
-
-	loaded := make([]ChunkPos, 0, len(loaders))
-	for _, loader := range loaders {
-		loader.mu.RLock()
-		pos := loader.pos
-		loader.mu.RUnlock()
-
-		loaded = append(loaded, pos)
-	}
-
 	for pos, c := range tx.World().chunks {
-		if !t.anyWithinDistance(pos, loaded, r) {
-			// No loaders in this chunk that are within the simulation distance, so proceed to the next.
+		if c.level != chunkLevelTicking {
+			// The chunk has no ticket, so it has no blocks randomly ticked.
 			continue
 		}
 		blockEntities = append(blockEntities, slices.Collect(maps.Keys(c.BlockEntities))...)
@@ -157,16 +193,24 @@ func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64) {
 		}
 	}
 
+	prof := tx.World().profiler
 	for _, pos := range randomBlocks {
+		tx.setBlockContext(txOperationRandomTick, pos)
+		start := prof.start()
 		if rb, ok := tx.Block(pos).(RandomTicker); ok {
 			rb.RandomTick(pos, tx, tx.World().r)
 		}
+		prof.randomTick(pos, start)
 	}
 	for _, pos := range blockEntities {
+		tx.setBlockContext(txOperationBlockTick, pos)
+		start := prof.start()
 		if tb, ok := tx.Block(pos).(TickerBlock); ok {
 			tb.Tick(tick, pos, tx)
 		}
+		prof.blockTick(pos, start)
 	}
+	tx.resetContext()
 }
 
 // anyWithinDistance checks if any of the ChunkPos loaded are within the distance r of the ChunkPos pos.
@@ -198,7 +242,8 @@ func (t ticker) tickEntities(tx *Tx, tick int64) {
 			// The entity was stored using an outdated chunk position. We update it and make sure it is ready
 			// for loaders to view it.
 			tx.World().entities[handle] = chunkPos
-			c.Entities = append(c.Entities, handle)
+			c.addEntity(handle)
+			c.modified = true
 
 			var viewers []Viewer
 
@@ -206,7 +251,8 @@ func (t ticker) tickEntities(tx *Tx, tick int64) {
 			// where the old chunk of the entity was not loaded. In this case, it should be safe simply to ignore
 			// the loaders from the old chunk. We can assume they never saw the entity in the first place.
 			if old, ok := tx.World().chunks[lastPos]; ok {
-				old.Entities = sliceutil.DeleteVal(old.Entities, handle)
+				old.removeEntity(handle)
+				old.modified = true
 				viewers = old.viewers
 			}
 
@@ -221,17 +267,32 @@ func (t ticker) tickEntities(tx *Tx, tick int64) {
 				if slices.Index(viewers, viewer) == -1 {
 					// Then we show the entity to all loaders that are now viewing the entity in the new
 					// chunk.
-					showEntity(e, viewer)
+					showEntity(tx, e, viewer)
 				}
 			}
 		}
 
-		if len(c.viewers) > 0 {
+		if c.level == chunkLevelTicking {
 			if te, ok := e.(TickerEntity); ok {
+				tx.setEntityContext(txOperationEntityTick, handle)
+				start := tx.World().profiler.start()
+				pos, vel, rot := handle.data.Pos, handle.data.Vel, handle.data.Rot
 				te.Tick(tx, tick)
+				// The tick is attributed to the chunk the entity was in
+				// before it was ticked, even if it moved out of it.
+				tx.World().profiler.entityTick(chunkPos, start)
+				if handle.w == tx.World() {
+					tx.World().entityIndex.move(handle)
+					if handle.data.Pos != pos || handle.data.Vel != vel || handle.data.Rot != rot {
+						// Entities standing still are not saved again,
+						// unless anything else changes in their chunk.
+						c.modified = true
+					}
+				}
 			}
 		}
 	}
+	tx.resetContext()
 }
 
 // randUint4 is a structure used to generate random uint4s.
@@ -285,10 +346,17 @@ func (queue *scheduledTickQueue) tick(tx *Tx, tick int64) {
 	queue.currentTick = tick
 
 	w := tx.World()
+	// Scheduled ticks in chunks that aren't ticked are postponed until the
+	// chunk is ticked again.
+	ready := func(pos cube.Pos, t int64) bool {
+		return t <= tick && w.chunkLevel(chunkPosFromBlockPos(pos)) >= chunkLevelBorder
+	}
 	for _, t := range queue.ticks {
-		if t.t > tick {
+		if !ready(t.pos, t.t) {
 			continue
 		}
+		tx.setBlockContext(txOperationScheduledTick, t.pos)
+		start := w.profiler.start()
 		b := tx.Block(t.pos)
 		if ticker, ok := b.(ScheduledTicker); ok && BlockHash(b) == t.bhash {
 			ticker.ScheduledTick(t.pos, tx, w.r)
@@ -297,14 +365,16 @@ func (queue *scheduledTickQueue) tick(tx *Tx, tick int64) {
 				ticker.ScheduledTick(t.pos, tx, w.r)
 			}
 		}
+		w.profiler.blockTick(t.pos, start)
 	}
+	tx.resetContext()
 
 	// Clear scheduled ticks that were processed from the queue.
 	queue.ticks = slices.DeleteFunc(queue.ticks, func(t scheduledTick) bool {
-		return t.t <= tick
+		return ready(t.pos, t.t)
 	})
 	maps.DeleteFunc(queue.furthestTicks, func(index scheduledTickIndex, t int64) bool {
-		return t <= tick
+		return ready(index.pos, t)
 	})
 }
 
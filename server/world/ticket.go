@@ -0,0 +1,153 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"time"
+)
+
+// chunkLevel is the level at which a loaded chunk is processed by the World
+// when it is ticked. The level of a chunk is derived every tick from the
+// tickets of the chunk and the chunks around it.
+type chunkLevel uint8
+
+const (
+	// chunkLevelInactive is the level of chunks that are loaded, for example
+	// because a player is viewing them, but that are not ticked at all.
+	chunkLevelInactive chunkLevel = iota
+	// chunkLevelBorder is the level of chunks without a ticket that are
+	// adjacent to a chunk with a ticket. Scheduled and neighbour block updates
+	// are performed in these chunks, but blocks are not ticked randomly and
+	// entities are not ticked, so that block updates crossing the border of
+	// ticking chunks behave the same as within them.
+	chunkLevelBorder
+	// chunkLevelTicking is the level of chunks with a ticket. These chunks
+	// are ticked fully. A chunk holds a player ticket if it is within the
+	// tick range of a Loader, a forced ticket if it was passed to
+	// World.ForceLoad and a spawn ticket if it is within
+	// Config.SpawnChunkRadius of the spawn of the World.
+	chunkLevelTicking
+)
+
+// ForceLoad gives the chunk at the position passed a forced ticket for the
+// duration d, which keeps the chunk loaded and ticking as if a player was
+// close to it, even if no players are in the World. The chunk is loaded or
+// generated if it is not yet loaded. Calling ForceLoad for a chunk that
+// already has a forced ticket replaces its duration. If d is 0 or lower, the
+// forced ticket of the chunk is removed instead.
+// Once a forced ticket expires, the chunk is saved and unloaded after
+// Config.UnloadDelay, unless it has other tickets or is viewed by a player.
+// The duration is measured in ticks of the World, so tickets do not expire
+// while the World is frozen.
+// ForceLoad must not be called from within a transaction of the World.
+func (w *World) ForceLoad(pos ChunkPos, d time.Duration) {
+	if w == nil {
+		return
+	}
+	<-w.Exec(func(tx *Tx) {
+		if d <= 0 {
+			if c, ok := w.chunks[pos]; ok && c.forced != 0 {
+				c.forced, c.unused = 0, time.Now()
+			}
+			return
+		}
+		w.chunk(pos).forced = w.scheduledUpdates.currentTick + int64(max(d/(time.Second/20), 1))
+	})
+}
+
+// forceLoaded checks if any of the chunks loaded in the World has a forced
+// ticket.
+func (w *World) forceLoaded() bool {
+	for _, c := range w.chunks {
+		if c.forced != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// spawnChunk checks if the chunk at the position passed has a spawn ticket
+// for the spawn position passed.
+func (w *World) spawnChunk(pos ChunkPos, spawn cube.Pos) bool {
+	r, centre := w.conf.SpawnChunkRadius, chunkPosFromBlockPos(spawn)
+	return r > 0 && abs(int(pos[0]-centre[0])) <= r && abs(int(pos[1]-centre[1])) <= r
+}
+
+// closeIfUnused closes the chunk at the position passed if it has not been
+// viewed by any players for at least Config.UnloadDelay and has no forced or
+// spawn ticket.
+func (w *World) closeIfUnused(tx *Tx, pos ChunkPos, c *Column, spawn cube.Pos) {
+	if len(c.viewers) > 0 || c.forced != 0 || w.spawnChunk(pos, spawn) || time.Since(c.unused) < w.conf.UnloadDelay {
+		return
+	}
+	w.closeChunk(tx, pos, c)
+}
+
+// chunkLevel returns the chunkLevel of the chunk at the position passed. If
+// the chunk is not loaded, chunkLevelInactive is returned.
+func (w *World) chunkLevel(pos ChunkPos) chunkLevel {
+	if c, ok := w.chunks[pos]; ok {
+		return c.level
+	}
+	return chunkLevelInactive
+}
+
+// updateChunkLevels expires forced tickets, requests the spawn chunks of the
+// World to be loaded and derives the chunkLevel of all chunks loaded from the
+// tickets they and the chunks around them hold. Neighbour updates postponed
+// in chunks that are now ticked are queued again. The chunk that a Loader is
+// in always holds a player ticket, so that players are ticked even if the
+// tick range of the World is 0.
+func (t ticker) updateChunkLevels(tx *Tx, loaders []*Loader, spawn cube.Pos, tick int64) {
+	w := tx.World()
+	if r := int32(w.conf.SpawnChunkRadius); r > 0 {
+		centre := chunkPosFromBlockPos(spawn)
+		for x := centre[0] - r; x <= centre[0]+r; x++ {
+			for z := centre[1] - r; z <= centre[1]+r; z++ {
+				pos := ChunkPos{x, z}
+				if _, ok := w.chunks[pos]; ok {
+					continue
+				}
+				if _, ok := w.failed[pos]; !ok {
+					// The chunk is installed by installFinished once loaded.
+					w.requestChunk(pos)
+				}
+			}
+		}
+	}
+
+	r := int32(max(w.tickRange(), 0))
+	centres := make([]ChunkPos, 0, len(loaders))
+	for _, l := range loaders {
+		centres = append(centres, l.centre())
+	}
+	ticking := make([]ChunkPos, 0, len(w.chunks))
+	for pos, c := range w.chunks {
+		if c.forced != 0 && c.forced <= tick {
+			// The chunk is unloaded by World.closeUnusedChunks once it was
+			// unused for long enough.
+			c.forced, c.unused = 0, time.Now()
+		}
+		c.level = chunkLevelInactive
+		if c.forced != 0 || w.spawnChunk(pos, spawn) || t.anyWithinDistance(pos, centres, r) {
+			ticking = append(ticking, pos)
+		}
+	}
+	for _, pos := range ticking {
+		w.chunks[pos].level = chunkLevelTicking
+	}
+	for _, pos := range ticking {
+		for x := int32(-1); x <= 1; x++ {
+			for z := int32(-1); z <= 1; z++ {
+				if c, ok := w.chunks[ChunkPos{pos[0] + x, pos[1] + z}]; ok && c.level == chunkLevelInactive {
+					c.level = chunkLevelBorder
+				}
+			}
+		}
+	}
+	for _, c := range w.chunks {
+		if len(c.neighbourUpdates) > 0 && c.level >= chunkLevelBorder {
+			w.neighbourUpdates = append(w.neighbourUpdates, c.neighbourUpdates...)
+			c.neighbourUpdates = nil
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package world_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// storeRecorder is a world.Provider that records the positions of the
+// columns stored to it.
+type storeRecorder struct {
+	world.NopProvider
+	stored chan world.ChunkPos
+}
+
+func (p storeRecorder) StoreColumn(pos world.ChunkPos, _ world.Dimension, _ *chunk.Column) error {
+	p.stored <- pos
+	return nil
+}
+
+func TestForceLoadExpiresAndSavesOnUnload(t *testing.T) {
+	p := storeRecorder{stored: make(chan world.ChunkPos, 16)}
+	w := world.Config{Provider: p, UnloadDelay: -1}.New()
+	defer w.Close()
+
+	pos := world.ChunkPos{4, 4}
+	w.ForceLoad(pos, time.Second/20)
+	<-w.Exec(func(tx *world.Tx) {
+		tx.SetBlock(cube.Pos{64, 0, 64}, block.Stone{}, nil)
+	})
+
+	select {
+	case stored := <-p.stored:
+		if stored != pos {
+			t.Fatalf("expected chunk %v to be stored, got %v", pos, stored)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("chunk %v was not stored after its forced ticket expired", pos)
+	}
+}
+
+func TestForceLoadRemovedDoesNotSaveUnmodified(t *testing.T) {
+	p := storeRecorder{stored: make(chan world.ChunkPos, 16)}
+	w := world.Config{Provider: p, UnloadDelay: -1}.New()
+	defer w.Close()
+
+	w.ForceLoad(world.ChunkPos{4, 4}, time.Minute)
+	w.ForceLoad(world.ChunkPos{4, 4}, 0)
+
+	select {
+	case stored := <-p.stored:
+		t.Fatalf("expected unmodified chunk not to be stored, got %v", stored)
+	case <-time.After(2 * time.Second):
+	}
+}
+
+func TestEntitiesTickedInLoaderChunkWithTickRangeZero(t *testing.T) {
+	w := world.Config{Entities: entity.DefaultRegistry}.New()
+	defer w.Close()
+	w.SetTickRange(0)
+
+	l := world.NewLoader(1, w, world.NopViewer{})
+	start := mgl64.Vec3{8, 100, 8}
+	<-w.Exec(func(tx *world.Tx) {
+		l.Move(tx, start)
+		tx.AddEntity(entity.NewItem(world.EntitySpawnOpts{Position: start}, item.NewStack(block.Stone{}, 1)))
+	})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		var moved bool
+		<-w.Exec(func(tx *world.Tx) {
+			l.Load(tx, 9)
+			for e := range tx.Entities() {
+				moved = e.Position()[1] < start[1]
+			}
+		})
+		if moved {
+			return
+		}
+		time.Sleep(time.Second / 20)
+	}
+	t.Fatalf("expected item in the chunk of a loader to be ticked with a tick range of 0")
+}
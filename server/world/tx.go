@@ -4,6 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/go-gl/mathgl/mgl64"
 	"iter"
+	"log/slog"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -15,6 +16,11 @@ import (
 type Tx struct {
 	w      *World
 	closed bool
+
+	// ctx is the operation currently performed by the Tx, which is added to
+	// records logged using the Logger returned by Log.
+	ctx txContext
+	log *slog.Logger
 }
 
 // Range returns the lower and upper bounds of the World that the Tx is
@@ -48,6 +54,13 @@ func (tx *Tx) Block(pos cube.Pos) Block {
 	return tx.World().block(pos)
 }
 
+// LoadedBlock reads a block from the position passed if the chunk at that
+// position is currently loaded. Unlike Block, LoadedBlock never loads or
+// generates a chunk: False is returned if the chunk is not loaded.
+func (tx *Tx) LoadedBlock(pos cube.Pos) (Block, bool) {
+	return tx.World().loadedBlock(pos)
+}
+
 // Liquid attempts to return a Liquid block at the position passed. This
 // Liquid may be in the foreground or in any other layer. If found, the Liquid
 // is returned. If not, the bool returned is false.
@@ -93,10 +106,21 @@ func (tx *Tx) HighestLightBlocker(x, z int) int {
 }
 
 // HighestBlock looks up the highest non-air block in the World at a specific x
-// and z. The y value of the highest block is returned, or 0 if no blocks were
-// present in the column.
-func (tx *Tx) HighestBlock(x, z int) int {
-	return tx.World().highestBlock(x, z)
+// and z. The y value of the highest block is returned along with the block
+// itself, or the minimum height of the World and air if no blocks were present
+// in the column. The chunk is loaded or generated if it was not yet loaded.
+func (tx *Tx) HighestBlock(x, z int) (int, Block) {
+	y := tx.World().highestBlock(x, z)
+	return y, tx.World().block(cube.Pos{x, y, z})
+}
+
+// HighestMotionBlocking looks up the highest block in the World at a specific
+// x and z that blocks motion, such as solid blocks and liquids. The y value of
+// the block is returned, or the minimum height of the World if no such blocks
+// were present in the column. The chunk is loaded or generated if it was not
+// yet loaded.
+func (tx *Tx) HighestMotionBlocking(x, z int) int {
+	return tx.World().highestMotionBlocking(x, z)
 }
 
 // Light returns the light level at the position passed. This is the highest of
@@ -115,9 +139,10 @@ func (tx *Tx) SkyLight(pos cube.Pos) uint8 {
 	return tx.World().skyLight(pos)
 }
 
-// SetBiome sets the Biome at the position passed. If a chunk is not yet loaded
-// at that position, the chunk is first loaded or generated if it could not be
-// found in the world save.
+// SetBiome sets the Biome at the position passed. Biomes are stored for every
+// block, so the Biome of blocks above or below pos is not changed. If a chunk
+// is not yet loaded at that position, the chunk is first loaded or generated
+// if it could not be found in the world save.
 func (tx *Tx) SetBiome(pos cube.Pos, b Biome) {
 	tx.World().setBiome(pos, b)
 }
@@ -177,6 +202,13 @@ func (tx *Tx) PlaySound(pos mgl64.Vec3, s Sound) {
 	tx.World().playSound(tx, pos, s)
 }
 
+// EmitGameEvent emits a GameEvent at the position passed, which is perceived
+// by GameEventListener blocks and EntityGameEventListener entities within
+// range of it. src is the entity that caused the game event and may be nil.
+func (tx *Tx) EmitGameEvent(pos mgl64.Vec3, ev GameEvent, src Entity) {
+	tx.World().emitGameEvent(tx, pos, ev, src)
+}
+
 // AddEntity adds an EntityHandle to a World. The Entity will be visible to all
 // viewers of the World that have the chunk at the EntityHandle's position. If
 // the chunk that the EntityHandle is in is not yet loaded, it will first be
@@ -194,12 +226,69 @@ func (tx *Tx) RemoveEntity(e Entity) *EntityHandle {
 	return tx.World().removeEntity(e, tx)
 }
 
+// Mount makes the rider passed start riding the vehicle passed, dismounting it
+// from any vehicle it was riding before. Riders are moved along with their
+// vehicles until they dismount, and a rider may itself be ridden by other
+// entities. False is returned if either entity is not in the World, if all
+// seats of the vehicle are taken or if the vehicle is riding the rider.
+func (tx *Tx) Mount(rider, vehicle Entity) bool {
+	return tx.World().mount(tx, rider, vehicle)
+}
+
+// Dismount makes the rider passed stop riding its vehicle. Dismount has no
+// effect if the rider is not riding any entity. Entities are dismounted
+// automatically when they or their vehicle leave the World.
+func (tx *Tx) Dismount(rider Entity) {
+	tx.World().dismount(tx, rider.H())
+}
+
+// Vehicle returns the entity that the Entity passed is riding. False is
+// returned if the Entity is not riding any entity.
+func (tx *Tx) Vehicle(e Entity) (Entity, bool) {
+	if v := e.H().vehicle; v != nil {
+		return v.mustEntity(tx), true
+	}
+	return nil, false
+}
+
+// Passengers returns the entities riding the Entity passed, ordered by their
+// seats. The first passenger, if any, is the driver of the Entity.
+func (tx *Tx) Passengers(e Entity) []Entity {
+	passengers := make([]Entity, 0, len(e.H().passengers))
+	for _, p := range e.H().passengers {
+		passengers = append(passengers, p.mustEntity(tx))
+	}
+	return passengers
+}
+
 // EntitiesWithin returns an iterator that yields all entities contained within
 // the cube.BBox passed.
 func (tx *Tx) EntitiesWithin(box cube.BBox) iter.Seq[Entity] {
 	return tx.World().entitiesWithin(tx, box)
 }
 
+// BlockEntitiesWithin returns an iterator that yields the positions of all
+// blocks with block entity data, such as chests and signs, in the box spanned
+// by the two corners passed, along with the blocks themselves. Only chunks that
+// are currently loaded are searched.
+func (tx *Tx) BlockEntitiesWithin(a, b cube.Pos) iter.Seq2[cube.Pos, Block] {
+	return tx.World().blockEntitiesWithin(a, b)
+}
+
+// NearestEntity returns the entity closest to the position passed that is at
+// most maxDist blocks away from it and for which filter returns true. If
+// filter is nil, any entity is accepted. False is returned if no such entity
+// exists. Unlike iterating over EntitiesWithin, NearestEntity only checks the
+// entities close to the position, which makes it suitable for frequent
+// queries such as targeting by mobs.
+// Entities are indexed by their position after being ticked, after every
+// EntityHandle.ExecWorld transaction and at the start of every world tick. An
+// entity teleported by another entity may therefore be found at its old
+// position until the next tick.
+func (tx *Tx) NearestEntity(pos mgl64.Vec3, maxDist float64, filter func(Entity) bool) (Entity, bool) {
+	return tx.World().nearestEntity(tx, pos, maxDist, filter)
+}
+
 // Entities returns an iterator that yields all entities in the World.
 func (tx *Tx) Entities() iter.Seq[Entity] {
 	return tx.World().allEntities(tx)
@@ -215,6 +304,23 @@ func (tx *Tx) Viewers(pos mgl64.Vec3) []Viewer {
 	return tx.World().viewersOf(pos)
 }
 
+// Village returns the village that the position passed is in. A village is a
+// cluster of VillagePoint blocks, such as beds and job site blocks, and
+// covers the area around those blocks. If the position is in multiple
+// villages, the village with its centre closest to the position is returned.
+// Only loaded chunks are searched for village points. False is returned if
+// the position is not in any village.
+func (tx *Tx) Village(pos cube.Pos) (Village, bool) {
+	return tx.World().village(pos)
+}
+
+// Villages returns all villages with at least one village point within the
+// radius passed around pos. Only loaded chunks are searched for village
+// points.
+func (tx *Tx) Villages(pos cube.Pos, radius int) []Village {
+	return tx.World().villages(pos, radius)
+}
+
 // World returns the World of the Tx. It panics if the transaction was already
 // marked complete.
 func (tx *Tx) World() *World {
@@ -226,6 +332,7 @@ func (tx *Tx) World() *World {
 
 // close finishes the Tx, causing any following call on the Tx to panic.
 func (tx *Tx) close() {
+	tx.w.flushBlockUpdates()
 	tx.closed = true
 }
 
@@ -2,7 +2,6 @@ package world
 
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
-	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
 	"time"
@@ -29,13 +28,20 @@ type Viewer interface {
 	// ViewEntityTeleport views the teleportation of an Entity. The Entity is immediately moved to a different
 	// target position.
 	ViewEntityTeleport(e Entity, pos mgl64.Vec3)
+	// ViewEntityMount views an Entity starting to ride another Entity, or
+	// changing seats on it. offset is the offset from the position of the
+	// vehicle at which the rider sits, and driver specifies if the rider is in
+	// control of the vehicle.
+	ViewEntityMount(rider, vehicle Entity, offset mgl64.Vec3, driver bool)
+	// ViewEntityDismount views an Entity that stops riding another Entity.
+	ViewEntityDismount(rider, vehicle Entity)
 	// ViewFurnaceUpdate updates a furnace for the associated session based on previous times.
 	ViewFurnaceUpdate(prevCookTime, cookTime, prevRemainingFuelTime, remainingFuelTime, prevMaxFuelTime, maxFuelTime time.Duration)
 	// ViewBrewingUpdate updates a brewing stand for the associated session based on previous times.
 	ViewBrewingUpdate(prevBrewTime, brewTime time.Duration, prevFuelAmount, fuelAmount, prevFuelTotal, fuelTotal int32)
-	// ViewChunk views the chunk passed at a particular position. It is called for every chunk loaded using
-	// the world.Loader.
-	ViewChunk(pos ChunkPos, dim Dimension, blockEntities map[cube.Pos]Block, c *chunk.Chunk)
+	// ViewChunk views the Column passed at a particular position. It is called for every chunk loaded using
+	// the world.Loader. The Column may only be used until ViewChunk returns.
+	ViewChunk(pos ChunkPos, dim Dimension, col *Column)
 	// ViewTime views the time of the world. It is called every time the time is changed or otherwise every
 	// second.
 	ViewTime(t int)
@@ -72,6 +78,19 @@ type Viewer interface {
 	ViewWeather(raining, thunder bool)
 }
 
+// BlockUpdatesViewer is a Viewer that is able to view the blocks changed in a
+// chunk during a transaction at once. Viewers that do not implement
+// BlockUpdatesViewer have ViewBlockUpdate called for every block changed
+// instead.
+type BlockUpdatesViewer interface {
+	Viewer
+	// ViewBlockUpdates views the updating of multiple blocks in the chunk at
+	// the position passed. Blocks changed in a chunk during a transaction are
+	// passed to ViewBlockUpdates once at the end of the transaction, with
+	// every position and layer included at most once.
+	ViewBlockUpdates(pos ChunkPos, updates []BlockUpdate)
+}
+
 // NopViewer is a Viewer implementation that does not implement any behaviour. It may be embedded by other structs to
 // prevent having to implement all of Viewer's methods.
 type NopViewer struct{}
@@ -85,7 +104,9 @@ func (NopViewer) ViewEntityGameMode(Entity)
 func (NopViewer) ViewEntityMovement(Entity, mgl64.Vec3, cube.Rotation, bool)                 {}
 func (NopViewer) ViewEntityVelocity(Entity, mgl64.Vec3)                                      {}
 func (NopViewer) ViewEntityTeleport(Entity, mgl64.Vec3)                                      {}
-func (NopViewer) ViewChunk(ChunkPos, Dimension, map[cube.Pos]Block, *chunk.Chunk)            {}
+func (NopViewer) ViewEntityMount(Entity, Entity, mgl64.Vec3, bool)                           {}
+func (NopViewer) ViewEntityDismount(Entity, Entity)                                          {}
+func (NopViewer) ViewChunk(ChunkPos, Dimension, *Column)                                     {}
 func (NopViewer) ViewTime(int)                                                               {}
 func (NopViewer) ViewEntityItems(Entity)                                                     {}
 func (NopViewer) ViewEntityArmour(Entity)                                                    {}
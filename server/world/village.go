@@ -0,0 +1,172 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// VillagePoint is a Block that marks a point of interest of a village, such
+// as a bed, a bell or the job site block of a villager. Villages are formed by
+// clusters of these blocks.
+type VillagePoint interface {
+	Block
+	// VillagePoint checks if the block, in its current state, is a point of
+	// interest of a village. Blocks that span multiple positions, such as
+	// beds, return true for only one of those positions.
+	VillagePoint() bool
+}
+
+const (
+	// villageLinkDistance is the maximum distance between two village points
+	// for them to be part of the same village.
+	villageLinkDistance = 32
+	// villageRadius is the distance around the village points of a village
+	// that is still considered part of the village.
+	villageRadius = 32
+	// villageSearchRadius is the distance around a position within which
+	// village points are searched to find the village it is in.
+	villageSearchRadius = 96
+)
+
+// Village is a cluster of village points in a World. Villages are not stored
+// and are instead found on demand from the blocks in the World, so a village
+// grows or disappears as its beds and job site blocks are placed and broken.
+type Village struct {
+	// Points holds the positions of the village points that make up the
+	// village.
+	Points []cube.Pos
+}
+
+// Centre returns the centre of the village, which is the average position of
+// its village points.
+func (v Village) Centre() cube.Pos {
+	var sum mgl64.Vec3
+	for _, pos := range v.Points {
+		sum = sum.Add(pos.Vec3())
+	}
+	return cube.PosFromVec3(sum.Mul(1 / float64(len(v.Points))))
+}
+
+// Bounds returns the area covered by the village: The box surrounding all of
+// its village points, grown by the radius around every point that is still
+// part of the village.
+func (v Village) Bounds() cube.BBox {
+	minPos, maxPos := v.Points[0], v.Points[0]
+	for _, pos := range v.Points[1:] {
+		for i := range 3 {
+			minPos[i], maxPos[i] = min(minPos[i], pos[i]), max(maxPos[i], pos[i])
+		}
+	}
+	return cube.Box(float64(minPos[0]), float64(minPos[1]), float64(minPos[2]), float64(maxPos[0]+1), float64(maxPos[1]+1), float64(maxPos[2]+1)).Grow(villageRadius)
+}
+
+// Contains checks if the position passed is part of the village.
+func (v Village) Contains(pos mgl64.Vec3) bool {
+	return v.Bounds().Vec3Within(pos)
+}
+
+// villages returns all villages with at least one village point within the
+// radius passed around pos. Only chunks that are currently loaded are
+// searched, so villages partially in unloaded chunks may be returned without
+// the village points in those chunks.
+func (w *World) villages(pos cube.Pos, radius int) []Village {
+	var points []cube.Pos
+	minChunk, maxChunk := chunkPosFromBlockPos(pos.Sub(cube.Pos{radius, 0, radius})), chunkPosFromBlockPos(pos.Add(cube.Pos{radius, 0, radius}))
+	for x := minChunk[0]; x <= maxChunk[0]; x++ {
+		for z := minChunk[1]; z <= maxChunk[1]; z++ {
+			if c, ok := w.chunks[ChunkPos{x, z}]; ok {
+				points = appendVillagePoints(points, ChunkPos{x, z}, c, w.Range())
+			}
+		}
+	}
+	// Village points are linked to all other points within the link distance
+	// and grouped into villages using a flood fill.
+	var villages []Village
+	visited := make([]bool, len(points))
+	for i := range points {
+		if visited[i] {
+			continue
+		}
+		visited[i] = true
+		v, queue := Village{}, []int{i}
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			v.Points = append(v.Points, points[n])
+			for j, other := range points {
+				if !visited[j] && other.Vec3().Sub(points[n].Vec3()).Len() <= villageLinkDistance {
+					visited[j] = true
+					queue = append(queue, j)
+				}
+			}
+		}
+		if villageWithin(v, pos, radius) {
+			villages = append(villages, v)
+		}
+	}
+	return villages
+}
+
+// village returns the village that the position passed is in. If the
+// position is in multiple villages, the village with its centre closest to
+// the position is returned.
+func (w *World) village(pos cube.Pos) (Village, bool) {
+	var (
+		closest Village
+		dist    = math.MaxFloat64
+	)
+	for _, v := range w.villages(pos, villageSearchRadius) {
+		if !v.Contains(pos.Vec3Centre()) {
+			continue
+		}
+		if d := v.Centre().Vec3().Sub(pos.Vec3()).Len(); d < dist {
+			closest, dist = v, d
+		}
+	}
+	return closest, dist != math.MaxFloat64
+}
+
+// villageWithin checks if any of the village points of a village are within
+// the radius passed around pos.
+func villageWithin(v Village, pos cube.Pos, radius int) bool {
+	for _, p := range v.Points {
+		if p.Vec3().Sub(pos.Vec3()).Len() <= float64(radius) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendVillagePoints appends the positions of all village points in the
+// chunk passed to points. Sub chunks of which the palette holds no village
+// points are skipped without checking any of their blocks.
+func appendVillagePoints(points []cube.Pos, pos ChunkPos, c *Column, r cube.Range) []cube.Pos {
+	for i, sub := range c.Sub() {
+		if sub.Empty() {
+			continue
+		}
+		storage := sub.Layer(0)
+		palette, found := storage.Palette(), false
+		for n := range palette.Len() {
+			if villagePointBlocks[palette.Value(uint16(n))] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+		baseY := (i + (r.Min() >> 4)) << 4
+		for x := byte(0); x < 16; x++ {
+			for y := byte(0); y < 16; y++ {
+				for z := byte(0); z < 16; z++ {
+					if villagePointBlocks[storage.At(x, y, z)] {
+						points = append(points, cube.Pos{int(pos[0]<<4) + int(x), baseY + int(y), int(pos[1]<<4) + int(z)})
+					}
+				}
+			}
+		}
+	}
+	return points
+}
@@ -192,13 +192,18 @@ func (w weather) strikeLightning(tx *Tx, c ChunkPos) {
 }
 
 // lightningPosition finds a random position in the ChunkPos to strike
-// lightning and adjusts the position to any of the living entities found in or
-// above the position if any are found.
+// lightning. If a lightning rod is close to the position, the lightning strikes
+// the rod instead. Otherwise, the position is adjusted to any of the living
+// entities found in or above the position if any are found.
 func (w weather) lightningPosition(tx *Tx, c ChunkPos) mgl64.Vec3 {
 	v := w.w.r.Int32()
 	x, z := float64(c[0]<<4+(v&0xf)), float64(c[1]<<4+((v>>8)&0xf))
 
-	vec := w.adjustPositionToEntities(tx, mgl64.Vec3{x, float64(tx.HighestBlock(int(x), int(z)) + 1), z})
+	y, _ := tx.HighestBlock(int(x), int(z))
+	if rod, ok := w.lightningRod(tx, cube.Pos{int(x), y, int(z)}); ok {
+		return rod.Side(cube.FaceUp).Vec3Middle()
+	}
+	vec := w.adjustPositionToEntities(tx, mgl64.Vec3{x, float64(y + 1), z})
 	if pos := cube.PosFromVec3(vec); len(tx.Block(pos).Model().BBox(pos, tx)) != 0 {
 		// If lightning is about to strike inside a block that is not fully
 		// transparent. In this case, move the lightning up by one block so that
@@ -208,6 +213,52 @@ func (w weather) lightningPosition(tx *Tx, c ChunkPos) mgl64.Vec3 {
 	return vec
 }
 
+// lightningRodRadius is the radius in blocks around a lightning strike within
+// which lightning rods redirect the lightning to themselves.
+const lightningRodRadius = 64
+
+// lightningAttractor is a Block that lightning striking close to it is
+// redirected to, such as a lightning rod.
+type lightningAttractor interface {
+	// AttractsLightning checks if the block currently redirects lightning
+	// strikes to itself.
+	AttractsLightning() bool
+}
+
+// lightningRod finds the lightning attracting block closest to the position
+// passed within lightningRodRadius. Only blocks that are the highest block in
+// their column, and chunks that are already loaded, are considered. False is
+// returned if no such block was found.
+func (w weather) lightningRod(tx *Tx, pos cube.Pos) (cube.Pos, bool) {
+	minPos := chunkPosFromBlockPos(pos.Sub(cube.Pos{lightningRodRadius, 0, lightningRodRadius}))
+	maxPos := chunkPosFromBlockPos(pos.Add(cube.Pos{lightningRodRadius, 0, lightningRodRadius}))
+
+	var (
+		rod     cube.Pos
+		closest = lightningRodRadius*lightningRodRadius + 1
+	)
+	for cx := minPos[0]; cx <= maxPos[0]; cx++ {
+		for cz := minPos[1]; cz <= maxPos[1]; cz++ {
+			col, ok := w.w.chunks[ChunkPos{cx, cz}]
+			if !ok {
+				continue
+			}
+			for x := uint8(0); x < 16; x++ {
+				for z := uint8(0); z < 16; z++ {
+					candidate := cube.Pos{int(cx)<<4 + int(x), int(col.HighestBlock(x, z)), int(cz)<<4 + int(z)}
+					d := candidate.Sub(pos)
+					if dist := d[0]*d[0] + d[1]*d[1] + d[2]*d[2]; dist < closest {
+						if a, ok := tx.Block(candidate).(lightningAttractor); ok && a.AttractsLightning() {
+							rod, closest = candidate, dist
+						}
+					}
+				}
+			}
+		}
+	}
+	return rod, closest <= lightningRodRadius*lightningRodRadius
+}
+
 // adjustPositionToEntities adjusts the mgl64.Vec3 passed to the position of
 // any Entity found in the 3x3 column upwards from the mgl64.Vec3. If multiple
 // entities are found, the position of one of the entities is selected
@@ -222,7 +273,7 @@ func (w weather) adjustPositionToEntities(tx *Tx, vec mgl64.Vec3) mgl64.Vec3 {
 			// block at its position is eligible to be struck by lightning. We
 			// first save all entity positions where this is the case.
 			pos := cube.PosFromVec3(e.Position())
-			if tx.HighestBlock(pos[0], pos[1]) < pos[2] {
+			if y, _ := tx.HighestBlock(pos[0], pos[2]); y < pos[1] {
 				list = append(list, e.Position())
 			}
 		}
@@ -0,0 +1,38 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+func TestLightningRedirectedToRod(t *testing.T) {
+	w := Config{}.New()
+	defer w.Close()
+
+	rod, _ := BlockByName("minecraft:lightning_rod", map[string]any{"facing_direction": int32(cube.FaceUp)})
+	stone, _ := BlockByName("minecraft:stone", nil)
+	near, covered, far := cube.Pos{8, -60, 8}, cube.Pos{4, -62, 4}, cube.Pos{80, -60, 8}
+
+	<-w.Exec(func(tx *Tx) {
+		tx.SetBlock(near, rod, nil)
+		tx.SetBlock(covered, rod, nil)
+		tx.SetBlock(covered.Side(cube.FaceUp), stone, nil)
+		tx.SetBlock(far, rod, nil)
+		// Every strike in the chunk of the rods is redirected to the only rod
+		// in range that is not covered by another block.
+		for range 20 {
+			if pos := w.lightningPosition(tx, ChunkPos{}); pos != near.Side(cube.FaceUp).Vec3Middle() {
+				t.Errorf("expected lightning to strike the top of the rod at %v, struck %v", near, pos)
+				return
+			}
+		}
+		tx.SetBlock(near, nil, nil)
+		for range 20 {
+			if pos := w.lightningPosition(tx, ChunkPos{}); pos == covered.Side(cube.FaceUp).Vec3Middle() || pos == far.Side(cube.FaceUp).Vec3Middle() {
+				t.Errorf("expected lightning not to be redirected to a covered rod or a rod out of range, struck %v", pos)
+				return
+			}
+		}
+	})
+}
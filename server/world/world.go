@@ -1,18 +1,18 @@
 package world
 
 import (
+	"cmp"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/event"
-	"github.com/df-mc/dragonfly/server/internal/sliceutil"
 	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/df-mc/goleveldb/leveldb"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
 	"iter"
-	"maps"
+	"math"
 	"math/rand/v2"
 	"slices"
 	"sync"
@@ -36,6 +36,11 @@ type World struct {
 	// advance is a bool that specifies if this World should advance the current
 	// tick, time and weather saved in the Settings struct held by the World.
 	advance bool
+	// frozen is true if the World was frozen using World.Freeze. steps holds
+	// the amount of ticks that are still to be run while the World is frozen,
+	// added using World.Step.
+	frozen atomic.Bool
+	steps  atomic.Int64
 
 	o sync.Once
 
@@ -55,6 +60,15 @@ type World struct {
 	// that the Entity was in. These are tracked so that a call to RemoveEntity
 	// can find the correct Entity.
 	entities map[*EntityHandle]ChunkPos
+	// entityIndex is a spatial index of all entities in entities, used to
+	// quickly find the entities closest to a position.
+	entityIndex *entityIndex
+	// profiler records the time spent ticking chunks if Config.Profiling is
+	// true. It is nil otherwise.
+	profiler *profiler
+	// metrics records the duration of ticks if Config.Metrics is true. It is
+	// nil otherwise.
+	metrics *tickRecorder
 
 	r *rand.Rand
 
@@ -67,6 +81,33 @@ type World struct {
 
 	viewerMu sync.Mutex
 	viewers  map[*Loader]Viewer
+
+	events *EventBus
+
+	// io and gen are the worker pools that chunks are loaded, stored and
+	// generated on. loading holds the chunks that are currently being loaded
+	// by these workers, and storing the chunks that were closed and are still
+	// being stored.
+	io, gen *workerPool
+	loading map[ChunkPos]*chunkRequest
+	// updated holds the positions of the chunks that have block updates
+	// queued, which are sent to viewers at the end of a transaction.
+	updated   []ChunkPos
+	storingMu sync.Mutex
+	storing   map[ChunkPos]chan struct{}
+	// cache holds recently closed chunks, so that they do not have to be
+	// loaded from the Provider again if they are used again shortly after.
+	cache *columnCache
+	// failed holds empty chunks shown in place of chunks that could not be
+	// loaded.
+	failed map[ChunkPos]*Column
+	// finished holds the chunk requests finished by the workers that have not
+	// yet been installed in the World.
+	finishedMu sync.Mutex
+	finished   []finishedChunk
+	// closed is set to true once all chunks were saved while closing the
+	// World. Chunks loaded after that are no longer added to it.
+	closed bool
 }
 
 // transaction is a type that may be added to the transaction queue of a World.
@@ -94,6 +135,54 @@ func (w *World) Name() string {
 	return w.set.Name
 }
 
+// ChunkProfile returns the time spent ticking each chunk of the World over the
+// last sampling window of 100 ticks, ordered from the most to the least
+// expensive chunk. Only chunks that were ticked during the window are
+// included. ChunkProfile returns nil if Config.Profiling was not set to true.
+// ChunkProfile may be called from any goroutine.
+func (w *World) ChunkProfile() []ChunkTiming {
+	return w.profiler.profile()
+}
+
+// TickMetrics returns metrics of the World over its last 100 ticks, such as
+// the amount of ticks it ran per second, along with the amount of chunks and
+// entities in it and the amount of transactions waiting to be run. The
+// metrics are updated once every second. TickMetrics returns a zero
+// TickMetrics if Config.Metrics was not set to true. TickMetrics may be
+// called from any goroutine.
+func (w *World) TickMetrics() TickMetrics {
+	if w == nil {
+		return TickMetrics{}
+	}
+	m, ok := w.metrics.metrics()
+	if ok {
+		m.QueueDepth = len(w.queue)
+	}
+	return m
+}
+
+// ColumnCacheStats returns statistics of the cache of recently unloaded chunks
+// of the World, such as the amount of chunks loaded from it. All values are 0
+// if Config.ColumnCacheSize was not set. ColumnCacheStats may be called from
+// any goroutine.
+func (w *World) ColumnCacheStats() ColumnCacheStats {
+	if w == nil {
+		return ColumnCacheStats{}
+	}
+	return w.cache.stats()
+}
+
+// Seed returns the seed of the World, as found in its Settings. The World does
+// not use the seed itself, but it determines properties of the terrain of
+// worlds generated using it. The seed is saved along with the Settings, so it
+// remains the same when the World is loaded again. GenerationRand and
+// PositionRand may be used to derive random values from it deterministically.
+func (w *World) Seed() int64 {
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.Seed
+}
+
 // Dimension returns the Dimension assigned to the World in world.New. The sky
 // colour and behaviour of a variety of world features differ based on the
 // Dimension.
@@ -101,12 +190,29 @@ func (w *World) Dimension() Dimension {
 	return w.conf.Dim
 }
 
+// DespawnPolicy returns the DespawnPolicy of the World, which decides when
+// mobs far away from all players despawn.
+func (w *World) DespawnPolicy() DespawnPolicy {
+	return w.conf.Despawn
+}
+
 // Range returns the range in blocks of the World (min and max). It is
 // equivalent to calling World.Dimension().Range().
 func (w *World) Range() cube.Range {
 	return w.ra
 }
 
+// Events returns the EventBus of the World, which may be used to subscribe to
+// changes in the World, such as blocks being changed or entities spawning,
+// using Subscribe. Subscriptions to the EventBus are closed when the World is
+// closed.
+func (w *World) Events() *EventBus {
+	if w == nil {
+		return nil
+	}
+	return w.events
+}
+
 // ExecFunc is a function that performs a synchronised transaction on a World.
 type ExecFunc func(tx *Tx)
 
@@ -144,6 +250,34 @@ func (w *World) EntityRegistry() EntityRegistry {
 	return w.conf.Entities
 }
 
+// Structure loads the NBT data of a structure saved in the World under the
+// name passed, such as by a structure block. False is returned if no such
+// structure exists or if the Provider of the World does not implement
+// StructureProvider.
+func (w *World) Structure(name string) (map[string]any, bool) {
+	p, ok := w.conf.Provider.(StructureProvider)
+	if !ok {
+		return nil, false
+	}
+	data, ok, err := p.LoadStructure(name)
+	if err != nil {
+		w.conf.Log.Error("load structure: "+err.Error(), "name", name)
+		return nil, false
+	}
+	return data, ok
+}
+
+// SaveStructure saves the NBT data of a structure in the World under the name
+// passed. An error is returned if the Provider of the World does not
+// implement StructureProvider or if the structure could not be stored.
+func (w *World) SaveStructure(name string, data map[string]any) error {
+	p, ok := w.conf.Provider.(StructureProvider)
+	if !ok {
+		return fmt.Errorf("save structure: provider %T cannot store structures", w.conf.Provider)
+	}
+	return p.StoreStructure(name, data)
+}
+
 // block reads a block from the position passed. If a chunk is not yet loaded
 // at that position, the chunk is loaded, or generated if it could not be found
 // in the world save, and the block returned.
@@ -151,6 +285,16 @@ func (w *World) block(pos cube.Pos) Block {
 	return w.blockInChunk(w.chunk(chunkPosFromBlockPos(pos)), pos)
 }
 
+// loadedBlock reads a block from the position passed if the chunk at that
+// position is currently loaded. False is returned if it is not.
+func (w *World) loadedBlock(pos cube.Pos) (Block, bool) {
+	c, ok := w.chunks[chunkPosFromBlockPos(pos)]
+	if !ok {
+		return nil, false
+	}
+	return w.blockInChunk(c, pos), true
+}
+
 // blockInChunk reads a block from a chunk at the position passed. The block
 // is assumed to be within the chunk passed.
 func (w *World) blockInChunk(c *Column, pos cube.Pos) Block {
@@ -167,10 +311,9 @@ func (w *World) blockInChunk(c *Column, pos cube.Pos) Block {
 		// Despite being a block with NBT, the block didn't actually have any
 		// stored NBT yet. We add it here and update the block.
 		nbtB := blockByRuntimeIDOrAir(rid).(NBTer).DecodeNBT(map[string]any{}).(Block)
-		c.BlockEntities[pos] = nbtB
-		for _, v := range c.viewers {
-			v.ViewBlockUpdate(pos, nbtB, 0)
-		}
+		c.setBlockEntity(pos, nbtB)
+		c.changed()
+		w.queueBlockUpdate(c, pos, 0)
 		return nbtB
 	}
 	return blockByRuntimeIDOrAir(rid)
@@ -178,18 +321,21 @@ func (w *World) blockInChunk(c *Column, pos cube.Pos) Block {
 
 // biome reads the Biome at the position passed. If a chunk is not yet loaded
 // at that position, the chunk is loaded, or generated if it could not be found
-// in the world save, and the Biome returned.
+// in the world save, and the Biome returned. The default Biome of the World is
+// returned for biomes that are not registered.
 func (w *World) biome(pos cube.Pos) Biome {
 	if pos.OutOfBounds(w.Range()) {
 		// Fast way out.
-		return ocean()
+		return w.defaultBiome()
 	}
-	id := int(w.chunk(chunkPosFromBlockPos(pos)).Biome(uint8(pos[0]), int16(pos[1]), uint8(pos[2])))
-	b, ok := BiomeByID(id)
-	if !ok {
-		w.conf.Log.Error("biome not found by ID", "ID", id)
-	}
-	return b
+	return biomeByIDOr(int(w.chunk(chunkPosFromBlockPos(pos)).Biome(uint8(pos[0]), int16(pos[1]), uint8(pos[2]))), w.defaultBiome())
+}
+
+// defaultBiome returns the Biome of positions in the World outside of its
+// range or with a biome that is not registered. This is the biome that new
+// chunks are filled with before they are generated, which is ocean.
+func (w *World) defaultBiome() Biome {
+	return ocean()
 }
 
 // highestLightBlocker gets the Y value of the highest fully light blocking
@@ -199,12 +345,20 @@ func (w *World) highestLightBlocker(x, z int) int {
 }
 
 // highestBlock looks up the highest non-air block in the World at a specific x
-// and z The y value of the highest block is returned, or 0 if no blocks were
-// present in the column.
+// and z The y value of the highest block is returned, or the minimum height of
+// the World if no blocks were present in the column.
 func (w *World) highestBlock(x, z int) int {
 	return int(w.chunk(ChunkPos{int32(x >> 4), int32(z >> 4)}).HighestBlock(uint8(x), uint8(z)))
 }
 
+// highestMotionBlocking looks up the highest block in the World at a specific
+// x and z that blocks motion, such as solid blocks and liquids. The y value of
+// the block is returned, or the minimum height of the World if no such blocks
+// were present in the column.
+func (w *World) highestMotionBlocking(x, z int) int {
+	return int(w.chunk(ChunkPos{int32(x >> 4), int32(z >> 4)}).HighestMotionBlocking(uint8(x), uint8(z)))
+}
+
 // highestObstructingBlock returns the highest block in the World at a given x
 // and z that has at least a solid top or bottom face.
 func (w *World) highestObstructingBlock(x, z int) int {
@@ -262,21 +416,18 @@ func (w *World) setBlock(pos cube.Pos, b Block, opts *SetOpts) {
 
 	rid := BlockRuntimeID(b)
 
-	var before uint32
-	if rid != airRID && !opts.DisableLiquidDisplacement {
-		before = c.Block(x, y, z, 0)
-	}
+	before := c.Block(x, y, z, 0)
+	lightBefore := lightStateAt(c, pos)
+	publish := w.events.active()
 
-	c.modified = true
+	c.changed()
 	c.SetBlock(x, y, z, 0, rid)
 	if nbtBlocks[rid] {
-		c.BlockEntities[pos] = b
+		c.setBlockEntity(pos, b)
 	} else {
-		delete(c.BlockEntities, pos)
+		c.removeBlockEntity(pos)
 	}
 
-	viewers := slices.Clone(c.viewers)
-
 	if !opts.DisableLiquidDisplacement {
 		var secondLayer Block
 
@@ -301,16 +452,19 @@ func (w *World) setBlock(pos cube.Pos, b Block, opts *SetOpts) {
 		}
 
 		if secondLayer != nil {
-			for _, viewer := range viewers {
-				viewer.ViewBlockUpdate(pos, secondLayer, 1)
-			}
+			w.queueBlockUpdate(c, pos, 1)
 		}
 	}
 
-	for _, viewer := range viewers {
-		viewer.ViewBlockUpdate(pos, b, 0)
+	w.updateLight(c, pos, lightBefore)
+	w.queueBlockUpdate(c, pos, 0)
+	if publish {
+		w.events.publish(BlockChangeEvent{Pos: pos, Before: blockByRuntimeIDOrAir(before), After: b})
 	}
 
+	if before != rid {
+		w.notifyObservers(pos)
+	}
 	if !opts.DisableBlockUpdates {
 		w.doBlockUpdatesAround(pos)
 	}
@@ -325,7 +479,7 @@ func (w *World) setBiome(pos cube.Pos, b Biome) {
 		return
 	}
 	c := w.chunk(chunkPosFromBlockPos(pos))
-	c.modified = true
+	c.changed()
 	c.SetBiome(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), uint32(b.EncodeBiome()))
 }
 
@@ -389,9 +543,9 @@ func (w *World) buildStructure(pos cube.Pos, s Structure) {
 
 								nbtPos := cube.Pos{xOffset, yOffset, zOffset}
 								if nbtBlocks[rid] {
-									c.BlockEntities[nbtPos] = b
+									c.setBlockEntity(nbtPos, b)
 								} else {
-									delete(c.BlockEntities, nbtPos)
+									c.removeBlockEntity(nbtPos)
 								}
 							}
 							if liq != nil {
@@ -403,13 +557,18 @@ func (w *World) buildStructure(pos cube.Pos, s Structure) {
 					}
 				}
 			}
-			c.SetBlock(0, 0, 0, 0, c.Block(0, 0, 0, 0)) // Make sure the heightmap is recalculated.
-			c.modified = true
+			c.InvalidateHeightMaps()
+			c.changed()
+
+			// Light is recalculated for the whole chunk at once, rather than
+			// updated for every block set.
+			chunk.LightArea([]*chunk.Chunk{c.Chunk}, int(chunkPos[0]), int(chunkPos[1])).Fill()
+			w.calculateLight(chunkPos)
 
 			// After setting all blocks of the structure within a single chunk,
 			// we show the new chunk to all viewers once.
 			for _, viewer := range c.viewers {
-				viewer.ViewChunk(chunkPos, w.Dimension(), c.BlockEntities, c.Chunk)
+				viewer.ViewChunk(chunkPos, w.Dimension(), c)
 			}
 		}
 	}
@@ -459,8 +618,11 @@ func (w *World) setLiquid(pos cube.Pos, b Liquid) {
 	}
 	chunkPos := chunkPosFromBlockPos(pos)
 	c := w.chunk(chunkPos)
+	lightBefore := lightStateAt(c, pos)
 	if b == nil {
 		w.removeLiquids(c, pos)
+		c.changed()
+		w.updateLight(c, pos, lightBefore)
 		w.doBlockUpdatesAround(pos)
 		return
 	}
@@ -473,16 +635,13 @@ func (w *World) setLiquid(pos cube.Pos, b Liquid) {
 	rid := BlockRuntimeID(b)
 	if w.removeLiquids(c, pos) {
 		c.SetBlock(x, y, z, 0, rid)
-		for _, v := range c.viewers {
-			v.ViewBlockUpdate(pos, b, 0)
-		}
+		w.queueBlockUpdate(c, pos, 0)
 	} else {
 		c.SetBlock(x, y, z, 1, rid)
-		for _, v := range c.viewers {
-			v.ViewBlockUpdate(pos, b, 1)
-		}
+		w.queueBlockUpdate(c, pos, 1)
 	}
-	c.modified = true
+	c.changed()
+	w.updateLight(c, pos, lightBefore)
 
 	w.doBlockUpdatesAround(pos)
 }
@@ -496,16 +655,12 @@ func (w *World) removeLiquids(c *Column, pos cube.Pos) bool {
 	noneLeft := false
 	if noLeft, changed := w.removeLiquidOnLayer(c.Chunk, x, y, z, 0); noLeft {
 		if changed {
-			for _, v := range c.viewers {
-				v.ViewBlockUpdate(pos, air(), 0)
-			}
+			w.queueBlockUpdate(c, pos, 0)
 		}
 		noneLeft = true
 	}
 	if _, changed := w.removeLiquidOnLayer(c.Chunk, x, y, z, 1); changed {
-		for _, v := range c.viewers {
-			v.ViewBlockUpdate(pos, air(), 1)
-		}
+		w.queueBlockUpdate(c, pos, 1)
 	}
 	return noneLeft
 }
@@ -589,6 +744,39 @@ func (w *World) Time() int {
 	return int(w.set.Time)
 }
 
+// SunAngle returns the angle of the sun in the sky in radians, based on the
+// current time of the world. The angle is 0 at noon, when the sun is straight
+// above, and π at midnight.
+func (w *World) SunAngle() float64 {
+	// The sun moves slightly faster around sunrise and sunset, so that days
+	// are a little longer than nights.
+	t := float64(w.Time())/24000 - 0.25
+	t -= math.Floor(t)
+	return (t*2 + (0.5 - math.Cos(t*math.Pi)/2)) / 3 * math.Pi * 2
+}
+
+// SkyDarkening returns the amount by which the sky light in the world is
+// darkened by the time of day and the weather. It ranges from 0 on a clear day
+// to 11 at night. Dimensions without a day cycle, such as the Nether, have
+// no daylight at all, so 15 is always returned for them.
+func (w *World) SkyDarkening() int {
+	if w == nil || !w.conf.Dim.TimeCycle() {
+		return 15
+	}
+	w.set.Lock()
+	raining, thundering := w.set.Raining, w.set.Raining && w.set.Thundering
+	w.set.Unlock()
+
+	brightness := 0.5 + 2*min(max(math.Cos(w.SunAngle()), -0.25), 0.25)
+	if raining {
+		brightness *= 1 - 5.0/16
+	}
+	if thundering {
+		brightness *= 1 - 5.0/16
+	}
+	return int((1 - brightness) * 11)
+}
+
 // SetTime sets the new time of the world. SetTime will always work, regardless
 // of whether the time is stopped or not.
 func (w *World) SetTime(new int) {
@@ -619,6 +807,50 @@ func (w *World) StartTime() {
 	w.enableTimeCycle(true)
 }
 
+// Freeze freezes the ticking of the World, similar to the /tick freeze
+// command in vanilla. While frozen, the World still runs transactions and
+// loads chunks, but entities, blocks, scheduled updates, time and weather are
+// no longer ticked. Ticks may be run while frozen using World.Step, and the
+// World may be unfrozen using World.Unfreeze.
+func (w *World) Freeze() {
+	if w == nil {
+		return
+	}
+	w.frozen.Store(true)
+}
+
+// Unfreeze unfreezes the World after a call to World.Freeze, so that it
+// continues ticking normally. Ticks that were added using World.Step and
+// were not yet run are discarded.
+func (w *World) Unfreeze() {
+	if w == nil {
+		return
+	}
+	w.frozen.Store(false)
+	w.steps.Store(0)
+}
+
+// Frozen checks if the World is currently frozen using World.Freeze.
+func (w *World) Frozen() bool {
+	if w == nil {
+		return false
+	}
+	return w.frozen.Load()
+}
+
+// Step runs n ticks of a frozen World, similar to the /tick step command in
+// vanilla. The ticks are run at the normal tick rate of the World, after which
+// the World remains frozen. Every tick run updates all entities, blocks,
+// scheduled updates, time and weather, exactly like a tick of a World that
+// is not frozen. Step has no effect if the World is not frozen or if n is not
+// positive.
+func (w *World) Step(n int) {
+	if w == nil || n <= 0 || !w.frozen.Load() {
+		return
+	}
+	w.steps.Add(int64(n))
+}
+
 // enableTimeCycle enables or disables the time cycling of the World.
 func (w *World) enableTimeCycle(v bool) {
 	if w == nil {
@@ -662,6 +894,16 @@ func (w *World) playSound(tx *Tx, pos mgl64.Vec3, s Sound) {
 	}
 }
 
+// entityChanged marks the chunk that the EntityHandle passed is in as
+// modified, so that the entity is saved along with it.
+func (w *World) entityChanged(handle *EntityHandle) {
+	if pos, ok := w.entities[handle]; ok {
+		if c, ok := w.chunks[pos]; ok {
+			c.modified = true
+		}
+	}
+}
+
 // addEntity adds an EntityHandle to a World. The Entity will be visible to all
 // viewers of the World that have the chunk at the EntityHandle's position. If
 // the chunk that the EntityHandle is in is not yet loaded, it will first be
@@ -671,16 +913,21 @@ func (w *World) addEntity(tx *Tx, handle *EntityHandle) Entity {
 	handle.setAndUnlockWorld(w)
 	pos := chunkPosFromVec3(handle.data.Pos)
 	w.entities[handle] = pos
+	w.entityIndex.insert(handle)
 
 	c := w.chunk(pos)
-	c.Entities, c.modified = append(c.Entities, handle), true
+	c.addEntity(handle)
+	c.modified = true
 
 	e := handle.mustEntity(tx)
 	for _, v := range c.viewers {
 		// Show the entity to all viewers in the chunk of the entity.
-		showEntity(e, v)
+		showEntity(tx, e, v)
 	}
 	w.Handler().HandleEntitySpawn(tx, e)
+	if w.events.active() {
+		w.events.publish(EntitySpawnEvent{Entity: handle, Pos: e.Position()})
+	}
 	return e
 }
 
@@ -696,14 +943,23 @@ func (w *World) removeEntity(e Entity, tx *Tx) *EntityHandle {
 		return nil
 	}
 	w.Handler().HandleEntityDespawn(tx, e)
+	if w.events.active() {
+		w.events.publish(EntityDespawnEvent{Entity: handle, Pos: e.Position()})
+	}
+
+	// Links are removed before the entity is hidden, so that viewers never
+	// see links to entities they no longer know about.
+	w.unlink(tx, handle)
 
 	c := w.chunk(pos)
-	c.Entities, c.modified = sliceutil.DeleteVal(c.Entities, handle), true
+	c.removeEntity(handle)
+	c.modified = true
 
 	for _, v := range c.viewers {
 		v.HideEntity(e)
 	}
 	delete(w.entities, handle)
+	w.entityIndex.remove(handle)
 	handle.unsetAndLockWorld()
 	return handle
 }
@@ -734,6 +990,37 @@ func (w *World) entitiesWithin(tx *Tx, box cube.BBox) iter.Seq[Entity] {
 	}
 }
 
+// blockEntitiesWithin returns an iterator that yields all blocks with block
+// entity data between two corners in loaded chunks.
+func (w *World) blockEntitiesWithin(a, b cube.Pos) iter.Seq2[cube.Pos, Block] {
+	low := cube.Pos{min(a[0], b[0]), min(a[1], b[1]), min(a[2], b[2])}
+	high := cube.Pos{max(a[0], b[0]), max(a[1], b[1]), max(a[2], b[2])}
+	return func(yield func(cube.Pos, Block) bool) {
+		for x := low[0] >> 4; x <= high[0]>>4; x++ {
+			for z := low[2] >> 4; z <= high[2]>>4; z++ {
+				c, ok := w.chunks[ChunkPos{int32(x), int32(z)}]
+				if !ok {
+					continue
+				}
+				for pos, be := range c.BlockEntities {
+					if pos[0] < low[0] || pos[1] < low[1] || pos[2] < low[2] || pos[0] > high[0] || pos[1] > high[1] || pos[2] > high[2] {
+						continue
+					}
+					if !yield(pos, be) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// nearestEntity returns the entity closest to the position passed within
+// maxDist for which filter returns true.
+func (w *World) nearestEntity(tx *Tx, pos mgl64.Vec3, maxDist float64, filter func(Entity) bool) (Entity, bool) {
+	return w.entityIndex.nearest(tx, pos, maxDist, filter)
+}
+
 // allEntities returns an iterator that yields all entities in the World.
 func (w *World) allEntities(tx *Tx) iter.Seq[Entity] {
 	return func(yield func(Entity) bool) {
@@ -785,30 +1072,149 @@ func (w *World) SetSpawn(pos cube.Pos) {
 	}
 }
 
+// Border returns the current Border of the World. The Border returned does
+// not change if the Border of the World is changed later, but its size does
+// reflect the progress of resizing at the time Border was called.
+func (w *World) Border() Border {
+	if w == nil {
+		return Border{}
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	b := w.set.Border
+	b.tick = w.set.CurrentTick
+	return b
+}
+
+// SetBorder sets the Border of the World to a square centred around centre
+// with sides of the size passed in blocks. If the Border was resizing, it
+// stops doing so. Passing a size of 0 or lower removes the Border.
+func (w *World) SetBorder(centre mgl64.Vec2, size float64) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	b := &w.set.Border
+	if !b.Enabled() && b.DamageBuffer == 0 && b.Damage == 0 {
+		b.DamageBuffer, b.Damage = 5, 0.2
+	}
+	size = max(size, 0)
+	b.Centre, b.From, b.To = centre, size, size
+	b.ResizeStart, b.ResizeEnd = w.set.CurrentTick, w.set.CurrentTick
+	w.set.Unlock()
+
+	w.populateLoadQueues()
+}
+
+// ResizeBorder gradually changes the size of the Border of the World to the
+// size passed over the duration passed, starting from its current size.
+// ResizeBorder does nothing if the World has no Border.
+func (w *World) ResizeBorder(size float64, d time.Duration) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	current := w.set.Border
+	current.tick = w.set.CurrentTick
+	if !current.Enabled() {
+		w.set.Unlock()
+		return
+	}
+	b := &w.set.Border
+	b.From, b.To = current.Size(), max(size, 1)
+	b.ResizeStart, b.ResizeEnd = w.set.CurrentTick, w.set.CurrentTick+max(int64(d/(time.Second/20)), 0)
+	w.set.Unlock()
+
+	w.populateLoadQueues()
+}
+
+// populateLoadQueues populates the load queues of all Loaders in the World
+// again after its Border changed, so that chunks that the Border now
+// contains are loaded.
+func (w *World) populateLoadQueues() {
+	_, loaders := w.allViewers()
+	for _, l := range loaders {
+		l.mu.Lock()
+		if !l.closed && l.w == w {
+			l.populateLoadQueue()
+		}
+		l.mu.Unlock()
+	}
+}
+
+// SetBorderDamage changes the damage dealt by the Border of the World. Players
+// that are more than buffer blocks outside the Border are dealt damage every
+// second for every block past the buffer.
+func (w *World) SetBorderDamage(buffer, damage float64) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.Border.DamageBuffer, w.set.Border.Damage = buffer, damage
+}
+
 // PlayerSpawn returns the spawn position of a player with a UUID in this World.
+// If the player has no spawn position set, or if it is in a different
+// Dimension than this World, the spawn of the World is returned.
 func (w *World) PlayerSpawn(id uuid.UUID) cube.Pos {
 	if w == nil {
 		return cube.Pos{}
 	}
-	pos, exist, err := w.conf.Provider.LoadPlayerSpawnPosition(id)
+	pos, dim, exist, err := w.loadPlayerSpawn(id)
 	if err != nil {
 		w.conf.Log.Error("load player spawn: "+err.Error(), "ID", id)
 		return w.Spawn()
 	}
-	if !exist {
+	if !exist || dim != w.conf.Dim {
 		return w.Spawn()
 	}
 	return pos
 }
 
+// PlayerSpawnDimension returns the Dimension that the spawn position of a
+// player with a UUID is in, such as Nether if the player set its spawn at a
+// respawn anchor. If the player has no spawn position set, the Dimension of
+// this World is returned.
+func (w *World) PlayerSpawnDimension(id uuid.UUID) Dimension {
+	if w == nil {
+		return Overworld
+	}
+	_, dim, exist, err := w.loadPlayerSpawn(id)
+	if err != nil || !exist {
+		return w.conf.Dim
+	}
+	return dim
+}
+
+// loadPlayerSpawn loads the spawn position of a player with a UUID and the
+// Dimension it is in. If the Provider of the World is not a
+// SpawnDimensionProvider, the spawn position is always in the Overworld.
+func (w *World) loadPlayerSpawn(id uuid.UUID) (cube.Pos, Dimension, bool, error) {
+	if p, ok := w.conf.Provider.(SpawnDimensionProvider); ok {
+		return p.LoadPlayerSpawn(id)
+	}
+	pos, exist, err := w.conf.Provider.LoadPlayerSpawnPosition(id)
+	return pos, Overworld, exist, err
+}
+
 // SetPlayerSpawn sets the spawn position of a player with a UUID in this
 // World. If the player has a spawn in the world, the player will be teleported
-// to this location on respawn.
+// to this location on respawn. A player has only one spawn position, so
+// setting it in this World replaces any spawn position that the player had
+// in another Dimension. Spawn positions outside the Overworld are only saved
+// if the Provider of the World is a SpawnDimensionProvider.
 func (w *World) SetPlayerSpawn(id uuid.UUID, pos cube.Pos) {
 	if w == nil {
 		return
 	}
-	if err := w.conf.Provider.SavePlayerSpawnPosition(id, pos); err != nil {
+	var err error
+	if p, ok := w.conf.Provider.(SpawnDimensionProvider); ok {
+		err = p.SavePlayerSpawn(id, pos, w.conf.Dim)
+	} else if w.conf.Dim == Overworld {
+		err = w.conf.Provider.SavePlayerSpawnPosition(id, pos)
+	}
+	if err != nil {
 		w.conf.Log.Error("save player spawn: "+err.Error(), "ID", id)
 	}
 }
@@ -875,6 +1281,105 @@ func (w *World) SetDifficulty(d Difficulty) {
 	w.set.Difficulty = d
 }
 
+// Insomnia checks if phantoms spawn around players in the world that have not
+// slept for a long time.
+func (w *World) Insomnia() bool {
+	if w == nil {
+		return false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.Insomnia
+}
+
+// SetInsomnia enables or disables the spawning of phantoms around players that
+// have not slept for a long time. Players keep track of the time since they
+// last slept regardless of this setting.
+func (w *World) SetInsomnia(v bool) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.Insomnia = v
+}
+
+// MobGriefing checks if mobs, such as the wither, are able to destroy blocks in
+// the world.
+func (w *World) MobGriefing() bool {
+	if w == nil {
+		return false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.MobGriefing
+}
+
+// SetMobGriefing enables or disables the destruction of blocks by mobs, such as
+// the wither.
+func (w *World) SetMobGriefing(v bool) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.MobGriefing = v
+}
+
+// NaturalRegeneration checks if players in the world regenerate health when
+// their food bar is full enough.
+func (w *World) NaturalRegeneration() bool {
+	if w == nil {
+		return false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.NaturalRegeneration
+}
+
+// SetNaturalRegeneration enables or disables the regeneration of health of
+// players with a full enough food bar. Healing from other sources, such as
+// the Regeneration effect or golden apples, is not affected.
+func (w *World) SetNaturalRegeneration(v bool) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.NaturalRegeneration = v
+}
+
+// defaultDamageImmunity is the duration that entities are immune to further
+// damage after being hurt if no other duration was set.
+const defaultDamageImmunity = time.Second / 2
+
+// DamageImmunity returns the duration that entities in the world are immune
+// to further damage after being hurt. By default, this is 0.5 seconds.
+func (w *World) DamageImmunity() time.Duration {
+	if w == nil {
+		return defaultDamageImmunity
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	if w.set.DamageImmunity <= 0 {
+		return defaultDamageImmunity
+	}
+	return w.set.DamageImmunity
+}
+
+// SetDamageImmunity changes the duration that entities in the world are
+// immune to further damage after being hurt. The duration is never shorter
+// than one tick, so that entities can never be hurt multiple times by a
+// single attack, for example a single swing of a sword.
+func (w *World) SetDamageImmunity(d time.Duration) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.DamageImmunity = max(d, time.Second/20)
+}
+
 // scheduleBlockUpdate schedules a block update at the position passed for the
 // block type passed after a specific delay. If the block at that position does
 // not handle block updates, nothing will happen.
@@ -902,10 +1407,27 @@ func (w *World) doBlockUpdatesAround(pos cube.Pos) {
 	}, w.Range())
 }
 
+// notifyObservers queues an update for all BlockChangeObservers directly
+// around the position passed, which had its state changed. Only loaded chunks
+// are checked.
+func (w *World) notifyObservers(pos cube.Pos) {
+	pos.Neighbours(func(neighbour cube.Pos) {
+		c, ok := w.chunks[chunkPosFromBlockPos(neighbour)]
+		if !ok {
+			return
+		}
+		if observerBlocks[c.Block(uint8(neighbour[0]), int16(neighbour[1]), uint8(neighbour[2]), 0)] {
+			w.neighbourUpdates = append(w.neighbourUpdates, neighbourUpdate{pos: neighbour, neighbour: pos, observed: true})
+		}
+	}, w.Range())
+}
+
 // neighbourUpdate represents a position that needs to be updated because of a
-// neighbour that changed.
+// neighbour that changed. If observed is true, the update is only passed to a
+// BlockChangeObserver at the position.
 type neighbourUpdate struct {
 	pos, neighbour cube.Pos
+	observed       bool
 }
 
 // updateNeighbour ticks the position passed as a result of the neighbour
@@ -951,9 +1473,20 @@ func (w *World) PortalDestination(dim Dimension) *World {
 	return w
 }
 
-// Save saves the World to the provider.
+// Save saves the World to the provider. The chunks modified since they were
+// last saved are copied within a transaction, after which they are encoded
+// and stored on the IO workers of the World, so that saving holds up the
+// World as little as possible. Save returns once all chunks were stored.
 func (w *World) Save() {
-	<-w.Exec(w.save(w.saveChunk))
+	var stores []<-chan struct{}
+	<-w.Exec(w.save(func(_ *Tx, pos ChunkPos, c *Column) {
+		if done := w.saveChunk(pos, c); done != nil {
+			stores = append(stores, done)
+		}
+	}))
+	for _, done := range stores {
+		<-done
+	}
 }
 
 // save saves all loaded chunks to the World's provider.
@@ -971,13 +1504,91 @@ func (w *World) save(f func(*Tx, ChunkPos, *Column)) ExecFunc {
 	}
 }
 
-// saveChunk saves a chunk and its entities to disk after compacting the chunk.
-func (w *World) saveChunk(_ *Tx, pos ChunkPos, c *Column) {
-	if !w.conf.ReadOnly && c.modified {
-		c.Compact()
-		if err := w.conf.Provider.StoreColumn(pos, w.conf.Dim, w.columnTo(c, pos)); err != nil {
-			w.conf.Log.Error("save chunk: "+err.Error(), "X", pos[0], "Z", pos[1])
+// saveChunk saves a chunk and its entities to disk if it was modified since it
+// was last saved. Entities mark the chunk they are in as modified when they
+// move during a tick or are used in a transaction through
+// EntityHandle.ExecWorld. A snapshot of the chunk is compacted and stored on
+// the IO workers of the World, so that the chunk may be changed while it is
+// stored. The channel returned is closed once the chunk is stored, or nil if
+// the chunk is not saved.
+func (w *World) saveChunk(pos ChunkPos, c *Column) <-chan struct{} {
+	if w.conf.ReadOnly || !c.modified {
+		return nil
+	}
+	col := w.columnTo(c, pos)
+	col.Chunk = c.Chunk.Snapshot()
+	c.modified = false
+	return w.storeChunk(pos, col, true, false)
+}
+
+// storeColumn stores a column in the provider of the World, logging any error
+// that occurs.
+func (w *World) storeColumn(pos ChunkPos, col *chunk.Column) {
+	if err := w.conf.Provider.StoreColumn(pos, w.conf.Dim, col); err != nil {
+		w.conf.Log.Error("save chunk: "+err.Error(), "X", pos[0], "Z", pos[1])
+	}
+}
+
+// storeClosedChunk stores a chunk that is being closed on the IO workers of
+// the World without waiting for it to be done, and adds it to the cache of
+// recently closed chunks.
+func (w *World) storeClosedChunk(pos ChunkPos, c *Column) {
+	store := !w.conf.ReadOnly && c.modified
+	if !store && w.cache == nil {
+		return
+	}
+	w.storeChunk(pos, w.columnTo(c, pos), store, true)
+}
+
+// storeChunk compacts the chunk.Column passed, stores it in the provider of
+// the World if store is true and adds it to the cache of recently closed
+// chunks if cache is true. The chunk.Column must no longer be modified by the
+// caller. This is done on the IO workers of the World, and the channel
+// returned is closed once it is done. Until then, the chunk is tracked so
+// that loading it again waits for the store to finish. Stores of the same
+// chunk are always done in the order in which storeChunk was called.
+func (w *World) storeChunk(pos ChunkPos, col *chunk.Column, store, cache bool) <-chan struct{} {
+	done := make(chan struct{})
+
+	w.storingMu.Lock()
+	prev := w.storing[pos]
+	w.storing[pos] = done
+	w.storingMu.Unlock()
+
+	w.io.submit(func() {
+		defer func() {
+			w.storingMu.Lock()
+			if w.storing[pos] == done {
+				delete(w.storing, pos)
+			}
+			w.storingMu.Unlock()
+			close(done)
+		}()
+		if prev != nil {
+			<-prev
+		}
+		col.Chunk.Compact()
+		if store {
+			w.storeColumn(pos, col)
+		}
+		if !cache {
+			return
 		}
+		if err := w.cache.put(pos, col); err != nil {
+			w.conf.Log.Error("cache chunk: "+err.Error(), "X", pos[0], "Z", pos[1])
+		}
+	})
+	return done
+}
+
+// awaitStore waits until the chunk at the position passed, if it is being
+// stored after being closed, is done being stored.
+func (w *World) awaitStore(pos ChunkPos) {
+	w.storingMu.Lock()
+	done, ok := w.storing[pos]
+	w.storingMu.Unlock()
+	if ok {
+		<-done
 	}
 }
 
@@ -985,8 +1596,13 @@ func (w *World) saveChunk(_ *Tx, pos ChunkPos, c *Column) {
 // Afterwards, scheduled updates from that chunk are removed and all entities
 // in it are closed.
 func (w *World) closeChunk(tx *Tx, pos ChunkPos, c *Column) {
-	w.saveChunk(tx, pos, c)
+	w.storeClosedChunk(pos, c)
 	w.scheduledUpdates.removeChunk(pos)
+	for _, e := range c.Entities {
+		// Entities in other chunks may be linked with the entities closed
+		// here. These links are removed before any entity is closed.
+		w.unlink(tx, e)
+	}
 	// Note: We close c.Entities here because some entities may remove
 	// themselves from the world in their Close method, which can lead to
 	// unexpected conditions.
@@ -994,7 +1610,11 @@ func (w *World) closeChunk(tx *Tx, pos ChunkPos, c *Column) {
 		_ = e.mustEntity(tx).Close()
 	}
 	clear(c.Entities)
+	c.gameEventEntities = nil
 	delete(w.chunks, pos)
+	if w.events.active() {
+		w.events.publish(ChunkUnloadEvent{Pos: pos})
+	}
 }
 
 // Close closes the world and saves all chunks currently loaded.
@@ -1012,13 +1632,21 @@ func (w *World) close() {
 		w.Handle(NopHandler{})
 
 		w.save(w.closeChunk)(tx)
+		w.closed = true
 	})
+	// Drain the worker pools before the transaction queue is closed, as
+	// finished chunk requests still add a transaction to the queue. The IO
+	// workers are drained first, because they may still submit jobs to the
+	// generation workers.
+	w.io.close()
+	w.gen.close()
 
 	close(w.closing)
 	w.running.Wait()
 
 	close(w.queueClosing)
 	w.queueing.Wait()
+	w.events.close()
 
 	if w.set.ref.Add(-1); !w.advance {
 		return
@@ -1043,6 +1671,23 @@ func (w *World) allViewers() ([]Viewer, []*Loader) {
 	return viewers, loaders
 }
 
+// updateLoaderCentres passes the positions of the chunks that the loaders
+// passed are centred around to the workers of the World, so that the chunks
+// closest to loaders are loaded first.
+func (w *World) updateLoaderCentres(loaders []*Loader) {
+	centres := make([]ChunkPos, 0, len(loaders))
+	for _, l := range loaders {
+		centres = append(centres, l.centre())
+	}
+	// The centres are sorted so that the workers only re-prioritise their
+	// jobs if a loader actually moved.
+	slices.SortFunc(centres, func(a, b ChunkPos) int {
+		return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+	})
+	w.io.setCentres(centres)
+	w.gen.setCentres(slices.Clone(centres))
+}
+
 // addWorldViewer adds a viewer to the world. Should only be used while the
 // viewer isn't viewing any chunks.
 func (w *World) addWorldViewer(l *Loader) {
@@ -1066,7 +1711,7 @@ func (w *World) addViewer(tx *Tx, c *Column, loader *Loader) {
 	c.loaders = append(c.loaders, loader)
 
 	for _, entity := range c.Entities {
-		showEntity(entity.mustEntity(tx), loader.viewer)
+		showEntity(tx, entity.mustEntity(tx), loader.viewer)
 	}
 }
 
@@ -1084,6 +1729,9 @@ func (w *World) removeViewer(tx *Tx, pos ChunkPos, loader *Loader) {
 	if i := slices.Index(c.loaders, loader); i != -1 {
 		c.viewers = slices.Delete(c.viewers, i, i+1)
 		c.loaders = slices.Delete(c.loaders, i, i+1)
+		if len(c.viewers) == 0 {
+			c.unused = time.Now()
+		}
 	}
 
 	// Hide all entities in the chunk from the viewer.
@@ -1101,91 +1749,355 @@ func (w *World) Handler() Handler {
 }
 
 // showEntity shows an Entity to a viewer of the world. It makes sure
-// everything of the Entity, including the items held, is shown.
-func showEntity(e Entity, viewer Viewer) {
+// everything of the Entity, including the items held and the entities it is
+// linked with, is shown.
+func showEntity(tx *Tx, e Entity, viewer Viewer) {
 	viewer.ViewEntity(e)
 	viewer.ViewEntityItems(e)
 	viewer.ViewEntityArmour(e)
+	showLinks(tx, e, viewer)
 }
 
 // chunk reads a chunk from the position passed. If a chunk at that position is
 // not yet loaded, the chunk is loaded from the provider, or generated if it
 // did not yet exist. Additionally, chunks newly loaded have the light in them
-// calculated before they are returned.
+// calculated before they are returned. The chunk is loaded on the workers of
+// the World, but chunk blocks until it is done.
 func (w *World) chunk(pos ChunkPos) *Column {
-	c, ok := w.chunks[pos]
-	if ok {
+	if c, ok := w.chunks[pos]; ok {
 		return c
 	}
-	c, err := w.loadChunk(pos)
-	chunk.LightArea([]*chunk.Chunk{c.Chunk}, int(pos[0]), int(pos[1])).Fill()
-	if err != nil {
-		w.conf.Log.Error("load chunk: "+err.Error(), "X", pos[0], "Z", pos[1])
-		return c
+	req := w.requestChunk(pos)
+	req.urgent.Store(true)
+	w.io.expedite(req)
+	w.gen.expedite(req)
+	<-req.done
+	return w.installChunk(pos, req)
+}
+
+// chunkRequest is a request to load or generate a chunk on the workers of a
+// World. Once done is closed, either col or c is set if the chunk was loaded
+// or generated respectively, or err is set if loading it failed.
+type chunkRequest struct {
+	done chan struct{}
+
+	col        *chunk.Column
+	c          *chunk.Chunk
+	generating bool
+	err        error
+	// urgent is set to true if a transaction is waiting for the chunk, so
+	// that the workers load it before any other chunks.
+	urgent atomic.Bool
+}
+
+// requestChunk requests a chunk to be loaded on the IO workers of the World,
+// or generated on its generation workers if the provider does not have it.
+// Once the chunk is ready, it is installed in the World in a transaction. If
+// the chunk was already requested, the existing request is returned.
+func (w *World) requestChunk(pos ChunkPos) *chunkRequest {
+	if req, ok := w.loading[pos]; ok {
+		return req
+	}
+	req := &chunkRequest{done: make(chan struct{})}
+	w.loading[pos] = req
+	w.io.submitChunk(pos, req, func() {
+		defer func() {
+			if !req.generating {
+				w.finishRequest(pos, req)
+			}
+		}()
+		w.awaitStore(pos)
+		if column, ok, err := w.cache.take(pos); ok {
+			req.col = column
+			return
+		} else if err != nil {
+			w.conf.Log.Error("load cached chunk: "+err.Error(), "X", pos[0], "Z", pos[1])
+		}
+		column, err := w.conf.Provider.LoadColumn(pos, w.conf.Dim)
+		switch {
+		case err == nil:
+			req.col = column
+		case errors.Is(err, leveldb.ErrNotFound):
+			// The provider doesn't have a chunk saved at this position, so we
+			// generate a new one.
+			req.generating = true
+			w.gen.submitChunk(pos, req, func() {
+				defer w.finishRequest(pos, req)
+				c := chunk.New(airRID, w.Range())
+				w.conf.Generator.GenerateChunk(pos, c)
+				req.c = c
+			})
+		default:
+			req.err = err
+		}
+	})
+	return req
+}
+
+// finishRequest fills the light of a chunk that was loaded or generated for
+// the request passed and installs it in the World. finishRequest is called on
+// a worker, even if loading the chunk panicked.
+func (w *World) finishRequest(pos ChunkPos, req *chunkRequest) {
+	defer func() {
+		close(req.done)
+		w.finishedMu.Lock()
+		w.finished = append(w.finished, finishedChunk{pos: pos, req: req})
+		first := len(w.finished) == 1
+		w.finishedMu.Unlock()
+		if first {
+			// The chunk is installed as soon as possible, but without blocking
+			// the worker if the transaction queue is full: The chunks finished
+			// are otherwise installed during the next tick.
+			select {
+			case w.queue <- normalTransaction{c: make(chan struct{}), f: w.installFinished}:
+			default:
+			}
+		}
+	}()
+	c := req.c
+	if req.col != nil {
+		c = req.col.Chunk
+	}
+	if c == nil {
+		if req.err == nil {
+			req.err = fmt.Errorf("worker panicked")
+		}
+		return
 	}
-	w.calculateLight(pos)
-	return c
+	chunk.LightArea([]*chunk.Chunk{c}, int(pos[0]), int(pos[1])).Fill()
+}
+
+// finishedChunk is a chunk request that was finished by the workers of a
+// World and still has to be installed.
+type finishedChunk struct {
+	pos ChunkPos
+	req *chunkRequest
+}
+
+// installFinished installs all chunks that were finished by the workers of
+// the World since the last call to installFinished.
+func (w *World) installFinished(*Tx) {
+	w.finishedMu.Lock()
+	finished := w.finished
+	w.finished = nil
+	w.finishedMu.Unlock()
+
+	if w.closed {
+		return
+	}
+	installed := make([]ChunkPos, 0, len(finished))
+	for _, f := range finished {
+		if _, added := w.addChunk(f.pos, f.req); added {
+			installed = append(installed, f.pos)
+		}
+	}
+	// Light is spread for all chunks installed at once, so that it may be
+	// spread on multiple goroutines.
+	w.calculateLight(installed...)
+	for _, pos := range installed {
+		w.decorateAround(pos)
+	}
+}
+
+// installChunk adds the chunk loaded for the request passed to the World and
+// spreads light into its neighbours. If the request was already installed,
+// the chunk already in the World is returned. If loading the chunk failed, an
+// empty chunk is returned without adding it to the World.
+func (w *World) installChunk(pos ChunkPos, req *chunkRequest) *Column {
+	col, added := w.addChunk(pos, req)
+	if added {
+		w.calculateLight(pos)
+		w.decorateAround(pos)
+	}
+	return col
 }
 
-// loadChunk attempts to load a chunk from the provider, or generates a chunk
-// if one doesn't currently exist.
-func (w *World) loadChunk(pos ChunkPos) (*Column, error) {
-	column, err := w.conf.Provider.LoadColumn(pos, w.conf.Dim)
+// addChunk adds the chunk loaded for the request passed to the World without
+// spreading light into it. It returns the chunk and true if the chunk was
+// added. If the request was already installed, the chunk already in the World
+// is returned. If loading the chunk failed, an empty chunk is returned without
+// adding it to the World.
+func (w *World) addChunk(pos ChunkPos, req *chunkRequest) (*Column, bool) {
+	if w.loading[pos] != req {
+		return w.chunks[pos], false
+	}
+	delete(w.loading, pos)
+
+	var col *Column
 	switch {
-	case err == nil:
-		col := w.columnFrom(column, pos)
-		w.chunks[pos] = col
+	case req.col != nil:
+		col = w.columnFrom(req.col, pos)
 		for _, e := range col.Entities {
 			w.entities[e] = pos
+			w.entityIndex.insert(e)
 			e.w = w
 		}
-		return col, nil
-	case errors.Is(err, leveldb.ErrNotFound):
-		// The provider doesn't have a chunk saved at this position, so we generate a new one.
-		col := newColumn(chunk.New(airRID, w.Range()))
-		w.chunks[pos] = col
-
-		w.conf.Generator.GenerateChunk(pos, col.Chunk)
-		return col, nil
+	case req.c != nil:
+		col = newColumn(req.c)
+		col.undecorated = w.decorates()
 	default:
-		return newColumn(chunk.New(airRID, w.Range())), err
+		// The empty chunk returned is not added to the World, so that the
+		// chunk is loaded again when it is next used, but it is kept so that
+		// loaders can show it instead of requesting it over and over.
+		w.conf.Log.Error("load chunk: "+req.err.Error(), "X", pos[0], "Z", pos[1])
+		c := chunk.New(airRID, w.Range())
+		chunk.LightArea([]*chunk.Chunk{c}, int(pos[0]), int(pos[1])).Fill()
+		w.failed[pos] = newColumn(c)
+		return w.failed[pos], false
+	}
+	delete(w.failed, pos)
+	col.unused = time.Now()
+	w.chunks[pos] = col
+	if w.events.active() {
+		w.events.publish(ChunkLoadEvent{Pos: pos})
+	}
+	return col, true
+}
+
+// decorateAround decorates the chunk at the position passed and its
+// neighbours if they are waiting to be decorated and all of their neighbours
+// are now loaded, which may be the case as a result of the chunk passed being
+// loaded. Chunks changed by decoration have their light recalculated and are
+// sent to their viewers again.
+func (w *World) decorateAround(centre ChunkPos) {
+	if !w.decorates() {
+		return
 	}
-}
-
-// calculateLight calculates the light in the chunk passed and spreads the
-// light of any surrounding neighbours if they have all chunks loaded around it
-// as a result of the one passed.
-func (w *World) calculateLight(centre ChunkPos) {
 	for x := int32(-1); x <= 1; x++ {
 		for z := int32(-1); z <= 1; z++ {
-			// For all the neighbours of this chunk, if they exist, check if all
-			// neighbours of that chunk now exist because of this one.
 			pos := ChunkPos{centre[0] + x, centre[1] + z}
-			if _, ok := w.chunks[pos]; ok {
-				// Attempt to spread the light of all neighbours into the
-				// surrounding ones.
-				w.spreadLight(pos)
+			if col, ok := w.chunks[pos]; !ok || !col.undecorated {
+				continue
+			}
+			area := &DecorationArea{centre: pos}
+			complete := true
+			for i := range area.cols {
+				var ok bool
+				if area.cols[i], ok = w.chunks[area.chunkPos(i)]; !ok {
+					complete = false
+					break
+				}
+			}
+			if !complete {
+				continue
+			}
+			area.cols[4].undecorated, area.cols[4].modified = false, true
+			w.placeStructures(pos, area)
+			if d, ok := w.conf.Generator.(Decorator); ok {
+				d.DecorateChunk(pos, area)
 			}
+			w.finishDecoration(area)
 		}
 	}
 }
 
-// spreadLight spreads the light from the chunk passed at the position passed
-// to all neighbours if each of them is loaded.
-func (w *World) spreadLight(pos ChunkPos) {
+// decorates checks if the Generator of the World has a second stage of
+// generation, meaning it is either a Decorator or a StructureGenerator.
+func (w *World) decorates() bool {
+	switch w.conf.Generator.(type) {
+	case Decorator, StructureGenerator:
+		return true
+	}
+	return false
+}
+
+// placeStructures places the parts of all structures of the
+// StructureGenerator of the World that overlap the chunk at the position
+// passed, if the Generator is a StructureGenerator. Structures are started
+// in chunks within the radius of their StructurePlacer around pos.
+func (w *World) placeStructures(pos ChunkPos, area *DecorationArea) {
+	g, ok := w.conf.Generator.(StructureGenerator)
+	if !ok {
+		return
+	}
+	ctx := &GenerationContext{pos: pos, seed: w.Seed(), area: area}
+	minX, minZ := int(pos[0])<<4, int(pos[1])<<4
+	for _, p := range g.StructurePlacers() {
+		r := int32(p.Radius())
+		for x := pos[0] - r; x <= pos[0]+r; x++ {
+			for z := pos[1] - r; z <= pos[1]+r; z++ {
+				for _, s := range p.Start(ChunkPos{x, z}, ctx.seed) {
+					sMin, sMax := s.Bounds()
+					if sMax[0] < minX || sMin[0] > minX+15 || sMax[2] < minZ || sMin[2] > minZ+15 {
+						continue
+					}
+					s.Place(ctx)
+				}
+			}
+		}
+	}
+}
+
+// finishDecoration recalculates the light of all chunks in the DecorationArea
+// passed that were changed by a Decorator and sends them to their viewers
+// again.
+func (w *World) finishDecoration(area *DecorationArea) {
+	for i, col := range area.cols {
+		if area.modified[i] {
+			col.changed()
+			pos := area.chunkPos(i)
+			chunk.LightArea([]*chunk.Chunk{col.Chunk}, int(pos[0]), int(pos[1])).Fill()
+		}
+	}
+	modified := make([]ChunkPos, 0, len(area.cols))
+	for i := range area.cols {
+		if area.modified[i] {
+			modified = append(modified, area.chunkPos(i))
+		}
+	}
+	w.calculateLight(modified...)
+	for _, pos := range modified {
+		col := w.chunks[pos]
+		for _, v := range col.viewers {
+			v.ViewChunk(pos, w.Dimension(), col)
+		}
+	}
+}
+
+// calculateLight spreads the light of the chunks passed and of any of their
+// neighbours that have all chunks around them loaded as a result of the ones
+// passed. If light needs to be spread in multiple areas, it is spread on
+// multiple goroutines.
+func (w *World) calculateLight(centres ...ChunkPos) {
+	var (
+		areas   []*chunk.Area
+		handled = make(map[ChunkPos]struct{}, len(centres)*9)
+	)
+	for _, centre := range centres {
+		for x := int32(-1); x <= 1; x++ {
+			for z := int32(-1); z <= 1; z++ {
+				// For all the neighbours of this chunk, if they exist, check if
+				// all neighbours of that chunk now exist because of this one.
+				pos := ChunkPos{centre[0] + x, centre[1] + z}
+				if _, ok := handled[pos]; ok {
+					continue
+				}
+				handled[pos] = struct{}{}
+				if area, ok := w.lightArea(pos); ok {
+					areas = append(areas, area)
+				}
+			}
+		}
+	}
+	chunk.SpreadAll(areas, w.conf.GenWorkers)
+}
+
+// lightArea returns the area of chunks around the chunk at the position passed
+// that light is spread in from that chunk. false is returned if not all of the
+// chunks in the area are loaded.
+func (w *World) lightArea(pos ChunkPos) (*chunk.Area, bool) {
 	c := make([]*chunk.Chunk, 0, 9)
 	for z := int32(-1); z <= 1; z++ {
 		for x := int32(-1); x <= 1; x++ {
 			neighbour, ok := w.chunks[ChunkPos{pos[0] + x, pos[1] + z}]
 			if !ok {
-				// Not all surrounding chunks existed: Stop spreading light.
-				return
+				// Not all surrounding chunks existed: Light cannot be spread.
+				return nil, false
 			}
 			c = append(c, neighbour.Chunk)
 		}
 	}
-	// All chunks surrounding the current one are present, so we can spread.
-	chunk.LightArea(c, int(pos[0])-1, int(pos[1])-1).Spread()
+	return chunk.LightArea(c, int(pos[0])-1, int(pos[1])-1), true
 }
 
 // autoSave runs until the world is running, saving and removing chunks that
@@ -1196,13 +2108,16 @@ func (w *World) autoSave() {
 		save = time.NewTicker(w.conf.SaveInterval)
 		defer save.Stop()
 	}
-	closeUnused := time.NewTicker(time.Minute * 2)
+	closeUnused, clearFailed := time.NewTicker(time.Second), time.NewTicker(time.Minute*2)
 	defer closeUnused.Stop()
+	defer clearFailed.Stop()
 
 	for {
 		select {
 		case <-closeUnused.C:
 			<-w.Exec(w.closeUnusedChunks)
+		case <-clearFailed.C:
+			<-w.Exec(func(*Tx) { clear(w.failed) })
 		case <-save.C:
 			w.Save()
 		case <-w.closing:
@@ -1212,12 +2127,13 @@ func (w *World) autoSave() {
 	}
 }
 
-// closeUnusedChunk is called every 5 minutes by autoSave.
+// closeUnusedChunk is called every second by autoSave. Chunks that have not
+// been viewed for Config.UnloadDelay and hold no forced or spawn ticket are
+// closed.
 func (w *World) closeUnusedChunks(tx *Tx) {
+	spawn := w.Spawn()
 	for pos, c := range w.chunks {
-		if len(c.viewers) == 0 {
-			w.closeChunk(tx, pos, c)
-		}
+		w.closeIfUnused(tx, pos, c, spawn)
 	}
 }
 
@@ -1225,6 +2141,12 @@ func (w *World) closeUnusedChunks(tx *Tx) {
 // viewers and loaders.
 type Column struct {
 	modified bool
+	// revision is a number unique among all Columns, which is changed every
+	// time a block, biome or block entity in the Column changes.
+	revision uint64
+	// undecorated is true if the Column was generated, but not yet decorated
+	// by the Decorator of the World.
+	undecorated bool
 
 	*chunk.Chunk
 	Entities      []*EntityHandle
@@ -1232,11 +2154,54 @@ type Column struct {
 
 	viewers []Viewer
 	loaders []*Loader
+
+	// unused is the time at which the Column was last left without viewers
+	// or tickets. The Column is closed once it was unused for longer than
+	// Config.UnloadDelay.
+	unused time.Time
+	// updates holds the positions of the blocks changed in the Column during
+	// the current transaction, with a bit set for every layer changed.
+	updates map[cube.Pos]uint8
+	// forced is the tick at which the forced ticket of the Column, added using
+	// World.ForceLoad, expires. It is 0 if the Column has no forced ticket.
+	forced int64
+	// level is the chunkLevel of the Column, derived from its tickets and those
+	// of the Columns around it during the last tick.
+	level chunkLevel
+	// gameEventBlocks and gameEventEntities hold the positions of the
+	// GameEventListener blocks and the entities listening to game events in
+	// the Column. Only these are considered when a game event is emitted.
+	gameEventBlocks   map[cube.Pos]struct{}
+	gameEventEntities []*EntityHandle
+	// neighbourUpdates holds the neighbour updates in the Column that were
+	// postponed because the Column was not at least chunkLevelBorder. They are
+	// performed once the Column reaches that level.
+	neighbourUpdates []neighbourUpdate
 }
 
+// columnRevisions is the last revision given to a Column. It is shared by all
+// worlds, so that revisions are never shared between Columns.
+var columnRevisions atomic.Uint64
+
 // newColumn returns a new Column wrapper around the chunk.Chunk passed.
 func newColumn(c *chunk.Chunk) *Column {
-	return &Column{Chunk: c, BlockEntities: map[cube.Pos]Block{}}
+	return &Column{Chunk: c, BlockEntities: map[cube.Pos]Block{}, revision: columnRevisions.Add(1)}
+}
+
+// Revision returns a number that identifies the blocks, biomes and block
+// entities of the Column. The revision of a Column changes every time any of
+// them changes and is never shared with another Column, also not with a
+// Column of a different World or one loaded again at the same position.
+// Viewers may use it to reuse data encoded from the Column for as long as
+// the Column does not change.
+func (col *Column) Revision() uint64 {
+	return col.revision
+}
+
+// changed marks the Column as modified after a block, biome or block entity
+// in it changed and gives it a new revision.
+func (col *Column) changed() {
+	col.modified, col.revision = true, columnRevisions.Add(1)
 }
 
 // columnTo converts a Column to a chunk.Column so that it can be written to
@@ -1249,12 +2214,10 @@ func (w *World) columnTo(col *Column, pos ChunkPos) *chunk.Column {
 		BlockEntities:   make([]chunk.BlockEntity, 0, len(col.BlockEntities)),
 		ScheduledBlocks: make([]chunk.ScheduledBlockUpdate, 0, len(scheduled)),
 		Tick:            w.scheduledUpdates.currentTick,
+		Undecorated:     col.undecorated,
 	}
 	for _, e := range col.Entities {
-		data := e.encodeNBT()
-		maps.Copy(data, e.t.EncodeNBT(&e.data))
-		data["identifier"] = e.t.EncodeEntity()
-		c.Entities = append(c.Entities, chunk.Entity{ID: int64(binary.LittleEndian.Uint64(e.id[8:])), Data: data})
+		c.Entities = append(c.Entities, chunk.Entity{ID: int64(binary.LittleEndian.Uint64(e.id[8:])), Data: e.EncodeNBT()})
 	}
 	for pos, be := range col.BlockEntities {
 		c.BlockEntities = append(c.BlockEntities, chunk.BlockEntity{Pos: pos, Data: be.(NBTer).EncodeNBT()})
@@ -1272,6 +2235,8 @@ func (w *World) columnFrom(c *chunk.Column, _ ChunkPos) *Column {
 		Chunk:         c.Chunk,
 		Entities:      make([]*EntityHandle, 0, len(c.Entities)),
 		BlockEntities: make(map[cube.Pos]Block, len(c.BlockEntities)),
+		undecorated:   c.Undecorated,
+		revision:      columnRevisions.Add(1),
 	}
 	for _, e := range c.Entities {
 		eid, ok := e.Data["identifier"].(string)
@@ -1284,7 +2249,7 @@ func (w *World) columnFrom(c *chunk.Column, _ ChunkPos) *Column {
 			w.conf.Log.Error("read column: unknown entity type", "ID", e.ID, "type", eid)
 			continue
 		}
-		col.Entities = append(col.Entities, entityFromData(t, e.ID, e.Data))
+		col.addEntity(entityFromData(t, e.ID, e.Data))
 	}
 	for _, be := range c.BlockEntities {
 		rid := c.Chunk.Block(uint8(be.Pos[0]), int16(be.Pos[1]), uint8(be.Pos[2]), 0)
@@ -1298,7 +2263,7 @@ func (w *World) columnFrom(c *chunk.Column, _ ChunkPos) *Column {
 			w.conf.Log.Error("read column: block with nbt does not implement NBTer", "block", fmt.Sprintf("%#v", b))
 			continue
 		}
-		col.BlockEntities[be.Pos] = nb.DecodeNBT(be.Data).(Block)
+		col.setBlockEntity(be.Pos, nb.DecodeNBT(be.Data).(Block))
 	}
 	scheduled, savedTick := make([]scheduledTick, 0, len(c.ScheduledBlocks)), c.Tick
 	for _, t := range c.ScheduledBlocks {